@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/konzy/ehrglot/pkg/browse"
+	"github.com/spf13/cobra"
+)
+
+func browseCmd() *cobra.Command {
+	var noColor bool
+
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Browse schemas and mappings interactively",
+		Long: `Opens an interactive terminal browser over the schemas: pick a namespace,
+then a resource, to see its fields with their types, PII levels, and the
+source columns mappings fill them from. Type /<text> to fuzzy search
+resources and fields, m to list a resource's mappings, b to go back, and q to
+quit.
+
+Example:
+  ehrglot browse --schemas schemas`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+
+			color := !noColor && os.Getenv("NO_COLOR") == ""
+			if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+				color = false
+			}
+			return browse.New(schemas, mappings).Run(os.Stdin, os.Stdout, color)
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+
+	return cmd
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/provenance"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/runner"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func checkCmd() *cobra.Command {
+	var (
+		lang        string
+		dir         string
+		changedOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "check",
+		Short:        "Check that generated code is up to date with its schemas",
+		SilenceUsage: true,
+		Long: `Regenerates code into a temporary directory and compares it with the
+generated code in --output, reporting stale, missing, and no longer generated
+files. With --changed-only, only namespaces whose schema files git reports as
+modified, added, deleted, or renamed are regenerated and compared (together
+with the namespaces that reference them), which keeps it fast enough for a
+pre-commit hook.
+
+The language defaults to the one recorded in the output's
+` + provenance.ManifestFile + `.
+
+Example:
+  ehrglot check --changed-only --output ./generated`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, _ := provenance.Read(dir)
+			pkg := false
+			if lang == "" {
+				if manifest == nil {
+					return fmt.Errorf("no %s in %s; pass --lang", provenance.ManifestFile, dir)
+				}
+				lang = manifest.Language
+			}
+			name, err := generator.Canonical(lang)
+			if err != nil {
+				return err
+			}
+			if manifest != nil && manifest.Language == name {
+				pkg = manifest.Package
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			loader := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays())
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+			gen, err := generator.New(name, cfg)
+			if err != nil {
+				return err
+			}
+
+			var dirs []string
+			if layout, ok := gen.(schema.Layout); ok && changedOnly {
+				files, err := changedSchemaFiles(schemaDir)
+				if err != nil {
+					return err
+				}
+				namespaces, err := affectedNamespaces(schemas, schemaDir, files)
+				if err != nil {
+					return err
+				}
+				if len(namespaces) == 0 {
+					fmt.Println("No schema changes")
+					return nil
+				}
+				if schemas, err = withDependencies(schemas, namespaces); err != nil {
+					return err
+				}
+				for _, ns := range namespaces {
+					dirs = append(dirs, filepath.ToSlash(layout.NamespaceDir(ns)))
+				}
+				// Package manifests cover the whole output
+				pkg = false
+			}
+
+			workDir, err := os.MkdirTemp("", "ehrglot-check-")
+			if err != nil {
+				return fmt.Errorf("failed to create work directory: %w", err)
+			}
+			defer os.RemoveAll(workDir)
+
+			notes, err := runner.Render(cmd.Context(), name, cfg, schemas, mappings, workDir, pkg)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+			}
+			problems, err := provenance.Compare(workDir, dir, dirs)
+			if err != nil {
+				return err
+			}
+
+			if len(problems) == 0 {
+				fmt.Printf("%s code in %s is up to date\n", name, dir)
+				return nil
+			}
+			for _, p := range problems {
+				fmt.Println(p)
+			}
+			return fmt.Errorf("%d generated files in %s are out of date; run ehrglot generate", len(problems), dir)
+		},
+	}
+
+	cmd.Flags().StringVarP(&lang, "lang", "l", "", "Target language (default: from the output's manifest)")
+	cmd.RegisterFlagCompletionFunc("lang", completeLanguages(generator.Languages))
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&dir, "output", "o", "./generated", "Generated code directory")
+	cmd.Flags().BoolVar(&changedOnly, "changed-only", false, "Only check namespaces with schema files changed in git")
+
+	return cmd
+}
+
+// changedSchemaFiles returns the absolute paths of the YAML files under dir
+// that git reports as changed, staged or not, including both sides of
+// renames.
+func changedSchemaFiles(dir string) ([]string, error) {
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top = strings.TrimSpace(top)
+
+	status, err := git(dir, "status", "--porcelain=v1", "-z", "--untracked-files=all", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+	return statusSchemaFiles(top, status), nil
+}
+
+// statusSchemaFiles returns the paths, joined to the repository root top, of
+// the YAML files in the output of git status --porcelain=v1 -z.
+func statusSchemaFiles(top, status string) []string {
+	var files []string
+	entries := strings.Split(status, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		paths := []string{entry[3:]}
+		if entry[0] == 'R' || entry[0] == 'C' {
+			// The original path follows as its own entry
+			i++
+			if i < len(entries) {
+				paths = append(paths, entries[i])
+			}
+		}
+		for _, p := range paths {
+			if ext := filepath.Ext(p); ext == ".yaml" || ext == ".yml" {
+				files = append(files, filepath.Join(top, filepath.FromSlash(p)))
+			}
+		}
+	}
+	return files
+}
+
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// affectedNamespaces returns the namespaces of the changed schema files,
+// each a directory directly under schemaDir, together with the namespaces
+// whose schemas reference schemas in them, sorted.
+func affectedNamespaces(schemas []schema.Schema, schemaDir string, files []string) ([]string, error) {
+	root, err := filepath.Abs(schemaDir)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve symlinks so paths from git compare equal to the schema directory
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	changed := make(map[string]bool)
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if ns, _, ok := strings.Cut(filepath.ToSlash(rel), "/"); ok {
+			changed[ns] = true
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+	affected := make(map[string]bool)
+	for ns := range changed {
+		affected[ns] = true
+	}
+	for _, s := range schemas {
+		for _, dep := range refs.Dependencies(s) {
+			if changed[dep.Namespace] {
+				affected[s.Namespace] = true
+			}
+		}
+	}
+
+	namespaces := make([]string, 0, len(affected))
+	for ns := range affected {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// withDependencies returns the schemas of namespaces together with every
+// schema they reference, directly or indirectly, so that generating them
+// yields the same files for those namespaces as generating all schemas.
+func withDependencies(schemas []schema.Schema, namespaces []string) ([]schema.Schema, error) {
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool)
+	for _, ns := range namespaces {
+		wanted[ns] = true
+	}
+	keep := make(map[string]bool)
+	var visit func(s schema.Schema)
+	visit = func(s schema.Schema) {
+		key := s.Namespace + "/" + s.GetName()
+		if keep[key] {
+			return
+		}
+		keep[key] = true
+		for _, dep := range refs.Dependencies(s) {
+			visit(dep)
+		}
+	}
+	for _, s := range schemas {
+		if wanted[s.Namespace] {
+			visit(s)
+		}
+	}
+
+	var subset []schema.Schema
+	for _, s := range schemas {
+		if keep[s.Namespace+"/"+s.GetName()] {
+			subset = append(subset, s)
+		}
+	}
+	return subset, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestStatusSchemaFiles(t *testing.T) {
+	top := filepath.FromSlash("/repo")
+	tests := []struct {
+		name   string
+		status string
+		want   []string
+	}{
+		{
+			name:   "modified and untracked",
+			status: " M schemas/fhir_r4/patient.yaml\x00?? schemas/custom/visit.yml\x00",
+			want:   []string{"schemas/fhir_r4/patient.yaml", "schemas/custom/visit.yml"},
+		},
+		{
+			name:   "renamed",
+			status: "R  schemas/admin/location.yaml\x00schemas/fhir_r4/location.yaml\x00",
+			want:   []string{"schemas/admin/location.yaml", "schemas/fhir_r4/location.yaml"},
+		},
+		{
+			name:   "renamed from a file that is not yaml",
+			status: "R  schemas/custom/visit.yaml\x00notes/visit.txt\x00",
+			want:   []string{"schemas/custom/visit.yaml"},
+		},
+		{
+			name:   "deleted",
+			status: " D schemas/dicom/series.yaml\x00D  schemas/dicom/study.yaml\x00",
+			want:   []string{"schemas/dicom/series.yaml", "schemas/dicom/study.yaml"},
+		},
+		{
+			name:   "not yaml",
+			status: " M README.md\x00?? schemas/fhir_r4/patient.json\x00",
+		},
+		{
+			name: "clean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []string
+			for _, p := range tt.want {
+				want = append(want, filepath.Join(top, filepath.FromSlash(p)))
+			}
+			if got := statusSchemaFiles(top, tt.status); !reflect.DeepEqual(got, want) {
+				t.Errorf("statusSchemaFiles() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAffectedNamespaces(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaDir := filepath.Join(root, "schemas")
+	schemas := []schema.Schema{
+		{Name: "Patient", Namespace: "admin", Fields: []schema.Field{{Name: "id", Type: "string"}}},
+		{Name: "Encounter", Namespace: "clinical", Fields: []schema.Field{{Name: "subject", Type: "Patient"}}},
+		{Name: "Claim", Namespace: "billing", Fields: []schema.Field{{Name: "encounter", Type: "[]Encounter"}}},
+		{Name: "Series", Namespace: "dicom", Fields: []schema.Field{{Name: "uid", Type: "id"}}},
+	}
+	path := func(rel string) string {
+		return filepath.Join(root, filepath.FromSlash(rel))
+	}
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "referenced namespace",
+			files: []string{path("schemas/admin/patient.yaml")},
+			want:  []string{"admin", "clinical"},
+		},
+		{
+			name:  "namespace nothing references",
+			files: []string{path("schemas/billing/claim.yaml")},
+			want:  []string{"billing"},
+		},
+		{
+			name:  "renamed across namespaces",
+			files: []string{path("schemas/dicom/encounter.yaml"), path("schemas/clinical/encounter.yaml")},
+			want:  []string{"billing", "clinical", "dicom"},
+		},
+		{
+			name:  "deleted namespace",
+			files: []string{path("schemas/retired/old.yaml")},
+			want:  []string{"retired"},
+		},
+		{
+			name:  "out of tree",
+			files: []string{path("other/admin/patient.yaml"), path("schemas.yaml"), path("schemas/top.yaml")},
+		},
+		{
+			name: "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := affectedNamespaces(schemas, schemaDir, tt.files)
+			if err != nil {
+				t.Fatalf("affectedNamespaces() failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("affectedNamespaces() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/konzy/ehrglot/pkg/convert"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func convertCmd() *cobra.Command {
+	var (
+		mappingFile string
+		input       string
+		output      string
+		dest        string
+		timezone    string
+		passthrough bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "convert",
+		Short:        "Convert source rows to FHIR resources with a mapping",
+		SilenceUsage: true,
+		Long: `Runs a mapping directly on a CSV extract of its source table and streams the
+resulting resources as NDJSON or as a collection Bundle, without generating
+code first. Columns are matched to mapping sources by name, values pass
+through the mapping's transforms and value mappings, and target_context
+values are set alongside each target. Values that fail to convert are
+reported with their row and left out of the resource.
+
+Example:
+  ehrglot convert --mapping schemas/epic_clarity/patient_mapping.yaml --input patients.csv --output ndjson --passthrough`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc, err := loadLocation(timezone)
+			if err != nil {
+				return err
+			}
+			mapping, err := schema.LoadMappingFile(mappingFile)
+			if err != nil {
+				return err
+			}
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			c, err := convert.New(schemas, mapping, convert.Options{Location: loc, Passthrough: passthrough})
+			if err != nil {
+				return err
+			}
+
+			var in io.Reader = os.Stdin
+			if input != "-" {
+				f, err := os.Open(input)
+				if err != nil {
+					return fmt.Errorf("failed to open input: %w", err)
+				}
+				defer f.Close()
+				in = f
+			}
+			var out io.Writer = os.Stdout
+			if dest != "" {
+				f, err := os.Create(dest)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", dest, err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			stats, err := c.Stream(in, out, output, func(row int, err error) {
+				for _, line := range strings.Split(err.Error(), "\n") {
+					fmt.Fprintf(os.Stderr, "%s:row %d: %s\n", input, row, line)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if len(stats.Missing) > 0 {
+				fmt.Fprintf(os.Stderr, "Source columns not in the input: %s\n", strings.Join(stats.Missing, ", "))
+			}
+			fmt.Fprintf(os.Stderr, "Converted %d rows to %s (%d with errors)\n", stats.Rows, c.Resource(), stats.Failed)
+			if stats.Failed > 0 {
+				return fmt.Errorf("%d rows had values that failed to convert", stats.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&mappingFile, "mapping", "m", "", "Mapping file to run")
+	cmd.Flags().StringVarP(&input, "input", "i", "-", "CSV file to convert (- for stdin)")
+	cmd.Flags().StringVarP(&output, "output", "o", convert.NDJSON, "Output format (ndjson, bundle)")
+	cmd.Flags().StringVar(&dest, "dest", "", "File to write resources to (default: stdout)")
+	cmd.Flags().StringVar(&timezone, "timezone", "UTC", "Time zone of source datetimes without an offset")
+	cmd.Flags().BoolVar(&passthrough, "passthrough", false, "Copy values through unknown transforms unchanged")
+	cmd.MarkFlagRequired("mapping")
+	cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{convert.NDJSON, convert.Bundle}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// loadLocation loads the --timezone of naive source datetimes.
+func loadLocation(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --timezone: %w", err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/generator/python"
+	"github.com/konzy/ehrglot/pkg/generator/sql"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func diffCmd() *cobra.Command {
+	var (
+		oldDir        string
+		format        string
+		dialect       string
+		compatibility string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "diff",
+		Short:        "Compare two versions of the schemas",
+		SilenceUsage: true,
+		Long: `Compares the schemas in --old with those in --schemas and reports added and
+removed schemas and fields, renamed fields, and fields whose type or
+required flag changed. Fields are matched by uid when both versions give
+one, and otherwise by name, so give fields a uid to have renames reported
+as renames rather than as a removal and an addition:
+
+  fields:
+    - name: mrn
+      uid: patient-mrn
+      type: string
+
+--format sql writes the statements that migrate the tables, with RENAME
+COLUMN for renamed fields, in the configured SQL dialect or --dialect.
+--format python writes a module of functions that migrate stored records,
+as decoded from JSON, to the new shape. Both fill fields with their default:
+
+  fields:
+    - name: active
+      type: boolean
+      default: true
+
+diff fails, before writing anything, when a change breaks the compatibility
+mode of its namespace, from namespaces.<name>.compatibility or else
+registry.compatibility, as a schema registry would: BACKWARD rejects
+required fields without a default that new readers would miss in old
+records, FORWARD rejects removed fields, even optional ones, that old
+readers would miss in new records, unless the old version gave them a
+default, and FULL rejects both. Renames and type changes other than integer
+to decimal break both. --compatibility overrides the mode of every
+namespace, and NONE turns the check off:
+
+  namespaces:
+    clinic:
+      compatibility: full
+
+Example:
+  ehrglot diff --old ../schemas-v1 --format sql --dialect snowflake`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			old, err := schema.NewLoader(oldDir).WithOverlays(cfg.Overlays()).LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load old schemas: %w", err)
+			}
+			schemas, err := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays()).LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			changes, err := diff.Compare(old, schemas)
+			if err != nil {
+				return err
+			}
+			if err := checkCompatibility(cfg, changes, compatibility); err != nil {
+				return err
+			}
+
+			switch format {
+			case "text":
+				for _, c := range changes {
+					fmt.Println(c)
+				}
+				return nil
+			case "json":
+				return writeChangesJSON(os.Stdout, changes)
+			case "sql":
+				if dialect != "" {
+					if !contains([]string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake, config.DialectDelta, config.DialectIceberg}, dialect) {
+						return fmt.Errorf("unknown SQL dialect %q (want postgres, bigquery, snowflake, delta, or iceberg)", dialect)
+					}
+					l := cfg.Language("sql")
+					l.Dialect = dialect
+					if cfg.Languages == nil {
+						cfg.Languages = make(map[string]config.Language)
+					}
+					cfg.Languages["sql"] = l
+				}
+				return sql.NewGenerator(cfg).WriteAlter(os.Stdout, changes)
+			case "python":
+				return python.NewGenerator(cfg).WriteMigration(os.Stdout, changes)
+			default:
+				return fmt.Errorf("unknown format %q (want text, json, sql, or python)", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&oldDir, "old", "", "Schema directory of the old version")
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory of the new version")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, json, sql, python)")
+	cmd.Flags().StringVar(&dialect, "dialect", "", "SQL dialect of --format sql (postgres, bigquery, snowflake, delta, iceberg)")
+	cmd.Flags().StringVar(&compatibility, "compatibility", "", "Compatibility mode to enforce on every namespace, overriding the configured ones")
+	cmd.MarkFlagRequired("old")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json", "sql", "python"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("compatibility", cobra.FixedCompletions(config.CompatibilityModes, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("dialect", cobra.FixedCompletions([]string{"postgres", "bigquery", "snowflake", "delta", "iceberg"}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// checkCompatibility returns an error when changes break the compatibility
+// mode of their namespace, or the mode override when one is given.
+func checkCompatibility(cfg config.Config, changes []diff.Change, override string) error {
+	if override != "" && !contains(config.CompatibilityModes, strings.ToUpper(override)) {
+		return fmt.Errorf("unknown --compatibility %q (want %s)", override, strings.Join(config.CompatibilityModes, ", "))
+	}
+	return diff.Check(changes, func(namespace string) string {
+		if override != "" {
+			return override
+		}
+		return cfg.Compatibility(namespace)
+	})
+}
+
+// changeJSON is the JSON form of a change. From and To hold what changed:
+// the old and new names, types, or required flags of the field.
+type changeJSON struct {
+	Kind      diff.Kind `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Schema    string    `json:"schema"`
+	Field     string    `json:"field,omitempty"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to,omitempty"`
+}
+
+func writeChangesJSON(w io.Writer, changes []diff.Change) error {
+	out := []changeJSON{}
+	for _, c := range changes {
+		j := changeJSON{Kind: c.Kind, Namespace: c.Namespace(), Schema: c.Schema(), Field: c.Field()}
+		switch c.Kind {
+		case diff.FieldRenamed:
+			j.From, j.To = c.OldField.Name, c.NewField.Name
+		case diff.TypeChanged:
+			j.From, j.To = c.OldField.Type, c.NewField.Type
+		case diff.RequiredChanged:
+			j.From, j.To = strconv.FormatBool(c.OldField.Required), strconv.FormatBool(c.NewField.Required)
+		}
+		out = append(out, j)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
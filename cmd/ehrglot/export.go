@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/konzy/ehrglot/pkg/sdtm"
+	"github.com/spf13/cobra"
+)
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export FHIR resources to other data standards",
+	}
+	cmd.AddCommand(exportSDTMCmd())
+	return cmd
+}
+
+func exportSDTMCmd() *cobra.Command {
+	var (
+		study  string
+		inputs []string
+		dest   string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "sdtm",
+		Short:        "Export FHIR resources as CDISC SDTM domains",
+		SilenceUsage: true,
+		Long: `Maps FHIR R4 resources to CDISC SDTM domains and writes each domain as a CSV
+dataset: Patient to DM, AdverseEvent to AE, and laboratory Observations to LB.
+Coded values are translated to CDISC controlled terminology, such as gender
+to the SEX codelist and US Core race categories to RACE. define.csv lists the
+variables written with their labels and codelists. Inputs may be NDJSON, a
+JSON array of resources, or FHIR Bundles.
+
+Example:
+  ehrglot export sdtm --study ABC-123 --input Patient.ndjson --input Observation.ndjson --dest sdtm`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e := sdtm.New(study)
+			for _, input := range inputs {
+				f, err := os.Open(input)
+				if err != nil {
+					return fmt.Errorf("failed to open input: %w", err)
+				}
+				err = e.Read(f)
+				f.Close()
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", input, err)
+				}
+			}
+
+			paths, err := e.Write(dest)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Exported study %s to %s\n", study, dest)
+			for _, path := range paths {
+				fmt.Printf("  - %s\n", path)
+			}
+			if n := e.Skipped(); n > 0 {
+				fmt.Fprintf(os.Stderr, "Skipped %d resources no SDTM domain is built from\n", n)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&study, "study", "", "Study identifier (STUDYID)")
+	cmd.Flags().StringArrayVarP(&inputs, "input", "i", nil, "FHIR resource file to export (repeatable)")
+	cmd.Flags().StringVar(&dest, "dest", "sdtm", "Directory to write datasets to")
+	cmd.MarkFlagRequired("study")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
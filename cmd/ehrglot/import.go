@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/importer"
+	"github.com/konzy/ehrglot/pkg/importer/ncpdp"
+	"github.com/konzy/ehrglot/pkg/importer/openapi"
+	"github.com/konzy/ehrglot/pkg/importer/questionnaire"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import source schemas from external formats",
+	}
+
+	cmd.AddCommand(importOpenAPICmd())
+	cmd.AddCommand(importNCPDPCmd())
+	cmd.AddCommand(importQuestionnaireCmd())
+	return cmd
+}
+
+func importOpenAPICmd() *cobra.Command {
+	var (
+		file        string
+		namespace   string
+		target      string
+		schemasOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Import component schemas from an OpenAPI spec",
+		Long: `Converts the component schemas of a vendor EHR API spec into source
+schemas under <schemas>/<namespace>, and stubs mappings to FHIR R4 targets
+for schemas whose names match a FHIR resource.
+
+Example:
+  ehrglot import openapi --file vendor-api.yaml --namespace vendor --schemas-only`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := openapi.Load(file)
+			if err != nil {
+				return err
+			}
+
+			if namespace == "" {
+				base := filepath.Base(file)
+				namespace = strings.ReplaceAll(strings.TrimSuffix(base, filepath.Ext(base)), "-", "_")
+			}
+
+			importer := openapi.NewImporter(namespace)
+			schemas := importer.Import(spec)
+			if len(schemas) == 0 {
+				return fmt.Errorf("no component schemas found in %s", file)
+			}
+
+			dir := filepath.Join(schemaDir, namespace)
+			written, err := openapi.WriteSchemas(schemas, dir, file)
+			if err != nil {
+				return fmt.Errorf("failed to write schemas: %w", err)
+			}
+
+			if !schemasOnly {
+				var targets []schema.Schema
+				loader, err := newLoader(schemaDir)
+				if err != nil {
+					return err
+				}
+				err = loader.Walk(cmd.Context(), func(s schema.Schema) error {
+					if s.Namespace == "fhir_r4" {
+						targets = append(targets, s)
+					}
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to load schemas: %w", err)
+				}
+
+				mappings, err := openapi.WriteMappings(importer.StubMappings(schemas, targets, target), dir, file)
+				if err != nil {
+					return fmt.Errorf("failed to write mappings: %w", err)
+				}
+				written = append(written, mappings...)
+			}
+
+			fmt.Printf("Imported %d schemas into %s\n", len(schemas), dir)
+			for _, path := range written {
+				fmt.Printf("  - %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "OpenAPI spec file (YAML or JSON)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Target namespace (defaults to the spec file name)")
+	cmd.Flags().StringVar(&target, "target", "", "FHIR resource to stub mappings against (defaults to name matching)")
+	cmd.Flags().BoolVar(&schemasOnly, "schemas-only", false, "Import schemas without stubbing mappings")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func importNCPDPCmd() *cobra.Command {
+	var (
+		files       []string
+		namespace   string
+		target      string
+		schemasOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ncpdp",
+		Short: "Import transactions from NCPDP SCRIPT messages",
+		Long: `Converts the transactions of sample NCPDP SCRIPT XML messages, such as
+NewRx, into source schemas under <schemas>/<namespace>, merging messages of
+the same transaction, and stubs mappings of the prescription transactions to
+FHIR R4 MedicationRequest.
+
+Example:
+  ehrglot import ncpdp --file newrx.xml --file newrx-compound.xml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var messages []*ncpdp.Element
+			for _, file := range files {
+				msg, err := ncpdp.Load(file)
+				if err != nil {
+					return err
+				}
+				messages = append(messages, msg)
+			}
+
+			imp := ncpdp.NewImporter(namespace)
+			schemas := imp.Import(messages...)
+			if len(schemas) == 0 {
+				return fmt.Errorf("no transactions found in %s", strings.Join(files, ", "))
+			}
+
+			dir := filepath.Join(schemaDir, namespace)
+			written, err := importer.WriteSchemas(schemas, dir, files[0], "ncpdp")
+			if err != nil {
+				return fmt.Errorf("failed to write schemas: %w", err)
+			}
+
+			if !schemasOnly {
+				mappings, err := importer.WriteMappings(imp.StubMappings(schemas, target), dir, files[0], "ncpdp")
+				if err != nil {
+					return fmt.Errorf("failed to write mappings: %w", err)
+				}
+				written = append(written, mappings...)
+			}
+
+			fmt.Printf("Imported %d schemas into %s\n", len(schemas), dir)
+			for _, path := range written {
+				fmt.Printf("  - %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringSliceVarP(&files, "file", "f", nil, "SCRIPT message XML file (repeatable)")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "ncpdp_script", "Target namespace")
+	cmd.Flags().StringVar(&target, "target", "", "FHIR resource to stub mappings against (defaults to MedicationRequest)")
+	cmd.Flags().BoolVar(&schemasOnly, "schemas-only", false, "Import schemas without stubbing mappings")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func importQuestionnaireCmd() *cobra.Command {
+	var (
+		file      string
+		namespace string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "questionnaire",
+		Short: "Import the response model of a FHIR Questionnaire",
+		Long: `Converts a FHIR R4 Questionnaire JSON file, such as a patient intake form,
+into a source schema of its responses under <schemas>/<namespace>. Fields
+carry the linkIds of their questions, and enableWhen becomes enabled_if, so
+generated validators reject answers to disabled questions.
+
+Example:
+  ehrglot import questionnaire --file intake.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := questionnaire.Load(file)
+			if err != nil {
+				return err
+			}
+
+			s, notes := questionnaire.NewImporter(namespace).Import(q)
+			for _, note := range notes {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+			}
+			if len(s.Fields) == 0 {
+				return fmt.Errorf("no questions found in %s", file)
+			}
+
+			dir := filepath.Join(schemaDir, namespace)
+			written, err := importer.WriteSchemas([]schema.Schema{s}, dir, file, "questionnaire")
+			if err != nil {
+				return fmt.Errorf("failed to write schemas: %w", err)
+			}
+
+			fmt.Printf("Imported %s into %s\n", s.Name, dir)
+			for _, path := range written {
+				fmt.Printf("  - %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Questionnaire JSON file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "forms", "Target namespace")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
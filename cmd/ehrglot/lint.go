@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/lint"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func lintCmd() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "lint",
+		Short:        "Check schemas against style and privacy rules",
+		SilenceUsage: true,
+		Long: `Checks every schema against the lint rules and reports violations. Rule
+severities can be changed, or rules turned off, under lint.rules in
+ehrglot.yaml:
+
+  lint:
+    rules:
+      field-description: note
+      enum-lowercase: off
+
+Exits non-zero when any finding has severity error. --format sarif writes a
+SARIF 2.1.0 log for code review and code scanning tools.
+
+Example:
+  ehrglot lint --format sarif --output lint.sarif`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			linter, err := lint.New(cfg.Lint)
+			if err != nil {
+				return err
+			}
+			schemas, err := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays()).LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			findings := linter.Lint(schemas)
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", output, err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			switch format {
+			case "text":
+				for _, f := range findings {
+					fmt.Fprintln(w, f)
+				}
+			case "sarif":
+				if err := linter.WriteSARIF(w, findings, version); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unknown format %q (want text or sarif)", format)
+			}
+
+			errors := lint.Count(findings, config.SeverityError)
+			fmt.Fprintf(os.Stderr, "%d schemas checked: %d errors, %d warnings, %d notes\n", len(schemas),
+				errors, lint.Count(findings, config.SeverityWarning), lint.Count(findings, config.SeverityNote))
+			if errors > 0 {
+				return fmt.Errorf("lint found %d errors", errors)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, sarif)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the report to a file instead of stdout")
+
+	return cmd
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// piiLevels are the PII levels in ascending sensitivity.
+var piiLevels = []string{"none", "low", "medium", "high", "critical"}
+
+// schemaSummary describes a schema in list output.
+type schemaSummary struct {
+	Namespace   string         `json:"namespace" yaml:"namespace"`
+	Name        string         `json:"name" yaml:"name"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Owner       string         `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Steward     string         `json:"steward,omitempty" yaml:"steward,omitempty"`
+	Tags        []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Fields      int            `json:"fields" yaml:"fields"`
+	PII         map[string]int `json:"pii,omitempty" yaml:"pii,omitempty"`
+	File        string         `json:"file" yaml:"file"`
+}
+
+func listCmd() *cobra.Command {
+	var (
+		format     string
+		namespaces []string
+		pii        []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [schema...]",
+		Short: "List available schemas",
+		Long: `Lists the schemas in the schema directory, optionally only those named as
+arguments (Name or namespace/Name), in the given namespaces, or with fields at
+the given PII levels. The json, yaml, and table formats include the owner of
+each schema, per-schema field counts, and the number of fields at each PII
+level; json and yaml also include the steward and tags.
+
+Example:
+  ehrglot list --namespace fhir_r4 --pii high,critical --format table`,
+		ValidArgsFunction: completeSchemas,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, level := range pii {
+				if !contains(piiLevels, strings.ToLower(level)) {
+					return fmt.Errorf("unknown PII level %q (want %s)", level, strings.Join(piiLevels, ", "))
+				}
+			}
+
+			var summaries []schemaSummary
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			err = loader.Walk(cmd.Context(), func(s schema.Schema) error {
+				summary := summarize(s)
+				if matches(summary, args, namespaces, pii) {
+					summaries = append(summaries, summary)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list schemas: %w", err)
+			}
+			return writeSummaries(os.Stdout, format, summaries)
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&format, "format", "f", "text", "Output format (text, table, json, yaml)")
+	cmd.Flags().StringSliceVarP(&namespaces, "namespace", "n", nil, "Only list schemas in these namespaces")
+	cmd.Flags().StringSliceVar(&pii, "pii", nil, "Only list schemas with fields at these PII levels ("+strings.Join(piiLevels, ", ")+")")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces)
+	cmd.RegisterFlagCompletionFunc("pii", cobra.FixedCompletions(piiLevels, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func summarize(s schema.Schema) schemaSummary {
+	summary := schemaSummary{
+		Namespace:   s.Namespace,
+		Name:        s.GetName(),
+		Description: strings.TrimSpace(s.Description),
+		Owner:       s.Owner,
+		Steward:     s.Steward,
+		Tags:        s.Tags,
+		File:        s.SourceFile,
+	}
+	var count func(fields []schema.Field)
+	count = func(fields []schema.Field) {
+		for _, f := range fields {
+			summary.Fields++
+			if f.PIILevel != "" {
+				if summary.PII == nil {
+					summary.PII = make(map[string]int)
+				}
+				summary.PII[strings.ToLower(f.PIILevel)]++
+			}
+			count(f.Children)
+		}
+	}
+	count(s.Fields)
+	return summary
+}
+
+// matches reports whether a schema passes the list filters. Empty filters
+// match every schema.
+func matches(s schemaSummary, names, namespaces, pii []string) bool {
+	if len(names) > 0 && !contains(names, s.Name) && !contains(names, s.Namespace+"/"+s.Name) {
+		return false
+	}
+	if len(namespaces) > 0 && !contains(namespaces, s.Namespace) {
+		return false
+	}
+	if len(pii) == 0 {
+		return true
+	}
+	for _, level := range pii {
+		if s.PII[strings.ToLower(level)] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func writeSummaries(w io.Writer, format string, summaries []schemaSummary) error {
+	switch format {
+	case "text":
+		fmt.Fprintln(w, "Available schemas:")
+		for _, s := range summaries {
+			fmt.Fprintf(w, "  - %s/%s\n", s.Namespace, s.Name)
+		}
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tNAME\tOWNER\tFIELDS\tPII")
+		for _, s := range summaries {
+			owner := s.Owner
+			if owner == "" {
+				owner = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", s.Namespace, s.Name, owner, s.Fields, formatPII(s.PII))
+		}
+		return tw.Flush()
+	case "json":
+		if summaries == nil {
+			summaries = []schemaSummary{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summaries)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(summaries); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown format %q (want text, table, json, or yaml)", format)
+	}
+	return nil
+}
+
+// formatPII renders PII counts from most to least sensitive, such as
+// "critical:2 high:1".
+func formatPII(counts map[string]int) string {
+	var parts []string
+	for i := len(piiLevels) - 1; i >= 0; i-- {
+		if n := counts[piiLevels[i]]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s:%d", piiLevels[i], n))
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// completeLanguages completes --lang with the given language names.
+func completeLanguages(languages []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return cobra.FixedCompletions(languages, cobra.ShellCompDirectiveNoFileComp)
+}
+
+// completeSchemas completes schema names as namespace/Name.
+func completeSchemas(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	loader, err := newLoader(schemaDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names, err := loader.ListSchemas(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var completions []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) && !contains(args, name) {
+			completions = append(completions, name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNamespaces completes namespace names.
+func completeNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	seen := make(map[string]bool)
+	var namespaces []string
+	loader, err := newLoader(schemaDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	err = loader.Walk(cmd.Context(), func(s schema.Schema) error {
+		if !seen[s.Namespace] {
+			seen[s.Namespace] = true
+			namespaces = append(namespaces, s.Namespace)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	sort.Strings(namespaces)
+	return namespaces, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSummarize(t *testing.T) {
+	s := schema.Schema{
+		Name:        "Patient",
+		Namespace:   "fhir_r4",
+		Description: "Demographics\n",
+		Owner:       "registration",
+		SourceFile:  "schemas/fhir_r4/patient.yaml",
+		Fields: []schema.Field{
+			{Name: "id", PIILevel: "HIGH"},
+			{Name: "name", Children: []schema.Field{
+				{Name: "family", PIILevel: "high"},
+				{Name: "given", PIILevel: "critical"},
+			}},
+			{Name: "active"},
+		},
+	}
+	want := schemaSummary{
+		Namespace:   "fhir_r4",
+		Name:        "Patient",
+		Description: "Demographics",
+		Owner:       "registration",
+		Fields:      5,
+		PII:         map[string]int{"high": 2, "critical": 1},
+		File:        "schemas/fhir_r4/patient.yaml",
+	}
+	if got := summarize(s); !reflect.DeepEqual(got, want) {
+		t.Errorf("summarize() = %+v, want %+v", got, want)
+	}
+	if got := summarize(schema.Schema{Name: "Empty"}); got.Fields != 0 || got.PII != nil {
+		t.Errorf("summarize() of a schema without fields = %+v, want no counts", got)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	s := schemaSummary{Namespace: "fhir_r4", Name: "Patient", PII: map[string]int{"high": 2}}
+	tests := []struct {
+		name       string
+		names      []string
+		namespaces []string
+		pii        []string
+		want       bool
+	}{
+		{name: "no filters", want: true},
+		{name: "name", names: []string{"Patient"}, want: true},
+		{name: "qualified name", names: []string{"fhir_r4/Patient"}, want: true},
+		{name: "other name", names: []string{"Encounter", "epic_clarity/Patient"}, want: false},
+		{name: "namespace", namespaces: []string{"custom", "fhir_r4"}, want: true},
+		{name: "other namespace", namespaces: []string{"custom"}, want: false},
+		{name: "pii level", pii: []string{"low", "HIGH"}, want: true},
+		{name: "other pii level", pii: []string{"critical"}, want: false},
+		{name: "all filters", names: []string{"Patient"}, namespaces: []string{"fhir_r4"}, pii: []string{"high"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matches(s, tt.names, tt.namespaces, tt.pii); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteSummaries(t *testing.T) {
+	summaries := []schemaSummary{
+		{Namespace: "fhir_r4", Name: "Patient", Owner: "registration", Tags: []string{"core"}, Fields: 5, PII: map[string]int{"high": 2, "critical": 1}, File: "patient.yaml"},
+		{Namespace: "custom", Name: "Visit", Fields: 1, File: "visit.yaml"},
+	}
+
+	write := func(t *testing.T, format string, summaries []schemaSummary) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := writeSummaries(&buf, format, summaries); err != nil {
+			t.Fatalf("writeSummaries(%s) failed: %v", format, err)
+		}
+		return buf.String()
+	}
+
+	t.Run("text", func(t *testing.T) {
+		want := "Available schemas:\n  - fhir_r4/Patient\n  - custom/Visit\n"
+		if got := write(t, "text", summaries); got != want {
+			t.Errorf("text output =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("table", func(t *testing.T) {
+		want := "NAMESPACE  NAME     OWNER         FIELDS  PII\n" +
+			"fhir_r4    Patient  registration  5       critical:1 high:2\n" +
+			"custom     Visit    -             1       -\n"
+		if got := write(t, "table", summaries); got != want {
+			t.Errorf("table output =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var got []schemaSummary
+		if err := json.Unmarshal([]byte(write(t, "json", summaries)), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, summaries) {
+			t.Errorf("json output = %+v, want %+v", got, summaries)
+		}
+		if got := write(t, "json", nil); got != "[]\n" {
+			t.Errorf("json output without schemas = %q, want an empty array", got)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var got []schemaSummary
+		if err := yaml.Unmarshal([]byte(write(t, "yaml", summaries)), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, summaries) {
+			t.Errorf("yaml output = %+v, want %+v", got, summaries)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if err := writeSummaries(&bytes.Buffer{}, "csv", summaries); err == nil {
+			t.Error("writeSummaries(csv) succeeded, want an error")
+		}
+	})
+}
+
+func TestListCompletions(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"fhir_r4/patient.yaml":   "resource: Patient\nfields:\n  - name: id\n    type: string\n",
+		"fhir_r4/encounter.yaml": "resource: Encounter\nfields:\n  - name: id\n    type: string\n",
+		"custom/visit.yaml":      "resource: Visit\nfields:\n  - name: id\n    type: string\n",
+	}
+	for rel, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	saved := schemaDir
+	schemaDir = dir
+	t.Cleanup(func() { schemaDir = saved })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	got, directive := completeSchemas(cmd, []string{"fhir_r4/Encounter"}, "fhir_r4/")
+	if want := []string{"fhir_r4/Patient"}; !reflect.DeepEqual(got, want) || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completeSchemas() = %q, %v, want %q", got, directive, want)
+	}
+
+	got, directive = completeNamespaces(cmd, nil, "")
+	if want := []string{"custom", "fhir_r4"}; !reflect.DeepEqual(got, want) || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("completeNamespaces() = %q, %v, want %q", got, directive, want)
+	}
+
+	list := listCmd()
+	for flag, want := range map[string]string{"format": "text table json yaml", "pii": strings.Join(piiLevels, " ")} {
+		complete, ok := list.GetFlagCompletionFunc(flag)
+		if !ok {
+			t.Errorf("--%s has no completion", flag)
+			continue
+		}
+		values, _ := complete(list, nil, "")
+		if got := strings.Join(values, " "); got != want {
+			t.Errorf("--%s completions = %q, want %q", flag, got, want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+
+	"github.com/konzy/ehrglot/pkg/lsp"
+	"github.com/spf13/cobra"
+)
+
+func lspCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server for schema authoring",
+		Long: `Runs a Language Server Protocol server on stdin and stdout. Editors get
+diagnostics for files that do not match the meta-schema and for field types
+that do not resolve, go-to-definition from field types and mapping target
+resources to the schemas they name, and hover documentation for field types.
+
+A relative --schemas is resolved against the editor's workspace root.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lsp.New(schemaDir).Serve(os.Stdin, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+
+	return cmd
+}
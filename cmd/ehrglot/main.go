@@ -1,27 +1,34 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
 
-	"github.com/konzy/ehrglot/pkg/generator/csharp"
-	"github.com/konzy/ehrglot/pkg/generator/golang"
-	"github.com/konzy/ehrglot/pkg/generator/java"
-	"github.com/konzy/ehrglot/pkg/generator/kotlin"
-	"github.com/konzy/ehrglot/pkg/generator/python"
-	"github.com/konzy/ehrglot/pkg/generator/rust"
-	"github.com/konzy/ehrglot/pkg/generator/scala"
-	"github.com/konzy/ehrglot/pkg/generator/sql"
-	"github.com/konzy/ehrglot/pkg/generator/typescript"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/events"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/runner"
 	"github.com/konzy/ehrglot/pkg/schema"
 	"github.com/spf13/cobra"
 )
 
 var (
-	version   = "0.1.0"
-	schemaDir = "schemas"
-	outputDir = "./generated"
-	language  = "python"
+	version    = "0.1.0"
+	configFile = config.DefaultFile
+	schemaDir  = "schemas"
+	outputDir  = "./generated"
+	language   = "python"
+	packageOut bool
+	profile    string
+	stripPII   string
+	stripMode  string
+	timeout    time.Duration
 )
 
 func main() {
@@ -45,11 +52,46 @@ Example:
   ehrglot generate --lang python --output ./generated`,
 	}
 
+	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", config.DefaultFile, "Project configuration file")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Stop after this long, e.g. 2m (0 for no limit)")
+	cancel := func() {}
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if timeout > 0 {
+			var ctx context.Context
+			ctx, cancel = context.WithTimeout(cmd.Context(), timeout)
+			cmd.SetContext(ctx)
+		}
+	}
+
+	rootCmd.AddCommand(browseCmd())
+	rootCmd.AddCommand(checkCmd())
+	rootCmd.AddCommand(convertCmd())
+	rootCmd.AddCommand(diffCmd())
+	rootCmd.AddCommand(exportCmd())
 	rootCmd.AddCommand(generateCmd())
 	rootCmd.AddCommand(listCmd())
+	rootCmd.AddCommand(lspCmd())
+	rootCmd.AddCommand(mapCmd())
+	rootCmd.AddCommand(mappingCmd())
+	rootCmd.AddCommand(metaSchemaCmd())
+	rootCmd.AddCommand(importCmd())
+	rootCmd.AddCommand(lintCmd())
+	rootCmd.AddCommand(publishCmd())
+	rootCmd.AddCommand(sampleCmd())
+	rootCmd.AddCommand(scaffoldCmd())
+	rootCmd.AddCommand(selftestCmd())
+	rootCmd.AddCommand(statsCmd())
+	rootCmd.AddCommand(validateDataCmd())
+	rootCmd.AddCommand(verifyCmd())
 	rootCmd.AddCommand(versionCmd())
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C and SIGTERM cancel loading and generation instead of killing
+	// them midway through writing output
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	cancel()
+	stop()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -57,42 +99,69 @@ Example:
 
 func generateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "generate",
-		Short: "Generate code from schemas",
+		Use:          "generate",
+		Short:        "Generate code from schemas",
+		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			loader := schema.NewLoader(schemaDir)
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
 
-			schemas, err := loader.LoadAll()
+			loader := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays())
+			schemas, err := loader.LoadAll(cmd.Context())
 			if err != nil {
 				return fmt.Errorf("failed to load schemas: %w", err)
 			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+			if cfg, schemas, mappings, err = applyProfile(cfg, profile, schemas, mappings); err != nil {
+				return err
+			}
+			if schemas, err = applyEvents(cfg, schemas); err != nil {
+				return err
+			}
+			if stripPII != "" {
+				match, err := schema.PIIThreshold(stripPII)
+				if err != nil {
+					return err
+				}
+				switch stripMode {
+				case "omit", "redact":
+				default:
+					return fmt.Errorf("unknown --strip-pii-mode %q (want omit or redact)", stripMode)
+				}
+				schemas, mappings = schema.Trim(schemas, mappings, match, stripMode == "redact")
+			}
 
-			var generator schema.Generator
-			switch language {
-			case "python":
-				generator = python.NewGenerator()
-			case "go", "golang":
-				generator = golang.NewGenerator()
-			case "typescript", "ts":
-				generator = typescript.NewGenerator()
-			case "java":
-				generator = java.NewGenerator()
-			case "rust", "rs":
-				generator = rust.NewGenerator()
-			case "csharp", "cs":
-				generator = csharp.NewGenerator()
-			case "scala":
-				generator = scala.NewGenerator()
-			case "kotlin", "kt":
-				generator = kotlin.NewGenerator()
-			case "sql", "dbt":
-				generator = sql.NewGenerator()
-			default:
-				return fmt.Errorf("unsupported language: %s", language)
+			lang, err := generator.Canonical(language)
+			if err != nil {
+				return err
+			}
+			if dir := cfg.OutputDir(lang); dir != "" && !cmd.Flags().Changed("output") {
+				outputDir = dir
 			}
 
-			if err := generator.Generate(schemas, outputDir); err != nil {
-				return fmt.Errorf("failed to generate code: %w", err)
+			// The runner renders into a scratch directory first so that the
+			// manifest records exactly the generated files
+			r := runner.Runner{Version: version}
+			req := runner.Request{Langs: []string{lang}, Schemas: schemas, Mappings: mappings, Output: outputDir, Config: cfg, Package: packageOut}
+			results, err := r.Generate(cmd.Context(), req)
+			for _, res := range results {
+				for _, note := range res.Notes {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+				}
+			}
+			if partial := new(runner.PartialError); errors.As(err, &partial) {
+				// The rest of the schemas were written; the failures are
+				// summarized and the command still fails
+				fmt.Printf("Generated %s code in %s for %d of %d schemas\n", language, outputDir, partial.Total-len(partial.Failures), partial.Total)
+				return partial
+			}
+			if err != nil {
+				return err
 			}
 
 			fmt.Printf("Generated %s code in %s\n", language, outputDir)
@@ -101,34 +170,81 @@ func generateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
-	cmd.Flags().StringVarP(&outputDir, "output", "o", "./generated", "Output directory")
+	cmd.Flags().StringVarP(&outputDir, "output", "o", "./generated", "Output directory (defaults to the configured output)")
 	cmd.Flags().StringVarP(&language, "lang", "l", "python", "Target language (python, go, ts, java, rust, csharp, scala, kotlin, sql)")
+	cmd.Flags().BoolVar(&packageOut, "package", false, "Also write a package manifest (python, go, ts, java, rust) named and versioned by the package config")
+	cmd.Flags().StringVar(&profile, "profile", "", "Generate only the schemas of this configured profile, stripped of its PII")
+	cmd.Flags().StringVar(&stripPII, "strip-pii", "", "Strip fields at a pii_level or pii_category and above, or only above it with above:, such as above:quasi_identifier")
+	cmd.Flags().StringVar(&stripMode, "strip-pii-mode", "omit", "How --strip-pii strips fields: omit them, or redact them to text")
+	cmd.RegisterFlagCompletionFunc("lang", completeLanguages(generator.Languages))
+	cmd.RegisterFlagCompletionFunc("profile", completeProfiles)
 
 	return cmd
 }
 
-func listCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List available schemas",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			loader := schema.NewLoader(schemaDir)
+// applyEvents adds the change-event envelopes cfg configures to schemas.
+func applyEvents(cfg config.Config, schemas []schema.Schema) ([]schema.Schema, error) {
+	if cfg.Events.Envelope == "" {
+		return schemas, nil
+	}
+	wrapped, err := events.Wrap(schemas, cfg.Events.Schemas)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	return wrapped, nil
+}
 
-			schemas, err := loader.ListSchemas()
-			if err != nil {
-				return fmt.Errorf("failed to list schemas: %w", err)
-			}
+// applyProfile trims schemas and mappings to the configured profile named
+// name, and returns cfg with the package settings of the profile. An empty
+// name leaves them all as they are.
+func applyProfile(cfg config.Config, name string, schemas []schema.Schema, mappings []schema.SchemaMapping) (config.Config, []schema.Schema, []schema.SchemaMapping, error) {
+	if name == "" {
+		return cfg, schemas, mappings, nil
+	}
+	cfg, p, err := cfg.WithProfile(name)
+	if err != nil {
+		return cfg, nil, nil, err
+	}
+	if len(p.Schemas) > 0 {
+		if schemas, err = schema.Select(schemas, p.Schemas); err != nil {
+			return cfg, nil, nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+	schemas, mappings = schema.Trim(schemas, mappings, func(f schema.Field) bool { return f.Sensitive(p.StripPII) }, false)
+	return cfg, schemas, mappings, nil
+}
 
-			fmt.Println("Available schemas:")
-			for _, s := range schemas {
-				fmt.Printf("  - %s\n", s)
-			}
-			return nil
-		},
+// completeProfiles completes the profiles of the project configuration.
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
 	}
+	var names []string
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
 
-	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
-	return cmd
+// loadConfig reads the project configuration named by --config.
+func loadConfig() (config.Config, error) {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return cfg, err
+	}
+	return generator.Configure(cfg)
+}
+
+// newLoader returns a loader of the schemas in dir, with the namespace
+// overlays of the project configuration.
+func newLoader(dir string) (*schema.Loader, error) {
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewLoader(dir).WithOverlays(cfg.Overlays()), nil
 }
 
 func versionCmd() *cobra.Command {
@@ -140,3 +256,19 @@ func versionCmd() *cobra.Command {
 		},
 	}
 }
+
+func metaSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "meta-schema",
+		Short: "Print the JSON Schema of ehrglot schema and mapping files",
+		Long: `Prints the JSON Schema that ehrglot validates schema and mapping files
+against, for editor completion and validation while authoring them.
+
+Example:
+  ehrglot meta-schema > ehrglot.schema.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := os.Stdout.Write(schema.MetaSchema)
+			return err
+		},
+	}
+}
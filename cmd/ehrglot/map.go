@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/sheet"
+	"github.com/konzy/ehrglot/pkg/validate"
+	"github.com/konzy/ehrglot/pkg/wizard"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func mapCmd() *cobra.Command {
+	var (
+		source string
+		target string
+		output string
+		all    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "map",
+		Short:        "Draft a mapping interactively",
+		SilenceUsage: true,
+		Long: `Walks through the fields of a target resource that the mapping does not fill
+yet, suggesting the columns of a source schema whose names are most alike,
+and writes the chosen field mappings to a _mapping.yaml file. Answer each
+field with the number of a suggestion, a column name or source expression,
+Enter to skip it, or q to finish; each chosen column then asks for its
+transform. Fields of datatypes are listed by path, such as
+name[0].family. Only fields with candidate columns are asked about unless
+--all is set.
+
+The mapping goes to <schemas>/<source namespace>/<target>_mapping.yaml
+unless --output is set. When that file exists and maps the same source to
+the same target, the fields it fills are skipped and new field mappings are
+appended, keeping its comments.
+
+Example:
+  ehrglot map --source custom/DataWarehouse --target Patient`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			src, err := validate.Find(schemas, source)
+			if err != nil {
+				return err
+			}
+			dst, err := validate.Find(schemas, target)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = filepath.Join(schemaDir, src.Namespace, strings.ToLower(dst.GetName())+"_mapping.yaml")
+			}
+
+			var existing *schema.SchemaMapping
+			if _, err := os.Stat(output); err == nil {
+				m, err := schema.LoadMappingFile(output)
+				if err != nil {
+					return err
+				}
+				if m.SourceTable != src.GetName() || m.TargetResource != dst.GetName() {
+					return fmt.Errorf("%s maps %s to %s; choose another --output", output, m.SourceTable, m.TargetResource)
+				}
+				existing = &m
+				fmt.Printf("Extending %s, which has %d field mappings.\n", output, len(m.FieldMappings))
+			}
+
+			var filled []schema.FieldMapping
+			if existing != nil {
+				filled = existing.FieldMappings
+			}
+			added, err := wizard.New(schemas, src, dst, filled, all).Run(os.Stdin, os.Stdout)
+			if err != nil {
+				return err
+			}
+			if len(added) == 0 {
+				fmt.Println("No field mappings chosen; nothing written.")
+				return nil
+			}
+
+			var data []byte
+			if existing != nil {
+				data, err = appendFieldMappings(output, added)
+			} else {
+				data, err = draftMapping(output, schema.SchemaMapping{
+					SourceSystem:   src.Namespace,
+					SourceTable:    src.GetName(),
+					TargetResource: dst.GetName(),
+					Description:    fmt.Sprintf("Draft mapping from %s %s to %s.", src.Namespace, src.GetName(), dst.GetName()),
+					FieldMappings:  added,
+				})
+			}
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			fmt.Printf("Wrote %d field mappings to %s\n", len(added), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVar(&source, "source", "", "Source schema whose fields are the columns (Name or namespace/Name)")
+	cmd.Flags().StringVar(&target, "target", "", "Target resource (Name or namespace/Name)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Mapping file to write (default <schemas>/<source namespace>/<target>_mapping.yaml)")
+	cmd.Flags().BoolVar(&all, "all", false, "Ask about fields without candidate columns too")
+	cmd.MarkFlagRequired("source")
+	cmd.MarkFlagRequired("target")
+	cmd.RegisterFlagCompletionFunc("source", completeSchemas)
+	cmd.RegisterFlagCompletionFunc("target", completeSchemas)
+
+	return cmd
+}
+
+// draftMapping encodes a new mapping file, checked against the mapping
+// JSON Schema.
+func draftMapping(path string, m schema.SchemaMapping) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# Drafted by ehrglot map\n\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := schema.Validate(path, buf.Bytes(), true); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// appendFieldMappings returns the mapping file at path with added appended
+// to its field mappings, keeping its comments.
+func appendFieldMappings(path string, added []schema.FieldMapping) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	rows, err := sheet.Rows(path, data)
+	if err != nil {
+		return nil, err
+	}
+	for _, fm := range added {
+		rows = append(rows, sheet.Row{Mapping: path, Values: map[string]string{"source": fm.Source, "target": fm.Target, "transform": fm.Transform}})
+	}
+	updated, _, err := sheet.Apply(path, data, rows)
+	return updated, err
+}
@@ -0,0 +1,556 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/assist"
+	"github.com/konzy/ehrglot/pkg/convert"
+	"github.com/konzy/ehrglot/pkg/diagram"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/sheet"
+	"github.com/konzy/ehrglot/pkg/suggest"
+	"github.com/konzy/ehrglot/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+func mappingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mapping",
+		Short: "Work with mapping files",
+	}
+
+	cmd.AddCommand(mappingTestCmd())
+	cmd.AddCommand(mappingDiagramCmd())
+	cmd.AddCommand(mappingExportCmd())
+	cmd.AddCommand(mappingImportCmd())
+	cmd.AddCommand(mappingSuggestCmd())
+	return cmd
+}
+
+func mappingTestCmd() *cobra.Command {
+	var (
+		mappingFile string
+		sampleFile  string
+		timezone    string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "test",
+		Short:        "Apply a mapping to sample source records",
+		SilenceUsage: true,
+		Long: `Applies a mapping to sample source records, written as a JSON object or an
+array of objects keyed by source column, and prints each resulting resource
+with the values that failed to convert, the sample fields no mapping reads,
+the mapped sources the sample lacks, and the problems the target schema
+finds in the result. Transforms that are neither built in nor defined by a
+value mapping copy their values unchanged and are listed, so a mapping can
+be tried before its transforms exist. Exits non-zero when a value fails to
+convert.
+
+Example:
+  ehrglot mapping test --mapping schemas/epic_clarity/patient_mapping.yaml --sample sample.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loc, err := loadLocation(timezone)
+			if err != nil {
+				return err
+			}
+			mapping, err := schema.LoadMappingFile(mappingFile)
+			if err != nil {
+				return err
+			}
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			c, err := convert.New(schemas, mapping, convert.Options{Location: loc, Passthrough: true})
+			if err != nil {
+				return err
+			}
+			v, err := validate.New(schemas, c.Target(), false)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(sampleFile)
+			if err != nil {
+				return fmt.Errorf("failed to open sample: %w", err)
+			}
+			defer f.Close()
+			samples, err := convert.ReadSamples(f)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", sampleFile, err)
+			}
+
+			failed := 0
+			for i, sample := range samples {
+				if len(samples) > 1 {
+					fmt.Printf("Sample %d:\n", i+1)
+				}
+				resource, convErr := c.Convert(sample.Row)
+				data, err := convert.Marshal(resource)
+				if err != nil {
+					return err
+				}
+				var pretty bytes.Buffer
+				json.Indent(&pretty, data, "", "  ")
+				fmt.Println(pretty.String())
+
+				if convErr != nil {
+					failed++
+					printList("Failed", strings.Split(convErr.Error(), "\n"))
+				}
+				printNames("Unmapped sample fields", c.Unmapped(sample.Columns))
+				printNames("Sources missing from the sample", c.Missing(sample.Columns))
+				printList("Target issues", targetIssues(v, data))
+				fmt.Println()
+			}
+			printNames("Unknown transforms (values copied unchanged)", c.Unknown())
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d samples had values that failed to convert", failed, len(samples))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&mappingFile, "mapping", "m", "", "Mapping file to test")
+	cmd.Flags().StringVar(&sampleFile, "sample", "", "JSON file of sample source records")
+	cmd.Flags().StringVar(&timezone, "timezone", "UTC", "Time zone of source datetimes without an offset")
+	cmd.MarkFlagRequired("mapping")
+	cmd.MarkFlagRequired("sample")
+
+	return cmd
+}
+
+func mappingDiagramCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:          "diagram",
+		Short:        "Write Markdown pages diagramming the mappings",
+		SilenceUsage: true,
+		Long: `Writes a Markdown page per mapping file with a Mermaid flowchart from its
+source columns through its transforms to its target fields, followed by
+tables of its field mappings, constants, and value mappings, and a
+README.md linking the pages by source system. Pages are written to
+<output>/<source_system>/<mapping file name>.md, where GitHub, GitLab, and
+most documentation sites render the diagrams.
+
+Example:
+  ehrglot mapping diagram --output docs/mappings`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+			if len(mappings) == 0 {
+				return fmt.Errorf("no mappings found in %s", schemaDir)
+			}
+
+			write := func(path string, render func(*bytes.Buffer) error) error {
+				var b bytes.Buffer
+				if err := render(&b); err != nil {
+					return err
+				}
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					return fmt.Errorf("failed to create output directory: %w", err)
+				}
+				if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", path, err)
+				}
+				return nil
+			}
+			for _, m := range mappings {
+				if err := write(filepath.Join(dir, diagram.Path(m)), func(b *bytes.Buffer) error { return diagram.Page(b, m) }); err != nil {
+					return err
+				}
+			}
+			if err := write(filepath.Join(dir, "README.md"), func(b *bytes.Buffer) error { return diagram.Index(b, mappings) }); err != nil {
+				return err
+			}
+			fmt.Printf("Wrote diagrams of %d mappings to %s\n", len(mappings), dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&dir, "output", "o", "docs/mappings", "Directory to write the pages to")
+
+	return cmd
+}
+
+func mappingExportCmd() *cobra.Command {
+	var (
+		files  []string
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:          "export",
+		Short:        "Write field mappings to a spreadsheet for review",
+		SilenceUsage: true,
+		Long: `Writes the field mappings of the mapping files in the schema directory, or
+of the files given with --mapping, to one spreadsheet: a row per field
+mapping with its file, its position in the file, and its source, target,
+transform, description, default, and skip_if_null. Edit the rows, reorder,
+delete, or add them (leaving # empty), and apply the edits with
+mapping import.
+
+Example:
+  ehrglot mapping export --format xlsx --output mappings.xlsx`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(files) == 0 {
+				loader, err := newLoader(schemaDir)
+				if err != nil {
+					return err
+				}
+				mappings, err := loader.LoadMappings(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to load mappings: %w", err)
+				}
+				for _, m := range mappings {
+					files = append(files, m.SourceFile)
+				}
+			}
+			if len(files) == 0 {
+				return fmt.Errorf("no mappings found in %s", schemaDir)
+			}
+
+			var rows []sheet.Row
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read mapping: %w", err)
+				}
+				fileRows, err := sheet.Rows(file, data)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, fileRows...)
+			}
+
+			var b bytes.Buffer
+			if err := sheet.Write(&b, format, rows); err != nil {
+				return err
+			}
+			if output == "" {
+				output = "mappings." + format
+			}
+			if err := os.WriteFile(output, b.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			fmt.Printf("Wrote %d field mappings of %d mapping files to %s\n", len(rows), len(files), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringSliceVarP(&files, "mapping", "m", nil, "Mapping files to export (default all in the schema directory)")
+	cmd.Flags().StringVar(&format, "format", sheet.XLSX, "Spreadsheet format ("+strings.Join(sheet.Formats, ", ")+")")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write (default mappings.<format>)")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(sheet.Formats, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func mappingImportCmd() *cobra.Command {
+	var (
+		input  string
+		format string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "import",
+		Short:        "Apply field mapping edits from a spreadsheet",
+		SilenceUsage: true,
+		Long: `Reads a spreadsheet written by mapping export and makes each mapping file it
+names hold the field mappings of its rows, in row order. Rows keep the
+target_context, value_mapping, and comments of the field mapping their #
+column numbers, and only the values that changed are rewritten; field
+mappings no row numbers are removed, and rows with an empty # are added.
+Files whose rows did not change are left untouched, and files the
+spreadsheet does not name are not read. Every file is checked before any is
+written.
+
+Example:
+  ehrglot mapping import --input mappings.xlsx --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				format = strings.TrimPrefix(filepath.Ext(input), ".")
+			}
+			f, err := os.Open(input)
+			if err != nil {
+				return fmt.Errorf("failed to open spreadsheet: %w", err)
+			}
+			defer f.Close()
+			info, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			rows, err := sheet.Read(f, info.Size(), format)
+			if err != nil {
+				return fmt.Errorf("%s: %w", input, err)
+			}
+
+			var files []string
+			byFile := make(map[string][]sheet.Row)
+			for _, row := range rows {
+				if _, ok := byFile[row.Mapping]; !ok {
+					files = append(files, row.Mapping)
+				}
+				byFile[row.Mapping] = append(byFile[row.Mapping], row)
+			}
+			updates := make(map[string][]byte)
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read mapping: %w", err)
+				}
+				updated, changed, err := sheet.Apply(file, data, byFile[file])
+				if err != nil {
+					return err
+				}
+				if changed {
+					updates[file] = updated
+				}
+			}
+
+			verb := "Updated"
+			if dryRun {
+				verb = "Would update"
+			}
+			for _, file := range files {
+				data, ok := updates[file]
+				if !ok {
+					continue
+				}
+				if !dryRun {
+					if err := os.WriteFile(file, data, 0644); err != nil {
+						return fmt.Errorf("failed to write %s: %w", file, err)
+					}
+				}
+				fmt.Printf("%s %s\n", verb, file)
+			}
+			fmt.Printf("%s %d of %d mapping files\n", verb, len(updates), len(files))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&input, "input", "i", "", "Spreadsheet to import")
+	cmd.Flags().StringVar(&format, "format", "", "Spreadsheet format ("+strings.Join(sheet.Formats, ", ")+"; default from the file extension)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the files that would change without writing them")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(sheet.Formats, cobra.ShellCompDirectiveNoFileComp))
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+// targetIssues validates an encoded resource against the target schema.
+func targetIssues(v *validate.Validator, data []byte) []string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var record map[string]any
+	if err := dec.Decode(&record); err != nil {
+		return []string{err.Error()}
+	}
+	var issues []string
+	for _, issue := range v.Record(record) {
+		issues = append(issues, issue.String())
+	}
+	return issues
+}
+
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Printf("%s:\n", title)
+	for _, item := range items {
+		fmt.Printf("  %s\n", item)
+	}
+}
+
+func printNames(title string, names []string) {
+	if len(names) > 0 {
+		fmt.Printf("%s: %s\n", title, strings.Join(names, ", "))
+	}
+}
+
+func mappingSuggestCmd() *cobra.Command {
+	var (
+		source        string
+		target        string
+		output        string
+		minConfidence float64
+		force         bool
+		ai            bool
+		dryRun        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "suggest",
+		Aliases:      []string{"draft"},
+		Short:        "Draft a mapping from the names and types of fields",
+		SilenceUsage: true,
+		Long: `Proposes a field mapping for each target field that a column of the source
+schema fits, and writes them as a draft mapping for review. A column fits by
+how alike its name is to the target path, after mapping healthcare synonyms
+such as dob to birth and sex to gender, and by how well its type fits, such
+as a date for a dateTime. Each column is proposed once, best fits first.
+
+A comment above each field mapping gives its confidence from 0 to 1, the
+product of the name and type ratings it lists, and the header lists the
+required target fields and the columns left unmapped. The draft goes to
+standard output unless --output is set; use ehrglot map to go through the
+fields interactively instead.
+
+With --ai, the language model that the ai section of the configuration
+names proposes the field mappings and their transforms instead, with its
+confidence and reason above each. It is sent the names, types, and
+descriptions of the source columns and target fields and the names of the
+built-in transforms, never data; --dry-run prints that prompt instead of
+sending it. Proposals of columns, targets, or transforms that do not exist
+are dropped with a warning. Drafting with a model is off unless ai.url and
+ai.model are set, so that mappings are only sent to a model run where the
+project allows:
+
+  ai:
+    provider: openai   # or ollama
+    url: http://localhost:8000/v1
+    model: llama3
+    token: ${LLM_TOKEN}
+
+Example:
+  ehrglot mapping suggest --source custom/data_warehouse --target Patient
+  ehrglot mapping suggest --source ehr/PROVIDER --target Practitioner --min-confidence 0.7 -o schemas/ehr/practitioner_mapping.yaml
+  ehrglot mapping draft --ai --source custom/data_warehouse --target Patient`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if minConfidence < 0 || minConfidence > 1 {
+				return fmt.Errorf("--min-confidence %v is not between 0 and 1", minConfidence)
+			}
+			if dryRun && !ai {
+				return fmt.Errorf("--dry-run needs --ai")
+			}
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			src, err := validate.Find(schemas, source)
+			if err != nil {
+				return err
+			}
+			dst, err := validate.Find(schemas, target)
+			if err != nil {
+				return err
+			}
+			path := output
+			if path == "" {
+				path = filepath.Join(schemaDir, src.Namespace, strings.ToLower(dst.GetName())+"_mapping.yaml")
+			}
+
+			var data []byte
+			count := 0
+			if ai {
+				if dryRun {
+					fmt.Printf("%s\n\n%s", assist.System, assist.Prompt(schemas, src, dst))
+					return nil
+				}
+				cfg, err := loadConfig()
+				if err != nil {
+					return err
+				}
+				provider, err := assist.New(cfg.AI)
+				if err != nil {
+					return err
+				}
+				proposals, notes, err := assist.Propose(cmd.Context(), provider, schemas, src, dst)
+				if err != nil {
+					return err
+				}
+				for _, note := range notes {
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+				}
+				var mappings []suggest.Mapping
+				for _, p := range proposals {
+					if p.Confidence < minConfidence {
+						continue
+					}
+					comment := fmt.Sprintf("confidence %.2f", p.Confidence)
+					if p.Reason != "" {
+						comment += ": " + p.Reason
+					}
+					mappings = append(mappings, suggest.Mapping{FieldMapping: p.FieldMapping, Comment: comment})
+				}
+				header := fmt.Sprintf(`Drafted by ehrglot mapping draft --ai with %s from %s/%s to %s.
+Review each field mapping; the comment above it gives the confidence of the
+model from 0 to 1 and its reason.`, cfg.AI.Model, src.Namespace, src.GetName(), dst.GetName())
+				data, err = suggest.Write(header, src, dst, mappings)
+				if err != nil {
+					return fmt.Errorf("failed to encode %s: %w", path, err)
+				}
+				count = len(mappings)
+			} else {
+				proposals := suggest.Propose(schemas, src, dst, minConfidence)
+				data, err = suggest.Draft(src, dst, proposals)
+				if err != nil {
+					return fmt.Errorf("failed to encode %s: %w", path, err)
+				}
+				count = len(proposals)
+			}
+			if err := schema.Validate(path, data, true); err != nil {
+				return err
+			}
+
+			if output == "" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+			if _, err := os.Stat(output); err == nil && !force {
+				return fmt.Errorf("%s already exists (use --force to overwrite it)", output)
+			}
+			if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			fmt.Printf("Wrote %d proposed field mappings to %s\n", count, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVar(&source, "source", "", "Source schema whose fields are the columns (Name or namespace/Name)")
+	cmd.Flags().StringVar(&target, "target", "", "Target resource (Name or namespace/Name)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Mapping file to write (default standard output)")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0.5, "Lowest confidence, from 0 to 1, of proposed field mappings")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing --output file")
+	cmd.Flags().BoolVar(&ai, "ai", false, "Ask the configured language model for the field mappings")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "With --ai, print the prompt instead of sending it")
+	cmd.MarkFlagRequired("source")
+	cmd.MarkFlagRequired("target")
+	cmd.RegisterFlagCompletionFunc("source", completeSchemas)
+	cmd.RegisterFlagCompletionFunc("target", completeSchemas)
+
+	return cmd
+}
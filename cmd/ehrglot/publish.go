@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/publish"
+	"github.com/konzy/ehrglot/pkg/registry"
+	"github.com/konzy/ehrglot/pkg/runner"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func publishCmd() *cobra.Command {
+	var (
+		lang   string
+		repo   string
+		dir    string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "publish",
+		Short:        "Build the generated package and upload it to a repository",
+		SilenceUsage: true,
+		Long: `Generates code with a package manifest (as generate --package does),
+builds it, and uploads it with the language's tooling: python -m build and
+twine for PyPI-compatible indexes, npm publish for npm registries, and
+mvn deploy or gradle publish, as languages.<lang>.build selects, for Maven
+repositories.
+
+The repository URL comes from --repo or languages.<lang>.publish.url.
+Credentials come from languages.<lang>.publish.username and password, which
+may reference environment variables as ${NAME}, or else from
+` + publish.UsernameEnv + ` and ` + publish.PasswordEnv + `.
+
+With --profile, only the schemas of a profile in ehrglot.yaml are published,
+without the fields its strip_pii level leaves out, under the package name
+the profile sets.
+
+Example:
+  ehrglot publish --lang python --repo https://upload.pypi.org/legacy/
+  ehrglot publish --lang ts --profile mobile`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			name, err := generator.Canonical(lang)
+			if err != nil {
+				return err
+			}
+
+			target := cfg.Language(name).Publish
+			if repo != "" {
+				target.URL = repo
+			}
+			plan, err := publish.New(name, cfg.BuildTool(name), target)
+			if err != nil {
+				return err
+			}
+
+			loader := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays())
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+			if cfg, schemas, mappings, err = applyProfile(cfg, profile, schemas, mappings); err != nil {
+				return err
+			}
+
+			if dir == "" {
+				dir, err = os.MkdirTemp("", "ehrglot-publish-")
+				if err != nil {
+					return fmt.Errorf("failed to create work directory: %w", err)
+				}
+				defer os.RemoveAll(dir)
+			}
+			notes, err := runner.Render(cmd.Context(), name, cfg, schemas, mappings, dir, true)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+			}
+
+			if dryRun {
+				for _, c := range plan.Commands {
+					fmt.Println(c)
+				}
+				return nil
+			}
+			if err := plan.Run(dir, os.Stdout); err != nil {
+				return fmt.Errorf("failed to publish: %w", err)
+			}
+
+			info := cfg.PackageInfo(name)
+			fmt.Printf("Published %s %s to %s\n", info.Name, info.Version, target.URL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&lang, "lang", "l", "python", "Target language ("+strings.Join(publish.Languages, ", ")+")")
+	cmd.RegisterFlagCompletionFunc("lang", completeLanguages(publish.Languages))
+	cmd.Flags().StringVar(&repo, "repo", "", "Repository URL, overriding languages.<lang>.publish.url")
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&dir, "output", "o", "", "Build directory (default: a temporary directory)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Generate the package and print the commands without running them")
+	cmd.Flags().StringVar(&profile, "profile", "", "Publish only the schemas of this configured profile, stripped of its PII")
+	cmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+
+	cmd.AddCommand(publishRegistryCmd("avro", registry.Avro))
+	cmd.AddCommand(publishRegistryCmd("json-schema", registry.JSONSchema))
+	return cmd
+}
+
+// publishRegistryCmd registers schemas of format in a schema registry.
+func publishRegistryCmd(use, format string) *cobra.Command {
+	var (
+		registryURL   string
+		compatibility string
+		oldDir        string
+		namespaces    []string
+		dryRun        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          use,
+		Short:        "Register " + use + " subjects in a schema registry",
+		SilenceUsage: true,
+		Long: `Converts each schema to ` + use + ` and registers it in a Confluent-compatible
+schema registry as the subject namespace.Name, after setting the subject's
+compatibility mode when one is configured: --compatibility, or else
+namespaces.<name>.compatibility or registry.compatibility. Schemas and
+datatypes a schema refers to are included in its subject. Registering an
+unchanged schema again returns its existing id.
+
+With --old, the schemas are first compared with that directory, the version
+last published, and nothing is registered when a change breaks the
+compatibility mode of its namespace, as ehrglot diff reports it.
+
+The registry comes from --registry-url or registry.url, and credentials
+from registry.username and password, which may reference environment
+variables as ${NAME}.
+
+Example:
+  ehrglot publish ` + use + ` --registry-url http://localhost:8081 --compatibility BACKWARD`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			target := cfg.Registry
+			if registryURL != "" {
+				target.URL = registryURL
+			}
+			if compatibility != "" {
+				compatibility = strings.ToUpper(compatibility)
+				if !contains(config.CompatibilityModes, compatibility) {
+					return fmt.Errorf("unknown --compatibility %q (want %s)", compatibility, strings.Join(config.CompatibilityModes, ", "))
+				}
+			}
+			modeOf := func(namespace string) string {
+				if compatibility != "" {
+					return compatibility
+				}
+				return cfg.Compatibility(namespace)
+			}
+
+			schemas, err := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays()).LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			if oldDir != "" {
+				old, err := schema.NewLoader(oldDir).WithOverlays(cfg.Overlays()).LoadAll(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to load old schemas: %w", err)
+				}
+				changes, err := diff.Compare(old, schemas)
+				if err != nil {
+					return err
+				}
+				if err := diff.Check(changes, modeOf); err != nil {
+					return err
+				}
+			}
+			if schemas, err = applyEvents(cfg, schemas); err != nil {
+				return err
+			}
+			subjects, err := registry.Subjects(schemas, format, registry.OptionsFor(cfg))
+			if err != nil {
+				return err
+			}
+			if len(namespaces) > 0 {
+				var selected []registry.Subject
+				for _, sub := range subjects {
+					namespace, _, _ := strings.Cut(sub.Name, ".")
+					if contains(namespaces, namespace) {
+						selected = append(selected, sub)
+					}
+				}
+				subjects = selected
+			}
+
+			if dryRun {
+				for _, sub := range subjects {
+					fmt.Printf("%s\n%s\n\n", sub.Name, sub.Schema)
+				}
+				return nil
+			}
+			client, err := registry.NewClient(target)
+			if err != nil {
+				return err
+			}
+			for _, sub := range subjects {
+				namespace, _, _ := strings.Cut(sub.Name, ".")
+				if mode := modeOf(namespace); mode != "" {
+					if err := client.SetCompatibility(cmd.Context(), sub.Name, mode); err != nil {
+						return fmt.Errorf("failed to set compatibility of %s: %w", sub.Name, err)
+					}
+				}
+				id, err := client.Register(cmd.Context(), sub)
+				if err != nil {
+					return fmt.Errorf("failed to register %s: %w", sub.Name, err)
+				}
+				fmt.Printf("Registered %s (id %d)\n", sub.Name, id)
+			}
+			fmt.Printf("Registered %d %s subjects in %s\n", len(subjects), use, target.URL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVar(&registryURL, "registry-url", "", "Schema registry URL, overriding registry.url")
+	cmd.Flags().StringVar(&compatibility, "compatibility", "", "Compatibility mode to set on each subject, overriding registry.compatibility")
+	cmd.Flags().StringVar(&oldDir, "old", "", "Schema directory of the last published version, to check compatibility against")
+	cmd.Flags().StringSliceVarP(&namespaces, "namespace", "n", nil, "Only register these namespaces")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the subjects and their schemas without registering them")
+	cmd.RegisterFlagCompletionFunc("compatibility", cobra.FixedCompletions(config.CompatibilityModes, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces)
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/konzy/ehrglot/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+func scaffoldCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Write starting points for code to edit",
+	}
+
+	cmd.AddCommand(scaffoldPipelineCmd())
+	return cmd
+}
+
+func scaffoldPipelineCmd() *cobra.Command {
+	var (
+		orchestrator string
+		dir          string
+		force        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "pipeline",
+		Short:        "Scaffold an orchestrator pipeline that runs the mappings",
+		SilenceUsage: true,
+		Long: `Writes an Airflow DAG per source system, or Dagster assets grouped by source
+system, that for each mapping extracts its source table, converts it to FHIR
+resources with ehrglot convert, and loads them into the target resource's
+table. Extraction reads CSV files from $EHRGLOT_DATA/<source_system> and
+loading only counts the resources until they are replaced with real
+connections, so the pipeline runs end to end from the start. The file is
+meant to be edited, so an existing one is kept unless --force is set.
+
+Example:
+  ehrglot scaffold pipeline --orchestrator dagster --output pipeline`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := scaffold.FileName(orchestrator)
+			if err != nil {
+				return err
+			}
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil && !force {
+				return fmt.Errorf("%s already exists (use --force to overwrite it)", path)
+			}
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+			if len(mappings) == 0 {
+				return fmt.Errorf("no mappings found in %s", schemaDir)
+			}
+
+			var b bytes.Buffer
+			if err := scaffold.Pipeline(&b, orchestrator, mappings, version); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write pipeline: %w", err)
+			}
+			fmt.Printf("Wrote %s pipeline for %d mappings to %s\n", orchestrator, len(mappings), path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVar(&orchestrator, "orchestrator", scaffold.Dagster, "Orchestrator to scaffold for (airflow, dagster)")
+	cmd.Flags().StringVarP(&dir, "output", "o", "pipeline", "Directory to write the pipeline to")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing pipeline")
+	cmd.RegisterFlagCompletionFunc("orchestrator", cobra.FixedCompletions(scaffold.Orchestrators, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/selftest"
+	"github.com/spf13/cobra"
+)
+
+func selftestCmd() *cobra.Command {
+	var (
+		langs string
+		keep  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:          "selftest",
+		Short:        "Compile generated code with the installed toolchains",
+		SilenceUsage: true,
+		Long: `Generates code from a built-in fixture schema set and compiles or
+type-checks it with the toolchains available on PATH (go build, mypy or
+python -m compileall, tsc, javac, cargo check, dotnet build). Languages
+without an installed toolchain are reported as skipped.
+
+Example:
+  ehrglot selftest --lang go,python,ts`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+
+			workDir, err := os.MkdirTemp("", "ehrglot-selftest-")
+			if err != nil {
+				return fmt.Errorf("failed to create work directory: %w", err)
+			}
+			if !keep {
+				defer os.RemoveAll(workDir)
+			}
+
+			results, err := selftest.Run(cmd.Context(), fixtures.Schemas(), strings.Split(langs, ","), cfg, workDir)
+			if err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, r := range results {
+				tool := r.Tool
+				if tool == "" {
+					tool = "-"
+				}
+				fmt.Printf("%-12s %-8s %s\n", r.Lang, r.Status, tool)
+				if r.Status != selftest.Passed && r.Output != "" {
+					for _, line := range strings.Split(r.Output, "\n") {
+						fmt.Printf("    %s\n", line)
+					}
+				}
+				if r.Status == selftest.Failed {
+					failed++
+				}
+			}
+			if keep {
+				fmt.Printf("Generated code kept in %s\n", workDir)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d languages produced invalid code", failed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&langs, "lang", "l", strings.Join(generator.Languages, ","), "Comma-separated target languages")
+	cmd.Flags().BoolVar(&keep, "keep", false, "Keep the generated code for inspection")
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konzy/ehrglot/pkg/stats"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func statsCmd() *cobra.Command {
+	var (
+		format     string
+		namespaces []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Report schema statistics per namespace",
+		Long: `Reports, per namespace, the number of resources and fields (nested children
+included), the share of optional fields, the number of fields with a PII
+level, the deepest nesting through children and referenced schemas, and the
+field types that resolve to no schema. Useful for scoping the work of
+supporting a vendor's model.
+
+Example:
+  ehrglot stats --namespace epic_clarity,cerner_millennium`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			var report []stats.Namespace
+			for _, n := range stats.Compute(schemas) {
+				if len(namespaces) == 0 || contains(namespaces, n.Name) {
+					report = append(report, n)
+				}
+			}
+			return writeStats(os.Stdout, format, report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format (table, json, yaml)")
+	cmd.Flags().StringSliceVarP(&namespaces, "namespace", "n", nil, "Only report these namespaces")
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces)
+
+	return cmd
+}
+
+func writeStats(w io.Writer, format string, report []stats.Namespace) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tRESOURCES\tFIELDS\tOPTIONAL\tPII\tDEPTH\tUNRESOLVED")
+		row := func(n stats.Namespace) {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%.0f%%\t%d\t%d\t%d\n", n.Name, n.Resources, n.Fields, 100*n.OptionalRatio(), n.PII, n.Depth, len(n.Unresolved))
+		}
+		for _, n := range report {
+			row(n)
+		}
+		total := stats.Total(report)
+		if len(report) > 1 {
+			row(total)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(total.Unresolved) > 0 {
+			fmt.Fprintln(w, "\nUnresolved references:")
+			for _, n := range report {
+				if len(n.Unresolved) > 0 {
+					fmt.Fprintf(w, "  %s: %s\n", n.Name, strings.Join(n.Unresolved, ", "))
+				}
+			}
+		}
+	case "json":
+		if report == nil {
+			report = []stats.Namespace{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unknown format %q (want table, json, or yaml)", format)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/konzy/ehrglot/pkg/validate"
+	"github.com/spf13/cobra"
+)
+
+// indexPattern matches list indexes in issue paths, so that issue counts
+// group name[0].family with name[1].family.
+var indexPattern = regexp.MustCompile(`\[\d+\]`)
+
+func validateDataCmd() *cobra.Command {
+	var (
+		resource  string
+		input     string
+		format    string
+		strict    bool
+		maxIssues int
+	)
+
+	cmd := &cobra.Command{
+		Use:          "validate-data",
+		Short:        "Validate data files against a schema",
+		SilenceUsage: true,
+		Long: `Validates the records of an NDJSON, JSON, or CSV file against a schema and
+reports each issue with the line, record, or row it was found in, followed by
+counts of records and of issues per field. JSON files may hold one record, an
+array of records, or a FHIR Bundle. Exits non-zero when any record is invalid,
+so bulk exports can be checked before they are loaded.
+
+Example:
+  ehrglot validate-data --resource Patient --input patients.ndjson`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loader, err := newLoader(schemaDir)
+			if err != nil {
+				return err
+			}
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			target, err := validate.Find(schemas, resource)
+			if err != nil {
+				return err
+			}
+			v, err := validate.New(schemas, target, strict)
+			if err != nil {
+				return err
+			}
+			if format == "" {
+				if format, err = validate.Format(input); err != nil {
+					return err
+				}
+			}
+
+			f, err := os.Open(input)
+			if err != nil {
+				return fmt.Errorf("failed to open input: %w", err)
+			}
+			defer f.Close()
+
+			records, invalid, shown := 0, 0, 0
+			counts := make(map[string]int)
+			err = v.Stream(f, format, func(r validate.Result) error {
+				records++
+				if len(r.Issues) == 0 {
+					return nil
+				}
+				invalid++
+				for _, issue := range r.Issues {
+					path := indexPattern.ReplaceAllString(issue.Path, "[]")
+					if path == "" {
+						path = "(record)"
+					}
+					counts[path]++
+					if maxIssues <= 0 || shown < maxIssues {
+						fmt.Printf("%s:%s: %s\n", input, r.Location, issue)
+					}
+					shown++
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", input, err)
+			}
+
+			if maxIssues > 0 && shown > maxIssues {
+				fmt.Printf("... %d more issues not shown\n", shown-maxIssues)
+			}
+			fmt.Printf("%d records: %d valid, %d invalid\n", records, records-invalid, invalid)
+			if len(counts) > 0 {
+				paths := make([]string, 0, len(counts))
+				for path := range counts {
+					paths = append(paths, path)
+				}
+				sort.Slice(paths, func(i, j int) bool {
+					if counts[paths[i]] != counts[paths[j]] {
+						return counts[paths[i]] > counts[paths[j]]
+					}
+					return paths[i] < paths[j]
+				})
+				fmt.Println("Issues by field:")
+				for _, path := range paths {
+					fmt.Printf("  %6d  %s\n", counts[path], path)
+				}
+				return fmt.Errorf("%d of %d records in %s are invalid", invalid, records, input)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&resource, "resource", "r", "", "Schema to validate against (Name or namespace/Name)")
+	cmd.Flags().StringVarP(&input, "input", "i", "", "Data file to validate")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "Input format (ndjson, json, csv; default: from the file extension)")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Also report properties the schema does not define")
+	cmd.Flags().IntVar(&maxIssues, "max-issues", 100, "Print at most this many issues (0 for all); counts cover every issue")
+	cmd.MarkFlagRequired("resource")
+	cmd.MarkFlagRequired("input")
+	cmd.RegisterFlagCompletionFunc("resource", completeSchemas)
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{validate.NDJSON, validate.JSON, validate.CSV}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
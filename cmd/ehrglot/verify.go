@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/konzy/ehrglot/pkg/provenance"
+	"github.com/konzy/ehrglot/pkg/runner"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+func verifyCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:          "verify",
+		Short:        "Check generated code against its schemas",
+		SilenceUsage: true,
+		Long: `Reads the ` + provenance.ManifestFile + ` that generate writes next to the
+generated code and reports files that were edited by hand or deleted. It then
+regenerates the code from the current schemas and configuration and reports
+files that are stale, newly generated, or no longer generated. Exits non-zero
+when any file diverges, so it can guard checked-in generated code in CI.
+
+Example:
+  ehrglot verify --schemas schemas --output ./generated`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := provenance.Read(dir)
+			if err != nil {
+				return err
+			}
+			problems, err := manifest.Check(dir)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				return err
+			}
+			loader := schema.NewLoader(schemaDir).WithOverlays(cfg.Overlays())
+			schemas, err := loader.LoadAll(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load schemas: %w", err)
+			}
+			mappings, err := loader.LoadMappings(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to load mappings: %w", err)
+			}
+
+			workDir, err := os.MkdirTemp("", "ehrglot-verify-")
+			if err != nil {
+				return fmt.Errorf("failed to create work directory: %w", err)
+			}
+			defer os.RemoveAll(workDir)
+
+			notes, err := runner.Render(cmd.Context(), manifest.Language, cfg, schemas, mappings, workDir, manifest.Package)
+			if err != nil {
+				return err
+			}
+			for _, note := range notes {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", note)
+			}
+			fresh, err := provenance.Build(workDir, version, manifest.Language, manifest.Package, schemas)
+			if err != nil {
+				return err
+			}
+			problems = append(problems, manifest.Drift(fresh)...)
+
+			if len(problems) == 0 {
+				fmt.Printf("%s code in %s matches its schemas\n", manifest.Language, dir)
+				return nil
+			}
+			for _, p := range problems {
+				fmt.Println(p)
+			}
+			if changed := manifest.ChangedSchemas(fresh); len(changed) > 0 {
+				fmt.Println("Schemas changed since generation:")
+				for _, name := range changed {
+					fmt.Printf("  - %s\n", name)
+				}
+			}
+			if manifest.Generator != version {
+				fmt.Printf("Code was generated by ehrglot %s; this is %s\n", manifest.Generator, version)
+			}
+			return fmt.Errorf("%d generated files in %s do not match their provenance", len(problems), dir)
+		},
+	}
+
+	cmd.Flags().StringVarP(&schemaDir, "schemas", "s", "schemas", "Schema directory path")
+	cmd.Flags().StringVarP(&dir, "output", "o", "./generated", "Generated code directory")
+
+	return cmd
+}
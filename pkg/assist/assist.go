@@ -0,0 +1,172 @@
+// Package assist drafts field mappings with a language model. The model is
+// told the names, types, and descriptions of the columns of a source schema
+// and the fields of a target resource, never data, and its proposals are
+// kept only where they name real columns, fields, and transforms.
+package assist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/convert"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/suggest"
+)
+
+// System is the system prompt that tells the model how to answer.
+const System = `You draft ehrglot mapping files, which map the columns of a source table to the fields of a FHIR resource.
+Answer with a JSON object only, in this form:
+{"field_mappings": [{"source": "COLUMN", "target": "path", "transform": "", "confidence": 0.9, "reason": "why they match"}]}
+Use only the listed source columns, target paths, and transforms; leave transform empty when the value needs no conversion.
+Map each target at most once and leave out targets no column fits. Confidence is from 0 to 1.`
+
+// Proposal is a field mapping a model proposed.
+type Proposal struct {
+	schema.FieldMapping
+	Confidence float64
+	Reason     string
+}
+
+// Prompt returns the prompt that asks for the field mappings from source to
+// target: the columns of source and the fields of target with their types
+// and the first lines of their descriptions, and the built-in transforms.
+func Prompt(schemas []schema.Schema, source, target schema.Schema) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source table %s/%s", source.Namespace, source.GetName())
+	writeDescription(&b, source.Description)
+	b.WriteString("Columns:\n")
+	for _, c := range suggest.Columns(source) {
+		writeField(&b, c.Name, c.Field)
+	}
+	fmt.Fprintf(&b, "\nTarget resource %s", target.GetName())
+	writeDescription(&b, target.Description)
+	b.WriteString("Target paths:\n")
+	for _, f := range suggest.Fields(schemas, target) {
+		writeField(&b, f.Path, f.Field)
+	}
+	b.WriteString("\nTransforms:\n")
+	for _, name := range convert.Transforms() {
+		fmt.Fprintf(&b, "- %s\n", name)
+	}
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, description string) {
+	if line, _, _ := strings.Cut(strings.TrimSpace(description), "\n"); line != "" {
+		fmt.Fprintf(b, ": %s", line)
+	}
+	b.WriteString("\n")
+}
+
+func writeField(b *strings.Builder, name string, f schema.Field) {
+	label := f.Type
+	if f.Required {
+		label += ", required"
+	}
+	fmt.Fprintf(b, "- %s (%s)", name, label)
+	if line, _, _ := strings.Cut(strings.TrimSpace(f.Description), "\n"); line != "" {
+		fmt.Fprintf(b, ": %s", line)
+	}
+	b.WriteString("\n")
+}
+
+// Propose asks p for the field mappings from source to target, and returns
+// those it can keep, in target field order, with notes on the rest.
+func Propose(ctx context.Context, p Provider, schemas []schema.Schema, source, target schema.Schema) ([]Proposal, []string, error) {
+	reply, err := p.Complete(ctx, System, Prompt(schemas, source, target))
+	if err != nil {
+		return nil, nil, err
+	}
+	return Parse(reply, suggest.Columns(source), suggest.Fields(schemas, target))
+}
+
+var index = regexp.MustCompile(`\[\d+\]`)
+
+// Parse reads the field mappings of a model reply, which may be wrapped in
+// a Markdown code fence. Column names and target paths are matched
+// ignoring case, and paths without list indexes too. Proposals of unknown
+// columns or targets, or of targets proposed before, are dropped, and
+// unknown transforms are left out; each is reported in the notes.
+func Parse(reply string, columns []suggest.Column, fields []suggest.Field) ([]Proposal, []string, error) {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, nil, fmt.Errorf("model reply holds no JSON object: %q", reply)
+	}
+	var answer struct {
+		FieldMappings []struct {
+			Source     string  `json:"source"`
+			Target     string  `json:"target"`
+			Transform  string  `json:"transform"`
+			Confidence float64 `json:"confidence"`
+			Reason     string  `json:"reason"`
+		} `json:"field_mappings"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &answer); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse model reply: %w", err)
+	}
+
+	chosen := make([]*Proposal, len(fields))
+	var notes []string
+	for _, a := range answer.FieldMappings {
+		column, ok := findColumn(columns, a.Source)
+		if !ok {
+			notes = append(notes, fmt.Sprintf("dropped %s -> %s: no column %s", a.Source, a.Target, a.Source))
+			continue
+		}
+		i, ok := findField(fields, a.Target)
+		if !ok {
+			notes = append(notes, fmt.Sprintf("dropped %s -> %s: no target path %s", a.Source, a.Target, a.Target))
+			continue
+		}
+		if chosen[i] != nil {
+			notes = append(notes, fmt.Sprintf("dropped %s -> %s: %s is mapped from %s already", a.Source, a.Target, fields[i].Path, chosen[i].Source))
+			continue
+		}
+		p := Proposal{
+			FieldMapping: schema.FieldMapping{Source: column, Target: fields[i].Path, Transform: strings.TrimSpace(a.Transform)},
+			Confidence:   min(max(a.Confidence, 0), 1),
+			Reason:       strings.Join(strings.Fields(a.Reason), " "),
+		}
+		if p.Transform != "" && !convert.IsTransform(p.Transform) {
+			notes = append(notes, fmt.Sprintf("left out unknown transform %s of %s -> %s", p.Transform, column, p.Target))
+			p.Transform = ""
+		}
+		chosen[i] = &p
+	}
+
+	var proposals []Proposal
+	for _, p := range chosen {
+		if p != nil {
+			proposals = append(proposals, *p)
+		}
+	}
+	return proposals, notes, nil
+}
+
+func findColumn(columns []suggest.Column, name string) (string, bool) {
+	for _, c := range columns {
+		if strings.EqualFold(c.Name, strings.TrimSpace(name)) {
+			return c.Name, true
+		}
+	}
+	return "", false
+}
+
+func findField(fields []suggest.Field, path string) (int, bool) {
+	path = strings.TrimSpace(path)
+	for i, f := range fields {
+		if strings.EqualFold(f.Path, path) {
+			return i, true
+		}
+	}
+	plain := index.ReplaceAllString(path, "")
+	for i, f := range fields {
+		if strings.EqualFold(index.ReplaceAllString(f.Path, ""), plain) {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,175 @@
+package assist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/suggest"
+)
+
+var (
+	columns = []suggest.Column{
+		{Name: "PAT_ID", Field: schema.Field{Name: "PAT_ID", Type: "string"}},
+		{Name: "BIRTH_DTTM", Field: schema.Field{Name: "BIRTH_DTTM", Type: "string"}},
+		{Name: "SEX_C", Field: schema.Field{Name: "SEX_C", Type: "string"}},
+	}
+	fields = []suggest.Field{
+		{Path: "id", Field: schema.Field{Name: "id", Type: "id"}},
+		{Path: "gender", Field: schema.Field{Name: "gender", Type: "code"}},
+		{Path: "birthDate", Field: schema.Field{Name: "birthDate", Type: "date"}},
+		{Path: "name[0].family", Field: schema.Field{Name: "family", Type: "string"}},
+	}
+)
+
+// fakeProvider replies with reply to every prompt.
+type fakeProvider struct {
+	reply  string
+	prompt string
+}
+
+func (p *fakeProvider) Complete(_ context.Context, system, prompt string) (string, error) {
+	p.prompt = prompt
+	return p.reply, nil
+}
+
+func TestParse(t *testing.T) {
+	reply := "Here is the draft:\n```json\n" + `{"field_mappings": [
+		{"source": "birth_dttm", "target": "birthDate", "transform": "datetime_to_fhir_date", "confidence": 0.9, "reason": "Birth\n date"},
+		{"source": "PAT_ID", "target": "id", "confidence": 1.5},
+		{"source": "SEX_C", "target": "gender", "transform": "epic_sex", "confidence": 0.7},
+		{"source": "PAT_NAME", "target": "name.family"},
+		{"source": "SEX_C", "target": "name.family", "confidence": 0.2},
+		{"source": "PAT_ID", "target": "id"},
+		{"source": "PAT_ID", "target": "telecom"}
+	]}` + "\n```"
+	got, notes, err := Parse(reply, columns, fields)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	want := []Proposal{
+		{FieldMapping: schema.FieldMapping{Source: "PAT_ID", Target: "id"}, Confidence: 1},
+		{FieldMapping: schema.FieldMapping{Source: "SEX_C", Target: "gender"}, Confidence: 0.7},
+		{FieldMapping: schema.FieldMapping{Source: "BIRTH_DTTM", Target: "birthDate", Transform: "datetime_to_fhir_date"}, Confidence: 0.9, Reason: "Birth date"},
+		{FieldMapping: schema.FieldMapping{Source: "SEX_C", Target: "name[0].family"}, Confidence: 0.2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+	wantNotes := []string{
+		"left out unknown transform epic_sex of SEX_C -> gender",
+		"dropped PAT_NAME -> name.family: no column PAT_NAME",
+		"dropped PAT_ID -> id: id is mapped from PAT_ID already",
+		"dropped PAT_ID -> telecom: no target path telecom",
+	}
+	if !reflect.DeepEqual(notes, wantNotes) {
+		t.Errorf("Parse() notes = %q, want %q", notes, wantNotes)
+	}
+
+	if _, _, err := Parse("I cannot help with that.", columns, fields); err == nil || !strings.Contains(err.Error(), "holds no JSON object") {
+		t.Errorf("Parse() error = %v, want no JSON object", err)
+	}
+}
+
+func TestPropose(t *testing.T) {
+	source := schema.Schema{Name: "PATIENT", Namespace: "ehr", Description: "One row per patient", Fields: []schema.Field{
+		{Name: "PAT_ID", Type: "string", Required: true, Description: "Patient id\nassigned at registration"},
+	}}
+	target := schema.Schema{Name: "Patient", Namespace: "fhir", Fields: []schema.Field{{Name: "id", Type: "id"}}}
+	p := &fakeProvider{reply: `{"field_mappings": [{"source": "PAT_ID", "target": "id", "confidence": 0.95}]}`}
+	got, _, err := Propose(context.Background(), p, []schema.Schema{source, target}, source, target)
+	if err != nil {
+		t.Fatalf("Propose() failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Source != "PAT_ID" || got[0].Target != "id" {
+		t.Errorf("Propose() = %+v", got)
+	}
+	for _, want := range []string{
+		"Source table ehr/PATIENT: One row per patient\n",
+		"- PAT_ID (string, required): Patient id\n",
+		"Target resource Patient\nTarget paths:\n- id (id)\n",
+		"- to_<resource>_reference\n",
+	} {
+		if !strings.Contains(p.prompt, want) {
+			t.Errorf("prompt lacks %q:\n%s", want, p.prompt)
+		}
+	}
+}
+
+func TestProviders(t *testing.T) {
+	for _, tc := range []struct {
+		provider, path, response string
+	}{
+		{config.AIProviderOpenAI, "/v1/chat/completions", `{"choices": [{"message": {"role": "assistant", "content": "{}"}}]}`},
+		{config.AIProviderOllama, "/api/chat", `{"message": {"role": "assistant", "content": "{}"}}`},
+	} {
+		t.Run(tc.provider, func(t *testing.T) {
+			var body map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tc.path || r.Header.Get("Authorization") != "Bearer secret" {
+					t.Errorf("request %s with %q", r.URL.Path, r.Header.Get("Authorization"))
+				}
+				data, _ := io.ReadAll(r.Body)
+				json.Unmarshal(data, &body)
+				io.WriteString(w, tc.response)
+			}))
+			defer server.Close()
+
+			t.Setenv("LLM_TOKEN", "secret")
+			url := server.URL
+			if tc.provider == config.AIProviderOpenAI {
+				url += "/v1/"
+			}
+			p, err := New(config.AI{Provider: tc.provider, URL: url, Model: "llama3", Token: "${LLM_TOKEN}"})
+			if err != nil {
+				t.Fatalf("New() failed: %v", err)
+			}
+			reply, err := p.Complete(context.Background(), "Be brief.", "Map PAT_ID.")
+			if err != nil {
+				t.Fatalf("Complete() failed: %v", err)
+			}
+			if reply != "{}" {
+				t.Errorf("Complete() = %q, want {}", reply)
+			}
+			messages, _ := json.Marshal(body["messages"])
+			if body["model"] != "llama3" || string(messages) != `[{"content":"Be brief.","role":"system"},{"content":"Map PAT_ID.","role":"user"}]` {
+				t.Errorf("request body = %v", body)
+			}
+		})
+	}
+}
+
+func TestProviderErrors(t *testing.T) {
+	for _, tc := range []struct {
+		provider, response, want string
+	}{
+		{config.AIProviderOpenAI, `{"error": {"message": "model llama3 not found"}}`, "POST /chat/completions: model llama3 not found (404 Not Found)"},
+		{config.AIProviderOllama, `{"error": "model \"llama3\" not found, try pulling it first"}`, `POST /api/chat: model "llama3" not found, try pulling it first (404 Not Found)`},
+		{config.AIProviderOllama, `not found`, "POST /api/chat: 404 Not Found"},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, tc.response)
+		}))
+		p, err := New(config.AI{Provider: tc.provider, URL: server.URL, Model: "llama3"})
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		_, err = p.Complete(context.Background(), "", "")
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("Complete() error = %v, want %s", err, tc.want)
+		}
+		server.Close()
+	}
+
+	if _, err := New(config.AI{}); err == nil || err.Error() != "no AI model configured (set ai.url and ai.model)" {
+		t.Errorf("New() error = %v, want no AI model configured", err)
+	}
+}
@@ -0,0 +1,147 @@
+package assist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+)
+
+// Provider asks a language model for its reply to a prompt, following the
+// instructions of a system prompt.
+type Provider interface {
+	Complete(ctx context.Context, system, prompt string) (string, error)
+}
+
+// New returns the provider for the endpoint cfg describes. The token may
+// reference environment variables as ${NAME}.
+func New(cfg config.AI) (Provider, error) {
+	if cfg.URL == "" || cfg.Model == "" {
+		return nil, fmt.Errorf("no AI model configured (set ai.url and ai.model)")
+	}
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid AI model URL %q: %w", cfg.URL, err)
+	}
+	e := endpoint{URL: strings.TrimSuffix(cfg.URL, "/"), Model: cfg.Model, Token: os.ExpandEnv(cfg.Token), HTTP: http.DefaultClient}
+	switch cfg.Provider {
+	case "", config.AIProviderOpenAI:
+		return openAI{e}, nil
+	case config.AIProviderOllama:
+		return ollama{e}, nil
+	}
+	return nil, fmt.Errorf("unknown AI provider %q (want openai or ollama)", cfg.Provider)
+}
+
+// endpoint is a model served over HTTP.
+type endpoint struct {
+	URL   string
+	Model string
+	Token string
+	HTTP  *http.Client
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// openAI speaks the OpenAI chat completions API.
+type openAI struct{ endpoint }
+
+func (p openAI) Complete(ctx context.Context, system, prompt string) (string, error) {
+	body := struct {
+		Model       string    `json:"model"`
+		Messages    []message `json:"messages"`
+		Temperature float64   `json:"temperature"`
+	}{p.Model, []message{{"system", system}, {"user", prompt}}, 0}
+	var result struct {
+		Choices []struct {
+			Message message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := p.post(ctx, "/chat/completions", body, &result); err != nil {
+		return "", err
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("model %s replied with no choices", p.Model)
+	}
+	return result.Choices[0].Message.Content, nil
+}
+
+// ollama speaks the Ollama chat API.
+type ollama struct{ endpoint }
+
+func (p ollama) Complete(ctx context.Context, system, prompt string) (string, error) {
+	body := struct {
+		Model    string         `json:"model"`
+		Messages []message      `json:"messages"`
+		Stream   bool           `json:"stream"`
+		Format   string         `json:"format"`
+		Options  map[string]any `json:"options"`
+	}{p.Model, []message{{"system", system}, {"user", prompt}}, false, "json", map[string]any{"temperature": 0}}
+	var result struct {
+		Message message `json:"message"`
+	}
+	if err := p.post(ctx, "/api/chat", body, &result); err != nil {
+		return "", err
+	}
+	return result.Message.Content, nil
+}
+
+// post sends body as JSON and decodes the response into result. Errors are
+// reported with the message the endpoint returned, which OpenAI-compatible
+// servers nest in an object and Ollama gives as a string.
+func (e endpoint) post(ctx context.Context, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.Token)
+	}
+	resp, err := e.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach AI model: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read AI model response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var modelErr struct {
+			Error json.RawMessage `json:"error"`
+		}
+		if json.Unmarshal(respBody, &modelErr) == nil && len(modelErr.Error) > 0 {
+			var nested struct {
+				Message string `json:"message"`
+			}
+			var message string
+			if json.Unmarshal(modelErr.Error, &nested) == nil && nested.Message != "" {
+				message = nested.Message
+			} else if json.Unmarshal(modelErr.Error, &message) != nil {
+				message = ""
+			}
+			if message != "" {
+				return fmt.Errorf("POST %s: %s (%s)", path, message, resp.Status)
+			}
+		}
+		return fmt.Errorf("POST %s: %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to decode AI model response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package audit defines the audit fields that ehrglot can add to generated
+// tables and models. Warehouse loads fill them in on every record.
+package audit
+
+import (
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Names of the audit fields.
+const (
+	IngestedAt   = "_ingested_at"
+	SourceSystem = "_source_system"
+	RecordHash   = "_record_hash"
+)
+
+// Fields are the audit fields, in generated order. They are optional so that
+// code that builds records before they are loaded need not set them.
+var Fields = []schema.Field{
+	{Name: IngestedAt, Type: "instant", Description: "When the record was loaded into the warehouse"},
+	{Name: SourceSystem, Type: "string", Description: "System the record was extracted from"},
+	{Name: RecordHash, Type: "string", Description: "Hash of the source record, for change detection"},
+}
+
+// Inject returns schemas with the audit fields appended to each schema's
+// fields. A schema that already declares a field under an audit field's
+// name, in any case convention and with or without the leading underscore,
+// as source_system or sourceSystem for _source_system, keeps its own
+// declaration, so that the two do not clash once generators convert names.
+func Inject(schemas []schema.Schema) []schema.Schema {
+	injected := make([]schema.Schema, len(schemas))
+	for i, s := range schemas {
+		declared := make(map[string]bool, len(s.Fields))
+		for _, f := range s.Fields {
+			declared[naming.Snake.Apply(f.Name)] = true
+		}
+		fields := append([]schema.Field(nil), s.Fields...)
+		for _, f := range Fields {
+			if !declared[naming.Snake.Apply(f.Name)] {
+				fields = append(fields, f)
+			}
+		}
+		s.Fields = fields
+		injected[i] = s
+	}
+	return injected
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{
+			name:   "appends audit fields",
+			fields: []string{"id"},
+			want:   []string{"id", IngestedAt, SourceSystem, RecordHash},
+		},
+		{
+			name:   "keeps declared audit field",
+			fields: []string{"id", IngestedAt},
+			want:   []string{"id", IngestedAt, SourceSystem, RecordHash},
+		},
+		{
+			name:   "without leading underscore",
+			fields: []string{"id", "source_system"},
+			want:   []string{"id", "source_system", IngestedAt, RecordHash},
+		},
+		{
+			name:   "in another case",
+			fields: []string{"id", "ingestedAt", "RecordHash"},
+			want:   []string{"id", "ingestedAt", "RecordHash", SourceSystem},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := schema.Schema{Name: "Load"}
+			for _, name := range tt.fields {
+				s.Fields = append(s.Fields, schema.Field{Name: name, Type: "string"})
+			}
+			injected := Inject([]schema.Schema{s})
+			var got []string
+			for _, f := range injected[0].Fields {
+				got = append(got, f.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Inject() fields = %q, want %q", got, tt.want)
+			}
+			if len(s.Fields) != len(tt.fields) {
+				t.Errorf("Inject() changed its argument: %d fields, want %d", len(s.Fields), len(tt.fields))
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+// Package browse implements an interactive terminal browser over schemas and
+// mappings, for reviewing models with people who do not read YAML.
+package browse
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// ANSI styles used when color is enabled.
+const (
+	bold   = "\x1b[1m"
+	dim    = "\x1b[2m"
+	red    = "\x1b[31m"
+	green  = "\x1b[32m"
+	yellow = "\x1b[33m"
+	purple = "\x1b[35m"
+	reset  = "\x1b[0m"
+)
+
+// maxResults caps the number of search results shown.
+const maxResults = 20
+
+// view is a screen of the browser: the namespace list, the resources of a
+// namespace, or the fields of a resource.
+type view struct {
+	namespace string
+	resource  *schema.Schema
+}
+
+// item is a numbered choice on the current screen.
+type item struct {
+	label string
+	view  view
+}
+
+// Browser navigates namespaces, resources, fields, and mappings.
+type Browser struct {
+	namespaces []string
+	schemas    map[string][]*schema.Schema       // namespace -> schemas sorted by name
+	mappings   map[string][]schema.SchemaMapping // target resource -> mappings
+
+	out   io.Writer
+	color bool
+	stack []view
+	items []item
+}
+
+// New creates a Browser over schemas and the mappings that target them.
+func New(schemas []schema.Schema, mappings []schema.SchemaMapping) *Browser {
+	b := &Browser{
+		schemas:  make(map[string][]*schema.Schema),
+		mappings: make(map[string][]schema.SchemaMapping),
+		stack:    []view{{}},
+	}
+	for i := range schemas {
+		s := &schemas[i]
+		if _, ok := b.schemas[s.Namespace]; !ok {
+			b.namespaces = append(b.namespaces, s.Namespace)
+		}
+		b.schemas[s.Namespace] = append(b.schemas[s.Namespace], s)
+	}
+	sort.Strings(b.namespaces)
+	for _, ns := range b.namespaces {
+		list := b.schemas[ns]
+		sort.SliceStable(list, func(i, j int) bool { return list[i].GetName() < list[j].GetName() })
+	}
+
+	for _, m := range mappings {
+		b.mappings[m.TargetResource] = append(b.mappings[m.TargetResource], m)
+	}
+	for _, list := range b.mappings {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].SourceSystem != list[j].SourceSystem {
+				return list[i].SourceSystem < list[j].SourceSystem
+			}
+			return list[i].SourceTable < list[j].SourceTable
+		})
+	}
+	return b
+}
+
+// Run reads commands from in and writes screens to out until the user quits
+// or in ends. Color enables ANSI styling, for terminals.
+func (b *Browser) Run(in io.Reader, out io.Writer, color bool) error {
+	b.out, b.color = out, color
+	b.render()
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		cmd := strings.TrimSpace(scanner.Text())
+		switch {
+		case cmd == "":
+			b.render()
+		case cmd == "q" || cmd == "quit":
+			return nil
+		case cmd == "?" || cmd == "help":
+			b.help()
+		case cmd == "b" || cmd == "..":
+			if len(b.stack) > 1 {
+				b.stack = b.stack[:len(b.stack)-1]
+			}
+			b.render()
+		case cmd == "m":
+			b.showMappings()
+		case strings.HasPrefix(cmd, "/"):
+			b.search(strings.TrimSpace(cmd[1:]))
+		default:
+			n, err := strconv.Atoi(cmd)
+			if err != nil || n < 1 || n > len(b.items) {
+				fmt.Fprintf(out, "Unknown choice %q; type ? for help\n", cmd)
+				continue
+			}
+			b.stack = append(b.stack, b.items[n-1].view)
+			b.render()
+		}
+	}
+}
+
+func (b *Browser) style(s, codes string) string {
+	if !b.color || codes == "" {
+		return s
+	}
+	return codes + s + reset
+}
+
+func (b *Browser) help() {
+	fmt.Fprintln(b.out, `Commands:
+  <number>  open the numbered item
+  b, ..     go back
+  /<text>   fuzzy search resources and fields
+  m         show the mappings of the current resource
+  ?         show this help
+  q         quit`)
+}
+
+func (b *Browser) current() view {
+	return b.stack[len(b.stack)-1]
+}
+
+// render prints the current screen and makes its entries selectable.
+func (b *Browser) render() {
+	v := b.current()
+	b.items = nil
+	switch {
+	case v.resource != nil:
+		b.renderResource(v.resource)
+	case v.namespace != "":
+		fmt.Fprintln(b.out, b.style(v.namespace, bold))
+		for _, s := range b.schemas[v.namespace] {
+			label := fmt.Sprintf("%-28s %3d fields", s.GetName(), countFields(s.Fields))
+			if n := len(b.mappings[s.GetName()]); n > 0 {
+				label += fmt.Sprintf(", %d mappings", n)
+			}
+			b.items = append(b.items, item{label: label, view: view{namespace: v.namespace, resource: s}})
+		}
+	default:
+		fmt.Fprintln(b.out, b.style("Namespaces", bold))
+		for _, ns := range b.namespaces {
+			label := fmt.Sprintf("%-28s %3d resources", ns, len(b.schemas[ns]))
+			b.items = append(b.items, item{label: label, view: view{namespace: ns}})
+		}
+	}
+	for i, it := range b.items {
+		fmt.Fprintf(b.out, "%3d  %s\n", i+1, it.label)
+	}
+}
+
+func (b *Browser) renderResource(s *schema.Schema) {
+	fmt.Fprintf(b.out, "%s/%s\n", s.Namespace, b.style(s.GetName(), bold))
+	if s.Description != "" {
+		fmt.Fprintln(b.out, b.style(strings.TrimSpace(s.Description), dim))
+	}
+	sources := b.fieldSources(s.GetName())
+	var printFields func(fields []schema.Field, depth int)
+	printFields = func(fields []schema.Field, depth int) {
+		for _, f := range fields {
+			name := strings.Repeat("  ", depth) + f.Name
+			if f.Required {
+				name += "*"
+			}
+			fmt.Fprintf(b.out, "  %-30s %-24s %s", name, f.Type, b.pii(f.PIILevel))
+			if f.Description != "" {
+				fmt.Fprintf(b.out, "  %s", strings.TrimSpace(f.Description))
+			}
+			fmt.Fprintln(b.out)
+			if depth == 0 {
+				for _, src := range sources[f.Name] {
+					fmt.Fprintf(b.out, "  %-30s %s\n", "", b.style("<- "+src, dim))
+				}
+			}
+			printFields(f.Children, depth+1)
+		}
+	}
+	printFields(s.Fields, 0)
+	if n := len(b.mappings[s.GetName()]); n > 0 {
+		fmt.Fprintf(b.out, "%d mappings target %s; type m to list them\n", n, s.GetName())
+	}
+}
+
+// pii renders a PII level as a fixed-width, colored cell.
+func (b *Browser) pii(level string) string {
+	level = strings.ToLower(level)
+	cell := fmt.Sprintf("%-8s", level)
+	switch level {
+	case "critical":
+		return b.style(cell, red+bold)
+	case "high":
+		return b.style(cell, purple)
+	case "medium":
+		return b.style(cell, yellow)
+	case "low":
+		return b.style(cell, green)
+	}
+	return b.style(cell, dim)
+}
+
+// fieldSources lists, by top-level target field, the source columns that
+// mappings of a resource populate it from. Constant targets are left out.
+func (b *Browser) fieldSources(resource string) map[string][]string {
+	sources := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, m := range b.mappings[resource] {
+		for _, fm := range m.FieldMappings {
+			if fm.Source == "" {
+				continue
+			}
+			field := fm.Target
+			if i := strings.IndexAny(field, ".["); i >= 0 {
+				field = field[:i]
+			}
+			src := fmt.Sprintf("%s %s.%s", m.SourceSystem, m.SourceTable, fm.Source)
+			if !seen[field+" "+src] {
+				seen[field+" "+src] = true
+				sources[field] = append(sources[field], src)
+			}
+		}
+	}
+	return sources
+}
+
+func (b *Browser) showMappings() {
+	s := b.current().resource
+	if s == nil {
+		fmt.Fprintln(b.out, "Open a resource to see its mappings")
+		return
+	}
+	mappings := b.mappings[s.GetName()]
+	if len(mappings) == 0 {
+		fmt.Fprintf(b.out, "No mappings target %s\n", s.GetName())
+		return
+	}
+	for _, m := range mappings {
+		fmt.Fprintf(b.out, "%s %s -> %s\n", b.style(m.SourceSystem, bold), m.SourceTable, m.TargetResource)
+		for _, fm := range m.FieldMappings {
+			src := fm.Source
+			if src == "" {
+				src = "(constant)"
+			}
+			line := fmt.Sprintf("  %-28s -> %s", src, fm.Target)
+			if fm.Transform != "" {
+				line += b.style("  ["+fm.Transform+"]", dim)
+			}
+			fmt.Fprintln(b.out, line)
+		}
+	}
+}
+
+// search lists the resources and fields that best match query.
+func (b *Browser) search(query string) {
+	if query == "" {
+		fmt.Fprintln(b.out, "Type /<text> to search")
+		return
+	}
+	type match struct {
+		item
+		score int
+	}
+	var matches []match
+	for _, ns := range b.namespaces {
+		for _, s := range b.schemas[ns] {
+			target := view{namespace: ns, resource: s}
+			name := ns + "/" + s.GetName()
+			if score := Score(query, name); score >= 0 {
+				matches = append(matches, match{item{label: name, view: target}, score})
+			}
+			for _, f := range s.Fields {
+				label := name + "." + f.Name
+				if score := Score(query, label); score >= 0 {
+					matches = append(matches, match{item{label: label, view: target}, score})
+				}
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].label < matches[j].label
+	})
+	if len(matches) == 0 {
+		fmt.Fprintf(b.out, "No matches for %q\n", query)
+		return
+	}
+	if len(matches) > maxResults {
+		matches = matches[:maxResults]
+	}
+
+	b.items = nil
+	for i, m := range matches {
+		b.items = append(b.items, m.item)
+		fmt.Fprintf(b.out, "%3d  %s\n", i+1, m.label)
+	}
+}
+
+// Score rates how well query fuzzily matches text: every query character
+// must appear in text in order, ignoring case. Consecutive characters and
+// characters at word starts score higher. Score is -1 when text does not
+// match.
+func Score(query, text string) int {
+	q := []rune(strings.ToLower(query))
+	t := []rune(text)
+	score, qi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if unicode.ToLower(t[ti]) != q[qi] {
+			run = 0
+			continue
+		}
+		score++
+		run++
+		score += run - 1
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) || (unicode.IsUpper(t[ti]) && unicode.IsLower(t[ti-1])) {
+			score += 3
+		}
+		qi++
+	}
+	if qi < len(q) {
+		return -1
+	}
+	return score
+}
+
+func countFields(fields []schema.Field) int {
+	n := len(fields)
+	for _, f := range fields {
+		n += countFields(f.Children)
+	}
+	return n
+}
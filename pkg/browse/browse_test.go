@@ -0,0 +1,53 @@
+package browse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestScore(t *testing.T) {
+	if Score("pat", "fhir_r4/Patient") <= Score("pat", "fhir_r4/Encounter.participant") {
+		t.Error("a word-start match should outscore a scattered one")
+	}
+	if Score("bd", "Patient.birthDate") < 0 {
+		t.Error("Score() should match word starts in camelCase names")
+	}
+	if Score("xyz", "Patient") != -1 {
+		t.Error("Score() matched characters that are absent")
+	}
+}
+
+func TestRun(t *testing.T) {
+	schemas := fixtures.Schemas()
+	mappings := []schema.SchemaMapping{{
+		SourceSystem:   "epic_clarity",
+		SourceTable:    "PATIENT",
+		TargetResource: schemas[0].GetName(),
+		FieldMappings:  []schema.FieldMapping{{Source: "PAT_ID", Target: schemas[0].Fields[0].Name + ".value"}},
+	}}
+
+	var out strings.Builder
+	in := strings.NewReader("1\n1\nm\nb\nb\n/" + schemas[0].GetName() + "\n1\nq\n")
+	if err := New(schemas, mappings).Run(in, &out, false); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		"Namespaces\n",
+		schemas[0].Namespace + "/" + schemas[0].GetName() + "\n",
+		"<- epic_clarity PATIENT.PAT_ID",
+		"1 mappings target " + schemas[0].GetName(),
+		"epic_clarity PATIENT -> " + schemas[0].GetName(),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Error("output is styled with color disabled")
+	}
+}
@@ -0,0 +1,214 @@
+// Package conceptmap reads concept maps, in the form of FHIR ConceptMaps, that
+// translate the codes of source code systems, such as local lab codes, to
+// codes of target systems, such as LOINC.
+package conceptmap
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Equivalences are the FHIR R4 ConceptMap equivalences of target codes.
+var Equivalences = []string{"relatedto", "equivalent", "equal", "wider", "subsumes", "narrower", "specializes", "inexact", "unmatched", "disjoint"}
+
+// DefaultEquivalence is the equivalence of targets that do not state one.
+const DefaultEquivalence = "equivalent"
+
+// names are the names concept maps may have, which generated translation
+// functions are named after.
+var names = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// ConceptMap is a concept map. Its YAML form follows FHIR ConceptMaps, so a
+// ConceptMap in JSON loads as is once it names the namespace whose generated
+// code translates by it.
+type ConceptMap struct {
+	Name        string  `yaml:"name"`
+	Namespace   string  `yaml:"namespace"`
+	Description string  `yaml:"description,omitempty"`
+	Groups      []Group `yaml:"group"`
+	// Path is the file the map was loaded from, for error messages.
+	Path string `yaml:"-"`
+}
+
+// Group maps codes of the Source code system to codes of the Target one.
+type Group struct {
+	Source   string    `yaml:"source"`
+	Target   string    `yaml:"target"`
+	Elements []Element `yaml:"element"`
+}
+
+// Element is a source code and the target codes it maps to.
+type Element struct {
+	Code    string   `yaml:"code"`
+	Display string   `yaml:"display,omitempty"`
+	Targets []Target `yaml:"target"`
+}
+
+// Target is a code a source code maps to, and how equivalent they are. The
+// code of unmatched targets, which say that a source code has no match, may
+// be empty.
+type Target struct {
+	Code        string `yaml:"code,omitempty"`
+	Display     string `yaml:"display,omitempty"`
+	Equivalence string `yaml:"equivalence,omitempty"`
+}
+
+// Load reads the concept map file at path.
+func Load(path string) (ConceptMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ConceptMap{}, fmt.Errorf("failed to read concept map: %w", err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return ConceptMap{}, fmt.Errorf("%s: %w", path, err)
+	}
+	m.Path = path
+	return m, nil
+}
+
+// Parse reads a concept map in YAML or JSON form. It reports a missing or
+// malformed name, a missing namespace, groups without a source or target
+// system, elements without a code or targets, codes mapped twice in a
+// group, unknown equivalences, and targets other than unmatched ones
+// without a code.
+func Parse(data []byte) (ConceptMap, error) {
+	var m ConceptMap
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return ConceptMap{}, err
+	}
+	if !names.MatchString(m.Name) {
+		return ConceptMap{}, fmt.Errorf("name %q must start with a letter and hold only letters, digits, - and _", m.Name)
+	}
+	if m.Namespace == "" {
+		return ConceptMap{}, fmt.Errorf("concept map %s has no namespace", m.Name)
+	}
+	for i := range m.Groups {
+		group := &m.Groups[i]
+		if group.Source == "" || group.Target == "" {
+			return ConceptMap{}, fmt.Errorf("group %d of %s needs a source and a target system", i+1, m.Name)
+		}
+		codes := make(map[string]bool)
+		for j := range group.Elements {
+			e := &group.Elements[j]
+			if e.Code == "" {
+				return ConceptMap{}, fmt.Errorf("element %d of group %d of %s has no code", j+1, i+1, m.Name)
+			}
+			if codes[e.Code] {
+				return ConceptMap{}, fmt.Errorf("group %d of %s maps %q twice", i+1, m.Name, e.Code)
+			}
+			codes[e.Code] = true
+			if len(e.Targets) == 0 {
+				return ConceptMap{}, fmt.Errorf("%s of %s has no targets", e.Code, m.Name)
+			}
+			for k := range e.Targets {
+				t := &e.Targets[k]
+				if t.Equivalence == "" {
+					t.Equivalence = DefaultEquivalence
+				}
+				if !contains(Equivalences, t.Equivalence) {
+					return ConceptMap{}, fmt.Errorf("%s of %s has unknown equivalence %q (want %s)", e.Code, m.Name, t.Equivalence, strings.Join(Equivalences, ", "))
+				}
+				if t.Code == "" && t.Equivalence != "unmatched" {
+					return ConceptMap{}, fmt.Errorf("%s of %s has a %s target without a code", e.Code, m.Name, t.Equivalence)
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Translation is a code that a concept map translates a source code to.
+type Translation struct {
+	System      string
+	Code        string
+	Display     string
+	Equivalence string
+}
+
+// Source is a code of a source system and the codes it translates to.
+type Source struct {
+	System       string
+	Code         string
+	Translations []Translation
+}
+
+// Sources returns the codes m translates, in map order, with the codes they
+// translate to. A code mapped in several groups of the same source system,
+// such as to different target systems, translates to the targets of all of
+// them. Unmatched and disjoint targets, which say that a code has no match,
+// are left out, as are the codes with no other targets.
+func (m ConceptMap) Sources() []Source {
+	var sources []Source
+	index := make(map[[2]string]int)
+	for _, group := range m.Groups {
+		for _, e := range group.Elements {
+			var translations []Translation
+			for _, t := range e.Targets {
+				if t.Equivalence == "unmatched" || t.Equivalence == "disjoint" {
+					continue
+				}
+				translations = append(translations, Translation{System: group.Target, Code: t.Code, Display: t.Display, Equivalence: t.Equivalence})
+			}
+			if len(translations) == 0 {
+				continue
+			}
+			key := [2]string{group.Source, e.Code}
+			if n, ok := index[key]; ok {
+				sources[n].Translations = append(sources[n].Translations, translations...)
+				continue
+			}
+			index[key] = len(sources)
+			sources = append(sources, Source{System: group.Source, Code: e.Code, Translations: translations})
+		}
+	}
+	return sources
+}
+
+// LoadAll reads the concept map files at paths and returns them grouped by
+// namespace, in file order. Each must name the namespace of one of schemas,
+// and the names of the maps of a namespace must differ in more than case,
+// dashes, and underscores.
+func LoadAll(paths []string, schemas []schema.Schema) (map[string][]ConceptMap, error) {
+	namespaces := make(map[string]bool)
+	for _, s := range schemas {
+		namespaces[s.Namespace] = true
+	}
+	groups := make(map[string][]ConceptMap)
+	for _, path := range paths {
+		m, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if !namespaces[m.Namespace] {
+			return nil, fmt.Errorf("%s: unknown namespace %s", path, m.Namespace)
+		}
+		for _, other := range groups[m.Namespace] {
+			if nameKey(other.Name) == nameKey(m.Name) {
+				return nil, fmt.Errorf("%s: concept map %s of namespace %s is also in %s", path, m.Name, m.Namespace, other.Path)
+			}
+		}
+		groups[m.Namespace] = append(groups[m.Namespace], m)
+	}
+	return groups, nil
+}
+
+// nameKey returns name as generated code names functions after it in any
+// case, so that names with the same key clash.
+func nameKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
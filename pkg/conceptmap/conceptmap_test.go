@@ -0,0 +1,104 @@
+package conceptmap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+)
+
+func TestSources(t *testing.T) {
+	m, err := Parse([]byte(fixtures.ConceptMap))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	sources := m.Sources()
+	// MISC is unmatched, so it has no translations
+	if len(sources) != 4 {
+		t.Fatalf("got %d sources, want 4", len(sources))
+	}
+	if got := sources[1].Translations; len(got) != 1 || got[0].Equivalence != DefaultEquivalence || got[0].System != "http://loinc.org" {
+		t.Errorf("K translations = %+v", got)
+	}
+	if got := sources[2].Translations; len(got) != 2 || got[1].Code != "2075-0" || got[1].Equivalence != "wider" {
+		t.Errorf("LYTES translations = %+v", got)
+	}
+	if got := sources[3]; got.System != "http://legacy.example/lab" || got.Code != "0012" {
+		t.Errorf("sources[3] = %+v", got)
+	}
+}
+
+func TestSourcesMergesGroups(t *testing.T) {
+	m, err := Parse([]byte(`name: merged
+namespace: fixtures
+group:
+  - source: local
+    target: http://loinc.org
+    element:
+      - {code: A, target: [{code: "1"}]}
+  - source: local
+    target: http://snomed.info/sct
+    element:
+      - {code: A, target: [{code: "2", equivalence: narrower}, {code: "3", equivalence: disjoint}]}
+`))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	sources := m.Sources()
+	if len(sources) != 1 || len(sources[0].Translations) != 2 || sources[0].Translations[1].System != "http://snomed.info/sct" {
+		t.Errorf("Sources() = %+v", sources)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	const group = "group:\n  - source: local\n    target: http://loinc.org\n    element:\n"
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"name: 1-lab\nnamespace: fixtures\n", `name "1-lab" must start with a letter and hold only letters, digits, - and _`},
+		{"name: lab\n", "concept map lab has no namespace"},
+		{"name: lab\nnamespace: fixtures\ngroup:\n  - source: local\n", "group 1 of lab needs a source and a target system"},
+		{"name: lab\nnamespace: fixtures\n" + group + "      - target: [{code: \"1\"}]\n", "element 1 of group 1 of lab has no code"},
+		{"name: lab\nnamespace: fixtures\n" + group + "      - code: A\n", "A of lab has no targets"},
+		{"name: lab\nnamespace: fixtures\n" + group + "      - {code: A, target: [{code: \"1\"}]}\n      - {code: A, target: [{code: \"2\"}]}\n", `group 1 of lab maps "A" twice`},
+		{"name: lab\nnamespace: fixtures\n" + group + "      - {code: A, target: [{code: \"1\", equivalence: same}]}\n", `A of lab has unknown equivalence "same" (want ` + strings.Join(Equivalences, ", ") + ")"},
+		{"name: lab\nnamespace: fixtures\n" + group + "      - {code: A, target: [{equivalence: wider}]}\n", "A of lab has a wider target without a code"},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.input))
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%q: got error %v, want %s", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	first := write("first.yaml", fixtures.ConceptMap)
+	clash := write("clash.yaml", "name: Lab_To_Loinc\nnamespace: fixtures\n")
+	unknown := write("unknown.yaml", "name: lab\nnamespace: nowhere\n")
+
+	maps, err := LoadAll([]string{first}, fixtures.Schemas())
+	if err != nil {
+		t.Fatalf("LoadAll() failed: %v", err)
+	}
+	if got := maps[fixtures.Namespace]; len(got) != 1 || got[0].Path != first {
+		t.Errorf("LoadAll() = %+v", maps)
+	}
+	if _, err := LoadAll([]string{first, clash}, fixtures.Schemas()); err == nil || err.Error() != clash+": concept map Lab_To_Loinc of namespace fixtures is also in "+first {
+		t.Errorf("clashing names: got error %v", err)
+	}
+	if _, err := LoadAll([]string{unknown}, fixtures.Schemas()); err == nil || err.Error() != unknown+": unknown namespace nowhere" {
+		t.Errorf("unknown namespace: got error %v", err)
+	}
+}
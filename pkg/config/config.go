@@ -0,0 +1,952 @@
+// Package config loads project configuration from ehrglot.yaml.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/paths"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the configuration file looked up in the working directory.
+const DefaultFile = "ehrglot.yaml"
+
+// Decimal modes select how schema decimals are represented in generated code.
+const (
+	// DecimalFloat maps decimals to binary floating point (the default).
+	DecimalFloat = "float"
+	// DecimalExact maps decimals to arbitrary-precision types.
+	DecimalExact = "exact"
+	// DecimalShopspring maps Go decimals to github.com/shopspring/decimal.
+	DecimalShopspring = "shopspring"
+)
+
+// Temporal policies select how schema instants and dates are represented in
+// generated code.
+const (
+	// InstantsUTC normalizes datetimes and instants to UTC (the default).
+	InstantsUTC = "utc"
+	// InstantsOffset keeps the UTC offset a datetime was recorded with.
+	InstantsOffset = "offset"
+	// DatesFull maps dates to calendar date types (the default).
+	DatesFull = "full"
+	// DatesPartial maps dates to a generated PartialDate type that also holds
+	// year (1970) and year-month (1970-03) precision, as FHIR permits.
+	DatesPartial = "partial"
+)
+
+// SQL dialects select the database the SQL generator writes DDL for.
+const (
+	// DialectPostgres writes PostgreSQL DDL (the default).
+	DialectPostgres = "postgres"
+	// DialectBigQuery writes Google BigQuery DDL.
+	DialectBigQuery = "bigquery"
+	// DialectSnowflake writes Snowflake DDL.
+	DialectSnowflake = "snowflake"
+	// DialectDelta writes Spark SQL DDL of Delta Lake tables.
+	DialectDelta = "delta"
+	// DialectIceberg writes Spark SQL DDL of Apache Iceberg tables.
+	DialectIceberg = "iceberg"
+)
+
+// Data-quality formats select the checks the SQL generator writes for each
+// table.
+const (
+	// QualityGreatExpectations writes a Great Expectations suite per table.
+	QualityGreatExpectations = "great_expectations"
+	// QualitySoda writes Soda checks per namespace.
+	QualitySoda = "soda"
+)
+
+// Migration formats wrap SQL DDL for database change management tools.
+const (
+	// MigrationsFlyway writes Flyway versioned migrations.
+	MigrationsFlyway = "flyway"
+	// MigrationsLiquibaseXML writes a Liquibase XML changelog.
+	MigrationsLiquibaseXML = "liquibase-xml"
+	// MigrationsLiquibaseYAML writes a Liquibase YAML changelog.
+	MigrationsLiquibaseYAML = "liquibase-yaml"
+)
+
+// Audit trails record every change to the rows of SQL tables in a
+// <table>_audit table.
+const (
+	// AuditTrailTriggers fills audit tables from row triggers, in Postgres.
+	AuditTrailTriggers = "triggers"
+	// AuditTrailChanges writes audit tables for change data capture tools
+	// to fill, and a stream that feeds them in Snowflake.
+	AuditTrailChanges = "changes"
+)
+
+// Build tools select the project files generate --package writes around
+// JVM output.
+const (
+	// BuildMaven writes a Maven pom.xml (the default for Java).
+	BuildMaven = "maven"
+	// BuildGradle writes a Gradle Kotlin DSL build (the default for Kotlin).
+	BuildGradle = "gradle"
+)
+
+// Module formats select the module system TypeScript is compiled to.
+const (
+	// ModuleCommonJS compiles to CommonJS modules (the default).
+	ModuleCommonJS = "commonjs"
+	// ModuleESM compiles to ECMAScript modules, whose relative imports name
+	// their .js files.
+	ModuleESM = "esm"
+)
+
+// Scala versions select the language version Scala is generated for.
+const (
+	// Scala2 generates Scala 2.13 code, which Scala 3 also compiles (the
+	// default).
+	Scala2 = "2"
+	// Scala3 generates Scala 3 code, with enums for fields of enumerated
+	// values and codecs derived with derives clauses.
+	Scala3 = "3"
+)
+
+// JSON codec libraries that Scala case classes derive codecs with.
+const (
+	CodecsCirce   = "circe"
+	CodecsZIOJSON = "zio-json"
+)
+
+// CompatibilityModes are the compatibility levels a schema registry can
+// enforce on the versions of a subject.
+var CompatibilityModes = []string{"BACKWARD", "BACKWARD_TRANSITIVE", "FORWARD", "FORWARD_TRANSITIVE", "FULL", "FULL_TRANSITIVE", "NONE"}
+
+// Config is the project configuration.
+type Config struct {
+	// Decimal is the default decimal mode for all languages.
+	Decimal string `yaml:"decimal,omitempty"`
+
+	// Temporal is the default temporal policy for all languages.
+	Temporal Temporal `yaml:"temporal,omitempty"`
+
+	// UCUM generates UCUM unit validation and conversion helpers alongside
+	// the built-in Quantity type.
+	UCUM bool `yaml:"ucum,omitempty"`
+
+	// Narrative generates helpers that render the fields of generated
+	// models as an XHTML summary, for the text.div of FHIR resources, in Go,
+	// Python, and TypeScript.
+	Narrative bool `yaml:"narrative,omitempty"`
+
+	// Bulk generates, for each namespace, a loader that reads a FHIR Bulk
+	// Data export manifest and streams its NDJSON files as generated models,
+	// in the languages whose models decode JSON.
+	Bulk bool `yaml:"bulk,omitempty"`
+
+	// Bundle generates, for each namespace, a builder that collects
+	// generated models into a FHIR transaction Bundle, in Go, TypeScript,
+	// and C#.
+	Bundle bool `yaml:"bundle,omitempty"`
+
+	// Resolver generates, for each namespace, an in-memory index of
+	// generated models by type and id that resolves the references between
+	// them and reports dangling ones, in Go, TypeScript, and C#.
+	Resolver bool `yaml:"resolver,omitempty"`
+
+	// Diff generates, for each model, a function that diffs two instances
+	// into an RFC 6902 JSON Patch, in Go, TypeScript, and C#.
+	Diff bool `yaml:"diff,omitempty"`
+
+	// TriState generates models that tell fields left out of a record from
+	// fields explicitly null, for FHIR PATCH and partial updates, in Go,
+	// Python, and TypeScript.
+	TriState bool `yaml:"tri_state,omitempty"`
+
+	// MessagePack generates compact binary codecs of each model, and
+	// round-trip tests of them, for caching records in Redis where the size
+	// and parse cost of JSON matter, in Go and TypeScript.
+	MessagePack bool `yaml:"msgpack,omitempty"`
+
+	// CloudEvents generates, for each namespace, CloudEvents types and
+	// constructors that wrap its resources in events typed
+	// org.ehrglot.<namespace>.<resource>.v1, in Go and TypeScript.
+	CloudEvents bool `yaml:"cloudevents,omitempty"`
+
+	// Client generates, for each namespace, a typed FHIR REST client for the
+	// resources it lists, in the languages whose models decode JSON.
+	Client Client `yaml:"client,omitempty"`
+
+	// Crosswalk is a CSV file mapping the local codes of schema fields to
+	// OMOP concepts and i2b2 paths. Go and Python get a lookup module per
+	// namespace, and SQL a dbt seed.
+	Crosswalk string `yaml:"crosswalk,omitempty"`
+
+	// ConceptMaps are ConceptMap files, each translating the codes of source
+	// code systems of a namespace to those of target systems, such as local
+	// lab codes to LOINC. Every language gets translation functions per
+	// namespace, and SQL a dbt seed.
+	ConceptMaps []string `yaml:"concept_maps,omitempty"`
+
+	// Redact masks fields whose pii_level is at least this level (low,
+	// medium, high, or critical) when generated models are printed, and
+	// generates helpers that return them masked for logging.
+	Redact string `yaml:"redact,omitempty"`
+
+	// Pseudonymize generates helpers that replace fields at or above a
+	// pii_level with stable HMAC tokens for research data releases, and SQL
+	// DDL for a re-identification table only one role may read.
+	Pseudonymize Pseudonymize `yaml:"pseudonymize,omitempty"`
+
+	// Matching configures the patient matching keys generated for schemas
+	// with fields that have a match role.
+	Matching Matching `yaml:"matching,omitempty"`
+
+	// Hashing generates canonical JSON and hashes of models that agree
+	// across Go and Python, for deduplicating repeated feed records, and
+	// optionally value equality by them.
+	Hashing Hashing `yaml:"hashing,omitempty"`
+
+	// Audit adds the audit fields _ingested_at, _source_system, and
+	// _record_hash to every generated table and model.
+	Audit bool `yaml:"audit,omitempty"`
+
+	// Header customizes the header written at the top of generated files.
+	Header Header `yaml:"header,omitempty"`
+
+	// Package describes the publishable package written by generate --package.
+	Package Package `yaml:"package,omitempty"`
+
+	// Lint configures the rules applied by ehrglot lint.
+	Lint Lint `yaml:"lint,omitempty"`
+
+	// Output is the directory ehrglot generate writes to when --output is
+	// not given. Like every path in the file, it is written with forward
+	// slashes on every OS.
+	Output string `yaml:"output,omitempty"`
+
+	// Languages holds per-language settings keyed by canonical language name.
+	Languages map[string]Language `yaml:"languages,omitempty"`
+
+	// Registry is the schema registry ehrglot publish avro and json-schema
+	// register subjects in.
+	Registry Registry `yaml:"registry,omitempty"`
+
+	// Namespaces holds per-namespace settings keyed by namespace.
+	Namespaces map[string]Namespace `yaml:"namespaces,omitempty"`
+
+	// AI is the language model ehrglot mapping draft --ai asks to propose
+	// field mappings. Drafting with a model is off unless it is set.
+	AI AI `yaml:"ai,omitempty"`
+
+	// Profiles are named subsets of the schemas, for packages published to
+	// less trusted consumers, that generate and publish --profile select.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	// Events wraps schemas in change-event envelopes, generated and
+	// registered alongside them.
+	Events Events `yaml:"events,omitempty"`
+}
+
+// EnvelopeDebezium is the change-event envelope of Debezium: op, ts_ms,
+// before, after, source, and transaction.
+const EnvelopeDebezium = "debezium"
+
+// Events selects the schemas that get change-event envelopes.
+type Events struct {
+	// Envelope is the shape of the envelopes: debezium. Empty generates none.
+	Envelope string `yaml:"envelope,omitempty"`
+
+	// Schemas are the schemas wrapped, by name or as namespace/name. Empty
+	// wraps every schema.
+	Schemas []string `yaml:"schemas,omitempty"`
+}
+
+// Profile trims the schemas generated for one consumer.
+type Profile struct {
+	// Schemas are the schemas generated, by name or as namespace/name, with
+	// the schemas of their namespaces that they use as types. Empty selects
+	// every schema.
+	Schemas []string `yaml:"schemas,omitempty"`
+
+	// StripPII leaves out the fields whose pii_level is at least this level
+	// (low, medium, high, or critical), and the field mappings to them.
+	StripPII string `yaml:"strip_pii,omitempty"`
+
+	// Package overrides the package settings of the project and of every
+	// language, so the trimmed package is published under its own name.
+	Package Package `yaml:"package,omitempty"`
+}
+
+// Namespace holds settings for one namespace of schemas.
+type Namespace struct {
+	// Compatibility is the compatibility mode, one of CompatibilityModes in
+	// any case, that ehrglot diff and publish enforce between versions of
+	// the namespace's schemas. It overrides registry.compatibility.
+	Compatibility string `yaml:"compatibility,omitempty"`
+
+	// Overlay is the vendor directory of the schema directory, such as
+	// epic_clarity or cerner_millennium, that backs the namespace: its
+	// schemas and mappings load into the namespace, over those of the
+	// namespace's own directory, so that generated code and mappings can
+	// name the namespace whichever vendor backs it.
+	Overlay string `yaml:"overlay,omitempty"`
+}
+
+// Lint severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+	// SeverityOff disables a rule.
+	SeverityOff = "off"
+)
+
+// Lint holds the settings of ehrglot lint.
+type Lint struct {
+	// Rules overrides the default severity of rules by rule ID.
+	Rules map[string]string `yaml:"rules,omitempty"`
+}
+
+// Header holds the settings of generated file headers. Template is a Go
+// text/template executed with the organization, license, ehrglot version,
+// source schema file, schema hash, and generation time.
+type Header struct {
+	Organization string `yaml:"organization,omitempty"`
+	License      string `yaml:"license,omitempty"`
+	Template     string `yaml:"template,omitempty"`
+}
+
+// Package names and versions a publishable package of generated code.
+type Package struct {
+	Name        string `yaml:"name,omitempty"`
+	Version     string `yaml:"version,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// Group is the Maven groupId; it defaults to Name.
+	Group string `yaml:"group,omitempty"`
+}
+
+// Language holds settings for one target language.
+type Language struct {
+	Naming Naming `yaml:"naming,omitempty"`
+
+	// Decimal overrides the project decimal mode for this language.
+	Decimal string `yaml:"decimal,omitempty"`
+
+	// Temporal overrides the project temporal policy for this language.
+	Temporal Temporal `yaml:"temporal,omitempty"`
+
+	// Module is the import path of the output directory, used by languages
+	// whose cross-package imports are absolute (Go), and as the path alias
+	// TypeScript imports other namespaces by.
+	Module string `yaml:"module,omitempty"`
+
+	// ModuleFormat is the module system TypeScript is compiled to: commonjs
+	// or esm. Only the TypeScript generator reads it.
+	ModuleFormat string `yaml:"module_format,omitempty"`
+
+	// Declarations writes TypeScript declarations (.d.ts) of the types
+	// alone, leaving out the runtime helpers other settings add. Only the
+	// TypeScript generator reads it.
+	Declarations bool `yaml:"declarations,omitempty"`
+
+	// ScalaVersion is the Scala version generated for: 2 or 3. Only the
+	// Scala generator reads it.
+	ScalaVersion string `yaml:"scala_version,omitempty"`
+
+	// Codecs derives JSON codecs for the generated types with circe or
+	// zio-json. Only the Scala generator reads it.
+	Codecs string `yaml:"codecs,omitempty"`
+
+	// Refined narrows the types of fields whose FHIR type constrains their
+	// values, such as positiveInt, with Iron refined types. It needs Scala
+	// 3. Only the Scala generator reads it.
+	Refined bool `yaml:"refined,omitempty"`
+
+	// Package overrides the project package settings for this language.
+	Package Package `yaml:"package,omitempty"`
+
+	// Publish is the repository ehrglot publish uploads this language's
+	// package to.
+	Publish Repository `yaml:"publish,omitempty"`
+
+	// Build is the build tool whose project generate --package writes, and
+	// ehrglot publish runs: maven or gradle. Only the Java and Kotlin
+	// generators read it.
+	Build string `yaml:"build,omitempty"`
+
+	// Output overrides the project output directory for this language.
+	Output string `yaml:"output,omitempty"`
+
+	// Dialect is the database SQL DDL is written for: postgres, bigquery,
+	// snowflake, or the lakehouse table formats delta and iceberg. Only the
+	// SQL generator reads it.
+	Dialect string `yaml:"dialect,omitempty"`
+
+	// TableProperties are added to the TBLPROPERTIES of delta and iceberg
+	// tables, overriding those the SQL generator sets itself.
+	TableProperties map[string]string `yaml:"table_properties,omitempty"`
+
+	// Migrations also wraps SQL DDL as flyway, liquibase-xml, or
+	// liquibase-yaml migrations. Only the SQL generator reads it.
+	Migrations string `yaml:"migrations,omitempty"`
+
+	// Quality also writes data-quality checks as great_expectations suites
+	// or soda checks. Only the SQL generator reads it.
+	Quality string `yaml:"quality,omitempty"`
+
+	// AuditTrail also writes a <table>_audit table per table, filled by
+	// triggers or change data capture. Only the SQL generator reads it.
+	AuditTrail string `yaml:"audit_trail,omitempty"`
+
+	// TenantColumn adds a column of this name, holding the tenant each
+	// record belongs to, to every table, and isolates tenants with row-level
+	// security or views. Only the SQL generator reads it.
+	TenantColumn string `yaml:"tenant_column,omitempty"`
+
+	// Flatten is how SQL tables store nested objects and lists unless
+	// their schema sets flatten: json, delimited, or tables. Only the SQL
+	// generator reads it.
+	Flatten string `yaml:"flatten,omitempty"`
+
+	// Terraform also writes the tables of the bigquery or snowflake dialect
+	// as Terraform resources, with the pii_level of columns as policy tags.
+	// Only the SQL generator reads it.
+	Terraform bool `yaml:"terraform,omitempty"`
+
+	// BQLoad also writes, in the bigquery dialect, the BigQuery schema of a
+	// table per resource that holds its FHIR NDJSON nested, and a bq load
+	// script that fills it. Only the SQL generator reads it.
+	BQLoad bool `yaml:"bq_load,omitempty"`
+
+	// Mongoose also writes a Mongoose schema and model per resource, for
+	// services that keep resources in MongoDB. Only the TypeScript generator
+	// reads it.
+	Mongoose bool `yaml:"mongoose,omitempty"`
+
+	// Format runs formatters over the generated code, in order: gofmt and
+	// goimports are built in, and other entries are commands run in the
+	// output directory, such as "ruff format ." or "prettier --write .".
+	// Formatters that are not installed are skipped.
+	Format []string `yaml:"format,omitempty"`
+}
+
+// Repository is an artifact repository that packages are published to.
+// Username and Password may reference environment variables as ${NAME}, so
+// that secrets stay out of the configuration file.
+type Repository struct {
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Registry is a Confluent-compatible schema registry. Username and Password
+// may reference environment variables as ${NAME}, as for Repository.
+type Registry struct {
+	URL      string `yaml:"url,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	// Compatibility is set on every subject before it is registered, one of
+	// CompatibilityModes; empty keeps the registry's default.
+	Compatibility string `yaml:"compatibility,omitempty"`
+}
+
+// AI providers name the APIs of language model endpoints.
+const (
+	// AIProviderOpenAI speaks the OpenAI chat completions API, which vLLM,
+	// llama.cpp, and LocalAI serve too (the default).
+	AIProviderOpenAI = "openai"
+	// AIProviderOllama speaks the Ollama chat API.
+	AIProviderOllama = "ollama"
+)
+
+// AI is a language model endpoint, meant to be one run on premises. Only
+// the names, types, and descriptions of schema fields are sent to it, never
+// data.
+type AI struct {
+	// Provider is the API the endpoint speaks, openai or ollama.
+	Provider string `yaml:"provider,omitempty"`
+	// URL is the base URL of the API, such as http://localhost:8000/v1 for
+	// openai or http://localhost:11434 for ollama.
+	URL string `yaml:"url,omitempty"`
+	// Model names the model to ask.
+	Model string `yaml:"model,omitempty"`
+	// Token is sent as a bearer token, if set. It may reference environment
+	// variables as ${NAME}, as for Repository.
+	Token string `yaml:"token,omitempty"`
+}
+
+// Client configures the generated SMART on FHIR clients.
+type Client struct {
+	// Resources names the schemas the clients search, read, and create.
+	// Namespaces that define none of them get no client.
+	Resources []string `yaml:"resources,omitempty"`
+}
+
+// Defaults of the pseudonymization settings.
+const (
+	// DefaultPseudonymKeyEnv is the environment variable generated helpers
+	// read the HMAC key of pseudonyms from.
+	DefaultPseudonymKeyEnv = "EHRGLOT_PSEUDONYM_KEY"
+	// DefaultReidentificationRole is the database role that may read the
+	// re-identification table.
+	DefaultReidentificationRole = "reidentification"
+)
+
+// Pseudonymize configures the generated pseudonymization helpers.
+type Pseudonymize struct {
+	// Level is the pii_level (low, medium, high, or critical) from which
+	// fields are pseudonymized. Empty generates no helpers.
+	Level string `yaml:"pii_level,omitempty"`
+	// KeyEnv names the environment variable that holds the HMAC key; it
+	// defaults to DefaultPseudonymKeyEnv.
+	KeyEnv string `yaml:"key_env,omitempty"`
+	// Role is the database role granted the re-identification table; it
+	// defaults to DefaultReidentificationRole.
+	Role string `yaml:"role,omitempty"`
+}
+
+// Phonetic encodings of the names in patient matching keys.
+const (
+	// PhoneticSoundex encodes names as American Soundex codes.
+	PhoneticSoundex = "soundex"
+	// PhoneticMetaphone encodes names as Metaphone codes.
+	PhoneticMetaphone = "metaphone"
+)
+
+// Matching configures the generated patient matching keys.
+type Matching struct {
+	// Phonetic encodes the names of demographic keys as soundex or
+	// metaphone codes, so that names spelled differently but sounding alike
+	// block together. Empty keeps the normalized names.
+	Phonetic string `yaml:"phonetic,omitempty"`
+}
+
+// Hash algorithms of canonical hashes.
+const (
+	// HashSHA256 hashes canonical JSON with SHA-256.
+	HashSHA256 = "sha256"
+	// HashSHA512 hashes canonical JSON with SHA-512.
+	HashSHA512 = "sha512"
+)
+
+// Hashing configures the generated canonical JSON and hashes.
+type Hashing struct {
+	// Algorithm is the hash of canonical JSON that canonical hashes take:
+	// sha256 or sha512. Empty generates no canonical JSON or hashes.
+	Algorithm string `yaml:"algorithm,omitempty"`
+	// Equality makes models equal when their canonical JSON is, instead of
+	// when each field is, and hashable by it in Python.
+	Equality bool `yaml:"equality,omitempty"`
+}
+
+// Temporal selects how instants and dates are represented.
+type Temporal struct {
+	// Instants is utc or offset.
+	Instants string `yaml:"instants,omitempty"`
+	// Dates is full or partial.
+	Dates string `yaml:"dates,omitempty"`
+}
+
+// Naming selects the case convention used for each kind of generated name.
+// Valid values are preserve, snake, camel, pascal, and lower; empty values
+// keep the generator's idiomatic default.
+type Naming struct {
+	Fields   string `yaml:"fields,omitempty"`
+	Types    string `yaml:"types,omitempty"`
+	Files    string `yaml:"files,omitempty"`
+	Packages string `yaml:"packages,omitempty"`
+}
+
+// Load reads the configuration at path. A missing file yields the default
+// (zero) configuration.
+func Load(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	if err := validateDecimal(c.Decimal, false); err != nil {
+		return fmt.Errorf("decimal: %w", err)
+	}
+	if err := c.Temporal.validate(); err != nil {
+		return fmt.Errorf("temporal: %w", err)
+	}
+	for rule, severity := range c.Lint.Rules {
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityNote, SeverityOff:
+		default:
+			return fmt.Errorf("lint.rules.%s: unknown severity %q (want error, warning, note, or off)", rule, severity)
+		}
+	}
+	switch c.Redact {
+	case "", "low", "medium", "high", "critical":
+	default:
+		return fmt.Errorf("redact: unknown pii_level %q (want low, medium, high, or critical)", c.Redact)
+	}
+	switch c.Pseudonymize.Level {
+	case "", "low", "medium", "high", "critical":
+	default:
+		return fmt.Errorf("pseudonymize.pii_level: unknown pii_level %q (want low, medium, high, or critical)", c.Pseudonymize.Level)
+	}
+	for _, name := range sortedKeys(c.Profiles) {
+		switch level := c.Profiles[name].StripPII; level {
+		case "", "low", "medium", "high", "critical":
+		default:
+			return fmt.Errorf("profiles.%s.strip_pii: unknown pii_level %q (want low, medium, high, or critical)", name, level)
+		}
+	}
+	switch c.Matching.Phonetic {
+	case "", PhoneticSoundex, PhoneticMetaphone:
+	default:
+		return fmt.Errorf("matching.phonetic: unknown phonetic encoding %q (want soundex or metaphone)", c.Matching.Phonetic)
+	}
+	switch c.Hashing.Algorithm {
+	case "", HashSHA256, HashSHA512:
+	default:
+		return fmt.Errorf("hashing.algorithm: unknown hash algorithm %q (want sha256 or sha512)", c.Hashing.Algorithm)
+	}
+	if c.Hashing.Equality && c.Hashing.Algorithm == "" {
+		return fmt.Errorf("hashing.equality: needs hashing.algorithm")
+	}
+	switch c.Events.Envelope {
+	case "", EnvelopeDebezium:
+	default:
+		return fmt.Errorf("events.envelope: unknown envelope %q (want %s)", c.Events.Envelope, EnvelopeDebezium)
+	}
+	if len(c.Events.Schemas) > 0 && c.Events.Envelope == "" {
+		return fmt.Errorf("events.schemas: no envelope is set")
+	}
+	if mode := c.Registry.Compatibility; mode != "" && !contains(CompatibilityModes, mode) {
+		return fmt.Errorf("registry.compatibility: unknown compatibility %q (want %s)", mode, strings.Join(CompatibilityModes, ", "))
+	}
+	backed := make(map[string]string)
+	for _, ns := range sortedKeys(c.Namespaces) {
+		n := c.Namespaces[ns]
+		if mode := n.Compatibility; mode != "" && !contains(CompatibilityModes, strings.ToUpper(mode)) {
+			return fmt.Errorf("namespaces.%s.compatibility: unknown compatibility %q (want %s)", ns, mode, strings.Join(CompatibilityModes, ", "))
+		}
+		if n.Overlay == "" {
+			continue
+		}
+		switch {
+		case ns == "fhir_r4" || ns == "schema_overrides" || n.Overlay == "fhir_r4" || n.Overlay == "schema_overrides":
+			return fmt.Errorf("namespaces.%s.overlay: fhir_r4 and schema_overrides cannot be overlaid or overlays", ns)
+		case n.Overlay == ns || strings.ContainsAny(n.Overlay, `/\`):
+			return fmt.Errorf("namespaces.%s.overlay: %q is not another directory of the schema directory", ns, n.Overlay)
+		case backed[n.Overlay] != "":
+			return fmt.Errorf("namespaces.%s.overlay: %s backs namespace %s already", ns, n.Overlay, backed[n.Overlay])
+		case c.Namespaces[n.Overlay].Overlay != "":
+			return fmt.Errorf("namespaces.%s.overlay: %s is overlaid itself", ns, n.Overlay)
+		}
+		backed[n.Overlay] = ns
+	}
+	switch c.AI.Provider {
+	case "", AIProviderOpenAI, AIProviderOllama:
+	default:
+		return fmt.Errorf("ai.provider: unknown provider %q (want openai or ollama)", c.AI.Provider)
+	}
+	if (c.AI.URL == "") != (c.AI.Model == "") {
+		return fmt.Errorf("ai: url and model are both needed")
+	}
+	for lang, l := range c.Languages {
+		if err := validateDecimal(l.Decimal, lang == "go" || lang == "golang"); err != nil {
+			return fmt.Errorf("languages.%s.decimal: %w", lang, err)
+		}
+		if err := l.Temporal.validate(); err != nil {
+			return fmt.Errorf("languages.%s.temporal: %w", lang, err)
+		}
+		switch l.Dialect {
+		case "", DialectPostgres, DialectBigQuery, DialectSnowflake:
+			if len(l.TableProperties) > 0 {
+				return fmt.Errorf("languages.%s.table_properties: need the delta or iceberg dialect", lang)
+			}
+		case DialectDelta, DialectIceberg:
+		default:
+			return fmt.Errorf("languages.%s.dialect: unknown SQL dialect %q (want postgres, bigquery, snowflake, delta, or iceberg)", lang, l.Dialect)
+		}
+		switch l.Migrations {
+		case "", MigrationsFlyway, MigrationsLiquibaseXML, MigrationsLiquibaseYAML:
+		default:
+			return fmt.Errorf("languages.%s.migrations: unknown migration format %q (want flyway, liquibase-xml, or liquibase-yaml)", lang, l.Migrations)
+		}
+		switch l.Quality {
+		case "", QualityGreatExpectations, QualitySoda:
+		default:
+			return fmt.Errorf("languages.%s.quality: unknown data-quality format %q (want great_expectations or soda)", lang, l.Quality)
+		}
+		switch l.AuditTrail {
+		case "", AuditTrailChanges:
+		case AuditTrailTriggers:
+			if l.Dialect != "" && l.Dialect != DialectPostgres {
+				return fmt.Errorf("languages.%s.audit_trail: triggers need the postgres dialect (use changes for %s)", lang, l.Dialect)
+			}
+		default:
+			return fmt.Errorf("languages.%s.audit_trail: unknown audit trail %q (want triggers or changes)", lang, l.AuditTrail)
+		}
+		switch l.Build {
+		case "", BuildMaven, BuildGradle:
+		default:
+			return fmt.Errorf("languages.%s.build: unknown build tool %q (want maven or gradle)", lang, l.Build)
+		}
+		switch l.ModuleFormat {
+		case "", ModuleCommonJS, ModuleESM:
+		default:
+			return fmt.Errorf("languages.%s.module_format: unknown module format %q (want commonjs or esm)", lang, l.ModuleFormat)
+		}
+		switch l.ScalaVersion {
+		case "", Scala2, Scala3:
+		default:
+			return fmt.Errorf("languages.%s.scala_version: unknown Scala version %q (want 2 or 3)", lang, l.ScalaVersion)
+		}
+		switch l.Codecs {
+		case "", CodecsCirce, CodecsZIOJSON:
+		default:
+			return fmt.Errorf("languages.%s.codecs: unknown codec library %q (want circe or zio-json)", lang, l.Codecs)
+		}
+		if l.Refined && l.ScalaVersion != Scala3 {
+			return fmt.Errorf("languages.%s.refined: refined types need scala_version 3", lang)
+		}
+		switch l.Flatten {
+		case "", "json", "delimited", "tables":
+		default:
+			return fmt.Errorf("languages.%s.flatten: unknown flatten strategy %q (want json, delimited, or tables)", lang, l.Flatten)
+		}
+		for i, command := range l.Format {
+			if strings.TrimSpace(command) == "" {
+				return fmt.Errorf("languages.%s.format[%d]: empty formatter command", lang, i)
+			}
+		}
+		for _, value := range []string{l.Naming.Fields, l.Naming.Types, l.Naming.Files, l.Naming.Packages} {
+			if _, err := naming.ParseCase(value); err != nil {
+				return fmt.Errorf("languages.%s.naming: %w", lang, err)
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDecimal(mode string, goLang bool) error {
+	switch mode {
+	case "", DecimalFloat, DecimalExact:
+		return nil
+	case DecimalShopspring:
+		if goLang {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown decimal mode %q (want float or exact)", mode)
+}
+
+func (t Temporal) validate() error {
+	switch t.Instants {
+	case "", InstantsUTC, InstantsOffset:
+	default:
+		return fmt.Errorf("unknown instants policy %q (want utc or offset)", t.Instants)
+	}
+	switch t.Dates {
+	case "", DatesFull, DatesPartial:
+	default:
+		return fmt.Errorf("unknown dates policy %q (want full or partial)", t.Dates)
+	}
+	return nil
+}
+
+// Language returns the settings for a canonical language name.
+func (c Config) Language(lang string) Language {
+	return c.Languages[lang]
+}
+
+// Compatibility returns the compatibility mode of a namespace, in upper
+// case: its own, or else registry.compatibility, or "" for none.
+func (c Config) Compatibility(namespace string) string {
+	if mode := c.Namespaces[namespace].Compatibility; mode != "" {
+		return strings.ToUpper(mode)
+	}
+	return c.Registry.Compatibility
+}
+
+// Overlays returns the vendor directories that back namespaces, keyed by
+// namespace, or nil if none do.
+func (c Config) Overlays() map[string]string {
+	var overlays map[string]string
+	for ns, n := range c.Namespaces {
+		if n.Overlay != "" {
+			if overlays == nil {
+				overlays = make(map[string]string)
+			}
+			overlays[ns] = n.Overlay
+		}
+	}
+	return overlays
+}
+
+// DecimalMode returns the effective decimal mode for a canonical language name.
+func (c Config) DecimalMode(lang string) string {
+	if mode := c.Languages[lang].Decimal; mode != "" {
+		return mode
+	}
+	if c.Decimal != "" {
+		return c.Decimal
+	}
+	return DecimalFloat
+}
+
+// BuildTool returns the effective build tool for a canonical language name:
+// gradle for Kotlin and maven for Java unless the language sets another.
+func (c Config) BuildTool(lang string) string {
+	if tool := c.Languages[lang].Build; tool != "" {
+		return tool
+	}
+	if lang == "kotlin" {
+		return BuildGradle
+	}
+	return BuildMaven
+}
+
+// TemporalPolicy returns the effective temporal policy for a canonical
+// language name, with every setting resolved.
+func (c Config) TemporalPolicy(lang string) Temporal {
+	policy := Temporal{Instants: InstantsUTC, Dates: DatesFull}
+	for _, t := range []Temporal{c.Temporal, c.Languages[lang].Temporal} {
+		if t.Instants != "" {
+			policy.Instants = t.Instants
+		}
+		if t.Dates != "" {
+			policy.Dates = t.Dates
+		}
+	}
+	return policy
+}
+
+// SQLDialect returns the effective SQL dialect.
+func (c Config) SQLDialect() string {
+	if dialect := c.Languages["sql"].Dialect; dialect != "" {
+		return dialect
+	}
+	return DialectPostgres
+}
+
+// OutputDir returns the effective output directory for a canonical language
+// name in the OS form, or "" when none is configured.
+func (c Config) OutputDir(lang string) string {
+	if dir := c.Languages[lang].Output; dir != "" {
+		return paths.FromConfig(dir)
+	}
+	return paths.FromConfig(c.Output)
+}
+
+// CrosswalkFile returns the crosswalk file in the OS form, or "" when none is
+// configured.
+func (c Config) CrosswalkFile() string {
+	return paths.FromConfig(c.Crosswalk)
+}
+
+// ConceptMapFiles returns the concept map files in the OS form.
+func (c Config) ConceptMapFiles() []string {
+	var files []string
+	for _, f := range c.ConceptMaps {
+		files = append(files, paths.FromConfig(f))
+	}
+	return files
+}
+
+// Pseudonymization returns the effective pseudonymization settings, with
+// the key variable and role defaulted when a level is configured.
+func (c Config) Pseudonymization() Pseudonymize {
+	p := c.Pseudonymize
+	if p.Level == "" {
+		return p
+	}
+	if p.KeyEnv == "" {
+		p.KeyEnv = DefaultPseudonymKeyEnv
+	}
+	if p.Role == "" {
+		p.Role = DefaultReidentificationRole
+	}
+	return p
+}
+
+// PackageInfo returns the effective package settings for a canonical language
+// name, with language settings overriding project settings field by field.
+func (c Config) PackageInfo(lang string) Package {
+	return c.Languages[lang].Package.over(c.Package)
+}
+
+// over returns base with the settings p sets in place of its own.
+func (p Package) over(base Package) Package {
+	if p.Name != "" {
+		base.Name = p.Name
+	}
+	if p.Version != "" {
+		base.Version = p.Version
+	}
+	if p.Description != "" {
+		base.Description = p.Description
+	}
+	if p.Group != "" {
+		base.Group = p.Group
+	}
+	return base
+}
+
+// WithProfile returns the profile named name and c as generated for it:
+// with the package settings of the profile overriding those of the project
+// and of every language.
+func (c Config) WithProfile(name string) (Config, Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return c, p, fmt.Errorf("unknown profile %q (no profiles are configured)", name)
+		}
+		return c, p, fmt.Errorf("unknown profile %q (want %s)", name, strings.Join(sortedKeys(c.Profiles), ", "))
+	}
+	c.Package = p.Package.over(c.Package)
+	languages := make(map[string]Language, len(c.Languages))
+	for lang, l := range c.Languages {
+		l.Package = p.Package.over(l.Package)
+		languages[lang] = l
+	}
+	c.Languages = languages
+	return c, p, nil
+}
+
+// Validate reports whether p names and versions a package.
+func (p Package) Validate() error {
+	if p.Name == "" || p.Version == "" {
+		return errors.New("package.name and package.version are required")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecimalMode(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		lang string
+		want string
+	}{
+		{name: "default", lang: "python", want: DecimalFloat},
+		{name: "project", cfg: Config{Decimal: DecimalExact}, lang: "java", want: DecimalExact},
+		{
+			name: "language overrides project",
+			cfg:  Config{Decimal: DecimalExact, Languages: map[string]Language{"go": {Decimal: DecimalShopspring}}},
+			lang: "go",
+			want: DecimalShopspring,
+		},
+		{
+			name: "other languages keep project",
+			cfg:  Config{Decimal: DecimalExact, Languages: map[string]Language{"go": {Decimal: DecimalFloat}}},
+			lang: "csharp",
+			want: DecimalExact,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.DecimalMode(tt.lang); got != tt.want {
+				t.Errorf("DecimalMode(%s) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "exact", yaml: "decimal: exact\n"},
+		{name: "shopspring for go", yaml: "languages:\n  go:\n    decimal: shopspring\n"},
+		{name: "shopspring for golang", yaml: "languages:\n  golang:\n    decimal: shopspring\n"},
+		{name: "shopspring for the project", yaml: "decimal: shopspring\n", wantErr: `decimal: unknown decimal mode "shopspring"`},
+		{name: "shopspring for rust", yaml: "languages:\n  rust:\n    decimal: shopspring\n", wantErr: `languages.rust.decimal: unknown decimal mode "shopspring"`},
+		{name: "unknown", yaml: "decimal: bcd\n", wantErr: `decimal: unknown decimal mode "bcd"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), DefaultFile)
+			if err := os.WriteFile(path, []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load(path)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("Load() failed: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,414 @@
+// Package convert executes mappings directly, turning source rows into
+// target resources without generating code first.
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Options control how a Converter reads source values.
+type Options struct {
+	// Location is the time zone of source datetimes without an offset. The
+	// default is UTC.
+	Location *time.Location
+	// Passthrough copies values through transforms that are neither built in
+	// nor defined by a value mapping, rather than rejecting the mapping.
+	Passthrough bool
+}
+
+// Converter applies one mapping to source rows.
+type Converter struct {
+	target  schema.Schema
+	fields  []field
+	loc     *time.Location
+	unknown []string
+}
+
+// field is a compiled field mapping.
+type field struct {
+	schema.FieldMapping
+	path      []step
+	fieldType string
+	values    map[string]string
+	transform transform
+	context   []constant
+}
+
+type constant struct {
+	path      []step
+	fieldType string
+	value     string
+}
+
+// step is one segment of a target path: a key, and an index when the key
+// names a list, such as name[0].
+type step struct {
+	key   string
+	index int
+}
+
+var stepPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\[(\d+)\])?$`)
+
+// New compiles mapping, resolving the types of its targets among schemas so
+// that values are written as the JSON types the target fields expect.
+func New(schemas []schema.Schema, mapping schema.SchemaMapping, opts Options) (*Converter, error) {
+	r, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+	target, ok, err := r.Scope(filepath.Base(filepath.Dir(mapping.SourceFile))).Resolve(mapping.TargetResource)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("target resource %s not found", mapping.TargetResource)
+	}
+	scope := r.Scope(target.Namespace)
+
+	c := &Converter{target: target, loc: opts.Location}
+	if c.loc == nil {
+		c.loc = time.UTC
+	}
+	var problems []error
+	var unknown []string
+	for _, fm := range mapping.FieldMappings {
+		f := field{FieldMapping: fm}
+		if f.path, err = parsePath(fm.Target); err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		f.fieldType = typeAt(scope, target.Fields, f.path)
+		for path, value := range fm.TargetContext {
+			steps, err := parsePath(path)
+			if err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			f.context = append(f.context, constant{path: steps, fieldType: typeAt(scope, target.Fields, steps), value: fmt.Sprint(value)})
+		}
+		sort.Slice(f.context, func(i, j int) bool { return fmt.Sprint(f.context[i].path) < fmt.Sprint(f.context[j].path) })
+
+		f.values = fm.ValueMapping
+		if f.values == nil {
+			f.values = mapping.ValueMappings[fm.Transform]
+		}
+		if fm.Transform != "" {
+			t, ok := lookupTransform(fm.Transform)
+			switch {
+			case ok:
+				f.transform = t
+			case f.values == nil && opts.Passthrough:
+				c.unknown = append(c.unknown, fm.Transform)
+			case f.values == nil:
+				unknown = append(unknown, fm.Transform)
+			}
+		}
+		c.fields = append(c.fields, f)
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		problems = append(problems, fmt.Errorf("unknown transforms %s; add value mappings for them or allow passthrough", strings.Join(dedupe(unknown), ", ")))
+	}
+	sort.Strings(c.unknown)
+	c.unknown = dedupe(c.unknown)
+	if err := errors.Join(problems...); err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %w", mapping.SourceFile, err)
+	}
+	return c, nil
+}
+
+// Resource returns the name of the resource the converter produces.
+func (c *Converter) Resource() string {
+	return c.target.GetName()
+}
+
+// Target returns the schema of the resources the converter produces.
+func (c *Converter) Target() schema.Schema {
+	return c.target
+}
+
+// Unknown returns the transforms that values are copied through unchanged
+// because they are neither built in nor defined by a value mapping.
+func (c *Converter) Unknown() []string {
+	return c.unknown
+}
+
+// Unmapped returns the columns that no field mapping reads.
+func (c *Converter) Unmapped(columns []string) []string {
+	read := make(map[string]bool)
+	for _, f := range c.fields {
+		read[f.Source] = true
+	}
+	var unmapped []string
+	for _, column := range columns {
+		if !read[column] {
+			unmapped = append(unmapped, column)
+		}
+	}
+	return unmapped
+}
+
+// Missing returns the source columns the mapping reads that are not in
+// columns.
+func (c *Converter) Missing(columns []string) []string {
+	have := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		have[column] = true
+	}
+	var missing []string
+	for _, f := range c.fields {
+		if f.Source != "" && !have[f.Source] {
+			missing = append(missing, f.Source)
+		}
+	}
+	return dedupe(missing)
+}
+
+// Convert maps one source row, keyed by column, to a resource. Empty values
+// are treated as null. Values that fail to convert are left out of the
+// resource and reported together in the returned error.
+func (c *Converter) Convert(row map[string]string) (map[string]any, error) {
+	resource := map[string]any{"resourceType": c.target.GetName()}
+	var problems []error
+	for _, f := range c.fields {
+		value, ok, err := c.value(f, row)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", f.Target, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := set(resource, f.path, value); err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		for _, k := range f.context {
+			value, err := coerce(k.value, k.fieldType)
+			if err == nil {
+				err = set(resource, k.path, value)
+			}
+			if err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+	compact(resource)
+	return resource, errors.Join(problems...)
+}
+
+// value returns the target value of a field mapping for row, and false when
+// the target is left unset.
+func (c *Converter) value(f field, row map[string]string) (any, bool, error) {
+	var raw string
+	if f.Source != "" {
+		raw = row[f.Source]
+	}
+	if raw == "" {
+		if mapped, ok := f.values[""]; ok && f.Source != "" {
+			v, err := coerce(mapped, f.fieldType)
+			return v, err == nil, err
+		}
+		if f.Default == nil {
+			return nil, false, nil
+		}
+		v, err := coerce(fmt.Sprint(f.Default), f.fieldType)
+		return v, err == nil, err
+	}
+
+	var value any = raw
+	if mapped, ok := f.values[raw]; ok {
+		value = mapped
+	}
+	if f.transform != nil {
+		var err error
+		if value, err = f.transform(value.(string), c.loc); err != nil {
+			return nil, false, err
+		}
+	}
+	if s, ok := value.(string); ok {
+		v, err := coerce(s, f.fieldType)
+		return v, err == nil, err
+	}
+	return value, true, nil
+}
+
+// coerce converts a text value to the JSON type of a primitive field type.
+// Other types keep the text.
+func coerce(value, fieldType string) (any, error) {
+	switch fieldType {
+	case "boolean":
+		return parseBoolean(value)
+	case "integer", "positiveInt", "unsignedInt":
+		return parseInteger(value)
+	case "decimal":
+		return parseDecimal(value)
+	}
+	return value, nil
+}
+
+func parsePath(path string) ([]step, error) {
+	var steps []step
+	for _, part := range strings.Split(path, ".") {
+		m := stepPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("invalid target path %q", path)
+		}
+		s := step{key: m[1], index: -1}
+		if m[2] != "" {
+			fmt.Sscan(m[2], &s.index)
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
+
+// TargetType returns the type of the field a target path such as
+// name[0].family ends at, starting from fields as seen from scope, or "" when
+// the path is invalid or leaves the schema. List types give their element
+// type.
+func TargetType(scope resolve.Scope, fields []schema.Field, path string) string {
+	steps, err := parsePath(path)
+	if err != nil {
+		return ""
+	}
+	return typeAt(scope, fields, steps)
+}
+
+// typeAt returns the type of the field a target path ends at, or "" when the
+// path leaves the schema.
+func typeAt(scope resolve.Scope, fields []schema.Field, path []step) string {
+	for i, s := range path {
+		var f *schema.Field
+		for j := range fields {
+			if fields[j].Name == s.key {
+				f = &fields[j]
+				break
+			}
+		}
+		if f == nil {
+			return ""
+		}
+		fieldType, _ := schema.ElementType(f.Type)
+		if i == len(path)-1 {
+			return fieldType
+		}
+		if len(f.Children) > 0 {
+			fields = f.Children
+			continue
+		}
+		target, ok := scope.Lookup(fieldType)
+		if !ok {
+			return ""
+		}
+		scope, fields = scope.Scope(target.Namespace), target.Fields
+	}
+	return ""
+}
+
+// set stores value at path in obj, creating objects and growing lists on the
+// way. Lists are padded with nil, which compact removes.
+func set(obj map[string]any, path []step, value any) error {
+	for i, s := range path {
+		last := i == len(path)-1
+		if s.index < 0 {
+			if last {
+				obj[s.key] = value
+				return nil
+			}
+			next, err := child(obj[s.key], path[:i+1])
+			if err != nil {
+				return err
+			}
+			obj[s.key] = next
+			obj = next
+			continue
+		}
+
+		list, ok := obj[s.key].([]any)
+		if obj[s.key] != nil && !ok {
+			return fmt.Errorf("%s is not a list", format(path[:i+1]))
+		}
+		for len(list) <= s.index {
+			list = append(list, nil)
+		}
+		obj[s.key] = list
+		if last {
+			list[s.index] = value
+			return nil
+		}
+		next, err := child(list[s.index], path[:i+1])
+		if err != nil {
+			return err
+		}
+		list[s.index] = next
+		obj = next
+	}
+	return nil
+}
+
+func child(v any, path []step) (map[string]any, error) {
+	if v == nil {
+		return make(map[string]any), nil
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an object", format(path))
+	}
+	return obj, nil
+}
+
+// compact removes the nil padding from lists, so that name[0].given[1] set
+// without given[0] becomes the first given name.
+func compact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = compact(value)
+		}
+	case []any:
+		items := v[:0]
+		for _, item := range v {
+			if item != nil {
+				items = append(items, compact(item))
+			}
+		}
+		return items
+	}
+	return v
+}
+
+func format(path []step) string {
+	var b strings.Builder
+	for i, s := range path {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.key)
+		if s.index >= 0 {
+			fmt.Fprintf(&b, "[%d]", s.index)
+		}
+	}
+	return b.String()
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,245 @@
+package convert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+const (
+	patientYAML = `resource: Patient
+fields:
+  - name: id
+    type: id
+  - name: active
+    type: boolean
+  - name: multipleBirthInteger
+    type: integer
+  - name: birthDate
+    type: date
+  - name: name
+    type: array<HumanName>
+  - name: identifier
+    type: array<Identifier>
+  - name: managingOrganization
+    type: Reference
+`
+
+	mappingYAML = `source_system: clinic
+source_table: PATIENTS
+target_resource: Patient
+field_mappings:
+  - source: PAT_ID
+    target: id
+    transform: to_string
+  - source: ACTIVE_YN
+    target: active
+    transform: clinic_yn_to_boolean
+  - source: BIRTH_ORDER
+    target: multipleBirthInteger
+  - source: DOB
+    target: birthDate
+    transform: hl7_datetime_to_fhir_date
+  - source: FIRST
+    target: name[0].given[0]
+  - source: MIDDLE
+    target: name[0].given[1]
+    skip_if_null: true
+  - source: LAST
+    target: name[0].family
+  - source: null
+    target: name[0].use
+    default: official
+  - source: MRN
+    target: identifier[0].value
+    target_context:
+      identifier[0].system: "urn:oid:clinic:mrn"
+  - source: SSN
+    target: identifier[1].value
+    transform: format_ssn
+    target_context:
+      identifier[1].system: "http://hl7.org/fhir/sid/us-ssn"
+  - source: ORG_ID
+    target: managingOrganization.reference
+    transform: to_organization_reference
+value_mappings:
+  clinic_yn_to_boolean:
+    Y: true
+    N: false
+    null: false
+`
+)
+
+func compile(t *testing.T, mappingYAML string) *Converter {
+	t.Helper()
+	dir := t.TempDir()
+	for path, content := range map[string]string{"fhir_r4/patient.yaml": patientYAML, "clinic/patient_mapping.yaml": mappingYAML} {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	schemas, err := schema.NewLoader(dir).LoadAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping, err := schema.LoadMappingFile(filepath.Join(dir, "clinic", "patient_mapping.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(schemas, mapping, Options{})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	return c
+}
+
+func TestStream(t *testing.T) {
+	c := compile(t, mappingYAML)
+	input := `PAT_ID,ACTIVE_YN,BIRTH_ORDER,DOB,FIRST,MIDDLE,LAST,MRN,SSN,ORG_ID
+p1,Y,2,19800304,Ana,,Lopez,,123456789,org1
+p2,,x,1990,Bo,Q,Li,M2,12345,
+`
+	var out bytes.Buffer
+	var errs []string
+	stats, err := c.Stream(strings.NewReader(input), &out, NDJSON, func(row int, err error) {
+		errs = append(errs, err.Error())
+	})
+	if err != nil {
+		t.Fatalf("Stream() failed: %v", err)
+	}
+
+	want := []string{
+		`{"resourceType":"Patient","active":true,"birthDate":"1980-03-04","id":"p1","identifier":[{"system":"http://hl7.org/fhir/sid/us-ssn","value":"123-45-6789"}],"managingOrganization":{"reference":"Organization/org1"},"multipleBirthInteger":2,"name":[{"family":"Lopez","given":["Ana"],"use":"official"}]}`,
+		`{"resourceType":"Patient","active":false,"birthDate":"1990","id":"p2","identifier":[{"system":"urn:oid:clinic:mrn","value":"M2"}],"name":[{"family":"Li","given":["Bo","Q"],"use":"official"}]}`,
+	}
+	if got := strings.Split(strings.TrimSpace(out.String()), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() wrote\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	wantErrs := []string{"multipleBirthInteger: \"x\" is not an integer\nidentifier[1].value: \"12345\" is not a nine-digit SSN"}
+	if !reflect.DeepEqual(errs, wantErrs) {
+		t.Errorf("errors = %q, want %q", errs, wantErrs)
+	}
+	if stats.Rows != 2 || stats.Failed != 1 || len(stats.Missing) != 0 {
+		t.Errorf("stats = %+v, want 2 rows, 1 failed, none missing", stats)
+	}
+}
+
+func TestStreamBundle(t *testing.T) {
+	c := compile(t, mappingYAML)
+	var out bytes.Buffer
+	stats, err := c.Stream(strings.NewReader("PAT_ID\np1\np2\n"), &out, Bundle, func(int, error) {})
+	if err != nil {
+		t.Fatalf("Stream() failed: %v", err)
+	}
+	var bundle struct {
+		Type  string `json:"type"`
+		Entry []struct {
+			Resource map[string]any `json:"resource"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &bundle); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if bundle.Type != "collection" || len(bundle.Entry) != 2 || bundle.Entry[1].Resource["id"] != "p2" {
+		t.Errorf("unexpected bundle:\n%s", out.String())
+	}
+	if want := []string{"ACTIVE_YN", "BIRTH_ORDER", "DOB", "FIRST", "MIDDLE", "LAST", "MRN", "SSN", "ORG_ID"}; !reflect.DeepEqual(stats.Missing, want) {
+		t.Errorf("Missing = %v, want %v", stats.Missing, want)
+	}
+}
+
+func TestUnknownTransform(t *testing.T) {
+	dir := t.TempDir()
+	mapping := schema.SchemaMapping{
+		TargetResource: "Patient",
+		SourceFile:     filepath.Join(dir, "clinic", "patient_mapping.yaml"),
+		FieldMappings: []schema.FieldMapping{
+			{Source: "SEX", Target: "gender", Transform: "clinic_sex_to_gender"},
+		},
+	}
+	schemas := []schema.Schema{{Resource: "Patient", Namespace: "fhir_r4", Fields: []schema.Field{{Name: "gender", Type: "code"}}}}
+	if _, err := New(schemas, mapping, Options{}); err == nil || !strings.Contains(err.Error(), "clinic_sex_to_gender") {
+		t.Errorf("New() error = %v, want unknown transform", err)
+	}
+	c, err := New(schemas, mapping, Options{Passthrough: true})
+	if err != nil {
+		t.Fatalf("New() with passthrough failed: %v", err)
+	}
+	if got, _ := c.Convert(map[string]string{"SEX": "F"}); got["gender"] != "F" {
+		t.Errorf("Convert() = %v, want gender passed through", got)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	est, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+	tests := []struct {
+		transform, in string
+		want          any
+	}{
+		{"datetime_to_fhir_datetime", "2023-04-01 09:30:00", "2023-04-01T09:30:00-04:00"},
+		{"datetime_to_fhir_datetime", "04/01/2023", "2023-04-01"},
+		{"datetime_to_fhir_instant", "2023-04-01T13:30:00Z", "2023-04-01T13:30:00Z"},
+		{"date_to_fhir_date", "2023-04-01 00:00:00", "2023-04-01"},
+		{"hl7_datetime_to_fhir_datetime", "202304010930", "2023-04-01T09:30:00-04:00"},
+		{"hl7_datetime_to_fhir_datetime", "20230401093015.5+0000", "2023-04-01T09:30:15.5Z"},
+		{"cda_datetime_to_fhir_date", "202304", "2023-04"},
+		{"normalize_phone", "1 (617) 555-0100", "617-555-0100"},
+		{"oid_to_uri", "2.16.840.1.113883.4.1", "urn:oid:2.16.840.1.113883.4.1"},
+		{"to_medication_request_reference", "42", "MedicationRequest/42"},
+		{"to_decimal", "4.50", json.Number("4.50")},
+		{"invert_delete_ind", "0", true},
+	}
+	for _, tt := range tests {
+		fn, ok := lookupTransform(tt.transform)
+		if !ok {
+			t.Errorf("transform %s not found", tt.transform)
+			continue
+		}
+		if got, err := fn(tt.in, est); err != nil || got != tt.want {
+			t.Errorf("%s(%q) = %v, %v, want %v", tt.transform, tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestReadSamples(t *testing.T) {
+	c := compile(t, mappingYAML)
+	samples, err := ReadSamples(strings.NewReader(`[{"PAT_ID": 7, "ACTIVE_YN": null, "FIRST": "Ana", "NOTE": "x"}, {"PAT_ID": true}]`))
+	if err != nil {
+		t.Fatalf("ReadSamples() failed: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("ReadSamples() = %d samples, want 2", len(samples))
+	}
+	got, err := c.Convert(samples[0].Row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := Marshal(got)
+	if want := `{"resourceType":"Patient","active":false,"id":"7","name":[{"given":["Ana"],"use":"official"}]}`; string(data) != want {
+		t.Errorf("Convert() = %s, want %s", data, want)
+	}
+	if got := c.Unmapped(samples[0].Columns); !reflect.DeepEqual(got, []string{"NOTE"}) {
+		t.Errorf("Unmapped() = %v, want [NOTE]", got)
+	}
+	if samples[1].Row["PAT_ID"] != "true" {
+		t.Errorf("boolean sample value = %q, want true", samples[1].Row["PAT_ID"])
+	}
+
+	if _, err := ReadSamples(strings.NewReader(`{"PAT_ID": {"nested": 1}}`)); err == nil {
+		t.Error("ReadSamples() accepted a nested object")
+	}
+}
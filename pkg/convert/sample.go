@@ -0,0 +1,75 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Sample is a source record written as a JSON object, for trying out a
+// mapping.
+type Sample struct {
+	Row map[string]string
+	// Columns are the record's keys in sorted order.
+	Columns []string
+}
+
+// ReadSamples reads sample source records: a JSON object, or an array of
+// them. Numbers and booleans are read as the text a CSV extract would hold,
+// and null as an empty value.
+func ReadSamples(r io.Reader) ([]Sample, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	var raw []map[string]json.RawMessage
+	if bytes.HasPrefix(data, []byte("[")) {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		var record map[string]json.RawMessage
+		err = json.Unmarshal(data, &record)
+		raw = append(raw, record)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("samples must be a JSON object or an array of objects: %w", err)
+	}
+
+	samples := make([]Sample, 0, len(raw))
+	for i, record := range raw {
+		sample := Sample{Row: make(map[string]string, len(record))}
+		for key, value := range record {
+			text, err := sampleText(value)
+			if err != nil {
+				return nil, fmt.Errorf("sample %d: %s: %w", i+1, key, err)
+			}
+			sample.Row[key] = text
+			sample.Columns = append(sample.Columns, key)
+		}
+		sort.Strings(sample.Columns)
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func sampleText(value json.RawMessage) (string, error) {
+	var v any
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("expected a string, number, boolean, or null")
+}
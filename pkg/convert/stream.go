@@ -0,0 +1,120 @@
+package convert
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Output formats.
+const (
+	NDJSON = "ndjson"
+	Bundle = "bundle"
+)
+
+// Stats counts the rows a stream converted.
+type Stats struct {
+	Rows   int
+	Failed int
+	// Missing are the source columns the mapping reads that the input lacks.
+	Missing []string
+}
+
+// Stream converts the CSV rows of r, whose first row names the columns, and
+// writes each resource to w as soon as it is converted: one per line for
+// NDJSON, or as the entries of a collection Bundle. Rows whose values fail
+// to convert are still written, without those values, and passed to onError
+// with their row number, counting the header as row 1.
+func (c *Converter) Stream(r io.Reader, w io.Writer, format string, onError func(row int, err error)) (Stats, error) {
+	var stats Stats
+	if format != NDJSON && format != Bundle {
+		return stats, fmt.Errorf("unknown output format %q (want ndjson or bundle)", format)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return stats, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	stats.Missing = c.Missing(header)
+
+	out := bufio.NewWriter(w)
+	if format == Bundle {
+		out.WriteString(`{"resourceType":"Bundle","type":"collection","entry":[`)
+	}
+	for rowNum := 2; ; rowNum++ {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("failed to read CSV row %d: %w", rowNum, err)
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(cells) {
+				row[column] = strings.TrimSpace(cells[i])
+			}
+		}
+		resource, err := c.Convert(row)
+		if err != nil {
+			stats.Failed++
+			onError(rowNum, err)
+		}
+		data, err := Marshal(resource)
+		if err != nil {
+			return stats, err
+		}
+
+		if format == Bundle {
+			if stats.Rows > 0 {
+				out.WriteByte(',')
+			}
+			out.WriteString("\n" + `{"resource":`)
+			out.Write(data)
+			out.WriteByte('}')
+		} else {
+			out.Write(data)
+			out.WriteByte('\n')
+		}
+		stats.Rows++
+	}
+	if format == Bundle {
+		out.WriteString("\n]}\n")
+	}
+	return stats, out.Flush()
+}
+
+// Marshal encodes a resource with resourceType first, as FHIR JSON is
+// conventionally written.
+func Marshal(resource map[string]any) ([]byte, error) {
+	resourceType, _ := json.Marshal(resource["resourceType"])
+	rest := make(map[string]any, len(resource))
+	for key, value := range resource {
+		if key != "resourceType" {
+			rest[key] = value
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(rest); err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %w", err)
+	}
+	data := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	head := `{"resourceType":` + string(resourceType)
+	if len(rest) > 0 {
+		head += ","
+	}
+	return append([]byte(head), data[1:]...), nil
+}
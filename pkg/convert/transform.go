@@ -0,0 +1,268 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// transform converts a source value. Naive datetimes are read in loc.
+type transform func(value string, loc *time.Location) (any, error)
+
+// transforms are the built-in transforms mappings may name. Transforms of
+// the form to_<resource>_reference are handled by lookupTransform.
+var transforms = map[string]transform{
+	"to_string":  func(v string, _ *time.Location) (any, error) { return v, nil },
+	"to_integer": func(v string, _ *time.Location) (any, error) { return parseInteger(v) },
+	"to_decimal": func(v string, _ *time.Location) (any, error) { return parseDecimal(v) },
+	"to_boolean": func(v string, _ *time.Location) (any, error) { return parseBoolean(v) },
+	"invert_boolean": func(v string, _ *time.Location) (any, error) {
+		b, err := parseBoolean(v)
+		return !b, err
+	},
+	"datetime_to_fhir_datetime":     func(v string, loc *time.Location) (any, error) { return dateTime(v, loc, false) },
+	"datetime_to_fhir_instant":      func(v string, loc *time.Location) (any, error) { return dateTime(v, loc, true) },
+	"datetime_to_fhir_date":         func(v string, loc *time.Location) (any, error) { return date(v, loc) },
+	"hl7_datetime_to_fhir_datetime": func(v string, loc *time.Location) (any, error) { return hl7DateTime(v, loc, false) },
+	"hl7_datetime_to_fhir_instant":  func(v string, loc *time.Location) (any, error) { return hl7DateTime(v, loc, true) },
+	"hl7_datetime_to_fhir_date":     func(v string, _ *time.Location) (any, error) { return hl7Date(v) },
+	"normalize_phone":               func(v string, _ *time.Location) (any, error) { return normalizePhone(v), nil },
+	"format_ssn":                    func(v string, _ *time.Location) (any, error) { return formatSSN(v) },
+	"oid_to_uri": func(v string, _ *time.Location) (any, error) {
+		if strings.HasPrefix(v, "urn:oid:") {
+			return v, nil
+		}
+		return "urn:oid:" + v, nil
+	},
+}
+
+func init() {
+	transforms["date_to_fhir_date"] = transforms["datetime_to_fhir_date"]
+	transforms["invert_delete_ind"] = transforms["invert_boolean"]
+	transforms["cda_datetime_to_fhir_datetime"] = transforms["hl7_datetime_to_fhir_datetime"]
+	transforms["cda_datetime_to_fhir_date"] = transforms["hl7_datetime_to_fhir_date"]
+}
+
+// Transforms returns the names of the built-in transforms, sorted, with
+// to_<resource>_reference standing for the transforms that turn ids into
+// references.
+func Transforms() []string {
+	names := []string{"to_<resource>_reference"}
+	for name := range transforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsTransform reports whether name is a built-in transform.
+func IsTransform(name string) bool {
+	_, ok := lookupTransform(name)
+	return ok
+}
+
+// lookupTransform returns the built-in transform called name.
+func lookupTransform(name string) (transform, bool) {
+	if t, ok := transforms[name]; ok {
+		return t, true
+	}
+	if resource, ok := ReferenceResource(name); ok {
+		return func(v string, _ *time.Location) (any, error) { return resource + "/" + v, nil }, true
+	}
+	return nil, false
+}
+
+// ReferenceResource returns the resource a to_<resource>_reference transform
+// refers to, such as Patient for to_patient_reference.
+func ReferenceResource(name string) (string, bool) {
+	if resource, ok := strings.CutPrefix(name, "to_"); ok {
+		if resource, ok := strings.CutSuffix(resource, "_reference"); ok && resource != "" {
+			return pascal(resource), true
+		}
+	}
+	return "", false
+}
+
+func pascal(s string) string {
+	words := strings.Split(s, "_")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+func parseInteger(v string) (int64, error) {
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		// Extracts often render integer columns as floats, such as 12.0.
+		f, ferr := strconv.ParseFloat(v, 64)
+		if ferr != nil || f != float64(int64(f)) {
+			return 0, fmt.Errorf("%q is not an integer", v)
+		}
+		i = int64(f)
+	}
+	return i, nil
+}
+
+func parseDecimal(v string) (json.Number, error) {
+	if _, err := strconv.ParseFloat(v, 64); err != nil {
+		return "", fmt.Errorf("%q is not a number", v)
+	}
+	return json.Number(v), nil
+}
+
+func parseBoolean(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "true", "t", "yes", "y", "1":
+		return true, nil
+	case "false", "f", "no", "n", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a boolean", v)
+}
+
+// layouts are the datetime formats source values are read in, and whether
+// they include a time of day.
+var layouts = []struct {
+	layout string
+	clock  bool
+}{
+	{time.RFC3339Nano, true},
+	{"2006-01-02T15:04:05.999999999", true},
+	{"2006-01-02 15:04:05.999999999Z07:00", true},
+	{"2006-01-02 15:04:05.999999999", true},
+	{"2006-01-02T15:04", true},
+	{"2006-01-02 15:04", true},
+	{"2006-01-02", false},
+	{"01/02/2006 15:04:05", true},
+	{"01/02/2006 15:04", true},
+	{"1/2/2006 15:04:05", true},
+	{"1/2/2006 15:04", true},
+	{"01/02/2006", false},
+	{"1/2/2006", false},
+}
+
+func parseTime(v string, loc *time.Location) (time.Time, bool, error) {
+	for _, l := range layouts {
+		if t, err := time.ParseInLocation(l.layout, v, loc); err == nil {
+			return t, l.clock, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%q is not a recognized date or datetime", v)
+}
+
+// dateTime renders a source datetime as a FHIR dateTime, which keeps dates
+// without a time of day as dates, or as a FHIR instant, which requires one.
+func dateTime(v string, loc *time.Location, instant bool) (string, error) {
+	t, clock, err := parseTime(v, loc)
+	if err != nil {
+		return "", err
+	}
+	if !clock {
+		if instant {
+			return "", fmt.Errorf("%q has no time of day", v)
+		}
+		return t.Format("2006-01-02"), nil
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+func date(v string, loc *time.Location) (string, error) {
+	t, _, err := parseTime(v, loc)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+// hl7TS matches HL7 v2 and CDA timestamps, YYYY[MM[DD[HH[MM[SS[.S]]]]]][+/-ZZZZ].
+var hl7TS = regexp.MustCompile(`^(\d{4})(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\d{2})?(\.\d{1,4})?([+-]\d{4})?$`)
+
+// hl7DateTime renders an HL7 timestamp at its own precision: dateTime keeps
+// partial dates, while instants require a time of day. Times without an
+// offset are read in loc.
+func hl7DateTime(v string, loc *time.Location, instant bool) (string, error) {
+	m := hl7TS.FindStringSubmatch(v)
+	if m == nil {
+		return "", fmt.Errorf("%q is not an HL7 timestamp", v)
+	}
+	if m[4] == "" {
+		if instant {
+			return "", fmt.Errorf("%q has no time of day", v)
+		}
+		return hl7Date(v)
+	}
+	minute, second := or(m[5], "00"), or(m[6], "00")
+	layout := "20060102150405"
+	if m[8] != "" {
+		layout += "-0700"
+	}
+	t, err := time.ParseInLocation(layout, m[1]+m[2]+m[3]+m[4]+minute+second+m[8], loc)
+	if err != nil {
+		return "", fmt.Errorf("%q is not an HL7 timestamp", v)
+	}
+	if m[7] != "" {
+		frac, _ := strconv.ParseFloat("0"+m[7], 64)
+		t = t.Add(time.Duration(frac * float64(time.Second)))
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// hl7Date renders the date part of an HL7 timestamp as a FHIR date, keeping
+// year and year-month precision.
+func hl7Date(v string) (string, error) {
+	m := hl7TS.FindStringSubmatch(v)
+	if m == nil {
+		return "", fmt.Errorf("%q is not an HL7 timestamp", v)
+	}
+	d := m[1]
+	if m[2] != "" {
+		d += "-" + m[2]
+		if m[3] != "" {
+			d += "-" + m[3]
+		}
+	}
+	if _, err := time.Parse("2006-01-02"[:len(d)], d); err != nil {
+		return "", fmt.Errorf("%q is not a valid date", v)
+	}
+	return d, nil
+}
+
+func or(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
+// normalizePhone formats ten-digit North American numbers, with or without
+// a leading 1, as 555-555-0100 and leaves other numbers as written.
+func normalizePhone(v string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, v)
+	if len(digits) == 11 && digits[0] == '1' {
+		digits = digits[1:]
+	}
+	if len(digits) != 10 || strings.HasPrefix(strings.TrimSpace(v), "+") && !strings.HasPrefix(strings.TrimSpace(v), "+1") {
+		return v
+	}
+	return digits[:3] + "-" + digits[3:6] + "-" + digits[6:]
+}
+
+func formatSSN(v string) (string, error) {
+	digits := strings.ReplaceAll(strings.ReplaceAll(v, "-", ""), " ", "")
+	if len(digits) != 9 || strings.Trim(digits, "0123456789") != "" {
+		return "", fmt.Errorf("%q is not a nine-digit SSN", v)
+	}
+	return digits[:3] + "-" + digits[3:5] + "-" + digits[5:], nil
+}
@@ -0,0 +1,207 @@
+// Package crosswalk reads crosswalks from the local codes of schema fields to
+// standard concepts: OMOP concept ids and i2b2 ontology paths.
+package crosswalk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Columns are the columns of a crosswalk CSV file, which must have a header
+// row. namespace, concept_id, vocabulary_id, concept_code, concept_name,
+// and i2b2_path may be left out.
+var Columns = []string{"namespace", "schema", "field", "local_code", "concept_id", "vocabulary_id", "concept_code", "concept_name", "i2b2_path"}
+
+var required = []string{"schema", "field", "local_code"}
+
+// Concept is the standard concept a local code maps to.
+type Concept struct {
+	LocalCode string
+	// ConceptID is the OMOP concept_id, or 0, the OMOP "No matching
+	// concept", when the code has none.
+	ConceptID    int64
+	VocabularyID string
+	ConceptCode  string
+	ConceptName  string
+	// I2B2Path is the concept path of the code in an i2b2 ontology, such as
+	// \i2b2\Diagnoses\E11\.
+	I2B2Path string
+}
+
+// Entry is a row of a crosswalk file: a local code of a schema field and its
+// concept. An empty Namespace matches the schema in any namespace.
+type Entry struct {
+	Namespace string
+	Schema    string
+	Field     string
+	Line      int
+	Concept
+}
+
+// Crosswalk is the entries of a crosswalk file, in file order.
+type Crosswalk []Entry
+
+// Load reads the crosswalk CSV file at path.
+func Load(path string) (Crosswalk, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crosswalk: %w", err)
+	}
+	defer f.Close()
+
+	c, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Parse reads a crosswalk in CSV form.
+func Parse(r io.Reader) (Crosswalk, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+	index := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.TrimSpace(name)
+		if !contains(Columns, name) {
+			return nil, fmt.Errorf("unknown column %q (want %s)", name, strings.Join(Columns, ", "))
+		}
+		index[name] = i
+	}
+	for _, name := range required {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var c Crosswalk
+	for n, record := range records[1:] {
+		value := func(column string) string {
+			if i, ok := index[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		e := Entry{
+			Namespace: value("namespace"),
+			Schema:    value("schema"),
+			Field:     value("field"),
+			Line:      n + 2,
+			Concept: Concept{
+				LocalCode:    value("local_code"),
+				VocabularyID: value("vocabulary_id"),
+				ConceptCode:  value("concept_code"),
+				ConceptName:  value("concept_name"),
+				I2B2Path:     value("i2b2_path"),
+			},
+		}
+		if e.Schema == "" || e.Field == "" || e.LocalCode == "" {
+			return nil, fmt.Errorf("line %d: schema, field, and local_code are required", e.Line)
+		}
+		if id := value("concept_id"); id != "" {
+			if e.ConceptID, err = strconv.ParseInt(id, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: concept_id %q is not an integer", e.Line, id)
+			}
+		}
+		if e.ConceptID == 0 && e.I2B2Path == "" {
+			return nil, fmt.Errorf("line %d: %s of %s.%s maps to neither a concept_id nor an i2b2_path", e.Line, e.LocalCode, e.Schema, e.Field)
+		}
+		c = append(c, e)
+	}
+	return c, nil
+}
+
+// Field is a schema field with the concepts its local codes map to.
+type Field struct {
+	Schema   schema.Schema
+	Field    schema.Field
+	Concepts []Concept
+}
+
+// Fields returns the fields of schemas the crosswalk maps codes of, in
+// schema and field order, with their concepts in file order. Entries must
+// name a top-level field of one of schemas, and codes must be among the
+// field's enum values when it has them.
+func (c Crosswalk) Fields(schemas []schema.Schema) ([]Field, error) {
+	var fields []Field
+	index := make(map[[3]string]int)
+	for _, s := range schemas {
+		for _, f := range s.Fields {
+			index[[3]string{s.Namespace, s.GetName(), f.Name}] = len(fields)
+			fields = append(fields, Field{Schema: s, Field: f})
+		}
+	}
+
+	for _, e := range c {
+		matched := false
+		for _, s := range schemas {
+			if s.GetName() != e.Schema || (e.Namespace != "" && s.Namespace != e.Namespace) {
+				continue
+			}
+			n, ok := index[[3]string{s.Namespace, s.GetName(), e.Field}]
+			if !ok {
+				return nil, fmt.Errorf("line %d: %s has no field %s", e.Line, e.Schema, e.Field)
+			}
+			f := &fields[n]
+			if len(f.Field.Enum) > 0 && !contains(f.Field.Enum, e.LocalCode) {
+				return nil, fmt.Errorf("line %d: %q is not one of the codes of %s.%s", e.Line, e.LocalCode, e.Schema, e.Field)
+			}
+			for _, concept := range f.Concepts {
+				if concept.LocalCode == e.LocalCode {
+					return nil, fmt.Errorf("line %d: %s.%s maps %q twice", e.Line, e.Schema, e.Field, e.LocalCode)
+				}
+			}
+			f.Concepts = append(f.Concepts, e.Concept)
+			matched = true
+		}
+		if !matched {
+			return nil, fmt.Errorf("line %d: unknown schema %s", e.Line, e.Schema)
+		}
+	}
+
+	var mapped []Field
+	for _, f := range fields {
+		if len(f.Concepts) > 0 {
+			mapped = append(mapped, f)
+		}
+	}
+	return mapped, nil
+}
+
+// LoadFields reads the crosswalk file at path and returns the fields of
+// schemas it maps codes of, grouped by namespace.
+func LoadFields(path string, schemas []schema.Schema) (map[string][]Field, error) {
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := c.Fields(schemas)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	groups := make(map[string][]Field)
+	for _, f := range fields {
+		groups[f.Schema.Namespace] = append(groups[f.Schema.Namespace], f)
+	}
+	return groups, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
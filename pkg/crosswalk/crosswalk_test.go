@@ -0,0 +1,59 @@
+package crosswalk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+)
+
+func TestFields(t *testing.T) {
+	c, err := Parse(strings.NewReader(fixtures.Crosswalk))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	fields, err := c.Fields(fixtures.Schemas())
+	if err != nil {
+		t.Fatalf("Fields() failed: %v", err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("got %d fields, want 2", len(fields))
+	}
+	// Schema order, not file order
+	if got := fields[0].Schema.GetName() + "." + fields[0].Field.Name; got != "Specimen.status" {
+		t.Errorf("fields[0] = %s, want Specimen.status", got)
+	}
+	icd := fields[1].Concepts
+	if len(icd) != 2 || icd[0].ConceptID != 201826 || icd[0].I2B2Path != `\i2b2\Diagnoses\E11\E11.9\` || icd[1].ConceptName != `Essential hypertension, "primary"` {
+		t.Errorf("lab_result.ICD10Code concepts = %+v", icd)
+	}
+	if got := fields[0].Concepts[1]; got.ConceptID != 0 || got.I2B2Path == "" {
+		t.Errorf("i2b2-only concept = %+v", got)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	const header = "namespace,schema,field,local_code,concept_id,i2b2_path\n"
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"schema,field,code\n", `unknown column "code" (want ` + strings.Join(Columns, ", ") + ")"},
+		{"schema,local_code\n", `missing column "field"`},
+		{header + ",Specimen,status,available,x,\n", `line 2: concept_id "x" is not an integer`},
+		{header + ",Specimen,status,available,,\n", "line 2: available of Specimen.status maps to neither a concept_id nor an i2b2_path"},
+		{header + ",Specimen,status,lost,1,\n", `line 2: "lost" is not one of the codes of Specimen.status`},
+		{header + ",Specimen,kind,x,1,\n", "line 2: Specimen has no field kind"},
+		{header + ",Specimen,status,available,1,\n,Specimen,status,available,2,\n", `line 3: Specimen.status maps "available" twice`},
+		{header + "registry,Specimen,status,available,1,\n", "line 2: unknown schema Specimen"},
+	}
+	for _, tt := range tests {
+		c, err := Parse(strings.NewReader(tt.input))
+		if err == nil {
+			_, err = c.Fields(fixtures.Schemas())
+		}
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%q: got error %v, want %s", tt.input, err, tt.want)
+		}
+	}
+}
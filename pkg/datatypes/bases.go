@@ -0,0 +1,144 @@
+package datatypes
+
+import "github.com/konzy/ehrglot/pkg/schema"
+
+// Names of the FHIR base resources a schema can inherit.
+const (
+	ResourceName       = "Resource"
+	DomainResourceName = "DomainResource"
+)
+
+// bases holds the base resources by name. DomainResource omits contained,
+// whose resources may be of any type.
+var bases = map[string]schema.Schema{
+	ResourceName: base(ResourceName, "", "Base Resource", []schema.Field{
+		{Name: "id", Type: "id", Description: "Logical id of this artifact"},
+		{Name: "meta", Type: "Meta", Description: "Metadata about the resource"},
+		{Name: "implicitRules", Type: "uri", Description: "A set of rules under which this content was created"},
+		{Name: "language", Type: "code", Description: "Language of the resource content"},
+	}),
+	DomainResourceName: base(DomainResourceName, ResourceName, "A resource with narrative and extensions", []schema.Field{
+		{Name: "text", Type: "Narrative", Description: "Text summary of the resource, for human interpretation"},
+		{Name: "extension", Type: "[]Extension", Description: "Additional content defined by implementations"},
+		{Name: "modifierExtension", Type: "[]Extension", Description: "Extensions that cannot be ignored"},
+	}),
+}
+
+func base(name, inherits, description string, fields []schema.Field) schema.Schema {
+	s := builtin(name, description, fields)
+	s.Inherits = inherits
+	return s
+}
+
+func init() {
+	// Mapping paths pass through datatypes and inherited elements
+	schema.RegisterBuiltins(func(name string) (schema.Schema, bool) {
+		if b, ok := Base(name); ok {
+			return b, true
+		}
+		if name, ok := Lookup(name); ok {
+			return Builtin(name)
+		}
+		return schema.Schema{}, false
+	})
+}
+
+// Base returns the flattened base resource of a name, as Flatten returns it.
+func Base(name string) (schema.Schema, bool) {
+	b, ok := bases[name]
+	if !ok {
+		return schema.Schema{}, false
+	}
+	return Flatten(b), true
+}
+
+// IsBaseName reports whether name is a base resource a schema can inherit.
+func IsBaseName(name string) bool {
+	_, ok := bases[name]
+	return ok
+}
+
+// IsBase reports whether s is a built-in base resource.
+func IsBase(s schema.Schema) bool {
+	return IsBuiltin(s) && IsBaseName(s.GetName())
+}
+
+// Flatten returns s with the elements of its base resources, root base
+// first, ahead of its own fields. A field s declares with the name of an
+// inherited element replaces that element in place, so that a schema can
+// refine it, for example to make id required. Schemas that inherit nothing,
+// or an unknown base, are returned unchanged.
+func Flatten(s schema.Schema) schema.Schema {
+	b, ok := bases[s.Inherits]
+	if !ok {
+		return s
+	}
+	own := make(map[string]int, len(s.Fields))
+	for i, f := range s.Fields {
+		own[f.Name] = i
+	}
+
+	inherited := Flatten(b).Fields
+	fields := make([]schema.Field, 0, len(inherited)+len(s.Fields))
+	redeclared := make(map[string]bool)
+	for _, f := range inherited {
+		if i, ok := own[f.Name]; ok {
+			f = s.Fields[i]
+			redeclared[f.Name] = true
+		}
+		fields = append(fields, f)
+	}
+	for _, f := range s.Fields {
+		if !redeclared[f.Name] {
+			fields = append(fields, f)
+		}
+	}
+	s.Fields = fields
+	return s
+}
+
+// Own returns the fields of s that its base resources do not declare, for
+// generators that emit base resources as base classes. Elements s redeclares
+// are left to the base class.
+func Own(s schema.Schema) []schema.Field {
+	b, ok := bases[s.Inherits]
+	if !ok {
+		return s.Fields
+	}
+	inherited := make(map[string]bool)
+	for _, f := range Flatten(b).Fields {
+		inherited[f.Name] = true
+	}
+	var fields []schema.Field
+	for _, f := range s.Fields {
+		if !inherited[f.Name] {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// WithBases appends the base resources that schemas inherit, directly or
+// through other bases, in the common Namespace, for generators that emit them
+// as base classes. Bases are appended root first, and those already present
+// in schemas are not added again.
+func WithBases(schemas []schema.Schema) []schema.Schema {
+	present := make(map[string]bool)
+	used := make(map[string]bool)
+	for _, s := range schemas {
+		if s.Namespace == Namespace {
+			present[s.GetName()] = true
+		}
+		for name := s.Inherits; IsBaseName(name); name = bases[name].Inherits {
+			used[name] = true
+		}
+	}
+
+	with := append([]schema.Schema(nil), schemas...)
+	for _, name := range []string{ResourceName, DomainResourceName} {
+		if used[name] && !present[name] {
+			with = append(with, bases[name])
+		}
+	}
+	return with
+}
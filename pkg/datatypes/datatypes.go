@@ -0,0 +1,249 @@
+// Package datatypes defines the FHIR datatypes that ehrglot generates itself
+// rather than reading from schema files.
+package datatypes
+
+import (
+	"sort"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Namespace is the common namespace built-in datatypes are generated into.
+// Schemas in every other namespace import them from there.
+const Namespace = "datatypes"
+
+// QuantityName is the schema name of the built-in Quantity type.
+const QuantityName = "Quantity"
+
+// ReferenceName is the schema name of the built-in Reference type.
+const ReferenceName = "Reference"
+
+// BuiltinSource is the SourceFile recorded on built-in schemas.
+const BuiltinSource = "<builtin>"
+
+// profiles maps FHIR types that share a built-in datatype's structure to
+// that datatype.
+var profiles = map[string]string{
+	"SimpleQuantity": QuantityName,
+	"MoneyQuantity":  QuantityName,
+	"Age":            QuantityName,
+	"Count":          QuantityName,
+	"Distance":       QuantityName,
+	"Duration":       QuantityName,
+}
+
+// builtins holds the built-in datatypes by name. Identifier omits FHIR's
+// assigner so that the datatypes do not reference each other in a cycle, and
+// Extension carries only the common value[x] types and no nested extensions.
+var builtins = map[string]schema.Schema{
+	"Address": builtin("Address", "An address expressed using postal conventions", []schema.Field{
+		{Name: "use", Type: "code", Enum: []string{"home", "work", "temp", "old", "billing"}, Description: "home | work | temp | old | billing - purpose of this address"},
+		{Name: "type", Type: "code", Enum: []string{"postal", "physical", "both"}, Description: "postal | physical | both"},
+		{Name: "text", Type: "string", Description: "Text representation of the address"},
+		{Name: "line", Type: "[]string", Description: "Street name, number, direction & P.O. Box etc."},
+		{Name: "city", Type: "string", Description: "Name of city, town etc."},
+		{Name: "district", Type: "string", Description: "District name (aka county)"},
+		{Name: "state", Type: "string", Description: "Sub-unit of country (abbreviations ok)"},
+		{Name: "postalCode", Type: "string", Description: "Postal code for area"},
+		{Name: "country", Type: "string", Description: "Country (e.g. can be ISO 3166 2 or 3 letter code)"},
+		{Name: "period", Type: "Period", Description: "Time period when address was/is in use"},
+	}),
+	"CodeableConcept": builtin("CodeableConcept", "Concept - reference to a terminology or just text", []schema.Field{
+		{Name: "coding", Type: "[]Coding", Description: "Code defined by a terminology system"},
+		{Name: "text", Type: "string", Description: "Plain text representation of the concept"},
+	}),
+	"Coding": builtin("Coding", "A reference to a code defined by a terminology system", []schema.Field{
+		{Name: "system", Type: "uri", Description: "Identity of the terminology system"},
+		{Name: "version", Type: "string", Description: "Version of the system - if relevant"},
+		{Name: "code", Type: "code", Description: "Symbol in syntax defined by the system"},
+		{Name: "display", Type: "string", Description: "Representation defined by the system"},
+		{Name: "userSelected", Type: "boolean", Description: "If this coding was chosen directly by the user"},
+	}),
+	"Extension": builtin("Extension", "Additional content defined by implementations", []schema.Field{
+		{Name: "url", Type: "uri", Required: true, Description: "Identifies the meaning of the extension"},
+		{Name: "valueString", Type: "string", Description: "Value of extension"},
+		{Name: "valueCode", Type: "code", Description: "Value of extension"},
+		{Name: "valueBoolean", Type: "boolean", Description: "Value of extension"},
+		{Name: "valueInteger", Type: "integer", Description: "Value of extension"},
+		{Name: "valueDecimal", Type: "decimal", Description: "Value of extension"},
+		{Name: "valueDateTime", Type: "datetime", Description: "Value of extension"},
+		{Name: "valueCoding", Type: "Coding", Description: "Value of extension"},
+		{Name: "valueCodeableConcept", Type: "CodeableConcept", Description: "Value of extension"},
+		{Name: "valueReference", Type: "Reference", Description: "Value of extension"},
+		{Name: "valueAddress", Type: "Address", Description: "Value of extension"},
+	}),
+	"HumanName": builtin("HumanName", "Name of a human - parts and usage", []schema.Field{
+		{Name: "use", Type: "code", Enum: []string{"usual", "official", "temp", "nickname", "anonymous", "old", "maiden"}, Description: "usual | official | temp | nickname | anonymous | old | maiden"},
+		{Name: "text", Type: "string", Description: "Text representation of the full name"},
+		{Name: "family", Type: "string", Description: "Family name (often called 'Surname')"},
+		{Name: "given", Type: "[]string", Description: "Given names (not always 'first'). Includes middle names"},
+		{Name: "prefix", Type: "[]string", Description: "Parts that come before the name"},
+		{Name: "suffix", Type: "[]string", Description: "Parts that come after the name"},
+		{Name: "period", Type: "Period", Description: "Time period when name was/is in use"},
+	}),
+	"Identifier": builtin("Identifier", "An identifier intended for computation", []schema.Field{
+		{Name: "use", Type: "code", Enum: []string{"usual", "official", "temp", "secondary", "old"}, Description: "usual | official | temp | secondary | old (If known)"},
+		{Name: "type", Type: "CodeableConcept", Description: "Description of identifier"},
+		{Name: "system", Type: "uri", Description: "The namespace for the identifier value"},
+		{Name: "value", Type: "string", Description: "The value that is unique"},
+		{Name: "period", Type: "Period", Description: "Time period when id is/was valid for use"},
+	}),
+	"Meta": builtin("Meta", "Metadata about a resource", []schema.Field{
+		{Name: "versionId", Type: "id", Description: "Version specific identifier"},
+		{Name: "lastUpdated", Type: "instant", Description: "When the resource version last changed"},
+		{Name: "source", Type: "uri", Description: "Identifies where the resource comes from"},
+		{Name: "profile", Type: "[]uri", Description: "Profiles this resource claims to conform to"},
+		{Name: "security", Type: "[]Coding", Description: "Security Labels applied to this resource"},
+		{Name: "tag", Type: "[]Coding", Description: "Tags applied to this resource"},
+	}),
+	"Narrative": builtin("Narrative", "Human-readable summary of the resource", []schema.Field{
+		{Name: "status", Type: "code", Required: true, Enum: []string{"generated", "extensions", "additional", "empty"}, Description: "generated | extensions | additional | empty"},
+		{Name: "div", Type: "string", Required: true, Description: "Limited xhtml content"},
+	}),
+	"Period": builtin("Period", "Time range defined by start and end date/time", []schema.Field{
+		{Name: "start", Type: "datetime", Description: "Starting time with inclusive boundary"},
+		{Name: "end", Type: "datetime", Description: "End time with inclusive boundary, if not ongoing"},
+	}),
+	QuantityName: builtin(QuantityName, "A measured amount (or an amount that can potentially be measured)", []schema.Field{
+		{Name: "value", Type: "decimal", Description: "Numerical value (with implicit precision)"},
+		{Name: "comparator", Type: "code", Enum: []string{"<", "<=", ">=", ">"}, Description: "< | <= | >= | > - how to understand the value"},
+		{Name: "unit", Type: "string", Description: "Unit representation"},
+		{Name: "system", Type: "uri", Description: "System that defines coded unit form"},
+		{Name: "code", Type: "code", Description: "Coded form of the unit"},
+	}),
+	ReferenceName: builtin(ReferenceName, "A reference from one resource to another", []schema.Field{
+		{Name: "reference", Type: "string", Description: "Literal reference, Relative, internal or absolute URL"},
+		{Name: "type", Type: "uri", Description: "Type the reference refers to (e.g. \"Patient\")"},
+		{Name: "identifier", Type: "Identifier", Description: "Logical reference, when literal reference is not known"},
+		{Name: "display", Type: "string", Description: "Text alternative for the resource"},
+	}),
+}
+
+func builtin(name, description string, fields []schema.Field) schema.Schema {
+	return schema.Schema{
+		Name:        name,
+		Description: description,
+		Namespace:   Namespace,
+		SourceFile:  BuiltinSource,
+		Fields:      fields,
+	}
+}
+
+// Lookup returns the name of the built-in datatype a schema field type
+// refers to, resolving profiles such as Age and Duration to Quantity. List
+// types are looked up by their element type with schema.ElementType first.
+func Lookup(fieldType string) (string, bool) {
+	if name, ok := profiles[fieldType]; ok {
+		return name, true
+	}
+	_, ok := builtins[fieldType]
+	return fieldType, ok
+}
+
+// Builtin returns the built-in datatype named name.
+func Builtin(name string) (schema.Schema, bool) {
+	s, ok := builtins[name]
+	return s, ok
+}
+
+// ReferenceFields returns the top-level fields of s that hold built-in
+// References, singly or in lists.
+func ReferenceFields(s schema.Schema) []schema.Field {
+	var fields []schema.Field
+	for _, f := range s.Fields {
+		elem, _ := schema.ElementType(f.Type)
+		if name, ok := Lookup(elem); ok && name == ReferenceName {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// primitives are the field types generators map to language types directly.
+var primitives = map[string]bool{
+	"string": true, "code": true, "id": true, "uri": true, "url": true, "canonical": true,
+	"oid": true, "uuid": true, "markdown": true, "integer": true, "positiveInt": true,
+	"unsignedInt": true, "decimal": true, "boolean": true, "date": true, "datetime": true,
+	"dateTime": true, "instant": true, "time": true, "base64Binary": true,
+}
+
+// IsPrimitive reports whether a field type is a primitive rather than a
+// reference to a schema or datatype.
+func IsPrimitive(fieldType string) bool {
+	return primitives[fieldType]
+}
+
+// IsBuiltin reports whether s is a built-in datatype rather than a schema
+// read from disk.
+func IsBuiltin(s schema.Schema) bool {
+	return s.SourceFile == BuiltinSource
+}
+
+// References returns the sorted names of the built-in datatypes that fields
+// of s refer to, not counting s itself.
+func References(s schema.Schema) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, f := range s.Fields {
+		elem, _ := schema.ElementType(f.Type)
+		name, ok := Lookup(elem)
+		if ok && name != s.GetName() && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Expand appends the built-in datatypes that schemas use, directly or
+// through other datatypes, in the common Namespace so generators emit each
+// of them once. Names are appended in sorted order. A namespace that defines
+// a schema with a built-in's name uses its own, and built-ins already present
+// in schemas are not added again.
+//
+// Schemas that inherit a base resource are returned flattened, as Flatten
+// does, so that their inherited elements resolve like their own fields, and
+// with array<T> list types written as []T, as schema.ListTypes does.
+func Expand(schemas []schema.Schema) []schema.Schema {
+	flattened := make([]schema.Schema, len(schemas))
+	for i, s := range schemas {
+		s = Flatten(s)
+		s.Fields = schema.ListTypes(s.Fields)
+		flattened[i] = s
+	}
+	schemas = flattened
+
+	defined := make(map[string]bool)
+	for _, s := range schemas {
+		defined[s.Namespace+"."+s.GetName()] = true
+	}
+
+	used := make(map[string]bool)
+	var visit func(s schema.Schema)
+	visit = func(s schema.Schema) {
+		for _, name := range References(s) {
+			if !used[name] && !defined[s.Namespace+"."+name] {
+				used[name] = true
+				visit(builtins[name])
+			}
+		}
+	}
+	for _, s := range schemas {
+		visit(s)
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		if !defined[Namespace+"."+name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	expanded := schemas
+	for _, name := range names {
+		expanded = append(expanded, builtins[name])
+	}
+	return expanded
+}
@@ -0,0 +1,89 @@
+package datatypes
+
+import (
+	"strconv"
+	"strings"
+)
+
+// UCUMSystem is the FHIR system URI for UCUM unit codes.
+const UCUMSystem = "http://unitsofmeasure.org"
+
+// Unit is a UCUM unit that generated helpers can validate and convert.
+// A value v in the unit equals v*Factor + Offset in the base unit of its
+// dimension; only temperatures have an offset.
+type Unit struct {
+	Code      string
+	Dimension string
+	Factor    float64
+	Offset    float64
+}
+
+// Units lists the clinical UCUM units known to the generated helpers. It is
+// a practical subset, not the full UCUM grammar: codes outside it are
+// reported as unknown rather than parsed.
+var Units = []Unit{
+	{Code: "g", Dimension: "mass", Factor: 1},
+	{Code: "kg", Dimension: "mass", Factor: 1e3},
+	{Code: "mg", Dimension: "mass", Factor: 1e-3},
+	{Code: "ug", Dimension: "mass", Factor: 1e-6},
+	{Code: "ng", Dimension: "mass", Factor: 1e-9},
+	{Code: "[lb_av]", Dimension: "mass", Factor: 453.59237},
+	{Code: "[oz_av]", Dimension: "mass", Factor: 28.349523125},
+
+	{Code: "m", Dimension: "length", Factor: 1},
+	{Code: "km", Dimension: "length", Factor: 1e3},
+	{Code: "cm", Dimension: "length", Factor: 1e-2},
+	{Code: "mm", Dimension: "length", Factor: 1e-3},
+	{Code: "[in_i]", Dimension: "length", Factor: 0.0254},
+	{Code: "[ft_i]", Dimension: "length", Factor: 0.3048},
+
+	{Code: "L", Dimension: "volume", Factor: 1},
+	{Code: "dL", Dimension: "volume", Factor: 1e-1},
+	{Code: "mL", Dimension: "volume", Factor: 1e-3},
+	{Code: "uL", Dimension: "volume", Factor: 1e-6},
+
+	{Code: "s", Dimension: "time", Factor: 1},
+	{Code: "min", Dimension: "time", Factor: 60},
+	{Code: "h", Dimension: "time", Factor: 3600},
+	{Code: "d", Dimension: "time", Factor: 86400},
+	{Code: "wk", Dimension: "time", Factor: 604800},
+	{Code: "mo", Dimension: "time", Factor: 2629800},
+	{Code: "a", Dimension: "time", Factor: 31557600},
+
+	{Code: "K", Dimension: "temperature", Factor: 1},
+	{Code: "Cel", Dimension: "temperature", Factor: 1, Offset: 273.15},
+	{Code: "[degF]", Dimension: "temperature", Factor: 5.0 / 9.0, Offset: 459.67 * 5.0 / 9.0},
+
+	{Code: "g/L", Dimension: "mass_concentration", Factor: 1},
+	{Code: "g/dL", Dimension: "mass_concentration", Factor: 10},
+	{Code: "mg/dL", Dimension: "mass_concentration", Factor: 1e-2},
+	{Code: "mg/L", Dimension: "mass_concentration", Factor: 1e-3},
+	{Code: "ug/mL", Dimension: "mass_concentration", Factor: 1e-3},
+	{Code: "ng/mL", Dimension: "mass_concentration", Factor: 1e-6},
+
+	{Code: "mol/L", Dimension: "substance_concentration", Factor: 1},
+	{Code: "mmol/L", Dimension: "substance_concentration", Factor: 1e-3},
+	{Code: "umol/L", Dimension: "substance_concentration", Factor: 1e-6},
+	{Code: "nmol/L", Dimension: "substance_concentration", Factor: 1e-9},
+
+	{Code: "Pa", Dimension: "pressure", Factor: 1},
+	{Code: "kPa", Dimension: "pressure", Factor: 1e3},
+	{Code: "mm[Hg]", Dimension: "pressure", Factor: 133.322},
+
+	{Code: "Hz", Dimension: "frequency", Factor: 1},
+	{Code: "/min", Dimension: "frequency", Factor: 1.0 / 60},
+	{Code: "/h", Dimension: "frequency", Factor: 1.0 / 3600},
+
+	{Code: "1", Dimension: "ratio", Factor: 1},
+	{Code: "%", Dimension: "ratio", Factor: 1e-2},
+}
+
+// FloatLiteral formats f as a floating-point literal that every target
+// language reads as a double, e.g. 1.0 rather than 1.
+func FloatLiteral(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
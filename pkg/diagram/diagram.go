@@ -0,0 +1,229 @@
+// Package diagram draws mappings as Mermaid flowcharts, from source columns
+// through transforms to target fields, in Markdown pages for people who
+// review mappings without reading YAML.
+package diagram
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Path returns the path, relative to the docs directory, of the page of m:
+// the mapping file's name under its source system.
+func Path(m schema.SchemaMapping) string {
+	name := strings.TrimSuffix(filepath.Base(m.SourceFile), filepath.Ext(m.SourceFile))
+	if m.SourceFile == "" {
+		name = strings.ToLower(m.SourceTable + "_" + m.TargetResource + "_mapping")
+	}
+	return filepath.Join(m.SourceSystem, name+".md")
+}
+
+// Mermaid returns the flowchart of m. Source columns and target fields are
+// grouped in subgraphs, and each field mapping with a transform or value
+// mapping passes through a node naming it. Constants set by target_context
+// flow into their fields over dotted edges.
+func Mermaid(m schema.SchemaMapping) string {
+	var sources, targets []string
+	sourceIDs := make(map[string]string)
+	targetIDs := make(map[string]string)
+	node := func(ids map[string]string, names *[]string, prefix, name string) string {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		*names = append(*names, name)
+		ids[name] = fmt.Sprintf("%s%d", prefix, len(*names))
+		return ids[name]
+	}
+
+	var steps, edges []string
+	for i, fm := range m.FieldMappings {
+		source := node(sourceIDs, &sources, "s", fm.Source)
+		target := node(targetIDs, &targets, "t", fm.Target)
+		if label := transformLabel(m, fm); label != "" {
+			step := fmt.Sprintf("x%d", i+1)
+			steps = append(steps, fmt.Sprintf("%s{{%s}}", step, quote(label)))
+			edges = append(edges, source+" --> "+step, step+" --> "+target)
+		} else {
+			edges = append(edges, source+" --> "+target)
+		}
+	}
+	var constants []string
+	n := 0
+	for _, fm := range m.FieldMappings {
+		for _, path := range sortedKeys(fm.TargetContext) {
+			n++
+			constant := fmt.Sprintf("k%d", n)
+			target := node(targetIDs, &targets, "t", path)
+			constants = append(constants, fmt.Sprintf("%s[/%s/]", constant, quote(fmt.Sprint(fm.TargetContext[path]))))
+			edges = append(edges, constant+" -.-> "+target)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	fmt.Fprintf(&b, "    subgraph source[%s]\n", quote(m.SourceSystem+"."+m.SourceTable))
+	for _, name := range sources {
+		fmt.Fprintf(&b, "        %s[%s]\n", sourceIDs[name], quote(name))
+	}
+	b.WriteString("    end\n")
+	fmt.Fprintf(&b, "    subgraph target[%s]\n", quote(m.TargetResource))
+	for _, name := range targets {
+		fmt.Fprintf(&b, "        %s[%s]\n", targetIDs[name], quote(name))
+	}
+	b.WriteString("    end\n")
+	for _, line := range append(steps, constants...) {
+		fmt.Fprintf(&b, "    %s\n", line)
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(&b, "    %s\n", edge)
+	}
+	return b.String()
+}
+
+// transformLabel returns the name of the transform of fm and the number of
+// codes its value mapping translates, or "" when fm copies its value.
+func transformLabel(m schema.SchemaMapping, fm schema.FieldMapping) string {
+	values := fm.ValueMapping
+	if values == nil {
+		values = m.ValueMappings[fm.Transform]
+	}
+	label := fm.Transform
+	if values != nil {
+		if label == "" {
+			label = "value mapping"
+		}
+		label += fmt.Sprintf(" (%d codes)", len(values))
+	}
+	return label
+}
+
+// Page writes the Markdown page of m: its flowchart, then tables of its
+// field mappings, constants, and value mappings.
+func Page(w io.Writer, m schema.SchemaMapping) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s.%s → %s\n\n", m.SourceSystem, m.SourceTable, m.TargetResource)
+	if description := strings.TrimSpace(m.Description); description != "" {
+		b.WriteString(description + "\n\n")
+	}
+	if m.SourceFile != "" {
+		fmt.Fprintf(&b, "Mapping file: `%s`\n\n", filepath.ToSlash(m.SourceFile))
+	}
+	fmt.Fprintf(&b, "```mermaid\n%s```\n", Mermaid(m))
+
+	b.WriteString("\n## Fields\n\n| Source | Transform | Target | Notes |\n| --- | --- | --- | --- |\n")
+	for _, fm := range m.FieldMappings {
+		var notes []string
+		if fm.Description != "" {
+			notes = append(notes, cell(fm.Description))
+		}
+		if fm.Default != nil {
+			notes = append(notes, fmt.Sprintf("Defaults to `%v`.", fm.Default))
+		}
+		if fm.SkipIfNull {
+			notes = append(notes, "Skipped when null.")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", code(fm.Source), code(transformLabel(m, fm)), code(fm.Target), strings.Join(notes, " "))
+	}
+
+	var constants []string
+	for _, fm := range m.FieldMappings {
+		for _, path := range sortedKeys(fm.TargetContext) {
+			constants = append(constants, fmt.Sprintf("| %s | %s |\n", code(path), code(fmt.Sprint(fm.TargetContext[path]))))
+		}
+	}
+	if len(constants) > 0 {
+		b.WriteString("\n## Constants\n\n| Target | Value |\n| --- | --- |\n")
+		b.WriteString(strings.Join(constants, ""))
+	}
+
+	type table struct {
+		name   string
+		values schema.ValueMapping
+	}
+	var tables []table
+	for _, name := range sortedKeys(m.ValueMappings) {
+		tables = append(tables, table{name, m.ValueMappings[name]})
+	}
+	for _, fm := range m.FieldMappings {
+		if fm.ValueMapping != nil {
+			tables = append(tables, table{fm.Target, fm.ValueMapping})
+		}
+	}
+	if len(tables) > 0 {
+		b.WriteString("\n## Value Mappings\n")
+	}
+	for _, t := range tables {
+		fmt.Fprintf(&b, "\n### %s\n\n| Source code | Target code |\n| --- | --- |\n", t.name)
+		for _, from := range sortedKeys(t.values) {
+			fmt.Fprintf(&b, "| %s | %s |\n", codeOrNull(from), codeOrNull(t.values[from]))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Index writes a Markdown page linking the pages of mappings, grouped by
+// source system.
+func Index(w io.Writer, mappings []schema.SchemaMapping) error {
+	sorted := append([]schema.SchemaMapping(nil), mappings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return Path(sorted[i]) < Path(sorted[j]) })
+
+	var b strings.Builder
+	b.WriteString("# Mappings\n")
+	system := ""
+	for i, m := range sorted {
+		if i == 0 || m.SourceSystem != system {
+			system = m.SourceSystem
+			fmt.Fprintf(&b, "\n## %s\n\n", system)
+		}
+		fmt.Fprintf(&b, "- [%s → %s](%s)\n", m.SourceTable, m.TargetResource, filepath.ToSlash(Path(m)))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelEscaper escapes the characters Mermaid reads as markup in quoted
+// labels.
+var labelEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ")
+
+func quote(label string) string {
+	return `"` + labelEscaper.Replace(label) + `"`
+}
+
+// code returns s as inline code in a table cell, or "" when s is empty.
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(cell(s), "`", "'") + "`"
+}
+
+// codeOrNull returns a value mapping code as inline code, showing the empty
+// code that null maps from or to as null.
+func codeOrNull(s string) string {
+	if s == "" {
+		return "*null*"
+	}
+	return code(s)
+}
+
+// cell returns s on one line, with the pipes that would end a table cell
+// escaped.
+func cell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
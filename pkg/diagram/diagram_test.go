@@ -0,0 +1,96 @@
+package diagram
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// patientMapping exercises transforms, shared and inline value mappings,
+// constants, defaults, and a source column read twice.
+var patientMapping = schema.SchemaMapping{
+	SourceSystem:   "ehr",
+	SourceTable:    "PATIENT",
+	TargetResource: "Patient",
+	Description:    "Patients, with their \"preferred\" names",
+	SourceFile:     filepath.Join("schemas", "ehr", "patient_mapping.yaml"),
+	FieldMappings: []schema.FieldMapping{
+		{Source: "PAT_ID", Target: "id", Transform: "to_string", Description: "Internal | patient id"},
+		{Source: "PAT_MRN", Target: "identifier[0].value", TargetContext: map[string]any{
+			"identifier[0].system": "urn:oid:ehr:mrn",
+			"identifier[0].use":    "official",
+		}},
+		{Source: "SEX_C", Target: "gender", Transform: "gender_map", Default: "unknown"},
+		{Source: "PAT_ID", Target: "link[0].other", SkipIfNull: true},
+		{Source: "LANG_C", Target: "communication[0].language", ValueMapping: schema.ValueMapping{"1": "en", "": ""}},
+	},
+	ValueMappings: map[string]schema.ValueMapping{
+		"gender_map": {"1": "female", "2": "male"},
+	},
+}
+
+func TestPageGolden(t *testing.T) {
+	mappings := append(fixtures.Mappings(), patientMapping)
+	for _, m := range mappings {
+		t.Run(Path(m), func(t *testing.T) {
+			var got bytes.Buffer
+			if err := Page(&got, m); err != nil {
+				t.Fatalf("Page() failed: %v", err)
+			}
+			checkGolden(t, filepath.Join("testdata", Path(m)), got.Bytes())
+		})
+	}
+	t.Run("index", func(t *testing.T) {
+		var got bytes.Buffer
+		if err := Index(&got, mappings); err != nil {
+			t.Fatalf("Index() failed: %v", err)
+		}
+		checkGolden(t, filepath.Join("testdata", "README.md"), got.Bytes())
+	})
+}
+
+func TestMermaidSharesNodes(t *testing.T) {
+	got := Mermaid(patientMapping)
+	// PAT_ID feeds two fields from one node.
+	if n := strings.Count(got, `["PAT_ID"]`); n != 1 {
+		t.Errorf("PAT_ID declared %d times, want 1:\n%s", n, got)
+	}
+	for _, want := range []string{
+		`x1{{"to_string"}}`,
+		`x3{{"gender_map (2 codes)"}}`,
+		`x5{{"value mapping (2 codes)"}}`,
+		`source["ehr.PATIENT"]`,
+		`k1[/"urn:oid:ehr:mrn"/]`,
+		"s1 --> t4",
+		"k2 -.-> t7",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Mermaid() lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\n%s", path, got)
+	}
+}
@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Breaks reports whether c breaks compatibility mode, one of the registry
+// modes of config.CompatibilityModes in any case, and the direction it
+// breaks. Only two versions are compared, so transitive modes check as
+// their plain mode.
+//
+// As in a schema registry, BACKWARD requires that readers of the new
+// version can read records of the old one, FORWARD that readers of the old
+// version can read records of the new one, and FULL both. Added and removed
+// schemas break neither; their subjects start or stop.
+func (c Change) Breaks(mode string) (direction string, ok bool) {
+	mode = strings.TrimSuffix(strings.ToUpper(mode), "_TRANSITIVE")
+	if (mode == "BACKWARD" || mode == "FULL") && c.breaksBackward() {
+		return "backward", true
+	}
+	if (mode == "FORWARD" || mode == "FULL") && c.breaksForward() {
+		return "forward", true
+	}
+	return "", false
+}
+
+// breaksBackward reports whether new readers cannot read old records after
+// c: they lack a field that is now required and has no default to read in
+// its place, or hold a value of a type that does not promote.
+func (c Change) breaksBackward() bool {
+	switch c.Kind {
+	case FieldAdded, RequiredChanged:
+		return c.NewField.Required && c.NewField.Default == ""
+	case FieldRenamed:
+		return true
+	case TypeChanged:
+		return !promotes(c.OldField.Type, c.NewField.Type)
+	}
+	return false
+}
+
+// breaksForward reports whether old readers cannot read new records after
+// c: they lack a field the old version has no default for, even an optional
+// one, or a field that was required.
+func (c Change) breaksForward() bool {
+	switch c.Kind {
+	case FieldRemoved:
+		return c.OldField.Default == ""
+	case RequiredChanged:
+		return !c.NewField.Required && c.OldField.Default == ""
+	case FieldRenamed:
+		return true
+	case TypeChanged:
+		return !promotes(c.NewField.Type, c.OldField.Type)
+	}
+	return false
+}
+
+// promotes reports whether values of type from read as values of type to,
+// as integers read as decimals.
+func promotes(from, to string) bool {
+	switch from {
+	case "integer", "positiveInt", "unsignedInt":
+		return to == "decimal"
+	}
+	return false
+}
+
+// Check returns an error that lists the changes breaking the compatibility
+// mode of their namespace, as mode returns it, or nil when none do.
+func Check(changes []Change, mode func(namespace string) string) error {
+	var broken []string
+	for _, c := range changes {
+		m := mode(c.Namespace())
+		if direction, ok := c.Breaks(m); ok {
+			broken = append(broken, fmt.Sprintf("  %s (breaks %s compatibility of %s under %s)", c, direction, c.Namespace(), strings.ToUpper(m)))
+		}
+	}
+	switch len(broken) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("1 change breaks compatibility:\n%s", broken[0])
+	}
+	return fmt.Errorf("%d changes break compatibility:\n%s", len(broken), strings.Join(broken, "\n"))
+}
@@ -0,0 +1,90 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestBreaks(t *testing.T) {
+	old := []schema.Schema{{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+		{Name: "id", Type: "id", Required: true},
+		{Name: "phone", Type: "string"},
+		{Name: "status", Type: "code", Default: "active"},
+		{Name: "visits", Type: "integer"},
+		{Name: "gender", Type: "string"},
+	}}}
+	new := []schema.Schema{{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+		{Name: "id", Type: "id"},
+		{Name: "visits", Type: "decimal"},
+		{Name: "gender", Type: "code"},
+		{Name: "mrn", Type: "string", Required: true},
+		{Name: "active", Type: "boolean", Required: true, Default: "true"},
+		{Name: "email", Type: "string"},
+	}}}
+	changes, err := Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+
+	tests := map[string][2]bool{
+		// change: {breaks backward, breaks forward}
+		"clinic.Patient.id: no longer required":                       {false, true},
+		"clinic.Patient.visits: type changed from integer to decimal": {false, true},
+		"clinic.Patient.gender: type changed from string to code":     {true, true},
+		"clinic.Patient.mrn: added (string)":                          {true, false},
+		"clinic.Patient.active: added (boolean)":                      {false, false},
+		"clinic.Patient.email: added (string)":                        {false, false},
+		"clinic.Patient.phone: removed":                               {false, true},
+		"clinic.Patient.status: removed":                              {false, false},
+	}
+	if len(changes) != len(tests) {
+		t.Fatalf("Compare() returned %d changes, want %d: %v", len(changes), len(tests), changes)
+	}
+	for _, c := range changes {
+		want, ok := tests[c.String()]
+		if !ok {
+			t.Errorf("unexpected change %q", c)
+			continue
+		}
+		if _, got := c.Breaks("BACKWARD"); got != want[0] {
+			t.Errorf("%q breaks BACKWARD = %v, want %v", c, got, want[0])
+		}
+		if _, got := c.Breaks("forward_transitive"); got != want[1] {
+			t.Errorf("%q breaks FORWARD_TRANSITIVE = %v, want %v", c, got, want[1])
+		}
+		if _, got := c.Breaks("FULL"); got != (want[0] || want[1]) {
+			t.Errorf("%q breaks FULL = %v, want %v", c, got, want[0] || want[1])
+		}
+		if _, got := c.Breaks("NONE"); got {
+			t.Errorf("%q breaks NONE", c)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	old := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{{Name: "phone", Type: "string"}}},
+		{Name: "Claim", Namespace: "billing", Fields: []schema.Field{{Name: "memo", Type: "string"}}},
+	}
+	new := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic"},
+		{Name: "Claim", Namespace: "billing"},
+	}
+	changes, err := Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	modes := map[string]string{"clinic": "FORWARD", "billing": "BACKWARD"}
+	err = Check(changes, func(namespace string) string { return modes[namespace] })
+	if err == nil {
+		t.Fatal("Check() accepted a removed field under FORWARD")
+	}
+	if !strings.Contains(err.Error(), "clinic.Patient.phone: removed (breaks forward compatibility of clinic under FORWARD)") || strings.Contains(err.Error(), "billing") {
+		t.Errorf("Check() = %v", err)
+	}
+	if err := Check(changes, func(string) string { return "" }); err != nil {
+		t.Errorf("Check() without modes = %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Package diff compares two versions of a set of schemas. Fields are
+// matched by uid when both versions give one, and otherwise by name, so that
+// a field renamed under a stable uid is reported as renamed rather than as
+// removed and added.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Kind is the kind of a change.
+type Kind string
+
+// Kinds of changes.
+const (
+	SchemaAdded     Kind = "schema_added"
+	SchemaRemoved   Kind = "schema_removed"
+	FieldAdded      Kind = "field_added"
+	FieldRemoved    Kind = "field_removed"
+	FieldRenamed    Kind = "field_renamed"
+	TypeChanged     Kind = "type_changed"
+	RequiredChanged Kind = "required_changed"
+)
+
+// Change is one difference between two versions of a schema.
+type Change struct {
+	Kind Kind
+	// Old and New are the schema before and after the change. Old is nil
+	// for added schemas and New for removed ones.
+	Old, New *schema.Schema
+	// Path names the parents of the changed field by their new names; it is
+	// empty for top-level fields.
+	Path []string
+	// OldField and NewField are the changed field before and after the
+	// change. Both are nil for schema changes; OldField is nil for added
+	// fields and NewField for removed ones.
+	OldField, NewField *schema.Field
+}
+
+// Namespace returns the namespace of the changed schema.
+func (c Change) Namespace() string {
+	return c.schema().Namespace
+}
+
+// Schema returns the name of the changed schema.
+func (c Change) Schema() string {
+	return c.schema().GetName()
+}
+
+// Field returns the dotted path of the changed field, by its new name
+// unless it was removed, or "" for schema changes.
+func (c Change) Field() string {
+	f := c.NewField
+	if f == nil {
+		f = c.OldField
+	}
+	if f == nil {
+		return ""
+	}
+	return strings.Join(append(append([]string(nil), c.Path...), f.Name), ".")
+}
+
+func (c Change) schema() *schema.Schema {
+	if c.New != nil {
+		return c.New
+	}
+	return c.Old
+}
+
+// String describes the change on one line, such as
+// "clinic.Patient.mrn: renamed from medical_record_number".
+func (c Change) String() string {
+	name := c.Namespace() + "." + c.Schema()
+	if field := c.Field(); field != "" {
+		name += "." + field
+	}
+	switch c.Kind {
+	case SchemaAdded, FieldAdded:
+		if c.NewField != nil {
+			return fmt.Sprintf("%s: added (%s)", name, c.NewField.Type)
+		}
+		return name + ": added"
+	case SchemaRemoved, FieldRemoved:
+		return name + ": removed"
+	case FieldRenamed:
+		return fmt.Sprintf("%s: renamed from %s", name, c.OldField.Name)
+	case TypeChanged:
+		return fmt.Sprintf("%s: type changed from %s to %s", name, c.OldField.Type, c.NewField.Type)
+	case RequiredChanged:
+		if c.NewField.Required {
+			return name + ": now required"
+		}
+		return name + ": no longer required"
+	}
+	return name + ": " + string(c.Kind)
+}
+
+// Compare returns the changes from the old to the new version of a set of
+// schemas, ordered by namespace and schema name. Schemas are matched by
+// namespace and name. It reports schemas whose fields share a uid, and new
+// schemas with invalid defaults.
+func Compare(from, to []schema.Schema) ([]Change, error) {
+	key := func(s schema.Schema) string { return s.Namespace + "." + s.GetName() }
+	olds := make(map[string]*schema.Schema, len(from))
+	for i, s := range from {
+		if err := s.CheckUIDs(); err != nil {
+			return nil, fmt.Errorf("%s: %w", key(s), err)
+		}
+		olds[key(s)] = &from[i]
+	}
+	news := make(map[string]*schema.Schema, len(to))
+	for i, s := range to {
+		if err := s.CheckUIDs(); err != nil {
+			return nil, fmt.Errorf("%s: %w", key(s), err)
+		}
+		if err := s.CheckDefaults(); err != nil {
+			return nil, fmt.Errorf("%s: %w", key(s), err)
+		}
+		news[key(s)] = &to[i]
+	}
+
+	var keys []string
+	for k := range olds {
+		keys = append(keys, k)
+	}
+	for k := range news {
+		if olds[k] == nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, k := range keys {
+		o, n := olds[k], news[k]
+		switch {
+		case o == nil:
+			changes = append(changes, Change{Kind: SchemaAdded, New: n})
+		case n == nil:
+			changes = append(changes, Change{Kind: SchemaRemoved, Old: o})
+		default:
+			changes = append(changes, compareFields(o, n, nil, o.Fields, n.Fields)...)
+		}
+	}
+	return changes, nil
+}
+
+// compareFields returns the changes from the old fields at path to the new.
+func compareFields(o, n *schema.Schema, path []string, from, to []schema.Field) []Change {
+	matched := make([]int, len(to))
+	used := make([]bool, len(from))
+	for j := range to {
+		matched[j] = -1
+	}
+	// Uids match first, so that a field renamed to the old name of another
+	// keeps its identity.
+	for j, nf := range to {
+		if nf.UID == "" {
+			continue
+		}
+		for i, of := range from {
+			if !used[i] && of.UID == nf.UID {
+				matched[j], used[i] = i, true
+				break
+			}
+		}
+	}
+	// Names match fields that a uid does not tell apart: one of them has
+	// none, such as a field given its uid in the new version.
+	for j, nf := range to {
+		if matched[j] >= 0 {
+			continue
+		}
+		for i, of := range from {
+			if !used[i] && of.Name == nf.Name && (of.UID == "" || nf.UID == "") {
+				matched[j], used[i] = i, true
+				break
+			}
+		}
+	}
+
+	var changes []Change
+	for j := range to {
+		nf := &to[j]
+		if matched[j] < 0 {
+			changes = append(changes, Change{Kind: FieldAdded, Old: o, New: n, Path: path, NewField: nf})
+			continue
+		}
+		of := &from[matched[j]]
+		change := Change{Old: o, New: n, Path: path, OldField: of, NewField: nf}
+		if of.Name != nf.Name {
+			change.Kind = FieldRenamed
+			changes = append(changes, change)
+		}
+		if of.Type != nf.Type {
+			change.Kind = TypeChanged
+			changes = append(changes, change)
+		}
+		if of.Required != nf.Required {
+			change.Kind = RequiredChanged
+			changes = append(changes, change)
+		}
+		if len(of.Children) > 0 || len(nf.Children) > 0 {
+			children := append(append([]string(nil), path...), nf.Name)
+			changes = append(changes, compareFields(o, n, children, of.Children, nf.Children)...)
+		}
+	}
+	for i := range from {
+		if !used[i] {
+			changes = append(changes, Change{Kind: FieldRemoved, Old: o, New: n, Path: path, OldField: &from[i]})
+		}
+	}
+	return changes
+}
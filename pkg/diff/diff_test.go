@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestCompare(t *testing.T) {
+	old := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "medical_record_number", Type: "string", UID: "p1"},
+			{Name: "phone", Type: "string", UID: "p2"},
+			{Name: "gender", Type: "string"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "name", Type: "string", UID: "c1"},
+				{Name: "fax", Type: "string"},
+			}},
+		}},
+		{Name: "Visit", Namespace: "clinic", Fields: []schema.Field{{Name: "id", Type: "id"}}},
+	}
+	new := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", UID: "p0"},
+			{Name: "mrn", Type: "string", UID: "p1", Required: true},
+			// Same name, another uid: a different field.
+			{Name: "phone", Type: "string", UID: "p9"},
+			{Name: "gender", Type: "code"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "full_name", Type: "string", UID: "c1"},
+			}},
+		}},
+		{Name: "Claim", Namespace: "billing", Fields: []schema.Field{{Name: "id", Type: "id"}}},
+	}
+	changes, err := Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"billing.Claim: added",
+		"clinic.Patient.id: no longer required",
+		"clinic.Patient.mrn: renamed from medical_record_number",
+		"clinic.Patient.mrn: now required",
+		"clinic.Patient.phone: added (string)",
+		"clinic.Patient.gender: type changed from string to code",
+		"clinic.Patient.contact.full_name: renamed from name",
+		"clinic.Patient.contact.fax: removed",
+		"clinic.Patient.phone: removed",
+		"clinic.Visit: removed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCompareSharedUID(t *testing.T) {
+	s := schema.Schema{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+		{Name: "a", Type: "string", UID: "x"},
+		{Name: "b", Type: "string", UID: "x"},
+	}}
+	if _, err := Compare(nil, []schema.Schema{s}); err == nil {
+		t.Error("Compare() accepted fields that share a uid")
+	}
+}
@@ -0,0 +1,127 @@
+// Package events defines the change-event envelopes that ehrglot can wrap
+// schemas in, so that the types of CDC streams are generated and registered
+// from the same schemas as the records they carry.
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Names of the schemas that envelopes share within a namespace.
+const (
+	Source      = "ChangeEventSource"
+	Transaction = "ChangeEventTransaction"
+)
+
+// Ops are the Debezium operation codes: create, update, delete, read during
+// a snapshot, and truncate.
+var Ops = []string{"c", "u", "d", "r", "t"}
+
+// sourceFields are the fields of the Debezium source block that every
+// connector fills. Fields keep their Debezium names on the wire.
+var sourceFields = []schema.Field{
+	{Name: "version", Type: "string", Required: true, Description: "Version of the connector"},
+	{Name: "connector", Type: "string", Required: true, Description: "Type of the connector, such as postgresql or mysql"},
+	{Name: "name", Type: "string", Required: true, Description: "Logical name of the connector"},
+	{Name: "ts_ms", Type: "instant", JSONName: "ts_ms", Required: true, Description: "When the change was made in the database"},
+	{Name: "snapshot", Type: "string", Description: "Whether the event is part of a snapshot: true, last, or false"},
+	{Name: "db", Type: "string", Required: true, Description: "Database the change was made in"},
+	{Name: "schema", Type: "string", Description: "Schema of the changed table"},
+	{Name: "table", Type: "string", Description: "Changed table"},
+}
+
+var transactionFields = []schema.Field{
+	{Name: "id", Type: "string", Required: true, Description: "Transaction the change was made in"},
+	{Name: "total_order", Type: "integer", JSONName: "total_order", Required: true, Description: "Position of the event among the events of the transaction"},
+	{Name: "data_collection_order", Type: "integer", JSONName: "data_collection_order", Required: true, Description: "Position of the event among the events of the transaction for its table"},
+}
+
+// CloudEventType returns the CloudEvents type of events about resources of
+// s: org.ehrglot.<namespace>.<resource>.v1, with the resource name in snake
+// case, such as org.ehrglot.fhir_r4.patient.v1.
+func CloudEventType(s schema.Schema) string {
+	return "org.ehrglot." + s.Namespace + "." + naming.Snake.Apply(s.GetName()) + ".v1"
+}
+
+// Name returns the name of the envelope schema of s, such as
+// PatientChangeEvent.
+func Name(s schema.Schema) string {
+	return naming.Pascal.Apply(s.GetName()) + "ChangeEvent"
+}
+
+// Wrap returns schemas followed by a Debezium-style envelope for each schema
+// named in names, by name or as namespace/name, or for every schema when
+// names is empty. An envelope holds the record before and after the change,
+// the op, when the connector processed it, and the source and transaction
+// the change came from, whose schemas follow once per namespace. It reports
+// names that match no schema and envelopes whose names schemas already have.
+func Wrap(schemas []schema.Schema, names []string) ([]schema.Schema, error) {
+	selected := make([]bool, len(schemas))
+	for _, name := range names {
+		ns, n, qualified := strings.Cut(name, "/")
+		if !qualified {
+			ns, n = "", name
+		}
+		found := false
+		for i, s := range schemas {
+			if s.GetName() == n && (!qualified || s.Namespace == ns) {
+				selected[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no schema %s", name)
+		}
+	}
+
+	taken := make(map[string]bool, len(schemas))
+	for _, s := range schemas {
+		taken[s.Namespace+"/"+s.GetName()] = true
+	}
+	wrapped := append([]schema.Schema(nil), schemas...)
+	shared := make(map[string]bool)
+	for i, s := range schemas {
+		if len(names) > 0 && !selected[i] {
+			continue
+		}
+		if !shared[s.Namespace] {
+			shared[s.Namespace] = true
+			for _, common := range []schema.Schema{
+				{Name: Source, Description: "Where a change event came from", Fields: append([]schema.Field(nil), sourceFields...)},
+				{Name: Transaction, Description: "Transaction a change event was part of", Fields: append([]schema.Field(nil), transactionFields...)},
+			} {
+				if taken[s.Namespace+"/"+common.Name] {
+					return nil, fmt.Errorf("%s: schema %s is already defined", s.Namespace, common.Name)
+				}
+				common.Namespace, common.SourceFile = s.Namespace, s.SourceFile
+				wrapped = append(wrapped, common)
+			}
+		}
+		name := Name(s)
+		if taken[s.Namespace+"/"+name] {
+			return nil, fmt.Errorf("%s: schema %s is already defined", s.Namespace, name)
+		}
+		wrapped = append(wrapped, schema.Schema{
+			Name:        name,
+			Description: "Change to a " + s.GetName() + " record",
+			Owner:       s.Owner,
+			Steward:     s.Steward,
+			Tags:        s.Tags,
+			Namespace:   s.Namespace,
+			SourceFile:  s.SourceFile,
+			Fields: []schema.Field{
+				{Name: "before", Type: s.GetName(), Description: "Record before the change; empty for creates and reads"},
+				{Name: "after", Type: s.GetName(), Description: "Record after the change; empty for deletes"},
+				{Name: "source", Type: Source, Required: true, Description: "Where the change came from"},
+				{Name: "op", Type: "code", Required: true, Enum: Ops, Description: "c for create, u for update, d for delete, r for a snapshot read, t for truncate"},
+				{Name: "ts_ms", Type: "instant", JSONName: "ts_ms", Description: "When the connector processed the change"},
+				{Name: "transaction", Type: Transaction, Description: "Transaction the change was part of"},
+			},
+		})
+	}
+	return wrapped, nil
+}
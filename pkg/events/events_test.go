@@ -0,0 +1,65 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestWrap(t *testing.T) {
+	schemas := []schema.Schema{
+		{Resource: "Patient", Namespace: "fhir_r4", Owner: "mpi"},
+		{Resource: "Observation", Namespace: "fhir_r4"},
+		{Name: "lab_result", Namespace: "lab"},
+	}
+	names := func(schemas []schema.Schema) []string {
+		var names []string
+		for _, s := range schemas {
+			names = append(names, s.Namespace+"/"+s.GetName())
+		}
+		return names
+	}
+
+	got, err := Wrap(schemas, []string{"Patient", "lab/lab_result"})
+	if err != nil {
+		t.Fatalf("Wrap() failed: %v", err)
+	}
+	want := []string{
+		"fhir_r4/Patient", "fhir_r4/Observation", "lab/lab_result",
+		"fhir_r4/ChangeEventSource", "fhir_r4/ChangeEventTransaction", "fhir_r4/PatientChangeEvent",
+		"lab/ChangeEventSource", "lab/ChangeEventTransaction", "lab/LabResultChangeEvent",
+	}
+	if !reflect.DeepEqual(names(got), want) {
+		t.Errorf("Wrap() = %q, want %q", names(got), want)
+	}
+	event := got[5]
+	if event.Owner != "mpi" || event.Fields[0].Type != "Patient" || event.Fields[1].Type != "Patient" || !reflect.DeepEqual(event.Fields[3].Enum, Ops) {
+		t.Errorf("Wrap() envelope = %+v", event)
+	}
+
+	if got, err := Wrap(schemas, nil); err != nil || len(got) != 10 {
+		t.Errorf("Wrap(all) = %q, %v", names(got), err)
+	}
+	if _, err := Wrap(schemas, []string{"lab/Patient"}); err == nil || err.Error() != "no schema lab/Patient" {
+		t.Errorf("Wrap(lab/Patient) error = %v, want no schema", err)
+	}
+	taken := append(schemas, schema.Schema{Name: "PatientChangeEvent", Namespace: "fhir_r4"})
+	if _, err := Wrap(taken, []string{"fhir_r4/Patient"}); err == nil {
+		t.Error("Wrap() over an existing PatientChangeEvent succeeded, want error")
+	}
+}
+
+func TestCloudEventType(t *testing.T) {
+	for _, tc := range []struct {
+		s    schema.Schema
+		want string
+	}{
+		{schema.Schema{Resource: "MedicationRequest", Namespace: "fhir_r4"}, "org.ehrglot.fhir_r4.medication_request.v1"},
+		{schema.Schema{Name: "lab_result", Namespace: "lab"}, "org.ehrglot.lab.lab_result.v1"},
+	} {
+		if got := CloudEventType(tc.s); got != tc.want {
+			t.Errorf("CloudEventType(%s) = %s, want %s", tc.s.GetName(), got, tc.want)
+		}
+	}
+}
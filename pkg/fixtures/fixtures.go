@@ -0,0 +1,300 @@
+// Package fixtures provides representative schemas for exercising generators.
+package fixtures
+
+import "github.com/konzy/ehrglot/pkg/schema"
+
+// Namespace is the namespace assigned to most fixture schemas.
+const Namespace = "fixtures"
+
+// RegistryNamespace is a second namespace whose schemas the others reference.
+const RegistryNamespace = "registry"
+
+// Schemas returns a small schema set covering the shapes generators must
+// handle: primitives, optional and required fields, arrays, enums, nested
+// children, conditionally required fields, defaults, units and reference
+// ranges, derived fields, complex type references, built-in datatypes,
+// references across namespaces and in cycles, inheritance from a FHIR base
+// resource, non-ASCII text, JSON and column names set apart from field
+// names, and names that clash with target-language keywords or with each
+// other after case conversion.
+func Schemas() []schema.Schema {
+	return []schema.Schema{
+		{
+			Resource:    "Specimen",
+			Description: "A sample collected for analysis",
+			Namespace:   Namespace,
+			SourceFile:  "fixtures/specimen.yaml",
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Logical id of this artifact"},
+				{Name: "status", Type: "code", Required: true, Enum: []string{"available", "unavailable", "entered-in-error"}, Default: "available", Description: "available | unavailable | entered-in-error"},
+				{Name: "accessionIdentifier", Type: "Identifier", Description: "Identifier assigned by the lab"},
+				{Name: "receivedTime", Type: "datetime", RequiredIf: "status == available", Description: "When the specimen was received"},
+				{Name: "collectedDate", Type: "date", Description: "Collection date"},
+				{Name: "quantity", Type: "decimal", Unit: "mL", Description: "Amount collected"},
+				{Name: "containerCount", Type: "integer", Default: "1", ReferenceHigh: "4", Description: "Number of containers"},
+				{Name: "fasting", Type: "boolean", Default: "false", Description: "Whether the patient was fasting"},
+				{Name: "note", Type: "[]string", RequiredIf: "status != available", Description: "Comments"},
+				{Name: "subject", Type: "Reference", PIILevel: "high", Description: "Where the specimen came from"},
+				{Name: "request", Type: "array<Reference>", Description: "Why the specimen was collected"},
+				{Name: "visit", Type: "Visit", Description: "Visit during which the specimen was collected"},
+				{Name: "turnaroundDays", Type: "integer", Derived: "days_between(collectedDate, receivedTime)", Description: "Days from collection to receipt"},
+				{
+					Name:        "collection",
+					Type:        "BackboneElement",
+					Description: "Collection details",
+					Children: []schema.Field{
+						{Name: "collector", Type: "Reference", Description: "Who collected the specimen"},
+						{Name: "method", Type: "code", Description: "Technique used"},
+					},
+				},
+			},
+		},
+		{
+			Name:        "lab_result",
+			Description: "Résultat de laboratoire — température °C, 检验结果",
+			Namespace:   Namespace,
+			SourceFile:  "fixtures/lab_result.yaml",
+			Unique:      []schema.Index{{Fields: []string{"result_id"}}},
+			Indexes:     []schema.Index{{Fields: []string{"mrn", "resulted_at"}}},
+			Owner:       "lab-data",
+			Steward:     "ana.ruiz@example.org",
+			Tags:        []string{"clinical", "phi"},
+			Purposes:    []string{"treatment", "research"},
+			Retention:   schema.Retention{Period: "7 years", Field: "resulted_at"},
+			Fields: []schema.Field{
+				{Name: "result_id", Type: "string", Required: true, Example: "LR-1001", Description: "Identifiant du résultat"},
+				{Name: "mrn", Type: "string", Required: true, PIILevel: "critical", Encryption: "deterministic", Purposes: []string{"treatment"}, Description: "Medical record number"},
+				{Name: "value_numeric", Type: "decimal", Example: "5.4", Examples: []string{"12.0"}, Unit: "umol/L", ReferenceLow: "3.5", ReferenceHigh: "11", Description: "Valeur mesurée (µmol/L)"},
+				{Name: "value_mmol", Type: "decimal", Derived: "value_numeric / 1000", Unit: "mmol/L", Description: "Measured value in mmol/L"},
+				{Name: "charge_amount", Type: "decimal", Precision: 12, Scale: 2, Default: "0", Description: "Billed amount"},
+				{Name: "value_quantity", Type: "Quantity", Description: "Measured value with its unit"},
+				{Name: "resulted_at", Type: "instant", Description: "When the result was released"},
+				{Name: "performer_name", Type: "HumanName", Description: "Who performed the test"},
+				{Name: "ordered_by", Type: "registry.Practitioner", Description: "Who ordered the test"},
+				{Name: "raw_payload", Type: "base64Binary", JSONName: "rawPayload", DBColumn: "RAW_HL7_MSG", Description: "Original HL7 message"},
+				{Name: "ICD10Code", Type: "code", Description: "Diagnosis the test was ordered for"},
+				{Name: "reviewer_ids", Type: "[]id", Description: "Reviewing clinicians"},
+			},
+		},
+		{
+			Resource:    "Visit",
+			Description: "An encounter whose field names are reserved words in target languages",
+			Namespace:   Namespace,
+			SourceFile:  "fixtures/visit.yaml",
+			Fields: []schema.Field{
+				{Name: "class", Type: "Coding", Required: true, Description: "Classification of the visit"},
+				{Name: "type", Type: "array<CodeableConcept>", Description: "Specific type of visit"},
+				{Name: "for", Type: "Reference", Description: "Who the visit is for"},
+				{Name: "match", Type: "string", Default: "unlinked", Description: "Record linkage match grade"},
+				{Name: "order", Type: "integer", Description: "Sequence within the episode"},
+				{Name: "self", Type: "uri", Description: "Canonical URL of the visit"},
+				{Name: "where", Type: "Address", Description: "Where the visit took place"},
+				{Name: "2ndOpinion", Type: "boolean", Description: "Whether a second opinion was requested"},
+				{Name: "priorityCode", Type: "code", Description: "Priority as a code"},
+				{Name: "priority_code", Type: "string", Description: "Priority as legacy text"},
+				{Name: "visit", Type: "string", Description: "Visit number"},
+				{Name: "display", Type: "string", Derived: "concat('Visit ', visit)", Description: "Label shown for the visit"},
+				{Name: "length", Type: "Duration", Description: "Time the visit lasted"},
+				{Name: "attender", Type: "Practitioner", RequiredIf: "2ndOpinion == true", Description: "Practitioner who saw the patient"},
+				{Name: "specimens", Type: "[]Specimen", Description: "Specimens collected during the visit"},
+			},
+		},
+		{
+			Resource:    "Practitioner",
+			Description: "A person providing care",
+			Inherits:    "DomainResource",
+			Namespace:   RegistryNamespace,
+			SourceFile:  "registry/practitioner.yaml",
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Logical id of this artifact"},
+				{Name: "name", Type: "[]HumanName", Description: "The name(s) associated with the practitioner"},
+				{Name: "supervisor", Type: "Practitioner", Description: "Practitioner responsible for this one"},
+			},
+		},
+	}
+}
+
+// DICOMNamespace is the namespace of the DICOM fixture schemas.
+const DICOMNamespace = "imaging"
+
+// DICOMSchemas returns a dicom schema whose fields cover the DICOM value
+// representations generators decode: UIDs, strings, multi-valued codes,
+// person names, dates, date-times, and integer and decimal strings.
+func DICOMSchemas() []schema.Schema {
+	return []schema.Schema{
+		{
+			Name:        "ImagingSeries",
+			Style:       schema.StyleDICOM,
+			Description: "Series-level DICOM attributes",
+			Namespace:   DICOMNamespace,
+			SourceFile:  "imaging/series.yaml",
+			Fields: []schema.Field{
+				{Name: "series_instance_uid", Type: "id", Required: true, DICOMTag: "(0020,000E)", Description: "Series Instance UID"},
+				{Name: "modality", Type: "code", Required: true, DICOMTag: "(0008,0060)", Description: "Acquisition modality"},
+				{Name: "image_type", Type: "[]code", DICOMTag: "(0008,0008)", Description: "Image type values"},
+				{Name: "performing_physician", Type: "string", PIILevel: "high", DICOMTag: "(0008,1050)", Description: "Performing physician's name"},
+				{Name: "series_date", Type: "date", DICOMTag: "(0008,0021)", Description: "Date the series started"},
+				{Name: "acquired_at", Type: "datetime", DICOMTag: "(0008,002A)", Description: "Acquisition date and time"},
+				{Name: "series_number", Type: "integer", DICOMTag: "(0020,0011)", Description: "Number of the series in the study"},
+				{Name: "slice_thickness", Type: "decimal", DICOMTag: "(0018,0050)", Description: "Slice thickness in millimeters"},
+			},
+		},
+	}
+}
+
+// MatchNamespace is the namespace of the patient matching fixture schemas.
+const MatchNamespace = "mpi"
+
+// MatchSchemas returns a schema with fields of every match role: two
+// identifiers, one of them optional, names, a birth date, and a coded sex.
+func MatchSchemas() []schema.Schema {
+	return []schema.Schema{
+		{
+			Name:        "Person",
+			Description: "A person in the master patient index",
+			Namespace:   MatchNamespace,
+			SourceFile:  "mpi/person.yaml",
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Logical id of this person"},
+				{Name: "mrn", Type: "string", Required: true, PIILevel: "critical", Match: schema.MatchIdentifier, Description: "Medical record number"},
+				{Name: "ssn", Type: "string", PIILevel: "critical", Match: schema.MatchIdentifier, Description: "Social security number"},
+				{Name: "family_name", Type: "string", PIILevel: "high", Match: schema.MatchFamilyName},
+				{Name: "given_name", Type: "string", PIILevel: "high", Match: schema.MatchGivenName},
+				{Name: "birth_date", Type: "date", PIILevel: "high", Match: schema.MatchBirthDate},
+				{Name: "sex", Type: "code", Enum: []string{"male", "female", "other", "unknown"}, Match: schema.MatchSex},
+			},
+		},
+	}
+}
+
+// QuestionnaireNamespace is the namespace of the questionnaire fixture
+// schemas.
+const QuestionnaireNamespace = "forms"
+
+// QuestionnaireSchemas returns the response model of an intake
+// questionnaire, as ehrglot import questionnaire writes it but with its
+// fields renamed: fields that
+// answer items of every kind of answer, a repeating choice, a question that
+// another enables and then requires, and one enabled by a choice.
+func QuestionnaireSchemas() []schema.Schema {
+	return []schema.Schema{
+		{
+			Name:        "PatientIntakeResponse",
+			Description: "Responses to Patient intake",
+			Namespace:   QuestionnaireNamespace,
+			SourceFile:  "forms/patient_intake_response.yaml",
+			Fields: []schema.Field{
+				{Name: "chief_complaint", Type: "string", Required: true, LinkID: "1", Description: "What brings you in today?"},
+				{Name: "symptom_onset", Type: "date", LinkID: "1.1", Description: "When did it start?"},
+				{Name: "pain_score", Type: "integer", LinkID: "1.2", Description: "Pain, from 0 to 10"},
+				{Name: "smoker", Type: "boolean", LinkID: "smoking", Description: "Do you smoke?"},
+				{Name: "packs_per_day", Type: "decimal", RequiredIf: "smoker == true", EnabledIf: "smoker == true", LinkID: "smoking.packs", Description: "Packs a day"},
+				{Name: "allergies", Type: "[]code", Enum: []string{"penicillin", "latex", "peanut"}, LinkID: "allergies", Description: "Allergies"},
+				{Name: "pregnant", Type: "code", Enum: []string{"yes", "no", "unknown"}, LinkID: "pregnancy", Description: "Are you pregnant?"},
+				{Name: "due_date", Type: "date", EnabledIf: "pregnant == yes", LinkID: "pregnancy.due", Description: "Expected due date"},
+				{Name: "signed_at", Type: "datetime", LinkID: "signature", Description: "Signed at"},
+			},
+		},
+	}
+}
+
+// Crosswalk is a crosswalk from the local codes of fixture fields to OMOP
+// concepts and i2b2 paths, covering codes with only one of the two and
+// values that need quoting.
+const Crosswalk = `schema,field,local_code,concept_id,vocabulary_id,concept_code,concept_name,i2b2_path
+lab_result,ICD10Code,E11.9,201826,SNOMED,44054006,Type 2 diabetes mellitus,\i2b2\Diagnoses\E11\E11.9\
+lab_result,ICD10Code,I10,320128,SNOMED,59621000,"Essential hypertension, ""primary""",
+Specimen,status,available,4188539,SNOMED,373066001,Yes,
+Specimen,status,entered-in-error,,,,,\i2b2\Status\Error\
+`
+
+// ConceptMap is a concept map from local lab codes of the fixture namespace
+// to LOINC, covering a code with two targets, a default equivalence, an
+// unmatched code, displays that need quoting, and a second source system.
+const ConceptMap = `name: lab-to-loinc
+namespace: fixtures
+description: Local lab test codes to LOINC
+group:
+  - source: http://hospital.example/lab-codes
+    target: http://loinc.org
+    element:
+      - code: GLU
+        display: Glucose
+        target:
+          - code: 2345-7
+            display: Glucose [Mass/volume] in Serum or Plasma
+            equivalence: equivalent
+      - code: K
+        target:
+          - code: 2823-3
+            display: Potassium [Moles/volume] in Serum or Plasma
+      - code: LYTES
+        display: Electrolytes
+        target:
+          - code: 2951-2
+            display: Sodium [Moles/volume] in Serum or Plasma
+            equivalence: wider
+          - code: 2075-0
+            display: 'Chloride "Cl" [Moles/volume] in Serum or Plasma'
+            equivalence: wider
+      - code: MISC
+        target:
+          - equivalence: unmatched
+  - source: http://legacy.example/lab
+    target: http://loinc.org
+    element:
+      - code: "0012"
+        target:
+          - code: 2345-7
+            display: Glucose [Mass/volume] in Serum or Plasma
+`
+
+// Mappings returns mappings from two source systems into the fixture
+// schemas: two tables feeding one resource, a table feeding two resources,
+// sources that are expressions rather than columns, and value mappings,
+// transforms, defaults, and target context.
+func Mappings() []schema.SchemaMapping {
+	return []schema.SchemaMapping{
+		{
+			SourceSystem:   "lis",
+			SourceTable:    "SPECIMEN",
+			TargetResource: "Specimen",
+			Description:    "Specimens received by the lab",
+			FieldMappings: []schema.FieldMapping{
+				{Source: "SPEC_ID", Target: "id", Description: "Specimen number"},
+				{Source: "STATUS_C", Target: "status", ValueMapping: schema.ValueMapping{"1": "available", "2": "unavailable", "": "entered-in-error"}},
+				{Source: "RECV_DTTM", Target: "receivedTime", Transform: "datetime_to_fhir_datetime", Description: "When the lab received the specimen"},
+				{Source: "ACCESSION_NUM", Target: "accessionIdentifier.value", TargetContext: map[string]any{"accessionIdentifier.system": "urn:oid:1.2.3.4"}},
+				{Source: "CONTAINERS", Target: "containerCount", Default: 1},
+				{Source: "PAT_ID", Target: "subject.reference", Transform: "to_patient_reference"},
+				{Source: "COLLECTOR_NOTE", Target: "note[1]", Transform: "hl7_escape"},
+			},
+		},
+		{
+			SourceSystem:   "lis",
+			SourceTable:    "SPECIMEN_ARCHIVE",
+			TargetResource: "Specimen",
+			FieldMappings: []schema.FieldMapping{
+				{Source: "SPEC_ID", Target: "id"},
+			},
+		},
+		{
+			SourceSystem:   "ehr",
+			SourceTable:    "VISIT",
+			TargetResource: "Visit",
+			Description:    "Visits,\nwith their \"class\"",
+			FieldMappings: []schema.FieldMapping{
+				{Source: "VISIT_ID", Target: "visit"},
+				{Source: "CLASS_CODE || '-' || CLASS_SUB", Target: "class.code"},
+			},
+		},
+		{
+			SourceSystem:   "ehr",
+			SourceTable:    "VISIT",
+			TargetResource: "Practitioner",
+			FieldMappings: []schema.FieldMapping{
+				{Source: "ATTENDING_ID", Target: "id", Description: "Attending provider"},
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+// Package format runs formatters over generated code, so that it passes the
+// format checks of the projects that vendor it.
+package format
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	goformat "go/format"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Formatters built in, which need no tool installed.
+const (
+	// Gofmt formats Go files as gofmt does.
+	Gofmt = "gofmt"
+	// Goimports runs goimports -w when it is installed, and falls back to
+	// Gofmt, which also sorts imports, when it is not.
+	Goimports = "goimports"
+)
+
+// Run runs formatters over the files under dir, in order. Each formatter is
+// a built-in name or a command, split on spaces, run in dir. Commands that
+// are not installed are skipped; Run returns a note for each of them. A
+// formatter that fails, such as on code it cannot parse, fails Run.
+func Run(ctx context.Context, dir string, formatters []string) ([]string, error) {
+	var notes []string
+	for _, formatter := range formatters {
+		if err := ctx.Err(); err != nil {
+			return notes, err
+		}
+		args := strings.Fields(formatter)
+		if len(args) == 0 {
+			continue
+		}
+		switch {
+		case formatter == Gofmt:
+			if err := gofmt(dir); err != nil {
+				return notes, err
+			}
+			continue
+		case formatter == Goimports:
+			if _, err := exec.LookPath(Goimports); err != nil {
+				notes = append(notes, "goimports not found on PATH; formatted with gofmt instead")
+				if err := gofmt(dir); err != nil {
+					return notes, err
+				}
+				continue
+			}
+			args = []string{Goimports, "-w", "."}
+		}
+		if _, err := exec.LookPath(args[0]); err != nil {
+			notes = append(notes, fmt.Sprintf("%s not found on PATH; skipped formatter %q", args[0], formatter))
+			continue
+		}
+		var out bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = dir
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		if err := cmd.Run(); err != nil {
+			return notes, fmt.Errorf("formatter %q failed: %w\n%s", formatter, err, strings.TrimSpace(out.String()))
+		}
+	}
+	return notes, nil
+}
+
+// gofmt formats the Go files under dir in place, leaving those already
+// formatted untouched.
+func gofmt(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		formatted, err := goformat.Source(src)
+		if err != nil {
+			rel, _ := filepath.Rel(dir, path)
+			return fmt.Errorf("gofmt: %s: %w", filepath.ToSlash(rel), err)
+		}
+		if bytes.Equal(src, formatted) {
+			return nil
+		}
+		return os.WriteFile(path, formatted, 0644)
+	})
+}
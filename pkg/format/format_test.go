@@ -0,0 +1,62 @@
+package format
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunGofmt(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "models", "types.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("package models\ntype Patient struct {\nID string\nActive   bool\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	notes, err := Run(context.Background(), dir, []string{Gofmt})
+	if err != nil || len(notes) > 0 {
+		t.Fatalf("Run() = %q, %v", notes, err)
+	}
+	got, _ := os.ReadFile(path)
+	want := "package models\n\ntype Patient struct {\n\tID     string\n\tActive bool\n}\n"
+	if string(got) != want {
+		t.Errorf("formatted file =\n%s\nwant\n%s", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("package models\ntype {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Run(context.Background(), dir, []string{Gofmt}); err == nil || !strings.Contains(err.Error(), "models/types.go") {
+		t.Errorf("Run() of invalid Go error = %v, want the file named", err)
+	}
+}
+
+func TestRunCommands(t *testing.T) {
+	dir := t.TempDir()
+	notes, err := Run(context.Background(), dir, []string{"ehrglot-no-such-formatter --write ."})
+	if err != nil {
+		t.Fatalf("Run() of a missing formatter failed: %v", err)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "ehrglot-no-such-formatter not found") {
+		t.Errorf("Run() notes = %q, want the missing formatter", notes)
+	}
+
+	if _, err := exec.LookPath("touch"); err != nil {
+		t.Skip("touch not found")
+	}
+	if _, err := Run(context.Background(), dir, []string{"touch formatted"}); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "formatted")); err != nil {
+		t.Errorf("formatter not run in the output directory: %v", err)
+	}
+	if _, err := Run(context.Background(), dir, []string{"touch"}); err == nil || !strings.Contains(err.Error(), `formatter "touch" failed`) {
+		t.Errorf("Run() of a failing formatter error = %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+// Package asyncapi generates AsyncAPI documents describing the event
+// channels of the resources of schemas, with JSON Schemas of their payloads.
+package asyncapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/events"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/registry"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
+)
+
+const Version = "0.1.0"
+
+// SchemasDir is the directory, within the directory of a namespace, that
+// holds the JSON Schemas of its payloads.
+const SchemasDir = "schemas"
+
+// Generator generates AsyncAPI documents from schemas.
+type Generator struct {
+	version   string
+	audit     bool
+	header    *header.Header
+	templates templates.Cache
+}
+
+// NewGenerator creates a new AsyncAPI generator.
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{version: cfg.PackageInfo("asyncapi").Version, audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+	if g.version == "" {
+		g.version = "1.0.0"
+	}
+	return g
+}
+
+// channel is the channel of the events of a resource.
+type channel struct {
+	Schema schema.Schema
+	Key    string
+	// Address is the CloudEvents type of the events.
+	Address string
+	// Payload is the path of the JSON Schema of the resource, relative to
+	// the document.
+	Payload string
+}
+
+// Generate writes an asyncapi.yaml AsyncAPI 3.0 document per namespace,
+// with a channel per resource carrying its events, and the JSON Schema of
+// each resource, as ehrglot publish json-schema registers it, which the
+// messages of the channels refer to as their payload. Channels are
+// addressed by the CloudEvents types of the events. Namespaces of built-in
+// datatypes only have no events and get no document.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	subjects, err := registry.Subjects(schemas, registry.JSONSchema, registry.Options{})
+	if err != nil {
+		return err
+	}
+	payloads := make(map[string][]byte, len(subjects))
+	for _, sub := range subjects {
+		payloads[sub.Name] = sub.Schema
+	}
+
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var channels []channel
+		for _, s := range refs.Namespace(namespace) {
+			payload, ok := payloads[s.Namespace+"."+s.GetName()]
+			if !ok {
+				continue
+			}
+			channels = append(channels, channel{
+				Schema:  s,
+				Key:     naming.Snake.Apply(s.GetName()),
+				Address: events.CloudEventType(s),
+				Payload: "./" + SchemasDir + "/" + s.GetName() + ".json",
+			})
+			dir := filepath.Join(outputDir, g.NamespaceDir(namespace), SchemasDir)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			if err := writeJSON(filepath.Join(dir, s.GetName()+".json"), payload); err != nil {
+				return err
+			}
+		}
+		if len(channels) == 0 {
+			continue
+		}
+		if err := g.generateDocument(namespace, channels, filepath.Join(outputDir, g.NamespaceDir(namespace), "asyncapi.yaml")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateDocument writes the AsyncAPI document of the channels of a
+// namespace.
+func (g *Generator) generateDocument(namespace string, channels []channel, path string) error {
+	tmpl := `{{header}}
+asyncapi: 3.0.0
+info:
+  title: {{printf "%s events" .Namespace | quote}}
+  version: {{quote .Version}}
+  description: {{printf "Events about the resources of the %s namespace, in the CloudEvents format." .Namespace | quote}}
+defaultContentType: application/json
+channels:
+{{- range .Channels}}
+  {{.Key}}:
+    address: {{quote .Address}}
+    {{- with .Schema.Description}}
+    description: {{quote .}}
+    {{- end}}
+    messages:
+      {{.Schema.GetName}}:
+        $ref: "#/components/messages/{{.Schema.GetName}}"
+{{- end}}
+components:
+  messages:
+{{- range .Channels}}
+    {{.Schema.GetName}}:
+      name: {{quote .Schema.GetName}}
+      title: {{quote .Schema.GetName}}
+      {{- with .Schema.Description}}
+      summary: {{quote .}}
+      {{- end}}
+      contentType: application/json
+      payload:
+        $ref: {{quote .Payload}}
+{{- end}}
+`
+	var sources []schema.Schema
+	for _, c := range channels {
+		sources = append(sources, c.Schema)
+	}
+	funcMap := template.FuncMap{
+		"quote":  quote,
+		"header": g.header.Func("# ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Version   string
+		Channels  []channel
+	}{
+		Namespace: namespace,
+		Version:   g.version,
+		Channels:  channels,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// quote returns s as a double-quoted YAML scalar on one line.
+func quote(s string) string {
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.Encode(strings.TrimSpace(s))
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// writeJSON writes the compact JSON data indented, for review.
+func writeJSON(path string, data []byte) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	out.WriteByte('\n')
+	if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
+// GenerateMappings generates nothing: mappings have no events.
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
+	return nil
+}
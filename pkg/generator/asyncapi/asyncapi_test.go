@@ -0,0 +1,13 @@
+package asyncapi_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/generator/asyncapi"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, asyncapi.NewGenerator(config.Config{}))
+}
@@ -0,0 +1,309 @@
+// Package cddl generates CDDL (RFC 8610) definitions of the CBOR payloads of
+// schemas.
+package cddl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
+)
+
+const Version = "0.1.0"
+
+// prelude holds the names the CDDL prelude defines, which rules must not
+// redefine.
+var prelude = map[string]bool{
+	"any": true, "uint": true, "nint": true, "int": true, "bstr": true, "bytes": true,
+	"tstr": true, "text": true, "tdate": true, "time": true, "number": true,
+	"biguint": true, "bignint": true, "bigint": true, "integer": true, "unsigned": true,
+	"decfrac": true, "bigfloat": true, "eb64url": true, "eb64legacy": true, "eb16": true,
+	"encoded-cbor": true, "uri": true, "b64url": true, "b64legacy": true, "regexp": true,
+	"mime-message": true, "cbor-any": true, "float16": true, "float32": true, "float64": true,
+	"float16-32": true, "float32-64": true, "float": true, "false": true, "true": true,
+	"bool": true, "nil": true, "null": true, "undefined": true,
+}
+
+// bareword matches the member keys CDDL takes as text without quotes.
+var bareword = regexp.MustCompile(`^[A-Za-z@_$](?:[-.]*[A-Za-z@_$0-9])*$`)
+
+// Generator generates CDDL definitions from schemas.
+type Generator struct {
+	naming    config.Naming
+	dateType  string
+	audit     bool
+	header    *header.Header
+	templates templates.Cache
+}
+
+// NewGenerator creates a new CDDL generator.
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("cddl").Naming, dateType: `tstr .regexp "[0-9]{4}-[0-9]{2}-[0-9]{2}"`, audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+	if cfg.TemporalPolicy("cddl").Dates == config.DatesPartial {
+		g.dateType = `tstr .regexp "[0-9]{4}(-[0-9]{2}(-[0-9]{2})?)?"`
+	}
+	return g
+}
+
+// Generate writes a .cddl file per namespace defining a rule for each of its
+// schemas. Each file also defines the schemas of other namespaces its rules
+// refer to, so that it validates payloads on its own. Payloads hold values as
+// FHIR JSON does, so that records converted from JSON to CBOR validate:
+// dates, instants, and binary as text, and decimals as numbers.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
+		if err := os.MkdirAll(nsDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		path := filepath.Join(nsDir, naming.Snake.Apply(namespace)+".cddl")
+		if err := g.generateRules(refs, namespace, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rule is a CDDL rule defining the map of a schema.
+type rule struct {
+	Schema  schema.Schema
+	Name    string
+	Members []member
+}
+
+// member is a member of the map of a rule.
+type member struct {
+	Key      string
+	Type     string
+	Optional bool
+	Doc      string
+}
+
+// generateRules writes the rules of the schemas of namespace, followed by
+// those of the schemas of other namespaces they refer to.
+func (g *Generator) generateRules(refs *resolve.Resolver, namespace, path string) error {
+	local := refs.Namespace(namespace)
+	var foreign []schema.Schema
+	seen := make(map[string]bool)
+	var visit func(s schema.Schema)
+	visit = func(s schema.Schema) {
+		for _, dep := range refs.Dependencies(s) {
+			key := dep.Namespace + "." + dep.GetName()
+			if dep.Namespace == namespace || seen[key] {
+				continue
+			}
+			seen[key] = true
+			visit(dep)
+			foreign = append(foreign, dep)
+		}
+	}
+	for _, s := range local {
+		visit(s)
+	}
+
+	for _, s := range local {
+		if err := s.CheckDefaults(); err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+	}
+	var rules, foreignRules []rule
+	for _, s := range local {
+		rules = append(rules, g.rule(refs, namespace, s))
+	}
+	for _, s := range foreign {
+		foreignRules = append(foreignRules, g.rule(refs, namespace, s))
+	}
+
+	tmpl := `{{header}}
+{{- range .Rules}}
+{{template "rule" .}}
+{{end}}
+{{- with .Foreign}}
+; Types of other namespaces the rules above refer to.
+{{range .}}
+{{template "rule" .}}
+{{end}}
+{{- end}}
+{{- define "rule"}}
+{{- with .Schema.Description}}{{comment .}}
+{{end}}
+{{- .Name}} = {
+{{- range .Members}}
+  {{if .Optional}}? {{end}}{{.Key}}: {{.Type}},{{with .Doc}} ; {{.}}{{end}}
+{{- end}}
+}
+{{- end}}`
+	funcMap := template.FuncMap{
+		"comment": comment,
+		"header":  g.header.Func("; ", local...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Rules   []rule
+		Foreign []rule
+	}{
+		Rules:   rules,
+		Foreign: foreignRules,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// rule returns the rule of s as the file of namespace names it. Resources
+// hold their resourceType, as FHIR JSON does.
+func (g *Generator) rule(refs *resolve.Resolver, namespace string, s schema.Schema) rule {
+	scope := refs.Scope(s.Namespace)
+	r := rule{Schema: s, Name: g.ruleName(namespace, s)}
+	if s.Resource != "" {
+		r.Members = append(r.Members, member{Key: "resourceType", Type: strconv.Quote(s.Resource)})
+	}
+	for _, f := range s.StoredFields() {
+		r.Members = append(r.Members, g.member(scope, namespace, f))
+	}
+	return r
+}
+
+func (g *Generator) member(scope resolve.Scope, namespace string, f schema.Field) member {
+	key := f.WireName(f.Name)
+	if !bareword.MatchString(key) {
+		key = strconv.Quote(key)
+	}
+	return member{Key: key, Type: g.fieldType(scope, namespace, f), Optional: !f.Required, Doc: oneLine(f.Description)}
+}
+
+// fieldType returns the CDDL type of the values of f.
+func (g *Generator) fieldType(scope resolve.Scope, namespace string, f schema.Field) string {
+	elem, list := strings.CutPrefix(f.Type, "[]")
+	var t string
+	switch {
+	case len(f.Children) > 0:
+		var members []string
+		for _, child := range f.Children {
+			m := g.member(scope, namespace, child)
+			optional := ""
+			if m.Optional {
+				optional = "? "
+			}
+			members = append(members, optional+m.Key+": "+m.Type)
+		}
+		t = "{ " + strings.Join(members, ", ") + " }"
+	case len(f.Enum) > 0:
+		values := make([]string, len(f.Enum))
+		for i, v := range f.Enum {
+			values[i] = strconv.Quote(v)
+		}
+		t = strings.Join(values, " / ")
+		if list {
+			t = "(" + t + ")"
+		}
+	default:
+		t = g.toCDDLType(scope, namespace, elem)
+	}
+	if list {
+		return "[* " + t + "]"
+	}
+	return t
+}
+
+func (g *Generator) toCDDLType(scope resolve.Scope, namespace, yamlType string) string {
+	if target, ok := scope.Lookup(yamlType); ok {
+		return g.ruleName(namespace, target)
+	}
+	switch yamlType {
+	case "string", "code", "id", "markdown", "time", "uri", "url", "canonical", "oid", "uuid", "datetime", "dateTime", "instant", "base64Binary":
+		return "tstr"
+	case "integer":
+		return "int"
+	case "unsignedInt":
+		return "uint"
+	case "positiveInt":
+		return "uint .ge 1"
+	case "decimal":
+		return "number"
+	case "boolean":
+		return "bool"
+	case "date":
+		return g.dateType
+	default:
+		return "any"
+	}
+}
+
+// ruleName returns the name of the rule of s in the file of namespace:
+// kebab-case by default, and qualified by its namespace when s belongs to
+// another one.
+func (g *Generator) ruleName(namespace string, s schema.Schema) string {
+	name := naming.Case(g.naming.Types).Or(kebabCase)(s.GetName())
+	if prelude[name] {
+		name += "-type"
+	}
+	if s.Namespace != namespace {
+		name = naming.Snake.Apply(s.Namespace) + "." + name
+	}
+	return name
+}
+
+func kebabCase(s string) string {
+	return strings.ToLower(strings.Join(naming.Words(s), "-"))
+}
+
+// comment returns text as CDDL comment lines.
+func comment(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("; "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// oneLine returns the first line of a description, for a trailing comment.
+func oneLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
+// GenerateMappings generates nothing: mappings have no CBOR form.
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
+	return nil
+}
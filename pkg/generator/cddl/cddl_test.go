@@ -0,0 +1,22 @@
+package cddl_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/cddl"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, cddl.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Temporal: config.Temporal{Dates: config.DatesPartial},
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, cddl.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
@@ -0,0 +1,190 @@
+package csharp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateBundle writes a builder that collects the resources of a namespace
+// into a FHIR transaction Bundle.
+func (g *Generator) generateBundle(resources []schema.Schema, namespace string, path string) error {
+	tmpl := `// FHIR transaction Bundle building for the models of this namespace.
+//
+{{header}}
+using System;
+using System.Collections.Generic;
+using System.Text.Json;
+using System.Text.Json.Nodes;
+using System.Text.Json.Serialization;
+
+namespace {{.Namespace}}
+{
+    /// <summary>
+    /// A FHIR transaction Bundle of resources of this namespace.
+    /// </summary>
+    public class Bundle
+    {
+        [JsonPropertyName("resourceType")]
+        public string ResourceType { get; set; } = "Bundle";
+
+        [JsonPropertyName("type")]
+        public string Type { get; set; } = "transaction";
+
+        [JsonPropertyName("entry")]
+        public List<BundleEntry> Entry { get; set; } = new List<BundleEntry>();
+    }
+
+    /// <summary>
+    /// An entry of a transaction Bundle: a resource and the request that
+    /// stores it.
+    /// </summary>
+    public class BundleEntry
+    {
+        [JsonPropertyName("fullUrl")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+        public string? FullUrl { get; set; }
+
+        [JsonPropertyName("resource")]
+        public JsonObject Resource { get; set; } = new JsonObject();
+
+        [JsonPropertyName("request")]
+        public BundleRequest Request { get; set; } = new BundleRequest();
+    }
+
+    /// <summary>
+    /// The request of a transaction Bundle entry.
+    /// </summary>
+    public class BundleRequest
+    {
+        [JsonPropertyName("method")]
+        public string Method { get; set; } = "";
+
+        [JsonPropertyName("url")]
+        public string Url { get; set; } = "";
+
+        [JsonPropertyName("ifNoneExist")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+        public string? IfNoneExist { get; set; }
+    }
+
+    /// <summary>
+    /// Collects resources of this namespace into a transaction Bundle, which
+    /// a FHIR server stores all or nothing.
+    /// </summary>
+    public class BundleBuilder
+    {
+        private static readonly Dictionary<Type, string> ResourceTypes = new Dictionary<Type, string>
+        {
+{{- range .Resources}}
+            [typeof({{className .}})] = {{quote .GetName}},
+{{- end}}
+        };
+
+        private readonly List<BundleEntry> entries = new List<BundleEntry>();
+
+        /// <summary>
+        /// The FHIR base URL of the server, such as https://fhir.example.org/r4.
+        /// It makes the fullUrl of updated resources; when null, they have
+        /// none.
+        /// </summary>
+        public string? BaseUrl { get; set; }
+
+        /// <summary>
+        /// Adds an entry that creates resource, such as a {{className .Example}}, and
+        /// returns its fullUrl: a new urn:uuid that references from other
+        /// resources of the Bundle can use, and that the server replaces with
+        /// the id it assigns. Given ifNoneExist, a search query such as
+        /// identifier=http://hospital.example|123, the server creates the
+        /// resource only when nothing matches it.
+        /// </summary>
+        public string Create(object resource, string? ifNoneExist = null)
+        {
+            var (resourceType, body) = ToResource(resource);
+            var fullUrl = "urn:uuid:" + Guid.NewGuid();
+            entries.Add(new BundleEntry
+            {
+                FullUrl = fullUrl,
+                Resource = body,
+                Request = new BundleRequest { Method = "POST", Url = resourceType, IfNoneExist = ifNoneExist },
+            });
+            return fullUrl;
+        }
+
+        /// <summary>
+        /// Adds an entry that creates or replaces resource as the resource
+        /// with id, which must match the id resource holds, if any. Returns
+        /// the entry's fullUrl, which is null without a BaseUrl.
+        /// </summary>
+        public string? Update(object resource, string id)
+        {
+            var (resourceType, body) = ToResource(resource);
+            var url = resourceType + "/" + Uri.EscapeDataString(id);
+            var fullUrl = BaseUrl == null ? null : BaseUrl.TrimEnd('/') + "/" + url;
+            entries.Add(new BundleEntry
+            {
+                FullUrl = fullUrl,
+                Resource = body,
+                Request = new BundleRequest { Method = "PUT", Url = url },
+            });
+            return fullUrl;
+        }
+
+        /// <summary>
+        /// Returns the transaction Bundle of the entries added so far.
+        /// </summary>
+        public Bundle Build()
+        {
+            return new Bundle { Entry = new List<BundleEntry>(entries) };
+        }
+
+        private static (string, JsonObject) ToResource(object resource)
+        {
+            if (!ResourceTypes.TryGetValue(resource.GetType(), out var resourceType))
+            {
+                throw new ArgumentException($"{resource.GetType()} is not a resource of this namespace", nameof(resource));
+            }
+            // FHIR JSON has no nulls, so unset properties are left out
+            var body = new JsonObject { ["resourceType"] = resourceType };
+            foreach (var field in JsonSerializer.SerializeToNode(resource, resource.GetType())!.AsObject())
+            {
+                if (field.Key != "resourceType" && field.Value != null)
+                {
+                    body[field.Key] = field.Value.DeepClone();
+                }
+            }
+            return (resourceType, body);
+        }
+    }
+}
+`
+	funcMap := template.FuncMap{
+		"className": g.className,
+		"quote":     strconv.Quote,
+		"header":    g.header.Func("// ", resources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Resources []schema.Schema
+		Example   schema.Schema
+	}{
+		Namespace: g.namespaceName(namespace),
+		Resources: resources,
+		Example:   resources[0],
+	}
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,110 @@
+package csharp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// conceptMapTable is the lookup table of a concept map and the method
+// translating codes by it.
+type conceptMapTable struct {
+	Map     conceptmap.ConceptMap
+	Field   string
+	Method  string
+	Sources []conceptmap.Source
+}
+
+// generateConceptMaps writes the methods that translate codes by the concept
+// maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, namespace string, path string) error {
+	var tables []conceptMapTable
+	for _, m := range maps {
+		name := naming.Pascal.Apply(m.Name)
+		tables = append(tables, conceptMapTable{
+			Map:     m,
+			Field:   name + "Targets",
+			Method:  "Translate" + name,
+			Sources: m.Sources(),
+		})
+	}
+
+	tmpl := `// Translations of codes by concept maps.
+//
+{{header}}
+using System;
+using System.Collections.Generic;
+
+namespace {{.Namespace}}
+{
+    /// <summary>
+    /// A code that a concept map translates a source code to, and how
+    /// equivalent the two are.
+    /// </summary>
+    public sealed record ConceptMapTarget(string System, string Code, string Display, string Equivalence);
+
+    /// <summary>
+    /// Translates codes by the concept maps of this namespace.
+    /// </summary>
+    public static class ConceptMaps
+    {
+{{- range $i, $t := .Tables}}
+{{- if $i}}
+{{end}}
+        // The concept map {{.Map.Name}}, keyed by source system and code.
+        private static readonly Dictionary<(string System, string Code), ConceptMapTarget[]> {{.Field}} = new()
+        {
+{{- range .Sources}}
+            [({{quote .System}}, {{quote .Code}})] = new[]
+            {
+{{- range .Translations}}
+                new ConceptMapTarget({{quote .System}}, {{quote .Code}}, {{quote .Display}}, {{quote .Equivalence}}),
+{{- end}}
+            },
+{{- end}}
+        };
+
+        /// <summary>
+        /// Returns the codes that code of system translates to by the concept
+        /// map {{.Map.Name}}, if any.
+        /// </summary>
+{{- with .Map.Description}}
+        /// <remarks>{{line .}}</remarks>
+{{- end}}
+        public static IReadOnlyList<ConceptMapTarget> {{.Method}}(string system, string code) =>
+            {{.Field}}.TryGetValue((system, code), out var targets) ? Array.AsReadOnly(targets) : Array.Empty<ConceptMapTarget>();
+{{- end}}
+    }
+}
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"line":   func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"header": g.header.Func("// "),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Tables    []conceptMapTable
+	}{
+		Namespace: g.namespaceName(namespace),
+		Tables:    tables,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -2,45 +2,136 @@
 package csharp
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
 // Generator generates C# code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	dateType    string
+	ucum        bool
+	bulk        bool
+	bundle      bool
+	resolver    bool
+	diff        bool
+	client      []string
+	conceptMaps []string
+	redact      string
+	audit       bool
+	header      *header.Header
+	templates   templates.Cache
+}
 
-// NewGenerator creates a new C# code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+// NewGenerator creates a new C# code generator. Instants are always
+// DateTimeOffset, which keeps the offset under either temporal policy.
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("csharp").Naming, dateType: "DateOnly", ucum: cfg.UCUM, bulk: cfg.Bulk, bundle: cfg.Bundle, resolver: cfg.Resolver, diff: cfg.Diff, client: cfg.Client.Resources, conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+	if cfg.TemporalPolicy("csharp").Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
-// Generate generates C# classes from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+// Generate generates C# classes from schemas. Schemas that inherit a FHIR
+// base resource derive from an abstract class generated for it.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(datatypes.WithBases(schemas))
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
+		if g.dateType == "PartialDate" {
+			if err := g.generatePartialDate(namespace, filepath.Join(nsDir, "PartialDate.cs")); err != nil {
+				return err
+			}
+		}
+
 		// Generate each schema file
 		for _, s := range nsSchemas {
-			filename := s.GetName() + ".cs"
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			filename := naming.Case(g.naming.Files).Apply(s.GetName()) + ".cs"
 			path := filepath.Join(nsDir, filename)
-			if err := g.generateClass(s, namespace, path); err != nil {
+			if err := g.generateClass(refs.Scope(namespace), s, path); err != nil {
+				return err
+			}
+
+			if g.ucum && datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+				if err := g.generateUCUM(s, namespace, filepath.Join(nsDir, "Ucum.cs")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bulk && len(resources) > 0 {
+			if err := g.generateBulk(resources, namespace, filepath.Join(nsDir, "BulkLoader.cs")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bundle && len(resources) > 0 {
+			if err := g.generateBundle(resources, namespace, filepath.Join(nsDir, "BundleBuilder.cs")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.resolver && len(resources) > 0 {
+			if err := g.generateResolver(g.resolverModels(resources), namespace, filepath.Join(nsDir, "Resolver.cs")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.diffModels(nsSchemas); g.diff && len(models) > 0 {
+			if err := g.generateDiff(models, namespace, filepath.Join(nsDir, "JsonPatch.cs")); err != nil {
+				return err
+			}
+		}
+
+		if resources := clientResources(nsSchemas, g.client); len(resources) > 0 {
+			if err := g.generateClient(resources, namespace, filepath.Join(nsDir, "FhirClient.cs")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, namespace, filepath.Join(nsDir, "ConceptMaps.cs")); err != nil {
 				return err
 			}
 		}
@@ -49,38 +140,74 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 	return nil
 }
 
-func (g *Generator) generateClass(s schema.Schema, namespace string, path string) error {
+func (g *Generator) generateClass(scope resolve.Scope, s schema.Schema, path string) error {
+	if err := s.CheckDefaults(); err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
 	tmpl := `// {{.Schema.Description}}
 //
-// Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-// DO NOT EDIT.
-
+{{header}}
 using System;
+{{- if or .Lists .Redacted}}
+using System.Collections.Generic;
+{{- end}}
+{{- if .Redacted}}
+using System.Linq;
+{{- end}}
 using System.Text.Json.Serialization;
+{{- range .Usings}}
+using {{.}};
+{{- end}}
 
 namespace {{.Namespace}}
 {
     /// <summary>
     /// {{.Schema.Description}}
     /// </summary>
-    public class {{.Schema | schemaName}}
+{{- with .Schema.Docs}}
+    /// <remarks>
+{{- range .}}
+    /// {{.}}
+{{- end}}
+    /// </remarks>
+{{- end}}
+    public {{if .Abstract}}abstract {{end}}class {{.Schema | schemaName}}{{with .Base}} : {{.}}{{end}}
     {
-{{range .Schema.Fields}}        [JsonPropertyName("{{.Name | camel}}")]
-        public {{. | csharpType}} {{.Name | pascal}} { get; set; }
+{{range .Fields}}        [JsonPropertyName("{{.Wire}}")]
+        public {{.Field | csharpType}} {{.Ident}} { get; set; }{{with default .Field}} = {{.}};{{end}}
 
+{{end}}
+{{- if .Redacted}}        /// <summary>
+        /// Returns the properties of this record, with those at pii_level {{.Level}}
+        /// or above masked, for logging.
+        /// </summary>
+        public Dictionary<string, object?> RedactForLogging() => new Dictionary<string, object?>
+        {
+{{- range .Fields}}
+            ["{{.Wire}}"] = {{if .Sensitive $.Level}}"` + schema.Redacted + `"{{else}}{{.Ident}}{{end}},
+{{- end}}
+        };
+
+        /// <summary>
+        /// Formats this record with its properties at pii_level {{.Level}} or above masked.
+        /// </summary>
+        public override string ToString() =>
+            "{{.Schema | schemaName}} { " + string.Join(", ", RedactForLogging().Select(p => $"{p.Key} = {p.Value}")) + " }";
 {{end}}    }
 }
 `
 	funcMap := template.FuncMap{
-		"camel":      toCamelCase,
-		"pascal":     toPascalCase,
-		"csharpType": toCSharpType,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"csharpType": func(f schema.Field) string {
+			return g.toCSharpType(scope, f)
+		},
+		"schemaName": g.className,
+		"default":    defaultLiteral,
+		"header":     g.header.Func("// ", s),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -89,35 +216,299 @@ namespace {{.Namespace}}
 	}
 	defer f.Close()
 
-	// Convert namespace to C# namespace (PascalCase)
-	csharpNamespace := toPascalCase(strings.ReplaceAll(namespace, "_", "."))
-
 	data := struct {
 		Schema    schema.Schema
+		Fields    []naming.Field
+		Base      string
+		Abstract  bool
 		Namespace string
+		Usings    []string
+		Level     string
+		Redacted  bool
+		Lists     bool
 	}{
 		Schema:    s,
-		Namespace: csharpNamespace,
+		Fields:    g.fields(s),
+		Level:     g.redact,
+		Redacted:  s.HasSensitive(g.redact),
+		Abstract:  datatypes.IsBase(s),
+		Namespace: g.namespaceName(scope.Namespace),
+		Usings:    g.usings(scope, s),
+	}
+	if base, ok := scope.Lookup(s.Inherits); s.Inherits != "" && ok {
+		data.Base = g.className(base)
+	}
+	for _, f := range data.Fields {
+		if strings.Contains(g.toCSharpType(scope, f.Field), "List<") {
+			data.Lists = true
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generatePartialDate writes the PartialDate type used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generatePartialDate(namespace string, path string) error {
+	tmpl := `// Partial date support for FHIR dates.
+//
+{{header}}
+using System;
+using System.Globalization;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Text.RegularExpressions;
+
+namespace {{.}}
+{
+    /// <summary>
+    /// A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+    /// </summary>
+    [JsonConverter(typeof(PartialDateJsonConverter))]
+    public readonly record struct PartialDate
+    {
+        private static readonly Regex Pattern = new Regex(@"^(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?$");
+
+        public PartialDate(int year, int? month = null, int? day = null)
+        {
+            if (day != null && month == null)
+            {
+                throw new ArgumentException("a partial date with a day must have a month");
+            }
+            // Reject impossible dates such as 1970-02-30
+            _ = new DateOnly(year, month ?? 1, day ?? 1);
+            Year = year;
+            Month = month;
+            Day = day;
+        }
+
+        public int Year { get; }
+        public int? Month { get; }
+        public int? Day { get; }
+
+        /// <summary>
+        /// Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+        /// </summary>
+        public static PartialDate Parse(string value)
+        {
+            var match = Pattern.Match(value);
+            if (!match.Success)
+            {
+                throw new FormatException($"invalid partial date: {value}");
+            }
+            int? Group(int i) => match.Groups[i].Success ? int.Parse(match.Groups[i].Value, CultureInfo.InvariantCulture) : null;
+            return new PartialDate(Group(1)!.Value, Group(2), Group(3));
+        }
+
+        /// <summary>
+        /// Returns the full date, or null when the value is less precise than a day.
+        /// </summary>
+        public DateOnly? ToDateOnly() =>
+            Month is int month && Day is int day ? new DateOnly(Year, month, day) : null;
+
+        public override string ToString()
+        {
+            var text = Year.ToString("D4", CultureInfo.InvariantCulture);
+            if (Month is int month)
+            {
+                text += "-" + month.ToString("D2", CultureInfo.InvariantCulture);
+            }
+            if (Day is int day)
+            {
+                text += "-" + day.ToString("D2", CultureInfo.InvariantCulture);
+            }
+            return text;
+        }
+    }
+
+    /// <summary>
+    /// Serializes PartialDate as a JSON string.
+    /// </summary>
+    public sealed class PartialDateJsonConverter : JsonConverter<PartialDate>
+    {
+        public override PartialDate Read(ref Utf8JsonReader reader, Type typeToConvert, JsonSerializerOptions options) =>
+            PartialDate.Parse(reader.GetString() ?? throw new JsonException("expected a partial date string"));
+
+        public override void Write(Utf8JsonWriter writer, PartialDate value, JsonSerializerOptions options) =>
+            writer.WriteStringValue(value.ToString());
+    }
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("// ")})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, g.namespaceName(namespace))
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, namespace string, path string) error {
+	tmpl := `// UCUM unit validation and conversion for {{.Type}}.
+//
+{{header}}
+using System;
+using System.Collections.Generic;
+
+namespace {{.Namespace}}
+{
+    /// <summary>
+    /// UCUM unit validation and conversion for {{.Type}}.
+    /// </summary>
+    public static class Ucum
+    {
+        public const string SystemUri = "` + datatypes.UCUMSystem + `";
+
+        /// <summary>
+        /// A unit whose value v is v * factor + offset in its dimension's base unit.
+        /// </summary>
+        private readonly record struct Unit(string Dimension, double Factor, double Offset);
+
+        private static readonly Dictionary<string, Unit> Units = new Dictionary<string, Unit>
+        {
+{{- range .Units}}
+            ["{{.Code}}"] = new Unit("{{.Dimension}}", {{.Factor | float}}, {{.Offset | float}}),
+{{- end}}
+        };
+
+        /// <summary>
+        /// Reports whether code is a UCUM unit these helpers can convert.
+        /// </summary>
+        public static bool IsKnownUnit(string code) => Units.ContainsKey(code);
+
+        /// <summary>
+        /// Converts value between two UCUM units of the same dimension.
+        /// </summary>
+        public static double ConvertValue(double value, string fromCode, string toCode)
+        {
+            var source = Lookup(fromCode);
+            var target = Lookup(toCode);
+            if (source.Dimension != target.Dimension)
+            {
+                throw new ArgumentException($"cannot convert {fromCode} ({source.Dimension}) to {toCode} ({target.Dimension})");
+            }
+            return (value * source.Factor + source.Offset - target.Offset) / target.Factor;
+        }
+
+        /// <summary>
+        /// Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit.
+        /// </summary>
+        public static {{.Type}} Convert({{.Type}} quantity, string toCode)
+        {
+            if (quantity.{{.F.value}} is not decimal current)
+            {
+                throw new ArgumentException("cannot convert a quantity without a value");
+            }
+            var value = ConvertValue((double)current, quantity.{{.F.code}} ?? quantity.{{.F.unit}} ?? "", toCode);
+            return new {{.Type}}
+            {
+                {{.F.value}} = (decimal)value,
+                {{.F.comparator}} = quantity.{{.F.comparator}},
+                {{.F.unit}} = toCode,
+                {{.F.system}} = SystemUri,
+                {{.F.code}} = toCode,
+            };
+        }
+
+        private static Unit Lookup(string code) =>
+            Units.TryGetValue(code, out var unit) ? unit : throw new ArgumentException($"unknown UCUM unit: {code}");
+    }
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.header.Func("// ", quantity)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Type      string
+		F         map[string]string
+		Units     []datatypes.Unit
+	}{
+		Namespace: g.namespaceName(namespace),
+		Type:      g.className(quantity),
+		F:         naming.ByName(g.fields(quantity)),
+		Units:     datatypes.Units,
 	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
 // GenerateMappings generates C# mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	return nil
 }
 
-func toCamelCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if i == 0 {
-			words[i] = strings.ToLower(w)
-		} else if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+// usings returns the other C# namespaces s uses types from.
+func (g *Generator) usings(scope resolve.Scope, s schema.Schema) []string {
+	seen := make(map[string]bool)
+	var usings []string
+	for _, dep := range scope.ClassDependencies(s) {
+		if ns := g.namespaceName(dep.Namespace); dep.Namespace != s.Namespace && !seen[ns] {
+			seen[ns] = true
+			usings = append(usings, ns)
 		}
 	}
-	return strings.Join(words, "")
+	sort.Strings(usings)
+	return usings
+}
+
+// namespaceName converts a namespace to a C# namespace.
+func (g *Generator) namespaceName(namespace string) string {
+	if g.naming.Packages == "" {
+		return toPascalCase(strings.ReplaceAll(namespace, "_", "."))
+	}
+	parts := strings.Split(namespace, "_")
+	for i, p := range parts {
+		parts[i] = naming.Ident("csharp", naming.Case(g.naming.Packages).Apply(p))
+	}
+	return strings.Join(parts, ".")
+}
+
+// fields returns the properties declared in the class for s, leaving
+// inherited elements to its base class. Members keep clear of the base's
+// identifiers and of the class name, which C# members may not share.
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	reserved := append([]string{g.className(s)}, g.inherited(s)...)
+	return naming.Stored(naming.Wire(naming.Fields("csharp", datatypes.Own(s), naming.Case(g.naming.Fields).Or(toPascalCase), reserved...), naming.Camel.Apply))
+}
+
+// inherited returns the identifiers of the properties s inherits from its
+// base classes.
+func (g *Generator) inherited(s schema.Schema) []string {
+	base, ok := datatypes.Base(s.Inherits)
+	if !ok {
+		return nil
+	}
+	idents := g.inherited(base)
+	for _, f := range g.fields(base) {
+		idents = append(idents, f.Ident)
+	}
+	return idents
+}
+
+func (g *Generator) className(s schema.Schema) string {
+	return naming.Ident("csharp", naming.Case(g.naming.Types).Apply(s.GetName()))
 }
 
 func toPascalCase(s string) string {
@@ -130,7 +521,25 @@ func toPascalCase(s string) string {
 	return strings.Join(words, "")
 }
 
-func toCSharpType(f schema.Field) string {
+// defaultLiteral returns the default of f as a C# literal of the type
+// generated for it, or "" when it has none.
+func defaultLiteral(f schema.Field) string {
+	if f.Default == "" {
+		return ""
+	}
+	switch f.DefaultKind() {
+	case "integer":
+		return f.Default
+	case "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case "decimal":
+		return f.Default + "m"
+	}
+	return strconv.Quote(f.Default)
+}
+
+func (g *Generator) toCSharpType(scope resolve.Scope, f schema.Field) string {
 	baseType := ""
 	switch f.Type {
 	case "string", "code", "id", "uri", "url":
@@ -142,15 +551,17 @@ func toCSharpType(f schema.Field) string {
 	case "boolean":
 		baseType = "bool"
 	case "date":
-		baseType = "DateOnly"
+		baseType = g.dateType
 	case "datetime", "instant":
 		baseType = "DateTimeOffset"
 	case "base64Binary":
 		baseType = "byte[]"
 	default:
-		if strings.HasPrefix(f.Type, "[]") {
+		if target, ok := scope.Lookup(f.Type); ok {
+			baseType = g.className(target)
+		} else if strings.HasPrefix(f.Type, "[]") {
 			innerType := strings.TrimPrefix(f.Type, "[]")
-			inner := toCSharpType(schema.Field{Type: innerType, Required: true})
+			inner := g.toCSharpType(scope, schema.Field{Type: innerType, Required: true})
 			baseType = fmt.Sprintf("List<%s>", inner)
 		} else {
 			baseType = "object"
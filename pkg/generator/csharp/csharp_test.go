@@ -0,0 +1,42 @@
+package csharp_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/csharp"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, csharp.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, csharp.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestGenerateBulkGolden(t *testing.T) {
+	generatortest.RunSchemas(t, csharp.NewGenerator(config.Config{Bulk: true, Bundle: true, Resolver: true, Diff: true}), fixtures.Schemas(), "testdata/bulk")
+}
+
+func TestGenerateClientGolden(t *testing.T) {
+	cfg := config.Config{Client: config.Client{Resources: []string{"Specimen", "lab_result", "Practitioner"}}}
+	generatortest.RunSchemas(t, csharp.NewGenerator(cfg), fixtures.Schemas(), "testdata/client")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, csharp.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, csharp.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
@@ -0,0 +1,158 @@
+package csharp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// diffModel is a model with the JSON names of its properties, those it
+// inherits first.
+type diffModel struct {
+	Schema schema.Schema
+	Names  []string
+}
+
+// diffModels returns the schemas of a namespace that have properties to
+// diff.
+func (g *Generator) diffModels(schemas []schema.Schema) []diffModel {
+	var models []diffModel
+	for _, s := range schemas {
+		if names := g.propertyNames(s); len(names) > 0 {
+			models = append(models, diffModel{Schema: s, Names: names})
+		}
+	}
+	return models
+}
+
+// propertyNames returns the JSON names of the properties of s, those it
+// inherits first.
+func (g *Generator) propertyNames(s schema.Schema) []string {
+	var names []string
+	if base, ok := datatypes.Base(s.Inherits); ok {
+		names = g.propertyNames(base)
+	}
+	for _, f := range g.fields(s) {
+		names = append(names, f.Wire)
+	}
+	return names
+}
+
+// generateDiff writes Diff overloads that turn the differences between two
+// instances of a model into a JSON Patch, comparing properties as they are
+// serialized so that dates and decimals keep their JSON form.
+func (g *Generator) generateDiff(models []diffModel, namespace string, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// JSON Patch diffs of the models of this namespace.
+//
+{{header}}
+using System.Collections.Generic;
+using System.Text.Json;
+using System.Text.Json.Nodes;
+using System.Text.Json.Serialization;
+
+namespace {{.Namespace}}
+{
+    /// <summary>
+    /// An operation of a JSON Patch (RFC 6902).
+    /// </summary>
+    public class PatchOperation
+    {
+        [JsonPropertyName("op")]
+        public string Op { get; set; } = "";
+
+        [JsonPropertyName("path")]
+        public string Path { get; set; } = "";
+
+        [JsonPropertyName("value")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+        public JsonNode? Value { get; set; }
+    }
+
+    /// <summary>
+    /// Diffs instances of the models of this namespace into JSON Patches.
+    /// </summary>
+    public static class JsonPatch
+    {
+{{- range $n, $m := .Models}}
+{{- if $n}}
+{{end}}
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff({{className .Schema}} a, {{className .Schema}} b) =>
+            Diff(a, b, new[] { {{range $i, $name := .Names}}{{if $i}}, {{end}}{{quote $name}}{{end}} });
+{{- end}}
+
+        /// <summary>
+        /// Returns the operations that turn the members named names of the
+        /// JSON of a into those of b: adding the members only b holds,
+        /// removing those only a holds, and replacing those that differ. Null
+        /// members count as absent, so properties set null are removed.
+        /// </summary>
+        private static List<PatchOperation> Diff(object a, object b, string[] names)
+        {
+            var before = JsonSerializer.SerializeToNode(a, a.GetType()) as JsonObject ?? new JsonObject();
+            var after = JsonSerializer.SerializeToNode(b, b.GetType()) as JsonObject ?? new JsonObject();
+            var ops = new List<PatchOperation>();
+            foreach (var name in names)
+            {
+                var path = "/" + name.Replace("~", "~0").Replace("/", "~1");
+                var x = before[name];
+                var y = after[name];
+                if (y is null)
+                {
+                    if (x is not null)
+                    {
+                        ops.Add(new PatchOperation { Op = "remove", Path = path });
+                    }
+                }
+                else if (x is null)
+                {
+                    ops.Add(new PatchOperation { Op = "add", Path = path, Value = y.DeepClone() });
+                }
+                else if (!JsonNode.DeepEquals(x, y))
+                {
+                    ops.Add(new PatchOperation { Op = "replace", Path = path, Value = y.DeepClone() });
+                }
+            }
+            return ops;
+        }
+    }
+}
+`
+	funcMap := template.FuncMap{
+		"className": g.className,
+		"quote":     strconv.Quote,
+		"header":    g.header.Func("// ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Models    []diffModel
+	}{
+		Namespace: g.namespaceName(namespace),
+		Models:    models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,267 @@
+package csharp
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// resolverModel is a resource that a resolver indexes: the identifier of its
+// id property, or empty when it has none, and its reference fields.
+type resolverModel struct {
+	Schema schema.Schema
+	ID     string
+	Refs   []resolverRef
+}
+
+// resolverRef is a field holding references, singly or in a list.
+type resolverRef struct {
+	Name  string
+	Ident string
+	List  bool
+}
+
+// resolverModels returns the resources of a namespace as a resolver indexes
+// them.
+func (g *Generator) resolverModels(resources []schema.Schema) []resolverModel {
+	var models []resolverModel
+	for _, s := range resources {
+		idents := g.propertyIdents(s)
+		m := resolverModel{Schema: s, ID: idents["id"]}
+		for _, f := range datatypes.ReferenceFields(s) {
+			m.Refs = append(m.Refs, resolverRef{Name: f.Name, Ident: idents[f.Name], List: strings.HasPrefix(f.Type, "[]")})
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// propertyIdents returns the identifiers of the properties of s by field
+// name, including those it inherits.
+func (g *Generator) propertyIdents(s schema.Schema) map[string]string {
+	idents := make(map[string]string)
+	if base, ok := datatypes.Base(s.Inherits); ok {
+		idents = g.propertyIdents(base)
+	}
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f.Ident
+	}
+	return idents
+}
+
+// generateResolver writes an in-memory index of the resources of a
+// namespace that resolves the references between them.
+func (g *Generator) generateResolver(models []resolverModel, namespace string, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	reference, _ := datatypes.Builtin(datatypes.ReferenceName)
+	tmpl := `// In-memory reference resolution for the models of this namespace.
+//
+{{header}}
+using System;
+using System.Collections.Generic;
+
+namespace {{.Namespace}}
+{
+    /// <summary>
+    /// A reference of a resource added to a Resolver that points to none of
+    /// the resources added.
+    /// </summary>
+    public class DanglingReference
+    {
+        /// <summary>
+        /// Type and id of the referencing resource, such as {{.Example.Schema.GetName}}/123, or
+        /// its type when it has no id.
+        /// </summary>
+        public string Source { get; set; } = "";
+
+        /// <summary>
+        /// Name of the field holding the reference.
+        /// </summary>
+        public string Field { get; set; } = "";
+
+        public string Reference { get; set; } = "";
+    }
+
+    /// <summary>
+    /// Indexes resources of this namespace by type and id, and resolves the
+    /// references between them, such as those among the entries of a Bundle.
+    /// </summary>
+    public class Resolver
+    {
+        private static readonly Dictionary<Type, string> ResourceTypes = new Dictionary<Type, string>
+        {
+{{- range .Models}}
+            [typeof({{className .Schema}})] = {{quote .Schema.GetName}},
+{{- end}}
+        };
+
+        private readonly List<object> resources = new List<object>();
+        private readonly Dictionary<string, object> byKey = new Dictionary<string, object>();
+
+        /// <summary>
+        /// Indexes resource, such as a {{className .Example.Schema}}, by its type and id.
+        /// Resources without an id cannot be resolved, but Dangling checks
+        /// their references too.
+        /// </summary>
+        public void Add(object resource)
+        {
+            if (!ResourceTypes.TryGetValue(resource.GetType(), out var resourceType))
+            {
+                throw new ArgumentException($"{resource.GetType()} is not a resource of this namespace", nameof(resource));
+            }
+{{- if .Identified}}
+            string? id = resource switch
+            {
+{{- range .Models}}{{if .ID}}
+                {{className .Schema}} m => m.{{.ID}},
+{{- end}}{{end}}
+                _ => null,
+            };
+            if (!string.IsNullOrEmpty(id))
+            {
+                byKey[resourceType + "/" + id] = resource;
+            }
+{{- end}}
+            resources.Add(resource);
+        }
+
+        /// <summary>
+        /// Returns the resource that a literal reference, such as {{.Example.Schema.GetName}}/123
+        /// or https://fhir.example.org/r4/{{.Example.Schema.GetName}}/123/_history/2, points to, or
+        /// null when it was not added.
+        /// </summary>
+        public object? Resolve(string reference)
+        {
+            var key = ReferenceKey(reference);
+            return key != null && byKey.TryGetValue(key, out var resource) ? resource : null;
+        }
+
+        /// <summary>
+        /// Returns the resource of type T that reference points to, or null
+        /// when no such resource was added.
+        /// </summary>
+        public T? Resolve<T>(string reference) where T : class
+        {
+            return Resolve(reference) as T;
+        }
+
+        /// <summary>
+        /// Returns the references of the added resources that point to none
+        /// of them, in the order the resources were added. Only references to
+        /// a type and id are checked, not contained (#id), logical, or
+        /// urn:uuid ones.
+        /// </summary>
+        public List<DanglingReference> Dangling()
+        {
+            var dangling = new List<DanglingReference>();
+{{- if .Referencing}}
+            foreach (var resource in resources)
+            {
+                switch (resource)
+                {
+{{- range .Models}}{{if .Refs}}
+                    case {{className .Schema}} m:
+                    {
+                        var source = {{if .ID}}string.IsNullOrEmpty(m.{{.ID}}) ? {{quote .Schema.GetName}} : {{quote (print .Schema.GetName "/")}} + m.{{.ID}}{{else}}{{quote .Schema.GetName}}{{end}};
+{{- range .Refs}}
+{{- if .List}}
+                        if (m.{{.Ident}} != null)
+                        {
+                            foreach (var reference in m.{{.Ident}})
+                            {
+                                Check(dangling, source, {{quote .Name}}, reference?.{{$.Reference}});
+                            }
+                        }
+{{- else}}
+                        Check(dangling, source, {{quote .Name}}, m.{{.Ident}}?.{{$.Reference}});
+{{- end}}
+{{- end}}
+                        break;
+                    }
+{{- end}}{{end}}
+                }
+            }
+{{- end}}
+            return dangling;
+        }
+{{- if .Referencing}}
+
+        private void Check(List<DanglingReference> dangling, string source, string field, string? reference)
+        {
+            var key = ReferenceKey(reference);
+            if (reference != null && key != null && !byKey.ContainsKey(key))
+            {
+                dangling.Add(new DanglingReference { Source = source, Field = field, Reference = reference });
+            }
+        }
+{{- end}}
+
+        /// <summary>
+        /// Returns the type and id that a literal reference points to, as
+        /// Type/id, or null when it points to none, as contained (#id) and
+        /// urn:uuid references do not.
+        /// </summary>
+        private static string? ReferenceKey(string? reference)
+        {
+            if (reference == null)
+            {
+                return null;
+            }
+            var i = reference.IndexOf("/_history/", StringComparison.Ordinal);
+            var parts = (i >= 0 ? reference.Substring(0, i) : reference).Split('/');
+            if (parts.Length < 2 || parts[parts.Length - 2] == "" || parts[parts.Length - 1] == "")
+            {
+                return null;
+            }
+            return parts[parts.Length - 2] + "/" + parts[parts.Length - 1];
+        }
+    }
+}
+`
+	funcMap := template.FuncMap{
+		"className": g.className,
+		"quote":     strconv.Quote,
+		"header":    g.header.Func("// ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace   string
+		Models      []resolverModel
+		Example     resolverModel
+		Reference   string
+		Identified  bool
+		Referencing bool
+	}{
+		Namespace: g.namespaceName(namespace),
+		Models:    models,
+		Example:   models[0],
+		Reference: g.propertyIdents(reference)["reference"],
+	}
+	for i := len(models) - 1; i >= 0; i-- {
+		data.Referencing = data.Referencing || len(models[i].Refs) > 0
+		if models[i].ID != "" {
+			data.Identified = true
+			data.Example = models[i]
+		}
+	}
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,48 @@
+// An address expressed using postal conventions
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// An address expressed using postal conventions
+    /// </summary>
+    public class Address
+    {
+        [JsonPropertyName("use")]
+        public string Use { get; set; }
+
+        [JsonPropertyName("type")]
+        public string Type { get; set; }
+
+        [JsonPropertyName("text")]
+        public string Text { get; set; }
+
+        [JsonPropertyName("line")]
+        public List<string> Line { get; set; }
+
+        [JsonPropertyName("city")]
+        public string City { get; set; }
+
+        [JsonPropertyName("district")]
+        public string District { get; set; }
+
+        [JsonPropertyName("state")]
+        public string State { get; set; }
+
+        [JsonPropertyName("postalCode")]
+        public string Postalcode { get; set; }
+
+        [JsonPropertyName("country")]
+        public string Country { get; set; }
+
+        [JsonPropertyName("period")]
+        public Period? Period { get; set; }
+
+    }
+}
@@ -0,0 +1,160 @@
+// JSON Patch diffs of the models of this namespace.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System.Collections.Generic;
+using System.Text.Json;
+using System.Text.Json.Nodes;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// An operation of a JSON Patch (RFC 6902).
+    /// </summary>
+    public class PatchOperation
+    {
+        [JsonPropertyName("op")]
+        public string Op { get; set; } = "";
+
+        [JsonPropertyName("path")]
+        public string Path { get; set; } = "";
+
+        [JsonPropertyName("value")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+        public JsonNode? Value { get; set; }
+    }
+
+    /// <summary>
+    /// Diffs instances of the models of this namespace into JSON Patches.
+    /// </summary>
+    public static class JsonPatch
+    {
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Coding a, Coding b) =>
+            Diff(a, b, new[] { "system", "version", "code", "display", "userSelected" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Meta a, Meta b) =>
+            Diff(a, b, new[] { "versionId", "lastUpdated", "source", "profile", "security", "tag" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Resource a, Resource b) =>
+            Diff(a, b, new[] { "id", "meta", "implicitRules", "language" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Period a, Period b) =>
+            Diff(a, b, new[] { "start", "end" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Address a, Address b) =>
+            Diff(a, b, new[] { "use", "type", "text", "line", "city", "district", "state", "postalCode", "country", "period" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(CodeableConcept a, CodeableConcept b) =>
+            Diff(a, b, new[] { "coding", "text" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Identifier a, Identifier b) =>
+            Diff(a, b, new[] { "use", "type", "system", "value", "period" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Reference a, Reference b) =>
+            Diff(a, b, new[] { "reference", "type", "identifier", "display" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Extension a, Extension b) =>
+            Diff(a, b, new[] { "url", "valueString", "valueCode", "valueBoolean", "valueInteger", "valueDecimal", "valueDateTime", "valueCoding", "valueCodeableConcept", "valueReference", "valueAddress" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Narrative a, Narrative b) =>
+            Diff(a, b, new[] { "status", "div" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(DomainResource a, DomainResource b) =>
+            Diff(a, b, new[] { "id", "meta", "implicitRules", "language", "text", "extension", "modifierExtension" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(HumanName a, HumanName b) =>
+            Diff(a, b, new[] { "use", "text", "family", "given", "prefix", "suffix", "period" });
+
+        /// <summary>
+        /// Returns the JSON Patch that turns the JSON of a into that of b: an
+        /// operation for each property that differs, in schema order.
+        /// </summary>
+        public static List<PatchOperation> Diff(Quantity a, Quantity b) =>
+            Diff(a, b, new[] { "value", "comparator", "unit", "system", "code" });
+
+        /// <summary>
+        /// Returns the operations that turn the members named names of the
+        /// JSON of a into those of b: adding the members only b holds,
+        /// removing those only a holds, and replacing those that differ. Null
+        /// members count as absent, so properties set null are removed.
+        /// </summary>
+        private static List<PatchOperation> Diff(object a, object b, string[] names)
+        {
+            var before = JsonSerializer.SerializeToNode(a, a.GetType()) as JsonObject ?? new JsonObject();
+            var after = JsonSerializer.SerializeToNode(b, b.GetType()) as JsonObject ?? new JsonObject();
+            var ops = new List<PatchOperation>();
+            foreach (var name in names)
+            {
+                var path = "/" + name.Replace("~", "~0").Replace("/", "~1");
+                var x = before[name];
+                var y = after[name];
+                if (y is null)
+                {
+                    if (x is not null)
+                    {
+                        ops.Add(new PatchOperation { Op = "remove", Path = path });
+                    }
+                }
+                else if (x is null)
+                {
+                    ops.Add(new PatchOperation { Op = "add", Path = path, Value = y.DeepClone() });
+                }
+                else if (!JsonNode.DeepEquals(x, y))
+                {
+                    ops.Add(new PatchOperation { Op = "replace", Path = path, Value = y.DeepClone() });
+                }
+            }
+            return ops;
+        }
+    }
+}
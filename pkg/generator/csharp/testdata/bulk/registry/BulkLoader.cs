@@ -0,0 +1,148 @@
+// FHIR Bulk Data ($export) loading for the models of this namespace.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.IO;
+using System.Net.Http;
+using System.Net.Http.Headers;
+using System.Runtime.CompilerServices;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+namespace Registry
+{
+    /// <summary>
+    /// The manifest of a FHIR Bulk Data ($export) response.
+    /// </summary>
+    public class BulkManifest
+    {
+        [JsonPropertyName("transactionTime")]
+        public string TransactionTime { get; set; } = "";
+
+        [JsonPropertyName("request")]
+        public string Request { get; set; } = "";
+
+        [JsonPropertyName("requiresAccessToken")]
+        public bool RequiresAccessToken { get; set; }
+
+        [JsonPropertyName("output")]
+        public List<BulkFile> Output { get; set; } = new List<BulkFile>();
+
+        [JsonPropertyName("error")]
+        public List<BulkFile> Error { get; set; } = new List<BulkFile>();
+    }
+
+    /// <summary>
+    /// One NDJSON file of a bulk export.
+    /// </summary>
+    public class BulkFile
+    {
+        [JsonPropertyName("type")]
+        public string Type { get; set; } = "";
+
+        [JsonPropertyName("url")]
+        public string Url { get; set; } = "";
+
+        [JsonPropertyName("count")]
+        public int? Count { get; set; }
+    }
+
+    /// <summary>
+    /// Streams the resources of a bulk export as the models of this namespace.
+    /// </summary>
+    public class BulkLoader
+    {
+        private static readonly HttpClient DefaultClient = new HttpClient();
+
+        private static readonly Dictionary<string, Type> Types = new Dictionary<string, Type>
+        {
+            ["Practitioner"] = typeof(Practitioner),
+        };
+
+        /// <summary>
+        /// Sent as a bearer token for the files of manifests that require one.
+        /// </summary>
+        public string? AccessToken { get; set; }
+
+        /// <summary>
+        /// Fetches http and https file URLs.
+        /// </summary>
+        public HttpClient Client { get; set; } = DefaultClient;
+
+        /// <summary>
+        /// Reads the manifest at path and yields each resource of the output
+        /// files whose type is a model of this namespace, skipping other
+        /// files: Practitioner files yield Practitioner objects, for example.
+        /// File URLs that are not http or https are paths, relative to the
+        /// manifest.
+        /// </summary>
+        public async IAsyncEnumerable<object> LoadAsync(string path, [EnumeratorCancellation] CancellationToken cancellationToken = default)
+        {
+            BulkManifest manifest;
+            using (var stream = File.OpenRead(path))
+            {
+                manifest = await JsonSerializer.DeserializeAsync<BulkManifest>(stream, cancellationToken: cancellationToken)
+                    ?? throw new InvalidDataException($"{path}: empty manifest");
+            }
+            var dir = Path.GetDirectoryName(Path.GetFullPath(path)) ?? "";
+            foreach (var file in manifest.Output)
+            {
+                if (!Types.TryGetValue(file.Type, out var type))
+                {
+                    continue;
+                }
+                var token = manifest.RequiresAccessToken ? AccessToken : null;
+                using var reader = new StreamReader(await OpenAsync(dir, file.Url, token, cancellationToken));
+                var line = 0;
+                string? text;
+                while ((text = await reader.ReadLineAsync()) != null)
+                {
+                    line++;
+                    if (string.IsNullOrWhiteSpace(text))
+                    {
+                        continue;
+                    }
+                    object? resource;
+                    try
+                    {
+                        resource = JsonSerializer.Deserialize(text, type);
+                    }
+                    catch (JsonException e)
+                    {
+                        throw new InvalidDataException($"{file.Url}: line {line}: {e.Message}", e);
+                    }
+                    if (resource != null)
+                    {
+                        yield return resource;
+                    }
+                }
+            }
+        }
+
+        private async Task<Stream> OpenAsync(string dir, string url, string? token, CancellationToken cancellationToken)
+        {
+            if (url.StartsWith("http://") || url.StartsWith("https://"))
+            {
+                var request = new HttpRequestMessage(HttpMethod.Get, url);
+                request.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue("application/fhir+ndjson"));
+                if (!string.IsNullOrEmpty(token))
+                {
+                    request.Headers.Authorization = new AuthenticationHeaderValue("Bearer", token);
+                }
+                var response = await Client.SendAsync(request, HttpCompletionOption.ResponseHeadersRead, cancellationToken);
+                if (!response.IsSuccessStatusCode)
+                {
+                    throw new HttpRequestException($"GET {url}: {(int)response.StatusCode} {response.ReasonPhrase}");
+                }
+                return await response.Content.ReadAsStreamAsync(cancellationToken);
+            }
+            var local = url.StartsWith("file://") ? url.Substring("file://".Length) : url;
+            return File.OpenRead(Path.Combine(dir, local));
+        }
+    }
+}
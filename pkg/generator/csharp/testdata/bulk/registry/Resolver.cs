@@ -0,0 +1,120 @@
+// In-memory reference resolution for the models of this namespace.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+
+namespace Registry
+{
+    /// <summary>
+    /// A reference of a resource added to a Resolver that points to none of
+    /// the resources added.
+    /// </summary>
+    public class DanglingReference
+    {
+        /// <summary>
+        /// Type and id of the referencing resource, such as Practitioner/123, or
+        /// its type when it has no id.
+        /// </summary>
+        public string Source { get; set; } = "";
+
+        /// <summary>
+        /// Name of the field holding the reference.
+        /// </summary>
+        public string Field { get; set; } = "";
+
+        public string Reference { get; set; } = "";
+    }
+
+    /// <summary>
+    /// Indexes resources of this namespace by type and id, and resolves the
+    /// references between them, such as those among the entries of a Bundle.
+    /// </summary>
+    public class Resolver
+    {
+        private static readonly Dictionary<Type, string> ResourceTypes = new Dictionary<Type, string>
+        {
+            [typeof(Practitioner)] = "Practitioner",
+        };
+
+        private readonly List<object> resources = new List<object>();
+        private readonly Dictionary<string, object> byKey = new Dictionary<string, object>();
+
+        /// <summary>
+        /// Indexes resource, such as a Practitioner, by its type and id.
+        /// Resources without an id cannot be resolved, but Dangling checks
+        /// their references too.
+        /// </summary>
+        public void Add(object resource)
+        {
+            if (!ResourceTypes.TryGetValue(resource.GetType(), out var resourceType))
+            {
+                throw new ArgumentException($"{resource.GetType()} is not a resource of this namespace", nameof(resource));
+            }
+            string? id = resource switch
+            {
+                Practitioner m => m.Id,
+                _ => null,
+            };
+            if (!string.IsNullOrEmpty(id))
+            {
+                byKey[resourceType + "/" + id] = resource;
+            }
+            resources.Add(resource);
+        }
+
+        /// <summary>
+        /// Returns the resource that a literal reference, such as Practitioner/123
+        /// or https://fhir.example.org/r4/Practitioner/123/_history/2, points to, or
+        /// null when it was not added.
+        /// </summary>
+        public object? Resolve(string reference)
+        {
+            var key = ReferenceKey(reference);
+            return key != null && byKey.TryGetValue(key, out var resource) ? resource : null;
+        }
+
+        /// <summary>
+        /// Returns the resource of type T that reference points to, or null
+        /// when no such resource was added.
+        /// </summary>
+        public T? Resolve<T>(string reference) where T : class
+        {
+            return Resolve(reference) as T;
+        }
+
+        /// <summary>
+        /// Returns the references of the added resources that point to none
+        /// of them, in the order the resources were added. Only references to
+        /// a type and id are checked, not contained (#id), logical, or
+        /// urn:uuid ones.
+        /// </summary>
+        public List<DanglingReference> Dangling()
+        {
+            var dangling = new List<DanglingReference>();
+            return dangling;
+        }
+
+        /// <summary>
+        /// Returns the type and id that a literal reference points to, as
+        /// Type/id, or null when it points to none, as contained (#id) and
+        /// urn:uuid references do not.
+        /// </summary>
+        private static string? ReferenceKey(string? reference)
+        {
+            if (reference == null)
+            {
+                return null;
+            }
+            var i = reference.IndexOf("/_history/", StringComparison.Ordinal);
+            var parts = (i >= 0 ? reference.Substring(0, i) : reference).Split('/');
+            if (parts.Length < 2 || parts[parts.Length - 2] == "" || parts[parts.Length - 1] == "")
+            {
+                return null;
+            }
+            return parts[parts.Length - 2] + "/" + parts[parts.Length - 1];
+        }
+    }
+}
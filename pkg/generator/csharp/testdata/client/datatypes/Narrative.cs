@@ -0,0 +1,23 @@
+// Human-readable summary of the resource
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Human-readable summary of the resource
+    /// </summary>
+    public class Narrative
+    {
+        [JsonPropertyName("status")]
+        public string Status { get; set; }
+
+        [JsonPropertyName("div")]
+        public string Div { get; set; }
+
+    }
+}
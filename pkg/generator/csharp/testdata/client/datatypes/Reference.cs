@@ -0,0 +1,29 @@
+// A reference from one resource to another
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// A reference from one resource to another
+    /// </summary>
+    public class Reference
+    {
+        [JsonPropertyName("reference")]
+        public string Reference_2 { get; set; }
+
+        [JsonPropertyName("type")]
+        public string Type { get; set; }
+
+        [JsonPropertyName("identifier")]
+        public Identifier? Identifier { get; set; }
+
+        [JsonPropertyName("display")]
+        public string Display { get; set; }
+
+    }
+}
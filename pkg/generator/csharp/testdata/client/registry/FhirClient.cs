@@ -0,0 +1,306 @@
+// SMART on FHIR client for the models of this namespace.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Linq;
+using System.Net.Http;
+using System.Net.Http.Headers;
+using System.Security.Cryptography;
+using System.Text;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+namespace Registry
+{
+    /// <summary>
+    /// Supplies the OAuth2 access token sent with each FHIR request.
+    /// </summary>
+    public delegate Task<string> TokenSource(CancellationToken cancellationToken);
+
+    /// <summary>
+    /// The SMART on FHIR configuration a server publishes at
+    /// .well-known/smart-configuration.
+    /// </summary>
+    public class SmartConfiguration
+    {
+        [JsonPropertyName("authorization_endpoint")]
+        public string AuthorizationEndpoint { get; set; } = "";
+
+        [JsonPropertyName("token_endpoint")]
+        public string TokenEndpoint { get; set; } = "";
+
+        [JsonPropertyName("capabilities")]
+        public List<string> Capabilities { get; set; } = new List<string>();
+    }
+
+    /// <summary>
+    /// The token response of a SMART on FHIR authorization. Patient and
+    /// Encounter are the launch context, if the app asked for it.
+    /// </summary>
+    public class SmartToken
+    {
+        [JsonPropertyName("access_token")]
+        public string AccessToken { get; set; } = "";
+
+        [JsonPropertyName("token_type")]
+        public string TokenType { get; set; } = "";
+
+        [JsonPropertyName("expires_in")]
+        public int? ExpiresIn { get; set; }
+
+        [JsonPropertyName("scope")]
+        public string? Scope { get; set; }
+
+        [JsonPropertyName("refresh_token")]
+        public string? RefreshToken { get; set; }
+
+        [JsonPropertyName("patient")]
+        public string? Patient { get; set; }
+
+        [JsonPropertyName("encounter")]
+        public string? Encounter { get; set; }
+    }
+
+    /// <summary>
+    /// An app registered with an EHR for the SMART on FHIR authorization
+    /// code flow, as a public client using PKCE.
+    /// </summary>
+    public class SmartApp
+    {
+        public string ClientId { get; set; } = "";
+
+        public string RedirectUri { get; set; } = "";
+
+        /// <summary>
+        /// Requested scopes such as launch, openid, and patient/*.read.
+        /// </summary>
+        public List<string> Scopes { get; set; } = new List<string>();
+
+        /// <summary>
+        /// Returns a random PKCE code verifier, to keep with the state of an
+        /// authorization until its code is exchanged.
+        /// </summary>
+        public static string NewCodeVerifier()
+        {
+            return Base64Url(RandomNumberGenerator.GetBytes(32));
+        }
+
+        /// <summary>
+        /// Returns the URL to send the user to for authorization. aud is the
+        /// FHIR base URL, and launch the launch parameter of an EHR launch,
+        /// or null for a standalone launch.
+        /// </summary>
+        public string AuthorizeUrl(SmartConfiguration config, string aud, string state, string codeVerifier, string? launch = null)
+        {
+            var challenge = SHA256.HashData(Encoding.ASCII.GetBytes(codeVerifier));
+            var parameters = new List<KeyValuePair<string, string>>
+            {
+                new("response_type", "code"),
+                new("client_id", ClientId),
+                new("redirect_uri", RedirectUri),
+                new("scope", string.Join(" ", Scopes)),
+                new("state", state),
+                new("aud", aud),
+                new("code_challenge", Base64Url(challenge)),
+                new("code_challenge_method", "S256"),
+            };
+            if (!string.IsNullOrEmpty(launch))
+            {
+                parameters.Add(new("launch", launch));
+            }
+            return config.AuthorizationEndpoint + "?" + string.Join("&", parameters.Select(p => Uri.EscapeDataString(p.Key) + "=" + Uri.EscapeDataString(p.Value)));
+        }
+
+        /// <summary>
+        /// Exchanges the code an authorization redirected back with for a
+        /// token. Confidential clients, which authenticate to the token
+        /// endpoint, need their own exchange, and refreshing expired tokens
+        /// is left to the app.
+        /// </summary>
+        public async Task<SmartToken> ExchangeAsync(HttpClient client, SmartConfiguration config, string code, string codeVerifier, CancellationToken cancellationToken = default)
+        {
+            var request = new HttpRequestMessage(HttpMethod.Post, config.TokenEndpoint)
+            {
+                Content = new FormUrlEncodedContent(new Dictionary<string, string>
+                {
+                    ["grant_type"] = "authorization_code",
+                    ["code"] = code,
+                    ["redirect_uri"] = RedirectUri,
+                    ["client_id"] = ClientId,
+                    ["code_verifier"] = codeVerifier,
+                }),
+            };
+            request.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue("application/json"));
+            var response = await client.SendAsync(request, cancellationToken);
+            if (!response.IsSuccessStatusCode)
+            {
+                throw new HttpRequestException($"POST {config.TokenEndpoint}: {(int)response.StatusCode} {response.ReasonPhrase}");
+            }
+            var body = await response.Content.ReadAsStringAsync(cancellationToken);
+            return JsonSerializer.Deserialize<SmartToken>(body)
+                ?? throw new HttpRequestException($"POST {config.TokenEndpoint}: empty token response");
+        }
+
+        private static string Base64Url(byte[] bytes)
+        {
+            return Convert.ToBase64String(bytes).TrimEnd('=').Replace('+', '-').Replace('/', '_');
+        }
+    }
+
+    /// <summary>
+    /// A request the server did not accept. Body is usually an
+    /// OperationOutcome.
+    /// </summary>
+    public class FhirException : Exception
+    {
+        public FhirException(string method, string url, int status, string body)
+            : base($"{method} {url}: {status}")
+        {
+            Method = method;
+            Url = url;
+            Status = status;
+            Body = body;
+        }
+
+        public string Method { get; }
+
+        public string Url { get; }
+
+        public int Status { get; }
+
+        public string Body { get; }
+    }
+
+    /// <summary>
+    /// Calls the REST API of a FHIR server with the models of this namespace.
+    /// </summary>
+    public class FhirClient
+    {
+        private static readonly HttpClient DefaultClient = new HttpClient();
+
+        private readonly string baseUrl;
+
+        private readonly TokenSource? tokens;
+
+        /// <summary>
+        /// Creates a client of the server at baseUrl, its FHIR base URL.
+        /// Without a token source, requests carry no access token.
+        /// </summary>
+        public FhirClient(string baseUrl, TokenSource? tokens = null)
+        {
+            this.baseUrl = baseUrl.TrimEnd('/');
+            this.tokens = tokens;
+        }
+
+        /// <summary>
+        /// Sends requests.
+        /// </summary>
+        public HttpClient Client { get; set; } = DefaultClient;
+
+        /// <summary>
+        /// Fetches the server's SMART configuration.
+        /// </summary>
+        public async Task<SmartConfiguration> DiscoverAsync(CancellationToken cancellationToken = default)
+        {
+            return (await SendAsync<SmartConfiguration>(HttpMethod.Get, ".well-known/smart-configuration", null, cancellationToken))!;
+        }
+
+        /// <summary>
+        /// Reads the Practitioner with id.
+        /// </summary>
+        public async Task<Practitioner> ReadPractitionerAsync(string id, CancellationToken cancellationToken = default)
+        {
+            return (await SendAsync<Practitioner>(HttpMethod.Get, "Practitioner" + "/" + Uri.EscapeDataString(id), null, cancellationToken))!;
+        }
+
+        /// <summary>
+        /// Searches Practitioner resources with parameters and returns the
+        /// first page of matches.
+        /// </summary>
+        public async Task<List<Practitioner>> SearchPractitionerAsync(IEnumerable<KeyValuePair<string, string>>? parameters = null, CancellationToken cancellationToken = default)
+        {
+            var bundle = await SendAsync<SearchBundle<Practitioner>>(HttpMethod.Get, "Practitioner" + Query(parameters), null, cancellationToken);
+            return Matches(bundle);
+        }
+
+        /// <summary>
+        /// Creates resource and returns the server's copy of it, with its
+        /// assigned id, or resource if the server returns none.
+        /// </summary>
+        public async Task<Practitioner> CreatePractitionerAsync(Practitioner resource, CancellationToken cancellationToken = default)
+        {
+            return await SendAsync<Practitioner>(HttpMethod.Post, "Practitioner", resource, cancellationToken) ?? resource;
+        }
+
+        private async Task<T?> SendAsync<T>(HttpMethod method, string path, object? body, CancellationToken cancellationToken)
+            where T : class
+        {
+            var url = baseUrl + "/" + path;
+            var request = new HttpRequestMessage(method, url);
+            request.Headers.Accept.Add(new MediaTypeWithQualityHeaderValue("application/fhir+json"));
+            if (body != null)
+            {
+                request.Content = new StringContent(JsonSerializer.Serialize(body, body.GetType()), Encoding.UTF8, "application/fhir+json");
+                request.Headers.Add("Prefer", "return=representation");
+            }
+            if (tokens != null)
+            {
+                request.Headers.Authorization = new AuthenticationHeaderValue("Bearer", await tokens(cancellationToken));
+            }
+            var response = await Client.SendAsync(request, cancellationToken);
+            var text = await response.Content.ReadAsStringAsync(cancellationToken);
+            if (!response.IsSuccessStatusCode)
+            {
+                throw new FhirException(method.Method, url, (int)response.StatusCode, text);
+            }
+            return string.IsNullOrWhiteSpace(text) ? null : JsonSerializer.Deserialize<T>(text);
+        }
+
+        private static string Query(IEnumerable<KeyValuePair<string, string>>? parameters)
+        {
+            if (parameters == null || !parameters.Any())
+            {
+                return "";
+            }
+            return "?" + string.Join("&", parameters.Select(p => Uri.EscapeDataString(p.Key) + "=" + Uri.EscapeDataString(p.Value)));
+        }
+
+        /// <summary>
+        /// Returns the resources of a searchset Bundle that matched the
+        /// search, leaving out included resources and outcomes.
+        /// </summary>
+        private static List<T> Matches<T>(SearchBundle<T>? bundle)
+        {
+            return (bundle?.Entry ?? new List<SearchEntry<T>>())
+                .Where(e => e.Search == null || string.IsNullOrEmpty(e.Search.Mode) || e.Search.Mode == "match")
+                .Select(e => e.Resource)
+                .ToList();
+        }
+
+        private class SearchBundle<T>
+        {
+            [JsonPropertyName("entry")]
+            public List<SearchEntry<T>>? Entry { get; set; }
+        }
+
+        private class SearchEntry<T>
+        {
+            [JsonPropertyName("resource")]
+            public T Resource { get; set; } = default!;
+
+            [JsonPropertyName("search")]
+            public SearchMode? Search { get; set; }
+        }
+
+        private class SearchMode
+        {
+            [JsonPropertyName("mode")]
+            public string? Mode { get; set; }
+        }
+    }
+}
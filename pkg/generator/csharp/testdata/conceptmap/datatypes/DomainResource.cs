@@ -0,0 +1,27 @@
+// A resource with narrative and extensions
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// A resource with narrative and extensions
+    /// </summary>
+    public abstract class DomainResource : Resource
+    {
+        [JsonPropertyName("text")]
+        public Narrative? Text { get; set; }
+
+        [JsonPropertyName("extension")]
+        public List<Extension> Extension { get; set; }
+
+        [JsonPropertyName("modifierExtension")]
+        public List<Extension> Modifierextension { get; set; }
+
+    }
+}
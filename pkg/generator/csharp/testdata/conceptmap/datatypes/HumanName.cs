@@ -0,0 +1,39 @@
+// Name of a human - parts and usage
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Name of a human - parts and usage
+    /// </summary>
+    public class HumanName
+    {
+        [JsonPropertyName("use")]
+        public string Use { get; set; }
+
+        [JsonPropertyName("text")]
+        public string Text { get; set; }
+
+        [JsonPropertyName("family")]
+        public string Family { get; set; }
+
+        [JsonPropertyName("given")]
+        public List<string> Given { get; set; }
+
+        [JsonPropertyName("prefix")]
+        public List<string> Prefix { get; set; }
+
+        [JsonPropertyName("suffix")]
+        public List<string> Suffix { get; set; }
+
+        [JsonPropertyName("period")]
+        public Period? Period { get; set; }
+
+    }
+}
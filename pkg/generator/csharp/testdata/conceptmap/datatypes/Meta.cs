@@ -0,0 +1,36 @@
+// Metadata about a resource
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Metadata about a resource
+    /// </summary>
+    public class Meta
+    {
+        [JsonPropertyName("versionId")]
+        public string Versionid { get; set; }
+
+        [JsonPropertyName("lastUpdated")]
+        public DateTimeOffset? Lastupdated { get; set; }
+
+        [JsonPropertyName("source")]
+        public string Source { get; set; }
+
+        [JsonPropertyName("profile")]
+        public List<string> Profile { get; set; }
+
+        [JsonPropertyName("security")]
+        public List<Coding> Security { get; set; }
+
+        [JsonPropertyName("tag")]
+        public List<Coding> Tag { get; set; }
+
+    }
+}
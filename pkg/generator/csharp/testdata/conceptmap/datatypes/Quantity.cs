@@ -0,0 +1,32 @@
+// A measured amount (or an amount that can potentially be measured)
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// A measured amount (or an amount that can potentially be measured)
+    /// </summary>
+    public class Quantity
+    {
+        [JsonPropertyName("value")]
+        public decimal? Value { get; set; }
+
+        [JsonPropertyName("comparator")]
+        public string Comparator { get; set; }
+
+        [JsonPropertyName("unit")]
+        public string Unit { get; set; }
+
+        [JsonPropertyName("system")]
+        public string System { get; set; }
+
+        [JsonPropertyName("code")]
+        public string Code { get; set; }
+
+    }
+}
@@ -0,0 +1,52 @@
+// Translations of codes by concept maps.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+
+namespace Fixtures
+{
+    /// <summary>
+    /// A code that a concept map translates a source code to, and how
+    /// equivalent the two are.
+    /// </summary>
+    public sealed record ConceptMapTarget(string System, string Code, string Display, string Equivalence);
+
+    /// <summary>
+    /// Translates codes by the concept maps of this namespace.
+    /// </summary>
+    public static class ConceptMaps
+    {
+        // The concept map lab-to-loinc, keyed by source system and code.
+        private static readonly Dictionary<(string System, string Code), ConceptMapTarget[]> LabToLoincTargets = new()
+        {
+            [("http://hospital.example/lab-codes", "GLU")] = new[]
+            {
+                new ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent"),
+            },
+            [("http://hospital.example/lab-codes", "K")] = new[]
+            {
+                new ConceptMapTarget("http://loinc.org", "2823-3", "Potassium [Moles/volume] in Serum or Plasma", "equivalent"),
+            },
+            [("http://hospital.example/lab-codes", "LYTES")] = new[]
+            {
+                new ConceptMapTarget("http://loinc.org", "2951-2", "Sodium [Moles/volume] in Serum or Plasma", "wider"),
+                new ConceptMapTarget("http://loinc.org", "2075-0", "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", "wider"),
+            },
+            [("http://legacy.example/lab", "0012")] = new[]
+            {
+                new ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent"),
+            },
+        };
+
+        /// <summary>
+        /// Returns the codes that code of system translates to by the concept
+        /// map lab-to-loinc, if any.
+        /// </summary>
+        /// <remarks>Local lab test codes to LOINC</remarks>
+        public static IReadOnlyList<ConceptMapTarget> TranslateLabToLoinc(string system, string code) =>
+            LabToLoincTargets.TryGetValue((system, code), out var targets) ? Array.AsReadOnly(targets) : Array.Empty<ConceptMapTarget>();
+    }
+}
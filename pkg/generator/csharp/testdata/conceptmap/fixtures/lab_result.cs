@@ -0,0 +1,60 @@
+// Résultat de laboratoire — température °C, 检验结果
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+using Datatypes;
+using Registry;
+
+namespace Fixtures
+{
+    /// <summary>
+    /// Résultat de laboratoire — température °C, 检验结果
+    /// </summary>
+    /// <remarks>
+    /// Owner: lab-data
+    /// Steward: ana.ruiz@example.org
+    /// Tags: clinical, phi
+    /// Unique: result_id
+    /// Indexed: mrn, resulted_at
+    /// </remarks>
+    public class lab_result
+    {
+        [JsonPropertyName("resultId")]
+        public string ResultId { get; set; }
+
+        [JsonPropertyName("mrn")]
+        public string Mrn { get; set; }
+
+        [JsonPropertyName("valueNumeric")]
+        public decimal? ValueNumeric { get; set; }
+
+        [JsonPropertyName("chargeAmount")]
+        public decimal? ChargeAmount { get; set; } = 0m;
+
+        [JsonPropertyName("valueQuantity")]
+        public Quantity? ValueQuantity { get; set; }
+
+        [JsonPropertyName("resultedAt")]
+        public DateTimeOffset? ResultedAt { get; set; }
+
+        [JsonPropertyName("performerName")]
+        public HumanName? PerformerName { get; set; }
+
+        [JsonPropertyName("orderedBy")]
+        public Practitioner? OrderedBy { get; set; }
+
+        [JsonPropertyName("rawPayload")]
+        public byte[] RawPayload { get; set; }
+
+        [JsonPropertyName("icd10Code")]
+        public string Icd10code { get; set; }
+
+        [JsonPropertyName("reviewerIds")]
+        public List<string> ReviewerIds { get; set; }
+
+    }
+}
@@ -0,0 +1,24 @@
+// Concept - reference to a terminology or just text
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Concept - reference to a terminology or just text
+    /// </summary>
+    public class CodeableConcept
+    {
+        [JsonPropertyName("coding")]
+        public List<Coding> Coding { get; set; }
+
+        [JsonPropertyName("text")]
+        public string Text { get; set; }
+
+    }
+}
@@ -0,0 +1,32 @@
+// A reference to a code defined by a terminology system
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// A reference to a code defined by a terminology system
+    /// </summary>
+    public class Coding
+    {
+        [JsonPropertyName("system")]
+        public string System { get; set; }
+
+        [JsonPropertyName("version")]
+        public string Version { get; set; }
+
+        [JsonPropertyName("code")]
+        public string Code { get; set; }
+
+        [JsonPropertyName("display")]
+        public string Display { get; set; }
+
+        [JsonPropertyName("userSelected")]
+        public bool? Userselected { get; set; }
+
+    }
+}
@@ -0,0 +1,50 @@
+// Additional content defined by implementations
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Additional content defined by implementations
+    /// </summary>
+    public class Extension
+    {
+        [JsonPropertyName("url")]
+        public string Url { get; set; }
+
+        [JsonPropertyName("valueString")]
+        public string Valuestring { get; set; }
+
+        [JsonPropertyName("valueCode")]
+        public string Valuecode { get; set; }
+
+        [JsonPropertyName("valueBoolean")]
+        public bool? Valueboolean { get; set; }
+
+        [JsonPropertyName("valueInteger")]
+        public int? Valueinteger { get; set; }
+
+        [JsonPropertyName("valueDecimal")]
+        public decimal? Valuedecimal { get; set; }
+
+        [JsonPropertyName("valueDateTime")]
+        public DateTimeOffset? Valuedatetime { get; set; }
+
+        [JsonPropertyName("valueCoding")]
+        public Coding? Valuecoding { get; set; }
+
+        [JsonPropertyName("valueCodeableConcept")]
+        public CodeableConcept? Valuecodeableconcept { get; set; }
+
+        [JsonPropertyName("valueReference")]
+        public Reference? Valuereference { get; set; }
+
+        [JsonPropertyName("valueAddress")]
+        public Address? Valueaddress { get; set; }
+
+    }
+}
@@ -0,0 +1,114 @@
+// UCUM unit validation and conversion for Quantity.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// UCUM unit validation and conversion for Quantity.
+    /// </summary>
+    public static class Ucum
+    {
+        public const string SystemUri = "http://unitsofmeasure.org";
+
+        /// <summary>
+        /// A unit whose value v is v * factor + offset in its dimension's base unit.
+        /// </summary>
+        private readonly record struct Unit(string Dimension, double Factor, double Offset);
+
+        private static readonly Dictionary<string, Unit> Units = new Dictionary<string, Unit>
+        {
+            ["g"] = new Unit("mass", 1.0, 0.0),
+            ["kg"] = new Unit("mass", 1000.0, 0.0),
+            ["mg"] = new Unit("mass", 0.001, 0.0),
+            ["ug"] = new Unit("mass", 1e-06, 0.0),
+            ["ng"] = new Unit("mass", 1e-09, 0.0),
+            ["[lb_av]"] = new Unit("mass", 453.59237, 0.0),
+            ["[oz_av]"] = new Unit("mass", 28.349523125, 0.0),
+            ["m"] = new Unit("length", 1.0, 0.0),
+            ["km"] = new Unit("length", 1000.0, 0.0),
+            ["cm"] = new Unit("length", 0.01, 0.0),
+            ["mm"] = new Unit("length", 0.001, 0.0),
+            ["[in_i]"] = new Unit("length", 0.0254, 0.0),
+            ["[ft_i]"] = new Unit("length", 0.3048, 0.0),
+            ["L"] = new Unit("volume", 1.0, 0.0),
+            ["dL"] = new Unit("volume", 0.1, 0.0),
+            ["mL"] = new Unit("volume", 0.001, 0.0),
+            ["uL"] = new Unit("volume", 1e-06, 0.0),
+            ["s"] = new Unit("time", 1.0, 0.0),
+            ["min"] = new Unit("time", 60.0, 0.0),
+            ["h"] = new Unit("time", 3600.0, 0.0),
+            ["d"] = new Unit("time", 86400.0, 0.0),
+            ["wk"] = new Unit("time", 604800.0, 0.0),
+            ["mo"] = new Unit("time", 2.6298e+06, 0.0),
+            ["a"] = new Unit("time", 3.15576e+07, 0.0),
+            ["K"] = new Unit("temperature", 1.0, 0.0),
+            ["Cel"] = new Unit("temperature", 1.0, 273.15),
+            ["[degF]"] = new Unit("temperature", 0.5555555555555556, 255.37222222222223),
+            ["g/L"] = new Unit("mass_concentration", 1.0, 0.0),
+            ["g/dL"] = new Unit("mass_concentration", 10.0, 0.0),
+            ["mg/dL"] = new Unit("mass_concentration", 0.01, 0.0),
+            ["mg/L"] = new Unit("mass_concentration", 0.001, 0.0),
+            ["ug/mL"] = new Unit("mass_concentration", 0.001, 0.0),
+            ["ng/mL"] = new Unit("mass_concentration", 1e-06, 0.0),
+            ["mol/L"] = new Unit("substance_concentration", 1.0, 0.0),
+            ["mmol/L"] = new Unit("substance_concentration", 0.001, 0.0),
+            ["umol/L"] = new Unit("substance_concentration", 1e-06, 0.0),
+            ["nmol/L"] = new Unit("substance_concentration", 1e-09, 0.0),
+            ["Pa"] = new Unit("pressure", 1.0, 0.0),
+            ["kPa"] = new Unit("pressure", 1000.0, 0.0),
+            ["mm[Hg]"] = new Unit("pressure", 133.322, 0.0),
+            ["Hz"] = new Unit("frequency", 1.0, 0.0),
+            ["/min"] = new Unit("frequency", 0.016666666666666666, 0.0),
+            ["/h"] = new Unit("frequency", 0.0002777777777777778, 0.0),
+            ["1"] = new Unit("ratio", 1.0, 0.0),
+            ["%"] = new Unit("ratio", 0.01, 0.0),
+        };
+
+        /// <summary>
+        /// Reports whether code is a UCUM unit these helpers can convert.
+        /// </summary>
+        public static bool IsKnownUnit(string code) => Units.ContainsKey(code);
+
+        /// <summary>
+        /// Converts value between two UCUM units of the same dimension.
+        /// </summary>
+        public static double ConvertValue(double value, string fromCode, string toCode)
+        {
+            var source = Lookup(fromCode);
+            var target = Lookup(toCode);
+            if (source.Dimension != target.Dimension)
+            {
+                throw new ArgumentException($"cannot convert {fromCode} ({source.Dimension}) to {toCode} ({target.Dimension})");
+            }
+            return (value * source.Factor + source.Offset - target.Offset) / target.Factor;
+        }
+
+        /// <summary>
+        /// Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit.
+        /// </summary>
+        public static Quantity Convert(Quantity quantity, string toCode)
+        {
+            if (quantity.Value is not decimal current)
+            {
+                throw new ArgumentException("cannot convert a quantity without a value");
+            }
+            var value = ConvertValue((double)current, quantity.Code ?? quantity.Unit ?? "", toCode);
+            return new Quantity
+            {
+                Value = (decimal)value,
+                Comparator = quantity.Comparator,
+                Unit = toCode,
+                System = SystemUri,
+                Code = toCode,
+            };
+        }
+
+        private static Unit Lookup(string code) =>
+            Units.TryGetValue(code, out var unit) ? unit : throw new ArgumentException($"unknown UCUM unit: {code}");
+    }
+}
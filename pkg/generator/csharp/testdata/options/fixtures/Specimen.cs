@@ -0,0 +1,67 @@
+// A sample collected for analysis
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+using Datatypes;
+
+namespace Fixtures
+{
+    /// <summary>
+    /// A sample collected for analysis
+    /// </summary>
+    public class Specimen
+    {
+        [JsonPropertyName("id")]
+        public string Id { get; set; }
+
+        [JsonPropertyName("status")]
+        public string Status { get; set; } = "available";
+
+        [JsonPropertyName("accessionIdentifier")]
+        public Identifier? Accessionidentifier { get; set; }
+
+        [JsonPropertyName("receivedTime")]
+        public DateTimeOffset? Receivedtime { get; set; }
+
+        [JsonPropertyName("collectedDate")]
+        public PartialDate? Collecteddate { get; set; }
+
+        [JsonPropertyName("quantity")]
+        public decimal? Quantity { get; set; }
+
+        [JsonPropertyName("containerCount")]
+        public int? Containercount { get; set; } = 1;
+
+        [JsonPropertyName("fasting")]
+        public bool? Fasting { get; set; } = false;
+
+        [JsonPropertyName("note")]
+        public List<string> Note { get; set; }
+
+        [JsonPropertyName("subject")]
+        public Reference? Subject { get; set; }
+
+        [JsonPropertyName("request")]
+        public List<Reference> Request { get; set; }
+
+        [JsonPropertyName("visit")]
+        public Visit? Visit { get; set; }
+
+        [JsonPropertyName("collection")]
+        public object Collection { get; set; }
+
+        [JsonPropertyName("ingestedAt")]
+        public DateTimeOffset? IngestedAt { get; set; }
+
+        [JsonPropertyName("sourceSystem")]
+        public string SourceSystem { get; set; }
+
+        [JsonPropertyName("recordHash")]
+        public string RecordHash { get; set; }
+
+    }
+}
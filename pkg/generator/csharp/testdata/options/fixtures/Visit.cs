@@ -0,0 +1,71 @@
+// An encounter whose field names are reserved words in target languages
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+using Datatypes;
+using Registry;
+
+namespace Fixtures
+{
+    /// <summary>
+    /// An encounter whose field names are reserved words in target languages
+    /// </summary>
+    public class Visit
+    {
+        [JsonPropertyName("class")]
+        public Coding Class { get; set; }
+
+        [JsonPropertyName("type")]
+        public List<CodeableConcept> Type { get; set; }
+
+        [JsonPropertyName("for")]
+        public Reference? For { get; set; }
+
+        [JsonPropertyName("match")]
+        public string Match { get; set; } = "unlinked";
+
+        [JsonPropertyName("order")]
+        public int? Order { get; set; }
+
+        [JsonPropertyName("self")]
+        public string Self { get; set; }
+
+        [JsonPropertyName("where")]
+        public Address? Where { get; set; }
+
+        [JsonPropertyName("2ndOpinion")]
+        public bool? _2ndopinion { get; set; }
+
+        [JsonPropertyName("priorityCode")]
+        public string Prioritycode { get; set; }
+
+        [JsonPropertyName("priorityCode_2")]
+        public string PriorityCode { get; set; }
+
+        [JsonPropertyName("visit")]
+        public string Visit_2 { get; set; }
+
+        [JsonPropertyName("length")]
+        public Quantity? Length { get; set; }
+
+        [JsonPropertyName("attender")]
+        public Practitioner? Attender { get; set; }
+
+        [JsonPropertyName("specimens")]
+        public List<Specimen> Specimens { get; set; }
+
+        [JsonPropertyName("ingestedAt")]
+        public DateTimeOffset? IngestedAt { get; set; }
+
+        [JsonPropertyName("sourceSystem")]
+        public string SourceSystem { get; set; }
+
+        [JsonPropertyName("recordHash")]
+        public string RecordHash { get; set; }
+
+    }
+}
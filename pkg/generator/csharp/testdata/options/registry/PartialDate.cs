@@ -0,0 +1,85 @@
+// Partial date support for FHIR dates.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Globalization;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Text.RegularExpressions;
+
+namespace Registry
+{
+    /// <summary>
+    /// A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+    /// </summary>
+    [JsonConverter(typeof(PartialDateJsonConverter))]
+    public readonly record struct PartialDate
+    {
+        private static readonly Regex Pattern = new Regex(@"^(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?$");
+
+        public PartialDate(int year, int? month = null, int? day = null)
+        {
+            if (day != null && month == null)
+            {
+                throw new ArgumentException("a partial date with a day must have a month");
+            }
+            // Reject impossible dates such as 1970-02-30
+            _ = new DateOnly(year, month ?? 1, day ?? 1);
+            Year = year;
+            Month = month;
+            Day = day;
+        }
+
+        public int Year { get; }
+        public int? Month { get; }
+        public int? Day { get; }
+
+        /// <summary>
+        /// Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+        /// </summary>
+        public static PartialDate Parse(string value)
+        {
+            var match = Pattern.Match(value);
+            if (!match.Success)
+            {
+                throw new FormatException($"invalid partial date: {value}");
+            }
+            int? Group(int i) => match.Groups[i].Success ? int.Parse(match.Groups[i].Value, CultureInfo.InvariantCulture) : null;
+            return new PartialDate(Group(1)!.Value, Group(2), Group(3));
+        }
+
+        /// <summary>
+        /// Returns the full date, or null when the value is less precise than a day.
+        /// </summary>
+        public DateOnly? ToDateOnly() =>
+            Month is int month && Day is int day ? new DateOnly(Year, month, day) : null;
+
+        public override string ToString()
+        {
+            var text = Year.ToString("D4", CultureInfo.InvariantCulture);
+            if (Month is int month)
+            {
+                text += "-" + month.ToString("D2", CultureInfo.InvariantCulture);
+            }
+            if (Day is int day)
+            {
+                text += "-" + day.ToString("D2", CultureInfo.InvariantCulture);
+            }
+            return text;
+        }
+    }
+
+    /// <summary>
+    /// Serializes PartialDate as a JSON string.
+    /// </summary>
+    public sealed class PartialDateJsonConverter : JsonConverter<PartialDate>
+    {
+        public override PartialDate Read(ref Utf8JsonReader reader, Type typeToConvert, JsonSerializerOptions options) =>
+            PartialDate.Parse(reader.GetString() ?? throw new JsonException("expected a partial date string"));
+
+        public override void Write(Utf8JsonWriter writer, PartialDate value, JsonSerializerOptions options) =>
+            writer.WriteStringValue(value.ToString());
+    }
+}
@@ -0,0 +1,34 @@
+// A person providing care
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+using Datatypes;
+
+namespace Registry
+{
+    /// <summary>
+    /// A person providing care
+    /// </summary>
+    public class Practitioner : DomainResource
+    {
+        [JsonPropertyName("name")]
+        public List<HumanName> Name { get; set; }
+
+        [JsonPropertyName("supervisor")]
+        public Practitioner? Supervisor { get; set; }
+
+        [JsonPropertyName("ingestedAt")]
+        public DateTimeOffset? IngestedAt { get; set; }
+
+        [JsonPropertyName("sourceSystem")]
+        public string SourceSystem { get; set; }
+
+        [JsonPropertyName("recordHash")]
+        public string RecordHash { get; set; }
+
+    }
+}
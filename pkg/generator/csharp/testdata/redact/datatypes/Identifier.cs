@@ -0,0 +1,32 @@
+// An identifier intended for computation
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// An identifier intended for computation
+    /// </summary>
+    public class Identifier
+    {
+        [JsonPropertyName("use")]
+        public string Use { get; set; }
+
+        [JsonPropertyName("type")]
+        public CodeableConcept? Type { get; set; }
+
+        [JsonPropertyName("system")]
+        public string System { get; set; }
+
+        [JsonPropertyName("value")]
+        public string Value { get; set; }
+
+        [JsonPropertyName("period")]
+        public Period? Period { get; set; }
+
+    }
+}
@@ -0,0 +1,23 @@
+// Time range defined by start and end date/time
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Time range defined by start and end date/time
+    /// </summary>
+    public class Period
+    {
+        [JsonPropertyName("start")]
+        public DateTimeOffset? Start { get; set; }
+
+        [JsonPropertyName("end")]
+        public DateTimeOffset? End { get; set; }
+
+    }
+}
@@ -0,0 +1,29 @@
+// Base Resource
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Text.Json.Serialization;
+
+namespace Datatypes
+{
+    /// <summary>
+    /// Base Resource
+    /// </summary>
+    public abstract class Resource
+    {
+        [JsonPropertyName("id")]
+        public string Id { get; set; }
+
+        [JsonPropertyName("meta")]
+        public Meta? Meta { get; set; }
+
+        [JsonPropertyName("implicitRules")]
+        public string Implicitrules { get; set; }
+
+        [JsonPropertyName("language")]
+        public string Language { get; set; }
+
+    }
+}
@@ -0,0 +1,85 @@
+// A sample collected for analysis
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+using System;
+using System.Collections.Generic;
+using System.Linq;
+using System.Text.Json.Serialization;
+using Datatypes;
+
+namespace Fixtures
+{
+    /// <summary>
+    /// A sample collected for analysis
+    /// </summary>
+    public class Specimen
+    {
+        [JsonPropertyName("id")]
+        public string Id { get; set; }
+
+        [JsonPropertyName("status")]
+        public string Status { get; set; } = "available";
+
+        [JsonPropertyName("accessionIdentifier")]
+        public Identifier? Accessionidentifier { get; set; }
+
+        [JsonPropertyName("receivedTime")]
+        public DateTimeOffset? Receivedtime { get; set; }
+
+        [JsonPropertyName("collectedDate")]
+        public DateOnly? Collecteddate { get; set; }
+
+        [JsonPropertyName("quantity")]
+        public decimal? Quantity { get; set; }
+
+        [JsonPropertyName("containerCount")]
+        public int? Containercount { get; set; } = 1;
+
+        [JsonPropertyName("fasting")]
+        public bool? Fasting { get; set; } = false;
+
+        [JsonPropertyName("note")]
+        public List<string> Note { get; set; }
+
+        [JsonPropertyName("subject")]
+        public Reference? Subject { get; set; }
+
+        [JsonPropertyName("request")]
+        public List<Reference> Request { get; set; }
+
+        [JsonPropertyName("visit")]
+        public Visit? Visit { get; set; }
+
+        [JsonPropertyName("collection")]
+        public object Collection { get; set; }
+
+        /// <summary>
+        /// Returns the properties of this record, with those at pii_level high
+        /// or above masked, for logging.
+        /// </summary>
+        public Dictionary<string, object?> RedactForLogging() => new Dictionary<string, object?>
+        {
+            ["id"] = Id,
+            ["status"] = Status,
+            ["accessionIdentifier"] = Accessionidentifier,
+            ["receivedTime"] = Receivedtime,
+            ["collectedDate"] = Collecteddate,
+            ["quantity"] = Quantity,
+            ["containerCount"] = Containercount,
+            ["fasting"] = Fasting,
+            ["note"] = Note,
+            ["subject"] = "[REDACTED]",
+            ["request"] = Request,
+            ["visit"] = Visit,
+            ["collection"] = Collection,
+        };
+
+        /// <summary>
+        /// Formats this record with its properties at pii_level high or above masked.
+        /// </summary>
+        public override string ToString() =>
+            "Specimen { " + string.Join(", ", RedactForLogging().Select(p => $"{p.Key} = {p.Value}")) + " }";
+    }
+}
@@ -0,0 +1,116 @@
+// Package generator resolves target language names to code generators.
+package generator
+
+import (
+	"fmt"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/generator/asyncapi"
+	"github.com/konzy/ehrglot/pkg/generator/cddl"
+	"github.com/konzy/ehrglot/pkg/generator/csharp"
+	"github.com/konzy/ehrglot/pkg/generator/golang"
+	"github.com/konzy/ehrglot/pkg/generator/java"
+	"github.com/konzy/ehrglot/pkg/generator/kotlin"
+	"github.com/konzy/ehrglot/pkg/generator/mongodb"
+	"github.com/konzy/ehrglot/pkg/generator/neo4j"
+	"github.com/konzy/ehrglot/pkg/generator/python"
+	"github.com/konzy/ehrglot/pkg/generator/rust"
+	"github.com/konzy/ehrglot/pkg/generator/scala"
+	"github.com/konzy/ehrglot/pkg/generator/sql"
+	"github.com/konzy/ehrglot/pkg/generator/typescript"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Languages lists the canonical names of all supported target languages.
+var Languages = []string{"python", "go", "typescript", "java", "rust", "csharp", "scala", "kotlin", "sql", "cddl", "asyncapi", "mongodb", "neo4j"}
+
+// Configure rewrites language keys in cfg to their canonical names, so that
+// users may configure a language by any of its aliases, and checks the header
+// template.
+func Configure(cfg config.Config) (config.Config, error) {
+	languages := make(map[string]config.Language, len(cfg.Languages))
+	for lang, settings := range cfg.Languages {
+		name, err := Canonical(lang)
+		if err != nil {
+			return cfg, fmt.Errorf("config: %w", err)
+		}
+		languages[name] = settings
+	}
+	cfg.Languages = languages
+	if err := header.Validate(cfg.Header); err != nil {
+		return cfg, fmt.Errorf("config: header: %w", err)
+	}
+	return cfg, nil
+}
+
+// Canonical returns the canonical name for a language or one of its aliases.
+func Canonical(lang string) (string, error) {
+	switch lang {
+	case "python", "py":
+		return "python", nil
+	case "go", "golang":
+		return "go", nil
+	case "typescript", "ts":
+		return "typescript", nil
+	case "java":
+		return "java", nil
+	case "rust", "rs":
+		return "rust", nil
+	case "csharp", "cs":
+		return "csharp", nil
+	case "scala":
+		return "scala", nil
+	case "kotlin", "kt":
+		return "kotlin", nil
+	case "sql", "dbt":
+		return "sql", nil
+	case "cddl", "cbor":
+		return "cddl", nil
+	case "asyncapi":
+		return "asyncapi", nil
+	case "mongodb", "mongo":
+		return "mongodb", nil
+	case "neo4j", "cypher":
+		return "neo4j", nil
+	default:
+		return "", fmt.Errorf("unsupported language: %s", lang)
+	}
+}
+
+// New creates the generator for a language or one of its aliases.
+func New(lang string, cfg config.Config) (schema.Generator, error) {
+	name, err := Canonical(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "python":
+		return python.NewGenerator(cfg), nil
+	case "go":
+		return golang.NewGenerator(cfg), nil
+	case "typescript":
+		return typescript.NewGenerator(cfg), nil
+	case "java":
+		return java.NewGenerator(cfg), nil
+	case "rust":
+		return rust.NewGenerator(cfg), nil
+	case "csharp":
+		return csharp.NewGenerator(cfg), nil
+	case "scala":
+		return scala.NewGenerator(cfg), nil
+	case "kotlin":
+		return kotlin.NewGenerator(cfg), nil
+	case "cddl":
+		return cddl.NewGenerator(cfg), nil
+	case "asyncapi":
+		return asyncapi.NewGenerator(cfg), nil
+	case "mongodb":
+		return mongodb.NewGenerator(cfg), nil
+	case "neo4j":
+		return neo4j.NewGenerator(cfg), nil
+	default:
+		return sql.NewGenerator(cfg), nil
+	}
+}
@@ -0,0 +1,165 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateBundle writes a builder that collects the resources of a namespace
+// into a FHIR transaction Bundle.
+func (g *Generator) generateBundle(namespace string, resources []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Bundle is a FHIR Bundle of resources of this package.
+type Bundle struct {
+	ResourceType string        ` + "`json:\"resourceType\"`" + `
+	Type         string        ` + "`json:\"type\"`" + `
+	Entry        []BundleEntry ` + "`json:\"entry,omitempty\"`" + `
+}
+
+// BundleEntry is an entry of a transaction Bundle: a resource and the
+// request that stores it.
+type BundleEntry struct {
+	FullURL  string          ` + "`json:\"fullUrl,omitempty\"`" + `
+	Resource json.RawMessage ` + "`json:\"resource\"`" + `
+	Request  BundleRequest   ` + "`json:\"request\"`" + `
+}
+
+// BundleRequest is the request of a transaction Bundle entry.
+type BundleRequest struct {
+	Method      string ` + "`json:\"method\"`" + `
+	URL         string ` + "`json:\"url\"`" + `
+	IfNoneExist string ` + "`json:\"ifNoneExist,omitempty\"`" + `
+}
+
+// BundleBuilder collects resources of this package into a transaction
+// Bundle, which a FHIR server stores all or nothing.
+type BundleBuilder struct {
+	// BaseURL is the FHIR base URL of the server, such as
+	// https://fhir.example.org/r4. It makes the fullUrl of updated
+	// resources; when empty, they have none.
+	BaseURL string
+
+	entries []BundleEntry
+}
+
+// Create adds an entry that creates resource, such as a *{{.Example}}, and
+// returns its fullUrl: a new urn:uuid that references from other resources
+// of the Bundle can use, and that the server replaces with the id it
+// assigns. Unless ifNoneExist is empty, it is a search query, such as
+// identifier=http://hospital.example|123, and the server creates the
+// resource only when nothing matches it.
+func (b *BundleBuilder) Create(resource any, ifNoneExist string) (string, error) {
+	resourceType, body, err := bundleResource(resource)
+	if err != nil {
+		return "", err
+	}
+	fullURL, err := newUUIDURN()
+	if err != nil {
+		return "", err
+	}
+	b.entries = append(b.entries, BundleEntry{
+		FullURL:  fullURL,
+		Resource: body,
+		Request:  BundleRequest{Method: "POST", URL: resourceType, IfNoneExist: ifNoneExist},
+	})
+	return fullURL, nil
+}
+
+// Update adds an entry that creates or replaces resource as the resource
+// with id, which must match the id resource holds, if any. It returns the
+// entry's fullUrl, which is empty unless b has a BaseURL.
+func (b *BundleBuilder) Update(resource any, id string) (string, error) {
+	resourceType, body, err := bundleResource(resource)
+	if err != nil {
+		return "", err
+	}
+	path := resourceType + "/" + url.PathEscape(id)
+	var fullURL string
+	if b.BaseURL != "" {
+		fullURL = strings.TrimSuffix(b.BaseURL, "/") + "/" + path
+	}
+	b.entries = append(b.entries, BundleEntry{
+		FullURL:  fullURL,
+		Resource: body,
+		Request:  BundleRequest{Method: "PUT", URL: path},
+	})
+	return fullURL, nil
+}
+
+// Bundle returns the transaction Bundle of the entries added so far.
+func (b *BundleBuilder) Bundle() Bundle {
+	return Bundle{ResourceType: "Bundle", Type: "transaction", Entry: append([]BundleEntry(nil), b.entries...)}
+}
+
+// bundleResource returns the resource type of resource and its JSON, with
+// the resourceType that models of this package leave out.
+func bundleResource(resource any) (string, json.RawMessage, error) {
+	var resourceType string
+	switch resource.(type) {
+{{- range .Resources}}
+	case {{. | typeName}}, *{{. | typeName}}:
+		resourceType = "{{.GetName}}"
+{{- end}}
+	default:
+		return "", nil, fmt.Errorf("%T is not a resource of this package", resource)
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return "", nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", nil, err
+	}
+	fields["resourceType"], _ = json.Marshal(resourceType)
+	data, err = json.Marshal(fields)
+	return resourceType, data, err
+}
+
+// newUUIDURN returns a random (version 4) UUID as a urn:uuid URI.
+func newUUIDURN() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"typeName": g.typeName, "header": g.markedHeader(resources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package   string
+		Resources []schema.Schema
+		Example   string
+	}{
+		Package:   g.packageName(namespace),
+		Resources: resources,
+		Example:   g.typeName(resources[0]),
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,332 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// canonicalModel is a model and its fields in the order canonical JSON
+// writes them: sorted by schema name.
+type canonicalModel struct {
+	Schema schema.Schema
+	Type   string
+	Fields []naming.Field
+}
+
+// canonicalModels returns the schemas of a namespace that have fields to
+// hash.
+func (g *Generator) canonicalModels(schemas []schema.Schema) []canonicalModel {
+	var models []canonicalModel
+	for _, s := range schemas {
+		fields := g.fields(s)
+		if len(fields) == 0 {
+			continue
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+		models = append(models, canonicalModel{Schema: s, Type: g.typeName(s), Fields: fields})
+	}
+	return models
+}
+
+// canonicalValue returns the expression of the value of f that canonical
+// JSON writes: dates, which share *time.Time with instants, and
+// shopspring decimals are converted first.
+func (g *Generator) canonicalValue(f naming.Field) string {
+	value := "m." + f.Ident
+	switch {
+	case f.Type == "date" && g.dateType == "*time.Time":
+		return "canonicalDate(" + value + ")"
+	case f.Type == "[]date" && g.dateType == "*time.Time":
+		return "canonicalDates(" + value + ")"
+	case f.Type == "decimal" && g.decimalType == "decimal.Decimal":
+		return "json.Number(" + value + ".String())"
+	}
+	return value
+}
+
+// generateCanonical writes CanonicalJSON and CanonicalHash methods that
+// encode and hash models the same way the Python generator's do, so that a
+// record hashes alike in every service, and Equal methods comparing models
+// by them when equality is canonical.
+func (g *Generator) generateCanonical(namespace string, models []canonicalModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"bytes"
+	"crypto/{{.Algorithm}}"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+{{range .Models}}{{$type := .Type}}
+// CanonicalJSON returns m as canonical JSON, which ehrglot writes alike in
+// every language: members named as the schema names fields and sorted, no
+// whitespace, and no null or empty members.
+func (m {{$type}}) CanonicalJSON() ([]byte, error) {
+	return canonicalObject([]canonicalMember{
+{{- range .Fields}}
+		{ {{- quote .Name}}, {{canonicalValue .}}},
+{{- end}}
+	})
+}
+
+// CanonicalHash returns the hex {{$.Hash}} of the canonical JSON of m, which
+// identifies the record across languages, such as to deduplicate repeated
+// feed records.
+func (m {{$type}}) CanonicalHash() (string, error) {
+	return canonicalHash(m.CanonicalJSON())
+}
+{{- if $.Equality}}
+
+// Equal reports whether m and o have the same canonical JSON.
+func (m {{$type}}) Equal(o {{$type}}) bool {
+	a, err := m.CanonicalJSON()
+	if err != nil {
+		return false
+	}
+	b, err := o.CanonicalJSON()
+	return err == nil && bytes.Equal(a, b)
+}
+{{- end}}
+{{end}}
+// canonicalMember is a member of the canonical JSON of a model.
+type canonicalMember struct {
+	name  string
+	value any
+}
+
+// canonicalEncoder is a model that writes canonical JSON.
+type canonicalEncoder interface {
+	CanonicalJSON() ([]byte, error)
+}
+
+// canonicalHash returns the hex {{.Hash}} of the canonical JSON data, or
+// err.
+func canonicalHash(data []byte, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	sum := {{.Algorithm}}.Sum{{.Bits}}(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalObject writes members, sorted by name, as a canonical JSON
+// object, leaving out those that are null or empty: "", false, 0, [], and
+// {}.
+func canonicalObject(members []canonicalMember) ([]byte, error) {
+	b := []byte{'{'}
+	n := 0
+	for _, member := range members {
+		value, err := appendCanonical(nil, member.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", member.name, err)
+		}
+		switch string(value) {
+		case "null", ` + "`\"\"`" + `, "false", "0", "[]", "{}":
+			continue
+		}
+		if n > 0 {
+			b = append(b, ',')
+		}
+		n++
+		b = appendCanonicalString(b, member.name)
+		b = append(b, ':')
+		b = append(b, value...)
+	}
+	return append(b, '}'), nil
+}
+
+// appendCanonical appends v to b as canonical JSON: models as their own
+// canonical JSON, instants in UTC as RFC 3339, numbers in fixed notation
+// with no trailing zeros, and binary as base64.
+func appendCanonical(b []byte, v any) ([]byte, error) {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil() {
+		return append(b, "null"...), nil
+	}
+	switch v := v.(type) {
+	case canonicalEncoder:
+		data, err := v.CanonicalJSON()
+		return append(b, data...), err
+	case string:
+		return appendCanonicalString(b, v), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("unsupported number %v", v)
+		}
+		return append(b, canonicalNumber(strconv.FormatFloat(v, 'f', -1, 64))...), nil
+	case json.Number:
+		if strings.ContainsAny(string(v), "eE") {
+			f, err := v.Float64()
+			if err != nil {
+				return nil, err
+			}
+			return appendCanonical(b, f)
+		}
+		return append(b, canonicalNumber(string(v))...), nil
+	case []byte:
+		return appendCanonicalString(b, base64.StdEncoding.EncodeToString(v)), nil
+	case time.Time:
+		return appendCanonicalString(b, v.UTC().Format(time.RFC3339Nano)), nil
+	case *time.Time:
+		return appendCanonical(b, *v)
+	case map[string]any:
+		members := make([]canonicalMember, 0, len(v))
+		for name, value := range v {
+			members = append(members, canonicalMember{name, value})
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].name < members[j].name })
+		data, err := canonicalObject(members)
+		return append(b, data...), err
+	case []any:
+		b = append(b, '[')
+		for i, value := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendCanonical(b, value); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		values := make([]any, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+		return appendCanonical(b, values)
+	}
+	// Anything else, such as a PartialDate or an untyped value, is written
+	// as its JSON, canonicalized.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return appendCanonical(b, value)
+}
+
+// appendCanonicalString appends s to b as a JSON string, escaping only
+// quotes, backslashes, and control characters, as RFC 8785 does.
+func appendCanonicalString(b []byte, s string) []byte {
+	b = append(b, '"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b = append(b, '\\', '"')
+		case '\\':
+			b = append(b, '\\', '\\')
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\f':
+			b = append(b, '\\', 'f')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		default:
+			if r < 0x20 {
+				b = fmt.Appendf(b, "\\u%04x", r)
+			} else {
+				b = append(b, string(r)...)
+			}
+		}
+	}
+	return append(b, '"')
+}
+
+// canonicalNumber returns the number in fixed notation s without trailing
+// fractional zeros or a negative zero.
+func canonicalNumber(s string) string {
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// canonicalDate returns the date of t, or nil when it is unset.
+func canonicalDate(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.Format("2006-01-02")
+}
+
+// canonicalDates returns the dates of ts.
+func canonicalDates(ts []*time.Time) []any {
+	dates := make([]any, len(ts))
+	for i, t := range ts {
+		dates[i] = canonicalDate(t)
+	}
+	return dates
+}
+`
+	funcMap := template.FuncMap{
+		"quote":          strconv.Quote,
+		"canonicalValue": g.canonicalValue,
+		"header":         g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package   string
+		Algorithm string
+		Bits      string
+		Hash      string
+		Equality  bool
+		Models    []canonicalModel
+	}{
+		Package:   g.packageName(namespace),
+		Algorithm: g.hashing.Algorithm,
+		Bits:      strings.TrimPrefix(g.hashing.Algorithm, "sha"),
+		Hash:      strings.ToUpper(strings.Replace(g.hashing.Algorithm, "sha", "sha-", 1)),
+		Equality:  g.hashing.Equality,
+		Models:    models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,105 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/events"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateCloudEvents writes the CloudEvents types of the resources of a
+// namespace, and a constructor per resource that wraps one in an event.
+func (g *Generator) generateCloudEvents(namespace string, resources []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// CloudEventsVersion is the version of the CloudEvents specification the
+// events of this package follow.
+const CloudEventsVersion = "1.0"
+
+// CloudEvent is a CloudEvents event in the structured JSON format, whose
+// data is a resource of this package.
+type CloudEvent[T any] struct {
+	SpecVersion     string ` + "`json:\"specversion\"`" + `
+	ID              string ` + "`json:\"id\"`" + `
+	Source          string ` + "`json:\"source\"`" + `
+	Type            string ` + "`json:\"type\"`" + `
+	Subject         string ` + "`json:\"subject,omitempty\"`" + `
+	Time            string ` + "`json:\"time,omitempty\"`" + `
+	DataContentType string ` + "`json:\"datacontenttype\"`" + `
+	Data            T      ` + "`json:\"data\"`" + `
+}
+
+// Event types of the resources of this package.
+const (
+{{- range .Resources}}
+	{{. | typeName}}EventType = "{{. | eventType}}"
+{{- end}}
+)
+{{range .Resources}}
+// New{{. | typeName}}Event returns an event from source, a URI reference such
+// as /ehr/adt, that carries resource, with a new random id and the current
+// time.
+func New{{. | typeName}}Event(source string, resource *{{. | typeName}}) (CloudEvent[*{{. | typeName}}], error) {
+	return newCloudEvent(source, {{. | typeName}}EventType, resource)
+}
+{{end}}
+func newCloudEvent[T any](source, eventType string, data T) (CloudEvent[T], error) {
+	if source == "" {
+		return CloudEvent[T]{}, fmt.Errorf("%s event has no source", eventType)
+	}
+	id, err := newEventID()
+	if err != nil {
+		return CloudEvent[T]{}, err
+	}
+	return CloudEvent[T]{
+		SpecVersion:     CloudEventsVersion,
+		ID:              id,
+		Source:          source,
+		Type:            eventType,
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data,
+	}, nil
+}
+
+// newEventID returns a random (version 4) UUID.
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"typeName": g.typeName, "eventType": events.CloudEventType, "header": g.markedHeader(resources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package   string
+		Resources []schema.Schema
+	}{
+		Package:   g.packageName(namespace),
+		Resources: resources,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// conceptMapTable is the lookup table of a concept map and the function
+// translating codes by it.
+type conceptMapTable struct {
+	Map     conceptmap.ConceptMap
+	Var     string
+	Func    string
+	Sources []conceptmap.Source
+}
+
+// generateConceptMaps writes the functions that translate codes by the
+// concept maps of a namespace.
+func (g *Generator) generateConceptMaps(namespace string, maps []conceptmap.ConceptMap, path string) error {
+	var tables []conceptMapTable
+	for _, m := range maps {
+		tables = append(tables, conceptMapTable{
+			Map:     m,
+			Var:     naming.Camel.Apply(m.Name),
+			Func:    "Translate" + naming.Pascal.Apply(m.Name),
+			Sources: m.Sources(),
+		})
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+// ConceptMapTarget is a code that a concept map translates a source code to,
+// and how equivalent the two are.
+type ConceptMapTarget struct {
+	System      string
+	Code        string
+	Display     string
+	Equivalence string
+}
+{{range .Tables}}
+// {{.Var}} is the concept map {{.Map.Name}}, keyed by source system and code.
+var {{.Var}} = map[[2]string][]ConceptMapTarget{
+{{- range .Sources}}
+	{ {{- quote .System}}, {{quote .Code -}} }: {
+{{- range .Translations}}
+		{System: {{quote .System}}, Code: {{quote .Code}}, Display: {{quote .Display}}, Equivalence: {{quote .Equivalence}}},
+{{- end}}
+	},
+{{- end}}
+}
+
+// {{.Func}} returns the codes that code of system translates to
+// by the concept map {{.Map.Name}}, or nil when it translates to none.
+{{- with .Map.Description}}
+//
+// {{line .}}
+{{- end}}
+func {{.Func}}(system, code string) []ConceptMapTarget {
+	return {{.Var}}[[2]string{system, code}]
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"line":   func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"header": g.markedHeader(),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Tables  []conceptMapTable
+	}{
+		Package: g.packageName(namespace),
+		Tables:  tables,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
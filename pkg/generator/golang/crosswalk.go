@@ -0,0 +1,87 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/crosswalk"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// crosswalkTable is the lookup table of a field the crosswalk maps codes of.
+type crosswalkTable struct {
+	Var      string
+	Source   string
+	Concepts []crosswalk.Concept
+}
+
+// generateCrosswalk writes the lookup tables that map the local codes of
+// the fields of a namespace to standard concepts.
+func (g *Generator) generateCrosswalk(namespace string, fields []crosswalk.Field, path string) error {
+	var tables []crosswalkTable
+	var sources []schema.Schema
+	for _, f := range fields {
+		ident := f.Field.Name
+		for _, field := range g.fields(f.Schema) {
+			if field.Name == f.Field.Name {
+				ident = field.Ident
+			}
+		}
+		tables = append(tables, crosswalkTable{
+			Var:      g.typeName(f.Schema) + ident + "Concepts",
+			Source:   f.Schema.GetName() + "." + f.Field.Name,
+			Concepts: f.Concepts,
+		})
+		if len(sources) == 0 || sources[len(sources)-1].GetName() != f.Schema.GetName() {
+			sources = append(sources, f.Schema)
+		}
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+// Concept is the standard concept a local code maps to.
+type Concept struct {
+	// ConceptID is the OMOP concept_id, or 0 when the code has none.
+	ConceptID    int64
+	VocabularyID string
+	ConceptCode  string
+	ConceptName  string
+	// I2B2Path is the concept path of the code in the i2b2 ontology.
+	I2B2Path string
+}
+{{range .Tables}}
+// {{.Var}} maps the local codes of {{.Source}} to standard concepts.
+var {{.Var}} = map[string]Concept{
+{{- range .Concepts}}
+	{{quote .LocalCode}}: {ConceptID: {{.ConceptID}}, VocabularyID: {{quote .VocabularyID}}, ConceptCode: {{quote .ConceptCode}}, ConceptName: {{quote .ConceptName}}, I2B2Path: {{quote .I2B2Path}}},
+{{- end}}
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Tables  []crosswalkTable
+	}{
+		Package: g.packageName(namespace),
+		Tables:  tables,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,264 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// derivedModel is a schema with derived fields.
+type derivedModel struct {
+	Schema  schema.Schema
+	Type    string
+	Members []derivedMember
+}
+
+// derivedMember is the method computing a derived field: its name and
+// result type, the date fields it needs set, the partial dates it needs
+// known to the day, and the expression it returns.
+type derivedMember struct {
+	Field    schema.Field
+	Method   string
+	Type     string
+	Zero     string
+	Dates    []string
+	Partials []partialDate
+	Expr     string
+}
+
+// partialDate is a partial date field a derived field uses, and the
+// variable holding its time.
+type partialDate struct {
+	Ident string
+	Var   string
+}
+
+// derivedModels returns the schemas of a namespace with derived fields, and
+// the helper functions their methods call. Derived decimals are float64s,
+// whatever the decimal type of stored ones.
+func (g *Generator) derivedModels(schemas []schema.Schema) ([]derivedModel, []string, error) {
+	var models []derivedModel
+	var helpers []string
+	for _, s := range schemas {
+		derived, err := s.DerivedFields()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(derived) == 0 {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.allFields(s) {
+			idents[f.Name] = f
+		}
+		m := derivedModel{Schema: s, Type: g.typeName(s)}
+		for _, d := range derived {
+			member := derivedMember{Field: d.Field, Method: idents[d.Field.Name].Ident, Type: "string", Zero: `""`}
+			switch d.Field.ValueKind() {
+			case "integer":
+				member.Type, member.Zero = "int", "0"
+			case "decimal":
+				member.Type, member.Zero = "float64", "0"
+			}
+			for _, name := range d.Expr.Fields() {
+				f := idents[name]
+				if f.ValueKind() != "date" {
+					continue
+				}
+				member.Dates = append(member.Dates, "m."+f.Ident+" == nil")
+				if g.partial(f) {
+					member.Partials = append(member.Partials, partialDate{Ident: f.Ident, Var: partialVar(f)})
+				}
+			}
+			member.Expr = g.goExpr(d.Expr, idents, d.Field.ValueKind() == "decimal")
+			for _, fn := range d.Expr.Functions() {
+				if fn != "concat" && !contains(helpers, fn) {
+					helpers = append(helpers, fn)
+				}
+			}
+			if strings.Contains(member.Expr, "numberValue(") && !contains(helpers, "number") {
+				helpers = append(helpers, "number")
+			}
+			m.Members = append(m.Members, member)
+		}
+		models = append(models, m)
+	}
+	return models, helpers, nil
+}
+
+// goExpr returns e as a Go expression over the fields of m, converting
+// integers to float64 where float is set or e yields a decimal.
+func (g *Generator) goExpr(e schema.Expr, idents map[string]naming.Field, float bool) string {
+	float = float || e.Kind == "decimal"
+	arg := func(i int) string {
+		a := g.goExpr(e.Args[i], idents, float)
+		if isOperator(e.Args[i].Op) && (isOperator(e.Op) || e.Op == "neg") {
+			return "(" + a + ")"
+		}
+		return a
+	}
+	var expr string
+	switch e.Op {
+	case "number":
+		return e.Value
+	case "text":
+		return strconv.Quote(e.Value)
+	case "field":
+		f := idents[e.Value]
+		expr = "m." + f.Ident
+		switch {
+		case g.partial(f):
+			return partialVar(f)
+		case f.ValueKind() == "date":
+			return "*" + expr
+		case f.ValueKind() == "decimal" && g.decimalType == "json.Number":
+			return "numberValue(" + expr + ")"
+		case f.ValueKind() == "decimal" && g.decimalType == "decimal.Decimal":
+			return expr + ".InexactFloat64()"
+		}
+	case "neg":
+		return "-" + arg(0)
+	case "+", "-", "*", "/":
+		return arg(0) + " " + e.Op + " " + arg(1)
+	case "years_since":
+		expr = "yearsSince(" + arg(0) + ")"
+	case "days_between":
+		expr = "daysBetween(" + arg(0) + ", " + arg(1) + ")"
+	case "concat":
+		var args []string
+		for i := range e.Args {
+			args = append(args, arg(i))
+		}
+		return strings.Join(args, " + ")
+	}
+	if float && e.Kind == "integer" {
+		return "float64(" + expr + ")"
+	}
+	return expr
+}
+
+// partial reports whether f is a partial date, whose time is known only
+// when it is known to the day.
+func (g *Generator) partial(f naming.Field) bool {
+	return f.Type == "date" && g.dateType == "*PartialDate"
+}
+
+// partialVar returns the variable holding the time of partial date f.
+func partialVar(f naming.Field) string {
+	return strings.ToLower(f.Ident[:1]) + f.Ident[1:]
+}
+
+// isOperator reports whether op is an arithmetic operator, whose operands
+// are parenthesized when they are operations too.
+func isOperator(op string) bool {
+	return op == "+" || op == "-" || op == "*" || op == "/"
+}
+
+// contains reports whether values holds value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateDerived writes the methods computing the derived fields of
+// models, and the helpers they call.
+func (g *Generator) generateDerived(namespace string, models []derivedModel, helpers []string, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+{{- if or (has "number") (has "years_since") (has "days_between")}}
+
+import (
+{{- if has "number"}}
+	"encoding/json"
+{{- end}}
+{{- if or (has "years_since") (has "days_between")}}
+	"time"
+{{- end}}
+)
+{{- end}}
+{{range $m := .Models}}{{range $d := .Members}}
+// {{.Method}} returns the {{.Field.Name}} of m, derived as {{.Field.Derived}}{{if .Partials}},
+// or {{.Zero}} when a date it uses is unset or not known to the day
+{{- else if .Dates}},
+// or {{.Zero}} when a date it uses is unset{{end}}.{{with .Field.Description}} {{.}}{{end}}
+func (m {{$m.Type}}) {{.Method}}() {{.Type}} {
+{{- with .Dates}}
+	if {{join . " || "}} {
+		return {{$d.Zero}}
+	}
+{{- end}}
+{{- range .Partials}}
+	{{.Var}}, ok := m.{{.Ident}}.Time()
+	if !ok {
+		return {{$d.Zero}}
+	}
+{{- end}}
+	return {{.Expr}}
+}
+{{end}}{{end}}
+{{- if has "years_since"}}
+// yearsSince returns the whole years from t to today, as ages are counted.
+func yearsSince(t time.Time) int {
+	now := time.Now()
+	years := now.Year() - t.Year()
+	if now.Month() < t.Month() || now.Month() == t.Month() && now.Day() < t.Day() {
+		years--
+	}
+	return years
+}
+{{end}}
+{{- if has "days_between"}}
+// daysBetween returns the days from the date of start to that of end.
+func daysBetween(start, end time.Time) int {
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+{{end}}
+{{- if has "number"}}
+// numberValue returns n as a float64, or 0 when it is not a number.
+func numberValue(n json.Number) float64 {
+	f, _ := n.Float64()
+	return f
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"has":    func(helper string) bool { return contains(helpers, helper) },
+		"join":   strings.Join,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []derivedModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,194 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// dicomSchemas returns the dicom schemas of a namespace.
+func dicomSchemas(schemas []schema.Schema) []schema.Schema {
+	var dicom []schema.Schema
+	for _, s := range schemas {
+		if s.Style == schema.StyleDICOM {
+			dicom = append(dicom, s)
+		}
+	}
+	return dicom
+}
+
+// dicomModel is a dicom schema with the tag of each of its fields.
+type dicomModel struct {
+	Type   string
+	Fields []dicomField
+}
+
+type dicomField struct {
+	naming.Field
+	Tag schema.Tag
+}
+
+// generateDICOM writes the DICOM tag tables of the dicom schemas of a
+// namespace, and methods that decode the DICOM JSON model into them.
+func (g *Generator) generateDICOM(namespace string, schemas []schema.Schema, path string) error {
+	var models []dicomModel
+	for _, s := range schemas {
+		tagged, err := s.DICOMTags()
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		tags := make(map[string]schema.Tag, len(tagged))
+		for _, f := range tagged {
+			tags[f.Name] = f.Tag
+		}
+		model := dicomModel{Type: g.typeName(s)}
+		for _, f := range g.fields(s) {
+			if tag, ok := tags[f.Name]; ok {
+				model.Fields = append(model.Fields, dicomField{Field: f, Tag: tag})
+			}
+		}
+		models = append(models, model)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DICOMTag is a DICOM attribute tag.
+type DICOMTag struct {
+	Group, Element uint16
+}
+
+// String returns the tag as (gggg,eeee).
+func (t DICOMTag) String() string {
+	return fmt.Sprintf("(%04X,%04X)", t.Group, t.Element)
+}
+
+// DICOMAttribute maps a DICOM attribute to the model field that holds it.
+type DICOMAttribute struct {
+	Tag DICOMTag
+	// Keyword keys the attribute in the DICOM JSON model, such as 0020000D.
+	Keyword string
+	// Field is the JSON name of the field.
+	Field string
+}
+{{range .Models}}
+// {{.Type}}Tags maps the DICOM attributes of {{.Type}} to its fields.
+var {{.Type}}Tags = []DICOMAttribute{
+{{- range .Fields}}
+	{Tag: DICOMTag{0x{{printf "%04X" .Tag.Group}}, 0x{{printf "%04X" .Tag.Element}}}, Keyword: "{{.Tag.Keyword}}", Field: "{{.Wire}}"},
+{{- end}}
+}
+
+// FromDICOMJSON sets the fields of m from a dataset in the DICOM JSON model,
+// leaving the fields of attributes the dataset lacks unchanged.
+func (m *{{.Type}}) FromDICOMJSON(data []byte) error {
+	var dataset map[string]dicomElement
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return err
+	}
+{{- range .Fields}}
+	if e, ok := dataset["{{.Tag.Keyword}}"]; ok {
+		if err := e.decode(&m.{{.Ident}}); err != nil {
+			return fmt.Errorf("{{.Tag}} {{.Name}}: %w", err)
+		}
+	}
+{{- end}}
+	return nil
+}
+
+// DICOMValue returns the field of m that holds the attribute with tag.
+func (m *{{.Type}}) DICOMValue(tag DICOMTag) (any, bool) {
+	switch tag {
+{{- range .Fields}}
+	case DICOMTag{0x{{printf "%04X" .Tag.Group}}, 0x{{printf "%04X" .Tag.Element}}}:
+		return m.{{.Ident}}, true
+{{- end}}
+	}
+	return nil, false
+}
+{{end}}
+// dicomElement is an attribute of a dataset in the DICOM JSON model.
+type dicomElement struct {
+	VR    string            ` + "`json:\"vr\"`" + `
+	Value []json.RawMessage ` + "`json:\"Value\"`" + `
+}
+
+// decode stores the value of e in dst, a pointer to a model field. Slices
+// take every value and other fields the first. Person names decode as their
+// alphabetic representation, and DA and DT values as times.
+func (e dicomElement) decode(dst any) error {
+	values := make([]json.RawMessage, 0, len(e.Value))
+	for _, v := range e.Value {
+		if e.VR == "PN" {
+			var name struct{ Alphabetic string }
+			if err := json.Unmarshal(v, &name); err != nil {
+				return err
+			}
+			v, _ = json.Marshal(name.Alphabetic)
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if t, ok := dst.(**time.Time); ok {
+		var s string
+		if err := json.Unmarshal(values[0], &s); err != nil {
+			return err
+		}
+		// DT values may carry fractional seconds and an offset, which the
+		// models do not keep.
+		if len(s) > 14 {
+			s = s[:14]
+		}
+		parsed, err := time.Parse("20060102150405"[:len(s)], s)
+		if err != nil {
+			return err
+		}
+		*t = &parsed
+		return nil
+	}
+	if reflect.TypeOf(dst).Elem().Kind() == reflect.Slice && reflect.TypeOf(dst).Elem().Elem().Kind() != reflect.Uint8 {
+		data, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, dst)
+	}
+	return json.Unmarshal(values[0], dst)
+}
+`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []dicomModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
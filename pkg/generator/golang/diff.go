@@ -0,0 +1,126 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// diffModels returns the schemas of a namespace that have fields to diff.
+func (g *Generator) diffModels(schemas []schema.Schema) []schema.Schema {
+	var models []schema.Schema
+	for _, s := range schemas {
+		if len(g.fields(s)) > 0 {
+			models = append(models, s)
+		}
+	}
+	return models
+}
+
+// generateDiff writes Diff methods that turn the differences between two
+// instances of a model into a JSON Patch, comparing fields as the model
+// encodes them so that dates and decimals keep their JSON form.
+func (g *Generator) generateDiff(namespace string, schemas []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// PatchOperation is an operation of a JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op    string          ` + "`json:\"op\"`" + `
+	Path  string          ` + "`json:\"path\"`" + `
+	Value json.RawMessage ` + "`json:\"value,omitempty\"`" + `
+}
+{{range .Schemas}}{{$type := . | typeName}}
+// Diff returns the JSON Patch that turns the JSON of m into that of b: an
+// operation for each field that differs, in schema order, with the values of
+// b as b encodes them.
+func (m {{$type}}) Diff(b {{$type}}) ([]PatchOperation, error) {
+	return diffJSON(m, b, []string{
+{{- range $i, $f := fields .}}{{if $i}}, {{end}}"{{.Wire}}"{{end -}} })
+}
+{{end}}
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// diffJSON returns the operations that turn the members named names of the
+// JSON object a encodes as into those of b: adding the members only b holds,
+// removing those only a holds, and replacing those that differ. Null members
+// count as absent, so fields set null are removed.
+func diffJSON(a, b any, names []string) ([]PatchOperation, error) {
+	before, err := jsonMembers(a)
+	if err != nil {
+		return nil, err
+	}
+	after, err := jsonMembers(b)
+	if err != nil {
+		return nil, err
+	}
+	var ops []PatchOperation
+	for _, name := range names {
+		path := "/" + pointerEscaper.Replace(name)
+		x, y := before[name], after[name]
+		switch {
+		case x == nil && y == nil:
+		case y == nil:
+			ops = append(ops, PatchOperation{Op: "remove", Path: path})
+		case x == nil:
+			ops = append(ops, PatchOperation{Op: "add", Path: path, Value: y})
+		case !bytes.Equal(x, y):
+			ops = append(ops, PatchOperation{Op: "replace", Path: path, Value: y})
+		}
+	}
+	return ops, nil
+}
+
+// jsonMembers returns the members of the JSON object v encodes as, leaving
+// out null ones.
+func jsonMembers(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for name, value := range members {
+		if bytes.Equal(value, []byte("null")) {
+			delete(members, name)
+		}
+	}
+	return members, nil
+}
+`
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"fields":   g.fields,
+		"header":   g.markedHeader(schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Schemas []schema.Schema
+	}{
+		Package: g.packageName(namespace),
+		Schemas: schemas,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
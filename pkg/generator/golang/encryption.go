@@ -0,0 +1,146 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// encryptedModel is a schema with the fields applications encrypt.
+type encryptedModel struct {
+	Schema schema.Schema
+	Type   string
+	Fields []naming.Field
+}
+
+// encryptedModels returns the schemas of a namespace that have encrypted
+// fields.
+func (g *Generator) encryptedModels(schemas []schema.Schema) ([]encryptedModel, error) {
+	var models []encryptedModel
+	for _, s := range schemas {
+		if !s.HasEncrypted() {
+			continue
+		}
+		if _, err := s.EncryptedFields(); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		m := encryptedModel{Schema: s, Type: g.typeName(s)}
+		for _, f := range g.fields(s) {
+			if f.Encryption != "" {
+				m.Fields = append(m.Fields, f)
+			}
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generateEncryption writes EncryptFields and DecryptFields methods that pass
+// the encrypted fields of models through a FieldEncryptor, so applications
+// wire their envelope encryption in one place.
+func (g *Generator) generateEncryption(namespace string, models []encryptedModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+)
+
+// Encryption modes of encrypted fields.
+const (
+	// EncryptionDeterministic encrypts equal values to equal ciphertexts, so
+	// the field can still be matched and joined on.
+	EncryptionDeterministic = "` + schema.EncryptionDeterministic + `"
+	// EncryptionRandomized encrypts each value under a fresh nonce.
+	EncryptionRandomized = "` + schema.EncryptionRandomized + `"
+)
+
+// FieldEncryptor encrypts and decrypts the values of encrypted fields,
+// typically by envelope encryption under a data key that a KMS key wraps.
+// field is the schema and field name, such as patient.ssn, for choosing keys
+// or binding ciphertexts to their field. Ciphertexts are text, such as
+// base64, so that they fit the fields they replace.
+type FieldEncryptor interface {
+	Encrypt(ctx context.Context, field, mode, plaintext string) (string, error)
+	Decrypt(ctx context.Context, field, mode, ciphertext string) (string, error)
+}
+{{range .Models}}{{$name := .Schema.GetName}}
+// EncryptFields replaces the encrypted fields of m with their ciphertexts,
+// before m is stored. Empty fields stay empty.
+func (m *{{.Type}}) EncryptFields(ctx context.Context, enc FieldEncryptor) error {
+	return cryptFields(ctx, enc.Encrypt, []cryptField{
+{{- range .Fields}}
+		{"{{$name}}.{{.Name}}", {{mode .Encryption}}, &m.{{.Ident}}},
+{{- end}}
+	})
+}
+
+// DecryptFields replaces the encrypted fields of m with their plaintexts,
+// after m is loaded. Empty fields stay empty.
+func (m *{{.Type}}) DecryptFields(ctx context.Context, enc FieldEncryptor) error {
+	return cryptFields(ctx, enc.Decrypt, []cryptField{
+{{- range .Fields}}
+		{"{{$name}}.{{.Name}}", {{mode .Encryption}}, &m.{{.Ident}}},
+{{- end}}
+	})
+}
+{{end}}
+type cryptField struct {
+	name  string
+	mode  string
+	value *string
+}
+
+func cryptFields(ctx context.Context, crypt func(ctx context.Context, field, mode, value string) (string, error), fields []cryptField) error {
+	for _, f := range fields {
+		if *f.value == "" {
+			continue
+		}
+		value, err := crypt(ctx, f.name, f.mode, *f.value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.value = value
+	}
+	return nil
+}
+`
+	funcMap := template.FuncMap{
+		"mode": func(mode string) string {
+			if mode == schema.EncryptionDeterministic {
+				return "EncryptionDeterministic"
+			}
+			return "EncryptionRandomized"
+		},
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []encryptedModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
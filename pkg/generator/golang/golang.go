@@ -2,71 +2,357 @@
 package golang
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/crosswalk"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/sample"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
+// DefaultModule is the import path assumed for the output directory when the
+// go language settings do not set a module.
+const DefaultModule = "models"
+
+const Version = "0.1.0"
+
 // Generator generates Go code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	module      string
+	decimalType string
+	dateType    string
+	ucum        bool
+	bulk        bool
+	bundle      bool
+	cloudevents bool
+	resolver    bool
+	diff        bool
+	msgpack     bool
+	triState    bool
+	narrative   bool
+	phonetic    string
+	client      []string
+	crosswalk   string
+	conceptMaps []string
+	redact      string
+	pseudonym   config.Pseudonymize
+	hashing     config.Hashing
+	audit       bool
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Go code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("go").Naming, module: cfg.Language("go").Module, decimalType: "float64", dateType: "*time.Time", ucum: cfg.UCUM, bulk: cfg.Bulk, bundle: cfg.Bundle, cloudevents: cfg.CloudEvents, resolver: cfg.Resolver, diff: cfg.Diff, msgpack: cfg.MessagePack, triState: cfg.TriState, narrative: cfg.Narrative, phonetic: cfg.Matching.Phonetic, client: cfg.Client.Resources, crosswalk: cfg.CrosswalkFile(), conceptMaps: cfg.ConceptMapFiles(), redact: cfg.Redact, pseudonym: cfg.Pseudonymization(), hashing: cfg.Hashing, audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+	if g.module == "" {
+		g.module = DefaultModule
+	}
+	switch cfg.DecimalMode("go") {
+	case config.DecimalExact:
+		g.decimalType = "json.Number"
+	case config.DecimalShopspring:
+		g.decimalType = "decimal.Decimal"
+	}
+	if cfg.TemporalPolicy("go").Dates == config.DatesPartial {
+		g.dateType = "*PartialDate"
+	}
+	return g
 }
 
 // Generate generates Go structs from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	if cycle := refs.NamespaceCycle(); cycle != nil {
+		return fmt.Errorf("namespaces %s reference each other in a cycle, which Go packages cannot import", strings.Join(cycle, " -> "))
+	}
+	var crosswalks map[string][]crosswalk.Field
+	if g.crosswalk != "" {
+		if crosswalks, err = crosswalk.LoadFields(g.crosswalk, schemas); err != nil {
+			return err
+		}
+	}
+	samples, err := sample.New(schemas)
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
 		// Generate package file with all types
 		path := filepath.Join(nsDir, "types.go")
-		if err := g.generateTypes(namespace, nsSchemas, path); err != nil {
+		if err := g.generateTypes(refs.Scope(namespace), nsSchemas, path); err != nil {
+			return err
+		}
+		if err := g.generateDoc(namespace, nsSchemas, filepath.Join(nsDir, "doc.go")); err != nil {
+			return err
+		}
+
+		if models := g.presenceModels(nsSchemas); len(models) > 0 {
+			if err := g.generatePresence(namespace, models, filepath.Join(nsDir, "presence.go")); err != nil {
+				return err
+			}
+		}
+
+		if g.dateType == "*PartialDate" {
+			if err := g.generateTemporal(namespace, filepath.Join(nsDir, "temporal.go")); err != nil {
+				return err
+			}
+		}
+
+		if g.ucum {
+			for _, s := range nsSchemas {
+				if datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+					if err := g.generateUCUM(namespace, s, filepath.Join(nsDir, "ucum.go")); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if dicom := dicomSchemas(nsSchemas); len(dicom) > 0 {
+			if err := g.generateDICOM(namespace, dicom, filepath.Join(nsDir, "dicom.go")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bulk && len(resources) > 0 {
+			if err := g.generateBulk(namespace, resources, filepath.Join(nsDir, "bulk.go")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bundle && len(resources) > 0 {
+			if err := g.generateBundle(namespace, resources, filepath.Join(nsDir, "bundle.go")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.cloudevents && len(resources) > 0 {
+			if err := g.generateCloudEvents(namespace, resources, filepath.Join(nsDir, "cloudevents.go")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.resolver && len(resources) > 0 {
+			if err := g.generateResolver(namespace, g.resolverModels(resources), filepath.Join(nsDir, "resolve.go")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.diffModels(nsSchemas); g.diff && len(models) > 0 {
+			if err := g.generateDiff(namespace, models, filepath.Join(nsDir, "diff.go")); err != nil {
+				return err
+			}
+		}
+
+		if g.msgpack {
+			models, err := g.msgpackModels(refs.Scope(namespace), samples, nsSchemas)
+			if err != nil {
+				return err
+			}
+			if len(models) > 0 {
+				if err := g.generateMsgpack(namespace, models, filepath.Join(nsDir, "msgpack.go"), filepath.Join(nsDir, "msgpack_test.go")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if resources := clientResources(nsSchemas, g.client); len(resources) > 0 {
+			if err := g.generateClient(namespace, resources, filepath.Join(nsDir, "client.go")); err != nil {
+				return err
+			}
+		}
+
+		encrypted, err := g.encryptedModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(encrypted) > 0 {
+			if err := g.generateEncryption(namespace, encrypted, filepath.Join(nsDir, "encryption.go")); err != nil {
+				return err
+			}
+		}
+
+		purposes, err := g.purposeModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(purposes) > 0 {
+			if err := g.generatePurpose(namespace, purposes, filepath.Join(nsDir, "purpose.go")); err != nil {
+				return err
+			}
+		}
+
+		validated, err := g.validateModels(refs.Scope(namespace), nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(validated) > 0 {
+			if err := g.generateValidate(namespace, validated, filepath.Join(nsDir, "validate.go")); err != nil {
+				return err
+			}
+		}
+
+		derived, helpers, err := g.derivedModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(derived) > 0 {
+			if err := g.generateDerived(namespace, derived, helpers, filepath.Join(nsDir, "derived.go")); err != nil {
+				return err
+			}
+		}
+
+		if g.narrative {
+			if models, imports := g.narrativeModels(nsSchemas); len(models) > 0 {
+				if err := g.generateNarrative(namespace, models, imports, filepath.Join(nsDir, "narrative.go")); err != nil {
+					return err
+				}
+			}
+		}
+
+		matched, err := g.matchModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(matched) > 0 {
+			if err := g.generateMatch(namespace, matched, filepath.Join(nsDir, "match.go")); err != nil {
+				return err
+			}
+		}
+
+		ranged, err := g.rangeModels(nsSchemas)
+		if err != nil {
 			return err
 		}
+		if len(ranged) > 0 {
+			if err := g.generateReference(namespace, ranged, filepath.Join(nsDir, "reference.go")); err != nil {
+				return err
+			}
+		}
+
+		if redacted := redactSchemas(nsSchemas, g.redact); len(redacted) > 0 {
+			if err := g.generateRedact(namespace, redacted, filepath.Join(nsDir, "redact.go")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.pseudonymModels(nsSchemas, g.pseudonym.Level); len(models) > 0 {
+			if err := g.generatePseudonym(namespace, models, filepath.Join(nsDir, "pseudonym.go")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.canonicalModels(nsSchemas); g.hashing.Algorithm != "" && len(models) > 0 {
+			if err := g.generateCanonical(namespace, models, filepath.Join(nsDir, "canonical.go")); err != nil {
+				return err
+			}
+		}
+
+		if fields := crosswalks[namespace]; len(fields) > 0 {
+			if err := g.generateCrosswalk(namespace, fields, filepath.Join(nsDir, "crosswalk.go")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(namespace, maps, filepath.Join(nsDir, "conceptmaps.go")); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func (g *Generator) generateTypes(namespace string, schemas []schema.Schema, path string) error {
+func (g *Generator) generateTypes(scope resolve.Scope, schemas []schema.Schema, path string) error {
+	for _, s := range schemas {
+		if err := s.CheckDefaults(); err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+	}
 	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
-package {{.Namespace}}
+{{header}}package {{.Namespace}}
+
+{{- if .Imports}}
 
 import (
-	"time"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
 )
+{{- end}}
 
 {{range .Schemas}}
-// {{.Name}} - {{.Description}}
-type {{.Name}} struct {
-{{range .Fields}}	{{.Name | pascal}}	{{.Type | goType}}	` + "`json:\"{{.Name | lower}}{{if not .Required}},omitempty{{end}}\"`" + `{{if .Description}} // {{.Description}}{{end}}
+// {{. | typeName}} - {{.Description | line}}{{with .Docs}}
+//{{range .}}
+// {{.}}{{end}}{{end}}
+type {{. | typeName}} struct {
+{{range fields .}}	{{.Ident}}	{{.Type | goType}}	` + "`json:\"{{.Wire}}{{if not .Required}},omitempty{{end}}\"`" + `{{with .Doc}} // {{line .}}{{end}}
+{{end}}{{with presence .}}
+	present, null	{{.}}	// bitsets of the fields present, and null, in JSON
 {{end}}}
-{{end}}
+{{$type := typeName .}}{{with $defaults := defaults .}}
+// New{{$type}} returns a {{$type}} whose fields hold their defaults.
+func New{{$type}}() {{$type}} {
+	return {{$type}}{
+{{- range $defaults}}
+		{{.Ident}}: {{.Value}},
+{{- end}}
+	}
+}
+{{end}}{{end}}
 `
 	funcMap := template.FuncMap{
-		"lower":  strings.ToLower,
-		"pascal": toPascalCase,
-		"goType": toGoType,
+		// Descriptions may span lines, which comments cannot
+		"line": func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"goType": func(yamlType string) string {
+			return g.toGoType(scope, yamlType)
+		},
+		"typeName": g.typeName,
+		"fields":   g.fields,
+		"defaults": g.defaults,
+		"presence": g.presence,
+		"header":   g.markedHeader(schemas...),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -77,21 +363,317 @@ type {{.Name}} struct {
 
 	data := struct {
 		Namespace string
+		Imports   []string
 		Schemas   []schema.Schema
 	}{
-		Namespace: strings.ReplaceAll(namespace, "-", "_"),
+		Namespace: g.packageName(scope.Namespace),
+		Imports:   g.imports(scope, schemas),
 		Schemas:   schemas,
 	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// generateTemporal writes the PartialDate type used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generateTemporal(namespace string, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PartialDate is a date known to year, month, or day precision, such as
+// 1970, 1970-03, or 1970-03-14. Month and Day are zero when unknown.
+type PartialDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParsePartialDate parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+func ParsePartialDate(s string) (PartialDate, error) {
+	for _, layout := range []string{"2006", "2006-01", "2006-01-02"} {
+		if len(s) != len(layout) {
+			continue
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return PartialDate{}, fmt.Errorf("invalid partial date %q: %w", s, err)
+		}
+		d := PartialDate{Year: t.Year()}
+		if len(layout) >= len("2006-01") {
+			d.Month = t.Month()
+		}
+		if len(layout) == len("2006-01-02") {
+			d.Day = t.Day()
+		}
+		return d, nil
+	}
+	return PartialDate{}, fmt.Errorf("invalid partial date %q", s)
+}
+
+// Time returns the date at midnight UTC, or false when it is less precise
+// than a day.
+func (d PartialDate) Time() (time.Time, bool) {
+	if d.Day == 0 {
+		return time.Time{}, false
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC), true
+}
+
+// String formats the date at its known precision.
+func (d PartialDate) String() string {
+	switch {
+	case d.Day != 0:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	case d.Month != 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d", d.Year)
+	}
+}
+
+// MarshalJSON encodes the date as a JSON string.
+func (d PartialDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string in any supported precision.
+func (d *PartialDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParsePartialDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader()})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, g.packageName(namespace))
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(namespace string, quantity schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+{{- if eq .Decimal "json.Number"}}
+	"encoding/json"
+{{- end}}
+	"fmt"
+{{- if eq .Decimal "json.Number"}}
+	"strconv"
+{{- end}}
+{{- if eq .Decimal "decimal.Decimal"}}
+
+	"github.com/shopspring/decimal"
+{{- end}}
+)
+
+// UCUMSystem is the FHIR system URI for UCUM unit codes.
+const UCUMSystem = "` + datatypes.UCUMSystem + `"
+
+// ucumUnit is a unit whose value v is v*factor + offset in its dimension's base unit.
+type ucumUnit struct {
+	code      string
+	dimension string
+	factor    float64
+	offset    float64
+}
+
+var ucumUnits = []ucumUnit{
+{{- range .Units}}
+	{"{{.Code}}", "{{.Dimension}}", {{.Factor | float}}, {{.Offset | float}}},
+{{- end}}
+}
+
+func lookupUnit(code string) (ucumUnit, error) {
+	for _, u := range ucumUnits {
+		if u.code == code {
+			return u, nil
+		}
+	}
+	return ucumUnit{}, fmt.Errorf("unknown UCUM unit %q", code)
+}
+
+// IsKnownUnit reports whether code is a UCUM unit these helpers can convert.
+func IsKnownUnit(code string) bool {
+	_, err := lookupUnit(code)
+	return err == nil
+}
+
+// ConvertUnit converts value between two UCUM units of the same dimension.
+func ConvertUnit(value float64, from, to string) (float64, error) {
+	source, err := lookupUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	target, err := lookupUnit(to)
+	if err != nil {
+		return 0, err
+	}
+	if source.dimension != target.dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, source.dimension, to, target.dimension)
+	}
+	return (value*source.factor + source.offset - target.offset) / target.factor, nil
+}
+
+// Convert returns q in to units. Its code, or else its unit, must be a known
+// UCUM unit.
+func (q {{.Type}}) Convert(to string) ({{.Type}}, error) {
+	from := q.{{.F.code}}
+	if from == "" {
+		from = q.{{.F.unit}}
+	}
+{{- if eq .Decimal "json.Number"}}
+	v, err := q.{{.F.value}}.Float64()
+	if err != nil {
+		return {{.Type}}{}, fmt.Errorf("invalid quantity value: %w", err)
+	}
+{{- else if eq .Decimal "decimal.Decimal"}}
+	v := q.{{.F.value}}.InexactFloat64()
+{{- else}}
+	v := q.{{.F.value}}
+{{- end}}
+	value, err := ConvertUnit(v, from, to)
+	if err != nil {
+		return {{.Type}}{}, err
+	}
+{{- if eq .Decimal "json.Number"}}
+	q.{{.F.value}} = json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+{{- else if eq .Decimal "decimal.Decimal"}}
+	q.{{.F.value}} = decimal.NewFromFloat(value)
+{{- else}}
+	q.{{.F.value}} = value
+{{- end}}
+	q.{{.F.unit}}, q.{{.F.system}}, q.{{.F.code}} = to, UCUMSystem, to
+	return q, nil
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.markedHeader(quantity)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Type    string
+		Decimal string
+		F       map[string]string
+		Units   []datatypes.Unit
+	}{
+		Package: g.packageName(namespace),
+		Type:    g.typeName(quantity),
+		Decimal: g.decimalType,
+		F:       naming.ByName(g.fields(quantity)),
+		Units:   datatypes.Units,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return g.packageName(namespace)
+}
+
 // GenerateMappings generates Go mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	// TODO: Implement mapping generation
 	return nil
 }
 
+func (g *Generator) typeName(s schema.Schema) string {
+	return naming.Ident("go", naming.Case(g.naming.Types).Or(toPascalCase)(s.GetName()))
+}
+
+// fieldDefault is a field of a struct and the Go literal of its default.
+type fieldDefault struct {
+	Ident string
+	Value string
+}
+
+// defaults returns the fields of s that have defaults, with their values as
+// literals of the fields' Go types.
+func (g *Generator) defaults(s schema.Schema) []fieldDefault {
+	var defaults []fieldDefault
+	for _, f := range g.fields(s) {
+		if f.Default == "" {
+			continue
+		}
+		value := strconv.Quote(f.Default)
+		switch f.DefaultKind() {
+		case "integer":
+			value = f.Default
+		case "boolean":
+			b, _ := strconv.ParseBool(f.Default)
+			value = strconv.FormatBool(b)
+		case "decimal":
+			switch g.decimalType {
+			case "json.Number":
+				value = "json.Number(" + strconv.Quote(f.Default) + ")"
+			case "decimal.Decimal":
+				value = "decimal.RequireFromString(" + strconv.Quote(f.Default) + ")"
+			default:
+				d, _ := strconv.ParseFloat(f.Default, 64)
+				value = datatypes.FloatLiteral(d)
+			}
+		}
+		defaults = append(defaults, fieldDefault{Ident: f.Ident, Value: value})
+	}
+	return defaults
+}
+
+// fields returns the stored fields of s with their names in JSON, which
+// struct tags, patch paths, and the presence and logging helpers share: the
+// json_name of each field, or else its name as the schema writes it.
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Wire(g.allFields(s), func(name string) string { return name }))
+}
+
+// allFields returns the stored and derived fields of s, whose identifiers
+// are resolved together so that methods of derived fields do not clash with
+// stored fields.
+func (g *Generator) allFields(s schema.Schema) []naming.Field {
+	return naming.Fields("go", s.Fields, naming.Case(g.naming.Fields).Or(toPascalCase))
+}
+
+// packageName converts a namespace to a valid, lowercase Go package name.
+func (g *Generator) packageName(namespace string) string {
+	name := strings.ToLower(naming.Sanitize(naming.Case(g.naming.Packages).Apply(namespace)))
+	if naming.IsKeyword("go", name) {
+		return name + "_"
+	}
+	return name
+}
+
 func toPascalCase(s string) string {
 	words := strings.Split(s, "_")
 	for i, w := range words {
@@ -102,25 +684,75 @@ func toPascalCase(s string) string {
 	return strings.Join(words, "")
 }
 
-func toGoType(yamlType string) string {
+// imports returns the packages referenced by the Go types of the schemas' fields.
+func (g *Generator) imports(scope resolve.Scope, schemas []schema.Schema) []string {
+	used := make(map[string]bool)
+	for _, s := range schemas {
+		for _, dep := range scope.Dependencies(s) {
+			if dep.Namespace != scope.Namespace {
+				used[g.module+"/"+g.packageName(dep.Namespace)] = true
+			}
+		}
+		for _, f := range s.Fields {
+			goType := g.toGoType(scope, f.Type)
+			switch {
+			case strings.Contains(goType, "time."):
+				used["time"] = true
+			case strings.Contains(goType, "json."):
+				used["encoding/json"] = true
+			case strings.Contains(goType, "decimal."):
+				used["github.com/shopspring/decimal"] = true
+			}
+		}
+	}
+
+	imports := make([]string, 0, len(used))
+	for path := range used {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// toGoType maps a schema type to a Go type for a field of a schema in the
+// scope's namespace, qualifying types from other packages.
+func (g *Generator) toGoType(scope resolve.Scope, yamlType string) string {
+	if target, ok := scope.Lookup(yamlType); ok {
+		typeName := g.typeName(target)
+		if target.Namespace != scope.Namespace {
+			typeName = g.packageName(target.Namespace) + "." + typeName
+		}
+		return "*" + typeName
+	}
 	switch yamlType {
 	case "string", "code", "id", "uri", "url":
 		return "string"
 	case "integer", "positiveInt", "unsignedInt":
 		return "int"
 	case "decimal":
-		return "float64"
+		return g.decimalType
 	case "boolean":
 		return "bool"
-	case "date", "datetime", "instant":
+	case "date":
+		return g.dateType
+	case "datetime", "instant":
 		return "*time.Time"
 	case "base64Binary":
 		return "[]byte"
 	default:
 		if strings.HasPrefix(yamlType, "[]") {
 			innerType := strings.TrimPrefix(yamlType, "[]")
-			return fmt.Sprintf("[]%s", toGoType(innerType))
+			return fmt.Sprintf("[]%s", g.toGoType(scope, innerType))
 		}
 		return "interface{}"
 	}
 }
+
+// markedHeader returns the configured file header, if any, to follow the
+// conventional "Code generated" marker line.
+func (g *Generator) markedHeader(sources ...schema.Schema) func() string {
+	if !g.header.Configured() {
+		return func() string { return "" }
+	}
+	return g.header.Func("// ", sources...)
+}
@@ -0,0 +1,229 @@
+package golang_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/golang"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, golang.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:   config.DecimalExact,
+		Temporal:  config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:      true,
+		Narrative: true,
+		Audit:     true,
+	}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+// TestGoldenBuilds compiles each golden tree as a module of the default
+// module path, which the generated packages import each other by.
+func TestGoldenBuilds(t *testing.T) {
+	files, err := filepath.Glob("testdata/*/*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deeper, err := filepath.Glob("testdata/*/*/*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := make(map[string]bool)
+	for _, file := range append(files, deeper...) {
+		roots[filepath.Dir(filepath.Dir(file))] = true
+	}
+	gomod := "module " + golang.DefaultModule + "\n\ngo " + golang.GoVersion + "\n"
+	for root := range roots {
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err == nil {
+			// Package trees name their own module and dependencies
+			continue
+		}
+		t.Run(filepath.ToSlash(root), func(t *testing.T) {
+			generatortest.Compile(t, root, map[string]string{"go.mod": gomod}, "go", "vet", "./...")
+		})
+	}
+}
+
+// diffPathsTest runs the generated Diff of the bulk golden tree, checking
+// that each patch path names a member of the JSON the model encodes as.
+const diffPathsTest = `package fixtures
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffPaths(t *testing.T) {
+	b := LabResult{ResultId: "LR-1001", RawPayload: []byte("MSH|"), ICD10Code: "E11.9"}
+	ops, err := LabResult{}.Diff(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, op := range ops {
+		if _, ok := members[op.Path[1:]]; !ok {
+			t.Errorf("patch path %s names no member of %s", op.Path, data)
+		}
+		paths = append(paths, op.Path)
+	}
+	want := []string{"/result_id", "/rawPayload", "/ICD10Code"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("patch paths = %q, want %q", paths, want)
+	}
+}
+`
+
+func TestDiffPaths(t *testing.T) {
+	files := map[string]string{
+		"go.mod":                      "module " + golang.DefaultModule + "\n\ngo " + golang.GoVersion + "\n",
+		"fixtures/diff_paths_test.go": diffPathsTest,
+	}
+	generatortest.Compile(t, "testdata/bulk", files, "go", "test", "./fixtures")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:   config.DecimalShopspring,
+		Languages: map[string]config.Language{"go": {Module: "github.com/acme/models"}},
+	}
+	generatortest.RunPackage(t, golang.NewGenerator(cfg), "testdata/package")
+}
+
+func TestPackageInvalidModule(t *testing.T) {
+	cfg := config.Config{Languages: map[string]config.Language{"go": {Module: "github.com/acme/models/"}}}
+	err := golang.NewGenerator(cfg).Package(fixtures.Schemas(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "is not a module path") {
+		t.Errorf("Package() error = %v, want an invalid module path", err)
+	}
+}
+
+func TestGenerateNamespaceCycle(t *testing.T) {
+	schemas := []schema.Schema{
+		{Name: "Encounter", Namespace: "clinical", Fields: []schema.Field{{Name: "patient", Type: "Patient"}}},
+		{Name: "Patient", Namespace: "admin", Fields: []schema.Field{{Name: "encounter", Type: "Encounter"}}},
+	}
+	err := golang.NewGenerator(config.Config{}).Generate(context.Background(), schemas, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "clinical -> admin -> clinical") {
+		t.Fatalf("Generate() error = %v, want namespace cycle", err)
+	}
+}
+
+func TestGenerateBulkGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{Bulk: true, Bundle: true, CloudEvents: true, Resolver: true, Diff: true}), fixtures.Schemas(), "testdata/bulk")
+}
+
+func TestGenerateMsgpackGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{MessagePack: true}), fixtures.Schemas(), "testdata/msgpack")
+}
+
+func TestGenerateTriStateGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{TriState: true}), fixtures.Schemas(), "testdata/tristate")
+}
+
+func TestGenerateHashingGolden(t *testing.T) {
+	cfg := config.Config{Hashing: config.Hashing{Algorithm: config.HashSHA256, Equality: true}}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/hashing")
+}
+
+func TestGenerateClientGolden(t *testing.T) {
+	cfg := config.Config{Client: config.Client{Resources: []string{"Specimen", "lab_result", "Practitioner"}}}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/client")
+}
+
+func TestGenerateCrosswalkGolden(t *testing.T) {
+	cfg := config.Config{Crosswalk: generatortest.Crosswalk(t)}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/crosswalk")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateDICOMGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{}), fixtures.DICOMSchemas(), "testdata/dicom")
+}
+
+func TestGenerateDICOMAuditGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{Audit: true}), fixtures.DICOMSchemas(), "testdata/dicom-audit")
+}
+
+func TestGenerateQuestionnaireGolden(t *testing.T) {
+	generatortest.RunSchemas(t, golang.NewGenerator(config.Config{}), fixtures.QuestionnaireSchemas(), "testdata/questionnaire")
+}
+
+func TestGeneratePseudonymizeGolden(t *testing.T) {
+	cfg := config.Config{Pseudonymize: config.Pseudonymize{Level: "high"}}
+	generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.Schemas(), "testdata/pseudonymize")
+}
+
+func TestGenerateMatchGolden(t *testing.T) {
+	cfgs := map[string]config.Config{
+		"plain":     {},
+		"soundex":   {Matching: config.Matching{Phonetic: config.PhoneticSoundex}},
+		"metaphone": {Matching: config.Matching{Phonetic: config.PhoneticMetaphone}, Temporal: config.Temporal{Dates: config.DatesPartial}},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			generatortest.RunSchemas(t, golang.NewGenerator(cfg), fixtures.MatchSchemas(), "testdata/match/"+name)
+		})
+	}
+}
+
+func TestDecimalTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want []string
+	}{
+		{name: "float", want: []string{"ChargeAmount\tfloat64\t"}},
+		{name: "exact", cfg: config.Config{Decimal: config.DecimalExact}, want: []string{`"encoding/json"`, "ChargeAmount\tjson.Number\t"}},
+		{
+			name: "language overrides project",
+			cfg:  config.Config{Decimal: config.DecimalExact, Languages: map[string]config.Language{"go": {Decimal: config.DecimalShopspring}}},
+			want: []string{`"github.com/shopspring/decimal"`, "ChargeAmount\tdecimal.Decimal\t"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := golang.NewGenerator(tt.cfg).Generate(context.Background(), fixtures.Schemas(), dir); err != nil {
+				t.Fatalf("Generate() failed: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, "fixtures", "types.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("types.go is missing %q:\n%s", want, data)
+				}
+			}
+		})
+	}
+}
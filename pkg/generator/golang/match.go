@@ -0,0 +1,362 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// matchModel is a schema with fields that make patient matching keys: the
+// Go expressions of the components of its demographic key, and the names and
+// values of its identifiers.
+type matchModel struct {
+	Schema      schema.Schema
+	Type        string
+	Demographic []string
+	Identifiers []string
+}
+
+// matchModels returns the schemas of a namespace with fields that make
+// matching keys.
+func (g *Generator) matchModels(schemas []schema.Schema) ([]matchModel, error) {
+	var models []matchModel
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		matched, err := s.MatchFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if matched.Empty() {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		m := matchModel{Schema: s, Type: g.typeName(s)}
+		for _, f := range matched.Demographic {
+			field := "m." + idents[f.Name].Ident
+			switch f.Match {
+			case schema.MatchBirthDate:
+				m.Demographic = append(m.Demographic, "dateKey("+field+")")
+			case schema.MatchSex:
+				m.Demographic = append(m.Demographic, "sexKey("+field+")")
+			default:
+				m.Demographic = append(m.Demographic, "nameKey("+field+")")
+			}
+		}
+		for _, f := range matched.Identifiers {
+			m.Identifiers = append(m.Identifiers, "{"+strconv.Quote(f.Name)+", m."+idents[f.Name].Ident+"}")
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generateMatch writes methods that return the patient matching keys of the
+// records of models, which record linkage compares across source systems.
+// Every generator makes the same keys of the same records.
+func (g *Generator) generateMatch(namespace string, models []matchModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+{{if and .Dates (eq .DateType "*time.Time")}}
+import (
+	"strings"
+	"time"
+)
+{{else}}
+import "strings"
+{{end}}
+{{- range .Models}}
+// MatchKeys returns the patient matching keys of m, which records that are
+// candidate matches share: a blocking key of its normalized demographics,
+// when all are set, and a key of each identifier that is set.
+func (m {{.Type}}) MatchKeys() []string {
+	return matchKeys([]string{ {{- join .Demographic ", " -}} }, [][2]string{ {{- join .Identifiers ", " -}} })
+}
+{{end}}
+// matchKeys returns the demographic key made of components, unless there
+// are none or one is empty, and the key of each identifier, a name and a
+// value, whose normalized value is not empty.
+func matchKeys(components []string, identifiers [][2]string) []string {
+	var keys []string
+	complete := len(components) > 0
+	for _, c := range components {
+		complete = complete && c != ""
+	}
+	if complete {
+		keys = append(keys, "demographics:"+strings.Join(components, "|"))
+	}
+	for _, id := range identifiers {
+		if value := normalizeMatch(id[1], true); value != "" {
+			keys = append(keys, id[0]+":"+value)
+		}
+	}
+	return keys
+}
+
+// normalizeMatch returns the letters of value, and its digits too when
+// digits is set, in upper case.
+func normalizeMatch(value string, digits bool) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= 'A' && r <= 'Z', digits && r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		}
+	}
+	return b.String()
+}
+{{- if .Names}}
+
+// nameKey returns the key component of a name
+{{- if eq .Phonetic "soundex"}}: the Soundex code of its letters{{else if eq .Phonetic "metaphone"}}: the Metaphone code of its
+// letters{{else}}: its letters{{end}}.
+func nameKey(name string) string {
+	return {{if .Phonetic}}{{.Phonetic}}({{end}}normalizeMatch(name, false){{if .Phonetic}}){{end}}
+}
+{{- end}}
+{{- if .Dates}}
+
+// dateKey returns the key component of a date, as much of it as is known.
+func dateKey(date {{.DateType}}) string {
+	if date == nil {
+		return ""
+	}
+	return {{if eq .DateType "*PartialDate"}}date.String(){{else}}date.Format("2006-01-02"){{end}}
+}
+{{- end}}
+{{- if .Sexes}}
+
+// sexKey returns the key component of a sex: its first letter.
+func sexKey(sex string) string {
+	if sex = normalizeMatch(sex, false); sex == "" {
+		return ""
+	}
+	return sex[:1]
+}
+{{- end}}
+{{- if and .Names (eq .Phonetic "soundex")}}
+
+// soundexCodes are the Soundex digits of the letters A to Z.
+const soundexCodes = "01230120022455012623010202"
+
+// soundex returns the American Soundex code of name, which holds letters A
+// to Z only: its first letter and the digits of the next consonants, which
+// H and W do not separate, padded to four.
+func soundex(name string) string {
+	if name == "" {
+		return ""
+	}
+	code := []byte{name[0]}
+	last := soundexCodes[name[0]-'A']
+	for i := 1; i < len(name) && len(code) < 4; i++ {
+		digit := soundexCodes[name[i]-'A']
+		switch {
+		case name[i] == 'H' || name[i] == 'W':
+		case digit == '0':
+			last = digit
+		case digit != last:
+			code = append(code, digit)
+			last = digit
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+{{- end}}
+{{- if and .Names (eq .Phonetic "metaphone")}}
+
+// metaphone returns the Metaphone code of name, which holds letters A to Z
+// only, by the rules of the original algorithm: initial letters that are
+// silent or kept, then consonants by their sound, and no vowels after the
+// first letter.
+func metaphone(name string) string {
+	at := func(i int) byte {
+		if i < 0 || i >= len(name) {
+			return 0
+		}
+		return name[i]
+	}
+	vowel := func(c byte) bool { return c != 0 && strings.IndexByte("AEIOU", c) >= 0 }
+	soft := func(c byte) bool { return c != 0 && strings.IndexByte("EIY", c) >= 0 }
+	var code []byte
+	i := 0
+	switch at(0) {
+	case 'A':
+		if at(1) == 'E' {
+			code, i = append(code, 'E'), 2
+		} else {
+			code, i = append(code, 'A'), 1
+		}
+	case 'G', 'K', 'P':
+		if at(1) == 'N' {
+			code, i = append(code, 'N'), 2
+		}
+	case 'W':
+		if at(1) == 'R' {
+			code, i = append(code, 'R'), 2
+		} else if at(1) == 'H' || vowel(at(1)) {
+			code, i = append(code, 'W'), 2
+		}
+	case 'X':
+		code, i = append(code, 'S'), 1
+	case 'E', 'I', 'O', 'U':
+		code, i = append(code, at(0)), 1
+	}
+	for ; i < len(name); i++ {
+		c, prev, next, after := name[i], at(i-1), at(i+1), at(i+2)
+		if c == prev && c != 'C' {
+			continue
+		}
+		switch c {
+		case 'B':
+			if prev != 'M' {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case next == 'I' && after == 'A':
+				code = append(code, 'X')
+			case soft(next) && prev == 'S':
+			case soft(next):
+				code = append(code, 'S')
+			case next == 'H':
+				code = append(code, 'X')
+				i++
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if next == 'G' && soft(after) {
+				code = append(code, 'J')
+				i++
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case next == 'H':
+				if back := at(i - 3); !(back == 'B' || back == 'D' || back == 'H' || at(i-4) == 'H') {
+					code = append(code, 'F')
+					i++
+				}
+			case next == 'N':
+				if after != 0 && !(after == 'E' && at(i+3) == 'D') {
+					code = append(code, 'K')
+				}
+			case soft(next) && prev != 'G':
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			if vowel(next) && strings.IndexByte("CGPST", prev) < 0 {
+				code = append(code, 'H')
+			}
+		case 'K':
+			if prev != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if next == 'H' {
+				code = append(code, 'F')
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			switch {
+			case next == 'I' && (after == 'O' || after == 'A'):
+				code = append(code, 'X')
+			case next == 'H':
+				code = append(code, 'X')
+				i++
+			default:
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case next == 'I' && (after == 'O' || after == 'A'):
+				code = append(code, 'X')
+			case next == 'H':
+				code = append(code, '0')
+				i++
+			case !(next == 'C' && after == 'H'):
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if vowel(next) {
+				code = append(code, c)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		case 'F', 'J', 'L', 'M', 'N', 'R':
+			code = append(code, c)
+		}
+	}
+	return string(code)
+}
+{{- end}}
+`
+	funcMap := template.FuncMap{
+		"join":   strings.Join,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package  string
+		Models   []matchModel
+		Phonetic string
+		DateType string
+		Names    bool
+		Dates    bool
+		Sexes    bool
+	}{
+		Package:  g.packageName(namespace),
+		Models:   models,
+		Phonetic: g.phonetic,
+		DateType: g.dateType,
+	}
+	for _, m := range models {
+		for _, c := range m.Demographic {
+			data.Names = data.Names || strings.HasPrefix(c, "nameKey(")
+			data.Dates = data.Dates || strings.HasPrefix(c, "dateKey(")
+			data.Sexes = data.Sexes || strings.HasPrefix(c, "sexKey(")
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
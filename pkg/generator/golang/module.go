@@ -0,0 +1,92 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// GoVersion is the go directive of the go.mod files of generated modules.
+const GoVersion = "1.21"
+
+// generateDoc writes the package comment of the package of a namespace,
+// with the import path it has in the module.
+func (g *Generator) generateDoc(namespace string, schemas []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+{{- if .Datatypes}}
+// Package {{.Package}} holds the FHIR datatypes that the schemas of the
+// other packages use.
+{{- else}}
+// Package {{.Package}} holds the types of the schemas of the {{.Namespace}}
+// namespace.
+{{- end}}
+//
+// Import it as {{.ImportPath}}.
+package {{.Package}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader(schemas...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package    string
+		Namespace  string
+		ImportPath string
+		Datatypes  bool
+	}{
+		Package:    g.packageName(namespace),
+		Namespace:  namespace,
+		ImportPath: g.module + "/" + g.NamespaceDir(namespace),
+		Datatypes:  namespace == datatypes.Namespace,
+	}
+	return tmpl_parsed.Execute(f, data)
+}
+
+// Package writes the go.mod of the module the generated packages import
+// each other by, so that the output directory can be published and fetched
+// with go get as the configured module.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if strings.ContainsAny(g.module, " \t\\") || strings.HasPrefix(g.module, "/") || strings.HasSuffix(g.module, "/") {
+		return fmt.Errorf("languages.go.module: %q is not a module path", g.module)
+	}
+	tmpl := `// Generated by ehrglot v` + Version + `.
+
+module {{.Module}}
+
+go {{.GoVersion}}
+{{- if .Shopspring}}
+
+require github.com/shopspring/decimal v1.4.0
+{{- end}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, nil)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Module     string
+		GoVersion  string
+		Shopspring bool
+	}{Module: g.module, GoVersion: GoVersion, Shopspring: g.decimalType == "decimal.Decimal"}
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,452 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/sample"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// msgpackModel is a model and the JSON of the example record its round-trip
+// test decodes.
+type msgpackModel struct {
+	Schema schema.Schema
+	Type   string
+	Sample string
+}
+
+// msgpackModels returns the schemas of a namespace that have fields to
+// encode, with their example records.
+func (g *Generator) msgpackModels(scope resolve.Scope, samples *sample.Builder, schemas []schema.Schema) ([]msgpackModel, error) {
+	var models []msgpackModel
+	for _, s := range schemas {
+		if len(g.fields(s)) == 0 {
+			continue
+		}
+		data, err := json.Marshal(g.sampleRecord(scope, s.StoredFields(), samples.Record(s, 0)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		models = append(models, msgpackModel{Schema: s, Type: g.typeName(s), Sample: string(data)})
+	}
+	return models, nil
+}
+
+// sampleRecord returns an example record of fields as models decode it:
+// dates, which *time.Time decodes only as instants, become midnight UTC.
+func (g *Generator) sampleRecord(scope resolve.Scope, fields []schema.Field, record map[string]any) map[string]any {
+	for _, f := range fields {
+		value, ok := record[f.Name]
+		if !ok {
+			continue
+		}
+		elem := strings.TrimPrefix(f.Type, "[]")
+		if target, ok := scope.Lookup(elem); ok {
+			nested := target.StoredFields()
+			switch value := value.(type) {
+			case map[string]any:
+				g.sampleRecord(scope, nested, value)
+			case []any:
+				for _, v := range value {
+					if v, ok := v.(map[string]any); ok {
+						g.sampleRecord(scope, nested, v)
+					}
+				}
+			}
+			continue
+		}
+		if elem != "date" || g.dateType != "*time.Time" {
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			record[f.Name] = value + "T00:00:00Z"
+		case []any:
+			for i, v := range value {
+				if v, ok := v.(string); ok {
+					value[i] = v + "T00:00:00Z"
+				}
+			}
+		}
+	}
+	return record
+}
+
+// goLiteral returns s as a Go string literal, raw when it can be.
+func goLiteral(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// generateMsgpack writes MarshalMsgpack and UnmarshalMsgpack methods that
+// encode models as MessagePack, as compact binary for caches such as Redis,
+// and a test of each that round-trips an example record. Models are encoded
+// as their JSON would be, so that dates and decimals keep their JSON form.
+func (g *Generator) generateMsgpack(namespace string, models []msgpackModel, path, testPath string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+{{range .Models}}
+// MarshalMsgpack returns m encoded as MessagePack.
+func (m {{.Type}}) MarshalMsgpack() ([]byte, error) {
+	return encodeMsgpack(m)
+}
+
+// UnmarshalMsgpack decodes the MessagePack data into m.
+func (m *{{.Type}}) UnmarshalMsgpack(data []byte) error {
+	return decodeMsgpack(data, m)
+}
+{{end}}
+var errMsgpackShort = errors.New("msgpack: unexpected end of data")
+
+// encodeMsgpack returns the JSON of v encoded as MessagePack: objects as maps
+// with sorted keys, whole numbers as the smallest integers that hold them,
+// and other numbers as 64-bit floats.
+func encodeMsgpack(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return appendMsgpack(nil, value)
+}
+
+// decodeMsgpack decodes the MessagePack data into v through the JSON it
+// encodes.
+func decodeMsgpack(data []byte, v any) error {
+	value, rest, err := readMsgpack(data)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("msgpack: %d bytes after the value", len(rest))
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, v)
+}
+
+// appendMsgpack appends the JSON value v, as decoded with UseNumber, to b.
+func appendMsgpack(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case json.Number:
+		if n, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return appendMsgpackInt(b, n), nil
+		}
+		if n, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return binary.BigEndian.AppendUint64(append(b, 0xcf), n), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f)), nil
+	case string:
+		return append(appendMsgpackHeader(b, len(v), 0xa0, 32, 0xd9, 0xda), v...), nil
+	case []any:
+		b = appendMsgpackHeader(b, len(v), 0x90, 16, 0, 0xdc)
+		for _, value := range v {
+			var err error
+			if b, err = appendMsgpack(b, value); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b = appendMsgpackHeader(b, len(v), 0x80, 16, 0, 0xde)
+		for _, key := range keys {
+			var err error
+			if b, err = appendMsgpack(b, key); err != nil {
+				return nil, err
+			}
+			if b, err = appendMsgpack(b, v[key]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("msgpack: unsupported value %T", v)
+}
+
+// appendMsgpackInt appends n as the smallest MessagePack integer that holds
+// it.
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n < 128, n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n >= 0 && n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n >= 0 && n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	case n >= 0:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), uint64(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+}
+
+// appendMsgpackHeader appends the header of a string, array, or map of n
+// elements: the fix form when n is below fixMax, and otherwise the 8-bit
+// form, which only strings have, or the 16- or 32-bit form, which follows
+// it.
+func appendMsgpackHeader(b []byte, n int, fix byte, fixMax int, form8, form16 byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(b, fix|byte(n))
+	case form8 != 0 && n <= math.MaxUint8:
+		return append(b, form8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, form16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, form16+1), uint32(n))
+}
+
+// readMsgpack decodes the MessagePack value at the start of data as a JSON
+// value, and returns the bytes after it. Binary decodes as []byte, which
+// JSON writes as base64.
+func readMsgpack(data []byte) (any, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errMsgpackShort
+	}
+	tag, data := data[0], data[1:]
+	switch {
+	case tag < 0x80:
+		return int64(tag), data, nil
+	case tag >= 0xe0:
+		return int64(int8(tag)), data, nil
+	case tag >= 0xa0 && tag < 0xc0:
+		return readMsgpackString(data, int(tag&0x1f))
+	case tag >= 0x90 && tag < 0xa0:
+		return readMsgpackArray(data, int(tag&0x0f))
+	case tag >= 0x80 && tag < 0x90:
+		return readMsgpackMap(data, int(tag&0x0f))
+	}
+	switch tag {
+	case 0xc0:
+		return nil, data, nil
+	case 0xc2:
+		return false, data, nil
+	case 0xc3:
+		return true, data, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, data, err := readMsgpackLength(data, 1<<(tag-0xc4))
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) < n {
+			return nil, nil, errMsgpackShort
+		}
+		return bytes.Clone(data[:n]), data[n:], nil
+	case 0xca:
+		if len(data) < 4 {
+			return nil, nil, errMsgpackShort
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), data[4:], nil
+	case 0xcb:
+		if len(data) < 8 {
+			return nil, nil, errMsgpackShort
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		size := 1 << (tag - 0xcc)
+		if len(data) < size {
+			return nil, nil, errMsgpackShort
+		}
+		return readMsgpackUint(data[:size]), data[size:], nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (tag - 0xd0)
+		if len(data) < size {
+			return nil, nil, errMsgpackShort
+		}
+		n := readMsgpackUint(data[:size])
+		// Sign-extend from the size of the integer.
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, data[size:], nil
+	case 0xd9, 0xda, 0xdb:
+		n, data, err := readMsgpackLength(data, 1<<(tag-0xd9))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackString(data, n)
+	case 0xdc, 0xdd:
+		n, data, err := readMsgpackLength(data, 2<<(tag-0xdc))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackArray(data, n)
+	case 0xde, 0xdf:
+		n, data, err := readMsgpackLength(data, 2<<(tag-0xde))
+		if err != nil {
+			return nil, nil, err
+		}
+		return readMsgpackMap(data, n)
+	}
+	return nil, nil, fmt.Errorf("msgpack: unsupported type 0x%02x", tag)
+}
+
+// readMsgpackUint returns the big-endian unsigned integer data holds.
+func readMsgpackUint(data []byte) uint64 {
+	var n uint64
+	for _, b := range data {
+		n = n<<8 | uint64(b)
+	}
+	return n
+}
+
+// readMsgpackLength reads a length of size bytes.
+func readMsgpackLength(data []byte, size int) (int, []byte, error) {
+	if len(data) < size {
+		return 0, nil, errMsgpackShort
+	}
+	n := readMsgpackUint(data[:size])
+	if n > uint64(len(data)) {
+		return 0, nil, errMsgpackShort
+	}
+	return int(n), data[size:], nil
+}
+
+func readMsgpackString(data []byte, n int) (any, []byte, error) {
+	if len(data) < n {
+		return nil, nil, errMsgpackShort
+	}
+	return string(data[:n]), data[n:], nil
+}
+
+func readMsgpackArray(data []byte, n int) (any, []byte, error) {
+	values := make([]any, n)
+	for i := range values {
+		var err error
+		if values[i], data, err = readMsgpack(data); err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, data, nil
+}
+
+func readMsgpackMap(data []byte, n int) (any, []byte, error) {
+	values := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		key, rest, err := readMsgpack(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("msgpack: map key %v is not a string", key)
+		}
+		if values[name], data, err = readMsgpack(rest); err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, data, nil
+}
+`
+	testTmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+{{range .Models}}
+func Test{{.Type}}Msgpack(t *testing.T) {
+	var m {{.Type}}
+	if err := json.Unmarshal([]byte({{literal .Sample}}), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got {{.Type}}
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"literal": goLiteral,
+		"header":  g.markedHeader(sources...),
+	}
+
+	data := struct {
+		Package string
+		Models  []msgpackModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	for _, file := range []struct{ tmpl, path string }{{tmpl, path}, {testTmpl, testPath}} {
+		tmpl_parsed, err := g.templates.Parse(file.tmpl, funcMap)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(file.path)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		err = tmpl_parsed.Execute(f, data)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
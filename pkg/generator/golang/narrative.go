@@ -0,0 +1,189 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// narrativeModel is a schema whose records render as narratives.
+type narrativeModel struct {
+	Schema schema.Schema
+	Type   string
+	Rows   []narrativeRow
+}
+
+// narrativeRow is a row of a narrative: the label of a field, a Go
+// expression that is true when the field is set, or empty when it always
+// is, and the field's value as text.
+type narrativeRow struct {
+	Label string
+	Set   string
+	Value string
+}
+
+// narrativeModels returns the schemas of a namespace with fields that
+// narratives show, and the packages their methods import. Fields other
+// than pointers are unset when zero, as omitempty treats them, so narratives
+// leave out optional falses and zeros.
+func (g *Generator) narrativeModels(schemas []schema.Schema) ([]narrativeModel, []string) {
+	var models []narrativeModel
+	imports := []string{"html", "strings"}
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		shown := s.NarrativeFields()
+		if len(shown) == 0 {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		m := narrativeModel{Schema: s, Type: g.typeName(s)}
+		for _, f := range shown {
+			row, pkg := g.narrativeRow(idents[f.Name])
+			if pkg != "" && !contains(imports, pkg) {
+				imports = append(imports, pkg)
+			}
+			if f.Unit != "" {
+				row.Value += " + " + strconv.Quote(" "+f.Unit)
+			}
+			m.Rows = append(m.Rows, row)
+		}
+		models = append(models, m)
+	}
+	sort.Strings(imports)
+	return models, imports
+}
+
+// narrativeRow returns the row of f, and the package its value needs, if
+// any.
+func (g *Generator) narrativeRow(f naming.Field) (narrativeRow, string) {
+	field := "m." + f.Ident
+	row := narrativeRow{Label: strconv.Quote(f.NarrativeLabel()), Set: field + " != 0", Value: field}
+	var pkg string
+	switch f.Type {
+	case "integer", "positiveInt", "unsignedInt":
+		row.Value, pkg = "strconv.Itoa("+field+")", "strconv"
+	case "decimal":
+		switch g.decimalType {
+		case "json.Number":
+			row.Set, row.Value = field+` != ""`, field+".String()"
+		case "decimal.Decimal":
+			row.Set, row.Value = "!"+field+".IsZero()", field+".String()"
+		default:
+			row.Value, pkg = "strconv.FormatFloat("+field+", 'f', -1, 64)", "strconv"
+		}
+	case "boolean":
+		row.Set, row.Value = field, `"Yes"`
+		if f.Required {
+			row.Value = "yesNo(" + field + ")"
+		}
+	case "date":
+		// Dates and times are pointers, which may be nil even when required
+		row.Set, row.Value = field+" != nil", field+".String()"
+		if g.dateType != "*PartialDate" {
+			row.Value = field + `.Format("2006-01-02")`
+		}
+		return row, ""
+	case "datetime", "instant":
+		row.Set, row.Value = field+" != nil", field+".Format(time.RFC3339)"
+		return row, "time"
+	default:
+		row.Set = field + ` != ""`
+	}
+	if f.Required {
+		row.Set = ""
+	}
+	return row, pkg
+}
+
+// generateNarrative writes methods that render the records of models as
+// XHTML narratives.
+func (g *Generator) generateNarrative(namespace string, models []narrativeModel, imports []string, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Models}}
+// ToNarrative returns the fields of m that are set as an XHTML table, for
+// the text.div of its FHIR resource.
+func (m {{.Type}}) ToNarrative() string {
+	var b strings.Builder
+	b.WriteString(` + "`" + `<div xmlns="http://www.w3.org/1999/xhtml"><table>` + "`" + `)
+{{- range .Rows}}
+{{- if .Set}}
+	if {{.Set}} {
+		writeNarrativeRow(&b, {{.Label}}, {{.Value}})
+	}
+{{- else}}
+	writeNarrativeRow(&b, {{.Label}}, {{.Value}})
+{{- end}}
+{{- end}}
+	b.WriteString("</table></div>")
+	return b.String()
+}
+{{end}}
+// writeNarrativeRow writes a table row that labels value, both escaped as
+// XHTML.
+func writeNarrativeRow(b *strings.Builder, label, value string) {
+	b.WriteString("<tr><th>" + html.EscapeString(label) + "</th><td>" + html.EscapeString(value) + "</td></tr>")
+}
+{{- if .YesNo}}
+
+// yesNo returns a boolean as narratives show it.
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+{{- end}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Imports []string
+		Models  []narrativeModel
+		YesNo   bool
+	}{
+		Package: g.packageName(namespace),
+		Imports: imports,
+		Models:  models,
+	}
+	for _, m := range models {
+		for _, r := range m.Rows {
+			data.YesNo = data.YesNo || strings.HasPrefix(r.Value, "yesNo(")
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,246 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// presenceModel is a model whose fields track whether they were present,
+// and whether they were null, in JSON.
+type presenceModel struct {
+	Schema schema.Schema
+	Type   string
+	Fields []naming.Field
+}
+
+// presence returns the type of the bitsets of s that track its fields in
+// JSON, or "" when tri-state fields are disabled or s has no fields.
+func (g *Generator) presence(s schema.Schema) string {
+	n := len(g.fields(s))
+	if !g.triState || n == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[%d]uint64", (n+63)/64)
+}
+
+// presenceModels returns the schemas of a namespace whose fields are
+// tri-state.
+func (g *Generator) presenceModels(schemas []schema.Schema) []presenceModel {
+	var models []presenceModel
+	for _, s := range schemas {
+		if g.presence(s) == "" {
+			continue
+		}
+		models = append(models, presenceModel{Schema: s, Type: g.typeName(s), Fields: g.fields(s)})
+	}
+	return models
+}
+
+// generatePresence writes the methods that tell the fields of models left
+// out of JSON from those explicitly null, and encode and decode models
+// keeping the two apart, for FHIR PATCH and partial updates.
+func (g *Generator) generatePresence(namespace string, models []presenceModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+{{range .Models}}{{$type := .Type}}
+// {{$type}}Field names a field of {{$type}} for its presence methods.
+type {{$type}}Field uint
+
+// Fields of {{$type}}.
+const (
+{{- range $i, $f := .Fields}}
+	{{$type}}Field{{.Ident}}{{if not $i}} {{$type}}Field = iota{{end}}
+{{- end}}
+)
+
+// IsPresent reports whether f was present in the JSON m was decoded from,
+// even as null, or was set null with SetNull.
+func (m {{$type}}) IsPresent(f {{$type}}Field) bool {
+	return hasBit(m.present[:], uint(f))
+}
+
+// IsNull reports whether f was null in the JSON m was decoded from, or was
+// set null with SetNull. m encodes f as null as long as f holds no value.
+func (m {{$type}}) IsNull(f {{$type}}Field) bool {
+	return hasBit(m.null[:], uint(f))
+}
+
+// SetNull clears f and marks it null, so that m encodes it as null, as a
+// FHIR PATCH or partial update removes a field.
+func (m *{{$type}}) SetNull(f {{$type}}Field) {
+	m.clearField(f)
+	setBit(m.present[:], uint(f), true)
+	setBit(m.null[:], uint(f), true)
+}
+
+// Unset clears f and marks it absent, so that m leaves it out of JSON, as a
+// FHIR PATCH or partial update leaves a field unchanged.
+func (m *{{$type}}) Unset(f {{$type}}Field) {
+	m.clearField(f)
+	setBit(m.present[:], uint(f), false)
+	setBit(m.null[:], uint(f), false)
+}
+
+func (m *{{$type}}) clearField(f {{$type}}Field) {
+	var zero {{$type}}
+	switch f {
+{{- range .Fields}}
+	case {{$type}}Field{{.Ident}}:
+		m.{{.Ident}} = zero.{{.Ident}}
+{{- end}}
+	}
+}
+
+// MarshalJSON encodes m as JSON, writing the fields marked null that hold
+// no value as null rather than leaving them out.
+func (m {{$type}}) MarshalJSON() ([]byte, error) {
+	return encodeObject([]jsonMember{
+{{- range .Fields}}
+		{"{{.Wire}}", m.{{.Ident}}, {{.Required}}, m.IsNull({{$type}}Field{{.Ident}})},
+{{- end}}
+	})
+}
+
+// UnmarshalJSON decodes m from JSON, recording which of its fields were
+// present and which were null.
+func (m *{{$type}}) UnmarshalJSON(data []byte) error {
+	type plain {{$type}}
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	return decodePresence(data, m.present[:], m.null[:], []string{
+{{- range $i, $f := .Fields}}{{if $i}}, {{end}}"{{.Wire}}"{{end -}} })
+}
+{{end}}
+// jsonMember is a member of the JSON object a model encodes as.
+type jsonMember struct {
+	name     string
+	value    any
+	required bool
+	null     bool
+}
+
+// encodeObject encodes members as a JSON object. It leaves out the optional
+// members that hold no value, as omitempty does, unless they are marked
+// null, which it writes as null.
+func encodeObject(members []jsonMember) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	n := 0
+	for _, member := range members {
+		value := []byte("null")
+		switch {
+		case member.required || !isEmpty(member.value):
+			data, err := json.Marshal(member.value)
+			if err != nil {
+				return nil, err
+			}
+			value = data
+		case !member.null:
+			continue
+		}
+		if n > 0 {
+			b.WriteByte(',')
+		}
+		n++
+		name, _ := json.Marshal(member.name)
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// isEmpty reports whether omitempty leaves v out of JSON.
+func isEmpty(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return rv.IsZero()
+	case reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// decodePresence records which of the members named names the JSON object
+// data holds in present, and which of those are null in null. Names match
+// ignoring case, as encoding/json matches them.
+func decodePresence(data []byte, present, null []uint64, names []string) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for i := range present {
+		present[i], null[i] = 0, 0
+	}
+	for key, raw := range members {
+		for i, name := range names {
+			if strings.EqualFold(key, name) {
+				setBit(present, uint(i), true)
+				setBit(null, uint(i), bytes.Equal(raw, []byte("null")))
+			}
+		}
+	}
+	return nil
+}
+
+func hasBit(bits []uint64, i uint) bool {
+	return bits[i/64]&(1<<(i%64)) != 0
+}
+
+func setBit(bits []uint64, i uint, on bool) {
+	if on {
+		bits[i/64] |= 1 << (i % 64)
+	} else {
+		bits[i/64] &^= 1 << (i % 64)
+	}
+}
+`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []presenceModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
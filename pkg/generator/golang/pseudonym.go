@@ -0,0 +1,155 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// pseudonymModel is a schema with fields sensitive at the pseudonymization
+// level: text fields that tokens replace, and others that are cleared.
+type pseudonymModel struct {
+	Schema    schema.Schema
+	Type      string
+	Tokenized []naming.Field
+	Cleared   []naming.Field
+}
+
+// pseudonymModels returns the schemas of a namespace with fields sensitive at
+// level.
+func (g *Generator) pseudonymModels(schemas []schema.Schema, level string) []pseudonymModel {
+	var models []pseudonymModel
+	for _, s := range schemas {
+		if !s.HasSensitive(level) {
+			continue
+		}
+		m := pseudonymModel{Schema: s, Type: g.typeName(s)}
+		for _, f := range g.fields(s) {
+			switch {
+			case !f.Sensitive(level):
+			case f.Pseudonymizable():
+				m.Tokenized = append(m.Tokenized, f)
+			default:
+				m.Cleared = append(m.Cleared, f)
+			}
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// generatePseudonym writes a Pseudonymizer that derives stable HMAC tokens
+// from identifiers, and Pseudonymize methods that replace the sensitive
+// fields of models with them for research data releases.
+func (g *Generator) generatePseudonym(namespace string, models []pseudonymModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// PseudonymKeyEnv is the environment variable that holds the HMAC key of
+// pseudonyms.
+const PseudonymKeyEnv = "{{.KeyEnv}}"
+
+// Pseudonymizer replaces identifiers with stable tokens: the hex
+// HMAC-SHA256 of the identifier under a secret key. Releases made with the
+// same key link on equal identifiers without revealing them.
+type Pseudonymizer struct {
+	key []byte
+}
+
+// NewPseudonymizer returns a Pseudonymizer keyed by key.
+func NewPseudonymizer(key []byte) *Pseudonymizer {
+	return &Pseudonymizer{key: key}
+}
+
+// PseudonymizerFromEnv returns a Pseudonymizer keyed by the value of
+// PseudonymKeyEnv.
+func PseudonymizerFromEnv() (*Pseudonymizer, error) {
+	key := os.Getenv(PseudonymKeyEnv)
+	if key == "" {
+		return nil, fmt.Errorf("%s is not set", PseudonymKeyEnv)
+	}
+	return NewPseudonymizer([]byte(key)), nil
+}
+
+// Token returns the pseudonym of value.
+func (p *Pseudonymizer) Token(value string) string {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Pseudonym links a token to the value it replaced, as a row of the
+// re-identification table. Field is the schema and field name, such as
+// patient.ssn.
+type Pseudonym struct {
+	Token string
+	Field string
+	Value string
+}
+{{range .Models}}{{$name := .Schema.GetName}}
+// Pseudonymize returns m with its fields at pii_level {{$.Level}} or above
+// replaced by their tokens, or cleared when they are not text, and the
+// pseudonyms it made.
+func (m {{.Type}}) Pseudonymize(p *Pseudonymizer) ({{.Type}}, []Pseudonym) {
+	var pseudonyms []Pseudonym
+{{- range .Tokenized}}
+	if m.{{.Ident}} != "" {
+		token := p.Token(m.{{.Ident}})
+		pseudonyms = append(pseudonyms, Pseudonym{Token: token, Field: "{{$name}}.{{.Name}}", Value: m.{{.Ident}}})
+		m.{{.Ident}} = token
+	}
+{{- end}}
+{{- if .Cleared}}
+	var zero {{.Type}}
+{{- range .Cleared}}
+	m.{{.Ident}} = zero.{{.Ident}}
+{{- end}}
+{{- end}}
+	return m, pseudonyms
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		KeyEnv  string
+		Level   string
+		Models  []pseudonymModel
+	}{
+		Package: g.packageName(namespace),
+		KeyEnv:  g.pseudonym.KeyEnv,
+		Level:   strings.ToLower(g.pseudonym.Level),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,120 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// purposeModel is a schema that restricts the purposes of use it is
+// disclosed for, with the fields each permitted purpose must not see.
+type purposeModel struct {
+	Schema   schema.Schema
+	Type     string
+	Purposes []purposeFilter
+}
+
+type purposeFilter struct {
+	Purpose  string
+	Stripped []naming.Field
+}
+
+// purposeModels returns the schemas of a namespace that restrict their
+// purposes of use.
+func (g *Generator) purposeModels(schemas []schema.Schema) ([]purposeModel, error) {
+	var models []purposeModel
+	for _, s := range schemas {
+		if !s.HasPurposes() {
+			continue
+		}
+		if err := s.CheckPurposes(); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		m := purposeModel{Schema: s, Type: g.typeName(s)}
+		for _, p := range schema.Purposes {
+			if !s.Permits(p) {
+				continue
+			}
+			filter := purposeFilter{Purpose: p}
+			for _, f := range g.fields(s) {
+				if !s.PermitsField(f.Field, p) {
+					filter.Stripped = append(filter.Stripped, f)
+				}
+			}
+			m.Purposes = append(m.Purposes, filter)
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generatePurpose writes ForPurpose methods that strip the fields of models
+// a purpose of use may not see, and whole records it may not see at all.
+func (g *Generator) generatePurpose(namespace string, models []purposeModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+// Purpose is a purpose of use that records are disclosed for.
+type Purpose string
+
+// Purposes of use.
+const (
+{{- range .Purposes}}
+	{{purpose .}} Purpose = "{{.}}"
+{{- end}}
+)
+{{range .Models}}
+// ForPurpose returns m with the fields that purpose may not see cleared, and
+// false when purpose may not see m at all.
+func (m {{.Type}}) ForPurpose(purpose Purpose) ({{.Type}}, bool) {
+	var zero {{.Type}}
+	switch purpose {
+{{- range .Purposes}}
+	case {{purpose .Purpose}}:
+{{- range .Stripped}}
+		m.{{.Ident}} = zero.{{.Ident}}
+{{- end}}
+{{- end}}
+	default:
+		return zero, false
+	}
+	return m, true
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"purpose": func(p string) string {
+			return "Purpose" + naming.Pascal.Apply(p)
+		},
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package  string
+		Purposes []string
+		Models   []purposeModel
+	}{
+		Package:  g.packageName(namespace),
+		Purposes: schema.Purposes,
+		Models:   models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
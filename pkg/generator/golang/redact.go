@@ -0,0 +1,110 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// redactSchemas returns the schemas of a namespace with fields sensitive at
+// level.
+func redactSchemas(schemas []schema.Schema, level string) []schema.Schema {
+	var redacted []schema.Schema
+	for _, s := range schemas {
+		if s.HasSensitive(level) {
+			redacted = append(redacted, s)
+		}
+	}
+	return redacted
+}
+
+// generateRedact writes String, GoString, and RedactForLogging methods that
+// mask the sensitive fields of schemas, so that printing or logging a model
+// does not leak them.
+func (g *Generator) generateRedact(namespace string, schemas []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Redacted replaces the values of fields at pii_level {{.Level}} or above
+// when models are printed or logged.
+const Redacted = "` + schema.Redacted + `"
+{{range .Schemas}}{{$type := . | typeName}}
+// String returns m with its fields at pii_level {{$.Level}} or above masked,
+// so that printing or logging m does not leak them.
+func (m {{$type}}) String() string {
+	return redactedString("{{$type}}", []redactedField{
+{{- range fields .}}
+		{"{{.Ident}}", {{if .Sensitive $.Level}}Redacted{{else}}m.{{.Ident}}{{end}}},
+{{- end}}
+	})
+}
+
+// GoString masks the same fields as String, for the %#v verb.
+func (m {{$type}}) GoString() string {
+	return m.String()
+}
+
+// RedactForLogging returns the fields of m keyed by JSON name, with those at
+// pii_level {{$.Level}} or above masked, for structured logs.
+func (m {{$type}}) RedactForLogging() map[string]any {
+	return map[string]any{
+{{- range fields .}}
+		"{{.Wire}}": {{if .Sensitive $.Level}}Redacted{{else}}m.{{.Ident}}{{end}},
+{{- end}}
+	}
+}
+{{end}}
+type redactedField struct {
+	name  string
+	value any
+}
+
+func redactedString(typeName string, fields []redactedField) string {
+	var b strings.Builder
+	b.WriteString(typeName + "{")
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "%s:%+v", f.name, f.value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+`
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"fields":   g.fields,
+		"header":   g.markedHeader(schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Level   string
+		Schemas []schema.Schema
+	}{
+		Package: g.packageName(namespace),
+		Level:   strings.ToLower(g.redact),
+		Schemas: schemas,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,123 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// rangeModel is a schema with fields that have reference ranges.
+type rangeModel struct {
+	Schema schema.Schema
+	Type   string
+	Checks []rangeCheck
+}
+
+// rangeCheck is the body of the method reporting whether a field is outside
+// its reference range: statements that decode the field, if it needs them,
+// and an expression that is true when it is set and out of range.
+type rangeCheck struct {
+	Method     string
+	Name       string
+	Range      string
+	Decode     string
+	OutOfRange string
+}
+
+// rangeModels returns the schemas of a namespace with reference ranges.
+// Fields other than json.Numbers are unset when zero, as omitempty treats
+// them.
+func (g *Generator) rangeModels(schemas []schema.Schema) ([]rangeModel, error) {
+	var models []rangeModel
+	for _, s := range schemas {
+		ranged, err := s.RangedFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(ranged) == 0 {
+			continue
+		}
+		idents := make(map[string]string)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f.Ident
+		}
+		m := rangeModel{Schema: s, Type: g.typeName(s)}
+		for _, f := range ranged {
+			ident := idents[f.Name]
+			check := rangeCheck{Method: ident + "OutOfRange", Name: f.Name, Range: f.ReferenceRange()}
+			value, set := "m."+ident, "m."+ident+" != 0"
+			if f.Type == "decimal" {
+				switch g.decimalType {
+				case "json.Number":
+					check.Decode = "v, err := m." + ident + ".Float64()"
+					value, set = "v", "err == nil"
+				case "decimal.Decimal":
+					value, set = "m."+ident+".InexactFloat64()", "!m."+ident+".IsZero()"
+				}
+			}
+			var test string
+			switch {
+			case f.ReferenceLow != "" && f.ReferenceHigh != "":
+				test = value + " < " + f.ReferenceLow + " || " + value + " > " + f.ReferenceHigh
+				if !f.Required || check.Decode != "" {
+					test = "(" + test + ")"
+				}
+			case f.ReferenceLow != "":
+				test = value + " < " + f.ReferenceLow
+			default:
+				test = value + " > " + f.ReferenceHigh
+			}
+			if !f.Required || check.Decode != "" {
+				test = set + " && " + test
+			}
+			check.OutOfRange = test
+			m.Checks = append(m.Checks, check)
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generateReference writes methods that report whether the fields of models
+// with reference ranges hold values outside them, to flag for review.
+func (g *Generator) generateReference(namespace string, models []rangeModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+{{range $m := .Models}}{{range .Checks}}
+// {{.Method}} reports whether the {{.Name}} of m is set and outside its
+// reference range, {{.Range}}.
+func (m {{$m.Type}}) {{.Method}}() bool {
+{{- with .Decode}}
+	{{.}}
+{{- end}}
+	return {{.OutOfRange}}
+}
+{{end}}{{end}}`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []rangeModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
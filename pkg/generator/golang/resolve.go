@@ -0,0 +1,226 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// resolverModel is a resource that a resolver indexes: the identifier of its
+// id field, or empty when it has none, and its reference fields.
+type resolverModel struct {
+	Schema schema.Schema
+	Type   string
+	ID     string
+	Refs   []resolverRef
+}
+
+// resolverRef is a field holding references, singly or in a list.
+type resolverRef struct {
+	Name  string
+	Ident string
+	List  bool
+}
+
+// resolverModels returns the resources of a namespace as a resolver indexes
+// them.
+func (g *Generator) resolverModels(resources []schema.Schema) []resolverModel {
+	var models []resolverModel
+	for _, s := range resources {
+		idents := make(map[string]string)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f.Ident
+		}
+		m := resolverModel{Schema: s, Type: g.typeName(s), ID: idents["id"]}
+		for _, f := range datatypes.ReferenceFields(s) {
+			m.Refs = append(m.Refs, resolverRef{Name: f.Name, Ident: idents[f.Name], List: strings.HasPrefix(f.Type, "[]")})
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// referenceIdent returns the identifier of the literal reference of the
+// built-in Reference.
+func (g *Generator) referenceIdent() string {
+	reference, _ := datatypes.Builtin(datatypes.ReferenceName)
+	for _, f := range g.fields(reference) {
+		if f.Name == "reference" {
+			return f.Ident
+		}
+	}
+	return ""
+}
+
+// generateResolver writes an in-memory index of the resources of a
+// namespace that resolves the references between them.
+func (g *Generator) generateResolver(namespace string, models []resolverModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resolver indexes resources of this package by type and id, and resolves
+// the references between them, such as those among the entries of a Bundle.
+// The zero Resolver is empty and ready to use.
+type Resolver struct {
+	resources []any
+	byKey     map[string]any
+}
+
+// DanglingReference is a reference of a resource added to a Resolver that
+// points to none of the resources added.
+type DanglingReference struct {
+	Source    string // Type and id of the referencing resource, such as {{.Example.Schema.GetName}}/123, or its type when it has no id
+	Field     string // Name of the field holding the reference
+	Reference string
+}
+
+// Add indexes resource, such as a *{{.Example.Type}}, by its type and id. Resources
+// are indexed as pointers, so adding a value indexes a copy of it, and nil
+// pointers are ignored. Resources without an id cannot be resolved, but
+// Dangling checks their references too.
+func (r *Resolver) Add(resource any) error {
+	switch m := resource.(type) {
+{{- range .Models}}
+	case {{.Type}}:
+		r.add(&m, "{{.Schema.GetName}}", {{if .ID}}m.{{.ID}}{{else}}""{{end}})
+	case *{{.Type}}:
+		if m != nil {
+			r.add(m, "{{.Schema.GetName}}", {{if .ID}}m.{{.ID}}{{else}}""{{end}})
+		}
+{{- end}}
+	default:
+		return fmt.Errorf("%T is not a resource of this package", resource)
+	}
+	return nil
+}
+
+func (r *Resolver) add(resource any, resourceType, id string) {
+	if id != "" {
+		if r.byKey == nil {
+			r.byKey = make(map[string]any)
+		}
+		r.byKey[resourceType+"/"+id] = resource
+	}
+	r.resources = append(r.resources, resource)
+}
+
+// Resolve returns the resource that a literal reference, such as
+// {{.Example.Schema.GetName}}/123 or https://fhir.example.org/r4/{{.Example.Schema.GetName}}/123/_history/2,
+// points to, and whether it was added.
+func (r *Resolver) Resolve(reference string) (any, bool) {
+	resource, ok := r.byKey[referenceKey(reference)]
+	return resource, ok
+}
+{{range .Models}}{{if .ID}}
+// Resolve{{.Type}} returns the {{.Type}} that reference points to, and whether it
+// was added.
+func (r *Resolver) Resolve{{.Type}}(reference string) (*{{.Type}}, bool) {
+	m, ok := r.byKey[referenceKey(reference)].(*{{.Type}})
+	return m, ok
+}
+{{end}}{{end}}
+// Dangling returns the references of the added resources that point to none
+// of them, in the order the resources were added. Only references to a type
+// and id are checked, not contained (#id), logical, or urn:uuid ones.
+func (r *Resolver) Dangling() []DanglingReference {
+	var dangling []DanglingReference
+{{- if .Referencing}}
+	for _, resource := range r.resources {
+		switch m := resource.(type) {
+{{- range .Models}}{{if .Refs}}
+		case *{{.Type}}:
+			source := "{{.Schema.GetName}}"{{if .ID}}
+			if m.{{.ID}} != "" {
+				source += "/" + m.{{.ID}}
+			}{{end}}
+{{- range .Refs}}
+{{- if .List}}
+			for _, ref := range m.{{.Ident}} {
+				if ref != nil {
+					dangling = r.dangling(dangling, source, "{{.Name}}", ref.{{$.Reference}})
+				}
+			}
+{{- else}}
+			if m.{{.Ident}} != nil {
+				dangling = r.dangling(dangling, source, "{{.Name}}", m.{{.Ident}}.{{$.Reference}})
+			}
+{{- end}}
+{{- end}}
+{{- end}}{{end}}
+		}
+	}
+{{- end}}
+	return dangling
+}
+{{- if .Referencing}}
+
+// dangling appends a reference to dangling when it points to a type and id
+// that was not added.
+func (r *Resolver) dangling(dangling []DanglingReference, source, field, reference string) []DanglingReference {
+	if key := referenceKey(reference); key != "" && r.byKey[key] == nil {
+		dangling = append(dangling, DanglingReference{Source: source, Field: field, Reference: reference})
+	}
+	return dangling
+}
+{{- end}}
+
+// referenceKey returns the type and id that a literal reference points to,
+// as Type/id, or "" when it points to none, as contained (#id) and urn:uuid
+// references do not.
+func referenceKey(reference string) string {
+	if i := strings.Index(reference, "/_history/"); i >= 0 {
+		reference = reference[:i]
+	}
+	parts := strings.Split(reference, "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return ""
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package     string
+		Models      []resolverModel
+		Example     resolverModel
+		Reference   string
+		Referencing bool
+	}{
+		Package:   g.packageName(namespace),
+		Models:    models,
+		Example:   models[0],
+		Reference: g.referenceIdent(),
+	}
+	for i := len(models) - 1; i >= 0; i-- {
+		data.Referencing = data.Referencing || len(models[i].Refs) > 0
+		if models[i].ID != "" {
+			data.Example = models[i]
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
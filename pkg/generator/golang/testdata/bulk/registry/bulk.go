@@ -0,0 +1,140 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// BulkManifest is the manifest of a FHIR Bulk Data ($export) response.
+type BulkManifest struct {
+	TransactionTime     string     `json:"transactionTime"`
+	Request             string     `json:"request"`
+	RequiresAccessToken bool       `json:"requiresAccessToken"`
+	Output              []BulkFile `json:"output"`
+	Error               []BulkFile `json:"error,omitempty"`
+}
+
+// BulkFile is one NDJSON file of a bulk export.
+type BulkFile struct {
+	Type  string `json:"type"`
+	URL   string `json:"url"`
+	Count int    `json:"count,omitempty"`
+}
+
+// BulkLoader streams the resources of a bulk export as the models of this
+// package.
+type BulkLoader struct {
+	// AccessToken is sent as a bearer token for the files of manifests that
+	// require one.
+	AccessToken string
+	// Client fetches http and https file URLs; nil uses http.DefaultClient.
+	Client *http.Client
+}
+
+// Load reads the manifest at path and calls fn with each resource of the
+// output files whose type is a model of this package, such as a *Practitioner
+// for Practitioner files. Other files are skipped. File URLs that are not
+// http or https are paths, relative to the manifest. Load stops at the first
+// error fn returns.
+func (l *BulkLoader) Load(path string, fn func(resource any) error) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var manifest BulkManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, file := range manifest.Output {
+		decode, ok := bulkDecoders[file.Type]
+		if !ok {
+			continue
+		}
+		if err := l.read(filepath.Dir(path), manifest.RequiresAccessToken, file.URL, decode, fn); err != nil {
+			return fmt.Errorf("%s: %w", file.URL, err)
+		}
+	}
+	return nil
+}
+
+func (l *BulkLoader) read(dir string, auth bool, fileURL string, decode func([]byte) (any, error), fn func(any) error) error {
+	r, err := l.open(dir, auth, fileURL)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	scanner := bufio.NewScanner(r)
+	// Resources with large attachments can make long lines
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		resource, err := decode(scanner.Bytes())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(resource); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (l *BulkLoader) open(dir string, auth bool, fileURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(fileURL)
+	if err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		req, err := http.NewRequest(http.MethodGet, fileURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/fhir+ndjson")
+		if auth && l.AccessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+l.AccessToken)
+		}
+		client := l.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("GET %s: %s", fileURL, resp.Status)
+		}
+		return resp.Body, nil
+	}
+	path := fileURL
+	if err == nil && u.Scheme == "file" {
+		path = u.Path
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+	}
+	return os.Open(path)
+}
+
+func decodeBulk[T any](data []byte) (any, error) {
+	var resource T
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// bulkDecoders decode a line of a bulk export file by its resource type.
+var bulkDecoders = map[string]func([]byte) (any, error){
+	"Practitioner": decodeBulk[Practitioner],
+}
@@ -0,0 +1,306 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package fixtures
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TokenSource supplies the OAuth2 access token sent with each FHIR request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken string
+
+// Token returns t.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// SMARTConfiguration is the SMART on FHIR configuration a server publishes
+// at .well-known/smart-configuration.
+type SMARTConfiguration struct {
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	Capabilities          []string `json:"capabilities,omitempty"`
+}
+
+// SMARTApp is an app registered with an EHR for the SMART on FHIR
+// authorization code flow, as a public client using PKCE.
+type SMARTApp struct {
+	ClientID    string
+	RedirectURI string
+	// Scopes are requested scopes such as launch, openid, and
+	// patient/*.read.
+	Scopes []string
+}
+
+// NewCodeVerifier returns a random PKCE code verifier, to keep with the
+// state of an authorization until its code is exchanged.
+func NewCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthorizeURL returns the URL to send the user to for authorization. aud is
+// the FHIR base URL, and launch the launch parameter of an EHR launch, or
+// empty for a standalone launch.
+func (a SMARTApp) AuthorizeURL(config SMARTConfiguration, aud, launch, state, codeVerifier string) string {
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {a.ClientID},
+		"redirect_uri":          {a.RedirectURI},
+		"scope":                 {strings.Join(a.Scopes, " ")},
+		"state":                 {state},
+		"aud":                   {aud},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	if launch != "" {
+		params.Set("launch", launch)
+	}
+	return config.AuthorizationEndpoint + "?" + params.Encode()
+}
+
+// SMARTToken is the token response of a SMART on FHIR authorization.
+type SMARTToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Patient and Encounter are the launch context, if the app asked for it.
+	Patient   string `json:"patient,omitempty"`
+	Encounter string `json:"encounter,omitempty"`
+}
+
+// Token returns the access token, so that a SMARTToken is a TokenSource.
+// Refreshing expired tokens is left to the app.
+func (t *SMARTToken) Token(context.Context) (string, error) {
+	return t.AccessToken, nil
+}
+
+// Exchange exchanges the code an authorization redirected back with for a
+// token. Confidential clients, which authenticate to the token endpoint,
+// need their own exchange.
+func (a SMARTApp) Exchange(ctx context.Context, client *http.Client, config SMARTConfiguration, code, codeVerifier string) (*SMARTToken, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {a.RedirectURI},
+		"client_id":     {a.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("POST %s: %s", config.TokenEndpoint, resp.Status)
+	}
+	var token SMARTToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FHIRError is a request the server did not accept. Body is usually an
+// OperationOutcome.
+type FHIRError struct {
+	Method string
+	URL    string
+	Status string
+	Body   []byte
+}
+
+func (e *FHIRError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Method, e.URL, e.Status)
+}
+
+// FHIRClient calls the REST API of a FHIR server with the models of this
+// package.
+type FHIRClient struct {
+	// BaseURL is the server's FHIR base URL.
+	BaseURL string
+	// Tokens supplies the access token of each request; nil sends none.
+	Tokens TokenSource
+	// HTTP sends requests; nil uses http.DefaultClient.
+	HTTP *http.Client
+}
+
+// NewFHIRClient returns a client of the server at baseURL.
+func NewFHIRClient(baseURL string, tokens TokenSource) *FHIRClient {
+	return &FHIRClient{BaseURL: strings.TrimSuffix(baseURL, "/"), Tokens: tokens}
+}
+
+// Discover fetches the server's SMART configuration.
+func (c *FHIRClient) Discover(ctx context.Context) (*SMARTConfiguration, error) {
+	var config SMARTConfiguration
+	if err := c.do(ctx, http.MethodGet, ".well-known/smart-configuration", nil, nil, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// searchBundle is the searchset Bundle of a search for resources of type T.
+type searchBundle[T any] struct {
+	Entry []struct {
+		Resource T `json:"resource"`
+		Search   struct {
+			Mode string `json:"mode"`
+		} `json:"search"`
+	} `json:"entry"`
+}
+
+// matches returns the resources of b that matched the search, leaving out
+// included resources and outcomes.
+func (b searchBundle[T]) matches() []T {
+	resources := []T{}
+	for _, e := range b.Entry {
+		if e.Search.Mode == "" || e.Search.Mode == "match" {
+			resources = append(resources, e.Resource)
+		}
+	}
+	return resources
+}
+
+func (c *FHIRClient) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := c.BaseURL + "/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/fhir+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/fhir+json")
+		req.Header.Set("Prefer", "return=representation")
+	}
+	if c.Tokens != nil {
+		token, err := c.Tokens.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return &FHIRError{Method: method, URL: u, Status: resp.Status, Body: data}
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+// ReadSpecimen reads the Specimen with id.
+func (c *FHIRClient) ReadSpecimen(ctx context.Context, id string) (*Specimen, error) {
+	var resource Specimen
+	if err := c.do(ctx, http.MethodGet, "Specimen/"+url.PathEscape(id), nil, nil, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// SearchSpecimen searches Specimen resources with params and returns the
+// first page of matches.
+func (c *FHIRClient) SearchSpecimen(ctx context.Context, params url.Values) ([]Specimen, error) {
+	var bundle searchBundle[Specimen]
+	if err := c.do(ctx, http.MethodGet, "Specimen", params, nil, &bundle); err != nil {
+		return nil, err
+	}
+	return bundle.matches(), nil
+}
+
+// CreateSpecimen creates resource and returns the server's copy of it, with
+// its assigned id, or resource if the server returns none.
+func (c *FHIRClient) CreateSpecimen(ctx context.Context, resource *Specimen) (*Specimen, error) {
+	var created *Specimen
+	if err := c.do(ctx, http.MethodPost, "Specimen", nil, resource, &created); err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return resource, nil
+	}
+	return created, nil
+}
+
+// ReadLabResult reads the lab_result with id.
+func (c *FHIRClient) ReadLabResult(ctx context.Context, id string) (*LabResult, error) {
+	var resource LabResult
+	if err := c.do(ctx, http.MethodGet, "lab_result/"+url.PathEscape(id), nil, nil, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// SearchLabResult searches lab_result resources with params and returns the
+// first page of matches.
+func (c *FHIRClient) SearchLabResult(ctx context.Context, params url.Values) ([]LabResult, error) {
+	var bundle searchBundle[LabResult]
+	if err := c.do(ctx, http.MethodGet, "lab_result", params, nil, &bundle); err != nil {
+		return nil, err
+	}
+	return bundle.matches(), nil
+}
+
+// CreateLabResult creates resource and returns the server's copy of it, with
+// its assigned id, or resource if the server returns none.
+func (c *FHIRClient) CreateLabResult(ctx context.Context, resource *LabResult) (*LabResult, error) {
+	var created *LabResult
+	if err := c.do(ctx, http.MethodPost, "lab_result", nil, resource, &created); err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return resource, nil
+	}
+	return created, nil
+}
@@ -0,0 +1,36 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package fixtures
+
+// ConceptMapTarget is a code that a concept map translates a source code to,
+// and how equivalent the two are.
+type ConceptMapTarget struct {
+	System      string
+	Code        string
+	Display     string
+	Equivalence string
+}
+
+// labToLoinc is the concept map lab-to-loinc, keyed by source system and code.
+var labToLoinc = map[[2]string][]ConceptMapTarget{
+	{"http://hospital.example/lab-codes", "GLU"}: {
+		{System: "http://loinc.org", Code: "2345-7", Display: "Glucose [Mass/volume] in Serum or Plasma", Equivalence: "equivalent"},
+	},
+	{"http://hospital.example/lab-codes", "K"}: {
+		{System: "http://loinc.org", Code: "2823-3", Display: "Potassium [Moles/volume] in Serum or Plasma", Equivalence: "equivalent"},
+	},
+	{"http://hospital.example/lab-codes", "LYTES"}: {
+		{System: "http://loinc.org", Code: "2951-2", Display: "Sodium [Moles/volume] in Serum or Plasma", Equivalence: "wider"},
+		{System: "http://loinc.org", Code: "2075-0", Display: "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", Equivalence: "wider"},
+	},
+	{"http://legacy.example/lab", "0012"}: {
+		{System: "http://loinc.org", Code: "2345-7", Display: "Glucose [Mass/volume] in Serum or Plasma", Equivalence: "equivalent"},
+	},
+}
+
+// TranslateLabToLoinc returns the codes that code of system translates to
+// by the concept map lab-to-loinc, or nil when it translates to none.
+//
+// Local lab test codes to LOINC
+func TranslateLabToLoinc(system, code string) []ConceptMapTarget {
+	return labToLoinc[[2]string{system, code}]
+}
@@ -0,0 +1,20 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package imaging
+
+import (
+	"time"
+)
+
+
+// ImagingSeries - Series-level DICOM attributes
+type ImagingSeries struct {
+	SeriesInstanceUid	string	`json:"series_instance_uid"` // Series Instance UID
+	Modality	string	`json:"modality"` // Acquisition modality
+	ImageType	[]string	`json:"image_type,omitempty"` // Image type values
+	PerformingPhysician	string	`json:"performing_physician,omitempty"` // Performing physician's name
+	SeriesDate	*time.Time	`json:"series_date,omitempty"` // Date the series started
+	AcquiredAt	*time.Time	`json:"acquired_at,omitempty"` // Acquisition date and time
+	SeriesNumber	int	`json:"series_number,omitempty"` // Number of the series in the study
+	SliceThickness	float64	`json:"slice_thickness,omitempty"` // Slice thickness in millimeters
+}
+
@@ -0,0 +1,78 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package mpi
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PartialDate is a date known to year, month, or day precision, such as
+// 1970, 1970-03, or 1970-03-14. Month and Day are zero when unknown.
+type PartialDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// ParsePartialDate parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+func ParsePartialDate(s string) (PartialDate, error) {
+	for _, layout := range []string{"2006", "2006-01", "2006-01-02"} {
+		if len(s) != len(layout) {
+			continue
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return PartialDate{}, fmt.Errorf("invalid partial date %q: %w", s, err)
+		}
+		d := PartialDate{Year: t.Year()}
+		if len(layout) >= len("2006-01") {
+			d.Month = t.Month()
+		}
+		if len(layout) == len("2006-01-02") {
+			d.Day = t.Day()
+		}
+		return d, nil
+	}
+	return PartialDate{}, fmt.Errorf("invalid partial date %q", s)
+}
+
+// Time returns the date at midnight UTC, or false when it is less precise
+// than a day.
+func (d PartialDate) Time() (time.Time, bool) {
+	if d.Day == 0 {
+		return time.Time{}, false
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC), true
+}
+
+// String formats the date at its known precision.
+func (d PartialDate) String() string {
+	switch {
+	case d.Day != 0:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	case d.Month != 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d", d.Year)
+	}
+}
+
+// MarshalJSON encodes the date as a JSON string.
+func (d PartialDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a JSON string in any supported precision.
+func (d *PartialDate) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParsePartialDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
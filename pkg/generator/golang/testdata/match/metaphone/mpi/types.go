@@ -0,0 +1,15 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package mpi
+
+
+// Person - A person in the master patient index
+type Person struct {
+	Id	string	`json:"id"` // Logical id of this person
+	Mrn	string	`json:"mrn"` // Medical record number
+	Ssn	string	`json:"ssn,omitempty"` // Social security number
+	FamilyName	string	`json:"family_name,omitempty"`
+	GivenName	string	`json:"given_name,omitempty"`
+	BirthDate	*PartialDate	`json:"birth_date,omitempty"`
+	Sex	string	`json:"sex,omitempty"`
+}
+
@@ -0,0 +1,19 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package mpi
+
+import (
+	"time"
+)
+
+
+// Person - A person in the master patient index
+type Person struct {
+	Id	string	`json:"id"` // Logical id of this person
+	Mrn	string	`json:"mrn"` // Medical record number
+	Ssn	string	`json:"ssn,omitempty"` // Social security number
+	FamilyName	string	`json:"family_name,omitempty"`
+	GivenName	string	`json:"given_name,omitempty"`
+	BirthDate	*time.Time	`json:"birth_date,omitempty"`
+	Sex	string	`json:"sex,omitempty"`
+}
+
@@ -0,0 +1,228 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package datatypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestPeriodMsgpack(t *testing.T) {
+	var m Period
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Period
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestAddressMsgpack(t *testing.T) {
+	var m Address
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Address
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestCodingMsgpack(t *testing.T) {
+	var m Coding
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Coding
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestCodeableConceptMsgpack(t *testing.T) {
+	var m CodeableConcept
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got CodeableConcept
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestIdentifierMsgpack(t *testing.T) {
+	var m Identifier
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Identifier
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestReferenceMsgpack(t *testing.T) {
+	var m Reference
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Reference
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestExtensionMsgpack(t *testing.T) {
+	var m Extension
+	if err := json.Unmarshal([]byte(`{"url":"https://example.org"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Extension
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestHumanNameMsgpack(t *testing.T) {
+	var m HumanName
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got HumanName
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestMetaMsgpack(t *testing.T) {
+	var m Meta
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Meta
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestNarrativeMsgpack(t *testing.T) {
+	var m Narrative
+	if err := json.Unmarshal([]byte(`{"div":"example","status":"generated"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Narrative
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestQuantityMsgpack(t *testing.T) {
+	var m Quantity
+	if err := json.Unmarshal([]byte(`{}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Quantity
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
@@ -0,0 +1,68 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestVisitMsgpack(t *testing.T) {
+	var m Visit
+	if err := json.Unmarshal([]byte(`{"attender":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"class":{},"resourceType":"Visit","specimens":[{"id":"example-1","status":"available"}]}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Visit
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestSpecimenMsgpack(t *testing.T) {
+	var m Specimen
+	if err := json.Unmarshal([]byte(`{"id":"example-1","receivedTime":"<timestamp>","resourceType":"Specimen","status":"available","visit":{"attender":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"class":{}}}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Specimen
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
+
+func TestLabResultMsgpack(t *testing.T) {
+	var m LabResult
+	if err := json.Unmarshal([]byte(`{"mrn":"example","ordered_by":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"result_id":"LR-1001","value_numeric":5.4}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got LabResult
+	if err := got.UnmarshalMsgpack(data); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(m)
+	have, _ := json.Marshal(got)
+	if !bytes.Equal(have, want) {
+		t.Errorf("round trip = %s, want %s", have, want)
+	}
+}
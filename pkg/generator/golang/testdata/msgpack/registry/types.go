@@ -0,0 +1,21 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package registry
+
+import (
+	"models/datatypes"
+)
+
+
+// Practitioner - A person providing care
+type Practitioner struct {
+	Id	string	`json:"id"` // Logical id of this artifact
+	Meta	*datatypes.Meta	`json:"meta,omitempty"` // Metadata about the resource
+	ImplicitRules	string	`json:"implicitRules,omitempty"` // A set of rules under which this content was created
+	Language	string	`json:"language,omitempty"` // Language of the resource content
+	Text	*datatypes.Narrative	`json:"text,omitempty"` // Text summary of the resource, for human interpretation
+	Extension	[]*datatypes.Extension	`json:"extension,omitempty"` // Additional content defined by implementations
+	ModifierExtension	[]*datatypes.Extension	`json:"modifierExtension,omitempty"` // Extensions that cannot be ignored
+	Name	[]*datatypes.HumanName	`json:"name,omitempty"` // The name(s) associated with the practitioner
+	Supervisor	*Practitioner	`json:"supervisor,omitempty"` // Practitioner responsible for this one
+}
+
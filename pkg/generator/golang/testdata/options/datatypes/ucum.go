@@ -0,0 +1,118 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package datatypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// UCUMSystem is the FHIR system URI for UCUM unit codes.
+const UCUMSystem = "http://unitsofmeasure.org"
+
+// ucumUnit is a unit whose value v is v*factor + offset in its dimension's base unit.
+type ucumUnit struct {
+	code      string
+	dimension string
+	factor    float64
+	offset    float64
+}
+
+var ucumUnits = []ucumUnit{
+	{"g", "mass", 1.0, 0.0},
+	{"kg", "mass", 1000.0, 0.0},
+	{"mg", "mass", 0.001, 0.0},
+	{"ug", "mass", 1e-06, 0.0},
+	{"ng", "mass", 1e-09, 0.0},
+	{"[lb_av]", "mass", 453.59237, 0.0},
+	{"[oz_av]", "mass", 28.349523125, 0.0},
+	{"m", "length", 1.0, 0.0},
+	{"km", "length", 1000.0, 0.0},
+	{"cm", "length", 0.01, 0.0},
+	{"mm", "length", 0.001, 0.0},
+	{"[in_i]", "length", 0.0254, 0.0},
+	{"[ft_i]", "length", 0.3048, 0.0},
+	{"L", "volume", 1.0, 0.0},
+	{"dL", "volume", 0.1, 0.0},
+	{"mL", "volume", 0.001, 0.0},
+	{"uL", "volume", 1e-06, 0.0},
+	{"s", "time", 1.0, 0.0},
+	{"min", "time", 60.0, 0.0},
+	{"h", "time", 3600.0, 0.0},
+	{"d", "time", 86400.0, 0.0},
+	{"wk", "time", 604800.0, 0.0},
+	{"mo", "time", 2.6298e+06, 0.0},
+	{"a", "time", 3.15576e+07, 0.0},
+	{"K", "temperature", 1.0, 0.0},
+	{"Cel", "temperature", 1.0, 273.15},
+	{"[degF]", "temperature", 0.5555555555555556, 255.37222222222223},
+	{"g/L", "mass_concentration", 1.0, 0.0},
+	{"g/dL", "mass_concentration", 10.0, 0.0},
+	{"mg/dL", "mass_concentration", 0.01, 0.0},
+	{"mg/L", "mass_concentration", 0.001, 0.0},
+	{"ug/mL", "mass_concentration", 0.001, 0.0},
+	{"ng/mL", "mass_concentration", 1e-06, 0.0},
+	{"mol/L", "substance_concentration", 1.0, 0.0},
+	{"mmol/L", "substance_concentration", 0.001, 0.0},
+	{"umol/L", "substance_concentration", 1e-06, 0.0},
+	{"nmol/L", "substance_concentration", 1e-09, 0.0},
+	{"Pa", "pressure", 1.0, 0.0},
+	{"kPa", "pressure", 1000.0, 0.0},
+	{"mm[Hg]", "pressure", 133.322, 0.0},
+	{"Hz", "frequency", 1.0, 0.0},
+	{"/min", "frequency", 0.016666666666666666, 0.0},
+	{"/h", "frequency", 0.0002777777777777778, 0.0},
+	{"1", "ratio", 1.0, 0.0},
+	{"%", "ratio", 0.01, 0.0},
+}
+
+func lookupUnit(code string) (ucumUnit, error) {
+	for _, u := range ucumUnits {
+		if u.code == code {
+			return u, nil
+		}
+	}
+	return ucumUnit{}, fmt.Errorf("unknown UCUM unit %q", code)
+}
+
+// IsKnownUnit reports whether code is a UCUM unit these helpers can convert.
+func IsKnownUnit(code string) bool {
+	_, err := lookupUnit(code)
+	return err == nil
+}
+
+// ConvertUnit converts value between two UCUM units of the same dimension.
+func ConvertUnit(value float64, from, to string) (float64, error) {
+	source, err := lookupUnit(from)
+	if err != nil {
+		return 0, err
+	}
+	target, err := lookupUnit(to)
+	if err != nil {
+		return 0, err
+	}
+	if source.dimension != target.dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, source.dimension, to, target.dimension)
+	}
+	return (value*source.factor + source.offset - target.offset) / target.factor, nil
+}
+
+// Convert returns q in to units. Its code, or else its unit, must be a known
+// UCUM unit.
+func (q Quantity) Convert(to string) (Quantity, error) {
+	from := q.Code
+	if from == "" {
+		from = q.Unit
+	}
+	v, err := q.Value.Float64()
+	if err != nil {
+		return Quantity{}, fmt.Errorf("invalid quantity value: %w", err)
+	}
+	value, err := ConvertUnit(v, from, to)
+	if err != nil {
+		return Quantity{}, err
+	}
+	q.Value = json.Number(strconv.FormatFloat(value, 'g', -1, 64))
+	q.Unit, q.System, q.Code = to, UCUMSystem, to
+	return q, nil
+}
@@ -0,0 +1,7 @@
+// Generated by ehrglot v0.1.0.
+
+module github.com/acme/models
+
+go 1.21
+
+require github.com/shopspring/decimal v1.4.0
@@ -0,0 +1,21 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package forms
+
+import (
+	"time"
+)
+
+
+// PatientIntakeResponse - Responses to Patient intake
+type PatientIntakeResponse struct {
+	ChiefComplaint	string	`json:"chief_complaint"` // What brings you in today?
+	SymptomOnset	*time.Time	`json:"symptom_onset,omitempty"` // When did it start?
+	PainScore	int	`json:"pain_score,omitempty"` // Pain, from 0 to 10
+	Smoker	bool	`json:"smoker,omitempty"` // Do you smoke?
+	PacksPerDay	float64	`json:"packs_per_day,omitempty"` // Packs a day
+	Allergies	[]string	`json:"allergies,omitempty"` // Allergies
+	Pregnant	string	`json:"pregnant,omitempty"` // Are you pregnant?
+	DueDate	*time.Time	`json:"due_date,omitempty"` // Expected due date
+	SignedAt	*time.Time	`json:"signed_at,omitempty"` // Signed at
+}
+
@@ -0,0 +1,14 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package fixtures
+
+// ContainerCountOutOfRange reports whether the containerCount of m is set and outside its
+// reference range, at most 4.
+func (m Specimen) ContainerCountOutOfRange() bool {
+	return m.ContainerCount != 0 && m.ContainerCount > 4
+}
+
+// ValueNumericOutOfRange reports whether the value_numeric of m is set and outside its
+// reference range, 3.5 to 11 umol/L.
+func (m LabResult) ValueNumericOutOfRange() bool {
+	return m.ValueNumeric != 0 && (m.ValueNumeric < 3.5 || m.ValueNumeric > 11)
+}
@@ -0,0 +1,133 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package datatypes
+
+import (
+	"time"
+)
+
+
+// Period - Time range defined by start and end date/time
+type Period struct {
+	Start	*time.Time	`json:"start,omitempty"` // Starting time with inclusive boundary
+	End	*time.Time	`json:"end,omitempty"` // End time with inclusive boundary, if not ongoing
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Address - An address expressed using postal conventions
+type Address struct {
+	Use	string	`json:"use,omitempty"` // home | work | temp | old | billing - purpose of this address
+	Type	string	`json:"type,omitempty"` // postal | physical | both
+	Text	string	`json:"text,omitempty"` // Text representation of the address
+	Line	[]string	`json:"line,omitempty"` // Street name, number, direction & P.O. Box etc.
+	City	string	`json:"city,omitempty"` // Name of city, town etc.
+	District	string	`json:"district,omitempty"` // District name (aka county)
+	State	string	`json:"state,omitempty"` // Sub-unit of country (abbreviations ok)
+	PostalCode	string	`json:"postalCode,omitempty"` // Postal code for area
+	Country	string	`json:"country,omitempty"` // Country (e.g. can be ISO 3166 2 or 3 letter code)
+	Period	*Period	`json:"period,omitempty"` // Time period when address was/is in use
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Coding - A reference to a code defined by a terminology system
+type Coding struct {
+	System	string	`json:"system,omitempty"` // Identity of the terminology system
+	Version	string	`json:"version,omitempty"` // Version of the system - if relevant
+	Code	string	`json:"code,omitempty"` // Symbol in syntax defined by the system
+	Display	string	`json:"display,omitempty"` // Representation defined by the system
+	UserSelected	bool	`json:"userSelected,omitempty"` // If this coding was chosen directly by the user
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// CodeableConcept - Concept - reference to a terminology or just text
+type CodeableConcept struct {
+	Coding	[]*Coding	`json:"coding,omitempty"` // Code defined by a terminology system
+	Text	string	`json:"text,omitempty"` // Plain text representation of the concept
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Identifier - An identifier intended for computation
+type Identifier struct {
+	Use	string	`json:"use,omitempty"` // usual | official | temp | secondary | old (If known)
+	Type	*CodeableConcept	`json:"type,omitempty"` // Description of identifier
+	System	string	`json:"system,omitempty"` // The namespace for the identifier value
+	Value	string	`json:"value,omitempty"` // The value that is unique
+	Period	*Period	`json:"period,omitempty"` // Time period when id is/was valid for use
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Reference - A reference from one resource to another
+type Reference struct {
+	Reference	string	`json:"reference,omitempty"` // Literal reference, Relative, internal or absolute URL
+	Type	string	`json:"type,omitempty"` // Type the reference refers to (e.g. "Patient")
+	Identifier	*Identifier	`json:"identifier,omitempty"` // Logical reference, when literal reference is not known
+	Display	string	`json:"display,omitempty"` // Text alternative for the resource
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Extension - Additional content defined by implementations
+type Extension struct {
+	Url	string	`json:"url"` // Identifies the meaning of the extension
+	ValueString	string	`json:"valueString,omitempty"` // Value of extension
+	ValueCode	string	`json:"valueCode,omitempty"` // Value of extension
+	ValueBoolean	bool	`json:"valueBoolean,omitempty"` // Value of extension
+	ValueInteger	int	`json:"valueInteger,omitempty"` // Value of extension
+	ValueDecimal	float64	`json:"valueDecimal,omitempty"` // Value of extension
+	ValueDateTime	*time.Time	`json:"valueDateTime,omitempty"` // Value of extension
+	ValueCoding	*Coding	`json:"valueCoding,omitempty"` // Value of extension
+	ValueCodeableConcept	*CodeableConcept	`json:"valueCodeableConcept,omitempty"` // Value of extension
+	ValueReference	*Reference	`json:"valueReference,omitempty"` // Value of extension
+	ValueAddress	*Address	`json:"valueAddress,omitempty"` // Value of extension
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// HumanName - Name of a human - parts and usage
+type HumanName struct {
+	Use	string	`json:"use,omitempty"` // usual | official | temp | nickname | anonymous | old | maiden
+	Text	string	`json:"text,omitempty"` // Text representation of the full name
+	Family	string	`json:"family,omitempty"` // Family name (often called 'Surname')
+	Given	[]string	`json:"given,omitempty"` // Given names (not always 'first'). Includes middle names
+	Prefix	[]string	`json:"prefix,omitempty"` // Parts that come before the name
+	Suffix	[]string	`json:"suffix,omitempty"` // Parts that come after the name
+	Period	*Period	`json:"period,omitempty"` // Time period when name was/is in use
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Meta - Metadata about a resource
+type Meta struct {
+	VersionId	string	`json:"versionId,omitempty"` // Version specific identifier
+	LastUpdated	*time.Time	`json:"lastUpdated,omitempty"` // When the resource version last changed
+	Source	string	`json:"source,omitempty"` // Identifies where the resource comes from
+	Profile	[]string	`json:"profile,omitempty"` // Profiles this resource claims to conform to
+	Security	[]*Coding	`json:"security,omitempty"` // Security Labels applied to this resource
+	Tag	[]*Coding	`json:"tag,omitempty"` // Tags applied to this resource
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Narrative - Human-readable summary of the resource
+type Narrative struct {
+	Status	string	`json:"status"` // generated | extensions | additional | empty
+	Div	string	`json:"div"` // Limited xhtml content
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// Quantity - A measured amount (or an amount that can potentially be measured)
+type Quantity struct {
+	Value	float64	`json:"value,omitempty"` // Numerical value (with implicit precision)
+	Comparator	string	`json:"comparator,omitempty"` // < | <= | >= | > - how to understand the value
+	Unit	string	`json:"unit,omitempty"` // Unit representation
+	System	string	`json:"system,omitempty"` // System that defines coded unit form
+	Code	string	`json:"code,omitempty"` // Coded form of the unit
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
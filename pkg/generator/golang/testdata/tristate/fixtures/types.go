@@ -0,0 +1,95 @@
+// Code generated by ehrglot. DO NOT EDIT.
+package fixtures
+
+import (
+	"models/datatypes"
+	"models/registry"
+	"time"
+)
+
+
+// Visit - An encounter whose field names are reserved words in target languages
+type Visit struct {
+	Class	*datatypes.Coding	`json:"class"` // Classification of the visit
+	Type	[]*datatypes.CodeableConcept	`json:"type,omitempty"` // Specific type of visit
+	For	*datatypes.Reference	`json:"for,omitempty"` // Who the visit is for
+	Match	string	`json:"match,omitempty"` // Record linkage match grade
+	Order	int	`json:"order,omitempty"` // Sequence within the episode
+	Self	string	`json:"self,omitempty"` // Canonical URL of the visit
+	Where	*datatypes.Address	`json:"where,omitempty"` // Where the visit took place
+	X_2ndOpinion	bool	`json:"2ndOpinion,omitempty"` // Whether a second opinion was requested
+	PriorityCode	string	`json:"priorityCode,omitempty"` // Priority as a code
+	PriorityCode_2	string	`json:"priority_code,omitempty"` // Priority as legacy text
+	Visit	string	`json:"visit,omitempty"` // Visit number
+	Length	*datatypes.Quantity	`json:"length,omitempty"` // Time the visit lasted
+	Attender	*registry.Practitioner	`json:"attender,omitempty"` // Practitioner who saw the patient
+	Specimens	[]*Specimen	`json:"specimens,omitempty"` // Specimens collected during the visit
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// NewVisit returns a Visit whose fields hold their defaults.
+func NewVisit() Visit {
+	return Visit{
+		Match: "unlinked",
+	}
+}
+
+// Specimen - A sample collected for analysis
+type Specimen struct {
+	Id	string	`json:"id"` // Logical id of this artifact
+	Status	string	`json:"status"` // available | unavailable | entered-in-error
+	AccessionIdentifier	*datatypes.Identifier	`json:"accessionIdentifier,omitempty"` // Identifier assigned by the lab
+	ReceivedTime	*time.Time	`json:"receivedTime,omitempty"` // When the specimen was received
+	CollectedDate	*time.Time	`json:"collectedDate,omitempty"` // Collection date
+	Quantity	float64	`json:"quantity,omitempty"` // Amount collected
+	ContainerCount	int	`json:"containerCount,omitempty"` // Number of containers
+	Fasting	bool	`json:"fasting,omitempty"` // Whether the patient was fasting
+	Note	[]string	`json:"note,omitempty"` // Comments
+	Subject	*datatypes.Reference	`json:"subject,omitempty"` // Where the specimen came from
+	Request	[]*datatypes.Reference	`json:"request,omitempty"` // Why the specimen was collected
+	Visit	*Visit	`json:"visit,omitempty"` // Visit during which the specimen was collected
+	Collection	interface{}	`json:"collection,omitempty"` // Collection details
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// NewSpecimen returns a Specimen whose fields hold their defaults.
+func NewSpecimen() Specimen {
+	return Specimen{
+		Status: "available",
+		ContainerCount: 1,
+		Fasting: false,
+	}
+}
+
+// LabResult - Résultat de laboratoire — température °C, 检验结果
+//
+// Owner: lab-data
+// Steward: ana.ruiz@example.org
+// Tags: clinical, phi
+// Unique: result_id
+// Indexed: mrn, resulted_at
+type LabResult struct {
+	ResultId	string	`json:"result_id"` // Identifiant du résultat. Example: LR-1001
+	Mrn	string	`json:"mrn"` // Medical record number
+	ValueNumeric	float64	`json:"value_numeric,omitempty"` // Valeur mesurée (µmol/L). Examples: 5.4, 12.0
+	ChargeAmount	float64	`json:"charge_amount,omitempty"` // Billed amount
+	ValueQuantity	*datatypes.Quantity	`json:"value_quantity,omitempty"` // Measured value with its unit
+	ResultedAt	*time.Time	`json:"resulted_at,omitempty"` // When the result was released
+	PerformerName	*datatypes.HumanName	`json:"performer_name,omitempty"` // Who performed the test
+	OrderedBy	*registry.Practitioner	`json:"ordered_by,omitempty"` // Who ordered the test
+	RawPayload	[]byte	`json:"rawPayload,omitempty"` // Original HL7 message
+	ICD10Code	string	`json:"ICD10Code,omitempty"` // Diagnosis the test was ordered for
+	ReviewerIds	[]string	`json:"reviewer_ids,omitempty"` // Reviewing clinicians
+
+	present, null	[1]uint64	// bitsets of the fields present, and null, in JSON
+}
+
+// NewLabResult returns a LabResult whose fields hold their defaults.
+func NewLabResult() LabResult {
+	return LabResult{
+		ChargeAmount: 0.0,
+	}
+}
+
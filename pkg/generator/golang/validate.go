@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// validateModel is a schema with fields that a condition on another field
+// makes required, or without which they are left out.
+type validateModel struct {
+	Schema schema.Schema
+	Type   string
+	// Zero is whether a check compares a field with its zero value.
+	Zero   bool
+	Checks []validateCheck
+}
+
+// validateCheck is a Go expression that is true when a conditionally
+// required field is missing, or a conditionally enabled one set although
+// not enabled, and the message reporting it.
+type validateCheck struct {
+	Invalid string
+	Message string
+}
+
+// validateModels returns the schemas of a namespace with required_if or
+// enabled_if fields.
+func (g *Generator) validateModels(scope resolve.Scope, schemas []schema.Schema) ([]validateModel, error) {
+	var models []validateModel
+	for _, s := range schemas {
+		conditional, err := s.ConditionalFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		enabled, err := s.EnabledFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(conditional) == 0 && len(enabled) == 0 {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		m := validateModel{Schema: s, Type: g.typeName(s)}
+		for _, c := range conditional {
+			field, on := idents[c.Field.Name], idents[c.Condition.Field]
+			check := g.condition(scope, on, c.Condition) + " && " + g.isMissing(scope, field)
+			m.Zero = m.Zero || strings.Contains(check, "zero.")
+			m.Checks = append(m.Checks, validateCheck{
+				Invalid: check,
+				Message: strconv.Quote(c.Field.Name + " is required when " + c.Condition.String()),
+			})
+		}
+		for _, c := range enabled {
+			field, on := idents[c.Field.Name], idents[c.Condition.Field]
+			check := "!(" + g.condition(scope, on, c.Condition) + ") && " + g.isSet(scope, field)
+			m.Zero = m.Zero || strings.Contains(check, "zero.")
+			m.Checks = append(m.Checks, validateCheck{
+				Invalid: check,
+				Message: strconv.Quote(c.Field.Name + " must be left out unless " + c.Condition.String()),
+			})
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// isSet returns a Go expression that is true when field f of m is set:
+// slices are set when not empty, other fields when not their zero value.
+func (g *Generator) isSet(scope resolve.Scope, f naming.Field) string {
+	if strings.HasPrefix(g.toGoType(scope, f.Type), "[]") {
+		return "len(m." + f.Ident + ") > 0"
+	}
+	return "m." + f.Ident + " != zero." + f.Ident
+}
+
+// isMissing returns a Go expression that is true when field f of m is not
+// set.
+func (g *Generator) isMissing(scope resolve.Scope, f naming.Field) string {
+	if strings.HasPrefix(g.toGoType(scope, f.Type), "[]") {
+		return "len(m." + f.Ident + ") == 0"
+	}
+	return "m." + f.Ident + " == zero." + f.Ident
+}
+
+// condition returns a Go expression that is true when c holds for m, whose
+// field on it tests.
+func (g *Generator) condition(scope resolve.Scope, on naming.Field, c schema.Condition) string {
+	if c.Op == "" {
+		return g.isSet(scope, on)
+	}
+	value := strconv.Quote(c.Value)
+	switch on.DefaultKind() {
+	case "integer":
+		value = c.Value
+	case "boolean":
+		if (c.Value == "true") == (c.Op == "==") {
+			return "m." + on.Ident
+		}
+		return "!m." + on.Ident
+	}
+	return "m." + on.Ident + " " + c.Op + " " + value
+}
+
+// generateValidate writes Validate methods that report the required_if
+// fields of models that are missing although their condition holds, and the
+// enabled_if fields that are set although theirs does not.
+func (g *Generator) generateValidate(namespace string, models []validateModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}package {{.Package}}
+
+import "errors"
+{{range .Models}}
+// Validate reports the fields of m that are missing although a condition on
+// another field requires them, or set although one leaves them out.
+func (m {{.Type}}) Validate() error {
+{{- if .Zero}}
+	var zero {{.Type}}
+{{- end}}
+	var errs []error
+{{- range .Checks}}
+	if {{.Invalid}} {
+		errs = append(errs, errors.New({{.Message}}))
+	}
+{{- end}}
+	return errors.Join(errs...)
+}
+{{end}}`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Models  []validateModel
+	}{
+		Package: g.packageName(namespace),
+		Models:  models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
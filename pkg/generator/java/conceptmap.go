@@ -0,0 +1,164 @@
+package java
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// conceptMapTable is the lookup table of a concept map and the method
+// translating codes by it.
+type conceptMapTable struct {
+	Map     conceptmap.ConceptMap
+	Const   string
+	Method  string
+	Sources []conceptmap.Source
+}
+
+// generateConceptMaps writes the methods that translate codes by the concept
+// maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, namespace string, path string) error {
+	var tables []conceptMapTable
+	for _, m := range maps {
+		tables = append(tables, conceptMapTable{
+			Map:     m,
+			Const:   strings.ToUpper(naming.Snake.Apply(m.Name)),
+			Method:  "translate" + naming.Pascal.Apply(m.Name),
+			Sources: m.Sources(),
+		})
+	}
+
+	tmpl := `/**
+ * Translations of codes by concept maps.
+ *
+{{header}} */
+package {{.Package}};
+
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+
+public final class ConceptMaps {
+    /**
+     * A code that a concept map translates a source code to, and how
+     * equivalent the two are.
+     */
+    public static final class Target {
+        private final String system;
+        private final String code;
+        private final String display;
+        private final String equivalence;
+
+        public Target(String system, String code, String display, String equivalence) {
+            this.system = system;
+            this.code = code;
+            this.display = display;
+            this.equivalence = equivalence;
+        }
+
+        public String getSystem() {
+            return system;
+        }
+
+        public String getCode() {
+            return code;
+        }
+
+        public String getDisplay() {
+            return display;
+        }
+
+        public String getEquivalence() {
+            return equivalence;
+        }
+
+        @Override
+        public boolean equals(Object o) {
+            if (this == o) {
+                return true;
+            }
+            if (!(o instanceof Target)) {
+                return false;
+            }
+            Target other = (Target) o;
+            return Objects.equals(system, other.system) && Objects.equals(code, other.code)
+                && Objects.equals(display, other.display) && Objects.equals(equivalence, other.equivalence);
+        }
+
+        @Override
+        public int hashCode() {
+            return Objects.hash(system, code, display, equivalence);
+        }
+
+        @Override
+        public String toString() {
+            return "Target{system=" + system + ", code=" + code + ", display=" + display + ", equivalence=" + equivalence + "}";
+        }
+    }
+{{range .Tables}}
+    // The concept map {{.Map.Name}}, keyed by source system and code.
+    private static final Map<List<String>, List<Target>> {{.Const}} = new HashMap<>();
+{{- end}}
+
+    static {
+{{- range .Tables}}
+{{- $const := .Const}}
+{{- range .Sources}}
+        {{$const}}.put(Arrays.asList({{quote .System}}, {{quote .Code}}), List.of(
+{{- range $i, $t := .Translations}}{{if $i}},{{end}}
+            new Target({{quote $t.System}}, {{quote $t.Code}}, {{quote $t.Display}}, {{quote $t.Equivalence}})
+{{- end}}));
+{{- end}}
+{{- end}}
+    }
+
+    private ConceptMaps() {}
+{{range .Tables}}
+    /**
+     * Returns the codes that code of system translates to by the concept map
+     * {{.Map.Name}}, if any.
+{{- with .Map.Description}}
+     *
+     * <p>{{line .}}
+{{- end}}
+     */
+    public static List<Target> {{.Method}}(String system, String code) {
+        return {{.Const}}.getOrDefault(Arrays.asList(system, code), Collections.emptyList());
+    }
+{{- end}}
+}
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"line":   func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"header": g.header.Func(" * "),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Tables  []conceptMapTable
+	}{
+		Package: g.packageName(namespace),
+		Tables:  tables,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,108 @@
+package java
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/publish"
+)
+
+// writeGradle writes the Gradle Kotlin DSL build that Package writes in
+// place of a pom.xml with the gradle build: a java-library with the same
+// coordinates and dependencies, published with maven-publish.
+func (g *Generator) writeGradle(outputDir string) error {
+	for name, tmpl := range map[string]string{"build.gradle.kts": gradleBuild, "settings.gradle.kts": gradleSettings} {
+		tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"quote": kotlinString})
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(filepath.Join(outputDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		err = tmpl_parsed.Execute(f, g.project())
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kotlinString returns s as a Kotlin string literal, in which $ would
+// otherwise start a template.
+func kotlinString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`).Replace(s) + `"`
+}
+
+const gradleBuild = `// Generated by ehrglot v` + Version + `.
+
+plugins {
+    ` + "`java-library`" + `
+    ` + "`maven-publish`" + `
+}
+
+group = {{quote .Group}}
+version = {{quote .Version}}
+{{- with .Description}}
+description = {{quote .}}
+{{- end}}
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    api("com.fasterxml.jackson.core:jackson-databind:` + jacksonVersion + `")
+    api("com.fasterxml.jackson.datatype:jackson-datatype-jsr310:` + jacksonVersion + `")
+}
+
+sourceSets {
+    main {
+        java {
+            setSrcDirs(listOf("."))
+            exclude("build/**")
+        }
+    }
+}
+
+tasks.withType<JavaCompile> {
+    options.release.set(11)
+    options.encoding = "UTF-8"
+}
+
+publishing {
+    publications {
+        create<MavenPublication>("maven") {
+            artifactId = {{quote .Name}}
+            from(components["java"])
+        }
+    }
+    repositories {
+        ` + publishRepository + `
+    }
+}
+`
+
+const gradleSettings = `// Generated by ehrglot v` + Version + `.
+
+rootProject.name = {{quote .Name}}
+`
+
+// publishRepository declares the repository gradle publish uploads to: the
+// ehrglotRepository property that ehrglot publish sets, or else the
+// configured one. Credentials come from the environment, as for Maven.
+const publishRepository = `{{if .Repository}}(findProperty("ehrglotRepository") ?: {{quote .Repository}}).let{{else}}findProperty("ehrglotRepository")?.let{{end}} { repository ->
+            maven {
+                name = "ehrglot"
+                url = uri(repository)
+                credentials {
+                    username = System.getenv("` + publish.UsernameEnv + `")
+                    password = System.getenv("` + publish.PasswordEnv + `")
+                }
+            }
+        }`
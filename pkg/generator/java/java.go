@@ -2,47 +2,119 @@
 package java
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
+// jacksonVersion is the Jackson that packages depend on to read and write
+// the generated classes.
+const jacksonVersion = "2.17.2"
+
 // Generator generates Java code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	decimalType string
+	instantType string
+	dateType    string
+	ucum        bool
+	conceptMaps []string
+	redact      string
+	audit       bool
+	pkg         config.Package
+	build       string
+	repository  string
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Java code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("java").Naming, decimalType: "Double", instantType: "Instant", dateType: "LocalDate", ucum: cfg.UCUM, conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), audit: cfg.Audit, pkg: cfg.PackageInfo("java"), build: cfg.BuildTool("java"), repository: cfg.Language("java").Publish.URL, header: header.New(cfg.Header, Version)}
+	if cfg.DecimalMode("java") == config.DecimalExact {
+		g.decimalType = "BigDecimal"
+	}
+	temporal := cfg.TemporalPolicy("java")
+	if temporal.Instants == config.InstantsOffset {
+		g.instantType = "OffsetDateTime"
+	}
+	if temporal.Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
-// Generate generates Java classes from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+// Generate generates Java classes from schemas. Schemas that inherit a FHIR
+// base resource extend an abstract class generated for it.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(datatypes.WithBases(schemas))
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		// Convert namespace to package path (e.g., fhir_r4 -> fhir/r4)
-		packagePath := strings.ReplaceAll(namespace, "_", "/")
-		nsDir := filepath.Join(outputDir, packagePath)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
+		if g.dateType == "PartialDate" {
+			if err := g.generatePartialDate(namespace, filepath.Join(nsDir, "PartialDate.java")); err != nil {
+				return err
+			}
+		}
+
 		// Generate each schema file
 		for _, s := range nsSchemas {
-			filename := s.GetName() + ".java"
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// Public classes must live in a file named after the class
+			filename := g.className(s) + ".java"
 			path := filepath.Join(nsDir, filename)
-			if err := g.generateClass(s, namespace, path); err != nil {
+			if err := g.generateClass(refs.Scope(namespace), s, path); err != nil {
+				return err
+			}
+
+			if g.ucum && datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+				if err := g.generateUCUM(s, namespace, filepath.Join(nsDir, "Ucum.java")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, namespace, filepath.Join(nsDir, "ConceptMaps.java")); err != nil {
 				return err
 			}
 		}
@@ -51,46 +123,170 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 	return nil
 }
 
-func (g *Generator) generateClass(s schema.Schema, namespace string, path string) error {
+// Package writes a pom.xml, or with the gradle build a Gradle build, that
+// builds the generated sources into a jar with Jackson to read and write
+// them, and deploys it to the configured publish repository. The groupId
+// defaults to the package name.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if err := g.pkg.Validate(); err != nil {
+		return err
+	}
+	if g.build == config.BuildGradle {
+		return g.writeGradle(outputDir)
+	}
+
+	tmpl := `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Generated by ehrglot v` + Version + `. -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>{{html .Group}}</groupId>
+    <artifactId>{{html .Name}}</artifactId>
+    <version>{{html .Version}}</version>
+    <packaging>jar</packaging>
+{{- with .Description}}
+    <description>{{html .}}</description>
+{{- end}}
+
+    <properties>
+        <maven.compiler.release>11</maven.compiler.release>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+    </properties>
+
+    <dependencies>
+        <dependency>
+            <groupId>com.fasterxml.jackson.core</groupId>
+            <artifactId>jackson-databind</artifactId>
+            <version>` + jacksonVersion + `</version>
+        </dependency>
+        <dependency>
+            <groupId>com.fasterxml.jackson.datatype</groupId>
+            <artifactId>jackson-datatype-jsr310</artifactId>
+            <version>` + jacksonVersion + `</version>
+        </dependency>
+    </dependencies>
+
+    <build>
+        <sourceDirectory>${project.basedir}</sourceDirectory>
+    </build>
+{{- with .Repository}}
+
+    <distributionManagement>
+        <repository>
+            <id>ehrglot</id>
+            <url>{{html .}}</url>
+        </repository>
+    </distributionManagement>
+{{- end}}
+</project>
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, nil)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, "pom.xml"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, g.project())
+}
+
+// project is the Maven coordinates of the package, and the repository it is
+// deployed to, if any.
+type project struct {
+	config.Package
+	Repository string
+}
+
+// project returns the coordinates of the package, with the groupId
+// defaulting to its name.
+func (g *Generator) project() project {
+	p := project{Package: g.pkg, Repository: g.repository}
+	if p.Group == "" {
+		p.Group = p.Name
+	}
+	return p
+}
+
+func (g *Generator) generateClass(scope resolve.Scope, s schema.Schema, path string) error {
+	if err := s.CheckDefaults(); err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
 	tmpl := `/**
  * {{.Schema.Description}}
  *
- * Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
- * DO NOT EDIT.
- */
+{{- with .Schema.Docs}}
+{{- range .}}
+ * {{.}}
+{{- end}}
+ *
+{{- end}}
+{{header}} */
 package {{.Package}};
-
+{{if .Exact}}
+import java.math.BigDecimal;
+{{- end}}
 import java.time.LocalDate;
-import java.time.Instant;
+import java.time.{{.Instant}};
 import java.util.List;
-
-public class {{.Schema | schemaName}} {
-{{range .Schema.Fields}}
-    private {{.Type | javaType}} {{.Name | camel}};
+{{if .Imports}}
+{{range .Imports}}import {{.}};
+{{end}}{{end}}
+public {{if .Abstract}}abstract {{end}}class {{.Schema | schemaName}}{{with .Base}} extends {{.}}{{end}} {
+{{range .Fields}}
+    private {{.Type | javaType}} {{.Ident}}{{with default .Field}} = {{.}}{{end}};
 {{end}}
 
     public {{.Schema | schemaName}}() {}
-{{range .Schema.Fields}}
-    public {{.Type | javaType}} get{{.Name | pascal}}() {
-        return this.{{.Name | camel}};
+{{range .Fields}}
+{{- with .Doc}}
+    /** {{.}} */
+{{- end}}
+    public {{.Type | javaType}} get{{.Ident | accessor}}() {
+        return this.{{.Ident}};
     }
 
-    public void set{{.Name | pascal}}({{.Type | javaType}} {{.Name | camel}}) {
-        this.{{.Name | camel}} = {{.Name | camel}};
+    public void set{{.Ident | accessor}}({{.Type | javaType}} {{.Ident}}) {
+        this.{{.Ident}} = {{.Ident}};
+    }
+{{end}}{{if .Schema.HasSensitive .Level}}
+    /**
+     * Returns the properties of this record, with those at pii_level {{.Level}}
+     * or above masked, for logging.
+     */
+    public java.util.Map<String, Object> redactForLogging() {
+        java.util.Map<String, Object> fields = new java.util.LinkedHashMap<>();
+{{- range .Fields}}
+        fields.put("{{.Ident}}", {{if .Sensitive $.Level}}"` + schema.Redacted + `"{{else}}this.{{.Ident}}{{end}});
+{{- end}}
+        return fields;
+    }
+
+    @Override
+    public String toString() {
+        return "{{.Schema | schemaName}}" + redactForLogging();
     }
 {{end}}
 }
 `
 	funcMap := template.FuncMap{
-		"camel":      toCamelCase,
-		"pascal":     toPascalCase,
-		"javaType":   toJavaType,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"accessor": toAccessorName,
+		"javaType": func(yamlType string) string {
+			return g.toJavaType(scope, yamlType)
+		},
+		"schemaName": g.className,
+		"default":    g.defaultLiteral,
+		"header":     g.header.Func(" * ", s),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -99,68 +295,380 @@ public class {{.Schema | schemaName}} {
 	}
 	defer f.Close()
 
-	// Convert namespace to Java package name
-	packageName := strings.ReplaceAll(namespace, "_", ".")
+	data := struct {
+		Schema   schema.Schema
+		Fields   []naming.Field
+		Base     string
+		Abstract bool
+		Package  string
+		Exact    bool
+		Instant  string
+		Imports  []string
+		Level    string
+	}{
+		Schema:   s,
+		Abstract: datatypes.IsBase(s),
+		Exact:    g.decimalType == "BigDecimal",
+		Instant:  g.instantType,
+		Fields:   g.fields(s),
+		Package:  g.packageName(scope.Namespace),
+		Imports:  g.imports(scope, s),
+		Level:    g.redact,
+	}
+	if base, ok := scope.Lookup(s.Inherits); s.Inherits != "" && ok {
+		data.Base = g.className(base)
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generatePartialDate writes the PartialDate class used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generatePartialDate(namespace string, path string) error {
+	tmpl := `/**
+ * Partial date support for FHIR dates.
+ *
+{{header}} */
+package {{.}};
+
+import java.time.LocalDate;
+import java.time.YearMonth;
+import java.util.Objects;
+import java.util.Optional;
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+public final class PartialDate {
+    private final int year;
+    private final Integer month;
+    private final Integer day;
+
+    public PartialDate(int year, Integer month, Integer day) {
+        if (day != null && month == null) {
+            throw new IllegalArgumentException("a partial date with a day must have a month");
+        }
+        // Reject impossible dates such as 1970-02-30
+        LocalDate.of(year, month != null ? month : 1, day != null ? day : 1);
+        this.year = year;
+        this.month = month;
+        this.day = day;
+    }
+
+    /**
+     * Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+     */
+    public static PartialDate parse(String value) {
+        if (value.matches("\\d{4}")) {
+            return new PartialDate(Integer.parseInt(value), null, null);
+        }
+        if (value.matches("\\d{4}-\\d{2}")) {
+            YearMonth yearMonth = YearMonth.parse(value);
+            return new PartialDate(yearMonth.getYear(), yearMonth.getMonthValue(), null);
+        }
+        if (value.matches("\\d{4}-\\d{2}-\\d{2}")) {
+            LocalDate date = LocalDate.parse(value);
+            return new PartialDate(date.getYear(), date.getMonthValue(), date.getDayOfMonth());
+        }
+        throw new IllegalArgumentException("invalid partial date: " + value);
+    }
+
+    public int getYear() {
+        return this.year;
+    }
+
+    public Integer getMonth() {
+        return this.month;
+    }
+
+    public Integer getDay() {
+        return this.day;
+    }
+
+    /**
+     * Returns the full date, or empty when the value is less precise than a day.
+     */
+    public Optional<LocalDate> toLocalDate() {
+        if (this.day == null) {
+            return Optional.empty();
+        }
+        return Optional.of(LocalDate.of(this.year, this.month, this.day));
+    }
+
+    @Override
+    public boolean equals(Object other) {
+        if (!(other instanceof PartialDate)) {
+            return false;
+        }
+        PartialDate that = (PartialDate) other;
+        return this.year == that.year && Objects.equals(this.month, that.month) && Objects.equals(this.day, that.day);
+    }
+
+    @Override
+    public int hashCode() {
+        return Objects.hash(this.year, this.month, this.day);
+    }
+
+    @Override
+    public String toString() {
+        StringBuilder text = new StringBuilder(String.format("%04d", this.year));
+        if (this.month != null) {
+            text.append(String.format("-%02d", this.month));
+        }
+        if (this.day != null) {
+            text.append(String.format("-%02d", this.day));
+        }
+        return text.toString();
+    }
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func(" * ")})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, g.packageName(namespace))
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, namespace string, path string) error {
+	tmpl := `/**
+ * UCUM unit validation and conversion for {{.Type}}.
+ *
+{{header}} */
+package {{.Package}};
+{{if .Exact}}
+import java.math.BigDecimal;
+{{- end}}
+import java.util.HashMap;
+import java.util.Map;
+
+public final class Ucum {
+    public static final String SYSTEM = "` + datatypes.UCUMSystem + `";
+
+    /**
+     * A unit whose value v is v * factor + offset in its dimension's base unit.
+     */
+    private static final class Unit {
+        final String dimension;
+        final double factor;
+        final double offset;
+
+        Unit(String dimension, double factor, double offset) {
+            this.dimension = dimension;
+            this.factor = factor;
+            this.offset = offset;
+        }
+    }
+
+    private static final Map<String, Unit> UNITS = new HashMap<>();
+
+    static {
+{{- range .Units}}
+        UNITS.put("{{.Code}}", new Unit("{{.Dimension}}", {{.Factor | float}}, {{.Offset | float}}));
+{{- end}}
+    }
+
+    private Ucum() {}
+
+    /**
+     * Reports whether code is a UCUM unit these helpers can convert.
+     */
+    public static boolean isKnownUnit(String code) {
+        return UNITS.containsKey(code);
+    }
+
+    /**
+     * Converts value between two UCUM units of the same dimension.
+     */
+    public static double convertValue(double value, String fromCode, String toCode) {
+        Unit source = unit(fromCode);
+        Unit target = unit(toCode);
+        if (!source.dimension.equals(target.dimension)) {
+            throw new IllegalArgumentException(
+                "cannot convert " + fromCode + " (" + source.dimension + ") to " + toCode + " (" + target.dimension + ")");
+        }
+        return (value * source.factor + source.offset - target.offset) / target.factor;
+    }
+
+    /**
+     * Returns quantity in toCode units. Its code, or else its unit, must be a
+     * known UCUM unit.
+     */
+    public static {{.Type}} convert({{.Type}} quantity, String toCode) {
+        if (quantity.get{{.F.value | accessor}}() == null) {
+            throw new IllegalArgumentException("cannot convert a quantity without a value");
+        }
+        String fromCode = quantity.get{{.F.code | accessor}}() != null ? quantity.get{{.F.code | accessor}}() : quantity.get{{.F.unit | accessor}}();
+        double value = convertValue(quantity.get{{.F.value | accessor}}().doubleValue(), fromCode, toCode);
+
+        {{.Type}} converted = new {{.Type}}();
+        converted.set{{.F.value | accessor}}({{if .Exact}}BigDecimal.valueOf(value){{else}}value{{end}});
+        converted.set{{.F.comparator | accessor}}(quantity.get{{.F.comparator | accessor}}());
+        converted.set{{.F.unit | accessor}}(toCode);
+        converted.set{{.F.system | accessor}}(SYSTEM);
+        converted.set{{.F.code | accessor}}(toCode);
+        return converted;
+    }
+
+    private static Unit unit(String code) {
+        Unit unit = UNITS.get(code);
+        if (unit == null) {
+            throw new IllegalArgumentException("unknown UCUM unit: " + code);
+        }
+        return unit;
+    }
+}
+`
+	funcMap := template.FuncMap{
+		"accessor": toAccessorName,
+		"float":    datatypes.FloatLiteral,
+		"header":   g.header.Func(" * ", quantity),
+	}
+
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
 
 	data := struct {
-		Schema  schema.Schema
 		Package string
+		Type    string
+		Exact   bool
+		F       map[string]string
+		Units   []datatypes.Unit
 	}{
-		Schema:  s,
-		Package: packageName,
+		Package: g.packageName(namespace),
+		Type:    g.className(quantity),
+		Exact:   g.decimalType == "BigDecimal",
+		F:       naming.ByName(g.fields(quantity)),
+		Units:   datatypes.Units,
 	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into: its package path (e.g., fhir_r4 -> fhir/r4).
+func (g *Generator) NamespaceDir(namespace string) string {
+	return filepath.FromSlash(strings.ReplaceAll(namespace, "_", "/"))
+}
+
 // GenerateMappings generates Java mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	// TODO: Implement mapping generation
 	return nil
 }
 
-func toCamelCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if i == 0 {
-			words[i] = strings.ToLower(w)
-		} else if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
-		}
+// fields returns the fields declared in the class for s, leaving inherited
+// elements to its base class and keeping clear of the base's identifiers.
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Fields("java", datatypes.Own(s), naming.Case(g.naming.Fields).Or(naming.Camel.Apply), g.inherited(s)...))
+}
+
+// inherited returns the identifiers of the fields s inherits from its base
+// classes.
+func (g *Generator) inherited(s schema.Schema) []string {
+	base, ok := datatypes.Base(s.Inherits)
+	if !ok {
+		return nil
+	}
+	idents := g.inherited(base)
+	for _, f := range g.fields(base) {
+		idents = append(idents, f.Ident)
 	}
-	return strings.Join(words, "")
+	return idents
 }
 
-func toPascalCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+// imports returns the classes s uses from other packages.
+func (g *Generator) imports(scope resolve.Scope, s schema.Schema) []string {
+	var imports []string
+	for _, dep := range scope.ClassDependencies(s) {
+		if dep.Namespace != s.Namespace {
+			imports = append(imports, g.packageName(dep.Namespace)+"."+g.className(dep))
 		}
 	}
-	return strings.Join(words, "")
+	sort.Strings(imports)
+	return imports
+}
+
+func (g *Generator) className(s schema.Schema) string {
+	return naming.Ident("java", naming.Case(g.naming.Types).Apply(s.GetName()))
+}
+
+// packageName converts a namespace to a Java package name (e.g., fhir_r4 -> fhir.r4).
+func (g *Generator) packageName(namespace string) string {
+	parts := strings.Split(strings.ToLower(namespace), "_")
+	for i, p := range parts {
+		parts[i] = naming.Ident("java", naming.Case(g.naming.Packages).Apply(p))
+	}
+	return strings.Join(parts, ".")
+}
+
+// toAccessorName derives the getter/setter suffix from a field identifier.
+// Escaped identifiers keep their trailing underscore so that a field named
+// "class" yields getClass_() rather than clashing with Object.getClass().
+func toAccessorName(ident string) string {
+	r, size := utf8.DecodeRuneInString(ident)
+	return string(unicode.ToUpper(r)) + ident[size:]
 }
 
-func toJavaType(yamlType string) string {
+// defaultLiteral returns the default of f as a Java literal of the type
+// generated for it, or "" when it has none.
+func (g *Generator) defaultLiteral(f schema.Field) string {
+	switch {
+	case f.Default == "":
+		return ""
+	case f.DefaultKind() == "integer":
+		return f.Default
+	case f.DefaultKind() == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case f.DefaultKind() == "decimal" && g.decimalType == "BigDecimal":
+		return "new BigDecimal(" + strconv.Quote(f.Default) + ")"
+	case f.DefaultKind() == "decimal":
+		d, _ := strconv.ParseFloat(f.Default, 64)
+		return datatypes.FloatLiteral(d)
+	}
+	return strconv.Quote(f.Default)
+}
+
+func (g *Generator) toJavaType(scope resolve.Scope, yamlType string) string {
+	if target, ok := scope.Lookup(yamlType); ok {
+		return g.className(target)
+	}
 	switch yamlType {
 	case "string", "code", "id", "uri", "url":
 		return "String"
 	case "integer", "positiveInt", "unsignedInt":
 		return "Integer"
 	case "decimal":
-		return "Double"
+		return g.decimalType
 	case "boolean":
 		return "Boolean"
 	case "date":
-		return "LocalDate"
+		return g.dateType
 	case "datetime", "instant":
-		return "Instant"
+		return g.instantType
 	case "base64Binary":
 		return "byte[]"
 	default:
 		if strings.HasPrefix(yamlType, "[]") {
 			innerType := strings.TrimPrefix(yamlType, "[]")
-			return fmt.Sprintf("List<%s>", toJavaType(innerType))
+			return fmt.Sprintf("List<%s>", g.toJavaType(scope, innerType))
 		}
 		return "Object"
 	}
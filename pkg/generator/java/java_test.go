@@ -0,0 +1,50 @@
+package java_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/java"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, java.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, java.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+	}
+	generatortest.RunPackage(t, java.NewGenerator(cfg), "testdata/package")
+}
+
+func TestPackageGradleGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+		Languages: map[string]config.Language{
+			"java": {Build: config.BuildGradle, Publish: config.Repository{URL: "https://maven.example.com/releases"}},
+		},
+	}
+	generatortest.RunPackage(t, java.NewGenerator(cfg), "testdata/package-gradle")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, java.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, java.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
@@ -0,0 +1,84 @@
+/**
+ * Metadata about a resource
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+public class Meta {
+
+    private String versionId;
+
+    private Instant lastUpdated;
+
+    private String source;
+
+    private List<String> profile;
+
+    private List<Coding> security;
+
+    private List<Coding> tag;
+
+
+    public Meta() {}
+
+    /** Version specific identifier */
+    public String getVersionId() {
+        return this.versionId;
+    }
+
+    public void setVersionId(String versionId) {
+        this.versionId = versionId;
+    }
+
+    /** When the resource version last changed */
+    public Instant getLastUpdated() {
+        return this.lastUpdated;
+    }
+
+    public void setLastUpdated(Instant lastUpdated) {
+        this.lastUpdated = lastUpdated;
+    }
+
+    /** Identifies where the resource comes from */
+    public String getSource() {
+        return this.source;
+    }
+
+    public void setSource(String source) {
+        this.source = source;
+    }
+
+    /** Profiles this resource claims to conform to */
+    public List<String> getProfile() {
+        return this.profile;
+    }
+
+    public void setProfile(List<String> profile) {
+        this.profile = profile;
+    }
+
+    /** Security Labels applied to this resource */
+    public List<Coding> getSecurity() {
+        return this.security;
+    }
+
+    public void setSecurity(List<Coding> security) {
+        this.security = security;
+    }
+
+    /** Tags applied to this resource */
+    public List<Coding> getTag() {
+        return this.tag;
+    }
+
+    public void setTag(List<Coding> tag) {
+        this.tag = tag;
+    }
+
+}
@@ -0,0 +1,100 @@
+/**
+ * Translations of codes by concept maps.
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+
+public final class ConceptMaps {
+    /**
+     * A code that a concept map translates a source code to, and how
+     * equivalent the two are.
+     */
+    public static final class Target {
+        private final String system;
+        private final String code;
+        private final String display;
+        private final String equivalence;
+
+        public Target(String system, String code, String display, String equivalence) {
+            this.system = system;
+            this.code = code;
+            this.display = display;
+            this.equivalence = equivalence;
+        }
+
+        public String getSystem() {
+            return system;
+        }
+
+        public String getCode() {
+            return code;
+        }
+
+        public String getDisplay() {
+            return display;
+        }
+
+        public String getEquivalence() {
+            return equivalence;
+        }
+
+        @Override
+        public boolean equals(Object o) {
+            if (this == o) {
+                return true;
+            }
+            if (!(o instanceof Target)) {
+                return false;
+            }
+            Target other = (Target) o;
+            return Objects.equals(system, other.system) && Objects.equals(code, other.code)
+                && Objects.equals(display, other.display) && Objects.equals(equivalence, other.equivalence);
+        }
+
+        @Override
+        public int hashCode() {
+            return Objects.hash(system, code, display, equivalence);
+        }
+
+        @Override
+        public String toString() {
+            return "Target{system=" + system + ", code=" + code + ", display=" + display + ", equivalence=" + equivalence + "}";
+        }
+    }
+
+    // The concept map lab-to-loinc, keyed by source system and code.
+    private static final Map<List<String>, List<Target>> LAB_TO_LOINC = new HashMap<>();
+
+    static {
+        LAB_TO_LOINC.put(Arrays.asList("http://hospital.example/lab-codes", "GLU"), List.of(
+            new Target("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent")));
+        LAB_TO_LOINC.put(Arrays.asList("http://hospital.example/lab-codes", "K"), List.of(
+            new Target("http://loinc.org", "2823-3", "Potassium [Moles/volume] in Serum or Plasma", "equivalent")));
+        LAB_TO_LOINC.put(Arrays.asList("http://hospital.example/lab-codes", "LYTES"), List.of(
+            new Target("http://loinc.org", "2951-2", "Sodium [Moles/volume] in Serum or Plasma", "wider"),
+            new Target("http://loinc.org", "2075-0", "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", "wider")));
+        LAB_TO_LOINC.put(Arrays.asList("http://legacy.example/lab", "0012"), List.of(
+            new Target("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent")));
+    }
+
+    private ConceptMaps() {}
+
+    /**
+     * Returns the codes that code of system translates to by the concept map
+     * lab-to-loinc, if any.
+     *
+     * <p>Local lab test codes to LOINC
+     */
+    public static List<Target> translateLabToLoinc(String system, String code) {
+        return LAB_TO_LOINC.getOrDefault(Arrays.asList(system, code), Collections.emptyList());
+    }
+}
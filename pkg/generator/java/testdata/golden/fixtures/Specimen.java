@@ -0,0 +1,164 @@
+/**
+ * A sample collected for analysis
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+import datatypes.Identifier;
+import datatypes.Reference;
+
+public class Specimen {
+
+    private String id;
+
+    private String status = "available";
+
+    private Identifier accessionIdentifier;
+
+    private Instant receivedTime;
+
+    private LocalDate collectedDate;
+
+    private Double quantity;
+
+    private Integer containerCount = 1;
+
+    private Boolean fasting = false;
+
+    private List<String> note;
+
+    private Reference subject;
+
+    private List<Reference> request;
+
+    private Visit visit;
+
+    private Object collection;
+
+
+    public Specimen() {}
+
+    /** Logical id of this artifact */
+    public String getId() {
+        return this.id;
+    }
+
+    public void setId(String id) {
+        this.id = id;
+    }
+
+    /** available | unavailable | entered-in-error */
+    public String getStatus() {
+        return this.status;
+    }
+
+    public void setStatus(String status) {
+        this.status = status;
+    }
+
+    /** Identifier assigned by the lab */
+    public Identifier getAccessionIdentifier() {
+        return this.accessionIdentifier;
+    }
+
+    public void setAccessionIdentifier(Identifier accessionIdentifier) {
+        this.accessionIdentifier = accessionIdentifier;
+    }
+
+    /** When the specimen was received */
+    public Instant getReceivedTime() {
+        return this.receivedTime;
+    }
+
+    public void setReceivedTime(Instant receivedTime) {
+        this.receivedTime = receivedTime;
+    }
+
+    /** Collection date */
+    public LocalDate getCollectedDate() {
+        return this.collectedDate;
+    }
+
+    public void setCollectedDate(LocalDate collectedDate) {
+        this.collectedDate = collectedDate;
+    }
+
+    /** Amount collected */
+    public Double getQuantity() {
+        return this.quantity;
+    }
+
+    public void setQuantity(Double quantity) {
+        this.quantity = quantity;
+    }
+
+    /** Number of containers */
+    public Integer getContainerCount() {
+        return this.containerCount;
+    }
+
+    public void setContainerCount(Integer containerCount) {
+        this.containerCount = containerCount;
+    }
+
+    /** Whether the patient was fasting */
+    public Boolean getFasting() {
+        return this.fasting;
+    }
+
+    public void setFasting(Boolean fasting) {
+        this.fasting = fasting;
+    }
+
+    /** Comments */
+    public List<String> getNote() {
+        return this.note;
+    }
+
+    public void setNote(List<String> note) {
+        this.note = note;
+    }
+
+    /** Where the specimen came from */
+    public Reference getSubject() {
+        return this.subject;
+    }
+
+    public void setSubject(Reference subject) {
+        this.subject = subject;
+    }
+
+    /** Why the specimen was collected */
+    public List<Reference> getRequest() {
+        return this.request;
+    }
+
+    public void setRequest(List<Reference> request) {
+        this.request = request;
+    }
+
+    /** Visit during which the specimen was collected */
+    public Visit getVisit() {
+        return this.visit;
+    }
+
+    public void setVisit(Visit visit) {
+        this.visit = visit;
+    }
+
+    /** Collection details */
+    public Object getCollection() {
+        return this.collection;
+    }
+
+    public void setCollection(Object collection) {
+        this.collection = collection;
+    }
+
+}
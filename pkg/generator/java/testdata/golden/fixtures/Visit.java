@@ -0,0 +1,179 @@
+/**
+ * An encounter whose field names are reserved words in target languages
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+import datatypes.Address;
+import datatypes.CodeableConcept;
+import datatypes.Coding;
+import datatypes.Quantity;
+import datatypes.Reference;
+import registry.Practitioner;
+
+public class Visit {
+
+    private Coding class_;
+
+    private List<CodeableConcept> type;
+
+    private Reference for_;
+
+    private String match = "unlinked";
+
+    private Integer order;
+
+    private String self;
+
+    private Address where;
+
+    private Boolean _2ndOpinion;
+
+    private String priorityCode;
+
+    private String priorityCode_2;
+
+    private String visit;
+
+    private Quantity length;
+
+    private Practitioner attender;
+
+    private List<Specimen> specimens;
+
+
+    public Visit() {}
+
+    /** Classification of the visit */
+    public Coding getClass_() {
+        return this.class_;
+    }
+
+    public void setClass_(Coding class_) {
+        this.class_ = class_;
+    }
+
+    /** Specific type of visit */
+    public List<CodeableConcept> getType() {
+        return this.type;
+    }
+
+    public void setType(List<CodeableConcept> type) {
+        this.type = type;
+    }
+
+    /** Who the visit is for */
+    public Reference getFor_() {
+        return this.for_;
+    }
+
+    public void setFor_(Reference for_) {
+        this.for_ = for_;
+    }
+
+    /** Record linkage match grade */
+    public String getMatch() {
+        return this.match;
+    }
+
+    public void setMatch(String match) {
+        this.match = match;
+    }
+
+    /** Sequence within the episode */
+    public Integer getOrder() {
+        return this.order;
+    }
+
+    public void setOrder(Integer order) {
+        this.order = order;
+    }
+
+    /** Canonical URL of the visit */
+    public String getSelf() {
+        return this.self;
+    }
+
+    public void setSelf(String self) {
+        this.self = self;
+    }
+
+    /** Where the visit took place */
+    public Address getWhere() {
+        return this.where;
+    }
+
+    public void setWhere(Address where) {
+        this.where = where;
+    }
+
+    /** Whether a second opinion was requested */
+    public Boolean get_2ndOpinion() {
+        return this._2ndOpinion;
+    }
+
+    public void set_2ndOpinion(Boolean _2ndOpinion) {
+        this._2ndOpinion = _2ndOpinion;
+    }
+
+    /** Priority as a code */
+    public String getPriorityCode() {
+        return this.priorityCode;
+    }
+
+    public void setPriorityCode(String priorityCode) {
+        this.priorityCode = priorityCode;
+    }
+
+    /** Priority as legacy text */
+    public String getPriorityCode_2() {
+        return this.priorityCode_2;
+    }
+
+    public void setPriorityCode_2(String priorityCode_2) {
+        this.priorityCode_2 = priorityCode_2;
+    }
+
+    /** Visit number */
+    public String getVisit() {
+        return this.visit;
+    }
+
+    public void setVisit(String visit) {
+        this.visit = visit;
+    }
+
+    /** Time the visit lasted */
+    public Quantity getLength() {
+        return this.length;
+    }
+
+    public void setLength(Quantity length) {
+        this.length = length;
+    }
+
+    /** Practitioner who saw the patient */
+    public Practitioner getAttender() {
+        return this.attender;
+    }
+
+    public void setAttender(Practitioner attender) {
+        this.attender = attender;
+    }
+
+    /** Specimens collected during the visit */
+    public List<Specimen> getSpecimens() {
+        return this.specimens;
+    }
+
+    public void setSpecimens(List<Specimen> specimens) {
+        this.specimens = specimens;
+    }
+
+}
@@ -0,0 +1,41 @@
+/**
+ * Concept - reference to a terminology or just text
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class CodeableConcept {
+
+    private List<Coding> coding;
+
+    private String text;
+
+
+    public CodeableConcept() {}
+
+    /** Code defined by a terminology system */
+    public List<Coding> getCoding() {
+        return this.coding;
+    }
+
+    public void setCoding(List<Coding> coding) {
+        this.coding = coding;
+    }
+
+    /** Plain text representation of the concept */
+    public String getText() {
+        return this.text;
+    }
+
+    public void setText(String text) {
+        this.text = text;
+    }
+
+}
@@ -0,0 +1,52 @@
+/**
+ * A resource with narrative and extensions
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public abstract class DomainResource extends Resource {
+
+    private Narrative text;
+
+    private List<Extension> extension;
+
+    private List<Extension> modifierExtension;
+
+
+    public DomainResource() {}
+
+    /** Text summary of the resource, for human interpretation */
+    public Narrative getText() {
+        return this.text;
+    }
+
+    public void setText(Narrative text) {
+        this.text = text;
+    }
+
+    /** Additional content defined by implementations */
+    public List<Extension> getExtension() {
+        return this.extension;
+    }
+
+    public void setExtension(List<Extension> extension) {
+        this.extension = extension;
+    }
+
+    /** Extensions that cannot be ignored */
+    public List<Extension> getModifierExtension() {
+        return this.modifierExtension;
+    }
+
+    public void setModifierExtension(List<Extension> modifierExtension) {
+        this.modifierExtension = modifierExtension;
+    }
+
+}
@@ -0,0 +1,140 @@
+/**
+ * Additional content defined by implementations
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Extension {
+
+    private String url;
+
+    private String valueString;
+
+    private String valueCode;
+
+    private Boolean valueBoolean;
+
+    private Integer valueInteger;
+
+    private BigDecimal valueDecimal;
+
+    private OffsetDateTime valueDateTime;
+
+    private Coding valueCoding;
+
+    private CodeableConcept valueCodeableConcept;
+
+    private Reference valueReference;
+
+    private Address valueAddress;
+
+
+    public Extension() {}
+
+    /** Identifies the meaning of the extension */
+    public String getUrl() {
+        return this.url;
+    }
+
+    public void setUrl(String url) {
+        this.url = url;
+    }
+
+    /** Value of extension */
+    public String getValueString() {
+        return this.valueString;
+    }
+
+    public void setValueString(String valueString) {
+        this.valueString = valueString;
+    }
+
+    /** Value of extension */
+    public String getValueCode() {
+        return this.valueCode;
+    }
+
+    public void setValueCode(String valueCode) {
+        this.valueCode = valueCode;
+    }
+
+    /** Value of extension */
+    public Boolean getValueBoolean() {
+        return this.valueBoolean;
+    }
+
+    public void setValueBoolean(Boolean valueBoolean) {
+        this.valueBoolean = valueBoolean;
+    }
+
+    /** Value of extension */
+    public Integer getValueInteger() {
+        return this.valueInteger;
+    }
+
+    public void setValueInteger(Integer valueInteger) {
+        this.valueInteger = valueInteger;
+    }
+
+    /** Value of extension */
+    public BigDecimal getValueDecimal() {
+        return this.valueDecimal;
+    }
+
+    public void setValueDecimal(BigDecimal valueDecimal) {
+        this.valueDecimal = valueDecimal;
+    }
+
+    /** Value of extension */
+    public OffsetDateTime getValueDateTime() {
+        return this.valueDateTime;
+    }
+
+    public void setValueDateTime(OffsetDateTime valueDateTime) {
+        this.valueDateTime = valueDateTime;
+    }
+
+    /** Value of extension */
+    public Coding getValueCoding() {
+        return this.valueCoding;
+    }
+
+    public void setValueCoding(Coding valueCoding) {
+        this.valueCoding = valueCoding;
+    }
+
+    /** Value of extension */
+    public CodeableConcept getValueCodeableConcept() {
+        return this.valueCodeableConcept;
+    }
+
+    public void setValueCodeableConcept(CodeableConcept valueCodeableConcept) {
+        this.valueCodeableConcept = valueCodeableConcept;
+    }
+
+    /** Value of extension */
+    public Reference getValueReference() {
+        return this.valueReference;
+    }
+
+    public void setValueReference(Reference valueReference) {
+        this.valueReference = valueReference;
+    }
+
+    /** Value of extension */
+    public Address getValueAddress() {
+        return this.valueAddress;
+    }
+
+    public void setValueAddress(Address valueAddress) {
+        this.valueAddress = valueAddress;
+    }
+
+}
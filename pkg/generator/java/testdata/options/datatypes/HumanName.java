@@ -0,0 +1,96 @@
+/**
+ * Name of a human - parts and usage
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class HumanName {
+
+    private String use;
+
+    private String text;
+
+    private String family;
+
+    private List<String> given;
+
+    private List<String> prefix;
+
+    private List<String> suffix;
+
+    private Period period;
+
+
+    public HumanName() {}
+
+    /** usual | official | temp | nickname | anonymous | old | maiden */
+    public String getUse() {
+        return this.use;
+    }
+
+    public void setUse(String use) {
+        this.use = use;
+    }
+
+    /** Text representation of the full name */
+    public String getText() {
+        return this.text;
+    }
+
+    public void setText(String text) {
+        this.text = text;
+    }
+
+    /** Family name (often called 'Surname') */
+    public String getFamily() {
+        return this.family;
+    }
+
+    public void setFamily(String family) {
+        this.family = family;
+    }
+
+    /** Given names (not always 'first'). Includes middle names */
+    public List<String> getGiven() {
+        return this.given;
+    }
+
+    public void setGiven(List<String> given) {
+        this.given = given;
+    }
+
+    /** Parts that come before the name */
+    public List<String> getPrefix() {
+        return this.prefix;
+    }
+
+    public void setPrefix(List<String> prefix) {
+        this.prefix = prefix;
+    }
+
+    /** Parts that come after the name */
+    public List<String> getSuffix() {
+        return this.suffix;
+    }
+
+    public void setSuffix(List<String> suffix) {
+        this.suffix = suffix;
+    }
+
+    /** Time period when name was/is in use */
+    public Period getPeriod() {
+        return this.period;
+    }
+
+    public void setPeriod(Period period) {
+        this.period = period;
+    }
+
+}
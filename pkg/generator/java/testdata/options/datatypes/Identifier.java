@@ -0,0 +1,74 @@
+/**
+ * An identifier intended for computation
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Identifier {
+
+    private String use;
+
+    private CodeableConcept type;
+
+    private String system;
+
+    private String value;
+
+    private Period period;
+
+
+    public Identifier() {}
+
+    /** usual | official | temp | secondary | old (If known) */
+    public String getUse() {
+        return this.use;
+    }
+
+    public void setUse(String use) {
+        this.use = use;
+    }
+
+    /** Description of identifier */
+    public CodeableConcept getType() {
+        return this.type;
+    }
+
+    public void setType(CodeableConcept type) {
+        this.type = type;
+    }
+
+    /** The namespace for the identifier value */
+    public String getSystem() {
+        return this.system;
+    }
+
+    public void setSystem(String system) {
+        this.system = system;
+    }
+
+    /** The value that is unique */
+    public String getValue() {
+        return this.value;
+    }
+
+    public void setValue(String value) {
+        this.value = value;
+    }
+
+    /** Time period when id is/was valid for use */
+    public Period getPeriod() {
+        return this.period;
+    }
+
+    public void setPeriod(Period period) {
+        this.period = period;
+    }
+
+}
@@ -0,0 +1,41 @@
+/**
+ * Human-readable summary of the resource
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Narrative {
+
+    private String status;
+
+    private String div;
+
+
+    public Narrative() {}
+
+    /** generated | extensions | additional | empty */
+    public String getStatus() {
+        return this.status;
+    }
+
+    public void setStatus(String status) {
+        this.status = status;
+    }
+
+    /** Limited xhtml content */
+    public String getDiv() {
+        return this.div;
+    }
+
+    public void setDiv(String div) {
+        this.div = div;
+    }
+
+}
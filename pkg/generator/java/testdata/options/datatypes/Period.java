@@ -0,0 +1,41 @@
+/**
+ * Time range defined by start and end date/time
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Period {
+
+    private OffsetDateTime start;
+
+    private OffsetDateTime end;
+
+
+    public Period() {}
+
+    /** Starting time with inclusive boundary */
+    public OffsetDateTime getStart() {
+        return this.start;
+    }
+
+    public void setStart(OffsetDateTime start) {
+        this.start = start;
+    }
+
+    /** End time with inclusive boundary, if not ongoing */
+    public OffsetDateTime getEnd() {
+        return this.end;
+    }
+
+    public void setEnd(OffsetDateTime end) {
+        this.end = end;
+    }
+
+}
@@ -0,0 +1,74 @@
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Quantity {
+
+    private BigDecimal value;
+
+    private String comparator;
+
+    private String unit;
+
+    private String system;
+
+    private String code;
+
+
+    public Quantity() {}
+
+    /** Numerical value (with implicit precision) */
+    public BigDecimal getValue() {
+        return this.value;
+    }
+
+    public void setValue(BigDecimal value) {
+        this.value = value;
+    }
+
+    /** < | <= | >= | > - how to understand the value */
+    public String getComparator() {
+        return this.comparator;
+    }
+
+    public void setComparator(String comparator) {
+        this.comparator = comparator;
+    }
+
+    /** Unit representation */
+    public String getUnit() {
+        return this.unit;
+    }
+
+    public void setUnit(String unit) {
+        this.unit = unit;
+    }
+
+    /** System that defines coded unit form */
+    public String getSystem() {
+        return this.system;
+    }
+
+    public void setSystem(String system) {
+        this.system = system;
+    }
+
+    /** Coded form of the unit */
+    public String getCode() {
+        return this.code;
+    }
+
+    public void setCode(String code) {
+        this.code = code;
+    }
+
+}
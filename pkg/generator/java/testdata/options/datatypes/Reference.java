@@ -0,0 +1,63 @@
+/**
+ * A reference from one resource to another
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+public class Reference {
+
+    private String reference;
+
+    private String type;
+
+    private Identifier identifier;
+
+    private String display;
+
+
+    public Reference() {}
+
+    /** Literal reference, Relative, internal or absolute URL */
+    public String getReference() {
+        return this.reference;
+    }
+
+    public void setReference(String reference) {
+        this.reference = reference;
+    }
+
+    /** Type the reference refers to (e.g. "Patient") */
+    public String getType() {
+        return this.type;
+    }
+
+    public void setType(String type) {
+        this.type = type;
+    }
+
+    /** Logical reference, when literal reference is not known */
+    public Identifier getIdentifier() {
+        return this.identifier;
+    }
+
+    public void setIdentifier(Identifier identifier) {
+        this.identifier = identifier;
+    }
+
+    /** Text alternative for the resource */
+    public String getDisplay() {
+        return this.display;
+    }
+
+    public void setDisplay(String display) {
+        this.display = display;
+    }
+
+}
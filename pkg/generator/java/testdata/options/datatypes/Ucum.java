@@ -0,0 +1,130 @@
+/**
+ * UCUM unit validation and conversion for Quantity.
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.math.BigDecimal;
+import java.util.HashMap;
+import java.util.Map;
+
+public final class Ucum {
+    public static final String SYSTEM = "http://unitsofmeasure.org";
+
+    /**
+     * A unit whose value v is v * factor + offset in its dimension's base unit.
+     */
+    private static final class Unit {
+        final String dimension;
+        final double factor;
+        final double offset;
+
+        Unit(String dimension, double factor, double offset) {
+            this.dimension = dimension;
+            this.factor = factor;
+            this.offset = offset;
+        }
+    }
+
+    private static final Map<String, Unit> UNITS = new HashMap<>();
+
+    static {
+        UNITS.put("g", new Unit("mass", 1.0, 0.0));
+        UNITS.put("kg", new Unit("mass", 1000.0, 0.0));
+        UNITS.put("mg", new Unit("mass", 0.001, 0.0));
+        UNITS.put("ug", new Unit("mass", 1e-06, 0.0));
+        UNITS.put("ng", new Unit("mass", 1e-09, 0.0));
+        UNITS.put("[lb_av]", new Unit("mass", 453.59237, 0.0));
+        UNITS.put("[oz_av]", new Unit("mass", 28.349523125, 0.0));
+        UNITS.put("m", new Unit("length", 1.0, 0.0));
+        UNITS.put("km", new Unit("length", 1000.0, 0.0));
+        UNITS.put("cm", new Unit("length", 0.01, 0.0));
+        UNITS.put("mm", new Unit("length", 0.001, 0.0));
+        UNITS.put("[in_i]", new Unit("length", 0.0254, 0.0));
+        UNITS.put("[ft_i]", new Unit("length", 0.3048, 0.0));
+        UNITS.put("L", new Unit("volume", 1.0, 0.0));
+        UNITS.put("dL", new Unit("volume", 0.1, 0.0));
+        UNITS.put("mL", new Unit("volume", 0.001, 0.0));
+        UNITS.put("uL", new Unit("volume", 1e-06, 0.0));
+        UNITS.put("s", new Unit("time", 1.0, 0.0));
+        UNITS.put("min", new Unit("time", 60.0, 0.0));
+        UNITS.put("h", new Unit("time", 3600.0, 0.0));
+        UNITS.put("d", new Unit("time", 86400.0, 0.0));
+        UNITS.put("wk", new Unit("time", 604800.0, 0.0));
+        UNITS.put("mo", new Unit("time", 2.6298e+06, 0.0));
+        UNITS.put("a", new Unit("time", 3.15576e+07, 0.0));
+        UNITS.put("K", new Unit("temperature", 1.0, 0.0));
+        UNITS.put("Cel", new Unit("temperature", 1.0, 273.15));
+        UNITS.put("[degF]", new Unit("temperature", 0.5555555555555556, 255.37222222222223));
+        UNITS.put("g/L", new Unit("mass_concentration", 1.0, 0.0));
+        UNITS.put("g/dL", new Unit("mass_concentration", 10.0, 0.0));
+        UNITS.put("mg/dL", new Unit("mass_concentration", 0.01, 0.0));
+        UNITS.put("mg/L", new Unit("mass_concentration", 0.001, 0.0));
+        UNITS.put("ug/mL", new Unit("mass_concentration", 0.001, 0.0));
+        UNITS.put("ng/mL", new Unit("mass_concentration", 1e-06, 0.0));
+        UNITS.put("mol/L", new Unit("substance_concentration", 1.0, 0.0));
+        UNITS.put("mmol/L", new Unit("substance_concentration", 0.001, 0.0));
+        UNITS.put("umol/L", new Unit("substance_concentration", 1e-06, 0.0));
+        UNITS.put("nmol/L", new Unit("substance_concentration", 1e-09, 0.0));
+        UNITS.put("Pa", new Unit("pressure", 1.0, 0.0));
+        UNITS.put("kPa", new Unit("pressure", 1000.0, 0.0));
+        UNITS.put("mm[Hg]", new Unit("pressure", 133.322, 0.0));
+        UNITS.put("Hz", new Unit("frequency", 1.0, 0.0));
+        UNITS.put("/min", new Unit("frequency", 0.016666666666666666, 0.0));
+        UNITS.put("/h", new Unit("frequency", 0.0002777777777777778, 0.0));
+        UNITS.put("1", new Unit("ratio", 1.0, 0.0));
+        UNITS.put("%", new Unit("ratio", 0.01, 0.0));
+    }
+
+    private Ucum() {}
+
+    /**
+     * Reports whether code is a UCUM unit these helpers can convert.
+     */
+    public static boolean isKnownUnit(String code) {
+        return UNITS.containsKey(code);
+    }
+
+    /**
+     * Converts value between two UCUM units of the same dimension.
+     */
+    public static double convertValue(double value, String fromCode, String toCode) {
+        Unit source = unit(fromCode);
+        Unit target = unit(toCode);
+        if (!source.dimension.equals(target.dimension)) {
+            throw new IllegalArgumentException(
+                "cannot convert " + fromCode + " (" + source.dimension + ") to " + toCode + " (" + target.dimension + ")");
+        }
+        return (value * source.factor + source.offset - target.offset) / target.factor;
+    }
+
+    /**
+     * Returns quantity in toCode units. Its code, or else its unit, must be a
+     * known UCUM unit.
+     */
+    public static Quantity convert(Quantity quantity, String toCode) {
+        if (quantity.getValue() == null) {
+            throw new IllegalArgumentException("cannot convert a quantity without a value");
+        }
+        String fromCode = quantity.getCode() != null ? quantity.getCode() : quantity.getUnit();
+        double value = convertValue(quantity.getValue().doubleValue(), fromCode, toCode);
+
+        Quantity converted = new Quantity();
+        converted.setValue(BigDecimal.valueOf(value));
+        converted.setComparator(quantity.getComparator());
+        converted.setUnit(toCode);
+        converted.setSystem(SYSTEM);
+        converted.setCode(toCode);
+        return converted;
+    }
+
+    private static Unit unit(String code) {
+        Unit unit = UNITS.get(code);
+        if (unit == null) {
+            throw new IllegalArgumentException("unknown UCUM unit: " + code);
+        }
+        return unit;
+    }
+}
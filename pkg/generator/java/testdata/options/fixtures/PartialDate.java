@@ -0,0 +1,98 @@
+/**
+ * Partial date support for FHIR dates.
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.time.LocalDate;
+import java.time.YearMonth;
+import java.util.Objects;
+import java.util.Optional;
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+public final class PartialDate {
+    private final int year;
+    private final Integer month;
+    private final Integer day;
+
+    public PartialDate(int year, Integer month, Integer day) {
+        if (day != null && month == null) {
+            throw new IllegalArgumentException("a partial date with a day must have a month");
+        }
+        // Reject impossible dates such as 1970-02-30
+        LocalDate.of(year, month != null ? month : 1, day != null ? day : 1);
+        this.year = year;
+        this.month = month;
+        this.day = day;
+    }
+
+    /**
+     * Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+     */
+    public static PartialDate parse(String value) {
+        if (value.matches("\\d{4}")) {
+            return new PartialDate(Integer.parseInt(value), null, null);
+        }
+        if (value.matches("\\d{4}-\\d{2}")) {
+            YearMonth yearMonth = YearMonth.parse(value);
+            return new PartialDate(yearMonth.getYear(), yearMonth.getMonthValue(), null);
+        }
+        if (value.matches("\\d{4}-\\d{2}-\\d{2}")) {
+            LocalDate date = LocalDate.parse(value);
+            return new PartialDate(date.getYear(), date.getMonthValue(), date.getDayOfMonth());
+        }
+        throw new IllegalArgumentException("invalid partial date: " + value);
+    }
+
+    public int getYear() {
+        return this.year;
+    }
+
+    public Integer getMonth() {
+        return this.month;
+    }
+
+    public Integer getDay() {
+        return this.day;
+    }
+
+    /**
+     * Returns the full date, or empty when the value is less precise than a day.
+     */
+    public Optional<LocalDate> toLocalDate() {
+        if (this.day == null) {
+            return Optional.empty();
+        }
+        return Optional.of(LocalDate.of(this.year, this.month, this.day));
+    }
+
+    @Override
+    public boolean equals(Object other) {
+        if (!(other instanceof PartialDate)) {
+            return false;
+        }
+        PartialDate that = (PartialDate) other;
+        return this.year == that.year && Objects.equals(this.month, that.month) && Objects.equals(this.day, that.day);
+    }
+
+    @Override
+    public int hashCode() {
+        return Objects.hash(this.year, this.month, this.day);
+    }
+
+    @Override
+    public String toString() {
+        StringBuilder text = new StringBuilder(String.format("%04d", this.year));
+        if (this.month != null) {
+            text.append(String.format("-%02d", this.month));
+        }
+        if (this.day != null) {
+            text.append(String.format("-%02d", this.day));
+        }
+        return text.toString();
+    }
+}
@@ -0,0 +1,183 @@
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+import datatypes.HumanName;
+import datatypes.Quantity;
+import registry.Practitioner;
+
+public class lab_result {
+
+    private String resultId;
+
+    private String mrn;
+
+    private BigDecimal valueNumeric;
+
+    private BigDecimal chargeAmount = new BigDecimal("0");
+
+    private Quantity valueQuantity;
+
+    private OffsetDateTime resultedAt;
+
+    private HumanName performerName;
+
+    private Practitioner orderedBy;
+
+    private byte[] rawPayload;
+
+    private String icd10Code;
+
+    private List<String> reviewerIds;
+
+    private OffsetDateTime ingestedAt;
+
+    private String sourceSystem;
+
+    private String recordHash;
+
+
+    public lab_result() {}
+
+    /** Identifiant du résultat. Example: LR-1001 */
+    public String getResultId() {
+        return this.resultId;
+    }
+
+    public void setResultId(String resultId) {
+        this.resultId = resultId;
+    }
+
+    /** Medical record number */
+    public String getMrn() {
+        return this.mrn;
+    }
+
+    public void setMrn(String mrn) {
+        this.mrn = mrn;
+    }
+
+    /** Valeur mesurée (µmol/L). Examples: 5.4, 12.0 */
+    public BigDecimal getValueNumeric() {
+        return this.valueNumeric;
+    }
+
+    public void setValueNumeric(BigDecimal valueNumeric) {
+        this.valueNumeric = valueNumeric;
+    }
+
+    /** Billed amount */
+    public BigDecimal getChargeAmount() {
+        return this.chargeAmount;
+    }
+
+    public void setChargeAmount(BigDecimal chargeAmount) {
+        this.chargeAmount = chargeAmount;
+    }
+
+    /** Measured value with its unit */
+    public Quantity getValueQuantity() {
+        return this.valueQuantity;
+    }
+
+    public void setValueQuantity(Quantity valueQuantity) {
+        this.valueQuantity = valueQuantity;
+    }
+
+    /** When the result was released */
+    public OffsetDateTime getResultedAt() {
+        return this.resultedAt;
+    }
+
+    public void setResultedAt(OffsetDateTime resultedAt) {
+        this.resultedAt = resultedAt;
+    }
+
+    /** Who performed the test */
+    public HumanName getPerformerName() {
+        return this.performerName;
+    }
+
+    public void setPerformerName(HumanName performerName) {
+        this.performerName = performerName;
+    }
+
+    /** Who ordered the test */
+    public Practitioner getOrderedBy() {
+        return this.orderedBy;
+    }
+
+    public void setOrderedBy(Practitioner orderedBy) {
+        this.orderedBy = orderedBy;
+    }
+
+    /** Original HL7 message */
+    public byte[] getRawPayload() {
+        return this.rawPayload;
+    }
+
+    public void setRawPayload(byte[] rawPayload) {
+        this.rawPayload = rawPayload;
+    }
+
+    /** Diagnosis the test was ordered for */
+    public String getIcd10Code() {
+        return this.icd10Code;
+    }
+
+    public void setIcd10Code(String icd10Code) {
+        this.icd10Code = icd10Code;
+    }
+
+    /** Reviewing clinicians */
+    public List<String> getReviewerIds() {
+        return this.reviewerIds;
+    }
+
+    public void setReviewerIds(List<String> reviewerIds) {
+        this.reviewerIds = reviewerIds;
+    }
+
+    /** When the record was loaded into the warehouse */
+    public OffsetDateTime getIngestedAt() {
+        return this.ingestedAt;
+    }
+
+    public void setIngestedAt(OffsetDateTime ingestedAt) {
+        this.ingestedAt = ingestedAt;
+    }
+
+    /** System the record was extracted from */
+    public String getSourceSystem() {
+        return this.sourceSystem;
+    }
+
+    public void setSourceSystem(String sourceSystem) {
+        this.sourceSystem = sourceSystem;
+    }
+
+    /** Hash of the source record, for change detection */
+    public String getRecordHash() {
+        return this.recordHash;
+    }
+
+    public void setRecordHash(String recordHash) {
+        this.recordHash = recordHash;
+    }
+
+}
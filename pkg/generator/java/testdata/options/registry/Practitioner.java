@@ -0,0 +1,77 @@
+/**
+ * A person providing care
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package registry;
+
+import java.math.BigDecimal;
+import java.time.LocalDate;
+import java.time.OffsetDateTime;
+import java.util.List;
+
+import datatypes.DomainResource;
+import datatypes.HumanName;
+
+public class Practitioner extends DomainResource {
+
+    private List<HumanName> name;
+
+    private Practitioner supervisor;
+
+    private OffsetDateTime ingestedAt;
+
+    private String sourceSystem;
+
+    private String recordHash;
+
+
+    public Practitioner() {}
+
+    /** The name(s) associated with the practitioner */
+    public List<HumanName> getName() {
+        return this.name;
+    }
+
+    public void setName(List<HumanName> name) {
+        this.name = name;
+    }
+
+    /** Practitioner responsible for this one */
+    public Practitioner getSupervisor() {
+        return this.supervisor;
+    }
+
+    public void setSupervisor(Practitioner supervisor) {
+        this.supervisor = supervisor;
+    }
+
+    /** When the record was loaded into the warehouse */
+    public OffsetDateTime getIngestedAt() {
+        return this.ingestedAt;
+    }
+
+    public void setIngestedAt(OffsetDateTime ingestedAt) {
+        this.ingestedAt = ingestedAt;
+    }
+
+    /** System the record was extracted from */
+    public String getSourceSystem() {
+        return this.sourceSystem;
+    }
+
+    public void setSourceSystem(String sourceSystem) {
+        this.sourceSystem = sourceSystem;
+    }
+
+    /** Hash of the source record, for change detection */
+    public String getRecordHash() {
+        return this.recordHash;
+    }
+
+    public void setRecordHash(String recordHash) {
+        this.recordHash = recordHash;
+    }
+
+}
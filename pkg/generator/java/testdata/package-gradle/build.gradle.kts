@@ -0,0 +1,54 @@
+// Generated by ehrglot v0.1.0.
+
+plugins {
+    `java-library`
+    `maven-publish`
+}
+
+group = "com.acme"
+version = "1.2.0"
+description = "Acme \"clinical\" models"
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    api("com.fasterxml.jackson.core:jackson-databind:2.17.2")
+    api("com.fasterxml.jackson.datatype:jackson-datatype-jsr310:2.17.2")
+}
+
+sourceSets {
+    main {
+        java {
+            setSrcDirs(listOf("."))
+            exclude("build/**")
+        }
+    }
+}
+
+tasks.withType<JavaCompile> {
+    options.release.set(11)
+    options.encoding = "UTF-8"
+}
+
+publishing {
+    publications {
+        create<MavenPublication>("maven") {
+            artifactId = "acme-models"
+            from(components["java"])
+        }
+    }
+    repositories {
+        (findProperty("ehrglotRepository") ?: "https://maven.example.com/releases").let { repository ->
+            maven {
+                name = "ehrglot"
+                url = uri(repository)
+                credentials {
+                    username = System.getenv("EHRGLOT_REPO_USERNAME")
+                    password = System.getenv("EHRGLOT_REPO_PASSWORD")
+                }
+            }
+        }
+    }
+}
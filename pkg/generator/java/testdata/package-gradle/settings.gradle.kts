@@ -0,0 +1,3 @@
+// Generated by ehrglot v0.1.0.
+
+rootProject.name = "acme-models"
@@ -0,0 +1,128 @@
+/**
+ * An address expressed using postal conventions
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+public class Address {
+
+    private String use;
+
+    private String type;
+
+    private String text;
+
+    private List<String> line;
+
+    private String city;
+
+    private String district;
+
+    private String state;
+
+    private String postalCode;
+
+    private String country;
+
+    private Period period;
+
+
+    public Address() {}
+
+    /** home | work | temp | old | billing - purpose of this address */
+    public String getUse() {
+        return this.use;
+    }
+
+    public void setUse(String use) {
+        this.use = use;
+    }
+
+    /** postal | physical | both */
+    public String getType() {
+        return this.type;
+    }
+
+    public void setType(String type) {
+        this.type = type;
+    }
+
+    /** Text representation of the address */
+    public String getText() {
+        return this.text;
+    }
+
+    public void setText(String text) {
+        this.text = text;
+    }
+
+    /** Street name, number, direction & P.O. Box etc. */
+    public List<String> getLine() {
+        return this.line;
+    }
+
+    public void setLine(List<String> line) {
+        this.line = line;
+    }
+
+    /** Name of city, town etc. */
+    public String getCity() {
+        return this.city;
+    }
+
+    public void setCity(String city) {
+        this.city = city;
+    }
+
+    /** District name (aka county) */
+    public String getDistrict() {
+        return this.district;
+    }
+
+    public void setDistrict(String district) {
+        this.district = district;
+    }
+
+    /** Sub-unit of country (abbreviations ok) */
+    public String getState() {
+        return this.state;
+    }
+
+    public void setState(String state) {
+        this.state = state;
+    }
+
+    /** Postal code for area */
+    public String getPostalCode() {
+        return this.postalCode;
+    }
+
+    public void setPostalCode(String postalCode) {
+        this.postalCode = postalCode;
+    }
+
+    /** Country (e.g. can be ISO 3166 2 or 3 letter code) */
+    public String getCountry() {
+        return this.country;
+    }
+
+    public void setCountry(String country) {
+        this.country = country;
+    }
+
+    /** Time period when address was/is in use */
+    public Period getPeriod() {
+        return this.period;
+    }
+
+    public void setPeriod(Period period) {
+        this.period = period;
+    }
+
+}
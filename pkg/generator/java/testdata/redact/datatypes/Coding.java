@@ -0,0 +1,73 @@
+/**
+ * A reference to a code defined by a terminology system
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+public class Coding {
+
+    private String system;
+
+    private String version;
+
+    private String code;
+
+    private String display;
+
+    private Boolean userSelected;
+
+
+    public Coding() {}
+
+    /** Identity of the terminology system */
+    public String getSystem() {
+        return this.system;
+    }
+
+    public void setSystem(String system) {
+        this.system = system;
+    }
+
+    /** Version of the system - if relevant */
+    public String getVersion() {
+        return this.version;
+    }
+
+    public void setVersion(String version) {
+        this.version = version;
+    }
+
+    /** Symbol in syntax defined by the system */
+    public String getCode() {
+        return this.code;
+    }
+
+    public void setCode(String code) {
+        this.code = code;
+    }
+
+    /** Representation defined by the system */
+    public String getDisplay() {
+        return this.display;
+    }
+
+    public void setDisplay(String display) {
+        this.display = display;
+    }
+
+    /** If this coding was chosen directly by the user */
+    public Boolean getUserSelected() {
+        return this.userSelected;
+    }
+
+    public void setUserSelected(Boolean userSelected) {
+        this.userSelected = userSelected;
+    }
+
+}
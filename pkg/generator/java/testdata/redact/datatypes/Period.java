@@ -0,0 +1,40 @@
+/**
+ * Time range defined by start and end date/time
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+public class Period {
+
+    private Instant start;
+
+    private Instant end;
+
+
+    public Period() {}
+
+    /** Starting time with inclusive boundary */
+    public Instant getStart() {
+        return this.start;
+    }
+
+    public void setStart(Instant start) {
+        this.start = start;
+    }
+
+    /** End time with inclusive boundary, if not ongoing */
+    public Instant getEnd() {
+        return this.end;
+    }
+
+    public void setEnd(Instant end) {
+        this.end = end;
+    }
+
+}
@@ -0,0 +1,62 @@
+/**
+ * Base Resource
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package datatypes;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+public abstract class Resource {
+
+    private String id;
+
+    private Meta meta;
+
+    private String implicitRules;
+
+    private String language;
+
+
+    public Resource() {}
+
+    /** Logical id of this artifact */
+    public String getId() {
+        return this.id;
+    }
+
+    public void setId(String id) {
+        this.id = id;
+    }
+
+    /** Metadata about the resource */
+    public Meta getMeta() {
+        return this.meta;
+    }
+
+    public void setMeta(Meta meta) {
+        this.meta = meta;
+    }
+
+    /** A set of rules under which this content was created */
+    public String getImplicitRules() {
+        return this.implicitRules;
+    }
+
+    public void setImplicitRules(String implicitRules) {
+        this.implicitRules = implicitRules;
+    }
+
+    /** Language of the resource content */
+    public String getLanguage() {
+        return this.language;
+    }
+
+    public void setLanguage(String language) {
+        this.language = language;
+    }
+
+}
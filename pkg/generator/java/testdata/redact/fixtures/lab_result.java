@@ -0,0 +1,174 @@
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package fixtures;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+import datatypes.HumanName;
+import datatypes.Quantity;
+import registry.Practitioner;
+
+public class lab_result {
+
+    private String resultId;
+
+    private String mrn;
+
+    private Double valueNumeric;
+
+    private Double chargeAmount = 0.0;
+
+    private Quantity valueQuantity;
+
+    private Instant resultedAt;
+
+    private HumanName performerName;
+
+    private Practitioner orderedBy;
+
+    private byte[] rawPayload;
+
+    private String icd10Code;
+
+    private List<String> reviewerIds;
+
+
+    public lab_result() {}
+
+    /** Identifiant du résultat. Example: LR-1001 */
+    public String getResultId() {
+        return this.resultId;
+    }
+
+    public void setResultId(String resultId) {
+        this.resultId = resultId;
+    }
+
+    /** Medical record number */
+    public String getMrn() {
+        return this.mrn;
+    }
+
+    public void setMrn(String mrn) {
+        this.mrn = mrn;
+    }
+
+    /** Valeur mesurée (µmol/L). Examples: 5.4, 12.0 */
+    public Double getValueNumeric() {
+        return this.valueNumeric;
+    }
+
+    public void setValueNumeric(Double valueNumeric) {
+        this.valueNumeric = valueNumeric;
+    }
+
+    /** Billed amount */
+    public Double getChargeAmount() {
+        return this.chargeAmount;
+    }
+
+    public void setChargeAmount(Double chargeAmount) {
+        this.chargeAmount = chargeAmount;
+    }
+
+    /** Measured value with its unit */
+    public Quantity getValueQuantity() {
+        return this.valueQuantity;
+    }
+
+    public void setValueQuantity(Quantity valueQuantity) {
+        this.valueQuantity = valueQuantity;
+    }
+
+    /** When the result was released */
+    public Instant getResultedAt() {
+        return this.resultedAt;
+    }
+
+    public void setResultedAt(Instant resultedAt) {
+        this.resultedAt = resultedAt;
+    }
+
+    /** Who performed the test */
+    public HumanName getPerformerName() {
+        return this.performerName;
+    }
+
+    public void setPerformerName(HumanName performerName) {
+        this.performerName = performerName;
+    }
+
+    /** Who ordered the test */
+    public Practitioner getOrderedBy() {
+        return this.orderedBy;
+    }
+
+    public void setOrderedBy(Practitioner orderedBy) {
+        this.orderedBy = orderedBy;
+    }
+
+    /** Original HL7 message */
+    public byte[] getRawPayload() {
+        return this.rawPayload;
+    }
+
+    public void setRawPayload(byte[] rawPayload) {
+        this.rawPayload = rawPayload;
+    }
+
+    /** Diagnosis the test was ordered for */
+    public String getIcd10Code() {
+        return this.icd10Code;
+    }
+
+    public void setIcd10Code(String icd10Code) {
+        this.icd10Code = icd10Code;
+    }
+
+    /** Reviewing clinicians */
+    public List<String> getReviewerIds() {
+        return this.reviewerIds;
+    }
+
+    public void setReviewerIds(List<String> reviewerIds) {
+        this.reviewerIds = reviewerIds;
+    }
+
+    /**
+     * Returns the properties of this record, with those at pii_level high
+     * or above masked, for logging.
+     */
+    public java.util.Map<String, Object> redactForLogging() {
+        java.util.Map<String, Object> fields = new java.util.LinkedHashMap<>();
+        fields.put("resultId", this.resultId);
+        fields.put("mrn", "[REDACTED]");
+        fields.put("valueNumeric", this.valueNumeric);
+        fields.put("chargeAmount", this.chargeAmount);
+        fields.put("valueQuantity", this.valueQuantity);
+        fields.put("resultedAt", this.resultedAt);
+        fields.put("performerName", this.performerName);
+        fields.put("orderedBy", this.orderedBy);
+        fields.put("rawPayload", this.rawPayload);
+        fields.put("icd10Code", this.icd10Code);
+        fields.put("reviewerIds", this.reviewerIds);
+        return fields;
+    }
+
+    @Override
+    public String toString() {
+        return "lab_result" + redactForLogging();
+    }
+
+}
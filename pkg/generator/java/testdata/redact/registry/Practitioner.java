@@ -0,0 +1,43 @@
+/**
+ * A person providing care
+ *
+ * Generated by ehrglot v0.1.0 at <timestamp>.
+ * DO NOT EDIT.
+ */
+package registry;
+
+import java.time.LocalDate;
+import java.time.Instant;
+import java.util.List;
+
+import datatypes.DomainResource;
+import datatypes.HumanName;
+
+public class Practitioner extends DomainResource {
+
+    private List<HumanName> name;
+
+    private Practitioner supervisor;
+
+
+    public Practitioner() {}
+
+    /** The name(s) associated with the practitioner */
+    public List<HumanName> getName() {
+        return this.name;
+    }
+
+    public void setName(List<HumanName> name) {
+        this.name = name;
+    }
+
+    /** Practitioner responsible for this one */
+    public Practitioner getSupervisor() {
+        return this.supervisor;
+    }
+
+    public void setSupervisor(Practitioner supervisor) {
+        this.supervisor = supervisor;
+    }
+
+}
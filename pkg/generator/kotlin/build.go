@@ -0,0 +1,212 @@
+package kotlin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/publish"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Versions of the Kotlin compiler and kotlinx.serialization that packages
+// build with.
+const (
+	kotlinVersion        = "1.9.24"
+	serializationVersion = "1.6.3"
+)
+
+// Package writes a Gradle Kotlin DSL build, or with the maven build a
+// pom.xml, that compiles the generated sources with the kotlinx.serialization
+// plugin into a jar, and deploys it to the configured publish repository.
+// The groupId defaults to the package name.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if err := g.pkg.Validate(); err != nil {
+		return err
+	}
+	files := map[string]string{"build.gradle.kts": gradleBuild, "settings.gradle.kts": gradleSettings}
+	if g.build == config.BuildMaven {
+		files = map[string]string{"pom.xml": mavenPOM}
+	}
+	for name, tmpl := range files {
+		tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"quote": kotlinString})
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(filepath.Join(outputDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		err = tmpl_parsed.Execute(f, g.project())
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// project is the Maven coordinates of the package, and the repository it is
+// deployed to, if any.
+type project struct {
+	config.Package
+	Repository string
+}
+
+// project returns the coordinates of the package, with the groupId
+// defaulting to its name.
+func (g *Generator) project() project {
+	p := project{Package: g.pkg, Repository: g.repository}
+	if p.Group == "" {
+		p.Group = p.Name
+	}
+	return p
+}
+
+// kotlinString returns s as a Kotlin string literal, in which $ would
+// otherwise start a template.
+func kotlinString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`).Replace(s) + `"`
+}
+
+const gradleBuild = `// Generated by ehrglot v` + Version + `.
+
+plugins {
+    kotlin("jvm") version "` + kotlinVersion + `"
+    kotlin("plugin.serialization") version "` + kotlinVersion + `"
+    ` + "`maven-publish`" + `
+}
+
+group = {{quote .Group}}
+version = {{quote .Version}}
+{{- with .Description}}
+description = {{quote .}}
+{{- end}}
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    api("org.jetbrains.kotlinx:kotlinx-serialization-json:` + serializationVersion + `")
+}
+
+kotlin {
+    sourceSets["main"].kotlin {
+        setSrcDirs(listOf("."))
+        exclude("build/**")
+    }
+}
+
+publishing {
+    publications {
+        create<MavenPublication>("maven") {
+            artifactId = {{quote .Name}}
+            from(components["java"])
+        }
+    }
+    repositories {
+        ` + publishRepository + `
+    }
+}
+`
+
+const gradleSettings = `// Generated by ehrglot v` + Version + `.
+
+rootProject.name = {{quote .Name}}
+`
+
+// publishRepository declares the repository gradle publish uploads to: the
+// ehrglotRepository property that ehrglot publish sets, or else the
+// configured one. Credentials come from the environment, as for Maven.
+const publishRepository = `{{if .Repository}}(findProperty("ehrglotRepository") ?: {{quote .Repository}}).let{{else}}findProperty("ehrglotRepository")?.let{{end}} { repository ->
+            maven {
+                name = "ehrglot"
+                url = uri(repository)
+                credentials {
+                    username = System.getenv("` + publish.UsernameEnv + `")
+                    password = System.getenv("` + publish.PasswordEnv + `")
+                }
+            }
+        }`
+
+const mavenPOM = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Generated by ehrglot v` + Version + `. -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>{{html .Group}}</groupId>
+    <artifactId>{{html .Name}}</artifactId>
+    <version>{{html .Version}}</version>
+    <packaging>jar</packaging>
+{{- with .Description}}
+    <description>{{html .}}</description>
+{{- end}}
+
+    <properties>
+        <kotlin.version>` + kotlinVersion + `</kotlin.version>
+        <kotlin.compiler.jvmTarget>11</kotlin.compiler.jvmTarget>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+    </properties>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.jetbrains.kotlin</groupId>
+            <artifactId>kotlin-stdlib</artifactId>
+            <version>${kotlin.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>org.jetbrains.kotlinx</groupId>
+            <artifactId>kotlinx-serialization-json-jvm</artifactId>
+            <version>` + serializationVersion + `</version>
+        </dependency>
+    </dependencies>
+
+    <build>
+        <sourceDirectory>${project.basedir}</sourceDirectory>
+        <plugins>
+            <plugin>
+                <groupId>org.jetbrains.kotlin</groupId>
+                <artifactId>kotlin-maven-plugin</artifactId>
+                <version>${kotlin.version}</version>
+                <executions>
+                    <execution>
+                        <id>compile</id>
+                        <phase>compile</phase>
+                        <goals>
+                            <goal>compile</goal>
+                        </goals>
+                    </execution>
+                </executions>
+                <configuration>
+                    <compilerPlugins>
+                        <plugin>kotlinx-serialization</plugin>
+                    </compilerPlugins>
+                </configuration>
+                <dependencies>
+                    <dependency>
+                        <groupId>org.jetbrains.kotlin</groupId>
+                        <artifactId>kotlin-maven-serialization</artifactId>
+                        <version>${kotlin.version}</version>
+                    </dependency>
+                </dependencies>
+            </plugin>
+        </plugins>
+    </build>
+{{- with .Repository}}
+
+    <distributionManagement>
+        <repository>
+            <id>ehrglot</id>
+            <url>{{html .}}</url>
+        </repository>
+    </distributionManagement>
+{{- end}}
+</project>
+`
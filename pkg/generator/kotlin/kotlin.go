@@ -2,45 +2,111 @@
 package kotlin
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
 // Generator generates Kotlin code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	decimalType string
+	instantType string
+	dateType    string
+	ucum        bool
+	conceptMaps []string
+	redact      string
+	audit       bool
+	pkg         config.Package
+	build       string
+	repository  string
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Kotlin code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("kotlin").Naming, decimalType: "Double", instantType: "Instant", dateType: "LocalDate", ucum: cfg.UCUM, conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), audit: cfg.Audit, pkg: cfg.PackageInfo("kotlin"), build: cfg.BuildTool("kotlin"), repository: cfg.Language("kotlin").Publish.URL, header: header.New(cfg.Header, Version)}
+	if cfg.DecimalMode("kotlin") == config.DecimalExact {
+		g.decimalType = "BigDecimal"
+	}
+	temporal := cfg.TemporalPolicy("kotlin")
+	if temporal.Instants == config.InstantsOffset {
+		g.instantType = "OffsetDateTime"
+	}
+	if temporal.Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
 // Generate generates Kotlin data classes from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
+		if g.dateType == "PartialDate" {
+			if err := g.generatePartialDate(namespace, filepath.Join(nsDir, "PartialDate.kt")); err != nil {
+				return err
+			}
+		}
+
 		// Generate each schema file
 		for _, s := range nsSchemas {
-			filename := s.GetName() + ".kt"
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			filename := naming.Case(g.naming.Files).Apply(s.GetName()) + ".kt"
 			path := filepath.Join(nsDir, filename)
-			if err := g.generateDataClass(s, namespace, path); err != nil {
+			if err := g.generateDataClass(refs.Scope(namespace), s, path); err != nil {
+				return err
+			}
+
+			if g.ucum && datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+				if err := g.generateUCUM(s, namespace, filepath.Join(nsDir, "Ucum.kt")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, namespace, filepath.Join(nsDir, "ConceptMaps.kt")); err != nil {
 				return err
 			}
 		}
@@ -49,38 +115,63 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 	return nil
 }
 
-func (g *Generator) generateDataClass(s schema.Schema, namespace string, path string) error {
+func (g *Generator) generateDataClass(scope resolve.Scope, s schema.Schema, path string) error {
+	if err := s.CheckDefaults(); err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
 	tmpl := `// {{.Schema.Description}}
 //
-// Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-// DO NOT EDIT.
-
+{{header}}
 package {{.Package}}
 
-import java.time.LocalDate
-import java.time.Instant
+{{range .Imports}}import {{.}}
+{{end}}{{if .Exact}}import java.math.BigDecimal
+{{end}}import java.time.LocalDate
+import java.time.{{.Instant}}
 import kotlinx.serialization.Serializable
 import kotlinx.serialization.SerialName
 
 /**
  * {{.Schema.Description}}
+{{- with .Schema.Docs}}
+ *
+{{- range .}}
+ * {{.}}
+{{- end}}
+{{- end}}
  */
 @Serializable
 data class {{.Schema | schemaName}}(
-{{range $i, $f := .Schema.Fields}}{{if $i}},
-{{end}}    @SerialName("{{$f.Name | camel}}")
-    val {{$f.Name | camel}}: {{$f | kotlinType}}{{if not $f.Required}} = null{{end}}{{end}}
-)
+{{range $i, $f := .Fields}}{{if $i}},
+{{end}}    @SerialName("{{$f.Wire}}")
+    val {{$f.Ident}}: {{$f.Field | kotlinType}}{{with default $f.Field}} = {{.}}{{else}}{{if not $f.Required}} = null{{end}}{{end}}{{end}}
+){{if .Schema.HasSensitive .Level}} {
+    /**
+     * Returns the properties of this record, with those at pii_level {{.Level}}
+     * or above masked, for logging.
+     */
+    fun redactForLogging(): Map<String, Any?> = mapOf(
+{{- range .Fields}}
+        "{{.Wire}}" to {{if .Sensitive $.Level}}"` + schema.Redacted + `"{{else}}{{.Ident}}{{end}},
+{{- end}}
+    )
+
+    override fun toString(): String =
+        "{{.Schema | schemaName}}(" + redactForLogging().entries.joinToString(", ") { "${it.key}=${it.value}" } + ")"
+}{{end}}
 `
 	funcMap := template.FuncMap{
-		"camel":      toCamelCase,
-		"kotlinType": toKotlinType,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"kotlinType": func(f schema.Field) string {
+			return g.toKotlinType(scope, f)
+		},
+		"schemaName": g.typeName,
+		"default":    g.defaultLiteral,
+		"header":     g.header.Func("// ", s),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -89,38 +180,223 @@ data class {{.Schema | schemaName}}(
 	}
 	defer f.Close()
 
-	// Convert namespace to Kotlin package name
-	packageName := strings.ReplaceAll(namespace, "_", ".")
-
 	data := struct {
 		Schema  schema.Schema
+		Fields  []naming.Field
 		Package string
+		Exact   bool
+		Instant string
+		Imports []string
+		Level   string
 	}{
 		Schema:  s,
-		Package: packageName,
+		Exact:   g.decimalType == "BigDecimal",
+		Instant: g.instantType,
+		Fields:  g.fields(s),
+		Package: g.packageName(scope.Namespace),
+		Imports: g.imports(scope, s),
+		Level:   g.redact,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generatePartialDate writes the PartialDate class used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generatePartialDate(namespace string, path string) error {
+	tmpl := `// Partial date support for FHIR dates.
+//
+{{header}}
+package {{.}}
+
+import java.time.LocalDate
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+data class PartialDate(val year: Int, val month: Int? = null, val day: Int? = null) {
+    init {
+        require(day == null || month != null) { "a partial date with a day must have a month" }
+        // Reject impossible dates such as 1970-02-30
+        LocalDate.of(year, month ?: 1, day ?: 1)
+    }
+
+    /** Returns the full date, or null when the value is less precise than a day. */
+    fun toLocalDate(): LocalDate? =
+        if (month != null && day != null) LocalDate.of(year, month, day) else null
+
+    override fun toString(): String = buildString {
+        append("%04d".format(year))
+        month?.let { append("-%02d".format(it)) }
+        day?.let { append("-%02d".format(it)) }
+    }
+
+    companion object {
+        private val PATTERN = Regex("""(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""")
+
+        /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+        fun parse(value: String): PartialDate {
+            val match = PATTERN.matchEntire(value)
+                ?: throw IllegalArgumentException("invalid partial date: $value")
+            val (year, month, day) = match.destructured
+            return PartialDate(year.toInt(), month.toIntOrNull(), day.toIntOrNull())
+        }
+    }
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("// ")})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, g.packageName(namespace))
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, namespace string, path string) error {
+	tmpl := `// UCUM unit validation and conversion for {{.Type}}.
+//
+{{header}}
+package {{.Package}}
+
+object Ucum {
+    const val SYSTEM = "` + datatypes.UCUMSystem + `"
+
+    /** A unit whose value v is v * factor + offset in its dimension's base unit. */
+    private data class UcumUnit(val dimension: String, val factor: Double, val offset: Double)
+
+    private val UNITS = mapOf(
+{{- range $i, $u := .Units}}{{if $i}},{{end}}
+        "{{$u.Code}}" to UcumUnit("{{$u.Dimension}}", {{$u.Factor | float}}, {{$u.Offset | float}})
+{{- end}}
+    )
+
+    /** Reports whether code is a UCUM unit these helpers can convert. */
+    fun isKnownUnit(code: String): Boolean = code in UNITS
+
+    /** Converts value between two UCUM units of the same dimension. */
+    fun convertValue(value: Double, fromCode: String, toCode: String): Double {
+        val source = unit(fromCode)
+        val target = unit(toCode)
+        require(source.dimension == target.dimension) {
+            "cannot convert $fromCode (${source.dimension}) to $toCode (${target.dimension})"
+        }
+        return (value * source.factor + source.offset - target.offset) / target.factor
+    }
+
+    /** Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit. */
+    fun convert(quantity: {{.Type}}, toCode: String): {{.Type}} {
+        val current = requireNotNull(quantity.{{.F.value}}) { "cannot convert a quantity without a value" }
+        val value = convertValue(current.toDouble(), quantity.{{.F.code}} ?: quantity.{{.F.unit}} ?: "", toCode)
+        return quantity.copy(
+            {{.F.value}} = {{if .Exact}}value.toBigDecimal(){{else}}value{{end}},
+            {{.F.unit}} = toCode,
+            {{.F.system}} = SYSTEM,
+            {{.F.code}} = toCode
+        )
+    }
+
+    private fun unit(code: String): UcumUnit =
+        UNITS[code] ?: throw IllegalArgumentException("unknown UCUM unit: $code")
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.header.Func("// ", quantity)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Type    string
+		Exact   bool
+		F       map[string]string
+		Units   []datatypes.Unit
+	}{
+		Package: g.packageName(namespace),
+		Type:    g.typeName(quantity),
+		Exact:   g.decimalType == "BigDecimal",
+		F:       naming.ByName(g.fields(quantity)),
+		Units:   datatypes.Units,
 	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
 // GenerateMappings generates Kotlin mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	return nil
 }
 
-func toCamelCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if i == 0 {
-			words[i] = strings.ToLower(w)
-		} else if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+// imports returns the classes s uses from other packages.
+func (g *Generator) imports(scope resolve.Scope, s schema.Schema) []string {
+	var imports []string
+	for _, dep := range scope.Dependencies(s) {
+		if dep.Namespace != s.Namespace {
+			imports = append(imports, g.packageName(dep.Namespace)+"."+g.typeName(dep))
 		}
 	}
-	return strings.Join(words, "")
+	sort.Strings(imports)
+	return imports
+}
+
+func (g *Generator) typeName(s schema.Schema) string {
+	return naming.Ident("kotlin", naming.Case(g.naming.Types).Apply(s.GetName()))
+}
+
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Wire(naming.Fields("kotlin", s.Fields, naming.Case(g.naming.Fields).Or(naming.Camel.Apply)), naming.Camel.Apply))
+}
+
+// packageName converts a namespace to a Kotlin package name (e.g., fhir_r4 -> fhir.r4).
+func (g *Generator) packageName(namespace string) string {
+	parts := strings.Split(strings.ToLower(namespace), "_")
+	for i, p := range parts {
+		parts[i] = naming.Ident("kotlin", naming.Case(g.naming.Packages).Apply(p))
+	}
+	return strings.Join(parts, ".")
+}
+
+// defaultLiteral returns the default of f as a Kotlin literal of the type
+// generated for it, or "" when it has none.
+func (g *Generator) defaultLiteral(f schema.Field) string {
+	switch {
+	case f.Default == "":
+		return ""
+	case f.DefaultKind() == "integer":
+		return f.Default
+	case f.DefaultKind() == "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case f.DefaultKind() == "decimal" && g.decimalType == "BigDecimal":
+		return "BigDecimal(" + strconv.Quote(f.Default) + ")"
+	case f.DefaultKind() == "decimal":
+		d, _ := strconv.ParseFloat(f.Default, 64)
+		return datatypes.FloatLiteral(d)
+	}
+	// Kotlin strings interpolate $.
+	return strings.ReplaceAll(strconv.Quote(f.Default), "$", `\$`)
 }
 
-func toKotlinType(f schema.Field) string {
+func (g *Generator) toKotlinType(scope resolve.Scope, f schema.Field) string {
 	baseType := ""
 	switch f.Type {
 	case "string", "code", "id", "uri", "url":
@@ -128,19 +404,21 @@ func toKotlinType(f schema.Field) string {
 	case "integer", "positiveInt", "unsignedInt":
 		baseType = "Int"
 	case "decimal":
-		baseType = "Double"
+		baseType = g.decimalType
 	case "boolean":
 		baseType = "Boolean"
 	case "date":
-		baseType = "LocalDate"
+		baseType = g.dateType
 	case "datetime", "instant":
-		baseType = "Instant"
+		baseType = g.instantType
 	case "base64Binary":
 		baseType = "ByteArray"
 	default:
-		if strings.HasPrefix(f.Type, "[]") {
+		if target, ok := scope.Lookup(f.Type); ok {
+			baseType = g.typeName(target)
+		} else if strings.HasPrefix(f.Type, "[]") {
 			innerType := strings.TrimPrefix(f.Type, "[]")
-			inner := toKotlinType(schema.Field{Type: innerType, Required: true})
+			inner := g.toKotlinType(scope, schema.Field{Type: innerType, Required: true})
 			baseType = fmt.Sprintf("List<%s>", inner)
 		} else {
 			baseType = "Any"
@@ -0,0 +1,50 @@
+package kotlin_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/kotlin"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, kotlin.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, kotlin.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+	}
+	generatortest.RunPackage(t, kotlin.NewGenerator(cfg), "testdata/package")
+}
+
+func TestPackageMavenGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Group: "com.acme"},
+		Languages: map[string]config.Language{
+			"kotlin": {Build: config.BuildMaven, Publish: config.Repository{URL: "https://maven.example.com/releases"}},
+		},
+	}
+	generatortest.RunPackage(t, kotlin.NewGenerator(cfg), "testdata/package-maven")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, kotlin.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, kotlin.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
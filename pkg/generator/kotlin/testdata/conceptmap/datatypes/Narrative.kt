@@ -0,0 +1,22 @@
+// Human-readable summary of the resource
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Human-readable summary of the resource
+ */
+@Serializable
+data class Narrative(
+    @SerialName("status")
+    val status: String,
+    @SerialName("div")
+    val div: String
+)
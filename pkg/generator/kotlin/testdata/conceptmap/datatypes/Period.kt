@@ -0,0 +1,22 @@
+// Time range defined by start and end date/time
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Time range defined by start and end date/time
+ */
+@Serializable
+data class Period(
+    @SerialName("start")
+    val start: Instant? = null,
+    @SerialName("end")
+    val end: Instant? = null
+)
@@ -0,0 +1,38 @@
+// Translations of codes by concept maps.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+/** A code that a concept map translates a source code to, and how equivalent the two are. */
+data class ConceptMapTarget(val system: String, val code: String, val display: String, val equivalence: String)
+
+/** Translates codes by the concept maps of this namespace. */
+object ConceptMaps {
+    // The concept map lab-to-loinc, keyed by source system and code.
+    private val LAB_TO_LOINC: Map<Pair<String, String>, List<ConceptMapTarget>> = mapOf(
+        Pair("http://hospital.example/lab-codes", "GLU") to listOf(
+            ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent")
+        ),
+        Pair("http://hospital.example/lab-codes", "K") to listOf(
+            ConceptMapTarget("http://loinc.org", "2823-3", "Potassium [Moles/volume] in Serum or Plasma", "equivalent")
+        ),
+        Pair("http://hospital.example/lab-codes", "LYTES") to listOf(
+            ConceptMapTarget("http://loinc.org", "2951-2", "Sodium [Moles/volume] in Serum or Plasma", "wider"),
+            ConceptMapTarget("http://loinc.org", "2075-0", "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", "wider")
+        ),
+        Pair("http://legacy.example/lab", "0012") to listOf(
+            ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent")
+        )
+    )
+
+    /**
+     * Returns the codes that code of system translates to by the concept map
+     * lab-to-loinc, if any.
+     *
+     * Local lab test codes to LOINC
+     */
+    fun translateLabToLoinc(system: String, code: String): List<ConceptMapTarget> =
+        LAB_TO_LOINC[Pair(system, code)] ?: emptyList()
+}
@@ -0,0 +1,46 @@
+// A sample collected for analysis
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import datatypes.Identifier
+import datatypes.Reference
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * A sample collected for analysis
+ */
+@Serializable
+data class Specimen(
+    @SerialName("id")
+    val id: String,
+    @SerialName("status")
+    val status: String = "available",
+    @SerialName("accessionIdentifier")
+    val accessionIdentifier: Identifier? = null,
+    @SerialName("receivedTime")
+    val receivedTime: Instant? = null,
+    @SerialName("collectedDate")
+    val collectedDate: LocalDate? = null,
+    @SerialName("quantity")
+    val quantity: Double? = null,
+    @SerialName("containerCount")
+    val containerCount: Int? = 1,
+    @SerialName("fasting")
+    val fasting: Boolean? = false,
+    @SerialName("note")
+    val note: List<String>? = null,
+    @SerialName("subject")
+    val subject: Reference? = null,
+    @SerialName("request")
+    val request: List<Reference>? = null,
+    @SerialName("visit")
+    val visit: Visit? = null,
+    @SerialName("collection")
+    val collection: Any? = null
+)
@@ -0,0 +1,52 @@
+// An encounter whose field names are reserved words in target languages
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import datatypes.Address
+import datatypes.CodeableConcept
+import datatypes.Coding
+import datatypes.Quantity
+import datatypes.Reference
+import registry.Practitioner
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+@Serializable
+data class Visit(
+    @SerialName("class")
+    val `class`: Coding,
+    @SerialName("type")
+    val type: List<CodeableConcept>? = null,
+    @SerialName("for")
+    val `for`: Reference? = null,
+    @SerialName("match")
+    val match: String? = "unlinked",
+    @SerialName("order")
+    val order: Int? = null,
+    @SerialName("self")
+    val self: String? = null,
+    @SerialName("where")
+    val where: Address? = null,
+    @SerialName("2ndOpinion")
+    val _2ndOpinion: Boolean? = null,
+    @SerialName("priorityCode")
+    val priorityCode: String? = null,
+    @SerialName("priorityCode_2")
+    val priorityCode_2: String? = null,
+    @SerialName("visit")
+    val visit: String? = null,
+    @SerialName("length")
+    val length: Quantity? = null,
+    @SerialName("attender")
+    val attender: Practitioner? = null,
+    @SerialName("specimens")
+    val specimens: List<Specimen>? = null
+)
@@ -0,0 +1,38 @@
+// An address expressed using postal conventions
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * An address expressed using postal conventions
+ */
+@Serializable
+data class Address(
+    @SerialName("use")
+    val use: String? = null,
+    @SerialName("type")
+    val type: String? = null,
+    @SerialName("text")
+    val text: String? = null,
+    @SerialName("line")
+    val line: List<String>? = null,
+    @SerialName("city")
+    val city: String? = null,
+    @SerialName("district")
+    val district: String? = null,
+    @SerialName("state")
+    val state: String? = null,
+    @SerialName("postalCode")
+    val postalCode: String? = null,
+    @SerialName("country")
+    val country: String? = null,
+    @SerialName("period")
+    val period: Period? = null
+)
@@ -0,0 +1,32 @@
+// Name of a human - parts and usage
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Name of a human - parts and usage
+ */
+@Serializable
+data class HumanName(
+    @SerialName("use")
+    val use: String? = null,
+    @SerialName("text")
+    val text: String? = null,
+    @SerialName("family")
+    val family: String? = null,
+    @SerialName("given")
+    val given: List<String>? = null,
+    @SerialName("prefix")
+    val prefix: List<String>? = null,
+    @SerialName("suffix")
+    val suffix: List<String>? = null,
+    @SerialName("period")
+    val period: Period? = null
+)
@@ -0,0 +1,23 @@
+// Concept - reference to a terminology or just text
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+@Serializable
+data class CodeableConcept(
+    @SerialName("coding")
+    val coding: List<Coding>? = null,
+    @SerialName("text")
+    val text: String? = null
+)
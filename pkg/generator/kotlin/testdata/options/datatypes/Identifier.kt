@@ -0,0 +1,29 @@
+// An identifier intended for computation
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * An identifier intended for computation
+ */
+@Serializable
+data class Identifier(
+    @SerialName("use")
+    val use: String? = null,
+    @SerialName("type")
+    val type: CodeableConcept? = null,
+    @SerialName("system")
+    val system: String? = null,
+    @SerialName("value")
+    val value: String? = null,
+    @SerialName("period")
+    val period: Period? = null
+)
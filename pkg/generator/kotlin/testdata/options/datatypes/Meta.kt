@@ -0,0 +1,31 @@
+// Metadata about a resource
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Metadata about a resource
+ */
+@Serializable
+data class Meta(
+    @SerialName("versionId")
+    val versionId: String? = null,
+    @SerialName("lastUpdated")
+    val lastUpdated: OffsetDateTime? = null,
+    @SerialName("source")
+    val source: String? = null,
+    @SerialName("profile")
+    val profile: List<String>? = null,
+    @SerialName("security")
+    val security: List<Coding>? = null,
+    @SerialName("tag")
+    val tag: List<Coding>? = null
+)
@@ -0,0 +1,41 @@
+// Partial date support for FHIR dates.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+data class PartialDate(val year: Int, val month: Int? = null, val day: Int? = null) {
+    init {
+        require(day == null || month != null) { "a partial date with a day must have a month" }
+        // Reject impossible dates such as 1970-02-30
+        LocalDate.of(year, month ?: 1, day ?: 1)
+    }
+
+    /** Returns the full date, or null when the value is less precise than a day. */
+    fun toLocalDate(): LocalDate? =
+        if (month != null && day != null) LocalDate.of(year, month, day) else null
+
+    override fun toString(): String = buildString {
+        append("%04d".format(year))
+        month?.let { append("-%02d".format(it)) }
+        day?.let { append("-%02d".format(it)) }
+    }
+
+    companion object {
+        private val PATTERN = Regex("""(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""")
+
+        /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+        fun parse(value: String): PartialDate {
+            val match = PATTERN.matchEntire(value)
+                ?: throw IllegalArgumentException("invalid partial date: $value")
+            val (year, month, day) = match.destructured
+            return PartialDate(year.toInt(), month.toIntOrNull(), day.toIntOrNull())
+        }
+    }
+}
@@ -0,0 +1,23 @@
+// Time range defined by start and end date/time
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Time range defined by start and end date/time
+ */
+@Serializable
+data class Period(
+    @SerialName("start")
+    val start: OffsetDateTime? = null,
+    @SerialName("end")
+    val end: OffsetDateTime? = null
+)
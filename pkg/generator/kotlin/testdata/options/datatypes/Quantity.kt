@@ -0,0 +1,29 @@
+// A measured amount (or an amount that can potentially be measured)
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+@Serializable
+data class Quantity(
+    @SerialName("value")
+    val value: BigDecimal? = null,
+    @SerialName("comparator")
+    val comparator: String? = null,
+    @SerialName("unit")
+    val unit: String? = null,
+    @SerialName("system")
+    val system: String? = null,
+    @SerialName("code")
+    val code: String? = null
+)
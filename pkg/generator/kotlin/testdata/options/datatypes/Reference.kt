@@ -0,0 +1,27 @@
+// A reference from one resource to another
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * A reference from one resource to another
+ */
+@Serializable
+data class Reference(
+    @SerialName("reference")
+    val reference: String? = null,
+    @SerialName("type")
+    val type: String? = null,
+    @SerialName("identifier")
+    val identifier: Identifier? = null,
+    @SerialName("display")
+    val display: String? = null
+)
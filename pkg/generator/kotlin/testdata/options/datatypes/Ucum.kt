@@ -0,0 +1,89 @@
+// UCUM unit validation and conversion for Quantity.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+object Ucum {
+    const val SYSTEM = "http://unitsofmeasure.org"
+
+    /** A unit whose value v is v * factor + offset in its dimension's base unit. */
+    private data class UcumUnit(val dimension: String, val factor: Double, val offset: Double)
+
+    private val UNITS = mapOf(
+        "g" to UcumUnit("mass", 1.0, 0.0),
+        "kg" to UcumUnit("mass", 1000.0, 0.0),
+        "mg" to UcumUnit("mass", 0.001, 0.0),
+        "ug" to UcumUnit("mass", 1e-06, 0.0),
+        "ng" to UcumUnit("mass", 1e-09, 0.0),
+        "[lb_av]" to UcumUnit("mass", 453.59237, 0.0),
+        "[oz_av]" to UcumUnit("mass", 28.349523125, 0.0),
+        "m" to UcumUnit("length", 1.0, 0.0),
+        "km" to UcumUnit("length", 1000.0, 0.0),
+        "cm" to UcumUnit("length", 0.01, 0.0),
+        "mm" to UcumUnit("length", 0.001, 0.0),
+        "[in_i]" to UcumUnit("length", 0.0254, 0.0),
+        "[ft_i]" to UcumUnit("length", 0.3048, 0.0),
+        "L" to UcumUnit("volume", 1.0, 0.0),
+        "dL" to UcumUnit("volume", 0.1, 0.0),
+        "mL" to UcumUnit("volume", 0.001, 0.0),
+        "uL" to UcumUnit("volume", 1e-06, 0.0),
+        "s" to UcumUnit("time", 1.0, 0.0),
+        "min" to UcumUnit("time", 60.0, 0.0),
+        "h" to UcumUnit("time", 3600.0, 0.0),
+        "d" to UcumUnit("time", 86400.0, 0.0),
+        "wk" to UcumUnit("time", 604800.0, 0.0),
+        "mo" to UcumUnit("time", 2.6298e+06, 0.0),
+        "a" to UcumUnit("time", 3.15576e+07, 0.0),
+        "K" to UcumUnit("temperature", 1.0, 0.0),
+        "Cel" to UcumUnit("temperature", 1.0, 273.15),
+        "[degF]" to UcumUnit("temperature", 0.5555555555555556, 255.37222222222223),
+        "g/L" to UcumUnit("mass_concentration", 1.0, 0.0),
+        "g/dL" to UcumUnit("mass_concentration", 10.0, 0.0),
+        "mg/dL" to UcumUnit("mass_concentration", 0.01, 0.0),
+        "mg/L" to UcumUnit("mass_concentration", 0.001, 0.0),
+        "ug/mL" to UcumUnit("mass_concentration", 0.001, 0.0),
+        "ng/mL" to UcumUnit("mass_concentration", 1e-06, 0.0),
+        "mol/L" to UcumUnit("substance_concentration", 1.0, 0.0),
+        "mmol/L" to UcumUnit("substance_concentration", 0.001, 0.0),
+        "umol/L" to UcumUnit("substance_concentration", 1e-06, 0.0),
+        "nmol/L" to UcumUnit("substance_concentration", 1e-09, 0.0),
+        "Pa" to UcumUnit("pressure", 1.0, 0.0),
+        "kPa" to UcumUnit("pressure", 1000.0, 0.0),
+        "mm[Hg]" to UcumUnit("pressure", 133.322, 0.0),
+        "Hz" to UcumUnit("frequency", 1.0, 0.0),
+        "/min" to UcumUnit("frequency", 0.016666666666666666, 0.0),
+        "/h" to UcumUnit("frequency", 0.0002777777777777778, 0.0),
+        "1" to UcumUnit("ratio", 1.0, 0.0),
+        "%" to UcumUnit("ratio", 0.01, 0.0)
+    )
+
+    /** Reports whether code is a UCUM unit these helpers can convert. */
+    fun isKnownUnit(code: String): Boolean = code in UNITS
+
+    /** Converts value between two UCUM units of the same dimension. */
+    fun convertValue(value: Double, fromCode: String, toCode: String): Double {
+        val source = unit(fromCode)
+        val target = unit(toCode)
+        require(source.dimension == target.dimension) {
+            "cannot convert $fromCode (${source.dimension}) to $toCode (${target.dimension})"
+        }
+        return (value * source.factor + source.offset - target.offset) / target.factor
+    }
+
+    /** Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit. */
+    fun convert(quantity: Quantity, toCode: String): Quantity {
+        val current = requireNotNull(quantity.value) { "cannot convert a quantity without a value" }
+        val value = convertValue(current.toDouble(), quantity.code ?: quantity.unit ?: "", toCode)
+        return quantity.copy(
+            value = value.toBigDecimal(),
+            unit = toCode,
+            system = SYSTEM,
+            code = toCode
+        )
+    }
+
+    private fun unit(code: String): UcumUnit =
+        UNITS[code] ?: throw IllegalArgumentException("unknown UCUM unit: $code")
+}
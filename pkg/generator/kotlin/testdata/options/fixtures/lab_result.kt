@@ -0,0 +1,56 @@
+// Résultat de laboratoire — température °C, 检验结果
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import datatypes.HumanName
+import datatypes.Quantity
+import registry.Practitioner
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+@Serializable
+data class lab_result(
+    @SerialName("resultId")
+    val resultId: String,
+    @SerialName("mrn")
+    val mrn: String,
+    @SerialName("valueNumeric")
+    val valueNumeric: BigDecimal? = null,
+    @SerialName("chargeAmount")
+    val chargeAmount: BigDecimal? = BigDecimal("0"),
+    @SerialName("valueQuantity")
+    val valueQuantity: Quantity? = null,
+    @SerialName("resultedAt")
+    val resultedAt: OffsetDateTime? = null,
+    @SerialName("performerName")
+    val performerName: HumanName? = null,
+    @SerialName("orderedBy")
+    val orderedBy: Practitioner? = null,
+    @SerialName("rawPayload")
+    val rawPayload: ByteArray? = null,
+    @SerialName("icd10Code")
+    val icd10Code: String? = null,
+    @SerialName("reviewerIds")
+    val reviewerIds: List<String>? = null,
+    @SerialName("ingestedAt")
+    val ingestedAt: OffsetDateTime? = null,
+    @SerialName("sourceSystem")
+    val sourceSystem: String? = null,
+    @SerialName("recordHash")
+    val recordHash: String? = null
+)
@@ -0,0 +1,47 @@
+// A person providing care
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package registry
+
+import datatypes.Extension
+import datatypes.HumanName
+import datatypes.Meta
+import datatypes.Narrative
+import java.math.BigDecimal
+import java.time.LocalDate
+import java.time.OffsetDateTime
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * A person providing care
+ */
+@Serializable
+data class Practitioner(
+    @SerialName("id")
+    val id: String,
+    @SerialName("meta")
+    val meta: Meta? = null,
+    @SerialName("implicitRules")
+    val implicitRules: String? = null,
+    @SerialName("language")
+    val language: String? = null,
+    @SerialName("text")
+    val text: Narrative? = null,
+    @SerialName("extension")
+    val extension: List<Extension>? = null,
+    @SerialName("modifierExtension")
+    val modifierExtension: List<Extension>? = null,
+    @SerialName("name")
+    val name: List<HumanName>? = null,
+    @SerialName("supervisor")
+    val supervisor: Practitioner? = null,
+    @SerialName("ingestedAt")
+    val ingestedAt: OffsetDateTime? = null,
+    @SerialName("sourceSystem")
+    val sourceSystem: String? = null,
+    @SerialName("recordHash")
+    val recordHash: String? = null
+)
@@ -0,0 +1,47 @@
+// Generated by ehrglot v0.1.0.
+
+plugins {
+    kotlin("jvm") version "1.9.24"
+    kotlin("plugin.serialization") version "1.9.24"
+    `maven-publish`
+}
+
+group = "com.acme"
+version = "1.2.0"
+description = "Acme \"clinical\" models"
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    api("org.jetbrains.kotlinx:kotlinx-serialization-json:1.6.3")
+}
+
+kotlin {
+    sourceSets["main"].kotlin {
+        setSrcDirs(listOf("."))
+        exclude("build/**")
+    }
+}
+
+publishing {
+    publications {
+        create<MavenPublication>("maven") {
+            artifactId = "acme-models"
+            from(components["java"])
+        }
+    }
+    repositories {
+        findProperty("ehrglotRepository")?.let { repository ->
+            maven {
+                name = "ehrglot"
+                url = uri(repository)
+                credentials {
+                    username = System.getenv("EHRGLOT_REPO_USERNAME")
+                    password = System.getenv("EHRGLOT_REPO_PASSWORD")
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,28 @@
+// A reference to a code defined by a terminology system
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+@Serializable
+data class Coding(
+    @SerialName("system")
+    val system: String? = null,
+    @SerialName("version")
+    val version: String? = null,
+    @SerialName("code")
+    val code: String? = null,
+    @SerialName("display")
+    val display: String? = null,
+    @SerialName("userSelected")
+    val userSelected: Boolean? = null
+)
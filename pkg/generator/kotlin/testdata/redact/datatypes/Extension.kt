@@ -0,0 +1,40 @@
+// Additional content defined by implementations
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Additional content defined by implementations
+ */
+@Serializable
+data class Extension(
+    @SerialName("url")
+    val url: String,
+    @SerialName("valueString")
+    val valueString: String? = null,
+    @SerialName("valueCode")
+    val valueCode: String? = null,
+    @SerialName("valueBoolean")
+    val valueBoolean: Boolean? = null,
+    @SerialName("valueInteger")
+    val valueInteger: Int? = null,
+    @SerialName("valueDecimal")
+    val valueDecimal: Double? = null,
+    @SerialName("valueDateTime")
+    val valueDateTime: Instant? = null,
+    @SerialName("valueCoding")
+    val valueCoding: Coding? = null,
+    @SerialName("valueCodeableConcept")
+    val valueCodeableConcept: CodeableConcept? = null,
+    @SerialName("valueReference")
+    val valueReference: Reference? = null,
+    @SerialName("valueAddress")
+    val valueAddress: Address? = null
+)
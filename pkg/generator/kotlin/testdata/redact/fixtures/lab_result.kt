@@ -0,0 +1,70 @@
+// Résultat de laboratoire — température °C, 检验结果
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import datatypes.HumanName
+import datatypes.Quantity
+import registry.Practitioner
+import java.time.LocalDate
+import java.time.Instant
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerialName
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+@Serializable
+data class lab_result(
+    @SerialName("resultId")
+    val resultId: String,
+    @SerialName("mrn")
+    val mrn: String,
+    @SerialName("valueNumeric")
+    val valueNumeric: Double? = null,
+    @SerialName("chargeAmount")
+    val chargeAmount: Double? = 0.0,
+    @SerialName("valueQuantity")
+    val valueQuantity: Quantity? = null,
+    @SerialName("resultedAt")
+    val resultedAt: Instant? = null,
+    @SerialName("performerName")
+    val performerName: HumanName? = null,
+    @SerialName("orderedBy")
+    val orderedBy: Practitioner? = null,
+    @SerialName("rawPayload")
+    val rawPayload: ByteArray? = null,
+    @SerialName("icd10Code")
+    val icd10Code: String? = null,
+    @SerialName("reviewerIds")
+    val reviewerIds: List<String>? = null
+) {
+    /**
+     * Returns the properties of this record, with those at pii_level high
+     * or above masked, for logging.
+     */
+    fun redactForLogging(): Map<String, Any?> = mapOf(
+        "resultId" to resultId,
+        "mrn" to "[REDACTED]",
+        "valueNumeric" to valueNumeric,
+        "chargeAmount" to chargeAmount,
+        "valueQuantity" to valueQuantity,
+        "resultedAt" to resultedAt,
+        "performerName" to performerName,
+        "orderedBy" to orderedBy,
+        "rawPayload" to rawPayload,
+        "icd10Code" to icd10Code,
+        "reviewerIds" to reviewerIds,
+    )
+
+    override fun toString(): String =
+        "lab_result(" + redactForLogging().entries.joinToString(", ") { "${it.key}=${it.value}" } + ")"
+}
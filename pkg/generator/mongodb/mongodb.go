@@ -0,0 +1,350 @@
+// Package mongodb generates MongoDB collection validators and the scripts
+// that create collections and their indexes from schemas.
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/ir"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
+	"github.com/konzy/ehrglot/pkg/validate"
+)
+
+const Version = "0.1.0"
+
+// ValidatorsDir is the directory, within the directory of a namespace, that
+// holds the validators of its collections.
+const ValidatorsDir = "validators"
+
+// Generator generates MongoDB validators and setup scripts from schemas.
+type Generator struct {
+	naming    config.Naming
+	audit     bool
+	header    *header.Header
+	templates templates.Cache
+}
+
+// NewGenerator creates a new MongoDB generator.
+func NewGenerator(cfg config.Config) *Generator {
+	return &Generator{naming: cfg.Language("mongodb").Naming, audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+}
+
+// collection is the MongoDB collection that holds the documents of a schema.
+type collection struct {
+	Schema schema.Schema
+	Name   string
+	// Validator is the validator of the collection as a JavaScript object.
+	Validator string
+	Indexes   []index
+}
+
+// index is a createIndex call: its keys and options as JavaScript objects.
+type index struct {
+	Keys    string
+	Options string
+}
+
+// Generate writes, for each namespace, the validator of a collection per
+// schema, whose $jsonSchema checks the required fields, types, enums, and
+// value patterns of its documents, and setup.js, a mongosh script that
+// creates the collections with their validators, or updates those of
+// existing ones, and creates their unique keys and indexes. Documents are
+// stored as JSON is written, with the datatypes and schemas their fields
+// refer to nested in them. Built-in datatypes get no collection.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	model, err := ir.Build(schemas)
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range model.Namespaces {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		namespace := ns.Name
+		var collections []collection
+		for _, s := range ns.Schemas {
+			if s.Builtin {
+				continue
+			}
+			if err := s.Source.CheckKeys(); err != nil {
+				return fmt.Errorf("%s: %w", s.Name, err)
+			}
+			validator := map[string]any{"$jsonSchema": g.jsonSchema(s)}
+			dir := filepath.Join(outputDir, g.NamespaceDir(namespace), ValidatorsDir)
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create validators directory: %w", err)
+			}
+			data, err := marshal(validator)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, g.collectionName(s.Source)+".json"), append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+			collections = append(collections, collection{Schema: s.Source, Name: g.collectionName(s.Source), Validator: string(data), Indexes: g.indexes(s)})
+		}
+		if len(collections) == 0 {
+			continue
+		}
+		if err := g.generateSetup(namespace, collections, filepath.Join(outputDir, g.NamespaceDir(namespace), "setup.js")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonSchema returns the $jsonSchema of the documents of s. Resources carry
+// their resourceType, as FHIR JSON does.
+func (g *Generator) jsonSchema(s *ir.Schema) map[string]any {
+	doc := g.object(s.Source.Description, s.StoredFields(), map[string]bool{s.Namespace + "." + s.Name: true})
+	doc["title"] = s.Name
+	if s.Resource != "" {
+		doc["properties"].(map[string]any)["resourceType"] = map[string]any{"bsonType": "string", "enum": []string{s.Resource}}
+		required, _ := doc["required"].([]string)
+		doc["required"] = append([]string{"resourceType"}, required...)
+	}
+	return doc
+}
+
+// object returns the $jsonSchema of an embedded document of fields. Types on
+// the path from the collection, in seen, are not nested again: a field that
+// refers back to one of them may hold any document.
+func (g *Generator) object(description string, fields []ir.Field, seen map[string]bool) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for _, f := range fields {
+		name := g.fieldName(f.Source)
+		properties[name] = g.field(f, seen)
+		if f.Required {
+			required = append(required, name)
+		}
+	}
+	obj := map[string]any{"bsonType": "object", "properties": properties}
+	if description != "" {
+		obj["description"] = description
+	}
+	if len(required) > 0 {
+		obj["required"] = required
+	}
+	return obj
+}
+
+func (g *Generator) field(f ir.Field, seen map[string]bool) map[string]any {
+	var t map[string]any
+	switch target := f.Type.Schema; {
+	case len(f.Children) > 0:
+		t = g.object("", f.Children, seen)
+	case target != nil && !seen[target.Namespace+"."+target.Name]:
+		key := target.Namespace + "." + target.Name
+		seen[key] = true
+		t = g.object("", target.StoredFields(), seen)
+		delete(seen, key)
+	case target != nil:
+		t = map[string]any{"bsonType": "object"}
+	case f.Source.Encryption != "":
+		// Encrypted fields hold ciphertext, as BSON binary or text.
+		t = map[string]any{"bsonType": []string{"binData", "string"}}
+	default:
+		t = primitive(f.Source, f.Type.Name)
+	}
+	if f.Type.List {
+		t = map[string]any{"bsonType": "array", "items": t}
+	}
+	if f.Source.Description != "" {
+		t["description"] = f.Source.Description
+	}
+	return t
+}
+
+// primitive returns the $jsonSchema of values of primitive fieldType. Dates
+// and times may be BSON dates or, as in FHIR JSON, text; binary data may be
+// BSON binary or base64 text.
+func primitive(f schema.Field, fieldType string) map[string]any {
+	var t map[string]any
+	switch fieldType {
+	case "integer":
+		t = map[string]any{"bsonType": []string{"int", "long"}}
+	case "positiveInt":
+		t = map[string]any{"bsonType": []string{"int", "long"}, "minimum": 1}
+	case "unsignedInt":
+		t = map[string]any{"bsonType": []string{"int", "long"}, "minimum": 0}
+	case "decimal":
+		t = map[string]any{"bsonType": "number"}
+	case "boolean":
+		t = map[string]any{"bsonType": "bool"}
+	case "datetime", "instant":
+		t = map[string]any{"bsonType": []string{"date", "string"}}
+	case "base64Binary":
+		t = map[string]any{"bsonType": []string{"binData", "string"}}
+	default:
+		if !datatypes.IsPrimitive(fieldType) {
+			// Types that resolve to no schema accept any value
+			return map[string]any{}
+		}
+		t = map[string]any{"bsonType": "string"}
+	}
+	if pattern := validate.Pattern(fieldType); pattern != nil {
+		t["pattern"] = pattern.String()
+	}
+	if len(f.Enum) > 0 {
+		t["enum"] = f.Enum
+	}
+	return t
+}
+
+// indexes returns the createIndex calls of the unique keys and indexes of
+// s. Unique keys skip documents that lack an optional field of theirs, as
+// MongoDB would otherwise let only one document lack it.
+func (g *Generator) indexes(s *ir.Schema) []index {
+	fields := make(map[string]schema.Field)
+	for _, f := range s.StoredFields() {
+		fields[f.Name] = f.Source
+	}
+	build := func(ix schema.Index, unique bool) index {
+		var keys, options, partial []string
+		for _, name := range ix.Fields {
+			f := fields[name]
+			keys = append(keys, quote(g.fieldName(f))+": 1")
+			if unique && !f.Required {
+				partial = append(partial, quote(g.fieldName(f))+": { $exists: true }")
+			}
+		}
+		if unique {
+			options = append(options, "unique: true")
+		}
+		if ix.Name != "" {
+			options = append(options, "name: "+quote(ix.Name))
+		}
+		if len(partial) > 0 {
+			options = append(options, "partialFilterExpression: { "+strings.Join(partial, ", ")+" }")
+		}
+		out := index{Keys: "{ " + strings.Join(keys, ", ") + " }"}
+		if len(options) > 0 {
+			out.Options = "{ " + strings.Join(options, ", ") + " }"
+		}
+		return out
+	}
+	var out []index
+	for _, ix := range s.Unique {
+		out = append(out, build(ix, true))
+	}
+	for _, ix := range s.Indexes {
+		out = append(out, build(ix, false))
+	}
+	return out
+}
+
+// generateSetup writes the mongosh script that sets up the collections of a
+// namespace.
+func (g *Generator) generateSetup(namespace string, collections []collection, path string) error {
+	tmpl := `// Creates the MongoDB collections of the {{.Namespace}} namespace, each with
+// the validator of its schema, and their indexes. Rerunning it updates the
+// validators of existing collections. Run it with mongosh:
+//
+//   mongosh "mongodb://localhost:27017/<database>" setup.js
+//
+{{header}}
+const existing = db.getCollectionNames();
+
+function ensureCollection(name, validator) {
+  if (existing.includes(name)) {
+    db.runCommand({ collMod: name, validator: validator });
+  } else {
+    db.createCollection(name, { validator: validator });
+  }
+}
+{{range .Collections}}{{$name := .Name}}
+// {{.Schema.GetName}}{{with .Schema.Description}}: {{.}}{{end}}
+ensureCollection({{quote .Name}}, {{.Validator}});
+{{- range .Indexes}}
+db.getCollection({{quote $name}}).createIndex({{.Keys}}{{with .Options}}, {{.}}{{end}});
+{{- end}}
+{{end}}`
+	var sources []schema.Schema
+	for _, c := range collections {
+		sources = append(sources, c.Schema)
+	}
+	funcMap := template.FuncMap{
+		"quote":  quote,
+		"header": g.header.Func("// ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace   string
+		Collections []collection
+	}{
+		Namespace:   namespace,
+		Collections: collections,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// collectionName returns the name of the collection of s.
+func (g *Generator) collectionName(s schema.Schema) string {
+	return naming.Case(g.naming.Types).Or(naming.Snake.Apply)(s.GetName())
+}
+
+// fieldName returns the name of f in documents: its json_name, or else its
+// name in the configured case.
+func (g *Generator) fieldName(f schema.Field) string {
+	return f.WireName(naming.Case(g.naming.Fields).Apply(f.Name))
+}
+
+// marshal encodes v as indented JSON, leaving characters such as < in
+// descriptions unescaped.
+func marshal(v any) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// quote returns s as a JavaScript string literal.
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
+// GenerateMappings generates nothing: mappings have no collections.
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
+	return nil
+}
@@ -0,0 +1,4305 @@
+// Creates the MongoDB collections of the fixtures namespace, each with
+// the validator of its schema, and their indexes. Rerunning it updates the
+// validators of existing collections. Run it with mongosh:
+//
+//   mongosh "mongodb://localhost:27017/<database>" setup.js
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+const existing = db.getCollectionNames();
+
+function ensureCollection(name, validator) {
+  if (existing.includes(name)) {
+    db.runCommand({ collMod: name, validator: validator });
+  } else {
+    db.createCollection(name, { validator: validator });
+  }
+}
+
+// Visit: An encounter whose field names are reserved words in target languages
+ensureCollection("visit", {
+  "$jsonSchema": {
+    "bsonType": "object",
+    "description": "An encounter whose field names are reserved words in target languages",
+    "properties": {
+      "2ndOpinion": {
+        "bsonType": "bool",
+        "description": "Whether a second opinion was requested"
+      },
+      "attender": {
+        "bsonType": "object",
+        "description": "Practitioner who saw the patient",
+        "properties": {
+          "extension": {
+            "bsonType": "array",
+            "description": "Additional content defined by implementations",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "url": {
+                  "bsonType": "string",
+                  "description": "Identifies the meaning of the extension"
+                },
+                "valueAddress": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "city": {
+                      "bsonType": "string",
+                      "description": "Name of city, town etc."
+                    },
+                    "country": {
+                      "bsonType": "string",
+                      "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                    },
+                    "district": {
+                      "bsonType": "string",
+                      "description": "District name (aka county)"
+                    },
+                    "line": {
+                      "bsonType": "array",
+                      "description": "Street name, number, direction & P.O. Box etc.",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when address was/is in use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "postalCode": {
+                      "bsonType": "string",
+                      "description": "Postal code for area"
+                    },
+                    "state": {
+                      "bsonType": "string",
+                      "description": "Sub-unit of country (abbreviations ok)"
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Text representation of the address"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "postal | physical | both",
+                      "enum": [
+                        "postal",
+                        "physical",
+                        "both"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "home | work | temp | old | billing - purpose of this address",
+                      "enum": [
+                        "home",
+                        "work",
+                        "temp",
+                        "old",
+                        "billing"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    }
+                  }
+                },
+                "valueBoolean": {
+                  "bsonType": "bool",
+                  "description": "Value of extension"
+                },
+                "valueCode": {
+                  "bsonType": "string",
+                  "description": "Value of extension",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "valueCodeableConcept": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "valueCoding": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                },
+                "valueDateTime": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "Value of extension",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                },
+                "valueDecimal": {
+                  "bsonType": "number",
+                  "description": "Value of extension"
+                },
+                "valueInteger": {
+                  "bsonType": [
+                    "int",
+                    "long"
+                  ],
+                  "description": "Value of extension"
+                },
+                "valueReference": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Text alternative for the resource"
+                    },
+                    "identifier": {
+                      "bsonType": "object",
+                      "description": "Logical reference, when literal reference is not known",
+                      "properties": {
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when id is/was valid for use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "The namespace for the identifier value"
+                        },
+                        "type": {
+                          "bsonType": "object",
+                          "description": "Description of identifier",
+                          "properties": {
+                            "coding": {
+                              "bsonType": "array",
+                              "description": "Code defined by a terminology system",
+                              "items": {
+                                "bsonType": "object",
+                                "properties": {
+                                  "code": {
+                                    "bsonType": "string",
+                                    "description": "Symbol in syntax defined by the system",
+                                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                  },
+                                  "display": {
+                                    "bsonType": "string",
+                                    "description": "Representation defined by the system"
+                                  },
+                                  "system": {
+                                    "bsonType": "string",
+                                    "description": "Identity of the terminology system"
+                                  },
+                                  "userSelected": {
+                                    "bsonType": "bool",
+                                    "description": "If this coding was chosen directly by the user"
+                                  },
+                                  "version": {
+                                    "bsonType": "string",
+                                    "description": "Version of the system - if relevant"
+                                  }
+                                }
+                              }
+                            },
+                            "text": {
+                              "bsonType": "string",
+                              "description": "Plain text representation of the concept"
+                            }
+                          }
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "usual | official | temp | secondary | old (If known)",
+                          "enum": [
+                            "usual",
+                            "official",
+                            "temp",
+                            "secondary",
+                            "old"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "value": {
+                          "bsonType": "string",
+                          "description": "The value that is unique"
+                        }
+                      }
+                    },
+                    "reference": {
+                      "bsonType": "string",
+                      "description": "Literal reference, Relative, internal or absolute URL"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "Type the reference refers to (e.g. \"Patient\")"
+                    }
+                  }
+                },
+                "valueString": {
+                  "bsonType": "string",
+                  "description": "Value of extension"
+                }
+              },
+              "required": [
+                "url"
+              ]
+            }
+          },
+          "id": {
+            "bsonType": "string",
+            "description": "Logical id of this artifact",
+            "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+          },
+          "implicitRules": {
+            "bsonType": "string",
+            "description": "A set of rules under which this content was created"
+          },
+          "language": {
+            "bsonType": "string",
+            "description": "Language of the resource content",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "meta": {
+            "bsonType": "object",
+            "description": "Metadata about the resource",
+            "properties": {
+              "lastUpdated": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "When the resource version last changed"
+              },
+              "profile": {
+                "bsonType": "array",
+                "description": "Profiles this resource claims to conform to",
+                "items": {
+                  "bsonType": "string"
+                }
+              },
+              "security": {
+                "bsonType": "array",
+                "description": "Security Labels applied to this resource",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                }
+              },
+              "source": {
+                "bsonType": "string",
+                "description": "Identifies where the resource comes from"
+              },
+              "tag": {
+                "bsonType": "array",
+                "description": "Tags applied to this resource",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                }
+              },
+              "versionId": {
+                "bsonType": "string",
+                "description": "Version specific identifier",
+                "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+              }
+            }
+          },
+          "modifierExtension": {
+            "bsonType": "array",
+            "description": "Extensions that cannot be ignored",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "url": {
+                  "bsonType": "string",
+                  "description": "Identifies the meaning of the extension"
+                },
+                "valueAddress": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "city": {
+                      "bsonType": "string",
+                      "description": "Name of city, town etc."
+                    },
+                    "country": {
+                      "bsonType": "string",
+                      "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                    },
+                    "district": {
+                      "bsonType": "string",
+                      "description": "District name (aka county)"
+                    },
+                    "line": {
+                      "bsonType": "array",
+                      "description": "Street name, number, direction & P.O. Box etc.",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when address was/is in use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "postalCode": {
+                      "bsonType": "string",
+                      "description": "Postal code for area"
+                    },
+                    "state": {
+                      "bsonType": "string",
+                      "description": "Sub-unit of country (abbreviations ok)"
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Text representation of the address"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "postal | physical | both",
+                      "enum": [
+                        "postal",
+                        "physical",
+                        "both"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "home | work | temp | old | billing - purpose of this address",
+                      "enum": [
+                        "home",
+                        "work",
+                        "temp",
+                        "old",
+                        "billing"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    }
+                  }
+                },
+                "valueBoolean": {
+                  "bsonType": "bool",
+                  "description": "Value of extension"
+                },
+                "valueCode": {
+                  "bsonType": "string",
+                  "description": "Value of extension",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "valueCodeableConcept": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "valueCoding": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                },
+                "valueDateTime": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "Value of extension",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                },
+                "valueDecimal": {
+                  "bsonType": "number",
+                  "description": "Value of extension"
+                },
+                "valueInteger": {
+                  "bsonType": [
+                    "int",
+                    "long"
+                  ],
+                  "description": "Value of extension"
+                },
+                "valueReference": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Text alternative for the resource"
+                    },
+                    "identifier": {
+                      "bsonType": "object",
+                      "description": "Logical reference, when literal reference is not known",
+                      "properties": {
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when id is/was valid for use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "The namespace for the identifier value"
+                        },
+                        "type": {
+                          "bsonType": "object",
+                          "description": "Description of identifier",
+                          "properties": {
+                            "coding": {
+                              "bsonType": "array",
+                              "description": "Code defined by a terminology system",
+                              "items": {
+                                "bsonType": "object",
+                                "properties": {
+                                  "code": {
+                                    "bsonType": "string",
+                                    "description": "Symbol in syntax defined by the system",
+                                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                  },
+                                  "display": {
+                                    "bsonType": "string",
+                                    "description": "Representation defined by the system"
+                                  },
+                                  "system": {
+                                    "bsonType": "string",
+                                    "description": "Identity of the terminology system"
+                                  },
+                                  "userSelected": {
+                                    "bsonType": "bool",
+                                    "description": "If this coding was chosen directly by the user"
+                                  },
+                                  "version": {
+                                    "bsonType": "string",
+                                    "description": "Version of the system - if relevant"
+                                  }
+                                }
+                              }
+                            },
+                            "text": {
+                              "bsonType": "string",
+                              "description": "Plain text representation of the concept"
+                            }
+                          }
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "usual | official | temp | secondary | old (If known)",
+                          "enum": [
+                            "usual",
+                            "official",
+                            "temp",
+                            "secondary",
+                            "old"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "value": {
+                          "bsonType": "string",
+                          "description": "The value that is unique"
+                        }
+                      }
+                    },
+                    "reference": {
+                      "bsonType": "string",
+                      "description": "Literal reference, Relative, internal or absolute URL"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "Type the reference refers to (e.g. \"Patient\")"
+                    }
+                  }
+                },
+                "valueString": {
+                  "bsonType": "string",
+                  "description": "Value of extension"
+                }
+              },
+              "required": [
+                "url"
+              ]
+            }
+          },
+          "name": {
+            "bsonType": "array",
+            "description": "The name(s) associated with the practitioner",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "family": {
+                  "bsonType": "string",
+                  "description": "Family name (often called 'Surname')"
+                },
+                "given": {
+                  "bsonType": "array",
+                  "description": "Given names (not always 'first'). Includes middle names",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when name was/is in use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "prefix": {
+                  "bsonType": "array",
+                  "description": "Parts that come before the name",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "suffix": {
+                  "bsonType": "array",
+                  "description": "Parts that come after the name",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Text representation of the full name"
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "usual | official | temp | nickname | anonymous | old | maiden",
+                  "enum": [
+                    "usual",
+                    "official",
+                    "temp",
+                    "nickname",
+                    "anonymous",
+                    "old",
+                    "maiden"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                }
+              }
+            }
+          },
+          "supervisor": {
+            "bsonType": "object",
+            "description": "Practitioner responsible for this one"
+          },
+          "text": {
+            "bsonType": "object",
+            "description": "Text summary of the resource, for human interpretation",
+            "properties": {
+              "div": {
+                "bsonType": "string",
+                "description": "Limited xhtml content"
+              },
+              "status": {
+                "bsonType": "string",
+                "description": "generated | extensions | additional | empty",
+                "enum": [
+                  "generated",
+                  "extensions",
+                  "additional",
+                  "empty"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              }
+            },
+            "required": [
+              "status",
+              "div"
+            ]
+          }
+        },
+        "required": [
+          "id"
+        ]
+      },
+      "class": {
+        "bsonType": "object",
+        "description": "Classification of the visit",
+        "properties": {
+          "code": {
+            "bsonType": "string",
+            "description": "Symbol in syntax defined by the system",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "display": {
+            "bsonType": "string",
+            "description": "Representation defined by the system"
+          },
+          "system": {
+            "bsonType": "string",
+            "description": "Identity of the terminology system"
+          },
+          "userSelected": {
+            "bsonType": "bool",
+            "description": "If this coding was chosen directly by the user"
+          },
+          "version": {
+            "bsonType": "string",
+            "description": "Version of the system - if relevant"
+          }
+        }
+      },
+      "for": {
+        "bsonType": "object",
+        "description": "Who the visit is for",
+        "properties": {
+          "display": {
+            "bsonType": "string",
+            "description": "Text alternative for the resource"
+          },
+          "identifier": {
+            "bsonType": "object",
+            "description": "Logical reference, when literal reference is not known",
+            "properties": {
+              "period": {
+                "bsonType": "object",
+                "description": "Time period when id is/was valid for use",
+                "properties": {
+                  "end": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "End time with inclusive boundary, if not ongoing",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  },
+                  "start": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "Starting time with inclusive boundary",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  }
+                }
+              },
+              "system": {
+                "bsonType": "string",
+                "description": "The namespace for the identifier value"
+              },
+              "type": {
+                "bsonType": "object",
+                "description": "Description of identifier",
+                "properties": {
+                  "coding": {
+                    "bsonType": "array",
+                    "description": "Code defined by a terminology system",
+                    "items": {
+                      "bsonType": "object",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    }
+                  },
+                  "text": {
+                    "bsonType": "string",
+                    "description": "Plain text representation of the concept"
+                  }
+                }
+              },
+              "use": {
+                "bsonType": "string",
+                "description": "usual | official | temp | secondary | old (If known)",
+                "enum": [
+                  "usual",
+                  "official",
+                  "temp",
+                  "secondary",
+                  "old"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "value": {
+                "bsonType": "string",
+                "description": "The value that is unique"
+              }
+            }
+          },
+          "reference": {
+            "bsonType": "string",
+            "description": "Literal reference, Relative, internal or absolute URL"
+          },
+          "type": {
+            "bsonType": "string",
+            "description": "Type the reference refers to (e.g. \"Patient\")"
+          }
+        }
+      },
+      "length": {
+        "bsonType": "object",
+        "description": "Time the visit lasted",
+        "properties": {
+          "code": {
+            "bsonType": "string",
+            "description": "Coded form of the unit",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "comparator": {
+            "bsonType": "string",
+            "description": "< | <= | >= | > - how to understand the value",
+            "enum": [
+              "<",
+              "<=",
+              ">=",
+              ">"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "system": {
+            "bsonType": "string",
+            "description": "System that defines coded unit form"
+          },
+          "unit": {
+            "bsonType": "string",
+            "description": "Unit representation"
+          },
+          "value": {
+            "bsonType": "number",
+            "description": "Numerical value (with implicit precision)"
+          }
+        }
+      },
+      "match": {
+        "bsonType": "string",
+        "description": "Record linkage match grade"
+      },
+      "order": {
+        "bsonType": [
+          "int",
+          "long"
+        ],
+        "description": "Sequence within the episode"
+      },
+      "priorityCode": {
+        "bsonType": "string",
+        "description": "Priority as a code",
+        "pattern": "^[^\\s]+( [^\\s]+)*$"
+      },
+      "priority_code": {
+        "bsonType": "string",
+        "description": "Priority as legacy text"
+      },
+      "resourceType": {
+        "bsonType": "string",
+        "enum": [
+          "Visit"
+        ]
+      },
+      "self": {
+        "bsonType": "string",
+        "description": "Canonical URL of the visit"
+      },
+      "specimens": {
+        "bsonType": "array",
+        "description": "Specimens collected during the visit",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "accessionIdentifier": {
+              "bsonType": "object",
+              "description": "Identifier assigned by the lab",
+              "properties": {
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when id is/was valid for use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "The namespace for the identifier value"
+                },
+                "type": {
+                  "bsonType": "object",
+                  "description": "Description of identifier",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "usual | official | temp | secondary | old (If known)",
+                  "enum": [
+                    "usual",
+                    "official",
+                    "temp",
+                    "secondary",
+                    "old"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "value": {
+                  "bsonType": "string",
+                  "description": "The value that is unique"
+                }
+              }
+            },
+            "collectedDate": {
+              "bsonType": "string",
+              "description": "Collection date",
+              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01]))?)?$"
+            },
+            "collection": {
+              "bsonType": "object",
+              "description": "Collection details",
+              "properties": {
+                "collector": {
+                  "bsonType": "object",
+                  "description": "Who collected the specimen",
+                  "properties": {
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Text alternative for the resource"
+                    },
+                    "identifier": {
+                      "bsonType": "object",
+                      "description": "Logical reference, when literal reference is not known",
+                      "properties": {
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when id is/was valid for use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "The namespace for the identifier value"
+                        },
+                        "type": {
+                          "bsonType": "object",
+                          "description": "Description of identifier",
+                          "properties": {
+                            "coding": {
+                              "bsonType": "array",
+                              "description": "Code defined by a terminology system",
+                              "items": {
+                                "bsonType": "object",
+                                "properties": {
+                                  "code": {
+                                    "bsonType": "string",
+                                    "description": "Symbol in syntax defined by the system",
+                                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                  },
+                                  "display": {
+                                    "bsonType": "string",
+                                    "description": "Representation defined by the system"
+                                  },
+                                  "system": {
+                                    "bsonType": "string",
+                                    "description": "Identity of the terminology system"
+                                  },
+                                  "userSelected": {
+                                    "bsonType": "bool",
+                                    "description": "If this coding was chosen directly by the user"
+                                  },
+                                  "version": {
+                                    "bsonType": "string",
+                                    "description": "Version of the system - if relevant"
+                                  }
+                                }
+                              }
+                            },
+                            "text": {
+                              "bsonType": "string",
+                              "description": "Plain text representation of the concept"
+                            }
+                          }
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "usual | official | temp | secondary | old (If known)",
+                          "enum": [
+                            "usual",
+                            "official",
+                            "temp",
+                            "secondary",
+                            "old"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "value": {
+                          "bsonType": "string",
+                          "description": "The value that is unique"
+                        }
+                      }
+                    },
+                    "reference": {
+                      "bsonType": "string",
+                      "description": "Literal reference, Relative, internal or absolute URL"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "Type the reference refers to (e.g. \"Patient\")"
+                    }
+                  }
+                },
+                "method": {
+                  "bsonType": "string",
+                  "description": "Technique used",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                }
+              }
+            },
+            "containerCount": {
+              "bsonType": [
+                "int",
+                "long"
+              ],
+              "description": "Number of containers"
+            },
+            "fasting": {
+              "bsonType": "bool",
+              "description": "Whether the patient was fasting"
+            },
+            "id": {
+              "bsonType": "string",
+              "description": "Logical id of this artifact",
+              "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+            },
+            "note": {
+              "bsonType": "array",
+              "description": "Comments",
+              "items": {
+                "bsonType": "string"
+              }
+            },
+            "quantity": {
+              "bsonType": "number",
+              "description": "Amount collected"
+            },
+            "receivedTime": {
+              "bsonType": [
+                "date",
+                "string"
+              ],
+              "description": "When the specimen was received",
+              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+            },
+            "request": {
+              "bsonType": "array",
+              "description": "Why the specimen was collected",
+              "items": {
+                "bsonType": "object",
+                "properties": {
+                  "display": {
+                    "bsonType": "string",
+                    "description": "Text alternative for the resource"
+                  },
+                  "identifier": {
+                    "bsonType": "object",
+                    "description": "Logical reference, when literal reference is not known",
+                    "properties": {
+                      "period": {
+                        "bsonType": "object",
+                        "description": "Time period when id is/was valid for use",
+                        "properties": {
+                          "end": {
+                            "bsonType": [
+                              "date",
+                              "string"
+                            ],
+                            "description": "End time with inclusive boundary, if not ongoing",
+                            "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                          },
+                          "start": {
+                            "bsonType": [
+                              "date",
+                              "string"
+                            ],
+                            "description": "Starting time with inclusive boundary",
+                            "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                          }
+                        }
+                      },
+                      "system": {
+                        "bsonType": "string",
+                        "description": "The namespace for the identifier value"
+                      },
+                      "type": {
+                        "bsonType": "object",
+                        "description": "Description of identifier",
+                        "properties": {
+                          "coding": {
+                            "bsonType": "array",
+                            "description": "Code defined by a terminology system",
+                            "items": {
+                              "bsonType": "object",
+                              "properties": {
+                                "code": {
+                                  "bsonType": "string",
+                                  "description": "Symbol in syntax defined by the system",
+                                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                },
+                                "display": {
+                                  "bsonType": "string",
+                                  "description": "Representation defined by the system"
+                                },
+                                "system": {
+                                  "bsonType": "string",
+                                  "description": "Identity of the terminology system"
+                                },
+                                "userSelected": {
+                                  "bsonType": "bool",
+                                  "description": "If this coding was chosen directly by the user"
+                                },
+                                "version": {
+                                  "bsonType": "string",
+                                  "description": "Version of the system - if relevant"
+                                }
+                              }
+                            }
+                          },
+                          "text": {
+                            "bsonType": "string",
+                            "description": "Plain text representation of the concept"
+                          }
+                        }
+                      },
+                      "use": {
+                        "bsonType": "string",
+                        "description": "usual | official | temp | secondary | old (If known)",
+                        "enum": [
+                          "usual",
+                          "official",
+                          "temp",
+                          "secondary",
+                          "old"
+                        ],
+                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                      },
+                      "value": {
+                        "bsonType": "string",
+                        "description": "The value that is unique"
+                      }
+                    }
+                  },
+                  "reference": {
+                    "bsonType": "string",
+                    "description": "Literal reference, Relative, internal or absolute URL"
+                  },
+                  "type": {
+                    "bsonType": "string",
+                    "description": "Type the reference refers to (e.g. \"Patient\")"
+                  }
+                }
+              }
+            },
+            "status": {
+              "bsonType": "string",
+              "description": "available | unavailable | entered-in-error",
+              "enum": [
+                "available",
+                "unavailable",
+                "entered-in-error"
+              ],
+              "pattern": "^[^\\s]+( [^\\s]+)*$"
+            },
+            "subject": {
+              "bsonType": "object",
+              "description": "Where the specimen came from",
+              "properties": {
+                "display": {
+                  "bsonType": "string",
+                  "description": "Text alternative for the resource"
+                },
+                "identifier": {
+                  "bsonType": "object",
+                  "description": "Logical reference, when literal reference is not known",
+                  "properties": {
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when id is/was valid for use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "The namespace for the identifier value"
+                    },
+                    "type": {
+                      "bsonType": "object",
+                      "description": "Description of identifier",
+                      "properties": {
+                        "coding": {
+                          "bsonType": "array",
+                          "description": "Code defined by a terminology system",
+                          "items": {
+                            "bsonType": "object",
+                            "properties": {
+                              "code": {
+                                "bsonType": "string",
+                                "description": "Symbol in syntax defined by the system",
+                                "pattern": "^[^\\s]+( [^\\s]+)*$"
+                              },
+                              "display": {
+                                "bsonType": "string",
+                                "description": "Representation defined by the system"
+                              },
+                              "system": {
+                                "bsonType": "string",
+                                "description": "Identity of the terminology system"
+                              },
+                              "userSelected": {
+                                "bsonType": "bool",
+                                "description": "If this coding was chosen directly by the user"
+                              },
+                              "version": {
+                                "bsonType": "string",
+                                "description": "Version of the system - if relevant"
+                              }
+                            }
+                          }
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Plain text representation of the concept"
+                        }
+                      }
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "usual | official | temp | secondary | old (If known)",
+                      "enum": [
+                        "usual",
+                        "official",
+                        "temp",
+                        "secondary",
+                        "old"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "value": {
+                      "bsonType": "string",
+                      "description": "The value that is unique"
+                    }
+                  }
+                },
+                "reference": {
+                  "bsonType": "string",
+                  "description": "Literal reference, Relative, internal or absolute URL"
+                },
+                "type": {
+                  "bsonType": "string",
+                  "description": "Type the reference refers to (e.g. \"Patient\")"
+                }
+              }
+            },
+            "visit": {
+              "bsonType": "object",
+              "description": "Visit during which the specimen was collected"
+            }
+          },
+          "required": [
+            "id",
+            "status"
+          ]
+        }
+      },
+      "type": {
+        "bsonType": "array",
+        "description": "Specific type of visit",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "coding": {
+              "bsonType": "array",
+              "description": "Code defined by a terminology system",
+              "items": {
+                "bsonType": "object",
+                "properties": {
+                  "code": {
+                    "bsonType": "string",
+                    "description": "Symbol in syntax defined by the system",
+                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                  },
+                  "display": {
+                    "bsonType": "string",
+                    "description": "Representation defined by the system"
+                  },
+                  "system": {
+                    "bsonType": "string",
+                    "description": "Identity of the terminology system"
+                  },
+                  "userSelected": {
+                    "bsonType": "bool",
+                    "description": "If this coding was chosen directly by the user"
+                  },
+                  "version": {
+                    "bsonType": "string",
+                    "description": "Version of the system - if relevant"
+                  }
+                }
+              }
+            },
+            "text": {
+              "bsonType": "string",
+              "description": "Plain text representation of the concept"
+            }
+          }
+        }
+      },
+      "visit": {
+        "bsonType": "string",
+        "description": "Visit number"
+      },
+      "where": {
+        "bsonType": "object",
+        "description": "Where the visit took place",
+        "properties": {
+          "city": {
+            "bsonType": "string",
+            "description": "Name of city, town etc."
+          },
+          "country": {
+            "bsonType": "string",
+            "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+          },
+          "district": {
+            "bsonType": "string",
+            "description": "District name (aka county)"
+          },
+          "line": {
+            "bsonType": "array",
+            "description": "Street name, number, direction & P.O. Box etc.",
+            "items": {
+              "bsonType": "string"
+            }
+          },
+          "period": {
+            "bsonType": "object",
+            "description": "Time period when address was/is in use",
+            "properties": {
+              "end": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "End time with inclusive boundary, if not ongoing",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              },
+              "start": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "Starting time with inclusive boundary",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              }
+            }
+          },
+          "postalCode": {
+            "bsonType": "string",
+            "description": "Postal code for area"
+          },
+          "state": {
+            "bsonType": "string",
+            "description": "Sub-unit of country (abbreviations ok)"
+          },
+          "text": {
+            "bsonType": "string",
+            "description": "Text representation of the address"
+          },
+          "type": {
+            "bsonType": "string",
+            "description": "postal | physical | both",
+            "enum": [
+              "postal",
+              "physical",
+              "both"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "use": {
+            "bsonType": "string",
+            "description": "home | work | temp | old | billing - purpose of this address",
+            "enum": [
+              "home",
+              "work",
+              "temp",
+              "old",
+              "billing"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          }
+        }
+      }
+    },
+    "required": [
+      "resourceType",
+      "class"
+    ],
+    "title": "Visit"
+  }
+});
+
+// Specimen: A sample collected for analysis
+ensureCollection("specimen", {
+  "$jsonSchema": {
+    "bsonType": "object",
+    "description": "A sample collected for analysis",
+    "properties": {
+      "accessionIdentifier": {
+        "bsonType": "object",
+        "description": "Identifier assigned by the lab",
+        "properties": {
+          "period": {
+            "bsonType": "object",
+            "description": "Time period when id is/was valid for use",
+            "properties": {
+              "end": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "End time with inclusive boundary, if not ongoing",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              },
+              "start": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "Starting time with inclusive boundary",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              }
+            }
+          },
+          "system": {
+            "bsonType": "string",
+            "description": "The namespace for the identifier value"
+          },
+          "type": {
+            "bsonType": "object",
+            "description": "Description of identifier",
+            "properties": {
+              "coding": {
+                "bsonType": "array",
+                "description": "Code defined by a terminology system",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                }
+              },
+              "text": {
+                "bsonType": "string",
+                "description": "Plain text representation of the concept"
+              }
+            }
+          },
+          "use": {
+            "bsonType": "string",
+            "description": "usual | official | temp | secondary | old (If known)",
+            "enum": [
+              "usual",
+              "official",
+              "temp",
+              "secondary",
+              "old"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "value": {
+            "bsonType": "string",
+            "description": "The value that is unique"
+          }
+        }
+      },
+      "collectedDate": {
+        "bsonType": "string",
+        "description": "Collection date",
+        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01]))?)?$"
+      },
+      "collection": {
+        "bsonType": "object",
+        "description": "Collection details",
+        "properties": {
+          "collector": {
+            "bsonType": "object",
+            "description": "Who collected the specimen",
+            "properties": {
+              "display": {
+                "bsonType": "string",
+                "description": "Text alternative for the resource"
+              },
+              "identifier": {
+                "bsonType": "object",
+                "description": "Logical reference, when literal reference is not known",
+                "properties": {
+                  "period": {
+                    "bsonType": "object",
+                    "description": "Time period when id is/was valid for use",
+                    "properties": {
+                      "end": {
+                        "bsonType": [
+                          "date",
+                          "string"
+                        ],
+                        "description": "End time with inclusive boundary, if not ongoing",
+                        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                      },
+                      "start": {
+                        "bsonType": [
+                          "date",
+                          "string"
+                        ],
+                        "description": "Starting time with inclusive boundary",
+                        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                      }
+                    }
+                  },
+                  "system": {
+                    "bsonType": "string",
+                    "description": "The namespace for the identifier value"
+                  },
+                  "type": {
+                    "bsonType": "object",
+                    "description": "Description of identifier",
+                    "properties": {
+                      "coding": {
+                        "bsonType": "array",
+                        "description": "Code defined by a terminology system",
+                        "items": {
+                          "bsonType": "object",
+                          "properties": {
+                            "code": {
+                              "bsonType": "string",
+                              "description": "Symbol in syntax defined by the system",
+                              "pattern": "^[^\\s]+( [^\\s]+)*$"
+                            },
+                            "display": {
+                              "bsonType": "string",
+                              "description": "Representation defined by the system"
+                            },
+                            "system": {
+                              "bsonType": "string",
+                              "description": "Identity of the terminology system"
+                            },
+                            "userSelected": {
+                              "bsonType": "bool",
+                              "description": "If this coding was chosen directly by the user"
+                            },
+                            "version": {
+                              "bsonType": "string",
+                              "description": "Version of the system - if relevant"
+                            }
+                          }
+                        }
+                      },
+                      "text": {
+                        "bsonType": "string",
+                        "description": "Plain text representation of the concept"
+                      }
+                    }
+                  },
+                  "use": {
+                    "bsonType": "string",
+                    "description": "usual | official | temp | secondary | old (If known)",
+                    "enum": [
+                      "usual",
+                      "official",
+                      "temp",
+                      "secondary",
+                      "old"
+                    ],
+                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                  },
+                  "value": {
+                    "bsonType": "string",
+                    "description": "The value that is unique"
+                  }
+                }
+              },
+              "reference": {
+                "bsonType": "string",
+                "description": "Literal reference, Relative, internal or absolute URL"
+              },
+              "type": {
+                "bsonType": "string",
+                "description": "Type the reference refers to (e.g. \"Patient\")"
+              }
+            }
+          },
+          "method": {
+            "bsonType": "string",
+            "description": "Technique used",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          }
+        }
+      },
+      "containerCount": {
+        "bsonType": [
+          "int",
+          "long"
+        ],
+        "description": "Number of containers"
+      },
+      "fasting": {
+        "bsonType": "bool",
+        "description": "Whether the patient was fasting"
+      },
+      "id": {
+        "bsonType": "string",
+        "description": "Logical id of this artifact",
+        "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+      },
+      "note": {
+        "bsonType": "array",
+        "description": "Comments",
+        "items": {
+          "bsonType": "string"
+        }
+      },
+      "quantity": {
+        "bsonType": "number",
+        "description": "Amount collected"
+      },
+      "receivedTime": {
+        "bsonType": [
+          "date",
+          "string"
+        ],
+        "description": "When the specimen was received",
+        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+      },
+      "request": {
+        "bsonType": "array",
+        "description": "Why the specimen was collected",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "display": {
+              "bsonType": "string",
+              "description": "Text alternative for the resource"
+            },
+            "identifier": {
+              "bsonType": "object",
+              "description": "Logical reference, when literal reference is not known",
+              "properties": {
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when id is/was valid for use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "The namespace for the identifier value"
+                },
+                "type": {
+                  "bsonType": "object",
+                  "description": "Description of identifier",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "usual | official | temp | secondary | old (If known)",
+                  "enum": [
+                    "usual",
+                    "official",
+                    "temp",
+                    "secondary",
+                    "old"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "value": {
+                  "bsonType": "string",
+                  "description": "The value that is unique"
+                }
+              }
+            },
+            "reference": {
+              "bsonType": "string",
+              "description": "Literal reference, Relative, internal or absolute URL"
+            },
+            "type": {
+              "bsonType": "string",
+              "description": "Type the reference refers to (e.g. \"Patient\")"
+            }
+          }
+        }
+      },
+      "resourceType": {
+        "bsonType": "string",
+        "enum": [
+          "Specimen"
+        ]
+      },
+      "status": {
+        "bsonType": "string",
+        "description": "available | unavailable | entered-in-error",
+        "enum": [
+          "available",
+          "unavailable",
+          "entered-in-error"
+        ],
+        "pattern": "^[^\\s]+( [^\\s]+)*$"
+      },
+      "subject": {
+        "bsonType": "object",
+        "description": "Where the specimen came from",
+        "properties": {
+          "display": {
+            "bsonType": "string",
+            "description": "Text alternative for the resource"
+          },
+          "identifier": {
+            "bsonType": "object",
+            "description": "Logical reference, when literal reference is not known",
+            "properties": {
+              "period": {
+                "bsonType": "object",
+                "description": "Time period when id is/was valid for use",
+                "properties": {
+                  "end": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "End time with inclusive boundary, if not ongoing",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  },
+                  "start": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "Starting time with inclusive boundary",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  }
+                }
+              },
+              "system": {
+                "bsonType": "string",
+                "description": "The namespace for the identifier value"
+              },
+              "type": {
+                "bsonType": "object",
+                "description": "Description of identifier",
+                "properties": {
+                  "coding": {
+                    "bsonType": "array",
+                    "description": "Code defined by a terminology system",
+                    "items": {
+                      "bsonType": "object",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    }
+                  },
+                  "text": {
+                    "bsonType": "string",
+                    "description": "Plain text representation of the concept"
+                  }
+                }
+              },
+              "use": {
+                "bsonType": "string",
+                "description": "usual | official | temp | secondary | old (If known)",
+                "enum": [
+                  "usual",
+                  "official",
+                  "temp",
+                  "secondary",
+                  "old"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "value": {
+                "bsonType": "string",
+                "description": "The value that is unique"
+              }
+            }
+          },
+          "reference": {
+            "bsonType": "string",
+            "description": "Literal reference, Relative, internal or absolute URL"
+          },
+          "type": {
+            "bsonType": "string",
+            "description": "Type the reference refers to (e.g. \"Patient\")"
+          }
+        }
+      },
+      "visit": {
+        "bsonType": "object",
+        "description": "Visit during which the specimen was collected",
+        "properties": {
+          "2ndOpinion": {
+            "bsonType": "bool",
+            "description": "Whether a second opinion was requested"
+          },
+          "attender": {
+            "bsonType": "object",
+            "description": "Practitioner who saw the patient",
+            "properties": {
+              "extension": {
+                "bsonType": "array",
+                "description": "Additional content defined by implementations",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "url": {
+                      "bsonType": "string",
+                      "description": "Identifies the meaning of the extension"
+                    },
+                    "valueAddress": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "city": {
+                          "bsonType": "string",
+                          "description": "Name of city, town etc."
+                        },
+                        "country": {
+                          "bsonType": "string",
+                          "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                        },
+                        "district": {
+                          "bsonType": "string",
+                          "description": "District name (aka county)"
+                        },
+                        "line": {
+                          "bsonType": "array",
+                          "description": "Street name, number, direction & P.O. Box etc.",
+                          "items": {
+                            "bsonType": "string"
+                          }
+                        },
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when address was/is in use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "postalCode": {
+                          "bsonType": "string",
+                          "description": "Postal code for area"
+                        },
+                        "state": {
+                          "bsonType": "string",
+                          "description": "Sub-unit of country (abbreviations ok)"
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Text representation of the address"
+                        },
+                        "type": {
+                          "bsonType": "string",
+                          "description": "postal | physical | both",
+                          "enum": [
+                            "postal",
+                            "physical",
+                            "both"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "home | work | temp | old | billing - purpose of this address",
+                          "enum": [
+                            "home",
+                            "work",
+                            "temp",
+                            "old",
+                            "billing"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        }
+                      }
+                    },
+                    "valueBoolean": {
+                      "bsonType": "bool",
+                      "description": "Value of extension"
+                    },
+                    "valueCode": {
+                      "bsonType": "string",
+                      "description": "Value of extension",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "valueCodeableConcept": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "coding": {
+                          "bsonType": "array",
+                          "description": "Code defined by a terminology system",
+                          "items": {
+                            "bsonType": "object",
+                            "properties": {
+                              "code": {
+                                "bsonType": "string",
+                                "description": "Symbol in syntax defined by the system",
+                                "pattern": "^[^\\s]+( [^\\s]+)*$"
+                              },
+                              "display": {
+                                "bsonType": "string",
+                                "description": "Representation defined by the system"
+                              },
+                              "system": {
+                                "bsonType": "string",
+                                "description": "Identity of the terminology system"
+                              },
+                              "userSelected": {
+                                "bsonType": "bool",
+                                "description": "If this coding was chosen directly by the user"
+                              },
+                              "version": {
+                                "bsonType": "string",
+                                "description": "Version of the system - if relevant"
+                              }
+                            }
+                          }
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Plain text representation of the concept"
+                        }
+                      }
+                    },
+                    "valueCoding": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    },
+                    "valueDateTime": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Value of extension",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "valueDecimal": {
+                      "bsonType": "number",
+                      "description": "Value of extension"
+                    },
+                    "valueInteger": {
+                      "bsonType": [
+                        "int",
+                        "long"
+                      ],
+                      "description": "Value of extension"
+                    },
+                    "valueReference": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Text alternative for the resource"
+                        },
+                        "identifier": {
+                          "bsonType": "object",
+                          "description": "Logical reference, when literal reference is not known",
+                          "properties": {
+                            "period": {
+                              "bsonType": "object",
+                              "description": "Time period when id is/was valid for use",
+                              "properties": {
+                                "end": {
+                                  "bsonType": [
+                                    "date",
+                                    "string"
+                                  ],
+                                  "description": "End time with inclusive boundary, if not ongoing",
+                                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                                },
+                                "start": {
+                                  "bsonType": [
+                                    "date",
+                                    "string"
+                                  ],
+                                  "description": "Starting time with inclusive boundary",
+                                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                                }
+                              }
+                            },
+                            "system": {
+                              "bsonType": "string",
+                              "description": "The namespace for the identifier value"
+                            },
+                            "type": {
+                              "bsonType": "object",
+                              "description": "Description of identifier",
+                              "properties": {
+                                "coding": {
+                                  "bsonType": "array",
+                                  "description": "Code defined by a terminology system",
+                                  "items": {
+                                    "bsonType": "object",
+                                    "properties": {
+                                      "code": {
+                                        "bsonType": "string",
+                                        "description": "Symbol in syntax defined by the system",
+                                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                      },
+                                      "display": {
+                                        "bsonType": "string",
+                                        "description": "Representation defined by the system"
+                                      },
+                                      "system": {
+                                        "bsonType": "string",
+                                        "description": "Identity of the terminology system"
+                                      },
+                                      "userSelected": {
+                                        "bsonType": "bool",
+                                        "description": "If this coding was chosen directly by the user"
+                                      },
+                                      "version": {
+                                        "bsonType": "string",
+                                        "description": "Version of the system - if relevant"
+                                      }
+                                    }
+                                  }
+                                },
+                                "text": {
+                                  "bsonType": "string",
+                                  "description": "Plain text representation of the concept"
+                                }
+                              }
+                            },
+                            "use": {
+                              "bsonType": "string",
+                              "description": "usual | official | temp | secondary | old (If known)",
+                              "enum": [
+                                "usual",
+                                "official",
+                                "temp",
+                                "secondary",
+                                "old"
+                              ],
+                              "pattern": "^[^\\s]+( [^\\s]+)*$"
+                            },
+                            "value": {
+                              "bsonType": "string",
+                              "description": "The value that is unique"
+                            }
+                          }
+                        },
+                        "reference": {
+                          "bsonType": "string",
+                          "description": "Literal reference, Relative, internal or absolute URL"
+                        },
+                        "type": {
+                          "bsonType": "string",
+                          "description": "Type the reference refers to (e.g. \"Patient\")"
+                        }
+                      }
+                    },
+                    "valueString": {
+                      "bsonType": "string",
+                      "description": "Value of extension"
+                    }
+                  },
+                  "required": [
+                    "url"
+                  ]
+                }
+              },
+              "id": {
+                "bsonType": "string",
+                "description": "Logical id of this artifact",
+                "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+              },
+              "implicitRules": {
+                "bsonType": "string",
+                "description": "A set of rules under which this content was created"
+              },
+              "language": {
+                "bsonType": "string",
+                "description": "Language of the resource content",
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "meta": {
+                "bsonType": "object",
+                "description": "Metadata about the resource",
+                "properties": {
+                  "lastUpdated": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "When the resource version last changed"
+                  },
+                  "profile": {
+                    "bsonType": "array",
+                    "description": "Profiles this resource claims to conform to",
+                    "items": {
+                      "bsonType": "string"
+                    }
+                  },
+                  "security": {
+                    "bsonType": "array",
+                    "description": "Security Labels applied to this resource",
+                    "items": {
+                      "bsonType": "object",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    }
+                  },
+                  "source": {
+                    "bsonType": "string",
+                    "description": "Identifies where the resource comes from"
+                  },
+                  "tag": {
+                    "bsonType": "array",
+                    "description": "Tags applied to this resource",
+                    "items": {
+                      "bsonType": "object",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    }
+                  },
+                  "versionId": {
+                    "bsonType": "string",
+                    "description": "Version specific identifier",
+                    "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+                  }
+                }
+              },
+              "modifierExtension": {
+                "bsonType": "array",
+                "description": "Extensions that cannot be ignored",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "url": {
+                      "bsonType": "string",
+                      "description": "Identifies the meaning of the extension"
+                    },
+                    "valueAddress": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "city": {
+                          "bsonType": "string",
+                          "description": "Name of city, town etc."
+                        },
+                        "country": {
+                          "bsonType": "string",
+                          "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                        },
+                        "district": {
+                          "bsonType": "string",
+                          "description": "District name (aka county)"
+                        },
+                        "line": {
+                          "bsonType": "array",
+                          "description": "Street name, number, direction & P.O. Box etc.",
+                          "items": {
+                            "bsonType": "string"
+                          }
+                        },
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when address was/is in use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "postalCode": {
+                          "bsonType": "string",
+                          "description": "Postal code for area"
+                        },
+                        "state": {
+                          "bsonType": "string",
+                          "description": "Sub-unit of country (abbreviations ok)"
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Text representation of the address"
+                        },
+                        "type": {
+                          "bsonType": "string",
+                          "description": "postal | physical | both",
+                          "enum": [
+                            "postal",
+                            "physical",
+                            "both"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "home | work | temp | old | billing - purpose of this address",
+                          "enum": [
+                            "home",
+                            "work",
+                            "temp",
+                            "old",
+                            "billing"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        }
+                      }
+                    },
+                    "valueBoolean": {
+                      "bsonType": "bool",
+                      "description": "Value of extension"
+                    },
+                    "valueCode": {
+                      "bsonType": "string",
+                      "description": "Value of extension",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "valueCodeableConcept": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "coding": {
+                          "bsonType": "array",
+                          "description": "Code defined by a terminology system",
+                          "items": {
+                            "bsonType": "object",
+                            "properties": {
+                              "code": {
+                                "bsonType": "string",
+                                "description": "Symbol in syntax defined by the system",
+                                "pattern": "^[^\\s]+( [^\\s]+)*$"
+                              },
+                              "display": {
+                                "bsonType": "string",
+                                "description": "Representation defined by the system"
+                              },
+                              "system": {
+                                "bsonType": "string",
+                                "description": "Identity of the terminology system"
+                              },
+                              "userSelected": {
+                                "bsonType": "bool",
+                                "description": "If this coding was chosen directly by the user"
+                              },
+                              "version": {
+                                "bsonType": "string",
+                                "description": "Version of the system - if relevant"
+                              }
+                            }
+                          }
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Plain text representation of the concept"
+                        }
+                      }
+                    },
+                    "valueCoding": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "code": {
+                          "bsonType": "string",
+                          "description": "Symbol in syntax defined by the system",
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Representation defined by the system"
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "Identity of the terminology system"
+                        },
+                        "userSelected": {
+                          "bsonType": "bool",
+                          "description": "If this coding was chosen directly by the user"
+                        },
+                        "version": {
+                          "bsonType": "string",
+                          "description": "Version of the system - if relevant"
+                        }
+                      }
+                    },
+                    "valueDateTime": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Value of extension",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "valueDecimal": {
+                      "bsonType": "number",
+                      "description": "Value of extension"
+                    },
+                    "valueInteger": {
+                      "bsonType": [
+                        "int",
+                        "long"
+                      ],
+                      "description": "Value of extension"
+                    },
+                    "valueReference": {
+                      "bsonType": "object",
+                      "description": "Value of extension",
+                      "properties": {
+                        "display": {
+                          "bsonType": "string",
+                          "description": "Text alternative for the resource"
+                        },
+                        "identifier": {
+                          "bsonType": "object",
+                          "description": "Logical reference, when literal reference is not known",
+                          "properties": {
+                            "period": {
+                              "bsonType": "object",
+                              "description": "Time period when id is/was valid for use",
+                              "properties": {
+                                "end": {
+                                  "bsonType": [
+                                    "date",
+                                    "string"
+                                  ],
+                                  "description": "End time with inclusive boundary, if not ongoing",
+                                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                                },
+                                "start": {
+                                  "bsonType": [
+                                    "date",
+                                    "string"
+                                  ],
+                                  "description": "Starting time with inclusive boundary",
+                                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                                }
+                              }
+                            },
+                            "system": {
+                              "bsonType": "string",
+                              "description": "The namespace for the identifier value"
+                            },
+                            "type": {
+                              "bsonType": "object",
+                              "description": "Description of identifier",
+                              "properties": {
+                                "coding": {
+                                  "bsonType": "array",
+                                  "description": "Code defined by a terminology system",
+                                  "items": {
+                                    "bsonType": "object",
+                                    "properties": {
+                                      "code": {
+                                        "bsonType": "string",
+                                        "description": "Symbol in syntax defined by the system",
+                                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                      },
+                                      "display": {
+                                        "bsonType": "string",
+                                        "description": "Representation defined by the system"
+                                      },
+                                      "system": {
+                                        "bsonType": "string",
+                                        "description": "Identity of the terminology system"
+                                      },
+                                      "userSelected": {
+                                        "bsonType": "bool",
+                                        "description": "If this coding was chosen directly by the user"
+                                      },
+                                      "version": {
+                                        "bsonType": "string",
+                                        "description": "Version of the system - if relevant"
+                                      }
+                                    }
+                                  }
+                                },
+                                "text": {
+                                  "bsonType": "string",
+                                  "description": "Plain text representation of the concept"
+                                }
+                              }
+                            },
+                            "use": {
+                              "bsonType": "string",
+                              "description": "usual | official | temp | secondary | old (If known)",
+                              "enum": [
+                                "usual",
+                                "official",
+                                "temp",
+                                "secondary",
+                                "old"
+                              ],
+                              "pattern": "^[^\\s]+( [^\\s]+)*$"
+                            },
+                            "value": {
+                              "bsonType": "string",
+                              "description": "The value that is unique"
+                            }
+                          }
+                        },
+                        "reference": {
+                          "bsonType": "string",
+                          "description": "Literal reference, Relative, internal or absolute URL"
+                        },
+                        "type": {
+                          "bsonType": "string",
+                          "description": "Type the reference refers to (e.g. \"Patient\")"
+                        }
+                      }
+                    },
+                    "valueString": {
+                      "bsonType": "string",
+                      "description": "Value of extension"
+                    }
+                  },
+                  "required": [
+                    "url"
+                  ]
+                }
+              },
+              "name": {
+                "bsonType": "array",
+                "description": "The name(s) associated with the practitioner",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "family": {
+                      "bsonType": "string",
+                      "description": "Family name (often called 'Surname')"
+                    },
+                    "given": {
+                      "bsonType": "array",
+                      "description": "Given names (not always 'first'). Includes middle names",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when name was/is in use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "prefix": {
+                      "bsonType": "array",
+                      "description": "Parts that come before the name",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "suffix": {
+                      "bsonType": "array",
+                      "description": "Parts that come after the name",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Text representation of the full name"
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "usual | official | temp | nickname | anonymous | old | maiden",
+                      "enum": [
+                        "usual",
+                        "official",
+                        "temp",
+                        "nickname",
+                        "anonymous",
+                        "old",
+                        "maiden"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    }
+                  }
+                }
+              },
+              "supervisor": {
+                "bsonType": "object",
+                "description": "Practitioner responsible for this one"
+              },
+              "text": {
+                "bsonType": "object",
+                "description": "Text summary of the resource, for human interpretation",
+                "properties": {
+                  "div": {
+                    "bsonType": "string",
+                    "description": "Limited xhtml content"
+                  },
+                  "status": {
+                    "bsonType": "string",
+                    "description": "generated | extensions | additional | empty",
+                    "enum": [
+                      "generated",
+                      "extensions",
+                      "additional",
+                      "empty"
+                    ],
+                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                  }
+                },
+                "required": [
+                  "status",
+                  "div"
+                ]
+              }
+            },
+            "required": [
+              "id"
+            ]
+          },
+          "class": {
+            "bsonType": "object",
+            "description": "Classification of the visit",
+            "properties": {
+              "code": {
+                "bsonType": "string",
+                "description": "Symbol in syntax defined by the system",
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "display": {
+                "bsonType": "string",
+                "description": "Representation defined by the system"
+              },
+              "system": {
+                "bsonType": "string",
+                "description": "Identity of the terminology system"
+              },
+              "userSelected": {
+                "bsonType": "bool",
+                "description": "If this coding was chosen directly by the user"
+              },
+              "version": {
+                "bsonType": "string",
+                "description": "Version of the system - if relevant"
+              }
+            }
+          },
+          "for": {
+            "bsonType": "object",
+            "description": "Who the visit is for",
+            "properties": {
+              "display": {
+                "bsonType": "string",
+                "description": "Text alternative for the resource"
+              },
+              "identifier": {
+                "bsonType": "object",
+                "description": "Logical reference, when literal reference is not known",
+                "properties": {
+                  "period": {
+                    "bsonType": "object",
+                    "description": "Time period when id is/was valid for use",
+                    "properties": {
+                      "end": {
+                        "bsonType": [
+                          "date",
+                          "string"
+                        ],
+                        "description": "End time with inclusive boundary, if not ongoing",
+                        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                      },
+                      "start": {
+                        "bsonType": [
+                          "date",
+                          "string"
+                        ],
+                        "description": "Starting time with inclusive boundary",
+                        "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                      }
+                    }
+                  },
+                  "system": {
+                    "bsonType": "string",
+                    "description": "The namespace for the identifier value"
+                  },
+                  "type": {
+                    "bsonType": "object",
+                    "description": "Description of identifier",
+                    "properties": {
+                      "coding": {
+                        "bsonType": "array",
+                        "description": "Code defined by a terminology system",
+                        "items": {
+                          "bsonType": "object",
+                          "properties": {
+                            "code": {
+                              "bsonType": "string",
+                              "description": "Symbol in syntax defined by the system",
+                              "pattern": "^[^\\s]+( [^\\s]+)*$"
+                            },
+                            "display": {
+                              "bsonType": "string",
+                              "description": "Representation defined by the system"
+                            },
+                            "system": {
+                              "bsonType": "string",
+                              "description": "Identity of the terminology system"
+                            },
+                            "userSelected": {
+                              "bsonType": "bool",
+                              "description": "If this coding was chosen directly by the user"
+                            },
+                            "version": {
+                              "bsonType": "string",
+                              "description": "Version of the system - if relevant"
+                            }
+                          }
+                        }
+                      },
+                      "text": {
+                        "bsonType": "string",
+                        "description": "Plain text representation of the concept"
+                      }
+                    }
+                  },
+                  "use": {
+                    "bsonType": "string",
+                    "description": "usual | official | temp | secondary | old (If known)",
+                    "enum": [
+                      "usual",
+                      "official",
+                      "temp",
+                      "secondary",
+                      "old"
+                    ],
+                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                  },
+                  "value": {
+                    "bsonType": "string",
+                    "description": "The value that is unique"
+                  }
+                }
+              },
+              "reference": {
+                "bsonType": "string",
+                "description": "Literal reference, Relative, internal or absolute URL"
+              },
+              "type": {
+                "bsonType": "string",
+                "description": "Type the reference refers to (e.g. \"Patient\")"
+              }
+            }
+          },
+          "length": {
+            "bsonType": "object",
+            "description": "Time the visit lasted",
+            "properties": {
+              "code": {
+                "bsonType": "string",
+                "description": "Coded form of the unit",
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "comparator": {
+                "bsonType": "string",
+                "description": "< | <= | >= | > - how to understand the value",
+                "enum": [
+                  "<",
+                  "<=",
+                  ">=",
+                  ">"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "system": {
+                "bsonType": "string",
+                "description": "System that defines coded unit form"
+              },
+              "unit": {
+                "bsonType": "string",
+                "description": "Unit representation"
+              },
+              "value": {
+                "bsonType": "number",
+                "description": "Numerical value (with implicit precision)"
+              }
+            }
+          },
+          "match": {
+            "bsonType": "string",
+            "description": "Record linkage match grade"
+          },
+          "order": {
+            "bsonType": [
+              "int",
+              "long"
+            ],
+            "description": "Sequence within the episode"
+          },
+          "priorityCode": {
+            "bsonType": "string",
+            "description": "Priority as a code",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "priority_code": {
+            "bsonType": "string",
+            "description": "Priority as legacy text"
+          },
+          "self": {
+            "bsonType": "string",
+            "description": "Canonical URL of the visit"
+          },
+          "specimens": {
+            "bsonType": "array",
+            "description": "Specimens collected during the visit",
+            "items": {
+              "bsonType": "object"
+            }
+          },
+          "type": {
+            "bsonType": "array",
+            "description": "Specific type of visit",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "coding": {
+                  "bsonType": "array",
+                  "description": "Code defined by a terminology system",
+                  "items": {
+                    "bsonType": "object",
+                    "properties": {
+                      "code": {
+                        "bsonType": "string",
+                        "description": "Symbol in syntax defined by the system",
+                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                      },
+                      "display": {
+                        "bsonType": "string",
+                        "description": "Representation defined by the system"
+                      },
+                      "system": {
+                        "bsonType": "string",
+                        "description": "Identity of the terminology system"
+                      },
+                      "userSelected": {
+                        "bsonType": "bool",
+                        "description": "If this coding was chosen directly by the user"
+                      },
+                      "version": {
+                        "bsonType": "string",
+                        "description": "Version of the system - if relevant"
+                      }
+                    }
+                  }
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Plain text representation of the concept"
+                }
+              }
+            }
+          },
+          "visit": {
+            "bsonType": "string",
+            "description": "Visit number"
+          },
+          "where": {
+            "bsonType": "object",
+            "description": "Where the visit took place",
+            "properties": {
+              "city": {
+                "bsonType": "string",
+                "description": "Name of city, town etc."
+              },
+              "country": {
+                "bsonType": "string",
+                "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+              },
+              "district": {
+                "bsonType": "string",
+                "description": "District name (aka county)"
+              },
+              "line": {
+                "bsonType": "array",
+                "description": "Street name, number, direction & P.O. Box etc.",
+                "items": {
+                  "bsonType": "string"
+                }
+              },
+              "period": {
+                "bsonType": "object",
+                "description": "Time period when address was/is in use",
+                "properties": {
+                  "end": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "End time with inclusive boundary, if not ongoing",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  },
+                  "start": {
+                    "bsonType": [
+                      "date",
+                      "string"
+                    ],
+                    "description": "Starting time with inclusive boundary",
+                    "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                  }
+                }
+              },
+              "postalCode": {
+                "bsonType": "string",
+                "description": "Postal code for area"
+              },
+              "state": {
+                "bsonType": "string",
+                "description": "Sub-unit of country (abbreviations ok)"
+              },
+              "text": {
+                "bsonType": "string",
+                "description": "Text representation of the address"
+              },
+              "type": {
+                "bsonType": "string",
+                "description": "postal | physical | both",
+                "enum": [
+                  "postal",
+                  "physical",
+                  "both"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              },
+              "use": {
+                "bsonType": "string",
+                "description": "home | work | temp | old | billing - purpose of this address",
+                "enum": [
+                  "home",
+                  "work",
+                  "temp",
+                  "old",
+                  "billing"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              }
+            }
+          }
+        },
+        "required": [
+          "class"
+        ]
+      }
+    },
+    "required": [
+      "resourceType",
+      "id",
+      "status"
+    ],
+    "title": "Specimen"
+  }
+});
+
+// lab_result: Résultat de laboratoire — température °C, 检验结果
+ensureCollection("lab_result", {
+  "$jsonSchema": {
+    "bsonType": "object",
+    "description": "Résultat de laboratoire — température °C, 检验结果",
+    "properties": {
+      "ICD10Code": {
+        "bsonType": "string",
+        "description": "Diagnosis the test was ordered for",
+        "pattern": "^[^\\s]+( [^\\s]+)*$"
+      },
+      "charge_amount": {
+        "bsonType": "number",
+        "description": "Billed amount"
+      },
+      "mrn": {
+        "bsonType": [
+          "binData",
+          "string"
+        ],
+        "description": "Medical record number"
+      },
+      "ordered_by": {
+        "bsonType": "object",
+        "description": "Who ordered the test",
+        "properties": {
+          "extension": {
+            "bsonType": "array",
+            "description": "Additional content defined by implementations",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "url": {
+                  "bsonType": "string",
+                  "description": "Identifies the meaning of the extension"
+                },
+                "valueAddress": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "city": {
+                      "bsonType": "string",
+                      "description": "Name of city, town etc."
+                    },
+                    "country": {
+                      "bsonType": "string",
+                      "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                    },
+                    "district": {
+                      "bsonType": "string",
+                      "description": "District name (aka county)"
+                    },
+                    "line": {
+                      "bsonType": "array",
+                      "description": "Street name, number, direction & P.O. Box etc.",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when address was/is in use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "postalCode": {
+                      "bsonType": "string",
+                      "description": "Postal code for area"
+                    },
+                    "state": {
+                      "bsonType": "string",
+                      "description": "Sub-unit of country (abbreviations ok)"
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Text representation of the address"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "postal | physical | both",
+                      "enum": [
+                        "postal",
+                        "physical",
+                        "both"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "home | work | temp | old | billing - purpose of this address",
+                      "enum": [
+                        "home",
+                        "work",
+                        "temp",
+                        "old",
+                        "billing"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    }
+                  }
+                },
+                "valueBoolean": {
+                  "bsonType": "bool",
+                  "description": "Value of extension"
+                },
+                "valueCode": {
+                  "bsonType": "string",
+                  "description": "Value of extension",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "valueCodeableConcept": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "valueCoding": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                },
+                "valueDateTime": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "Value of extension",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                },
+                "valueDecimal": {
+                  "bsonType": "number",
+                  "description": "Value of extension"
+                },
+                "valueInteger": {
+                  "bsonType": [
+                    "int",
+                    "long"
+                  ],
+                  "description": "Value of extension"
+                },
+                "valueReference": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Text alternative for the resource"
+                    },
+                    "identifier": {
+                      "bsonType": "object",
+                      "description": "Logical reference, when literal reference is not known",
+                      "properties": {
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when id is/was valid for use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "The namespace for the identifier value"
+                        },
+                        "type": {
+                          "bsonType": "object",
+                          "description": "Description of identifier",
+                          "properties": {
+                            "coding": {
+                              "bsonType": "array",
+                              "description": "Code defined by a terminology system",
+                              "items": {
+                                "bsonType": "object",
+                                "properties": {
+                                  "code": {
+                                    "bsonType": "string",
+                                    "description": "Symbol in syntax defined by the system",
+                                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                  },
+                                  "display": {
+                                    "bsonType": "string",
+                                    "description": "Representation defined by the system"
+                                  },
+                                  "system": {
+                                    "bsonType": "string",
+                                    "description": "Identity of the terminology system"
+                                  },
+                                  "userSelected": {
+                                    "bsonType": "bool",
+                                    "description": "If this coding was chosen directly by the user"
+                                  },
+                                  "version": {
+                                    "bsonType": "string",
+                                    "description": "Version of the system - if relevant"
+                                  }
+                                }
+                              }
+                            },
+                            "text": {
+                              "bsonType": "string",
+                              "description": "Plain text representation of the concept"
+                            }
+                          }
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "usual | official | temp | secondary | old (If known)",
+                          "enum": [
+                            "usual",
+                            "official",
+                            "temp",
+                            "secondary",
+                            "old"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "value": {
+                          "bsonType": "string",
+                          "description": "The value that is unique"
+                        }
+                      }
+                    },
+                    "reference": {
+                      "bsonType": "string",
+                      "description": "Literal reference, Relative, internal or absolute URL"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "Type the reference refers to (e.g. \"Patient\")"
+                    }
+                  }
+                },
+                "valueString": {
+                  "bsonType": "string",
+                  "description": "Value of extension"
+                }
+              },
+              "required": [
+                "url"
+              ]
+            }
+          },
+          "id": {
+            "bsonType": "string",
+            "description": "Logical id of this artifact",
+            "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+          },
+          "implicitRules": {
+            "bsonType": "string",
+            "description": "A set of rules under which this content was created"
+          },
+          "language": {
+            "bsonType": "string",
+            "description": "Language of the resource content",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "meta": {
+            "bsonType": "object",
+            "description": "Metadata about the resource",
+            "properties": {
+              "lastUpdated": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "When the resource version last changed"
+              },
+              "profile": {
+                "bsonType": "array",
+                "description": "Profiles this resource claims to conform to",
+                "items": {
+                  "bsonType": "string"
+                }
+              },
+              "security": {
+                "bsonType": "array",
+                "description": "Security Labels applied to this resource",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                }
+              },
+              "source": {
+                "bsonType": "string",
+                "description": "Identifies where the resource comes from"
+              },
+              "tag": {
+                "bsonType": "array",
+                "description": "Tags applied to this resource",
+                "items": {
+                  "bsonType": "object",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                }
+              },
+              "versionId": {
+                "bsonType": "string",
+                "description": "Version specific identifier",
+                "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+              }
+            }
+          },
+          "modifierExtension": {
+            "bsonType": "array",
+            "description": "Extensions that cannot be ignored",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "url": {
+                  "bsonType": "string",
+                  "description": "Identifies the meaning of the extension"
+                },
+                "valueAddress": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "city": {
+                      "bsonType": "string",
+                      "description": "Name of city, town etc."
+                    },
+                    "country": {
+                      "bsonType": "string",
+                      "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                    },
+                    "district": {
+                      "bsonType": "string",
+                      "description": "District name (aka county)"
+                    },
+                    "line": {
+                      "bsonType": "array",
+                      "description": "Street name, number, direction & P.O. Box etc.",
+                      "items": {
+                        "bsonType": "string"
+                      }
+                    },
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when address was/is in use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "postalCode": {
+                      "bsonType": "string",
+                      "description": "Postal code for area"
+                    },
+                    "state": {
+                      "bsonType": "string",
+                      "description": "Sub-unit of country (abbreviations ok)"
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Text representation of the address"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "postal | physical | both",
+                      "enum": [
+                        "postal",
+                        "physical",
+                        "both"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "home | work | temp | old | billing - purpose of this address",
+                      "enum": [
+                        "home",
+                        "work",
+                        "temp",
+                        "old",
+                        "billing"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    }
+                  }
+                },
+                "valueBoolean": {
+                  "bsonType": "bool",
+                  "description": "Value of extension"
+                },
+                "valueCode": {
+                  "bsonType": "string",
+                  "description": "Value of extension",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "valueCodeableConcept": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "coding": {
+                      "bsonType": "array",
+                      "description": "Code defined by a terminology system",
+                      "items": {
+                        "bsonType": "object",
+                        "properties": {
+                          "code": {
+                            "bsonType": "string",
+                            "description": "Symbol in syntax defined by the system",
+                            "pattern": "^[^\\s]+( [^\\s]+)*$"
+                          },
+                          "display": {
+                            "bsonType": "string",
+                            "description": "Representation defined by the system"
+                          },
+                          "system": {
+                            "bsonType": "string",
+                            "description": "Identity of the terminology system"
+                          },
+                          "userSelected": {
+                            "bsonType": "bool",
+                            "description": "If this coding was chosen directly by the user"
+                          },
+                          "version": {
+                            "bsonType": "string",
+                            "description": "Version of the system - if relevant"
+                          }
+                        }
+                      }
+                    },
+                    "text": {
+                      "bsonType": "string",
+                      "description": "Plain text representation of the concept"
+                    }
+                  }
+                },
+                "valueCoding": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "code": {
+                      "bsonType": "string",
+                      "description": "Symbol in syntax defined by the system",
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Representation defined by the system"
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "Identity of the terminology system"
+                    },
+                    "userSelected": {
+                      "bsonType": "bool",
+                      "description": "If this coding was chosen directly by the user"
+                    },
+                    "version": {
+                      "bsonType": "string",
+                      "description": "Version of the system - if relevant"
+                    }
+                  }
+                },
+                "valueDateTime": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "Value of extension",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                },
+                "valueDecimal": {
+                  "bsonType": "number",
+                  "description": "Value of extension"
+                },
+                "valueInteger": {
+                  "bsonType": [
+                    "int",
+                    "long"
+                  ],
+                  "description": "Value of extension"
+                },
+                "valueReference": {
+                  "bsonType": "object",
+                  "description": "Value of extension",
+                  "properties": {
+                    "display": {
+                      "bsonType": "string",
+                      "description": "Text alternative for the resource"
+                    },
+                    "identifier": {
+                      "bsonType": "object",
+                      "description": "Logical reference, when literal reference is not known",
+                      "properties": {
+                        "period": {
+                          "bsonType": "object",
+                          "description": "Time period when id is/was valid for use",
+                          "properties": {
+                            "end": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "End time with inclusive boundary, if not ongoing",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            },
+                            "start": {
+                              "bsonType": [
+                                "date",
+                                "string"
+                              ],
+                              "description": "Starting time with inclusive boundary",
+                              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                            }
+                          }
+                        },
+                        "system": {
+                          "bsonType": "string",
+                          "description": "The namespace for the identifier value"
+                        },
+                        "type": {
+                          "bsonType": "object",
+                          "description": "Description of identifier",
+                          "properties": {
+                            "coding": {
+                              "bsonType": "array",
+                              "description": "Code defined by a terminology system",
+                              "items": {
+                                "bsonType": "object",
+                                "properties": {
+                                  "code": {
+                                    "bsonType": "string",
+                                    "description": "Symbol in syntax defined by the system",
+                                    "pattern": "^[^\\s]+( [^\\s]+)*$"
+                                  },
+                                  "display": {
+                                    "bsonType": "string",
+                                    "description": "Representation defined by the system"
+                                  },
+                                  "system": {
+                                    "bsonType": "string",
+                                    "description": "Identity of the terminology system"
+                                  },
+                                  "userSelected": {
+                                    "bsonType": "bool",
+                                    "description": "If this coding was chosen directly by the user"
+                                  },
+                                  "version": {
+                                    "bsonType": "string",
+                                    "description": "Version of the system - if relevant"
+                                  }
+                                }
+                              }
+                            },
+                            "text": {
+                              "bsonType": "string",
+                              "description": "Plain text representation of the concept"
+                            }
+                          }
+                        },
+                        "use": {
+                          "bsonType": "string",
+                          "description": "usual | official | temp | secondary | old (If known)",
+                          "enum": [
+                            "usual",
+                            "official",
+                            "temp",
+                            "secondary",
+                            "old"
+                          ],
+                          "pattern": "^[^\\s]+( [^\\s]+)*$"
+                        },
+                        "value": {
+                          "bsonType": "string",
+                          "description": "The value that is unique"
+                        }
+                      }
+                    },
+                    "reference": {
+                      "bsonType": "string",
+                      "description": "Literal reference, Relative, internal or absolute URL"
+                    },
+                    "type": {
+                      "bsonType": "string",
+                      "description": "Type the reference refers to (e.g. \"Patient\")"
+                    }
+                  }
+                },
+                "valueString": {
+                  "bsonType": "string",
+                  "description": "Value of extension"
+                }
+              },
+              "required": [
+                "url"
+              ]
+            }
+          },
+          "name": {
+            "bsonType": "array",
+            "description": "The name(s) associated with the practitioner",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "family": {
+                  "bsonType": "string",
+                  "description": "Family name (often called 'Surname')"
+                },
+                "given": {
+                  "bsonType": "array",
+                  "description": "Given names (not always 'first'). Includes middle names",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when name was/is in use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "prefix": {
+                  "bsonType": "array",
+                  "description": "Parts that come before the name",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "suffix": {
+                  "bsonType": "array",
+                  "description": "Parts that come after the name",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Text representation of the full name"
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "usual | official | temp | nickname | anonymous | old | maiden",
+                  "enum": [
+                    "usual",
+                    "official",
+                    "temp",
+                    "nickname",
+                    "anonymous",
+                    "old",
+                    "maiden"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                }
+              }
+            }
+          },
+          "supervisor": {
+            "bsonType": "object",
+            "description": "Practitioner responsible for this one"
+          },
+          "text": {
+            "bsonType": "object",
+            "description": "Text summary of the resource, for human interpretation",
+            "properties": {
+              "div": {
+                "bsonType": "string",
+                "description": "Limited xhtml content"
+              },
+              "status": {
+                "bsonType": "string",
+                "description": "generated | extensions | additional | empty",
+                "enum": [
+                  "generated",
+                  "extensions",
+                  "additional",
+                  "empty"
+                ],
+                "pattern": "^[^\\s]+( [^\\s]+)*$"
+              }
+            },
+            "required": [
+              "status",
+              "div"
+            ]
+          }
+        },
+        "required": [
+          "id"
+        ]
+      },
+      "performer_name": {
+        "bsonType": "object",
+        "description": "Who performed the test",
+        "properties": {
+          "family": {
+            "bsonType": "string",
+            "description": "Family name (often called 'Surname')"
+          },
+          "given": {
+            "bsonType": "array",
+            "description": "Given names (not always 'first'). Includes middle names",
+            "items": {
+              "bsonType": "string"
+            }
+          },
+          "period": {
+            "bsonType": "object",
+            "description": "Time period when name was/is in use",
+            "properties": {
+              "end": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "End time with inclusive boundary, if not ongoing",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              },
+              "start": {
+                "bsonType": [
+                  "date",
+                  "string"
+                ],
+                "description": "Starting time with inclusive boundary",
+                "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+              }
+            }
+          },
+          "prefix": {
+            "bsonType": "array",
+            "description": "Parts that come before the name",
+            "items": {
+              "bsonType": "string"
+            }
+          },
+          "suffix": {
+            "bsonType": "array",
+            "description": "Parts that come after the name",
+            "items": {
+              "bsonType": "string"
+            }
+          },
+          "text": {
+            "bsonType": "string",
+            "description": "Text representation of the full name"
+          },
+          "use": {
+            "bsonType": "string",
+            "description": "usual | official | temp | nickname | anonymous | old | maiden",
+            "enum": [
+              "usual",
+              "official",
+              "temp",
+              "nickname",
+              "anonymous",
+              "old",
+              "maiden"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          }
+        }
+      },
+      "rawPayload": {
+        "bsonType": [
+          "binData",
+          "string"
+        ],
+        "description": "Original HL7 message"
+      },
+      "result_id": {
+        "bsonType": "string",
+        "description": "Identifiant du résultat"
+      },
+      "resulted_at": {
+        "bsonType": [
+          "date",
+          "string"
+        ],
+        "description": "When the result was released"
+      },
+      "reviewer_ids": {
+        "bsonType": "array",
+        "description": "Reviewing clinicians",
+        "items": {
+          "bsonType": "string",
+          "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+        }
+      },
+      "value_numeric": {
+        "bsonType": "number",
+        "description": "Valeur mesurée (µmol/L)"
+      },
+      "value_quantity": {
+        "bsonType": "object",
+        "description": "Measured value with its unit",
+        "properties": {
+          "code": {
+            "bsonType": "string",
+            "description": "Coded form of the unit",
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "comparator": {
+            "bsonType": "string",
+            "description": "< | <= | >= | > - how to understand the value",
+            "enum": [
+              "<",
+              "<=",
+              ">=",
+              ">"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          },
+          "system": {
+            "bsonType": "string",
+            "description": "System that defines coded unit form"
+          },
+          "unit": {
+            "bsonType": "string",
+            "description": "Unit representation"
+          },
+          "value": {
+            "bsonType": "number",
+            "description": "Numerical value (with implicit precision)"
+          }
+        }
+      }
+    },
+    "required": [
+      "result_id",
+      "mrn"
+    ],
+    "title": "lab_result"
+  }
+});
+db.getCollection("lab_result").createIndex({ "result_id": 1 }, { unique: true });
+db.getCollection("lab_result").createIndex({ "mrn": 1, "resulted_at": 1 });
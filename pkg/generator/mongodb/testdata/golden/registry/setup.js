@@ -0,0 +1,849 @@
+// Creates the MongoDB collections of the registry namespace, each with
+// the validator of its schema, and their indexes. Rerunning it updates the
+// validators of existing collections. Run it with mongosh:
+//
+//   mongosh "mongodb://localhost:27017/<database>" setup.js
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+const existing = db.getCollectionNames();
+
+function ensureCollection(name, validator) {
+  if (existing.includes(name)) {
+    db.runCommand({ collMod: name, validator: validator });
+  } else {
+    db.createCollection(name, { validator: validator });
+  }
+}
+
+// Practitioner: A person providing care
+ensureCollection("practitioner", {
+  "$jsonSchema": {
+    "bsonType": "object",
+    "description": "A person providing care",
+    "properties": {
+      "extension": {
+        "bsonType": "array",
+        "description": "Additional content defined by implementations",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "url": {
+              "bsonType": "string",
+              "description": "Identifies the meaning of the extension"
+            },
+            "valueAddress": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "city": {
+                  "bsonType": "string",
+                  "description": "Name of city, town etc."
+                },
+                "country": {
+                  "bsonType": "string",
+                  "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                },
+                "district": {
+                  "bsonType": "string",
+                  "description": "District name (aka county)"
+                },
+                "line": {
+                  "bsonType": "array",
+                  "description": "Street name, number, direction & P.O. Box etc.",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when address was/is in use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "postalCode": {
+                  "bsonType": "string",
+                  "description": "Postal code for area"
+                },
+                "state": {
+                  "bsonType": "string",
+                  "description": "Sub-unit of country (abbreviations ok)"
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Text representation of the address"
+                },
+                "type": {
+                  "bsonType": "string",
+                  "description": "postal | physical | both",
+                  "enum": [
+                    "postal",
+                    "physical",
+                    "both"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "home | work | temp | old | billing - purpose of this address",
+                  "enum": [
+                    "home",
+                    "work",
+                    "temp",
+                    "old",
+                    "billing"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                }
+              }
+            },
+            "valueBoolean": {
+              "bsonType": "bool",
+              "description": "Value of extension"
+            },
+            "valueCode": {
+              "bsonType": "string",
+              "description": "Value of extension",
+              "pattern": "^[^\\s]+( [^\\s]+)*$"
+            },
+            "valueCodeableConcept": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "coding": {
+                  "bsonType": "array",
+                  "description": "Code defined by a terminology system",
+                  "items": {
+                    "bsonType": "object",
+                    "properties": {
+                      "code": {
+                        "bsonType": "string",
+                        "description": "Symbol in syntax defined by the system",
+                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                      },
+                      "display": {
+                        "bsonType": "string",
+                        "description": "Representation defined by the system"
+                      },
+                      "system": {
+                        "bsonType": "string",
+                        "description": "Identity of the terminology system"
+                      },
+                      "userSelected": {
+                        "bsonType": "bool",
+                        "description": "If this coding was chosen directly by the user"
+                      },
+                      "version": {
+                        "bsonType": "string",
+                        "description": "Version of the system - if relevant"
+                      }
+                    }
+                  }
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Plain text representation of the concept"
+                }
+              }
+            },
+            "valueCoding": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "code": {
+                  "bsonType": "string",
+                  "description": "Symbol in syntax defined by the system",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "display": {
+                  "bsonType": "string",
+                  "description": "Representation defined by the system"
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "Identity of the terminology system"
+                },
+                "userSelected": {
+                  "bsonType": "bool",
+                  "description": "If this coding was chosen directly by the user"
+                },
+                "version": {
+                  "bsonType": "string",
+                  "description": "Version of the system - if relevant"
+                }
+              }
+            },
+            "valueDateTime": {
+              "bsonType": [
+                "date",
+                "string"
+              ],
+              "description": "Value of extension",
+              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+            },
+            "valueDecimal": {
+              "bsonType": "number",
+              "description": "Value of extension"
+            },
+            "valueInteger": {
+              "bsonType": [
+                "int",
+                "long"
+              ],
+              "description": "Value of extension"
+            },
+            "valueReference": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "display": {
+                  "bsonType": "string",
+                  "description": "Text alternative for the resource"
+                },
+                "identifier": {
+                  "bsonType": "object",
+                  "description": "Logical reference, when literal reference is not known",
+                  "properties": {
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when id is/was valid for use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "The namespace for the identifier value"
+                    },
+                    "type": {
+                      "bsonType": "object",
+                      "description": "Description of identifier",
+                      "properties": {
+                        "coding": {
+                          "bsonType": "array",
+                          "description": "Code defined by a terminology system",
+                          "items": {
+                            "bsonType": "object",
+                            "properties": {
+                              "code": {
+                                "bsonType": "string",
+                                "description": "Symbol in syntax defined by the system",
+                                "pattern": "^[^\\s]+( [^\\s]+)*$"
+                              },
+                              "display": {
+                                "bsonType": "string",
+                                "description": "Representation defined by the system"
+                              },
+                              "system": {
+                                "bsonType": "string",
+                                "description": "Identity of the terminology system"
+                              },
+                              "userSelected": {
+                                "bsonType": "bool",
+                                "description": "If this coding was chosen directly by the user"
+                              },
+                              "version": {
+                                "bsonType": "string",
+                                "description": "Version of the system - if relevant"
+                              }
+                            }
+                          }
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Plain text representation of the concept"
+                        }
+                      }
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "usual | official | temp | secondary | old (If known)",
+                      "enum": [
+                        "usual",
+                        "official",
+                        "temp",
+                        "secondary",
+                        "old"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "value": {
+                      "bsonType": "string",
+                      "description": "The value that is unique"
+                    }
+                  }
+                },
+                "reference": {
+                  "bsonType": "string",
+                  "description": "Literal reference, Relative, internal or absolute URL"
+                },
+                "type": {
+                  "bsonType": "string",
+                  "description": "Type the reference refers to (e.g. \"Patient\")"
+                }
+              }
+            },
+            "valueString": {
+              "bsonType": "string",
+              "description": "Value of extension"
+            }
+          },
+          "required": [
+            "url"
+          ]
+        }
+      },
+      "id": {
+        "bsonType": "string",
+        "description": "Logical id of this artifact",
+        "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+      },
+      "implicitRules": {
+        "bsonType": "string",
+        "description": "A set of rules under which this content was created"
+      },
+      "language": {
+        "bsonType": "string",
+        "description": "Language of the resource content",
+        "pattern": "^[^\\s]+( [^\\s]+)*$"
+      },
+      "meta": {
+        "bsonType": "object",
+        "description": "Metadata about the resource",
+        "properties": {
+          "lastUpdated": {
+            "bsonType": [
+              "date",
+              "string"
+            ],
+            "description": "When the resource version last changed"
+          },
+          "profile": {
+            "bsonType": "array",
+            "description": "Profiles this resource claims to conform to",
+            "items": {
+              "bsonType": "string"
+            }
+          },
+          "security": {
+            "bsonType": "array",
+            "description": "Security Labels applied to this resource",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "code": {
+                  "bsonType": "string",
+                  "description": "Symbol in syntax defined by the system",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "display": {
+                  "bsonType": "string",
+                  "description": "Representation defined by the system"
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "Identity of the terminology system"
+                },
+                "userSelected": {
+                  "bsonType": "bool",
+                  "description": "If this coding was chosen directly by the user"
+                },
+                "version": {
+                  "bsonType": "string",
+                  "description": "Version of the system - if relevant"
+                }
+              }
+            }
+          },
+          "source": {
+            "bsonType": "string",
+            "description": "Identifies where the resource comes from"
+          },
+          "tag": {
+            "bsonType": "array",
+            "description": "Tags applied to this resource",
+            "items": {
+              "bsonType": "object",
+              "properties": {
+                "code": {
+                  "bsonType": "string",
+                  "description": "Symbol in syntax defined by the system",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "display": {
+                  "bsonType": "string",
+                  "description": "Representation defined by the system"
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "Identity of the terminology system"
+                },
+                "userSelected": {
+                  "bsonType": "bool",
+                  "description": "If this coding was chosen directly by the user"
+                },
+                "version": {
+                  "bsonType": "string",
+                  "description": "Version of the system - if relevant"
+                }
+              }
+            }
+          },
+          "versionId": {
+            "bsonType": "string",
+            "description": "Version specific identifier",
+            "pattern": "^[A-Za-z0-9\\-.]{1,64}$"
+          }
+        }
+      },
+      "modifierExtension": {
+        "bsonType": "array",
+        "description": "Extensions that cannot be ignored",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "url": {
+              "bsonType": "string",
+              "description": "Identifies the meaning of the extension"
+            },
+            "valueAddress": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "city": {
+                  "bsonType": "string",
+                  "description": "Name of city, town etc."
+                },
+                "country": {
+                  "bsonType": "string",
+                  "description": "Country (e.g. can be ISO 3166 2 or 3 letter code)"
+                },
+                "district": {
+                  "bsonType": "string",
+                  "description": "District name (aka county)"
+                },
+                "line": {
+                  "bsonType": "array",
+                  "description": "Street name, number, direction & P.O. Box etc.",
+                  "items": {
+                    "bsonType": "string"
+                  }
+                },
+                "period": {
+                  "bsonType": "object",
+                  "description": "Time period when address was/is in use",
+                  "properties": {
+                    "end": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "End time with inclusive boundary, if not ongoing",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    },
+                    "start": {
+                      "bsonType": [
+                        "date",
+                        "string"
+                      ],
+                      "description": "Starting time with inclusive boundary",
+                      "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                    }
+                  }
+                },
+                "postalCode": {
+                  "bsonType": "string",
+                  "description": "Postal code for area"
+                },
+                "state": {
+                  "bsonType": "string",
+                  "description": "Sub-unit of country (abbreviations ok)"
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Text representation of the address"
+                },
+                "type": {
+                  "bsonType": "string",
+                  "description": "postal | physical | both",
+                  "enum": [
+                    "postal",
+                    "physical",
+                    "both"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "use": {
+                  "bsonType": "string",
+                  "description": "home | work | temp | old | billing - purpose of this address",
+                  "enum": [
+                    "home",
+                    "work",
+                    "temp",
+                    "old",
+                    "billing"
+                  ],
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                }
+              }
+            },
+            "valueBoolean": {
+              "bsonType": "bool",
+              "description": "Value of extension"
+            },
+            "valueCode": {
+              "bsonType": "string",
+              "description": "Value of extension",
+              "pattern": "^[^\\s]+( [^\\s]+)*$"
+            },
+            "valueCodeableConcept": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "coding": {
+                  "bsonType": "array",
+                  "description": "Code defined by a terminology system",
+                  "items": {
+                    "bsonType": "object",
+                    "properties": {
+                      "code": {
+                        "bsonType": "string",
+                        "description": "Symbol in syntax defined by the system",
+                        "pattern": "^[^\\s]+( [^\\s]+)*$"
+                      },
+                      "display": {
+                        "bsonType": "string",
+                        "description": "Representation defined by the system"
+                      },
+                      "system": {
+                        "bsonType": "string",
+                        "description": "Identity of the terminology system"
+                      },
+                      "userSelected": {
+                        "bsonType": "bool",
+                        "description": "If this coding was chosen directly by the user"
+                      },
+                      "version": {
+                        "bsonType": "string",
+                        "description": "Version of the system - if relevant"
+                      }
+                    }
+                  }
+                },
+                "text": {
+                  "bsonType": "string",
+                  "description": "Plain text representation of the concept"
+                }
+              }
+            },
+            "valueCoding": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "code": {
+                  "bsonType": "string",
+                  "description": "Symbol in syntax defined by the system",
+                  "pattern": "^[^\\s]+( [^\\s]+)*$"
+                },
+                "display": {
+                  "bsonType": "string",
+                  "description": "Representation defined by the system"
+                },
+                "system": {
+                  "bsonType": "string",
+                  "description": "Identity of the terminology system"
+                },
+                "userSelected": {
+                  "bsonType": "bool",
+                  "description": "If this coding was chosen directly by the user"
+                },
+                "version": {
+                  "bsonType": "string",
+                  "description": "Version of the system - if relevant"
+                }
+              }
+            },
+            "valueDateTime": {
+              "bsonType": [
+                "date",
+                "string"
+              ],
+              "description": "Value of extension",
+              "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+            },
+            "valueDecimal": {
+              "bsonType": "number",
+              "description": "Value of extension"
+            },
+            "valueInteger": {
+              "bsonType": [
+                "int",
+                "long"
+              ],
+              "description": "Value of extension"
+            },
+            "valueReference": {
+              "bsonType": "object",
+              "description": "Value of extension",
+              "properties": {
+                "display": {
+                  "bsonType": "string",
+                  "description": "Text alternative for the resource"
+                },
+                "identifier": {
+                  "bsonType": "object",
+                  "description": "Logical reference, when literal reference is not known",
+                  "properties": {
+                    "period": {
+                      "bsonType": "object",
+                      "description": "Time period when id is/was valid for use",
+                      "properties": {
+                        "end": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "End time with inclusive boundary, if not ongoing",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        },
+                        "start": {
+                          "bsonType": [
+                            "date",
+                            "string"
+                          ],
+                          "description": "Starting time with inclusive boundary",
+                          "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                        }
+                      }
+                    },
+                    "system": {
+                      "bsonType": "string",
+                      "description": "The namespace for the identifier value"
+                    },
+                    "type": {
+                      "bsonType": "object",
+                      "description": "Description of identifier",
+                      "properties": {
+                        "coding": {
+                          "bsonType": "array",
+                          "description": "Code defined by a terminology system",
+                          "items": {
+                            "bsonType": "object",
+                            "properties": {
+                              "code": {
+                                "bsonType": "string",
+                                "description": "Symbol in syntax defined by the system",
+                                "pattern": "^[^\\s]+( [^\\s]+)*$"
+                              },
+                              "display": {
+                                "bsonType": "string",
+                                "description": "Representation defined by the system"
+                              },
+                              "system": {
+                                "bsonType": "string",
+                                "description": "Identity of the terminology system"
+                              },
+                              "userSelected": {
+                                "bsonType": "bool",
+                                "description": "If this coding was chosen directly by the user"
+                              },
+                              "version": {
+                                "bsonType": "string",
+                                "description": "Version of the system - if relevant"
+                              }
+                            }
+                          }
+                        },
+                        "text": {
+                          "bsonType": "string",
+                          "description": "Plain text representation of the concept"
+                        }
+                      }
+                    },
+                    "use": {
+                      "bsonType": "string",
+                      "description": "usual | official | temp | secondary | old (If known)",
+                      "enum": [
+                        "usual",
+                        "official",
+                        "temp",
+                        "secondary",
+                        "old"
+                      ],
+                      "pattern": "^[^\\s]+( [^\\s]+)*$"
+                    },
+                    "value": {
+                      "bsonType": "string",
+                      "description": "The value that is unique"
+                    }
+                  }
+                },
+                "reference": {
+                  "bsonType": "string",
+                  "description": "Literal reference, Relative, internal or absolute URL"
+                },
+                "type": {
+                  "bsonType": "string",
+                  "description": "Type the reference refers to (e.g. \"Patient\")"
+                }
+              }
+            },
+            "valueString": {
+              "bsonType": "string",
+              "description": "Value of extension"
+            }
+          },
+          "required": [
+            "url"
+          ]
+        }
+      },
+      "name": {
+        "bsonType": "array",
+        "description": "The name(s) associated with the practitioner",
+        "items": {
+          "bsonType": "object",
+          "properties": {
+            "family": {
+              "bsonType": "string",
+              "description": "Family name (often called 'Surname')"
+            },
+            "given": {
+              "bsonType": "array",
+              "description": "Given names (not always 'first'). Includes middle names",
+              "items": {
+                "bsonType": "string"
+              }
+            },
+            "period": {
+              "bsonType": "object",
+              "description": "Time period when name was/is in use",
+              "properties": {
+                "end": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "End time with inclusive boundary, if not ongoing",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                },
+                "start": {
+                  "bsonType": [
+                    "date",
+                    "string"
+                  ],
+                  "description": "Starting time with inclusive boundary",
+                  "pattern": "^\\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\\d|3[01])(T([01]\\d|2[0-3]):[0-5]\\d:([0-5]\\d|60)(\\.\\d+)?(Z|[+-]((0\\d|1[0-3]):[0-5]\\d|14:00)))?)?)?$"
+                }
+              }
+            },
+            "prefix": {
+              "bsonType": "array",
+              "description": "Parts that come before the name",
+              "items": {
+                "bsonType": "string"
+              }
+            },
+            "suffix": {
+              "bsonType": "array",
+              "description": "Parts that come after the name",
+              "items": {
+                "bsonType": "string"
+              }
+            },
+            "text": {
+              "bsonType": "string",
+              "description": "Text representation of the full name"
+            },
+            "use": {
+              "bsonType": "string",
+              "description": "usual | official | temp | nickname | anonymous | old | maiden",
+              "enum": [
+                "usual",
+                "official",
+                "temp",
+                "nickname",
+                "anonymous",
+                "old",
+                "maiden"
+              ],
+              "pattern": "^[^\\s]+( [^\\s]+)*$"
+            }
+          }
+        }
+      },
+      "resourceType": {
+        "bsonType": "string",
+        "enum": [
+          "Practitioner"
+        ]
+      },
+      "supervisor": {
+        "bsonType": "object",
+        "description": "Practitioner responsible for this one"
+      },
+      "text": {
+        "bsonType": "object",
+        "description": "Text summary of the resource, for human interpretation",
+        "properties": {
+          "div": {
+            "bsonType": "string",
+            "description": "Limited xhtml content"
+          },
+          "status": {
+            "bsonType": "string",
+            "description": "generated | extensions | additional | empty",
+            "enum": [
+              "generated",
+              "extensions",
+              "additional",
+              "empty"
+            ],
+            "pattern": "^[^\\s]+( [^\\s]+)*$"
+          }
+        },
+        "required": [
+          "status",
+          "div"
+        ]
+      }
+    },
+    "required": [
+      "resourceType",
+      "id"
+    ],
+    "title": "Practitioner"
+  }
+});
@@ -0,0 +1,402 @@
+// Package neo4j generates Neo4j property-graph models from schemas: the
+// Cypher constraints and indexes of their nodes, and the Cypher statements
+// that load resources into nodes and their references into relationships.
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/ir"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
+)
+
+const Version = "0.1.0"
+
+// LoadDir is the directory, within the directory of a namespace, that holds
+// the load statements of its schemas.
+const LoadDir = "load"
+
+// ResourceLabel is the label every node of a FHIR resource with an id gets,
+// so that references resolve to it by its resourceType and id whichever
+// namespace defines it.
+const ResourceLabel = "Resource"
+
+// Generator generates Neo4j constraints and load statements from schemas.
+type Generator struct {
+	naming    config.Naming
+	audit     bool
+	header    *header.Header
+	templates templates.Cache
+}
+
+// NewGenerator creates a new Neo4j generator.
+func NewGenerator(cfg config.Config) *Generator {
+	return &Generator{naming: cfg.Language("neo4j").Naming, audit: cfg.Audit, header: header.New(cfg.Header, Version)}
+}
+
+// node is the node of a schema and how its records are loaded.
+type node struct {
+	Schema schema.Schema
+	Label  string
+	File   string
+	// Merge is the pattern records are merged on, or empty when the schema
+	// has no id or unique key and each record creates a node.
+	Merge         string
+	Properties    []property
+	Relationships []relationship
+	Constraints   []string
+}
+
+// property is a node property and the Cypher expression of its value in a
+// record.
+type property struct {
+	Name  string
+	Value string
+}
+
+// relationship is the relationship type of a reference field and the
+// Cypher expression of the list of its references in a record.
+type relationship struct {
+	Type       string
+	References string
+}
+
+// Generate writes, for each namespace, constraints.cypher, which creates the
+// uniqueness constraints and indexes of the nodes of its schemas, and a load
+// statement per schema under load/. Resources with an id are merged on
+// their resourceType and id under the Resource label, their primitive
+// fields become properties, and their Reference fields relationships, named
+// after the fields, to the resources they point to. Built-in datatypes get
+// no nodes.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	model, err := ir.Build(schemas)
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range model.Namespaces {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		namespace := ns.Name
+		var nodes []node
+		for _, s := range ns.Schemas {
+			if s.Builtin {
+				continue
+			}
+			if err := s.Source.CheckKeys(); err != nil {
+				return fmt.Errorf("%s: %w", s.Name, err)
+			}
+			nodes = append(nodes, g.node(s))
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		dir := filepath.Join(outputDir, g.NamespaceDir(namespace))
+		if err := os.MkdirAll(filepath.Join(dir, LoadDir), 0755); err != nil {
+			return fmt.Errorf("failed to create load directory: %w", err)
+		}
+		if err := g.generateConstraints(namespace, nodes, filepath.Join(dir, "constraints.cypher")); err != nil {
+			return err
+		}
+		for _, n := range nodes {
+			if err := g.generateLoad(n, filepath.Join(dir, LoadDir, n.File+".cypher")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// node returns the node of s.
+func (g *Generator) node(s *ir.Schema) node {
+	n := node{Schema: s.Source, Label: g.label(s.Source), File: naming.Snake.Apply(s.Name)}
+	fields := make(map[string]schema.Field)
+	for _, f := range s.StoredFields() {
+		fields[f.Name] = f.Source
+	}
+
+	// Records are merged on the fields of their key, which the merge
+	// pattern sets.
+	key := make(map[string]bool)
+	switch id, ok := fields["id"]; {
+	case s.Resource != "" && ok:
+		n.Merge = fmt.Sprintf("(n:%s {resourceType: %s, id: %s})", ResourceLabel, strconv.Quote(s.Resource), g.value(id))
+		key["id"] = true
+	case len(s.Unique) > 0:
+		var parts []string
+		for _, name := range s.Unique[0].Fields {
+			parts = append(parts, escape(g.propertyName(fields[name]))+": "+g.value(fields[name]))
+			key[name] = true
+		}
+		n.Merge = fmt.Sprintf("(n:%s {%s})", escape(n.Label), strings.Join(parts, ", "))
+	}
+
+	for _, f := range s.StoredFields() {
+		if f.Type.IsReference() {
+			references := "[r IN [" + g.value(f.Source) + "] WHERE r IS NOT NULL]"
+			if f.Type.List {
+				references = "coalesce(" + g.value(f.Source) + ", [])"
+			}
+			n.Relationships = append(n.Relationships, relationship{Type: escape(strings.ToUpper(naming.Snake.Apply(f.Name))), References: references})
+			continue
+		}
+		if !f.Type.Primitive || len(f.Children) > 0 || key[f.Name] {
+			// Properties hold primitives and lists of them only
+			continue
+		}
+		n.Properties = append(n.Properties, property{Name: escape(g.propertyName(f.Source)), Value: convert(f.Type.Name, g.value(f.Source), f.Type.List)})
+	}
+
+	// names returns the name of an index or unique key, derived from the
+	// node and its fields when it has none, and its properties.
+	names := func(ix schema.Index, suffix string) (string, string) {
+		name := ix.Name
+		var props []string
+		for _, field := range ix.Fields {
+			props = append(props, "n."+escape(g.propertyName(fields[field])))
+		}
+		if name == "" {
+			parts := []string{n.File}
+			for _, field := range ix.Fields {
+				parts = append(parts, naming.Snake.Apply(field))
+			}
+			name = strings.Join(append(parts, suffix), "_")
+		}
+		return escape(name), strings.Join(props, ", ")
+	}
+	for _, ix := range s.Unique {
+		name, props := names(ix, "key")
+		if len(ix.Fields) > 1 {
+			props = "(" + props + ")"
+		}
+		n.Constraints = append(n.Constraints, fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE %s IS UNIQUE;", name, escape(n.Label), props))
+	}
+	for _, ix := range s.Indexes {
+		name, props := names(ix, "idx")
+		n.Constraints = append(n.Constraints, fmt.Sprintf("CREATE INDEX %s IF NOT EXISTS FOR (n:%s) ON (%s);", name, escape(n.Label), props))
+	}
+	return n
+}
+
+// convert returns the Cypher expression of the property value of value, a
+// field of primitive fieldType in a record. Dates and times become temporal
+// values, which Cypher compares and indexes as such.
+func convert(fieldType, value string, list bool) string {
+	var function string
+	switch fieldType {
+	case "date":
+		function = "date"
+	case "datetime", "instant":
+		function = "datetime"
+	case "time":
+		function = "localtime"
+	default:
+		return value
+	}
+	if list {
+		return "[v IN " + value + " | " + function + "(v)]"
+	}
+	return function + "(" + value + ")"
+}
+
+// value returns the Cypher expression of f in a record, read by its JSON
+// name.
+func (g *Generator) value(f schema.Field) string {
+	return "record." + escape(f.WireName(f.Name))
+}
+
+// label returns the node label of s.
+func (g *Generator) label(s schema.Schema) string {
+	return naming.Case(g.naming.Types).Or(naming.Pascal.Apply)(s.GetName())
+}
+
+// propertyName returns the name of the node property of f: its json_name,
+// or else its name in the configured case.
+func (g *Generator) propertyName(f schema.Field) string {
+	return f.WireName(naming.Case(g.naming.Fields).Apply(f.Name))
+}
+
+// generateConstraints writes the constraints and indexes of the nodes of a
+// namespace.
+func (g *Generator) generateConstraints(namespace string, nodes []node, path string) error {
+	tmpl := `// Creates the constraints and indexes of the Neo4j nodes of the {{.Namespace}}
+// namespace. Rerunning it leaves existing ones as they are. Run it with
+// cypher-shell:
+//
+//   cypher-shell -f constraints.cypher
+//
+{{header}}
+{{- if .Resources}}
+// Resources are merged, and references resolved, on their type and id.
+CREATE CONSTRAINT resource_key IF NOT EXISTS FOR (n:{{.Label}}) REQUIRE (n.resourceType, n.id) IS UNIQUE;
+{{end}}
+{{- range .Nodes}}{{if .Constraints}}
+// {{.Schema.GetName}}{{with .Schema.Description}}: {{.}}{{end}}
+{{- range .Constraints}}
+{{.}}
+{{- end}}
+{{end}}{{end}}`
+	var sources []schema.Schema
+	resources := false
+	for _, n := range nodes {
+		sources = append(sources, n.Schema)
+		resources = resources || strings.HasPrefix(n.Merge, "(n:"+ResourceLabel+" ")
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("// ", sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace string
+		Label     string
+		Resources bool
+		Nodes     []node
+	}{
+		Namespace: namespace,
+		Label:     ResourceLabel,
+		Resources: resources,
+		Nodes:     nodes,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generateLoad writes the statement that loads records of a schema, given
+// as the $records parameter, into nodes and relationships.
+func (g *Generator) generateLoad(n node, path string) error {
+	tmpl := `// Loads {{.Schema.GetName}} records into {{.Label}} nodes{{if .Relationships}}, and their references into
+// relationships{{end}}.
+{{- if .Merge}}
+// Loading a record again updates its node.
+{{- else}}
+// Each record creates a node, so load a record once.
+{{- end}}
+//
+// The records are the $records parameter, a list of maps of their JSON.
+{{- if .Relationships}}
+// Literal references, relative or absolute and maybe versioned, such as
+// Patient/123/_history/2, point to the node of that type and id, which is
+// created until the resource is loaded; contained and logical references
+// are left out.
+{{- end}}
+//
+// Run it in batches with a driver, or with cypher-shell:
+//
+//   cypher-shell --param 'records => [...]' -f {{.File}}.cypher
+//
+{{header}}
+UNWIND $records AS record
+{{- if .Merge}}
+MERGE {{.Merge}}
+{{- else}}
+CREATE (n:{{escape .Label}})
+{{- end}}
+{{- with .Set}}
+SET {{join . ",\n    "}}
+{{- end}}
+{{- range .Relationships}}
+CALL {
+  WITH n, record
+  UNWIND {{.References}} AS reference
+  WITH n, split(split(reference.reference, "/_history/")[0], "/") AS parts
+  WHERE size(parts) >= 2
+  MERGE (target:{{$.Resource}} {resourceType: parts[-2], id: parts[-1]})
+  MERGE (n)-[:{{.Type}}]->(target)
+}
+{{- end}};
+`
+	var set []string
+	if strings.HasPrefix(n.Merge, "(n:"+ResourceLabel+" ") {
+		set = append(set, "n:"+escape(n.Label))
+	}
+	for _, p := range n.Properties {
+		set = append(set, "n."+p.Name+" = "+p.Value)
+	}
+	data := struct {
+		node
+		Set      []string
+		Resource string
+	}{
+		node:     n,
+		Set:      set,
+		Resource: ResourceLabel,
+	}
+	funcMap := template.FuncMap{
+		"join":   strings.Join,
+		"escape": escape,
+		"header": g.header.Func("// ", n.Schema),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// identifier matches the names Cypher takes without backticks.
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// keywords are the Cypher keywords names are quoted apart from.
+var keywords = map[string]bool{
+	"ALL": true, "AND": true, "AS": true, "ASC": true, "BY": true, "CALL": true,
+	"CASE": true, "CONSTRAINT": true, "CONTAINS": true, "CREATE": true,
+	"DELETE": true, "DESC": true, "DETACH": true, "DISTINCT": true, "DROP": true,
+	"ELSE": true, "END": true, "ENDS": true, "EXISTS": true, "FALSE": true,
+	"FOR": true, "IN": true, "INDEX": true, "IS": true, "LIMIT": true,
+	"MATCH": true, "MERGE": true, "NOT": true, "NULL": true, "ON": true,
+	"OPTIONAL": true, "OR": true, "ORDER": true, "REMOVE": true, "RETURN": true,
+	"SET": true, "SKIP": true, "STARTS": true, "THEN": true, "TRUE": true,
+	"UNION": true, "UNWIND": true, "WHEN": true, "WHERE": true, "WITH": true,
+	"XOR": true, "YIELD": true,
+}
+
+// escape quotes name with backticks when Cypher would not read it as a
+// name otherwise.
+func escape(name string) string {
+	if identifier.MatchString(name) && !keywords[strings.ToUpper(name)] {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
+// GenerateMappings generates nothing: mappings have no nodes.
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
+	return nil
+}
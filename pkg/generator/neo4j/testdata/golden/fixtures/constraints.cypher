@@ -0,0 +1,15 @@
+// Creates the constraints and indexes of the Neo4j nodes of the fixtures
+// namespace. Rerunning it leaves existing ones as they are. Run it with
+// cypher-shell:
+//
+//   cypher-shell -f constraints.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+// Resources are merged, and references resolved, on their type and id.
+CREATE CONSTRAINT resource_key IF NOT EXISTS FOR (n:Resource) REQUIRE (n.resourceType, n.id) IS UNIQUE;
+
+// lab_result: Résultat de laboratoire — température °C, 检验结果
+CREATE CONSTRAINT lab_result_result_id_key IF NOT EXISTS FOR (n:LabResult) REQUIRE n.result_id IS UNIQUE;
+CREATE INDEX lab_result_mrn_resulted_at_idx IF NOT EXISTS FOR (n:LabResult) ON (n.mrn, n.resulted_at);
@@ -0,0 +1,21 @@
+// Loads lab_result records into LabResult nodes.
+// Loading a record again updates its node.
+//
+// The records are the $records parameter, a list of maps of their JSON.
+//
+// Run it in batches with a driver, or with cypher-shell:
+//
+//   cypher-shell --param 'records => [...]' -f lab_result.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+UNWIND $records AS record
+MERGE (n:LabResult {result_id: record.result_id})
+SET n.mrn = record.mrn,
+    n.value_numeric = record.value_numeric,
+    n.charge_amount = record.charge_amount,
+    n.resulted_at = datetime(record.resulted_at),
+    n.rawPayload = record.rawPayload,
+    n.ICD10Code = record.ICD10Code,
+    n.reviewer_ids = record.reviewer_ids;
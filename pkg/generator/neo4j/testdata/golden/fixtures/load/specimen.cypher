@@ -0,0 +1,43 @@
+// Loads Specimen records into Specimen nodes, and their references into
+// relationships.
+// Loading a record again updates its node.
+//
+// The records are the $records parameter, a list of maps of their JSON.
+// Literal references, relative or absolute and maybe versioned, such as
+// Patient/123/_history/2, point to the node of that type and id, which is
+// created until the resource is loaded; contained and logical references
+// are left out.
+//
+// Run it in batches with a driver, or with cypher-shell:
+//
+//   cypher-shell --param 'records => [...]' -f specimen.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+UNWIND $records AS record
+MERGE (n:Resource {resourceType: "Specimen", id: record.id})
+SET n:Specimen,
+    n.status = record.status,
+    n.receivedTime = datetime(record.receivedTime),
+    n.collectedDate = date(record.collectedDate),
+    n.quantity = record.quantity,
+    n.containerCount = record.containerCount,
+    n.fasting = record.fasting,
+    n.note = record.note
+CALL {
+  WITH n, record
+  UNWIND [r IN [record.subject] WHERE r IS NOT NULL] AS reference
+  WITH n, split(split(reference.reference, "/_history/")[0], "/") AS parts
+  WHERE size(parts) >= 2
+  MERGE (target:Resource {resourceType: parts[-2], id: parts[-1]})
+  MERGE (n)-[:SUBJECT]->(target)
+}
+CALL {
+  WITH n, record
+  UNWIND coalesce(record.request, []) AS reference
+  WITH n, split(split(reference.reference, "/_history/")[0], "/") AS parts
+  WHERE size(parts) >= 2
+  MERGE (target:Resource {resourceType: parts[-2], id: parts[-1]})
+  MERGE (n)-[:REQUEST]->(target)
+};
@@ -0,0 +1,34 @@
+// Loads Visit records into Visit nodes, and their references into
+// relationships.
+// Each record creates a node, so load a record once.
+//
+// The records are the $records parameter, a list of maps of their JSON.
+// Literal references, relative or absolute and maybe versioned, such as
+// Patient/123/_history/2, point to the node of that type and id, which is
+// created until the resource is loaded; contained and logical references
+// are left out.
+//
+// Run it in batches with a driver, or with cypher-shell:
+//
+//   cypher-shell --param 'records => [...]' -f visit.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+UNWIND $records AS record
+CREATE (n:Visit)
+SET n.`match` = record.`match`,
+    n.`order` = record.`order`,
+    n.self = record.self,
+    n.`2ndOpinion` = record.`2ndOpinion`,
+    n.priorityCode = record.priorityCode,
+    n.priority_code = record.priority_code,
+    n.visit = record.visit
+CALL {
+  WITH n, record
+  UNWIND [r IN [record.`for`] WHERE r IS NOT NULL] AS reference
+  WITH n, split(split(reference.reference, "/_history/")[0], "/") AS parts
+  WHERE size(parts) >= 2
+  MERGE (target:Resource {resourceType: parts[-2], id: parts[-1]})
+  MERGE (n)-[:`FOR`]->(target)
+};
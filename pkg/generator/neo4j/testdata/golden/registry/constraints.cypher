@@ -0,0 +1,11 @@
+// Creates the constraints and indexes of the Neo4j nodes of the registry
+// namespace. Rerunning it leaves existing ones as they are. Run it with
+// cypher-shell:
+//
+//   cypher-shell -f constraints.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+// Resources are merged, and references resolved, on their type and id.
+CREATE CONSTRAINT resource_key IF NOT EXISTS FOR (n:Resource) REQUIRE (n.resourceType, n.id) IS UNIQUE;
@@ -0,0 +1,17 @@
+// Loads Practitioner records into Practitioner nodes.
+// Loading a record again updates its node.
+//
+// The records are the $records parameter, a list of maps of their JSON.
+//
+// Run it in batches with a driver, or with cypher-shell:
+//
+//   cypher-shell --param 'records => [...]' -f practitioner.cypher
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+UNWIND $records AS record
+MERGE (n:Resource {resourceType: "Practitioner", id: record.id})
+SET n:Practitioner,
+    n.implicitRules = record.implicitRules,
+    n.language = record.language;
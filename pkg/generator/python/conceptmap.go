@@ -0,0 +1,71 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// generateConceptMaps writes the functions that translate codes by the
+// concept maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, path string) error {
+	type table struct {
+		Name        string
+		Description string
+		Const       string
+		Func        string
+		Sources     []conceptmap.Source
+	}
+	var tables []table
+	for _, m := range maps {
+		snake := naming.Snake.Apply(m.Name)
+		tables = append(tables, table{
+			Name:        m.Name,
+			Description: strings.Join(strings.Fields(m.Description), " "),
+			Const:       "_" + strings.ToUpper(snake),
+			Func:        "translate_" + snake,
+			Sources:     m.Sources(),
+		})
+	}
+
+	tmpl := `"""Translations of codes by concept maps.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from typing import NamedTuple
+
+
+class ConceptMapTarget(NamedTuple):
+    """A code that a concept map translates a source code to, and how equivalent the two are."""
+
+    system: str
+    code: str
+    display: str
+    equivalence: str
+{{range .}}
+
+# The concept map {{.Name}}, keyed by source system and code.
+{{.Const}}: dict[tuple[str, str], tuple[ConceptMapTarget, ...]] = {
+{{- range .Sources}}
+    ({{quote .System}}, {{quote .Code}}): (
+{{- range .Translations}}
+        ConceptMapTarget({{quote .System}}, {{quote .Code}}, {{quote .Display}}, {{quote .Equivalence}}),
+{{- end}}
+    ),
+{{- end}}
+}
+
+
+def {{.Func}}(system: str, code: str) -> list[ConceptMapTarget]:
+    """Return the codes that code of system translates to by the concept map {{.Name}}, if any.{{with .Description}}
+
+    {{.}}
+    {{end}}"""
+    return list({{.Const}}.get((system, code), ()))
+{{- end}}
+`
+	return g.executeTemplate(tmpl, g.header.Func(""), tables, path)
+}
@@ -0,0 +1,132 @@
+package python
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// derivedProperty is the property computing a derived field: its name and
+// result type, the fields it needs set, the partial dates it needs known to
+// the day, and the expression it returns.
+type derivedProperty struct {
+	Field    schema.Field
+	Ident    string
+	Type     string
+	Unset    []string
+	Partials []naming.Field
+	Expr     string
+}
+
+// derivedProperties returns the properties of the derived fields of s, and
+// the helper functions they call.
+func (g *Generator) derivedProperties(s schema.Schema) ([]derivedProperty, []string, error) {
+	derived, err := s.DerivedFields()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	idents := make(map[string]naming.Field)
+	for _, f := range g.allFields(s) {
+		idents[f.Name] = f
+	}
+	var properties []derivedProperty
+	var helpers []string
+	for _, d := range derived {
+		p := derivedProperty{Field: d.Field, Ident: idents[d.Field.Name].Ident, Type: "str"}
+		switch d.Field.ValueKind() {
+		case "integer":
+			p.Type = "int"
+		case "decimal":
+			p.Type = g.decimalType
+		}
+		for _, name := range d.Expr.Fields() {
+			f := idents[name]
+			if !f.Required {
+				p.Unset = append(p.Unset, "self."+f.Ident+" is None")
+			}
+			if g.partial(f) {
+				p.Partials = append(p.Partials, f)
+			}
+		}
+		if len(p.Unset) > 0 || len(p.Partials) > 0 {
+			p.Type += " | None"
+		}
+		p.Expr = g.pythonExpr(d.Expr, idents)
+		for _, fn := range d.Expr.Functions() {
+			if fn != "concat" && !contains(helpers, fn) {
+				helpers = append(helpers, fn)
+			}
+		}
+		properties = append(properties, p)
+	}
+	return properties, helpers, nil
+}
+
+// pythonExpr returns e as a Python expression over the fields of self.
+// Dividing integers yields a Decimal where decimals are exact.
+func (g *Generator) pythonExpr(e schema.Expr, idents map[string]naming.Field) string {
+	arg := func(i int) string {
+		a := g.pythonExpr(e.Args[i], idents)
+		if isOperator(e.Args[i].Op) && (isOperator(e.Op) || e.Op == "neg") {
+			return "(" + a + ")"
+		}
+		return a
+	}
+	switch e.Op {
+	case "number":
+		if e.Kind == "decimal" && g.decimalType == "Decimal" {
+			return "Decimal(" + strconv.Quote(e.Value) + ")"
+		}
+		return e.Value
+	case "text":
+		return strconv.Quote(e.Value)
+	case "field":
+		f := idents[e.Value]
+		if g.partial(f) {
+			return f.Ident
+		}
+		return "self." + f.Ident
+	case "neg":
+		return "-" + arg(0)
+	case "+", "-", "*", "/":
+		left := arg(0)
+		if e.Op == "/" && g.decimalType == "Decimal" && e.Args[0].Kind == "integer" && e.Args[1].Kind == "integer" {
+			left = "Decimal(" + g.pythonExpr(e.Args[0], idents) + ")"
+		}
+		return left + " " + e.Op + " " + arg(1)
+	case "years_since":
+		return "_years_since(" + arg(0) + ")"
+	case "days_between":
+		return "_days_between(" + arg(0) + ", " + arg(1) + ")"
+	}
+	var args []string
+	for i := range e.Args {
+		args = append(args, arg(i))
+	}
+	return strings.Join(args, " + ")
+}
+
+// partial reports whether f is a partial date, whose date is known only
+// when it is known to the day.
+func (g *Generator) partial(f naming.Field) bool {
+	return f.Type == "date" && g.dateType == "PartialDate"
+}
+
+// isOperator reports whether op is an arithmetic operator, whose operands
+// are parenthesized when they are operations too.
+func isOperator(op string) bool {
+	return op == "+" || op == "-" || op == "*" || op == "/"
+}
+
+// contains reports whether values holds value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
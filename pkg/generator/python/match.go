@@ -0,0 +1,257 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// matchCall is the call of match_keys that the match_keys method of a model
+// returns: the Python expressions of the components of its demographic key
+// and of its identifiers, and the helpers it imports.
+type matchCall struct {
+	Demographic []string
+	Identifiers []string
+	Helpers     []string
+}
+
+// matchCall returns the match_keys call of s, or nil when s has no fields
+// that make matching keys. Schemas with invalid match roles are reported by
+// matchedSchemas before models are generated.
+func (g *Generator) matchCall(s schema.Schema) *matchCall {
+	if datatypes.IsBuiltin(s) {
+		return nil
+	}
+	matched, err := s.MatchFields()
+	if err != nil || matched.Empty() {
+		return nil
+	}
+	idents := make(map[string]string)
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f.Ident
+	}
+	call := &matchCall{Helpers: []string{"match_keys"}}
+	for _, f := range matched.Demographic {
+		helper := "name_key"
+		switch f.Match {
+		case schema.MatchBirthDate:
+			helper = "date_key"
+		case schema.MatchSex:
+			helper = "sex_key"
+		}
+		if !contains(call.Helpers, helper) {
+			call.Helpers = append(call.Helpers, helper)
+		}
+		call.Demographic = append(call.Demographic, helper+"(self."+idents[f.Name]+")")
+	}
+	for _, f := range matched.Identifiers {
+		call.Identifiers = append(call.Identifiers, "("+strconv.Quote(f.Name)+", self."+idents[f.Name]+")")
+	}
+	sort.Strings(call.Helpers)
+	return call
+}
+
+// matchedSchemas returns the schemas of a namespace with fields that make
+// matching keys.
+func (g *Generator) matchedSchemas(schemas []schema.Schema) ([]schema.Schema, error) {
+	var matched []schema.Schema
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		m, err := s.MatchFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if !m.Empty() {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
+
+// generateMatch writes the helpers that the match_keys methods of models
+// make patient matching keys with. Every generator makes the same keys of
+// the same records.
+func (g *Generator) generateMatch(schemas []schema.Schema, path string) error {
+	tmpl := `"""Patient matching keys, which records that are candidate matches share.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from typing import Any
+{{- if eq .Phonetic "soundex"}}
+
+# The Soundex digits of the letters A to Z.
+_SOUNDEX_CODES = "01230120022455012623010202"
+{{- end}}
+
+
+def match_keys(components: list[str], identifiers: list[tuple[str, str | None]]) -> list[str]:
+    """Return the demographic key made of components, unless there are none or one is empty, and the key of each identifier, a name and a value, whose normalized value is not empty."""
+    keys: list[str] = []
+    if components and all(components):
+        keys.append("demographics:" + "|".join(components))
+    for name, value in identifiers:
+        normalized = _normalize(value or "", digits=True)
+        if normalized:
+            keys.append(f"{name}:{normalized}")
+    return keys
+
+
+def name_key(name: str | None) -> str:
+    """Return the key component of a name: {{if eq .Phonetic "soundex"}}the Soundex code of its letters{{else if eq .Phonetic "metaphone"}}the Metaphone code of its letters{{else}}its letters{{end}}."""
+    return {{if .Phonetic}}_{{.Phonetic}}({{end}}_normalize(name or ""){{if .Phonetic}}){{end}}
+
+
+def date_key(value: Any) -> str:
+    """Return the key component of a date, as much of it as is known."""
+    return "" if value is None else str(value)
+
+
+def sex_key(sex: str | None) -> str:
+    """Return the key component of a sex: its first letter."""
+    return _normalize(sex or "")[:1]
+
+
+def _normalize(value: str, digits: bool = False) -> str:
+    """Return the letters A to Z of value, and its digits too when digits is set, in upper case."""
+    return "".join(c.upper() for c in value if "A" <= c <= "Z" or "a" <= c <= "z" or (digits and "0" <= c <= "9"))
+{{- if eq .Phonetic "soundex"}}
+
+
+def _soundex(name: str) -> str:
+    """Return the American Soundex code of name, which holds letters A to Z only: its first letter and the digits of the next consonants, which H and W do not separate, padded to four."""
+    if not name:
+        return ""
+    code = name[0]
+    last = _SOUNDEX_CODES[ord(name[0]) - ord("A")]
+    for c in name[1:]:
+        if len(code) == 4:
+            break
+        digit = _SOUNDEX_CODES[ord(c) - ord("A")]
+        if c in "HW":
+            continue
+        if digit == "0":
+            last = digit
+        elif digit != last:
+            code += digit
+            last = digit
+    return code.ljust(4, "0")
+{{- end}}
+{{- if eq .Phonetic "metaphone"}}
+
+
+def _metaphone(name: str) -> str:
+    """Return the Metaphone code of name, which holds letters A to Z only, by the rules of the original algorithm: initial letters that are silent or kept, then consonants by their sound, and no vowels after the first letter."""
+
+    def at(i: int) -> str:
+        return name[i] if 0 <= i < len(name) else ""
+
+    def vowel(c: str) -> bool:
+        return c != "" and c in "AEIOU"
+
+    def soft(c: str) -> bool:
+        return c != "" and c in "EIY"
+
+    code = ""
+    i = 0
+    first, second = at(0), at(1)
+    if first == "A":
+        code, i = ("E", 2) if second == "E" else ("A", 1)
+    elif first in ("G", "K", "P") and second == "N":
+        code, i = "N", 2
+    elif first == "W" and second == "R":
+        code, i = "R", 2
+    elif first == "W" and (second == "H" or vowel(second)):
+        code, i = "W", 2
+    elif first == "X":
+        code, i = "S", 1
+    elif first in ("E", "I", "O", "U"):
+        code, i = first, 1
+    while i < len(name):
+        c, prev, ahead, after = name[i], at(i - 1), at(i + 1), at(i + 2)
+        i += 1
+        if c == prev and c != "C":
+            continue
+        if c == "B":
+            if prev != "M":
+                code += "B"
+        elif c == "C":
+            if ahead == "I" and after == "A":
+                code += "X"
+            elif soft(ahead):
+                if prev != "S":
+                    code += "S"
+            elif ahead == "H":
+                code += "X"
+                i += 1
+            else:
+                code += "K"
+        elif c == "D":
+            if ahead == "G" and soft(after):
+                code += "J"
+                i += 1
+            else:
+                code += "T"
+        elif c == "G":
+            if ahead == "H":
+                if not (at(i - 4) in ("B", "D", "H") or at(i - 5) == "H"):
+                    code += "F"
+                    i += 1
+            elif ahead == "N":
+                if after != "" and not (after == "E" and at(i + 2) == "D"):
+                    code += "K"
+            elif soft(ahead) and prev != "G":
+                code += "J"
+            else:
+                code += "K"
+        elif c == "H":
+            if vowel(ahead) and (prev == "" or prev not in "CGPST"):
+                code += "H"
+        elif c == "K":
+            if prev != "C":
+                code += "K"
+        elif c == "P":
+            code += "F" if ahead == "H" else "P"
+        elif c == "Q":
+            code += "K"
+        elif c == "S":
+            if ahead == "I" and after in ("O", "A"):
+                code += "X"
+            elif ahead == "H":
+                code += "X"
+                i += 1
+            else:
+                code += "S"
+        elif c == "T":
+            if ahead == "I" and after in ("O", "A"):
+                code += "X"
+            elif ahead == "H":
+                code += "0"
+                i += 1
+            elif not (ahead == "C" and after == "H"):
+                code += "T"
+        elif c == "V":
+            code += "F"
+        elif c in "WY":
+            if vowel(ahead):
+                code += c
+        elif c == "X":
+            code += "KS"
+        elif c == "Z":
+            code += "S"
+        elif c in "FJLMNR":
+            code += c
+    return code
+{{- end}}
+`
+	data := struct {
+		Phonetic string
+	}{Phonetic: g.phonetic}
+	return g.executeTemplate(tmpl, g.header.Func("", schemas...), data, path)
+}
@@ -0,0 +1,224 @@
+package python
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// migrator is a function that migrates the records of one level of a
+// changed schema, the top level or the fields of a nested field.
+type migrator struct {
+	Name    string
+	Doc     string
+	Removed []string
+	Renamed [][2]string
+	// Converted are the fields whose values convert to their new type,
+	// keyed by field, and Kept those whose values stay as they are.
+	Converted [][2]string
+	Kept      []string
+	Filled    [][2]string
+	// Missing are the required fields that have no default to fill.
+	Missing []string
+	Nested  [][2]string
+}
+
+// WriteMigration writes a module of functions that migrate records, as
+// decoded from JSON, from the old to the new version of their changed
+// schemas: migrate_<namespace>_<schema> per schema. They drop removed
+// fields, rename renamed ones, convert values to changed types where
+// Python can, and fill fields with their defaults, in nested records too.
+func (g *Generator) WriteMigration(w io.Writer, changes []diff.Change) error {
+	var sources []schema.Schema
+	var order []string
+	byPath := make(map[string]*migrator)
+	// level returns the migrator of path, creating it and those of its
+	// parents, which call it, as needed.
+	var level func(c diff.Change, path []string) *migrator
+	level = func(c diff.Change, path []string) *migrator {
+		parts := []string{"migrate", naming.Snake.Apply(c.Namespace()), naming.Snake.Apply(c.Schema())}
+		for _, p := range path {
+			parts = append(parts, naming.Snake.Apply(p))
+		}
+		key := strings.Join(parts, "_")
+		if m, ok := byPath[key]; ok {
+			return m
+		}
+		m := &migrator{Name: key, Doc: fmt.Sprintf("Return a %s.%s record of the old version in the shape of the new one.", c.Namespace(), c.Schema())}
+		if len(path) > 0 {
+			m.Name = "_" + key
+			m.Doc = fmt.Sprintf("Migrate a %s.%s.%s record.", c.Namespace(), c.Schema(), strings.Join(path, "."))
+			parent := level(c, path[:len(path)-1])
+			parent.Nested = append(parent.Nested, [2]string{path[len(path)-1], m.Name})
+		} else {
+			sources = append(sources, *c.New)
+		}
+		byPath[key] = m
+		order = append(order, key)
+		return m
+	}
+
+	exact := false
+	for _, c := range changes {
+		if c.Old == nil || c.New == nil {
+			continue
+		}
+		m := level(c, c.Path)
+		switch c.Kind {
+		case diff.FieldRemoved:
+			m.Removed = append(m.Removed, c.OldField.Name)
+		case diff.FieldRenamed:
+			m.Renamed = append(m.Renamed, [2]string{c.OldField.Name, c.NewField.Name})
+		case diff.TypeChanged:
+			convert := g.conversion(*c.OldField, *c.NewField)
+			if convert == "" {
+				m.Kept = append(m.Kept, fmt.Sprintf("%s (%s to %s)", c.NewField.Name, c.OldField.Type, c.NewField.Type))
+				break
+			}
+			exact = exact || convert == "_decimal"
+			m.Converted = append(m.Converted, [2]string{c.NewField.Name, convert})
+		case diff.FieldAdded, diff.RequiredChanged:
+			f := c.NewField
+			switch {
+			case f.Default != "":
+				m.Filled = append(m.Filled, [2]string{f.Name, pythonLiteral(*f)})
+			case f.Required:
+				m.Missing = append(m.Missing, f.Name)
+			}
+		}
+	}
+	var migrators []*migrator
+	for _, key := range order {
+		migrators = append(migrators, byPath[key])
+	}
+
+	tmpl := `"""Migrations of records from the old to the new version of changed schemas.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+{{- if .Exact}}
+from decimal import Decimal
+{{- end}}
+from typing import Any
+{{range .Migrators}}
+
+def {{.Name}}(record: dict[str, Any]) -> dict[str, Any]:
+    """{{.Doc}}"""
+    record = dict(record)
+{{- range .Removed}}
+    record.pop({{quote .}}, None)
+{{- end}}
+{{- if .Renamed}}
+    renamed = [{{range $i, $r := .Renamed}}{{if $i}}, {{end}}({{index $r 0 | quote}}, {{index $r 1 | quote}}){{end}}]
+    record.update({new: record.pop(old) for old, new in renamed if old in record})
+{{- end}}
+{{- range .Converted}}
+    if record.get({{index . 0 | quote}}) is not None:
+        record[{{index . 0 | quote}}] = {{index . 1}}(record[{{index . 0 | quote}}])
+{{- end}}
+{{- range .Kept}}
+    # {{.}}: values are kept as they are.
+{{- end}}
+{{- range .Filled}}
+    if record.get({{index . 0 | quote}}) is None:
+        record[{{index . 0 | quote}}] = {{index . 1}}
+{{- end}}
+{{- range .Missing}}
+    # {{.}} is required but has no default to fill.
+{{- end}}
+{{- range .Nested}}
+    if record.get({{index . 0 | quote}}) is not None:
+        record[{{index . 0 | quote}}] = _each(record[{{index . 0 | quote}}], {{index . 1}})
+{{- end}}
+    return record
+{{end}}
+{{- if .Exact}}
+
+def _decimal(value: Any) -> Decimal:
+    return Decimal(str(value))
+{{end}}
+
+def _each(value: Any, migrate: Callable[[dict[str, Any]], dict[str, Any]]) -> Any:
+    """Migrate a nested record, or each of a list of them."""
+    if isinstance(value, list):
+        return [migrate(item) for item in value]
+    return migrate(value)
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"header": g.header.Func("", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	data := struct {
+		Migrators []*migrator
+		Exact     bool
+	}{
+		Migrators: migrators,
+		Exact:     exact,
+	}
+
+	return tmpl_parsed.Execute(w, data)
+}
+
+// conversion returns the function that converts values of the old field to
+// the type of the new one, or "" when they are kept as they are.
+func (g *Generator) conversion(old, new schema.Field) string {
+	switch new.DefaultKind() {
+	case "integer":
+		return "int"
+	case "decimal":
+		if g.decimalType == "Decimal" {
+			return "_decimal"
+		}
+		return "float"
+	case "text":
+		if old.DefaultKind() != "text" {
+			return "str"
+		}
+	}
+	return ""
+}
+
+// defaultLiteral returns the default of f as a Python literal of the type
+// generated for it, or "" when it has none.
+func (g *Generator) defaultLiteral(f schema.Field) string {
+	if f.Default == "" {
+		return ""
+	}
+	if f.DefaultKind() == "decimal" {
+		if g.decimalType == "Decimal" {
+			return "Decimal(" + strconv.Quote(f.Default) + ")"
+		}
+		value, _ := strconv.ParseFloat(f.Default, 64)
+		return datatypes.FloatLiteral(value)
+	}
+	return pythonLiteral(f)
+}
+
+// pythonLiteral returns the default of f as a Python literal.
+func pythonLiteral(f schema.Field) string {
+	switch f.DefaultKind() {
+	case "integer", "decimal":
+		return f.Default
+	case "boolean":
+		if b, _ := strconv.ParseBool(f.Default); b {
+			return "True"
+		}
+		return "False"
+	}
+	return strconv.Quote(f.Default)
+}
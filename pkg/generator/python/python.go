@@ -2,36 +2,87 @@
 package python
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/crosswalk"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
 // Generator generates Python code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	decimalType string
+	dateType    string
+	ucum        bool
+	narrative   bool
+	triState    bool
+	phonetic    string
+	crosswalk   string
+	conceptMaps []string
+	redact      string
+	pseudonym   config.Pseudonymize
+	hashing     config.Hashing
+	audit       bool
+	pkg         config.Package
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Python code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("python").Naming, decimalType: "float", dateType: "date", ucum: cfg.UCUM, narrative: cfg.Narrative, triState: cfg.TriState, phonetic: cfg.Matching.Phonetic, crosswalk: cfg.CrosswalkFile(), conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), pseudonym: cfg.Pseudonymization(), hashing: cfg.Hashing, audit: cfg.Audit, pkg: cfg.PackageInfo("python"), header: header.New(cfg.Header, Version)}
+	if cfg.DecimalMode("python") == config.DecimalExact {
+		g.decimalType = "Decimal"
+	}
+	if cfg.TemporalPolicy("python").Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
 // Generate generates Python dataclasses from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	var crosswalks map[string][]crosswalk.Field
+	if g.crosswalk != "" {
+		if crosswalks, err = crosswalk.LoadFields(g.crosswalk, schemas); err != nil {
+			return err
+		}
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
@@ -42,11 +93,103 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 			return err
 		}
 
+		if g.dateType == "PartialDate" {
+			if err := g.generateTemporal(filepath.Join(nsDir, "_temporal.py")); err != nil {
+				return err
+			}
+		}
+
+		var encrypted []schema.Schema
+		for _, s := range nsSchemas {
+			if !s.HasEncrypted() {
+				continue
+			}
+			if _, err := s.EncryptedFields(); err != nil {
+				return fmt.Errorf("%s: %w", s.GetName(), err)
+			}
+			encrypted = append(encrypted, s)
+		}
+		if len(encrypted) > 0 {
+			if err := g.generateEncryption(encrypted, filepath.Join(nsDir, "_encryption.py")); err != nil {
+				return err
+			}
+		}
+
+		var pseudonymized []schema.Schema
+		for _, s := range nsSchemas {
+			if s.HasSensitive(g.pseudonym.Level) {
+				pseudonymized = append(pseudonymized, s)
+			}
+		}
+		if len(pseudonymized) > 0 {
+			if err := g.generatePseudonym(pseudonymized, filepath.Join(nsDir, "_pseudonym.py")); err != nil {
+				return err
+			}
+		}
+
+		for _, s := range nsSchemas {
+			if len(g.canonicalFields(s)) > 0 {
+				if err := g.generateCanonical(filepath.Join(nsDir, "_canonical.py")); err != nil {
+					return err
+				}
+				break
+			}
+		}
+
+		for _, s := range nsSchemas {
+			if len(g.optionalFields(s)) > 0 {
+				if err := g.generateUnset(filepath.Join(nsDir, "_unset.py")); err != nil {
+					return err
+				}
+				break
+			}
+		}
+
+		for _, s := range nsSchemas {
+			if g.narrated(s) {
+				if err := g.generateNarrative(filepath.Join(nsDir, "_narrative.py")); err != nil {
+					return err
+				}
+				break
+			}
+		}
+
+		matched, err := g.matchedSchemas(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(matched) > 0 {
+			if err := g.generateMatch(matched, filepath.Join(nsDir, "_match.py")); err != nil {
+				return err
+			}
+		}
+
 		// Generate each schema file
 		for _, s := range nsSchemas {
-			filename := strings.ToLower(s.GetName()) + ".py"
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			filename := g.moduleName(s) + ".py"
 			path := filepath.Join(nsDir, filename)
-			if err := g.generateSchema(s, path); err != nil {
+			if err := g.generateSchema(refs.Scope(namespace), s, path); err != nil {
+				return err
+			}
+
+			if g.ucum && datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+				if err := g.generateUCUM(s, filepath.Join(nsDir, "ucum.py")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if fields := crosswalks[namespace]; len(fields) > 0 {
+			if err := g.generateCrosswalk(fields, filepath.Join(nsDir, "crosswalk.py")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, filepath.Join(nsDir, "conceptmaps.py")); err != nil {
 				return err
 			}
 		}
@@ -55,13 +198,51 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 	return nil
 }
 
-func (g *Generator) generateInit(schemas []schema.Schema, path string) error {
-	tmpl := `"""Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
+// Package writes a pyproject.toml that installs the generated packages.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if err := g.pkg.Validate(); err != nil {
+		return err
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+
+	var packages []string
+	for _, namespace := range refs.Namespaces() {
+		packages = append(packages, g.packageName(namespace))
+	}
+	sort.Strings(packages)
+
+	tmpl := `# Generated by ehrglot v` + Version + `.
+
+[build-system]
+requires = ["setuptools>=61"]
+build-backend = "setuptools.build_meta"
 
-DO NOT EDIT - This file is auto-generated from YAML schemas.
-"""
+[project]
+name = {{quote .Package.Name}}
+version = {{quote .Package.Version}}
+{{- with .Package.Description}}
+description = {{quote .}}
+{{- end}}
+requires-python = ">=3.9"
+
+[tool.setuptools]
+packages = [{{range $i, $p := .Packages}}{{if $i}}, {{end}}{{quote $p}}{{end}}]
+`
+	data := struct {
+		Package  config.Package
+		Packages []string
+	}{Package: g.pkg, Packages: packages}
 
-{{range .Schemas}}from .{{. | schemaName | lower}} import {{. | schemaName}}
+	return g.executeTemplate(tmpl, nil, data, filepath.Join(outputDir, "pyproject.toml"))
+}
+
+func (g *Generator) generateInit(schemas []schema.Schema, path string) error {
+	tmpl := `"""{{header}}"""
+
+{{range .Schemas}}from .{{. | moduleName}} import {{. | schemaName}}
 {{end}}
 __all__ = [
 {{range .Schemas}}    "{{. | schemaName}}",
@@ -70,47 +251,880 @@ __all__ = [
 	data := struct {
 		Schemas []schema.Schema
 	}{Schemas: schemas}
-	return g.executeTemplate(tmpl, data, path)
+	return g.executeTemplate(tmpl, g.header.Func("", schemas...), data, path)
 }
 
-func (g *Generator) generateSchema(s schema.Schema, path string) error {
+func (g *Generator) generateSchema(scope resolve.Scope, s schema.Schema, path string) error {
 	tmpl := `"""{{.Schema.Description}}
 
-Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-DO NOT EDIT.
-"""
+{{header}}"""
 
 from __future__ import annotations
 
-from dataclasses import dataclass
+from dataclasses import dataclass{{if or .Redacted .Optional}}, fields{{end}}{{if or .Purposes .Pseudonymized}}, replace{{end}}
 from datetime import date, datetime
-from typing import Any
+{{- if .Exact}}
+from decimal import Decimal
+{{- end}}
+from typing import {{if .Deferred}}TYPE_CHECKING, {{end}}Any{{if or .Redacted .Encrypted .Optional .Canonical}}, ClassVar{{end}}
+{{- range .Imports}}
+{{range .}}
+{{.}}{{end}}
+{{- end}}
+{{- if .Deferred}}
+
+if TYPE_CHECKING:
+{{- range .Deferred}}
+    {{.}}
+{{- end}}
+{{- end}}
 
 
 @dataclass
 class {{.Schema | schemaName}}:
-    """{{.Schema.Description}}"""
-{{range .Schema.Fields}}
-    {{.Name | snake}}: {{.Type | pythonType}}{{if not .Required}} | None = None{{end}}{{if .Description}}  # {{.Description}}{{end}}
+    """{{.Schema.Description}}{{with .Schema.Docs}}
+{{range .}}
+    {{.}}{{end}}
+    {{end}}"""
+{{range fields .Schema}}
+    {{.Ident}}: {{pythonType $.Scope .Type}}{{if not .Required}} | None{{end}}{{if and $.Optional (not .Required)}} = UNSET{{else}}{{with default .Field}} = {{.}}{{else}}{{if not .Required}} = None{{end}}{{end}}{{end}}{{with .Doc}}  # {{.}}{{end}}
 {{end}}
+{{- with .Optional}}
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = { {{- range $i, $f := .}}{{if $i}}, {{end}}"{{$f.Ident}}": {{with default $f.Field}}{{.}}{{else}}None{{end}}{{end -}} }
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+{{- if or $.Purposes $.Pseudonymized}}
+
+    def _replace(self, **changes: Any) -> {{$.Schema | schemaName}}:
+        """Return a copy of this record with changes, leaving out the fields left out of it."""
+        return replace(self, **(changes | {name: UNSET for name in self._unset}))
+{{- end}}
+{{end}}
+{{- with .Canonical}}
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = { {{- range $i, $f := .}}{{if $i}}, {{end}}"{{$f.Ident}}": {{quote $f.Name}}{{end -}} }
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex {{$.Hash}} of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+{{- if $.Equality}}
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, {{$.Schema | schemaName}}) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+{{- end}}
+{{end}}
+{{- with .Redacted}}
+    # Fields at pii_level {{$.Level}} or above, masked when the record is
+    # printed or logged.
+    _REDACTED: ClassVar[frozenset[str]] = frozenset({ {{- range $i, $f := .}}{{if $i}}, {{end}}"{{$f.Ident}}"{{end -}} })
+
+    def redact_for_logging(self) -> dict[str, Any]:
+        """Return the fields of this record with those at pii_level {{$.Level}} or above masked."""
+        return {
+            f.name: "` + schema.Redacted + `" if f.name in self._REDACTED else getattr(self, f.name)
+            for f in fields(self)
+        }
+
+    def __repr__(self) -> str:
+        values = ", ".join(f"{name}={value!r}" for name, value in self.redact_for_logging().items())
+        return f"{type(self).__name__}({values})"
+{{end}}
+{{- with .Encrypted}}
+    # Source name and encryption mode of each encrypted field.
+    _ENCRYPTED: ClassVar[dict[str, tuple[str, str]]] = { {{- range $i, $f := .}}{{if $i}}, {{end}}"{{$f.Ident}}": ("{{$.Schema.GetName}}.{{$f.Name}}", "{{$f.Encryption}}"){{end -}} }
+
+    def encrypt_fields(self, encryptor: FieldEncryptor) -> None:
+        """Replace the encrypted fields of this record with their ciphertexts, before it is stored."""
+        crypt_fields(self, self._ENCRYPTED, encryptor.encrypt)
+
+    def decrypt_fields(self, encryptor: FieldEncryptor) -> None:
+        """Replace the encrypted fields of this record with their plaintexts, after it is loaded."""
+        crypt_fields(self, self._ENCRYPTED, encryptor.decrypt)
+{{end}}
+{{- if .Pseudonymized}}
+    def pseudonymize(self, pseudonymizer: Pseudonymizer) -> tuple[{{.Schema | schemaName}}, list[Pseudonym]]:
+        """Return a copy of this record with its fields at pii_level {{.PseudonymLevel}} or above replaced by tokens, or cleared when they are not text, and the pseudonyms made."""
+        pseudonyms: list[Pseudonym] = []
+        record = {{if .Optional}}self._replace({{else}}replace(
+            self,{{end}}
+{{- range .Tokenized}}
+            {{.Ident}}=pseudonymizer.pseudonymize("{{$.Schema.GetName}}.{{.Name}}", self.{{.Ident}}, pseudonyms),
+{{- end}}
+{{- range .Cleared}}
+            {{.Ident}}={{cleared .}},
+{{- end}}
+        )
+        return record, pseudonyms
+{{end}}
+{{- with .Checks}}
+    def validate(self) -> None:
+        """Raise ValueError when fields that a condition on another field requires are missing, or set although one leaves them out."""
+        errors: list[str] = []
+{{- range .}}
+        if {{.Invalid}}:
+            errors.append({{.Message}})
+{{- end}}
+        if errors:
+            raise ValueError("; ".join(errors))
+{{end}}
+{{- range .Ranges}}
+    def {{.Ident}}_out_of_range(self) -> bool:
+        """Return whether {{.Name}} is set and outside its reference range, {{.Range}}."""
+        return {{.OutOfRange}}
+{{end}}
+{{- range $d := .Derived}}
+    @property
+    def {{.Ident}}(self) -> {{.Type}}:
+        """Return {{.Field.Name}}, derived as {{.Field.Derived}}{{if .Partials}}, or None when a field it uses is unset or not known to the day{{else if .Unset}}, or None when a field it uses is unset{{end}}.{{with .Field.Description}} {{.}}{{end}}"""
+{{- with .Unset}}
+        if {{join . " or "}}:
+            return None
+{{- end}}
+{{- range .Partials}}
+        {{.Ident}} = self.{{.Ident}}.to_date()
+        if {{.Ident}} is None:
+            return None
+{{- end}}
+        return {{.Expr}}
+{{end}}
+{{- with .Narrative}}
+    def to_narrative(self) -> str:
+        """Return the fields of this record that are set as an XHTML table, for the text.div of its FHIR resource."""
+        return narrative(
+            [
+{{- range .}}
+                ({{.Label}}, self.{{.Ident}}, {{.Unit}}),
+{{- end}}
+            ]
+        )
+{{end}}
+{{- with .Match}}
+    def match_keys(self) -> list[str]:
+        """Return the patient matching keys of this record, which records that are candidate matches share: a blocking key of its normalized demographics, when all are set, and a key of each identifier that is set."""
+        return match_keys(
+            [{{join .Demographic ", "}}],
+            [{{join .Identifiers ", "}}],
+        )
+{{end}}
+{{- with .Purposes}}
+    def for_purpose(self, purpose: str) -> {{$.Schema | schemaName}} | None:
+        """Return a copy of this record without the fields that purpose may not see, or None when it may not see the record."""
+{{- range .}}
+        if purpose == "{{.Purpose}}":
+            return {{if $.Optional}}self._replace({{range $i, $f := .Stripped}}{{if $i}}, {{end}}{{.Ident}}={{cleared .}}{{end}}){{else}}replace(self{{range .Stripped}}, {{.Ident}}=None{{end}}){{end}}
+{{- end}}
+        return None
+{{end}}
+{{- if .YearsSince}}
+
+def _years_since(value: date) -> int:
+    """Return the whole years from value to today, as ages are counted."""
+    start = _as_date(value)
+    today = date.today()
+    return today.year - start.year - ((today.month, today.day) < (start.month, start.day))
+{{end}}
+{{- if .DaysBetween}}
+
+def _days_between(start: date, end: date) -> int:
+    """Return the days from the date of start to that of end."""
+    return (_as_date(end) - _as_date(start)).days
+{{end}}
+{{- if or .YearsSince .DaysBetween}}
+
+def _as_date(value: date) -> date:
+    """Return the date of value, which may be a datetime."""
+    return value.date() if isinstance(value, datetime) else value
+{{end}}
+`
+	data := struct {
+		Schema    schema.Schema
+		Scope     resolve.Scope
+		Exact     bool
+		Imports   [][]string
+		Deferred  []string
+		Level     string
+		Redacted  []naming.Field
+		Encrypted []naming.Field
+		Purposes  []purposeFilter
+		Checks    []validateCheck
+		Ranges    []rangeCheck
+		Derived   []derivedProperty
+		Narrative []narrativeRow
+		Match     *matchCall
+		Optional  []naming.Field
+		Canonical []naming.Field
+		Hash      string
+		Equality  bool
+
+		YearsSince  bool
+		DaysBetween bool
+
+		Pseudonymized  bool
+		PseudonymLevel string
+		Tokenized      []naming.Field
+		Cleared        []naming.Field
+	}{Schema: s, Scope: scope, Exact: g.decimalType == "Decimal", Level: g.redact, PseudonymLevel: strings.ToLower(g.pseudonym.Level)}
+	if err := s.CheckDefaults(); err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if s.HasPurposes() {
+		if err := s.CheckPurposes(); err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		data.Purposes = g.purposeFilters(s)
+	}
+	checks, err := g.validateChecks(s)
+	if err != nil {
+		return err
+	}
+	data.Checks = checks
+	if data.Ranges, err = g.rangeChecks(s); err != nil {
+		return err
+	}
+	var helpers []string
+	if data.Derived, helpers, err = g.derivedProperties(s); err != nil {
+		return err
+	}
+	data.YearsSince, data.DaysBetween = contains(helpers, "years_since"), contains(helpers, "days_between")
+	if g.narrated(s) {
+		data.Narrative = g.narrativeRows(s)
+	}
+	data.Match = g.matchCall(s)
+	data.Optional = g.optionalFields(s)
+	data.Canonical = g.canonicalFields(s)
+	data.Hash, data.Equality = strings.ToUpper(strings.Replace(g.hashing.Algorithm, "sha", "sha-", 1)), g.hashing.Equality
+	for _, f := range g.fields(s) {
+		if f.Sensitive(g.redact) {
+			data.Redacted = append(data.Redacted, f)
+		}
+		if f.Encryption != "" {
+			data.Encrypted = append(data.Encrypted, f)
+		}
+		switch {
+		case !f.Sensitive(g.pseudonym.Level):
+		case f.Pseudonymizable():
+			data.Tokenized = append(data.Tokenized, f)
+		default:
+			data.Cleared = append(data.Cleared, f)
+		}
+	}
+	data.Pseudonymized = len(data.Tokenized)+len(data.Cleared) > 0
+	data.Imports, data.Deferred = g.imports(scope, s)
+	return g.executeTemplate(tmpl, g.header.Func("", s), data, path)
+}
+
+// purposeFilter is a purpose of use a schema permits, with the fields of
+// the schema it may not see.
+type purposeFilter struct {
+	Purpose  string
+	Stripped []naming.Field
+}
+
+// purposeFilters returns the purposes of use s permits, in schema.Purposes
+// order.
+func (g *Generator) purposeFilters(s schema.Schema) []purposeFilter {
+	var filters []purposeFilter
+	for _, p := range schema.Purposes {
+		if !s.Permits(p) {
+			continue
+		}
+		filter := purposeFilter{Purpose: p}
+		for _, f := range g.fields(s) {
+			if !s.PermitsField(f.Field, p) {
+				filter.Stripped = append(filter.Stripped, f)
+			}
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// validateCheck is a Python expression that is true when a conditionally
+// required field is missing, or a conditionally enabled one set although
+// not enabled, and the message reporting it.
+type validateCheck struct {
+	Invalid string
+	Message string
+}
+
+// validateChecks returns the checks of the required_if and enabled_if
+// fields of s. Lists are missing when empty, other fields when None.
+func (g *Generator) validateChecks(s schema.Schema) ([]validateCheck, error) {
+	conditional, err := s.ConditionalFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	enabled, err := s.EnabledFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	idents := make(map[string]naming.Field)
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f
+	}
+	var checks []validateCheck
+	for _, c := range conditional {
+		field, on := idents[c.Field.Name], idents[c.Condition.Field]
+		missing := "self." + field.Ident + " is None"
+		if strings.HasPrefix(field.Type, "[]") {
+			missing = "not self." + field.Ident
+		}
+		checks = append(checks, validateCheck{
+			Invalid: condition(on, c.Condition) + " and " + missing,
+			Message: strconv.Quote(c.Field.Name + " is required when " + c.Condition.String()),
+		})
+	}
+	for _, c := range enabled {
+		field, on := idents[c.Field.Name], idents[c.Condition.Field]
+		set := "self." + field.Ident + " is not None"
+		if strings.HasPrefix(field.Type, "[]") {
+			set = "bool(self." + field.Ident + ")"
+		}
+		checks = append(checks, validateCheck{
+			Invalid: "not (" + condition(on, c.Condition) + ") and " + set,
+			Message: strconv.Quote(c.Field.Name + " must be left out unless " + c.Condition.String()),
+		})
+	}
+	return checks, nil
+}
+
+// rangeCheck is a Python expression that is true when a field is outside
+// its reference range.
+type rangeCheck struct {
+	Ident      string
+	Name       string
+	Range      string
+	OutOfRange string
+}
+
+// rangeChecks returns the checks of the fields of s with reference ranges.
+func (g *Generator) rangeChecks(s schema.Schema) ([]rangeCheck, error) {
+	ranged, err := s.RangedFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	idents := make(map[string]string)
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f.Ident
+	}
+	var checks []rangeCheck
+	for _, f := range ranged {
+		attr := "self." + idents[f.Name]
+		var test string
+		switch low, high := g.bound(f, f.ReferenceLow), g.bound(f, f.ReferenceHigh); {
+		case low != "" && high != "":
+			test = "not " + low + " <= " + attr + " <= " + high
+		case low != "":
+			test = attr + " < " + low
+		default:
+			test = attr + " > " + high
+		}
+		if !f.Required {
+			test = attr + " is not None and " + test
+		}
+		checks = append(checks, rangeCheck{Ident: idents[f.Name], Name: f.Name, Range: f.ReferenceRange(), OutOfRange: test})
+	}
+	return checks, nil
+}
+
+// bound returns a reference bound of f as a Python number of its type, or
+// "" when it has none.
+func (g *Generator) bound(f schema.Field, value string) string {
+	if value != "" && f.Type == "decimal" && g.decimalType == "Decimal" {
+		return "Decimal(" + strconv.Quote(value) + ")"
+	}
+	return value
+}
+
+// condition returns a Python expression that is true when c holds for self,
+// whose field on it tests.
+func condition(on naming.Field, c schema.Condition) string {
+	attr := "self." + on.Ident
+	switch {
+	case c.Op == "" && strings.HasPrefix(on.Type, "[]"):
+		return "bool(" + attr + ")"
+	case c.Op == "":
+		return attr + " is not None"
+	}
+	switch on.DefaultKind() {
+	case "integer":
+		return attr + " " + c.Op + " " + c.Value
+	case "boolean":
+		value := map[string]string{"true": "True", "false": "False"}[c.Value]
+		if c.Op == "!=" {
+			return attr + " is not " + value
+		}
+		return attr + " is " + value
+	}
+	return attr + " " + c.Op + " " + strconv.Quote(c.Value)
+}
+
+// imports returns the import lines a schema module needs, grouped into
+// absolute imports from other packages and relative imports from its own
+// package. Imports of modules that import s back are returned separately as
+// deferred, to be guarded by TYPE_CHECKING: field annotations are not
+// evaluated at runtime, so the cycle never executes.
+func (g *Generator) imports(scope resolve.Scope, s schema.Schema) (groups [][]string, deferred []string) {
+	var absolute, relative []string
+	if g.dateType == "PartialDate" {
+		relative = append(relative, "from ._temporal import PartialDate")
+	}
+	if s.HasEncrypted() {
+		relative = append(relative, "from ._encryption import FieldEncryptor, crypt_fields")
+	}
+	if len(g.optionalFields(s)) > 0 {
+		relative = append(relative, "from ._unset import UNSET")
+	}
+	if len(g.canonicalFields(s)) > 0 {
+		relative = append(relative, "from ._canonical import encode_canonical, hash_canonical")
+	}
+	if g.narrated(s) {
+		relative = append(relative, "from ._narrative import narrative")
+	}
+	if s.HasSensitive(g.pseudonym.Level) {
+		relative = append(relative, "from ._pseudonym import Pseudonym, Pseudonymizer")
+	}
+	if call := g.matchCall(s); call != nil {
+		relative = append(relative, "from ._match import "+strings.Join(call.Helpers, ", "))
+	}
+	for _, dep := range scope.Dependencies(s) {
+		var line string
+		if dep.Namespace == s.Namespace {
+			line = fmt.Sprintf("from .%s import %s", g.moduleName(dep), g.className(dep))
+		} else {
+			line = fmt.Sprintf("from %s.%s import %s", g.packageName(dep.Namespace), g.moduleName(dep), g.className(dep))
+		}
+		switch {
+		case scope.Cyclic(s, dep):
+			deferred = append(deferred, line)
+		case dep.Namespace == s.Namespace:
+			relative = append(relative, line)
+		default:
+			absolute = append(absolute, line)
+		}
+	}
+
+	for _, group := range [][]string{absolute, relative} {
+		if len(group) > 0 {
+			sort.Strings(group)
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(deferred)
+	return groups, deferred
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, path string) error {
+	tmpl := `"""UCUM unit validation and conversion for {{.Quantity | schemaName}}.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from dataclasses import replace
+from decimal import Decimal
+from typing import NamedTuple
+
+from .{{.Quantity | moduleName}} import {{.Quantity | schemaName}}
+
+UCUM_SYSTEM = "` + datatypes.UCUMSystem + `"
+
+
+class Unit(NamedTuple):
+    """A unit whose value v is v * factor + offset in its dimension's base unit."""
+
+    dimension: str
+    factor: float
+    offset: float = 0.0
+
+
+UNITS: dict[str, Unit] = {
+{{- range .Units}}
+    "{{.Code}}": Unit("{{.Dimension}}", {{.Factor | float}}{{if .Offset}}, {{.Offset | float}}{{end}}),
+{{- end}}
+}
+
+
+def is_known_unit(code: str) -> bool:
+    """Report whether code is a UCUM unit these helpers can convert."""
+    return code in UNITS
+
+
+def convert_value(value: float, from_code: str, to_code: str) -> float:
+    """Convert value between two UCUM units of the same dimension."""
+    source, target = _unit(from_code), _unit(to_code)
+    if source.dimension != target.dimension:
+        raise ValueError(f"cannot convert {from_code} ({source.dimension}) to {to_code} ({target.dimension})")
+    return (value * source.factor + source.offset - target.offset) / target.factor
+
+
+def convert(quantity: {{.Quantity | schemaName}}, to_code: str) -> {{.Quantity | schemaName}}:
+    """Return quantity in to_code units. Its code, or else its unit, must be a known UCUM unit."""
+    if quantity.{{.F.value}} is None:
+        raise ValueError("cannot convert a quantity without a value")
+    from_code = quantity.{{.F.code}} or quantity.{{.F.unit}} or ""
+    value = convert_value(float(quantity.{{.F.value}}), from_code, to_code)
+    return replace(
+        quantity,
+        {{.F.value}}=Decimal(repr(value)) if isinstance(quantity.{{.F.value}}, Decimal) else value,
+        {{.F.unit}}=to_code,
+        {{.F.system}}=UCUM_SYSTEM,
+        {{.F.code}}=to_code,
+    )
+
+
+def _unit(code: str) -> Unit:
+    unit = UNITS.get(code)
+    if unit is None:
+        raise ValueError(f"unknown UCUM unit: {code!r}")
+    return unit
 `
 	data := struct {
-		Schema schema.Schema
-	}{Schema: s}
-	return g.executeTemplate(tmpl, data, path)
+		Quantity schema.Schema
+		F        map[string]string
+		Units    []datatypes.Unit
+	}{Quantity: quantity, F: naming.ByName(g.fields(quantity)), Units: datatypes.Units}
+	return g.executeTemplate(tmpl, g.header.Func("", quantity), data, path)
+}
+
+// generateCrosswalk writes the lookup tables that map the local codes of the
+// fields of a namespace to standard concepts.
+func (g *Generator) generateCrosswalk(fields []crosswalk.Field, path string) error {
+	type table struct {
+		Name     string
+		Source   string
+		Concepts []crosswalk.Concept
+	}
+	var tables []table
+	var sources []schema.Schema
+	for _, f := range fields {
+		ident := naming.Snake.Apply(f.Field.Name)
+		for _, field := range g.fields(f.Schema) {
+			if field.Name == f.Field.Name {
+				ident = field.Ident
+			}
+		}
+		tables = append(tables, table{
+			Name:     strings.ToUpper(naming.Snake.Apply(f.Schema.GetName()) + "_" + strings.Trim(ident, "_")),
+			Source:   f.Schema.GetName() + "." + f.Field.Name,
+			Concepts: f.Concepts,
+		})
+		if len(sources) == 0 || sources[len(sources)-1].GetName() != f.Schema.GetName() {
+			sources = append(sources, f.Schema)
+		}
+	}
+
+	tmpl := `"""Standard concepts of local codes.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from typing import NamedTuple
+
+
+class Concept(NamedTuple):
+    """The standard concept a local code maps to.
+
+    concept_id is the OMOP concept_id, or 0 when the code has none, and
+    i2b2_path the concept path of the code in the i2b2 ontology.
+    """
+
+    concept_id: int
+    vocabulary_id: str
+    concept_code: str
+    concept_name: str
+    i2b2_path: str
+{{range .}}
+
+# Maps the local codes of {{.Source}} to standard concepts.
+{{.Name}}_CONCEPTS: dict[str, Concept] = {
+{{- range .Concepts}}
+    {{quote .LocalCode}}: Concept({{.ConceptID}}, {{quote .VocabularyID}}, {{quote .ConceptCode}}, {{quote .ConceptName}}, {{quote .I2B2Path}}),
+{{- end}}
 }
+{{- end}}
+`
+	return g.executeTemplate(tmpl, g.header.Func("", sources...), tables, path)
+}
+
+// generateEncryption writes the FieldEncryptor protocol that the
+// encrypt_fields and decrypt_fields methods of models with encrypted fields
+// pass their values through.
+func (g *Generator) generateEncryption(schemas []schema.Schema, path string) error {
+	tmpl := `"""Field-level encryption hooks.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+from typing import Any, Protocol
+
+# Encrypts equal values to equal ciphertexts, so the field can still be
+# matched and joined on.
+DETERMINISTIC = "` + schema.EncryptionDeterministic + `"
+# Encrypts each value under a fresh nonce.
+RANDOMIZED = "` + schema.EncryptionRandomized + `"
+
+
+class FieldEncryptor(Protocol):
+    """Encrypts and decrypts the values of encrypted fields.
+
+    Implementations typically use envelope encryption under a data key that a
+    KMS key wraps. field is the schema and field name, such as patient.ssn,
+    for choosing keys or binding ciphertexts to their field. Ciphertexts are
+    text, such as base64, so that they fit the fields they replace.
+    """
+
+    def encrypt(self, field: str, mode: str, plaintext: str) -> str: ...
+
+    def decrypt(self, field: str, mode: str, ciphertext: str) -> str: ...
+
+
+def crypt_fields(record: Any, fields: dict[str, tuple[str, str]], crypt: Callable[[str, str, str], str]) -> None:
+    """Replace each non-empty encrypted field of record with crypt of its value."""
+    for name, (field, mode) in fields.items():
+        value = getattr(record, name)
+        if value:
+            setattr(record, name, crypt(field, mode, value))
+`
+	return g.executeTemplate(tmpl, g.header.Func("", schemas...), nil, path)
+}
+
+// narrativeRow is a field that narratives show: its label and unit, as
+// Python strings, and its attribute.
+type narrativeRow struct {
+	Label string
+	Ident string
+	Unit  string
+}
+
+// narrated reports whether records of s get a to_narrative method.
+func (g *Generator) narrated(s schema.Schema) bool {
+	return g.narrative && !datatypes.IsBuiltin(s) && len(s.NarrativeFields()) > 0
+}
+
+// narrativeRows returns the rows of the narratives of s.
+func (g *Generator) narrativeRows(s schema.Schema) []narrativeRow {
+	idents := make(map[string]string)
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f.Ident
+	}
+	var rows []narrativeRow
+	for _, f := range s.NarrativeFields() {
+		rows = append(rows, narrativeRow{Label: strconv.Quote(f.NarrativeLabel()), Ident: idents[f.Name], Unit: strconv.Quote(f.Unit)})
+	}
+	return rows
+}
+
+// generateNarrative writes the helper that the to_narrative methods of
+// models render their rows with.
+func (g *Generator) generateNarrative(path string) error {
+	tmpl := `"""XHTML narratives of records, for the text.div of FHIR resources.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from datetime import date
+from html import escape
+from typing import Any
+
+
+def narrative(rows: list[tuple[str, Any, str]]) -> str:
+    """Return an XHTML table of the labeled values of rows that are set, each followed by its unit, if any."""
+    cells: list[str] = []
+    for label, value, unit in rows:
+        if value is None:
+            continue
+        if isinstance(value, bool):
+            text = "Yes" if value else "No"
+        elif isinstance(value, date):
+            text = value.isoformat()
+        else:
+            text = str(value)
+        if unit:
+            text += " " + unit
+        cells.append(f"<tr><th>{escape(label)}</th><td>{escape(text)}</td></tr>")
+    return '<div xmlns="http://www.w3.org/1999/xhtml"><table>' + "".join(cells) + "</table></div>"
+`
+	return g.executeTemplate(tmpl, g.header.Func(""), nil, path)
+}
+
+// generatePseudonym writes the Pseudonymizer that the pseudonymize methods
+// of models with sensitive fields derive stable HMAC tokens with.
+func (g *Generator) generatePseudonym(schemas []schema.Schema, path string) error {
+	tmpl := `"""Pseudonymization helpers for research data releases.
+
+{{header}}"""
+
+from __future__ import annotations
+
+import hashlib
+import hmac
+import os
+from typing import NamedTuple
+
+# Environment variable that holds the HMAC key of pseudonyms.
+PSEUDONYM_KEY_ENV = "{{.}}"
+
+
+class Pseudonym(NamedTuple):
+    """Links a token to the value it replaced, as a row of the re-identification table.
+
+    field is the schema and field name, such as patient.ssn.
+    """
 
-func (g *Generator) executeTemplate(tmplStr string, data any, path string) error {
+    token: str
+    field: str
+    value: str
+
+
+class Pseudonymizer:
+    """Replaces identifiers with stable tokens: the hex HMAC-SHA256 of the identifier under a secret key.
+
+    Releases made with the same key link on equal identifiers without
+    revealing them.
+    """
+
+    def __init__(self, key: bytes) -> None:
+        self._key = key
+
+    @classmethod
+    def from_env(cls) -> Pseudonymizer:
+        """Return a Pseudonymizer keyed by the value of PSEUDONYM_KEY_ENV."""
+        key = os.environ.get(PSEUDONYM_KEY_ENV)
+        if not key:
+            raise KeyError(f"{PSEUDONYM_KEY_ENV} is not set")
+        return cls(key.encode())
+
+    def token(self, value: str) -> str:
+        """Return the pseudonym of value."""
+        return hmac.new(self._key, value.encode(), hashlib.sha256).hexdigest()
+
+    def pseudonymize(self, field: str, value: str | None, pseudonyms: list[Pseudonym]) -> str | None:
+        """Return the token of value, recording it in pseudonyms, or value itself when it is empty."""
+        if not value:
+            return value
+        token = self.token(value)
+        pseudonyms.append(Pseudonym(token, field, value))
+        return token
+`
+	return g.executeTemplate(tmpl, g.header.Func("", schemas...), g.pseudonym.KeyEnv, path)
+}
+
+// generateTemporal writes the PartialDate helper used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generateTemporal(path string) error {
+	tmpl := `"""Partial date support for FHIR dates.
+
+{{header}}"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from datetime import date
+
+_PARTIAL_DATE = re.compile(r"(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?")
+
+
+@dataclass(frozen=True)
+class PartialDate:
+    """A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14."""
+
+    year: int
+    month: int | None = None
+    day: int | None = None
+
+    def __post_init__(self) -> None:
+        if self.day is not None and self.month is None:
+            raise ValueError("a partial date with a day must have a month")
+        # Reject impossible dates such as 1970-02-30
+        date(self.year, self.month or 1, self.day or 1)
+
+    @classmethod
+    def parse(cls, value: str) -> PartialDate:
+        """Parse a date in YYYY, YYYY-MM, or YYYY-MM-DD form."""
+        match = _PARTIAL_DATE.fullmatch(value)
+        if match is None:
+            raise ValueError(f"invalid partial date: {value!r}")
+        year, month, day = match.groups()
+        return cls(int(year), int(month) if month else None, int(day) if day else None)
+
+    def to_date(self) -> date | None:
+        """Return the full date, or None when the value is less precise than a day."""
+        if self.month is None or self.day is None:
+            return None
+        return date(self.year, self.month, self.day)
+
+    def __str__(self) -> str:
+        text = f"{self.year:04d}"
+        if self.month is not None:
+            text += f"-{self.month:02d}"
+        if self.day is not None:
+            text += f"-{self.day:02d}"
+        return text
+`
+	return g.executeTemplate(tmpl, g.header.Func(""), nil, path)
+}
+
+// executeTemplate renders tmplStr into path, with header, if set, bound as
+// {{header}}.
+func (g *Generator) executeTemplate(tmplStr string, header func() string, data any, path string) error {
 	funcMap := template.FuncMap{
-		"lower":      strings.ToLower,
-		"snake":      toSnakeCase,
-		"pythonType": toPythonType,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"pythonType": g.toPythonType,
+		"moduleName": g.moduleName,
+		"schemaName": g.className,
+		"fields":     g.fields,
+		"float":      datatypes.FloatLiteral,
+		"quote":      strconv.Quote,
+		"default":    g.defaultLiteral,
+		"join":       strings.Join,
+		"cleared":    g.cleared,
+	}
+
+	if header != nil {
+		funcMap["header"] = header
 	}
 
-	tmpl, err := template.New("").Funcs(funcMap).Parse(tmplStr)
+	tmpl, err := g.templates.Parse(tmplStr, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -122,35 +1136,212 @@ func (g *Generator) executeTemplate(tmplStr string, data any, path string) error
 	return tmpl.Execute(f, data)
 }
 
+// optionalFields returns the optional fields of s, which are tri-state
+// when tri-state fields are enabled, and none otherwise.
+func (g *Generator) optionalFields(s schema.Schema) []naming.Field {
+	if !g.triState {
+		return nil
+	}
+	var optional []naming.Field
+	for _, f := range g.fields(s) {
+		if !f.Required {
+			optional = append(optional, f)
+		}
+	}
+	return optional
+}
+
+// canonicalFields returns the fields of s sorted by schema name, as
+// canonical JSON writes them, when canonical hashing is enabled, and none
+// otherwise.
+func (g *Generator) canonicalFields(s schema.Schema) []naming.Field {
+	if g.hashing.Algorithm == "" {
+		return nil
+	}
+	fields := g.fields(s)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+	return fields
+}
+
+// generateCanonical writes the canonical JSON encoder and hash of records,
+// which agree with those of the Go generator.
+func (g *Generator) generateCanonical(path string) error {
+	tmpl := `"""Canonical JSON and hashes of records, which ehrglot writes alike in every language.
+
+{{header}}"""
+
+from __future__ import annotations
+
+import base64
+import hashlib
+import math
+from datetime import date, datetime, timezone
+from decimal import Decimal
+from typing import Any
+
+# Canonical JSON of the null and empty members that objects leave out.
+_EMPTY = frozenset({"null", '""', "false", "0", "[]", "{}"})
+
+_ESCAPES = {'"': '\\"', "\\": "\\\\", "\b": "\\b", "\f": "\\f", "\n": "\\n", "\r": "\\r", "\t": "\\t"}
+
+
+def encode_canonical(members: dict[str, Any]) -> str:
+    """Return members as a canonical JSON object, sorted by name and without those that are null or empty: "", false, 0, [], and {}."""
+    parts: list[str] = []
+    for name in sorted(members):
+        value = _encode(members[name])
+        if value not in _EMPTY:
+            parts.append(_string(name) + ":" + value)
+    return "{" + ",".join(parts) + "}"
+
+
+def hash_canonical(text: str) -> str:
+    """Return the hex {{.Hash}} of the canonical JSON text."""
+    return hashlib.{{.Algorithm}}(text.encode()).hexdigest()
+
+
+def _encode(value: Any) -> str:
+    """Return value as canonical JSON: records as their own canonical JSON, instants in UTC as RFC 3339, numbers in fixed notation with no trailing zeros, and binary as base64."""
+    if value is None:
+        return "null"
+    if hasattr(value, "canonical_json"):
+        return str(value.canonical_json())
+    if isinstance(value, str):
+        return _string(value)
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, int):
+        return str(value)
+    if isinstance(value, float):
+        if not math.isfinite(value):
+            raise ValueError(f"unsupported number {value}")
+        return _number(Decimal(repr(value)))
+    if isinstance(value, Decimal):
+        if not value.is_finite():
+            raise ValueError(f"unsupported number {value}")
+        return _number(value)
+    if isinstance(value, (bytes, bytearray)):
+        return _string(base64.b64encode(value).decode())
+    if isinstance(value, datetime):
+        instant = value.replace(tzinfo=timezone.utc) if value.tzinfo is None else value.astimezone(timezone.utc)
+        text = instant.replace(microsecond=0, tzinfo=None).isoformat()
+        if instant.microsecond:
+            text += f".{instant.microsecond:06d}".rstrip("0")
+        return _string(text + "Z")
+    if isinstance(value, date):
+        return _string(value.isoformat())
+    if isinstance(value, dict):
+        return encode_canonical({str(k): v for k, v in value.items()})
+    if isinstance(value, (list, tuple)):
+        return "[" + ",".join(_encode(v) for v in value) + "]"
+    # Anything else, such as a PartialDate, is written as its text.
+    return _string(str(value))
+
+
+def _number(value: Decimal) -> str:
+    """Return value in fixed notation without trailing fractional zeros or a negative zero."""
+    text = format(value, "f")
+    if "." in text:
+        text = text.rstrip("0").rstrip(".")
+    return "0" if text == "-0" else text
+
+
+def _string(text: str) -> str:
+    """Return text as a JSON string, escaping only quotes, backslashes, and control characters, as RFC 8785 does."""
+    return '"' + "".join(_ESCAPES.get(c) or (f"\\u{ord(c):04x}" if c < " " else c) for c in text) + '"'
+`
+	data := struct {
+		Algorithm string
+		Hash      string
+	}{g.hashing.Algorithm, strings.ToUpper(strings.Replace(g.hashing.Algorithm, "sha", "sha-", 1))}
+	return g.executeTemplate(tmpl, g.header.Func(""), data, path)
+}
+
+// cleared returns the value that copies of records clear f to: UNSET, which
+// leaves f out of the copy, when f is tri-state, and None otherwise.
+func (g *Generator) cleared(f naming.Field) string {
+	if g.triState && !f.Required {
+		return "UNSET"
+	}
+	return "None"
+}
+
+// generateUnset writes the UNSET sentinel that the optional fields of
+// models default to when tri-state fields are enabled.
+func (g *Generator) generateUnset(path string) error {
+	tmpl := `"""The UNSET sentinel of fields left out of records.
+
+{{header}}"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+class Unset:
+    """The type of UNSET, which marks the optional fields left out of a record, as opposed to those set to None."""
+
+    def __repr__(self) -> str:
+        return "UNSET"
+
+    def __bool__(self) -> bool:
+        return False
+
+
+# UNSET is typed Any so that it can default fields of every type.
+UNSET: Any = Unset()
+`
+	return g.executeTemplate(tmpl, g.header.Func(""), nil, path)
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return g.packageName(namespace)
+}
+
 // GenerateMappings generates Python mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	// TODO: Implement mapping generation
 	return nil
 }
 
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('_')
-		}
-		result.WriteRune(r)
-	}
-	return strings.ToLower(result.String())
+func (g *Generator) packageName(namespace string) string {
+	return naming.Case(g.naming.Packages).Apply(namespace)
+}
+
+func (g *Generator) moduleName(s schema.Schema) string {
+	return naming.Ident("python", naming.Case(g.naming.Files).Or(strings.ToLower)(s.GetName()))
+}
+
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(g.allFields(s))
 }
 
-func toPythonType(yamlType string) string {
+// allFields returns the stored and derived fields of s.
+func (g *Generator) allFields(s schema.Schema) []naming.Field {
+	return naming.Fields("python", s.Fields, naming.Case(g.naming.Fields).Or(naming.Snake.Apply))
+}
+
+func (g *Generator) className(s schema.Schema) string {
+	return naming.Ident("python", naming.Case(g.naming.Types).Apply(s.GetName()))
+}
+
+func (g *Generator) toPythonType(scope resolve.Scope, yamlType string) string {
+	if target, ok := scope.Lookup(yamlType); ok {
+		return g.className(target)
+	}
 	switch yamlType {
 	case "string", "code", "id", "uri", "url":
 		return "str"
 	case "integer", "positiveInt", "unsignedInt":
 		return "int"
 	case "decimal":
-		return "float"
+		return g.decimalType
 	case "boolean":
 		return "bool"
 	case "date":
-		return "date"
+		return g.dateType
 	case "datetime", "instant":
 		return "datetime"
 	case "base64Binary":
@@ -158,7 +1349,7 @@ func toPythonType(yamlType string) string {
 	default:
 		if strings.HasPrefix(yamlType, "[]") {
 			innerType := strings.TrimPrefix(yamlType, "[]")
-			return fmt.Sprintf("list[%s]", toPythonType(innerType))
+			return fmt.Sprintf("list[%s]", g.toPythonType(scope, innerType))
 		}
 		return "Any"
 	}
@@ -0,0 +1,135 @@
+package python_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/python"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, python.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:   config.DecimalExact,
+		Temporal:  config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:      true,
+		Narrative: true,
+		Audit:     true,
+	}
+	generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestGenerateTriStateGolden(t *testing.T) {
+	generatortest.RunSchemas(t, python.NewGenerator(config.Config{TriState: true}), fixtures.Schemas(), "testdata/tristate")
+}
+
+func TestGenerateHashingGolden(t *testing.T) {
+	cfg := config.Config{Hashing: config.Hashing{Algorithm: config.HashSHA256, Equality: true}}
+	generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.Schemas(), "testdata/hashing")
+}
+
+func TestGenerateCrosswalkGolden(t *testing.T) {
+	cfg := config.Config{Crosswalk: generatortest.Crosswalk(t)}
+	generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.Schemas(), "testdata/crosswalk")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, python.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+	}
+	generatortest.RunPackage(t, python.NewGenerator(cfg), "testdata/package")
+}
+
+func TestGeneratePseudonymizeGolden(t *testing.T) {
+	cfg := config.Config{Pseudonymize: config.Pseudonymize{Level: "high"}}
+	generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.Schemas(), "testdata/pseudonymize")
+}
+
+func TestWriteMigrationGolden(t *testing.T) {
+	old := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "medicalRecordNumber", Type: "string", UID: "p1"},
+			{Name: "fax", Type: "string"},
+			{Name: "visits", Type: "integer"},
+			{Name: "rank", Type: "decimal"},
+			{Name: "birthDate", Type: "string"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "name", Type: "string", UID: "c1"},
+				{Name: "telecom", Type: "BackboneElement", Children: []schema.Field{{Name: "use", Type: "code"}}},
+			}},
+		}},
+	}
+	new := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "mrn", Type: "string", UID: "p1"},
+			{Name: "visits", Type: "decimal"},
+			{Name: "rank", Type: "string"},
+			{Name: "birthDate", Type: "date"},
+			{Name: "active", Type: "boolean", Required: true, Default: "true"},
+			{Name: "language", Type: "code", Default: "en-US"},
+			{Name: "gender", Type: "code", Required: true},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "fullName", Type: "string", UID: "c1"},
+				{Name: "telecom", Type: "BackboneElement", Children: []schema.Field{{Name: "use", Type: "code"}, {Name: "rank", Type: "positiveInt", Default: "1"}}},
+			}},
+		}},
+		{Name: "Claim", Namespace: "billing", Fields: []schema.Field{{Name: "id", Type: "id"}}},
+	}
+	changes, err := diff.Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := python.NewGenerator(config.Config{}).WriteMigration(&buf, changes); err != nil {
+		t.Fatalf("WriteMigration() failed: %v", err)
+	}
+	got := generatortest.Normalize(buf.Bytes())
+	path := filepath.Join("testdata", "migrate.py")
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("WriteMigration() differs from %s:\n%s", path, got)
+	}
+}
+
+func TestGenerateMatchGolden(t *testing.T) {
+	cfgs := map[string]config.Config{
+		"plain":     {},
+		"soundex":   {Matching: config.Matching{Phonetic: config.PhoneticSoundex}},
+		"metaphone": {Matching: config.Matching{Phonetic: config.PhoneticMetaphone}, Temporal: config.Temporal{Dates: config.DatesPartial}},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			generatortest.RunSchemas(t, python.NewGenerator(cfg), fixtures.MatchSchemas(), "testdata/match/"+name)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+"""A reference to a code defined by a terminology system
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+
+@dataclass
+class Coding:
+    """A reference to a code defined by a terminology system"""
+
+    system: str | None = None  # Identity of the terminology system
+
+    version: str | None = None  # Version of the system - if relevant
+
+    code: str | None = None  # Symbol in syntax defined by the system
+
+    display: str | None = None  # Representation defined by the system
+
+    user_selected: bool | None = None  # If this coding was chosen directly by the user
+
@@ -0,0 +1,21 @@
+"""Time range defined by start and end date/time
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+
+@dataclass
+class Period:
+    """Time range defined by start and end date/time"""
+
+    start: datetime | None = None  # Starting time with inclusive boundary
+
+    end: datetime | None = None  # End time with inclusive boundary, if not ongoing
+
@@ -0,0 +1,27 @@
+"""A reference from one resource to another
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from .identifier import Identifier
+
+
+@dataclass
+class Reference:
+    """A reference from one resource to another"""
+
+    reference: str | None = None  # Literal reference, Relative, internal or absolute URL
+
+    type: str | None = None  # Type the reference refers to (e.g. "Patient")
+
+    identifier: Identifier | None = None  # Logical reference, when literal reference is not known
+
+    display: str | None = None  # Text alternative for the resource
+
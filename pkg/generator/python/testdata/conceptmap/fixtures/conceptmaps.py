@@ -0,0 +1,44 @@
+"""Translations of codes by concept maps.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import NamedTuple
+
+
+class ConceptMapTarget(NamedTuple):
+    """A code that a concept map translates a source code to, and how equivalent the two are."""
+
+    system: str
+    code: str
+    display: str
+    equivalence: str
+
+
+# The concept map lab-to-loinc, keyed by source system and code.
+_LAB_TO_LOINC: dict[tuple[str, str], tuple[ConceptMapTarget, ...]] = {
+    ("http://hospital.example/lab-codes", "GLU"): (
+        ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent"),
+    ),
+    ("http://hospital.example/lab-codes", "K"): (
+        ConceptMapTarget("http://loinc.org", "2823-3", "Potassium [Moles/volume] in Serum or Plasma", "equivalent"),
+    ),
+    ("http://hospital.example/lab-codes", "LYTES"): (
+        ConceptMapTarget("http://loinc.org", "2951-2", "Sodium [Moles/volume] in Serum or Plasma", "wider"),
+        ConceptMapTarget("http://loinc.org", "2075-0", "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", "wider"),
+    ),
+    ("http://legacy.example/lab", "0012"): (
+        ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent"),
+    ),
+}
+
+
+def translate_lab_to_loinc(system: str, code: str) -> list[ConceptMapTarget]:
+    """Return the codes that code of system translates to by the concept map lab-to-loinc, if any.
+
+    Local lab test codes to LOINC
+    """
+    return list(_LAB_TO_LOINC.get((system, code), ()))
@@ -0,0 +1,21 @@
+"""Human-readable summary of the resource
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+
+@dataclass
+class Narrative:
+    """Human-readable summary of the resource"""
+
+    status: str  # generated | extensions | additional | empty
+
+    div: str  # Limited xhtml content
+
@@ -0,0 +1,36 @@
+"""Standard concepts of local codes.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import NamedTuple
+
+
+class Concept(NamedTuple):
+    """The standard concept a local code maps to.
+
+    concept_id is the OMOP concept_id, or 0 when the code has none, and
+    i2b2_path the concept path of the code in the i2b2 ontology.
+    """
+
+    concept_id: int
+    vocabulary_id: str
+    concept_code: str
+    concept_name: str
+    i2b2_path: str
+
+
+# Maps the local codes of Specimen.status to standard concepts.
+SPECIMEN_STATUS_CONCEPTS: dict[str, Concept] = {
+    "available": Concept(4188539, "SNOMED", "373066001", "Yes", ""),
+    "entered-in-error": Concept(0, "", "", "", "\\i2b2\\Status\\Error\\"),
+}
+
+# Maps the local codes of lab_result.ICD10Code to standard concepts.
+LAB_RESULT_ICD10_CODE_CONCEPTS: dict[str, Concept] = {
+    "E11.9": Concept(201826, "SNOMED", "44054006", "Type 2 diabetes mellitus", "\\i2b2\\Diagnoses\\E11\\E11.9\\"),
+    "I10": Concept(320128, "SNOMED", "59621000", "Essential hypertension, \"primary\"", ""),
+}
@@ -0,0 +1,39 @@
+"""An address expressed using postal conventions
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from .period import Period
+
+
+@dataclass
+class Address:
+    """An address expressed using postal conventions"""
+
+    use: str | None = None  # home | work | temp | old | billing - purpose of this address
+
+    type: str | None = None  # postal | physical | both
+
+    text: str | None = None  # Text representation of the address
+
+    line: list[str] | None = None  # Street name, number, direction & P.O. Box etc.
+
+    city: str | None = None  # Name of city, town etc.
+
+    district: str | None = None  # District name (aka county)
+
+    state: str | None = None  # Sub-unit of country (abbreviations ok)
+
+    postal_code: str | None = None  # Postal code for area
+
+    country: str | None = None  # Country (e.g. can be ISO 3166 2 or 3 letter code)
+
+    period: Period | None = None  # Time period when address was/is in use
+
@@ -0,0 +1,31 @@
+"""Metadata about a resource
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from .coding import Coding
+
+
+@dataclass
+class Meta:
+    """Metadata about a resource"""
+
+    version_id: str | None = None  # Version specific identifier
+
+    last_updated: datetime | None = None  # When the resource version last changed
+
+    source: str | None = None  # Identifies where the resource comes from
+
+    profile: list[str] | None = None  # Profiles this resource claims to conform to
+
+    security: list[Coding] | None = None  # Security Labels applied to this resource
+
+    tag: list[Coding] | None = None  # Tags applied to this resource
+
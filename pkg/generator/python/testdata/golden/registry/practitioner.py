@@ -0,0 +1,40 @@
+"""A person providing care
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from datatypes.extension import Extension
+from datatypes.humanname import HumanName
+from datatypes.meta import Meta
+from datatypes.narrative import Narrative
+
+
+@dataclass
+class Practitioner:
+    """A person providing care"""
+
+    id: str  # Logical id of this artifact
+
+    meta: Meta | None = None  # Metadata about the resource
+
+    implicit_rules: str | None = None  # A set of rules under which this content was created
+
+    language: str | None = None  # Language of the resource content
+
+    text: Narrative | None = None  # Text summary of the resource, for human interpretation
+
+    extension: list[Extension] | None = None  # Additional content defined by implementations
+
+    modifier_extension: list[Extension] | None = None  # Extensions that cannot be ignored
+
+    name: list[HumanName] | None = None  # The name(s) associated with the practitioner
+
+    supervisor: Practitioner | None = None  # Practitioner responsible for this one
+
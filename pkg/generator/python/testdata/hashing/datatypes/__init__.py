@@ -0,0 +1,29 @@
+"""Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from .period import Period
+from .address import Address
+from .coding import Coding
+from .codeableconcept import CodeableConcept
+from .identifier import Identifier
+from .reference import Reference
+from .extension import Extension
+from .humanname import HumanName
+from .meta import Meta
+from .narrative import Narrative
+from .quantity import Quantity
+
+__all__ = [
+    "Period",
+    "Address",
+    "Coding",
+    "CodeableConcept",
+    "Identifier",
+    "Reference",
+    "Extension",
+    "HumanName",
+    "Meta",
+    "Narrative",
+    "Quantity",
+]
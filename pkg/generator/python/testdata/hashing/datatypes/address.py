@@ -0,0 +1,57 @@
+"""An address expressed using postal conventions
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .period import Period
+
+
+@dataclass
+class Address:
+    """An address expressed using postal conventions"""
+
+    use: str | None = None  # home | work | temp | old | billing - purpose of this address
+
+    type: str | None = None  # postal | physical | both
+
+    text: str | None = None  # Text representation of the address
+
+    line: list[str] | None = None  # Street name, number, direction & P.O. Box etc.
+
+    city: str | None = None  # Name of city, town etc.
+
+    district: str | None = None  # District name (aka county)
+
+    state: str | None = None  # Sub-unit of country (abbreviations ok)
+
+    postal_code: str | None = None  # Postal code for area
+
+    country: str | None = None  # Country (e.g. can be ISO 3166 2 or 3 letter code)
+
+    period: Period | None = None  # Time period when address was/is in use
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"city": "city", "country": "country", "district": "district", "line": "line", "period": "period", "postal_code": "postalCode", "state": "state", "text": "text", "type": "type", "use": "use"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Address) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
@@ -0,0 +1,41 @@
+"""Concept - reference to a terminology or just text
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .coding import Coding
+
+
+@dataclass
+class CodeableConcept:
+    """Concept - reference to a terminology or just text"""
+
+    coding: list[Coding] | None = None  # Code defined by a terminology system
+
+    text: str | None = None  # Plain text representation of the concept
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"coding": "coding", "text": "text"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, CodeableConcept) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
@@ -0,0 +1,46 @@
+"""A reference to a code defined by a terminology system
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+
+
+@dataclass
+class Coding:
+    """A reference to a code defined by a terminology system"""
+
+    system: str | None = None  # Identity of the terminology system
+
+    version: str | None = None  # Version of the system - if relevant
+
+    code: str | None = None  # Symbol in syntax defined by the system
+
+    display: str | None = None  # Representation defined by the system
+
+    user_selected: bool | None = None  # If this coding was chosen directly by the user
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"code": "code", "display": "display", "system": "system", "user_selected": "userSelected", "version": "version"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Coding) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
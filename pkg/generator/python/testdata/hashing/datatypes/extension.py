@@ -0,0 +1,62 @@
+"""Additional content defined by implementations
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .address import Address
+from .codeableconcept import CodeableConcept
+from .coding import Coding
+from .reference import Reference
+
+
+@dataclass
+class Extension:
+    """Additional content defined by implementations"""
+
+    url: str  # Identifies the meaning of the extension
+
+    value_string: str | None = None  # Value of extension
+
+    value_code: str | None = None  # Value of extension
+
+    value_boolean: bool | None = None  # Value of extension
+
+    value_integer: int | None = None  # Value of extension
+
+    value_decimal: float | None = None  # Value of extension
+
+    value_date_time: datetime | None = None  # Value of extension
+
+    value_coding: Coding | None = None  # Value of extension
+
+    value_codeable_concept: CodeableConcept | None = None  # Value of extension
+
+    value_reference: Reference | None = None  # Value of extension
+
+    value_address: Address | None = None  # Value of extension
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"url": "url", "value_address": "valueAddress", "value_boolean": "valueBoolean", "value_code": "valueCode", "value_codeable_concept": "valueCodeableConcept", "value_coding": "valueCoding", "value_date_time": "valueDateTime", "value_decimal": "valueDecimal", "value_integer": "valueInteger", "value_reference": "valueReference", "value_string": "valueString"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Extension) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
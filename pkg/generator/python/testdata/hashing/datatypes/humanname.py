@@ -0,0 +1,51 @@
+"""Name of a human - parts and usage
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .period import Period
+
+
+@dataclass
+class HumanName:
+    """Name of a human - parts and usage"""
+
+    use: str | None = None  # usual | official | temp | nickname | anonymous | old | maiden
+
+    text: str | None = None  # Text representation of the full name
+
+    family: str | None = None  # Family name (often called 'Surname')
+
+    given: list[str] | None = None  # Given names (not always 'first'). Includes middle names
+
+    prefix: list[str] | None = None  # Parts that come before the name
+
+    suffix: list[str] | None = None  # Parts that come after the name
+
+    period: Period | None = None  # Time period when name was/is in use
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"family": "family", "given": "given", "period": "period", "prefix": "prefix", "suffix": "suffix", "text": "text", "use": "use"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, HumanName) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
@@ -0,0 +1,48 @@
+"""An identifier intended for computation
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .codeableconcept import CodeableConcept
+from .period import Period
+
+
+@dataclass
+class Identifier:
+    """An identifier intended for computation"""
+
+    use: str | None = None  # usual | official | temp | secondary | old (If known)
+
+    type: CodeableConcept | None = None  # Description of identifier
+
+    system: str | None = None  # The namespace for the identifier value
+
+    value: str | None = None  # The value that is unique
+
+    period: Period | None = None  # Time period when id is/was valid for use
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"period": "period", "system": "system", "type": "type", "use": "use", "value": "value"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Identifier) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
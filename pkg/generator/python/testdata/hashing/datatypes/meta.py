@@ -0,0 +1,49 @@
+"""Metadata about a resource
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .coding import Coding
+
+
+@dataclass
+class Meta:
+    """Metadata about a resource"""
+
+    version_id: str | None = None  # Version specific identifier
+
+    last_updated: datetime | None = None  # When the resource version last changed
+
+    source: str | None = None  # Identifies where the resource comes from
+
+    profile: list[str] | None = None  # Profiles this resource claims to conform to
+
+    security: list[Coding] | None = None  # Security Labels applied to this resource
+
+    tag: list[Coding] | None = None  # Tags applied to this resource
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"last_updated": "lastUpdated", "profile": "profile", "security": "security", "source": "source", "tag": "tag", "version_id": "versionId"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Meta) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
@@ -0,0 +1,45 @@
+"""A reference from one resource to another
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._canonical import encode_canonical, hash_canonical
+from .identifier import Identifier
+
+
+@dataclass
+class Reference:
+    """A reference from one resource to another"""
+
+    reference: str | None = None  # Literal reference, Relative, internal or absolute URL
+
+    type: str | None = None  # Type the reference refers to (e.g. "Patient")
+
+    identifier: Identifier | None = None  # Logical reference, when literal reference is not known
+
+    display: str | None = None  # Text alternative for the resource
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"display": "display", "identifier": "identifier", "reference": "reference", "type": "type"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Reference) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
@@ -0,0 +1,85 @@
+"""Canonical JSON and hashes of records, which ehrglot writes alike in every language.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+import base64
+import hashlib
+import math
+from datetime import date, datetime, timezone
+from decimal import Decimal
+from typing import Any
+
+# Canonical JSON of the null and empty members that objects leave out.
+_EMPTY = frozenset({"null", '""', "false", "0", "[]", "{}"})
+
+_ESCAPES = {'"': '\\"', "\\": "\\\\", "\b": "\\b", "\f": "\\f", "\n": "\\n", "\r": "\\r", "\t": "\\t"}
+
+
+def encode_canonical(members: dict[str, Any]) -> str:
+    """Return members as a canonical JSON object, sorted by name and without those that are null or empty: "", false, 0, [], and {}."""
+    parts: list[str] = []
+    for name in sorted(members):
+        value = _encode(members[name])
+        if value not in _EMPTY:
+            parts.append(_string(name) + ":" + value)
+    return "{" + ",".join(parts) + "}"
+
+
+def hash_canonical(text: str) -> str:
+    """Return the hex SHA-256 of the canonical JSON text."""
+    return hashlib.sha256(text.encode()).hexdigest()
+
+
+def _encode(value: Any) -> str:
+    """Return value as canonical JSON: records as their own canonical JSON, instants in UTC as RFC 3339, numbers in fixed notation with no trailing zeros, and binary as base64."""
+    if value is None:
+        return "null"
+    if hasattr(value, "canonical_json"):
+        return str(value.canonical_json())
+    if isinstance(value, str):
+        return _string(value)
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, int):
+        return str(value)
+    if isinstance(value, float):
+        if not math.isfinite(value):
+            raise ValueError(f"unsupported number {value}")
+        return _number(Decimal(repr(value)))
+    if isinstance(value, Decimal):
+        if not value.is_finite():
+            raise ValueError(f"unsupported number {value}")
+        return _number(value)
+    if isinstance(value, (bytes, bytearray)):
+        return _string(base64.b64encode(value).decode())
+    if isinstance(value, datetime):
+        instant = value.replace(tzinfo=timezone.utc) if value.tzinfo is None else value.astimezone(timezone.utc)
+        text = instant.replace(microsecond=0, tzinfo=None).isoformat()
+        if instant.microsecond:
+            text += f".{instant.microsecond:06d}".rstrip("0")
+        return _string(text + "Z")
+    if isinstance(value, date):
+        return _string(value.isoformat())
+    if isinstance(value, dict):
+        return encode_canonical({str(k): v for k, v in value.items()})
+    if isinstance(value, (list, tuple)):
+        return "[" + ",".join(_encode(v) for v in value) + "]"
+    # Anything else, such as a PartialDate, is written as its text.
+    return _string(str(value))
+
+
+def _number(value: Decimal) -> str:
+    """Return value in fixed notation without trailing fractional zeros or a negative zero."""
+    text = format(value, "f")
+    if "." in text:
+        text = text.rstrip("0").rstrip(".")
+    return "0" if text == "-0" else text
+
+
+def _string(text: str) -> str:
+    """Return text as a JSON string, escaping only quotes, backslashes, and control characters, as RFC 8785 does."""
+    return '"' + "".join(_ESCAPES.get(c) or (f"\\u{ord(c):04x}" if c < " " else c) for c in text) + '"'
@@ -0,0 +1,89 @@
+"""An encounter whose field names are reserved words in target languages
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import TYPE_CHECKING, Any, ClassVar
+
+from datatypes.address import Address
+from datatypes.codeableconcept import CodeableConcept
+from datatypes.coding import Coding
+from datatypes.quantity import Quantity
+from datatypes.reference import Reference
+from registry.practitioner import Practitioner
+
+from ._canonical import encode_canonical, hash_canonical
+
+if TYPE_CHECKING:
+    from .specimen import Specimen
+
+
+@dataclass
+class Visit:
+    """An encounter whose field names are reserved words in target languages"""
+
+    class_: Coding  # Classification of the visit
+
+    type: list[CodeableConcept] | None = None  # Specific type of visit
+
+    for_: Reference | None = None  # Who the visit is for
+
+    match: str | None = "unlinked"  # Record linkage match grade
+
+    order: int | None = None  # Sequence within the episode
+
+    self: str | None = None  # Canonical URL of the visit
+
+    where: Address | None = None  # Where the visit took place
+
+    _2nd_opinion: bool | None = None  # Whether a second opinion was requested
+
+    priority_code: str | None = None  # Priority as a code
+
+    priority_code_2: str | None = None  # Priority as legacy text
+
+    visit: str | None = None  # Visit number
+
+    length: Quantity | None = None  # Time the visit lasted
+
+    attender: Practitioner | None = None  # Practitioner who saw the patient
+
+    specimens: list[Specimen] | None = None  # Specimens collected during the visit
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"_2nd_opinion": "2ndOpinion", "attender": "attender", "class_": "class", "for_": "for", "length": "length", "match": "match", "order": "order", "priority_code": "priorityCode", "priority_code_2": "priority_code", "self": "self", "specimens": "specimens", "type": "type", "visit": "visit", "where": "where"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Visit) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
+    def validate(self) -> None:
+        """Raise ValueError when fields that a condition on another field requires are missing, or set although one leaves them out."""
+        errors: list[str] = []
+        if self._2nd_opinion is True and self.attender is None:
+            errors.append("attender is required when 2ndOpinion == true")
+        if errors:
+            raise ValueError("; ".join(errors))
+
+    @property
+    def display(self) -> str | None:
+        """Return display, derived as concat('Visit ', visit), or None when a field it uses is unset. Label shown for the visit"""
+        if self.visit is None:
+            return None
+        return "Visit " + self.visit
+
@@ -0,0 +1,59 @@
+"""A person providing care
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from datatypes.extension import Extension
+from datatypes.humanname import HumanName
+from datatypes.meta import Meta
+from datatypes.narrative import Narrative
+
+from ._canonical import encode_canonical, hash_canonical
+
+
+@dataclass
+class Practitioner:
+    """A person providing care"""
+
+    id: str  # Logical id of this artifact
+
+    meta: Meta | None = None  # Metadata about the resource
+
+    implicit_rules: str | None = None  # A set of rules under which this content was created
+
+    language: str | None = None  # Language of the resource content
+
+    text: Narrative | None = None  # Text summary of the resource, for human interpretation
+
+    extension: list[Extension] | None = None  # Additional content defined by implementations
+
+    modifier_extension: list[Extension] | None = None  # Extensions that cannot be ignored
+
+    name: list[HumanName] | None = None  # The name(s) associated with the practitioner
+
+    supervisor: Practitioner | None = None  # Practitioner responsible for this one
+
+    # Schema names of the fields by attribute, in canonical JSON order.
+    _CANONICAL: ClassVar[dict[str, str]] = {"extension": "extension", "id": "id", "implicit_rules": "implicitRules", "language": "language", "meta": "meta", "modifier_extension": "modifierExtension", "name": "name", "supervisor": "supervisor", "text": "text"}
+
+    def canonical_json(self) -> str:
+        """Return this record as canonical JSON, which ehrglot writes alike in every language: members named as the schema names fields and sorted, no whitespace, and no null or empty members."""
+        return encode_canonical({name: getattr(self, attr) for attr, name in self._CANONICAL.items()})
+
+    def canonical_hash(self) -> str:
+        """Return the hex SHA-256 of the canonical JSON of this record, which identifies the record across languages, such as to deduplicate repeated feed records."""
+        return hash_canonical(self.canonical_json())
+
+    def __eq__(self, other: object) -> bool:
+        return isinstance(other, Practitioner) and self.canonical_json() == other.canonical_json()
+
+    def __hash__(self) -> int:
+        return hash(self.canonical_json())
+
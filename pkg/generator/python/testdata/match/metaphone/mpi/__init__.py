@@ -0,0 +1,9 @@
+"""Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from .person import Person
+
+__all__ = [
+    "Person",
+]
@@ -0,0 +1,145 @@
+"""Patient matching keys, which records that are candidate matches share.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+def match_keys(components: list[str], identifiers: list[tuple[str, str | None]]) -> list[str]:
+    """Return the demographic key made of components, unless there are none or one is empty, and the key of each identifier, a name and a value, whose normalized value is not empty."""
+    keys: list[str] = []
+    if components and all(components):
+        keys.append("demographics:" + "|".join(components))
+    for name, value in identifiers:
+        normalized = _normalize(value or "", digits=True)
+        if normalized:
+            keys.append(f"{name}:{normalized}")
+    return keys
+
+
+def name_key(name: str | None) -> str:
+    """Return the key component of a name: the Metaphone code of its letters."""
+    return _metaphone(_normalize(name or ""))
+
+
+def date_key(value: Any) -> str:
+    """Return the key component of a date, as much of it as is known."""
+    return "" if value is None else str(value)
+
+
+def sex_key(sex: str | None) -> str:
+    """Return the key component of a sex: its first letter."""
+    return _normalize(sex or "")[:1]
+
+
+def _normalize(value: str, digits: bool = False) -> str:
+    """Return the letters A to Z of value, and its digits too when digits is set, in upper case."""
+    return "".join(c.upper() for c in value if "A" <= c <= "Z" or "a" <= c <= "z" or (digits and "0" <= c <= "9"))
+
+
+def _metaphone(name: str) -> str:
+    """Return the Metaphone code of name, which holds letters A to Z only, by the rules of the original algorithm: initial letters that are silent or kept, then consonants by their sound, and no vowels after the first letter."""
+
+    def at(i: int) -> str:
+        return name[i] if 0 <= i < len(name) else ""
+
+    def vowel(c: str) -> bool:
+        return c != "" and c in "AEIOU"
+
+    def soft(c: str) -> bool:
+        return c != "" and c in "EIY"
+
+    code = ""
+    i = 0
+    first, second = at(0), at(1)
+    if first == "A":
+        code, i = ("E", 2) if second == "E" else ("A", 1)
+    elif first in ("G", "K", "P") and second == "N":
+        code, i = "N", 2
+    elif first == "W" and second == "R":
+        code, i = "R", 2
+    elif first == "W" and (second == "H" or vowel(second)):
+        code, i = "W", 2
+    elif first == "X":
+        code, i = "S", 1
+    elif first in ("E", "I", "O", "U"):
+        code, i = first, 1
+    while i < len(name):
+        c, prev, ahead, after = name[i], at(i - 1), at(i + 1), at(i + 2)
+        i += 1
+        if c == prev and c != "C":
+            continue
+        if c == "B":
+            if prev != "M":
+                code += "B"
+        elif c == "C":
+            if ahead == "I" and after == "A":
+                code += "X"
+            elif soft(ahead):
+                if prev != "S":
+                    code += "S"
+            elif ahead == "H":
+                code += "X"
+                i += 1
+            else:
+                code += "K"
+        elif c == "D":
+            if ahead == "G" and soft(after):
+                code += "J"
+                i += 1
+            else:
+                code += "T"
+        elif c == "G":
+            if ahead == "H":
+                if not (at(i - 4) in ("B", "D", "H") or at(i - 5) == "H"):
+                    code += "F"
+                    i += 1
+            elif ahead == "N":
+                if after != "" and not (after == "E" and at(i + 2) == "D"):
+                    code += "K"
+            elif soft(ahead) and prev != "G":
+                code += "J"
+            else:
+                code += "K"
+        elif c == "H":
+            if vowel(ahead) and (prev == "" or prev not in "CGPST"):
+                code += "H"
+        elif c == "K":
+            if prev != "C":
+                code += "K"
+        elif c == "P":
+            code += "F" if ahead == "H" else "P"
+        elif c == "Q":
+            code += "K"
+        elif c == "S":
+            if ahead == "I" and after in ("O", "A"):
+                code += "X"
+            elif ahead == "H":
+                code += "X"
+                i += 1
+            else:
+                code += "S"
+        elif c == "T":
+            if ahead == "I" and after in ("O", "A"):
+                code += "X"
+            elif ahead == "H":
+                code += "0"
+                i += 1
+            elif not (ahead == "C" and after == "H"):
+                code += "T"
+        elif c == "V":
+            code += "F"
+        elif c in "WY":
+            if vowel(ahead):
+                code += c
+        elif c == "X":
+            code += "KS"
+        elif c == "Z":
+            code += "S"
+        elif c in "FJLMNR":
+            code += c
+    return code
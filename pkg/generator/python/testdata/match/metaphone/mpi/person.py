@@ -0,0 +1,41 @@
+"""A person in the master patient index
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from ._match import date_key, match_keys, name_key, sex_key
+from ._temporal import PartialDate
+
+
+@dataclass
+class Person:
+    """A person in the master patient index"""
+
+    id: str  # Logical id of this person
+
+    mrn: str  # Medical record number
+
+    ssn: str | None = None  # Social security number
+
+    family_name: str | None = None
+
+    given_name: str | None = None
+
+    birth_date: PartialDate | None = None
+
+    sex: str | None = None
+
+    def match_keys(self) -> list[str]:
+        """Return the patient matching keys of this record, which records that are candidate matches share: a blocking key of its normalized demographics, when all are set, and a key of each identifier that is set."""
+        return match_keys(
+            [name_key(self.family_name), name_key(self.given_name), date_key(self.birth_date), sex_key(self.sex)],
+            [("mrn", self.mrn), ("ssn", self.ssn)],
+        )
+
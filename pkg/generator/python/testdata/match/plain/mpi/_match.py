@@ -0,0 +1,41 @@
+"""Patient matching keys, which records that are candidate matches share.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+def match_keys(components: list[str], identifiers: list[tuple[str, str | None]]) -> list[str]:
+    """Return the demographic key made of components, unless there are none or one is empty, and the key of each identifier, a name and a value, whose normalized value is not empty."""
+    keys: list[str] = []
+    if components and all(components):
+        keys.append("demographics:" + "|".join(components))
+    for name, value in identifiers:
+        normalized = _normalize(value or "", digits=True)
+        if normalized:
+            keys.append(f"{name}:{normalized}")
+    return keys
+
+
+def name_key(name: str | None) -> str:
+    """Return the key component of a name: its letters."""
+    return _normalize(name or "")
+
+
+def date_key(value: Any) -> str:
+    """Return the key component of a date, as much of it as is known."""
+    return "" if value is None else str(value)
+
+
+def sex_key(sex: str | None) -> str:
+    """Return the key component of a sex: its first letter."""
+    return _normalize(sex or "")[:1]
+
+
+def _normalize(value: str, digits: bool = False) -> str:
+    """Return the letters A to Z of value, and its digits too when digits is set, in upper case."""
+    return "".join(c.upper() for c in value if "A" <= c <= "Z" or "a" <= c <= "z" or (digits and "0" <= c <= "9"))
@@ -0,0 +1,64 @@
+"""Patient matching keys, which records that are candidate matches share.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+# The Soundex digits of the letters A to Z.
+_SOUNDEX_CODES = "01230120022455012623010202"
+
+
+def match_keys(components: list[str], identifiers: list[tuple[str, str | None]]) -> list[str]:
+    """Return the demographic key made of components, unless there are none or one is empty, and the key of each identifier, a name and a value, whose normalized value is not empty."""
+    keys: list[str] = []
+    if components and all(components):
+        keys.append("demographics:" + "|".join(components))
+    for name, value in identifiers:
+        normalized = _normalize(value or "", digits=True)
+        if normalized:
+            keys.append(f"{name}:{normalized}")
+    return keys
+
+
+def name_key(name: str | None) -> str:
+    """Return the key component of a name: the Soundex code of its letters."""
+    return _soundex(_normalize(name or ""))
+
+
+def date_key(value: Any) -> str:
+    """Return the key component of a date, as much of it as is known."""
+    return "" if value is None else str(value)
+
+
+def sex_key(sex: str | None) -> str:
+    """Return the key component of a sex: its first letter."""
+    return _normalize(sex or "")[:1]
+
+
+def _normalize(value: str, digits: bool = False) -> str:
+    """Return the letters A to Z of value, and its digits too when digits is set, in upper case."""
+    return "".join(c.upper() for c in value if "A" <= c <= "Z" or "a" <= c <= "z" or (digits and "0" <= c <= "9"))
+
+
+def _soundex(name: str) -> str:
+    """Return the American Soundex code of name, which holds letters A to Z only: its first letter and the digits of the next consonants, which H and W do not separate, padded to four."""
+    if not name:
+        return ""
+    code = name[0]
+    last = _SOUNDEX_CODES[ord(name[0]) - ord("A")]
+    for c in name[1:]:
+        if len(code) == 4:
+            break
+        digit = _SOUNDEX_CODES[ord(c) - ord("A")]
+        if c in "HW":
+            continue
+        if digit == "0":
+            last = digit
+        elif digit != last:
+            code += digit
+            last = digit
+    return code.ljust(4, "0")
@@ -0,0 +1,56 @@
+"""Migrations of records from the old to the new version of changed schemas.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+from typing import Any
+
+
+def migrate_clinic_patient(record: dict[str, Any]) -> dict[str, Any]:
+    """Return a clinic.Patient record of the old version in the shape of the new one."""
+    record = dict(record)
+    record.pop("fax", None)
+    renamed = [("medicalRecordNumber", "mrn")]
+    record.update({new: record.pop(old) for old, new in renamed if old in record})
+    if record.get("visits") is not None:
+        record["visits"] = float(record["visits"])
+    if record.get("rank") is not None:
+        record["rank"] = str(record["rank"])
+    # birthDate (string to date): values are kept as they are.
+    if record.get("active") is None:
+        record["active"] = True
+    if record.get("language") is None:
+        record["language"] = "en-US"
+    # gender is required but has no default to fill.
+    if record.get("contact") is not None:
+        record["contact"] = _each(record["contact"], _migrate_clinic_patient_contact)
+    return record
+
+
+def _migrate_clinic_patient_contact(record: dict[str, Any]) -> dict[str, Any]:
+    """Migrate a clinic.Patient.contact record."""
+    record = dict(record)
+    renamed = [("name", "fullName")]
+    record.update({new: record.pop(old) for old, new in renamed if old in record})
+    if record.get("telecom") is not None:
+        record["telecom"] = _each(record["telecom"], _migrate_clinic_patient_contact_telecom)
+    return record
+
+
+def _migrate_clinic_patient_contact_telecom(record: dict[str, Any]) -> dict[str, Any]:
+    """Migrate a clinic.Patient.contact.telecom record."""
+    record = dict(record)
+    if record.get("rank") is None:
+        record["rank"] = 1
+    return record
+
+
+def _each(value: Any, migrate: Callable[[dict[str, Any]], dict[str, Any]]) -> Any:
+    """Migrate a nested record, or each of a list of them."""
+    if isinstance(value, list):
+        return [migrate(item) for item in value]
+    return migrate(value)
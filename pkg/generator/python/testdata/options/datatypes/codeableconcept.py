@@ -0,0 +1,25 @@
+"""Concept - reference to a terminology or just text
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any
+
+from ._temporal import PartialDate
+from .coding import Coding
+
+
+@dataclass
+class CodeableConcept:
+    """Concept - reference to a terminology or just text"""
+
+    coding: list[Coding] | None = None  # Code defined by a terminology system
+
+    text: str | None = None  # Plain text representation of the concept
+
@@ -0,0 +1,46 @@
+"""Additional content defined by implementations
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any
+
+from ._temporal import PartialDate
+from .address import Address
+from .codeableconcept import CodeableConcept
+from .coding import Coding
+from .reference import Reference
+
+
+@dataclass
+class Extension:
+    """Additional content defined by implementations"""
+
+    url: str  # Identifies the meaning of the extension
+
+    value_string: str | None = None  # Value of extension
+
+    value_code: str | None = None  # Value of extension
+
+    value_boolean: bool | None = None  # Value of extension
+
+    value_integer: int | None = None  # Value of extension
+
+    value_decimal: Decimal | None = None  # Value of extension
+
+    value_date_time: datetime | None = None  # Value of extension
+
+    value_coding: Coding | None = None  # Value of extension
+
+    value_codeable_concept: CodeableConcept | None = None  # Value of extension
+
+    value_reference: Reference | None = None  # Value of extension
+
+    value_address: Address | None = None  # Value of extension
+
@@ -0,0 +1,32 @@
+"""An identifier intended for computation
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any
+
+from ._temporal import PartialDate
+from .codeableconcept import CodeableConcept
+from .period import Period
+
+
+@dataclass
+class Identifier:
+    """An identifier intended for computation"""
+
+    use: str | None = None  # usual | official | temp | secondary | old (If known)
+
+    type: CodeableConcept | None = None  # Description of identifier
+
+    system: str | None = None  # The namespace for the identifier value
+
+    value: str | None = None  # The value that is unique
+
+    period: Period | None = None  # Time period when id is/was valid for use
+
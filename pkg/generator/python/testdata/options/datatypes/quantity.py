@@ -0,0 +1,30 @@
+"""A measured amount (or an amount that can potentially be measured)
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any
+
+from ._temporal import PartialDate
+
+
+@dataclass
+class Quantity:
+    """A measured amount (or an amount that can potentially be measured)"""
+
+    value: Decimal | None = None  # Numerical value (with implicit precision)
+
+    comparator: str | None = None  # < | <= | >= | > - how to understand the value
+
+    unit: str | None = None  # Unit representation
+
+    system: str | None = None  # System that defines coded unit form
+
+    code: str | None = None  # Coded form of the unit
+
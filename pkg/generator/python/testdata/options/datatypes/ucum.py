@@ -0,0 +1,107 @@
+"""UCUM unit validation and conversion for Quantity.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import replace
+from decimal import Decimal
+from typing import NamedTuple
+
+from .quantity import Quantity
+
+UCUM_SYSTEM = "http://unitsofmeasure.org"
+
+
+class Unit(NamedTuple):
+    """A unit whose value v is v * factor + offset in its dimension's base unit."""
+
+    dimension: str
+    factor: float
+    offset: float = 0.0
+
+
+UNITS: dict[str, Unit] = {
+    "g": Unit("mass", 1.0),
+    "kg": Unit("mass", 1000.0),
+    "mg": Unit("mass", 0.001),
+    "ug": Unit("mass", 1e-06),
+    "ng": Unit("mass", 1e-09),
+    "[lb_av]": Unit("mass", 453.59237),
+    "[oz_av]": Unit("mass", 28.349523125),
+    "m": Unit("length", 1.0),
+    "km": Unit("length", 1000.0),
+    "cm": Unit("length", 0.01),
+    "mm": Unit("length", 0.001),
+    "[in_i]": Unit("length", 0.0254),
+    "[ft_i]": Unit("length", 0.3048),
+    "L": Unit("volume", 1.0),
+    "dL": Unit("volume", 0.1),
+    "mL": Unit("volume", 0.001),
+    "uL": Unit("volume", 1e-06),
+    "s": Unit("time", 1.0),
+    "min": Unit("time", 60.0),
+    "h": Unit("time", 3600.0),
+    "d": Unit("time", 86400.0),
+    "wk": Unit("time", 604800.0),
+    "mo": Unit("time", 2.6298e+06),
+    "a": Unit("time", 3.15576e+07),
+    "K": Unit("temperature", 1.0),
+    "Cel": Unit("temperature", 1.0, 273.15),
+    "[degF]": Unit("temperature", 0.5555555555555556, 255.37222222222223),
+    "g/L": Unit("mass_concentration", 1.0),
+    "g/dL": Unit("mass_concentration", 10.0),
+    "mg/dL": Unit("mass_concentration", 0.01),
+    "mg/L": Unit("mass_concentration", 0.001),
+    "ug/mL": Unit("mass_concentration", 0.001),
+    "ng/mL": Unit("mass_concentration", 1e-06),
+    "mol/L": Unit("substance_concentration", 1.0),
+    "mmol/L": Unit("substance_concentration", 0.001),
+    "umol/L": Unit("substance_concentration", 1e-06),
+    "nmol/L": Unit("substance_concentration", 1e-09),
+    "Pa": Unit("pressure", 1.0),
+    "kPa": Unit("pressure", 1000.0),
+    "mm[Hg]": Unit("pressure", 133.322),
+    "Hz": Unit("frequency", 1.0),
+    "/min": Unit("frequency", 0.016666666666666666),
+    "/h": Unit("frequency", 0.0002777777777777778),
+    "1": Unit("ratio", 1.0),
+    "%": Unit("ratio", 0.01),
+}
+
+
+def is_known_unit(code: str) -> bool:
+    """Report whether code is a UCUM unit these helpers can convert."""
+    return code in UNITS
+
+
+def convert_value(value: float, from_code: str, to_code: str) -> float:
+    """Convert value between two UCUM units of the same dimension."""
+    source, target = _unit(from_code), _unit(to_code)
+    if source.dimension != target.dimension:
+        raise ValueError(f"cannot convert {from_code} ({source.dimension}) to {to_code} ({target.dimension})")
+    return (value * source.factor + source.offset - target.offset) / target.factor
+
+
+def convert(quantity: Quantity, to_code: str) -> Quantity:
+    """Return quantity in to_code units. Its code, or else its unit, must be a known UCUM unit."""
+    if quantity.value is None:
+        raise ValueError("cannot convert a quantity without a value")
+    from_code = quantity.code or quantity.unit or ""
+    value = convert_value(float(quantity.value), from_code, to_code)
+    return replace(
+        quantity,
+        value=Decimal(repr(value)) if isinstance(quantity.value, Decimal) else value,
+        unit=to_code,
+        system=UCUM_SYSTEM,
+        code=to_code,
+    )
+
+
+def _unit(code: str) -> Unit:
+    unit = UNITS.get(code)
+    if unit is None:
+        raise ValueError(f"unknown UCUM unit: {code!r}")
+    return unit
@@ -0,0 +1,103 @@
+"""Résultat de laboratoire — température °C, 检验结果
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, replace
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any, ClassVar
+
+from datatypes.humanname import HumanName
+from datatypes.quantity import Quantity
+from registry.practitioner import Practitioner
+
+from ._encryption import FieldEncryptor, crypt_fields
+from ._narrative import narrative
+from ._temporal import PartialDate
+
+
+@dataclass
+class lab_result:
+    """Résultat de laboratoire — température °C, 检验结果
+
+    Owner: lab-data
+    Steward: ana.ruiz@example.org
+    Tags: clinical, phi
+    Unique: result_id
+    Indexed: mrn, resulted_at
+    """
+
+    result_id: str  # Identifiant du résultat. Example: LR-1001
+
+    mrn: str  # Medical record number
+
+    value_numeric: Decimal | None = None  # Valeur mesurée (µmol/L). Examples: 5.4, 12.0
+
+    charge_amount: Decimal | None = Decimal("0")  # Billed amount
+
+    value_quantity: Quantity | None = None  # Measured value with its unit
+
+    resulted_at: datetime | None = None  # When the result was released
+
+    performer_name: HumanName | None = None  # Who performed the test
+
+    ordered_by: Practitioner | None = None  # Who ordered the test
+
+    raw_payload: bytes | None = None  # Original HL7 message
+
+    icd10_code: str | None = None  # Diagnosis the test was ordered for
+
+    reviewer_ids: list[str] | None = None  # Reviewing clinicians
+
+    ingested_at: datetime | None = None  # When the record was loaded into the warehouse
+
+    source_system: str | None = None  # System the record was extracted from
+
+    record_hash: str | None = None  # Hash of the source record, for change detection
+
+    # Source name and encryption mode of each encrypted field.
+    _ENCRYPTED: ClassVar[dict[str, tuple[str, str]]] = {"mrn": ("lab_result.mrn", "deterministic")}
+
+    def encrypt_fields(self, encryptor: FieldEncryptor) -> None:
+        """Replace the encrypted fields of this record with their ciphertexts, before it is stored."""
+        crypt_fields(self, self._ENCRYPTED, encryptor.encrypt)
+
+    def decrypt_fields(self, encryptor: FieldEncryptor) -> None:
+        """Replace the encrypted fields of this record with their plaintexts, after it is loaded."""
+        crypt_fields(self, self._ENCRYPTED, encryptor.decrypt)
+
+    def value_numeric_out_of_range(self) -> bool:
+        """Return whether value_numeric is set and outside its reference range, 3.5 to 11 umol/L."""
+        return self.value_numeric is not None and not Decimal("3.5") <= self.value_numeric <= Decimal("11")
+
+    @property
+    def value_mmol(self) -> Decimal | None:
+        """Return value_mmol, derived as value_numeric / 1000, or None when a field it uses is unset. Measured value in mmol/L"""
+        if self.value_numeric is None:
+            return None
+        return self.value_numeric / 1000
+
+    def to_narrative(self) -> str:
+        """Return the fields of this record that are set as an XHTML table, for the text.div of its FHIR resource."""
+        return narrative(
+            [
+                ("Identifiant du résultat", self.result_id, ""),
+                ("Valeur mesurée (µmol/L)", self.value_numeric, "umol/L"),
+                ("Billed amount", self.charge_amount, ""),
+                ("When the result was released", self.resulted_at, ""),
+                ("Diagnosis the test was ordered for", self.icd10_code, ""),
+            ]
+        )
+
+    def for_purpose(self, purpose: str) -> lab_result | None:
+        """Return a copy of this record without the fields that purpose may not see, or None when it may not see the record."""
+        if purpose == "treatment":
+            return replace(self)
+        if purpose == "research":
+            return replace(self, mrn=None)
+        return None
+
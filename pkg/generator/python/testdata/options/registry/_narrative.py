@@ -0,0 +1,29 @@
+"""XHTML narratives of records, for the text.div of FHIR resources.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from datetime import date
+from html import escape
+from typing import Any
+
+
+def narrative(rows: list[tuple[str, Any, str]]) -> str:
+    """Return an XHTML table of the labeled values of rows that are set, each followed by its unit, if any."""
+    cells: list[str] = []
+    for label, value, unit in rows:
+        if value is None:
+            continue
+        if isinstance(value, bool):
+            text = "Yes" if value else "No"
+        elif isinstance(value, date):
+            text = value.isoformat()
+        else:
+            text = str(value)
+        if unit:
+            text += " " + unit
+        cells.append(f"<tr><th>{escape(label)}</th><td>{escape(text)}</td></tr>")
+    return '<div xmlns="http://www.w3.org/1999/xhtml"><table>' + "".join(cells) + "</table></div>"
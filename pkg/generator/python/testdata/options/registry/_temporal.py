@@ -0,0 +1,51 @@
+"""Partial date support for FHIR dates.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass
+from datetime import date
+
+_PARTIAL_DATE = re.compile(r"(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?")
+
+
+@dataclass(frozen=True)
+class PartialDate:
+    """A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14."""
+
+    year: int
+    month: int | None = None
+    day: int | None = None
+
+    def __post_init__(self) -> None:
+        if self.day is not None and self.month is None:
+            raise ValueError("a partial date with a day must have a month")
+        # Reject impossible dates such as 1970-02-30
+        date(self.year, self.month or 1, self.day or 1)
+
+    @classmethod
+    def parse(cls, value: str) -> PartialDate:
+        """Parse a date in YYYY, YYYY-MM, or YYYY-MM-DD form."""
+        match = _PARTIAL_DATE.fullmatch(value)
+        if match is None:
+            raise ValueError(f"invalid partial date: {value!r}")
+        year, month, day = match.groups()
+        return cls(int(year), int(month) if month else None, int(day) if day else None)
+
+    def to_date(self) -> date | None:
+        """Return the full date, or None when the value is less precise than a day."""
+        if self.month is None or self.day is None:
+            return None
+        return date(self.year, self.month, self.day)
+
+    def __str__(self) -> str:
+        text = f"{self.year:04d}"
+        if self.month is not None:
+            text += f"-{self.month:02d}"
+        if self.day is not None:
+            text += f"-{self.day:02d}"
+        return text
@@ -0,0 +1,60 @@
+"""A person providing care
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from decimal import Decimal
+from typing import Any
+
+from datatypes.extension import Extension
+from datatypes.humanname import HumanName
+from datatypes.meta import Meta
+from datatypes.narrative import Narrative
+
+from ._narrative import narrative
+from ._temporal import PartialDate
+
+
+@dataclass
+class Practitioner:
+    """A person providing care"""
+
+    id: str  # Logical id of this artifact
+
+    meta: Meta | None = None  # Metadata about the resource
+
+    implicit_rules: str | None = None  # A set of rules under which this content was created
+
+    language: str | None = None  # Language of the resource content
+
+    text: Narrative | None = None  # Text summary of the resource, for human interpretation
+
+    extension: list[Extension] | None = None  # Additional content defined by implementations
+
+    modifier_extension: list[Extension] | None = None  # Extensions that cannot be ignored
+
+    name: list[HumanName] | None = None  # The name(s) associated with the practitioner
+
+    supervisor: Practitioner | None = None  # Practitioner responsible for this one
+
+    ingested_at: datetime | None = None  # When the record was loaded into the warehouse
+
+    source_system: str | None = None  # System the record was extracted from
+
+    record_hash: str | None = None  # Hash of the source record, for change detection
+
+    def to_narrative(self) -> str:
+        """Return the fields of this record that are set as an XHTML table, for the text.div of its FHIR resource."""
+        return narrative(
+            [
+                ("Logical id of this artifact", self.id, ""),
+                ("A set of rules under which this content was created", self.implicit_rules, ""),
+                ("Language of the resource content", self.language, ""),
+            ]
+        )
+
@@ -0,0 +1,13 @@
+"""Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from .visit import Visit
+from .specimen import Specimen
+from .lab_result import lab_result
+
+__all__ = [
+    "Visit",
+    "Specimen",
+    "lab_result",
+]
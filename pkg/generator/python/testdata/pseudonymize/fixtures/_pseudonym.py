@@ -0,0 +1,57 @@
+"""Pseudonymization helpers for research data releases.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+import hashlib
+import hmac
+import os
+from typing import NamedTuple
+
+# Environment variable that holds the HMAC key of pseudonyms.
+PSEUDONYM_KEY_ENV = "EHRGLOT_PSEUDONYM_KEY"
+
+
+class Pseudonym(NamedTuple):
+    """Links a token to the value it replaced, as a row of the re-identification table.
+
+    field is the schema and field name, such as patient.ssn.
+    """
+
+    token: str
+    field: str
+    value: str
+
+
+class Pseudonymizer:
+    """Replaces identifiers with stable tokens: the hex HMAC-SHA256 of the identifier under a secret key.
+
+    Releases made with the same key link on equal identifiers without
+    revealing them.
+    """
+
+    def __init__(self, key: bytes) -> None:
+        self._key = key
+
+    @classmethod
+    def from_env(cls) -> Pseudonymizer:
+        """Return a Pseudonymizer keyed by the value of PSEUDONYM_KEY_ENV."""
+        key = os.environ.get(PSEUDONYM_KEY_ENV)
+        if not key:
+            raise KeyError(f"{PSEUDONYM_KEY_ENV} is not set")
+        return cls(key.encode())
+
+    def token(self, value: str) -> str:
+        """Return the pseudonym of value."""
+        return hmac.new(self._key, value.encode(), hashlib.sha256).hexdigest()
+
+    def pseudonymize(self, field: str, value: str | None, pseudonyms: list[Pseudonym]) -> str | None:
+        """Return the token of value, recording it in pseudonyms, or value itself when it is empty."""
+        if not value:
+            return value
+        token = self.token(value)
+        pseudonyms.append(Pseudonym(token, field, value))
+        return token
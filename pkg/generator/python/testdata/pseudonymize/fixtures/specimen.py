@@ -0,0 +1,91 @@
+"""A sample collected for analysis
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, replace
+from datetime import date, datetime
+from typing import TYPE_CHECKING, Any
+
+from datatypes.identifier import Identifier
+from datatypes.reference import Reference
+
+from ._pseudonym import Pseudonym, Pseudonymizer
+
+if TYPE_CHECKING:
+    from .visit import Visit
+
+
+@dataclass
+class Specimen:
+    """A sample collected for analysis"""
+
+    id: str  # Logical id of this artifact
+
+    status: str = "available"  # available | unavailable | entered-in-error
+
+    accession_identifier: Identifier | None = None  # Identifier assigned by the lab
+
+    received_time: datetime | None = None  # When the specimen was received
+
+    collected_date: date | None = None  # Collection date
+
+    quantity: float | None = None  # Amount collected
+
+    container_count: int | None = 1  # Number of containers
+
+    fasting: bool | None = False  # Whether the patient was fasting
+
+    note: list[str] | None = None  # Comments
+
+    subject: Reference | None = None  # Where the specimen came from
+
+    request: list[Reference] | None = None  # Why the specimen was collected
+
+    visit: Visit | None = None  # Visit during which the specimen was collected
+
+    collection: Any | None = None  # Collection details
+
+    def pseudonymize(self, pseudonymizer: Pseudonymizer) -> tuple[Specimen, list[Pseudonym]]:
+        """Return a copy of this record with its fields at pii_level high or above replaced by tokens, or cleared when they are not text, and the pseudonyms made."""
+        pseudonyms: list[Pseudonym] = []
+        record = replace(
+            self,
+            subject=None,
+        )
+        return record, pseudonyms
+
+    def validate(self) -> None:
+        """Raise ValueError when fields that a condition on another field requires are missing, or set although one leaves them out."""
+        errors: list[str] = []
+        if self.status == "available" and self.received_time is None:
+            errors.append("receivedTime is required when status == available")
+        if self.status != "available" and not self.note:
+            errors.append("note is required when status != available")
+        if errors:
+            raise ValueError("; ".join(errors))
+
+    def container_count_out_of_range(self) -> bool:
+        """Return whether containerCount is set and outside its reference range, at most 4."""
+        return self.container_count is not None and self.container_count > 4
+
+    @property
+    def turnaround_days(self) -> int | None:
+        """Return turnaroundDays, derived as days_between(collectedDate, receivedTime), or None when a field it uses is unset. Days from collection to receipt"""
+        if self.collected_date is None or self.received_time is None:
+            return None
+        return _days_between(self.collected_date, self.received_time)
+
+
+def _days_between(start: date, end: date) -> int:
+    """Return the days from the date of start to that of end."""
+    return (_as_date(end) - _as_date(start)).days
+
+
+def _as_date(value: date) -> date:
+    """Return the date of value, which may be a datetime."""
+    return value.date() if isinstance(value, datetime) else value
+
@@ -0,0 +1,9 @@
+"""Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from .practitioner import Practitioner
+
+__all__ = [
+    "Practitioner",
+]
@@ -0,0 +1,33 @@
+"""Name of a human - parts and usage
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from datetime import date, datetime
+from typing import Any
+
+from .period import Period
+
+
+@dataclass
+class HumanName:
+    """Name of a human - parts and usage"""
+
+    use: str | None = None  # usual | official | temp | nickname | anonymous | old | maiden
+
+    text: str | None = None  # Text representation of the full name
+
+    family: str | None = None  # Family name (often called 'Surname')
+
+    given: list[str] | None = None  # Given names (not always 'first'). Includes middle names
+
+    prefix: list[str] | None = None  # Parts that come before the name
+
+    suffix: list[str] | None = None  # Parts that come after the name
+
+    period: Period | None = None  # Time period when name was/is in use
+
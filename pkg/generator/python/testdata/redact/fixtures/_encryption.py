@@ -0,0 +1,38 @@
+"""Field-level encryption hooks.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+from typing import Any, Protocol
+
+# Encrypts equal values to equal ciphertexts, so the field can still be
+# matched and joined on.
+DETERMINISTIC = "deterministic"
+# Encrypts each value under a fresh nonce.
+RANDOMIZED = "randomized"
+
+
+class FieldEncryptor(Protocol):
+    """Encrypts and decrypts the values of encrypted fields.
+
+    Implementations typically use envelope encryption under a data key that a
+    KMS key wraps. field is the schema and field name, such as patient.ssn,
+    for choosing keys or binding ciphertexts to their field. Ciphertexts are
+    text, such as base64, so that they fit the fields they replace.
+    """
+
+    def encrypt(self, field: str, mode: str, plaintext: str) -> str: ...
+
+    def decrypt(self, field: str, mode: str, ciphertext: str) -> str: ...
+
+
+def crypt_fields(record: Any, fields: dict[str, tuple[str, str]], crypt: Callable[[str, str, str], str]) -> None:
+    """Replace each non-empty encrypted field of record with crypt of its value."""
+    for name, (field, mode) in fields.items():
+        value = getattr(record, name)
+        if value:
+            setattr(record, name, crypt(field, mode, value))
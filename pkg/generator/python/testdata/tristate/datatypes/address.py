@@ -0,0 +1,68 @@
+"""An address expressed using postal conventions
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._unset import UNSET
+from .period import Period
+
+
+@dataclass
+class Address:
+    """An address expressed using postal conventions"""
+
+    use: str | None = UNSET  # home | work | temp | old | billing - purpose of this address
+
+    type: str | None = UNSET  # postal | physical | both
+
+    text: str | None = UNSET  # Text representation of the address
+
+    line: list[str] | None = UNSET  # Street name, number, direction & P.O. Box etc.
+
+    city: str | None = UNSET  # Name of city, town etc.
+
+    district: str | None = UNSET  # District name (aka county)
+
+    state: str | None = UNSET  # Sub-unit of country (abbreviations ok)
+
+    postal_code: str | None = UNSET  # Postal code for area
+
+    country: str | None = UNSET  # Country (e.g. can be ISO 3166 2 or 3 letter code)
+
+    period: Period | None = UNSET  # Time period when address was/is in use
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"use": None, "type": None, "text": None, "line": None, "city": None, "district": None, "state": None, "postal_code": None, "country": None, "period": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
@@ -0,0 +1,52 @@
+"""Concept - reference to a terminology or just text
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._unset import UNSET
+from .coding import Coding
+
+
+@dataclass
+class CodeableConcept:
+    """Concept - reference to a terminology or just text"""
+
+    coding: list[Coding] | None = UNSET  # Code defined by a terminology system
+
+    text: str | None = UNSET  # Plain text representation of the concept
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"coding": None, "text": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
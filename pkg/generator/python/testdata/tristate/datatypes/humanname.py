@@ -0,0 +1,62 @@
+"""Name of a human - parts and usage
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._unset import UNSET
+from .period import Period
+
+
+@dataclass
+class HumanName:
+    """Name of a human - parts and usage"""
+
+    use: str | None = UNSET  # usual | official | temp | nickname | anonymous | old | maiden
+
+    text: str | None = UNSET  # Text representation of the full name
+
+    family: str | None = UNSET  # Family name (often called 'Surname')
+
+    given: list[str] | None = UNSET  # Given names (not always 'first'). Includes middle names
+
+    prefix: list[str] | None = UNSET  # Parts that come before the name
+
+    suffix: list[str] | None = UNSET  # Parts that come after the name
+
+    period: Period | None = UNSET  # Time period when name was/is in use
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"use": None, "text": None, "family": None, "given": None, "prefix": None, "suffix": None, "period": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
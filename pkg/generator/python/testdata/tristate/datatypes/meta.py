@@ -0,0 +1,60 @@
+"""Metadata about a resource
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from ._unset import UNSET
+from .coding import Coding
+
+
+@dataclass
+class Meta:
+    """Metadata about a resource"""
+
+    version_id: str | None = UNSET  # Version specific identifier
+
+    last_updated: datetime | None = UNSET  # When the resource version last changed
+
+    source: str | None = UNSET  # Identifies where the resource comes from
+
+    profile: list[str] | None = UNSET  # Profiles this resource claims to conform to
+
+    security: list[Coding] | None = UNSET  # Security Labels applied to this resource
+
+    tag: list[Coding] | None = UNSET  # Tags applied to this resource
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"version_id": None, "last_updated": None, "source": None, "profile": None, "security": None, "tag": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
@@ -0,0 +1,23 @@
+"""The UNSET sentinel of fields left out of records.
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+class Unset:
+    """The type of UNSET, which marks the optional fields left out of a record, as opposed to those set to None."""
+
+    def __repr__(self) -> str:
+        return "UNSET"
+
+    def __bool__(self) -> bool:
+        return False
+
+
+# UNSET is typed Any so that it can default fields of every type.
+UNSET: Any = Unset()
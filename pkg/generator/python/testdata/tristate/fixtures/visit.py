@@ -0,0 +1,100 @@
+"""An encounter whose field names are reserved words in target languages
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import TYPE_CHECKING, Any, ClassVar
+
+from datatypes.address import Address
+from datatypes.codeableconcept import CodeableConcept
+from datatypes.coding import Coding
+from datatypes.quantity import Quantity
+from datatypes.reference import Reference
+from registry.practitioner import Practitioner
+
+from ._unset import UNSET
+
+if TYPE_CHECKING:
+    from .specimen import Specimen
+
+
+@dataclass
+class Visit:
+    """An encounter whose field names are reserved words in target languages"""
+
+    class_: Coding  # Classification of the visit
+
+    type: list[CodeableConcept] | None = UNSET  # Specific type of visit
+
+    for_: Reference | None = UNSET  # Who the visit is for
+
+    match: str | None = UNSET  # Record linkage match grade
+
+    order: int | None = UNSET  # Sequence within the episode
+
+    self: str | None = UNSET  # Canonical URL of the visit
+
+    where: Address | None = UNSET  # Where the visit took place
+
+    _2nd_opinion: bool | None = UNSET  # Whether a second opinion was requested
+
+    priority_code: str | None = UNSET  # Priority as a code
+
+    priority_code_2: str | None = UNSET  # Priority as legacy text
+
+    visit: str | None = UNSET  # Visit number
+
+    length: Quantity | None = UNSET  # Time the visit lasted
+
+    attender: Practitioner | None = UNSET  # Practitioner who saw the patient
+
+    specimens: list[Specimen] | None = UNSET  # Specimens collected during the visit
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"type": None, "for_": None, "match": "unlinked", "order": None, "self": None, "where": None, "_2nd_opinion": None, "priority_code": None, "priority_code_2": None, "visit": None, "length": None, "attender": None, "specimens": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
+    def validate(self) -> None:
+        """Raise ValueError when fields that a condition on another field requires are missing, or set although one leaves them out."""
+        errors: list[str] = []
+        if self._2nd_opinion is True and self.attender is None:
+            errors.append("attender is required when 2ndOpinion == true")
+        if errors:
+            raise ValueError("; ".join(errors))
+
+    @property
+    def display(self) -> str | None:
+        """Return display, derived as concat('Visit ', visit), or None when a field it uses is unset. Label shown for the visit"""
+        if self.visit is None:
+            return None
+        return "Visit " + self.visit
+
@@ -0,0 +1,70 @@
+"""A person providing care
+
+Generated by ehrglot v0.1.0 at <timestamp>.
+DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass, fields
+from datetime import date, datetime
+from typing import Any, ClassVar
+
+from datatypes.extension import Extension
+from datatypes.humanname import HumanName
+from datatypes.meta import Meta
+from datatypes.narrative import Narrative
+
+from ._unset import UNSET
+
+
+@dataclass
+class Practitioner:
+    """A person providing care"""
+
+    id: str  # Logical id of this artifact
+
+    meta: Meta | None = UNSET  # Metadata about the resource
+
+    implicit_rules: str | None = UNSET  # A set of rules under which this content was created
+
+    language: str | None = UNSET  # Language of the resource content
+
+    text: Narrative | None = UNSET  # Text summary of the resource, for human interpretation
+
+    extension: list[Extension] | None = UNSET  # Additional content defined by implementations
+
+    modifier_extension: list[Extension] | None = UNSET  # Extensions that cannot be ignored
+
+    name: list[HumanName] | None = UNSET  # The name(s) associated with the practitioner
+
+    supervisor: Practitioner | None = UNSET  # Practitioner responsible for this one
+
+    # Defaults of the optional fields, which those left out of the record
+    # take until they are assigned.
+    _DEFAULTS: ClassVar[dict[str, Any]] = {"meta": None, "implicit_rules": None, "language": None, "text": None, "extension": None, "modifier_extension": None, "name": None, "supervisor": None}
+
+    def __post_init__(self) -> None:
+        unset = {name for name in self._DEFAULTS if getattr(self, name) is UNSET}
+        for name in unset:
+            setattr(self, name, self._DEFAULTS[name])
+        self._unset = unset
+
+    def __setattr__(self, name: str, value: Any) -> None:
+        unset = self.__dict__.get("_unset")
+        if unset is not None and name in self._DEFAULTS:
+            if value is UNSET:
+                value = self._DEFAULTS[name]
+                unset.add(name)
+            else:
+                unset.discard(name)
+        super().__setattr__(name, value)
+
+    def is_unset(self, name: str) -> bool:
+        """Return whether the field name is left out of this record, rather than set to a value or to None."""
+        return name in self._unset
+
+    def set_fields(self) -> dict[str, Any]:
+        """Return the fields of this record that are set, to a value or to None, by name, as a FHIR PATCH or partial update sends them."""
+        return {f.name: getattr(self, f.name) for f in fields(self) if f.name not in self._unset}
+
@@ -0,0 +1,176 @@
+package rust
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// bulkResources returns the schemas of a namespace that a bulk export can
+// hold: all but the built-in datatypes.
+func bulkResources(schemas []schema.Schema) []schema.Schema {
+	var resources []schema.Schema
+	for _, s := range schemas {
+		if !datatypes.IsBuiltin(s) {
+			resources = append(resources, s)
+		}
+	}
+	return resources
+}
+
+// generateBulk writes a loader for FHIR Bulk Data exports of the resources
+// of a namespace. The crate has no HTTP client, so files are read from the
+// paths of a downloaded export.
+func (g *Generator) generateBulk(resources []schema.Schema, path string) error {
+	tmpl := `//! FHIR Bulk Data ($export) loading for the models of this module.
+//!
+{{header}}
+use std::error::Error;
+use std::fmt;
+use std::fs::File;
+use std::io::{self, BufRead, BufReader};
+use std::path::{Path, PathBuf};
+
+use serde::Deserialize;
+
+use super::{ {{- range $i, $s := .}}{{if $i}}, {{end}}{{typeName $s}}{{end}}};
+
+/// The manifest of a FHIR Bulk Data ($export) response.
+#[derive(Debug, Clone, Deserialize)]
+#[serde(rename_all = "camelCase")]
+pub struct BulkManifest {
+    pub transaction_time: String,
+    pub request: String,
+    #[serde(default)]
+    pub requires_access_token: bool,
+    pub output: Vec<BulkFile>,
+    #[serde(default)]
+    pub error: Vec<BulkFile>,
+}
+
+/// One NDJSON file of a bulk export.
+#[derive(Debug, Clone, Deserialize)]
+pub struct BulkFile {
+    #[serde(rename = "type")]
+    pub resource_type: String,
+    pub url: String,
+    #[serde(default)]
+    pub count: Option<u64>,
+}
+
+/// A resource of a bulk export, as the model of its type.
+#[derive(Debug, Clone)]
+pub enum BulkResource {
+{{- range .}}
+    {{typeName .}}({{typeName .}}),
+{{- end}}
+}
+
+/// An error reading a bulk export.
+#[derive(Debug)]
+pub enum BulkError {
+    /// A manifest or file could not be read.
+    Io(PathBuf, io::Error),
+    /// A manifest or a line of a file is not valid JSON for its model.
+    Json(String, usize, serde_json::Error),
+    /// A file is only available from a server; download it and read it with
+    /// read_bulk_file.
+    Remote(String),
+}
+
+impl fmt::Display for BulkError {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        match self {
+            BulkError::Io(path, err) => write!(f, "{}: {}", path.display(), err),
+            BulkError::Json(name, line, err) => write!(f, "{}: line {}: {}", name, line, err),
+            BulkError::Remote(url) => write!(f, "{}: remote files must be downloaded first", url),
+        }
+    }
+}
+
+impl Error for BulkError {
+    fn source(&self) -> Option<&(dyn Error + 'static)> {
+        match self {
+            BulkError::Io(_, err) => Some(err),
+            BulkError::Json(_, _, err) => Some(err),
+            BulkError::Remote(_) => None,
+        }
+    }
+}
+
+/// Reads the manifest at path and calls f with each resource of the output
+/// files whose type is a model of this module, skipping other files. File
+/// URLs are paths, relative to the manifest, or file URLs.
+pub fn load_bulk_export<F>(path: impl AsRef<Path>, mut f: F) -> Result<(), BulkError>
+where
+    F: FnMut(BulkResource),
+{
+    let path = path.as_ref();
+    let file = File::open(path).map_err(|err| BulkError::Io(path.to_path_buf(), err))?;
+    let manifest: BulkManifest = serde_json::from_reader(BufReader::new(file))
+        .map_err(|err| BulkError::Json(path.display().to_string(), err.line(), err))?;
+    let dir = path.parent().unwrap_or_else(|| Path::new(""));
+    for output in &manifest.output {
+        if !is_bulk_type(&output.resource_type) {
+            continue;
+        }
+        if output.url.starts_with("http://") || output.url.starts_with("https://") {
+            return Err(BulkError::Remote(output.url.clone()));
+        }
+        let file_path = dir.join(output.url.strip_prefix("file://").unwrap_or(&output.url));
+        let file = File::open(&file_path).map_err(|err| BulkError::Io(file_path.clone(), err))?;
+        read_bulk_file(&output.resource_type, &output.url, BufReader::new(file), &mut f)?;
+    }
+    Ok(())
+}
+
+/// Reads the NDJSON resources of type resource_type from reader, calling f
+/// with each. name identifies the file in errors. Files of types that are not
+/// models of this module are skipped.
+pub fn read_bulk_file<R, F>(resource_type: &str, name: &str, reader: R, mut f: F) -> Result<(), BulkError>
+where
+    R: BufRead,
+    F: FnMut(BulkResource),
+{
+    for (i, line) in reader.lines().enumerate() {
+        let line = line.map_err(|err| BulkError::Io(PathBuf::from(name), err))?;
+        if line.trim().is_empty() {
+            continue;
+        }
+        let resource = match resource_type {
+{{- range .}}
+            {{quote .GetName}} => serde_json::from_str(&line).map(BulkResource::{{typeName .}}),
+{{- end}}
+            _ => return Ok(()),
+        };
+        f(resource.map_err(|err| BulkError::Json(name.to_string(), i + 1, err))?);
+    }
+    Ok(())
+}
+
+fn is_bulk_type(resource_type: &str) -> bool {
+    matches!(resource_type, {{range $i, $s := .}}{{if $i}} | {{end}}{{quote $s.GetName}}{{end}})
+}
+`
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"quote":    strconv.Quote,
+		"header":   g.header.Func("//! ", resources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, resources)
+}
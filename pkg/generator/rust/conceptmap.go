@@ -0,0 +1,62 @@
+package rust
+
+import (
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// generateConceptMaps writes the functions that translate codes by the
+// concept maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, path string) error {
+	type table struct {
+		Name        string
+		Description string
+		Func        string
+		Sources     []conceptmap.Source
+	}
+	var tables []table
+	for _, m := range maps {
+		tables = append(tables, table{
+			Name:        m.Name,
+			Description: strings.Join(strings.Fields(m.Description), " "),
+			Func:        "translate_" + naming.Snake.Apply(m.Name),
+			Sources:     m.Sources(),
+		})
+	}
+
+	tmpl := `//! Translations of codes by concept maps.
+//!
+{{header}}
+/// A code that a concept map translates a source code to, and how
+/// equivalent the two are.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+pub struct ConceptMapTarget {
+    pub system: &'static str,
+    pub code: &'static str,
+    pub display: &'static str,
+    pub equivalence: &'static str,
+}
+{{range .}}
+/// Returns the codes that code of system translates to by the concept map
+/// {{.Name}}, if any.
+{{- with .Description}}
+///
+/// {{.}}
+{{- end}}
+pub fn {{.Func}}(system: &str, code: &str) -> &'static [ConceptMapTarget] {
+    match (system, code) {
+{{- range .Sources}}
+        ({{quote .System}}, {{quote .Code}}) => &[
+{{- range .Translations}}
+            ConceptMapTarget { system: {{quote .System}}, code: {{quote .Code}}, display: {{quote .Display}}, equivalence: {{quote .Equivalence}} },
+{{- end}}
+        ],
+{{- end}}
+        _ => &[],
+    }
+}
+{{end}}`
+	return g.executeTemplate(tmpl, g.header.Func("//! "), tables, path)
+}
@@ -2,51 +2,122 @@
 package rust
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
 // Generator generates Rust code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	decimalType string
+	timeZone    string
+	dateType    string
+	ucum        bool
+	bulk        bool
+	conceptMaps []string
+	redact      string
+	audit       bool
+	pkg         config.Package
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Rust code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("rust").Naming, decimalType: "f64", timeZone: "Utc", dateType: "NaiveDate", ucum: cfg.UCUM, bulk: cfg.Bulk, conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), audit: cfg.Audit, pkg: cfg.PackageInfo("rust"), header: header.New(cfg.Header, Version)}
+	if cfg.DecimalMode("rust") == config.DecimalExact {
+		g.decimalType = "Decimal"
+	}
+	temporal := cfg.TemporalPolicy("rust")
+	if temporal.Instants == config.InstantsOffset {
+		g.timeZone = "FixedOffset"
+	}
+	if temporal.Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
 // Generate generates Rust structs from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
 		// Generate mod.rs with all types
 		modPath := filepath.Join(nsDir, "mod.rs")
-		if err := g.generateMod(nsSchemas, modPath); err != nil {
+		if err := g.generateMod(nsSchemas, len(conceptMaps[namespace]) > 0, modPath); err != nil {
 			return err
 		}
 
+		if g.dateType == "PartialDate" {
+			if err := g.generateTemporal(filepath.Join(nsDir, "temporal.rs")); err != nil {
+				return err
+			}
+		}
+
 		// Generate each schema file
 		for _, s := range nsSchemas {
-			filename := toSnakeCase(s.GetName()) + ".rs"
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			filename := strings.TrimPrefix(g.moduleName(s), "r#") + ".rs"
 			path := filepath.Join(nsDir, filename)
-			if err := g.generateStruct(s, path); err != nil {
+			if err := g.generateStruct(refs.Scope(namespace), s, path); err != nil {
+				return err
+			}
+
+			if g.hasUCUM(s) {
+				if err := g.generateUCUM(s, filepath.Join(nsDir, "ucum.rs")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bulk && len(resources) > 0 {
+			if err := g.generateBulk(resources, filepath.Join(nsDir, "bulk.rs")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, filepath.Join(nsDir, "concept_maps.rs")); err != nil {
 				return err
 			}
 		}
@@ -55,22 +126,109 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 	return nil
 }
 
-func (g *Generator) generateMod(schemas []schema.Schema, path string) error {
-	tmpl := `//! Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-//! DO NOT EDIT.
+// Package writes a Cargo.toml and a lib.rs that declares each generated
+// namespace as a public module of one crate.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if err := g.pkg.Validate(); err != nil {
+		return err
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+
+	var modules []string
+	for _, namespace := range refs.Namespaces() {
+		modules = append(modules, g.packageName(namespace))
+	}
+	sort.Strings(modules)
+
+	data := struct {
+		Package config.Package
+		Modules []string
+		Exact   bool
+	}{Package: g.pkg, Modules: modules, Exact: g.decimalType == "Decimal"}
+
+	cargo := `# Generated by ehrglot v` + Version + `.
+
+[package]
+name = {{quote .Package.Name}}
+version = {{quote .Package.Version}}
+{{- with .Package.Description}}
+description = {{quote .}}
+{{- end}}
+edition = "2021"
+
+[lib]
+path = "lib.rs"
+
+[dependencies]
+chrono = { version = "0.4", features = ["serde"] }
+{{- if .Exact}}
+rust_decimal = { version = "1", features = ["serde"] }
+{{- end}}
+serde = { version = "1", features = ["derive"] }
+serde_json = "1"
+`
+	if err := g.executeTemplate(cargo, nil, data, filepath.Join(outputDir, "Cargo.toml")); err != nil {
+		return err
+	}
+
+	lib := `{{header}}{{range .Modules}}
+pub mod {{.}};
+{{- end}}
+`
+	return g.executeTemplate(lib, g.header.Func("//! ", schemas...), data, filepath.Join(outputDir, "lib.rs"))
+}
+
+// executeTemplate renders tmplStr into path, with header, if set, bound as
+// {{header}}.
+func (g *Generator) executeTemplate(tmplStr string, header func() string, data any, path string) error {
+	funcMap := template.FuncMap{"quote": strconv.Quote}
+	if header != nil {
+		funcMap["header"] = header
+	}
+	tmpl, err := g.templates.Parse(tmplStr, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl.Execute(f, data)
+}
 
-{{range .}}mod {{. | schemaName | snake}};
-pub use {{. | schemaName | snake}}::{{. | schemaName}};
+func (g *Generator) generateMod(schemas []schema.Schema, conceptMaps bool, path string) error {
+	tmpl := `{{header}}{{if .Partial}}
+mod temporal;
+pub use temporal::PartialDate;
+{{end}}
+{{- if .UCUM}}
+pub mod ucum;
+{{end}}
+{{- if .Bulk}}
+pub mod bulk;
+{{end}}
+{{- if .ConceptMaps}}
+pub mod concept_maps;
+{{end}}
+{{range .Schemas}}mod {{. | moduleName}};
+pub use {{. | moduleName}}::{{. | schemaName}};
 {{end}}
 `
 	funcMap := template.FuncMap{
-		"snake":      toSnakeCase,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"moduleName": g.moduleName,
+		"schemaName": g.typeName,
+		"header":     g.header.Func("//! ", schemas...),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -79,34 +237,206 @@ pub use {{. | schemaName | snake}}::{{. | schemaName}};
 	}
 	defer f.Close()
 
-	return tmpl_parsed.Execute(f, schemas)
+	data := struct {
+		Schemas     []schema.Schema
+		Partial     bool
+		UCUM        bool
+		Bulk        bool
+		ConceptMaps bool
+	}{
+		Schemas:     schemas,
+		Partial:     g.dateType == "PartialDate",
+		Bulk:        g.bulk && len(bulkResources(schemas)) > 0,
+		ConceptMaps: conceptMaps,
+	}
+	for _, s := range schemas {
+		if g.hasUCUM(s) {
+			data.UCUM = true
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
 }
 
-func (g *Generator) generateStruct(s schema.Schema, path string) error {
-	tmpl := `//! {{.Schema.Description}}
+// generateTemporal writes the PartialDate type used for dates when the
+// partial dates policy is enabled.
+func (g *Generator) generateTemporal(path string) error {
+	tmpl := `//! Partial date support for FHIR dates.
 //!
-//! Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-//! DO NOT EDIT.
+{{header}}
+use std::fmt;
+use std::str::FromStr;
 
+use chrono::{Datelike, NaiveDate};
 use serde::{Deserialize, Serialize};
-use chrono::{NaiveDate, DateTime, Utc};
 
-/// {{.Schema.Description}}
-#[derive(Debug, Clone, Serialize, Deserialize)]
+/// A date known to year, month, or day precision, e.g. ` + "`1970`, `1970-03`, or `1970-03-14`" + `.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+#[serde(try_from = "String", into = "String")]
+pub struct PartialDate {
+    pub year: i32,
+    pub month: Option<u32>,
+    pub day: Option<u32>,
+}
+
+impl PartialDate {
+    /// Returns the full date, or ` + "`None`" + ` when the value is less precise than a day.
+    pub fn to_naive_date(&self) -> Option<NaiveDate> {
+        NaiveDate::from_ymd_opt(self.year, self.month?, self.day?)
+    }
+}
+
+impl FromStr for PartialDate {
+    type Err = String;
+
+    /// Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+    fn from_str(s: &str) -> Result<Self, Self::Err> {
+        let invalid = || format!("invalid partial date: {s}");
+        if !s.bytes().all(|b| b.is_ascii_digit() || b == b'-') {
+            return Err(invalid());
+        }
+        match s.len() {
+            4 => Ok(PartialDate { year: s.parse().map_err(|_| invalid())?, month: None, day: None }),
+            7 => {
+                let date = NaiveDate::parse_from_str(&format!("{s}-01"), "%Y-%m-%d").map_err(|_| invalid())?;
+                Ok(PartialDate { year: date.year(), month: Some(date.month()), day: None })
+            }
+            10 => {
+                let date = NaiveDate::parse_from_str(s, "%Y-%m-%d").map_err(|_| invalid())?;
+                Ok(PartialDate { year: date.year(), month: Some(date.month()), day: Some(date.day()) })
+            }
+            _ => Err(invalid()),
+        }
+    }
+}
+
+impl TryFrom<String> for PartialDate {
+    type Error = String;
+
+    fn try_from(s: String) -> Result<Self, Self::Error> {
+        s.parse()
+    }
+}
+
+impl From<PartialDate> for String {
+    fn from(date: PartialDate) -> Self {
+        date.to_string()
+    }
+}
+
+impl fmt::Display for PartialDate {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        write!(f, "{:04}", self.year)?;
+        if let Some(month) = self.month {
+            write!(f, "-{month:02}")?;
+        }
+        if let Some(day) = self.day {
+            write!(f, "-{day:02}")?;
+        }
+        Ok(())
+    }
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("//! ")})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, nil)
+}
+
+func (g *Generator) generateStruct(scope resolve.Scope, s schema.Schema, path string) error {
+	if err := s.CheckDefaults(); err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	tmpl := `//! {{.Schema.Description}}
+//!
+{{header}}
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, {{.TimeZone}}};
+{{- if .Exact}}
+use rust_decimal::Decimal;
+{{- end}}
+{{- if .Uses}}
+{{range .Uses}}
+use {{.}};
+{{- end}}
+{{- end}}
+
+/// {{.Schema.Description}}{{with .Schema.Docs}}
+///{{range .}}
+/// {{.}}{{end}}{{end}}
+#[derive({{if not .Redacted}}Debug, {{end}}Clone, Serialize, Deserialize)]
 pub struct {{.Schema | schemaName}} {
-{{range .Schema.Fields}}    {{if not .Required}}#[serde(skip_serializing_if = "Option::is_none")]
-    {{end}}pub {{.Name | snake}}: {{. | rustType}},
+{{range .Fields}}    {{with .JSONName}}#[serde(rename = "{{.}}")]
+    {{end}}{{if not .Required}}#[serde(skip_serializing_if = "Option::is_none")]
+    {{end}}{{if .Default}}#[serde(default = "{{$.Schema | schemaName}}::default_{{.Ident | key}}")]
+    {{end}}pub {{.Ident}}: {{.Field | rustType}},
 {{end}}}
+{{- with .Defaults}}
+
+impl {{$.Schema | schemaName}} {
+{{- range $i, $f := .}}{{if $i}}
+{{end}}
+    /// Returns the default of {{$f.Ident | key}}, for fields missing on deserialization.
+    fn default_{{$f.Ident | key}}() -> {{$f.Field | rustType}} {
+        {{if $f.Required}}{{default $f.Field}}{{else}}Some({{default $f.Field}}){{end}}
+    }
+{{- end}}
+}
+{{- end}}
+{{- if .Redacted}}
+
+/// Formats the record with its fields at pii_level {{.Level}} or above masked,
+/// so that logging it does not leak them.
+impl std::fmt::Debug for {{.Schema | schemaName}} {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.debug_struct("{{.Schema | schemaName}}")
+{{- range .Fields}}
+            .field("{{.Ident | key}}", {{if .Sensitive $.Level}}&"` + schema.Redacted + `"{{else}}&self.{{.Ident}}{{end}})
+{{- end}}
+            .finish()
+    }
+}
+
+impl {{.Schema | schemaName}} {
+    /// Returns the record as JSON, with its fields at pii_level {{.Level}} or
+    /// above masked, for structured logs.
+    pub fn redact_for_logging(&self) -> serde_json::Value {
+        let mut value = serde_json::to_value(self).unwrap_or_default();
+        if let Some(fields) = value.as_object_mut() {
+            for name in [{{range $i, $f := .Redacted}}{{if $i}}, {{end}}"{{$f.Ident | key}}"{{end}}] {
+                if let Some(field) = fields.get_mut(name) {
+                    *field = "` + schema.Redacted + `".into();
+                }
+            }
+        }
+        value
+    }
+}
+{{- end}}
 `
 	funcMap := template.FuncMap{
-		"snake":      toSnakeCase,
-		"rustType":   toRustTypeFromField,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"rustType": func(f schema.Field) string {
+			return g.toRustFieldType(scope, s, f)
+		},
+		"schemaName": g.typeName,
+		"key": func(ident string) string {
+			return strings.TrimPrefix(ident, "r#")
+		},
+		"default": g.defaultLiteral,
+		"header":  g.header.Func("//! ", s),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -116,34 +446,223 @@ pub struct {{.Schema | schemaName}} {
 	defer f.Close()
 
 	data := struct {
-		Schema schema.Schema
-	}{Schema: s}
+		Schema   schema.Schema
+		Fields   []naming.Field
+		Exact    bool
+		TimeZone string
+		Uses     []string
+		Level    string
+		Redacted []naming.Field
+		Defaults []naming.Field
+	}{
+		Schema:   s,
+		Exact:    g.decimalType == "Decimal",
+		TimeZone: g.timeZone,
+		Fields:   g.fields(s),
+		Level:    g.redact,
+	}
+	for _, f := range data.Fields {
+		if f.Sensitive(g.redact) {
+			data.Redacted = append(data.Redacted, f)
+		}
+		if f.Default != "" {
+			data.Defaults = append(data.Defaults, f)
+		}
+	}
+	if g.dateType == "PartialDate" {
+		data.Uses = append(data.Uses, "super::PartialDate")
+	}
+	// Namespace modules are siblings, so other namespaces are reached from
+	// the parent of this struct's namespace module.
+	for _, dep := range scope.Dependencies(s) {
+		prefix := "super::"
+		if dep.Namespace != s.Namespace {
+			prefix = "super::super::" + g.packageName(dep.Namespace) + "::"
+		}
+		data.Uses = append(data.Uses, prefix+g.typeName(dep))
+	}
+	sort.Strings(data.Uses)
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// hasUCUM reports whether UCUM helpers are generated for s.
+func (g *Generator) hasUCUM(s schema.Schema) bool {
+	return g.ucum && datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, path string) error {
+	tmpl := `//! UCUM unit validation and conversion for {{.Type}}.
+//!
+{{header}}{{if .Exact}}
+use rust_decimal::prelude::{FromPrimitive, ToPrimitive};
+use rust_decimal::Decimal;
+{{end}}
+use super::{{.Type}};
+
+pub const SYSTEM: &str = "` + datatypes.UCUMSystem + `";
+
+/// A unit whose value v is v * factor + offset in its dimension's base unit.
+struct Unit {
+    dimension: &'static str,
+    factor: f64,
+    offset: f64,
+}
+
+const UNITS: &[(&str, Unit)] = &[
+{{- range .Units}}
+    ("{{.Code}}", Unit { dimension: "{{.Dimension}}", factor: {{.Factor | float}}, offset: {{.Offset | float}} }),
+{{- end}}
+];
+
+fn unit(code: &str) -> Result<&'static Unit, String> {
+    UNITS
+        .iter()
+        .find(|(c, _)| *c == code)
+        .map(|(_, u)| u)
+        .ok_or_else(|| format!("unknown UCUM unit: {code}"))
+}
+
+/// Reports whether code is a UCUM unit these helpers can convert.
+pub fn is_known_unit(code: &str) -> bool {
+    unit(code).is_ok()
+}
+
+/// Converts value between two UCUM units of the same dimension.
+pub fn convert_value(value: f64, from_code: &str, to_code: &str) -> Result<f64, String> {
+    let source = unit(from_code)?;
+    let target = unit(to_code)?;
+    if source.dimension != target.dimension {
+        return Err(format!(
+            "cannot convert {from_code} ({}) to {to_code} ({})",
+            source.dimension, target.dimension
+        ));
+    }
+    Ok((value * source.factor + source.offset - target.offset) / target.factor)
+}
+
+/// Returns quantity in to_code units. Its code, or else its unit, must be a
+/// known UCUM unit.
+pub fn convert(quantity: &{{.Type}}, to_code: &str) -> Result<{{.Type}}, String> {
+    let current = quantity.{{.F.value}}.ok_or("cannot convert a quantity without a value")?;
+    let from_code = quantity.{{.F.code}}.as_deref().or(quantity.{{.F.unit}}.as_deref()).unwrap_or("");
+{{- if .Exact}}
+    let value = convert_value(current.to_f64().ok_or("quantity value out of range")?, from_code, to_code)?;
+    let value = Decimal::from_f64(value).ok_or("converted value out of range")?;
+{{- else}}
+    let value = convert_value(current, from_code, to_code)?;
+{{- end}}
+    Ok({{.Type}} {
+        {{.F.value}}: Some(value),
+        {{.F.unit}}: Some(to_code.to_string()),
+        {{.F.system}}: Some(SYSTEM.to_string()),
+        {{.F.code}}: Some(to_code.to_string()),
+        ..quantity.clone()
+    })
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.header.Func("//! ", quantity)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Type  string
+		Exact bool
+		F     map[string]string
+		Units []datatypes.Unit
+	}{
+		Type:  g.typeName(quantity),
+		Exact: g.decimalType == "Decimal",
+		F:     naming.ByName(g.fields(quantity)),
+		Units: datatypes.Units,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return g.packageName(namespace)
+}
+
 // GenerateMappings generates Rust mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	// TODO: Implement mapping generation
 	return nil
 }
 
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('_')
+func (g *Generator) packageName(namespace string) string {
+	return naming.Case(g.naming.Packages).Apply(namespace)
+}
+
+func (g *Generator) typeName(s schema.Schema) string {
+	return naming.Ident("rust", naming.Case(g.naming.Types).Or(naming.Pascal.Apply)(s.GetName()))
+}
+
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Fields("rust", s.Fields, naming.Case(g.naming.Fields).Or(naming.Snake.Apply)))
+}
+
+// moduleName names the module (and file) holding a schema's struct. Modules
+// and types share a namespace, so a module named as its struct, as with
+// snake_case types, gets a _mod suffix to keep the struct's re-export from
+// clashing with it.
+func (g *Generator) moduleName(s schema.Schema) string {
+	name := naming.Ident("rust", naming.Case(g.naming.Files).Or(naming.Snake.Apply)(s.GetName()))
+	if name == g.typeName(s) {
+		name = naming.Ident("rust", strings.TrimPrefix(name, "r#")+"_mod")
+	}
+	return name
+}
+
+// toRustFieldType maps a field of s to a Rust type. A struct that contains
+// itself, directly or through other structs, would have infinite size, so
+// fields closing such a cycle are boxed; arrays already hold their elements
+// on the heap.
+// defaultLiteral returns the default of f as a Rust expression of the type
+// generated for it, or "" when it has none.
+func (g *Generator) defaultLiteral(f schema.Field) string {
+	if f.Default == "" {
+		return ""
+	}
+	switch f.DefaultKind() {
+	case "integer":
+		return f.Default
+	case "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case "decimal":
+		if g.decimalType == "Decimal" {
+			return "Decimal::from_str_exact(" + strconv.Quote(f.Default) + ").unwrap()"
 		}
-		result.WriteRune(r)
+		d, _ := strconv.ParseFloat(f.Default, 64)
+		return datatypes.FloatLiteral(d)
 	}
-	return strings.ToLower(result.String())
+	return strconv.Quote(f.Default) + ".to_string()"
 }
 
-func toRustTypeFromField(f schema.Field) string {
-	return toRustType(f.Type, f.Required)
+func (g *Generator) toRustFieldType(scope resolve.Scope, s schema.Schema, f schema.Field) string {
+	if target, ok := scope.Lookup(f.Type); ok && scope.Cyclic(s, target) {
+		boxed := fmt.Sprintf("Box<%s>", g.typeName(target))
+		if f.Required {
+			return boxed
+		}
+		return fmt.Sprintf("Option<%s>", boxed)
+	}
+	return g.toRustType(scope, f.Type, f.Required)
 }
 
-func toRustType(yamlType string, required bool) string {
+func (g *Generator) toRustType(scope resolve.Scope, yamlType string, required bool) string {
 	baseType := ""
 	switch yamlType {
 	case "string", "code", "id", "uri", "url":
@@ -151,19 +670,21 @@ func toRustType(yamlType string, required bool) string {
 	case "integer", "positiveInt", "unsignedInt":
 		baseType = "i64"
 	case "decimal":
-		baseType = "f64"
+		baseType = g.decimalType
 	case "boolean":
 		baseType = "bool"
 	case "date":
-		baseType = "NaiveDate"
+		baseType = g.dateType
 	case "datetime", "instant":
-		baseType = "DateTime<Utc>"
+		baseType = fmt.Sprintf("DateTime<%s>", g.timeZone)
 	case "base64Binary":
 		baseType = "Vec<u8>"
 	default:
-		if strings.HasPrefix(yamlType, "[]") {
+		if target, ok := scope.Lookup(yamlType); ok {
+			baseType = g.typeName(target)
+		} else if strings.HasPrefix(yamlType, "[]") {
 			innerType := strings.TrimPrefix(yamlType, "[]")
-			inner := toRustType(innerType, true) // inner types are always required in Vec
+			inner := g.toRustType(scope, innerType, true) // inner types are always required in Vec
 			baseType = fmt.Sprintf("Vec<%s>", inner)
 		} else {
 			baseType = "serde_json::Value"
@@ -0,0 +1,70 @@
+package rust_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/rust"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, rust.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, rust.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+	}
+	generatortest.RunPackage(t, rust.NewGenerator(cfg), "testdata/package")
+}
+
+func TestGenerateBulkGolden(t *testing.T) {
+	generatortest.RunSchemas(t, rust.NewGenerator(config.Config{Bulk: true}), fixtures.Schemas(), "testdata/bulk")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, rust.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, rust.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
+
+func TestGenerateSnakeTypes(t *testing.T) {
+	// Modules and types share a namespace, so snake_case types would clash
+	// with their modules
+	cfg := config.Config{Languages: map[string]config.Language{"rust": {Naming: config.Naming{Types: "snake"}}}}
+	dir := t.TempDir()
+	if err := rust.NewGenerator(cfg).Generate(context.Background(), fixtures.Schemas(), dir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	mod, err := os.ReadFile(filepath.Join(dir, "fixtures", "mod.rs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"mod lab_result_mod;\npub use lab_result_mod::lab_result;", "mod visit_mod;\npub use visit_mod::visit;"} {
+		if !strings.Contains(string(mod), want) {
+			t.Errorf("mod.rs does not declare %q:\n%s", want, mod)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "fixtures", "lab_result_mod.rs")); err != nil {
+		t.Errorf("struct module not written: %v", err)
+	}
+}
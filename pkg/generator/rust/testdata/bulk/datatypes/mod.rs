@@ -0,0 +1,26 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+mod period;
+pub use period::Period;
+mod address;
+pub use address::Address;
+mod coding;
+pub use coding::Coding;
+mod codeable_concept;
+pub use codeable_concept::CodeableConcept;
+mod identifier;
+pub use identifier::Identifier;
+mod reference;
+pub use reference::Reference;
+mod extension;
+pub use extension::Extension;
+mod human_name;
+pub use human_name::HumanName;
+mod meta;
+pub use meta::Meta;
+mod narrative;
+pub use narrative::Narrative;
+mod quantity;
+pub use quantity::Quantity;
+
@@ -0,0 +1,14 @@
+//! Human-readable summary of the resource
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+/// Human-readable summary of the resource
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Narrative {
+    pub status: String,
+    pub div: String,
+}
@@ -0,0 +1,8 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+pub mod bulk;
+
+mod practitioner;
+pub use practitioner::Practitioner;
+
@@ -0,0 +1,25 @@
+//! An identifier intended for computation
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::CodeableConcept;
+use super::Period;
+
+/// An identifier intended for computation
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Identifier {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#use: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#type: Option<CodeableConcept>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub system: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub period: Option<Period>,
+}
@@ -0,0 +1,16 @@
+//! Time range defined by start and end date/time
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+/// Time range defined by start and end date/time
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Period {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub start: Option<DateTime<Utc>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub end: Option<DateTime<Utc>>,
+}
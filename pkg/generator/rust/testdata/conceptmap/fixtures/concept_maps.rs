@@ -0,0 +1,37 @@
+//! Translations of codes by concept maps.
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+/// A code that a concept map translates a source code to, and how
+/// equivalent the two are.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash)]
+pub struct ConceptMapTarget {
+    pub system: &'static str,
+    pub code: &'static str,
+    pub display: &'static str,
+    pub equivalence: &'static str,
+}
+
+/// Returns the codes that code of system translates to by the concept map
+/// lab-to-loinc, if any.
+///
+/// Local lab test codes to LOINC
+pub fn translate_lab_to_loinc(system: &str, code: &str) -> &'static [ConceptMapTarget] {
+    match (system, code) {
+        ("http://hospital.example/lab-codes", "GLU") => &[
+            ConceptMapTarget { system: "http://loinc.org", code: "2345-7", display: "Glucose [Mass/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+        ("http://hospital.example/lab-codes", "K") => &[
+            ConceptMapTarget { system: "http://loinc.org", code: "2823-3", display: "Potassium [Moles/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+        ("http://hospital.example/lab-codes", "LYTES") => &[
+            ConceptMapTarget { system: "http://loinc.org", code: "2951-2", display: "Sodium [Moles/volume] in Serum or Plasma", equivalence: "wider" },
+            ConceptMapTarget { system: "http://loinc.org", code: "2075-0", display: "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", equivalence: "wider" },
+        ],
+        ("http://legacy.example/lab", "0012") => &[
+            ConceptMapTarget { system: "http://loinc.org", code: "2345-7", display: "Glucose [Mass/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+        _ => &[],
+    }
+}
@@ -0,0 +1,51 @@
+//! Résultat de laboratoire — température °C, 检验结果
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::super::datatypes::HumanName;
+use super::super::datatypes::Quantity;
+use super::super::registry::Practitioner;
+
+/// Résultat de laboratoire — température °C, 检验结果
+///
+/// Owner: lab-data
+/// Steward: ana.ruiz@example.org
+/// Tags: clinical, phi
+/// Unique: result_id
+/// Indexed: mrn, resulted_at
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct LabResult {
+    pub result_id: String,
+    pub mrn: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_numeric: Option<f64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default = "LabResult::default_charge_amount")]
+    pub charge_amount: Option<f64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_quantity: Option<Quantity>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub resulted_at: Option<DateTime<Utc>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub performer_name: Option<HumanName>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub ordered_by: Option<Practitioner>,
+    #[serde(rename = "rawPayload")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub raw_payload: Option<Vec<u8>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub icd10_code: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub reviewer_ids: Option<Vec<String>>,
+}
+
+impl LabResult {
+    /// Returns the default of charge_amount, for fields missing on deserialization.
+    fn default_charge_amount() -> Option<f64> {
+        Some(0.0)
+    }
+}
@@ -0,0 +1,60 @@
+//! A sample collected for analysis
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::Visit;
+use super::super::datatypes::Identifier;
+use super::super::datatypes::Reference;
+
+/// A sample collected for analysis
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Specimen {
+    pub id: String,
+    #[serde(default = "Specimen::default_status")]
+    pub status: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub accession_identifier: Option<Identifier>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub received_time: Option<DateTime<Utc>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub collected_date: Option<NaiveDate>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub quantity: Option<f64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default = "Specimen::default_container_count")]
+    pub container_count: Option<i64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default = "Specimen::default_fasting")]
+    pub fasting: Option<bool>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub note: Option<Vec<String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub subject: Option<Reference>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub request: Option<Vec<Reference>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub visit: Option<Box<Visit>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub collection: Option<serde_json::Value>,
+}
+
+impl Specimen {
+    /// Returns the default of status, for fields missing on deserialization.
+    fn default_status() -> String {
+        "available".to_string()
+    }
+
+    /// Returns the default of container_count, for fields missing on deserialization.
+    fn default_container_count() -> Option<i64> {
+        Some(1)
+    }
+
+    /// Returns the default of fasting, for fields missing on deserialization.
+    fn default_fasting() -> Option<bool> {
+        Some(false)
+    }
+}
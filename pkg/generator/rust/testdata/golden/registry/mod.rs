@@ -0,0 +1,6 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+mod practitioner;
+pub use practitioner::Practitioner;
+
@@ -0,0 +1,25 @@
+//! A reference to a code defined by a terminology system
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, FixedOffset};
+use rust_decimal::Decimal;
+
+use super::PartialDate;
+
+/// A reference to a code defined by a terminology system
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Coding {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub system: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub version: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub code: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub display: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub user_selected: Option<bool>,
+}
@@ -0,0 +1,40 @@
+//! Additional content defined by implementations
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, FixedOffset};
+use rust_decimal::Decimal;
+
+use super::Address;
+use super::CodeableConcept;
+use super::Coding;
+use super::PartialDate;
+use super::Reference;
+
+/// Additional content defined by implementations
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Extension {
+    pub url: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_string: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_code: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_boolean: Option<bool>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_integer: Option<i64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_decimal: Option<Decimal>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_date_time: Option<DateTime<FixedOffset>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_coding: Option<Coding>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_codeable_concept: Option<CodeableConcept>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_reference: Option<Reference>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_address: Option<Address>,
+}
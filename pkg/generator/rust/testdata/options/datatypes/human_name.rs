@@ -0,0 +1,30 @@
+//! Name of a human - parts and usage
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, FixedOffset};
+use rust_decimal::Decimal;
+
+use super::PartialDate;
+use super::Period;
+
+/// Name of a human - parts and usage
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct HumanName {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#use: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub text: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub family: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub given: Option<Vec<String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub prefix: Option<Vec<String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub suffix: Option<Vec<String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub period: Option<Period>,
+}
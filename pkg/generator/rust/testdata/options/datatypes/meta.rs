@@ -0,0 +1,28 @@
+//! Metadata about a resource
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, FixedOffset};
+use rust_decimal::Decimal;
+
+use super::Coding;
+use super::PartialDate;
+
+/// Metadata about a resource
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Meta {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub version_id: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub last_updated: Option<DateTime<FixedOffset>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub source: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub profile: Option<Vec<String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub security: Option<Vec<Coding>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub tag: Option<Vec<Coding>>,
+}
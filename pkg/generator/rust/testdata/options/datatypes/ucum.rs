@@ -0,0 +1,108 @@
+//! UCUM unit validation and conversion for Quantity.
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use rust_decimal::prelude::{FromPrimitive, ToPrimitive};
+use rust_decimal::Decimal;
+
+use super::Quantity;
+
+pub const SYSTEM: &str = "http://unitsofmeasure.org";
+
+/// A unit whose value v is v * factor + offset in its dimension's base unit.
+struct Unit {
+    dimension: &'static str,
+    factor: f64,
+    offset: f64,
+}
+
+const UNITS: &[(&str, Unit)] = &[
+    ("g", Unit { dimension: "mass", factor: 1.0, offset: 0.0 }),
+    ("kg", Unit { dimension: "mass", factor: 1000.0, offset: 0.0 }),
+    ("mg", Unit { dimension: "mass", factor: 0.001, offset: 0.0 }),
+    ("ug", Unit { dimension: "mass", factor: 1e-06, offset: 0.0 }),
+    ("ng", Unit { dimension: "mass", factor: 1e-09, offset: 0.0 }),
+    ("[lb_av]", Unit { dimension: "mass", factor: 453.59237, offset: 0.0 }),
+    ("[oz_av]", Unit { dimension: "mass", factor: 28.349523125, offset: 0.0 }),
+    ("m", Unit { dimension: "length", factor: 1.0, offset: 0.0 }),
+    ("km", Unit { dimension: "length", factor: 1000.0, offset: 0.0 }),
+    ("cm", Unit { dimension: "length", factor: 0.01, offset: 0.0 }),
+    ("mm", Unit { dimension: "length", factor: 0.001, offset: 0.0 }),
+    ("[in_i]", Unit { dimension: "length", factor: 0.0254, offset: 0.0 }),
+    ("[ft_i]", Unit { dimension: "length", factor: 0.3048, offset: 0.0 }),
+    ("L", Unit { dimension: "volume", factor: 1.0, offset: 0.0 }),
+    ("dL", Unit { dimension: "volume", factor: 0.1, offset: 0.0 }),
+    ("mL", Unit { dimension: "volume", factor: 0.001, offset: 0.0 }),
+    ("uL", Unit { dimension: "volume", factor: 1e-06, offset: 0.0 }),
+    ("s", Unit { dimension: "time", factor: 1.0, offset: 0.0 }),
+    ("min", Unit { dimension: "time", factor: 60.0, offset: 0.0 }),
+    ("h", Unit { dimension: "time", factor: 3600.0, offset: 0.0 }),
+    ("d", Unit { dimension: "time", factor: 86400.0, offset: 0.0 }),
+    ("wk", Unit { dimension: "time", factor: 604800.0, offset: 0.0 }),
+    ("mo", Unit { dimension: "time", factor: 2.6298e+06, offset: 0.0 }),
+    ("a", Unit { dimension: "time", factor: 3.15576e+07, offset: 0.0 }),
+    ("K", Unit { dimension: "temperature", factor: 1.0, offset: 0.0 }),
+    ("Cel", Unit { dimension: "temperature", factor: 1.0, offset: 273.15 }),
+    ("[degF]", Unit { dimension: "temperature", factor: 0.5555555555555556, offset: 255.37222222222223 }),
+    ("g/L", Unit { dimension: "mass_concentration", factor: 1.0, offset: 0.0 }),
+    ("g/dL", Unit { dimension: "mass_concentration", factor: 10.0, offset: 0.0 }),
+    ("mg/dL", Unit { dimension: "mass_concentration", factor: 0.01, offset: 0.0 }),
+    ("mg/L", Unit { dimension: "mass_concentration", factor: 0.001, offset: 0.0 }),
+    ("ug/mL", Unit { dimension: "mass_concentration", factor: 0.001, offset: 0.0 }),
+    ("ng/mL", Unit { dimension: "mass_concentration", factor: 1e-06, offset: 0.0 }),
+    ("mol/L", Unit { dimension: "substance_concentration", factor: 1.0, offset: 0.0 }),
+    ("mmol/L", Unit { dimension: "substance_concentration", factor: 0.001, offset: 0.0 }),
+    ("umol/L", Unit { dimension: "substance_concentration", factor: 1e-06, offset: 0.0 }),
+    ("nmol/L", Unit { dimension: "substance_concentration", factor: 1e-09, offset: 0.0 }),
+    ("Pa", Unit { dimension: "pressure", factor: 1.0, offset: 0.0 }),
+    ("kPa", Unit { dimension: "pressure", factor: 1000.0, offset: 0.0 }),
+    ("mm[Hg]", Unit { dimension: "pressure", factor: 133.322, offset: 0.0 }),
+    ("Hz", Unit { dimension: "frequency", factor: 1.0, offset: 0.0 }),
+    ("/min", Unit { dimension: "frequency", factor: 0.016666666666666666, offset: 0.0 }),
+    ("/h", Unit { dimension: "frequency", factor: 0.0002777777777777778, offset: 0.0 }),
+    ("1", Unit { dimension: "ratio", factor: 1.0, offset: 0.0 }),
+    ("%", Unit { dimension: "ratio", factor: 0.01, offset: 0.0 }),
+];
+
+fn unit(code: &str) -> Result<&'static Unit, String> {
+    UNITS
+        .iter()
+        .find(|(c, _)| *c == code)
+        .map(|(_, u)| u)
+        .ok_or_else(|| format!("unknown UCUM unit: {code}"))
+}
+
+/// Reports whether code is a UCUM unit these helpers can convert.
+pub fn is_known_unit(code: &str) -> bool {
+    unit(code).is_ok()
+}
+
+/// Converts value between two UCUM units of the same dimension.
+pub fn convert_value(value: f64, from_code: &str, to_code: &str) -> Result<f64, String> {
+    let source = unit(from_code)?;
+    let target = unit(to_code)?;
+    if source.dimension != target.dimension {
+        return Err(format!(
+            "cannot convert {from_code} ({}) to {to_code} ({})",
+            source.dimension, target.dimension
+        ));
+    }
+    Ok((value * source.factor + source.offset - target.offset) / target.factor)
+}
+
+/// Returns quantity in to_code units. Its code, or else its unit, must be a
+/// known UCUM unit.
+pub fn convert(quantity: &Quantity, to_code: &str) -> Result<Quantity, String> {
+    let current = quantity.value.ok_or("cannot convert a quantity without a value")?;
+    let from_code = quantity.code.as_deref().or(quantity.unit.as_deref()).unwrap_or("");
+    let value = convert_value(current.to_f64().ok_or("quantity value out of range")?, from_code, to_code)?;
+    let value = Decimal::from_f64(value).ok_or("converted value out of range")?;
+    Ok(Quantity {
+        value: Some(value),
+        unit: Some(to_code.to_string()),
+        system: Some(SYSTEM.to_string()),
+        code: Some(to_code.to_string()),
+        ..quantity.clone()
+    })
+}
@@ -0,0 +1,77 @@
+//! Partial date support for FHIR dates.
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use std::fmt;
+use std::str::FromStr;
+
+use chrono::{Datelike, NaiveDate};
+use serde::{Deserialize, Serialize};
+
+/// A date known to year, month, or day precision, e.g. `1970`, `1970-03`, or `1970-03-14`.
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+#[serde(try_from = "String", into = "String")]
+pub struct PartialDate {
+    pub year: i32,
+    pub month: Option<u32>,
+    pub day: Option<u32>,
+}
+
+impl PartialDate {
+    /// Returns the full date, or `None` when the value is less precise than a day.
+    pub fn to_naive_date(&self) -> Option<NaiveDate> {
+        NaiveDate::from_ymd_opt(self.year, self.month?, self.day?)
+    }
+}
+
+impl FromStr for PartialDate {
+    type Err = String;
+
+    /// Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form.
+    fn from_str(s: &str) -> Result<Self, Self::Err> {
+        let invalid = || format!("invalid partial date: {s}");
+        if !s.bytes().all(|b| b.is_ascii_digit() || b == b'-') {
+            return Err(invalid());
+        }
+        match s.len() {
+            4 => Ok(PartialDate { year: s.parse().map_err(|_| invalid())?, month: None, day: None }),
+            7 => {
+                let date = NaiveDate::parse_from_str(&format!("{s}-01"), "%Y-%m-%d").map_err(|_| invalid())?;
+                Ok(PartialDate { year: date.year(), month: Some(date.month()), day: None })
+            }
+            10 => {
+                let date = NaiveDate::parse_from_str(s, "%Y-%m-%d").map_err(|_| invalid())?;
+                Ok(PartialDate { year: date.year(), month: Some(date.month()), day: Some(date.day()) })
+            }
+            _ => Err(invalid()),
+        }
+    }
+}
+
+impl TryFrom<String> for PartialDate {
+    type Error = String;
+
+    fn try_from(s: String) -> Result<Self, Self::Error> {
+        s.parse()
+    }
+}
+
+impl From<PartialDate> for String {
+    fn from(date: PartialDate) -> Self {
+        date.to_string()
+    }
+}
+
+impl fmt::Display for PartialDate {
+    fn fmt(&self, f: &mut fmt::Formatter<'_>) -> fmt::Result {
+        write!(f, "{:04}", self.year)?;
+        if let Some(month) = self.month {
+            write!(f, "-{month:02}")?;
+        }
+        if let Some(day) = self.day {
+            write!(f, "-{day:02}")?;
+        }
+        Ok(())
+    }
+}
@@ -0,0 +1,63 @@
+//! An encounter whose field names are reserved words in target languages
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, FixedOffset};
+use rust_decimal::Decimal;
+
+use super::PartialDate;
+use super::Specimen;
+use super::super::datatypes::Address;
+use super::super::datatypes::CodeableConcept;
+use super::super::datatypes::Coding;
+use super::super::datatypes::Quantity;
+use super::super::datatypes::Reference;
+use super::super::registry::Practitioner;
+
+/// An encounter whose field names are reserved words in target languages
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Visit {
+    pub class: Coding,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#type: Option<Vec<CodeableConcept>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#for: Option<Reference>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default = "Visit::default_match")]
+    pub r#match: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub order: Option<i64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub self_: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub r#where: Option<Address>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub _2nd_opinion: Option<bool>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub priority_code: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub priority_code_2: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub visit: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub length: Option<Quantity>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub attender: Option<Practitioner>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub specimens: Option<Vec<Specimen>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub ingested_at: Option<DateTime<FixedOffset>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub source_system: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub record_hash: Option<String>,
+}
+
+impl Visit {
+    /// Returns the default of match, for fields missing on deserialization.
+    fn default_match() -> Option<String> {
+        Some("unlinked".to_string())
+    }
+}
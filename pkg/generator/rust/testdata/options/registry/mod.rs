@@ -0,0 +1,9 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+mod temporal;
+pub use temporal::PartialDate;
+
+mod practitioner;
+pub use practitioner::Practitioner;
+
@@ -0,0 +1,6 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+pub mod datatypes;
+pub mod fixtures;
+pub mod registry;
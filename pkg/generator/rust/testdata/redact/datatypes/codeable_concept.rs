@@ -0,0 +1,18 @@
+//! Concept - reference to a terminology or just text
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::Coding;
+
+/// Concept - reference to a terminology or just text
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct CodeableConcept {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub coding: Option<Vec<Coding>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub text: Option<String>,
+}
@@ -0,0 +1,87 @@
+//! Résultat de laboratoire — température °C, 检验结果
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::super::datatypes::HumanName;
+use super::super::datatypes::Quantity;
+use super::super::registry::Practitioner;
+
+/// Résultat de laboratoire — température °C, 检验结果
+///
+/// Owner: lab-data
+/// Steward: ana.ruiz@example.org
+/// Tags: clinical, phi
+/// Unique: result_id
+/// Indexed: mrn, resulted_at
+#[derive(Clone, Serialize, Deserialize)]
+pub struct LabResult {
+    pub result_id: String,
+    pub mrn: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_numeric: Option<f64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default = "LabResult::default_charge_amount")]
+    pub charge_amount: Option<f64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub value_quantity: Option<Quantity>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub resulted_at: Option<DateTime<Utc>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub performer_name: Option<HumanName>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub ordered_by: Option<Practitioner>,
+    #[serde(rename = "rawPayload")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub raw_payload: Option<Vec<u8>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub icd10_code: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub reviewer_ids: Option<Vec<String>>,
+}
+
+impl LabResult {
+    /// Returns the default of charge_amount, for fields missing on deserialization.
+    fn default_charge_amount() -> Option<f64> {
+        Some(0.0)
+    }
+}
+
+/// Formats the record with its fields at pii_level high or above masked,
+/// so that logging it does not leak them.
+impl std::fmt::Debug for LabResult {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.debug_struct("LabResult")
+            .field("result_id", &self.result_id)
+            .field("mrn", &"[REDACTED]")
+            .field("value_numeric", &self.value_numeric)
+            .field("charge_amount", &self.charge_amount)
+            .field("value_quantity", &self.value_quantity)
+            .field("resulted_at", &self.resulted_at)
+            .field("performer_name", &self.performer_name)
+            .field("ordered_by", &self.ordered_by)
+            .field("raw_payload", &self.raw_payload)
+            .field("icd10_code", &self.icd10_code)
+            .field("reviewer_ids", &self.reviewer_ids)
+            .finish()
+    }
+}
+
+impl LabResult {
+    /// Returns the record as JSON, with its fields at pii_level high or
+    /// above masked, for structured logs.
+    pub fn redact_for_logging(&self) -> serde_json::Value {
+        let mut value = serde_json::to_value(self).unwrap_or_default();
+        if let Some(fields) = value.as_object_mut() {
+            for name in ["mrn"] {
+                if let Some(field) = fields.get_mut(name) {
+                    *field = "[REDACTED]".into();
+                }
+            }
+        }
+        value
+    }
+}
@@ -0,0 +1,10 @@
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+mod visit;
+pub use visit::Visit;
+mod specimen;
+pub use specimen::Specimen;
+mod lab_result;
+pub use lab_result::LabResult;
+
@@ -0,0 +1,34 @@
+//! A person providing care
+//!
+//! Generated by ehrglot v0.1.0 at <timestamp>.
+//! DO NOT EDIT.
+
+use serde::{Deserialize, Serialize};
+use chrono::{NaiveDate, DateTime, Utc};
+
+use super::super::datatypes::Extension;
+use super::super::datatypes::HumanName;
+use super::super::datatypes::Meta;
+use super::super::datatypes::Narrative;
+
+/// A person providing care
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Practitioner {
+    pub id: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub meta: Option<Meta>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub implicit_rules: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub language: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub text: Option<Narrative>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub extension: Option<Vec<Extension>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub modifier_extension: Option<Vec<Extension>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub name: Option<Vec<HumanName>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub supervisor: Option<Box<Practitioner>>,
+}
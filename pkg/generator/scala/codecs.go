@@ -0,0 +1,179 @@
+package scala
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// caseClass is a schema with what its case class and companion object are
+// generated with.
+type caseClass struct {
+	schema.Schema
+	Name   string
+	Fields []field
+	// Derives is the type class the case class derives, in Scala 3.
+	Derives string
+	// Enums are the enums of the fields of enumerated values, in Scala 3.
+	Enums []enumType
+	// Codecs are the members of the companion object that give the case
+	// class its codec, in Scala 2 or with circe.
+	Codecs []string
+}
+
+// field is a field of a case class.
+type field struct {
+	naming.Field
+	Type       string
+	Default    string
+	Annotation string
+}
+
+// enumType is the Scala 3 enum of a field of enumerated values, nested in
+// the companion object of its case class.
+type enumType struct {
+	Name  string
+	Cases []enumCase
+	Codec string
+}
+
+// enumCase is a case of an enumType and the value it stands for.
+type enumCase struct {
+	Ident string
+	Value string
+}
+
+// symbols spell the characters of enumerated values, such as the <= of a
+// Quantity comparator, that have no letters to name their case by.
+var symbols = strings.NewReplacer("<", " Lt ", ">", " Gt ", "=", " Eq ", "!", " Not ", "+", " Plus ")
+
+// caseClass returns the case class of s, whose types resolve from scope.
+func (g *Generator) caseClass(scope resolve.Scope, s schema.Schema) caseClass {
+	c := caseClass{Schema: s, Name: g.typeName(s)}
+	enums := naming.NewScope("scala")
+	var renamed []string
+	for _, f := range g.fields(s) {
+		out := field{Field: f, Type: g.toScalaType(scope, f.Field), Default: defaultLiteral(f.Field)}
+		if g.scala3 && len(f.Enum) > 0 {
+			e := g.enumType(enums.Ident(naming.Pascal.Apply(f.Name)), f.Enum)
+			c.Enums = append(c.Enums, e)
+			qualified := c.Name + "." + e.Name
+			out.Type = qualified
+			if strings.HasPrefix(f.Type, "[]") {
+				out.Type = "Seq[" + out.Type + "]"
+			}
+			if !f.Required {
+				out.Type = "Option[" + out.Type + "]"
+			}
+			for _, ec := range e.Cases {
+				if ec.Value == f.Default {
+					out.Default = qualified + "." + ec.Ident
+				}
+			}
+		}
+		if out.Default != "" && !f.Required {
+			out.Default = "Some(" + out.Default + ")"
+		}
+		if wire := f.Wire; wire != strings.Trim(f.Ident, "`") {
+			if g.codecs == config.CodecsZIOJSON {
+				out.Annotation = "@jsonField(" + strconv.Quote(wire) + ")"
+			}
+			renamed = append(renamed, strconv.Quote(strings.Trim(f.Ident, "`"))+" -> "+strconv.Quote(wire))
+		}
+		c.Fields = append(c.Fields, out)
+	}
+
+	switch {
+	case g.codecs == config.CodecsCirce:
+		names := ""
+		if len(renamed) > 0 {
+			names = "Map(" + strings.Join(renamed, ", ") + ").withDefault(identity)"
+		}
+		if g.scala3 {
+			c.Derives = "ConfiguredCodec"
+			config := "private given Configuration = Configuration.default.withDefaults"
+			if names != "" {
+				config += ".withTransformMemberNames(" + names + ")"
+			}
+			c.Codecs = []string{config}
+		} else {
+			config := "private implicit val config: Configuration = Configuration.default.withDefaults"
+			if names != "" {
+				config += ".copy(transformMemberNames = " + names + ")"
+			}
+			c.Codecs = []string{config, fmt.Sprintf("implicit val codec: Codec.AsObject[%s] = deriveConfiguredCodec[%[1]s]", c.Name)}
+		}
+	case g.codecs == config.CodecsZIOJSON && g.scala3:
+		c.Derives = "JsonCodec"
+	case g.codecs == config.CodecsZIOJSON:
+		c.Codecs = []string{fmt.Sprintf("implicit val codec: JsonCodec[%s] = DeriveJsonCodec.gen[%[1]s]", c.Name)}
+	}
+	return c
+}
+
+// enumType returns the enum name of the values of a field, whose codec
+// reads and writes them as the strings they stand for.
+func (g *Generator) enumType(name string, values []string) enumType {
+	e := enumType{Name: name, Codec: g.stringCodec(name, "fromValue", "_.value")}
+	cases := naming.NewScope("scala")
+	for _, v := range values {
+		ident := naming.Pascal.Apply(symbols.Replace(v))
+		if ident == "" {
+			ident = "Value"
+		}
+		e.Cases = append(e.Cases, enumCase{Ident: cases.Ident(ident), Value: v})
+	}
+	return e
+}
+
+// stringCodec returns the codec of a type written as a string in JSON, as a
+// member of its companion object: decode turns the string into an Either of
+// an error or the value, and encode the value into the string.
+func (g *Generator) stringCodec(name, decode, encode string) string {
+	var typ, codec string
+	switch g.codecs {
+	case config.CodecsCirce:
+		typ = "Codec[" + name + "]"
+		codec = fmt.Sprintf("Codec.from(Decoder.decodeString.emap(%s), Encoder.encodeString.contramap(%s))", decode, encode)
+	case config.CodecsZIOJSON:
+		typ = "JsonCodec[" + name + "]"
+		codec = fmt.Sprintf("JsonCodec.string.transformOrFail(%s, %s)", decode, encode)
+	default:
+		return ""
+	}
+	if g.scala3 {
+		return "given " + typ + " = " + codec
+	}
+	return "implicit val codec: " + typ + " = " + codec
+}
+
+// libraries returns the imports of the codec and refined type libraries
+// the generated code uses.
+func (g *Generator) libraries() []string {
+	var imports []string
+	switch {
+	case g.codecs == config.CodecsCirce && g.scala3:
+		imports = append(imports, "io.circe.{Codec, Decoder, Encoder, Json}", "io.circe.derivation.{Configuration, ConfiguredCodec}")
+	case g.codecs == config.CodecsCirce:
+		imports = append(imports, "io.circe.{Codec, Decoder, Encoder, Json}", "io.circe.generic.extras.Configuration", "io.circe.generic.extras.semiauto.deriveConfiguredCodec")
+	case g.codecs == config.CodecsZIOJSON && g.scala3:
+		imports = append(imports, "zio.json.{JsonCodec, jsonField}", "zio.json.ast.Json")
+	case g.codecs == config.CodecsZIOJSON:
+		imports = append(imports, "zio.json.{DeriveJsonCodec, JsonCodec, jsonField}", "zio.json.ast.Json")
+	}
+	if g.refined {
+		imports = append(imports, "io.github.iltotore.iron.*", "io.github.iltotore.iron.constraint.all.*")
+		switch g.codecs {
+		case config.CodecsCirce:
+			imports = append(imports, "io.github.iltotore.iron.circe.given")
+		case config.CodecsZIOJSON:
+			imports = append(imports, "io.github.iltotore.iron.zioJson.given")
+		}
+	}
+	return imports
+}
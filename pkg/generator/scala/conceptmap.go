@@ -0,0 +1,98 @@
+package scala
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// conceptMapTable is the lookup table of a concept map and the method
+// translating codes by it.
+type conceptMapTable struct {
+	Map     conceptmap.ConceptMap
+	Val     string
+	Def     string
+	Sources []conceptmap.Source
+}
+
+// generateConceptMaps writes the methods that translate codes by the concept
+// maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, namespace string, path string) error {
+	var tables []conceptMapTable
+	for _, m := range maps {
+		name := naming.Pascal.Apply(m.Name)
+		tables = append(tables, conceptMapTable{
+			Map:     m,
+			Val:     name,
+			Def:     "translate" + name,
+			Sources: m.Sources(),
+		})
+	}
+
+	tmpl := `// Translations of codes by concept maps.
+//
+{{header}}
+package {{.Package}}
+
+/** A code that a concept map translates a source code to, and how equivalent the two are. */
+final case class ConceptMapTarget(system: String, code: String, display: String, equivalence: String)
+
+/** Translates codes by the concept maps of this namespace. */
+object ConceptMaps {
+{{- range $n, $table := .Tables}}
+{{- if $n}}
+{{end}}
+  // The concept map {{.Map.Name}}, keyed by source system and code.
+  private val {{.Val}}: Map[(String, String), List[ConceptMapTarget]] = Map(
+{{- range $i, $s := .Sources}}{{if $i}},{{end}}
+    ({{quote $s.System}}, {{quote $s.Code}}) -> List(
+{{- range $j, $t := $s.Translations}}{{if $j}},{{end}}
+      ConceptMapTarget({{quote $t.System}}, {{quote $t.Code}}, {{quote $t.Display}}, {{quote $t.Equivalence}})
+{{- end}})
+{{- end}}
+  )
+
+  /**
+   * Returns the codes that code of system translates to by the concept map
+   * {{.Map.Name}}, if any.
+{{- with .Map.Description}}
+   *
+   * {{line .}}
+{{- end}}
+   */
+  def {{.Def}}(system: String, code: String): List[ConceptMapTarget] =
+    {{.Val}}.getOrElse((system, code), Nil)
+{{- end}}
+}
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"line":   func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"header": g.header.Func("// "),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package string
+		Tables  []conceptMapTable
+	}{
+		Package: g.packageName(namespace),
+		Tables:  tables,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -2,77 +2,306 @@
 package scala
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
+// idPattern is the pattern of FHIR ids, which refined types check ids
+// against.
+const idPattern = `[A-Za-z0-9\\-\\.]{1,64}`
+
 // Generator generates Scala code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming      config.Naming
+	instantType string
+	dateType    string
+	ucum        bool
+	conceptMaps []string
+	redact      string
+	audit       bool
+	scala3      bool
+	codecs      string
+	refined     bool
+	anyType     string
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new Scala code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	lang := cfg.Language("scala")
+	g := &Generator{naming: lang.Naming, instantType: "Instant", dateType: "LocalDate", ucum: cfg.UCUM, conceptMaps: cfg.ConceptMapFiles(), redact: strings.ToLower(cfg.Redact), audit: cfg.Audit, scala3: lang.ScalaVersion == config.Scala3, codecs: lang.Codecs, refined: lang.Refined, anyType: "Any", header: header.New(cfg.Header, Version)}
+	if g.codecs != "" {
+		// Fields of unknown types hold their JSON as it is
+		g.anyType = "Json"
+	}
+	temporal := cfg.TemporalPolicy("scala")
+	if temporal.Instants == config.InstantsOffset {
+		g.instantType = "OffsetDateTime"
+	}
+	if temporal.Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
 // Generate generates Scala case classes from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
 		// Generate package file with all case classes
 		path := filepath.Join(nsDir, "types.scala")
-		if err := g.generateTypes(namespace, nsSchemas, path); err != nil {
+		if err := g.generateTypes(refs.Scope(namespace), nsSchemas, path); err != nil {
 			return err
 		}
+
+		if g.ucum {
+			for _, s := range nsSchemas {
+				if datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+					if err := g.generateUCUM(s, namespace, filepath.Join(nsDir, "Ucum.scala")); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, namespace, filepath.Join(nsDir, "ConceptMaps.scala")); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func (g *Generator) generateTypes(namespace string, schemas []schema.Schema, path string) error {
-	tmpl := `// Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-// DO NOT EDIT.
-
+func (g *Generator) generateTypes(scope resolve.Scope, schemas []schema.Schema, path string) error {
+	for _, s := range schemas {
+		if err := s.CheckDefaults(); err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+	}
+	tmpl := `{{header}}
 package {{.Package}}
 
-import java.time.{LocalDate, Instant}
+import java.time.{LocalDate, {{.Instant}}}
+{{- range .Libraries}}
+import {{.}}
+{{- end}}
+{{- range .Imports}}
+import {{.}}
+{{- end}}
+{{if .Partial}}
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+case class PartialDate(year: Int, month: Option[Int] = None, day: Option[Int] = None) {
+  require(day.isEmpty || month.isDefined, "a partial date with a day must have a month")
+  // Reject impossible dates such as 1970-02-30
+  LocalDate.of(year, month.getOrElse(1), day.getOrElse(1))
 
-{{range .Schemas}}
+  /** Returns the full date, or None when the value is less precise than a day. */
+  def toLocalDate: Option[LocalDate] =
+    for (m <- month; d <- day) yield LocalDate.of(year, m, d)
+
+  override def toString: String =
+    f"$year%04d" + month.fold("")(m => f"-$m%02d") + day.fold("")(d => f"-$d%02d")
+}
+
+object PartialDate {
+  private val Pattern = """(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""".r
+
+  /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+  def parse(value: String): PartialDate = value match {
+    case Pattern(year, month, day) =>
+      PartialDate(year.toInt, Option(month).map(_.toInt), Option(day).map(_.toInt))
+    case _ =>
+      throw new IllegalArgumentException(s"invalid partial date: $value")
+  }
+{{- with .PartialCodec}}
+
+  {{.}}
+{{- end}}
+}
+{{end}}
+{{range $s := .Schemas}}
 /**
  * {{.Description}}
+{{- with .Docs}}
+ *
+{{- range .}}
+ * {{.}}
+{{- end}}
+{{- end}}
  */
-case class {{. | schemaName}}(
+case class {{.Name}}(
 {{range $i, $f := .Fields}}{{if $i}},
-{{end}}  {{$f.Name | camel}}: {{$f | scalaType}}{{end}}
-)
+{{end}}  {{with $f.Annotation}}{{.}} {{end}}{{$f.Ident}}: {{$f.Type}}{{with $f.Default}} = {{.}}{{end}}{{end}}
+){{with .Derives}} derives {{.}}{{end}}{{if .HasSensitive $.Level}} {
+  /** Returns the fields of this record, with those at pii_level {{$.Level}} or above masked, for logging. */
+  def redactForLogging: Map[String, Any] = scala.collection.immutable.ListMap(
+{{- range $i, $f := .Fields}}{{if $i}},{{end}}
+    "{{$f.Name}}" -> {{if $f.Sensitive $.Level}}"` + schema.Redacted + `"{{else}}{{$f.Ident}}{{end}}
+{{- end}}
+  )
+
+  override def toString: String =
+    redactForLogging.map { case (name, value) => s"$name=$value" }.mkString("{{.Name}}(", ", ", ")")
+}{{end}}
+{{- if or .Enums .Codecs}}
+
+object {{.Name}} {
+{{- range .Codecs}}
+  {{.}}
+{{- end}}
+{{- range $i, $e := .Enums}}{{if or $i $s.Codecs}}
+{{end}}
+  enum {{$e.Name}}(val value: String) {
+{{- range $e.Cases}}
+    case {{.Ident}} extends {{$e.Name}}({{quote .Value}})
+{{- end}}
+  }
+
+  object {{$e.Name}} {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, {{$e.Name}}] =
+      values.find(_.value == value).toRight(s"unknown {{$s.Name}}.{{$e.Name}}: $value")
+{{- with $e.Codec}}
+
+    {{.}}
+{{- end}}
+  }
+{{- end}}
+}
+{{- end}}
 {{end}}
 `
-	funcMap := template.FuncMap{
-		"camel":      toCamelCase,
-		"scalaType":  toScalaType,
-		"schemaName": func(s schema.Schema) string { return s.GetName() },
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"quote": strconv.Quote, "header": g.header.Func("// ", schemas...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Package      string
+		Instant      string
+		Libraries    []string
+		Imports      []string
+		Partial      bool
+		PartialCodec string
+		Level        string
+		Schemas      []caseClass
+	}{
+		Package:      g.packageName(scope.Namespace),
+		Instant:      g.instantType,
+		Libraries:    g.libraries(),
+		Imports:      g.imports(scope, schemas),
+		Partial:      g.dateType == "PartialDate",
+		PartialCodec: g.stringCodec("PartialDate", "value => scala.util.Try(parse(value)).toEither.left.map(_.getMessage)", "_.toString"),
+		Level:        g.redact,
+	}
+	for _, s := range schemas {
+		data.Schemas = append(data.Schemas, g.caseClass(scope, s))
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, namespace string, path string) error {
+	tmpl := `// UCUM unit validation and conversion for {{.Type}}.
+//
+{{header}}
+package {{.Package}}
+
+object Ucum {
+  val SystemUri: String = "` + datatypes.UCUMSystem + `"
+
+  /** A unit whose value v is v * factor + offset in its dimension's base unit. */
+  private final case class UcumUnit(dimension: String, factor: Double, offset: Double)
+
+  private val Units: Map[String, UcumUnit] = Map(
+{{- range $i, $u := .Units}}{{if $i}},{{end}}
+    "{{$u.Code}}" -> UcumUnit("{{$u.Dimension}}", {{$u.Factor | float}}, {{$u.Offset | float}})
+{{- end}}
+  )
+
+  /** Reports whether code is a UCUM unit these helpers can convert. */
+  def isKnownUnit(code: String): Boolean = Units.contains(code)
+
+  /** Converts value between two UCUM units of the same dimension. */
+  def convertValue(value: Double, fromCode: String, toCode: String): Double = {
+    val source = unit(fromCode)
+    val target = unit(toCode)
+    require(source.dimension == target.dimension,
+      s"cannot convert $fromCode (${source.dimension}) to $toCode (${target.dimension})")
+    (value * source.factor + source.offset - target.offset) / target.factor
+  }
+
+  /** Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit. */
+  def convert(quantity: {{.Type}}, toCode: String): {{.Type}} = {
+    val current = quantity.{{.F.value}}.getOrElse(
+      throw new IllegalArgumentException("cannot convert a quantity without a value"))
+    val fromCode = quantity.{{.F.code}}.orElse(quantity.{{.F.unit}}).getOrElse("")
+    val value = convertValue(current.toDouble, fromCode, toCode)
+    quantity.copy(
+      {{.F.value}} = Some(BigDecimal(value)),
+      {{.F.unit}} = Some(toCode),
+      {{.F.system}} = Some(SystemUri),
+      {{.F.code}} = Some(toCode))
+  }
+
+  private def unit(code: String): UcumUnit =
+    Units.getOrElse(code, throw new IllegalArgumentException(s"unknown UCUM unit: $code"))
+}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.header.Func("// ", quantity)})
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -81,61 +310,135 @@ case class {{. | schemaName}}(
 	}
 	defer f.Close()
 
-	// Convert namespace to Scala package name
-	packageName := strings.ReplaceAll(namespace, "_", ".")
-
 	data := struct {
 		Package string
-		Schemas []schema.Schema
+		Type    string
+		F       map[string]string
+		Units   []datatypes.Unit
 	}{
-		Package: packageName,
-		Schemas: schemas,
+		Package: g.packageName(namespace),
+		Type:    g.typeName(quantity),
+		F:       naming.ByName(g.fields(quantity)),
+		Units:   datatypes.Units,
 	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
 // GenerateMappings generates Scala mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	return nil
 }
 
-func toCamelCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if i == 0 {
-			words[i] = strings.ToLower(w)
-		} else if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+// imports returns one import per other package the schemas use types from,
+// e.g. datatypes.{Coding, Reference}.
+func (g *Generator) imports(scope resolve.Scope, schemas []schema.Schema) []string {
+	names := make(map[string]map[string]bool)
+	for _, s := range schemas {
+		for _, dep := range scope.Dependencies(s) {
+			if dep.Namespace == scope.Namespace {
+				continue
+			}
+			pkg := g.packageName(dep.Namespace)
+			if names[pkg] == nil {
+				names[pkg] = make(map[string]bool)
+			}
+			names[pkg][g.typeName(dep)] = true
+		}
+	}
+
+	imports := make([]string, 0, len(names))
+	for pkg, set := range names {
+		selected := make([]string, 0, len(set))
+		for name := range set {
+			selected = append(selected, name)
+		}
+		sort.Strings(selected)
+		if len(selected) == 1 {
+			imports = append(imports, pkg+"."+selected[0])
+		} else {
+			imports = append(imports, pkg+".{"+strings.Join(selected, ", ")+"}")
 		}
 	}
-	return strings.Join(words, "")
+	sort.Strings(imports)
+	return imports
 }
 
-func toScalaType(f schema.Field) string {
+func (g *Generator) typeName(s schema.Schema) string {
+	return naming.Ident("scala", naming.Case(g.naming.Types).Apply(s.GetName()))
+}
+
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Wire(naming.Fields("scala", s.Fields, naming.Case(g.naming.Fields).Or(naming.Camel.Apply)), naming.Camel.Apply))
+}
+
+// packageName converts a namespace to a Scala package name (e.g., fhir_r4 -> fhir.r4).
+func (g *Generator) packageName(namespace string) string {
+	parts := strings.Split(strings.ToLower(namespace), "_")
+	for i, p := range parts {
+		parts[i] = naming.Ident("scala", naming.Case(g.naming.Packages).Apply(p))
+	}
+	return strings.Join(parts, ".")
+}
+
+// defaultLiteral returns the default of f as a Scala literal of the type
+// generated for it, or "" when it has none.
+func defaultLiteral(f schema.Field) string {
+	if f.Default == "" {
+		return ""
+	}
+	switch f.DefaultKind() {
+	case "integer":
+		return f.Default
+	case "boolean":
+		b, _ := strconv.ParseBool(f.Default)
+		return strconv.FormatBool(b)
+	case "decimal":
+		return "BigDecimal(" + strconv.Quote(f.Default) + ")"
+	}
+	return strconv.Quote(f.Default)
+}
+
+func (g *Generator) toScalaType(scope resolve.Scope, f schema.Field) string {
 	baseType := ""
 	switch f.Type {
 	case "string", "code", "id", "uri", "url":
 		baseType = "String"
+		if g.refined && f.Type == "id" {
+			baseType = `String :| Match["` + idPattern + `"]`
+		}
 	case "integer", "positiveInt", "unsignedInt":
 		baseType = "Int"
+		if g.refined && f.Type == "positiveInt" {
+			baseType = "Int :| Positive"
+		} else if g.refined && f.Type == "unsignedInt" {
+			baseType = "Int :| Positive0"
+		}
 	case "decimal":
 		baseType = "BigDecimal"
 	case "boolean":
 		baseType = "Boolean"
 	case "date":
-		baseType = "LocalDate"
+		baseType = g.dateType
 	case "datetime", "instant":
-		baseType = "Instant"
+		baseType = g.instantType
 	case "base64Binary":
 		baseType = "Array[Byte]"
 	default:
-		if strings.HasPrefix(f.Type, "[]") {
+		if target, ok := scope.Lookup(f.Type); ok {
+			baseType = g.typeName(target)
+		} else if strings.HasPrefix(f.Type, "[]") {
 			innerType := strings.TrimPrefix(f.Type, "[]")
-			inner := toScalaType(schema.Field{Type: innerType, Required: true})
+			inner := g.toScalaType(scope, schema.Field{Type: innerType, Required: true})
 			baseType = fmt.Sprintf("Seq[%s]", inner)
 		} else {
-			baseType = "Any"
+			baseType = g.anyType
 		}
 	}
 
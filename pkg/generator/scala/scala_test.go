@@ -0,0 +1,55 @@
+package scala_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/scala"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, scala.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, scala.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestGenerateCodecsGolden(t *testing.T) {
+	cfgs := map[string]config.Config{
+		"scala3-circe": {
+			Temporal:  config.Temporal{Dates: config.DatesPartial},
+			Redact:    "high",
+			Languages: map[string]config.Language{"scala": {ScalaVersion: config.Scala3, Codecs: config.CodecsCirce, Refined: true}},
+		},
+		"scala3-zio-json": {
+			Languages: map[string]config.Language{"scala": {ScalaVersion: config.Scala3, Codecs: config.CodecsZIOJSON}},
+		},
+		"scala2-circe": {
+			Temporal:  config.Temporal{Dates: config.DatesPartial},
+			Languages: map[string]config.Language{"scala": {Codecs: config.CodecsCirce}},
+		},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			generatortest.RunSchemas(t, scala.NewGenerator(cfg), fixtures.Schemas(), "testdata/codecs/"+name)
+		})
+	}
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, scala.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, scala.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
@@ -0,0 +1,221 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.{LocalDate, Instant}
+import io.circe.{Codec, Decoder, Encoder, Json}
+import io.circe.generic.extras.Configuration
+import io.circe.generic.extras.semiauto.deriveConfiguredCodec
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+case class PartialDate(year: Int, month: Option[Int] = None, day: Option[Int] = None) {
+  require(day.isEmpty || month.isDefined, "a partial date with a day must have a month")
+  // Reject impossible dates such as 1970-02-30
+  LocalDate.of(year, month.getOrElse(1), day.getOrElse(1))
+
+  /** Returns the full date, or None when the value is less precise than a day. */
+  def toLocalDate: Option[LocalDate] =
+    for (m <- month; d <- day) yield LocalDate.of(year, m, d)
+
+  override def toString: String =
+    f"$year%04d" + month.fold("")(m => f"-$m%02d") + day.fold("")(d => f"-$d%02d")
+}
+
+object PartialDate {
+  private val Pattern = """(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""".r
+
+  /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+  def parse(value: String): PartialDate = value match {
+    case Pattern(year, month, day) =>
+      PartialDate(year.toInt, Option(month).map(_.toInt), Option(day).map(_.toInt))
+    case _ =>
+      throw new IllegalArgumentException(s"invalid partial date: $value")
+  }
+
+  implicit val codec: Codec[PartialDate] = Codec.from(Decoder.decodeString.emap(value => scala.util.Try(parse(value)).toEither.left.map(_.getMessage)), Encoder.encodeString.contramap(_.toString))
+}
+
+
+/**
+ * Time range defined by start and end date/time
+ */
+case class Period(
+  start: Option[Instant],
+  end: Option[Instant]
+)
+
+object Period {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Period] = deriveConfiguredCodec[Period]
+}
+
+/**
+ * An address expressed using postal conventions
+ */
+case class Address(
+  use: Option[String],
+  `type`: Option[String],
+  text: Option[String],
+  line: Option[Seq[String]],
+  city: Option[String],
+  district: Option[String],
+  state: Option[String],
+  postalCode: Option[String],
+  country: Option[String],
+  period: Option[Period]
+)
+
+object Address {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Address] = deriveConfiguredCodec[Address]
+}
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+case class Coding(
+  system: Option[String],
+  version: Option[String],
+  code: Option[String],
+  display: Option[String],
+  userSelected: Option[Boolean]
+)
+
+object Coding {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Coding] = deriveConfiguredCodec[Coding]
+}
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+case class CodeableConcept(
+  coding: Option[Seq[Coding]],
+  text: Option[String]
+)
+
+object CodeableConcept {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[CodeableConcept] = deriveConfiguredCodec[CodeableConcept]
+}
+
+/**
+ * An identifier intended for computation
+ */
+case class Identifier(
+  use: Option[String],
+  `type`: Option[CodeableConcept],
+  system: Option[String],
+  value: Option[String],
+  period: Option[Period]
+)
+
+object Identifier {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Identifier] = deriveConfiguredCodec[Identifier]
+}
+
+/**
+ * A reference from one resource to another
+ */
+case class Reference(
+  reference: Option[String],
+  `type`: Option[String],
+  identifier: Option[Identifier],
+  display: Option[String]
+)
+
+object Reference {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Reference] = deriveConfiguredCodec[Reference]
+}
+
+/**
+ * Additional content defined by implementations
+ */
+case class Extension(
+  url: String,
+  valueString: Option[String],
+  valueCode: Option[String],
+  valueBoolean: Option[Boolean],
+  valueInteger: Option[Int],
+  valueDecimal: Option[BigDecimal],
+  valueDateTime: Option[Instant],
+  valueCoding: Option[Coding],
+  valueCodeableConcept: Option[CodeableConcept],
+  valueReference: Option[Reference],
+  valueAddress: Option[Address]
+)
+
+object Extension {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Extension] = deriveConfiguredCodec[Extension]
+}
+
+/**
+ * Name of a human - parts and usage
+ */
+case class HumanName(
+  use: Option[String],
+  text: Option[String],
+  family: Option[String],
+  `given`: Option[Seq[String]],
+  prefix: Option[Seq[String]],
+  suffix: Option[Seq[String]],
+  period: Option[Period]
+)
+
+object HumanName {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[HumanName] = deriveConfiguredCodec[HumanName]
+}
+
+/**
+ * Metadata about a resource
+ */
+case class Meta(
+  versionId: Option[String],
+  lastUpdated: Option[Instant],
+  source: Option[String],
+  profile: Option[Seq[String]],
+  security: Option[Seq[Coding]],
+  tag: Option[Seq[Coding]]
+)
+
+object Meta {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Meta] = deriveConfiguredCodec[Meta]
+}
+
+/**
+ * Human-readable summary of the resource
+ */
+case class Narrative(
+  status: String,
+  div: String
+)
+
+object Narrative {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Narrative] = deriveConfiguredCodec[Narrative]
+}
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+case class Quantity(
+  value: Option[BigDecimal],
+  comparator: Option[String],
+  unit: Option[String],
+  system: Option[String],
+  code: Option[String]
+)
+
+object Quantity {
+  private implicit val config: Configuration = Configuration.default.withDefaults
+  implicit val codec: Codec.AsObject[Quantity] = deriveConfiguredCodec[Quantity]
+}
+
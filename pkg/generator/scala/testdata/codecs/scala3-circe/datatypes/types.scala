@@ -0,0 +1,306 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.{LocalDate, Instant}
+import io.circe.{Codec, Decoder, Encoder, Json}
+import io.circe.derivation.{Configuration, ConfiguredCodec}
+import io.github.iltotore.iron.*
+import io.github.iltotore.iron.constraint.all.*
+import io.github.iltotore.iron.circe.given
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+case class PartialDate(year: Int, month: Option[Int] = None, day: Option[Int] = None) {
+  require(day.isEmpty || month.isDefined, "a partial date with a day must have a month")
+  // Reject impossible dates such as 1970-02-30
+  LocalDate.of(year, month.getOrElse(1), day.getOrElse(1))
+
+  /** Returns the full date, or None when the value is less precise than a day. */
+  def toLocalDate: Option[LocalDate] =
+    for (m <- month; d <- day) yield LocalDate.of(year, m, d)
+
+  override def toString: String =
+    f"$year%04d" + month.fold("")(m => f"-$m%02d") + day.fold("")(d => f"-$d%02d")
+}
+
+object PartialDate {
+  private val Pattern = """(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""".r
+
+  /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+  def parse(value: String): PartialDate = value match {
+    case Pattern(year, month, day) =>
+      PartialDate(year.toInt, Option(month).map(_.toInt), Option(day).map(_.toInt))
+    case _ =>
+      throw new IllegalArgumentException(s"invalid partial date: $value")
+  }
+
+  given Codec[PartialDate] = Codec.from(Decoder.decodeString.emap(value => scala.util.Try(parse(value)).toEither.left.map(_.getMessage)), Encoder.encodeString.contramap(_.toString))
+}
+
+
+/**
+ * Time range defined by start and end date/time
+ */
+case class Period(
+  start: Option[Instant],
+  end: Option[Instant]
+) derives ConfiguredCodec
+
+object Period {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * An address expressed using postal conventions
+ */
+case class Address(
+  use: Option[Address.Use],
+  `type`: Option[Address.Type],
+  text: Option[String],
+  line: Option[Seq[String]],
+  city: Option[String],
+  district: Option[String],
+  state: Option[String],
+  postalCode: Option[String],
+  country: Option[String],
+  period: Option[Period]
+) derives ConfiguredCodec
+
+object Address {
+  private given Configuration = Configuration.default.withDefaults
+
+  enum Use(val value: String) {
+    case Home extends Use("home")
+    case Work extends Use("work")
+    case Temp extends Use("temp")
+    case Old extends Use("old")
+    case Billing extends Use("billing")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown Address.Use: $value")
+
+    given Codec[Use] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+
+  enum Type(val value: String) {
+    case Postal extends Type("postal")
+    case Physical extends Type("physical")
+    case Both extends Type("both")
+  }
+
+  object Type {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Type] =
+      values.find(_.value == value).toRight(s"unknown Address.Type: $value")
+
+    given Codec[Type] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+}
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+case class Coding(
+  system: Option[String],
+  version: Option[String],
+  code: Option[String],
+  display: Option[String],
+  userSelected: Option[Boolean]
+) derives ConfiguredCodec
+
+object Coding {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+case class CodeableConcept(
+  coding: Option[Seq[Coding]],
+  text: Option[String]
+) derives ConfiguredCodec
+
+object CodeableConcept {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * An identifier intended for computation
+ */
+case class Identifier(
+  use: Option[Identifier.Use],
+  `type`: Option[CodeableConcept],
+  system: Option[String],
+  value: Option[String],
+  period: Option[Period]
+) derives ConfiguredCodec
+
+object Identifier {
+  private given Configuration = Configuration.default.withDefaults
+
+  enum Use(val value: String) {
+    case Usual extends Use("usual")
+    case Official extends Use("official")
+    case Temp extends Use("temp")
+    case Secondary extends Use("secondary")
+    case Old extends Use("old")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown Identifier.Use: $value")
+
+    given Codec[Use] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+}
+
+/**
+ * A reference from one resource to another
+ */
+case class Reference(
+  reference: Option[String],
+  `type`: Option[String],
+  identifier: Option[Identifier],
+  display: Option[String]
+) derives ConfiguredCodec
+
+object Reference {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * Additional content defined by implementations
+ */
+case class Extension(
+  url: String,
+  valueString: Option[String],
+  valueCode: Option[String],
+  valueBoolean: Option[Boolean],
+  valueInteger: Option[Int],
+  valueDecimal: Option[BigDecimal],
+  valueDateTime: Option[Instant],
+  valueCoding: Option[Coding],
+  valueCodeableConcept: Option[CodeableConcept],
+  valueReference: Option[Reference],
+  valueAddress: Option[Address]
+) derives ConfiguredCodec
+
+object Extension {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * Name of a human - parts and usage
+ */
+case class HumanName(
+  use: Option[HumanName.Use],
+  text: Option[String],
+  family: Option[String],
+  `given`: Option[Seq[String]],
+  prefix: Option[Seq[String]],
+  suffix: Option[Seq[String]],
+  period: Option[Period]
+) derives ConfiguredCodec
+
+object HumanName {
+  private given Configuration = Configuration.default.withDefaults
+
+  enum Use(val value: String) {
+    case Usual extends Use("usual")
+    case Official extends Use("official")
+    case Temp extends Use("temp")
+    case Nickname extends Use("nickname")
+    case Anonymous extends Use("anonymous")
+    case Old extends Use("old")
+    case Maiden extends Use("maiden")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown HumanName.Use: $value")
+
+    given Codec[Use] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+}
+
+/**
+ * Metadata about a resource
+ */
+case class Meta(
+  versionId: Option[String :| Match["[A-Za-z0-9\\-\\.]{1,64}"]],
+  lastUpdated: Option[Instant],
+  source: Option[String],
+  profile: Option[Seq[String]],
+  security: Option[Seq[Coding]],
+  tag: Option[Seq[Coding]]
+) derives ConfiguredCodec
+
+object Meta {
+  private given Configuration = Configuration.default.withDefaults
+}
+
+/**
+ * Human-readable summary of the resource
+ */
+case class Narrative(
+  status: Narrative.Status,
+  div: String
+) derives ConfiguredCodec
+
+object Narrative {
+  private given Configuration = Configuration.default.withDefaults
+
+  enum Status(val value: String) {
+    case Generated extends Status("generated")
+    case Extensions extends Status("extensions")
+    case Additional extends Status("additional")
+    case Empty extends Status("empty")
+  }
+
+  object Status {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Status] =
+      values.find(_.value == value).toRight(s"unknown Narrative.Status: $value")
+
+    given Codec[Status] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+}
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+case class Quantity(
+  value: Option[BigDecimal],
+  comparator: Option[Quantity.Comparator],
+  unit: Option[String],
+  system: Option[String],
+  code: Option[String]
+) derives ConfiguredCodec
+
+object Quantity {
+  private given Configuration = Configuration.default.withDefaults
+
+  enum Comparator(val value: String) {
+    case Lt extends Comparator("<")
+    case LtEq extends Comparator("<=")
+    case GtEq extends Comparator(">=")
+    case Gt extends Comparator(">")
+  }
+
+  object Comparator {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Comparator] =
+      values.find(_.value == value).toRight(s"unknown Quantity.Comparator: $value")
+
+    given Codec[Comparator] = Codec.from(Decoder.decodeString.emap(fromValue), Encoder.encodeString.contramap(_.value))
+  }
+}
+
@@ -0,0 +1,239 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.{LocalDate, Instant}
+import zio.json.{JsonCodec, jsonField}
+import zio.json.ast.Json
+
+
+/**
+ * Time range defined by start and end date/time
+ */
+case class Period(
+  start: Option[Instant],
+  end: Option[Instant]
+) derives JsonCodec
+
+/**
+ * An address expressed using postal conventions
+ */
+case class Address(
+  use: Option[Address.Use],
+  `type`: Option[Address.Type],
+  text: Option[String],
+  line: Option[Seq[String]],
+  city: Option[String],
+  district: Option[String],
+  state: Option[String],
+  postalCode: Option[String],
+  country: Option[String],
+  period: Option[Period]
+) derives JsonCodec
+
+object Address {
+  enum Use(val value: String) {
+    case Home extends Use("home")
+    case Work extends Use("work")
+    case Temp extends Use("temp")
+    case Old extends Use("old")
+    case Billing extends Use("billing")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown Address.Use: $value")
+
+    given JsonCodec[Use] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+
+  enum Type(val value: String) {
+    case Postal extends Type("postal")
+    case Physical extends Type("physical")
+    case Both extends Type("both")
+  }
+
+  object Type {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Type] =
+      values.find(_.value == value).toRight(s"unknown Address.Type: $value")
+
+    given JsonCodec[Type] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+case class Coding(
+  system: Option[String],
+  version: Option[String],
+  code: Option[String],
+  display: Option[String],
+  userSelected: Option[Boolean]
+) derives JsonCodec
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+case class CodeableConcept(
+  coding: Option[Seq[Coding]],
+  text: Option[String]
+) derives JsonCodec
+
+/**
+ * An identifier intended for computation
+ */
+case class Identifier(
+  use: Option[Identifier.Use],
+  `type`: Option[CodeableConcept],
+  system: Option[String],
+  value: Option[String],
+  period: Option[Period]
+) derives JsonCodec
+
+object Identifier {
+  enum Use(val value: String) {
+    case Usual extends Use("usual")
+    case Official extends Use("official")
+    case Temp extends Use("temp")
+    case Secondary extends Use("secondary")
+    case Old extends Use("old")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown Identifier.Use: $value")
+
+    given JsonCodec[Use] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
+/**
+ * A reference from one resource to another
+ */
+case class Reference(
+  reference: Option[String],
+  `type`: Option[String],
+  identifier: Option[Identifier],
+  display: Option[String]
+) derives JsonCodec
+
+/**
+ * Additional content defined by implementations
+ */
+case class Extension(
+  url: String,
+  valueString: Option[String],
+  valueCode: Option[String],
+  valueBoolean: Option[Boolean],
+  valueInteger: Option[Int],
+  valueDecimal: Option[BigDecimal],
+  valueDateTime: Option[Instant],
+  valueCoding: Option[Coding],
+  valueCodeableConcept: Option[CodeableConcept],
+  valueReference: Option[Reference],
+  valueAddress: Option[Address]
+) derives JsonCodec
+
+/**
+ * Name of a human - parts and usage
+ */
+case class HumanName(
+  use: Option[HumanName.Use],
+  text: Option[String],
+  family: Option[String],
+  `given`: Option[Seq[String]],
+  prefix: Option[Seq[String]],
+  suffix: Option[Seq[String]],
+  period: Option[Period]
+) derives JsonCodec
+
+object HumanName {
+  enum Use(val value: String) {
+    case Usual extends Use("usual")
+    case Official extends Use("official")
+    case Temp extends Use("temp")
+    case Nickname extends Use("nickname")
+    case Anonymous extends Use("anonymous")
+    case Old extends Use("old")
+    case Maiden extends Use("maiden")
+  }
+
+  object Use {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Use] =
+      values.find(_.value == value).toRight(s"unknown HumanName.Use: $value")
+
+    given JsonCodec[Use] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
+/**
+ * Metadata about a resource
+ */
+case class Meta(
+  versionId: Option[String],
+  lastUpdated: Option[Instant],
+  source: Option[String],
+  profile: Option[Seq[String]],
+  security: Option[Seq[Coding]],
+  tag: Option[Seq[Coding]]
+) derives JsonCodec
+
+/**
+ * Human-readable summary of the resource
+ */
+case class Narrative(
+  status: Narrative.Status,
+  div: String
+) derives JsonCodec
+
+object Narrative {
+  enum Status(val value: String) {
+    case Generated extends Status("generated")
+    case Extensions extends Status("extensions")
+    case Additional extends Status("additional")
+    case Empty extends Status("empty")
+  }
+
+  object Status {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Status] =
+      values.find(_.value == value).toRight(s"unknown Narrative.Status: $value")
+
+    given JsonCodec[Status] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+case class Quantity(
+  value: Option[BigDecimal],
+  comparator: Option[Quantity.Comparator],
+  unit: Option[String],
+  system: Option[String],
+  code: Option[String]
+) derives JsonCodec
+
+object Quantity {
+  enum Comparator(val value: String) {
+    case Lt extends Comparator("<")
+    case LtEq extends Comparator("<=")
+    case GtEq extends Comparator(">=")
+    case Gt extends Comparator(">")
+  }
+
+  object Comparator {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Comparator] =
+      values.find(_.value == value).toRight(s"unknown Quantity.Comparator: $value")
+
+    given JsonCodec[Comparator] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
@@ -0,0 +1,90 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import java.time.{LocalDate, Instant}
+import zio.json.{JsonCodec, jsonField}
+import zio.json.ast.Json
+import datatypes.{Address, CodeableConcept, Coding, HumanName, Identifier, Quantity, Reference}
+import registry.Practitioner
+
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+case class Visit(
+  `class`: Coding,
+  `type`: Option[Seq[CodeableConcept]],
+  `for`: Option[Reference],
+  `match`: Option[String] = Some("unlinked"),
+  order: Option[Int],
+  self: Option[String],
+  where: Option[Address],
+  @jsonField("2ndOpinion") _2ndOpinion: Option[Boolean],
+  priorityCode: Option[String],
+  priorityCode_2: Option[String],
+  visit: Option[String],
+  length: Option[Quantity],
+  attender: Option[Practitioner],
+  specimens: Option[Seq[Specimen]]
+) derives JsonCodec
+
+/**
+ * A sample collected for analysis
+ */
+case class Specimen(
+  id: String,
+  status: Specimen.Status = Specimen.Status.Available,
+  accessionIdentifier: Option[Identifier],
+  receivedTime: Option[Instant],
+  collectedDate: Option[LocalDate],
+  quantity: Option[BigDecimal],
+  containerCount: Option[Int] = Some(1),
+  fasting: Option[Boolean] = Some(false),
+  note: Option[Seq[String]],
+  subject: Option[Reference],
+  request: Option[Seq[Reference]],
+  visit: Option[Visit],
+  collection: Option[Json]
+) derives JsonCodec
+
+object Specimen {
+  enum Status(val value: String) {
+    case Available extends Status("available")
+    case Unavailable extends Status("unavailable")
+    case EnteredInError extends Status("entered-in-error")
+  }
+
+  object Status {
+    /** Returns the case whose value is value. */
+    def fromValue(value: String): Either[String, Status] =
+      values.find(_.value == value).toRight(s"unknown Specimen.Status: $value")
+
+    given JsonCodec[Status] = JsonCodec.string.transformOrFail(fromValue, _.value)
+  }
+}
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+case class lab_result(
+  resultId: String,
+  mrn: String,
+  valueNumeric: Option[BigDecimal],
+  chargeAmount: Option[BigDecimal] = Some(BigDecimal("0")),
+  valueQuantity: Option[Quantity],
+  resultedAt: Option[Instant],
+  performerName: Option[HumanName],
+  orderedBy: Option[Practitioner],
+  rawPayload: Option[Array[Byte]],
+  icd10Code: Option[String],
+  reviewerIds: Option[Seq[String]]
+) derives JsonCodec
+
@@ -0,0 +1,26 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package registry
+
+import java.time.{LocalDate, Instant}
+import zio.json.{JsonCodec, jsonField}
+import zio.json.ast.Json
+import datatypes.{Extension, HumanName, Meta, Narrative}
+
+
+/**
+ * A person providing care
+ */
+case class Practitioner(
+  id: String,
+  meta: Option[Meta],
+  implicitRules: Option[String],
+  language: Option[String],
+  text: Option[Narrative],
+  extension: Option[Seq[Extension]],
+  modifierExtension: Option[Seq[Extension]],
+  name: Option[Seq[HumanName]],
+  supervisor: Option[Practitioner]
+) derives JsonCodec
+
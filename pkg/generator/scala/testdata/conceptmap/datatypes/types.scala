@@ -0,0 +1,133 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+import java.time.{LocalDate, Instant}
+
+
+/**
+ * Time range defined by start and end date/time
+ */
+case class Period(
+  start: Option[Instant],
+  end: Option[Instant]
+)
+
+/**
+ * An address expressed using postal conventions
+ */
+case class Address(
+  use: Option[String],
+  `type`: Option[String],
+  text: Option[String],
+  line: Option[Seq[String]],
+  city: Option[String],
+  district: Option[String],
+  state: Option[String],
+  postalCode: Option[String],
+  country: Option[String],
+  period: Option[Period]
+)
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+case class Coding(
+  system: Option[String],
+  version: Option[String],
+  code: Option[String],
+  display: Option[String],
+  userSelected: Option[Boolean]
+)
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+case class CodeableConcept(
+  coding: Option[Seq[Coding]],
+  text: Option[String]
+)
+
+/**
+ * An identifier intended for computation
+ */
+case class Identifier(
+  use: Option[String],
+  `type`: Option[CodeableConcept],
+  system: Option[String],
+  value: Option[String],
+  period: Option[Period]
+)
+
+/**
+ * A reference from one resource to another
+ */
+case class Reference(
+  reference: Option[String],
+  `type`: Option[String],
+  identifier: Option[Identifier],
+  display: Option[String]
+)
+
+/**
+ * Additional content defined by implementations
+ */
+case class Extension(
+  url: String,
+  valueString: Option[String],
+  valueCode: Option[String],
+  valueBoolean: Option[Boolean],
+  valueInteger: Option[Int],
+  valueDecimal: Option[BigDecimal],
+  valueDateTime: Option[Instant],
+  valueCoding: Option[Coding],
+  valueCodeableConcept: Option[CodeableConcept],
+  valueReference: Option[Reference],
+  valueAddress: Option[Address]
+)
+
+/**
+ * Name of a human - parts and usage
+ */
+case class HumanName(
+  use: Option[String],
+  text: Option[String],
+  family: Option[String],
+  `given`: Option[Seq[String]],
+  prefix: Option[Seq[String]],
+  suffix: Option[Seq[String]],
+  period: Option[Period]
+)
+
+/**
+ * Metadata about a resource
+ */
+case class Meta(
+  versionId: Option[String],
+  lastUpdated: Option[Instant],
+  source: Option[String],
+  profile: Option[Seq[String]],
+  security: Option[Seq[Coding]],
+  tag: Option[Seq[Coding]]
+)
+
+/**
+ * Human-readable summary of the resource
+ */
+case class Narrative(
+  status: String,
+  div: String
+)
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+case class Quantity(
+  value: Option[BigDecimal],
+  comparator: Option[String],
+  unit: Option[String],
+  system: Option[String],
+  code: Option[String]
+)
+
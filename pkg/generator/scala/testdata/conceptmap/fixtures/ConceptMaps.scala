@@ -0,0 +1,34 @@
+// Translations of codes by concept maps.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+/** A code that a concept map translates a source code to, and how equivalent the two are. */
+final case class ConceptMapTarget(system: String, code: String, display: String, equivalence: String)
+
+/** Translates codes by the concept maps of this namespace. */
+object ConceptMaps {
+  // The concept map lab-to-loinc, keyed by source system and code.
+  private val LabToLoinc: Map[(String, String), List[ConceptMapTarget]] = Map(
+    ("http://hospital.example/lab-codes", "GLU") -> List(
+      ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent")),
+    ("http://hospital.example/lab-codes", "K") -> List(
+      ConceptMapTarget("http://loinc.org", "2823-3", "Potassium [Moles/volume] in Serum or Plasma", "equivalent")),
+    ("http://hospital.example/lab-codes", "LYTES") -> List(
+      ConceptMapTarget("http://loinc.org", "2951-2", "Sodium [Moles/volume] in Serum or Plasma", "wider"),
+      ConceptMapTarget("http://loinc.org", "2075-0", "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", "wider")),
+    ("http://legacy.example/lab", "0012") -> List(
+      ConceptMapTarget("http://loinc.org", "2345-7", "Glucose [Mass/volume] in Serum or Plasma", "equivalent"))
+  )
+
+  /**
+   * Returns the codes that code of system translates to by the concept map
+   * lab-to-loinc, if any.
+   *
+   * Local lab test codes to LOINC
+   */
+  def translateLabToLoinc(system: String, code: String): List[ConceptMapTarget] =
+    LabToLoinc.getOrElse((system, code), Nil)
+}
@@ -0,0 +1,72 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import java.time.{LocalDate, Instant}
+import datatypes.{Address, CodeableConcept, Coding, HumanName, Identifier, Quantity, Reference}
+import registry.Practitioner
+
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+case class Visit(
+  `class`: Coding,
+  `type`: Option[Seq[CodeableConcept]],
+  `for`: Option[Reference],
+  `match`: Option[String] = Some("unlinked"),
+  order: Option[Int],
+  self: Option[String],
+  where: Option[Address],
+  _2ndOpinion: Option[Boolean],
+  priorityCode: Option[String],
+  priorityCode_2: Option[String],
+  visit: Option[String],
+  length: Option[Quantity],
+  attender: Option[Practitioner],
+  specimens: Option[Seq[Specimen]]
+)
+
+/**
+ * A sample collected for analysis
+ */
+case class Specimen(
+  id: String,
+  status: String = "available",
+  accessionIdentifier: Option[Identifier],
+  receivedTime: Option[Instant],
+  collectedDate: Option[LocalDate],
+  quantity: Option[BigDecimal],
+  containerCount: Option[Int] = Some(1),
+  fasting: Option[Boolean] = Some(false),
+  note: Option[Seq[String]],
+  subject: Option[Reference],
+  request: Option[Seq[Reference]],
+  visit: Option[Visit],
+  collection: Option[Any]
+)
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+case class lab_result(
+  resultId: String,
+  mrn: String,
+  valueNumeric: Option[BigDecimal],
+  chargeAmount: Option[BigDecimal] = Some(BigDecimal("0")),
+  valueQuantity: Option[Quantity],
+  resultedAt: Option[Instant],
+  performerName: Option[HumanName],
+  orderedBy: Option[Practitioner],
+  rawPayload: Option[Array[Byte]],
+  icd10Code: Option[String],
+  reviewerIds: Option[Seq[String]]
+)
+
@@ -0,0 +1,89 @@
+// UCUM unit validation and conversion for Quantity.
+//
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package datatypes
+
+object Ucum {
+  val SystemUri: String = "http://unitsofmeasure.org"
+
+  /** A unit whose value v is v * factor + offset in its dimension's base unit. */
+  private final case class UcumUnit(dimension: String, factor: Double, offset: Double)
+
+  private val Units: Map[String, UcumUnit] = Map(
+    "g" -> UcumUnit("mass", 1.0, 0.0),
+    "kg" -> UcumUnit("mass", 1000.0, 0.0),
+    "mg" -> UcumUnit("mass", 0.001, 0.0),
+    "ug" -> UcumUnit("mass", 1e-06, 0.0),
+    "ng" -> UcumUnit("mass", 1e-09, 0.0),
+    "[lb_av]" -> UcumUnit("mass", 453.59237, 0.0),
+    "[oz_av]" -> UcumUnit("mass", 28.349523125, 0.0),
+    "m" -> UcumUnit("length", 1.0, 0.0),
+    "km" -> UcumUnit("length", 1000.0, 0.0),
+    "cm" -> UcumUnit("length", 0.01, 0.0),
+    "mm" -> UcumUnit("length", 0.001, 0.0),
+    "[in_i]" -> UcumUnit("length", 0.0254, 0.0),
+    "[ft_i]" -> UcumUnit("length", 0.3048, 0.0),
+    "L" -> UcumUnit("volume", 1.0, 0.0),
+    "dL" -> UcumUnit("volume", 0.1, 0.0),
+    "mL" -> UcumUnit("volume", 0.001, 0.0),
+    "uL" -> UcumUnit("volume", 1e-06, 0.0),
+    "s" -> UcumUnit("time", 1.0, 0.0),
+    "min" -> UcumUnit("time", 60.0, 0.0),
+    "h" -> UcumUnit("time", 3600.0, 0.0),
+    "d" -> UcumUnit("time", 86400.0, 0.0),
+    "wk" -> UcumUnit("time", 604800.0, 0.0),
+    "mo" -> UcumUnit("time", 2.6298e+06, 0.0),
+    "a" -> UcumUnit("time", 3.15576e+07, 0.0),
+    "K" -> UcumUnit("temperature", 1.0, 0.0),
+    "Cel" -> UcumUnit("temperature", 1.0, 273.15),
+    "[degF]" -> UcumUnit("temperature", 0.5555555555555556, 255.37222222222223),
+    "g/L" -> UcumUnit("mass_concentration", 1.0, 0.0),
+    "g/dL" -> UcumUnit("mass_concentration", 10.0, 0.0),
+    "mg/dL" -> UcumUnit("mass_concentration", 0.01, 0.0),
+    "mg/L" -> UcumUnit("mass_concentration", 0.001, 0.0),
+    "ug/mL" -> UcumUnit("mass_concentration", 0.001, 0.0),
+    "ng/mL" -> UcumUnit("mass_concentration", 1e-06, 0.0),
+    "mol/L" -> UcumUnit("substance_concentration", 1.0, 0.0),
+    "mmol/L" -> UcumUnit("substance_concentration", 0.001, 0.0),
+    "umol/L" -> UcumUnit("substance_concentration", 1e-06, 0.0),
+    "nmol/L" -> UcumUnit("substance_concentration", 1e-09, 0.0),
+    "Pa" -> UcumUnit("pressure", 1.0, 0.0),
+    "kPa" -> UcumUnit("pressure", 1000.0, 0.0),
+    "mm[Hg]" -> UcumUnit("pressure", 133.322, 0.0),
+    "Hz" -> UcumUnit("frequency", 1.0, 0.0),
+    "/min" -> UcumUnit("frequency", 0.016666666666666666, 0.0),
+    "/h" -> UcumUnit("frequency", 0.0002777777777777778, 0.0),
+    "1" -> UcumUnit("ratio", 1.0, 0.0),
+    "%" -> UcumUnit("ratio", 0.01, 0.0)
+  )
+
+  /** Reports whether code is a UCUM unit these helpers can convert. */
+  def isKnownUnit(code: String): Boolean = Units.contains(code)
+
+  /** Converts value between two UCUM units of the same dimension. */
+  def convertValue(value: Double, fromCode: String, toCode: String): Double = {
+    val source = unit(fromCode)
+    val target = unit(toCode)
+    require(source.dimension == target.dimension,
+      s"cannot convert $fromCode (${source.dimension}) to $toCode (${target.dimension})")
+    (value * source.factor + source.offset - target.offset) / target.factor
+  }
+
+  /** Returns quantity in toCode units. Its code, or else its unit, must be a known UCUM unit. */
+  def convert(quantity: Quantity, toCode: String): Quantity = {
+    val current = quantity.value.getOrElse(
+      throw new IllegalArgumentException("cannot convert a quantity without a value"))
+    val fromCode = quantity.code.orElse(quantity.unit).getOrElse("")
+    val value = convertValue(current.toDouble, fromCode, toCode)
+    quantity.copy(
+      value = Some(BigDecimal(value)),
+      unit = Some(toCode),
+      system = Some(SystemUri),
+      code = Some(toCode))
+  }
+
+  private def unit(code: String): UcumUnit =
+    Units.getOrElse(code, throw new IllegalArgumentException(s"unknown UCUM unit: $code"))
+}
@@ -0,0 +1,109 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package fixtures
+
+import java.time.{LocalDate, OffsetDateTime}
+import datatypes.{Address, CodeableConcept, Coding, HumanName, Identifier, Quantity, Reference}
+import registry.Practitioner
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+case class PartialDate(year: Int, month: Option[Int] = None, day: Option[Int] = None) {
+  require(day.isEmpty || month.isDefined, "a partial date with a day must have a month")
+  // Reject impossible dates such as 1970-02-30
+  LocalDate.of(year, month.getOrElse(1), day.getOrElse(1))
+
+  /** Returns the full date, or None when the value is less precise than a day. */
+  def toLocalDate: Option[LocalDate] =
+    for (m <- month; d <- day) yield LocalDate.of(year, m, d)
+
+  override def toString: String =
+    f"$year%04d" + month.fold("")(m => f"-$m%02d") + day.fold("")(d => f"-$d%02d")
+}
+
+object PartialDate {
+  private val Pattern = """(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""".r
+
+  /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+  def parse(value: String): PartialDate = value match {
+    case Pattern(year, month, day) =>
+      PartialDate(year.toInt, Option(month).map(_.toInt), Option(day).map(_.toInt))
+    case _ =>
+      throw new IllegalArgumentException(s"invalid partial date: $value")
+  }
+}
+
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+case class Visit(
+  `class`: Coding,
+  `type`: Option[Seq[CodeableConcept]],
+  `for`: Option[Reference],
+  `match`: Option[String] = Some("unlinked"),
+  order: Option[Int],
+  self: Option[String],
+  where: Option[Address],
+  _2ndOpinion: Option[Boolean],
+  priorityCode: Option[String],
+  priorityCode_2: Option[String],
+  visit: Option[String],
+  length: Option[Quantity],
+  attender: Option[Practitioner],
+  specimens: Option[Seq[Specimen]],
+  ingestedAt: Option[OffsetDateTime],
+  sourceSystem: Option[String],
+  recordHash: Option[String]
+)
+
+/**
+ * A sample collected for analysis
+ */
+case class Specimen(
+  id: String,
+  status: String = "available",
+  accessionIdentifier: Option[Identifier],
+  receivedTime: Option[OffsetDateTime],
+  collectedDate: Option[PartialDate],
+  quantity: Option[BigDecimal],
+  containerCount: Option[Int] = Some(1),
+  fasting: Option[Boolean] = Some(false),
+  note: Option[Seq[String]],
+  subject: Option[Reference],
+  request: Option[Seq[Reference]],
+  visit: Option[Visit],
+  collection: Option[Any],
+  ingestedAt: Option[OffsetDateTime],
+  sourceSystem: Option[String],
+  recordHash: Option[String]
+)
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+case class lab_result(
+  resultId: String,
+  mrn: String,
+  valueNumeric: Option[BigDecimal],
+  chargeAmount: Option[BigDecimal] = Some(BigDecimal("0")),
+  valueQuantity: Option[Quantity],
+  resultedAt: Option[OffsetDateTime],
+  performerName: Option[HumanName],
+  orderedBy: Option[Practitioner],
+  rawPayload: Option[Array[Byte]],
+  icd10Code: Option[String],
+  reviewerIds: Option[Seq[String]],
+  ingestedAt: Option[OffsetDateTime],
+  sourceSystem: Option[String],
+  recordHash: Option[String]
+)
+
@@ -0,0 +1,55 @@
+// Generated by ehrglot v0.1.0 at <timestamp>.
+// DO NOT EDIT.
+
+package registry
+
+import java.time.{LocalDate, OffsetDateTime}
+import datatypes.{Extension, HumanName, Meta, Narrative}
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+case class PartialDate(year: Int, month: Option[Int] = None, day: Option[Int] = None) {
+  require(day.isEmpty || month.isDefined, "a partial date with a day must have a month")
+  // Reject impossible dates such as 1970-02-30
+  LocalDate.of(year, month.getOrElse(1), day.getOrElse(1))
+
+  /** Returns the full date, or None when the value is less precise than a day. */
+  def toLocalDate: Option[LocalDate] =
+    for (m <- month; d <- day) yield LocalDate.of(year, m, d)
+
+  override def toString: String =
+    f"$year%04d" + month.fold("")(m => f"-$m%02d") + day.fold("")(d => f"-$d%02d")
+}
+
+object PartialDate {
+  private val Pattern = """(\d{4})(?:-(\d{2})(?:-(\d{2}))?)?""".r
+
+  /** Parses a date in YYYY, YYYY-MM, or YYYY-MM-DD form. */
+  def parse(value: String): PartialDate = value match {
+    case Pattern(year, month, day) =>
+      PartialDate(year.toInt, Option(month).map(_.toInt), Option(day).map(_.toInt))
+    case _ =>
+      throw new IllegalArgumentException(s"invalid partial date: $value")
+  }
+}
+
+
+/**
+ * A person providing care
+ */
+case class Practitioner(
+  id: String,
+  meta: Option[Meta],
+  implicitRules: Option[String],
+  language: Option[String],
+  text: Option[Narrative],
+  extension: Option[Seq[Extension]],
+  modifierExtension: Option[Seq[Extension]],
+  name: Option[Seq[HumanName]],
+  supervisor: Option[Practitioner],
+  ingestedAt: Option[OffsetDateTime],
+  sourceSystem: Option[String],
+  recordHash: Option[String]
+)
+
@@ -0,0 +1,189 @@
+package sql
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// alterOrder is the order statements of each kind of change are written in:
+// renames first, so that later statements name columns by their new names,
+// and dropped tables last.
+var alterOrder = []diff.Kind{diff.SchemaAdded, diff.FieldRenamed, diff.FieldRemoved, diff.FieldAdded, diff.TypeChanged, diff.RequiredChanged, diff.SchemaRemoved}
+
+// WriteAlter writes the statements that migrate tables from the old to the
+// new version of their schemas. Renamed fields become RENAME COLUMN, which
+// keeps their data, rather than a dropped column and an added one. Columns
+// of fields with a default are backfilled with it; the others stay nullable
+// until a backfill fills them. Changes to nested fields, which live in JSON
+// columns, need no DDL.
+func (g *Generator) WriteAlter(w io.Writer, changes []diff.Change) error {
+	var sources []schema.Schema
+	for _, c := range changes {
+		if c.New != nil {
+			sources = append(sources, *c.New)
+		}
+	}
+	fmt.Fprint(w, "-- Migration of changed schemas\n--\n"+g.header.Func("-- ", sources...)())
+
+	for _, kind := range alterOrder {
+		for _, c := range changes {
+			if c.Kind != kind {
+				continue
+			}
+			statements, err := g.alter(c)
+			if err != nil {
+				return fmt.Errorf("%s: %w", c, err)
+			}
+			fmt.Fprintf(w, "\n-- %s\n", c)
+			for _, s := range statements {
+				fmt.Fprintln(w, s)
+			}
+		}
+	}
+	return nil
+}
+
+// alter returns the statements of one change.
+func (g *Generator) alter(c diff.Change) ([]string, error) {
+	if len(c.Path) > 0 {
+		return []string{fmt.Sprintf("-- Held in the %s column; no DDL needed.", g.column(c.New, c.Path[0]))}, nil
+	}
+	switch c.Kind {
+	case diff.SchemaAdded:
+		return g.createTable(*c.New)
+	case diff.SchemaRemoved:
+		return []string{fmt.Sprintf("DROP TABLE IF EXISTS %s;", g.tableName(*c.Old))}, nil
+	}
+
+	table := g.tableName(*c.New)
+	switch c.Kind {
+	case diff.FieldRenamed:
+		return []string{fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", table, g.column(c.Old, c.OldField.Name), g.column(c.New, c.NewField.Name))}, nil
+	case diff.FieldRemoved:
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, g.column(c.Old, c.OldField.Name))}, nil
+	case diff.FieldAdded:
+		column := g.column(c.New, c.NewField.Name)
+		statements := []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, column, g.toSQLType(*c.NewField))}
+		statements = append(statements, g.backfill(table, column, *c.NewField)...)
+		if c.NewField.Required {
+			statements = append(statements, g.setNotNull(table, column, c.NewField.Default != "")...)
+		}
+		return statements, nil
+	case diff.TypeChanged:
+		column := g.column(c.New, c.NewField.Name)
+		sqlType := g.toSQLType(*c.NewField)
+		if g.toSQLType(*c.OldField) == sqlType {
+			return []string{fmt.Sprintf("-- %s is %s either way; no DDL needed.", column, sqlType)}, nil
+		}
+		switch g.dialectName {
+		case config.DialectPostgres:
+			return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", table, column, sqlType, column, sqlType)}, nil
+		case config.DialectDelta, config.DialectIceberg:
+			return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", table, column, sqlType)}, nil
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DATA TYPE %s;", table, column, sqlType)}, nil
+	case diff.RequiredChanged:
+		column := g.column(c.New, c.NewField.Name)
+		if c.NewField.Required {
+			return append(g.backfill(table, column, *c.NewField), g.setNotNull(table, column, c.NewField.Default != "")...), nil
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", table, column)}, nil
+	}
+	return nil, fmt.Errorf("unknown change %s", c.Kind)
+}
+
+// backfill returns the statement that fills a column with the default of
+// its field where it is null, if the field has one.
+func (g *Generator) backfill(table, column string, f schema.Field) []string {
+	if f.Default == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s IS NULL;", table, column, sqlLiteral(f), column)}
+}
+
+// sqlLiteral returns the default of f as a SQL literal.
+func sqlLiteral(f schema.Field) string {
+	return literal(f, f.Default)
+}
+
+// literal returns value, a value of f written as text, as a SQL literal.
+func literal(f schema.Field, value string) string {
+	switch f.DefaultKind() {
+	case "integer", "decimal":
+		return value
+	case "boolean":
+		if b, _ := strconv.ParseBool(value); b {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// setNotNull returns the statements that make a column required. Unless
+// its rows were backfilled, they are commented out so that a backfill can
+// run first.
+func (g *Generator) setNotNull(table, column string, backfilled bool) []string {
+	switch g.dialectName {
+	case config.DialectBigQuery:
+		return []string{fmt.Sprintf("-- BigQuery cannot make %s required; check it with a dbt not_null test.", column)}
+	case config.DialectIceberg:
+		return []string{fmt.Sprintf("-- Iceberg cannot make %s required; check it with a dbt not_null test.", column)}
+	}
+	set := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, column)
+	if backfilled {
+		return []string{set}
+	}
+	return []string{fmt.Sprintf("-- Backfill %s, then:", column), "-- " + set}
+}
+
+// column returns the column of the named top-level field of s.
+func (g *Generator) column(s *schema.Schema, name string) string {
+	for _, f := range g.fields(*s) {
+		if f.Name == name {
+			return f.Ident
+		}
+	}
+	return columnName(name)
+}
+
+// createTable returns the CREATE TABLE statement of an added schema, as
+// generate writes it.
+func (g *Generator) createTable(s schema.Schema) ([]string, error) {
+	schemas := []schema.Schema{s}
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	if g.tenant != "" {
+		schemas = g.injectTenant(schemas)
+	}
+	s = datatypes.Flatten(schemas[0])
+	t, err := g.table(s)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, c := range t.Columns {
+		lines = append(lines, "    "+c.Name+" "+c.Definition)
+	}
+	for _, c := range t.Constraints {
+		lines = append(lines, "    "+c)
+	}
+	create := "CREATE TABLE IF NOT EXISTS " + g.tableName(s) + " (\n" + strings.Join(lines, ",\n") + "\n)"
+	for _, c := range t.Clauses {
+		create += "\n" + c
+	}
+	statements := []string{create + ";"}
+	for _, st := range t.Statements {
+		statements = append(statements, st+";")
+	}
+	return statements, nil
+}
@@ -0,0 +1,166 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// auditColumn is a column of an audit table: a column of the audited table,
+// without its constraints.
+type auditColumn struct {
+	Name string
+	Type string
+}
+
+// generateAuditTrail writes the audit table of s, <table>_audit, which keeps
+// a row per change to the rows of its table: the operation, who made it,
+// when, and the values of the row. Triggers fill it in Postgres; otherwise
+// change data capture does, from a stream of the table in Snowflake.
+func (g *Generator) generateAuditTrail(s schema.Schema, path string) error {
+	tmpl := `-- Audit trail of {{.Schema | tableName}}
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+{{header}}
+CREATE TABLE IF NOT EXISTS {{.Table}} (
+    _audit_id {{.Identity}},
+    _audit_operation {{.Varchar}} NOT NULL,
+    _audit_user {{.Varchar}}{{if .Triggers}} NOT NULL{{end}},
+    _audit_at {{.Timestamp}} NOT NULL DEFAULT {{.Now}},
+{{- range .Columns}}
+    {{.Name}} {{.Type}},
+{{- end}}
+    PRIMARY KEY (_audit_id){{if .NotEnforced}} NOT ENFORCED{{end}}
+);
+{{- if .Triggers}}
+
+-- Audit rows are never changed, and only the trigger function adds them, so
+-- no role but the owner may write to the audit table, whatever earlier grants
+-- or default privileges gave it. Grant readers SELECT on the audit table.
+REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON {{.Table}} FROM PUBLIC;
+DO $$
+DECLARE
+    role_name text;
+BEGIN
+    FOR role_name IN
+        SELECT DISTINCT a.grantee::regrole::text
+        FROM pg_class c, aclexplode(c.relacl) a
+        WHERE c.oid = '{{.Table}}'::regclass
+            AND a.grantee NOT IN (0, c.relowner)
+            AND a.privilege_type IN ('INSERT', 'UPDATE', 'DELETE', 'TRUNCATE')
+    LOOP
+        EXECUTE format('REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON {{.Table}} FROM %s', role_name);
+    END LOOP;
+END
+$$;
+
+-- The function runs as its owner, so writers need no privileges on the audit
+-- table. The user is the ehrglot.user setting of the session, which
+-- applications that connect as a shared role set to their signed-in user, or
+-- else the database user the session logged in as.
+CREATE OR REPLACE FUNCTION {{.Function}}() RETURNS TRIGGER SECURITY DEFINER AS $$
+DECLARE
+    r {{.Schema | tableName}}%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO {{.Table}} (_audit_operation, _audit_user{{range .Columns}}, {{.Name}}{{end}})
+    VALUES (TG_OP, COALESCE(NULLIF(current_setting('ehrglot.user', true), ''), session_user){{range .Columns}}, r.{{.Name}}{{end}});
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pin the search_path of the function to this schema, with pg_temp last, so
+-- that no session's temporary tables can stand in for the audit table.
+DO $$
+BEGIN
+    EXECUTE format('ALTER FUNCTION {{.Function}}() SET search_path = %I, pg_temp', current_schema());
+END
+$$;
+
+CREATE OR REPLACE TRIGGER {{.Trigger}}
+    AFTER INSERT OR UPDATE OR DELETE ON {{.Schema | tableName}}
+    FOR EACH ROW EXECUTE FUNCTION {{.Function}}();
+{{- else if eq .Dialect "postgres"}}
+
+-- Change data capture tools such as Debezium append a row per change. They
+-- need the full old row of updates and deletes.
+ALTER TABLE {{.Schema | tableName}} REPLICA IDENTITY FULL;
+{{- else if eq .Dialect "snowflake"}}
+
+-- The stream holds the changes to {{.Schema | tableName}} since it was last read.
+-- Schedule the insert below, in a task, to move them to the audit table.
+-- Streams do not record who made a change, so _audit_user is left null.
+CREATE STREAM IF NOT EXISTS {{.Stream}} ON TABLE {{.Schema | tableName}};
+
+INSERT INTO {{.Table}} (_audit_operation{{range .Columns}}, {{.Name}}{{end}})
+SELECT
+    CASE WHEN METADATA$ISUPDATE THEN 'UPDATE' ELSE METADATA$ACTION END{{range .Columns}},
+    {{.Name}}{{end}}
+FROM {{.Stream}}
+WHERE NOT (METADATA$ISUPDATE AND METADATA$ACTION = 'DELETE');
+{{- else}}
+
+-- Change data capture tools such as Datastream append a row per change.
+{{- end}}
+`
+	funcMap := template.FuncMap{
+		"tableName": g.tableName,
+		"header":    g.header.Func("-- ", s),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	var columns []auditColumn
+	for _, field := range g.fields(s) {
+		columns = append(columns, auditColumn{Name: field.Ident, Type: g.toSQLType(field.Field)})
+	}
+	data := struct {
+		Schema      schema.Schema
+		Table       string
+		Function    string
+		Trigger     string
+		Stream      string
+		Columns     []auditColumn
+		Identity    string
+		Varchar     string
+		Timestamp   string
+		Now         string
+		NotEnforced bool
+		Triggers    bool
+		Dialect     string
+	}{
+		Schema:      s,
+		Table:       naming.Ident("sql", g.sourceName(s)+"_audit"),
+		Function:    naming.Ident("sql", g.sourceName(s)+"_audit_trigger"),
+		Trigger:     naming.Ident("sql", g.sourceName(s)+"_audit"),
+		Stream:      naming.Ident("sql", g.sourceName(s)+"_changes"),
+		Columns:     columns,
+		Identity:    g.dialect.identity,
+		Varchar:     g.dialect.varchar,
+		Timestamp:   g.timestampType,
+		Now:         g.dialect.now,
+		NotEnforced: g.dialectName == config.DialectBigQuery,
+		Triggers:    g.auditTrail == config.AuditTrailTriggers,
+		Dialect:     g.dialectName,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
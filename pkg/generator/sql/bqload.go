@@ -0,0 +1,212 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// BigQueryDir is the directory, within the directory of a namespace, that
+// holds the BigQuery table schemas and load scripts of its resources.
+const BigQueryDir = "bigquery"
+
+// bqField is a field of a BigQuery table schema, as bq load reads it.
+type bqField struct {
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Mode        string    `json:"mode"`
+	Precision   string    `json:"precision,omitempty"`
+	Scale       string    `json:"scale,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Fields      []bqField `json:"fields,omitempty"`
+}
+
+// generateBQLoad writes, for each resource of a namespace, the BigQuery
+// schema of a table that holds its FHIR NDJSON as is, and a script that
+// loads NDJSON into it with bq load. Unlike the DDL, the table keeps the
+// JSON names of fields, nested objects and datatypes as RECORD fields, and
+// lists as REPEATED ones.
+func (g *Generator) generateBQLoad(refs *resolve.Resolver, schemas []schema.Schema, namespace, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create bigquery directory: %w", err)
+	}
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		fields := g.bqFields(refs, refs.Scope(s.Namespace), s.StoredFields(), map[string]bool{s.Namespace + "." + s.GetName(): true})
+		if s.Resource != "" {
+			fields = append([]bqField{{Name: "resourceType", Type: "STRING", Mode: "REQUIRED", Description: "Always " + s.Resource}}, fields...)
+		}
+		var data bytes.Buffer
+		enc := json.NewEncoder(&data)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(fields); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, g.fileName(s)+".json"), data.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+		if err := g.generateLoadScript(s, namespace, filepath.Join(dir, "load_"+g.fileName(s)+".sh")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bqFields returns the BigQuery schema of fields. Types on the path from
+// the table, in seen, are not nested again: a field that refers back to one
+// of them holds JSON, as BigQuery schemas cannot recurse.
+func (g *Generator) bqFields(refs *resolve.Resolver, scope resolve.Scope, fields []schema.Field, seen map[string]bool) []bqField {
+	var out []bqField
+	for _, f := range fields {
+		elem, list := strings.CutPrefix(f.Type, "[]")
+		field := bqField{Name: f.WireName(f.Name), Mode: "NULLABLE", Description: oneLineDescription(f.Description)}
+		switch {
+		case list:
+			field.Mode = "REPEATED"
+		case f.Required:
+			field.Mode = "REQUIRED"
+		}
+		switch target, ok := scope.Lookup(elem); {
+		case len(f.Children) > 0:
+			field.Type = "RECORD"
+			field.Fields = g.bqFields(refs, scope, f.Children, seen)
+		case ok && !seen[target.Namespace+"."+target.GetName()]:
+			key := target.Namespace + "." + target.GetName()
+			seen[key] = true
+			field.Type = "RECORD"
+			field.Fields = g.bqFields(refs, refs.Scope(target.Namespace), datatypes.Flatten(target).StoredFields(), seen)
+			delete(seen, key)
+		case ok:
+			field.Type = "JSON"
+		default:
+			field.Type = g.bqType(elem)
+			if elem == "decimal" && f.Precision > 0 {
+				field.Precision = strconv.Itoa(f.Precision)
+				if f.Scale > 0 {
+					field.Scale = strconv.Itoa(f.Scale)
+				}
+			}
+		}
+		out = append(out, field)
+	}
+	return out
+}
+
+// bqType returns the BigQuery type of the values of primitive fieldType in
+// FHIR JSON. Types that resolve to no schema hold JSON.
+func (g *Generator) bqType(fieldType string) string {
+	switch fieldType {
+	case "integer", "positiveInt", "unsignedInt":
+		return "INTEGER"
+	case "decimal":
+		return "NUMERIC"
+	case "boolean":
+		return "BOOLEAN"
+	case "date":
+		if g.dateType == g.dialect.partialDate {
+			return "STRING"
+		}
+		return "DATE"
+	case "datetime", "dateTime", "instant":
+		return "TIMESTAMP"
+	case "time":
+		return "TIME"
+	case "base64Binary":
+		return "BYTES"
+	}
+	if datatypes.IsPrimitive(fieldType) {
+		return "STRING"
+	}
+	return "JSON"
+}
+
+// generateLoadScript writes a script that loads FHIR NDJSON of s into its
+// table, partitioned and clustered by the fields s names.
+func (g *Generator) generateLoadScript(s schema.Schema, namespace, path string) error {
+	wire := make(map[string]string)
+	for _, f := range s.StoredFields() {
+		wire[f.Name] = f.WireName(f.Name)
+	}
+	var flags []string
+	if s.PartitionBy != "" {
+		flags = append(flags, "--time_partitioning_field="+wire[s.PartitionBy], "--time_partitioning_type=DAY")
+	}
+	if len(s.ClusterBy) > 0 {
+		var cluster []string
+		for _, name := range s.ClusterBy {
+			cluster = append(cluster, wire[name])
+		}
+		flags = append(flags, "--clustering_fields="+strings.Join(cluster, ","))
+	}
+
+	tmpl := `#!/bin/sh
+{{header}}#
+# Loads FHIR NDJSON of {{.Schema.GetName}} into the {{.Table}} table of a BigQuery
+# dataset, creating the table from {{.File}}.json if it does not exist.
+# Fields the schema does not declare, such as extensions, are ignored.
+#
+# Usage: load_{{.File}}.sh SOURCE [DATASET]
+#   SOURCE   local path or gs:// URI of the NDJSON, which may use wildcards
+#   DATASET  dataset to load into (default: {{.Dataset}})
+set -eu
+
+if [ $# -lt 1 ]; then
+  echo "usage: $0 SOURCE [DATASET]" >&2
+  exit 2
+fi
+
+bq load \
+  --source_format=NEWLINE_DELIMITED_JSON \
+  --ignore_unknown_values \
+{{- range .Flags}}
+  {{.}} \
+{{- end}}
+  "${2:-{{.Dataset}}}.{{.Table}}" \
+  "$1" \
+  "$(dirname "$0")/{{.File}}.json"
+`
+	parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("# ", s)})
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	data := struct {
+		Schema  schema.Schema
+		Table   string
+		File    string
+		Dataset string
+		Flags   []string
+	}{
+		Schema:  s,
+		Table:   g.sourceName(s),
+		File:    g.fileName(s),
+		Dataset: naming.Snake.Apply(namespace) + "_raw",
+		Flags:   flags,
+	}
+	if err := parsed.Execute(&out, data); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0755); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// oneLineDescription returns a description on one line, as BigQuery shows
+// column descriptions.
+func oneLineDescription(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
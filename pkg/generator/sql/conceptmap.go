@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// generateConceptMaps writes the concept maps of a namespace as a dbt seed,
+// a row per code a source code translates to, that models join to translate
+// codes.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, namespace string, dir string) error {
+	seed := naming.Snake.Apply(namespace) + "_concept_map"
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create dbt seeds directory: %w", err)
+	}
+
+	records := [][]string{{"concept_map", "source_system", "source_code", "target_system", "target_code", "target_display", "equivalence"}}
+	for _, m := range maps {
+		for _, source := range m.Sources() {
+			for _, t := range source.Translations {
+				records = append(records, []string{m.Name, source.System, source.Code, t.System, t.Code, t.Display, t.Equivalence})
+			}
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, seed+".csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		return err
+	}
+
+	tmpl := `{{header}}
+version: 2
+
+seeds:
+  - name: ` + seed + `
+    description: "Translations of codes by the concept maps {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Name}}{{end}}"
+    config:
+      column_types:
+        concept_map: varchar
+        source_system: varchar
+        source_code: varchar
+        target_system: varchar
+        target_code: varchar
+        target_display: varchar
+        equivalence: varchar
+    columns:
+      - name: concept_map
+        description: "Concept map translating the code"
+        tests:
+          - not_null
+      - name: source_system
+        description: "Code system of the source code"
+        tests:
+          - not_null
+      - name: source_code
+        description: "Code as recorded at the source"
+        tests:
+          - not_null
+      - name: target_system
+        description: "Code system the code translates to"
+      - name: target_code
+        description: "Code the source code translates to"
+        tests:
+          - not_null
+      - name: target_display
+        description: "Display of the target code"
+      - name: equivalence
+        description: "How equivalent the target code is to the source code"
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("# ")})
+	if err != nil {
+		return err
+	}
+
+	props, err := os.Create(filepath.Join(dir, seed+".yml"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer props.Close()
+
+	return tmpl_parsed.Execute(props, maps)
+}
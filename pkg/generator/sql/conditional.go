@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// missingConditions returns SQL conditions that each select the rows of the
+// table of s missing a required_if field although its condition holds. As
+// in the generated models, a != condition also holds when its column is
+// null.
+func (g *Generator) missingConditions(s schema.Schema) ([]string, error) {
+	conditional, err := s.ConditionalFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	columns := make(map[string]string)
+	for _, f := range g.fields(s) {
+		columns[f.Name] = f.Ident
+	}
+	var conditions []string
+	for _, c := range conditional {
+		on := columns[c.Condition.Field]
+		var when string
+		switch c.Condition.Op {
+		case "":
+			when = on + " IS NOT NULL"
+		case "==":
+			when = on + " = " + literal(c.On, c.Condition.Value)
+		default:
+			when = on + " IS DISTINCT FROM " + literal(c.On, c.Condition.Value)
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s AND %s IS NULL)", when, columns[c.Field.Name]))
+	}
+	return conditions, nil
+}
+
+// generateRequiredIf writes a dbt singular test that fails on the rows of
+// the staging model of s missing required_if fields, if s has any.
+func (g *Generator) generateRequiredIf(s schema.Schema, dir string) error {
+	conditions, err := g.missingConditions(s)
+	if err != nil || len(conditions) == 0 {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create dbt tests directory: %w", err)
+	}
+
+	tmpl := `{#
+  Rows of stg_{{.File}} missing fields that a condition on another field
+  requires.
+
+{{header}}#}
+
+SELECT *
+FROM {{ "{{" }} ref('stg_{{.File}}') {{ "}}" }}
+WHERE {{join .Conditions "\n   OR "}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{
+		"join":   strings.Join,
+		"header": g.header.Func("  ", s),
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, "required_if_"+g.fileName(s)+".sql"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		File       string
+		Conditions []string
+	}{
+		File:       g.fileName(s),
+		Conditions: conditions,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/crosswalk"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateCrosswalk writes the crosswalk of the fields of a namespace as a dbt
+// seed, one row per local code, with properties that keep codes as text. The
+// seed is named after the namespace, as dbt seed names are project-wide.
+func (g *Generator) generateCrosswalk(fields []crosswalk.Field, namespace string, dir string) error {
+	seed := naming.Snake.Apply(namespace) + "_concept_crosswalk"
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create dbt seeds directory: %w", err)
+	}
+
+	records := [][]string{{"source_table", "source_column", "local_code", "concept_id", "vocabulary_id", "concept_code", "concept_name", "i2b2_path"}}
+	var sources []schema.Schema
+	for _, f := range fields {
+		column := columnName(f.Field.Name)
+		for _, field := range g.fields(f.Schema) {
+			if field.Name == f.Field.Name {
+				column = field.Ident
+			}
+		}
+		for _, c := range f.Concepts {
+			records = append(records, []string{g.sourceName(f.Schema), column, c.LocalCode, strconv.FormatInt(c.ConceptID, 10), c.VocabularyID, c.ConceptCode, c.ConceptName, c.I2B2Path})
+		}
+		if len(sources) == 0 || sources[len(sources)-1].GetName() != f.Schema.GetName() {
+			sources = append(sources, f.Schema)
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, seed+".csv"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	if err := csv.NewWriter(f).WriteAll(records); err != nil {
+		return err
+	}
+
+	tmpl := `{{header}}
+version: 2
+
+seeds:
+  - name: ` + seed + `
+    description: "Standard concepts of the local codes of {{range $i, $s := .}}{{if $i}}, {{end}}{{$s | sourceName}}{{end}}"
+    config:
+      column_types:
+        source_table: varchar
+        source_column: varchar
+        local_code: varchar
+        concept_id: bigint
+        vocabulary_id: varchar
+        concept_code: varchar
+        concept_name: varchar
+        i2b2_path: varchar
+    columns:
+      - name: source_table
+        description: "Table of the coded column"
+      - name: source_column
+        description: "Coded column"
+      - name: local_code
+        description: "Code as recorded at the source"
+        tests:
+          - not_null
+      - name: concept_id
+        description: "OMOP concept_id, or 0 when the code has none"
+      - name: vocabulary_id
+        description: "OMOP vocabulary of the concept"
+      - name: concept_code
+        description: "Code of the concept in its vocabulary"
+      - name: concept_name
+        description: "Name of the concept"
+      - name: i2b2_path
+        description: "Concept path of the code in the i2b2 ontology"
+`
+	funcMap := template.FuncMap{
+		"sourceName": g.sourceName,
+		"header":     g.header.Func("# ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	props, err := os.Create(filepath.Join(dir, "properties.yml"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer props.Close()
+
+	return tmpl_parsed.Execute(props, sources)
+}
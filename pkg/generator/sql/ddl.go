@@ -0,0 +1,497 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// dialect holds the column types and DDL syntax of one database.
+type dialect struct {
+	varchar     string
+	partialDate string
+	integer     string
+	decimal     string
+	boolean     string
+	bytes       string
+	json        string
+	timestamp   string
+	timestampTZ string
+	now         string
+	// identity is the type of a generated surrogate key.
+	identity string
+	// comments writes descriptions as COMMENT ON statements rather than
+	// column and table options.
+	comments bool
+	// yearsSince and daysBetween format the derived field functions of
+	// dates: the whole years from %[1]s to today, and the days from %[1]s
+	// to %[2]s.
+	yearsSince  string
+	daysBetween string
+	// stripChars, dateText, and soundex format the parts of patient
+	// matching keys: %[1]s without the characters that match the regular
+	// expression %[2]s, date %[1]s as YYYY-MM-DD, and the American Soundex
+	// code of %[1]s, a name of letters A to Z. The soundex of the Postgres
+	// fuzzystrmatch extension lets H and W separate consonants, so they are
+	// dropped after the first letter.
+	stripChars string
+	dateText   string
+	soundex    string
+	// format is the table format of the lakehouse dialects, which write
+	// Spark SQL: tables are created USING it, and describe their columns
+	// inline.
+	format string
+	// noDefaults drops the defaults of columns, which the table format
+	// cannot hold; loads set those values themselves.
+	noDefaults bool
+}
+
+var dialects = map[string]dialect{
+	config.DialectPostgres: {
+		varchar:     "VARCHAR(255)",
+		partialDate: "VARCHAR(10)",
+		integer:     "INTEGER",
+		decimal:     "DECIMAL(18, 6)",
+		boolean:     "BOOLEAN",
+		bytes:       "BYTEA",
+		json:        "JSONB",
+		timestamp:   "TIMESTAMP",
+		timestampTZ: "TIMESTAMP WITH TIME ZONE",
+		now:         "CURRENT_TIMESTAMP",
+		identity:    "BIGINT GENERATED ALWAYS AS IDENTITY",
+		comments:    true,
+		yearsSince:  "CAST(EXTRACT(YEAR FROM AGE(CURRENT_DATE, %[1]s)) AS INTEGER)",
+		daysBetween: "(%[2]s - %[1]s)",
+		stripChars:  "REGEXP_REPLACE(%[1]s, '%[2]s', '', 'g')",
+		dateText:    "TO_CHAR(%[1]s, 'YYYY-MM-DD')",
+		soundex:     "SOUNDEX(LEFT(%[1]s, 1) || REGEXP_REPLACE(SUBSTR(%[1]s, 2), '[HW]', '', 'g'))",
+	},
+	config.DialectBigQuery: {
+		varchar:     "STRING",
+		partialDate: "STRING(10)",
+		integer:     "INT64",
+		decimal:     "NUMERIC(18, 6)",
+		boolean:     "BOOL",
+		bytes:       "BYTES",
+		json:        "JSON",
+		timestamp:   "TIMESTAMP",
+		timestampTZ: "TIMESTAMP",
+		now:         "CURRENT_TIMESTAMP()",
+		identity:    "STRING DEFAULT GENERATE_UUID()",
+		yearsSince:  "DATE_DIFF(CURRENT_DATE(), %[1]s, YEAR) - IF(FORMAT_DATE('%%m%%d', CURRENT_DATE()) < FORMAT_DATE('%%m%%d', %[1]s), 1, 0)",
+		daysBetween: "DATE_DIFF(%[2]s, %[1]s, DAY)",
+		stripChars:  "REGEXP_REPLACE(%[1]s, r'%[2]s', '')",
+		dateText:    "FORMAT_DATE('%%Y-%%m-%%d', %[1]s)",
+		soundex:     "SOUNDEX(%[1]s)",
+	},
+	config.DialectSnowflake: {
+		varchar:     "VARCHAR(255)",
+		partialDate: "VARCHAR(10)",
+		integer:     "INTEGER",
+		decimal:     "DECIMAL(18, 6)",
+		boolean:     "BOOLEAN",
+		bytes:       "BINARY",
+		json:        "VARIANT",
+		timestamp:   "TIMESTAMP_NTZ",
+		timestampTZ: "TIMESTAMP_TZ",
+		now:         "CURRENT_TIMESTAMP()",
+		identity:    "BIGINT IDENTITY",
+		comments:    true,
+		yearsSince:  "DATEDIFF(year, %[1]s, CURRENT_DATE()) - IFF(TO_CHAR(CURRENT_DATE(), 'MMDD') < TO_CHAR(%[1]s, 'MMDD'), 1, 0)",
+		daysBetween: "DATEDIFF(day, %[1]s, %[2]s)",
+		stripChars:  "REGEXP_REPLACE(%[1]s, '%[2]s', '')",
+		dateText:    "TO_CHAR(%[1]s, 'YYYY-MM-DD')",
+		soundex:     "SOUNDEX(%[1]s)",
+	},
+	config.DialectDelta: {
+		varchar:     "STRING",
+		partialDate: "STRING",
+		integer:     "INT",
+		decimal:     "DECIMAL(18, 6)",
+		boolean:     "BOOLEAN",
+		bytes:       "BINARY",
+		json:        "STRING",
+		timestamp:   "TIMESTAMP",
+		timestampTZ: "TIMESTAMP",
+		now:         "CURRENT_TIMESTAMP()",
+		identity:    "BIGINT GENERATED ALWAYS AS IDENTITY",
+		yearsSince:  "CAST(FLOOR(MONTHS_BETWEEN(CURRENT_DATE(), %[1]s) / 12) AS INT)",
+		daysBetween: "DATEDIFF(%[2]s, %[1]s)",
+		stripChars:  "REGEXP_REPLACE(%[1]s, '%[2]s', '')",
+		dateText:    "DATE_FORMAT(%[1]s, 'yyyy-MM-dd')",
+		soundex:     "SOUNDEX(%[1]s)",
+		format:      "DELTA",
+	},
+	config.DialectIceberg: {
+		varchar:     "STRING",
+		partialDate: "STRING",
+		integer:     "INT",
+		decimal:     "DECIMAL(18, 6)",
+		boolean:     "BOOLEAN",
+		bytes:       "BINARY",
+		json:        "STRING",
+		timestamp:   "TIMESTAMP",
+		timestampTZ: "TIMESTAMP",
+		now:         "CURRENT_TIMESTAMP()",
+		// Iceberg has no identity columns; loads key versions with uuid().
+		identity:    "STRING",
+		yearsSince:  "CAST(FLOOR(MONTHS_BETWEEN(CURRENT_DATE(), %[1]s) / 12) AS INT)",
+		daysBetween: "DATEDIFF(%[2]s, %[1]s)",
+		stripChars:  "REGEXP_REPLACE(%[1]s, '%[2]s', '')",
+		dateText:    "DATE_FORMAT(%[1]s, 'yyyy-MM-dd')",
+		soundex:     "SOUNDEX(%[1]s)",
+		format:      "ICEBERG",
+		noDefaults:  true,
+	},
+}
+
+// bigQueryMaxClusterColumns is the most clustering columns BigQuery accepts.
+const bigQueryMaxClusterColumns = 4
+
+// deltaMaxClusterColumns is the most clustering columns Delta Lake accepts.
+const deltaMaxClusterColumns = 4
+
+// table is the layout of a CREATE TABLE statement.
+type table struct {
+	Columns []historyColumn
+	// Constraints follow the columns inside the parentheses.
+	Constraints []string
+	// Clauses follow the closing parenthesis, one per line.
+	Clauses []string
+	// Statements follow the CREATE TABLE statement.
+	Statements []string
+	// Comments writes column descriptions as COMMENT ON statements.
+	Comments bool
+	// Partition is the column BigQuery partitions the table by, and Cluster
+	// the expressions BigQuery or Snowflake cluster it by, which Terraform
+	// resources declare apart from the clauses.
+	Partition *column
+	Cluster   []string
+}
+
+// columnDescription returns the description of the column of f, noting the
+// encryption mode of encrypted fields so that catalogs show which columns
+// hold ciphertext.
+func columnDescription(f schema.Field) string {
+	if f.Encryption == "" {
+		return f.Description
+	}
+	note := "Encrypted (" + f.Encryption + ")."
+	if f.Description == "" {
+		return note
+	}
+	return strings.TrimSuffix(f.Description, ".") + ". " + note
+}
+
+// column is a table column with the kind of value it holds, which decides
+// how it may partition the table.
+type column struct {
+	historyColumn
+	kind string
+}
+
+// Kinds of partitioning columns.
+const (
+	kindDate      = "date"
+	kindTimestamp = "timestamp"
+	kindInteger   = "integer"
+	kindOther     = ""
+)
+
+// table lays out the CREATE TABLE statement of s: its columns, history
+// columns, keys, and partitioning and clustering in the generator's dialect.
+func (g *Generator) table(s schema.Schema) (table, error) {
+	history, filter, err := g.history(s)
+	if err != nil {
+		return table{}, err
+	}
+	if err := s.CheckKeys(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if _, err := s.EncryptedFields(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if err := s.CheckPurposes(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if err := s.CheckAliases(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if err := s.CheckDefaults(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if _, field, err := s.RetentionPeriod(); err != nil {
+		return table{}, fmt.Errorf("%s: %w", s.GetName(), err)
+	} else if field != nil && g.kind(*field) == kindOther {
+		// Partial dates are text, which purges cannot compare to a cutoff.
+		return table{}, fmt.Errorf("%s: retention field %s is a partial date", s.GetName(), field.Name)
+	}
+	fields := g.fields(s)
+	var columns []column
+	for _, f := range fields {
+		def := g.toSQLType(f.Field)
+		if f.Required {
+			def += " NOT NULL"
+		}
+		if f.Default != "" {
+			def = g.withDefault(def, sqlLiteral(f.Field))
+		}
+		if f.Name == audit.IngestedAt {
+			// Loads stamp _ingested_at unless they set it themselves.
+			def = g.withDefault(def, g.dialect.now)
+		}
+		columns = append(columns, column{historyColumn{Name: f.Ident, Definition: def, Description: columnDescription(f.Field)}, g.kind(f.Field)})
+	}
+	for _, h := range history {
+		kind := kindOther
+		if strings.HasPrefix(h.Definition, g.timestampType) {
+			kind = kindTimestamp
+		}
+		columns = append(columns, column{h, kind})
+	}
+	// find looks a column up by field name, or by name for history columns.
+	find := func(hint, name string) (column, error) {
+		for i, c := range columns {
+			if i < len(fields) && fields[i].Name == name || i >= len(fields) && c.Name == name {
+				return c, nil
+			}
+		}
+		return column{}, fmt.Errorf("%s: %s %s is not a field", s.GetName(), hint, name)
+	}
+
+	t := table{Comments: g.dialect.comments}
+	var partition *column
+	if s.PartitionBy != "" {
+		c, err := find("partition_by", s.PartitionBy)
+		if err != nil {
+			return table{}, err
+		}
+		partition = &c
+	}
+	var cluster []column
+	for _, name := range s.ClusterBy {
+		c, err := find("cluster_by", name)
+		if err != nil {
+			return table{}, err
+		}
+		cluster = append(cluster, c)
+	}
+
+	keys := func(section string, indexes []schema.Index) ([][]column, error) {
+		out := make([][]column, len(indexes))
+		for i, index := range indexes {
+			for _, name := range index.Fields {
+				c, err := find(section, name)
+				if err != nil {
+					return nil, err
+				}
+				out[i] = append(out[i], c)
+			}
+		}
+		return out, nil
+	}
+	unique, err := keys("unique", s.Unique)
+	if err != nil {
+		return table{}, err
+	}
+	indexes, err := keys("indexes", s.Indexes)
+	if err != nil {
+		return table{}, err
+	}
+
+	var key string
+	if s.History == schema.HistorySCD2 {
+		key = g.surrogateKey(s)
+	}
+	if fk := g.foreignKeyConstraint(s); fk != "" {
+		t.Constraints = append(t.Constraints, fk)
+	}
+	switch g.dialectName {
+	case config.DialectBigQuery:
+		// BigQuery has neither unique constraints nor indexes; the dbt
+		// staging models test single-field unique keys instead.
+		if key != "" {
+			t.Constraints = append(t.Constraints, fmt.Sprintf("PRIMARY KEY (%s) NOT ENFORCED", key))
+		}
+		if partition != nil {
+			switch partition.kind {
+			case kindDate:
+				t.Clauses = append(t.Clauses, "PARTITION BY "+partition.Name)
+			case kindTimestamp:
+				t.Clauses = append(t.Clauses, "PARTITION BY DATE("+partition.Name+")")
+			default:
+				return table{}, fmt.Errorf("%s: partition_by %s must be a date or timestamp field for bigquery", s.GetName(), s.PartitionBy)
+			}
+		}
+		if len(cluster) > bigQueryMaxClusterColumns {
+			return table{}, fmt.Errorf("%s: bigquery clusters by at most %d fields, got %d", s.GetName(), bigQueryMaxClusterColumns, len(cluster))
+		}
+		if len(cluster) > 0 {
+			t.Clauses = append(t.Clauses, "CLUSTER BY "+names(cluster, nil))
+		}
+		t.Partition = partition
+		for _, c := range cluster {
+			t.Cluster = append(t.Cluster, c.Name)
+		}
+		t.Clauses = append(t.Clauses, "OPTIONS(description="+bigQueryString(s.Description)+")")
+		for i := range columns {
+			columns[i].Definition += " OPTIONS(description=" + bigQueryString(columns[i].Description) + ")"
+		}
+	case config.DialectSnowflake:
+		if key != "" {
+			withPrimaryKey(columns, key)
+		}
+		// Snowflake records but does not enforce unique keys. Tables with
+		// history hold several rows per key, so they declare none.
+		if s.History == "" {
+			for i, cols := range unique {
+				t.Constraints = append(t.Constraints, fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", g.indexName(s, s.Unique[i], cols, "key"), names(cols, nil)))
+			}
+		}
+		// Snowflake has no partitions; the partition key leads the clustering
+		// key instead.
+		if partition != nil {
+			cluster = append([]column{*partition}, cluster...)
+		}
+		for _, c := range cluster {
+			if c.kind == kindTimestamp {
+				t.Cluster = append(t.Cluster, "TO_DATE("+c.Name+")")
+			} else {
+				t.Cluster = append(t.Cluster, c.Name)
+			}
+		}
+		if len(t.Cluster) > 0 {
+			t.Clauses = append(t.Clauses, "CLUSTER BY ("+strings.Join(t.Cluster, ", ")+")")
+		}
+	case config.DialectDelta, config.DialectIceberg:
+		// Lakehouse tables have neither keys nor indexes; the dbt staging
+		// models test single-field unique keys instead.
+		if columns, err = g.lakehouseLayout(s, &t, columns, partition, cluster); err != nil {
+			return table{}, err
+		}
+	default:
+		if partition != nil {
+			// The primary key of a partitioned table must include its
+			// partition key.
+			if key != "" {
+				t.Constraints = append(t.Constraints, fmt.Sprintf("PRIMARY KEY (%s, %s)", key, partition.Name))
+			}
+			if partition.kind == kindOther {
+				t.Clauses = append(t.Clauses, "PARTITION BY LIST ("+partition.Name+")")
+			} else {
+				t.Clauses = append(t.Clauses, "PARTITION BY RANGE ("+partition.Name+")")
+			}
+			// A partitioned table without partitions rejects every row, so
+			// a default partition takes rows until real ones are attached.
+			defaultPartition := naming.Ident("sql", g.sourceName(s)+"_default")
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s DEFAULT", defaultPartition, g.tableName(s)))
+		} else if key != "" {
+			withPrimaryKey(columns, key)
+		}
+		for i, cols := range unique {
+			if partition != nil && !hasColumn(cols, partition.Name) {
+				// Unique keys of a partitioned table must include its
+				// partition key as well.
+				cols = append(cols, *partition)
+			}
+			name := g.indexName(s, s.Unique[i], cols, "key")
+			if s.History == "" {
+				t.Constraints = append(t.Constraints, fmt.Sprintf("CONSTRAINT %s UNIQUE (%s)", name, names(cols, nil)))
+				continue
+			}
+			// Tables with history hold several rows per key, only one of
+			// them current.
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (%s) WHERE %s", name, g.tableName(s), names(cols, nil), filter))
+		}
+		if len(cluster) > 0 {
+			index := naming.Ident("sql", g.sourceName(s)+"_cluster_idx")
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", index, g.tableName(s), names(cluster, nil)))
+		}
+		for i, cols := range indexes {
+			t.Statements = append(t.Statements, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", g.indexName(s, s.Indexes[i], cols, "idx"), g.tableName(s), names(cols, nil)))
+		}
+	}
+	for _, c := range columns {
+		t.Columns = append(t.Columns, c.historyColumn)
+	}
+	return t, nil
+}
+
+// withDefault returns column definition def with a default value, unless
+// the dialect cannot hold one.
+func (g *Generator) withDefault(def, value string) string {
+	if g.dialect.noDefaults {
+		return def
+	}
+	return def + " DEFAULT " + value
+}
+
+// kind returns the kind of value the column of f holds.
+func (g *Generator) kind(f schema.Field) string {
+	switch f.Type {
+	case "date":
+		if g.dateType == g.dialect.partialDate {
+			return kindOther
+		}
+		return kindDate
+	case "datetime", "instant":
+		return kindTimestamp
+	case "integer", "positiveInt", "unsignedInt":
+		return kindInteger
+	}
+	return kindOther
+}
+
+// indexName returns the name of an index or unique key: its declared name,
+// or else the table and column names followed by suffix.
+func (g *Generator) indexName(s schema.Schema, index schema.Index, cols []column, suffix string) string {
+	if index.Name != "" {
+		return naming.Ident("sql", index.Name)
+	}
+	parts := []string{g.sourceName(s)}
+	for _, c := range cols {
+		parts = append(parts, strings.Trim(c.Name, `"`))
+	}
+	return naming.Ident("sql", strings.Join(append(parts, suffix), "_"))
+}
+
+// hasColumn reports whether columns include the column named name.
+func hasColumn(columns []column, name string) bool {
+	for _, c := range columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// withPrimaryKey marks the column named key as the primary key.
+func withPrimaryKey(columns []column, key string) {
+	for i := range columns {
+		if columns[i].Name == key {
+			columns[i].Definition += " PRIMARY KEY"
+		}
+	}
+}
+
+// names joins the names of columns, each passed through expr when set.
+func names(columns []column, expr func(column) string) string {
+	out := make([]string, len(columns))
+	for i, c := range columns {
+		out[i] = c.Name
+		if expr != nil {
+			out[i] = expr(c)
+		}
+	}
+	return strings.Join(out, ", ")
+}
+
+// bigQueryString quotes s as a BigQuery string literal.
+func bigQueryString(s string) string {
+	return strconv.Quote(strings.ReplaceAll(s, "\n", " "))
+}
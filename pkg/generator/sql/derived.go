@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// derivedColumn is a column that the staging model of a schema computes
+// from the columns of its source table.
+type derivedColumn struct {
+	Ident string
+	Expr  string
+}
+
+// derivedColumns returns the columns of the derived fields of s.
+func (g *Generator) derivedColumns(s schema.Schema) ([]derivedColumn, error) {
+	derived, err := s.DerivedFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	idents := make(map[string]naming.Field)
+	for _, f := range g.allFields(s) {
+		idents[f.Name] = f
+	}
+	var columns []derivedColumn
+	for _, d := range derived {
+		columns = append(columns, derivedColumn{Ident: idents[d.Field.Name].Ident, Expr: g.sqlExpr(d.Expr, idents)})
+	}
+	return columns, nil
+}
+
+// sqlExpr returns e as a SQL expression over the columns of a row, which is
+// null when a column it uses is. Partial dates, kept as text, are dates only
+// when known to the day; integers are divided as decimals.
+func (g *Generator) sqlExpr(e schema.Expr, idents map[string]naming.Field) string {
+	arg := func(i int) string {
+		a := g.sqlExpr(e.Args[i], idents)
+		if isOperator(e.Args[i].Op) && (isOperator(e.Op) || e.Op == "neg") {
+			return "(" + a + ")"
+		}
+		return a
+	}
+	switch e.Op {
+	case "number":
+		return e.Value
+	case "text":
+		return "'" + strings.ReplaceAll(e.Value, "'", "''") + "'"
+	case "field":
+		f := idents[e.Value]
+		switch {
+		case e.Kind != "date":
+			return f.Ident
+		case f.Type == "date" && g.kind(f.Field) == kindOther:
+			return "CAST(CASE WHEN LENGTH(" + f.Ident + ") = 10 THEN " + f.Ident + " END AS DATE)"
+		}
+		return "CAST(" + f.Ident + " AS DATE)"
+	case "neg":
+		return "-" + arg(0)
+	case "+", "-", "*", "/":
+		left := arg(0)
+		if e.Op == "/" && e.Args[0].Kind == "integer" && e.Args[1].Kind == "integer" {
+			left = "CAST(" + g.sqlExpr(e.Args[0], idents) + " AS " + g.dialect.decimal + ")"
+		}
+		return left + " " + e.Op + " " + arg(1)
+	case "years_since":
+		return fmt.Sprintf(g.dialect.yearsSince, arg(0))
+	case "days_between":
+		return fmt.Sprintf(g.dialect.daysBetween, arg(0), arg(1))
+	}
+	var args []string
+	for i := range e.Args {
+		args = append(args, arg(i))
+	}
+	return strings.Join(args, " || ")
+}
+
+// isOperator reports whether op is an arithmetic operator, whose operands
+// are parenthesized when they are operations too.
+func isOperator(op string) bool {
+	return op == "+" || op == "-" || op == "*" || op == "/"
+}
@@ -0,0 +1,228 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// listDelimiter separates the values of lists of primitives stored as text
+// by the delimited flatten strategy.
+const listDelimiter = "|"
+
+// foreignKey links a child table split off by the tables flatten strategy
+// to the row of its parent table it belongs to.
+type foreignKey struct {
+	// Column is the field of the child table that holds the parent key.
+	Column string
+	Parent schema.Schema
+	// Key is the field of the parent table the column refers to.
+	Key string
+}
+
+// flattenStrategy returns the flatten strategy of s: its own, or else the
+// configured one.
+func (g *Generator) flattenStrategy(s schema.Schema) string {
+	if s.Flatten != "" {
+		return s.Flatten
+	}
+	if g.flatten != "" {
+		return g.flatten
+	}
+	return schema.FlattenJSON
+}
+
+// unnest returns the tables s is stored in by its flatten strategy: s
+// itself, with its nested objects and lists laid out as the strategy says,
+// followed by the child tables the tables strategy splits off, whose
+// foreign keys it records.
+func (g *Generator) unnest(s schema.Schema) ([]schema.Schema, error) {
+	switch strategy := g.flattenStrategy(s); strategy {
+	case schema.FlattenJSON:
+		return []schema.Schema{s}, nil
+	case schema.FlattenDelimited:
+		s.Fields = g.widen(s.Fields, "", true)
+		return []schema.Schema{s}, nil
+	case schema.FlattenTables:
+		return g.splitTables(s)
+	default:
+		return nil, fmt.Errorf("%s: unknown flatten %q (want %s, %s, or %s)", s.GetName(), strategy, schema.FlattenJSON, schema.FlattenDelimited, schema.FlattenTables)
+	}
+}
+
+// widen returns fields with the fields of nested objects in place of the
+// objects, named after their path, and lists of primitives as delimited
+// text. Lists of objects stay JSON.
+func (g *Generator) widen(fields []schema.Field, prefix string, required bool) []schema.Field {
+	var wide []schema.Field
+	for _, f := range fields {
+		_, list := schema.ElementType(f.Type)
+		switch {
+		case len(f.Children) > 0 && !list:
+			wide = append(wide, g.widen(f.Children, prefix+f.Name+"_", required && f.Required)...)
+			continue
+		case list && g.primitive(f.Type):
+			f = delimited(f)
+		}
+		f.Name = prefix + f.Name
+		f.Required = f.Required && required
+		wide = append(wide, f)
+	}
+	return wide
+}
+
+// delimited returns list field f stored as text that joins its values with
+// listDelimiter. Constraints on single values no longer apply.
+func delimited(f schema.Field) schema.Field {
+	note := fmt.Sprintf("Values separated by %s.", listDelimiter)
+	if f.Description != "" {
+		note = strings.TrimSuffix(f.Description, ".") + ". " + note
+	}
+	return schema.Field{
+		Name:        f.Name,
+		UID:         f.UID,
+		Type:        "string",
+		Required:    f.Required,
+		RequiredIf:  f.RequiredIf,
+		Description: note,
+		PIILevel:    f.PIILevel,
+		PIICategory: f.PIICategory,
+		Purposes:    f.Purposes,
+		Encryption:  f.Encryption,
+		CodeName:    f.CodeName,
+		DBColumn:    f.DBColumn,
+	}
+}
+
+// splitTables returns s without its nested objects and lists, followed by a
+// child table for each of them. A child table holds the key of its parent
+// row, the position of list elements, and the fields of the object or
+// element, or a value column for lists of primitives and types. Objects and
+// lists nested in a child table stay JSON.
+func (g *Generator) splitTables(s schema.Schema) ([]schema.Schema, error) {
+	// Child rows are keyed by the id of their parent, or else its first
+	// required field, as history snapshots are.
+	var key *schema.Field
+	for i, f := range s.Fields {
+		if f.Name == "id" && f.Derived == "" {
+			key = &s.Fields[i]
+			break
+		}
+	}
+	for i := 0; key == nil && i < len(s.Fields); i++ {
+		_, list := schema.ElementType(s.Fields[i].Type)
+		if s.Fields[i].Required && s.Fields[i].Derived == "" && len(s.Fields[i].Children) == 0 && !list {
+			key = &s.Fields[i]
+		}
+	}
+
+	parent := s
+	parent.Fields = nil
+	var children []schema.Schema
+	var columns []string
+	for _, f := range s.Fields {
+		elem, list := schema.ElementType(f.Type)
+		if len(f.Children) == 0 && !list {
+			parent.Fields = append(parent.Fields, f)
+			continue
+		}
+		if key == nil {
+			return nil, fmt.Errorf("%s: flatten %s needs an id or required field to key the table of %s by", s.GetName(), schema.FlattenTables, f.Name)
+		}
+		if s.History != "" {
+			return nil, fmt.Errorf("%s: flatten %s cannot key the table of %s by a table with history", s.GetName(), schema.FlattenTables, f.Name)
+		}
+		if s.PartitionBy != "" && g.dialectName == config.DialectPostgres {
+			return nil, fmt.Errorf("%s: flatten %s cannot key the table of %s by a partitioned postgres table", s.GetName(), schema.FlattenTables, f.Name)
+		}
+
+		column := naming.Snake.Apply(s.GetName()) + "_" + key.Name
+		child := schema.Schema{
+			Name:        s.GetName() + "_" + f.Name,
+			Description: f.Description,
+			Owner:       s.Owner,
+			Steward:     s.Steward,
+			Tags:        s.Tags,
+			Purposes:    s.Purposes,
+			Namespace:   s.Namespace,
+			SourceFile:  s.SourceFile,
+			Fields: []schema.Field{
+				{Name: column, Type: key.Type, Required: true, PIILevel: key.PIILevel, PIICategory: key.PIICategory, Description: "Key of the " + s.GetName() + " this belongs to"},
+			},
+			Unique: []schema.Index{{Fields: []string{column}}},
+		}
+		if child.Description == "" {
+			child.Description = f.Name + " of " + s.GetName()
+		}
+		if list {
+			child.Fields = append(child.Fields, schema.Field{Name: "ordinal", Type: "integer", Required: true, Description: "Position of the element in the list, from 0"})
+			child.Unique[0].Fields = append(child.Unique[0].Fields, "ordinal")
+		}
+		if len(f.Children) > 0 {
+			child.Fields = append(child.Fields, f.Children...)
+		} else {
+			child.Fields = append(child.Fields, schema.Field{Name: "value", Type: elem, PIILevel: f.PIILevel, PIICategory: f.PIICategory, Purposes: f.Purposes, Encryption: f.Encryption, Description: f.Description})
+		}
+		children = append(children, child)
+		columns = append(columns, column)
+	}
+	if len(children) == 0 {
+		return []schema.Schema{s}, nil
+	}
+
+	// Foreign keys refer to a unique key of the parent.
+	if !hasUnique(parent.Unique, key.Name) {
+		parent.Unique = append(append([]schema.Index(nil), parent.Unique...), schema.Index{Fields: []string{key.Name}})
+	}
+	for i, child := range children {
+		g.foreignKeys[child.Namespace+"/"+child.Name] = foreignKey{Column: columns[i], Parent: parent, Key: key.Name}
+	}
+	return append([]schema.Schema{parent}, children...), nil
+}
+
+func hasUnique(keys []schema.Index, name string) bool {
+	for _, k := range keys {
+		if len(k.Fields) == 1 && k.Fields[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// primitive reports whether the elements of list type fieldType are stored
+// in columns of their own type rather than as JSON.
+func (g *Generator) primitive(fieldType string) bool {
+	elem, _ := schema.ElementType(fieldType)
+	_, ok := g.scalarType(schema.Field{Type: elem})
+	return ok
+}
+
+// foreignKeyConstraint returns the FOREIGN KEY constraint of the child
+// table s, or "" if s is not a child table.
+func (g *Generator) foreignKeyConstraint(s schema.Schema) string {
+	fk, ok := g.foreignKeys[s.Namespace+"/"+s.GetName()]
+	if !ok {
+		return ""
+	}
+	column, key := columnName(fk.Column), g.column(&fk.Parent, fk.Key)
+	for _, f := range g.fields(s) {
+		if f.Name == fk.Column {
+			column = f.Ident
+		}
+	}
+	constraint := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", column, g.tableName(fk.Parent), key)
+	switch g.dialectName {
+	case config.DialectBigQuery:
+		return constraint + " NOT ENFORCED"
+	case config.DialectSnowflake:
+		return constraint
+	case config.DialectDelta, config.DialectIceberg:
+		// Lakehouse tables have no foreign keys.
+		return ""
+	}
+	// Purges and deletes of parent rows take their child rows along.
+	return constraint + " ON DELETE CASCADE"
+}
@@ -0,0 +1,98 @@
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// lakehouseLayout adds to t the Spark SQL clauses of the lakehouse table of
+// s: its table format, partition spec or clustering, description, and table
+// properties. It returns columns with their descriptions and any column the
+// partition spec adds.
+func (g *Generator) lakehouseLayout(s schema.Schema, t *table, columns []column, partition *column, cluster []column) ([]column, error) {
+	t.Clauses = append(t.Clauses, "USING "+g.dialect.format)
+	properties := make(map[string]string)
+	switch g.dialectName {
+	case config.DialectIceberg:
+		// Iceberg partitions by hidden transforms of columns, which queries
+		// filtering on the columns prune by, and sorts the files it writes
+		// by the clustering columns.
+		properties["format-version"] = "2"
+		if partition != nil {
+			spec := partition.Name
+			if partition.kind == kindDate || partition.kind == kindTimestamp {
+				spec = "days(" + partition.Name + ")"
+			}
+			t.Clauses = append(t.Clauses, "PARTITIONED BY ("+spec+")")
+		}
+		if len(cluster) > 0 {
+			t.Statements = append(t.Statements, fmt.Sprintf("ALTER TABLE %s WRITE ORDERED BY %s", g.tableName(s), names(cluster, nil)))
+		}
+	default:
+		// Column mapping lets migrations rename and drop columns.
+		properties["delta.columnMapping.mode"] = "name"
+		for _, c := range columns {
+			if strings.Contains(c.Definition, " DEFAULT ") {
+				properties["delta.feature.allowColumnDefaults"] = "supported"
+			}
+		}
+		switch {
+		case len(cluster) > 0:
+			// Liquid clustering cannot be combined with partitions, so the
+			// partition key leads the clustering key instead.
+			if partition != nil {
+				cluster = append([]column{*partition}, cluster...)
+			}
+			if len(cluster) > deltaMaxClusterColumns {
+				return nil, fmt.Errorf("%s: delta clusters by at most %d fields, got %d", s.GetName(), deltaMaxClusterColumns, len(cluster))
+			}
+			t.Clauses = append(t.Clauses, "CLUSTER BY ("+names(cluster, nil)+")")
+		case partition != nil && partition.kind == kindTimestamp:
+			// A partition per instant would hold a few rows each; the table
+			// is partitioned by a generated column of their days instead.
+			day := naming.Ident("sql", strings.Trim(partition.Name, `"`)+"_date")
+			columns = append(columns, column{historyColumn{
+				Name:        day,
+				Definition:  "DATE GENERATED ALWAYS AS (CAST(" + partition.Name + " AS DATE))",
+				Description: "Day of " + strings.Trim(partition.Name, `"`) + ", which the table is partitioned by",
+			}, kindDate})
+			t.Clauses = append(t.Clauses, "PARTITIONED BY ("+day+")")
+		case partition != nil:
+			t.Clauses = append(t.Clauses, "PARTITIONED BY ("+partition.Name+")")
+		}
+	}
+
+	if s.Description != "" {
+		t.Clauses = append(t.Clauses, "COMMENT "+sparkString(s.Description))
+	}
+	for i := range columns {
+		if columns[i].Description != "" {
+			columns[i].Definition += " COMMENT " + sparkString(columns[i].Description)
+		}
+	}
+
+	for key, value := range g.properties {
+		properties[key] = value
+	}
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = "    " + sparkString(key) + " = " + sparkString(properties[key])
+	}
+	t.Clauses = append(t.Clauses, "TBLPROPERTIES (\n"+strings.Join(lines, ",\n")+"\n)")
+	return columns, nil
+}
+
+// sparkString quotes s as a Spark SQL string literal.
+func sparkString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`, "\n", " ").Replace(s) + "'"
+}
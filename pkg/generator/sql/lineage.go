@@ -0,0 +1,199 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// LineageDir is the directory, relative to the output directory, that the
+// dbt sources and exposures of mappings are generated into.
+const LineageDir = "lineage"
+
+// sourceColumn matches mapping sources that name a single column, as opposed
+// to expressions or paths into nested source documents.
+var sourceColumn = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// lineageSource is a dbt source: the tables one source system is mapped from.
+type lineageSource struct {
+	Name   string
+	Tables []*lineageTable
+}
+
+type lineageTable struct {
+	Name        string
+	Description string
+	Columns     []lineageColumn
+}
+
+type lineageColumn struct {
+	Name        string
+	Description string
+}
+
+// exposure is a dbt exposure for a target resource and the source tables
+// its mappings read.
+type exposure struct {
+	Name      string
+	Resource  string
+	DependsOn []string
+}
+
+// GenerateMappings writes dbt sources for the source tables of mappings and
+// an exposure for each target resource that depends on them, so that the dbt
+// DAG reflects the lineage the mappings declare, and a view over each source
+// table that reads it as the target resource of its mapping.
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
+	if len(mappings) == 0 {
+		return nil
+	}
+	sources, exposures := lineage(mappings)
+	dir := filepath.Join(outputDir, LineageDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create lineage directory: %w", err)
+	}
+	if err := g.writeLineage(sourcesTemplate, sources, filepath.Join(dir, "sources.yml")); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := g.writeLineage(exposuresTemplate, exposures, filepath.Join(dir, "exposures.yml")); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return g.generateViews(mappings, outputDir)
+}
+
+// lineage groups the source tables of mappings by source system, merging
+// the columns of tables that several mappings read, and collects the tables
+// each target resource depends on. Both are sorted by name.
+func lineage(mappings []schema.SchemaMapping) ([]*lineageSource, []*exposure) {
+	sources := make(map[string]*lineageSource)
+	tables := make(map[string]*lineageTable)
+	exposures := make(map[string]*exposure)
+	for _, m := range mappings {
+		name := naming.Snake.Apply(m.SourceSystem)
+		src, ok := sources[name]
+		if !ok {
+			src = &lineageSource{Name: name}
+			sources[name] = src
+		}
+		key := name + "." + m.SourceTable
+		table, ok := tables[key]
+		if !ok {
+			table = &lineageTable{Name: m.SourceTable, Description: strings.TrimSpace(m.Description)}
+			tables[key] = table
+			src.Tables = append(src.Tables, table)
+		}
+		for _, fm := range m.FieldMappings {
+			if sourceColumn.MatchString(fm.Source) && !hasLineageColumn(table.Columns, fm.Source) {
+				table.Columns = append(table.Columns, lineageColumn{Name: fm.Source, Description: fm.Description})
+			}
+		}
+
+		exp, ok := exposures[m.TargetResource]
+		if !ok {
+			exp = &exposure{Name: naming.Snake.Apply(m.TargetResource), Resource: m.TargetResource}
+			exposures[m.TargetResource] = exp
+		}
+		dep := fmt.Sprintf("source('%s', '%s')", name, m.SourceTable)
+		if !contains(exp.DependsOn, dep) {
+			exp.DependsOn = append(exp.DependsOn, dep)
+		}
+	}
+
+	var outSources []*lineageSource
+	for _, src := range sources {
+		sort.Slice(src.Tables, func(i, j int) bool { return src.Tables[i].Name < src.Tables[j].Name })
+		outSources = append(outSources, src)
+	}
+	sort.Slice(outSources, func(i, j int) bool { return outSources[i].Name < outSources[j].Name })
+	var outExposures []*exposure
+	for _, exp := range exposures {
+		sort.Strings(exp.DependsOn)
+		outExposures = append(outExposures, exp)
+	}
+	sort.Slice(outExposures, func(i, j int) bool { return outExposures[i].Name < outExposures[j].Name })
+	return outSources, outExposures
+}
+
+func hasLineageColumn(columns []lineageColumn, name string) bool {
+	for _, c := range columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+const sourcesTemplate = `{{header}}
+version: 2
+
+sources:
+{{range .}}  - name: {{.Name}}
+    tables:
+{{range .Tables}}      - name: {{.Name}}
+{{- with .Description}}
+        description: "{{. | escape}}"{{end}}
+{{- with .Columns}}
+        columns:
+{{- range .}}
+          - name: {{.Name}}
+{{- with .Description}}
+            description: "{{. | escape}}"{{end}}
+{{- end}}
+{{- end}}
+{{end}}{{end}}`
+
+const exposuresTemplate = `{{header}}
+version: 2
+
+exposures:
+{{range .}}  - name: {{.Name}}
+    type: application
+    description: "{{.Resource}} resources built by ehrglot mappings"
+    owner:
+      name: ehrglot
+    depends_on:
+{{range .DependsOn}}      - {{.}}
+{{end}}{{end}}`
+
+// writeLineage renders a dbt properties file from data to path.
+func (g *Generator) writeLineage(tmpl string, data any, path string) error {
+	funcMap := template.FuncMap{
+		"escape": escapeYaml,
+		"header": g.header.Func("# "),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// matchColumn is a column of the normalized values that a matching key is
+// made from: its alias and the expression computing it.
+type matchColumn struct {
+	Alias string
+	Expr  string
+}
+
+// matchKey is a kind of matching key: its key_type, the expression of the
+// key, and the columns that must be set for a row to have it.
+type matchKey struct {
+	Type     string
+	Expr     string
+	Required []string
+}
+
+// generateMatchKeys writes a dbt model of the patient matching keys of the
+// rows of the staging model of s, if s has fields that make them: a row per
+// key, with the natural key of its record. The keys are those the other
+// generators make of the same records.
+func (g *Generator) generateMatchKeys(s schema.Schema, namespace string, path string) error {
+	matched, err := s.MatchFields()
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if matched.Empty() {
+		return nil
+	}
+	if g.phonetic == config.PhoneticMetaphone {
+		return fmt.Errorf("%s: match keys cannot encode names as %s in SQL, whose databases have no Metaphone like that of the generated code (use %s)", s.GetName(), g.phonetic, config.PhoneticSoundex)
+	}
+	fields := g.fields(s)
+	key := g.naturalKey(fields)
+	if key == "" {
+		return fmt.Errorf("%s: match keys need an id or required field to identify records by", s.GetName())
+	}
+	idents := make(map[string]naming.Field)
+	for _, f := range fields {
+		idents[f.Name] = f
+	}
+	letters := func(column string) string {
+		return "UPPER(" + fmt.Sprintf(g.dialect.stripChars, column, "[^A-Za-z]") + ")"
+	}
+
+	var columns []matchColumn
+	var keys []matchKey
+	if len(matched.Demographic) > 0 {
+		demographic := matchKey{Type: "demographics"}
+		var parts []string
+		for _, f := range matched.Demographic {
+			column := idents[f.Name]
+			c := matchColumn{Alias: naming.Snake.Apply(f.Name) + "_key"}
+			part := c.Alias
+			switch {
+			case f.Match == schema.MatchBirthDate && g.kind(column.Field) == kindOther:
+				c.Expr = column.Ident
+			case f.Match == schema.MatchBirthDate:
+				c.Expr = fmt.Sprintf(g.dialect.dateText, column.Ident)
+			case f.Match == schema.MatchSex:
+				c.Expr = "SUBSTR(" + letters(column.Ident) + ", 1, 1)"
+			default:
+				c.Expr = letters(column.Ident)
+				if g.phonetic == config.PhoneticSoundex {
+					part = fmt.Sprintf(g.dialect.soundex, c.Alias)
+				}
+			}
+			columns = append(columns, c)
+			parts = append(parts, part)
+			demographic.Required = append(demographic.Required, c.Alias)
+		}
+		demographic.Expr = "'demographics:' || " + strings.Join(parts, "\n        || '|' || ")
+		keys = append(keys, demographic)
+	}
+	for _, f := range matched.Identifiers {
+		c := matchColumn{Alias: naming.Snake.Apply(f.Name) + "_key"}
+		c.Expr = "UPPER(" + fmt.Sprintf(g.dialect.stripChars, idents[f.Name].Ident, "[^A-Za-z0-9]") + ")"
+		columns = append(columns, c)
+		keys = append(keys, matchKey{Type: f.Name, Expr: "'" + f.Name + ":' || " + c.Alias, Required: []string{c.Alias}})
+	}
+
+	tmpl := `{#
+  Patient matching keys of stg_{{.File}}, a row per key of a record, which
+  records that are candidate matches share.
+
+{{header}}#}
+
+{{ "{{" }} config(
+    materialized='view',
+    schema='{{.Namespace}}'
+) {{ "}}" }}
+
+WITH normalized AS (
+    SELECT
+        {{.Key}}
+{{- range .Columns}},
+        {{.Expr}} AS {{.Alias}}{{end}}
+    FROM {{ "{{" }} ref('stg_{{.File}}') {{ "}}" }}
+)
+{{range $i, $k := .Keys}}{{if $i}}
+
+UNION ALL
+{{end}}
+SELECT
+    {{$.Key}},
+    '{{.Type}}' AS key_type,
+    {{.Expr}} AS match_key
+FROM normalized
+WHERE {{range $j, $c := .Required}}{{if $j}}
+  AND {{end}}{{$c}} <> ''{{end}}
+{{- end}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{"header": g.header.Func("  ", s)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		File      string
+		Namespace string
+		Key       string
+		Columns   []matchColumn
+		Keys      []matchKey
+	}{
+		File:      g.fileName(s),
+		Namespace: naming.Snake.Apply(namespace),
+		Key:       key,
+		Columns:   columns,
+		Keys:      keys,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
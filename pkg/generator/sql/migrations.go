@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// checksumsFile lists the SHA-256 of every migration file of a namespace, in
+// the format sha256sum -c reads.
+const checksumsFile = "checksums.txt"
+
+// changeSet is one Liquibase change set, creating the table of a schema.
+type changeSet struct {
+	ID      string
+	Comment string
+	Path    string
+	Table   string
+}
+
+// generateMigrations wraps the DDL of a namespace's schemas for the
+// configured migration tool. Each table gets its own migration file; Flyway
+// versions number them in file name order. The files leave the generation
+// time out of their header, so generating them again does not change their
+// checksums.
+func (g *Generator) generateMigrations(schemas []schema.Schema, namespace string, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+	sorted := append([]schema.Schema(nil), schemas...)
+	sort.SliceStable(sorted, func(i, j int) bool { return g.fileName(sorted[i]) < g.fileName(sorted[j]) })
+
+	stable := g.header.Stable()
+	var files []string
+	var changeSets []changeSet
+	for i, s := range sorted {
+		name := "create_" + g.fileName(s) + ".sql"
+		if g.migrations == config.MigrationsFlyway {
+			name = fmt.Sprintf("V%d__%s", i+1, name)
+		}
+		if err := g.generateDDL(s, namespace, filepath.Join(dir, name), stable); err != nil {
+			return err
+		}
+		files = append(files, name)
+		changeSets = append(changeSets, changeSet{ID: "create-" + g.fileName(s), Comment: s.Description, Path: name, Table: g.tableName(s)})
+	}
+
+	var changelog string
+	switch g.migrations {
+	case config.MigrationsLiquibaseXML:
+		changelog = "changelog.xml"
+		if err := g.writeChangelog(liquibaseXML, "  ", sorted, changeSets, filepath.Join(dir, changelog)); err != nil {
+			return err
+		}
+	case config.MigrationsLiquibaseYAML:
+		changelog = "changelog.yaml"
+		if err := g.writeChangelog(liquibaseYAML, "# ", sorted, changeSets, filepath.Join(dir, changelog)); err != nil {
+			return err
+		}
+	}
+	if changelog != "" {
+		files = append(files, changelog)
+	}
+	return writeChecksums(dir, files)
+}
+
+const liquibaseXML = `<?xml version="1.0" encoding="UTF-8"?>
+<!--
+{{header}}-->
+<databaseChangeLog
+    xmlns="http://www.liquibase.org/xml/ns/dbchangelog"
+    xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://www.liquibase.org/xml/ns/dbchangelog http://www.liquibase.org/xml/ns/dbchangelog/dbchangelog-4.20.xsd">
+{{range .}}
+    <changeSet id="{{.ID}}" author="ehrglot">
+        <comment>{{.Comment | html}}</comment>
+        <sqlFile path="{{.Path}}" relativeToChangelogFile="true"/>
+        <rollback>DROP TABLE IF EXISTS {{.Table | html}};</rollback>
+    </changeSet>
+{{end}}</databaseChangeLog>
+`
+
+const liquibaseYAML = `{{header}}
+databaseChangeLog:
+{{range .}}  - changeSet:
+      id: {{.ID}}
+      author: ehrglot
+      comment: "{{.Comment | escape}}"
+      changes:
+        - sqlFile:
+            path: {{.Path}}
+            relativeToChangelogFile: true
+      rollback: "DROP TABLE IF EXISTS {{.Table | escape}};"
+{{end}}`
+
+// writeChangelog renders a Liquibase changelog of changeSets to path.
+func (g *Generator) writeChangelog(tmpl string, prefix string, schemas []schema.Schema, changeSets []changeSet, path string) error {
+	funcMap := template.FuncMap{
+		"escape": escapeYaml,
+		"header": g.header.Stable().Func(prefix, schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, changeSets)
+}
+
+// writeChecksums writes the SHA-256 of each of files, relative to dir, to
+// the checksums file of dir.
+func writeChecksums(dir string, files []string) error {
+	var b strings.Builder
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read migration: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(&b, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+	}
+	if err := os.WriteFile(filepath.Join(dir, checksumsFile), []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write checksums: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateReidentification writes the table that links the pseudonyms of a
+// namespace's sensitive fields to the values they replaced, readable only by
+// the re-identification role.
+func (g *Generator) generateReidentification(schemas []schema.Schema, namespace string, path string) error {
+	tmpl := `-- Re-identification table of the pseudonyms of {{.Sources}}
+--
+{{header}}
+CREATE TABLE IF NOT EXISTS {{.Table}} (
+    token {{.Token}} NOT NULL,
+    source_field {{.Varchar}} NOT NULL,
+    value {{.Varchar}} NOT NULL,
+    created_at {{.Timestamp}} NOT NULL DEFAULT {{.Now}},
+    PRIMARY KEY (token, source_field){{if .NotEnforced}} NOT ENFORCED{{end}}
+);
+{{if eq .Dialect "postgres"}}
+REVOKE ALL ON {{.Table}} FROM PUBLIC;
+GRANT SELECT, INSERT ON {{.Table}} TO {{.Role}};
+{{- else if eq .Dialect "snowflake"}}
+REVOKE ALL ON TABLE {{.Table}} FROM ROLE PUBLIC;
+GRANT SELECT, INSERT ON TABLE {{.Table}} TO ROLE {{.Role}};
+{{- else}}
+-- BigQuery grants IAM roles to principals, such as group:reid@example.com.
+GRANT ` + "`roles/bigquery.dataEditor`" + ` ON TABLE {{.Table}} TO "{{.Role}}";
+{{- end}}
+`
+	var sources []string
+	for _, s := range schemas {
+		sources = append(sources, g.sourceName(s))
+	}
+	funcMap := template.FuncMap{
+		"header": g.header.Func("-- ", schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Sources     string
+		Table       string
+		Token       string
+		Varchar     string
+		Timestamp   string
+		Now         string
+		NotEnforced bool
+		Dialect     string
+		Role        string
+	}{
+		Sources: strings.Join(sources, ", "),
+		Table:   naming.Ident("sql", naming.Snake.Apply(namespace)+"_reidentification"),
+		// Tokens are the 64 hex digits of an HMAC-SHA256.
+		Token:       "CHAR(64)",
+		Varchar:     g.dialect.varchar,
+		Timestamp:   g.timestampType,
+		Now:         g.dialect.now,
+		NotEnforced: g.dialectName == config.DialectBigQuery,
+		Dialect:     g.dialectName,
+		Role:        g.pseudonym.Role,
+	}
+	if g.dialectName == config.DialectBigQuery {
+		data.Token = "STRING(64)"
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
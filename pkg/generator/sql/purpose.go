@@ -0,0 +1,118 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// purposeView is the view of a table for one purpose of use, with the
+// columns that purpose may read.
+type purposeView struct {
+	Name    string
+	Columns []string
+}
+
+// generatePurposes writes the access controls of a schema that restricts its
+// purposes of use: a view per permitted purpose with only the columns it may
+// read and, in Postgres, a row-level security policy that keeps sessions
+// reading for other purposes off the table.
+func (g *Generator) generatePurposes(s schema.Schema, path string) error {
+	_, filter, err := g.history(s)
+	if err != nil {
+		return err
+	}
+	if g.tenant != "" && g.dialectName != config.DialectPostgres {
+		// Readers are granted these views rather than the tenant views, so
+		// they isolate tenants too.
+		if filter != "" {
+			filter += " AND "
+		}
+		filter += g.tenantFilter(s)
+	}
+	var permitted []string
+	var views []purposeView
+	for _, p := range schema.Purposes {
+		if !s.Permits(p) {
+			continue
+		}
+		permitted = append(permitted, "'"+p+"'")
+		view := purposeView{Name: naming.Ident("sql", g.sourceName(s)+"_"+p)}
+		for _, f := range g.fields(s) {
+			if s.PermitsField(f.Field, p) {
+				view.Columns = append(view.Columns, f.Ident)
+			}
+		}
+		views = append(views, view)
+	}
+
+	tmpl := `-- Purpose-of-use access to {{.Schema | tableName}}
+--
+{{header}}
+{{- if .RLS}}
+-- Rows: sessions set ehrglot.purpose_of_use to the purpose they read for,
+-- and only {{.Permitted}} may read the table. Its owner, which loads it,
+-- is exempt.
+ALTER TABLE {{.Schema | tableName}} ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY {{.Schema | sourceName}}_purpose_of_use ON {{.Schema | tableName}}
+    FOR SELECT
+    USING (current_setting('ehrglot.purpose_of_use', true) IN ({{.Permitted}}));
+{{end}}
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+{{- range .Views}}
+
+CREATE OR REPLACE {{$.View}} {{.Name}} AS
+SELECT
+{{range $i, $c := .Columns}}{{if $i}},
+{{end}}    {{$c}}{{end}}
+FROM {{$.Schema | tableName}}
+{{- with $.Filter}}
+WHERE {{.}}{{end}};
+{{- end}}
+`
+	funcMap := template.FuncMap{
+		"tableName":  g.tableName,
+		"sourceName": g.sourceName,
+		"header":     g.header.Func("-- ", s),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Schema    schema.Schema
+		RLS       bool
+		Permitted string
+		View      string
+		Views     []purposeView
+		Filter    string
+	}{
+		Schema:    s,
+		RLS:       g.dialectName == config.DialectPostgres,
+		Permitted: strings.Join(permitted, ", "),
+		View:      "VIEW",
+		Views:     views,
+		Filter:    filter,
+	}
+	if g.dialectName == config.DialectSnowflake {
+		// Secure views hide their definition from the roles they are
+		// granted to.
+		data.View = "SECURE VIEW"
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
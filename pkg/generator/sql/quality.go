@@ -0,0 +1,240 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/validate"
+)
+
+// Kinds of data-quality checks.
+const (
+	checkNotNull = "not_null"
+	checkInSet   = "in_set"
+	checkRegex   = "regex"
+	checkUnique  = "unique"
+	checkRange   = "range"
+)
+
+// check is one data-quality check of a table, in no tool's format. Range
+// checks only warn, since values outside a reference range, such as
+// abnormal lab results, are expected now and then.
+type check struct {
+	Kind    string
+	Columns []string
+	Values  []string
+	Regex   string
+	Min     string
+	Max     string
+}
+
+// qualityTable is a table and the checks its rows must pass.
+type qualityTable struct {
+	Name   string
+	File   string
+	Schema schema.Schema
+	Checks []check
+}
+
+// checks returns the data-quality checks of the table of s, from the same
+// constraints the generated code validates: required fields must be set,
+// enum fields must hold one of their values, text columns of primitive
+// types must match the type's pattern, and numeric fields should hold values
+// in their reference ranges. Unique keys are checked only on tables without
+// history, which hold one row per key.
+func (g *Generator) checks(s schema.Schema) []check {
+	var checks []check
+	for _, f := range g.fields(s) {
+		name := strings.Trim(f.Ident, `"`)
+		if f.Required {
+			checks = append(checks, check{Kind: checkNotNull, Columns: []string{name}})
+		}
+		if len(f.Enum) > 0 {
+			checks = append(checks, check{Kind: checkInSet, Columns: []string{name}, Values: f.Enum})
+			continue
+		}
+		if pattern := validate.Pattern(f.Type); pattern != nil && g.isText(f.Field) {
+			checks = append(checks, check{Kind: checkRegex, Columns: []string{name}, Regex: pattern.String()})
+		}
+		if f.HasReferenceRange() {
+			checks = append(checks, check{Kind: checkRange, Columns: []string{name}, Min: f.ReferenceLow, Max: f.ReferenceHigh})
+		}
+	}
+	if s.History == "" {
+		fields := g.fields(s)
+		for _, key := range s.Unique {
+			var columns []string
+			for _, name := range key.Fields {
+				for _, f := range fields {
+					if f.Name == name {
+						columns = append(columns, strings.Trim(f.Ident, `"`))
+					}
+				}
+			}
+			checks = append(checks, check{Kind: checkUnique, Columns: columns})
+		}
+	}
+	return checks
+}
+
+// isText reports whether the column of f holds text, so that its values can
+// be matched against a pattern.
+func (g *Generator) isText(f schema.Field) bool {
+	t := g.toSQLType(f)
+	return t == g.dialect.varchar || t == g.dialect.partialDate
+}
+
+// generateQuality writes the data-quality checks of a namespace's tables
+// in the configured format.
+func (g *Generator) generateQuality(schemas []schema.Schema, namespace string, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create quality directory: %w", err)
+	}
+	var tables []qualityTable
+	for _, s := range schemas {
+		tables = append(tables, qualityTable{Name: g.sourceName(s), File: g.fileName(s), Schema: s, Checks: g.checks(s)})
+	}
+	if g.quality == config.QualitySoda {
+		return g.writeSodaChecks(schemas, tables, filepath.Join(dir, "checks.yml"))
+	}
+	for _, t := range tables {
+		if err := g.writeExpectationSuite(t, filepath.Join(dir, t.File+".json")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectationSuite is a Great Expectations expectation suite.
+type expectationSuite struct {
+	Name         string        `json:"expectation_suite_name"`
+	Expectations []expectation `json:"expectations"`
+	Meta         suiteMeta     `json:"meta"`
+}
+
+type expectation struct {
+	Type   string         `json:"expectation_type"`
+	Kwargs map[string]any `json:"kwargs"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+type suiteMeta struct {
+	Notes suiteNotes `json:"notes"`
+}
+
+type suiteNotes struct {
+	Format  string   `json:"format"`
+	Content []string `json:"content"`
+}
+
+// writeExpectationSuite writes the checks of t as a Great Expectations suite.
+// JSON has no comments, so the header goes in the suite's notes.
+func (g *Generator) writeExpectationSuite(t qualityTable, path string) error {
+	suite := expectationSuite{
+		Name:         t.Name,
+		Expectations: []expectation{},
+		Meta:         suiteMeta{Notes: suiteNotes{Format: "markdown", Content: g.header.Lines(t.Schema)}},
+	}
+	for _, c := range t.Checks {
+		e := expectation{Kwargs: map[string]any{"column": c.Columns[0]}}
+		switch c.Kind {
+		case checkNotNull:
+			e.Type = "expect_column_values_to_not_be_null"
+		case checkInSet:
+			e.Type = "expect_column_values_to_be_in_set"
+			e.Kwargs["value_set"] = c.Values
+		case checkRegex:
+			e.Type = "expect_column_values_to_match_regex"
+			e.Kwargs["regex"] = c.Regex
+		case checkRange:
+			e.Type = "expect_column_values_to_be_between"
+			if c.Min != "" {
+				e.Kwargs["min_value"] = json.Number(c.Min)
+			}
+			if c.Max != "" {
+				e.Kwargs["max_value"] = json.Number(c.Max)
+			}
+			e.Meta = map[string]any{"severity": "warning"}
+		case checkUnique:
+			e.Type = "expect_column_values_to_be_unique"
+			if len(c.Columns) > 1 {
+				e.Type = "expect_compound_columns_to_be_unique"
+				e.Kwargs = map[string]any{"column_list": c.Columns}
+			}
+		}
+		suite.Expectations = append(suite.Expectations, e)
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(suite); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+const sodaTemplate = `{{header}}
+{{- range .}}
+checks for {{.Name}}:
+{{- range .Checks}}
+{{- if eq .Kind "not_null"}}
+  - missing_count({{index .Columns 0}}) = 0
+{{- else if eq .Kind "in_set"}}
+  - invalid_count({{index .Columns 0}}) = 0:
+      valid values: [{{range $i, $v := .Values}}{{if $i}}, {{end}}{{quote $v}}{{end}}]
+{{- else if eq .Kind "regex"}}
+  - invalid_count({{index .Columns 0}}) = 0:
+      valid regex: {{quote .Regex}}
+{{- else if eq .Kind "range"}}
+  - invalid_count({{index .Columns 0}}):
+{{- with .Min}}
+      valid min: {{.}}
+{{- end}}
+{{- with .Max}}
+      valid max: {{.}}
+{{- end}}
+      warn: when > 0
+{{- else if eq .Kind "unique"}}
+  - duplicate_count({{join .Columns ", "}}) = 0
+{{- end}}
+{{- end}}
+{{end}}`
+
+// writeSodaChecks writes the checks of tables as one Soda checks file.
+func (g *Generator) writeSodaChecks(schemas []schema.Schema, tables []qualityTable, path string) error {
+	funcMap := template.FuncMap{
+		"join":   strings.Join,
+		"quote":  quoteYaml,
+		"header": g.header.Func("# ", schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(sodaTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, tables)
+}
+
+// quoteYaml quotes s as a single-quoted YAML string, in which backslashes
+// need no escaping.
+func quoteYaml(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateReferenceRange writes a dbt singular test that warns about the
+// rows of the staging model of s with values outside the reference ranges
+// of their fields, if s has any. It only warns, since values outside a
+// reference range, such as abnormal lab results, are expected now and then.
+func (g *Generator) generateReferenceRange(s schema.Schema, dir string) error {
+	ranged, err := s.RangedFields()
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.GetName(), err)
+	}
+	if len(ranged) == 0 {
+		return nil
+	}
+	columns := make(map[string]string)
+	for _, f := range g.fields(s) {
+		columns[f.Name] = f.Ident
+	}
+	var conditions []string
+	for _, f := range ranged {
+		column := columns[f.Name]
+		switch {
+		case f.ReferenceLow != "" && f.ReferenceHigh != "":
+			conditions = append(conditions, fmt.Sprintf("%s NOT BETWEEN %s AND %s", column, f.ReferenceLow, f.ReferenceHigh))
+		case f.ReferenceLow != "":
+			conditions = append(conditions, fmt.Sprintf("%s < %s", column, f.ReferenceLow))
+		default:
+			conditions = append(conditions, fmt.Sprintf("%s > %s", column, f.ReferenceHigh))
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create dbt tests directory: %w", err)
+	}
+
+	tmpl := `{#
+  Rows of stg_{{.File}} with values outside the reference ranges of their
+  fields, to review.
+
+{{header}}#}
+
+{{ "{{" }} config(severity='warn') {{ "}}" }}
+
+SELECT *
+FROM {{ "{{" }} ref('stg_{{.File}}') {{ "}}" }}
+WHERE {{join .Conditions "\n   OR "}}
+`
+	tmpl_parsed, err := g.templates.Parse(tmpl, template.FuncMap{
+		"join":   strings.Join,
+		"header": g.header.Func("  ", s),
+	})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, "reference_range_"+g.fileName(s)+".sql"))
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		File       string
+		Conditions []string
+	}{
+		File:       g.fileName(s),
+		Conditions: conditions,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,170 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// retentionTable is a table that keeps records for a retention period.
+type retentionTable struct {
+	Schema schema.Schema
+	Column string
+	Period schema.Period
+	// Expired selects the records older than Period.
+	Expired string
+}
+
+// retentionTables returns the schemas of a namespace that declare retention,
+// with the condition that selects their expired records. The schemas have
+// passed table, which checks their retention.
+func (g *Generator) retentionTables(schemas []schema.Schema) []retentionTable {
+	var tables []retentionTable
+	for _, s := range schemas {
+		period, field, _ := s.RetentionPeriod()
+		if field == nil {
+			continue
+		}
+		t := retentionTable{Schema: s, Period: period}
+		for _, f := range g.fields(s) {
+			if f.Name == field.Name {
+				t.Column = f.Ident
+			}
+		}
+		t.Expired = t.Column + " < " + g.cutoff(period, g.kind(*field))
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// cutoff returns the expression of the date or timestamp period ago.
+func (g *Generator) cutoff(period schema.Period, kind string) string {
+	n := strconv.Itoa(period.Count)
+	switch g.dialectName {
+	case config.DialectBigQuery:
+		date := "DATE_SUB(CURRENT_DATE(), INTERVAL " + n + " " + strings.ToUpper(period.Unit) + ")"
+		if kind == kindTimestamp {
+			return "TIMESTAMP(" + date + ")"
+		}
+		return date
+	case config.DialectSnowflake:
+		if kind == kindTimestamp {
+			return "DATEADD(" + period.Unit + ", -" + n + ", CURRENT_TIMESTAMP())"
+		}
+		return "DATEADD(" + period.Unit + ", -" + n + ", CURRENT_DATE())"
+	default:
+		if kind == kindTimestamp {
+			return "CURRENT_TIMESTAMP - INTERVAL '" + period.String() + "'"
+		}
+		return "CURRENT_DATE - INTERVAL '" + period.String() + "'"
+	}
+}
+
+// generateRetention writes, for the tables of a namespace that declare
+// retention, a script that purges their expired records, a dbt macro that
+// does the same, and a table listing their retention periods for audits.
+func (g *Generator) generateRetention(tables []retentionTable, namespace string, dir string, dbtDir string) error {
+	var sources []schema.Schema
+	for _, t := range tables {
+		sources = append(sources, t.Schema)
+	}
+	policy := naming.Ident("sql", naming.Snake.Apply(namespace)+"_retention_policy")
+	primaryKey, using := ",\n    PRIMARY KEY (source_table)", ""
+	switch g.dialectName {
+	case config.DialectBigQuery:
+		primaryKey += " NOT ENFORCED"
+	case config.DialectDelta, config.DialectIceberg:
+		primaryKey, using = "", "\nUSING "+g.dialect.format
+	}
+
+	files := []struct {
+		path string
+		tmpl string
+		head func() string
+	}{
+		{filepath.Join(dir, "purge.sql"), `-- Deletes the records of {{.Namespace}} tables older than their retention periods.
+--
+{{header}}
+{{range $i, $t := .Tables}}{{if $i}}
+{{end}}-- {{$t.Schema | tableName}} keeps records for {{$t.Period}} from {{$t.Column}}.
+DELETE FROM {{$t.Schema | tableName}}
+WHERE {{$t.Expired}};
+{{end}}`, g.header.Func("-- ", sources...)},
+		{filepath.Join(dir, "retention_policy.sql"), `-- Retention periods of {{.Namespace}} tables, for compliance audits.
+--
+{{header}}
+CREATE TABLE IF NOT EXISTS ` + policy + ` (
+    source_table {{.Varchar}} NOT NULL,
+    retention_field {{.Varchar}} NOT NULL,
+    retention_count {{.Integer}} NOT NULL,
+    retention_unit {{.Varchar}} NOT NULL` + primaryKey + `
+)` + using + `;
+
+DELETE FROM ` + policy + ` WHERE TRUE;
+
+INSERT INTO ` + policy + ` (source_table, retention_field, retention_count, retention_unit) VALUES
+{{range $i, $t := .Tables}}{{if $i}},
+{{end}}    ('{{$t.Schema | sourceName}}', '{{$t.Column}}', {{$t.Period.Count}}, '{{$t.Period.Unit}}'){{end}};
+`, g.header.Func("-- ", sources...)},
+		{filepath.Join(dbtDir, "macros", "purge_expired_"+naming.Snake.Apply(namespace)+".sql"), `{#
+  Deletes the records of {{.Namespace}} tables older than their retention
+  periods. Run with: dbt run-operation purge_expired_{{.Namespace | snake}}
+
+{{header}}#}
+
+{{ "{%" }} macro purge_expired_{{.Namespace | snake}}() {{ "%}" }}
+{{- range .Tables}}
+  {{ "{%" }} set purge {{ "%}" }}
+    DELETE FROM {{ "{{" }} source('{{$.Namespace | snake}}', '{{.Schema | sourceName}}') {{ "}}" }}
+    WHERE {{.Expired}}
+  {{ "{%" }} endset {{ "%}" }}
+  {{ "{%" }} do run_query(purge) {{ "%}" }}
+{{- end}}
+{{ "{%" }} endmacro {{ "%}" }}
+`, g.header.Func("  ", sources...)},
+	}
+
+	data := struct {
+		Namespace string
+		Tables    []retentionTable
+		Varchar   string
+		Integer   string
+	}{
+		Namespace: namespace,
+		Tables:    tables,
+		Varchar:   g.dialect.varchar,
+		Integer:   g.dialect.integer,
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+			return fmt.Errorf("failed to create retention directory: %w", err)
+		}
+		funcMap := template.FuncMap{
+			"snake":      naming.Snake.Apply,
+			"tableName":  g.tableName,
+			"sourceName": g.sourceName,
+			"header":     file.head,
+		}
+		tmpl_parsed, err := g.templates.Parse(file.tmpl, funcMap)
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(file.path)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		err = tmpl_parsed.Execute(f, data)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
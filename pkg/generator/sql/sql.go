@@ -2,60 +2,220 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
-	"time"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/crosswalk"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
 const Version = "0.1.0"
 
 // Generator generates SQL/dbt code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming        config.Naming
+	dialectName   string
+	dialect       dialect
+	timestampType string
+	dateType      string
+	audit         bool
+	migrations    string
+	quality       string
+	auditTrail    string
+	tenant        string
+	properties    map[string]string
+	terraform     bool
+	bqLoad        bool
+	crosswalk     string
+	conceptMaps   []string
+	phonetic      string
+	pseudonym     config.Pseudonymize
+	// schemas are those last generated, which mapping views resolve their
+	// target fields among.
+	schemas []schema.Schema
+	flatten string
+	// foreignKeys are the keys of the child tables the tables flatten
+	// strategy splits off, by namespace/name.
+	foreignKeys map[string]foreignKey
+	header      *header.Header
+	templates   templates.Cache
+}
 
 // NewGenerator creates a new SQL code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("sql").Naming, dialectName: cfg.SQLDialect(), dateType: "DATE", audit: cfg.Audit, migrations: cfg.Language("sql").Migrations, quality: cfg.Language("sql").Quality, auditTrail: cfg.Language("sql").AuditTrail, tenant: cfg.Language("sql").TenantColumn, properties: cfg.Language("sql").TableProperties, flatten: cfg.Language("sql").Flatten, terraform: cfg.Language("sql").Terraform, bqLoad: cfg.Language("sql").BQLoad, crosswalk: cfg.CrosswalkFile(), conceptMaps: cfg.ConceptMapFiles(), phonetic: cfg.Matching.Phonetic, pseudonym: cfg.Pseudonymization(), header: header.New(cfg.Header, Version)}
+	g.dialect = dialects[g.dialectName]
+	g.timestampType = g.dialect.timestamp
+	temporal := cfg.TemporalPolicy("sql")
+	if temporal.Instants == config.InstantsOffset {
+		g.timestampType = g.dialect.timestampTZ
+	}
+	if temporal.Dates == config.DatesPartial {
+		// Partial dates are kept as their ISO 8601 text: YYYY, YYYY-MM, or YYYY-MM-DD
+		g.dateType = g.dialect.partialDate
+	}
+	return g
 }
 
-// Generate generates SQL DDL and dbt models from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
+// Generate generates SQL DDL and dbt models from schemas. Elements inherited
+// from FHIR base resources become columns of each table.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	g.schemas = schemas
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	if g.tenant != "" {
+		schemas = g.injectTenant(schemas)
+	}
+	if g.auditTrail == config.AuditTrailTriggers && g.dialectName != config.DialectPostgres {
+		return fmt.Errorf("audit_trail triggers need the postgres dialect, not %s", g.dialectName)
+	}
+	if g.dialect.format != "" {
+		// Audit trails, tenant isolation, and the reidentification table
+		// rely on change streams, row access policies, and grants that
+		// lakehouse table formats leave to their catalogs.
+		switch {
+		case g.auditTrail != "":
+			return fmt.Errorf("audit_trail needs the postgres, bigquery, or snowflake dialect, not %s", g.dialectName)
+		case g.tenant != "":
+			return fmt.Errorf("tenant_column needs the postgres, bigquery, or snowflake dialect, not %s", g.dialectName)
+		case g.pseudonym.Level != "":
+			return fmt.Errorf("pseudonymize needs the postgres, bigquery, or snowflake dialect, not %s", g.dialectName)
+		}
+	}
+	if g.terraform && g.dialectName != config.DialectBigQuery && g.dialectName != config.DialectSnowflake {
+		return fmt.Errorf("terraform needs the bigquery or snowflake dialect, not %s", g.dialectName)
+	}
+	if g.bqLoad && g.dialectName != config.DialectBigQuery {
+		return fmt.Errorf("bq_load needs the bigquery dialect, not %s", g.dialectName)
+	}
 	// Group schemas by namespace
 	byNamespace := make(map[string][]schema.Schema)
+	g.foreignKeys = make(map[string]foreignKey)
+	// Resources keep their nested fields in the tables bq load fills.
+	resources := make(map[string][]schema.Schema)
 	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+		if g.bqLoad {
+			resources[s.Namespace] = append(resources[s.Namespace], datatypes.Flatten(s))
+		}
+		tables, err := g.unnest(datatypes.Flatten(s))
+		if err != nil {
+			return err
+		}
+		for _, s := range tables {
+			if _, err := g.table(s); err != nil {
+				return err
+			}
+			if s.History == schema.HistorySCD2 && g.naturalKey(g.fields(s)) == "" {
+				return fmt.Errorf("%s: history %s needs an id or required field to snapshot by", s.GetName(), s.History)
+			}
+			byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+		}
+	}
+	var crosswalks map[string][]crosswalk.Field
+	if g.crosswalk != "" {
+		var err error
+		if crosswalks, err = crosswalk.LoadFields(g.crosswalk, schemas); err != nil {
+			return err
+		}
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
+	}
+	var refs *resolve.Resolver
+	if g.bqLoad {
+		if refs, err = resolve.New(schemas); err != nil {
+			return err
+		}
 	}
 
 	for namespace, nsSchemas := range byNamespace {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// Create DDL directory
-		ddlDir := filepath.Join(outputDir, namespace, "ddl")
+		ddlDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "ddl")
 		if err := os.MkdirAll(ddlDir, 0755); err != nil {
 			return fmt.Errorf("failed to create DDL directory: %w", err)
 		}
 
 		// Create dbt models directory
-		dbtDir := filepath.Join(outputDir, namespace, "dbt")
+		dbtDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "dbt")
 		if err := os.MkdirAll(dbtDir, 0755); err != nil {
 			return fmt.Errorf("failed to create dbt directory: %w", err)
 		}
 
 		// Generate each schema
 		for _, s := range nsSchemas {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			// Generate DDL
-			ddlPath := filepath.Join(ddlDir, toSnakeCase(s.GetName())+".sql")
-			if err := g.generateDDL(s, namespace, ddlPath); err != nil {
+			ddlPath := filepath.Join(ddlDir, g.fileName(s)+".sql")
+			if err := g.generateDDL(s, namespace, ddlPath, g.header); err != nil {
 				return err
 			}
 
 			// Generate dbt model
-			dbtPath := filepath.Join(dbtDir, "stg_"+toSnakeCase(s.GetName())+".sql")
+			dbtPath := filepath.Join(dbtDir, "stg_"+g.fileName(s)+".sql")
 			if err := g.generateDbtModel(s, namespace, dbtPath); err != nil {
 				return err
 			}
+
+			if err := g.generateMatchKeys(s, namespace, filepath.Join(dbtDir, "match_"+g.fileName(s)+".sql")); err != nil {
+				return err
+			}
+
+			if err := g.generateRequiredIf(s, filepath.Join(dbtDir, "tests")); err != nil {
+				return err
+			}
+
+			if err := g.generateReferenceRange(s, filepath.Join(dbtDir, "tests")); err != nil {
+				return err
+			}
+
+			if s.HasPurposes() {
+				securityDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "security")
+				if err := os.MkdirAll(securityDir, 0755); err != nil {
+					return fmt.Errorf("failed to create security directory: %w", err)
+				}
+				if err := g.generatePurposes(s, filepath.Join(securityDir, g.fileName(s)+".sql")); err != nil {
+					return err
+				}
+			}
+
+			if g.auditTrail != "" {
+				auditDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "audit")
+				if err := os.MkdirAll(auditDir, 0755); err != nil {
+					return fmt.Errorf("failed to create audit directory: %w", err)
+				}
+				if err := g.generateAuditTrail(s, filepath.Join(auditDir, g.fileName(s)+".sql")); err != nil {
+					return err
+				}
+			}
+
+			if s.History == schema.HistorySCD2 {
+				snapshotDir := filepath.Join(dbtDir, "snapshots")
+				if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+					return fmt.Errorf("failed to create dbt snapshot directory: %w", err)
+				}
+				if err := g.generateDbtSnapshot(s, namespace, filepath.Join(snapshotDir, "snap_"+g.fileName(s)+".sql")); err != nil {
+					return err
+				}
+			}
 		}
 
 		// Generate dbt schema.yml
@@ -63,37 +223,108 @@ func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
 		if err := g.generateDbtSchema(nsSchemas, namespace, schemaPath); err != nil {
 			return err
 		}
+
+		if fields := crosswalks[namespace]; len(fields) > 0 {
+			if err := g.generateCrosswalk(fields, namespace, filepath.Join(dbtDir, "seeds")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, namespace, filepath.Join(dbtDir, "seeds")); err != nil {
+				return err
+			}
+		}
+
+		if g.tenant != "" {
+			securityDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "security")
+			if err := os.MkdirAll(securityDir, 0755); err != nil {
+				return fmt.Errorf("failed to create security directory: %w", err)
+			}
+			if err := g.generateTenantIsolation(nsSchemas, namespace, filepath.Join(securityDir, "tenant_isolation.sql")); err != nil {
+				return err
+			}
+		}
+
+		var pseudonymized []schema.Schema
+		for _, s := range nsSchemas {
+			if s.HasSensitive(g.pseudonym.Level) {
+				pseudonymized = append(pseudonymized, s)
+			}
+		}
+		if len(pseudonymized) > 0 {
+			securityDir := filepath.Join(outputDir, g.NamespaceDir(namespace), "security")
+			if err := os.MkdirAll(securityDir, 0755); err != nil {
+				return fmt.Errorf("failed to create security directory: %w", err)
+			}
+			if err := g.generateReidentification(pseudonymized, namespace, filepath.Join(securityDir, "reidentification.sql")); err != nil {
+				return err
+			}
+		}
+
+		if tables := g.retentionTables(nsSchemas); len(tables) > 0 {
+			if err := g.generateRetention(tables, namespace, filepath.Join(outputDir, g.NamespaceDir(namespace), "retention"), dbtDir); err != nil {
+				return err
+			}
+		}
+
+		if g.quality != "" {
+			if err := g.generateQuality(nsSchemas, namespace, filepath.Join(outputDir, g.NamespaceDir(namespace), "quality")); err != nil {
+				return err
+			}
+		}
+
+		if g.terraform {
+			if err := g.generateTerraform(nsSchemas, namespace, filepath.Join(outputDir, g.NamespaceDir(namespace), TerraformDir)); err != nil {
+				return err
+			}
+		}
+
+		if g.bqLoad {
+			if err := g.generateBQLoad(refs, resources[namespace], namespace, filepath.Join(outputDir, g.NamespaceDir(namespace), BigQueryDir)); err != nil {
+				return err
+			}
+		}
+
+		if g.migrations != "" {
+			if err := g.generateMigrations(nsSchemas, namespace, filepath.Join(outputDir, g.NamespaceDir(namespace), "migrations")); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func (g *Generator) generateDDL(s schema.Schema, namespace string, path string) error {
+func (g *Generator) generateDDL(s schema.Schema, namespace string, path string, h *header.Header) error {
 	tmpl := `-- {{.Schema.Description}}
 --
--- Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
--- DO NOT EDIT.
-
-CREATE TABLE IF NOT EXISTS {{.Schema | schemaName | snake}} (
-{{range $i, $f := .Schema.Fields}}{{if $i}},
-{{end}}    {{$f.Name | snake}} {{$f | sqlType}}{{if $f.Required}} NOT NULL{{end}}{{end}}
-);
-
+{{header}}
+CREATE TABLE IF NOT EXISTS {{.Schema | tableName}} (
+{{range $i, $c := .Table.Columns}}{{if $i}},
+{{end}}    {{$c.Name}} {{$c.Definition}}{{end}}
+{{- range .Table.Constraints}},
+    {{.}}{{end}}
+){{range .Table.Clauses}}
+{{.}}{{end}};
+{{- range .Table.Statements}}
+
+{{.}};
+{{- end}}
+{{if .Table.Comments}}
 -- Add comments
-COMMENT ON TABLE {{.Schema | schemaName | snake}} IS '{{.Schema.Description | escape}}';
-{{range .Schema.Fields}}COMMENT ON COLUMN {{$.Schema | schemaName | snake}}.{{.Name | snake}} IS '{{.Description | escape}}';
+COMMENT ON TABLE {{.Schema | tableName}} IS '{{.Schema.Description | escape}}';
+{{range .Table.Columns}}COMMENT ON COLUMN {{$.Schema | tableName}}.{{.Name}} IS '{{.Description | escape}}';
 {{end}}
-`
-	return g.executeTemplate(tmpl, s, namespace, path)
+{{end}}`
+	return g.executeTemplate(tmpl, h.Func("-- ", s), s, namespace, path)
 }
 
 func (g *Generator) generateDbtModel(s schema.Schema, namespace string, path string) error {
 	tmpl := `{#
   {{.Schema.Description}}
 
-  Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-  DO NOT EDIT.
-#}
+{{header}}#}
 
 {{ "{{" }} config(
     materialized='view',
@@ -101,48 +332,105 @@ func (g *Generator) generateDbtModel(s schema.Schema, namespace string, path str
 ) {{ "}}" }}
 
 SELECT
-{{range $i, $f := .Schema.Fields}}{{if $i}},
-{{end}}    {{$f.Name | snake}}{{end}}
-FROM {{ "{{" }} source('{{.Namespace | snake}}', '{{.Schema | schemaName | snake}}') {{ "}}" }}
+{{range $i, $f := .Fields}}{{if $i}},
+{{end}}    {{$f.Ident}}{{end}}
+{{- range .Derived}},
+    {{.Expr}} AS {{.Ident}}{{end}}
+FROM {{ "{{" }} source('{{.Namespace | snake}}', '{{.Schema | sourceName}}') {{ "}}" }}
+{{- with .Filter}}
+WHERE {{.}}{{end}}
 `
-	return g.executeTemplate(tmpl, s, namespace, path)
+	return g.executeTemplate(tmpl, g.header.Func("  ", s), s, namespace, path)
 }
 
-func (g *Generator) generateDbtSchema(schemas []schema.Schema, namespace string, path string) error {
-	tmpl := `# Generated by ehrglot v` + Version + ` at ` + time.Now().Format(time.RFC3339) + `.
-# DO NOT EDIT.
+// generateDbtSnapshot writes a dbt snapshot that keeps the history of a
+// staging model, keyed by the schema's natural key.
+func (g *Generator) generateDbtSnapshot(s schema.Schema, namespace string, path string) error {
+	tmpl := `{#
+  Snapshot of {{.Schema | schemaName}}
 
+{{header}}#}
+
+{{ "{%" }} snapshot snap_{{.Schema | sourceName}} {{ "%}" }}
+
+{{ "{{" }} config(
+    target_schema='{{.Namespace | snake}}_snapshots',
+    unique_key='{{.Key}}',
+    strategy='check',
+    check_cols='all'
+) {{ "}}" }}
+
+SELECT * FROM {{ "{{" }} ref('stg_{{.Schema | fileName}}') {{ "}}" }}
+
+{{ "{%" }} endsnapshot {{ "%}" }}
+`
+	return g.executeTemplate(tmpl, g.header.Func("  ", s), s, namespace, path)
+}
+
+func (g *Generator) generateDbtSchema(schemas []schema.Schema, namespace string, path string) error {
+	tmpl := `{{header}}
 version: 2
 
 sources:
   - name: {{.Namespace | snake}}
     tables:
-{{range .Schemas}}      - name: {{. | schemaName | snake}}
+{{range .Schemas}}      - name: {{. | sourceName}}
         description: "{{.Description | escape}}"
-        columns:
-{{range .Fields}}          - name: {{.Name | snake}}
+{{if or .Owner .Steward}}        meta:
+{{with .Owner}}          owner: "{{. | escape}}"
+{{end}}{{with .Steward}}          steward: "{{. | escape}}"
+{{end}}{{end}}{{with .Tags}}        tags:
+{{range .}}          - "{{. | escape}}"
+{{end}}{{end}}        columns:
+{{range .StoredFields}}          - name: {{.ColumnName (.Name | column)}}
             description: "{{.Description | escape}}"
-{{if .Required}}            tests:
+{{with .Encryption}}            meta:
+              encryption: {{.}}
+            tags:
+              - encrypted
+{{end}}{{if .Required}}            tests:
               - not_null
-{{end}}{{end}}{{end}}
+{{end}}{{end}}{{range history .}}          - name: {{.Name}}
+            description: "{{.Description | escape}}"
+{{end}}{{end}}
 
 models:
-{{range .Schemas}}  - name: stg_{{. | schemaName | snake}}
+{{range $s := .Schemas}}  - name: stg_{{. | fileName}}
     description: "Staging model for {{. | schemaName}}"
-    columns:
-{{range .Fields}}      - name: {{.Name | snake}}
+{{if or .Owner .Steward}}    meta:
+{{with .Owner}}      owner: "{{. | escape}}"
+{{end}}{{with .Steward}}      steward: "{{. | escape}}"
+{{end}}{{end}}{{with .Tags}}    tags:
+{{range .}}      - "{{. | escape}}"
+{{end}}{{end}}    columns:
+{{range .Fields}}      - name: {{.ColumnName (.Name | column)}}
         description: "{{.Description | escape}}"
-{{end}}{{end}}
+{{with .Encryption}}        meta:
+          encryption: {{.}}
+        tags:
+          - encrypted
+{{end}}{{if unique $s .Name}}        tests:
+          - unique
+{{end}}{{end}}{{end}}
 `
 	funcMap := template.FuncMap{
-		"snake":      toSnakeCase,
+		"snake":  naming.Snake.Apply,
+		"column": columnName,
+		"history": func(s schema.Schema) []historyColumn {
+			columns, _, _ := g.history(s)
+			return columns
+		},
+		"unique":     unique,
 		"escape":     escapeYaml,
+		"sourceName": g.sourceName,
+		"fileName":   g.fileName,
 		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"header":     g.header.Func("# ", schemas...),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -162,17 +450,41 @@ models:
 	return tmpl_parsed.Execute(f, data)
 }
 
-func (g *Generator) executeTemplate(tmplStr string, s schema.Schema, namespace string, path string) error {
+// unique reports whether field alone is a unique key of s. Staging models
+// hold only current rows, so they can test it even when the source table
+// keeps history.
+func unique(s schema.Schema, field string) bool {
+	for _, key := range s.Unique {
+		if len(key.Fields) == 1 && key.Fields[0] == field {
+			return true
+		}
+	}
+	return false
+}
+
+// columnName converts a field name to a column name. Leading underscores,
+// which mark audit columns such as _ingested_at, are kept.
+func columnName(name string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	return name[:len(name)-len(trimmed)] + naming.Snake.Apply(trimmed)
+}
+
+// executeTemplate renders tmplStr for s into path, with header bound as
+// {{header}}.
+func (g *Generator) executeTemplate(tmplStr string, header func() string, s schema.Schema, namespace string, path string) error {
 	funcMap := template.FuncMap{
-		"snake":      toSnakeCase,
-		"sqlType":    toSQLType,
+		"snake":      naming.Snake.Apply,
 		"escape":     escapeYaml,
+		"tableName":  g.tableName,
+		"sourceName": g.sourceName,
+		"fileName":   g.fileName,
 		"schemaName": func(s schema.Schema) string { return s.GetName() },
+		"header":     header,
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmplStr)
+	tmpl_parsed, err := g.templates.Parse(tmplStr, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -183,29 +495,117 @@ func (g *Generator) executeTemplate(tmplStr string, s schema.Schema, namespace s
 
 	data := struct {
 		Schema    schema.Schema
+		Fields    []naming.Field
+		Derived   []derivedColumn
+		Table     table
+		Filter    string
+		Key       string
 		Namespace string
 	}{
 		Schema:    s,
+		Fields:    g.fields(s),
 		Namespace: namespace,
 	}
+	data.Key = g.naturalKey(data.Fields)
+	if data.Table, err = g.table(s); err != nil {
+		return err
+	}
+	if _, data.Filter, err = g.history(s); err != nil {
+		return err
+	}
+	if data.Derived, err = g.derivedColumns(s); err != nil {
+		return err
+	}
 
 	return tmpl_parsed.Execute(f, data)
 }
 
-// GenerateMappings generates SQL/dbt mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
-	return nil
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(g.allFields(s))
 }
 
-func toSnakeCase(s string) string {
-	var result strings.Builder
-	for i, r := range s {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			result.WriteRune('_')
+// allFields returns the stored and derived fields of s.
+func (g *Generator) allFields(s schema.Schema) []naming.Field {
+	// A field's db_column names its column as code_name names identifiers
+	// in other languages.
+	fields := make([]schema.Field, len(s.Fields))
+	for i, f := range s.Fields {
+		if f.DBColumn != "" {
+			f.CodeName = f.DBColumn
 		}
-		result.WriteRune(r)
+		fields[i] = f
+	}
+	return naming.Fields("sql", fields, naming.Case(g.naming.Fields).Or(columnName))
+}
+
+// historyColumn is a column added to a table to track changes to its rows.
+type historyColumn struct {
+	Name        string
+	Definition  string
+	Description string
+}
+
+// history returns the columns the history mode of s adds to its table, and
+// the condition that selects the current, undeleted rows.
+func (g *Generator) history(s schema.Schema) ([]historyColumn, string, error) {
+	switch s.History {
+	case "":
+		return nil, "", nil
+	case schema.HistorySCD2:
+		return []historyColumn{
+			{Name: g.surrogateKey(s), Definition: g.dialect.identity, Description: "Surrogate key of this version of the record"},
+			{Name: "valid_from", Definition: g.timestampType + " NOT NULL", Description: "When this version became current"},
+			{Name: "valid_to", Definition: g.timestampType, Description: "When this version was superseded, or null while it is current"},
+			{Name: "is_current", Definition: g.withDefault(g.dialect.boolean+" NOT NULL", "TRUE"), Description: "Whether this is the current version"},
+		}, "is_current", nil
+	case schema.HistorySoftDelete:
+		return []historyColumn{
+			{Name: "is_deleted", Definition: g.withDefault(g.dialect.boolean+" NOT NULL", "FALSE"), Description: "Whether the record was deleted at the source"},
+			{Name: "deleted_at", Definition: g.timestampType, Description: "When the record was deleted"},
+		}, "NOT is_deleted", nil
+	default:
+		return nil, "", fmt.Errorf("%s: unknown history %q (want %s or %s)", s.GetName(), s.History, schema.HistorySCD2, schema.HistorySoftDelete)
 	}
-	return strings.ToLower(result.String())
+}
+
+// surrogateKey returns the name of the surrogate key column of an scd2 table.
+func (g *Generator) surrogateKey(s schema.Schema) string {
+	return g.sourceName(s) + "_sk"
+}
+
+// naturalKey returns the column that identifies a record across its
+// versions: its id, or else its first required column.
+func (g *Generator) naturalKey(fields []naming.Field) string {
+	for _, f := range fields {
+		if f.Name == "id" {
+			return f.Ident
+		}
+	}
+	for _, f := range fields {
+		if f.Required {
+			return f.Ident
+		}
+	}
+	return ""
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace's DDL and dbt models are generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return namespace
+}
+
+func (g *Generator) tableName(s schema.Schema) string {
+	return naming.Ident("sql", g.sourceName(s))
+}
+
+// sourceName is the unquoted table name used in dbt sources.
+func (g *Generator) sourceName(s schema.Schema) string {
+	return naming.Case(g.naming.Types).Or(naming.Snake.Apply)(s.GetName())
+}
+
+func (g *Generator) fileName(s schema.Schema) string {
+	return naming.Case(g.naming.Files).Or(naming.Snake.Apply)(s.GetName())
 }
 
 func escapeYaml(s string) string {
@@ -214,26 +614,39 @@ func escapeYaml(s string) string {
 	return s
 }
 
-func toSQLType(f schema.Field) string {
+func (g *Generator) toSQLType(f schema.Field) string {
+	if t, ok := g.scalarType(f); ok {
+		return t
+	}
+	// Arrays and complex types are stored as JSON
+	return g.dialect.json
+}
+
+// scalarType returns the column type of f, and whether f holds a single
+// value of its own type rather than JSON. JSON is text in some dialects, so
+// the types alone do not tell.
+func (g *Generator) scalarType(f schema.Field) (string, bool) {
 	switch f.Type {
 	case "string", "code", "id", "uri", "url":
-		return "VARCHAR(255)"
+		return g.dialect.varchar, true
 	case "integer", "positiveInt", "unsignedInt":
-		return "INTEGER"
+		return g.dialect.integer, true
 	case "decimal":
-		return "DECIMAL(18, 6)"
+		switch {
+		case f.Precision > 0 && f.Scale > 0:
+			return fmt.Sprintf("NUMERIC(%d, %d)", f.Precision, f.Scale), true
+		case f.Precision > 0:
+			return fmt.Sprintf("NUMERIC(%d)", f.Precision), true
+		}
+		return g.dialect.decimal, true
 	case "boolean":
-		return "BOOLEAN"
+		return g.dialect.boolean, true
 	case "date":
-		return "DATE"
+		return g.dateType, true
 	case "datetime", "instant":
-		return "TIMESTAMP"
+		return g.timestampType, true
 	case "base64Binary":
-		return "BYTEA"
-	default:
-		if strings.HasPrefix(f.Type, "[]") {
-			return "JSONB" // Arrays stored as JSON
-		}
-		return "JSONB" // Complex types as JSON
+		return g.dialect.bytes, true
 	}
+	return "", false
 }
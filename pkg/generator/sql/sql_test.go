@@ -0,0 +1,561 @@
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/diff"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/sql"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, sql.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:  config.DecimalExact,
+		Temporal: config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:     true,
+		Audit:    true,
+	}
+	generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestHistoryGolden(t *testing.T) {
+	schemas := []schema.Schema{
+		{
+			Name:        "patient_dim",
+			Description: "Patients, keeping every version",
+			Namespace:   "warehouse",
+			History:     schema.HistorySCD2,
+			Fields: []schema.Field{
+				{Name: "mrn", Type: "string", Required: true, Description: "Medical record number"},
+				{Name: "family_name", Type: "string", Description: "Family name"},
+			},
+		},
+		{
+			Name:        "encounter_fact",
+			Description: "Encounters, flagged when deleted at the source",
+			Namespace:   "warehouse",
+			History:     schema.HistorySoftDelete,
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+				{Name: "start", Type: "datetime", Description: "When the encounter began"},
+			},
+		},
+	}
+	generatortest.RunSchemas(t, sql.NewGenerator(config.Config{}), schemas, "testdata/history")
+}
+
+func TestHistoryErrors(t *testing.T) {
+	tests := []struct {
+		history string
+		fields  []schema.Field
+		want    string
+	}{
+		{"scd3", []schema.Field{{Name: "id", Type: "id"}}, `unknown history "scd3"`},
+		{schema.HistorySCD2, []schema.Field{{Name: "note", Type: "string"}}, "needs an id or required field"},
+	}
+	for _, tt := range tests {
+		s := schema.Schema{Name: "t", Namespace: "ns", History: tt.history, Fields: tt.fields}
+		err := sql.NewGenerator(config.Config{}).Generate(context.Background(), []schema.Schema{s}, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Generate(history %s) error = %v, want %q", tt.history, err, tt.want)
+		}
+	}
+}
+
+func TestPartitionGolden(t *testing.T) {
+	schemas := []schema.Schema{
+		{
+			Name:        "encounter_fact",
+			Description: "Encounters by day and facility",
+			Namespace:   "warehouse",
+			History:     schema.HistorySCD2,
+			PartitionBy: "start",
+			ClusterBy:   []string{"facility", "patient_id"},
+			Unique:      []schema.Index{{Fields: []string{"id"}}},
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+				{Name: "patient_id", Type: "id", Required: true, Description: "Patient id"},
+				{Name: "facility", Type: "code", Description: "Facility code"},
+				{Name: "start", Type: "datetime", Description: "When the encounter began"},
+			},
+		},
+		{
+			Name:        "claim_line",
+			Description: "Claim lines by payer",
+			Namespace:   "warehouse",
+			PartitionBy: "payer",
+			Unique:      []schema.Index{{Name: "claim_line_uq", Fields: []string{"id"}}},
+			Indexes:     []schema.Index{{Fields: []string{"service_date"}}},
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Claim line id"},
+				{Name: "payer", Type: "code", Required: true, Description: "Payer code"},
+				{Name: "service_date", Type: "date", Description: "Date of service"},
+				{Name: "amount", Type: "decimal", Description: "Billed amount"},
+			},
+		},
+	}
+	for _, dialect := range []string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake, config.DialectDelta, config.DialectIceberg} {
+		t.Run(dialect, func(t *testing.T) {
+			schemas := append([]schema.Schema(nil), schemas...)
+			if dialect == config.DialectBigQuery {
+				// BigQuery partitions by time only.
+				schemas[1].PartitionBy = "service_date"
+			}
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/"+dialect)
+		})
+	}
+}
+
+func TestPurposeGolden(t *testing.T) {
+	schemas := []schema.Schema{
+		{
+			Name:        "encounter_fact",
+			Description: "Encounters by day and facility",
+			Namespace:   "warehouse",
+			History:     schema.HistorySCD2,
+			Purposes:    []string{schema.PurposeTreatment, schema.PurposeOperations},
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+				{Name: "patient_id", Type: "id", Required: true, Purposes: []string{schema.PurposeTreatment}, Description: "Patient id"},
+				{Name: "facility", Type: "code", Description: "Facility code"},
+			},
+		},
+	}
+	for _, dialect := range []string{config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/purposes/"+dialect)
+		})
+	}
+}
+
+func TestPseudonymizeGolden(t *testing.T) {
+	for _, dialect := range []string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{
+				Pseudonymize: config.Pseudonymize{Level: "high"},
+				Languages:    map[string]config.Language{"sql": {Dialect: dialect}},
+			}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/pseudonymize/"+dialect)
+		})
+	}
+}
+
+func TestRetentionGolden(t *testing.T) {
+	for _, dialect := range []string{config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/retention/"+dialect)
+		})
+	}
+}
+
+func TestAuditTrailGolden(t *testing.T) {
+	tests := []struct {
+		dialect string
+		trail   string
+	}{
+		{config.DialectPostgres, config.AuditTrailTriggers},
+		{config.DialectPostgres, config.AuditTrailChanges},
+		{config.DialectBigQuery, config.AuditTrailChanges},
+		{config.DialectSnowflake, config.AuditTrailChanges},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dialect+"/"+tt.trail, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: tt.dialect, AuditTrail: tt.trail}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/audit_trail/"+tt.dialect+"/"+tt.trail)
+		})
+	}
+}
+
+func TestAuditTrailTriggersNeedPostgres(t *testing.T) {
+	cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: config.DialectSnowflake, AuditTrail: config.AuditTrailTriggers}}}
+	err := sql.NewGenerator(cfg).Generate(context.Background(), fixtures.Schemas(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "need the postgres dialect") {
+		t.Fatalf("Generate() error = %v, want one about the postgres dialect", err)
+	}
+}
+
+func TestLakehouseGolden(t *testing.T) {
+	events := schema.Schema{
+		Name:        "encounter_event",
+		Description: "Encounter events by day",
+		Namespace:   "warehouse",
+		History:     schema.HistorySoftDelete,
+		PartitionBy: "recorded",
+		Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true, Description: "Event id"},
+			{Name: "status", Type: "code", Default: "planned", Description: "Encounter status, such as 'planned'"},
+			{Name: "recorded", Type: "instant", Required: true, Description: "When the event was recorded"},
+		},
+	}
+	for _, dialect := range []string{config.DialectDelta, config.DialectIceberg} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{
+				Audit:     true,
+				Languages: map[string]config.Language{"sql": {Dialect: dialect, TableProperties: map[string]string{"write.target-file-size-bytes": "134217728"}}},
+			}
+			schemas := append(fixtures.Schemas(), events)
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/lakehouse/"+dialect)
+		})
+	}
+}
+
+func TestLakehouseUnsupported(t *testing.T) {
+	tests := []struct {
+		language config.Language
+		want     string
+	}{
+		{config.Language{Dialect: config.DialectDelta, AuditTrail: config.AuditTrailChanges}, "audit_trail needs"},
+		{config.Language{Dialect: config.DialectIceberg, TenantColumn: "tenant_id"}, "tenant_column needs"},
+		{config.Language{Dialect: config.DialectDelta, Terraform: true}, "bigquery or snowflake"},
+	}
+	for _, tt := range tests {
+		cfg := config.Config{Languages: map[string]config.Language{"sql": tt.language}}
+		err := sql.NewGenerator(cfg).Generate(context.Background(), fixtures.Schemas(), t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Generate(%s) error = %v, want %q", tt.language.Dialect, err, tt.want)
+		}
+	}
+}
+
+func TestTerraformGolden(t *testing.T) {
+	encounters := schema.Schema{
+		Name:        "encounter_fact",
+		Description: "Encounters by day and facility",
+		Namespace:   "warehouse",
+		History:     schema.HistorySCD2,
+		PartitionBy: "start",
+		ClusterBy:   []string{"facility"},
+		Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+			{Name: "patient_name", Type: "string", PIILevel: "high", Description: "Patient name"},
+			{Name: "facility", Type: "code", Description: "Facility code"},
+			{Name: "start", Type: "datetime", Description: "When the encounter began"},
+		},
+	}
+	for _, dialect := range []string{config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect, Terraform: true}}}
+			schemas := append(fixtures.Schemas(), encounters)
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/terraform/"+dialect)
+		})
+	}
+}
+
+func TestTerraformNeedsWarehouse(t *testing.T) {
+	cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: config.DialectPostgres, Terraform: true}}}
+	err := sql.NewGenerator(cfg).Generate(context.Background(), fixtures.Schemas(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "bigquery or snowflake") {
+		t.Fatalf("Generate() error = %v, want one about the bigquery or snowflake dialect", err)
+	}
+}
+
+func TestBQLoadGolden(t *testing.T) {
+	encounters := schema.Schema{
+		Name:        "encounter_fact",
+		Description: "Encounters by day and facility",
+		Namespace:   "warehouse",
+		PartitionBy: "start",
+		ClusterBy:   []string{"facility"},
+		Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+			{Name: "facility", Type: "code", JSONName: "facilityCode", Description: "Facility code"},
+			{Name: "start", Type: "datetime", Description: "When the encounter began"},
+			{Name: "diagnoses", Type: "[]CodeableConcept", Description: "Diagnoses made"},
+		},
+	}
+	cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: config.DialectBigQuery, BQLoad: true}}}
+	generatortest.RunSchemas(t, sql.NewGenerator(cfg), append(fixtures.Schemas(), encounters), "testdata/bq_load")
+}
+
+func TestBQLoadNeedsBigQuery(t *testing.T) {
+	cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: config.DialectSnowflake, BQLoad: true}}}
+	err := sql.NewGenerator(cfg).Generate(context.Background(), fixtures.Schemas(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "bigquery dialect") {
+		t.Fatalf("Generate() error = %v, want one about the bigquery dialect", err)
+	}
+}
+
+func TestTenantGolden(t *testing.T) {
+	for _, dialect := range []string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect, TenantColumn: "tenant_id"}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/tenant/"+dialect)
+		})
+	}
+}
+
+func TestWriteAlterGolden(t *testing.T) {
+	old := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "medical_record_number", Type: "string", UID: "p1"},
+			{Name: "fax", Type: "string"},
+			{Name: "gender", Type: "string"},
+			{Name: "visits", Type: "integer"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{{Name: "name", Type: "string"}}},
+		}},
+		{Name: "Legacy", Namespace: "clinic", Fields: []schema.Field{{Name: "id", Type: "id"}}},
+	}
+	new := []schema.Schema{
+		{Name: "Patient", Namespace: "clinic", Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "mrn", Type: "string", UID: "p1", Required: true},
+			{Name: "gender", Type: "code"},
+			{Name: "visits", Type: "decimal"},
+			{Name: "birth_date", Type: "date", Required: true},
+			{Name: "active", Type: "boolean", Required: true, Default: "true"},
+			{Name: "language", Type: "code", Default: "en-US"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{{Name: "name", Type: "string"}, {Name: "phone", Type: "string"}}},
+		}},
+		{Name: "Claim", Namespace: "billing", Description: "A claim", Fields: []schema.Field{{Name: "id", Type: "id", Required: true}, {Name: "amount", Type: "decimal"}}},
+	}
+	changes, err := diff.Compare(old, new)
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	for _, dialect := range []string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake, config.DialectDelta, config.DialectIceberg} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: dialect}}}
+			var buf bytes.Buffer
+			if err := sql.NewGenerator(cfg).WriteAlter(&buf, changes); err != nil {
+				t.Fatalf("WriteAlter() failed: %v", err)
+			}
+			got := generatortest.Normalize(buf.Bytes())
+			path := filepath.Join("testdata", "alter", dialect+".sql")
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create golden directory: %v", err)
+				}
+				if err := os.WriteFile(path, got, 0644); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("WriteAlter() differs from %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+func TestPartitionErrors(t *testing.T) {
+	fields := []schema.Field{{Name: "id", Type: "id", Required: true}, {Name: "status", Type: "code"}}
+	tests := []struct {
+		dialect   string
+		partition string
+		cluster   []string
+		unique    []schema.Index
+		want      string
+	}{
+		{config.DialectPostgres, "missing", nil, nil, "partition_by missing is not a field"},
+		{config.DialectSnowflake, "", []string{"id", "missing"}, nil, "cluster_by missing is not a field"},
+		{config.DialectBigQuery, "status", nil, nil, "must be a date or timestamp field"},
+		{config.DialectBigQuery, "", []string{"id", "status", "id", "status", "id"}, nil, "at most 4 fields"},
+		{config.DialectDelta, "id", []string{"status", "id", "status", "id"}, nil, "at most 4 fields"},
+		{config.DialectPostgres, "", nil, []schema.Index{{Fields: []string{"mrn"}}}, "unique[0] names mrn, which is not a field"},
+		{config.DialectPostgres, "", nil, []schema.Index{{}}, "unique[0] names no fields"},
+	}
+	for _, tt := range tests {
+		s := schema.Schema{Name: "t", Namespace: "ns", PartitionBy: tt.partition, ClusterBy: tt.cluster, Unique: tt.unique, Fields: fields}
+		cfg := config.Config{Languages: map[string]config.Language{"sql": {Dialect: tt.dialect}}}
+		err := sql.NewGenerator(cfg).Generate(context.Background(), []schema.Schema{s}, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Generate(%s) error = %v, want %q", tt.dialect, err, tt.want)
+		}
+	}
+}
+
+func TestMigrationsGolden(t *testing.T) {
+	schemas := []schema.Schema{
+		{
+			Name:        "patient_dim",
+			Description: "Patients & their <current> names",
+			Namespace:   "warehouse",
+			Fields: []schema.Field{
+				{Name: "mrn", Type: "string", Required: true, Description: "Medical record number"},
+				{Name: "family_name", Type: "string", Description: "Family name"},
+			},
+		},
+		{
+			Name:        "encounter_fact",
+			Description: "Encounters",
+			Namespace:   "warehouse",
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Encounter id"},
+				{Name: "start", Type: "datetime", Description: "When the encounter began"},
+			},
+		},
+	}
+	for _, format := range []string{config.MigrationsFlyway, config.MigrationsLiquibaseXML, config.MigrationsLiquibaseYAML} {
+		t.Run(format, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Migrations: format}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/"+format)
+		})
+	}
+}
+
+func TestFlattenGolden(t *testing.T) {
+	for _, strategy := range []string{schema.FlattenDelimited, schema.FlattenTables} {
+		t.Run(strategy, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Flatten: strategy}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/flatten/"+strategy)
+		})
+	}
+}
+
+func TestFlattenErrors(t *testing.T) {
+	for _, s := range []schema.Schema{
+		{Name: "note", Flatten: schema.FlattenTables, Fields: []schema.Field{{Name: "text", Type: "string"}, {Name: "tags", Type: "[]string"}}},
+		{Name: "note", Flatten: schema.FlattenTables, History: schema.HistorySoftDelete, Fields: []schema.Field{{Name: "id", Type: "id"}, {Name: "tags", Type: "[]string"}}},
+		{Name: "note", Flatten: "wide", Fields: []schema.Field{{Name: "id", Type: "id"}}},
+	} {
+		if err := sql.NewGenerator(config.Config{}).Generate(context.Background(), []schema.Schema{s}, t.TempDir()); err == nil {
+			t.Errorf("Generate(%+v) succeeded, want error", s)
+		}
+	}
+}
+
+func TestLineageGolden(t *testing.T) {
+	generatortest.RunMappings(t, sql.NewGenerator(config.Config{}), fixtures.Mappings(), "testdata/lineage")
+}
+
+func TestCrosswalkGolden(t *testing.T) {
+	cfg := config.Config{Crosswalk: generatortest.Crosswalk(t)}
+	generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/crosswalk")
+}
+
+func TestConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
+
+func TestQualityGolden(t *testing.T) {
+	schemas := []schema.Schema{
+		{
+			Name:        "lab_order",
+			Description: "Lab orders",
+			Namespace:   "warehouse",
+			Fields: []schema.Field{
+				{Name: "id", Type: "id", Required: true, Description: "Order id"},
+				{Name: "status", Type: "code", Required: true, Description: "Order status", Enum: []string{"draft", "active", "won't-do"}},
+				{Name: "priority", Type: "code", Description: "Order priority"},
+				{Name: "placer", Type: "string", Description: "Placer order number"},
+				{Name: "placer_system", Type: "uri", Description: "System of the placer order number"},
+				{Name: "ordered_at", Type: "datetime", Description: "When the order was placed"},
+				{Name: "tube_count", Type: "integer", Description: "Tubes drawn", ReferenceLow: "1", ReferenceHigh: "6"},
+			},
+			Unique: []schema.Index{{Fields: []string{"id"}}, {Fields: []string{"placer_system", "placer"}}},
+		},
+		{
+			Name:        "patient_dim",
+			Description: "Patients",
+			Namespace:   "warehouse",
+			History:     schema.HistorySCD2,
+			Fields: []schema.Field{
+				{Name: "mrn", Type: "string", Required: true, Description: "Medical record number"},
+				{Name: "birth_date", Type: "date", Description: "Date of birth"},
+			},
+			Unique: []schema.Index{{Fields: []string{"mrn"}}},
+		},
+	}
+	for _, format := range []string{config.QualityGreatExpectations, config.QualitySoda} {
+		t.Run(format, func(t *testing.T) {
+			cfg := config.Config{Languages: map[string]config.Language{"sql": {Quality: format}}}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), schemas, "testdata/"+format)
+		})
+	}
+}
+
+func TestMatchGolden(t *testing.T) {
+	for _, dialect := range []string{config.DialectPostgres, config.DialectBigQuery, config.DialectSnowflake} {
+		t.Run(dialect, func(t *testing.T) {
+			cfg := config.Config{
+				Languages: map[string]config.Language{"sql": {Dialect: dialect}},
+				Matching:  config.Matching{Phonetic: config.PhoneticSoundex},
+			}
+			generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.MatchSchemas(), "testdata/match/"+dialect)
+		})
+	}
+	t.Run("plain", func(t *testing.T) {
+		cfg := config.Config{Temporal: config.Temporal{Dates: config.DatesPartial}}
+		generatortest.RunSchemas(t, sql.NewGenerator(cfg), fixtures.MatchSchemas(), "testdata/match/plain")
+	})
+}
+
+func TestMatchMetaphoneUnsupported(t *testing.T) {
+	cfg := config.Config{Matching: config.Matching{Phonetic: config.PhoneticMetaphone}}
+	err := sql.NewGenerator(cfg).Generate(context.Background(), fixtures.MatchSchemas(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "use soundex") {
+		t.Fatalf("Generate() error = %v, want one suggesting soundex", err)
+	}
+}
+
+func TestViewsCastToTargetTypes(t *testing.T) {
+	gen := sql.NewGenerator(config.Config{})
+	dir := t.TempDir()
+	if err := gen.Generate(context.Background(), fixtures.Schemas(), dir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if err := gen.GenerateMappings(context.Background(), fixtures.Mappings(), dir); err != nil {
+		t.Fatalf("GenerateMappings() failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, sql.ViewsDir, "lis_specimen_specimen.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// With the schemas generated first, target field types decide casts too.
+	for _, want := range []string{
+		"COALESCE(CAST(CONTAINERS AS INTEGER), 1) AS container_count,",
+		"CAST(RECV_DTTM AS TIMESTAMP) AS received_time,",
+		"ACCESSION_NUM AS accession_identifier_value,",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("view is missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestNumericPrecision(t *testing.T) {
+	schemas := []schema.Schema{{
+		Name:        "claim_line",
+		Description: "Lines of a claim",
+		Namespace:   "billing",
+		Fields: []schema.Field{
+			{Name: "charge", Type: "decimal", Precision: 12, Scale: 2},
+			{Name: "units", Type: "decimal", Precision: 5},
+			{Name: "ratio", Type: "decimal"},
+		},
+	}}
+	dir := t.TempDir()
+	if err := sql.NewGenerator(config.Config{}).Generate(context.Background(), schemas, dir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "billing", "ddl", "claim_line.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"charge NUMERIC(12, 2)", "units NUMERIC(5)", "ratio DECIMAL(18, 6)"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("DDL is missing %q:\n%s", want, data)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// injectTenant returns schemas with the tenant column prepended to each
+// schema's fields and leading each of its unique keys, so that keys are
+// unique per tenant. A schema that already declares a field under the
+// column's name keeps its own declaration.
+func (g *Generator) injectTenant(schemas []schema.Schema) []schema.Schema {
+	injected := make([]schema.Schema, len(schemas))
+	for i, s := range schemas {
+		declared := false
+		for _, f := range s.Fields {
+			declared = declared || f.Name == g.tenant
+		}
+		if !declared {
+			tenant := schema.Field{Name: g.tenant, Type: "string", Required: true, Description: "Tenant the record belongs to"}
+			s.Fields = append([]schema.Field{tenant}, s.Fields...)
+		}
+		unique := make([]schema.Index, len(s.Unique))
+		for j, index := range s.Unique {
+			if !contains(index.Fields, g.tenant) {
+				index.Fields = append([]string{g.tenant}, index.Fields...)
+			}
+			unique[j] = index
+		}
+		s.Unique = unique
+		injected[i] = s
+	}
+	return injected
+}
+
+// tenantColumn returns the tenant column of s.
+func (g *Generator) tenantColumn(s schema.Schema) string {
+	for _, f := range g.fields(s) {
+		if f.Name == g.tenant {
+			return f.Ident
+		}
+	}
+	return ""
+}
+
+// tenantAccess returns the table that maps the principals of a namespace to
+// the tenants they may read, in dialects that isolate tenants with views.
+func (g *Generator) tenantAccess(namespace string) string {
+	return naming.Ident("sql", naming.Snake.Apply(namespace)+"_tenant_access")
+}
+
+// tenantFilter returns the condition that selects the rows of s the reader
+// may see, in dialects that isolate tenants with views: those of the tenants
+// the tenant access table grants the current role (Snowflake) or user
+// (BigQuery).
+func (g *Generator) tenantFilter(s schema.Schema) string {
+	principal := "CURRENT_ROLE()"
+	if g.dialectName == config.DialectBigQuery {
+		principal = "SESSION_USER()"
+	}
+	return fmt.Sprintf("%s IN (SELECT tenant_id FROM %s WHERE principal = %s)", g.tenantColumn(s), g.tenantAccess(s.Namespace), principal)
+}
+
+// generateTenantIsolation writes the controls that keep each tenant to its
+// own rows of a namespace's tables: in Postgres a row-level security policy
+// per table keyed on the session's ehrglot.tenant_id setting, and otherwise
+// a secure view per table keyed on a table of the tenants each principal may
+// read.
+func (g *Generator) generateTenantIsolation(schemas []schema.Schema, namespace string, path string) error {
+	tmpl := `-- Tenant isolation of the {{.Namespace}} tables
+--
+{{header}}
+{{- if .RLS}}
+-- Sessions set ehrglot.tenant_id to the tenant they act for, and read and
+-- write only its rows. Table owners, which load them, are exempt.
+{{- range .Schemas}}
+
+ALTER TABLE {{. | tableName}} ENABLE ROW LEVEL SECURITY;
+
+{{- if .HasPurposes}}
+
+-- Restrictive, so that the purpose-of-use policy of the table also holds.
+CREATE POLICY {{. | sourceName}}_tenant_isolation ON {{. | tableName}}
+    AS RESTRICTIVE
+{{- else}}
+
+CREATE POLICY {{. | sourceName}}_tenant_isolation ON {{. | tableName}}
+{{- end}}
+    USING ({{. | tenantColumn}} = current_setting('ehrglot.tenant_id', true))
+    WITH CHECK ({{. | tenantColumn}} = current_setting('ehrglot.tenant_id', true));
+{{- end}}
+{{- else}}
+-- Each row grants a principal the rows of a tenant: a role in Snowflake, a
+-- user in BigQuery. Grant readers the views below rather than the tables.
+CREATE TABLE IF NOT EXISTS {{.Access}} (
+    principal {{.Varchar}} NOT NULL,
+    tenant_id {{.Varchar}} NOT NULL,
+    PRIMARY KEY (principal, tenant_id){{if .NotEnforced}} NOT ENFORCED{{end}}
+);
+{{- range .Schemas}}
+
+CREATE OR REPLACE {{$.View}} {{. | view}} AS
+SELECT *
+FROM {{. | tableName}}
+WHERE {{. | tenantFilter}};
+{{- end}}
+{{- end}}
+`
+	funcMap := template.FuncMap{
+		"tableName":    g.tableName,
+		"sourceName":   g.sourceName,
+		"tenantColumn": g.tenantColumn,
+		"tenantFilter": g.tenantFilter,
+		"view": func(s schema.Schema) string {
+			return naming.Ident("sql", g.sourceName(s)+"_tenant")
+		},
+		"header": g.header.Func("-- ", schemas...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Namespace   string
+		Schemas     []schema.Schema
+		RLS         bool
+		Access      string
+		Varchar     string
+		NotEnforced bool
+		View        string
+	}{
+		Namespace:   namespace,
+		Schemas:     schemas,
+		RLS:         g.dialectName == config.DialectPostgres,
+		Access:      g.tenantAccess(namespace),
+		Varchar:     g.dialect.varchar,
+		NotEnforced: g.dialectName == config.DialectBigQuery,
+		View:        "VIEW",
+	}
+	if g.dialectName == config.DialectSnowflake {
+		data.View = "SECURE VIEW"
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,433 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// TerraformDir is the directory, within the directory of a namespace, that
+// holds its Terraform configuration.
+const TerraformDir = "terraform"
+
+// terraformColumn is a column of a table as a Terraform resource declares
+// it.
+type terraformColumn struct {
+	Name        string
+	Type        string
+	Required    bool
+	Default     string
+	Identity    bool
+	Description string
+	// PIILevel is the pii_level of the column, or "" when it holds no
+	// personal information.
+	PIILevel string
+}
+
+// terraformTable is the Terraform resource of a table.
+type terraformTable struct {
+	Schema schema.Schema
+	// Label names the resource within the configuration of its namespace.
+	Label   string
+	Name    string
+	Columns []terraformColumn
+	Table   table
+	// Tags are the columns of each pii_level, in the order of PIILevels.
+	Tags []piiTag
+}
+
+// piiTag is the columns of a table tagged with one pii_level.
+type piiTag struct {
+	Level   string
+	Columns []string
+}
+
+// labelChars matches the characters Terraform resource labels cannot hold.
+var labelChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// generateTerraform writes a Terraform configuration of the tables of a
+// namespace: a file per table declaring it as a google_bigquery_table or
+// snowflake_table, and a main.tf declaring the providers, variables, and the
+// policy tags, or Snowflake tag, that mark the pii_level of columns. Keys,
+// indexes, and statements that follow the tables stay in the DDL.
+func (g *Generator) generateTerraform(schemas []schema.Schema, namespace, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create terraform directory: %w", err)
+	}
+	var tables []terraformTable
+	used := make(map[string]bool)
+	for _, s := range schemas {
+		t, err := g.terraformTable(s)
+		if err != nil {
+			return err
+		}
+		for _, tag := range t.Tags {
+			used[tag.Level] = true
+		}
+		if err := g.writeTerraform(g.terraformTableTemplate(), t, []schema.Schema{s}, filepath.Join(dir, g.fileName(s)+".tf")); err != nil {
+			return err
+		}
+		tables = append(tables, t)
+	}
+	var levels []string
+	for _, level := range schema.PIILevels[1:] {
+		if used[level] {
+			levels = append(levels, level)
+		}
+	}
+
+	data := struct {
+		Namespace string
+		Schema    string
+		Levels    []string
+		// AllLevels are the values the Snowflake tag allows.
+		AllLevels []string
+	}{
+		Namespace: namespace,
+		Schema:    naming.Snake.Apply(namespace),
+		Levels:    levels,
+		AllLevels: schema.PIILevels[1:],
+	}
+	if g.dialectName == config.DialectSnowflake {
+		data.Schema = strings.ToUpper(data.Schema)
+	}
+	var sources []schema.Schema
+	for _, t := range tables {
+		sources = append(sources, t.Schema)
+	}
+	return g.writeTerraform(g.terraformMainTemplate(), data, sources, filepath.Join(dir, "main.tf"))
+}
+
+// terraformTable returns the Terraform resource of the table of s.
+func (g *Generator) terraformTable(s schema.Schema) (terraformTable, error) {
+	layout, err := g.table(s)
+	if err != nil {
+		return terraformTable{}, err
+	}
+	t := terraformTable{Schema: s, Label: terraformLabel(g.sourceName(s)), Name: g.objectName(g.tableName(s)), Table: layout}
+
+	tagged := make(map[string][]string)
+	for _, f := range g.fields(s) {
+		c := terraformColumn{Name: g.objectName(f.Ident), Type: g.toSQLType(f.Field), Required: f.Required, Description: columnDescription(f.Field)}
+		if f.Default != "" {
+			c.Default = sqlLiteral(f.Field)
+		}
+		if f.Name == audit.IngestedAt {
+			c.Default = g.dialect.now
+		}
+		if rank := schema.PIIRank(f.PIILevel); rank > 0 {
+			c.PIILevel = schema.PIILevels[rank]
+			tagged[c.PIILevel] = append(tagged[c.PIILevel], c.Name)
+		}
+		t.Columns = append(t.Columns, c)
+	}
+	history, _, err := g.history(s)
+	if err != nil {
+		return terraformTable{}, err
+	}
+	for _, h := range history {
+		// History columns are declared as DDL; take their parts apart.
+		c := terraformColumn{Name: g.objectName(h.Name), Description: h.Description}
+		definition, def, _ := strings.Cut(h.Definition, " DEFAULT ")
+		definition, c.Required = strings.CutSuffix(definition, " NOT NULL")
+		c.Type, c.Identity = strings.CutSuffix(definition, " IDENTITY")
+		c.Default = def
+		t.Columns = append(t.Columns, c)
+	}
+
+	for _, level := range schema.PIILevels[1:] {
+		if columns := tagged[level]; len(columns) > 0 {
+			t.Tags = append(t.Tags, piiTag{Level: level, Columns: columns})
+		}
+	}
+	return t, nil
+}
+
+// objectName returns the name of the database object of identifier ident,
+// as Terraform providers take it: unquoted, and in Snowflake in upper case
+// unless quoted, as Snowflake resolves the identifiers of the DDL.
+func (g *Generator) objectName(ident string) string {
+	if unquoted, ok := strings.CutPrefix(ident, `"`); ok {
+		return strings.TrimSuffix(unquoted, `"`)
+	}
+	if g.dialectName == config.DialectSnowflake {
+		return strings.ToUpper(ident)
+	}
+	return ident
+}
+
+// terraformLabel returns name as a Terraform resource label.
+func terraformLabel(name string) string {
+	label := labelChars.ReplaceAllString(strings.Trim(name, `"`), "_")
+	if label == "" || label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	return label
+}
+
+// bigQueryField returns the attributes of the BigQuery schema JSON field of
+// c, with the type parameters of the DDL type as their own attributes.
+func bigQueryField(c terraformColumn) [][2]string {
+	typeName, params, _ := strings.Cut(strings.TrimSuffix(c.Type, ")"), "(")
+	switch typeName {
+	case "INT64":
+		typeName = "INTEGER"
+	case "BOOL":
+		typeName = "BOOLEAN"
+	}
+	mode := "NULLABLE"
+	if c.Required {
+		mode = "REQUIRED"
+	}
+	attrs := [][2]string{{"name", hclString(c.Name)}, {"type", hclString(typeName)}, {"mode", hclString(mode)}}
+	if params != "" {
+		precision, scale, hasScale := strings.Cut(params, ",")
+		if typeName == "STRING" || typeName == "BYTES" {
+			attrs = append(attrs, [2]string{"maxLength", strings.TrimSpace(precision)})
+		} else {
+			attrs = append(attrs, [2]string{"precision", strings.TrimSpace(precision)})
+			if hasScale {
+				attrs = append(attrs, [2]string{"scale", strings.TrimSpace(scale)})
+			}
+		}
+	}
+	if c.Default != "" {
+		attrs = append(attrs, [2]string{"defaultValueExpression", hclString(c.Default)})
+	}
+	if c.Description != "" {
+		attrs = append(attrs, [2]string{"description", hclString(c.Description)})
+	}
+	if c.PIILevel != "" {
+		attrs = append(attrs, [2]string{"policyTags", "{ names = [google_data_catalog_policy_tag.pii_" + c.PIILevel + ".id] }"})
+	}
+	return attrs
+}
+
+// snowflakeColumn returns the attributes of the column block of c.
+func snowflakeColumn(c terraformColumn) [][2]string {
+	attrs := [][2]string{{"name", hclString(c.Name)}, {"type", hclString(c.Type)}, {"nullable", strconv.FormatBool(!c.Required)}}
+	if c.Description != "" {
+		attrs = append(attrs, [2]string{"comment", hclString(c.Description)})
+	}
+	return attrs
+}
+
+// attributes writes attrs one per line at indent, with their equals signs
+// aligned as terraform fmt aligns them.
+func attributes(indent string, attrs [][2]string) string {
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a[0]))
+	}
+	var b strings.Builder
+	for i, a := range attrs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s%-*s = %s", indent, width, a[0], a[1])
+	}
+	return b.String()
+}
+
+// hclString quotes s as an HCL string, escaping template sequences so that
+// descriptions are taken literally.
+func hclString(s string) string {
+	s = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"${", "$${",
+		"%{", "%%{",
+	).Replace(strings.TrimSpace(s))
+	return `"` + s + `"`
+}
+
+// hclList quotes values as an HCL list of strings.
+func hclList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = hclString(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func (g *Generator) terraformTableTemplate() string {
+	if g.dialectName == config.DialectBigQuery {
+		return `{{header}}
+resource "google_bigquery_table" "{{.Label}}" {
+  dataset_id  = var.dataset_id
+  table_id    = {{hcl .Name}}
+  description = {{hcl .Schema.Description}}
+{{- with .Table.Partition}}
+
+  time_partitioning {
+    type  = "DAY"
+    field = {{hcl .Name}}
+  }
+{{- end}}
+{{- with .Table.Cluster}}
+
+  clustering = {{hclList .}}
+{{- end}}
+
+  schema = jsonencode([
+{{- range .Columns}}
+    {
+{{bigQueryField . | attributes "      "}}
+    },
+{{- end}}
+  ])
+}
+`
+	}
+	return `{{header}}
+resource "snowflake_table" "{{.Label}}" {
+  database = var.database
+  schema   = var.schema
+  name     = {{hcl .Name}}
+  comment  = {{hcl .Schema.Description}}
+{{- with .Table.Cluster}}
+
+  cluster_by = {{hclList .}}
+{{- end}}
+{{- range .Columns}}
+
+  column {
+{{snowflakeColumn . | attributes "    "}}
+{{- if .Identity}}
+
+    identity {
+      start_num = 1
+      step_num  = 1
+    }
+{{- end}}
+{{- with .Default}}
+
+    default {
+      expression = {{hcl .}}
+    }
+{{- end}}
+  }
+{{- end}}
+}
+{{- $table := .}}
+{{- range .Tags}}
+
+resource "snowflake_tag_association" "{{$table.Label}}_pii_{{.Level}}" {
+  object_identifiers = [{{range $i, $c := .Columns}}{{if $i}}, {{end}}"${snowflake_table.{{$table.Label}}.fully_qualified_name}.\"{{$c}}\""{{end}}]
+  object_type        = "COLUMN"
+  tag_id             = snowflake_tag.pii_level.fully_qualified_name
+  tag_value          = "{{.Level}}"
+}
+{{- end}}
+`
+}
+
+func (g *Generator) terraformMainTemplate() string {
+	if g.dialectName == config.DialectBigQuery {
+		return `{{header}}
+terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+}
+
+variable "dataset_id" {
+  type        = string
+  description = "BigQuery dataset of the {{.Namespace}} tables"
+  default     = {{hcl .Schema}}
+}
+{{- if .Levels}}
+
+variable "region" {
+  type        = string
+  description = "Region of the policy tag taxonomy, which must be the location of the dataset"
+  default     = "us"
+}
+
+resource "google_data_catalog_taxonomy" "pii" {
+  region                 = var.region
+  display_name           = "{{.Namespace}} PII"
+  description            = "Sensitivity of the personal information in columns of the {{.Namespace}} tables"
+  activated_policy_types = ["FINE_GRAINED_ACCESS_CONTROL"]
+}
+{{- range .Levels}}
+
+resource "google_data_catalog_policy_tag" "pii_{{.}}" {
+  taxonomy     = google_data_catalog_taxonomy.pii.id
+  display_name = "{{.}}"
+  description  = "Columns of pii_level {{.}}"
+}
+{{- end}}
+{{- end}}
+`
+	}
+	return `{{header}}
+terraform {
+  required_providers {
+    snowflake = {
+      source = "Snowflake-Labs/snowflake"
+    }
+  }
+}
+
+variable "database" {
+  type        = string
+  description = "Snowflake database of the {{.Namespace}} tables"
+}
+
+variable "schema" {
+  type        = string
+  description = "Snowflake schema of the {{.Namespace}} tables"
+  default     = {{hcl .Schema}}
+}
+{{- if .Levels}}
+
+resource "snowflake_tag" "pii_level" {
+  database       = var.database
+  schema         = var.schema
+  name           = "PII_LEVEL"
+  comment        = "Sensitivity of the personal information in a column"
+  allowed_values = {{hclList .AllLevels}}
+}
+{{- end}}
+`
+}
+
+// writeTerraform executes tmpl with data into path, with a header naming
+// sources.
+func (g *Generator) writeTerraform(tmpl string, data any, sources []schema.Schema, path string) error {
+	funcMap := template.FuncMap{
+		"hcl":             hclString,
+		"hclList":         hclList,
+		"attributes":      attributes,
+		"bigQueryField":   bigQueryField,
+		"snowflakeColumn": snowflakeColumn,
+		"header":          g.header.Func("# ", sources...),
+	}
+	tmpl_parsed, err := g.templates.Parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, data)
+}
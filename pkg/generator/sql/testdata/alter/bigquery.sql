@@ -0,0 +1,45 @@
+-- Migration of changed schemas
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- billing.Claim: added
+CREATE TABLE IF NOT EXISTS claim (
+    id STRING NOT NULL OPTIONS(description=""),
+    amount NUMERIC(18, 6) OPTIONS(description="")
+)
+OPTIONS(description="A claim");
+
+-- clinic.Patient.mrn: renamed from medical_record_number
+ALTER TABLE patient RENAME COLUMN medical_record_number TO mrn;
+
+-- clinic.Patient.fax: removed
+ALTER TABLE patient DROP COLUMN fax;
+
+-- clinic.Patient.birth_date: added (date)
+ALTER TABLE patient ADD COLUMN birth_date DATE;
+-- BigQuery cannot make birth_date required; check it with a dbt not_null test.
+
+-- clinic.Patient.active: added (boolean)
+ALTER TABLE patient ADD COLUMN active BOOL;
+UPDATE patient SET active = TRUE WHERE active IS NULL;
+-- BigQuery cannot make active required; check it with a dbt not_null test.
+
+-- clinic.Patient.language: added (code)
+ALTER TABLE patient ADD COLUMN language STRING;
+UPDATE patient SET language = 'en-US' WHERE language IS NULL;
+
+-- clinic.Patient.contact.phone: added (string)
+-- Held in the contact column; no DDL needed.
+
+-- clinic.Patient.gender: type changed from string to code
+-- gender is STRING either way; no DDL needed.
+
+-- clinic.Patient.visits: type changed from integer to decimal
+ALTER TABLE patient ALTER COLUMN visits SET DATA TYPE NUMERIC(18, 6);
+
+-- clinic.Patient.mrn: now required
+-- BigQuery cannot make mrn required; check it with a dbt not_null test.
+
+-- clinic.Legacy: removed
+DROP TABLE IF EXISTS legacy;
@@ -0,0 +1,51 @@
+-- Migration of changed schemas
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- billing.Claim: added
+CREATE TABLE IF NOT EXISTS claim (
+    id STRING NOT NULL,
+    amount DECIMAL(18, 6)
+)
+USING DELTA
+COMMENT 'A claim'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name'
+);
+
+-- clinic.Patient.mrn: renamed from medical_record_number
+ALTER TABLE patient RENAME COLUMN medical_record_number TO mrn;
+
+-- clinic.Patient.fax: removed
+ALTER TABLE patient DROP COLUMN fax;
+
+-- clinic.Patient.birth_date: added (date)
+ALTER TABLE patient ADD COLUMN birth_date DATE;
+-- Backfill birth_date, then:
+-- ALTER TABLE patient ALTER COLUMN birth_date SET NOT NULL;
+
+-- clinic.Patient.active: added (boolean)
+ALTER TABLE patient ADD COLUMN active BOOLEAN;
+UPDATE patient SET active = TRUE WHERE active IS NULL;
+ALTER TABLE patient ALTER COLUMN active SET NOT NULL;
+
+-- clinic.Patient.language: added (code)
+ALTER TABLE patient ADD COLUMN language STRING;
+UPDATE patient SET language = 'en-US' WHERE language IS NULL;
+
+-- clinic.Patient.contact.phone: added (string)
+-- Held in the contact column; no DDL needed.
+
+-- clinic.Patient.gender: type changed from string to code
+-- gender is STRING either way; no DDL needed.
+
+-- clinic.Patient.visits: type changed from integer to decimal
+ALTER TABLE patient ALTER COLUMN visits TYPE DECIMAL(18, 6);
+
+-- clinic.Patient.mrn: now required
+-- Backfill mrn, then:
+-- ALTER TABLE patient ALTER COLUMN mrn SET NOT NULL;
+
+-- clinic.Legacy: removed
+DROP TABLE IF EXISTS legacy;
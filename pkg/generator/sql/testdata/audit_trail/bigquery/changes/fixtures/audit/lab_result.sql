@@ -0,0 +1,28 @@
+-- Audit trail of lab_result
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result_audit (
+    _audit_id STRING DEFAULT GENERATE_UUID(),
+    _audit_operation STRING NOT NULL,
+    _audit_user STRING,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    result_id STRING,
+    mrn STRING,
+    value_numeric NUMERIC(18, 6),
+    charge_amount NUMERIC(12, 2),
+    value_quantity JSON,
+    resulted_at TIMESTAMP,
+    performer_name JSON,
+    ordered_by JSON,
+    RAW_HL7_MSG BYTES,
+    icd10_code STRING,
+    reviewer_ids JSON,
+    PRIMARY KEY (_audit_id) NOT ENFORCED
+);
+
+-- Change data capture tools such as Datastream append a row per change.
@@ -0,0 +1,30 @@
+-- Audit trail of specimen
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_audit (
+    _audit_id STRING DEFAULT GENERATE_UUID(),
+    _audit_operation STRING NOT NULL,
+    _audit_user STRING,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    id STRING,
+    status STRING,
+    accession_identifier JSON,
+    received_time TIMESTAMP,
+    collected_date DATE,
+    quantity NUMERIC(18, 6),
+    container_count INT64,
+    fasting BOOL,
+    note JSON,
+    subject JSON,
+    request JSON,
+    visit JSON,
+    collection JSON,
+    PRIMARY KEY (_audit_id) NOT ENFORCED
+);
+
+-- Change data capture tools such as Datastream append a row per change.
@@ -0,0 +1,31 @@
+-- Audit trail of visit
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_audit (
+    _audit_id STRING DEFAULT GENERATE_UUID(),
+    _audit_operation STRING NOT NULL,
+    _audit_user STRING,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    class JSON,
+    type JSON,
+    "for" JSON,
+    match STRING,
+    "order" INT64,
+    self STRING,
+    "where" JSON,
+    _2nd_opinion BOOL,
+    priority_code STRING,
+    priority_code_2 STRING,
+    visit STRING,
+    length JSON,
+    attender JSON,
+    specimens JSON,
+    PRIMARY KEY (_audit_id) NOT ENFORCED
+);
+
+-- Change data capture tools such as Datastream append a row per change.
@@ -0,0 +1,26 @@
+-- Audit trail of practitioner
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_audit (
+    _audit_id STRING DEFAULT GENERATE_UUID(),
+    _audit_operation STRING NOT NULL,
+    _audit_user STRING,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    id STRING,
+    meta JSON,
+    implicit_rules STRING,
+    language STRING,
+    text JSON,
+    extension JSON,
+    modifier_extension JSON,
+    name JSON,
+    supervisor JSON,
+    PRIMARY KEY (_audit_id) NOT ENFORCED
+);
+
+-- Change data capture tools such as Datastream append a row per change.
@@ -0,0 +1,17 @@
+-- A person providing care
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner (
+    id STRING NOT NULL OPTIONS(description="Logical id of this artifact"),
+    meta JSON OPTIONS(description="Metadata about the resource"),
+    implicit_rules STRING OPTIONS(description="A set of rules under which this content was created"),
+    language STRING OPTIONS(description="Language of the resource content"),
+    text JSON OPTIONS(description="Text summary of the resource, for human interpretation"),
+    extension JSON OPTIONS(description="Additional content defined by implementations"),
+    modifier_extension JSON OPTIONS(description="Extensions that cannot be ignored"),
+    name JSON OPTIONS(description="The name(s) associated with the practitioner"),
+    supervisor JSON OPTIONS(description="Practitioner responsible for this one")
+)
+OPTIONS(description="A person providing care");
@@ -0,0 +1,30 @@
+-- Audit trail of lab_result
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    result_id VARCHAR(255),
+    mrn VARCHAR(255),
+    value_numeric DECIMAL(18, 6),
+    charge_amount NUMERIC(12, 2),
+    value_quantity JSONB,
+    resulted_at TIMESTAMP,
+    performer_name JSONB,
+    ordered_by JSONB,
+    RAW_HL7_MSG BYTEA,
+    icd10_code VARCHAR(255),
+    reviewer_ids JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Change data capture tools such as Debezium append a row per change. They
+-- need the full old row of updates and deletes.
+ALTER TABLE lab_result REPLICA IDENTITY FULL;
@@ -0,0 +1,32 @@
+-- Audit trail of specimen
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    id VARCHAR(255),
+    status VARCHAR(255),
+    accession_identifier JSONB,
+    received_time TIMESTAMP,
+    collected_date DATE,
+    quantity DECIMAL(18, 6),
+    container_count INTEGER,
+    fasting BOOLEAN,
+    note JSONB,
+    subject JSONB,
+    request JSONB,
+    visit JSONB,
+    collection JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Change data capture tools such as Debezium append a row per change. They
+-- need the full old row of updates and deletes.
+ALTER TABLE specimen REPLICA IDENTITY FULL;
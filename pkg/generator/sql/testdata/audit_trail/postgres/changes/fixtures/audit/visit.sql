@@ -0,0 +1,33 @@
+-- Audit trail of visit
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    class JSONB,
+    type JSONB,
+    "for" JSONB,
+    match VARCHAR(255),
+    "order" INTEGER,
+    self VARCHAR(255),
+    "where" JSONB,
+    _2nd_opinion BOOLEAN,
+    priority_code VARCHAR(255),
+    priority_code_2 VARCHAR(255),
+    visit VARCHAR(255),
+    length JSONB,
+    attender JSONB,
+    specimens JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Change data capture tools such as Debezium append a row per change. They
+-- need the full old row of updates and deletes.
+ALTER TABLE visit REPLICA IDENTITY FULL;
@@ -0,0 +1,28 @@
+-- Audit trail of practitioner
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    id VARCHAR(255),
+    meta JSONB,
+    implicit_rules VARCHAR(255),
+    language VARCHAR(255),
+    text JSONB,
+    extension JSONB,
+    modifier_extension JSONB,
+    name JSONB,
+    supervisor JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Change data capture tools such as Debezium append a row per change. They
+-- need the full old row of updates and deletes.
+ALTER TABLE practitioner REPLICA IDENTITY FULL;
@@ -0,0 +1,77 @@
+-- Audit trail of lab_result
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255) NOT NULL,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    result_id VARCHAR(255),
+    mrn VARCHAR(255),
+    value_numeric DECIMAL(18, 6),
+    charge_amount NUMERIC(12, 2),
+    value_quantity JSONB,
+    resulted_at TIMESTAMP,
+    performer_name JSONB,
+    ordered_by JSONB,
+    RAW_HL7_MSG BYTEA,
+    icd10_code VARCHAR(255),
+    reviewer_ids JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Audit rows are never changed, and only the trigger function adds them, so
+-- no role but the owner may write to the audit table, whatever earlier grants
+-- or default privileges gave it. Grant readers SELECT on the audit table.
+REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON lab_result_audit FROM PUBLIC;
+DO $$
+DECLARE
+    role_name text;
+BEGIN
+    FOR role_name IN
+        SELECT DISTINCT a.grantee::regrole::text
+        FROM pg_class c, aclexplode(c.relacl) a
+        WHERE c.oid = 'lab_result_audit'::regclass
+            AND a.grantee NOT IN (0, c.relowner)
+            AND a.privilege_type IN ('INSERT', 'UPDATE', 'DELETE', 'TRUNCATE')
+    LOOP
+        EXECUTE format('REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON lab_result_audit FROM %s', role_name);
+    END LOOP;
+END
+$$;
+
+-- The function runs as its owner, so writers need no privileges on the audit
+-- table. The user is the ehrglot.user setting of the session, which
+-- applications that connect as a shared role set to their signed-in user, or
+-- else the database user the session logged in as.
+CREATE OR REPLACE FUNCTION lab_result_audit_trigger() RETURNS TRIGGER SECURITY DEFINER AS $$
+DECLARE
+    r lab_result%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO lab_result_audit (_audit_operation, _audit_user, result_id, mrn, value_numeric, charge_amount, value_quantity, resulted_at, performer_name, ordered_by, RAW_HL7_MSG, icd10_code, reviewer_ids)
+    VALUES (TG_OP, COALESCE(NULLIF(current_setting('ehrglot.user', true), ''), session_user), r.result_id, r.mrn, r.value_numeric, r.charge_amount, r.value_quantity, r.resulted_at, r.performer_name, r.ordered_by, r.RAW_HL7_MSG, r.icd10_code, r.reviewer_ids);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pin the search_path of the function to this schema, with pg_temp last, so
+-- that no session's temporary tables can stand in for the audit table.
+DO $$
+BEGIN
+    EXECUTE format('ALTER FUNCTION lab_result_audit_trigger() SET search_path = %I, pg_temp', current_schema());
+END
+$$;
+
+CREATE OR REPLACE TRIGGER lab_result_audit
+    AFTER INSERT OR UPDATE OR DELETE ON lab_result
+    FOR EACH ROW EXECUTE FUNCTION lab_result_audit_trigger();
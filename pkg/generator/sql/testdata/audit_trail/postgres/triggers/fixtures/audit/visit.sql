@@ -0,0 +1,80 @@
+-- Audit trail of visit
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255) NOT NULL,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    class JSONB,
+    type JSONB,
+    "for" JSONB,
+    match VARCHAR(255),
+    "order" INTEGER,
+    self VARCHAR(255),
+    "where" JSONB,
+    _2nd_opinion BOOLEAN,
+    priority_code VARCHAR(255),
+    priority_code_2 VARCHAR(255),
+    visit VARCHAR(255),
+    length JSONB,
+    attender JSONB,
+    specimens JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Audit rows are never changed, and only the trigger function adds them, so
+-- no role but the owner may write to the audit table, whatever earlier grants
+-- or default privileges gave it. Grant readers SELECT on the audit table.
+REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON visit_audit FROM PUBLIC;
+DO $$
+DECLARE
+    role_name text;
+BEGIN
+    FOR role_name IN
+        SELECT DISTINCT a.grantee::regrole::text
+        FROM pg_class c, aclexplode(c.relacl) a
+        WHERE c.oid = 'visit_audit'::regclass
+            AND a.grantee NOT IN (0, c.relowner)
+            AND a.privilege_type IN ('INSERT', 'UPDATE', 'DELETE', 'TRUNCATE')
+    LOOP
+        EXECUTE format('REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON visit_audit FROM %s', role_name);
+    END LOOP;
+END
+$$;
+
+-- The function runs as its owner, so writers need no privileges on the audit
+-- table. The user is the ehrglot.user setting of the session, which
+-- applications that connect as a shared role set to their signed-in user, or
+-- else the database user the session logged in as.
+CREATE OR REPLACE FUNCTION visit_audit_trigger() RETURNS TRIGGER SECURITY DEFINER AS $$
+DECLARE
+    r visit%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO visit_audit (_audit_operation, _audit_user, class, type, "for", match, "order", self, "where", _2nd_opinion, priority_code, priority_code_2, visit, length, attender, specimens)
+    VALUES (TG_OP, COALESCE(NULLIF(current_setting('ehrglot.user', true), ''), session_user), r.class, r.type, r."for", r.match, r."order", r.self, r."where", r._2nd_opinion, r.priority_code, r.priority_code_2, r.visit, r.length, r.attender, r.specimens);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pin the search_path of the function to this schema, with pg_temp last, so
+-- that no session's temporary tables can stand in for the audit table.
+DO $$
+BEGIN
+    EXECUTE format('ALTER FUNCTION visit_audit_trigger() SET search_path = %I, pg_temp', current_schema());
+END
+$$;
+
+CREATE OR REPLACE TRIGGER visit_audit
+    AFTER INSERT OR UPDATE OR DELETE ON visit
+    FOR EACH ROW EXECUTE FUNCTION visit_audit_trigger();
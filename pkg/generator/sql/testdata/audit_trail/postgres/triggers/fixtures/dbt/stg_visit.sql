@@ -0,0 +1,29 @@
+{#
+  An encounter whose field names are reserved words in target languages
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    class,
+    type,
+    "for",
+    match,
+    "order",
+    self,
+    "where",
+    _2nd_opinion,
+    priority_code,
+    priority_code_2,
+    visit,
+    length,
+    attender,
+    specimens,
+    'Visit ' || visit AS display
+FROM {{ source('fixtures', 'visit') }}
@@ -0,0 +1,13 @@
+{#
+  Rows of stg_lab_result with values outside the reference ranges of their
+  fields, to review.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(severity='warn') }}
+
+SELECT *
+FROM {{ ref('stg_lab_result') }}
+WHERE value_numeric NOT BETWEEN 3.5 AND 11
@@ -0,0 +1,11 @@
+{#
+  Rows of stg_visit missing fields that a condition on another field
+  requires.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+SELECT *
+FROM {{ ref('stg_visit') }}
+WHERE (_2nd_opinion = TRUE AND attender IS NULL)
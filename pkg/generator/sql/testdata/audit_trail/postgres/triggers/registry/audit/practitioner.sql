@@ -0,0 +1,75 @@
+-- Audit trail of practitioner
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_audit (
+    _audit_id BIGINT GENERATED ALWAYS AS IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255) NOT NULL,
+    _audit_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    id VARCHAR(255),
+    meta JSONB,
+    implicit_rules VARCHAR(255),
+    language VARCHAR(255),
+    text JSONB,
+    extension JSONB,
+    modifier_extension JSONB,
+    name JSONB,
+    supervisor JSONB,
+    PRIMARY KEY (_audit_id)
+);
+
+-- Audit rows are never changed, and only the trigger function adds them, so
+-- no role but the owner may write to the audit table, whatever earlier grants
+-- or default privileges gave it. Grant readers SELECT on the audit table.
+REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON practitioner_audit FROM PUBLIC;
+DO $$
+DECLARE
+    role_name text;
+BEGIN
+    FOR role_name IN
+        SELECT DISTINCT a.grantee::regrole::text
+        FROM pg_class c, aclexplode(c.relacl) a
+        WHERE c.oid = 'practitioner_audit'::regclass
+            AND a.grantee NOT IN (0, c.relowner)
+            AND a.privilege_type IN ('INSERT', 'UPDATE', 'DELETE', 'TRUNCATE')
+    LOOP
+        EXECUTE format('REVOKE INSERT, UPDATE, DELETE, TRUNCATE ON practitioner_audit FROM %s', role_name);
+    END LOOP;
+END
+$$;
+
+-- The function runs as its owner, so writers need no privileges on the audit
+-- table. The user is the ehrglot.user setting of the session, which
+-- applications that connect as a shared role set to their signed-in user, or
+-- else the database user the session logged in as.
+CREATE OR REPLACE FUNCTION practitioner_audit_trigger() RETURNS TRIGGER SECURITY DEFINER AS $$
+DECLARE
+    r practitioner%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO practitioner_audit (_audit_operation, _audit_user, id, meta, implicit_rules, language, text, extension, modifier_extension, name, supervisor)
+    VALUES (TG_OP, COALESCE(NULLIF(current_setting('ehrglot.user', true), ''), session_user), r.id, r.meta, r.implicit_rules, r.language, r.text, r.extension, r.modifier_extension, r.name, r.supervisor);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Pin the search_path of the function to this schema, with pg_temp last, so
+-- that no session's temporary tables can stand in for the audit table.
+DO $$
+BEGIN
+    EXECUTE format('ALTER FUNCTION practitioner_audit_trigger() SET search_path = %I, pg_temp', current_schema());
+END
+$$;
+
+CREATE OR REPLACE TRIGGER practitioner_audit
+    AFTER INSERT OR UPDATE OR DELETE ON practitioner
+    FOR EACH ROW EXECUTE FUNCTION practitioner_audit_trigger();
@@ -0,0 +1,48 @@
+-- Audit trail of lab_result
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result_audit (
+    _audit_id BIGINT IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP_NTZ NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    result_id VARCHAR(255),
+    mrn VARCHAR(255),
+    value_numeric DECIMAL(18, 6),
+    charge_amount NUMERIC(12, 2),
+    value_quantity VARIANT,
+    resulted_at TIMESTAMP_NTZ,
+    performer_name VARIANT,
+    ordered_by VARIANT,
+    RAW_HL7_MSG BINARY,
+    icd10_code VARCHAR(255),
+    reviewer_ids VARIANT,
+    PRIMARY KEY (_audit_id)
+);
+
+-- The stream holds the changes to lab_result since it was last read.
+-- Schedule the insert below, in a task, to move them to the audit table.
+-- Streams do not record who made a change, so _audit_user is left null.
+CREATE STREAM IF NOT EXISTS lab_result_changes ON TABLE lab_result;
+
+INSERT INTO lab_result_audit (_audit_operation, result_id, mrn, value_numeric, charge_amount, value_quantity, resulted_at, performer_name, ordered_by, RAW_HL7_MSG, icd10_code, reviewer_ids)
+SELECT
+    CASE WHEN METADATA$ISUPDATE THEN 'UPDATE' ELSE METADATA$ACTION END,
+    result_id,
+    mrn,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids
+FROM lab_result_changes
+WHERE NOT (METADATA$ISUPDATE AND METADATA$ACTION = 'DELETE');
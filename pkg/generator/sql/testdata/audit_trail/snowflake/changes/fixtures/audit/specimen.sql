@@ -0,0 +1,52 @@
+-- Audit trail of specimen
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_audit (
+    _audit_id BIGINT IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP_NTZ NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    id VARCHAR(255),
+    status VARCHAR(255),
+    accession_identifier VARIANT,
+    received_time TIMESTAMP_NTZ,
+    collected_date DATE,
+    quantity DECIMAL(18, 6),
+    container_count INTEGER,
+    fasting BOOLEAN,
+    note VARIANT,
+    subject VARIANT,
+    request VARIANT,
+    visit VARIANT,
+    collection VARIANT,
+    PRIMARY KEY (_audit_id)
+);
+
+-- The stream holds the changes to specimen since it was last read.
+-- Schedule the insert below, in a task, to move them to the audit table.
+-- Streams do not record who made a change, so _audit_user is left null.
+CREATE STREAM IF NOT EXISTS specimen_changes ON TABLE specimen;
+
+INSERT INTO specimen_audit (_audit_operation, id, status, accession_identifier, received_time, collected_date, quantity, container_count, fasting, note, subject, request, visit, collection)
+SELECT
+    CASE WHEN METADATA$ISUPDATE THEN 'UPDATE' ELSE METADATA$ACTION END,
+    id,
+    status,
+    accession_identifier,
+    received_time,
+    collected_date,
+    quantity,
+    container_count,
+    fasting,
+    note,
+    subject,
+    request,
+    visit,
+    collection
+FROM specimen_changes
+WHERE NOT (METADATA$ISUPDATE AND METADATA$ACTION = 'DELETE');
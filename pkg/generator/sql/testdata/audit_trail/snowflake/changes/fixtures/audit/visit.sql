@@ -0,0 +1,54 @@
+-- Audit trail of visit
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_audit (
+    _audit_id BIGINT IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP_NTZ NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    class VARIANT,
+    type VARIANT,
+    "for" VARIANT,
+    match VARCHAR(255),
+    "order" INTEGER,
+    self VARCHAR(255),
+    "where" VARIANT,
+    _2nd_opinion BOOLEAN,
+    priority_code VARCHAR(255),
+    priority_code_2 VARCHAR(255),
+    visit VARCHAR(255),
+    length VARIANT,
+    attender VARIANT,
+    specimens VARIANT,
+    PRIMARY KEY (_audit_id)
+);
+
+-- The stream holds the changes to visit since it was last read.
+-- Schedule the insert below, in a task, to move them to the audit table.
+-- Streams do not record who made a change, so _audit_user is left null.
+CREATE STREAM IF NOT EXISTS visit_changes ON TABLE visit;
+
+INSERT INTO visit_audit (_audit_operation, class, type, "for", match, "order", self, "where", _2nd_opinion, priority_code, priority_code_2, visit, length, attender, specimens)
+SELECT
+    CASE WHEN METADATA$ISUPDATE THEN 'UPDATE' ELSE METADATA$ACTION END,
+    class,
+    type,
+    "for",
+    match,
+    "order",
+    self,
+    "where",
+    _2nd_opinion,
+    priority_code,
+    priority_code_2,
+    visit,
+    length,
+    attender,
+    specimens
+FROM visit_changes
+WHERE NOT (METADATA$ISUPDATE AND METADATA$ACTION = 'DELETE');
@@ -0,0 +1,44 @@
+-- Audit trail of practitioner
+--
+-- A row per insert, update, or delete of its rows, recording who changed
+-- them, when, and their values.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_audit (
+    _audit_id BIGINT IDENTITY,
+    _audit_operation VARCHAR(255) NOT NULL,
+    _audit_user VARCHAR(255),
+    _audit_at TIMESTAMP_NTZ NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    id VARCHAR(255),
+    meta VARIANT,
+    implicit_rules VARCHAR(255),
+    language VARCHAR(255),
+    text VARIANT,
+    extension VARIANT,
+    modifier_extension VARIANT,
+    name VARIANT,
+    supervisor VARIANT,
+    PRIMARY KEY (_audit_id)
+);
+
+-- The stream holds the changes to practitioner since it was last read.
+-- Schedule the insert below, in a task, to move them to the audit table.
+-- Streams do not record who made a change, so _audit_user is left null.
+CREATE STREAM IF NOT EXISTS practitioner_changes ON TABLE practitioner;
+
+INSERT INTO practitioner_audit (_audit_operation, id, meta, implicit_rules, language, text, extension, modifier_extension, name, supervisor)
+SELECT
+    CASE WHEN METADATA$ISUPDATE THEN 'UPDATE' ELSE METADATA$ACTION END,
+    id,
+    meta,
+    implicit_rules,
+    language,
+    text,
+    extension,
+    modifier_extension,
+    name,
+    supervisor
+FROM practitioner_changes
+WHERE NOT (METADATA$ISUPDATE AND METADATA$ACTION = 'DELETE');
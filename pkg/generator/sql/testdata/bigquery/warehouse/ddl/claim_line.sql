@@ -0,0 +1,13 @@
+-- Claim lines by payer
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS claim_line (
+    id STRING NOT NULL OPTIONS(description="Claim line id"),
+    payer STRING NOT NULL OPTIONS(description="Payer code"),
+    service_date DATE OPTIONS(description="Date of service"),
+    amount NUMERIC(18, 6) OPTIONS(description="Billed amount")
+)
+PARTITION BY service_date
+OPTIONS(description="Claim lines by payer");
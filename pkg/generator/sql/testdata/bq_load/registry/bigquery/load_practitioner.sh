@@ -0,0 +1,24 @@
+#!/bin/sh
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+#
+# Loads FHIR NDJSON of Practitioner into the practitioner table of a BigQuery
+# dataset, creating the table from practitioner.json if it does not exist.
+# Fields the schema does not declare, such as extensions, are ignored.
+#
+# Usage: load_practitioner.sh SOURCE [DATASET]
+#   SOURCE   local path or gs:// URI of the NDJSON, which may use wildcards
+#   DATASET  dataset to load into (default: registry_raw)
+set -eu
+
+if [ $# -lt 1 ]; then
+  echo "usage: $0 SOURCE [DATASET]" >&2
+  exit 2
+fi
+
+bq load \
+  --source_format=NEWLINE_DELIMITED_JSON \
+  --ignore_unknown_values \
+  "${2:-registry_raw}.practitioner" \
+  "$1" \
+  "$(dirname "$0")/practitioner.json"
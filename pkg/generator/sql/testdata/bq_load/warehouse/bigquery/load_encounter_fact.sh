@@ -0,0 +1,27 @@
+#!/bin/sh
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+#
+# Loads FHIR NDJSON of encounter_fact into the encounter_fact table of a BigQuery
+# dataset, creating the table from encounter_fact.json if it does not exist.
+# Fields the schema does not declare, such as extensions, are ignored.
+#
+# Usage: load_encounter_fact.sh SOURCE [DATASET]
+#   SOURCE   local path or gs:// URI of the NDJSON, which may use wildcards
+#   DATASET  dataset to load into (default: warehouse_raw)
+set -eu
+
+if [ $# -lt 1 ]; then
+  echo "usage: $0 SOURCE [DATASET]" >&2
+  exit 2
+fi
+
+bq load \
+  --source_format=NEWLINE_DELIMITED_JSON \
+  --ignore_unknown_values \
+  --time_partitioning_field=start \
+  --time_partitioning_type=DAY \
+  --clustering_fields=facilityCode \
+  "${2:-warehouse_raw}.encounter_fact" \
+  "$1" \
+  "$(dirname "$0")/encounter_fact.json"
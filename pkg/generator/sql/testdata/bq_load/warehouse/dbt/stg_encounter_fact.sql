@@ -0,0 +1,18 @@
+{#
+  Encounters by day and facility
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    facility,
+    start,
+    diagnoses
+FROM {{ source('warehouse', 'encounter_fact') }}
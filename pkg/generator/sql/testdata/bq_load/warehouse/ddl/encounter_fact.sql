@@ -0,0 +1,14 @@
+-- Encounters by day and facility
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id STRING NOT NULL OPTIONS(description="Encounter id"),
+    facility STRING OPTIONS(description="Facility code"),
+    start TIMESTAMP OPTIONS(description="When the encounter began"),
+    diagnoses JSON OPTIONS(description="Diagnoses made")
+)
+PARTITION BY DATE(start)
+CLUSTER BY facility
+OPTIONS(description="Encounters by day and facility");
@@ -0,0 +1,17 @@
+-- Claim lines by payer
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS claim_line (
+    id STRING NOT NULL COMMENT 'Claim line id',
+    payer STRING NOT NULL COMMENT 'Payer code',
+    service_date DATE COMMENT 'Date of service',
+    amount DECIMAL(18, 6) COMMENT 'Billed amount'
+)
+USING DELTA
+PARTITIONED BY (payer)
+COMMENT 'Claim lines by payer'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name'
+);
@@ -0,0 +1,29 @@
+{#
+  A sample collected for analysis
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    id,
+    status,
+    accession_identifier,
+    received_time,
+    collected_date,
+    quantity,
+    container_count,
+    fasting,
+    note,
+    subject,
+    request,
+    visit,
+    collection_collector,
+    collection_method,
+    (CAST(received_time AS DATE) - CAST(collected_date AS DATE)) AS turnaround_days
+FROM {{ source('fixtures', 'specimen') }}
@@ -0,0 +1,17 @@
+{#
+  Reviewing clinicians
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    lab_result_result_id,
+    ordinal,
+    value
+FROM {{ source('fixtures', 'lab_result_reviewer_ids') }}
@@ -0,0 +1,17 @@
+{#
+  Collection details
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    specimen_id,
+    collector,
+    method
+FROM {{ source('fixtures', 'specimen_collection') }}
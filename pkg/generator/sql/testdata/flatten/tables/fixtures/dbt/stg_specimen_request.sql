@@ -0,0 +1,17 @@
+{#
+  Why the specimen was collected
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    specimen_id,
+    ordinal,
+    value
+FROM {{ source('fixtures', 'specimen_request') }}
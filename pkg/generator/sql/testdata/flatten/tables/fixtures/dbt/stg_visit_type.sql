@@ -0,0 +1,17 @@
+{#
+  Specific type of visit
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    visit_class,
+    ordinal,
+    value
+FROM {{ source('fixtures', 'visit_type') }}
@@ -0,0 +1,11 @@
+{#
+  Rows of stg_specimen missing fields that a condition on another field
+  requires.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+SELECT *
+FROM {{ ref('stg_specimen') }}
+WHERE (status = 'available' AND received_time IS NULL)
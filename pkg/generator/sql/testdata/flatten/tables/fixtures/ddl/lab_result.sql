@@ -0,0 +1,34 @@
+-- Résultat de laboratoire — température °C, 检验结果
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result (
+    result_id VARCHAR(255) NOT NULL,
+    mrn VARCHAR(255) NOT NULL,
+    value_numeric DECIMAL(18, 6),
+    charge_amount NUMERIC(12, 2) DEFAULT 0,
+    value_quantity JSONB,
+    resulted_at TIMESTAMP,
+    performer_name JSONB,
+    ordered_by JSONB,
+    RAW_HL7_MSG BYTEA,
+    icd10_code VARCHAR(255),
+    CONSTRAINT lab_result_result_id_key UNIQUE (result_id)
+);
+
+CREATE INDEX IF NOT EXISTS lab_result_mrn_resulted_at_idx ON lab_result (mrn, resulted_at);
+
+-- Add comments
+COMMENT ON TABLE lab_result IS 'Résultat de laboratoire — température °C, 检验结果';
+COMMENT ON COLUMN lab_result.result_id IS 'Identifiant du résultat';
+COMMENT ON COLUMN lab_result.mrn IS 'Medical record number. Encrypted (deterministic).';
+COMMENT ON COLUMN lab_result.value_numeric IS 'Valeur mesurée (µmol/L)';
+COMMENT ON COLUMN lab_result.charge_amount IS 'Billed amount';
+COMMENT ON COLUMN lab_result.value_quantity IS 'Measured value with its unit';
+COMMENT ON COLUMN lab_result.resulted_at IS 'When the result was released';
+COMMENT ON COLUMN lab_result.performer_name IS 'Who performed the test';
+COMMENT ON COLUMN lab_result.ordered_by IS 'Who ordered the test';
+COMMENT ON COLUMN lab_result.RAW_HL7_MSG IS 'Original HL7 message';
+COMMENT ON COLUMN lab_result.icd10_code IS 'Diagnosis the test was ordered for';
+
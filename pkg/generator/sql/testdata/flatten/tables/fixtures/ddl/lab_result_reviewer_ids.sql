@@ -0,0 +1,19 @@
+-- Reviewing clinicians
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result_reviewer_ids (
+    lab_result_result_id VARCHAR(255) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value VARCHAR(255),
+    FOREIGN KEY (lab_result_result_id) REFERENCES lab_result (result_id) ON DELETE CASCADE,
+    CONSTRAINT lab_result_reviewer_ids_lab_result_result_id_ordinal_key UNIQUE (lab_result_result_id, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE lab_result_reviewer_ids IS 'Reviewing clinicians';
+COMMENT ON COLUMN lab_result_reviewer_ids.lab_result_result_id IS 'Key of the lab_result this belongs to';
+COMMENT ON COLUMN lab_result_reviewer_ids.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN lab_result_reviewer_ids.value IS 'Reviewing clinicians';
+
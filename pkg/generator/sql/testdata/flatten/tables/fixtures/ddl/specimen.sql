@@ -0,0 +1,32 @@
+-- A sample collected for analysis
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen (
+    id VARCHAR(255) NOT NULL,
+    status VARCHAR(255) NOT NULL DEFAULT 'available',
+    accession_identifier JSONB,
+    received_time TIMESTAMP,
+    collected_date DATE,
+    quantity DECIMAL(18, 6),
+    container_count INTEGER DEFAULT 1,
+    fasting BOOLEAN DEFAULT FALSE,
+    subject JSONB,
+    visit JSONB,
+    CONSTRAINT specimen_id_key UNIQUE (id)
+);
+
+-- Add comments
+COMMENT ON TABLE specimen IS 'A sample collected for analysis';
+COMMENT ON COLUMN specimen.id IS 'Logical id of this artifact';
+COMMENT ON COLUMN specimen.status IS 'available | unavailable | entered-in-error';
+COMMENT ON COLUMN specimen.accession_identifier IS 'Identifier assigned by the lab';
+COMMENT ON COLUMN specimen.received_time IS 'When the specimen was received';
+COMMENT ON COLUMN specimen.collected_date IS 'Collection date';
+COMMENT ON COLUMN specimen.quantity IS 'Amount collected';
+COMMENT ON COLUMN specimen.container_count IS 'Number of containers';
+COMMENT ON COLUMN specimen.fasting IS 'Whether the patient was fasting';
+COMMENT ON COLUMN specimen.subject IS 'Where the specimen came from';
+COMMENT ON COLUMN specimen.visit IS 'Visit during which the specimen was collected';
+
@@ -0,0 +1,19 @@
+-- Collection details
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_collection (
+    specimen_id VARCHAR(255) NOT NULL,
+    collector JSONB,
+    method VARCHAR(255),
+    FOREIGN KEY (specimen_id) REFERENCES specimen (id) ON DELETE CASCADE,
+    CONSTRAINT specimen_collection_specimen_id_key UNIQUE (specimen_id)
+);
+
+-- Add comments
+COMMENT ON TABLE specimen_collection IS 'Collection details';
+COMMENT ON COLUMN specimen_collection.specimen_id IS 'Key of the Specimen this belongs to';
+COMMENT ON COLUMN specimen_collection.collector IS 'Who collected the specimen';
+COMMENT ON COLUMN specimen_collection.method IS 'Technique used';
+
@@ -0,0 +1,19 @@
+-- Comments
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_note (
+    specimen_id VARCHAR(255) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value VARCHAR(255),
+    FOREIGN KEY (specimen_id) REFERENCES specimen (id) ON DELETE CASCADE,
+    CONSTRAINT specimen_note_specimen_id_ordinal_key UNIQUE (specimen_id, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE specimen_note IS 'Comments';
+COMMENT ON COLUMN specimen_note.specimen_id IS 'Key of the Specimen this belongs to';
+COMMENT ON COLUMN specimen_note.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN specimen_note.value IS 'Comments';
+
@@ -0,0 +1,19 @@
+-- Why the specimen was collected
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen_request (
+    specimen_id VARCHAR(255) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value JSONB,
+    FOREIGN KEY (specimen_id) REFERENCES specimen (id) ON DELETE CASCADE,
+    CONSTRAINT specimen_request_specimen_id_ordinal_key UNIQUE (specimen_id, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE specimen_request IS 'Why the specimen was collected';
+COMMENT ON COLUMN specimen_request.specimen_id IS 'Key of the Specimen this belongs to';
+COMMENT ON COLUMN specimen_request.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN specimen_request.value IS 'Why the specimen was collected';
+
@@ -0,0 +1,19 @@
+-- Specimens collected during the visit
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_specimens (
+    visit_class JSONB NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value JSONB,
+    FOREIGN KEY (visit_class) REFERENCES visit (class) ON DELETE CASCADE,
+    CONSTRAINT visit_specimens_visit_class_ordinal_key UNIQUE (visit_class, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE visit_specimens IS 'Specimens collected during the visit';
+COMMENT ON COLUMN visit_specimens.visit_class IS 'Key of the Visit this belongs to';
+COMMENT ON COLUMN visit_specimens.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN visit_specimens.value IS 'Specimens collected during the visit';
+
@@ -0,0 +1,19 @@
+-- Specific type of visit
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit_type (
+    visit_class JSONB NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value JSONB,
+    FOREIGN KEY (visit_class) REFERENCES visit (class) ON DELETE CASCADE,
+    CONSTRAINT visit_type_visit_class_ordinal_key UNIQUE (visit_class, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE visit_type IS 'Specific type of visit';
+COMMENT ON COLUMN visit_type.visit_class IS 'Key of the Visit this belongs to';
+COMMENT ON COLUMN visit_type.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN visit_type.value IS 'Specific type of visit';
+
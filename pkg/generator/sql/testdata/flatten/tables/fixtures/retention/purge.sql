@@ -0,0 +1,8 @@
+-- Deletes the records of fixtures tables older than their retention periods.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- lab_result keeps records for 7 years from resulted_at.
+DELETE FROM lab_result
+WHERE resulted_at < CURRENT_TIMESTAMP - INTERVAL '7 years';
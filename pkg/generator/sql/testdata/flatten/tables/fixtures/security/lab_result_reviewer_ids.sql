@@ -0,0 +1,30 @@
+-- Purpose-of-use access to lab_result_reviewer_ids
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Rows: sessions set ehrglot.purpose_of_use to the purpose they read for,
+-- and only 'treatment', 'research' may read the table. Its owner, which loads it,
+-- is exempt.
+ALTER TABLE lab_result_reviewer_ids ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY lab_result_reviewer_ids_purpose_of_use ON lab_result_reviewer_ids
+    FOR SELECT
+    USING (current_setting('ehrglot.purpose_of_use', true) IN ('treatment', 'research'));
+
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+
+CREATE OR REPLACE VIEW lab_result_reviewer_ids_treatment AS
+SELECT
+    lab_result_result_id,
+    ordinal,
+    value
+FROM lab_result_reviewer_ids;
+
+CREATE OR REPLACE VIEW lab_result_reviewer_ids_research AS
+SELECT
+    lab_result_result_id,
+    ordinal,
+    value
+FROM lab_result_reviewer_ids;
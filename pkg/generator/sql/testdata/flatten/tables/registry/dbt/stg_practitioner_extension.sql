@@ -0,0 +1,17 @@
+{#
+  Additional content defined by implementations
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='registry'
+) }}
+
+SELECT
+    practitioner_id,
+    ordinal,
+    value
+FROM {{ source('registry', 'practitioner_extension') }}
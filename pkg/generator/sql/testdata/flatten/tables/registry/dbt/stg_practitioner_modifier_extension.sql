@@ -0,0 +1,17 @@
+{#
+  Extensions that cannot be ignored
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='registry'
+) }}
+
+SELECT
+    practitioner_id,
+    ordinal,
+    value
+FROM {{ source('registry', 'practitioner_modifier_extension') }}
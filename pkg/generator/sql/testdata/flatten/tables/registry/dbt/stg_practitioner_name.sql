@@ -0,0 +1,17 @@
+{#
+  The name(s) associated with the practitioner
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='registry'
+) }}
+
+SELECT
+    practitioner_id,
+    ordinal,
+    value
+FROM {{ source('registry', 'practitioner_name') }}
@@ -0,0 +1,24 @@
+-- A person providing care
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner (
+    id VARCHAR(255) NOT NULL,
+    meta JSONB,
+    implicit_rules VARCHAR(255),
+    language VARCHAR(255),
+    text JSONB,
+    supervisor JSONB,
+    CONSTRAINT practitioner_id_key UNIQUE (id)
+);
+
+-- Add comments
+COMMENT ON TABLE practitioner IS 'A person providing care';
+COMMENT ON COLUMN practitioner.id IS 'Logical id of this artifact';
+COMMENT ON COLUMN practitioner.meta IS 'Metadata about the resource';
+COMMENT ON COLUMN practitioner.implicit_rules IS 'A set of rules under which this content was created';
+COMMENT ON COLUMN practitioner.language IS 'Language of the resource content';
+COMMENT ON COLUMN practitioner.text IS 'Text summary of the resource, for human interpretation';
+COMMENT ON COLUMN practitioner.supervisor IS 'Practitioner responsible for this one';
+
@@ -0,0 +1,19 @@
+-- Extensions that cannot be ignored
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_modifier_extension (
+    practitioner_id VARCHAR(255) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value JSONB,
+    FOREIGN KEY (practitioner_id) REFERENCES practitioner (id) ON DELETE CASCADE,
+    CONSTRAINT practitioner_modifier_extension_practitioner_id_ordinal_key UNIQUE (practitioner_id, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE practitioner_modifier_extension IS 'Extensions that cannot be ignored';
+COMMENT ON COLUMN practitioner_modifier_extension.practitioner_id IS 'Key of the Practitioner this belongs to';
+COMMENT ON COLUMN practitioner_modifier_extension.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN practitioner_modifier_extension.value IS 'Extensions that cannot be ignored';
+
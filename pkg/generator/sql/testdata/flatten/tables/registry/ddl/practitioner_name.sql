@@ -0,0 +1,19 @@
+-- The name(s) associated with the practitioner
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner_name (
+    practitioner_id VARCHAR(255) NOT NULL,
+    ordinal INTEGER NOT NULL,
+    value JSONB,
+    FOREIGN KEY (practitioner_id) REFERENCES practitioner (id) ON DELETE CASCADE,
+    CONSTRAINT practitioner_name_practitioner_id_ordinal_key UNIQUE (practitioner_id, ordinal)
+);
+
+-- Add comments
+COMMENT ON TABLE practitioner_name IS 'The name(s) associated with the practitioner';
+COMMENT ON COLUMN practitioner_name.practitioner_id IS 'Key of the Practitioner this belongs to';
+COMMENT ON COLUMN practitioner_name.ordinal IS 'Position of the element in the list, from 0';
+COMMENT ON COLUMN practitioner_name.value IS 'The name(s) associated with the practitioner';
+
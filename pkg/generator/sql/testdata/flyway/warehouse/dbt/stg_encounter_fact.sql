@@ -0,0 +1,16 @@
+{#
+  Encounters
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    start
+FROM {{ source('warehouse', 'encounter_fact') }}
@@ -0,0 +1,15 @@
+-- Encounters
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id VARCHAR(255) NOT NULL,
+    start TIMESTAMP
+);
+
+-- Add comments
+COMMENT ON TABLE encounter_fact IS 'Encounters';
+COMMENT ON COLUMN encounter_fact.id IS 'Encounter id';
+COMMENT ON COLUMN encounter_fact.start IS 'When the encounter began';
+
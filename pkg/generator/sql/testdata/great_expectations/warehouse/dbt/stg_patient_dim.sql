@@ -0,0 +1,17 @@
+{#
+  Patients
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    mrn,
+    birth_date
+FROM {{ source('warehouse', 'patient_dim') }}
+WHERE is_current
@@ -0,0 +1,27 @@
+-- Lab orders
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_order (
+    id VARCHAR(255) NOT NULL,
+    status VARCHAR(255) NOT NULL,
+    priority VARCHAR(255),
+    placer VARCHAR(255),
+    placer_system VARCHAR(255),
+    ordered_at TIMESTAMP,
+    tube_count INTEGER,
+    CONSTRAINT lab_order_id_key UNIQUE (id),
+    CONSTRAINT lab_order_placer_system_placer_key UNIQUE (placer_system, placer)
+);
+
+-- Add comments
+COMMENT ON TABLE lab_order IS 'Lab orders';
+COMMENT ON COLUMN lab_order.id IS 'Order id';
+COMMENT ON COLUMN lab_order.status IS 'Order status';
+COMMENT ON COLUMN lab_order.priority IS 'Order priority';
+COMMENT ON COLUMN lab_order.placer IS 'Placer order number';
+COMMENT ON COLUMN lab_order.placer_system IS 'System of the placer order number';
+COMMENT ON COLUMN lab_order.ordered_at IS 'When the order was placed';
+COMMENT ON COLUMN lab_order.tube_count IS 'Tubes drawn';
+
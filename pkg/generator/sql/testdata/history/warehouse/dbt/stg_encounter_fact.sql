@@ -0,0 +1,17 @@
+{#
+  Encounters, flagged when deleted at the source
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    start
+FROM {{ source('warehouse', 'encounter_fact') }}
+WHERE NOT is_deleted
@@ -0,0 +1,19 @@
+-- Encounters, flagged when deleted at the source
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id VARCHAR(255) NOT NULL,
+    start TIMESTAMP,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP
+);
+
+-- Add comments
+COMMENT ON TABLE encounter_fact IS 'Encounters, flagged when deleted at the source';
+COMMENT ON COLUMN encounter_fact.id IS 'Encounter id';
+COMMENT ON COLUMN encounter_fact.start IS 'When the encounter began';
+COMMENT ON COLUMN encounter_fact.is_deleted IS 'Whether the record was deleted at the source';
+COMMENT ON COLUMN encounter_fact.deleted_at IS 'When the record was deleted';
+
@@ -0,0 +1,23 @@
+-- Patients, keeping every version
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS patient_dim (
+    mrn VARCHAR(255) NOT NULL,
+    family_name VARCHAR(255),
+    patient_dim_sk BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP,
+    is_current BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+-- Add comments
+COMMENT ON TABLE patient_dim IS 'Patients, keeping every version';
+COMMENT ON COLUMN patient_dim.mrn IS 'Medical record number';
+COMMENT ON COLUMN patient_dim.family_name IS 'Family name';
+COMMENT ON COLUMN patient_dim.patient_dim_sk IS 'Surrogate key of this version of the record';
+COMMENT ON COLUMN patient_dim.valid_from IS 'When this version became current';
+COMMENT ON COLUMN patient_dim.valid_to IS 'When this version was superseded, or null while it is current';
+COMMENT ON COLUMN patient_dim.is_current IS 'Whether this is the current version';
+
@@ -0,0 +1,23 @@
+-- Encounters by day and facility
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id STRING NOT NULL COMMENT 'Encounter id',
+    patient_id STRING NOT NULL COMMENT 'Patient id',
+    facility STRING COMMENT 'Facility code',
+    start TIMESTAMP COMMENT 'When the encounter began',
+    encounter_fact_sk STRING COMMENT 'Surrogate key of this version of the record',
+    valid_from TIMESTAMP NOT NULL COMMENT 'When this version became current',
+    valid_to TIMESTAMP COMMENT 'When this version was superseded, or null while it is current',
+    is_current BOOLEAN NOT NULL COMMENT 'Whether this is the current version'
+)
+USING ICEBERG
+PARTITIONED BY (days(start))
+COMMENT 'Encounters by day and facility'
+TBLPROPERTIES (
+    'format-version' = '2'
+);
+
+ALTER TABLE encounter_fact WRITE ORDERED BY facility, patient_id;
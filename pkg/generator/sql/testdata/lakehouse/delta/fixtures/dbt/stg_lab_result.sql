@@ -0,0 +1,29 @@
+{#
+  Résultat de laboratoire — température °C, 检验结果
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    result_id,
+    mrn,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids,
+    _ingested_at,
+    _source_system,
+    _record_hash,
+    value_numeric / 1000 AS value_mmol
+FROM {{ source('fixtures', 'lab_result') }}
@@ -0,0 +1,28 @@
+-- Résultat de laboratoire — température °C, 检验结果
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result (
+    result_id STRING NOT NULL COMMENT 'Identifiant du résultat',
+    mrn STRING NOT NULL COMMENT 'Medical record number. Encrypted (deterministic).',
+    value_numeric DECIMAL(18, 6) COMMENT 'Valeur mesurée (µmol/L)',
+    charge_amount NUMERIC(12, 2) DEFAULT 0 COMMENT 'Billed amount',
+    value_quantity STRING COMMENT 'Measured value with its unit',
+    resulted_at TIMESTAMP COMMENT 'When the result was released',
+    performer_name STRING COMMENT 'Who performed the test',
+    ordered_by STRING COMMENT 'Who ordered the test',
+    RAW_HL7_MSG BINARY COMMENT 'Original HL7 message',
+    icd10_code STRING COMMENT 'Diagnosis the test was ordered for',
+    reviewer_ids STRING COMMENT 'Reviewing clinicians',
+    _ingested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection'
+)
+USING DELTA
+COMMENT 'Résultat de laboratoire — température °C, 检验结果'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name',
+    'delta.feature.allowColumnDefaults' = 'supported',
+    'write.target-file-size-bytes' = '134217728'
+);
@@ -0,0 +1,30 @@
+-- A sample collected for analysis
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen (
+    id STRING NOT NULL COMMENT 'Logical id of this artifact',
+    status STRING NOT NULL DEFAULT 'available' COMMENT 'available | unavailable | entered-in-error',
+    accession_identifier STRING COMMENT 'Identifier assigned by the lab',
+    received_time TIMESTAMP COMMENT 'When the specimen was received',
+    collected_date DATE COMMENT 'Collection date',
+    quantity DECIMAL(18, 6) COMMENT 'Amount collected',
+    container_count INT DEFAULT 1 COMMENT 'Number of containers',
+    fasting BOOLEAN DEFAULT FALSE COMMENT 'Whether the patient was fasting',
+    note STRING COMMENT 'Comments',
+    subject STRING COMMENT 'Where the specimen came from',
+    request STRING COMMENT 'Why the specimen was collected',
+    visit STRING COMMENT 'Visit during which the specimen was collected',
+    collection STRING COMMENT 'Collection details',
+    _ingested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection'
+)
+USING DELTA
+COMMENT 'A sample collected for analysis'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name',
+    'delta.feature.allowColumnDefaults' = 'supported',
+    'write.target-file-size-bytes' = '134217728'
+);
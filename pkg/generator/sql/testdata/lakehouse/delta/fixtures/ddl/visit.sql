@@ -0,0 +1,31 @@
+-- An encounter whose field names are reserved words in target languages
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit (
+    class STRING NOT NULL COMMENT 'Classification of the visit',
+    type STRING COMMENT 'Specific type of visit',
+    "for" STRING COMMENT 'Who the visit is for',
+    match STRING DEFAULT 'unlinked' COMMENT 'Record linkage match grade',
+    "order" INT COMMENT 'Sequence within the episode',
+    self STRING COMMENT 'Canonical URL of the visit',
+    "where" STRING COMMENT 'Where the visit took place',
+    _2nd_opinion BOOLEAN COMMENT 'Whether a second opinion was requested',
+    priority_code STRING COMMENT 'Priority as a code',
+    priority_code_2 STRING COMMENT 'Priority as legacy text',
+    visit STRING COMMENT 'Visit number',
+    length STRING COMMENT 'Time the visit lasted',
+    attender STRING COMMENT 'Practitioner who saw the patient',
+    specimens STRING COMMENT 'Specimens collected during the visit',
+    _ingested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection'
+)
+USING DELTA
+COMMENT 'An encounter whose field names are reserved words in target languages'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name',
+    'delta.feature.allowColumnDefaults' = 'supported',
+    'write.target-file-size-bytes' = '134217728'
+);
@@ -0,0 +1,42 @@
+-- Purpose-of-use access to lab_result
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+
+CREATE OR REPLACE VIEW lab_result_treatment AS
+SELECT
+    result_id,
+    mrn,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM lab_result;
+
+CREATE OR REPLACE VIEW lab_result_research AS
+SELECT
+    result_id,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM lab_result;
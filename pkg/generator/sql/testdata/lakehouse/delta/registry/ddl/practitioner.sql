@@ -0,0 +1,26 @@
+-- A person providing care
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner (
+    id STRING NOT NULL COMMENT 'Logical id of this artifact',
+    meta STRING COMMENT 'Metadata about the resource',
+    implicit_rules STRING COMMENT 'A set of rules under which this content was created',
+    language STRING COMMENT 'Language of the resource content',
+    text STRING COMMENT 'Text summary of the resource, for human interpretation',
+    extension STRING COMMENT 'Additional content defined by implementations',
+    modifier_extension STRING COMMENT 'Extensions that cannot be ignored',
+    name STRING COMMENT 'The name(s) associated with the practitioner',
+    supervisor STRING COMMENT 'Practitioner responsible for this one',
+    _ingested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection'
+)
+USING DELTA
+COMMENT 'A person providing care'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name',
+    'delta.feature.allowColumnDefaults' = 'supported',
+    'write.target-file-size-bytes' = '134217728'
+);
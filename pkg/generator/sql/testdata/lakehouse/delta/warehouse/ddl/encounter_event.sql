@@ -0,0 +1,24 @@
+-- Encounter events by day
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_event (
+    id STRING NOT NULL COMMENT 'Event id',
+    status STRING DEFAULT 'planned' COMMENT 'Encounter status, such as \'planned\'',
+    recorded TIMESTAMP NOT NULL COMMENT 'When the event was recorded',
+    _ingested_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection',
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether the record was deleted at the source',
+    deleted_at TIMESTAMP COMMENT 'When the record was deleted',
+    recorded_date DATE GENERATED ALWAYS AS (CAST(recorded AS DATE)) COMMENT 'Day of recorded, which the table is partitioned by'
+)
+USING DELTA
+PARTITIONED BY (recorded_date)
+COMMENT 'Encounter events by day'
+TBLPROPERTIES (
+    'delta.columnMapping.mode' = 'name',
+    'delta.feature.allowColumnDefaults' = 'supported',
+    'write.target-file-size-bytes' = '134217728'
+);
@@ -0,0 +1,26 @@
+{#
+  A person providing care
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='registry'
+) }}
+
+SELECT
+    id,
+    meta,
+    implicit_rules,
+    language,
+    text,
+    extension,
+    modifier_extension,
+    name,
+    supervisor,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM {{ source('registry', 'practitioner') }}
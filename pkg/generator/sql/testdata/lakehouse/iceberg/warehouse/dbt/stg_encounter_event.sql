@@ -0,0 +1,21 @@
+{#
+  Encounter events by day
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    status,
+    recorded,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM {{ source('warehouse', 'encounter_event') }}
+WHERE NOT is_deleted
@@ -0,0 +1,22 @@
+-- Encounter events by day
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_event (
+    id STRING NOT NULL COMMENT 'Event id',
+    status STRING COMMENT 'Encounter status, such as \'planned\'',
+    recorded TIMESTAMP NOT NULL COMMENT 'When the event was recorded',
+    _ingested_at TIMESTAMP COMMENT 'When the record was loaded into the warehouse',
+    _source_system STRING COMMENT 'System the record was extracted from',
+    _record_hash STRING COMMENT 'Hash of the source record, for change detection',
+    is_deleted BOOLEAN NOT NULL COMMENT 'Whether the record was deleted at the source',
+    deleted_at TIMESTAMP COMMENT 'When the record was deleted'
+)
+USING ICEBERG
+PARTITIONED BY (days(recorded))
+COMMENT 'Encounter events by day'
+TBLPROPERTIES (
+    'format-version' = '2',
+    'write.target-file-size-bytes' = '134217728'
+);
@@ -0,0 +1,9 @@
+-- Practitioner from ehr.VISIT
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE OR REPLACE VIEW practitioner_from_ehr_visit AS
+SELECT
+    ATTENDING_ID AS id
+FROM ehr.VISIT;
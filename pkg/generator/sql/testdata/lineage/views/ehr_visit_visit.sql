@@ -0,0 +1,15 @@
+-- Visit from ehr.VISIT
+--
+-- Visits,
+-- with their "class"
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE OR REPLACE VIEW visit_from_ehr_visit AS
+SELECT
+    VISIT_ID AS visit
+FROM ehr.VISIT;
+
+-- Not in the view:
+--   class.code: source "CLASS_CODE || '-' || CLASS_SUB" is not a column
@@ -0,0 +1,9 @@
+-- Specimen from lis.SPECIMEN_ARCHIVE
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE OR REPLACE VIEW specimen_from_lis_specimen_archive AS
+SELECT
+    SPEC_ID AS id
+FROM lis.SPECIMEN_ARCHIVE;
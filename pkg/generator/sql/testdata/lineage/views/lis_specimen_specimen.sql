@@ -0,0 +1,18 @@
+-- Specimen from lis.SPECIMEN
+--
+-- Specimens received by the lab
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE OR REPLACE VIEW specimen_from_lis_specimen AS
+SELECT
+    SPEC_ID AS id,
+    CASE WHEN STATUS_C IS NULL THEN 'entered-in-error' WHEN STATUS_C = '1' THEN 'available' WHEN STATUS_C = '2' THEN 'unavailable' ELSE STATUS_C END AS status,
+    CAST(RECV_DTTM AS TIMESTAMP) AS received_time,
+    ACCESSION_NUM AS accession_identifier_value,
+    'urn:oid:1.2.3.4' AS accession_identifier_system,
+    COALESCE(CONTAINERS, 1) AS container_count,
+    CONCAT('Patient/', PAT_ID) AS subject_reference,
+    COLLECTOR_NOTE AS note_1 -- transform hl7_escape is not applied
+FROM lis.SPECIMEN;
@@ -0,0 +1,16 @@
+{#
+  Patients & their <current> names
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    mrn,
+    family_name
+FROM {{ source('warehouse', 'patient_dim') }}
@@ -0,0 +1,15 @@
+-- Patients & their <current> names
+--
+-- Generated by ehrglot v0.1.0.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS patient_dim (
+    mrn VARCHAR(255) NOT NULL,
+    family_name VARCHAR(255)
+);
+
+-- Add comments
+COMMENT ON TABLE patient_dim IS 'Patients & their <current> names';
+COMMENT ON COLUMN patient_dim.mrn IS 'Medical record number';
+COMMENT ON COLUMN patient_dim.family_name IS 'Family name';
+
@@ -0,0 +1,15 @@
+-- A person in the master patient index
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS person (
+    id STRING NOT NULL OPTIONS(description="Logical id of this person"),
+    mrn STRING NOT NULL OPTIONS(description="Medical record number"),
+    ssn STRING OPTIONS(description="Social security number"),
+    family_name STRING OPTIONS(description=""),
+    given_name STRING OPTIONS(description=""),
+    birth_date DATE OPTIONS(description=""),
+    sex STRING OPTIONS(description="")
+)
+OPTIONS(description="A person in the master patient index");
@@ -0,0 +1,21 @@
+{#
+  A person in the master patient index
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='mpi'
+) }}
+
+SELECT
+    id,
+    mrn,
+    ssn,
+    family_name,
+    given_name,
+    birth_date,
+    sex
+FROM {{ source('mpi', 'person') }}
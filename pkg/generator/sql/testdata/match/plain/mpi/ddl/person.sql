@@ -0,0 +1,25 @@
+-- A person in the master patient index
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS person (
+    id VARCHAR(255) NOT NULL,
+    mrn VARCHAR(255) NOT NULL,
+    ssn VARCHAR(255),
+    family_name VARCHAR(255),
+    given_name VARCHAR(255),
+    birth_date VARCHAR(10),
+    sex VARCHAR(255)
+);
+
+-- Add comments
+COMMENT ON TABLE person IS 'A person in the master patient index';
+COMMENT ON COLUMN person.id IS 'Logical id of this person';
+COMMENT ON COLUMN person.mrn IS 'Medical record number';
+COMMENT ON COLUMN person.ssn IS 'Social security number';
+COMMENT ON COLUMN person.family_name IS '';
+COMMENT ON COLUMN person.given_name IS '';
+COMMENT ON COLUMN person.birth_date IS '';
+COMMENT ON COLUMN person.sex IS '';
+
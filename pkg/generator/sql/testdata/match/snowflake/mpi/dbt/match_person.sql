@@ -0,0 +1,55 @@
+{#
+  Patient matching keys of stg_person, a row per key of a record, which
+  records that are candidate matches share.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='mpi'
+) }}
+
+WITH normalized AS (
+    SELECT
+        id,
+        UPPER(REGEXP_REPLACE(family_name, '[^A-Za-z]', '')) AS family_name_key,
+        UPPER(REGEXP_REPLACE(given_name, '[^A-Za-z]', '')) AS given_name_key,
+        TO_CHAR(birth_date, 'YYYY-MM-DD') AS birth_date_key,
+        SUBSTR(UPPER(REGEXP_REPLACE(sex, '[^A-Za-z]', '')), 1, 1) AS sex_key,
+        UPPER(REGEXP_REPLACE(mrn, '[^A-Za-z0-9]', '')) AS mrn_key,
+        UPPER(REGEXP_REPLACE(ssn, '[^A-Za-z0-9]', '')) AS ssn_key
+    FROM {{ ref('stg_person') }}
+)
+
+SELECT
+    id,
+    'demographics' AS key_type,
+    'demographics:' || SOUNDEX(family_name_key)
+        || '|' || SOUNDEX(given_name_key)
+        || '|' || birth_date_key
+        || '|' || sex_key AS match_key
+FROM normalized
+WHERE family_name_key <> ''
+  AND given_name_key <> ''
+  AND birth_date_key <> ''
+  AND sex_key <> ''
+
+UNION ALL
+
+SELECT
+    id,
+    'mrn' AS key_type,
+    'mrn:' || mrn_key AS match_key
+FROM normalized
+WHERE mrn_key <> ''
+
+UNION ALL
+
+SELECT
+    id,
+    'ssn' AS key_type,
+    'ssn:' || ssn_key AS match_key
+FROM normalized
+WHERE ssn_key <> ''
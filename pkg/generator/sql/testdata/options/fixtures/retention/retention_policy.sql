@@ -0,0 +1,17 @@
+-- Retention periods of fixtures tables, for compliance audits.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS fixtures_retention_policy (
+    source_table VARCHAR(255) NOT NULL,
+    retention_field VARCHAR(255) NOT NULL,
+    retention_count INTEGER NOT NULL,
+    retention_unit VARCHAR(255) NOT NULL,
+    PRIMARY KEY (source_table)
+);
+
+DELETE FROM fixtures_retention_policy WHERE TRUE;
+
+INSERT INTO fixtures_retention_policy (source_table, retention_field, retention_count, retention_unit) VALUES
+    ('lab_result', 'resulted_at', 7, 'year');
@@ -0,0 +1,51 @@
+-- Purpose-of-use access to lab_result
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Rows: sessions set ehrglot.purpose_of_use to the purpose they read for,
+-- and only 'treatment', 'research' may read the table. Its owner, which loads it,
+-- is exempt.
+ALTER TABLE lab_result ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY lab_result_purpose_of_use ON lab_result
+    FOR SELECT
+    USING (current_setting('ehrglot.purpose_of_use', true) IN ('treatment', 'research'));
+
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+
+CREATE OR REPLACE VIEW lab_result_treatment AS
+SELECT
+    result_id,
+    mrn,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM lab_result;
+
+CREATE OR REPLACE VIEW lab_result_research AS
+SELECT
+    result_id,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids,
+    _ingested_at,
+    _source_system,
+    _record_hash
+FROM lab_result;
@@ -0,0 +1,35 @@
+-- A person providing care
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS practitioner (
+    id VARCHAR(255) NOT NULL,
+    meta JSONB,
+    implicit_rules VARCHAR(255),
+    language VARCHAR(255),
+    text JSONB,
+    extension JSONB,
+    modifier_extension JSONB,
+    name JSONB,
+    supervisor JSONB,
+    _ingested_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    _source_system VARCHAR(255),
+    _record_hash VARCHAR(255)
+);
+
+-- Add comments
+COMMENT ON TABLE practitioner IS 'A person providing care';
+COMMENT ON COLUMN practitioner.id IS 'Logical id of this artifact';
+COMMENT ON COLUMN practitioner.meta IS 'Metadata about the resource';
+COMMENT ON COLUMN practitioner.implicit_rules IS 'A set of rules under which this content was created';
+COMMENT ON COLUMN practitioner.language IS 'Language of the resource content';
+COMMENT ON COLUMN practitioner.text IS 'Text summary of the resource, for human interpretation';
+COMMENT ON COLUMN practitioner.extension IS 'Additional content defined by implementations';
+COMMENT ON COLUMN practitioner.modifier_extension IS 'Extensions that cannot be ignored';
+COMMENT ON COLUMN practitioner.name IS 'The name(s) associated with the practitioner';
+COMMENT ON COLUMN practitioner.supervisor IS 'Practitioner responsible for this one';
+COMMENT ON COLUMN practitioner._ingested_at IS 'When the record was loaded into the warehouse';
+COMMENT ON COLUMN practitioner._source_system IS 'System the record was extracted from';
+COMMENT ON COLUMN practitioner._record_hash IS 'Hash of the source record, for change detection';
+
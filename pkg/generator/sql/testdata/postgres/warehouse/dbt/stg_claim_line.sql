@@ -0,0 +1,18 @@
+{#
+  Claim lines by payer
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    payer,
+    service_date,
+    amount
+FROM {{ source('warehouse', 'claim_line') }}
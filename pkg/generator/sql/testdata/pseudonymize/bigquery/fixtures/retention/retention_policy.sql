@@ -0,0 +1,17 @@
+-- Retention periods of fixtures tables, for compliance audits.
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS fixtures_retention_policy (
+    source_table STRING NOT NULL,
+    retention_field STRING NOT NULL,
+    retention_count INT64 NOT NULL,
+    retention_unit STRING NOT NULL,
+    PRIMARY KEY (source_table) NOT ENFORCED
+);
+
+DELETE FROM fixtures_retention_policy WHERE TRUE;
+
+INSERT INTO fixtures_retention_policy (source_table, retention_field, retention_count, retention_unit) VALUES
+    ('lab_result', 'resulted_at', 7, 'year');
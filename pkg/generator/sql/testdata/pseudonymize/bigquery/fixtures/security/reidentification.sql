@@ -0,0 +1,15 @@
+-- Re-identification table of the pseudonyms of specimen, lab_result
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS fixtures_reidentification (
+    token STRING(64) NOT NULL,
+    source_field STRING NOT NULL,
+    value STRING NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP(),
+    PRIMARY KEY (token, source_field) NOT ENFORCED
+);
+
+-- BigQuery grants IAM roles to principals, such as group:reid@example.com.
+GRANT `roles/bigquery.dataEditor` ON TABLE fixtures_reidentification TO "reidentification";
@@ -0,0 +1,15 @@
+-- Re-identification table of the pseudonyms of specimen, lab_result
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS fixtures_reidentification (
+    token CHAR(64) NOT NULL,
+    source_field VARCHAR(255) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (token, source_field)
+);
+
+REVOKE ALL ON fixtures_reidentification FROM PUBLIC;
+GRANT SELECT, INSERT ON fixtures_reidentification TO reidentification;
@@ -0,0 +1,28 @@
+{#
+  A sample collected for analysis
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='fixtures'
+) }}
+
+SELECT
+    id,
+    status,
+    accession_identifier,
+    received_time,
+    collected_date,
+    quantity,
+    container_count,
+    fasting,
+    note,
+    subject,
+    request,
+    visit,
+    collection,
+    DATEDIFF(day, CAST(collected_date AS DATE), CAST(received_time AS DATE)) AS turnaround_days
+FROM {{ source('fixtures', 'specimen') }}
@@ -0,0 +1,13 @@
+{#
+  Rows of stg_specimen with values outside the reference ranges of their
+  fields, to review.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(severity='warn') }}
+
+SELECT *
+FROM {{ ref('stg_specimen') }}
+WHERE container_count > 4
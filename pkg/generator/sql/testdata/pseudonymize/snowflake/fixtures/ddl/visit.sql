@@ -0,0 +1,39 @@
+-- An encounter whose field names are reserved words in target languages
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit (
+    class VARIANT NOT NULL,
+    type VARIANT,
+    "for" VARIANT,
+    match VARCHAR(255) DEFAULT 'unlinked',
+    "order" INTEGER,
+    self VARCHAR(255),
+    "where" VARIANT,
+    _2nd_opinion BOOLEAN,
+    priority_code VARCHAR(255),
+    priority_code_2 VARCHAR(255),
+    visit VARCHAR(255),
+    length VARIANT,
+    attender VARIANT,
+    specimens VARIANT
+);
+
+-- Add comments
+COMMENT ON TABLE visit IS 'An encounter whose field names are reserved words in target languages';
+COMMENT ON COLUMN visit.class IS 'Classification of the visit';
+COMMENT ON COLUMN visit.type IS 'Specific type of visit';
+COMMENT ON COLUMN visit."for" IS 'Who the visit is for';
+COMMENT ON COLUMN visit.match IS 'Record linkage match grade';
+COMMENT ON COLUMN visit."order" IS 'Sequence within the episode';
+COMMENT ON COLUMN visit.self IS 'Canonical URL of the visit';
+COMMENT ON COLUMN visit."where" IS 'Where the visit took place';
+COMMENT ON COLUMN visit._2nd_opinion IS 'Whether a second opinion was requested';
+COMMENT ON COLUMN visit.priority_code IS 'Priority as a code';
+COMMENT ON COLUMN visit.priority_code_2 IS 'Priority as legacy text';
+COMMENT ON COLUMN visit.visit IS 'Visit number';
+COMMENT ON COLUMN visit.length IS 'Time the visit lasted';
+COMMENT ON COLUMN visit.attender IS 'Practitioner who saw the patient';
+COMMENT ON COLUMN visit.specimens IS 'Specimens collected during the visit';
+
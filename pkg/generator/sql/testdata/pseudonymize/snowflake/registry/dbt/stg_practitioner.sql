@@ -0,0 +1,23 @@
+{#
+  A person providing care
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='registry'
+) }}
+
+SELECT
+    id,
+    meta,
+    implicit_rules,
+    language,
+    text,
+    extension,
+    modifier_extension,
+    name,
+    supervisor
+FROM {{ source('registry', 'practitioner') }}
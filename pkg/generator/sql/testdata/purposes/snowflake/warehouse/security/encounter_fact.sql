@@ -0,0 +1,22 @@
+-- Purpose-of-use access to encounter_fact
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+
+CREATE OR REPLACE SECURE VIEW encounter_fact_treatment AS
+SELECT
+    id,
+    patient_id,
+    facility
+FROM encounter_fact
+WHERE is_current;
+
+CREATE OR REPLACE SECURE VIEW encounter_fact_operations AS
+SELECT
+    id,
+    facility
+FROM encounter_fact
+WHERE is_current;
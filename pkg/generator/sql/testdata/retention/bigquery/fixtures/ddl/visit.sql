@@ -0,0 +1,22 @@
+-- An encounter whose field names are reserved words in target languages
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS visit (
+    class JSON NOT NULL OPTIONS(description="Classification of the visit"),
+    type JSON OPTIONS(description="Specific type of visit"),
+    "for" JSON OPTIONS(description="Who the visit is for"),
+    match STRING DEFAULT 'unlinked' OPTIONS(description="Record linkage match grade"),
+    "order" INT64 OPTIONS(description="Sequence within the episode"),
+    self STRING OPTIONS(description="Canonical URL of the visit"),
+    "where" JSON OPTIONS(description="Where the visit took place"),
+    _2nd_opinion BOOL OPTIONS(description="Whether a second opinion was requested"),
+    priority_code STRING OPTIONS(description="Priority as a code"),
+    priority_code_2 STRING OPTIONS(description="Priority as legacy text"),
+    visit STRING OPTIONS(description="Visit number"),
+    length JSON OPTIONS(description="Time the visit lasted"),
+    attender JSON OPTIONS(description="Practitioner who saw the patient"),
+    specimens JSON OPTIONS(description="Specimens collected during the visit")
+)
+OPTIONS(description="An encounter whose field names are reserved words in target languages");
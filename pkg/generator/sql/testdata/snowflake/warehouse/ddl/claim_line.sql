@@ -0,0 +1,21 @@
+-- Claim lines by payer
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS claim_line (
+    id VARCHAR(255) NOT NULL,
+    payer VARCHAR(255) NOT NULL,
+    service_date DATE,
+    amount DECIMAL(18, 6),
+    CONSTRAINT claim_line_uq UNIQUE (id)
+)
+CLUSTER BY (payer);
+
+-- Add comments
+COMMENT ON TABLE claim_line IS 'Claim lines by payer';
+COMMENT ON COLUMN claim_line.id IS 'Claim line id';
+COMMENT ON COLUMN claim_line.payer IS 'Payer code';
+COMMENT ON COLUMN claim_line.service_date IS 'Date of service';
+COMMENT ON COLUMN claim_line.amount IS 'Billed amount';
+
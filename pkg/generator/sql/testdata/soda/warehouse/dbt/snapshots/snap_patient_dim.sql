@@ -0,0 +1,19 @@
+{#
+  Snapshot of patient_dim
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{% snapshot snap_patient_dim %}
+
+{{ config(
+    target_schema='warehouse_snapshots',
+    unique_key='mrn',
+    strategy='check',
+    check_cols='all'
+) }}
+
+SELECT * FROM {{ ref('stg_patient_dim') }}
+
+{% endsnapshot %}
@@ -0,0 +1,21 @@
+{#
+  Lab orders
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    status,
+    priority,
+    placer,
+    placer_system,
+    ordered_at,
+    tube_count
+FROM {{ source('warehouse', 'lab_order') }}
@@ -0,0 +1,13 @@
+{#
+  Rows of stg_lab_order with values outside the reference ranges of their
+  fields, to review.
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(severity='warn') }}
+
+SELECT *
+FROM {{ ref('stg_lab_order') }}
+WHERE tube_count NOT BETWEEN 1 AND 6
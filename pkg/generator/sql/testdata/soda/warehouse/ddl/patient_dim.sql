@@ -0,0 +1,25 @@
+-- Patients
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS patient_dim (
+    mrn VARCHAR(255) NOT NULL,
+    birth_date DATE,
+    patient_dim_sk BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP,
+    is_current BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS patient_dim_mrn_key ON patient_dim (mrn) WHERE is_current;
+
+-- Add comments
+COMMENT ON TABLE patient_dim IS 'Patients';
+COMMENT ON COLUMN patient_dim.mrn IS 'Medical record number';
+COMMENT ON COLUMN patient_dim.birth_date IS 'Date of birth';
+COMMENT ON COLUMN patient_dim.patient_dim_sk IS 'Surrogate key of this version of the record';
+COMMENT ON COLUMN patient_dim.valid_from IS 'When this version became current';
+COMMENT ON COLUMN patient_dim.valid_to IS 'When this version was superseded, or null while it is current';
+COMMENT ON COLUMN patient_dim.is_current IS 'Whether this is the current version';
+
@@ -0,0 +1,22 @@
+-- A sample collected for analysis
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS specimen (
+    tenant_id STRING NOT NULL OPTIONS(description="Tenant the record belongs to"),
+    id STRING NOT NULL OPTIONS(description="Logical id of this artifact"),
+    status STRING NOT NULL DEFAULT 'available' OPTIONS(description="available | unavailable | entered-in-error"),
+    accession_identifier JSON OPTIONS(description="Identifier assigned by the lab"),
+    received_time TIMESTAMP OPTIONS(description="When the specimen was received"),
+    collected_date DATE OPTIONS(description="Collection date"),
+    quantity NUMERIC(18, 6) OPTIONS(description="Amount collected"),
+    container_count INT64 DEFAULT 1 OPTIONS(description="Number of containers"),
+    fasting BOOL DEFAULT FALSE OPTIONS(description="Whether the patient was fasting"),
+    note JSON OPTIONS(description="Comments"),
+    subject JSON OPTIONS(description="Where the specimen came from"),
+    request JSON OPTIONS(description="Why the specimen was collected"),
+    visit JSON OPTIONS(description="Visit during which the specimen was collected"),
+    collection JSON OPTIONS(description="Collection details")
+)
+OPTIONS(description="A sample collected for analysis");
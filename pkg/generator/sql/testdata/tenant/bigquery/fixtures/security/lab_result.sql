@@ -0,0 +1,40 @@
+-- Purpose-of-use access to lab_result
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Columns: one view per purpose, with only the columns it may read. Grant
+-- readers the view of their purpose rather than the table.
+
+CREATE OR REPLACE VIEW lab_result_treatment AS
+SELECT
+    tenant_id,
+    result_id,
+    mrn,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids
+FROM lab_result
+WHERE tenant_id IN (SELECT tenant_id FROM fixtures_tenant_access WHERE principal = SESSION_USER());
+
+CREATE OR REPLACE VIEW lab_result_research AS
+SELECT
+    tenant_id,
+    result_id,
+    value_numeric,
+    charge_amount,
+    value_quantity,
+    resulted_at,
+    performer_name,
+    ordered_by,
+    RAW_HL7_MSG,
+    icd10_code,
+    reviewer_ids
+FROM lab_result
+WHERE tenant_id IN (SELECT tenant_id FROM fixtures_tenant_access WHERE principal = SESSION_USER());
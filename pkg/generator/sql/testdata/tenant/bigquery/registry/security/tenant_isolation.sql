@@ -0,0 +1,17 @@
+-- Tenant isolation of the registry tables
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Each row grants a principal the rows of a tenant: a role in Snowflake, a
+-- user in BigQuery. Grant readers the views below rather than the tables.
+CREATE TABLE IF NOT EXISTS registry_tenant_access (
+    principal STRING NOT NULL,
+    tenant_id STRING NOT NULL,
+    PRIMARY KEY (principal, tenant_id) NOT ENFORCED
+);
+
+CREATE OR REPLACE VIEW practitioner_tenant AS
+SELECT *
+FROM practitioner
+WHERE tenant_id IN (SELECT tenant_id FROM registry_tenant_access WHERE principal = SESSION_USER());
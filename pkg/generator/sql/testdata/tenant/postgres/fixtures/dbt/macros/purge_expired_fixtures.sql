@@ -0,0 +1,15 @@
+{#
+  Deletes the records of fixtures tables older than their retention
+  periods. Run with: dbt run-operation purge_expired_fixtures
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{% macro purge_expired_fixtures() %}
+  {% set purge %}
+    DELETE FROM {{ source('fixtures', 'lab_result') }}
+    WHERE resulted_at < CURRENT_TIMESTAMP - INTERVAL '7 years'
+  {% endset %}
+  {% do run_query(purge) %}
+{% endmacro %}
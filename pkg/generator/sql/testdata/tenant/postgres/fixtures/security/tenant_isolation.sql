@@ -0,0 +1,27 @@
+-- Tenant isolation of the fixtures tables
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Sessions set ehrglot.tenant_id to the tenant they act for, and read and
+-- write only its rows. Table owners, which load them, are exempt.
+
+ALTER TABLE specimen ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY specimen_tenant_isolation ON specimen
+    USING (tenant_id = current_setting('ehrglot.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('ehrglot.tenant_id', true));
+
+ALTER TABLE lab_result ENABLE ROW LEVEL SECURITY;
+
+-- Restrictive, so that the purpose-of-use policy of the table also holds.
+CREATE POLICY lab_result_tenant_isolation ON lab_result
+    AS RESTRICTIVE
+    USING (tenant_id = current_setting('ehrglot.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('ehrglot.tenant_id', true));
+
+ALTER TABLE visit ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY visit_tenant_isolation ON visit
+    USING (tenant_id = current_setting('ehrglot.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('ehrglot.tenant_id', true));
@@ -0,0 +1,13 @@
+-- Tenant isolation of the registry tables
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Sessions set ehrglot.tenant_id to the tenant they act for, and read and
+-- write only its rows. Table owners, which load them, are exempt.
+
+ALTER TABLE practitioner ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY practitioner_tenant_isolation ON practitioner
+    USING (tenant_id = current_setting('ehrglot.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('ehrglot.tenant_id', true));
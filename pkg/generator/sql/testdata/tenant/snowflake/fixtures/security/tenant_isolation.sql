@@ -0,0 +1,27 @@
+-- Tenant isolation of the fixtures tables
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+-- Each row grants a principal the rows of a tenant: a role in Snowflake, a
+-- user in BigQuery. Grant readers the views below rather than the tables.
+CREATE TABLE IF NOT EXISTS fixtures_tenant_access (
+    principal VARCHAR(255) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    PRIMARY KEY (principal, tenant_id)
+);
+
+CREATE OR REPLACE SECURE VIEW specimen_tenant AS
+SELECT *
+FROM specimen
+WHERE tenant_id IN (SELECT tenant_id FROM fixtures_tenant_access WHERE principal = CURRENT_ROLE());
+
+CREATE OR REPLACE SECURE VIEW lab_result_tenant AS
+SELECT *
+FROM lab_result
+WHERE tenant_id IN (SELECT tenant_id FROM fixtures_tenant_access WHERE principal = CURRENT_ROLE());
+
+CREATE OR REPLACE SECURE VIEW visit_tenant AS
+SELECT *
+FROM visit
+WHERE tenant_id IN (SELECT tenant_id FROM fixtures_tenant_access WHERE principal = CURRENT_ROLE());
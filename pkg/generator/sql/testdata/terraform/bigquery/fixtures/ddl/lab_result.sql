@@ -0,0 +1,19 @@
+-- Résultat de laboratoire — température °C, 检验结果
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS lab_result (
+    result_id STRING NOT NULL OPTIONS(description="Identifiant du résultat"),
+    mrn STRING NOT NULL OPTIONS(description="Medical record number. Encrypted (deterministic)."),
+    value_numeric NUMERIC(18, 6) OPTIONS(description="Valeur mesurée (µmol/L)"),
+    charge_amount NUMERIC(12, 2) DEFAULT 0 OPTIONS(description="Billed amount"),
+    value_quantity JSON OPTIONS(description="Measured value with its unit"),
+    resulted_at TIMESTAMP OPTIONS(description="When the result was released"),
+    performer_name JSON OPTIONS(description="Who performed the test"),
+    ordered_by JSON OPTIONS(description="Who ordered the test"),
+    RAW_HL7_MSG BYTES OPTIONS(description="Original HL7 message"),
+    icd10_code STRING OPTIONS(description="Diagnosis the test was ordered for"),
+    reviewer_ids JSON OPTIONS(description="Reviewing clinicians")
+)
+OPTIONS(description="Résultat de laboratoire — température °C, 检验结果");
@@ -0,0 +1,83 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "google_bigquery_table" "lab_result" {
+  dataset_id  = var.dataset_id
+  table_id    = "lab_result"
+  description = "Résultat de laboratoire — température °C, 检验结果"
+
+  schema = jsonencode([
+    {
+      name        = "result_id"
+      type        = "STRING"
+      mode        = "REQUIRED"
+      description = "Identifiant du résultat"
+    },
+    {
+      name        = "mrn"
+      type        = "STRING"
+      mode        = "REQUIRED"
+      description = "Medical record number. Encrypted (deterministic)."
+      policyTags  = { names = [google_data_catalog_policy_tag.pii_critical.id] }
+    },
+    {
+      name        = "value_numeric"
+      type        = "NUMERIC"
+      mode        = "NULLABLE"
+      precision   = 18
+      scale       = 6
+      description = "Valeur mesurée (µmol/L)"
+    },
+    {
+      name                   = "charge_amount"
+      type                   = "NUMERIC"
+      mode                   = "NULLABLE"
+      precision              = 12
+      scale                  = 2
+      defaultValueExpression = "0"
+      description            = "Billed amount"
+    },
+    {
+      name        = "value_quantity"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Measured value with its unit"
+    },
+    {
+      name        = "resulted_at"
+      type        = "TIMESTAMP"
+      mode        = "NULLABLE"
+      description = "When the result was released"
+    },
+    {
+      name        = "performer_name"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Who performed the test"
+    },
+    {
+      name        = "ordered_by"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Who ordered the test"
+    },
+    {
+      name        = "RAW_HL7_MSG"
+      type        = "BYTES"
+      mode        = "NULLABLE"
+      description = "Original HL7 message"
+    },
+    {
+      name        = "icd10_code"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Diagnosis the test was ordered for"
+    },
+    {
+      name        = "reviewer_ids"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Reviewing clinicians"
+    },
+  ])
+}
@@ -0,0 +1,95 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "google_bigquery_table" "specimen" {
+  dataset_id  = var.dataset_id
+  table_id    = "specimen"
+  description = "A sample collected for analysis"
+
+  schema = jsonencode([
+    {
+      name        = "id"
+      type        = "STRING"
+      mode        = "REQUIRED"
+      description = "Logical id of this artifact"
+    },
+    {
+      name                   = "status"
+      type                   = "STRING"
+      mode                   = "REQUIRED"
+      defaultValueExpression = "'available'"
+      description            = "available | unavailable | entered-in-error"
+    },
+    {
+      name        = "accession_identifier"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Identifier assigned by the lab"
+    },
+    {
+      name        = "received_time"
+      type        = "TIMESTAMP"
+      mode        = "NULLABLE"
+      description = "When the specimen was received"
+    },
+    {
+      name        = "collected_date"
+      type        = "DATE"
+      mode        = "NULLABLE"
+      description = "Collection date"
+    },
+    {
+      name        = "quantity"
+      type        = "NUMERIC"
+      mode        = "NULLABLE"
+      precision   = 18
+      scale       = 6
+      description = "Amount collected"
+    },
+    {
+      name                   = "container_count"
+      type                   = "INTEGER"
+      mode                   = "NULLABLE"
+      defaultValueExpression = "1"
+      description            = "Number of containers"
+    },
+    {
+      name                   = "fasting"
+      type                   = "BOOLEAN"
+      mode                   = "NULLABLE"
+      defaultValueExpression = "FALSE"
+      description            = "Whether the patient was fasting"
+    },
+    {
+      name        = "note"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Comments"
+    },
+    {
+      name        = "subject"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Where the specimen came from"
+      policyTags  = { names = [google_data_catalog_policy_tag.pii_high.id] }
+    },
+    {
+      name        = "request"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Why the specimen was collected"
+    },
+    {
+      name        = "visit"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Visit during which the specimen was collected"
+    },
+    {
+      name        = "collection"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Collection details"
+    },
+  ])
+}
@@ -0,0 +1,96 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "google_bigquery_table" "visit" {
+  dataset_id  = var.dataset_id
+  table_id    = "visit"
+  description = "An encounter whose field names are reserved words in target languages"
+
+  schema = jsonencode([
+    {
+      name        = "class"
+      type        = "JSON"
+      mode        = "REQUIRED"
+      description = "Classification of the visit"
+    },
+    {
+      name        = "type"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Specific type of visit"
+    },
+    {
+      name        = "for"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Who the visit is for"
+    },
+    {
+      name                   = "match"
+      type                   = "STRING"
+      mode                   = "NULLABLE"
+      defaultValueExpression = "'unlinked'"
+      description            = "Record linkage match grade"
+    },
+    {
+      name        = "order"
+      type        = "INTEGER"
+      mode        = "NULLABLE"
+      description = "Sequence within the episode"
+    },
+    {
+      name        = "self"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Canonical URL of the visit"
+    },
+    {
+      name        = "where"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Where the visit took place"
+    },
+    {
+      name        = "_2nd_opinion"
+      type        = "BOOLEAN"
+      mode        = "NULLABLE"
+      description = "Whether a second opinion was requested"
+    },
+    {
+      name        = "priority_code"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Priority as a code"
+    },
+    {
+      name        = "priority_code_2"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Priority as legacy text"
+    },
+    {
+      name        = "visit"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Visit number"
+    },
+    {
+      name        = "length"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Time the visit lasted"
+    },
+    {
+      name        = "attender"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Practitioner who saw the patient"
+    },
+    {
+      name        = "specimens"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Specimens collected during the visit"
+    },
+  ])
+}
@@ -0,0 +1,16 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+}
+
+variable "dataset_id" {
+  type        = string
+  description = "BigQuery dataset of the registry tables"
+  default     = "registry"
+}
@@ -0,0 +1,65 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "google_bigquery_table" "practitioner" {
+  dataset_id  = var.dataset_id
+  table_id    = "practitioner"
+  description = "A person providing care"
+
+  schema = jsonencode([
+    {
+      name        = "id"
+      type        = "STRING"
+      mode        = "REQUIRED"
+      description = "Logical id of this artifact"
+    },
+    {
+      name        = "meta"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Metadata about the resource"
+    },
+    {
+      name        = "implicit_rules"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "A set of rules under which this content was created"
+    },
+    {
+      name        = "language"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Language of the resource content"
+    },
+    {
+      name        = "text"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Text summary of the resource, for human interpretation"
+    },
+    {
+      name        = "extension"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Additional content defined by implementations"
+    },
+    {
+      name        = "modifier_extension"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Extensions that cannot be ignored"
+    },
+    {
+      name        = "name"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "The name(s) associated with the practitioner"
+    },
+    {
+      name        = "supervisor"
+      type        = "JSON"
+      mode        = "NULLABLE"
+      description = "Practitioner responsible for this one"
+    },
+  ])
+}
@@ -0,0 +1,19 @@
+{#
+  Encounters by day and facility
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{{ config(
+    materialized='view',
+    schema='warehouse'
+) }}
+
+SELECT
+    id,
+    patient_name,
+    facility,
+    start
+FROM {{ source('warehouse', 'encounter_fact') }}
+WHERE is_current
@@ -0,0 +1,19 @@
+-- Encounters by day and facility
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id STRING NOT NULL OPTIONS(description="Encounter id"),
+    patient_name STRING OPTIONS(description="Patient name"),
+    facility STRING OPTIONS(description="Facility code"),
+    start TIMESTAMP OPTIONS(description="When the encounter began"),
+    encounter_fact_sk STRING DEFAULT GENERATE_UUID() OPTIONS(description="Surrogate key of this version of the record"),
+    valid_from TIMESTAMP NOT NULL OPTIONS(description="When this version became current"),
+    valid_to TIMESTAMP OPTIONS(description="When this version was superseded, or null while it is current"),
+    is_current BOOL NOT NULL DEFAULT TRUE OPTIONS(description="Whether this is the current version"),
+    PRIMARY KEY (encounter_fact_sk) NOT ENFORCED
+)
+PARTITION BY DATE(start)
+CLUSTER BY facility
+OPTIONS(description="Encounters by day and facility");
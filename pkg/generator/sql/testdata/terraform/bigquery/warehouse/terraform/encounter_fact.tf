@@ -0,0 +1,69 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "google_bigquery_table" "encounter_fact" {
+  dataset_id  = var.dataset_id
+  table_id    = "encounter_fact"
+  description = "Encounters by day and facility"
+
+  time_partitioning {
+    type  = "DAY"
+    field = "start"
+  }
+
+  clustering = ["facility"]
+
+  schema = jsonencode([
+    {
+      name        = "id"
+      type        = "STRING"
+      mode        = "REQUIRED"
+      description = "Encounter id"
+    },
+    {
+      name        = "patient_name"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Patient name"
+      policyTags  = { names = [google_data_catalog_policy_tag.pii_high.id] }
+    },
+    {
+      name        = "facility"
+      type        = "STRING"
+      mode        = "NULLABLE"
+      description = "Facility code"
+    },
+    {
+      name        = "start"
+      type        = "TIMESTAMP"
+      mode        = "NULLABLE"
+      description = "When the encounter began"
+    },
+    {
+      name                   = "encounter_fact_sk"
+      type                   = "STRING"
+      mode                   = "NULLABLE"
+      defaultValueExpression = "GENERATE_UUID()"
+      description            = "Surrogate key of this version of the record"
+    },
+    {
+      name        = "valid_from"
+      type        = "TIMESTAMP"
+      mode        = "REQUIRED"
+      description = "When this version became current"
+    },
+    {
+      name        = "valid_to"
+      type        = "TIMESTAMP"
+      mode        = "NULLABLE"
+      description = "When this version was superseded, or null while it is current"
+    },
+    {
+      name                   = "is_current"
+      type                   = "BOOLEAN"
+      mode                   = "REQUIRED"
+      defaultValueExpression = "TRUE"
+      description            = "Whether this is the current version"
+    },
+  ])
+}
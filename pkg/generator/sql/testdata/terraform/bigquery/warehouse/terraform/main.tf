@@ -0,0 +1,35 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+terraform {
+  required_providers {
+    google = {
+      source = "hashicorp/google"
+    }
+  }
+}
+
+variable "dataset_id" {
+  type        = string
+  description = "BigQuery dataset of the warehouse tables"
+  default     = "warehouse"
+}
+
+variable "region" {
+  type        = string
+  description = "Region of the policy tag taxonomy, which must be the location of the dataset"
+  default     = "us"
+}
+
+resource "google_data_catalog_taxonomy" "pii" {
+  region                 = var.region
+  display_name           = "warehouse PII"
+  description            = "Sensitivity of the personal information in columns of the warehouse tables"
+  activated_policy_types = ["FINE_GRAINED_ACCESS_CONTROL"]
+}
+
+resource "google_data_catalog_policy_tag" "pii_high" {
+  taxonomy     = google_data_catalog_taxonomy.pii.id
+  display_name = "high"
+  description  = "Columns of pii_level high"
+}
@@ -0,0 +1,97 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "snowflake_table" "lab_result" {
+  database = var.database
+  schema   = var.schema
+  name     = "LAB_RESULT"
+  comment  = "Résultat de laboratoire — température °C, 检验结果"
+
+  column {
+    name     = "RESULT_ID"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "Identifiant du résultat"
+  }
+
+  column {
+    name     = "MRN"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "Medical record number. Encrypted (deterministic)."
+  }
+
+  column {
+    name     = "VALUE_NUMERIC"
+    type     = "DECIMAL(18, 6)"
+    nullable = true
+    comment  = "Valeur mesurée (µmol/L)"
+  }
+
+  column {
+    name     = "CHARGE_AMOUNT"
+    type     = "NUMERIC(12, 2)"
+    nullable = true
+    comment  = "Billed amount"
+
+    default {
+      expression = "0"
+    }
+  }
+
+  column {
+    name     = "VALUE_QUANTITY"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Measured value with its unit"
+  }
+
+  column {
+    name     = "RESULTED_AT"
+    type     = "TIMESTAMP_NTZ"
+    nullable = true
+    comment  = "When the result was released"
+  }
+
+  column {
+    name     = "PERFORMER_NAME"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Who performed the test"
+  }
+
+  column {
+    name     = "ORDERED_BY"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Who ordered the test"
+  }
+
+  column {
+    name     = "RAW_HL7_MSG"
+    type     = "BINARY"
+    nullable = true
+    comment  = "Original HL7 message"
+  }
+
+  column {
+    name     = "ICD10_CODE"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Diagnosis the test was ordered for"
+  }
+
+  column {
+    name     = "REVIEWER_IDS"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Reviewing clinicians"
+  }
+}
+
+resource "snowflake_tag_association" "lab_result_pii_critical" {
+  object_identifiers = ["${snowflake_table.lab_result.fully_qualified_name}.\"MRN\""]
+  object_type        = "COLUMN"
+  tag_id             = snowflake_tag.pii_level.fully_qualified_name
+  tag_value          = "critical"
+}
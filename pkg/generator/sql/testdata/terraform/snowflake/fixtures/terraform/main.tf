@@ -0,0 +1,29 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+terraform {
+  required_providers {
+    snowflake = {
+      source = "Snowflake-Labs/snowflake"
+    }
+  }
+}
+
+variable "database" {
+  type        = string
+  description = "Snowflake database of the fixtures tables"
+}
+
+variable "schema" {
+  type        = string
+  description = "Snowflake schema of the fixtures tables"
+  default     = "FIXTURES"
+}
+
+resource "snowflake_tag" "pii_level" {
+  database       = var.database
+  schema         = var.schema
+  name           = "PII_LEVEL"
+  comment        = "Sensitivity of the personal information in a column"
+  allowed_values = ["low", "medium", "high", "critical"]
+}
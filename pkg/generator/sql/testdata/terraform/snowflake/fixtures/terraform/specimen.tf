@@ -0,0 +1,119 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "snowflake_table" "specimen" {
+  database = var.database
+  schema   = var.schema
+  name     = "SPECIMEN"
+  comment  = "A sample collected for analysis"
+
+  column {
+    name     = "ID"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "Logical id of this artifact"
+  }
+
+  column {
+    name     = "STATUS"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "available | unavailable | entered-in-error"
+
+    default {
+      expression = "'available'"
+    }
+  }
+
+  column {
+    name     = "ACCESSION_IDENTIFIER"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Identifier assigned by the lab"
+  }
+
+  column {
+    name     = "RECEIVED_TIME"
+    type     = "TIMESTAMP_NTZ"
+    nullable = true
+    comment  = "When the specimen was received"
+  }
+
+  column {
+    name     = "COLLECTED_DATE"
+    type     = "DATE"
+    nullable = true
+    comment  = "Collection date"
+  }
+
+  column {
+    name     = "QUANTITY"
+    type     = "DECIMAL(18, 6)"
+    nullable = true
+    comment  = "Amount collected"
+  }
+
+  column {
+    name     = "CONTAINER_COUNT"
+    type     = "INTEGER"
+    nullable = true
+    comment  = "Number of containers"
+
+    default {
+      expression = "1"
+    }
+  }
+
+  column {
+    name     = "FASTING"
+    type     = "BOOLEAN"
+    nullable = true
+    comment  = "Whether the patient was fasting"
+
+    default {
+      expression = "FALSE"
+    }
+  }
+
+  column {
+    name     = "NOTE"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Comments"
+  }
+
+  column {
+    name     = "SUBJECT"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Where the specimen came from"
+  }
+
+  column {
+    name     = "REQUEST"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Why the specimen was collected"
+  }
+
+  column {
+    name     = "VISIT"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Visit during which the specimen was collected"
+  }
+
+  column {
+    name     = "COLLECTION"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Collection details"
+  }
+}
+
+resource "snowflake_tag_association" "specimen_pii_high" {
+  object_identifiers = ["${snowflake_table.specimen.fully_qualified_name}.\"SUBJECT\""]
+  object_type        = "COLUMN"
+  tag_id             = snowflake_tag.pii_level.fully_qualified_name
+  tag_value          = "high"
+}
@@ -0,0 +1,111 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "snowflake_table" "visit" {
+  database = var.database
+  schema   = var.schema
+  name     = "VISIT"
+  comment  = "An encounter whose field names are reserved words in target languages"
+
+  column {
+    name     = "CLASS"
+    type     = "VARIANT"
+    nullable = false
+    comment  = "Classification of the visit"
+  }
+
+  column {
+    name     = "TYPE"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Specific type of visit"
+  }
+
+  column {
+    name     = "for"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Who the visit is for"
+  }
+
+  column {
+    name     = "MATCH"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Record linkage match grade"
+
+    default {
+      expression = "'unlinked'"
+    }
+  }
+
+  column {
+    name     = "order"
+    type     = "INTEGER"
+    nullable = true
+    comment  = "Sequence within the episode"
+  }
+
+  column {
+    name     = "SELF"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Canonical URL of the visit"
+  }
+
+  column {
+    name     = "where"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Where the visit took place"
+  }
+
+  column {
+    name     = "_2ND_OPINION"
+    type     = "BOOLEAN"
+    nullable = true
+    comment  = "Whether a second opinion was requested"
+  }
+
+  column {
+    name     = "PRIORITY_CODE"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Priority as a code"
+  }
+
+  column {
+    name     = "PRIORITY_CODE_2"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Priority as legacy text"
+  }
+
+  column {
+    name     = "VISIT"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Visit number"
+  }
+
+  column {
+    name     = "LENGTH"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Time the visit lasted"
+  }
+
+  column {
+    name     = "ATTENDER"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Practitioner who saw the patient"
+  }
+
+  column {
+    name     = "SPECIMENS"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Specimens collected during the visit"
+  }
+}
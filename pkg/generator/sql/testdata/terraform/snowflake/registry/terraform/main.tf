@@ -0,0 +1,21 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+terraform {
+  required_providers {
+    snowflake = {
+      source = "Snowflake-Labs/snowflake"
+    }
+  }
+}
+
+variable "database" {
+  type        = string
+  description = "Snowflake database of the registry tables"
+}
+
+variable "schema" {
+  type        = string
+  description = "Snowflake schema of the registry tables"
+  default     = "REGISTRY"
+}
@@ -0,0 +1,72 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "snowflake_table" "practitioner" {
+  database = var.database
+  schema   = var.schema
+  name     = "PRACTITIONER"
+  comment  = "A person providing care"
+
+  column {
+    name     = "ID"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "Logical id of this artifact"
+  }
+
+  column {
+    name     = "META"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Metadata about the resource"
+  }
+
+  column {
+    name     = "IMPLICIT_RULES"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "A set of rules under which this content was created"
+  }
+
+  column {
+    name     = "LANGUAGE"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Language of the resource content"
+  }
+
+  column {
+    name     = "TEXT"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Text summary of the resource, for human interpretation"
+  }
+
+  column {
+    name     = "EXTENSION"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Additional content defined by implementations"
+  }
+
+  column {
+    name     = "MODIFIER_EXTENSION"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Extensions that cannot be ignored"
+  }
+
+  column {
+    name     = "NAME"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "The name(s) associated with the practitioner"
+  }
+
+  column {
+    name     = "SUPERVISOR"
+    type     = "VARIANT"
+    nullable = true
+    comment  = "Practitioner responsible for this one"
+  }
+}
@@ -0,0 +1,19 @@
+{#
+  Snapshot of encounter_fact
+
+  Generated by ehrglot v0.1.0 at <timestamp>.
+  DO NOT EDIT.
+#}
+
+{% snapshot snap_encounter_fact %}
+
+{{ config(
+    target_schema='warehouse_snapshots',
+    unique_key='id',
+    strategy='check',
+    check_cols='all'
+) }}
+
+SELECT * FROM {{ ref('stg_encounter_fact') }}
+
+{% endsnapshot %}
@@ -0,0 +1,28 @@
+-- Encounters by day and facility
+--
+-- Generated by ehrglot v0.1.0 at <timestamp>.
+-- DO NOT EDIT.
+
+CREATE TABLE IF NOT EXISTS encounter_fact (
+    id VARCHAR(255) NOT NULL,
+    patient_name VARCHAR(255),
+    facility VARCHAR(255),
+    start TIMESTAMP_NTZ,
+    encounter_fact_sk BIGINT IDENTITY PRIMARY KEY,
+    valid_from TIMESTAMP_NTZ NOT NULL,
+    valid_to TIMESTAMP_NTZ,
+    is_current BOOLEAN NOT NULL DEFAULT TRUE
+)
+CLUSTER BY (TO_DATE(start), facility);
+
+-- Add comments
+COMMENT ON TABLE encounter_fact IS 'Encounters by day and facility';
+COMMENT ON COLUMN encounter_fact.id IS 'Encounter id';
+COMMENT ON COLUMN encounter_fact.patient_name IS 'Patient name';
+COMMENT ON COLUMN encounter_fact.facility IS 'Facility code';
+COMMENT ON COLUMN encounter_fact.start IS 'When the encounter began';
+COMMENT ON COLUMN encounter_fact.encounter_fact_sk IS 'Surrogate key of this version of the record';
+COMMENT ON COLUMN encounter_fact.valid_from IS 'When this version became current';
+COMMENT ON COLUMN encounter_fact.valid_to IS 'When this version was superseded, or null while it is current';
+COMMENT ON COLUMN encounter_fact.is_current IS 'Whether this is the current version';
+
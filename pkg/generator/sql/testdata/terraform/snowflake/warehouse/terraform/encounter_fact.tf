@@ -0,0 +1,83 @@
+# Generated by ehrglot v0.1.0 at <timestamp>.
+# DO NOT EDIT.
+
+resource "snowflake_table" "encounter_fact" {
+  database = var.database
+  schema   = var.schema
+  name     = "ENCOUNTER_FACT"
+  comment  = "Encounters by day and facility"
+
+  cluster_by = ["TO_DATE(start)", "facility"]
+
+  column {
+    name     = "ID"
+    type     = "VARCHAR(255)"
+    nullable = false
+    comment  = "Encounter id"
+  }
+
+  column {
+    name     = "PATIENT_NAME"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Patient name"
+  }
+
+  column {
+    name     = "FACILITY"
+    type     = "VARCHAR(255)"
+    nullable = true
+    comment  = "Facility code"
+  }
+
+  column {
+    name     = "START"
+    type     = "TIMESTAMP_NTZ"
+    nullable = true
+    comment  = "When the encounter began"
+  }
+
+  column {
+    name     = "ENCOUNTER_FACT_SK"
+    type     = "BIGINT"
+    nullable = true
+    comment  = "Surrogate key of this version of the record"
+
+    identity {
+      start_num = 1
+      step_num  = 1
+    }
+  }
+
+  column {
+    name     = "VALID_FROM"
+    type     = "TIMESTAMP_NTZ"
+    nullable = false
+    comment  = "When this version became current"
+  }
+
+  column {
+    name     = "VALID_TO"
+    type     = "TIMESTAMP_NTZ"
+    nullable = true
+    comment  = "When this version was superseded, or null while it is current"
+  }
+
+  column {
+    name     = "IS_CURRENT"
+    type     = "BOOLEAN"
+    nullable = false
+    comment  = "Whether this is the current version"
+
+    default {
+      expression = "TRUE"
+    }
+  }
+}
+
+resource "snowflake_tag_association" "encounter_fact_pii_high" {
+  object_identifiers = ["${snowflake_table.encounter_fact.fully_qualified_name}.\"PATIENT_NAME\""]
+  object_type        = "COLUMN"
+  tag_id             = snowflake_tag.pii_level.fully_qualified_name
+  tag_value          = "high"
+}
@@ -0,0 +1,285 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/convert"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// ViewsDir is the directory, relative to the output directory, that the
+// views mappings define over their source tables are generated into.
+const ViewsDir = "views"
+
+// mappingView is a view that reads a source table as the resource a mapping
+// builds from it, without moving its rows.
+type mappingView struct {
+	Name     string
+	Mapping  schema.SchemaMapping
+	Table    string
+	Columns  []viewColumn
+	Skipped  []string
+	Resource string
+}
+
+// viewColumn is a column of a mapping view: a source column, translated and
+// cast as its field mapping says, under the name of its target path.
+type viewColumn struct {
+	Expr string
+	Name string
+	Note string
+}
+
+// pathStep matches one segment of a target path, such as name[0].
+var pathStep = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\[(\d+)\])?$`)
+
+// generateViews writes a view for each mapping that selects its source
+// table's columns under the names of the target fields they map to, so that
+// analysts can query raw extracts in the shape of the target resources.
+// Source tables are read as <source_system>.<source_table>, the schema and
+// table the generated dbt sources name.
+func (g *Generator) generateViews(mappings []schema.SchemaMapping, outputDir string) error {
+	var refs *resolve.Resolver
+	if len(g.schemas) > 0 {
+		var err error
+		if refs, err = resolve.New(g.schemas); err != nil {
+			return err
+		}
+	}
+	dir := filepath.Join(outputDir, ViewsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create views directory: %w", err)
+	}
+	names := make(map[string]bool)
+	for _, m := range mappings {
+		view := g.mappingView(refs, m)
+		file := naming.Snake.Apply(m.SourceSystem) + "_" + naming.Snake.Apply(m.SourceTable) + "_" + naming.Snake.Apply(m.TargetResource)
+		if names[file] {
+			return fmt.Errorf("%s: another mapping already reads %s.%s into %s", m.SourceFile, m.SourceSystem, m.SourceTable, m.TargetResource)
+		}
+		names[file] = true
+		if err := g.writeView(view, filepath.Join(dir, file+".sql")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mappingView compiles m into a view. Target field types come from the
+// schemas the generator last generated, when refs resolves the target
+// resource among them; otherwise only transforms decide the casts.
+func (g *Generator) mappingView(refs *resolve.Resolver, m schema.SchemaMapping) mappingView {
+	view := mappingView{
+		Name:     naming.Ident("sql", naming.Snake.Apply(m.TargetResource)+"_from_"+naming.Snake.Apply(m.SourceSystem)+"_"+naming.Snake.Apply(m.SourceTable)),
+		Mapping:  m,
+		Table:    naming.Ident("sql", naming.Snake.Apply(m.SourceSystem)) + "." + naming.Ident("sql", m.SourceTable),
+		Resource: m.TargetResource,
+	}
+	fieldType := func(string) string { return "" }
+	if refs != nil {
+		target, ok, err := refs.Scope(filepath.Base(filepath.Dir(m.SourceFile))).Resolve(m.TargetResource)
+		if err == nil && ok {
+			scope := refs.Scope(target.Namespace)
+			fieldType = func(path string) string { return convert.TargetType(scope, target.Fields, path) }
+		}
+	}
+
+	scope := naming.NewScope("sql")
+	seen := make(map[string]bool)
+	for _, fm := range m.FieldMappings {
+		name, ok := g.pathColumn(fm.Target)
+		switch {
+		case !ok:
+			view.Skipped = append(view.Skipped, fmt.Sprintf("%s: target %q is not a field path", fm.Source, fm.Target))
+			continue
+		case !sourceColumn.MatchString(fm.Source):
+			view.Skipped = append(view.Skipped, fmt.Sprintf("%s: source %q is not a column", fm.Target, fm.Source))
+			continue
+		}
+		values := fm.ValueMapping
+		if values == nil {
+			values = m.ValueMappings[fm.Transform]
+		}
+		expr, note := g.viewExpr(fm, values, fieldType(fm.Target))
+		view.Columns = append(view.Columns, viewColumn{Expr: expr, Name: scope.Ident(name), Note: note})
+		seen[fm.Target] = true
+
+		// Constants the target context sets alongside the value, such as
+		// the system of an identifier.
+		paths := make([]string, 0, len(fm.TargetContext))
+		for path := range fm.TargetContext {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			name, ok := g.pathColumn(path)
+			if !ok || seen[path] {
+				continue
+			}
+			seen[path] = true
+			value := fmt.Sprint(fm.TargetContext[path])
+			view.Columns = append(view.Columns, viewColumn{Expr: literal(schema.Field{Type: fieldType(path)}, value), Name: scope.Ident(name)})
+		}
+	}
+	return view
+}
+
+// pathColumn returns the column name of a target path: its segments as
+// column names joined by underscores, with the indexes of list elements
+// after the first, so that identifier[1].value becomes identifier_1_value.
+func (g *Generator) pathColumn(path string) (string, bool) {
+	var parts []string
+	for _, segment := range strings.Split(path, ".") {
+		m := pathStep.FindStringSubmatch(segment)
+		if m == nil {
+			return "", false
+		}
+		parts = append(parts, naming.Case(g.naming.Fields).Or(columnName)(m[1]))
+		if m[2] != "" && strings.TrimLeft(m[2], "0") != "" {
+			parts = append(parts, strings.TrimLeft(m[2], "0"))
+		}
+	}
+	return strings.Join(parts, "_"), true
+}
+
+// viewExpr returns the expression that reads the source column of fm as the
+// target field of type fieldType, which may be unknown: its value mapping as
+// a CASE, its transform as a cast or function, and its default for NULLs.
+// Transforms that SQL cannot express, such as HL7 datetimes and custom
+// transforms without value mappings, leave the column as it is, with a note
+// saying so.
+func (g *Generator) viewExpr(fm schema.FieldMapping, values schema.ValueMapping, fieldType string) (string, string) {
+	column := naming.Ident("sql", fm.Source)
+	expr := column
+	if len(values) > 0 {
+		codes := make([]string, 0, len(values))
+		for code := range values {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		var b strings.Builder
+		b.WriteString("CASE")
+		for _, code := range codes {
+			if code == "" {
+				fmt.Fprintf(&b, " WHEN %s IS NULL THEN %s", column, literal(schema.Field{}, values[code]))
+			} else {
+				fmt.Fprintf(&b, " WHEN %s = %s THEN %s", column, literal(schema.Field{}, code), literal(schema.Field{}, values[code]))
+			}
+		}
+		fmt.Fprintf(&b, " ELSE %s END", column)
+		expr = b.String()
+	}
+
+	// Transforms that produce their own type leave no cast to add.
+	var cast, note string
+	typed := false
+	switch fm.Transform {
+	case "":
+	case "to_string":
+		cast = g.dialect.varchar
+	case "to_integer":
+		cast = g.dialect.integer
+	case "to_decimal":
+		cast = g.dialect.decimal
+	case "to_boolean":
+		cast = g.dialect.boolean
+	case "invert_boolean", "invert_delete_ind":
+		expr, typed = fmt.Sprintf("NOT CAST(%s AS %s)", expr, g.dialect.boolean), true
+	case "datetime_to_fhir_date", "date_to_fhir_date":
+		cast = g.dateType
+	case "datetime_to_fhir_datetime", "datetime_to_fhir_instant":
+		cast = g.timestampType
+	case "oid_to_uri":
+		expr, typed = fmt.Sprintf("CASE WHEN %[1]s LIKE 'urn:oid:%%' THEN %[1]s ELSE CONCAT('urn:oid:', %[1]s) END", expr), true
+	default:
+		if resource, ok := convert.ReferenceResource(fm.Transform); ok {
+			expr, typed = fmt.Sprintf("CONCAT('%s/', %s)", resource, expr), true
+		} else if len(values) == 0 {
+			note = fmt.Sprintf("transform %s is not applied", fm.Transform)
+		}
+	}
+	if cast == "" && !typed && note == "" && fieldType != "" {
+		// Text and JSON columns are read as they are.
+		if t := g.toSQLType(schema.Field{Type: fieldType}); t != g.dialect.varchar && t != g.dialect.json {
+			cast = t
+		}
+	}
+	if cast != "" {
+		expr = fmt.Sprintf("CAST(%s AS %s)", expr, cast)
+	}
+	if fm.Default != nil {
+		if fieldType == "" {
+			fieldType = yamlType(fm.Default)
+		}
+		expr = fmt.Sprintf("COALESCE(%s, %s)", expr, literal(schema.Field{Type: fieldType}, fmt.Sprint(fm.Default)))
+	}
+	return expr, note
+}
+
+// yamlType returns the field type of a value decoded from YAML.
+func yamlType(value any) string {
+	switch value.(type) {
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "decimal"
+	case bool:
+		return "boolean"
+	}
+	return "string"
+}
+
+const viewTemplate = `-- {{.Resource}} from {{.Mapping.SourceSystem}}.{{.Mapping.SourceTable}}
+{{- with .Mapping.Description}}
+--
+{{. | comment}}{{end}}
+--
+{{header}}
+CREATE OR REPLACE VIEW {{.Name}} AS
+SELECT
+{{- range $i, $c := .Columns}}{{if $i}},{{end}}
+    {{$c.Expr}} AS {{$c.Name}}{{with $c.Note}} -- {{.}}{{end}}
+{{- end}}
+FROM {{.Table}};
+{{- with .Skipped}}
+
+-- Not in the view:
+{{- range .}}
+--   {{.}}
+{{- end}}
+{{- end}}
+`
+
+// writeView renders a mapping view to path.
+func (g *Generator) writeView(view mappingView, path string) error {
+	funcMap := template.FuncMap{
+		"comment": func(s string) string {
+			lines := strings.Split(strings.TrimSpace(s), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight("-- "+line, " ")
+			}
+			return strings.Join(lines, "\n")
+		},
+		"header": g.header.Func("-- "),
+	}
+	tmpl_parsed, err := g.templates.Parse(viewTemplate, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, view)
+}
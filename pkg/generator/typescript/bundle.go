@@ -0,0 +1,118 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateBundle writes a builder that collects the resources of a namespace
+// into a FHIR transaction Bundle.
+func (g *Generator) generateBundle(resources []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $s := .}}{{if $i}}, {{end}}{{$s | typeName}}{{end}} } from "{{local "index"}}";
+
+/**
+ * The models of this namespace, by resource type.
+ */
+export interface BundleResources {
+{{- range .}}
+  {{.GetName | quote}}: {{. | typeName}};
+{{- end}}
+}
+
+/**
+ * A FHIR transaction Bundle of resources of this namespace.
+ */
+export interface Bundle {
+  resourceType: "Bundle";
+  type: "transaction";
+  entry: BundleEntry[];
+}
+
+/**
+ * An entry of a transaction Bundle: a resource and the request that stores
+ * it.
+ */
+export interface BundleEntry {
+  fullUrl?: string;
+  resource: { resourceType: keyof BundleResources } & BundleResources[keyof BundleResources];
+  request: { method: "POST" | "PUT"; url: string; ifNoneExist?: string };
+}
+
+/**
+ * Collects resources of this namespace into a transaction Bundle, which a
+ * FHIR server stores all or nothing.
+ */
+export class BundleBuilder {
+  private readonly entries: BundleEntry[] = [];
+
+  /**
+   * baseUrl is the FHIR base URL of the server, such as
+   * https://fhir.example.org/r4. It makes the fullUrl of updated resources;
+   * without it, they have none.
+   */
+  constructor(private readonly baseUrl?: string) {}
+
+  /**
+   * Adds an entry that creates resource and returns its fullUrl: a new
+   * urn:uuid that references from other resources of the Bundle can use, and
+   * that the server replaces with the id it assigns. Given ifNoneExist, a
+   * search query such as identifier=http://hospital.example|123, the server
+   * creates the resource only when nothing matches it.
+   */
+  create<T extends keyof BundleResources>(resourceType: T, resource: BundleResources[T], ifNoneExist?: string): string {
+    const fullUrl = ` + "`urn:uuid:${crypto.randomUUID()}`" + `;
+    this.entries.push({
+      fullUrl,
+      resource: { resourceType, ...resource },
+      request: ifNoneExist ? { method: "POST", url: resourceType, ifNoneExist } : { method: "POST", url: resourceType },
+    });
+    return fullUrl;
+  }
+
+  /**
+   * Adds an entry that creates or replaces resource as the resource with id,
+   * which must match the id resource holds, if any. Returns the entry's
+   * fullUrl, which is undefined without a baseUrl.
+   */
+  update<T extends keyof BundleResources>(resourceType: T, resource: BundleResources[T], id: string): string | undefined {
+    const url = ` + "`${resourceType}/${encodeURIComponent(id)}`" + `;
+    const fullUrl = this.baseUrl ? ` + "`${this.baseUrl.replace(/\\/$/, \"\")}/${url}`" + ` : undefined;
+    const entry: BundleEntry = { resource: { resourceType, ...resource }, request: { method: "PUT", url } };
+    if (fullUrl) {
+      entry.fullUrl = fullUrl;
+    }
+    this.entries.push(entry);
+    return fullUrl;
+  }
+
+  /**
+   * Returns the transaction Bundle of the entries added so far.
+   */
+  bundle(): Bundle {
+    return { resourceType: "Bundle", type: "transaction", entry: [...this.entries] };
+  }
+}
+`
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"quote":    func(s string) string { return fmt.Sprintf("%q", s) },
+		"header":   g.markedHeader(resources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, resources)
+}
@@ -0,0 +1,250 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// clientResources returns the resources of a namespace that names lists,
+// in namespace order.
+func clientResources(schemas []schema.Schema, names []string) []schema.Schema {
+	var resources []schema.Schema
+	for _, s := range bulkResources(schemas) {
+		for _, name := range names {
+			if s.GetName() == name {
+				resources = append(resources, s)
+				break
+			}
+		}
+	}
+	return resources
+}
+
+// generateClient writes a SMART on FHIR client that searches, reads, and
+// creates the resources of a namespace with fetch and Web Crypto, which
+// browsers and Node.js 18 and later both provide.
+func (g *Generator) generateClient(resources []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $s := .}}{{if $i}}, {{end}}{{$s | typeName}}{{end}} } from "{{local "index"}}";
+
+/**
+ * Supplies the OAuth2 access token sent with each FHIR request.
+ */
+export type TokenSource = () => string | Promise<string>;
+
+/**
+ * The SMART on FHIR configuration a server publishes at
+ * .well-known/smart-configuration.
+ */
+export interface SmartConfiguration {
+  authorization_endpoint: string;
+  token_endpoint: string;
+  capabilities?: string[];
+}
+
+/**
+ * An app registered with an EHR for the SMART on FHIR authorization code
+ * flow, as a public client using PKCE.
+ */
+export interface SmartApp {
+  clientId: string;
+  redirectUri: string;
+  /** Requested scopes such as launch, openid, and patient/*.read. */
+  scopes: string[];
+}
+
+/**
+ * The token response of a SMART on FHIR authorization. patient and encounter
+ * are the launch context, if the app asked for it.
+ */
+export interface SmartToken {
+  access_token: string;
+  token_type: string;
+  expires_in?: number;
+  scope?: string;
+  refresh_token?: string;
+  patient?: string;
+  encounter?: string;
+}
+
+function base64Url(bytes: Uint8Array): string {
+  return btoa(Array.from(bytes, (b) => String.fromCharCode(b)).join("")).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+/**
+ * Returns a random PKCE code verifier, to keep with the state of an
+ * authorization until its code is exchanged.
+ */
+export function newCodeVerifier(): string {
+  return base64Url(crypto.getRandomValues(new Uint8Array(32)));
+}
+
+/**
+ * Returns the URL to send the user to for authorization. aud is the FHIR base
+ * URL, and launch the launch parameter of an EHR launch, or undefined for a
+ * standalone launch.
+ */
+export async function authorizeUrl(app: SmartApp, config: SmartConfiguration, aud: string, state: string, codeVerifier: string, launch?: string): Promise<string> {
+  const challenge = new Uint8Array(await crypto.subtle.digest("SHA-256", new TextEncoder().encode(codeVerifier)));
+  const params = new URLSearchParams({
+    response_type: "code",
+    client_id: app.clientId,
+    redirect_uri: app.redirectUri,
+    scope: app.scopes.join(" "),
+    state,
+    aud,
+    code_challenge: base64Url(challenge),
+    code_challenge_method: "S256",
+  });
+  if (launch) {
+    params.set("launch", launch);
+  }
+  return ` + "`${config.authorization_endpoint}?${params}`" + `;
+}
+
+/**
+ * Exchanges the code an authorization redirected back with for a token.
+ * Confidential clients, which authenticate to the token endpoint, need their
+ * own exchange, and refreshing expired tokens is left to the app.
+ */
+export async function exchangeCode(app: SmartApp, config: SmartConfiguration, code: string, codeVerifier: string): Promise<SmartToken> {
+  const response = await fetch(config.token_endpoint, {
+    method: "POST",
+    headers: { "Content-Type": "application/x-www-form-urlencoded", Accept: "application/json" },
+    body: new URLSearchParams({
+      grant_type: "authorization_code",
+      code,
+      redirect_uri: app.redirectUri,
+      client_id: app.clientId,
+      code_verifier: codeVerifier,
+    }),
+  });
+  if (!response.ok) {
+    throw new Error(` + "`POST ${config.token_endpoint}: ${response.status} ${response.statusText}`" + `);
+  }
+  return (await response.json()) as SmartToken;
+}
+
+/**
+ * A request the server did not accept. body is usually an OperationOutcome.
+ */
+export class FhirError extends Error {
+  constructor(
+    readonly method: string,
+    readonly url: string,
+    readonly status: number,
+    readonly body: string,
+  ) {
+    super(` + "`${method} ${url}: ${status}`" + `);
+    this.name = "FhirError";
+  }
+}
+
+interface SearchBundle<T> {
+  entry?: { resource: T; search?: { mode?: string } }[];
+}
+
+/**
+ * Calls the REST API of a FHIR server with the models of this namespace.
+ */
+export class FhirClient {
+  private readonly baseUrl: string;
+
+  /**
+   * Creates a client of the server at baseUrl, its FHIR base URL. Without a
+   * token source, requests carry no access token.
+   */
+  constructor(
+    baseUrl: string,
+    private readonly token?: TokenSource,
+  ) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+  }
+
+  /**
+   * Fetches the server's SMART configuration.
+   */
+  async discover(): Promise<SmartConfiguration> {
+    return (await this.request<SmartConfiguration>("GET", ".well-known/smart-configuration"))!;
+  }
+{{range .}}{{$type := typeName .}}{{$method := methodName .}}
+  /**
+   * Reads the {{.GetName}} with id.
+   */
+  async read{{$method}}(id: string): Promise<{{$type}}> {
+    return (await this.request<{{$type}}>("GET", ` + "`" + `{{.GetName}}/${encodeURIComponent(id)}` + "`" + `))!;
+  }
+
+  /**
+   * Searches {{.GetName}} resources with params and returns the first page of
+   * matches.
+   */
+  async search{{$method}}(params: Record<string, string> | URLSearchParams = {}): Promise<{{$type}}[]> {
+    const bundle = await this.request<SearchBundle<{{$type}}>>("GET", {{quote .GetName}}, new URLSearchParams(params));
+    return matches(bundle);
+  }
+
+  /**
+   * Creates resource and returns the server's copy of it, with its assigned
+   * id, or resource if the server returns none.
+   */
+  async create{{$method}}(resource: {{$type}}): Promise<{{$type}}> {
+    return (await this.request<{{$type}}>("POST", {{quote .GetName}}, undefined, resource)) ?? resource;
+  }
+{{end}}
+  private async request<T>(method: string, path: string, params?: URLSearchParams, body?: unknown): Promise<T | undefined> {
+    let url = ` + "`${this.baseUrl}/${path}`" + `;
+    if (params && params.toString() !== "") {
+      url += ` + "`?${params}`" + `;
+    }
+    const headers: Record<string, string> = { Accept: "application/fhir+json" };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/fhir+json";
+      headers.Prefer = "return=representation";
+    }
+    if (this.token) {
+      headers.Authorization = ` + "`Bearer ${await this.token()}`" + `;
+    }
+    const response = await fetch(url, { method, headers, body: body === undefined ? undefined : JSON.stringify(body) });
+    const text = await response.text();
+    if (!response.ok) {
+      throw new FhirError(method, url, response.status, text);
+    }
+    return text.trim() === "" ? undefined : (JSON.parse(text) as T);
+  }
+}
+
+/**
+ * Returns the resources of a searchset Bundle that matched the search,
+ * leaving out included resources and outcomes.
+ */
+function matches<T>(bundle: SearchBundle<T> | undefined): T[] {
+  return (bundle?.entry ?? []).filter((e) => !e.search?.mode || e.search.mode === "match").map((e) => e.resource);
+}
+`
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"methodName": func(s schema.Schema) string {
+			return naming.Pascal.Apply(g.typeName(s))
+		},
+		"quote":  func(s string) string { return fmt.Sprintf("%q", s) },
+		"header": g.markedHeader(resources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, resources)
+}
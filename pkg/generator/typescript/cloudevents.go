@@ -0,0 +1,86 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/events"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// generateCloudEvents writes the CloudEvents types of the resources of a
+// namespace, and a constructor per resource that wraps one in an event.
+func (g *Generator) generateCloudEvents(resources []schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $s := .}}{{if $i}}, {{end}}{{$s | typeName}}{{end}} } from "{{local "index"}}";
+
+/**
+ * A CloudEvents 1.0 event in the structured JSON format, whose data is a
+ * resource of this namespace.
+ */
+export interface CloudEvent<T> {
+  specversion: "1.0";
+  id: string;
+  source: string;
+  type: string;
+  subject?: string;
+  time?: string;
+  datacontenttype: "application/json";
+  data: T;
+}
+
+/**
+ * Event types of the resources of this namespace.
+ */
+export const EventTypes = {
+{{- range .}}
+  {{. | typeName}}: {{. | eventType | quote}},
+{{- end}}
+} as const;
+{{range .}}
+/**
+ * Returns an event from source, a URI reference such as /ehr/adt, that
+ * carries resource, with a new random id and the current time.
+ */
+export function new{{. | typeName | pascal}}Event(source: string, resource: {{. | typeName}}): CloudEvent<{{. | typeName}}> {
+  return newCloudEvent(source, EventTypes.{{. | typeName}}, resource);
+}
+{{end}}
+function newCloudEvent<T>(source: string, type: string, data: T): CloudEvent<T> {
+  if (!source) {
+    throw new Error(` + "`${type} event has no source`" + `);
+  }
+  return {
+    specversion: "1.0",
+    id: crypto.randomUUID(),
+    source,
+    type,
+    time: new Date().toISOString(),
+    datacontenttype: "application/json",
+    data,
+  };
+}
+`
+	funcMap := template.FuncMap{
+		"typeName":  g.typeName,
+		"eventType": events.CloudEventType,
+		"pascal":    naming.Pascal.Apply,
+		"quote":     func(s string) string { return fmt.Sprintf("%q", s) },
+		"header":    g.markedHeader(resources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, resources)
+}
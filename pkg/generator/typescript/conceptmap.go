@@ -0,0 +1,116 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/naming"
+)
+
+// conceptMapSystem is a source system of a concept map and its codes that
+// the map translates.
+type conceptMapSystem struct {
+	System  string
+	Sources []conceptmap.Source
+}
+
+// conceptMapTable is the lookup table of a concept map, by source system,
+// and the function translating codes by it.
+type conceptMapTable struct {
+	Map     conceptmap.ConceptMap
+	Const   string
+	Func    string
+	Systems []conceptMapSystem
+}
+
+// generateConceptMaps writes the functions that translate codes by the
+// concept maps of a namespace.
+func (g *Generator) generateConceptMaps(maps []conceptmap.ConceptMap, path string) error {
+	var tables []conceptMapTable
+	for _, m := range maps {
+		t := conceptMapTable{
+			Map:   m,
+			Const: strings.ToUpper(naming.Snake.Apply(m.Name)),
+			Func:  "translate" + naming.Pascal.Apply(m.Name),
+		}
+		index := make(map[string]int)
+		for _, source := range m.Sources() {
+			n, ok := index[source.System]
+			if !ok {
+				n = len(t.Systems)
+				index[source.System] = n
+				t.Systems = append(t.Systems, conceptMapSystem{System: source.System})
+			}
+			t.Systems[n].Sources = append(t.Systems[n].Sources, source)
+		}
+		tables = append(tables, t)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+/**
+ * A code that a concept map translates a source code to, and how equivalent
+ * the two are.
+ */
+export interface ConceptMapTarget {
+  system: string;
+  code: string;
+  display: string;
+  equivalence: string;
+}
+{{range .}}
+// The concept map {{.Map.Name}}, by source system and code.
+const {{.Const}}: ReadonlyMap<string, ReadonlyMap<string, readonly ConceptMapTarget[]>> = new Map([
+{{- range .Systems}}
+  [
+    {{quote .System}},
+    new Map([
+{{- range .Sources}}
+      [
+        {{quote .Code}},
+        [
+{{- range .Translations}}
+          { system: {{quote .System}}, code: {{quote .Code}}, display: {{quote .Display}}, equivalence: {{quote .Equivalence}} },
+{{- end}}
+        ],
+      ],
+{{- end}}
+    ]),
+  ],
+{{- end}}
+]);
+
+/**
+ * Returns the codes that code of system translates to by the concept map
+ * {{.Map.Name}}, if any.
+{{- with .Map.Description}}
+ *
+ * {{line .}}
+{{- end}}
+ */
+export function {{.Func}}(system: string, code: string): ConceptMapTarget[] {
+  return [...({{.Const}}.get(system)?.get(code) ?? [])];
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"line":   func(s string) string { return strings.Join(strings.Fields(s), " ") },
+		"header": g.markedHeader(),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, tables)
+}
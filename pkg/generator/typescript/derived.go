@@ -0,0 +1,212 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// derivedModel is a schema with derived fields.
+type derivedModel struct {
+	Schema  schema.Schema
+	Type    string
+	Members []derivedMember
+}
+
+// derivedMember is the function computing a derived field: its name and
+// result type, expressions that are true when a field it uses is unset or a
+// date it uses is not known to the day, and the expression it returns.
+type derivedMember struct {
+	Field schema.Field
+	Func  string
+	Type  string
+	Unset []string
+	Dates bool
+	Expr  string
+}
+
+// derivedModels returns the schemas of a namespace with derived fields, and
+// the helper functions their functions call. Derived decimals are numbers,
+// whatever the decimal type of stored ones.
+func (g *Generator) derivedModels(schemas []schema.Schema) ([]derivedModel, []string, error) {
+	var models []derivedModel
+	var helpers []string
+	for _, s := range schemas {
+		derived, err := s.DerivedFields()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(derived) == 0 {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		typeName := g.typeName(s)
+		m := derivedModel{Schema: s, Type: typeName}
+		for _, d := range derived {
+			member := derivedMember{Field: d.Field, Func: naming.Camel.Apply(typeName) + naming.Pascal.Apply(d.Field.Name), Type: "string"}
+			if d.Field.ValueKind() != "text" {
+				member.Type = "number"
+			}
+			for _, name := range d.Expr.Fields() {
+				f := idents[name]
+				if !f.Required {
+					member.Unset = append(member.Unset, access(f.Ident)+" == null")
+				}
+				if f.ValueKind() == "date" {
+					member.Dates = true
+					member.Unset = append(member.Unset, "!FULL_DATE.test("+access(f.Ident)+")")
+				}
+			}
+			member.Expr = g.tsExpr(d.Expr, idents)
+			for _, fn := range d.Expr.Functions() {
+				if fn != "concat" && !contains(helpers, fn) {
+					helpers = append(helpers, fn)
+				}
+			}
+			m.Members = append(m.Members, member)
+		}
+		models = append(models, m)
+	}
+	return models, helpers, nil
+}
+
+// tsExpr returns e as a TypeScript expression over the fields of value.
+func (g *Generator) tsExpr(e schema.Expr, idents map[string]naming.Field) string {
+	arg := func(i int) string {
+		a := g.tsExpr(e.Args[i], idents)
+		if isOperator(e.Args[i].Op) && (isOperator(e.Op) || e.Op == "neg") {
+			return "(" + a + ")"
+		}
+		return a
+	}
+	switch e.Op {
+	case "number":
+		return e.Value
+	case "text":
+		return strconv.Quote(e.Value)
+	case "field":
+		f := idents[e.Value]
+		if f.ValueKind() == "decimal" && g.decimalType == "string" {
+			return "Number(" + access(f.Ident) + ")"
+		}
+		return access(f.Ident)
+	case "neg":
+		return "-" + arg(0)
+	case "+", "-", "*", "/":
+		return arg(0) + " " + e.Op + " " + arg(1)
+	case "years_since":
+		return "yearsSince(" + arg(0) + ")"
+	case "days_between":
+		return "daysBetween(" + arg(0) + ", " + arg(1) + ")"
+	}
+	var args []string
+	for i := range e.Args {
+		args = append(args, arg(i))
+	}
+	return strings.Join(args, " + ")
+}
+
+// isOperator reports whether op is an arithmetic operator, whose operands
+// are parenthesized when they are operations too.
+func isOperator(op string) bool {
+	return op == "+" || op == "-" || op == "*" || op == "/"
+}
+
+// contains reports whether values holds value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// generateDerived writes the functions computing the derived fields of
+// models, and the helpers they call.
+func (g *Generator) generateDerived(models []derivedModel, helpers []string, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .Models}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{- if or (has "years_since") (has "days_between")}}
+
+// The year, month, and day of a date or datetime known to the day.
+const FULL_DATE = /^(\d{4})-(\d{2})-(\d{2})/;
+{{- end}}
+{{range $m := .Models}}{{range .Members}}
+/**
+ * Returns the {{.Field.Name}} of value, derived as {{.Field.Derived}}{{if .Dates}},
+ * or undefined when a field it uses is unset or a date it uses is not known
+ * to the day{{else if .Unset}},
+ * or undefined when a field it uses is unset{{end}}.{{with .Field.Description}} {{.}}{{end}}
+ */
+export function {{.Func}}(value: {{$m.Type}}): {{.Type}}{{if .Unset}} | undefined{{end}} {
+{{- with .Unset}}
+  if ({{join . " || "}}) {
+    return undefined;
+  }
+{{- end}}
+  return {{.Expr}};
+}
+{{end}}{{end}}
+{{- if has "years_since"}}
+/**
+ * Returns the whole years from a date to today, as ages are counted.
+ */
+function yearsSince(date: string): number {
+  const [, year, month, day] = FULL_DATE.exec(date)!.map(Number);
+  const today = new Date();
+  const years = today.getFullYear() - year;
+  const early = month - 1 > today.getMonth() || (month - 1 === today.getMonth() && day > today.getDate());
+  return early ? years - 1 : years;
+}
+{{end}}
+{{- if has "days_between"}}
+/**
+ * Returns the days from the date of start to that of end.
+ */
+function daysBetween(start: string, end: string): number {
+  const utc = (date: string) => {
+    const [, year, month, day] = FULL_DATE.exec(date)!.map(Number);
+    return Date.UTC(year, month - 1, day);
+  };
+  return Math.round((utc(end) - utc(start)) / 86400000);
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"has":    func(helper string) bool { return contains(helpers, helper) },
+		"join":   strings.Join,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Models []derivedModel
+	}{
+		Models: models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,178 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// dicomSchemas returns the dicom schemas of a namespace.
+func dicomSchemas(schemas []schema.Schema) []schema.Schema {
+	var dicom []schema.Schema
+	for _, s := range schemas {
+		if s.Style == schema.StyleDICOM {
+			dicom = append(dicom, s)
+		}
+	}
+	return dicom
+}
+
+// dicomModel is a dicom schema with the tag of each of its fields.
+type dicomModel struct {
+	Type   string
+	Func   string
+	Fields []dicomField
+}
+
+type dicomField struct {
+	naming.Field
+	Tag schema.Tag
+	// Key is the property name, quoted.
+	Key string
+	// Kind is the TypeScript type of the field's values when that is string
+	// or number, which DICOM values are converted to, and otherwise empty.
+	Kind     string
+	Multiple bool
+}
+
+// generateDICOM writes the DICOM tag tables of the dicom schemas of a
+// namespace, and functions that decode the DICOM JSON model into them.
+func (g *Generator) generateDICOM(scope resolve.Scope, schemas []schema.Schema, path string) error {
+	var models []dicomModel
+	for _, s := range schemas {
+		tagged, err := s.DICOMTags()
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		typeName := g.typeName(s)
+		model := dicomModel{Type: typeName, Func: naming.Camel.Apply(typeName) + "FromDicomJson"}
+		tags := make(map[string]schema.Tag, len(tagged))
+		for _, f := range tagged {
+			tags[f.Name] = f.Tag
+		}
+		for _, f := range g.fields(s) {
+			tag, ok := tags[f.Name]
+			if !ok {
+				continue
+			}
+			field := dicomField{Field: f, Tag: tag, Key: f.Ident, Multiple: strings.HasPrefix(f.Type, "[]")}
+			if !strings.HasPrefix(field.Key, `"`) {
+				field.Key = strconv.Quote(field.Key)
+			}
+			switch kind := strings.TrimSuffix(g.toTSType(scope, f.Type), "[]"); kind {
+			case "string", "number":
+				field.Kind = kind
+			}
+			model.Fields = append(model.Fields, field)
+		}
+		models = append(models, model)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * A dataset in the DICOM JSON model, keyed by tag, such as 0020000D.
+ */
+export type DicomDataset = Record<string, { vr: string; Value?: unknown[] }>;
+
+/**
+ * Maps a DICOM attribute to the model property that holds it.
+ */
+export interface DicomAttribute {
+  /** The tag as (gggg,eeee). */
+  tag: string;
+  /** Keys the attribute in the DICOM JSON model. */
+  keyword: string;
+  field: string;
+  /** Whether the property takes every value rather than the first. */
+  multiple: boolean;
+  /** The type values are converted to, if any. */
+  kind?: "string" | "number";
+}
+{{range .}}
+/**
+ * Maps the DICOM attributes of {{.Type}} to its properties.
+ */
+export const {{.Type}}Tags: DicomAttribute[] = [
+{{- range .Fields}}
+  { tag: "{{.Tag}}", keyword: "{{.Tag.Keyword}}", field: {{.Key}}, multiple: {{.Multiple}}{{with .Kind}}, kind: "{{.}}"{{end}} },
+{{- end}}
+];
+
+/**
+ * Returns the properties of {{.Type}} that a dataset in the DICOM JSON model
+ * holds attributes for.
+ */
+export function {{.Func}}(dataset: DicomDataset): Partial<{{.Type}}> {
+  return fromDicomJson({{.Type}}Tags, dataset) as Partial<{{.Type}}>;
+}
+{{end}}
+/**
+ * Returns the properties of the attributes of tags that dataset holds. Person
+ * names decode as their alphabetic representation, and DA and DT values as
+ * ISO 8601 dates and times.
+ */
+function fromDicomJson(tags: DicomAttribute[], dataset: DicomDataset): Record<string, unknown> {
+  const model: Record<string, unknown> = {};
+  for (const attribute of tags) {
+    const element = dataset[attribute.keyword];
+    if (!element?.Value?.length) {
+      continue;
+    }
+    const values = element.Value.map((value) => convert(dicomValue(element.vr, value), attribute.kind));
+    model[attribute.field] = attribute.multiple ? values : values[0];
+  }
+  return model;
+}
+
+function dicomValue(vr: string, value: unknown): unknown {
+  if (vr === "PN" && typeof value === "object" && value !== null) {
+    return (value as { Alphabetic?: string }).Alphabetic;
+  }
+  if (vr === "DA" && typeof value === "string" && value.length === 8) {
+    return ` + "`${value.slice(0, 4)}-${value.slice(4, 6)}-${value.slice(6, 8)}`" + `;
+  }
+  if (vr === "DT" && typeof value === "string" && value.length >= 8) {
+    // Fractional seconds and the offset are dropped, and a missing time of
+    // day reads as midnight.
+    const date = ` + "`${value.slice(0, 4)}-${value.slice(4, 6)}-${value.slice(6, 8)}`" + `;
+    const time = value.slice(8, 14).padEnd(6, "0");
+    return ` + "`${date}T${time.slice(0, 2)}:${time.slice(2, 4)}:${time.slice(4, 6)}`" + `;
+  }
+  return value;
+}
+
+function convert(value: unknown, kind?: "string" | "number"): unknown {
+  if (kind === "string" && typeof value === "number") {
+    return String(value);
+  }
+  if (kind === "number" && typeof value === "string") {
+    return Number(value);
+  }
+  return value;
+}
+`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(schemas...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
@@ -0,0 +1,153 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// diffModel is a model and the function diffing two of its instances, with
+// the JSON names of its properties, those it inherits first.
+type diffModel struct {
+	Schema schema.Schema
+	Type   string
+	Func   string
+	Names  []string
+}
+
+// diffModels returns the schemas of a namespace that have properties to
+// diff.
+func (g *Generator) diffModels(schemas []schema.Schema) []diffModel {
+	var models []diffModel
+	for _, s := range schemas {
+		names := g.propertyNames(s)
+		if len(names) == 0 {
+			continue
+		}
+		typeName := g.typeName(s)
+		models = append(models, diffModel{Schema: s, Type: typeName, Func: "diff" + naming.Pascal.Apply(typeName), Names: names})
+	}
+	return models
+}
+
+// propertyNames returns the JSON names of the properties of s, those it
+// inherits first.
+func (g *Generator) propertyNames(s schema.Schema) []string {
+	var names []string
+	if base, ok := datatypes.Base(s.Inherits); ok {
+		names = g.propertyNames(base)
+	}
+	for _, f := range g.fields(s) {
+		if name, err := strconv.Unquote(f.Ident); err == nil {
+			names = append(names, name)
+		} else {
+			names = append(names, f.Ident)
+		}
+	}
+	return names
+}
+
+// generateDiff writes functions that turn the differences between two
+// instances of a model into a JSON Patch, comparing properties as they are
+// serialized so that dates and decimals keep their JSON form.
+func (g *Generator) generateDiff(models []diffModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * An operation of a JSON Patch (RFC 6902).
+ */
+export type PatchOperation =
+  | { op: "add" | "replace"; path: string; value: unknown }
+  | { op: "remove"; path: string };
+{{range .}}
+/**
+ * Returns the JSON Patch that turns the JSON of a into that of b: an
+ * operation for each property that differs, in schema order.
+ */
+export function {{.Func}}(a: {{.Type}}, b: {{.Type}}): PatchOperation[] {
+  return diff(a, b, [{{range $i, $n := .Names}}{{if $i}}, {{end}}{{quote $n}}{{end}}]);
+}
+{{end}}
+/**
+ * Returns the operations that turn the members named names of the JSON of a
+ * into those of b: adding the members only b holds, removing those only a
+ * holds, and replacing those that differ. Null members count as absent, so
+ * properties set null are removed.
+ */
+function diff(a: object, b: object, names: string[]): PatchOperation[] {
+  const before = JSON.parse(JSON.stringify(a)) as Record<string, unknown>;
+  const after = JSON.parse(JSON.stringify(b)) as Record<string, unknown>;
+  const ops: PatchOperation[] = [];
+  for (const name of names) {
+    const path = "/" + name.replace(/~/g, "~0").replace(/\//g, "~1");
+    const x = member(before, name);
+    const y = member(after, name);
+    if (y === null) {
+      if (x !== null) {
+        ops.push({ op: "remove", path });
+      }
+    } else if (x === null) {
+      ops.push({ op: "add", path, value: y });
+    } else if (!sameJSON(x, y)) {
+      ops.push({ op: "replace", path, value: y });
+    }
+  }
+  return ops;
+}
+
+/**
+ * Returns the member name of object, or null when it has none.
+ */
+function member(object: Record<string, unknown>, name: string): unknown {
+  return Object.prototype.hasOwnProperty.call(object, name) ? object[name] : null;
+}
+
+/**
+ * Reports whether the JSON values x and y are equal, whatever the order of
+ * their object members.
+ */
+function sameJSON(x: unknown, y: unknown): boolean {
+  if (x === y) {
+    return true;
+  }
+  if (typeof x !== "object" || typeof y !== "object" || x === null || y === null || Array.isArray(x) !== Array.isArray(y)) {
+    return false;
+  }
+  if (Array.isArray(x) && Array.isArray(y)) {
+    return x.length === y.length && x.every((value, i) => sameJSON(value, y[i]));
+  }
+  const xs = x as Record<string, unknown>;
+  const ys = y as Record<string, unknown>;
+  const keys = Object.keys(xs);
+  return keys.length === Object.keys(ys).length && keys.every((key) => sameJSON(xs[key], member(ys, key)));
+}
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
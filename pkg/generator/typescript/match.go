@@ -0,0 +1,349 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// matchModel is a schema with fields that make patient matching keys: the
+// function returning its keys, and the TypeScript expressions of the
+// components of its demographic key and of its identifiers.
+type matchModel struct {
+	Schema      schema.Schema
+	Type        string
+	Func        string
+	Demographic []string
+	Identifiers []string
+}
+
+// matchModels returns the schemas of a namespace with fields that make
+// matching keys.
+func (g *Generator) matchModels(schemas []schema.Schema) ([]matchModel, error) {
+	var models []matchModel
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		matched, err := s.MatchFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if matched.Empty() {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		typeName := g.typeName(s)
+		m := matchModel{Schema: s, Type: typeName, Func: naming.Camel.Apply(typeName) + "MatchKeys"}
+		for _, f := range matched.Demographic {
+			helper := "nameKey"
+			switch f.Match {
+			case schema.MatchBirthDate:
+				helper = "dateKey"
+			case schema.MatchSex:
+				helper = "sexKey"
+			}
+			m.Demographic = append(m.Demographic, helper+"("+access(idents[f.Name].Ident)+")")
+		}
+		for _, f := range matched.Identifiers {
+			m.Identifiers = append(m.Identifiers, "["+strconv.Quote(f.Name)+", "+access(idents[f.Name].Ident)+"]")
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generateMatch writes the functions returning the patient matching keys of
+// the records of models, which record linkage compares across source
+// systems. Every generator makes the same keys of the same records.
+func (g *Generator) generateMatch(models []matchModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .Models}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{range .Models}}
+/**
+ * Returns the patient matching keys of value, which records that are
+ * candidate matches share: a blocking key of its normalized demographics,
+ * when all are set, and a key of each identifier that is set.
+ */
+export function {{.Func}}(value: {{.Type}}): string[] {
+  return matchKeys([{{join .Demographic ", "}}], [{{join .Identifiers ", "}}]);
+}
+{{end}}
+/**
+ * Returns the demographic key made of components, unless there are none or
+ * one is empty, and the key of each identifier, a name and a value, whose
+ * normalized value is not empty.
+ */
+function matchKeys(components: string[], identifiers: [string, string | null | undefined][]): string[] {
+  const keys: string[] = [];
+  if (components.length > 0 && components.every((c) => c !== "")) {
+    keys.push("demographics:" + components.join("|"));
+  }
+  for (const [name, value] of identifiers) {
+    const normalized = normalize(value ?? "", true);
+    if (normalized !== "") {
+      keys.push(name + ":" + normalized);
+    }
+  }
+  return keys;
+}
+{{- if .Names}}
+
+/**
+ * Returns the key component of a name: {{if eq .Phonetic "soundex"}}the Soundex code of its letters{{else if eq .Phonetic "metaphone"}}the Metaphone code of its
+ * letters{{else}}its letters{{end}}.
+ */
+function nameKey(name: string | null | undefined): string {
+  return {{if .Phonetic}}{{.Phonetic}}({{end}}normalize(name ?? "", false){{if .Phonetic}}){{end}};
+}
+{{- end}}
+{{- if .Dates}}
+
+/**
+ * Returns the key component of a date, as much of it as is known.
+ */
+function dateKey(date: string | null | undefined): string {
+  return date ?? "";
+}
+{{- end}}
+{{- if .Sexes}}
+
+/**
+ * Returns the key component of a sex: its first letter.
+ */
+function sexKey(sex: string | null | undefined): string {
+  return normalize(sex ?? "", false).slice(0, 1);
+}
+{{- end}}
+
+/**
+ * Returns the letters A to Z of value, and its digits too when digits is
+ * set, in upper case.
+ */
+function normalize(value: string, digits: boolean): string {
+  return value.replace(digits ? /[^A-Za-z0-9]/g : /[^A-Za-z]/g, "").toUpperCase();
+}
+{{- if and .Names (eq .Phonetic "soundex")}}
+
+// The Soundex digits of the letters A to Z.
+const SOUNDEX_CODES = "01230120022455012623010202";
+
+/**
+ * Returns the American Soundex code of name, which holds letters A to Z
+ * only: its first letter and the digits of the next consonants, which H and
+ * W do not separate, padded to four.
+ */
+function soundex(name: string): string {
+  if (name === "") {
+    return "";
+  }
+  const digitOf = (c: string) => SOUNDEX_CODES[c.charCodeAt(0) - 65];
+  let code = name[0];
+  let last = digitOf(name[0]);
+  for (let i = 1; i < name.length && code.length < 4; i++) {
+    const digit = digitOf(name[i]);
+    if (name[i] === "H" || name[i] === "W") {
+      continue;
+    }
+    if (digit === "0") {
+      last = digit;
+    } else if (digit !== last) {
+      code += digit;
+      last = digit;
+    }
+  }
+  return code.padEnd(4, "0");
+}
+{{- end}}
+{{- if and .Names (eq .Phonetic "metaphone")}}
+
+/**
+ * Returns the Metaphone code of name, which holds letters A to Z only, by the
+ * rules of the original algorithm: initial letters that are silent or kept,
+ * then consonants by their sound, and no vowels after the first letter.
+ */
+function metaphone(name: string): string {
+  const at = (i: number) => (i >= 0 && i < name.length ? name[i] : "");
+  const vowel = (c: string) => c !== "" && "AEIOU".includes(c);
+  const soft = (c: string) => c !== "" && "EIY".includes(c);
+  let code = "";
+  let i = 0;
+  const [first, second] = [at(0), at(1)];
+  if (first === "A") {
+    [code, i] = second === "E" ? ["E", 2] : ["A", 1];
+  } else if (first !== "" && "GKP".includes(first) && second === "N") {
+    [code, i] = ["N", 2];
+  } else if (first === "W" && second === "R") {
+    [code, i] = ["R", 2];
+  } else if (first === "W" && (second === "H" || vowel(second))) {
+    [code, i] = ["W", 2];
+  } else if (first === "X") {
+    [code, i] = ["S", 1];
+  } else if (first !== "" && "EIOU".includes(first)) {
+    [code, i] = [first, 1];
+  }
+  for (; i < name.length; i++) {
+    const [c, prev, next, after] = [name[i], at(i - 1), at(i + 1), at(i + 2)];
+    if (c === prev && c !== "C") {
+      continue;
+    }
+    switch (c) {
+      case "B":
+        if (prev !== "M") {
+          code += "B";
+        }
+        break;
+      case "C":
+        if (next === "I" && after === "A") {
+          code += "X";
+        } else if (soft(next)) {
+          if (prev !== "S") {
+            code += "S";
+          }
+        } else if (next === "H") {
+          code += "X";
+          i++;
+        } else {
+          code += "K";
+        }
+        break;
+      case "D":
+        if (next === "G" && soft(after)) {
+          code += "J";
+          i++;
+        } else {
+          code += "T";
+        }
+        break;
+      case "G":
+        if (next === "H") {
+          const back = at(i - 3);
+          if (!((back !== "" && "BDH".includes(back)) || at(i - 4) === "H")) {
+            code += "F";
+            i++;
+          }
+        } else if (next === "N") {
+          if (after !== "" && !(after === "E" && at(i + 3) === "D")) {
+            code += "K";
+          }
+        } else if (soft(next) && prev !== "G") {
+          code += "J";
+        } else {
+          code += "K";
+        }
+        break;
+      case "H":
+        if (vowel(next) && (prev === "" || !"CGPST".includes(prev))) {
+          code += "H";
+        }
+        break;
+      case "K":
+        if (prev !== "C") {
+          code += "K";
+        }
+        break;
+      case "P":
+        code += next === "H" ? "F" : "P";
+        break;
+      case "Q":
+        code += "K";
+        break;
+      case "S":
+        if (next === "I" && (after === "O" || after === "A")) {
+          code += "X";
+        } else if (next === "H") {
+          code += "X";
+          i++;
+        } else {
+          code += "S";
+        }
+        break;
+      case "T":
+        if (next === "I" && (after === "O" || after === "A")) {
+          code += "X";
+        } else if (next === "H") {
+          code += "0";
+          i++;
+        } else if (!(next === "C" && after === "H")) {
+          code += "T";
+        }
+        break;
+      case "V":
+        code += "F";
+        break;
+      case "W":
+      case "Y":
+        if (vowel(next)) {
+          code += c;
+        }
+        break;
+      case "X":
+        code += "KS";
+        break;
+      case "Z":
+        code += "S";
+        break;
+      case "F":
+      case "J":
+      case "L":
+      case "M":
+      case "N":
+      case "R":
+        code += c;
+        break;
+    }
+  }
+  return code;
+}
+{{- end}}
+`
+	funcMap := template.FuncMap{
+		"join":   strings.Join,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Models   []matchModel
+		Phonetic string
+		Names    bool
+		Dates    bool
+		Sexes    bool
+	}{
+		Models:   models,
+		Phonetic: g.phonetic,
+	}
+	for _, m := range models {
+		for _, c := range m.Demographic {
+			data.Names = data.Names || strings.HasPrefix(c, "nameKey(")
+			data.Dates = data.Dates || strings.HasPrefix(c, "dateKey(")
+			data.Sexes = data.Sexes || strings.HasPrefix(c, "sexKey(")
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
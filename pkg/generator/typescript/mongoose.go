@@ -0,0 +1,221 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// mongooseModel is the Mongoose schema and model of a resource.
+type mongooseModel struct {
+	Schema     schema.Schema
+	Collection string
+	Paths      []mongoosePath
+	Indexes    []mongooseIndex
+	Reserved   bool
+}
+
+// mongooseReserved are the paths Mongoose warns about, as documents have
+// methods or properties of their names.
+var mongooseReserved = map[string]bool{
+	"collection": true, "db": true, "emit": true, "errors": true, "get": true,
+	"init": true, "isModified": true, "isNew": true, "listeners": true,
+	"modelName": true, "on": true, "once": true, "populated": true,
+	"prototype": true, "remove": true, "removeListener": true, "save": true,
+	"schema": true, "toObject": true, "validate": true,
+}
+
+// mongoosePath is a path of a Mongoose schema and its definition.
+type mongoosePath struct {
+	Ident      string
+	Definition string
+}
+
+// mongooseIndex is an index of a Mongoose schema: its keys and options as
+// object literals.
+type mongooseIndex struct {
+	Keys    string
+	Options string
+}
+
+// mongooseModels returns the Mongoose models of the resources of a
+// namespace. Paths are the properties of their interfaces; the datatypes and
+// schemas fields refer to are Mixed, left to the collection validators
+// ehrglot generate --lang mongodb writes.
+func (g *Generator) mongooseModels(scope resolve.Scope, resources []schema.Schema) []mongooseModel {
+	var models []mongooseModel
+	for _, s := range resources {
+		m := mongooseModel{Schema: s, Collection: naming.Snake.Apply(s.GetName())}
+		if s.Resource != "" {
+			resourceType := strconv.Quote(s.Resource)
+			m.Paths = append(m.Paths, mongoosePath{Ident: "resourceType", Definition: "{ type: String, required: true, enum: [" + resourceType + "], default: " + resourceType + " }"})
+		}
+		defaults := make(map[string]string)
+		for _, d := range g.defaults(s) {
+			defaults[d.Ident] = d.Value
+		}
+		byName := make(map[string]naming.Field)
+		for _, f := range g.storedFields(s) {
+			byName[f.Name] = f
+			// FHIR names elements such as Specimen.collection after them;
+			// their documents read the elements with get().
+			m.Reserved = m.Reserved || mongooseReserved[f.Ident]
+			elem, list := strings.CutPrefix(f.Type, "[]")
+			t := g.mongooseType(scope, elem)
+			if len(f.Children) > 0 {
+				t = "Schema.Types.Mixed"
+			}
+			if list {
+				m.Paths = append(m.Paths, mongoosePath{Ident: f.Ident, Definition: "[" + t + "]"})
+				continue
+			}
+			options := []string{"type: " + t}
+			if f.Required {
+				options = append(options, "required: true")
+			}
+			if len(f.Enum) > 0 {
+				options = append(options, "enum: "+stringArray(f.Enum))
+			}
+			if value, ok := defaults[f.Ident]; ok {
+				options = append(options, "default: "+value)
+			}
+			definition := t
+			if len(options) > 1 {
+				definition = "{ " + strings.Join(options, ", ") + " }"
+			}
+			m.Paths = append(m.Paths, mongoosePath{Ident: f.Ident, Definition: definition})
+		}
+		index := func(ix schema.Index, unique bool) mongooseIndex {
+			var keys, options, partial []string
+			for _, name := range ix.Fields {
+				f := byName[name]
+				keys = append(keys, f.Ident+": 1")
+				if unique && !f.Required {
+					partial = append(partial, f.Ident+": { $exists: true }")
+				}
+			}
+			if unique {
+				options = append(options, "unique: true")
+			}
+			if ix.Name != "" {
+				options = append(options, "name: "+strconv.Quote(ix.Name))
+			}
+			if len(partial) > 0 {
+				// Documents without an optional key field would otherwise
+				// collide on null.
+				options = append(options, "partialFilterExpression: { "+strings.Join(partial, ", ")+" }")
+			}
+			out := mongooseIndex{Keys: "{ " + strings.Join(keys, ", ") + " }"}
+			if len(options) > 0 {
+				out.Options = "{ " + strings.Join(options, ", ") + " }"
+			}
+			return out
+		}
+		for _, ix := range s.Unique {
+			m.Indexes = append(m.Indexes, index(ix, true))
+		}
+		for _, ix := range s.Indexes {
+			m.Indexes = append(m.Indexes, index(ix, false))
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// storedFields returns the properties of the interface of s, including
+// those it inherits.
+func (g *Generator) storedFields(s schema.Schema) []naming.Field {
+	var fields []naming.Field
+	if base, ok := datatypes.Base(s.Inherits); ok {
+		fields = g.storedFields(base)
+	}
+	return append(fields, g.fields(s)...)
+}
+
+// mongooseType returns the Mongoose schema type of values of yamlType,
+// which hold what the interface property of its type holds.
+func (g *Generator) mongooseType(scope resolve.Scope, yamlType string) string {
+	if _, ok := scope.Lookup(yamlType); ok {
+		return "Schema.Types.Mixed"
+	}
+	switch yamlType {
+	case "string", "code", "id", "uri", "url", "date", "datetime", "instant", "base64Binary":
+		return "String"
+	case "integer", "positiveInt", "unsignedInt":
+		return "Number"
+	case "decimal":
+		if g.decimalType == "string" {
+			return "String"
+		}
+		return "Number"
+	case "boolean":
+		return "Boolean"
+	}
+	return "Schema.Types.Mixed"
+}
+
+// stringArray returns values as an array literal of strings.
+func stringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// generateMongoose writes the Mongoose schemas and models of the resources
+// of a namespace.
+func (g *Generator) generateMongoose(models []mongooseModel, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import { Schema, model } from "mongoose";
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Schema | typeName}}{{end}} } from "{{local "index"}}";
+{{range .}}{{$type := typeName .Schema}}
+/**
+ * Mongoose schema of {{$type}} documents, stored in the {{.Collection}} collection.
+ */
+export const {{camel $type}}Schema = new Schema(
+  {
+{{- range .Paths}}
+    {{.Ident}}: {{.Definition}},
+{{- end}}
+  },
+  { collection: {{quote .Collection}}, versionKey: false{{if .Reserved}}, suppressReservedKeysWarning: true{{end}} },
+);
+{{- range .Indexes}}
+{{camel $type}}Schema.index({{.Keys}}{{with .Options}}, {{.}}{{end}});
+{{- end}}
+
+export const {{pascal $type}}Model = model<{{$type}}>({{quote $type}}, {{camel $type}}Schema);
+{{end}}`
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+	funcMap := template.FuncMap{
+		"typeName": g.typeName,
+		"camel":    naming.Camel.Apply,
+		"pascal":   naming.Pascal.Apply,
+		"quote":    strconv.Quote,
+		"header":   g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
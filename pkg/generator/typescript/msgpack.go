@@ -0,0 +1,399 @@
+package typescript
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/sample"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// msgpackModel is a model, the functions encoding and decoding it, and the
+// JSON of the example record their round-trip test decodes.
+type msgpackModel struct {
+	Schema schema.Schema
+	Type   string
+	Encode string
+	Decode string
+	Sample string
+}
+
+// msgpackModels returns the schemas of a namespace that have properties to
+// encode, with their example records.
+func (g *Generator) msgpackModels(scope resolve.Scope, samples *sample.Builder, schemas []schema.Schema) ([]msgpackModel, error) {
+	var models []msgpackModel
+	for _, s := range schemas {
+		if len(g.propertyNames(s)) == 0 {
+			continue
+		}
+		data, err := json.Marshal(g.sampleRecord(scope, s, samples.Record(s, 0)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		typeName := g.typeName(s)
+		pascal := naming.Pascal.Apply(typeName)
+		models = append(models, msgpackModel{Schema: s, Type: typeName, Encode: "encode" + pascal, Decode: "decode" + pascal, Sample: string(data)})
+	}
+	return models, nil
+}
+
+// sampleRecord returns an example record of s with its members, and those of
+// the records it nests, named as the properties of the interfaces.
+func (g *Generator) sampleRecord(scope resolve.Scope, s schema.Schema, record map[string]any) map[string]any {
+	idents := g.propertyIdents(s)
+	renamed := make(map[string]any, len(record))
+	for _, f := range s.Fields {
+		value, ok := record[f.Name]
+		if !ok {
+			continue
+		}
+		if target, ok := scope.Lookup(strings.TrimPrefix(f.Type, "[]")); ok {
+			switch v := value.(type) {
+			case map[string]any:
+				value = g.sampleRecord(scope, target, v)
+			case []any:
+				for i, elem := range v {
+					if elem, ok := elem.(map[string]any); ok {
+						v[i] = g.sampleRecord(scope, target, elem)
+					}
+				}
+			}
+		}
+		name := f.Name
+		if ident, ok := idents[f.Name]; ok {
+			if unquoted, err := strconv.Unquote(ident); err == nil {
+				name = unquoted
+			} else {
+				name = ident
+			}
+		}
+		renamed[name] = value
+	}
+	if resourceType, ok := record["resourceType"]; ok {
+		renamed["resourceType"] = resourceType
+	}
+	return renamed
+}
+
+// templateLiteral escapes text for a JavaScript template literal.
+var templateLiteral = strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", "\\${")
+
+// generateMsgpack writes functions that encode models as MessagePack, as
+// compact binary for caches such as Redis, and a node:test test of each that
+// round-trips an example record.
+func (g *Generator) generateMsgpack(models []msgpackModel, path, testPath string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{range .}}
+/**
+ * Returns value encoded as MessagePack.
+ */
+export function {{.Encode}}(value: {{.Type}}): Uint8Array {
+  return encode(value);
+}
+
+/**
+ * Decodes a {{.Type}} from MessagePack data.
+ */
+export function {{.Decode}}(data: Uint8Array): {{.Type}} {
+  return decode(data) as {{.Type}};
+}
+{{end}}
+const utf8Encoder = new TextEncoder();
+const utf8Decoder = new TextDecoder();
+
+/**
+ * Returns the JSON value of value encoded as MessagePack: objects as maps
+ * with sorted keys, whole numbers as the smallest integers that hold them,
+ * and other numbers as 64-bit floats. Members that are undefined are left
+ * out, as JSON leaves them out.
+ */
+function encode(value: unknown): Uint8Array {
+  const out: number[] = [];
+  pack(out, value);
+  return Uint8Array.from(out);
+}
+
+function pack(out: number[], value: unknown): void {
+  if (value === null || value === undefined) {
+    out.push(0xc0);
+  } else if (typeof value === "boolean") {
+    out.push(value ? 0xc3 : 0xc2);
+  } else if (typeof value === "number") {
+    if (Number.isSafeInteger(value)) {
+      packInt(out, value);
+    } else {
+      packFixed(out, 0xcb, 8, (view) => view.setFloat64(0, value));
+    }
+  } else if (typeof value === "string") {
+    const bytes = utf8Encoder.encode(value);
+    packHeader(out, bytes.length, 0xa0, 32, 0xd9, 0xda);
+    for (const b of bytes) {
+      out.push(b);
+    }
+  } else if (Array.isArray(value)) {
+    packHeader(out, value.length, 0x90, 16, 0, 0xdc);
+    for (const elem of value) {
+      pack(out, elem);
+    }
+  } else if (typeof value === "object" && typeof (value as { toJSON?: unknown }).toJSON === "function") {
+    pack(out, (value as { toJSON(): unknown }).toJSON());
+  } else if (typeof value === "object") {
+    const members = Object.entries(value as Record<string, unknown>)
+      .filter(([, v]) => v !== undefined)
+      .sort(([a], [b]) => (a < b ? -1 : a > b ? 1 : 0));
+    packHeader(out, members.length, 0x80, 16, 0, 0xde);
+    for (const [name, v] of members) {
+      pack(out, name);
+      pack(out, v);
+    }
+  } else {
+    throw new TypeError("msgpack: unsupported value of type " + typeof value);
+  }
+}
+
+/**
+ * Appends n as the smallest MessagePack integer that holds it.
+ */
+function packInt(out: number[], n: number): void {
+  if (n >= 0) {
+    if (n < 0x80) {
+      out.push(n);
+    } else if (n <= 0xff) {
+      out.push(0xcc, n);
+    } else if (n <= 0xffff) {
+      packFixed(out, 0xcd, 2, (view) => view.setUint16(0, n));
+    } else if (n <= 0xffffffff) {
+      packFixed(out, 0xce, 4, (view) => view.setUint32(0, n));
+    } else {
+      packFixed(out, 0xcf, 8, (view) => view.setBigUint64(0, BigInt(n)));
+    }
+  } else if (n >= -32) {
+    out.push(n & 0xff);
+  } else if (n >= -0x80) {
+    packFixed(out, 0xd0, 1, (view) => view.setInt8(0, n));
+  } else if (n >= -0x8000) {
+    packFixed(out, 0xd1, 2, (view) => view.setInt16(0, n));
+  } else if (n >= -0x80000000) {
+    packFixed(out, 0xd2, 4, (view) => view.setInt32(0, n));
+  } else {
+    packFixed(out, 0xd3, 8, (view) => view.setBigInt64(0, BigInt(n)));
+  }
+}
+
+/**
+ * Appends the header of a string, array, or map of n elements: the fix form
+ * when n is below fixMax, and otherwise the 8-bit form, which only strings
+ * have, or the 16- or 32-bit form, which follows it.
+ */
+function packHeader(out: number[], n: number, fix: number, fixMax: number, form8: number, form16: number): void {
+  if (n < fixMax) {
+    out.push(fix | n);
+  } else if (form8 !== 0 && n <= 0xff) {
+    out.push(form8, n);
+  } else if (n <= 0xffff) {
+    packFixed(out, form16, 2, (view) => view.setUint16(0, n));
+  } else {
+    packFixed(out, form16 + 1, 4, (view) => view.setUint32(0, n));
+  }
+}
+
+/**
+ * Appends tag and the size bytes set writes.
+ */
+function packFixed(out: number[], tag: number, size: number, set: (view: DataView) => void): void {
+  const view = new DataView(new ArrayBuffer(size));
+  set(view);
+  out.push(tag);
+  for (let i = 0; i < size; i++) {
+    out.push(view.getUint8(i));
+  }
+}
+
+/**
+ * The MessagePack data being decoded and the offset of the next value.
+ */
+interface Reader {
+  data: Uint8Array;
+  view: DataView;
+  pos: number;
+}
+
+/**
+ * Decodes the MessagePack value data holds as a JSON value. Binary decodes
+ * as base64 text, as JSON writes it.
+ */
+function decode(data: Uint8Array): unknown {
+  const r: Reader = { data, view: new DataView(data.buffer, data.byteOffset, data.byteLength), pos: 0 };
+  const value = unpack(r);
+  if (r.pos !== data.length) {
+    throw new RangeError("msgpack: " + (data.length - r.pos) + " bytes after the value");
+  }
+  return value;
+}
+
+function unpack(r: Reader): unknown {
+  const tag = take(r, 1);
+  if (tag < 0x80) {
+    return tag;
+  }
+  if (tag >= 0xe0) {
+    return tag - 0x100;
+  }
+  if (tag >= 0xa0 && tag < 0xc0) {
+    return unpackString(r, tag & 0x1f);
+  }
+  if (tag >= 0x90 && tag < 0xa0) {
+    return unpackArray(r, tag & 0x0f);
+  }
+  if (tag >= 0x80 && tag < 0x90) {
+    return unpackMap(r, tag & 0x0f);
+  }
+  switch (tag) {
+    case 0xc0:
+      return null;
+    case 0xc2:
+      return false;
+    case 0xc3:
+      return true;
+    case 0xc4:
+    case 0xc5:
+    case 0xc6: {
+      const bytes = unpackBytes(r, take(r, 1 << (tag - 0xc4)));
+      let text = "";
+      for (const b of bytes) {
+        text += String.fromCharCode(b);
+      }
+      return btoa(text);
+    }
+    case 0xca:
+      return r.view.getFloat32(advance(r, 4));
+    case 0xcb:
+      return r.view.getFloat64(advance(r, 8));
+    case 0xcc:
+    case 0xcd:
+    case 0xce:
+      return take(r, 1 << (tag - 0xcc));
+    case 0xcf:
+      return Number(r.view.getBigUint64(advance(r, 8)));
+    case 0xd0:
+      return r.view.getInt8(advance(r, 1));
+    case 0xd1:
+      return r.view.getInt16(advance(r, 2));
+    case 0xd2:
+      return r.view.getInt32(advance(r, 4));
+    case 0xd3:
+      return Number(r.view.getBigInt64(advance(r, 8)));
+    case 0xd9:
+    case 0xda:
+    case 0xdb:
+      return unpackString(r, take(r, 1 << (tag - 0xd9)));
+    case 0xdc:
+    case 0xdd:
+      return unpackArray(r, take(r, 2 << (tag - 0xdc)));
+    case 0xde:
+    case 0xdf:
+      return unpackMap(r, take(r, 2 << (tag - 0xde)));
+  }
+  throw new RangeError("msgpack: unsupported type 0x" + tag.toString(16));
+}
+
+/**
+ * Returns the offset of the next size bytes, and moves past them.
+ */
+function advance(r: Reader, size: number): number {
+  if (r.pos + size > r.data.length) {
+    throw new RangeError("msgpack: unexpected end of data");
+  }
+  r.pos += size;
+  return r.pos - size;
+}
+
+/**
+ * Reads a big-endian unsigned integer of size bytes, up to 4.
+ */
+function take(r: Reader, size: number): number {
+  const pos = advance(r, size);
+  let n = 0;
+  for (let i = 0; i < size; i++) {
+    n = n * 0x100 + r.data[pos + i];
+  }
+  return n;
+}
+
+function unpackBytes(r: Reader, n: number): Uint8Array {
+  const pos = advance(r, n);
+  return r.data.subarray(pos, pos + n);
+}
+
+function unpackString(r: Reader, n: number): string {
+  return utf8Decoder.decode(unpackBytes(r, n));
+}
+
+function unpackArray(r: Reader, n: number): unknown[] {
+  const values: unknown[] = [];
+  for (let i = 0; i < n; i++) {
+    values.push(unpack(r));
+  }
+  return values;
+}
+
+function unpackMap(r: Reader, n: number): Record<string, unknown> {
+  const values: Record<string, unknown> = {};
+  for (let i = 0; i < n; i++) {
+    const name = unpack(r);
+    if (typeof name !== "string") {
+      throw new TypeError("msgpack: map key " + String(name) + " is not a string");
+    }
+    values[name] = unpack(r);
+  }
+  return values;
+}
+`
+	testTmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import { deepStrictEqual } from "node:assert";
+import { test } from "node:test";
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+import { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Decode}}, {{$m.Encode}}{{end}} } from "{{local "msgpack"}}";
+{{range .}}
+test("{{.Type}} round-trips through MessagePack", () => {
+  const record: {{.Type}} = JSON.parse(` + "`" + `{{jsString .Sample}}` + "`" + `);
+  deepStrictEqual({{.Decode}}({{.Encode}}(record)), record);
+});
+{{end}}`
+	funcMap := template.FuncMap{
+		"jsString": templateLiteral.Replace,
+		"header":   g.markedHeader(sources...),
+	}
+
+	for _, file := range []struct{ tmpl, path string }{{tmpl, path}, {testTmpl, testPath}} {
+		tmpl_parsed, err := g.parse(file.tmpl, funcMap)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Create(file.path)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		err = tmpl_parsed.Execute(f, models)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// narrativeModel is a schema whose records render as narratives.
+type narrativeModel struct {
+	Schema schema.Schema
+	Type   string
+	Func   string
+	Rows   []narrativeRow
+}
+
+// narrativeRow is a field that narratives show: its label and unit, as
+// TypeScript strings, and the expression reading it.
+type narrativeRow struct {
+	Label string
+	Value string
+	Unit  string
+}
+
+// narrativeModels returns the schemas of a namespace with fields that
+// narratives show.
+func (g *Generator) narrativeModels(schemas []schema.Schema) []narrativeModel {
+	var models []narrativeModel
+	for _, s := range schemas {
+		if datatypes.IsBuiltin(s) {
+			continue
+		}
+		shown := s.NarrativeFields()
+		if len(shown) == 0 {
+			continue
+		}
+		idents := make(map[string]string)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f.Ident
+		}
+		typeName := g.typeName(s)
+		m := narrativeModel{Schema: s, Type: typeName, Func: "narrative" + naming.Pascal.Apply(typeName)}
+		for _, f := range shown {
+			m.Rows = append(m.Rows, narrativeRow{Label: strconv.Quote(f.NarrativeLabel()), Value: access(idents[f.Name]), Unit: strconv.Quote(f.Unit)})
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// generateNarrative writes functions that render the records of models as
+// XHTML narratives.
+func (g *Generator) generateNarrative(models []narrativeModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{range .}}
+/**
+ * Returns the fields of value that are set as an XHTML table, for the
+ * text.div of its FHIR resource.
+ */
+export function {{.Func}}(value: {{.Type}}): string {
+  return narrative([
+{{- range .Rows}}
+    [{{.Label}}, {{.Value}}, {{.Unit}}],
+{{- end}}
+  ]);
+}
+{{end}}
+/**
+ * Returns an XHTML table of the labeled values of rows that are set, each
+ * followed by its unit, if any.
+ */
+function narrative(rows: [string, unknown, string][]): string {
+  const cells = rows
+    .filter(([, value]) => value != null)
+    .map(([label, value, unit]) => {
+      let text = typeof value === "boolean" ? (value ? "Yes" : "No") : String(value);
+      if (unit) {
+        text += " " + unit;
+      }
+      return "<tr><th>" + escapeXhtml(label) + "</th><td>" + escapeXhtml(text) + "</td></tr>";
+    });
+  return '<div xmlns="http://www.w3.org/1999/xhtml"><table>' + cells.join("") + "</table></div>";
+}
+
+/**
+ * Escapes text for XHTML.
+ */
+function escapeXhtml(text: string): string {
+  return text.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;").replace(/"/g, "&quot;");
+}
+`
+	tmpl_parsed, err := g.parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
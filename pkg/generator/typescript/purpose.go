@@ -0,0 +1,111 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// purposeModel is a schema that restricts the purposes of use it is
+// disclosed for, with the fields each permitted purpose must not see.
+type purposeModel struct {
+	Schema   schema.Schema
+	Type     string
+	Func     string
+	Purposes []purposeFilter
+}
+
+type purposeFilter struct {
+	Purpose  string
+	Stripped []naming.Field
+}
+
+// purposeModels returns the schemas of a namespace that restrict their
+// purposes of use.
+func (g *Generator) purposeModels(schemas []schema.Schema) ([]purposeModel, error) {
+	var models []purposeModel
+	for _, s := range schemas {
+		if !s.HasPurposes() {
+			continue
+		}
+		if err := s.CheckPurposes(); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		typeName := g.typeName(s)
+		m := purposeModel{Schema: s, Type: typeName, Func: naming.Camel.Apply(typeName) + "ForPurpose"}
+		for _, p := range schema.Purposes {
+			if !s.Permits(p) {
+				continue
+			}
+			filter := purposeFilter{Purpose: p}
+			for _, f := range g.fields(s) {
+				if !s.PermitsField(f.Field, p) {
+					filter.Stripped = append(filter.Stripped, f)
+				}
+			}
+			m.Purposes = append(m.Purposes, filter)
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generatePurpose writes functions that strip the fields of models a purpose
+// of use may not see, and whole records it may not see at all.
+func (g *Generator) generatePurpose(models []purposeModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .Models}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * A purpose of use that records are disclosed for.
+ */
+export type Purpose = {{range $i, $p := .Purposes}}{{if $i}} | {{end}}"{{$p}}"{{end}};
+{{range .Models}}
+/**
+ * Returns a copy of value without the fields that purpose may not see, or
+ * undefined when purpose may not see value at all.
+ */
+export function {{.Func}}(value: {{.Type}}, purpose: Purpose): Partial<{{.Type}}> | undefined {
+  switch (purpose) {
+{{- range .Purposes}}
+    case "{{.Purpose}}":
+      return { ...value{{range .Stripped}}, {{.Ident}}: undefined{{end}} };
+{{- end}}
+    default:
+      return undefined;
+  }
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Purposes []string
+		Models   []purposeModel
+	}{
+		Purposes: schema.Purposes,
+		Models:   models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,206 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// questionnaireModel is a schema whose fields answer the questions of a
+// FHIR Questionnaire.
+type questionnaireModel struct {
+	Schema  schema.Schema
+	Type    string
+	Func    string
+	LinkIDs []questionnaireLinkID
+	Answers []questionnaireAnswer
+}
+
+// questionnaireLinkID is a property and the linkId of the question it
+// answers, as a string literal.
+type questionnaireLinkID struct {
+	Ident  string
+	LinkID string
+}
+
+// questionnaireAnswer reads a property from the answers to its question:
+// Value is an expression of an answer that is undefined for answers of
+// another type.
+type questionnaireAnswer struct {
+	Access string
+	LinkID string
+	Value  string
+	List   bool
+}
+
+// questionnaireModels returns the schemas of a namespace with fields that
+// carry linkIds, such as those ehrglot import questionnaire writes. Groups
+// and fields whose answers have no property type keep their linkIds but
+// are not read from responses.
+func (g *Generator) questionnaireModels(schemas []schema.Schema) []questionnaireModel {
+	var models []questionnaireModel
+	for _, s := range schemas {
+		typeName := g.typeName(s)
+		m := questionnaireModel{Schema: s, Type: typeName, Func: naming.Camel.Apply(typeName) + "FromResponse"}
+		for _, f := range g.fields(s) {
+			if f.LinkID == "" {
+				continue
+			}
+			linkID := strconv.Quote(f.LinkID)
+			m.LinkIDs = append(m.LinkIDs, questionnaireLinkID{Ident: f.Ident, LinkID: linkID})
+			elem, list := strings.CutPrefix(f.Type, "[]")
+			value := g.answerValue(elem)
+			if value == "" || len(f.Children) > 0 {
+				continue
+			}
+			m.Answers = append(m.Answers, questionnaireAnswer{Access: access(f.Ident), LinkID: linkID, Value: value, List: list})
+		}
+		if len(m.LinkIDs) > 0 {
+			models = append(models, m)
+		}
+	}
+	return models
+}
+
+// answerValue returns the expression reading a value of yamlType from an
+// answer, or "" for types no answer holds.
+func (g *Generator) answerValue(yamlType string) string {
+	switch yamlType {
+	case "boolean":
+		return "answer.valueBoolean"
+	case "integer", "positiveInt", "unsignedInt":
+		return "answer.valueInteger"
+	case "decimal":
+		if g.decimalType == "string" {
+			return "answer.valueDecimal?.toString()"
+		}
+		return "answer.valueDecimal"
+	case "date":
+		return "answer.valueDate"
+	case "datetime", "instant":
+		return "answer.valueDateTime"
+	case "code":
+		// Choices are answered with codings, or with their text
+		return "answer.valueCoding?.code ?? answer.valueString"
+	case "string":
+		return "answer.valueString ?? answer.valueCoding?.display"
+	case "uri", "url":
+		return "answer.valueUri"
+	}
+	return ""
+}
+
+// generateQuestionnaire writes the linkIds of the fields of models, and
+// functions reading them from the answers of QuestionnaireResponses.
+func (g *Generator) generateQuestionnaire(models []questionnaireModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * An item of a FHIR QuestionnaireResponse: the answers to a question, or
+ * the items of a group.
+ */
+export interface QuestionnaireResponseItem {
+  linkId: string;
+  text?: string;
+  answer?: QuestionnaireResponseAnswer[];
+  item?: QuestionnaireResponseItem[];
+}
+
+/**
+ * An answer of a FHIR QuestionnaireResponse item, and the items nested
+ * under it.
+ */
+export interface QuestionnaireResponseAnswer {
+  valueBoolean?: boolean;
+  valueDecimal?: number;
+  valueInteger?: number;
+  valueDate?: string;
+  valueDateTime?: string;
+  valueTime?: string;
+  valueString?: string;
+  valueUri?: string;
+  valueCoding?: { system?: string; code?: string; display?: string };
+  item?: QuestionnaireResponseItem[];
+}
+
+/**
+ * Returns the answers to the questions of items, and of the items nested
+ * under them, by linkId.
+ */
+function answersByLinkId(
+  items: QuestionnaireResponseItem[] | undefined,
+  answers: Map<string, QuestionnaireResponseAnswer[]> = new Map(),
+): Map<string, QuestionnaireResponseAnswer[]> {
+  for (const item of items ?? []) {
+    if (item.answer?.length) {
+      answers.set(item.linkId, [...(answers.get(item.linkId) ?? []), ...item.answer]);
+    }
+    answersByLinkId(item.item, answers);
+    for (const answer of item.answer ?? []) {
+      answersByLinkId(answer.item, answers);
+    }
+  }
+  return answers;
+}
+
+/**
+ * Returns the values of answers, leaving out answers of another type, or
+ * undefined when none is left.
+ */
+function answerValues<T>(
+  answers: QuestionnaireResponseAnswer[] | undefined,
+  value: (answer: QuestionnaireResponseAnswer) => T | undefined,
+): T[] | undefined {
+  const values = (answers ?? []).map(value).filter((v): v is T => v !== undefined);
+  return values.length ? values : undefined;
+}
+{{range .}}
+/**
+ * The linkIds of the questions the fields of {{.Type}} answer.
+ */
+export const {{.Type}}LinkIds = {
+{{- range .LinkIDs}}
+  {{.Ident}}: {{.LinkID}},
+{{- end}}
+} as const;
+
+/**
+ * Returns the fields of {{.Type}} answered in a
+ * QuestionnaireResponse, found by the linkIds of their questions. Fields
+ * that are not answered, or answered with values of another type, are
+ * left out.
+ */
+export function {{.Func}}(response: { item?: QuestionnaireResponseItem[] }): Partial<{{.Type}}> {
+  const answers = answersByLinkId(response.item);
+  const value: Partial<{{.Type}}> = {};
+{{- range .Answers}}
+  {{.Access}} = answerValues(answers.get({{.LinkID}}), (answer) => {{.Value}}){{if not .List}}?.[0]{{end}};
+{{- end}}
+  return value;
+}
+{{end}}`
+	tmpl_parsed, err := g.parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
@@ -0,0 +1,89 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// redactModel is a schema with fields sensitive at the redact level.
+type redactModel struct {
+	Schema schema.Schema
+	Type   string
+	Func   string
+	Fields []naming.Field
+}
+
+// redactModels returns the schemas of a namespace with fields sensitive at
+// level, and those fields.
+func (g *Generator) redactModels(schemas []schema.Schema, level string) []redactModel {
+	var models []redactModel
+	for _, s := range schemas {
+		typeName := g.typeName(s)
+		model := redactModel{Schema: s, Type: typeName, Func: "redact" + naming.Pascal.Apply(typeName) + "ForLogging"}
+		for _, f := range g.fields(s) {
+			if f.Sensitive(level) {
+				model.Fields = append(model.Fields, f)
+			}
+		}
+		if len(model.Fields) > 0 {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// generateRedact writes functions that copy models with their sensitive
+// fields masked, for logging. Interfaces cannot override how console.log
+// prints them, so models must pass through these before they are logged.
+func (g *Generator) generateRedact(models []redactModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .Models}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * Replaces the values of fields at pii_level {{.Level}} or above in logs.
+ */
+export const REDACTED = "` + schema.Redacted + `";
+{{range .Models}}
+/**
+ * Returns a copy of value with its fields at pii_level {{$.Level}} or above
+ * masked, for logging.
+ */
+export function {{.Func}}(value: {{.Type}}): Record<string, unknown> {
+  return { ...value{{range .Fields}}, {{.Ident}}: REDACTED{{end}} };
+}
+{{end}}`
+	funcMap := template.FuncMap{
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Level  string
+		Models []redactModel
+	}{
+		Level:  strings.ToLower(g.redact),
+		Models: models,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
@@ -0,0 +1,111 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// rangeModel is a schema with fields that have reference ranges.
+type rangeModel struct {
+	Schema schema.Schema
+	Type   string
+	Func   string
+	Checks []rangeCheck
+}
+
+// rangeCheck is a TypeScript expression that is true when a field is set
+// and outside its reference range, and the field's name.
+type rangeCheck struct {
+	OutOfRange string
+	Name       string
+	Range      string
+}
+
+// rangeModels returns the schemas of a namespace with reference ranges.
+func (g *Generator) rangeModels(schemas []schema.Schema) ([]rangeModel, error) {
+	var models []rangeModel
+	for _, s := range schemas {
+		ranged, err := s.RangedFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(ranged) == 0 {
+			continue
+		}
+		idents := make(map[string]string)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f.Ident
+		}
+		typeName := g.typeName(s)
+		m := rangeModel{Schema: s, Type: typeName, Func: "outOfRange" + naming.Pascal.Apply(typeName)}
+		for _, f := range ranged {
+			field := access(idents[f.Name])
+			value := field
+			if f.Type == "decimal" && g.decimalType == "string" {
+				value = "Number(" + field + ")"
+			}
+			var test string
+			switch {
+			case f.ReferenceLow != "" && f.ReferenceHigh != "":
+				test = "(" + value + " < " + f.ReferenceLow + " || " + value + " > " + f.ReferenceHigh + ")"
+			case f.ReferenceLow != "":
+				test = value + " < " + f.ReferenceLow
+			default:
+				test = value + " > " + f.ReferenceHigh
+			}
+			m.Checks = append(m.Checks, rangeCheck{
+				OutOfRange: field + " != null && " + test,
+				Name:       strconv.Quote(f.Name),
+				Range:      f.ReferenceRange(),
+			})
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// generateReference writes functions that report the fields of models that
+// hold values outside their reference ranges, to flag for review.
+func (g *Generator) generateReference(models []rangeModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{range .}}
+/**
+ * Returns the names of the fields of value that are set and outside their
+ * reference ranges, or an empty list.
+ */
+export function {{.Func}}(value: {{.Type}}): string[] {
+  const flagged: string[] = [];
+{{- range .Checks}}
+  // {{.Range}}
+  if ({{.OutOfRange}}) {
+    flagged.push({{.Name}});
+  }
+{{- end}}
+  return flagged;
+}
+{{end}}`
+	tmpl_parsed, err := g.parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
@@ -0,0 +1,237 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// resolverModel is a resource that a resolver indexes: the property access
+// of its id, or empty when it has none, an expression naming a record as the
+// source of its references, and its reference fields.
+type resolverModel struct {
+	Schema schema.Schema
+	Type   string
+	ID     string
+	Source string
+	Refs   []resolverRef
+}
+
+// resolverRef is a field holding references, singly or in a list, and its
+// property access.
+type resolverRef struct {
+	Name   string
+	Access string
+	List   bool
+}
+
+// resolverModels returns the resources of a namespace as a resolver indexes
+// them.
+func (g *Generator) resolverModels(resources []schema.Schema) []resolverModel {
+	var models []resolverModel
+	for _, s := range resources {
+		idents := g.propertyIdents(s)
+		m := resolverModel{Schema: s, Type: g.typeName(s), Source: strconv.Quote(s.GetName())}
+		if ident, ok := idents["id"]; ok {
+			m.ID = access(ident)
+			m.Source = m.ID + " ? `" + s.GetName() + "/${" + m.ID + "}` : " + m.Source
+		}
+		for _, f := range datatypes.ReferenceFields(s) {
+			m.Refs = append(m.Refs, resolverRef{Name: strconv.Quote(f.Name), Access: access(idents[f.Name]), List: strings.HasPrefix(f.Type, "[]")})
+		}
+		models = append(models, m)
+	}
+	return models
+}
+
+// propertyIdents returns the identifiers of the properties of s by field
+// name, including those it inherits.
+func (g *Generator) propertyIdents(s schema.Schema) map[string]string {
+	idents := make(map[string]string)
+	if base, ok := datatypes.Base(s.Inherits); ok {
+		idents = g.propertyIdents(base)
+	}
+	for _, f := range g.fields(s) {
+		idents[f.Name] = f.Ident
+	}
+	return idents
+}
+
+// generateResolver writes an in-memory index of the resources of a
+// namespace that resolves the references between them.
+func (g *Generator) generateResolver(models []resolverModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	reference, _ := datatypes.Builtin(datatypes.ReferenceName)
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .Models}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+
+/**
+ * The models of this namespace, by resource type.
+ */
+export interface ResolverResources {
+{{- range .Models}}
+  {{quote .Schema.GetName}}: {{.Type}};
+{{- end}}
+}
+
+/**
+ * A reference of a resource added to a Resolver that points to none of the
+ * resources added.
+ */
+export interface DanglingReference {
+  /** Type and id of the referencing resource, such as {{.Example.Schema.GetName}}/123, or its type when it has no id. */
+  source: string;
+  /** Name of the field holding the reference. */
+  field: string;
+  reference: string;
+}
+
+/**
+ * Indexes resources of this namespace by type and id, and resolves the
+ * references between them, such as those among the entries of a Bundle.
+ */
+export class Resolver {
+  private readonly resources: Array<{ resourceType: keyof ResolverResources; resource: ResolverResources[keyof ResolverResources] }> = [];
+  private readonly byKey = new Map<string, ResolverResources[keyof ResolverResources]>();
+
+  /**
+   * Indexes resource by its type and id. Resources without an id cannot be
+   * resolved, but dangling() checks their references too.
+   */
+  add<T extends keyof ResolverResources>(resourceType: T, resource: ResolverResources[T]): void {
+{{- if .Identified}}
+    let id: string | null | undefined;
+    switch (resourceType) {
+{{- range .Models}}{{if .ID}}
+      case {{quote .Schema.GetName}}: {
+        const value = resource as {{.Type}};
+        id = {{.ID}};
+        break;
+      }
+{{- end}}{{end}}
+    }
+    if (id) {
+      this.byKey.set(` + "`${resourceType}/${id}`" + `, resource);
+    }
+{{- end}}
+    this.resources.push({ resourceType, resource });
+  }
+
+  /**
+   * Returns the resource that a literal reference, such as {{.Example.Schema.GetName}}/123 or
+   * https://fhir.example.org/r4/{{.Example.Schema.GetName}}/123/_history/2, points to, or
+   * undefined when it was not added.
+   */
+  resolve(reference: string): ResolverResources[keyof ResolverResources] | undefined {
+    const key = referenceKey(reference);
+    return key ? this.byKey.get(key) : undefined;
+  }
+
+  /**
+   * Returns the resource of type resourceType that reference points to, or
+   * undefined when no such resource was added.
+   */
+  resolveAs<T extends keyof ResolverResources>(resourceType: T, reference: string): ResolverResources[T] | undefined {
+    const key = referenceKey(reference);
+    return key?.startsWith(resourceType + "/") ? (this.byKey.get(key) as ResolverResources[T] | undefined) : undefined;
+  }
+
+  /**
+   * Returns the references of the added resources that point to none of
+   * them, in the order the resources were added. Only references to a type
+   * and id are checked, not contained (#id), logical, or urn:uuid ones.
+   */
+  dangling(): DanglingReference[] {
+    const dangling: DanglingReference[] = [];
+{{- if .Referencing}}
+    for (const { resourceType, resource } of this.resources) {
+      switch (resourceType) {
+{{- range .Models}}{{if .Refs}}
+        case {{quote .Schema.GetName}}: {
+          const value = resource as {{.Type}};
+          const source = {{.Source}};
+{{- range .Refs}}
+{{- if .List}}
+          for (const ref of {{.Access}} ?? []) {
+            this.check(dangling, source, {{.Name}}, ref{{$.Reference}});
+          }
+{{- else}}
+          this.check(dangling, source, {{.Name}}, {{.Access}}?{{$.Reference}});
+{{- end}}
+{{- end}}
+          break;
+        }
+{{- end}}{{end}}
+      }
+    }
+{{- end}}
+    return dangling;
+  }
+{{- if .Referencing}}
+
+  private check(dangling: DanglingReference[], source: string, field: string, reference: string | null | undefined): void {
+    const key = referenceKey(reference);
+    if (reference && key && !this.byKey.has(key)) {
+      dangling.push({ source, field, reference });
+    }
+  }
+{{- end}}
+}
+
+/**
+ * Returns the type and id that a literal reference points to, as Type/id, or
+ * undefined when it points to none, as contained (#id) and urn:uuid
+ * references do not.
+ */
+function referenceKey(reference: string | null | undefined): string | undefined {
+  const parts = (reference ?? "").split("/_history/")[0].split("/");
+  const [type, id] = parts.slice(-2);
+  return parts.length >= 2 && type && id ? ` + "`${type}/${id}`" + ` : undefined;
+}
+`
+	funcMap := template.FuncMap{
+		"quote":  strconv.Quote,
+		"header": g.markedHeader(sources...),
+	}
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Models      []resolverModel
+		Example     resolverModel
+		Reference   string
+		Identified  bool
+		Referencing bool
+	}{
+		Models:    models,
+		Example:   models[0],
+		Reference: strings.TrimPrefix(access(g.propertyIdents(reference)["reference"]), "value"),
+	}
+	for i := len(models) - 1; i >= 0; i-- {
+		data.Referencing = data.Referencing || len(models[i].Refs) > 0
+		if models[i].ID != "" {
+			data.Identified = true
+			data.Example = models[i]
+		}
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
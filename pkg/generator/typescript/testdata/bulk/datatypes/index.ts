@@ -0,0 +1,147 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+export interface Coding {
+  system?: string; // Identity of the terminology system
+  version?: string; // Version of the system - if relevant
+  code?: string; // Symbol in syntax defined by the system
+  display?: string; // Representation defined by the system
+  userSelected?: boolean; // If this coding was chosen directly by the user
+}
+
+/**
+ * Metadata about a resource
+ */
+export interface Meta {
+  versionId?: string; // Version specific identifier
+  lastUpdated?: string; // When the resource version last changed
+  source?: string; // Identifies where the resource comes from
+  profile?: string[]; // Profiles this resource claims to conform to
+  security?: Coding[]; // Security Labels applied to this resource
+  tag?: Coding[]; // Tags applied to this resource
+}
+
+/**
+ * Base Resource
+ */
+export interface Resource {
+  id?: string; // Logical id of this artifact
+  meta?: Meta; // Metadata about the resource
+  implicitRules?: string; // A set of rules under which this content was created
+  language?: string; // Language of the resource content
+}
+
+/**
+ * Time range defined by start and end date/time
+ */
+export interface Period {
+  start?: string; // Starting time with inclusive boundary
+  end?: string; // End time with inclusive boundary, if not ongoing
+}
+
+/**
+ * An address expressed using postal conventions
+ */
+export interface Address {
+  use?: string; // home | work | temp | old | billing - purpose of this address
+  type?: string; // postal | physical | both
+  text?: string; // Text representation of the address
+  line?: string[]; // Street name, number, direction & P.O. Box etc.
+  city?: string; // Name of city, town etc.
+  district?: string; // District name (aka county)
+  state?: string; // Sub-unit of country (abbreviations ok)
+  postalCode?: string; // Postal code for area
+  country?: string; // Country (e.g. can be ISO 3166 2 or 3 letter code)
+  period?: Period; // Time period when address was/is in use
+}
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+export interface CodeableConcept {
+  coding?: Coding[]; // Code defined by a terminology system
+  text?: string; // Plain text representation of the concept
+}
+
+/**
+ * An identifier intended for computation
+ */
+export interface Identifier {
+  use?: string; // usual | official | temp | secondary | old (If known)
+  type?: CodeableConcept; // Description of identifier
+  system?: string; // The namespace for the identifier value
+  value?: string; // The value that is unique
+  period?: Period; // Time period when id is/was valid for use
+}
+
+/**
+ * A reference from one resource to another
+ */
+export interface Reference {
+  reference?: string; // Literal reference, Relative, internal or absolute URL
+  type?: string; // Type the reference refers to (e.g. "Patient")
+  identifier?: Identifier; // Logical reference, when literal reference is not known
+  display?: string; // Text alternative for the resource
+}
+
+/**
+ * Additional content defined by implementations
+ */
+export interface Extension {
+  url: string; // Identifies the meaning of the extension
+  valueString?: string; // Value of extension
+  valueCode?: string; // Value of extension
+  valueBoolean?: boolean; // Value of extension
+  valueInteger?: number; // Value of extension
+  valueDecimal?: number; // Value of extension
+  valueDateTime?: string; // Value of extension
+  valueCoding?: Coding; // Value of extension
+  valueCodeableConcept?: CodeableConcept; // Value of extension
+  valueReference?: Reference; // Value of extension
+  valueAddress?: Address; // Value of extension
+}
+
+/**
+ * Human-readable summary of the resource
+ */
+export interface Narrative {
+  status: string; // generated | extensions | additional | empty
+  div: string; // Limited xhtml content
+}
+
+/**
+ * A resource with narrative and extensions
+ */
+export interface DomainResource extends Resource {
+  text?: Narrative; // Text summary of the resource, for human interpretation
+  extension?: Extension[]; // Additional content defined by implementations
+  modifierExtension?: Extension[]; // Extensions that cannot be ignored
+}
+
+/**
+ * Name of a human - parts and usage
+ */
+export interface HumanName {
+  use?: string; // usual | official | temp | nickname | anonymous | old | maiden
+  text?: string; // Text representation of the full name
+  family?: string; // Family name (often called 'Surname')
+  given?: string[]; // Given names (not always 'first'). Includes middle names
+  prefix?: string[]; // Parts that come before the name
+  suffix?: string[]; // Parts that come after the name
+  period?: Period; // Time period when name was/is in use
+}
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+export interface Quantity {
+  value?: number; // Numerical value (with implicit precision)
+  comparator?: string; // < | <= | >= | > - how to understand the value
+  unit?: string; // Unit representation
+  system?: string; // System that defines coded unit form
+  code?: string; // Coded form of the unit
+}
+
@@ -0,0 +1,66 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Visit, Specimen, lab_result } from "./index";
+
+/**
+ * A CloudEvents 1.0 event in the structured JSON format, whose data is a
+ * resource of this namespace.
+ */
+export interface CloudEvent<T> {
+  specversion: "1.0";
+  id: string;
+  source: string;
+  type: string;
+  subject?: string;
+  time?: string;
+  datacontenttype: "application/json";
+  data: T;
+}
+
+/**
+ * Event types of the resources of this namespace.
+ */
+export const EventTypes = {
+  Visit: "org.ehrglot.fixtures.visit.v1",
+  Specimen: "org.ehrglot.fixtures.specimen.v1",
+  lab_result: "org.ehrglot.fixtures.lab_result.v1",
+} as const;
+
+/**
+ * Returns an event from source, a URI reference such as /ehr/adt, that
+ * carries resource, with a new random id and the current time.
+ */
+export function newVisitEvent(source: string, resource: Visit): CloudEvent<Visit> {
+  return newCloudEvent(source, EventTypes.Visit, resource);
+}
+
+/**
+ * Returns an event from source, a URI reference such as /ehr/adt, that
+ * carries resource, with a new random id and the current time.
+ */
+export function newSpecimenEvent(source: string, resource: Specimen): CloudEvent<Specimen> {
+  return newCloudEvent(source, EventTypes.Specimen, resource);
+}
+
+/**
+ * Returns an event from source, a URI reference such as /ehr/adt, that
+ * carries resource, with a new random id and the current time.
+ */
+export function newLabResultEvent(source: string, resource: lab_result): CloudEvent<lab_result> {
+  return newCloudEvent(source, EventTypes.lab_result, resource);
+}
+
+function newCloudEvent<T>(source: string, type: string, data: T): CloudEvent<T> {
+  if (!source) {
+    throw new Error(`${type} event has no source`);
+  }
+  return {
+    specversion: "1.0",
+    id: crypto.randomUUID(),
+    source,
+    type,
+    time: new Date().toISOString(),
+    datacontenttype: "application/json",
+    data,
+  };
+}
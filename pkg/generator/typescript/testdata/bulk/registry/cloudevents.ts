@@ -0,0 +1,48 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Practitioner } from "./index";
+
+/**
+ * A CloudEvents 1.0 event in the structured JSON format, whose data is a
+ * resource of this namespace.
+ */
+export interface CloudEvent<T> {
+  specversion: "1.0";
+  id: string;
+  source: string;
+  type: string;
+  subject?: string;
+  time?: string;
+  datacontenttype: "application/json";
+  data: T;
+}
+
+/**
+ * Event types of the resources of this namespace.
+ */
+export const EventTypes = {
+  Practitioner: "org.ehrglot.registry.practitioner.v1",
+} as const;
+
+/**
+ * Returns an event from source, a URI reference such as /ehr/adt, that
+ * carries resource, with a new random id and the current time.
+ */
+export function newPractitionerEvent(source: string, resource: Practitioner): CloudEvent<Practitioner> {
+  return newCloudEvent(source, EventTypes.Practitioner, resource);
+}
+
+function newCloudEvent<T>(source: string, type: string, data: T): CloudEvent<T> {
+  if (!source) {
+    throw new Error(`${type} event has no source`);
+  }
+  return {
+    specversion: "1.0",
+    id: crypto.randomUUID(),
+    source,
+    type,
+    time: new Date().toISOString(),
+    datacontenttype: "application/json",
+    data,
+  };
+}
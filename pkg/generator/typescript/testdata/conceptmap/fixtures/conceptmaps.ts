@@ -0,0 +1,61 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+/**
+ * A code that a concept map translates a source code to, and how equivalent
+ * the two are.
+ */
+export interface ConceptMapTarget {
+  system: string;
+  code: string;
+  display: string;
+  equivalence: string;
+}
+
+// The concept map lab-to-loinc, by source system and code.
+const LAB_TO_LOINC: ReadonlyMap<string, ReadonlyMap<string, readonly ConceptMapTarget[]>> = new Map([
+  [
+    "http://hospital.example/lab-codes",
+    new Map([
+      [
+        "GLU",
+        [
+          { system: "http://loinc.org", code: "2345-7", display: "Glucose [Mass/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+      ],
+      [
+        "K",
+        [
+          { system: "http://loinc.org", code: "2823-3", display: "Potassium [Moles/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+      ],
+      [
+        "LYTES",
+        [
+          { system: "http://loinc.org", code: "2951-2", display: "Sodium [Moles/volume] in Serum or Plasma", equivalence: "wider" },
+          { system: "http://loinc.org", code: "2075-0", display: "Chloride \"Cl\" [Moles/volume] in Serum or Plasma", equivalence: "wider" },
+        ],
+      ],
+    ]),
+  ],
+  [
+    "http://legacy.example/lab",
+    new Map([
+      [
+        "0012",
+        [
+          { system: "http://loinc.org", code: "2345-7", display: "Glucose [Mass/volume] in Serum or Plasma", equivalence: "equivalent" },
+        ],
+      ],
+    ]),
+  ],
+]);
+
+/**
+ * Returns the codes that code of system translates to by the concept map
+ * lab-to-loinc, if any.
+ *
+ * Local lab test codes to LOINC
+ */
+export function translateLabToLoinc(system: string, code: string): ConceptMapTarget[] {
+  return [...(LAB_TO_LOINC.get(system)?.get(code) ?? [])];
+}
@@ -0,0 +1,17 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+
+/**
+ * Series-level DICOM attributes
+ */
+export interface ImagingSeries {
+  seriesInstanceUid: string; // Series Instance UID
+  modality: string; // Acquisition modality
+  imageType?: string[]; // Image type values
+  performingPhysician?: string; // Performing physician's name
+  seriesDate?: string; // Date the series started
+  acquiredAt?: string; // Acquisition date and time
+  seriesNumber?: number; // Number of the series in the study
+  sliceThickness?: number; // Slice thickness in millimeters
+}
+
@@ -0,0 +1,28 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+export type PartialDate = string;
+
+/**
+ * Reports whether value is a well-formed PartialDate.
+ */
+export function isPartialDate(value: string): value is PartialDate {
+  return /^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$/.test(value);
+}
+
+
+/**
+ * A person in the master patient index
+ */
+export interface Person {
+  id: string; // Logical id of this person
+  mrn: string; // Medical record number
+  ssn?: string; // Social security number
+  familyName?: string;
+  givenName?: string;
+  birthDate?: PartialDate;
+  sex?: string;
+}
+
@@ -0,0 +1,60 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Person } from "./index";
+
+/**
+ * Returns the patient matching keys of value, which records that are
+ * candidate matches share: a blocking key of its normalized demographics,
+ * when all are set, and a key of each identifier that is set.
+ */
+export function personMatchKeys(value: Person): string[] {
+  return matchKeys([nameKey(value.familyName), nameKey(value.givenName), dateKey(value.birthDate), sexKey(value.sex)], [["mrn", value.mrn], ["ssn", value.ssn]]);
+}
+
+/**
+ * Returns the demographic key made of components, unless there are none or
+ * one is empty, and the key of each identifier, a name and a value, whose
+ * normalized value is not empty.
+ */
+function matchKeys(components: string[], identifiers: [string, string | null | undefined][]): string[] {
+  const keys: string[] = [];
+  if (components.length > 0 && components.every((c) => c !== "")) {
+    keys.push("demographics:" + components.join("|"));
+  }
+  for (const [name, value] of identifiers) {
+    const normalized = normalize(value ?? "", true);
+    if (normalized !== "") {
+      keys.push(name + ":" + normalized);
+    }
+  }
+  return keys;
+}
+
+/**
+ * Returns the key component of a name: its letters.
+ */
+function nameKey(name: string | null | undefined): string {
+  return normalize(name ?? "", false);
+}
+
+/**
+ * Returns the key component of a date, as much of it as is known.
+ */
+function dateKey(date: string | null | undefined): string {
+  return date ?? "";
+}
+
+/**
+ * Returns the key component of a sex: its first letter.
+ */
+function sexKey(sex: string | null | undefined): string {
+  return normalize(sex ?? "", false).slice(0, 1);
+}
+
+/**
+ * Returns the letters A to Z of value, and its digits too when digits is
+ * set, in upper case.
+ */
+function normalize(value: string, digits: boolean): string {
+  return value.replace(digits ? /[^A-Za-z0-9]/g : /[^A-Za-z]/g, "").toUpperCase();
+}
@@ -0,0 +1,16 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+
+/**
+ * A person in the master patient index
+ */
+export interface Person {
+  id: string; // Logical id of this person
+  mrn: string; // Medical record number
+  ssn?: string; // Social security number
+  familyName?: string;
+  givenName?: string;
+  birthDate?: string;
+  sex?: string;
+}
+
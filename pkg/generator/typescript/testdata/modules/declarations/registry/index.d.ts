@@ -0,0 +1,23 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { DomainResource, HumanName } from "../datatypes";
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+export type PartialDate = string;
+
+/**
+ * Reports whether value is a well-formed PartialDate.
+ */
+export declare function isPartialDate(value: string): value is PartialDate;
+
+
+/**
+ * A person providing care
+ */
+export interface Practitioner extends DomainResource {
+  name?: HumanName[]; // The name(s) associated with the practitioner
+  supervisor?: Practitioner; // Practitioner responsible for this one
+}
+
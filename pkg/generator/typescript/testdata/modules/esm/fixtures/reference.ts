@@ -0,0 +1,29 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Specimen, lab_result } from "./index.js";
+
+/**
+ * Returns the names of the fields of value that are set and outside their
+ * reference ranges, or an empty list.
+ */
+export function outOfRangeSpecimen(value: Specimen): string[] {
+  const flagged: string[] = [];
+  // at most 4
+  if (value.containerCount != null && value.containerCount > 4) {
+    flagged.push("containerCount");
+  }
+  return flagged;
+}
+
+/**
+ * Returns the names of the fields of value that are set and outside their
+ * reference ranges, or an empty list.
+ */
+export function outOfRangeLabResult(value: lab_result): string[] {
+  const flagged: string[] = [];
+  // 3.5 to 11 umol/L
+  if (value.valueNumeric != null && (value.valueNumeric < 3.5 || value.valueNumeric > 11)) {
+    flagged.push("value_numeric");
+  }
+  return flagged;
+}
@@ -0,0 +1,93 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import { createReadStream } from "node:fs";
+import { readFile } from "node:fs/promises";
+import { dirname, resolve } from "node:path";
+import { createInterface } from "node:readline";
+import { Readable } from "node:stream";
+import type { ReadableStream } from "node:stream/web";
+import type { Practitioner } from "./index.js";
+
+/**
+ * The manifest of a FHIR Bulk Data ($export) response.
+ */
+export interface BulkManifest {
+  transactionTime: string;
+  request: string;
+  requiresAccessToken: boolean;
+  output: BulkFile[];
+  error?: BulkFile[];
+}
+
+/**
+ * One NDJSON file of a bulk export.
+ */
+export interface BulkFile {
+  type: string;
+  url: string;
+  count?: number;
+}
+
+/**
+ * The models of this namespace, by the resource type of their bulk files.
+ */
+export interface BulkResources {
+  "Practitioner": Practitioner;
+}
+
+/**
+ * A resource of a bulk export with its type, which narrows the resource.
+ */
+export type BulkResource = { [T in keyof BulkResources]: { type: T; resource: BulkResources[T] } }[keyof BulkResources];
+
+export interface BulkLoadOptions {
+  /** Sent as a bearer token for the files of manifests that require one. */
+  accessToken?: string;
+}
+
+const BULK_TYPES = new Set<string>(["Practitioner"]);
+
+/**
+ * Reads the manifest at path and yields each resource of the output files
+ * whose type is a model of this namespace, skipping other files. File URLs
+ * that are not http or https are paths, relative to the manifest.
+ */
+export async function* loadBulkExport(path: string, options: BulkLoadOptions = {}): AsyncGenerator<BulkResource> {
+  const manifest = JSON.parse(await readFile(path, "utf8")) as BulkManifest;
+  const token = manifest.requiresAccessToken ? options.accessToken : undefined;
+  for (const file of manifest.output) {
+    if (!BULK_TYPES.has(file.type)) {
+      continue;
+    }
+    const lines = createInterface({ input: await openBulkFile(dirname(path), file.url, token), crlfDelay: Infinity });
+    let line = 0;
+    for await (const text of lines) {
+      line++;
+      if (text.trim() === "") {
+        continue;
+      }
+      let resource: unknown;
+      try {
+        resource = JSON.parse(text);
+      } catch (e) {
+        throw new Error(`${file.url}: line ${line}: ${(e as Error).message}`);
+      }
+      yield { type: file.type, resource } as BulkResource;
+    }
+  }
+}
+
+async function openBulkFile(dir: string, url: string, token?: string): Promise<NodeJS.ReadableStream> {
+  if (/^https?:\/\//.test(url)) {
+    const headers: Record<string, string> = { Accept: "application/fhir+ndjson" };
+    if (token) {
+      headers.Authorization = `Bearer ${token}`;
+    }
+    const response = await fetch(url, { headers });
+    if (!response.ok || response.body === null) {
+      throw new Error(`GET ${url}: ${response.status} ${response.statusText}`);
+    }
+    return Readable.fromWeb(response.body as ReadableStream);
+  }
+  return createReadStream(resolve(dir, url.replace(/^file:\/\//, "")));
+}
@@ -0,0 +1,13 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { DomainResource, HumanName } from "@acme/models/datatypes";
+
+
+/**
+ * A person providing care
+ */
+export interface Practitioner extends DomainResource {
+  name?: HumanName[]; // The name(s) associated with the practitioner
+  supervisor?: Practitioner; // Practitioner responsible for this one
+}
+
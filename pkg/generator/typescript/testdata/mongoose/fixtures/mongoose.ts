@@ -0,0 +1,79 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import { Schema, model } from "mongoose";
+import type { Visit, Specimen, lab_result } from "./index";
+
+/**
+ * Mongoose schema of Visit documents, stored in the visit collection.
+ */
+export const visitSchema = new Schema(
+  {
+    resourceType: { type: String, required: true, enum: ["Visit"], default: "Visit" },
+    class: { type: Schema.Types.Mixed, required: true },
+    type: [Schema.Types.Mixed],
+    for: Schema.Types.Mixed,
+    match: { type: String, default: "unlinked" },
+    order: Number,
+    self: String,
+    where: Schema.Types.Mixed,
+    "2ndOpinion": Boolean,
+    priorityCode: String,
+    priorityCode_2: String,
+    visit: String,
+    length: Schema.Types.Mixed,
+    attender: Schema.Types.Mixed,
+    specimens: [Schema.Types.Mixed],
+  },
+  { collection: "visit", versionKey: false },
+);
+
+export const VisitModel = model<Visit>("Visit", visitSchema);
+
+/**
+ * Mongoose schema of Specimen documents, stored in the specimen collection.
+ */
+export const specimenSchema = new Schema(
+  {
+    resourceType: { type: String, required: true, enum: ["Specimen"], default: "Specimen" },
+    id: { type: String, required: true },
+    status: { type: String, required: true, enum: ["available", "unavailable", "entered-in-error"], default: "available" },
+    accessionIdentifier: Schema.Types.Mixed,
+    receivedTime: String,
+    collectedDate: String,
+    quantity: Number,
+    containerCount: { type: Number, default: 1 },
+    fasting: { type: Boolean, default: false },
+    note: [String],
+    subject: Schema.Types.Mixed,
+    request: [Schema.Types.Mixed],
+    visit: Schema.Types.Mixed,
+    collection: Schema.Types.Mixed,
+  },
+  { collection: "specimen", versionKey: false, suppressReservedKeysWarning: true },
+);
+
+export const SpecimenModel = model<Specimen>("Specimen", specimenSchema);
+
+/**
+ * Mongoose schema of lab_result documents, stored in the lab_result collection.
+ */
+export const labResultSchema = new Schema(
+  {
+    resultId: { type: String, required: true },
+    mrn: { type: String, required: true },
+    valueNumeric: Number,
+    chargeAmount: { type: Number, default: 0 },
+    valueQuantity: Schema.Types.Mixed,
+    resultedAt: String,
+    performerName: Schema.Types.Mixed,
+    orderedBy: Schema.Types.Mixed,
+    rawPayload: String,
+    icd10Code: String,
+    reviewerIds: [String],
+  },
+  { collection: "lab_result", versionKey: false },
+);
+labResultSchema.index({ resultId: 1 }, { unique: true });
+labResultSchema.index({ mrn: 1, resultedAt: 1 });
+
+export const LabResultModel = model<lab_result>("lab_result", labResultSchema);
@@ -0,0 +1,25 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import { Schema, model } from "mongoose";
+import type { Practitioner } from "./index";
+
+/**
+ * Mongoose schema of Practitioner documents, stored in the practitioner collection.
+ */
+export const practitionerSchema = new Schema(
+  {
+    resourceType: { type: String, required: true, enum: ["Practitioner"], default: "Practitioner" },
+    id: String,
+    meta: Schema.Types.Mixed,
+    implicitRules: String,
+    language: String,
+    text: Schema.Types.Mixed,
+    extension: [Schema.Types.Mixed],
+    modifierExtension: [Schema.Types.Mixed],
+    name: [Schema.Types.Mixed],
+    supervisor: Schema.Types.Mixed,
+  },
+  { collection: "practitioner", versionKey: false },
+);
+
+export const PractitionerModel = model<Practitioner>("Practitioner", practitionerSchema);
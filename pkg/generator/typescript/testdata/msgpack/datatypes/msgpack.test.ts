@@ -0,0 +1,71 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import { deepStrictEqual } from "node:assert";
+import { test } from "node:test";
+import type { Coding, Meta, Resource, Period, Address, CodeableConcept, Identifier, Reference, Extension, Narrative, DomainResource, HumanName, Quantity } from "./index";
+import { decodeCoding, encodeCoding, decodeMeta, encodeMeta, decodeResource, encodeResource, decodePeriod, encodePeriod, decodeAddress, encodeAddress, decodeCodeableConcept, encodeCodeableConcept, decodeIdentifier, encodeIdentifier, decodeReference, encodeReference, decodeExtension, encodeExtension, decodeNarrative, encodeNarrative, decodeDomainResource, encodeDomainResource, decodeHumanName, encodeHumanName, decodeQuantity, encodeQuantity } from "./msgpack";
+
+test("Coding round-trips through MessagePack", () => {
+  const record: Coding = JSON.parse(`{}`);
+  deepStrictEqual(decodeCoding(encodeCoding(record)), record);
+});
+
+test("Meta round-trips through MessagePack", () => {
+  const record: Meta = JSON.parse(`{}`);
+  deepStrictEqual(decodeMeta(encodeMeta(record)), record);
+});
+
+test("Resource round-trips through MessagePack", () => {
+  const record: Resource = JSON.parse(`{}`);
+  deepStrictEqual(decodeResource(encodeResource(record)), record);
+});
+
+test("Period round-trips through MessagePack", () => {
+  const record: Period = JSON.parse(`{}`);
+  deepStrictEqual(decodePeriod(encodePeriod(record)), record);
+});
+
+test("Address round-trips through MessagePack", () => {
+  const record: Address = JSON.parse(`{}`);
+  deepStrictEqual(decodeAddress(encodeAddress(record)), record);
+});
+
+test("CodeableConcept round-trips through MessagePack", () => {
+  const record: CodeableConcept = JSON.parse(`{}`);
+  deepStrictEqual(decodeCodeableConcept(encodeCodeableConcept(record)), record);
+});
+
+test("Identifier round-trips through MessagePack", () => {
+  const record: Identifier = JSON.parse(`{}`);
+  deepStrictEqual(decodeIdentifier(encodeIdentifier(record)), record);
+});
+
+test("Reference round-trips through MessagePack", () => {
+  const record: Reference = JSON.parse(`{}`);
+  deepStrictEqual(decodeReference(encodeReference(record)), record);
+});
+
+test("Extension round-trips through MessagePack", () => {
+  const record: Extension = JSON.parse(`{"url":"https://example.org"}`);
+  deepStrictEqual(decodeExtension(encodeExtension(record)), record);
+});
+
+test("Narrative round-trips through MessagePack", () => {
+  const record: Narrative = JSON.parse(`{"div":"example","status":"generated"}`);
+  deepStrictEqual(decodeNarrative(encodeNarrative(record)), record);
+});
+
+test("DomainResource round-trips through MessagePack", () => {
+  const record: DomainResource = JSON.parse(`{"extension":[{"url":"https://example.org"}],"modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}}`);
+  deepStrictEqual(decodeDomainResource(encodeDomainResource(record)), record);
+});
+
+test("HumanName round-trips through MessagePack", () => {
+  const record: HumanName = JSON.parse(`{}`);
+  deepStrictEqual(decodeHumanName(encodeHumanName(record)), record);
+});
+
+test("Quantity round-trips through MessagePack", () => {
+  const record: Quantity = JSON.parse(`{}`);
+  deepStrictEqual(decodeQuantity(encodeQuantity(record)), record);
+});
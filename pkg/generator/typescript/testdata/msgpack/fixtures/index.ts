@@ -0,0 +1,91 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Address, CodeableConcept, Coding, HumanName, Identifier, Quantity, Reference } from "../datatypes";
+import type { Practitioner } from "../registry";
+
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+export interface Visit {
+  class: Coding; // Classification of the visit
+  type?: CodeableConcept[]; // Specific type of visit
+  for?: Reference; // Who the visit is for
+  match?: string; // Record linkage match grade
+  order?: number; // Sequence within the episode
+  self?: string; // Canonical URL of the visit
+  where?: Address; // Where the visit took place
+  "2ndOpinion"?: boolean; // Whether a second opinion was requested
+  priorityCode?: string; // Priority as a code
+  priorityCode_2?: string; // Priority as legacy text
+  visit?: string; // Visit number
+  length?: Quantity; // Time the visit lasted
+  attender?: Practitioner; // Practitioner who saw the patient
+  specimens?: Specimen[]; // Specimens collected during the visit
+}
+
+/**
+ * Defaults of the fields of Visit, to spread into new records.
+ */
+export const visitDefaults = {
+  match: "unlinked",
+} satisfies Partial<Visit>;
+
+/**
+ * A sample collected for analysis
+ */
+export interface Specimen {
+  id: string; // Logical id of this artifact
+  status: string; // available | unavailable | entered-in-error
+  accessionIdentifier?: Identifier; // Identifier assigned by the lab
+  receivedTime?: string; // When the specimen was received
+  collectedDate?: string; // Collection date
+  quantity?: number; // Amount collected
+  containerCount?: number; // Number of containers
+  fasting?: boolean; // Whether the patient was fasting
+  note?: string[]; // Comments
+  subject?: Reference; // Where the specimen came from
+  request?: Reference[]; // Why the specimen was collected
+  visit?: Visit; // Visit during which the specimen was collected
+  collection?: unknown; // Collection details
+}
+
+/**
+ * Defaults of the fields of Specimen, to spread into new records.
+ */
+export const specimenDefaults = {
+  status: "available",
+  containerCount: 1,
+  fasting: false,
+} satisfies Partial<Specimen>;
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+export interface lab_result {
+  resultId: string; // Identifiant du résultat. Example: LR-1001
+  mrn: string; // Medical record number
+  valueNumeric?: number; // Valeur mesurée (µmol/L). Examples: 5.4, 12.0
+  chargeAmount?: number; // Billed amount
+  valueQuantity?: Quantity; // Measured value with its unit
+  resultedAt?: string; // When the result was released
+  performerName?: HumanName; // Who performed the test
+  orderedBy?: Practitioner; // Who ordered the test
+  rawPayload?: string; // Original HL7 message
+  icd10Code?: string; // Diagnosis the test was ordered for
+  reviewerIds?: string[]; // Reviewing clinicians
+}
+
+/**
+ * Defaults of the fields of lab_result, to spread into new records.
+ */
+export const labResultDefaults = {
+  chargeAmount: 0,
+} satisfies Partial<lab_result>;
+
@@ -0,0 +1,21 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import { deepStrictEqual } from "node:assert";
+import { test } from "node:test";
+import type { Visit, Specimen, lab_result } from "./index";
+import { decodeVisit, encodeVisit, decodeSpecimen, encodeSpecimen, decodeLabResult, encodeLabResult } from "./msgpack";
+
+test("Visit round-trips through MessagePack", () => {
+  const record: Visit = JSON.parse(`{"attender":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"class":{},"resourceType":"Visit","specimens":[{"id":"example-1","status":"available"}]}`);
+  deepStrictEqual(decodeVisit(encodeVisit(record)), record);
+});
+
+test("Specimen round-trips through MessagePack", () => {
+  const record: Specimen = JSON.parse(`{"id":"example-1","receivedTime":"<timestamp>","resourceType":"Specimen","status":"available","visit":{"attender":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"class":{}}}`);
+  deepStrictEqual(decodeSpecimen(encodeSpecimen(record)), record);
+});
+
+test("lab_result round-trips through MessagePack", () => {
+  const record: lab_result = JSON.parse(`{"mrn":"example","orderedBy":{"extension":[{"url":"https://example.org"}],"id":"example-1","modifierExtension":[{"url":"https://example.org"}],"text":{"div":"example","status":"generated"}},"resultId":"LR-1001","valueNumeric":5.4}`);
+  deepStrictEqual(decodeLabResult(encodeLabResult(record)), record);
+});
@@ -0,0 +1,107 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Quantity } from "./index";
+
+export const UCUM_SYSTEM = "http://unitsofmeasure.org";
+
+/**
+ * A unit whose value v is v * factor + offset in its dimension's base unit.
+ */
+interface Unit {
+  dimension: string;
+  factor: number;
+  offset: number;
+}
+
+const UNITS: Record<string, Unit> = {
+  "g": { dimension: "mass", factor: 1.0, offset: 0.0 },
+  "kg": { dimension: "mass", factor: 1000.0, offset: 0.0 },
+  "mg": { dimension: "mass", factor: 0.001, offset: 0.0 },
+  "ug": { dimension: "mass", factor: 1e-06, offset: 0.0 },
+  "ng": { dimension: "mass", factor: 1e-09, offset: 0.0 },
+  "[lb_av]": { dimension: "mass", factor: 453.59237, offset: 0.0 },
+  "[oz_av]": { dimension: "mass", factor: 28.349523125, offset: 0.0 },
+  "m": { dimension: "length", factor: 1.0, offset: 0.0 },
+  "km": { dimension: "length", factor: 1000.0, offset: 0.0 },
+  "cm": { dimension: "length", factor: 0.01, offset: 0.0 },
+  "mm": { dimension: "length", factor: 0.001, offset: 0.0 },
+  "[in_i]": { dimension: "length", factor: 0.0254, offset: 0.0 },
+  "[ft_i]": { dimension: "length", factor: 0.3048, offset: 0.0 },
+  "L": { dimension: "volume", factor: 1.0, offset: 0.0 },
+  "dL": { dimension: "volume", factor: 0.1, offset: 0.0 },
+  "mL": { dimension: "volume", factor: 0.001, offset: 0.0 },
+  "uL": { dimension: "volume", factor: 1e-06, offset: 0.0 },
+  "s": { dimension: "time", factor: 1.0, offset: 0.0 },
+  "min": { dimension: "time", factor: 60.0, offset: 0.0 },
+  "h": { dimension: "time", factor: 3600.0, offset: 0.0 },
+  "d": { dimension: "time", factor: 86400.0, offset: 0.0 },
+  "wk": { dimension: "time", factor: 604800.0, offset: 0.0 },
+  "mo": { dimension: "time", factor: 2.6298e+06, offset: 0.0 },
+  "a": { dimension: "time", factor: 3.15576e+07, offset: 0.0 },
+  "K": { dimension: "temperature", factor: 1.0, offset: 0.0 },
+  "Cel": { dimension: "temperature", factor: 1.0, offset: 273.15 },
+  "[degF]": { dimension: "temperature", factor: 0.5555555555555556, offset: 255.37222222222223 },
+  "g/L": { dimension: "mass_concentration", factor: 1.0, offset: 0.0 },
+  "g/dL": { dimension: "mass_concentration", factor: 10.0, offset: 0.0 },
+  "mg/dL": { dimension: "mass_concentration", factor: 0.01, offset: 0.0 },
+  "mg/L": { dimension: "mass_concentration", factor: 0.001, offset: 0.0 },
+  "ug/mL": { dimension: "mass_concentration", factor: 0.001, offset: 0.0 },
+  "ng/mL": { dimension: "mass_concentration", factor: 1e-06, offset: 0.0 },
+  "mol/L": { dimension: "substance_concentration", factor: 1.0, offset: 0.0 },
+  "mmol/L": { dimension: "substance_concentration", factor: 0.001, offset: 0.0 },
+  "umol/L": { dimension: "substance_concentration", factor: 1e-06, offset: 0.0 },
+  "nmol/L": { dimension: "substance_concentration", factor: 1e-09, offset: 0.0 },
+  "Pa": { dimension: "pressure", factor: 1.0, offset: 0.0 },
+  "kPa": { dimension: "pressure", factor: 1000.0, offset: 0.0 },
+  "mm[Hg]": { dimension: "pressure", factor: 133.322, offset: 0.0 },
+  "Hz": { dimension: "frequency", factor: 1.0, offset: 0.0 },
+  "/min": { dimension: "frequency", factor: 0.016666666666666666, offset: 0.0 },
+  "/h": { dimension: "frequency", factor: 0.0002777777777777778, offset: 0.0 },
+  "1": { dimension: "ratio", factor: 1.0, offset: 0.0 },
+  "%": { dimension: "ratio", factor: 0.01, offset: 0.0 },
+};
+
+/**
+ * Reports whether code is a UCUM unit these helpers can convert.
+ */
+export function isKnownUnit(code: string): boolean {
+  return Object.prototype.hasOwnProperty.call(UNITS, code);
+}
+
+/**
+ * Converts value between two UCUM units of the same dimension.
+ */
+export function convertValue(value: number, fromCode: string, toCode: string): number {
+  const source = unit(fromCode);
+  const target = unit(toCode);
+  if (source.dimension !== target.dimension) {
+    throw new Error(`cannot convert ${fromCode} (${source.dimension}) to ${toCode} (${target.dimension})`);
+  }
+  return (value * source.factor + source.offset - target.offset) / target.factor;
+}
+
+/**
+ * Returns quantity in toCode units. Its code, or else its unit, must be a
+ * known UCUM unit.
+ */
+export function convert(quantity: Quantity, toCode: string): Quantity {
+  if (quantity.value == null) {
+    throw new Error("cannot convert a quantity without a value");
+  }
+  const fromCode = quantity.code ?? quantity.unit ?? "";
+  const value = convertValue(Number(quantity.value), fromCode, toCode);
+  return {
+    ...quantity,
+    value: String(value),
+    unit: toCode,
+    system: UCUM_SYSTEM,
+    code: toCode,
+  };
+}
+
+function unit(code: string): Unit {
+  if (!isKnownUnit(code)) {
+    throw new Error(`unknown UCUM unit: ${code}`);
+  }
+  return UNITS[code];
+}
@@ -0,0 +1,51 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Visit, Specimen, lab_result } from "./index";
+
+// The year, month, and day of a date or datetime known to the day.
+const FULL_DATE = /^(\d{4})-(\d{2})-(\d{2})/;
+
+/**
+ * Returns the display of value, derived as concat('Visit ', visit),
+ * or undefined when a field it uses is unset. Label shown for the visit
+ */
+export function visitDisplay(value: Visit): string | undefined {
+  if (value.visit == null) {
+    return undefined;
+  }
+  return "Visit " + value.visit;
+}
+
+/**
+ * Returns the turnaroundDays of value, derived as days_between(collectedDate, receivedTime),
+ * or undefined when a field it uses is unset or a date it uses is not known
+ * to the day. Days from collection to receipt
+ */
+export function specimenTurnaroundDays(value: Specimen): number | undefined {
+  if (value.collectedDate == null || !FULL_DATE.test(value.collectedDate) || value.receivedTime == null || !FULL_DATE.test(value.receivedTime)) {
+    return undefined;
+  }
+  return daysBetween(value.collectedDate, value.receivedTime);
+}
+
+/**
+ * Returns the value_mmol of value, derived as value_numeric / 1000,
+ * or undefined when a field it uses is unset. Measured value in mmol/L
+ */
+export function labResultValueMmol(value: lab_result): number | undefined {
+  if (value.valueNumeric == null) {
+    return undefined;
+  }
+  return Number(value.valueNumeric) / 1000;
+}
+
+/**
+ * Returns the days from the date of start to that of end.
+ */
+function daysBetween(start: string, end: string): number {
+  const utc = (date: string) => {
+    const [, year, month, day] = FULL_DATE.exec(date)!.map(Number);
+    return Date.UTC(year, month - 1, day);
+  };
+  return Math.round((utc(end) - utc(start)) / 86400000);
+}
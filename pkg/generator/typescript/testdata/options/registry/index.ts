@@ -0,0 +1,28 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { DomainResource, HumanName } from "../datatypes";
+
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+export type PartialDate = string;
+
+/**
+ * Reports whether value is a well-formed PartialDate.
+ */
+export function isPartialDate(value: string): value is PartialDate {
+  return /^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$/.test(value);
+}
+
+
+/**
+ * A person providing care
+ */
+export interface Practitioner extends DomainResource {
+  name?: HumanName[]; // The name(s) associated with the practitioner
+  supervisor?: Practitioner; // Practitioner responsible for this one
+  ingestedAt?: string; // When the record was loaded into the warehouse
+  sourceSystem?: string; // System the record was extracted from
+  recordHash?: string; // Hash of the source record, for change detection
+}
+
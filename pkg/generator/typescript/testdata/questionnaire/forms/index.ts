@@ -0,0 +1,18 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+
+/**
+ * Responses to Patient intake
+ */
+export interface PatientIntakeResponse {
+  chiefComplaint: string; // What brings you in today?
+  symptomOnset?: string; // When did it start?
+  painScore?: number; // Pain, from 0 to 10
+  smoker?: boolean; // Do you smoke?
+  packsPerDay?: number; // Packs a day
+  allergies?: string[]; // Allergies
+  pregnant?: string; // Are you pregnant?
+  dueDate?: string; // Expected due date
+  signedAt?: string; // Signed at
+}
+
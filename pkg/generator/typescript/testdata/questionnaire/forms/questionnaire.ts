@@ -0,0 +1,99 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { PatientIntakeResponse } from "./index";
+
+/**
+ * An item of a FHIR QuestionnaireResponse: the answers to a question, or
+ * the items of a group.
+ */
+export interface QuestionnaireResponseItem {
+  linkId: string;
+  text?: string;
+  answer?: QuestionnaireResponseAnswer[];
+  item?: QuestionnaireResponseItem[];
+}
+
+/**
+ * An answer of a FHIR QuestionnaireResponse item, and the items nested
+ * under it.
+ */
+export interface QuestionnaireResponseAnswer {
+  valueBoolean?: boolean;
+  valueDecimal?: number;
+  valueInteger?: number;
+  valueDate?: string;
+  valueDateTime?: string;
+  valueTime?: string;
+  valueString?: string;
+  valueUri?: string;
+  valueCoding?: { system?: string; code?: string; display?: string };
+  item?: QuestionnaireResponseItem[];
+}
+
+/**
+ * Returns the answers to the questions of items, and of the items nested
+ * under them, by linkId.
+ */
+function answersByLinkId(
+  items: QuestionnaireResponseItem[] | undefined,
+  answers: Map<string, QuestionnaireResponseAnswer[]> = new Map(),
+): Map<string, QuestionnaireResponseAnswer[]> {
+  for (const item of items ?? []) {
+    if (item.answer?.length) {
+      answers.set(item.linkId, [...(answers.get(item.linkId) ?? []), ...item.answer]);
+    }
+    answersByLinkId(item.item, answers);
+    for (const answer of item.answer ?? []) {
+      answersByLinkId(answer.item, answers);
+    }
+  }
+  return answers;
+}
+
+/**
+ * Returns the values of answers, leaving out answers of another type, or
+ * undefined when none is left.
+ */
+function answerValues<T>(
+  answers: QuestionnaireResponseAnswer[] | undefined,
+  value: (answer: QuestionnaireResponseAnswer) => T | undefined,
+): T[] | undefined {
+  const values = (answers ?? []).map(value).filter((v): v is T => v !== undefined);
+  return values.length ? values : undefined;
+}
+
+/**
+ * The linkIds of the questions the fields of PatientIntakeResponse answer.
+ */
+export const PatientIntakeResponseLinkIds = {
+  chiefComplaint: "1",
+  symptomOnset: "1.1",
+  painScore: "1.2",
+  smoker: "smoking",
+  packsPerDay: "smoking.packs",
+  allergies: "allergies",
+  pregnant: "pregnancy",
+  dueDate: "pregnancy.due",
+  signedAt: "signature",
+} as const;
+
+/**
+ * Returns the fields of PatientIntakeResponse answered in a
+ * QuestionnaireResponse, found by the linkIds of their questions. Fields
+ * that are not answered, or answered with values of another type, are
+ * left out.
+ */
+export function patientIntakeResponseFromResponse(response: { item?: QuestionnaireResponseItem[] }): Partial<PatientIntakeResponse> {
+  const answers = answersByLinkId(response.item);
+  const value: Partial<PatientIntakeResponse> = {};
+  value.chiefComplaint = answerValues(answers.get("1"), (answer) => answer.valueString ?? answer.valueCoding?.display)?.[0];
+  value.symptomOnset = answerValues(answers.get("1.1"), (answer) => answer.valueDate)?.[0];
+  value.painScore = answerValues(answers.get("1.2"), (answer) => answer.valueInteger)?.[0];
+  value.smoker = answerValues(answers.get("smoking"), (answer) => answer.valueBoolean)?.[0];
+  value.packsPerDay = answerValues(answers.get("smoking.packs"), (answer) => answer.valueDecimal)?.[0];
+  value.allergies = answerValues(answers.get("allergies"), (answer) => answer.valueCoding?.code ?? answer.valueString);
+  value.pregnant = answerValues(answers.get("pregnancy"), (answer) => answer.valueCoding?.code ?? answer.valueString)?.[0];
+  value.dueDate = answerValues(answers.get("pregnancy.due"), (answer) => answer.valueDate)?.[0];
+  value.signedAt = answerValues(answers.get("signature"), (answer) => answer.valueDateTime)?.[0];
+  return value;
+}
@@ -0,0 +1,24 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Specimen, lab_result } from "./index";
+
+/**
+ * Replaces the values of fields at pii_level high or above in logs.
+ */
+export const REDACTED = "[REDACTED]";
+
+/**
+ * Returns a copy of value with its fields at pii_level high or above
+ * masked, for logging.
+ */
+export function redactSpecimenForLogging(value: Specimen): Record<string, unknown> {
+  return { ...value, subject: REDACTED };
+}
+
+/**
+ * Returns a copy of value with its fields at pii_level high or above
+ * masked, for logging.
+ */
+export function redactLabResultForLogging(value: lab_result): Record<string, unknown> {
+  return { ...value, mrn: REDACTED };
+}
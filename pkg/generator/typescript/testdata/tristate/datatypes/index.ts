@@ -0,0 +1,147 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+
+/**
+ * A reference to a code defined by a terminology system
+ */
+export interface Coding {
+  system?: string | null; // Identity of the terminology system
+  version?: string | null; // Version of the system - if relevant
+  code?: string | null; // Symbol in syntax defined by the system
+  display?: string | null; // Representation defined by the system
+  userSelected?: boolean | null; // If this coding was chosen directly by the user
+}
+
+/**
+ * Metadata about a resource
+ */
+export interface Meta {
+  versionId?: string | null; // Version specific identifier
+  lastUpdated?: string | null; // When the resource version last changed
+  source?: string | null; // Identifies where the resource comes from
+  profile?: string[] | null; // Profiles this resource claims to conform to
+  security?: Coding[] | null; // Security Labels applied to this resource
+  tag?: Coding[] | null; // Tags applied to this resource
+}
+
+/**
+ * Base Resource
+ */
+export interface Resource {
+  id?: string | null; // Logical id of this artifact
+  meta?: Meta | null; // Metadata about the resource
+  implicitRules?: string | null; // A set of rules under which this content was created
+  language?: string | null; // Language of the resource content
+}
+
+/**
+ * Time range defined by start and end date/time
+ */
+export interface Period {
+  start?: string | null; // Starting time with inclusive boundary
+  end?: string | null; // End time with inclusive boundary, if not ongoing
+}
+
+/**
+ * An address expressed using postal conventions
+ */
+export interface Address {
+  use?: string | null; // home | work | temp | old | billing - purpose of this address
+  type?: string | null; // postal | physical | both
+  text?: string | null; // Text representation of the address
+  line?: string[] | null; // Street name, number, direction & P.O. Box etc.
+  city?: string | null; // Name of city, town etc.
+  district?: string | null; // District name (aka county)
+  state?: string | null; // Sub-unit of country (abbreviations ok)
+  postalCode?: string | null; // Postal code for area
+  country?: string | null; // Country (e.g. can be ISO 3166 2 or 3 letter code)
+  period?: Period | null; // Time period when address was/is in use
+}
+
+/**
+ * Concept - reference to a terminology or just text
+ */
+export interface CodeableConcept {
+  coding?: Coding[] | null; // Code defined by a terminology system
+  text?: string | null; // Plain text representation of the concept
+}
+
+/**
+ * An identifier intended for computation
+ */
+export interface Identifier {
+  use?: string | null; // usual | official | temp | secondary | old (If known)
+  type?: CodeableConcept | null; // Description of identifier
+  system?: string | null; // The namespace for the identifier value
+  value?: string | null; // The value that is unique
+  period?: Period | null; // Time period when id is/was valid for use
+}
+
+/**
+ * A reference from one resource to another
+ */
+export interface Reference {
+  reference?: string | null; // Literal reference, Relative, internal or absolute URL
+  type?: string | null; // Type the reference refers to (e.g. "Patient")
+  identifier?: Identifier | null; // Logical reference, when literal reference is not known
+  display?: string | null; // Text alternative for the resource
+}
+
+/**
+ * Additional content defined by implementations
+ */
+export interface Extension {
+  url: string; // Identifies the meaning of the extension
+  valueString?: string | null; // Value of extension
+  valueCode?: string | null; // Value of extension
+  valueBoolean?: boolean | null; // Value of extension
+  valueInteger?: number | null; // Value of extension
+  valueDecimal?: number | null; // Value of extension
+  valueDateTime?: string | null; // Value of extension
+  valueCoding?: Coding | null; // Value of extension
+  valueCodeableConcept?: CodeableConcept | null; // Value of extension
+  valueReference?: Reference | null; // Value of extension
+  valueAddress?: Address | null; // Value of extension
+}
+
+/**
+ * Human-readable summary of the resource
+ */
+export interface Narrative {
+  status: string; // generated | extensions | additional | empty
+  div: string; // Limited xhtml content
+}
+
+/**
+ * A resource with narrative and extensions
+ */
+export interface DomainResource extends Resource {
+  text?: Narrative | null; // Text summary of the resource, for human interpretation
+  extension?: Extension[] | null; // Additional content defined by implementations
+  modifierExtension?: Extension[] | null; // Extensions that cannot be ignored
+}
+
+/**
+ * Name of a human - parts and usage
+ */
+export interface HumanName {
+  use?: string | null; // usual | official | temp | nickname | anonymous | old | maiden
+  text?: string | null; // Text representation of the full name
+  family?: string | null; // Family name (often called 'Surname')
+  given?: string[] | null; // Given names (not always 'first'). Includes middle names
+  prefix?: string[] | null; // Parts that come before the name
+  suffix?: string[] | null; // Parts that come after the name
+  period?: Period | null; // Time period when name was/is in use
+}
+
+/**
+ * A measured amount (or an amount that can potentially be measured)
+ */
+export interface Quantity {
+  value?: number | null; // Numerical value (with implicit precision)
+  comparator?: string | null; // < | <= | >= | > - how to understand the value
+  unit?: string | null; // Unit representation
+  system?: string | null; // System that defines coded unit form
+  code?: string | null; // Coded form of the unit
+}
+
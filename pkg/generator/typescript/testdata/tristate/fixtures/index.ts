@@ -0,0 +1,91 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { Address, CodeableConcept, Coding, HumanName, Identifier, Quantity, Reference } from "../datatypes";
+import type { Practitioner } from "../registry";
+
+
+/**
+ * An encounter whose field names are reserved words in target languages
+ */
+export interface Visit {
+  class: Coding; // Classification of the visit
+  type?: CodeableConcept[] | null; // Specific type of visit
+  for?: Reference | null; // Who the visit is for
+  match?: string | null; // Record linkage match grade
+  order?: number | null; // Sequence within the episode
+  self?: string | null; // Canonical URL of the visit
+  where?: Address | null; // Where the visit took place
+  "2ndOpinion"?: boolean | null; // Whether a second opinion was requested
+  priorityCode?: string | null; // Priority as a code
+  priorityCode_2?: string | null; // Priority as legacy text
+  visit?: string | null; // Visit number
+  length?: Quantity | null; // Time the visit lasted
+  attender?: Practitioner | null; // Practitioner who saw the patient
+  specimens?: Specimen[] | null; // Specimens collected during the visit
+}
+
+/**
+ * Defaults of the fields of Visit, to spread into new records.
+ */
+export const visitDefaults = {
+  match: "unlinked",
+} satisfies Partial<Visit>;
+
+/**
+ * A sample collected for analysis
+ */
+export interface Specimen {
+  id: string; // Logical id of this artifact
+  status: string; // available | unavailable | entered-in-error
+  accessionIdentifier?: Identifier | null; // Identifier assigned by the lab
+  receivedTime?: string | null; // When the specimen was received
+  collectedDate?: string | null; // Collection date
+  quantity?: number | null; // Amount collected
+  containerCount?: number | null; // Number of containers
+  fasting?: boolean | null; // Whether the patient was fasting
+  note?: string[] | null; // Comments
+  subject?: Reference | null; // Where the specimen came from
+  request?: Reference[] | null; // Why the specimen was collected
+  visit?: Visit | null; // Visit during which the specimen was collected
+  collection?: unknown | null; // Collection details
+}
+
+/**
+ * Defaults of the fields of Specimen, to spread into new records.
+ */
+export const specimenDefaults = {
+  status: "available",
+  containerCount: 1,
+  fasting: false,
+} satisfies Partial<Specimen>;
+
+/**
+ * Résultat de laboratoire — température °C, 检验结果
+ *
+ * Owner: lab-data
+ * Steward: ana.ruiz@example.org
+ * Tags: clinical, phi
+ * Unique: result_id
+ * Indexed: mrn, resulted_at
+ */
+export interface lab_result {
+  resultId: string; // Identifiant du résultat. Example: LR-1001
+  mrn: string; // Medical record number
+  valueNumeric?: number | null; // Valeur mesurée (µmol/L). Examples: 5.4, 12.0
+  chargeAmount?: number | null; // Billed amount
+  valueQuantity?: Quantity | null; // Measured value with its unit
+  resultedAt?: string | null; // When the result was released
+  performerName?: HumanName | null; // Who performed the test
+  orderedBy?: Practitioner | null; // Who ordered the test
+  rawPayload?: string | null; // Original HL7 message
+  icd10Code?: string | null; // Diagnosis the test was ordered for
+  reviewerIds?: string[] | null; // Reviewing clinicians
+}
+
+/**
+ * Defaults of the fields of lab_result, to spread into new records.
+ */
+export const labResultDefaults = {
+  chargeAmount: 0,
+} satisfies Partial<lab_result>;
+
@@ -0,0 +1,13 @@
+// Code generated by ehrglot. DO NOT EDIT.
+
+import type { DomainResource, HumanName } from "../datatypes";
+
+
+/**
+ * A person providing care
+ */
+export interface Practitioner extends DomainResource {
+  name?: HumanName[] | null; // The name(s) associated with the practitioner
+  supervisor?: Practitioner | null; // Practitioner responsible for this one
+}
+
@@ -2,67 +2,480 @@
 package typescript
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/konzy/ehrglot/pkg/audit"
+	"github.com/konzy/ehrglot/pkg/conceptmap"
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/sample"
 	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/templates"
 )
 
+const Version = "0.1.0"
+
 // Generator generates TypeScript code from schemas.
-type Generator struct{}
+type Generator struct {
+	naming       config.Naming
+	decimalType  string
+	dateType     string
+	ucum         bool
+	narrative    bool
+	phonetic     string
+	bulk         bool
+	bundle       bool
+	cloudevents  bool
+	mongoose     bool
+	resolver     bool
+	diff         bool
+	msgpack      bool
+	triState     bool
+	client       []string
+	conceptMaps  []string
+	redact       string
+	audit        bool
+	module       string
+	esm          bool
+	declarations bool
+	pkg          config.Package
+	header       *header.Header
+	templates    templates.Cache
+}
 
 // NewGenerator creates a new TypeScript code generator.
-func NewGenerator() *Generator {
-	return &Generator{}
+func NewGenerator(cfg config.Config) *Generator {
+	g := &Generator{naming: cfg.Language("typescript").Naming, decimalType: "number", dateType: "string", ucum: cfg.UCUM, narrative: cfg.Narrative, phonetic: cfg.Matching.Phonetic, bulk: cfg.Bulk, bundle: cfg.Bundle, cloudevents: cfg.CloudEvents, mongoose: cfg.Language("typescript").Mongoose, resolver: cfg.Resolver, diff: cfg.Diff, msgpack: cfg.MessagePack, triState: cfg.TriState, client: cfg.Client.Resources, conceptMaps: cfg.ConceptMapFiles(), redact: cfg.Redact, audit: cfg.Audit, module: strings.TrimSuffix(cfg.Language("typescript").Module, "/"), esm: cfg.Language("typescript").ModuleFormat == config.ModuleESM, declarations: cfg.Language("typescript").Declarations, pkg: cfg.PackageInfo("typescript"), header: header.New(cfg.Header, Version)}
+	if cfg.DecimalMode("typescript") == config.DecimalExact {
+		// JavaScript numbers are binary floats, so exact decimals travel as strings
+		g.decimalType = "string"
+	}
+	if cfg.TemporalPolicy("typescript").Dates == config.DatesPartial {
+		g.dateType = "PartialDate"
+	}
+	return g
 }
 
-// Generate generates TypeScript interfaces from schemas.
-func (g *Generator) Generate(schemas []schema.Schema, outputDir string) error {
-	// Group schemas by namespace
-	byNamespace := make(map[string][]schema.Schema)
-	for _, s := range schemas {
-		byNamespace[s.Namespace] = append(byNamespace[s.Namespace], s)
+// Generate generates TypeScript interfaces from schemas. Schemas that inherit
+// a FHIR base resource extend an interface generated for it.
+func (g *Generator) Generate(ctx context.Context, schemas []schema.Schema, outputDir string) error {
+	if g.audit {
+		schemas = audit.Inject(schemas)
+	}
+	refs, err := resolve.New(datatypes.WithBases(schemas))
+	if err != nil {
+		return err
+	}
+	samples, err := sample.New(datatypes.WithBases(schemas))
+	if err != nil {
+		return err
+	}
+	conceptMaps, err := conceptmap.LoadAll(g.conceptMaps, schemas)
+	if err != nil {
+		return err
 	}
 
-	for namespace, nsSchemas := range byNamespace {
-		nsDir := filepath.Join(outputDir, namespace)
+	for _, namespace := range refs.Namespaces() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		nsSchemas := refs.Namespace(namespace)
+		nsDir := filepath.Join(outputDir, g.NamespaceDir(namespace))
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
 		// Generate index.ts with all types
 		path := filepath.Join(nsDir, "index.ts")
-		if err := g.generateTypes(nsSchemas, path); err != nil {
+		if g.declarations {
+			path = filepath.Join(nsDir, "index.d.ts")
+		}
+		if err := g.generateTypes(refs.Scope(namespace), nsSchemas, path); err != nil {
+			return err
+		}
+		if g.declarations {
+			// The other files are helpers that run
+			continue
+		}
+
+		if g.ucum {
+			for _, s := range nsSchemas {
+				if datatypes.IsBuiltin(s) && s.GetName() == datatypes.QuantityName {
+					if err := g.generateUCUM(s, filepath.Join(nsDir, "ucum.ts")); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if dicom := dicomSchemas(nsSchemas); len(dicom) > 0 {
+			if err := g.generateDICOM(refs.Scope(namespace), dicom, filepath.Join(nsDir, "dicom.ts")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bulk && len(resources) > 0 {
+			if err := g.generateBulk(resources, filepath.Join(nsDir, "bulk.ts")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.bundle && len(resources) > 0 {
+			if err := g.generateBundle(resources, filepath.Join(nsDir, "bundle.ts")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.cloudevents && len(resources) > 0 {
+			if err := g.generateCloudEvents(resources, filepath.Join(nsDir, "cloudevents.ts")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.mongoose && len(resources) > 0 {
+			if err := g.generateMongoose(g.mongooseModels(refs.Scope(namespace), resources), filepath.Join(nsDir, "mongoose.ts")); err != nil {
+				return err
+			}
+		}
+
+		if resources := bulkResources(nsSchemas); g.resolver && len(resources) > 0 {
+			if err := g.generateResolver(g.resolverModels(resources), filepath.Join(nsDir, "resolve.ts")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.diffModels(nsSchemas); g.diff && len(models) > 0 {
+			if err := g.generateDiff(models, filepath.Join(nsDir, "diff.ts")); err != nil {
+				return err
+			}
+		}
+
+		if g.msgpack {
+			models, err := g.msgpackModels(refs.Scope(namespace), samples, nsSchemas)
+			if err != nil {
+				return err
+			}
+			if len(models) > 0 {
+				if err := g.generateMsgpack(models, filepath.Join(nsDir, "msgpack.ts"), filepath.Join(nsDir, "msgpack.test.ts")); err != nil {
+					return err
+				}
+			}
+		}
+
+		if resources := clientResources(nsSchemas, g.client); len(resources) > 0 {
+			if err := g.generateClient(resources, filepath.Join(nsDir, "client.ts")); err != nil {
+				return err
+			}
+		}
+
+		encrypted, err := g.encryptedModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(encrypted) > 0 {
+			if err := g.generateEncryption(encrypted, filepath.Join(nsDir, "encryption.ts")); err != nil {
+				return err
+			}
+		}
+
+		purposes, err := g.purposeModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(purposes) > 0 {
+			if err := g.generatePurpose(purposes, filepath.Join(nsDir, "purpose.ts")); err != nil {
+				return err
+			}
+		}
+
+		validated, err := g.validateModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(validated) > 0 {
+			if err := g.generateValidate(validated, filepath.Join(nsDir, "validate.ts")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.questionnaireModels(nsSchemas); len(models) > 0 {
+			if err := g.generateQuestionnaire(models, filepath.Join(nsDir, "questionnaire.ts")); err != nil {
+				return err
+			}
+		}
+
+		derived, helpers, err := g.derivedModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(derived) > 0 {
+			if err := g.generateDerived(derived, helpers, filepath.Join(nsDir, "derived.ts")); err != nil {
+				return err
+			}
+		}
+
+		if g.narrative {
+			if models := g.narrativeModels(nsSchemas); len(models) > 0 {
+				if err := g.generateNarrative(models, filepath.Join(nsDir, "narrative.ts")); err != nil {
+					return err
+				}
+			}
+		}
+
+		matched, err := g.matchModels(nsSchemas)
+		if err != nil {
+			return err
+		}
+		if len(matched) > 0 {
+			if err := g.generateMatch(matched, filepath.Join(nsDir, "match.ts")); err != nil {
+				return err
+			}
+		}
+
+		ranged, err := g.rangeModels(nsSchemas)
+		if err != nil {
 			return err
 		}
+		if len(ranged) > 0 {
+			if err := g.generateReference(ranged, filepath.Join(nsDir, "reference.ts")); err != nil {
+				return err
+			}
+		}
+
+		if models := g.redactModels(nsSchemas, g.redact); len(models) > 0 {
+			if err := g.generateRedact(models, filepath.Join(nsDir, "redact.ts")); err != nil {
+				return err
+			}
+		}
+
+		if maps := conceptMaps[namespace]; len(maps) > 0 {
+			if err := g.generateConceptMaps(maps, filepath.Join(nsDir, "conceptmaps.ts")); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
-func (g *Generator) generateTypes(schemas []schema.Schema, path string) error {
+// packageJSON is the package.json written by Package.
+type packageJSON struct {
+	Name            string                   `json:"name"`
+	Version         string                   `json:"version"`
+	Description     string                   `json:"description,omitempty"`
+	Type            string                   `json:"type"`
+	Files           []string                 `json:"files"`
+	Exports         map[string]packageExport `json:"exports"`
+	Scripts         map[string]string        `json:"scripts,omitempty"`
+	Dependencies    map[string]string        `json:"dependencies,omitempty"`
+	DevDependencies map[string]string        `json:"devDependencies"`
+}
+
+// packageExport maps a subpath to its declarations and code. Node matches
+// conditions in order, so types must come first.
+type packageExport struct {
+	Types   string `json:"types"`
+	Default string `json:"default,omitempty"`
+}
+
+// tsconfigJSON is the tsconfig.json that compiles the package.
+type tsconfigJSON struct {
+	CompilerOptions map[string]any `json:"compilerOptions"`
+	Include         []string       `json:"include"`
+}
+
+// Package writes a package.json and tsconfig.json that compile the generated
+// modules to dist/ and export each namespace as a subpath, e.g.
+// "my-models/fixtures". The package declares its module type, so that it
+// keeps it in workspaces whose root declares another. Declarations are
+// exported as they are, with a tsconfig.json that only type-checks them.
+func (g *Generator) Package(schemas []schema.Schema, outputDir string) error {
+	if err := g.pkg.Validate(); err != nil {
+		return err
+	}
+	refs, err := resolve.New(schemas)
+	if err != nil {
+		return err
+	}
+
+	pkg := packageJSON{
+		Name:            g.pkg.Name,
+		Version:         g.pkg.Version,
+		Description:     g.pkg.Description,
+		Type:            "commonjs",
+		Files:           []string{"dist"},
+		Exports:         make(map[string]packageExport),
+		Scripts:         map[string]string{"build": "tsc", "prepack": "tsc"},
+		DevDependencies: map[string]string{"typescript": "^5.0.0"},
+	}
+	tsconfig := tsconfigJSON{
+		CompilerOptions: map[string]any{
+			"target":      "ES2020",
+			"module":      "commonjs",
+			"declaration": true,
+			"strict":      true,
+			"rootDir":     ".",
+			"outDir":      "dist",
+		},
+	}
+	if g.esm {
+		pkg.Type = "module"
+		tsconfig.CompilerOptions["module"] = "NodeNext"
+		tsconfig.CompilerOptions["moduleResolution"] = "NodeNext"
+	}
+	index := "index.ts"
+	if g.declarations {
+		index = "index.d.ts"
+		pkg.Files = nil
+		pkg.Scripts = map[string]string{"typecheck": "tsc"}
+		for _, option := range []string{"declaration", "rootDir", "outDir"} {
+			delete(tsconfig.CompilerOptions, option)
+		}
+		tsconfig.CompilerOptions["noEmit"] = true
+	}
+	if g.module != "" {
+		// Runtimes resolve the alias as the package importing itself by
+		// name, and tsc by these paths
+		tsconfig.CompilerOptions["paths"] = map[string][]string{g.module + "/*": {"./*/" + index}}
+	}
+	// Declarations leave out the helpers that need these
+	if g.bulk && !g.declarations {
+		// The bulk loaders read files and fetch URLs with Node.js APIs
+		pkg.DevDependencies["@types/node"] = "^20.0.0"
+	}
+	if g.mongoose && !g.declarations {
+		// The models are built at runtime by Mongoose
+		pkg.Dependencies = map[string]string{"mongoose": "^8.0.0"}
+	}
+	if g.msgpack && !g.declarations {
+		// The MessagePack codecs are tested with node:test
+		pkg.DevDependencies["@types/node"] = "^20.0.0"
+		pkg.Scripts["test"] = "tsc && node --test dist/"
+	}
+	for _, namespace := range refs.Namespaces() {
+		pkgName := g.packageName(namespace)
+		if g.declarations {
+			pkg.Files = append(pkg.Files, pkgName)
+			pkg.Exports["./"+pkgName] = packageExport{Types: "./" + pkgName + "/index.d.ts"}
+			tsconfig.Include = append(tsconfig.Include, pkgName+"/**/*.d.ts")
+			continue
+		}
+		pkg.Exports["./"+pkgName] = packageExport{
+			Types:   "./dist/" + pkgName + "/index.d.ts",
+			Default: "./dist/" + pkgName + "/index.js",
+		}
+		pkg.Exports["./"+pkgName+"/*"] = packageExport{
+			Types:   "./dist/" + pkgName + "/*.d.ts",
+			Default: "./dist/" + pkgName + "/*.js",
+		}
+		tsconfig.Include = append(tsconfig.Include, pkgName+"/**/*.ts")
+	}
+	sort.Strings(pkg.Files)
+	sort.Strings(tsconfig.Include)
+
+	if err := writeJSON(pkg, filepath.Join(outputDir, "package.json")); err != nil {
+		return err
+	}
+	return writeJSON(tsconfig, filepath.Join(outputDir, "tsconfig.json"))
+}
+
+func writeJSON(v any, path string) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+func (g *Generator) generateTypes(scope resolve.Scope, schemas []schema.Schema, path string) error {
+	for _, s := range schemas {
+		if err := s.CheckDefaults(); err != nil {
+			return fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+	}
 	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}{{if .Imports}}
+{{range .Imports}}import type { {{join .Names ", "}} } from "{{namespaceImport .Package}}";
+{{end}}{{end}}{{if .Partial}}
+/**
+ * A date known to year, month, or day precision, e.g. 1970, 1970-03, or 1970-03-14.
+ */
+export type PartialDate = string;
 
-{{range .}}
+/**
+ * Reports whether value is a well-formed PartialDate.
+ */
+{{- if .Declarations}}
+export declare function isPartialDate(value: string): value is PartialDate;
+{{- else}}
+export function isPartialDate(value: string): value is PartialDate {
+  return /^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$/.test(value);
+}
+{{- end}}
+{{end}}
+{{range .Schemas}}
 /**
  * {{.Description}}
+{{- with .Docs}}
+ *
+{{- range .}}
+ * {{.}}
+{{- end}}
+{{- end}}
  */
-export interface {{.Name}} {
-{{range .Fields}}  {{.Name | camel}}{{if not .Required}}?{{end}}: {{.Type | tsType}};{{if .Description}} // {{.Description}}{{end}}
+export interface {{. | typeName}}{{with base .}} extends {{.}}{{end}} {
+{{range fields .}}  {{.Ident}}{{if not .Required}}?{{end}}: {{.Type | tsType}}{{if and $.TriState (not .Required)}} | null{{end}};{{with .Doc}} // {{.}}{{end}}
 {{end}}}
-{{end}}
+{{$type := typeName .}}{{with $defaults := defaults .}}
+/**
+ * Defaults of the fields of {{$type}}, to spread into new records.
+ */
+{{- if $.Declarations}}
+export declare const {{camel $type}}Defaults: Partial<{{$type}}>;
+{{- else}}
+export const {{camel $type}}Defaults = {
+{{- range $defaults}}
+  {{.Ident}}: {{.Value}},
+{{- end}}
+} satisfies Partial<{{$type}}>;
+{{- end}}
+{{end}}{{end}}
 `
 	funcMap := template.FuncMap{
-		"camel":  toCamelCase,
-		"tsType": toTSType,
+		"tsType": func(yamlType string) string {
+			return g.toTSType(scope, yamlType)
+		},
+		"typeName": g.typeName,
+		"base": func(s schema.Schema) string {
+			if base, ok := scope.Lookup(s.Inherits); s.Inherits != "" && ok {
+				return g.typeName(base)
+			}
+			return ""
+		},
+		"fields":   g.fields,
+		"defaults": g.defaults,
+		"camel":    naming.Camel.Apply,
+		"join":     strings.Join,
+		"header":   g.markedHeader(schemas...),
 	}
 
-	tmpl_parsed, err := template.New("").Funcs(funcMap).Parse(tmpl)
+	tmpl_parsed, err := g.parse(tmpl, funcMap)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
 	f, err := os.Create(path)
@@ -71,33 +484,255 @@ export interface {{.Name}} {
 	}
 	defer f.Close()
 
-	return tmpl_parsed.Execute(f, schemas)
+	data := struct {
+		Schemas      []schema.Schema
+		Partial      bool
+		TriState     bool
+		Imports      []tsImport
+		Declarations bool
+	}{
+		Schemas:      schemas,
+		Partial:      g.dateType == "PartialDate",
+		TriState:     g.triState,
+		Imports:      g.imports(scope, schemas),
+		Declarations: g.declarations,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// generateUCUM writes unit validation and conversion helpers for the
+// built-in Quantity type.
+func (g *Generator) generateUCUM(quantity schema.Schema, path string) error {
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{.Type}} } from "{{local "index"}}";
+
+export const UCUM_SYSTEM = "` + datatypes.UCUMSystem + `";
+
+/**
+ * A unit whose value v is v * factor + offset in its dimension's base unit.
+ */
+interface Unit {
+  dimension: string;
+  factor: number;
+  offset: number;
+}
+
+const UNITS: Record<string, Unit> = {
+{{- range .Units}}
+  "{{.Code}}": { dimension: "{{.Dimension}}", factor: {{.Factor | float}}, offset: {{.Offset | float}} },
+{{- end}}
+};
+
+/**
+ * Reports whether code is a UCUM unit these helpers can convert.
+ */
+export function isKnownUnit(code: string): boolean {
+  return Object.prototype.hasOwnProperty.call(UNITS, code);
+}
+
+/**
+ * Converts value between two UCUM units of the same dimension.
+ */
+export function convertValue(value: number, fromCode: string, toCode: string): number {
+  const source = unit(fromCode);
+  const target = unit(toCode);
+  if (source.dimension !== target.dimension) {
+    throw new Error(` + "`cannot convert ${fromCode} (${source.dimension}) to ${toCode} (${target.dimension})`" + `);
+  }
+  return (value * source.factor + source.offset - target.offset) / target.factor;
+}
+
+/**
+ * Returns quantity in toCode units. Its code, or else its unit, must be a
+ * known UCUM unit.
+ */
+export function convert(quantity: {{.Type}}, toCode: string): {{.Type}} {
+  if (quantity.{{.F.value}} == null) {
+    throw new Error("cannot convert a quantity without a value");
+  }
+  const fromCode = quantity.{{.F.code}} ?? quantity.{{.F.unit}} ?? "";
+  const value = convertValue(Number(quantity.{{.F.value}}), fromCode, toCode);
+  return {
+    ...quantity,
+    {{.F.value}}: {{if .Exact}}String(value){{else}}value{{end}},
+    {{.F.unit}}: toCode,
+    {{.F.system}}: UCUM_SYSTEM,
+    {{.F.code}}: toCode,
+  };
+}
+
+function unit(code: string): Unit {
+  if (!isKnownUnit(code)) {
+    throw new Error(` + "`unknown UCUM unit: ${code}`" + `);
+  }
+  return UNITS[code];
+}
+`
+	tmpl_parsed, err := g.parse(tmpl, template.FuncMap{"float": datatypes.FloatLiteral, "header": g.markedHeader(quantity)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	data := struct {
+		Type  string
+		Exact bool
+		F     map[string]string
+		Units []datatypes.Unit
+	}{
+		Type:  g.typeName(quantity),
+		Exact: g.decimalType == "string",
+		F:     naming.ByName(g.fields(quantity)),
+		Units: datatypes.Units,
+	}
+
+	return tmpl_parsed.Execute(f, data)
+}
+
+// NamespaceDir returns the directory, relative to the output directory, that
+// a namespace is generated into.
+func (g *Generator) NamespaceDir(namespace string) string {
+	return g.packageName(namespace)
 }
 
 // GenerateMappings generates TypeScript mapper functions.
-func (g *Generator) GenerateMappings(mappings []schema.SchemaMapping, outputDir string) error {
+func (g *Generator) GenerateMappings(ctx context.Context, mappings []schema.SchemaMapping, outputDir string) error {
 	// TODO: Implement mapping generation
 	return nil
 }
 
-func toCamelCase(s string) string {
-	words := strings.Split(s, "_")
-	for i, w := range words {
-		if i == 0 {
-			words[i] = strings.ToLower(w)
-		} else if len(w) > 0 {
-			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+// tsImport is a type-only import of names from a sibling namespace module.
+type tsImport struct {
+	Package string
+	Names   []string
+}
+
+// imports returns the types the schemas use from other namespaces, grouped by
+// namespace. Type-only imports are erased at runtime, so namespaces may
+// import each other in a cycle.
+func (g *Generator) imports(scope resolve.Scope, schemas []schema.Schema) []tsImport {
+	names := make(map[string]map[string]bool)
+	for _, s := range schemas {
+		for _, dep := range scope.ClassDependencies(s) {
+			if dep.Namespace == scope.Namespace {
+				continue
+			}
+			pkg := g.packageName(dep.Namespace)
+			if names[pkg] == nil {
+				names[pkg] = make(map[string]bool)
+			}
+			names[pkg][g.typeName(dep)] = true
+		}
+	}
+
+	imports := make([]tsImport, 0, len(names))
+	for pkg, set := range names {
+		imp := tsImport{Package: pkg}
+		for name := range set {
+			imp.Names = append(imp.Names, name)
+		}
+		sort.Strings(imp.Names)
+		imports = append(imports, imp)
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Package < imports[j].Package })
+	return imports
+}
+
+func (g *Generator) packageName(namespace string) string {
+	return naming.Case(g.naming.Packages).Apply(namespace)
+}
+
+func (g *Generator) typeName(s schema.Schema) string {
+	return naming.Ident("typescript", naming.Case(g.naming.Types).Apply(s.GetName()))
+}
+
+// fields returns the properties declared in the interface for s, leaving
+// inherited elements to the interface it extends.
+// fieldDefault is a property of an interface and the TypeScript literal of
+// its default.
+type fieldDefault struct {
+	Ident string
+	Value string
+}
+
+// defaults returns the fields of s that have defaults, with their values as
+// literals of the properties' types.
+func (g *Generator) defaults(s schema.Schema) []fieldDefault {
+	var defaults []fieldDefault
+	for _, f := range g.fields(s) {
+		if f.Default == "" {
+			continue
+		}
+		value := strconv.Quote(f.Default)
+		switch f.DefaultKind() {
+		case "integer":
+			value = f.Default
+		case "boolean":
+			b, _ := strconv.ParseBool(f.Default)
+			value = strconv.FormatBool(b)
+		case "decimal":
+			if g.decimalType == "number" {
+				d, _ := strconv.ParseFloat(f.Default, 64)
+				value = strconv.FormatFloat(d, 'g', -1, 64)
+			}
+		}
+		defaults = append(defaults, fieldDefault{Ident: f.Ident, Value: value})
+	}
+	return defaults
+}
+
+func (g *Generator) fields(s schema.Schema) []naming.Field {
+	return naming.Stored(naming.Fields("typescript", wireFields(datatypes.Own(s)), naming.Case(g.naming.Fields).Or(naming.Camel.Apply), g.inherited(s)...))
+}
+
+// wireFields returns fields with their json_names, where set, as their code
+// names: interfaces describe JSON, so their properties are named as its
+// members are.
+func wireFields(fields []schema.Field) []schema.Field {
+	wire := make([]schema.Field, len(fields))
+	for i, f := range fields {
+		if f.JSONName != "" {
+			f.CodeName = f.JSONName
 		}
+		wire[i] = f
 	}
-	return strings.Join(words, "")
+	return wire
 }
 
-func toTSType(yamlType string) string {
+// inherited returns the identifiers of the properties s inherits from the
+// interfaces it extends.
+func (g *Generator) inherited(s schema.Schema) []string {
+	base, ok := datatypes.Base(s.Inherits)
+	if !ok {
+		return nil
+	}
+	idents := g.inherited(base)
+	for _, f := range g.fields(base) {
+		idents = append(idents, f.Ident)
+	}
+	return idents
+}
+
+func (g *Generator) toTSType(scope resolve.Scope, yamlType string) string {
+	if target, ok := scope.Lookup(yamlType); ok {
+		return g.typeName(target)
+	}
 	switch yamlType {
-	case "string", "code", "id", "uri", "url", "date", "datetime", "instant":
+	case "string", "code", "id", "uri", "url", "datetime", "instant":
 		return "string"
-	case "integer", "positiveInt", "unsignedInt", "decimal":
+	case "date":
+		return g.dateType
+	case "integer", "positiveInt", "unsignedInt":
 		return "number"
+	case "decimal":
+		return g.decimalType
 	case "boolean":
 		return "boolean"
 	case "base64Binary":
@@ -105,8 +740,44 @@ func toTSType(yamlType string) string {
 	default:
 		if strings.HasPrefix(yamlType, "[]") {
 			innerType := strings.TrimPrefix(yamlType, "[]")
-			return fmt.Sprintf("%s[]", toTSType(innerType))
+			return fmt.Sprintf("%s[]", g.toTSType(scope, innerType))
 		}
 		return "unknown"
 	}
 }
+
+// parse parses a template with funcs and those naming the modules files
+// import: {{local "name"}} for a module of the same namespace, and
+// {{namespaceImport .Package}} for the index of another namespace. ESM
+// imports name the .js files that relative imports compile to.
+func (g *Generator) parse(text string, funcs template.FuncMap) (*template.Template, error) {
+	ext := ""
+	if g.esm {
+		ext = ".js"
+	}
+	all := template.FuncMap{
+		"local": func(name string) string { return "./" + name + ext },
+		"namespaceImport": func(pkgName string) string {
+			if g.module != "" {
+				return g.module + "/" + pkgName
+			}
+			if g.esm {
+				return "../" + pkgName + "/index.js"
+			}
+			return "../" + pkgName
+		},
+	}
+	for name, fn := range funcs {
+		all[name] = fn
+	}
+	return g.templates.Parse(text, all)
+}
+
+// markedHeader returns the configured file header, if any, to follow the
+// conventional "Code generated" marker line.
+func (g *Generator) markedHeader(sources ...schema.Schema) func() string {
+	if !g.header.Configured() {
+		return func() string { return "" }
+	}
+	return g.header.Func("// ", sources...)
+}
@@ -0,0 +1,111 @@
+package typescript_test
+
+import (
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator/typescript"
+	"github.com/konzy/ehrglot/pkg/generatortest"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	generatortest.Run(t, typescript.NewGenerator(config.Config{}))
+}
+
+func TestGenerateOptionsGolden(t *testing.T) {
+	cfg := config.Config{
+		Decimal:   config.DecimalExact,
+		Temporal:  config.Temporal{Instants: config.InstantsOffset, Dates: config.DatesPartial},
+		UCUM:      true,
+		Narrative: true,
+		Audit:     true,
+	}
+	generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.Schemas(), "testdata/options")
+}
+
+func TestGenerateTriStateGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{TriState: true}), fixtures.Schemas(), "testdata/tristate")
+}
+
+func TestGenerateMsgpackGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{MessagePack: true}), fixtures.Schemas(), "testdata/msgpack")
+}
+
+func TestPackageGolden(t *testing.T) {
+	cfg := config.Config{
+		Package: config.Package{Name: "acme-models", Version: "1.2.0", Description: "Acme \"clinical\" models", Group: "com.acme"},
+	}
+	generatortest.RunPackage(t, typescript.NewGenerator(cfg), "testdata/package")
+}
+
+func TestGenerateModulesGolden(t *testing.T) {
+	pkg := config.Package{Name: "@acme/models", Version: "1.2.0"}
+	cfgs := map[string]config.Config{
+		"esm": {
+			Bulk:      true,
+			Package:   pkg,
+			Languages: map[string]config.Language{"typescript": {ModuleFormat: config.ModuleESM, Module: "@acme/models"}},
+		},
+		"declarations": {
+			Bulk:      true,
+			Temporal:  config.Temporal{Dates: config.DatesPartial},
+			Package:   pkg,
+			Languages: map[string]config.Language{"typescript": {Declarations: true}},
+		},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.Schemas(), "testdata/modules/"+name)
+			generatortest.RunPackage(t, typescript.NewGenerator(cfg), "testdata/modules/"+name+"-package")
+		})
+	}
+}
+
+func TestGenerateBulkGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{Bulk: true, Bundle: true, CloudEvents: true, Resolver: true, Diff: true}), fixtures.Schemas(), "testdata/bulk")
+}
+
+func TestGenerateMongooseGolden(t *testing.T) {
+	cfg := config.Config{Languages: map[string]config.Language{"typescript": {Mongoose: true}}}
+	generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.Schemas(), "testdata/mongoose")
+}
+
+func TestGenerateClientGolden(t *testing.T) {
+	cfg := config.Config{Client: config.Client{Resources: []string{"Specimen", "lab_result", "Practitioner"}}}
+	generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.Schemas(), "testdata/client")
+}
+
+func TestGenerateRedactGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{Redact: "high"}), fixtures.Schemas(), "testdata/redact")
+}
+
+func TestGenerateDICOMGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{}), fixtures.DICOMSchemas(), "testdata/dicom")
+}
+
+func TestGenerateDICOMAuditGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{Audit: true}), fixtures.DICOMSchemas(), "testdata/dicom-audit")
+}
+
+func TestGenerateQuestionnaireGolden(t *testing.T) {
+	generatortest.RunSchemas(t, typescript.NewGenerator(config.Config{}), fixtures.QuestionnaireSchemas(), "testdata/questionnaire")
+}
+
+func TestGenerateConceptMapGolden(t *testing.T) {
+	cfg := config.Config{ConceptMaps: generatortest.ConceptMaps(t)}
+	generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.Schemas(), "testdata/conceptmap")
+}
+
+func TestGenerateMatchGolden(t *testing.T) {
+	cfgs := map[string]config.Config{
+		"plain":     {},
+		"soundex":   {Matching: config.Matching{Phonetic: config.PhoneticSoundex}},
+		"metaphone": {Matching: config.Matching{Phonetic: config.PhoneticMetaphone}, Temporal: config.Temporal{Dates: config.DatesPartial}},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			generatortest.RunSchemas(t, typescript.NewGenerator(cfg), fixtures.MatchSchemas(), "testdata/match/"+name)
+		})
+	}
+}
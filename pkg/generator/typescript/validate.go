@@ -0,0 +1,136 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// validateModel is a schema with fields that a condition on another field
+// makes required, or without which they are left out.
+type validateModel struct {
+	Schema schema.Schema
+	Type   string
+	Func   string
+	Checks []validateCheck
+}
+
+// validateCheck is a TypeScript expression that is true when a
+// conditionally required field is missing, or a conditionally enabled one
+// set although not enabled, and the message reporting it.
+type validateCheck struct {
+	Invalid string
+	Message string
+}
+
+// validateModels returns the schemas of a namespace with required_if or
+// enabled_if fields.
+func (g *Generator) validateModels(schemas []schema.Schema) ([]validateModel, error) {
+	var models []validateModel
+	for _, s := range schemas {
+		conditional, err := s.ConditionalFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		enabled, err := s.EnabledFields()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.GetName(), err)
+		}
+		if len(conditional) == 0 && len(enabled) == 0 {
+			continue
+		}
+		idents := make(map[string]naming.Field)
+		for _, f := range g.fields(s) {
+			idents[f.Name] = f
+		}
+		typeName := g.typeName(s)
+		m := validateModel{Schema: s, Type: typeName, Func: "validate" + naming.Pascal.Apply(typeName)}
+		for _, c := range conditional {
+			field, on := idents[c.Field.Name], idents[c.Condition.Field]
+			missing := access(field.Ident) + " == null"
+			if strings.HasPrefix(field.Type, "[]") {
+				missing = "!" + access(field.Ident) + "?.length"
+			}
+			m.Checks = append(m.Checks, validateCheck{
+				Invalid: condition(on, c.Condition) + " && " + missing,
+				Message: strconv.Quote(c.Field.Name + " is required when " + c.Condition.String()),
+			})
+		}
+		for _, c := range enabled {
+			field, on := idents[c.Field.Name], idents[c.Condition.Field]
+			set := access(field.Ident) + " != null"
+			if strings.HasPrefix(field.Type, "[]") {
+				set = "!!" + access(field.Ident) + "?.length"
+			}
+			m.Checks = append(m.Checks, validateCheck{
+				Invalid: "!(" + condition(on, c.Condition) + ") && " + set,
+				Message: strconv.Quote(c.Field.Name + " must be left out unless " + c.Condition.String()),
+			})
+		}
+		models = append(models, m)
+	}
+	return models, nil
+}
+
+// condition returns a TypeScript expression that is true when c holds for
+// value, whose field on it tests. Lists are set when not empty.
+func condition(on naming.Field, c schema.Condition) string {
+	switch {
+	case c.Op == "" && strings.HasPrefix(on.Type, "[]"):
+		return "!!" + access(on.Ident) + "?.length"
+	case c.Op == "":
+		return access(on.Ident) + " != null"
+	}
+	value := strconv.Quote(c.Value)
+	if kind := on.DefaultKind(); kind == "integer" || kind == "boolean" {
+		value = c.Value
+	}
+	return access(on.Ident) + " " + c.Op + "= " + value
+}
+
+// generateValidate writes functions that report the required_if fields of
+// models that are missing although their condition holds, and the
+// enabled_if fields that are set although theirs does not.
+func (g *Generator) generateValidate(models []validateModel, path string) error {
+	var sources []schema.Schema
+	for _, m := range models {
+		sources = append(sources, m.Schema)
+	}
+
+	tmpl := `// Code generated by ehrglot. DO NOT EDIT.
+{{header}}
+import type { {{range $i, $m := .}}{{if $i}}, {{end}}{{$m.Type}}{{end}} } from "{{local "index"}}";
+{{range .}}
+/**
+ * Returns the fields of value that are missing although a condition on
+ * another field requires them, or set although one leaves them out, or an
+ * empty list.
+ */
+export function {{.Func}}(value: {{.Type}}): string[] {
+  const errors: string[] = [];
+{{- range .Checks}}
+  if ({{.Invalid}}) {
+    errors.push({{.Message}});
+  }
+{{- end}}
+  return errors;
+}
+{{end}}`
+	tmpl_parsed, err := g.parse(tmpl, template.FuncMap{"header": g.markedHeader(sources...)})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return tmpl_parsed.Execute(f, models)
+}
@@ -0,0 +1,232 @@
+// Package generatortest provides a golden-file test harness for generators.
+//
+// A generator test renders a schema set into a temporary directory and
+// compares the full output tree against testdata/golden in the calling
+// package. Run the tests with UPDATE_GOLDEN=1 to rewrite the golden files
+// after an intentional output change.
+package generatortest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/provenance"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// GoldenDir is the golden output directory, relative to the package under test.
+const GoldenDir = "testdata/golden"
+
+// Run generates code for the fixture schemas and compares it with the golden tree.
+func Run(t *testing.T, gen schema.Generator) {
+	t.Helper()
+	RunSchemas(t, gen, fixtures.Schemas(), GoldenDir)
+}
+
+// RunSchemas generates code for schemas and compares it with the tree in goldenDir.
+func RunSchemas(t *testing.T, gen schema.Generator, schemas []schema.Schema, goldenDir string) {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	if err := gen.Generate(context.Background(), schemas, outputDir); err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	compare(t, readTree(t, outputDir), goldenDir)
+}
+
+// RunMappings generates code for mappings and compares it with the tree in
+// goldenDir.
+func RunMappings(t *testing.T, gen schema.Generator, mappings []schema.SchemaMapping, goldenDir string) {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	if err := gen.GenerateMappings(context.Background(), mappings, outputDir); err != nil {
+		t.Fatalf("GenerateMappings() failed: %v", err)
+	}
+
+	compare(t, readTree(t, outputDir), goldenDir)
+}
+
+// RunPackage writes the package manifests for the fixture schemas and
+// compares them with the tree in goldenDir.
+func RunPackage(t *testing.T, packager schema.Packager, goldenDir string) {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	if err := packager.Package(fixtures.Schemas(), outputDir); err != nil {
+		t.Fatalf("Package() failed: %v", err)
+	}
+
+	compare(t, readTree(t, outputDir), goldenDir)
+}
+
+// Compile copies the tree in goldenDir, with files added to it, into a
+// temporary directory and runs the command name with args there, such as a
+// compiler, failing the test with its output when it fails. It skips the
+// test when name is not installed, and under go test -short.
+func Compile(t *testing.T, goldenDir string, files map[string]string, name string, args ...string) {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("compiling golden output is slow")
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		t.Skipf("%s not found", name)
+	}
+	dir := t.TempDir()
+	tree := readTree(t, goldenDir)
+	for rel, data := range files {
+		tree[rel] = []byte(data)
+	}
+	for rel, data := range tree {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("%s in %s failed: %v\n%s", strings.Join(append([]string{name}, args...), " "), goldenDir, err, out)
+	}
+}
+
+// Crosswalk writes the fixture crosswalk to a temporary file and returns its
+// path, for generators configured with a crosswalk.
+func Crosswalk(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "crosswalk.csv")
+	if err := os.WriteFile(path, []byte(fixtures.Crosswalk), 0644); err != nil {
+		t.Fatalf("failed to write crosswalk: %v", err)
+	}
+	return path
+}
+
+// ConceptMaps writes the fixture concept map to a temporary file and returns
+// its path in a list, for generators configured with concept maps.
+func ConceptMaps(t *testing.T) []string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "lab-to-loinc.yaml")
+	if err := os.WriteFile(path, []byte(fixtures.ConceptMap), 0644); err != nil {
+		t.Fatalf("failed to write concept map: %v", err)
+	}
+	return []string{path}
+}
+
+// compare checks got against the tree in goldenDir, or rewrites goldenDir
+// when UPDATE_GOLDEN is set.
+func compare(t *testing.T, got map[string][]byte, goldenDir string) {
+	t.Helper()
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatalf("failed to clear golden dir: %v", err)
+		}
+		for name, data := range got {
+			path := filepath.Join(goldenDir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatalf("failed to create golden dir: %v", err)
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatalf("failed to write golden file: %v", err)
+			}
+		}
+		return
+	}
+
+	want := readTree(t, goldenDir)
+
+	for _, name := range sortedKeys(want) {
+		data, ok := got[name]
+		if !ok {
+			t.Errorf("missing output file %s", name)
+			continue
+		}
+		if !bytes.Equal(data, want[name]) {
+			t.Errorf("%s differs from golden file:\n%s", name, firstDiff(want[name], data))
+		}
+	}
+	for _, name := range sortedKeys(got) {
+		if _, ok := want[name]; !ok {
+			t.Errorf("unexpected output file %s", name)
+		}
+	}
+}
+
+// readTree reads every file under dir, keyed by slash-separated relative path.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = Normalize(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v (run with UPDATE_GOLDEN=1 to create it)", dir, err)
+	}
+	return files
+}
+
+// Normalize replaces volatile content such as generation timestamps.
+func Normalize(data []byte) []byte {
+	return provenance.Normalize(data)
+}
+
+func firstDiff(want, got []byte) string {
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d:\n  want: %s\n  got:  %s", i+1, w, g)
+		}
+	}
+	return ""
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,189 @@
+// Package header renders the provenance header that generators place at the
+// top of every generated file.
+package header
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTemplate is used when the configuration sets no header template.
+const DefaultTemplate = `{{with .Organization}}Copyright (c) {{.}}
+{{end}}{{with .License}}SPDX-License-Identifier: {{.}}
+{{end}}Generated by ehrglot v{{.Version}}{{with .Time}} at {{.}}{{end}}.
+DO NOT EDIT.`
+
+// Data is the data a header template is executed with.
+type Data struct {
+	Organization string
+	License      string
+	// Version is the ehrglot version.
+	Version string
+	// Source is the schema file the generated file was rendered from, relative
+	// to the schema directory (e.g. fhir_r4/patient.yaml), or the directory of
+	// its schema files when there are several. It is empty for
+	// files rendered only from built-in datatypes or from no schema.
+	Source string
+	// Hash identifies the content of the source schemas.
+	Hash string
+	// Time is the generation time in RFC 3339 format. It is empty in files
+	// that must not change between runs, such as database migrations.
+	Time string
+}
+
+// Header renders file headers.
+type Header struct {
+	tmpl       *template.Template
+	cfg        config.Header
+	version    string
+	configured bool
+	stable     bool
+}
+
+// New creates a Header for ehrglot version from the project header settings.
+// It panics if the template is invalid; Validate reports such errors, and
+// generator.Configure applies it to loaded configurations.
+func New(cfg config.Header, version string) *Header {
+	tmpl, err := parse(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return &Header{tmpl: tmpl, cfg: cfg, version: version, configured: cfg != config.Header{}}
+}
+
+// Validate reports whether the header settings hold a usable template.
+func Validate(cfg config.Header) error {
+	tmpl, err := parse(cfg)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(&strings.Builder{}, Data{}); err != nil {
+		return fmt.Errorf("invalid header template: %w", err)
+	}
+	return nil
+}
+
+func parse(cfg config.Header) (*template.Template, error) {
+	text := cfg.Template
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("header").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Configured reports whether the project customizes headers. Generators whose
+// languages mark generated code by convention (such as Go's "Code generated
+// ... DO NOT EDIT." line) keep that marker and add the header only when it is
+// configured.
+func (h *Header) Configured() bool {
+	return h.configured
+}
+
+// Stable returns a copy of h that leaves out the generation time, for files
+// whose checksums must not change when they are generated again.
+func (h *Header) Stable() *Header {
+	stable := *h
+	stable.stable = true
+	return &stable
+}
+
+// Lines renders the header for a file generated from sources.
+func (h *Header) Lines(sources ...schema.Schema) []string {
+	data := Data{
+		Organization: h.cfg.Organization,
+		License:      h.cfg.License,
+		Version:      h.version,
+		Source:       source(sources),
+		Hash:         Hash(sources...),
+	}
+	if !h.stable {
+		data.Time = time.Now().Format(time.RFC3339)
+	}
+	var b strings.Builder
+	if err := h.tmpl.Execute(&b, data); err != nil {
+		panic(fmt.Errorf("invalid header template: %w", err))
+	}
+	return strings.Split(strings.Trim(b.String(), "\n"), "\n")
+}
+
+// Comment renders the header with each line prefixed by prefix, such as
+// "// " or " * ", and followed by a newline.
+func (h *Header) Comment(prefix string, sources ...schema.Schema) string {
+	var b strings.Builder
+	for _, line := range h.Lines(sources...) {
+		b.WriteString(strings.TrimRight(prefix+line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Func returns a template function that renders Comment, for generators to
+// bind as {{header}}. The header stays out of the template text, so its text
+// is never parsed as template syntax and one parsed template serves every
+// file.
+func (h *Header) Func(prefix string, sources ...schema.Schema) func() string {
+	return func() string {
+		return h.Comment(prefix, sources...)
+	}
+}
+
+// Hash returns a short digest of the schemas' content, or "" for none. Only
+// the schema definitions count, not the paths they were loaded from.
+func Hash(schemas ...schema.Schema) string {
+	if len(schemas) == 0 {
+		return ""
+	}
+	data, err := yaml.Marshal(schemas)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func source(schemas []schema.Schema) string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, s := range schemas {
+		if s.SourceFile == "" || datatypes.IsBuiltin(s) || seen[s.SourceFile] {
+			continue
+		}
+		seen[s.SourceFile] = true
+		file := filepath.ToSlash(s.SourceFile)
+		if dir, base := filepath.Split(s.SourceFile); filepath.Base(dir) == s.Namespace {
+			file = s.Namespace + "/" + base
+		}
+		files = append(files, file)
+	}
+	switch len(files) {
+	case 0:
+		return ""
+	case 1:
+		return files[0]
+	}
+
+	dir := path.Dir(files[0])
+	for _, f := range files[1:] {
+		if path.Dir(f) != dir {
+			sort.Strings(files)
+			return strings.Join(files, ", ")
+		}
+	}
+	return dir + "/"
+}
@@ -0,0 +1,86 @@
+package header
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestLines(t *testing.T) {
+	patient := schema.Schema{Name: "Patient", Namespace: "fhir_r4", SourceFile: "/repo/schemas/fhir_r4/patient.yaml"}
+	encounter := schema.Schema{Name: "Encounter", Namespace: "fhir_r4", SourceFile: "/repo/schemas/fhir_r4/encounter.yaml"}
+	h := New(config.Header{
+		Organization: "Acme Health",
+		License:      "Apache-2.0",
+		Template:     "{{.Organization}} ({{.License}})\nDO NOT EDIT — generated by ehrglot {{.Version}} from {{.Source}} at {{.Hash}}",
+	}, "1.2.3")
+
+	got := h.Lines(patient)
+	want := []string{"Acme Health (Apache-2.0)", "DO NOT EDIT — generated by ehrglot 1.2.3 from fhir_r4/patient.yaml at " + Hash(patient)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines(patient) = %q, want %q", got, want)
+	}
+	if got := h.Lines(patient, encounter)[1]; !strings.Contains(got, " from fhir_r4/ at ") {
+		t.Errorf("Lines(patient, encounter) = %q, want the namespace directory as source", got)
+	}
+	if !h.Configured() || New(config.Header{}, "1.2.3").Configured() {
+		t.Error("Configured() should report whether header settings are present")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	got := New(config.Header{License: "MIT"}, "0.1.0").Comment("// ")
+	if !strings.HasPrefix(got, "// SPDX-License-Identifier: MIT\n// Generated by ehrglot v0.1.0 at ") || !strings.HasSuffix(got, ".\n// DO NOT EDIT.\n") {
+		t.Errorf("Comment() = %q", got)
+	}
+}
+
+func TestStable(t *testing.T) {
+	h := New(config.Header{}, "0.1.0")
+	if got := h.Stable().Comment("-- "); got != "-- Generated by ehrglot v0.1.0.\n-- DO NOT EDIT.\n" {
+		t.Errorf("Stable().Comment() = %q", got)
+	}
+	if got := h.Comment("-- "); !strings.HasPrefix(got, "-- Generated by ehrglot v0.1.0 at ") {
+		t.Errorf("Comment() = %q, want the generation time kept", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := schema.Schema{Name: "Patient", SourceFile: "a/patient.yaml", Fields: []schema.Field{{Name: "id", Type: "id"}}}
+	b := a
+	b.SourceFile = "b/patient.yaml"
+	if Hash(a) != Hash(b) {
+		t.Error("Hash() depends on the schema path")
+	}
+	b.Fields = []schema.Field{{Name: "id", Type: "string"}}
+	if Hash(a) == Hash(b) {
+		t.Error("Hash() ignores schema content")
+	}
+}
+
+func TestFunc(t *testing.T) {
+	h := New(config.Header{Organization: `{{"Acme"}} "Health"`}, "0.1.0")
+	tmpl := template.Must(template.New("").Funcs(template.FuncMap{"header": h.Func(" * ")}).Parse("{{header}}{{.}}"))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "rest"); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); !strings.HasPrefix(got, ` * Copyright (c) {{"Acme"}} "Health"`+"\n") || !strings.HasSuffix(got, " * DO NOT EDIT.\nrest") {
+		t.Errorf("Func() rendered %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, tmpl := range []string{"{{.Organization", "{{.Unknown}}"} {
+		if err := Validate(config.Header{Template: tmpl}); err == nil {
+			t.Errorf("Validate(%q) succeeded, want error", tmpl)
+		}
+	}
+	if err := Validate(config.Header{}); err != nil {
+		t.Errorf("Validate() of the default template failed: %v", err)
+	}
+}
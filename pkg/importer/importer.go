@@ -0,0 +1,65 @@
+// Package importer writes source schemas and stub mappings imported from
+// external formats into a schema directory.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// WriteSchemas writes imported schemas as YAML files into dir, noting the
+// source file and the import subcommand, such as openapi, in each.
+func WriteSchemas(schemas []schema.Schema, dir, source, format string) ([]string, error) {
+	var paths []string
+	for _, s := range schemas {
+		path := filepath.Join(dir, naming.Snake.Apply(s.GetName())+".yaml")
+		if err := writeYAML(path, source, format, s); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// WriteMappings writes stub mappings as _mapping.yaml files into dir, like
+// WriteSchemas.
+func WriteMappings(mappings []schema.SchemaMapping, dir, source, format string) ([]string, error) {
+	var paths []string
+	for _, m := range mappings {
+		path := filepath.Join(dir, naming.Snake.Apply(m.SourceTable)+"_mapping.yaml")
+		if err := writeYAML(path, source, format, m); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeYAML(path, source, format string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Imported from %s by ehrglot import %s\n\n", filepath.Base(source), format)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Package ncpdp imports source schemas from NCPDP SCRIPT e-prescribing
+// messages.
+package ncpdp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Element is an element of a SCRIPT message.
+type Element struct {
+	Name     string
+	Text     string
+	Children []*Element
+}
+
+// Load parses a SCRIPT message, a Message element with a Header and a Body
+// that holds one transaction such as NewRx, from an XML file.
+func Load(path string) (*Element, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+	defer f.Close()
+
+	root, err := decode(xml.NewDecoder(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	if root == nil || root.Name != "Message" || root.child("Body") == nil {
+		return nil, fmt.Errorf("%s is not an NCPDP SCRIPT message", path)
+	}
+	return root, nil
+}
+
+// decode reads the document element, dropping namespaces and attributes.
+func decode(d *xml.Decoder) (*Element, error) {
+	var stack []*Element
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &Element{Name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, e)
+			}
+			stack = append(stack, e)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		case xml.EndElement:
+			e := stack[len(stack)-1]
+			e.Text = strings.TrimSpace(e.Text)
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return e, nil
+			}
+		}
+	}
+}
+
+func (e *Element) child(name string) *Element {
+	for _, c := range e.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Importer converts SCRIPT transactions into ehrglot source schemas.
+type Importer struct {
+	namespace string
+}
+
+// NewImporter creates a new SCRIPT importer writing into the given namespace.
+func NewImporter(namespace string) *Importer {
+	return &Importer{namespace: namespace}
+}
+
+// Import converts the transaction of each message into a source schema
+// named after it, such as NewRx. Elements become fields, nested elements
+// children, and elements that repeat lists; messages of the same
+// transaction are merged, so several samples cover optional elements.
+// SCRIPT wraps dates in Date and DateTime elements, so elements that hold
+// just one of those are dates, and other values are strings.
+func (i *Importer) Import(messages ...*Element) []schema.Schema {
+	var schemas []schema.Schema
+	index := make(map[string]int)
+	for _, msg := range messages {
+		body := msg.child("Body")
+		if body == nil {
+			continue
+		}
+		for _, tx := range body.Children {
+			fields := convert(tx.Children)
+			if n, ok := index[tx.Name]; ok {
+				schemas[n].Fields = merge(schemas[n].Fields, fields)
+				continue
+			}
+			index[tx.Name] = len(schemas)
+			schemas = append(schemas, schema.Schema{
+				Name:        tx.Name,
+				Description: fmt.Sprintf("NCPDP SCRIPT %s transaction", tx.Name),
+				Fields:      fields,
+				Namespace:   i.namespace,
+			})
+		}
+	}
+	return schemas
+}
+
+// convert returns the fields of elements, in the order each name first
+// appears. Names that repeat become lists.
+func convert(elements []*Element) []schema.Field {
+	var fields []schema.Field
+	index := make(map[string]int)
+	for _, e := range elements {
+		field := schema.Field{Name: e.Name, Type: leafType(e.Name)}
+		if len(e.Children) == 1 && len(e.Children[0].Children) == 0 && leafType(e.Children[0].Name) != "string" {
+			// Dates such as WrittenDate wrap a single Date or DateTime
+			field.Type = leafType(e.Children[0].Name)
+		} else if len(e.Children) > 0 {
+			field.Type = "BackboneElement"
+			field.Children = convert(e.Children)
+		}
+		if n, ok := index[e.Name]; ok {
+			fields[n] = mergeField(fields[n], field)
+			if !isList(fields[n].Type) {
+				fields[n].Type = "array<" + fields[n].Type + ">"
+			}
+			continue
+		}
+		index[e.Name] = len(fields)
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func leafType(name string) string {
+	switch name {
+	case "Date":
+		return "date"
+	case "DateTime":
+		return "datetime"
+	default:
+		return "string"
+	}
+}
+
+func isList(t string) bool {
+	return strings.HasPrefix(t, "array<")
+}
+
+// merge adds the fields of more that fields lacks, in order, and merges the
+// children of those it has.
+func merge(fields, more []schema.Field) []schema.Field {
+	for _, f := range more {
+		n := -1
+		for j := range fields {
+			if fields[j].Name == f.Name {
+				n = j
+				break
+			}
+		}
+		if n < 0 {
+			fields = append(fields, f)
+			continue
+		}
+		fields[n] = mergeField(fields[n], f)
+	}
+	return fields
+}
+
+// mergeField merges two occurrences of an element: it is a list if either
+// is, and nested if either has children.
+func mergeField(a, b schema.Field) schema.Field {
+	list := isList(a.Type) || isList(b.Type)
+	a.Children = merge(a.Children, b.Children)
+	if len(a.Children) > 0 {
+		a.Type = "BackboneElement"
+	}
+	if list && !isList(a.Type) {
+		a.Type = "array<" + a.Type + ">"
+	}
+	return a
+}
+
+// medicationRequest maps the elements of NewRx and the other prescription
+// transactions to FHIR R4 MedicationRequest.
+var medicationRequest = map[string]string{
+	"Patient.HumanPatient.Identification.MedicalRecordIdentificationNumberEHR": "subject.identifier.value",
+	"Prescriber.NonVeterinarian.Identification.NPI":                            "requester.identifier.value",
+	"MedicationPrescribed.DrugDescription":                                     "medicationCodeableConcept.text",
+	"MedicationPrescribed.DrugCoded.ProductCode.Code":                          "medicationCodeableConcept.coding[0].code",
+	"MedicationPrescribed.Quantity.Value":                                      "dispenseRequest.quantity.value",
+	"MedicationPrescribed.Quantity.QuantityUnitOfMeasure.Code":                 "dispenseRequest.quantity.code",
+	"MedicationPrescribed.DaysSupply":                                          "dispenseRequest.expectedSupplyDuration.value",
+	"MedicationPrescribed.NumberOfRefills":                                     "dispenseRequest.numberOfRepeatsAllowed",
+	"MedicationPrescribed.WrittenDate":                                         "authoredOn",
+	"MedicationPrescribed.Substitutions":                                       "substitution.allowedCodeableConcept.coding[0].code",
+	"MedicationPrescribed.Sig.SigText":                                         "dosageInstruction[0].text",
+	"MedicationPrescribed.Diagnosis.Primary.Code":                              "reasonCode[0].coding[0].code",
+	"MedicationPrescribed.Note":                                                "note[0].text",
+}
+
+// prescriptions are the transactions that carry a prescription.
+var prescriptions = map[string]bool{"NewRx": true, "RxChangeResponse": true, "RxRenewalResponse": true, "CancelRx": true}
+
+// StubMappings drafts mappings from the imported prescription transactions
+// to target, or to MedicationRequest when target is empty. Each value of a
+// transaction is mapped by its dotted element path, such as
+// MedicationPrescribed.DrugDescription, to the MedicationRequest element the
+// SCRIPT implementation guide pairs it with; values without a counterpart
+// keep an empty target so map authors can fill them in.
+func (i *Importer) StubMappings(sources []schema.Schema, target string) []schema.SchemaMapping {
+	known := medicationRequest
+	if target == "" {
+		target = "MedicationRequest"
+	} else if target != "MedicationRequest" {
+		known = nil
+	}
+
+	var mappings []schema.SchemaMapping
+	for _, src := range sources {
+		if !prescriptions[src.GetName()] {
+			continue
+		}
+		mapping := schema.SchemaMapping{
+			SourceSystem:   i.namespace,
+			SourceTable:    src.GetName(),
+			TargetResource: target,
+			Description:    fmt.Sprintf("Draft mapping from NCPDP SCRIPT %s to FHIR R4 %s.", src.GetName(), target),
+		}
+		for _, path := range leaves(src.Fields, "") {
+			mapping.FieldMappings = append(mapping.FieldMappings, schema.FieldMapping{
+				Source: path,
+				Target: known[path],
+			})
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+// leaves returns the dotted paths of the fields that hold values.
+func leaves(fields []schema.Field, prefix string) []string {
+	var paths []string
+	for _, f := range fields {
+		if len(f.Children) > 0 {
+			paths = append(paths, leaves(f.Children, prefix+f.Name+".")...)
+			continue
+		}
+		paths = append(paths, prefix+f.Name)
+	}
+	return paths
+}
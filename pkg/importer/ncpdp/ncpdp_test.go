@@ -0,0 +1,116 @@
+package ncpdp
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+const newRx = `<?xml version="1.0" encoding="UTF-8"?>
+<Message xmlns="http://www.ncpdp.org/schema/SCRIPT">
+  <Header><MessageID>1</MessageID></Header>
+  <Body>
+    <NewRx>
+      <Patient>
+        <HumanPatient>
+          <Identification><MedicalRecordIdentificationNumberEHR>MRN1</MedicalRecordIdentificationNumberEHR></Identification>
+          <DateOfBirth><Date>1980-05-14</Date></DateOfBirth>
+        </HumanPatient>
+      </Patient>
+      <MedicationPrescribed>
+        <DrugDescription>Amoxicillin 500 MG Oral Capsule</DrugDescription>
+        <Note>Take with food</Note>
+        <Note>Finish the course</Note>
+      </MedicationPrescribed>
+    </NewRx>
+  </Body>
+</Message>
+`
+
+const newRxSig = `<Message>
+  <Body>
+    <NewRx>
+      <MedicationPrescribed>
+        <Sig><SigText>Take 1 capsule 3 times daily</SigText></Sig>
+      </MedicationPrescribed>
+    </NewRx>
+  </Body>
+</Message>
+`
+
+func load(t *testing.T, name, data string) *Element {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	return msg
+}
+
+func TestImport(t *testing.T) {
+	schemas := NewImporter("ncpdp_script").Import(load(t, "newrx.xml", newRx), load(t, "sig.xml", newRxSig))
+	if len(schemas) != 1 || schemas[0].Name != "NewRx" {
+		t.Fatalf("Import() = %v, want one NewRx schema", schemas)
+	}
+
+	got := schemas[0].Fields
+	want := []schema.Field{
+		{Name: "Patient", Type: "BackboneElement", Children: []schema.Field{
+			{Name: "HumanPatient", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "Identification", Type: "BackboneElement", Children: []schema.Field{
+					{Name: "MedicalRecordIdentificationNumberEHR", Type: "string"},
+				}},
+				{Name: "DateOfBirth", Type: "date"},
+			}},
+		}},
+		{Name: "MedicationPrescribed", Type: "BackboneElement", Children: []schema.Field{
+			{Name: "DrugDescription", Type: "string"},
+			{Name: "Note", Type: "array<string>"},
+			{Name: "Sig", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "SigText", Type: "string"},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Import() fields = %+v, want %+v", got, want)
+	}
+}
+
+func TestStubMappings(t *testing.T) {
+	imp := NewImporter("ncpdp_script")
+	mappings := imp.StubMappings(imp.Import(load(t, "newrx.xml", newRx)), "")
+	if len(mappings) != 1 || mappings[0].TargetResource != "MedicationRequest" {
+		t.Fatalf("StubMappings() = %v, want one MedicationRequest mapping", mappings)
+	}
+
+	got := make(map[string]string)
+	for _, m := range mappings[0].FieldMappings {
+		got[m.Source] = m.Target
+	}
+	want := map[string]string{
+		"Patient.HumanPatient.Identification.MedicalRecordIdentificationNumberEHR": "subject.identifier.value",
+		"Patient.HumanPatient.DateOfBirth":                                         "",
+		"MedicationPrescribed.DrugDescription":                                     "medicationCodeableConcept.text",
+		"MedicationPrescribed.Note":                                                "note[0].text",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StubMappings() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadRejectsOtherXML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "other.xml")
+	if err := os.WriteFile(path, []byte("<ClinicalDocument/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() accepted a document that is not a SCRIPT message")
+	}
+}
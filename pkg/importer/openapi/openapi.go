@@ -0,0 +1,289 @@
+// Package openapi imports source schemas from OpenAPI specifications.
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/importer"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the subset of an OpenAPI 3 (or Swagger 2) document used for import.
+type Spec struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+	} `yaml:"info"`
+	Components struct {
+		Schemas map[string]*Definition `yaml:"schemas"`
+	} `yaml:"components"`
+	Definitions map[string]*Definition `yaml:"definitions"` // Swagger 2
+}
+
+// Definition is an OpenAPI schema object.
+type Definition struct {
+	Ref         string                 `yaml:"$ref"`
+	Type        string                 `yaml:"type"`
+	Format      string                 `yaml:"format"`
+	Description string                 `yaml:"description"`
+	Properties  map[string]*Definition `yaml:"properties"`
+	Required    []string               `yaml:"required"`
+	Items       *Definition            `yaml:"items"`
+	Enum        []any                  `yaml:"enum"`
+	AllOf       []*Definition          `yaml:"allOf"`
+	Example     any                    `yaml:"example"`
+	Examples    []any                  `yaml:"examples"` // OpenAPI 3.1
+}
+
+// Importer converts OpenAPI component schemas into ehrglot source schemas.
+type Importer struct {
+	namespace string
+}
+
+// NewImporter creates a new OpenAPI importer writing into the given namespace.
+func NewImporter(namespace string) *Importer {
+	return &Importer{namespace: namespace}
+}
+
+// Load parses an OpenAPI document from a YAML or JSON file.
+func Load(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	// JSON is a subset of YAML, so a single decoder handles both formats
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if spec.OpenAPI == "" && spec.Swagger == "" {
+		return nil, fmt.Errorf("%s is not an OpenAPI document", path)
+	}
+	return &spec, nil
+}
+
+// Import converts every component schema in the spec to a source schema.
+func (i *Importer) Import(spec *Spec) []schema.Schema {
+	defs := spec.Components.Schemas
+	if len(defs) == 0 {
+		defs = spec.Definitions
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var schemas []schema.Schema
+	for _, name := range names {
+		def := defs[name]
+		if def == nil {
+			continue
+		}
+		schemas = append(schemas, schema.Schema{
+			Name:        name,
+			Description: strings.TrimSpace(def.Description),
+			Fields:      i.convertProperties(def, defs),
+			Namespace:   i.namespace,
+		})
+	}
+	return schemas
+}
+
+func (i *Importer) convertProperties(def *Definition, defs map[string]*Definition) []schema.Field {
+	props, required := collectProperties(def, defs)
+
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []schema.Field
+	for _, name := range names {
+		prop := props[name]
+		field := schema.Field{
+			Name:        name,
+			Type:        i.convertType(prop),
+			Required:    required[name],
+			Description: strings.TrimSpace(prop.Description),
+		}
+		for _, v := range prop.Enum {
+			field.Enum = append(field.Enum, fmt.Sprint(v))
+		}
+		// Only scalar examples fit a field; object and array examples are
+		// left in the spec.
+		for _, v := range append([]any{prop.Example}, prop.Examples...) {
+			switch v.(type) {
+			case string, int, float64, bool:
+				if field.Example == "" {
+					field.Example = fmt.Sprint(v)
+				} else {
+					field.Examples = append(field.Examples, fmt.Sprint(v))
+				}
+			}
+		}
+		if obj := inlineObject(prop); obj != nil {
+			field.Children = i.convertProperties(obj, defs)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// inlineObject returns the object that def declares in place: def itself when
+// it has properties, with or without type: object, or the items of an array
+// of such objects. References and scalars declare none.
+func inlineObject(def *Definition) *Definition {
+	if def.Type == "array" && def.Items != nil {
+		return inlineObject(def.Items)
+	}
+	if def.Ref == "" && (def.Type == "" || def.Type == "object") && len(def.Properties) > 0 {
+		return def
+	}
+	return nil
+}
+
+// collectProperties flattens allOf compositions into a single property set.
+func collectProperties(def *Definition, defs map[string]*Definition) (map[string]*Definition, map[string]bool) {
+	props := make(map[string]*Definition)
+	required := make(map[string]bool)
+
+	var visit func(d *Definition, depth int)
+	visit = func(d *Definition, depth int) {
+		if d == nil || depth > 16 {
+			return
+		}
+		if d.Ref != "" {
+			visit(defs[refName(d.Ref)], depth+1)
+			return
+		}
+		for _, part := range d.AllOf {
+			visit(part, depth+1)
+		}
+		for name, prop := range d.Properties {
+			props[name] = prop
+		}
+		for _, name := range d.Required {
+			required[name] = true
+		}
+	}
+	visit(def, 0)
+
+	return props, required
+}
+
+func (i *Importer) convertType(def *Definition) string {
+	if def.Ref != "" {
+		return refName(def.Ref)
+	}
+	if len(def.AllOf) == 1 && def.AllOf[0].Ref != "" {
+		return refName(def.AllOf[0].Ref)
+	}
+
+	switch def.Type {
+	case "string":
+		switch def.Format {
+		case "date":
+			return "date"
+		case "date-time":
+			return "datetime"
+		case "byte", "binary":
+			return "base64Binary"
+		case "uri":
+			return "uri"
+		case "url":
+			return "url"
+		}
+		if len(def.Enum) > 0 {
+			return "code"
+		}
+		return "string"
+	case "integer":
+		return "integer"
+	case "number":
+		return "decimal"
+	case "boolean":
+		return "boolean"
+	case "array":
+		if def.Items == nil {
+			return "array<string>"
+		}
+		return fmt.Sprintf("array<%s>", i.convertType(def.Items))
+	default:
+		return "BackboneElement"
+	}
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// StubMappings drafts source-to-FHIR mappings for imported schemas.
+//
+// Each source schema is paired with the FHIR resource whose name it matches
+// (or target, when set), and each property is mapped to the target field
+// with the same normalized name. Properties without a match keep an empty
+// target so map authors can fill them in.
+func (i *Importer) StubMappings(sources, fhir []schema.Schema, target string) []schema.SchemaMapping {
+	byName := make(map[string]schema.Schema)
+	for _, s := range fhir {
+		byName[normalize(s.GetName())] = s
+	}
+
+	var mappings []schema.SchemaMapping
+	for _, src := range sources {
+		key := normalize(src.GetName())
+		if target != "" {
+			key = normalize(target)
+		}
+		dst, ok := byName[key]
+		if !ok {
+			continue
+		}
+
+		targetFields := make(map[string]string)
+		for _, f := range dst.Fields {
+			targetFields[normalize(f.Name)] = f.Name
+		}
+
+		mapping := schema.SchemaMapping{
+			SourceSystem:   i.namespace,
+			SourceTable:    src.GetName(),
+			TargetResource: dst.GetName(),
+			Description:    fmt.Sprintf("Draft mapping from %s %s to FHIR R4 %s.", i.namespace, src.GetName(), dst.GetName()),
+		}
+		for _, f := range src.Fields {
+			mapping.FieldMappings = append(mapping.FieldMappings, schema.FieldMapping{
+				Source: f.Name,
+				Target: targetFields[normalize(f.Name)],
+			})
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "_", "")
+	return strings.ReplaceAll(s, "-", "")
+}
+
+// WriteSchemas writes imported schemas as YAML files into dir.
+func WriteSchemas(schemas []schema.Schema, dir, source string) ([]string, error) {
+	return importer.WriteSchemas(schemas, dir, source, "openapi")
+}
+
+// WriteMappings writes stub mappings as _mapping.yaml files into dir.
+func WriteMappings(mappings []schema.SchemaMapping, dir, source string) ([]string, error) {
+	return importer.WriteMappings(mappings, dir, source, "openapi")
+}
@@ -0,0 +1,221 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+const labSpec = `openapi: 3.1.0
+info:
+  title: Lab API
+components:
+  schemas:
+    Base:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          description: "  Server-assigned id  "
+    LabResult:
+      description: A lab result
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [status]
+          properties:
+            status:
+              type: string
+              enum: [final, amended]
+            value:
+              type: number
+              example: 4.2
+              examples: [5.1, 6]
+            collected:
+              type: string
+              format: date-time
+            payload:
+              type: string
+              format: byte
+            tags:
+              type: array
+              items:
+                type: string
+            flags:
+              type: array
+            performer:
+              $ref: '#/components/schemas/Practitioner'
+            specimen:
+              allOf:
+                - $ref: '#/components/schemas/Specimen'
+            range:
+              type: object
+              properties:
+                low:
+                  type: integer
+                high:
+                  type: integer
+            raw:
+              type: object
+              example: {a: 1}
+            interval:
+              properties:
+                start:
+                  type: string
+                  format: date-time
+            components:
+              type: array
+              items:
+                type: object
+                required: [code]
+                properties:
+                  code:
+                    type: string
+                  value:
+                    type: number
+    Practitioner:
+      type: object
+      properties:
+        npi:
+          type: string
+    Specimen: ~
+`
+
+const patientSwagger = `{
+  "swagger": "2.0",
+  "definitions": {
+    "Patient": {
+      "type": "object",
+      "properties": {
+        "birth_date": {"type": "string", "format": "date"},
+        "active": {"type": "boolean"},
+        "home-page": {"type": "string", "format": "uri"}
+      }
+    }
+  }
+}
+`
+
+func load(t *testing.T, name, data string) *Spec {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	return spec
+}
+
+func TestImport(t *testing.T) {
+	schemas := NewImporter("lab").Import(load(t, "lab.yaml", labSpec))
+
+	var names []string
+	for _, s := range schemas {
+		if s.Namespace != "lab" {
+			t.Errorf("%s namespace = %q, want lab", s.Name, s.Namespace)
+		}
+		names = append(names, s.Name)
+	}
+	if want := []string{"Base", "LabResult", "Practitioner"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Import() schemas = %q, want %q", names, want)
+	}
+
+	result := schemas[1]
+	if result.Description != "A lab result" {
+		t.Errorf("LabResult description = %q, want %q", result.Description, "A lab result")
+	}
+	want := []schema.Field{
+		{Name: "collected", Type: "datetime"},
+		{Name: "components", Type: "array<BackboneElement>", Children: []schema.Field{
+			{Name: "code", Type: "string", Required: true},
+			{Name: "value", Type: "decimal"},
+		}},
+		{Name: "flags", Type: "array<string>"},
+		{Name: "id", Type: "string", Required: true, Description: "Server-assigned id"},
+		{Name: "interval", Type: "BackboneElement", Children: []schema.Field{
+			{Name: "start", Type: "datetime"},
+		}},
+		{Name: "payload", Type: "base64Binary"},
+		{Name: "performer", Type: "Practitioner"},
+		{Name: "range", Type: "BackboneElement", Children: []schema.Field{
+			{Name: "high", Type: "integer"},
+			{Name: "low", Type: "integer"},
+		}},
+		{Name: "raw", Type: "BackboneElement"},
+		{Name: "specimen", Type: "Specimen"},
+		{Name: "status", Type: "code", Required: true, Enum: []string{"final", "amended"}},
+		{Name: "tags", Type: "array<string>"},
+		{Name: "value", Type: "decimal", Example: "4.2", Examples: []string{"5.1", "6"}},
+	}
+	if !reflect.DeepEqual(result.Fields, want) {
+		t.Errorf("Import() fields =\n%+v\nwant\n%+v", result.Fields, want)
+	}
+}
+
+func TestImportSwagger(t *testing.T) {
+	schemas := NewImporter("ehr").Import(load(t, "patient.json", patientSwagger))
+	if len(schemas) != 1 || schemas[0].Name != "Patient" {
+		t.Fatalf("Import() = %v, want one Patient schema", schemas)
+	}
+	want := []schema.Field{
+		{Name: "active", Type: "boolean"},
+		{Name: "birth_date", Type: "date"},
+		{Name: "home-page", Type: "uri"},
+	}
+	if !reflect.DeepEqual(schemas[0].Fields, want) {
+		t.Errorf("Import() fields =\n%+v\nwant\n%+v", schemas[0].Fields, want)
+	}
+}
+
+func TestLoadRejectsOtherDocuments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("components:\n  schemas: {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of a document without an openapi or swagger version succeeded, want an error")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() of a missing file succeeded, want an error")
+	}
+}
+
+func TestStubMappings(t *testing.T) {
+	sources := NewImporter("ehr").Import(load(t, "patient.json", patientSwagger))
+	fhir := []schema.Schema{
+		{Name: "Patient", Fields: []schema.Field{{Name: "birthDate"}, {Name: "active"}, {Name: "gender"}}},
+		{Name: "Person", Fields: []schema.Field{{Name: "birthDate"}}},
+	}
+
+	got := NewImporter("ehr").StubMappings(sources, fhir, "")
+	want := []schema.SchemaMapping{{
+		SourceSystem:   "ehr",
+		SourceTable:    "Patient",
+		TargetResource: "Patient",
+		Description:    "Draft mapping from ehr Patient to FHIR R4 Patient.",
+		FieldMappings: []schema.FieldMapping{
+			{Source: "active", Target: "active"},
+			{Source: "birth_date", Target: "birthDate"},
+			{Source: "home-page"},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StubMappings() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	got = NewImporter("ehr").StubMappings(sources, fhir, "person")
+	if len(got) != 1 || got[0].TargetResource != "Person" || got[0].FieldMappings[1].Target != "birthDate" {
+		t.Errorf("StubMappings() with target = %+v, want a Person mapping", got)
+	}
+
+	if got := NewImporter("ehr").StubMappings(sources, fhir, "Observation"); got != nil {
+		t.Errorf("StubMappings() with an unknown target = %+v, want none", got)
+	}
+}
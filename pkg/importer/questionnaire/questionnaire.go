@@ -0,0 +1,300 @@
+// Package questionnaire imports the response models of FHIR Questionnaires,
+// such as patient intake forms, as source schemas.
+package questionnaire
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Questionnaire is a FHIR R4 Questionnaire, as far as its response model
+// needs it.
+type Questionnaire struct {
+	ResourceType string `json:"resourceType"`
+	URL          string `json:"url"`
+	Name         string `json:"name"`
+	Title        string `json:"title"`
+	Item         []Item `json:"item"`
+}
+
+// Item is a question, group, or display text of a Questionnaire.
+type Item struct {
+	LinkID       string         `json:"linkId"`
+	Text         string         `json:"text"`
+	Type         string         `json:"type"`
+	Required     bool           `json:"required"`
+	Repeats      bool           `json:"repeats"`
+	EnableWhen   []EnableWhen   `json:"enableWhen"`
+	AnswerOption []AnswerOption `json:"answerOption"`
+	Item         []Item         `json:"item"`
+}
+
+// EnableWhen is a condition on the answer of another item under which an
+// item is enabled.
+type EnableWhen struct {
+	Question      string  `json:"question"`
+	Operator      string  `json:"operator"`
+	AnswerBoolean *bool   `json:"answerBoolean"`
+	AnswerInteger *int64  `json:"answerInteger"`
+	AnswerString  *string `json:"answerString"`
+	AnswerCoding  *Coding `json:"answerCoding"`
+}
+
+// AnswerOption is a permitted answer of a choice item.
+type AnswerOption struct {
+	ValueCoding *Coding `json:"valueCoding"`
+	ValueString *string `json:"valueString"`
+}
+
+// Coding is a code of a terminology system.
+type Coding struct {
+	System string `json:"system"`
+	Code   string `json:"code"`
+}
+
+// Load reads a Questionnaire from a JSON file.
+func Load(path string) (*Questionnaire, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read questionnaire: %w", err)
+	}
+	var q Questionnaire
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse questionnaire: %w", err)
+	}
+	if q.ResourceType != "Questionnaire" {
+		return nil, fmt.Errorf("%s is not a FHIR Questionnaire", path)
+	}
+	return &q, nil
+}
+
+// Importer converts Questionnaires into ehrglot source schemas.
+type Importer struct {
+	namespace string
+}
+
+// NewImporter creates a new Questionnaire importer writing into the given
+// namespace.
+func NewImporter(namespace string) *Importer {
+	return &Importer{namespace: namespace}
+}
+
+// itemTypes are the field types of the answers of item types. Choices are
+// codes, with their options as enums.
+var itemTypes = map[string]string{
+	"boolean":     "boolean",
+	"decimal":     "decimal",
+	"integer":     "integer",
+	"date":        "date",
+	"dateTime":    "datetime",
+	"time":        "time",
+	"string":      "string",
+	"text":        "string",
+	"url":         "url",
+	"choice":      "code",
+	"open-choice": "string",
+	"reference":   "Reference",
+	"quantity":    "Quantity",
+}
+
+// linkIDName matches linkIds that fields can be named after.
+var linkIDName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.\-]*$`)
+
+// Import converts q into the schema of its responses, with a field per
+// question that carries its linkId. Groups that do not repeat are
+// flattened, as are questions nested under questions, so their questions
+// are top-level fields, and repeating groups are lists of nested fields.
+// Fields are named after linkIds, as item_1_2 for 1.2, and keep them as
+// they are renamed. An enableWhen on another
+// top-level question becomes enabled_if, and also required_if for a
+// required item; Import returns notes on the items whose enableWhen cannot
+// be written as a condition, which are left optional, and on the items that
+// get no field.
+func (i *Importer) Import(q *Questionnaire) (schema.Schema, []string) {
+	title := q.Title
+	if title == "" {
+		title = q.Name
+	}
+	name := naming.Pascal.Apply(q.Name)
+	if name == "" {
+		name = naming.Pascal.Apply(title)
+	}
+	if name == "" {
+		name = "Questionnaire"
+	}
+	if title == "" {
+		title = "a questionnaire"
+	}
+	description := "Responses to " + title
+	if q.URL != "" {
+		description += " (" + q.URL + ")"
+	}
+	s := schema.Schema{Name: name + "Response", Description: description, Namespace: i.namespace}
+
+	c := converter{names: make(map[string]bool), top: make(map[string]schema.Field)}
+	s.Fields = c.items(q.Item, true)
+	for n, f := range s.Fields {
+		item, ok := c.pending[f.LinkID]
+		if !ok {
+			continue
+		}
+		condition, note := c.condition(item)
+		if note != "" {
+			c.notes = append(c.notes, fmt.Sprintf("item %s: %s; the field is left optional", item.LinkID, note))
+			s.Fields[n].Required = false
+			continue
+		}
+		s.Fields[n].EnabledIf = condition
+		if f.Required {
+			s.Fields[n].Required = false
+			s.Fields[n].RequiredIf = condition
+		}
+	}
+	return s, c.notes
+}
+
+// converter converts the items of a Questionnaire into fields.
+type converter struct {
+	names map[string]bool
+	// top holds the top-level fields by linkId, which conditions can test.
+	top map[string]schema.Field
+	// pending holds the top-level items with an enableWhen by linkId.
+	pending map[string]Item
+	notes   []string
+}
+
+// items returns the fields of items, top-level when top is set.
+func (c *converter) items(items []Item, top bool) []schema.Field {
+	var fields []schema.Field
+	for _, item := range items {
+		switch {
+		case item.Type == "display":
+			continue
+		case item.Type == "group" && !item.Repeats && top:
+			if len(item.EnableWhen) > 0 {
+				c.notes = append(c.notes, fmt.Sprintf("item %s: enableWhen of a group is left out", item.LinkID))
+			}
+			fields = append(fields, c.items(item.Item, true)...)
+			continue
+		}
+
+		f := schema.Field{Name: c.name(item.LinkID), Required: item.Required, LinkID: item.LinkID, Description: item.Text}
+		switch t, ok := itemTypes[item.Type]; {
+		case item.Type == "group":
+			f.Type = "BackboneElement"
+			f.Children = c.items(item.Item, false)
+		case !ok:
+			c.notes = append(c.notes, fmt.Sprintf("item %s: %s answers have no field", item.LinkID, item.Type))
+			continue
+		default:
+			f.Type = t
+			if item.Type == "choice" {
+				for _, option := range item.AnswerOption {
+					switch {
+					case option.ValueCoding != nil:
+						f.Enum = append(f.Enum, option.ValueCoding.Code)
+					case option.ValueString != nil:
+						f.Enum = append(f.Enum, *option.ValueString)
+					}
+				}
+			}
+		}
+		if item.Repeats {
+			f.Type = "[]" + f.Type
+		}
+		if len(item.EnableWhen) > 0 {
+			if !top {
+				c.notes = append(c.notes, fmt.Sprintf("item %s: enableWhen within a repeating group is left out", item.LinkID))
+			} else {
+				if c.pending == nil {
+					c.pending = make(map[string]Item)
+				}
+				c.pending[item.LinkID] = item
+			}
+		}
+		if top {
+			c.top[item.LinkID] = f
+		}
+		fields = append(fields, f)
+		if item.Type != "group" {
+			// Questions nested under a question follow it
+			fields = append(fields, c.items(item.Item, top)...)
+		}
+	}
+	return fields
+}
+
+// name returns a field name for linkID that no other field has.
+func (c *converter) name(linkID string) string {
+	name := "item_" + linkID
+	if linkIDName.MatchString(linkID) {
+		name = linkID
+	}
+	name = naming.Snake.Apply(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+	unique := name
+	for n := 2; c.names[unique]; n++ {
+		unique = name + "_" + strconv.Itoa(n)
+	}
+	c.names[unique] = true
+	return unique
+}
+
+// condition returns the enableWhen of item as a condition on another
+// top-level field, or why it cannot be one. Conditions test a question
+// for being answered, or compare its single text, code, integer, or
+// boolean answer with a value.
+func (c *converter) condition(item Item) (string, string) {
+	if len(item.EnableWhen) > 1 {
+		return "", fmt.Sprintf("enableWhen with %d conditions cannot be written as one", len(item.EnableWhen))
+	}
+	when := item.EnableWhen[0]
+	on, ok := c.top[when.Question]
+	if !ok {
+		return "", fmt.Sprintf("enableWhen tests %s, which is not a top-level question", when.Question)
+	}
+	if when.Operator == "exists" {
+		if when.AnswerBoolean != nil && *when.AnswerBoolean {
+			return on.Name, ""
+		}
+		return "", fmt.Sprintf("enableWhen on %s not being answered cannot be written as a condition", when.Question)
+	}
+	if when.Operator != "=" && when.Operator != "!=" {
+		return "", fmt.Sprintf("enableWhen operator %s cannot be written as a condition", when.Operator)
+	}
+	switch on.Type {
+	case "boolean", "integer", "code", "string":
+	default:
+		return "", fmt.Sprintf("enableWhen compares %s, whose answers of type %s can only be tested for being given", when.Question, on.Type)
+	}
+	var value string
+	switch {
+	case when.AnswerBoolean != nil:
+		value = strconv.FormatBool(*when.AnswerBoolean)
+	case when.AnswerInteger != nil:
+		value = strconv.FormatInt(*when.AnswerInteger, 10)
+	case when.AnswerString != nil:
+		value = *when.AnswerString
+	case when.AnswerCoding != nil:
+		value = when.AnswerCoding.Code
+	default:
+		return "", fmt.Sprintf("enableWhen compares %s with an answer of another type", when.Question)
+	}
+	op := "=="
+	if when.Operator == "!=" {
+		op = "!="
+	}
+	condition := on.Name + " " + op + " " + value
+	// The condition is checked as the loader would check it
+	probe := schema.Schema{Fields: []schema.Field{on, {Name: "enabled", Type: "string", EnabledIf: condition}}}
+	if _, err := probe.EnabledFields(); err != nil {
+		return "", "enableWhen " + strings.TrimPrefix(err.Error(), "field enabled: enabled_if ")
+	}
+	return condition, ""
+}
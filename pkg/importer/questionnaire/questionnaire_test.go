@@ -0,0 +1,59 @@
+package questionnaire
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestImport(t *testing.T) {
+	q, err := Load("testdata/intake.json")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	s, notes := NewImporter("forms").Import(q)
+	if s.Name != "PatientIntakeResponse" || s.Description != "Responses to Patient intake (http://clinic.example/Questionnaire/intake)" {
+		t.Errorf("Import() = %s: %s, want PatientIntakeResponse", s.Name, s.Description)
+	}
+
+	want := []schema.Field{
+		{Name: "item_1", Type: "string", Required: true, LinkID: "1", Description: "What brings you in today?"},
+		{Name: "smoking", Type: "boolean", LinkID: "smoking", Description: "Do you smoke?"},
+		{Name: "smoking_packs", Type: "decimal", RequiredIf: "smoking == true", EnabledIf: "smoking == true", LinkID: "smoking.packs", Description: "Packs a day"},
+		{Name: "allergies", Type: "[]code", Enum: []string{"penicillin", "latex"}, LinkID: "allergies", Description: "Allergies"},
+		{Name: "pregnancy", Type: "code", Enum: []string{"yes", "no"}, LinkID: "pregnancy", Description: "Are you pregnant?"},
+		{Name: "pregnancy_due", Type: "date", EnabledIf: "pregnancy == yes", LinkID: "pregnancy.due", Description: "Expected due date"},
+		{Name: "item_1_2", Type: "integer", LinkID: "1.2", Description: "Pain, from 0 to 10"},
+		{Name: "item_3", Type: "date", LinkID: "3", Description: "Last visit"},
+		{Name: "medications", Type: "[]BackboneElement", LinkID: "medications", Description: "Medications", Children: []schema.Field{
+			{Name: "medications_name", Type: "string", Required: true, LinkID: "medications.name", Description: "Medication"},
+			{Name: "medications_since", Type: "date", LinkID: "medications.since", Description: "Since"},
+		}},
+	}
+	if !reflect.DeepEqual(s.Fields, want) {
+		t.Errorf("Import() fields =\n%+v\nwant\n%+v", s.Fields, want)
+	}
+	if _, err := s.EnabledFields(); err != nil {
+		t.Errorf("EnabledFields() failed: %v", err)
+	}
+
+	wantNotes := []string{
+		"item medications.since: enableWhen within a repeating group is left out",
+		"item consent: attachment answers have no field",
+		"item 1.2: enableWhen with 2 conditions cannot be written as one; the field is left optional",
+		`item 3: enableWhen compares pregnancy with "maybe", which is not one of yes, no; the field is left optional`,
+	}
+	if !reflect.DeepEqual(notes, wantNotes) {
+		t.Errorf("Import() notes =\n%q\nwant\n%q", notes, wantNotes)
+	}
+}
+
+func TestLoadRejectsOtherResources(t *testing.T) {
+	if _, err := Load("testdata/intake.json"); err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if _, err := Load("questionnaire.go"); err == nil {
+		t.Error("Load() of a Go file succeeded, want an error")
+	}
+}
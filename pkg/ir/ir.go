@@ -0,0 +1,260 @@
+// Package ir is an intermediate representation of schemas for generators:
+// schemas with their inherited elements flattened into their fields, field
+// types parsed and spelled one way, and each type that names a schema or
+// built-in datatype linked to it. Importers write schemas; Build turns
+// whatever they wrote into a Model, so the two meet at one contract rather
+// than at the spellings YAML allows, such as array<Coding> for []Coding or
+// dateTime for datetime.
+//
+// The MongoDB and Neo4j generators build a Model from the schemas they are
+// given. The other generators still resolve types with pkg/resolve, and the
+// loader returns schemas rather than a Model; moving them over is separate
+// work, done generator by generator.
+package ir
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Model is a set of schemas, grouped by namespace, with their types
+// resolved.
+type Model struct {
+	// Namespaces are in dependency order: each comes after the namespaces
+	// it references, but for cycles. Built-in datatypes that schemas use
+	// are in the datatypes.Namespace namespace.
+	Namespaces []Namespace
+	byKey      map[string]*Schema
+}
+
+// Namespace is a namespace and its schemas, in dependency order.
+type Namespace struct {
+	Name    string
+	Schemas []*Schema
+}
+
+// Schema is a schema of a Model.
+type Schema struct {
+	// Name is the schema's name, or its resource when it has none.
+	Name      string
+	Namespace string
+	Resource  string
+	// Builtin is set for the built-in datatypes, such as CodeableConcept.
+	Builtin bool
+	// Fields are the schema's fields, after the elements it inherits.
+	Fields  []Field
+	Unique  []schema.Index
+	Indexes []schema.Index
+	// Source is the schema as it was written, with its inherited elements
+	// flattened and its types canonical, for the attributes the IR passes
+	// through as they are.
+	Source schema.Schema
+}
+
+// Field is a field of a Schema, or of a field with nested fields.
+type Field struct {
+	Name     string
+	Type     Type
+	Required bool
+	// Stored is false for derived fields, which are computed from others.
+	Stored   bool
+	Children []Field
+	// Source is the field as it was written, with its type canonical.
+	Source schema.Field
+}
+
+// Type is the type of a field.
+type Type struct {
+	// Name is the element type of lists, with primitives spelled as
+	// Primitives lists them.
+	Name string
+	List bool
+	// Primitive is set for primitive types such as string and datetime.
+	Primitive bool
+	// Schema is the schema or built-in datatype the type names, or nil for
+	// primitives, nested fields, and types that name none.
+	Schema *Schema
+}
+
+// String returns the type as schemas write it, such as []Coding.
+func (t Type) String() string {
+	if t.List {
+		return "[]" + t.Name
+	}
+	return t.Name
+}
+
+// IsReference reports whether the type is the built-in Reference datatype.
+func (t Type) IsReference() bool {
+	return t.Schema != nil && t.Schema.Builtin && t.Schema.Name == datatypes.ReferenceName
+}
+
+// spellings are the primitive types schemas may spell another way.
+var spellings = map[string]string{
+	"dateTime": "datetime",
+}
+
+// Canonical returns fieldType spelled as the IR spells it: lists as []T
+// rather than array<T>, and primitives as datatypes.IsPrimitive and the
+// generators spell them, such as datetime for dateTime.
+func Canonical(fieldType string) string {
+	list := ""
+	if elem, ok := schema.ElementType(fieldType); ok {
+		list, fieldType = "[]", elem
+	}
+	if spelled, ok := spellings[fieldType]; ok {
+		fieldType = spelled
+	}
+	return list + fieldType
+}
+
+// canonical returns fields with their types, and those of their nested
+// fields, canonical.
+func canonical(fields []schema.Field) []schema.Field {
+	out := make([]schema.Field, len(fields))
+	for i, f := range fields {
+		f.Type = Canonical(f.Type)
+		f.Children = canonical(f.Children)
+		out[i] = f
+	}
+	return out
+}
+
+// Build resolves schemas into a Model, adding the built-in datatypes they
+// use. Types resolve as package resolve resolves them; types defined in
+// several other namespaces are errors, and types that name no schema are
+// left with a nil Schema.
+func Build(schemas []schema.Schema) (*Model, error) {
+	normalized := make([]schema.Schema, len(schemas))
+	for i, s := range schemas {
+		s.Fields = canonical(s.Fields)
+		normalized[i] = s
+	}
+	r, err := resolve.New(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Model{byKey: make(map[string]*Schema)}
+	type pending struct {
+		schema *Schema
+		scope  resolve.Scope
+	}
+	var all []pending
+	for _, namespace := range r.Namespaces() {
+		ns := Namespace{Name: namespace}
+		for _, s := range r.Namespace(namespace) {
+			s = datatypes.Flatten(s)
+			s.Fields = canonical(s.Fields)
+			out := &Schema{
+				Name:      s.GetName(),
+				Namespace: s.Namespace,
+				Resource:  s.Resource,
+				Builtin:   datatypes.IsBuiltin(s),
+				Unique:    s.Unique,
+				Indexes:   s.Indexes,
+				Source:    s,
+			}
+			m.byKey[namespace+"."+out.Name] = out
+			ns.Schemas = append(ns.Schemas, out)
+			all = append(all, pending{schema: out, scope: r.Scope(namespace)})
+		}
+		m.Namespaces = append(m.Namespaces, ns)
+	}
+
+	// Types link to schemas once every schema exists
+	for _, p := range all {
+		fields, err := m.fields(p.scope, p.schema.Source.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.schema.Name, err)
+		}
+		p.schema.Fields = fields
+	}
+	return m, nil
+}
+
+// fields returns the IR of fields, whose types resolve from scope.
+func (m *Model) fields(scope resolve.Scope, fields []schema.Field) ([]Field, error) {
+	out := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		elem, list := strings.CutPrefix(f.Type, "[]")
+		t := Type{Name: elem, List: list, Primitive: datatypes.IsPrimitive(elem)}
+		if !t.Primitive && len(f.Children) == 0 {
+			target, ok, err := scope.Resolve(elem)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			if ok {
+				t.Schema = m.byKey[target.Namespace+"."+target.GetName()]
+			}
+		}
+		children, err := m.fields(scope, f.Children)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Field{
+			Name:     f.Name,
+			Type:     t,
+			Required: f.Required,
+			Stored:   f.Derived == "",
+			Children: children,
+			Source:   f,
+		})
+	}
+	return out, nil
+}
+
+// Lookup returns the schema of a namespace with a name.
+func (m *Model) Lookup(namespace, name string) (*Schema, bool) {
+	s, ok := m.byKey[namespace+"."+name]
+	return s, ok
+}
+
+// Schemas returns the schemas of every namespace, in namespace order,
+// leaving out built-in datatypes.
+func (m *Model) Schemas() []*Schema {
+	var schemas []*Schema
+	for _, ns := range m.Namespaces {
+		for _, s := range ns.Schemas {
+			if !s.Builtin {
+				schemas = append(schemas, s)
+			}
+		}
+	}
+	return schemas
+}
+
+// Field returns the field of s with a name.
+func (s *Schema) Field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// StoredFields returns the fields of s that are not derived.
+func (s *Schema) StoredFields() []Field {
+	var stored []Field
+	for _, f := range s.Fields {
+		if f.Stored {
+			stored = append(stored, f)
+		}
+	}
+	return stored
+}
+
+// Load loads the schemas of loader and builds their Model.
+func Load(ctx context.Context, loader *schema.Loader) (*Model, error) {
+	schemas, err := loader.LoadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Build(schemas)
+}
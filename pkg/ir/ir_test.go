@@ -0,0 +1,117 @@
+package ir
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestCanonical(t *testing.T) {
+	for fieldType, want := range map[string]string{
+		"string":          "string",
+		"dateTime":        "datetime",
+		"[]dateTime":      "[]datetime",
+		"array<Coding>":   "[]Coding",
+		"array<dateTime>": "[]datetime",
+		"admin.Location":  "admin.Location",
+	} {
+		if got := Canonical(fieldType); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", fieldType, got, want)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	m, err := Build([]schema.Schema{
+		{Resource: "Patient", Namespace: "clinical", Inherits: datatypes.DomainResourceName, Fields: []schema.Field{
+			{Name: "name", Type: "array<HumanName>", Required: true},
+			{Name: "birth", Type: "dateTime"},
+			{Name: "age", Type: "integer", Derived: "years(birth)"},
+			{Name: "practitioner", Type: "[]Reference"},
+			{Name: "home", Type: "admin.Location"},
+			{Name: "contact", Type: "[]BackboneElement", Children: []schema.Field{{Name: "phone", Type: "string"}}},
+			{Name: "extra", Type: "Unknown"},
+		}},
+		{Name: "Location", Namespace: "admin", Fields: []schema.Field{{Name: "city", Type: "string"}}},
+	})
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+
+	var namespaces []string
+	for _, ns := range m.Namespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+	// Namespaces follow those they reference
+	if want := []string{"admin", datatypes.Namespace, "clinical"}; !reflect.DeepEqual(namespaces, want) {
+		t.Errorf("Build() namespaces = %q, want %q", namespaces, want)
+	}
+	if got := len(m.Schemas()); got != 2 {
+		t.Errorf("Schemas() returned %d schemas, want the 2 that are not built in", got)
+	}
+
+	patient, ok := m.Lookup("clinical", "Patient")
+	if !ok {
+		t.Fatal("Lookup(clinical, Patient) found nothing")
+	}
+	var types []string
+	for _, f := range patient.Fields {
+		types = append(types, f.Name+" "+f.Type.String())
+	}
+	want := []string{
+		"id id", "meta Meta", "implicitRules uri", "language code", "text Narrative", "extension []Extension", "modifierExtension []Extension",
+		"name []HumanName", "birth datetime", "age integer", "practitioner []Reference", "home admin.Location", "contact []BackboneElement", "extra Unknown",
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Build() fields = %q, want %q", types, want)
+	}
+
+	field := func(name string) Field {
+		f, ok := patient.Field(name)
+		if !ok {
+			t.Fatalf("Field(%q) found nothing", name)
+		}
+		return f
+	}
+	if f := field("name"); !f.Required || !f.Type.List || f.Type.Schema == nil || !f.Type.Schema.Builtin || f.Type.Schema.Name != "HumanName" {
+		t.Errorf("name = %+v, want a required list of the built-in HumanName", f.Type)
+	}
+	if f := field("birth"); !f.Type.Primitive || f.Type.Schema != nil || f.Source.Type != "datetime" {
+		t.Errorf("birth = %+v, want the primitive datetime", f.Type)
+	}
+	if f := field("practitioner"); !f.Type.IsReference() {
+		t.Errorf("practitioner = %+v, want References", f.Type)
+	}
+	location, _ := m.Lookup("admin", "Location")
+	if f := field("home"); f.Type.Schema != location || f.Type.IsReference() {
+		t.Errorf("home = %+v, want the admin Location schema", f.Type)
+	}
+	if f := field("contact"); f.Type.Schema != nil || len(f.Children) != 1 || !f.Children[0].Type.Primitive {
+		t.Errorf("contact = %+v, want nested fields", f)
+	}
+	if f := field("extra"); f.Type.Schema != nil || f.Type.Primitive {
+		t.Errorf("extra = %+v, want an unresolved type", f.Type)
+	}
+
+	var stored []string
+	for _, f := range patient.StoredFields() {
+		stored = append(stored, f.Name)
+	}
+	if strings.Contains(" "+strings.Join(stored, " ")+" ", " age ") {
+		t.Errorf("StoredFields() = %q, want the derived age left out", stored)
+	}
+}
+
+func TestBuildAmbiguousType(t *testing.T) {
+	_, err := Build([]schema.Schema{
+		{Name: "Visit", Namespace: "clinical", Fields: []schema.Field{{Name: "at", Type: "Location"}}},
+		{Name: "Location", Namespace: "admin"},
+		{Name: "Location", Namespace: "billing"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "type Location is defined in namespaces admin, billing") {
+		t.Errorf("Build() error = %v, want an ambiguous Location", err)
+	}
+}
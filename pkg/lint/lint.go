@@ -0,0 +1,432 @@
+// Package lint checks schemas against a configurable set of style and
+// privacy rules.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a lint rule.
+type Rule struct {
+	// ID names the rule in ehrglot.yaml and in reports.
+	ID string
+	// Description says what the rule requires.
+	Description string
+	// Severity is the severity used when the configuration does not set one.
+	Severity string
+
+	check func(s schema.Schema, report reporter)
+}
+
+// reporter records a finding for the field at path, or for the schema itself
+// when path is empty.
+type reporter func(path []string, format string, args ...any)
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	lowerCode  = regexp.MustCompile(`^[a-z0-9]+(?:[-_.][a-z0-9]+)*$`)
+)
+
+// piiLevels are the valid pii_level values, compared case-insensitively.
+var piiLevels = map[string]bool{"none": true, "low": true, "medium": true, "high": true, "critical": true}
+
+// phiWords are the name words that mark a field as likely holding protected
+// health information.
+var phiWords = map[string]bool{
+	"name": true, "given": true, "family": true, "birth": true, "birthdate": true, "dob": true,
+	"ssn": true, "mrn": true, "address": true, "street": true, "city": true, "zip": true,
+	"postal": true, "phone": true, "telecom": true, "email": true, "fax": true, "identifier": true,
+	"photo": true, "passport": true, "license": true,
+}
+
+// Rules lists the built-in rules.
+var Rules = []Rule{
+	{
+		ID:          "field-description",
+		Description: "Every field must have a description.",
+		Severity:    config.SeverityWarning,
+		check: func(s schema.Schema, report reporter) {
+			walk(s.Fields, nil, func(path []string, f schema.Field) {
+				if strings.TrimSpace(f.Description) == "" {
+					report(path, "field %s has no description", strings.Join(path, "."))
+				}
+			})
+		},
+	},
+	{
+		ID:          "pii-level",
+		Description: "Fields that look like they hold PHI must declare a valid pii_level.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			walk(s.Fields, nil, func(path []string, f schema.Field) {
+				switch {
+				case f.PIILevel != "" && !piiLevels[strings.ToLower(f.PIILevel)]:
+					report(path, "field %s has unknown pii_level %q (want none, low, medium, high, or critical)", strings.Join(path, "."), f.PIILevel)
+				case f.PIILevel == "" && looksLikePHI(f.Name):
+					report(path, "field %s looks like PHI but declares no pii_level", strings.Join(path, "."))
+				}
+			})
+		},
+	},
+	{
+		ID:          "enum-lowercase",
+		Description: "Enum values must be lowercase codes such as entered-in-error.",
+		Severity:    config.SeverityWarning,
+		check: func(s schema.Schema, report reporter) {
+			walk(s.Fields, nil, func(path []string, f schema.Field) {
+				for _, value := range f.Enum {
+					if !lowerCode.MatchString(value) {
+						report(path, "enum value %q of field %s is not a lowercase code", value, strings.Join(path, "."))
+					}
+				}
+			})
+		},
+	},
+	{
+		ID:          "index-fields",
+		Description: "Indexes and unique keys must name fields of the schema.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if err := datatypes.Flatten(s).CheckKeys(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "dicom-tags",
+		Description: "Fields of dicom schemas must each name a distinct, valid dicom_tag.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if s.Style != schema.StyleDICOM {
+				return
+			}
+			if _, err := s.DICOMTags(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "encryption",
+		Description: "Encrypted fields must be top-level text fields with a known encryption mode.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, err := s.EncryptedFields(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "purposes",
+		Description: "Purposes of use must be known, and fields may only narrow their schema's.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if err := s.CheckPurposes(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "aliases",
+		Description: "Fields' json_name and db_column must be writable and distinct from the names of the fields beside them.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if err := s.CheckAliases(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "retention",
+		Description: "Retention must give a valid period and a date or timestamp field to age records from.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, _, err := s.RetentionPeriod(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "field-uids",
+		Description: "Field uids must be unique within their schema.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if err := s.CheckUIDs(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "defaults",
+		Description: "Defaults must be valid values of their field, whose type must be text, a number, or a boolean.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if err := s.CheckDefaults(); err != nil {
+				report(nil, "%v", err)
+				return
+			}
+			walk(s.Fields, nil, func(path []string, f schema.Field) {
+				if f.Default == "" || f.DefaultKind() != "text" {
+					return
+				}
+				if msg := validate.Text(f, f.Default); msg != "" {
+					report(path, "default of field %s: %s", strings.Join(path, "."), msg)
+				}
+			})
+		},
+	},
+	{
+		ID:          "examples",
+		Description: "Examples must be valid values of their field, whose type must be primitive.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			walk(s.Fields, nil, func(path []string, f schema.Field) {
+				examples := f.AllExamples()
+				if len(examples) > 0 && len(f.Children) > 0 {
+					report(path, "field %s has children and cannot take examples", strings.Join(path, "."))
+					return
+				}
+				if elem, _ := schema.ElementType(f.Type); len(examples) > 0 && !datatypes.IsPrimitive(elem) {
+					report(path, "field %s of type %s cannot take examples", strings.Join(path, "."), f.Type)
+					return
+				}
+				for _, example := range examples {
+					if msg := validate.Text(f, example); msg != "" {
+						report(path, "example of field %s: %s", strings.Join(path, "."), msg)
+					}
+				}
+			})
+		},
+	},
+	{
+		ID:          "required-if",
+		Description: "required_if and enabled_if conditions must test another top-level field, comparing it only with values of its type.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, err := s.ConditionalFields(); err != nil {
+				report(nil, "%v", err)
+			}
+			if _, err := s.EnabledFields(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "derived",
+		Description: "Derived fields must be top-level text or number fields whose expressions use stored fields, with values of their type.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, err := s.DerivedFields(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "reference-range",
+		Description: "Units and reference ranges belong to numeric fields, whose reference_low must not exceed their reference_high.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, err := s.RangedFields(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "match",
+		Description: "Fields with a match role must be top-level, stored, and unencrypted, with a known role of a type that suits it, and only identifiers may share a role.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if _, err := s.MatchFields(); err != nil {
+				report(nil, "%v", err)
+			}
+		},
+	},
+	{
+		ID:          "ownership",
+		Description: "Every schema must name the team that owns it.",
+		Severity:    config.SeverityWarning,
+		check: func(s schema.Schema, report reporter) {
+			if strings.TrimSpace(s.Owner) == "" {
+				report(nil, "schema %s has no owner", s.GetName())
+			}
+		},
+	},
+	{
+		ID:          "resource-pascal-case",
+		Description: "Resource names must be PascalCase.",
+		Severity:    config.SeverityError,
+		check: func(s schema.Schema, report reporter) {
+			if !pascalCase.MatchString(s.GetName()) {
+				report(nil, "resource name %q is not PascalCase", s.GetName())
+			}
+		},
+	},
+}
+
+func walk(fields []schema.Field, parent []string, visit func(path []string, f schema.Field)) {
+	for _, f := range fields {
+		path := append(append([]string(nil), parent...), f.Name)
+		visit(path, f)
+		walk(f.Children, path, visit)
+	}
+}
+
+func looksLikePHI(name string) bool {
+	for _, w := range naming.Words(name) {
+		if phiWords[strings.ToLower(w)] {
+			return true
+		}
+	}
+	return false
+}
+
+// Finding is a rule violation.
+type Finding struct {
+	Rule     string
+	Severity string
+	Message  string
+	// File is the schema file, and Line the line of the offending field or
+	// schema within it (0 when unknown).
+	File string
+	Line int
+}
+
+func (f Finding) String() string {
+	location := filepath.ToSlash(f.File)
+	if f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, f.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", location, f.Severity, f.Message, f.Rule)
+}
+
+// Linter applies the rules with their configured severities.
+type Linter struct {
+	severity map[string]string
+}
+
+// New creates a Linter from the lint settings, rejecting unknown rule IDs.
+func New(cfg config.Lint) (*Linter, error) {
+	l := &Linter{severity: make(map[string]string)}
+	for _, r := range Rules {
+		l.severity[r.ID] = r.Severity
+	}
+	for id, severity := range cfg.Rules {
+		if _, ok := l.severity[id]; !ok {
+			return nil, fmt.Errorf("unknown lint rule %q", id)
+		}
+		l.severity[id] = severity
+	}
+	return l, nil
+}
+
+// Severity returns the effective severity of a rule.
+func (l *Linter) Severity(rule string) string {
+	return l.severity[rule]
+}
+
+// Lint checks schemas, returning the findings ordered by file and line.
+func (l *Linter) Lint(schemas []schema.Schema) []Finding {
+	var findings []Finding
+	for _, s := range schemas {
+		lines := fieldLines(s.SourceFile)
+		for _, r := range Rules {
+			severity := l.severity[r.ID]
+			if severity == config.SeverityOff {
+				continue
+			}
+			r.check(s, func(path []string, format string, args ...any) {
+				findings = append(findings, Finding{
+					Rule:     r.ID,
+					Severity: severity,
+					Message:  s.GetName() + ": " + fmt.Sprintf(format, args...),
+					File:     s.SourceFile,
+					Line:     lines[strings.Join(path, ".")],
+				})
+			})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings
+}
+
+// Count returns the number of findings with the given severity.
+func Count(findings []Finding, severity string) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// fieldLines maps the dotted path of every field in a schema file to the line
+// it is declared on, and "" to the line of the schema name. Files that cannot
+// be read yield no lines.
+func fieldLines(file string) map[string]int {
+	lines := make(map[string]int)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return lines
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return lines
+	}
+
+	root := doc.Content[0]
+	for _, key := range []string{"resource", "name"} {
+		if node := value(root, key); node != nil {
+			lines[""] = node.Line
+		}
+	}
+	var visit func(fields *yaml.Node, parent string)
+	visit = func(fields *yaml.Node, parent string) {
+		if fields == nil || fields.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, field := range fields.Content {
+			name := value(field, "name")
+			if name == nil {
+				continue
+			}
+			path := name.Value
+			if parent != "" {
+				path = parent + "." + path
+			}
+			lines[path] = field.Line
+			visit(value(field, "children"), path)
+		}
+	}
+	visit(value(root, "fields"), "")
+	return lines
+}
+
+// value returns the value of key in a mapping node, or nil.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+const patientYAML = `name: patient_record
+unique:
+  - fields: [medical_record_number]
+fields:
+  - name: mrn
+    type: string
+  - name: status
+    type: code
+    description: Record status
+    enum: [active, Entered-In-Error]
+    examples: [active, retired]
+  - name: contact_name
+    type: BackboneElement
+    description: Contact party
+    children:
+      - name: phone
+        type: string
+        description: Phone number
+  - name: closed_reason
+    type: string
+    description: Why the record was closed
+    required_if: status == retired
+`
+
+func load(t *testing.T) []schema.Schema {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "custom"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "custom", "patient.yaml"), []byte(patientYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := schema.NewLoader(dir).LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() failed: %v", err)
+	}
+	return schemas
+}
+
+type result struct {
+	Rule, Severity string
+	Line           int
+}
+
+func results(findings []Finding) []result {
+	var got []result
+	for _, f := range findings {
+		got = append(got, result{f.Rule, f.Severity, f.Line})
+	}
+	return got
+}
+
+func TestLint(t *testing.T) {
+	l, err := New(config.Lint{})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	got := results(l.Lint(load(t)))
+	want := []result{
+		{"index-fields", "error", 1},
+		{"required-if", "error", 1},
+		{"ownership", "warning", 1},
+		{"resource-pascal-case", "error", 1},
+		{"field-description", "warning", 5},
+		{"pii-level", "error", 5},
+		{"enum-lowercase", "warning", 7},
+		{"examples", "error", 7},
+		{"pii-level", "error", 12},
+		{"pii-level", "error", 16},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v, want %v", got, want)
+	}
+}
+
+func TestSeverityConfig(t *testing.T) {
+	l, err := New(config.Lint{Rules: map[string]string{"pii-level": "off", "field-description": "note", "ownership": "error"}})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	got := results(l.Lint(load(t)))
+	want := []result{
+		{"index-fields", "error", 1},
+		{"required-if", "error", 1},
+		{"ownership", "error", 1},
+		{"resource-pascal-case", "error", 1},
+		{"field-description", "note", 5},
+		{"enum-lowercase", "warning", 7},
+		{"examples", "error", 7},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v, want %v", got, want)
+	}
+
+	if _, err := New(config.Lint{Rules: map[string]string{"no-such-rule": "error"}}); err == nil {
+		t.Error("New() accepted an unknown rule")
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	l, err := New(config.Lint{Rules: map[string]string{"enum-lowercase": "off"}})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	findings := l.Lint(load(t))
+
+	var buf bytes.Buffer
+	if err := l.WriteSARIF(&buf, findings, "1.2.3"); err != nil {
+		t.Fatalf("WriteSARIF() failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("WriteSARIF() wrote invalid JSON: %v", err)
+	}
+
+	run := log.Runs[0]
+	if log.Version != "2.1.0" || run.Tool.Driver.Version != "1.2.3" || len(run.Tool.Driver.Rules) != len(Rules) {
+		t.Errorf("unexpected log header: %+v", log)
+	}
+	if len(run.Results) != len(findings) {
+		t.Fatalf("got %d results, want %d", len(run.Results), len(findings))
+	}
+	for _, r := range run.Tool.Driver.Rules {
+		if r.ID == "enum-lowercase" && r.DefaultConfiguration.Enabled {
+			t.Error("disabled rule is reported as enabled")
+		}
+	}
+	first := run.Results[0]
+	if first.RuleID != Rules[first.RuleIndex].ID || first.Level != "error" ||
+		first.Locations[0].PhysicalLocation.Region.StartLine != 1 ||
+		filepath.Base(first.Locations[0].PhysicalLocation.ArtifactLocation.URI) != "patient.yaml" {
+		t.Errorf("unexpected first result: %+v", first)
+	}
+}
@@ -0,0 +1,313 @@
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/paths"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// backboneElement is the type of inline nested elements.
+const backboneElement = "BackboneElement"
+
+// syntaxLine extracts the line number from yaml.v3 syntax errors.
+var syntaxLine = regexp.MustCompile(`line (\d+)`)
+
+// workspace is a snapshot of the schemas under the schema directory, with
+// open documents in place of their files on disk.
+type workspace struct {
+	schemas    []schema.Schema
+	resolver   *resolve.Resolver
+	namespaces map[string]bool
+	text       func(path string) ([]byte, error)
+}
+
+// reference is a field type, or the target resource of a mapping, in a
+// document.
+type reference struct {
+	rng   Range
+	value string
+	// target is set for mapping target resources.
+	target bool
+}
+
+// document is an analyzed schema or mapping file.
+type document struct {
+	path        string
+	namespace   string
+	diagnostics []Diagnostic
+	references  []reference
+}
+
+func isMapping(path string) bool {
+	return strings.HasSuffix(path, "_mapping.yaml")
+}
+
+// load reads every schema under dir as the loader would, tolerating files
+// that do not parse so that the rest of the workspace still resolves.
+func load(dir string, text func(path string) ([]byte, error), open []string) *workspace {
+	w := &workspace{namespaces: make(map[string]bool), text: text}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+
+	var files []string
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			nsFiles, _ := paths.Files(filepath.Join(dir, entry.Name()), ".yaml")
+			files = append(files, nsFiles...)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		seen[filepath.Clean(file)] = true
+	}
+	for _, file := range open {
+		if !seen[filepath.Clean(file)] && filepath.Ext(file) == ".yaml" {
+			files = append(files, file)
+		}
+	}
+
+	for _, file := range files {
+		namespace := filepath.Base(filepath.Dir(file))
+		if isMapping(file) || namespace == "schema_overrides" {
+			continue
+		}
+		data, err := text(file)
+		if err != nil {
+			continue
+		}
+		var s schema.Schema
+		if err := yaml.Unmarshal(data, &s); err != nil || s.GetName() == "" {
+			continue
+		}
+		s.SourceFile = file
+		s.Namespace = namespace
+		w.schemas = append(w.schemas, s)
+		w.namespaces[namespace] = true
+	}
+	w.resolver = resolve.Lenient(w.schemas)
+	return w
+}
+
+// analyze validates a document and collects its references.
+func (w *workspace) analyze(path string, data []byte) *document {
+	doc := &document{path: path, namespace: filepath.Base(filepath.Dir(path))}
+	lines := strings.Split(string(data), "\n")
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		line := 0
+		if m := syntaxLine.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			line--
+		}
+		doc.diagnostics = append(doc.diagnostics, Diagnostic{
+			Range:    lineRange(lines, line, 0),
+			Severity: SeverityError,
+			Source:   "ehrglot",
+			Message:  strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+		return doc
+	}
+	if len(root.Content) == 0 {
+		return doc
+	}
+
+	err := schema.Validate(path, data, isMapping(path))
+	var problems []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		problems = joined.Unwrap()
+	} else if err != nil {
+		problems = []error{err}
+	}
+	for _, problem := range problems {
+		d := Diagnostic{Severity: SeverityError, Source: "ehrglot", Message: problem.Error()}
+		var verr *schema.ValidationError
+		if errors.As(problem, &verr) {
+			d.Range = lineRange(lines, verr.Line-1, verr.Column-1)
+			d.Message = verr.Path + ": " + verr.Message
+		}
+		doc.diagnostics = append(doc.diagnostics, d)
+	}
+
+	top := root.Content[0]
+	if isMapping(path) {
+		if n := value(top, "target_resource"); n != nil && n.Kind == yaml.ScalarNode {
+			doc.references = append(doc.references, reference{rng: scalarRange(n), value: n.Value, target: true})
+		}
+	} else {
+		collectTypes(value(top, "fields"), &doc.references)
+	}
+
+	scope := w.resolver.Scope(doc.namespace)
+	for _, ref := range doc.references {
+		if d, ok := w.check(scope, ref); !ok {
+			doc.diagnostics = append(doc.diagnostics, d)
+		}
+	}
+	return doc
+}
+
+// collectTypes appends the type values of fields and their nested fields.
+func collectTypes(fields *yaml.Node, refs *[]reference) {
+	if fields == nil || fields.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, field := range fields.Content {
+		if n := value(field, "type"); n != nil && n.Kind == yaml.ScalarNode {
+			*refs = append(*refs, reference{rng: scalarRange(n), value: n.Value})
+		}
+		collectTypes(value(field, "children"), refs)
+		collectTypes(value(field, "fields"), refs)
+	}
+}
+
+// check reports a diagnostic for a reference that does not resolve.
+func (w *workspace) check(scope resolve.Scope, ref reference) (Diagnostic, bool) {
+	d := Diagnostic{Range: ref.rng, Severity: SeverityError, Source: "ehrglot"}
+	elem, _ := schema.ElementType(ref.value)
+	if !ref.target && (datatypes.IsPrimitive(elem) || elem == backboneElement) {
+		return d, true
+	}
+
+	_, ok, err := scope.Resolve(elem)
+	switch {
+	case err != nil:
+		d.Message = err.Error()
+	case ok:
+		return d, true
+	case ref.target:
+		d.Severity = SeverityWarning
+		d.Message = fmt.Sprintf("unknown target resource %q", ref.value)
+	default:
+		if ns, name, qualified := strings.Cut(elem, "."); qualified && w.namespaces[ns] {
+			d.Message = fmt.Sprintf("namespace %s has no schema %s", ns, name)
+			break
+		}
+		d.Severity = SeverityWarning
+		d.Message = fmt.Sprintf("unknown type %q; generated code falls back to an untyped value", elem)
+	}
+	return d, false
+}
+
+// at returns the reference at pos, if any.
+func (doc *document) at(pos Position) (reference, bool) {
+	for _, ref := range doc.references {
+		if ref.rng.contains(pos) {
+			return ref, true
+		}
+	}
+	return reference{}, false
+}
+
+// definition returns the location of the schema a reference resolves to.
+// Built-in datatypes have no location.
+func (w *workspace) definition(doc *document, ref reference) (*Location, bool) {
+	elem, _ := schema.ElementType(ref.value)
+	target, ok := w.resolver.Scope(doc.namespace).Lookup(elem)
+	if !ok || datatypes.IsBuiltin(target) {
+		return nil, false
+	}
+	loc := &Location{URI: pathToURI(target.SourceFile)}
+	if data, err := w.text(target.SourceFile); err == nil {
+		var root yaml.Node
+		if yaml.Unmarshal(data, &root) == nil && len(root.Content) > 0 {
+			for _, key := range []string{"name", "resource"} {
+				if n := value(root.Content[0], key); n != nil {
+					loc.Range = scalarRange(n)
+					break
+				}
+			}
+		}
+	}
+	return loc, true
+}
+
+// hover documents the type a reference names.
+func (w *workspace) hover(doc *document, ref reference) (*Hover, bool) {
+	elem, _ := schema.ElementType(ref.value)
+	var b strings.Builder
+	switch target, ok := w.resolver.Scope(doc.namespace).Lookup(elem); {
+	case ok:
+		fmt.Fprintf(&b, "**%s** (`%s`", target.GetName(), target.Namespace)
+		if datatypes.IsBuiltin(target) {
+			b.WriteString(", built-in datatype")
+		}
+		b.WriteString(")\n")
+		if target.Description != "" {
+			fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(target.Description))
+		}
+		if len(target.Fields) > 0 {
+			b.WriteString("\n")
+		}
+		for _, f := range target.Fields {
+			fmt.Fprintf(&b, "- `%s`: `%s`", f.Name, f.Type)
+			if f.Required {
+				b.WriteString(" (required)")
+			}
+			if f.Description != "" {
+				b.WriteString(" — " + strings.TrimSpace(f.Description))
+			}
+			b.WriteString("\n")
+		}
+	case datatypes.IsPrimitive(elem) && !ref.target:
+		fmt.Fprintf(&b, "`%s` is a primitive type, mapped to a native type by each generator.", elem)
+	case elem == backboneElement && !ref.target:
+		b.WriteString("`BackboneElement` is an inline nested element whose fields are declared under it.")
+	default:
+		return nil, false
+	}
+	if elem != ref.value {
+		fmt.Fprintf(&b, "\nThe field holds a list of `%s`.\n", elem)
+	}
+	rng := ref.rng
+	return &Hover{Contents: markupContent{Kind: "markdown", Value: strings.TrimSpace(b.String())}, Range: &rng}, true
+}
+
+// scalarRange is the range of a scalar's text, excluding quotes.
+func scalarRange(n *yaml.Node) Range {
+	start := Position{Line: n.Line - 1, Character: n.Column - 1}
+	if n.Style == yaml.DoubleQuotedStyle || n.Style == yaml.SingleQuotedStyle {
+		start.Character++
+	}
+	return Range{Start: start, End: Position{Line: start.Line, Character: start.Character + len([]rune(n.Value))}}
+}
+
+// lineRange is the range from a column to the end of a line.
+func lineRange(lines []string, line, column int) Range {
+	if line < 0 {
+		line = 0
+	}
+	end := column
+	if line < len(lines) {
+		end = len([]rune(strings.TrimRight(lines[line], "\r")))
+	}
+	if end < column {
+		end = column
+	}
+	return Range{Start: Position{Line: line, Character: column}, End: Position{Line: line, Character: end}}
+}
+
+// value returns the value of key in a mapping node, or nil.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Package lsp implements a minimal Language Server Protocol server for
+// authoring ehrglot schemas: diagnostics for invalid files and unresolved
+// types, go-to-definition from field types and mapping targets to the
+// schemas they name, and hover documentation for field types.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Server is a language server for one schema directory.
+type Server struct {
+	schemaDir string
+	// docs holds the text of open documents by path.
+	docs     map[string][]byte
+	out      io.Writer
+	shutdown bool
+}
+
+// New creates a Server for the schemas in schemaDir. A relative schemaDir is
+// resolved against the workspace root the client sends on initialization.
+func New(schemaDir string) *Server {
+	return &Server{schemaDir: schemaDir, docs: make(map[string][]byte)}
+}
+
+// Serve handles messages from in until the client exits or in is closed,
+// writing responses and notifications to out.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		msg, err := readMessage(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, rerr := s.handle(msg)
+		if msg.ID == nil {
+			continue
+		}
+		resp := &message{ID: msg.ID, Error: rerr}
+		if rerr == nil {
+			if resp.Result, err = json.Marshal(result); err != nil {
+				return fmt.Errorf("failed to encode result: %w", err)
+			}
+		}
+		if err := writeMessage(out, resp); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (any, *responseError) {
+	if s.shutdown && msg.ID != nil {
+		return nil, &responseError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+
+	switch msg.Method {
+	case "initialize":
+		var params initializeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if !filepath.IsAbs(s.schemaDir) && params.RootURI != "" {
+			s.schemaDir = filepath.Join(uriToPath(params.RootURI), s.schemaDir)
+		}
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   1, // full document sync
+				"definitionProvider": true,
+				"hoverProvider":      true,
+			},
+			"serverInfo": map[string]string{"name": "ehrglot"},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.docs[uriToPath(params.TextDocument.URI)] = []byte(params.TextDocument.Text)
+		s.publish()
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uriToPath(params.TextDocument.URI)] = []byte(params.ContentChanges[n-1].Text)
+		}
+		s.publish()
+	case "textDocument/didSave":
+		s.publish()
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.docs, uriToPath(params.TextDocument.URI))
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		s.publish()
+	case "textDocument/definition", "textDocument/hover":
+		var params positionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		w, doc := s.document(uriToPath(params.TextDocument.URI))
+		if doc == nil {
+			return nil, nil
+		}
+		ref, ok := doc.at(params.Position)
+		if !ok {
+			return nil, nil
+		}
+		if msg.Method == "textDocument/definition" {
+			if loc, ok := w.definition(doc, ref); ok {
+				return loc, nil
+			}
+			return nil, nil
+		}
+		if hover, ok := w.hover(doc, ref); ok {
+			return hover, nil
+		}
+		return nil, nil
+	case "initialized", "$/cancelRequest", "$/setTrace":
+	default:
+		if msg.ID != nil {
+			return nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + msg.Method}
+		}
+	}
+	return nil, nil
+}
+
+func invalidParams(err error) *responseError {
+	return &responseError{Code: codeInvalidParams, Message: err.Error()}
+}
+
+// text returns the content of a file, preferring its open document.
+func (s *Server) text(path string) ([]byte, error) {
+	if data, ok := s.docs[path]; ok {
+		return data, nil
+	}
+	return os.ReadFile(path)
+}
+
+func (s *Server) workspace() *workspace {
+	open := make([]string, 0, len(s.docs))
+	for path := range s.docs {
+		open = append(open, path)
+	}
+	sort.Strings(open)
+	return load(s.schemaDir, s.text, open)
+}
+
+// document analyzes the document at path.
+func (s *Server) document(path string) (*workspace, *document) {
+	data, err := s.text(path)
+	if err != nil {
+		return nil, nil
+	}
+	w := s.workspace()
+	return w, w.analyze(path, data)
+}
+
+// publish sends the diagnostics of every open document, since an edit to one
+// schema can break or fix references in the others.
+func (s *Server) publish() {
+	w := s.workspace()
+	paths := make([]string, 0, len(s.docs))
+	for path := range s.docs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		doc := w.analyze(path, s.docs[path])
+		diagnostics := doc.diagnostics
+		if diagnostics == nil {
+			diagnostics = []Diagnostic{}
+		}
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: pathToURI(path), Diagnostics: diagnostics})
+	}
+}
+
+func (s *Server) notify(method string, params any) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	writeMessage(s.out, &message{Method: method, Params: data})
+}
@@ -0,0 +1,150 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const patientYAML = `resource: Patient
+description: Demographics of a person receiving care
+fields:
+  - name: id
+    type: id
+    required: true
+    description: Logical id
+`
+
+const encounterYAML = `resource: Encounter
+fields:
+  - name: subject
+    type: Patient
+  - name: period
+    type: "[]Period"
+  - name: location
+    type: Place
+  - name: status
+    type: code
+    pii_level: secret
+  - name: notes
+    type: array<string>
+`
+
+type session struct {
+	t   *testing.T
+	in  bytes.Buffer
+	ids int
+}
+
+func (s *session) send(method string, params any) {
+	s.t.Helper()
+	data, err := json.Marshal(params)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	msg := &message{Method: method, Params: data}
+	if !strings.HasPrefix(method, "textDocument/did") && method != "initialized" && method != "exit" {
+		s.ids++
+		msg.ID = json.RawMessage(strconv.Itoa(s.ids))
+	}
+	if err := writeMessage(&s.in, msg); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "schemas", "clinical")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	patient := filepath.Join(dir, "patient.yaml")
+	encounter := filepath.Join(dir, "encounter.yaml")
+	if err := os.WriteFile(patient, []byte(patientYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &session{t: t}
+	s.send("initialize", map[string]any{"rootUri": pathToURI(root)})
+	s.send("initialized", map[string]any{})
+	s.send("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": pathToURI(encounter), "text": encounterYAML}})
+	position := func(line, character int) map[string]any {
+		return map[string]any{
+			"textDocument": map[string]any{"uri": pathToURI(encounter)},
+			"position":     map[string]any{"line": line, "character": character},
+		}
+	}
+	s.send("textDocument/definition", position(3, 12))
+	s.send("textDocument/hover", position(3, 12))
+	s.send("textDocument/hover", position(5, 13))
+	s.send("textDocument/definition", position(0, 2))
+	s.send("shutdown", nil)
+	s.send("exit", nil)
+
+	var out bytes.Buffer
+	if err := New("schemas").Serve(&s.in, &out); err != nil {
+		t.Fatalf("Serve() failed: %v", err)
+	}
+
+	var msgs []*message
+	r := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) != 7 {
+		t.Fatalf("got %d messages, want 7", len(msgs))
+	}
+
+	var diags publishDiagnosticsParams
+	if err := json.Unmarshal(msgs[1].Params, &diags); err != nil || msgs[1].Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("message 1 is not diagnostics: %+v", msgs[1])
+	}
+	var got []string
+	for _, d := range diags.Diagnostics {
+		got = append(got, d.Message)
+	}
+	want := []string{
+		`fields[3].pii_level: "secret" is not one of none, low, medium, high, critical, NONE, LOW, MEDIUM, HIGH, CRITICAL`,
+		`unknown type "Place"; generated code falls back to an untyped value`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("diagnostics = %q, want %q", got, want)
+	}
+	if diags.Diagnostics[1].Range.Start != (Position{Line: 7, Character: 10}) || diags.Diagnostics[1].Severity != SeverityWarning {
+		t.Errorf("unexpected unknown type diagnostic: %+v", diags.Diagnostics[1])
+	}
+
+	var loc Location
+	if err := json.Unmarshal(msgs[2].Result, &loc); err != nil {
+		t.Fatalf("definition result: %v", err)
+	}
+	if loc.URI != pathToURI(patient) || loc.Range.Start != (Position{Line: 0, Character: 10}) {
+		t.Errorf("definition = %+v, want patient.yaml line 0", loc)
+	}
+
+	var hover Hover
+	if err := json.Unmarshal(msgs[3].Result, &hover); err != nil {
+		t.Fatalf("hover result: %v", err)
+	}
+	if !strings.Contains(hover.Contents.Value, "**Patient** (`clinical`)") || !strings.Contains(hover.Contents.Value, "- `id`: `id` (required) — Logical id") {
+		t.Errorf("hover = %q", hover.Contents.Value)
+	}
+	if err := json.Unmarshal(msgs[4].Result, &hover); err != nil {
+		t.Fatalf("hover result: %v", err)
+	}
+	if !strings.Contains(hover.Contents.Value, "built-in datatype") || !strings.Contains(hover.Contents.Value, "list of `Period`") {
+		t.Errorf("hover = %q", hover.Contents.Value)
+	}
+	if string(msgs[5].Result) != "null" {
+		t.Errorf("definition outside a reference = %s, want null", msgs[5].Result)
+	}
+}
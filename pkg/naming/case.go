@@ -0,0 +1,120 @@
+package naming
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Case is a naming convention for generated identifiers.
+type Case string
+
+const (
+	Preserve Case = "preserve"
+	Snake    Case = "snake"
+	Camel    Case = "camel"
+	Pascal   Case = "pascal"
+	Lower    Case = "lower"
+)
+
+// ParseCase validates a configured case convention. The empty string is
+// accepted and means "use the generator default".
+func ParseCase(s string) (Case, error) {
+	switch c := Case(s); c {
+	case "", Preserve, Snake, Camel, Pascal, Lower:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown case convention %q (want preserve, snake, camel, pascal, or lower)", s)
+	}
+}
+
+// Apply converts s to the case convention.
+func (c Case) Apply(s string) string {
+	switch c {
+	case Snake:
+		return strings.ToLower(strings.Join(Words(s), "_"))
+	case Camel:
+		words := Words(s)
+		for i, w := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+			} else {
+				words[i] = capitalize(w)
+			}
+		}
+		return strings.Join(words, "")
+	case Pascal:
+		words := Words(s)
+		for i, w := range words {
+			words[i] = capitalize(w)
+		}
+		return strings.Join(words, "")
+	case Lower:
+		return strings.ToLower(s)
+	default:
+		return s
+	}
+}
+
+// Or returns the conversion for c, falling back to def when c is unset.
+func (c Case) Or(def func(string) string) func(string) string {
+	if c == "" {
+		return def
+	}
+	return c.Apply
+}
+
+// Words splits a name into words at separators and case transitions.
+//
+// Runs of capitals are treated as acronyms, and digits stay attached to the
+// word they follow:
+//
+//	HTTPStatus  -> HTTP Status
+//	MRNValue    -> MRN Value
+//	ICD10Code   -> ICD10 Code
+//	birthDate   -> birth Date
+func Words(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			flush()
+			continue
+		}
+
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			switch {
+			case unicode.IsLower(prev), unicode.IsDigit(prev):
+				// birthDate, ICD10Code
+				flush()
+			case unicode.IsUpper(prev) && nextLower:
+				// The last capital of an acronym starts the next word: HTTPStatus
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+func capitalize(w string) string {
+	r := []rune(strings.ToLower(w))
+	if len(r) == 0 {
+		return ""
+	}
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
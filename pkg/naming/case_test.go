@@ -0,0 +1,78 @@
+package naming
+
+import "testing"
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		// FHIR R4 element names
+		{"id", "id"},
+		{"resourceType", "resource_type"},
+		{"birthDate", "birth_date"},
+		{"deceasedBoolean", "deceased_boolean"},
+		{"deceasedDateTime", "deceased_date_time"},
+		{"multipleBirthInteger", "multiple_birth_integer"},
+		{"managingOrganization", "managing_organization"},
+		{"generalPractitioner", "general_practitioner"},
+		{"valueCodeableConcept", "value_codeable_concept"},
+		{"valueSampledData", "value_sampled_data"},
+		{"dataAbsentReason", "data_absent_reason"},
+		{"onsetDateTime", "onset_date_time"},
+		{"authoredOn", "authored_on"},
+		{"dosageInstruction", "dosage_instruction"},
+		{"occurrenceDateTime", "occurrence_date_time"},
+		{"vaccineCode", "vaccine_code"},
+		{"lotNumber", "lot_number"},
+		{"isSubpotent", "is_subpotent"},
+
+		// Acronyms and digits
+		{"HTTPStatus", "http_status"},
+		{"MRNValue", "mrn_value"},
+		{"ICD10Code", "icd10_code"},
+		{"diagnosisICD10Code", "diagnosis_icd10_code"},
+		{"isHIPAACompliant", "is_hipaa_compliant"},
+		{"patientSSN", "patient_ssn"},
+		{"URL", "url"},
+		{"LOINCCode2", "loinc_code2"},
+		{"2ndOpinion", "2nd_opinion"},
+
+		// Vendor column names and existing snake_case
+		{"PAT_MRN_ID", "pat_mrn_id"},
+		{"BIRTH_DATE", "birth_date"},
+		{"already_snake", "already_snake"},
+		{"in-progress", "in_progress"},
+		{"_source_system", "source_system"},
+		{"Patient", "patient"},
+		{"MedicationRequest", "medication_request"},
+		{"AllergyIntolerance", "allergy_intolerance"},
+	}
+
+	for _, tt := range tests {
+		if got := Snake.Apply(tt.in); got != tt.want {
+			t.Errorf("Snake.Apply(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCamelAndPascalCase(t *testing.T) {
+	tests := []struct {
+		in, camel, pascal string
+	}{
+		{"resource_type", "resourceType", "ResourceType"},
+		{"birthDate", "birthDate", "BirthDate"},
+		{"HTTPStatus", "httpStatus", "HttpStatus"},
+		{"ICD10Code", "icd10Code", "Icd10Code"},
+		{"PAT_MRN_ID", "patMrnId", "PatMrnId"},
+		{"_ingested_at", "ingestedAt", "IngestedAt"}, // audit fields
+	}
+
+	for _, tt := range tests {
+		if got := Camel.Apply(tt.in); got != tt.camel {
+			t.Errorf("Camel.Apply(%q) = %q, want %q", tt.in, got, tt.camel)
+		}
+		if got := Pascal.Apply(tt.in); got != tt.pascal {
+			t.Errorf("Pascal.Apply(%q) = %q, want %q", tt.in, got, tt.pascal)
+		}
+	}
+}
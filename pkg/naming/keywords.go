@@ -0,0 +1,96 @@
+package naming
+
+// keywords lists the reserved words of each target language that cannot be
+// used as plain identifiers.
+var keywords = map[string]map[string]bool{
+	"python": set(
+		"False", "None", "True", "and", "as", "assert", "async", "await",
+		"break", "class", "continue", "def", "del", "elif", "else", "except",
+		"finally", "for", "from", "global", "if", "import", "in", "is",
+		"lambda", "nonlocal", "not", "or", "pass", "raise", "return", "try",
+		"while", "with", "yield",
+	),
+	"go": set(
+		"break", "case", "chan", "const", "continue", "default", "defer",
+		"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+		"interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var",
+	),
+	"typescript": set(
+		"any", "as", "boolean", "break", "case", "catch", "class", "const",
+		"continue", "debugger", "declare", "default", "delete", "do", "else",
+		"enum", "export", "extends", "false", "finally", "for", "function",
+		"if", "implements", "import", "in", "instanceof", "interface", "let",
+		"new", "null", "number", "package", "private", "protected", "public",
+		"return", "static", "string", "super", "switch", "symbol", "this",
+		"throw", "true", "try", "type", "typeof", "var", "void", "while",
+		"with", "yield",
+	),
+	"java": set(
+		"_", "abstract", "assert", "boolean", "break", "byte", "case", "catch",
+		"char", "class", "const", "continue", "default", "do", "double",
+		"else", "enum", "extends", "false", "final", "finally", "float", "for",
+		"goto", "if", "implements", "import", "instanceof", "int", "interface",
+		"long", "native", "new", "null", "package", "private", "protected",
+		"public", "record", "return", "short", "static", "strictfp", "super",
+		"switch", "synchronized", "this", "throw", "throws", "transient",
+		"true", "try", "var", "void", "volatile", "while", "yield",
+	),
+	"kotlin": set(
+		"as", "break", "class", "continue", "do", "else", "false", "for",
+		"fun", "if", "in", "interface", "is", "null", "object", "package",
+		"return", "super", "this", "throw", "true", "try", "typealias",
+		"typeof", "val", "var", "when", "while",
+	),
+	"scala": set(
+		"abstract", "case", "catch", "class", "def", "do", "else", "enum",
+		"export", "extends", "false", "final", "finally", "for", "forSome",
+		"given", "if", "implicit", "import", "lazy", "macro", "match", "new",
+		"null", "object", "override", "package", "private", "protected",
+		"return", "sealed", "super", "then", "this", "throw", "trait", "true",
+		"try", "type", "val", "var", "while", "with", "yield",
+	),
+	"rust": set(
+		"Self", "abstract", "as", "async", "await", "become", "box", "break",
+		"const", "continue", "crate", "do", "dyn", "else", "enum", "extern",
+		"false", "final", "fn", "for", "if", "impl", "in", "let", "loop",
+		"macro", "match", "mod", "move", "mut", "override", "priv", "pub",
+		"ref", "return", "self", "static", "struct", "super", "trait", "true",
+		"try", "type", "typeof", "unsafe", "unsized", "use", "virtual",
+		"where", "while", "yield",
+	),
+	"csharp": set(
+		"abstract", "as", "base", "bool", "break", "byte", "case", "catch",
+		"char", "checked", "class", "const", "continue", "decimal", "default",
+		"delegate", "do", "double", "else", "enum", "event", "explicit",
+		"extern", "false", "finally", "fixed", "float", "for", "foreach",
+		"goto", "if", "implicit", "in", "int", "interface", "internal", "is",
+		"lock", "long", "namespace", "new", "null", "object", "operator",
+		"out", "override", "params", "private", "protected", "public",
+		"readonly", "ref", "return", "sbyte", "sealed", "short", "sizeof",
+		"stackalloc", "static", "string", "struct", "switch", "this", "throw",
+		"true", "try", "typeof", "uint", "ulong", "unchecked", "unsafe",
+		"ushort", "using", "virtual", "void", "volatile", "while",
+	),
+	"sql": set(
+		"all", "and", "any", "array", "as", "asc", "between", "both", "by",
+		"case", "cast", "check", "collate", "column", "constraint", "create",
+		"cross", "current_date", "current_time", "current_timestamp",
+		"current_user", "default", "desc", "distinct", "do", "else", "end",
+		"except", "false", "fetch", "for", "foreign", "from", "grant",
+		"group", "having", "in", "inner", "intersect", "into", "is", "join",
+		"leading", "left", "like", "limit", "natural", "not", "null",
+		"offset", "on", "only", "or", "order", "outer", "primary",
+		"references", "right", "select", "session_user", "some", "table",
+		"then", "to", "trailing", "true", "union", "unique", "user", "using",
+		"when", "where", "window", "with",
+	),
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
@@ -0,0 +1,251 @@
+// Package naming turns schema names into valid identifiers for each target language.
+//
+// Generators first apply their case convention (snake_case, camelCase, ...)
+// and then pass the result through this package, which replaces characters
+// the language cannot represent, guards against leading digits, escapes
+// reserved words in the language's idiomatic way, and resolves collisions
+// between names that only differed before case conversion.
+package naming
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// IsKeyword reports whether name is a reserved word in lang.
+func IsKeyword(lang, name string) bool {
+	if lang == "sql" {
+		name = strings.ToLower(name)
+	}
+	return keywords[lang][name]
+}
+
+// Sanitize replaces characters that are not valid in identifiers with
+// underscores and prefixes names that would start with a digit.
+//
+// Unicode letters and digits are kept, since every target language accepts
+// them in identifiers.
+func Sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	s := b.String()
+	if s == "" {
+		return "unnamed"
+	}
+	if unicode.IsDigit([]rune(s)[0]) {
+		s = "_" + s
+	}
+	return s
+}
+
+// Ident returns name as a valid identifier in lang.
+//
+// Go identifiers are additionally made exported, since generated types and
+// fields must be visible to encoding/json.
+func Ident(lang, name string) string {
+	return escape(lang, sanitize(lang, name))
+}
+
+// Member returns name as a valid member (field or property) name in lang.
+//
+// It matches Ident except for TypeScript, where reserved words are legal
+// property names and invalid identifiers are quoted rather than rewritten so
+// the property keeps its wire name.
+func Member(lang, name string) string {
+	if lang == "typescript" {
+		if isPlainIdent(name) {
+			return name
+		}
+		return strconv.Quote(name)
+	}
+	return Ident(lang, name)
+}
+
+// IsValid reports whether name can be used unescaped as an identifier in lang.
+func IsValid(lang, name string) bool {
+	return isPlainIdent(name) && !IsKeyword(lang, name)
+}
+
+func sanitize(lang, name string) string {
+	s := Sanitize(name)
+	if lang == "go" {
+		if first := []rune(s)[0]; !unicode.IsUpper(first) {
+			if unicode.IsLower(first) {
+				return string(unicode.ToUpper(first)) + s[len(string(first)):]
+			}
+			s = "X" + s
+		}
+	}
+	return s
+}
+
+func escape(lang, name string) string {
+	if !IsKeyword(lang, name) {
+		return name
+	}
+
+	switch lang {
+	case "kotlin", "scala":
+		return "`" + name + "`"
+	case "csharp":
+		return "@" + name
+	case "rust":
+		switch name {
+		case "self", "Self", "super", "crate":
+			return name + "_"
+		}
+		return "r#" + name
+	case "sql":
+		return `"` + name + `"`
+	default:
+		return name + "_"
+	}
+}
+
+func isPlainIdent(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// Scope hands out identifiers that are unique within one declaration scope,
+// such as the fields of a class.
+type Scope struct {
+	lang   string
+	member bool
+	used   map[string]bool
+}
+
+// NewScope creates a scope for lang. Reserved names, such as the enclosing
+// type name in C#, are never handed out.
+func NewScope(lang string, reserved ...string) *Scope {
+	s := &Scope{lang: lang, used: make(map[string]bool)}
+	for _, r := range reserved {
+		s.used[s.key(r)] = true
+	}
+	return s
+}
+
+// NewMemberScope creates a scope whose identifiers follow Member rules.
+func NewMemberScope(lang string, reserved ...string) *Scope {
+	s := NewScope(lang, reserved...)
+	s.member = true
+	return s
+}
+
+// Ident returns a valid identifier for name that is not yet used in the scope.
+// Collisions are resolved by appending _2, _3, ...
+func (s *Scope) Ident(name string) string {
+	ident := s.convert(name)
+	for n := 2; s.used[s.key(ident)]; n++ {
+		ident = s.convert(name + "_" + strconv.Itoa(n))
+	}
+	s.used[s.key(ident)] = true
+	return ident
+}
+
+func (s *Scope) convert(name string) string {
+	if s.member {
+		return Member(s.lang, name)
+	}
+	return Ident(s.lang, name)
+}
+
+func (s *Scope) key(ident string) string {
+	// Unquoted SQL identifiers are case-insensitive
+	if s.lang == "sql" {
+		return strings.ToLower(strings.Trim(ident, `"`))
+	}
+	return ident
+}
+
+// Field is a schema field paired with its identifier in a target language
+// and, once Wire has set it, its name in JSON.
+type Field struct {
+	schema.Field
+	Ident string
+	Wire  string
+}
+
+// Fields resolves unique member identifiers for fields, applying convert
+// (the generator's case convention) to each field name first. A field's
+// code_name, when set, is used as written instead of the converted name.
+func Fields(lang string, fields []schema.Field, convert func(string) string, reserved ...string) []Field {
+	scope := NewMemberScope(lang, reserved...)
+	result := make([]Field, len(fields))
+	for i, f := range fields {
+		name := convert(f.Name)
+		if f.CodeName != "" {
+			name = f.CodeName
+		}
+		result[i] = Field{Field: f, Ident: scope.Ident(name)}
+	}
+	return result
+}
+
+// Wire returns fields with their names in JSON: each field's json_name, or
+// else its name converted by convert. Converted names that clash with each
+// other or with a json_name, as priorityCode and priority_code do in
+// camelCase, are made unique by appending _2, _3, ... as Scope does.
+func Wire(fields []Field, convert func(string) string) []Field {
+	used := make(map[string]bool)
+	for _, f := range fields {
+		if f.JSONName != "" {
+			used[f.JSONName] = true
+		}
+	}
+	result := make([]Field, len(fields))
+	for i, f := range fields {
+		f.Wire = f.JSONName
+		if f.Wire == "" {
+			name := convert(f.Name)
+			f.Wire = name
+			for n := 2; used[f.Wire]; n++ {
+				f.Wire = name + "_" + strconv.Itoa(n)
+			}
+			used[f.Wire] = true
+		}
+		result[i] = f
+	}
+	return result
+}
+
+// Stored returns the fields that records hold, leaving out derived ones.
+// Generators resolve identifiers for all fields first, so that those of
+// the computed members of derived fields do not clash with them.
+func Stored(fields []Field) []Field {
+	var stored []Field
+	for _, f := range fields {
+		if f.Derived == "" {
+			stored = append(stored, f)
+		}
+	}
+	return stored
+}
+
+// ByName indexes field identifiers by schema field name, for templates
+// that refer to known fields directly.
+func ByName(fields []Field) map[string]string {
+	idents := make(map[string]string, len(fields))
+	for _, f := range fields {
+		idents[f.Name] = f.Ident
+	}
+	return idents
+}
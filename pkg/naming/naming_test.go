@@ -0,0 +1,157 @@
+package naming
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"birth_date", "birth_date"},
+		{"in-progress", "in_progress"},
+		{"value[x]", "value_x_"},
+		{"2ndOpinion", "_2ndOpinion"},
+		{"température", "température"},
+		{"检验结果", "检验结果"},
+		{"", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIdent(t *testing.T) {
+	tests := []struct {
+		lang, in, want string
+	}{
+		{"python", "class", "class_"},
+		{"python", "None", "None_"},
+		{"python", "type", "type"},
+		{"go", "type", "Type"},
+		{"go", "_id", "X_id"},
+		{"go", "2nd", "X_2nd"},
+		{"java", "class", "class_"},
+		{"java", "record", "record_"},
+		{"kotlin", "for", "`for`"},
+		{"scala", "match", "`match`"},
+		{"scala", "type", "`type`"},
+		{"csharp", "class", "@class"},
+		{"rust", "type", "r#type"},
+		{"rust", "self", "self_"},
+		{"rust", "Self", "Self_"},
+		{"sql", "order", `"order"`},
+		{"sql", "ORDER", `"ORDER"`},
+		{"typescript", "class", "class_"},
+		{"typescript", "in-progress", "in_progress"},
+	}
+	for _, tt := range tests {
+		if got := Ident(tt.lang, tt.in); got != tt.want {
+			t.Errorf("Ident(%s, %q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMember(t *testing.T) {
+	tests := []struct {
+		lang, in, want string
+	}{
+		{"typescript", "class", "class"},
+		{"typescript", "2ndOpinion", `"2ndOpinion"`},
+		{"typescript", "value-x", `"value-x"`},
+		{"python", "class", "class_"},
+	}
+	for _, tt := range tests {
+		if got := Member(tt.lang, tt.in); got != tt.want {
+			t.Errorf("Member(%s, %q) = %q, want %q", tt.lang, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		reserved []string
+		in       []string
+		want     []string
+	}{
+		{
+			name: "case conversion collisions",
+			lang: "python",
+			in:   []string{"priority_code", "priority_code", "priority_code"},
+			want: []string{"priority_code", "priority_code_2", "priority_code_3"},
+		},
+		{
+			name:     "reserved names",
+			lang:     "csharp",
+			reserved: []string{"Patient"},
+			in:       []string{"Patient", "Name"},
+			want:     []string{"Patient_2", "Name"},
+		},
+		{
+			name: "keywords escape before colliding",
+			lang: "python",
+			in:   []string{"class", "class_"},
+			want: []string{"class_", "class__2"},
+		},
+		{
+			name: "sql is case-insensitive",
+			lang: "sql",
+			in:   []string{"mrn", "MRN"},
+			want: []string{"mrn", "MRN_2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := NewScope(tt.lang, tt.reserved...)
+			var got []string
+			for _, name := range tt.in {
+				got = append(got, scope.Ident(name))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ident() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields := []schema.Field{
+		{Name: "priorityCode"},
+		{Name: "priority_code"},
+		{Name: "class"},
+		{Name: "mrn", CodeName: "medicalRecordNumber"},
+	}
+	var got []string
+	for _, f := range Fields("kotlin", fields, Camel.Apply) {
+		got = append(got, f.Ident)
+	}
+	want := []string{"priorityCode", "priorityCode_2", "`class`", "medicalRecordNumber"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() idents = %q, want %q", got, want)
+	}
+}
+
+func TestWire(t *testing.T) {
+	fields := Fields("csharp", []schema.Field{
+		{Name: "priorityCode"},
+		{Name: "priority_code"},
+		{Name: "birthDate"},
+		{Name: "mrn", JSONName: "medicalRecordNumber"},
+		{Name: "medical_record_number"},
+	}, Pascal.Apply)
+	var got []string
+	for _, f := range Wire(fields, Camel.Apply) {
+		got = append(got, f.Wire)
+	}
+	want := []string{"priorityCode", "priorityCode_2", "birthDate", "medicalRecordNumber", "medicalRecordNumber_2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wire() = %q, want %q", got, want)
+	}
+}
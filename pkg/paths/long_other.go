@@ -0,0 +1,8 @@
+//go:build !windows
+
+package paths
+
+// Long returns path unchanged; only Windows limits path length.
+func Long(path string) string {
+	return path
+}
@@ -0,0 +1,24 @@
+package paths
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Long returns the extended-length (\\?\) form of path, so that files deeper
+// than MAX_PATH, as in the package trees of large implementation guides, can
+// be created. The form is absolute; paths that cannot be made absolute are
+// returned unchanged.
+func Long(path string) string {
+	if strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if unc, ok := strings.CutPrefix(abs, `\\`); ok {
+		return `\\?\UNC\` + unc
+	}
+	return `\\?\` + abs
+}
@@ -0,0 +1,33 @@
+// Package paths handles file paths portably: paths from configuration files,
+// which are written with forward slashes on every OS, and the extended-length
+// paths Windows needs for deep output trees.
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FromConfig converts a slash-separated path from a configuration file to
+// the OS form.
+func FromConfig(path string) string {
+	return filepath.FromSlash(path)
+}
+
+// Files returns the paths of the files in dir whose names end in ext, sorted
+// by name. Unlike filepath.Glob, it does not read characters of dir such as [
+// as pattern syntax.
+func Files(dir, ext string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ext) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,35 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	// Brackets would make filepath.Glob read the directory as a pattern.
+	dir := filepath.Join(t.TempDir(), "guide[r4]")
+	if err := os.MkdirAll(filepath.Join(dir, "nested.yaml"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"b.yaml", "a.yaml", "notes.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := Files(dir, ".yaml")
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %v, want %v", got, want)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	if got, want := FromConfig("build/generated"), filepath.Join("build", "generated"); got != want {
+		t.Errorf("FromConfig() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,283 @@
+// Package provenance records how a generated output tree was produced and
+// detects generated files that were edited by hand or that no longer match
+// their schemas.
+package provenance
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/konzy/ehrglot/pkg/header"
+	"github.com/konzy/ehrglot/pkg/paths"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// ManifestFile is the sidecar manifest written at the root of generated output.
+const ManifestFile = ".ehrglot-manifest.json"
+
+// timestampPattern matches the generation timestamps embedded in file headers.
+var timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})`)
+
+// Normalize replaces volatile content such as generation timestamps, so that
+// regenerating unchanged schemas yields identical content.
+func Normalize(data []byte) []byte {
+	return timestampPattern.ReplaceAll(data, []byte("<timestamp>"))
+}
+
+// Manifest records the provenance of a generated output tree.
+type Manifest struct {
+	// Generator is the ehrglot version that produced the output.
+	Generator string `json:"generator"`
+	// Language is the canonical target language.
+	Language string `json:"language"`
+	// Package reports whether package manifests were written as well.
+	Package bool `json:"package,omitempty"`
+	// Schemas maps namespace/Name to the content hash of each source schema.
+	Schemas map[string]string `json:"schemas"`
+	// Files maps slash-separated paths to the SHA-256 of their normalized
+	// content.
+	Files map[string]string `json:"files"`
+}
+
+// Build records the provenance of the generated files in dir.
+func Build(dir, version, lang string, pkg bool, schemas []schema.Schema) (*Manifest, error) {
+	m := &Manifest{
+		Generator: version,
+		Language:  lang,
+		Package:   pkg,
+		Schemas:   make(map[string]string),
+	}
+	for _, s := range schemas {
+		m.Schemas[s.Namespace+"/"+s.GetName()] = header.Hash(s)
+	}
+
+	files, err := hashTree(dir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash generated files: %w", err)
+	}
+	m.Files = files
+	return m, nil
+}
+
+// hashFile hashes the normalized content of path a line at a time, which
+// gives the same sum as hashing Normalize of the whole file since timestamps
+// never span lines.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		h.Write(Normalize(line))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Write saves m as the manifest of dir.
+func (m *Manifest) Write(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// Read loads the manifest of dir.
+func Read(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no %s in %s; regenerate the code with ehrglot generate", ManifestFile, dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Kinds of Problem.
+const (
+	// Edited files were changed after they were generated.
+	Edited = "edited"
+	// Missing files were generated but have since been deleted.
+	Missing = "missing"
+	// Stale files would be generated differently from the current schemas.
+	Stale = "stale"
+	// Added files would be generated from the current schemas but are absent.
+	Added = "added"
+	// Removed files would no longer be generated from the current schemas.
+	Removed = "removed"
+)
+
+// Problem is a generated file that does not match its provenance.
+type Problem struct {
+	Kind string
+	Path string
+}
+
+func (p Problem) String() string {
+	return p.Kind + ": " + p.Path
+}
+
+// Check compares the files in dir with the hashes recorded when they were
+// generated, reporting Edited and Missing files.
+func (m *Manifest) Check(dir string) ([]Problem, error) {
+	dir = paths.Long(dir)
+	var problems []Problem
+	for _, path := range sortedKeys(m.Files) {
+		sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(path)))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			problems = append(problems, Problem{Kind: Missing, Path: path})
+		case err != nil:
+			return nil, fmt.Errorf("failed to hash %s: %w", path, err)
+		case sum != m.Files[path]:
+			problems = append(problems, Problem{Kind: Edited, Path: path})
+		}
+	}
+	return problems, nil
+}
+
+// Drift compares the recorded output with fresh, the manifest of output
+// regenerated from the current schemas, reporting Stale, Added, and Removed
+// files.
+func (m *Manifest) Drift(fresh *Manifest) []Problem {
+	var problems []Problem
+	for _, path := range sortedKeys(fresh.Files) {
+		sum, ok := m.Files[path]
+		switch {
+		case !ok:
+			problems = append(problems, Problem{Kind: Added, Path: path})
+		case sum != fresh.Files[path]:
+			problems = append(problems, Problem{Kind: Stale, Path: path})
+		}
+	}
+	for _, path := range sortedKeys(m.Files) {
+		if _, ok := fresh.Files[path]; !ok {
+			problems = append(problems, Problem{Kind: Removed, Path: path})
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems
+}
+
+// ChangedSchemas returns the namespace/Name of schemas that were added,
+// removed, or changed since m was recorded, sorted.
+func (m *Manifest) ChangedSchemas(fresh *Manifest) []string {
+	var changed []string
+	for name, sum := range fresh.Schemas {
+		if m.Schemas[name] != sum {
+			changed = append(changed, name)
+		}
+	}
+	for name := range m.Schemas {
+		if _, ok := fresh.Schemas[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Compare reports how the generated code in outputDir differs from code
+// freshly generated into freshDir: Stale files differ, Added files are
+// absent from outputDir, and Removed files are no longer generated. Only
+// files under dirs (relative to both directories) are compared, or all files
+// when dirs is empty. Files in outputDir count as Removed only when they share
+// an extension with generated files, so build artifacts are not reported.
+func Compare(freshDir, outputDir string, dirs []string) ([]Problem, error) {
+	fresh, err := hashTree(freshDir, dirs)
+	if err != nil {
+		return nil, err
+	}
+	current, err := hashTree(outputDir, dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := make(map[string]bool)
+	for path := range fresh {
+		extensions[filepath.Ext(path)] = true
+	}
+	for path := range current {
+		if !extensions[filepath.Ext(path)] {
+			delete(current, path)
+		}
+	}
+	return (&Manifest{Files: current}).Drift(&Manifest{Files: fresh}), nil
+}
+
+// hashTree hashes the files under the given subdirectories of dir.
+func hashTree(dir string, dirs []string) (map[string]string, error) {
+	dir = paths.Long(dir)
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	files := make(map[string]string)
+	for _, sub := range dirs {
+		root := filepath.Join(dir, filepath.FromSlash(sub))
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, os.ErrNotExist) && path == root {
+				return nil
+			}
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			if rel == ManifestFile {
+				return nil
+			}
+			sum, err := hashFile(path)
+			if err != nil {
+				return err
+			}
+			files[rel] = sum
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", root, err)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,101 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"fhir/patient.py":   "# Generated at 2024-01-02T03:04:05Z\nclass Patient: ...\n",
+		"fhir/encounter.py": "class Encounter: ...\n",
+		"fhir/__init__.py":  "",
+	})
+	schemas := []schema.Schema{{Name: "Patient", Namespace: "fhir"}}
+	m, err := Build(dir, "0.1.0", "python", false, schemas)
+	if err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	if err := m.Write(dir); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	m, err = Read(dir)
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if len(m.Files) != 3 || m.Schemas["fhir/Patient"] == "" {
+		t.Fatalf("Read() = %+v, want 3 files and the Patient schema", m)
+	}
+
+	// Regeneration only moves the timestamp
+	writeFiles(t, dir, map[string]string{"fhir/patient.py": "# Generated at 2025-06-07T08:09:10+02:00\nclass Patient: ...\n"})
+	if problems, err := m.Check(dir); err != nil || len(problems) != 0 {
+		t.Fatalf("Check() = %v, %v, want no problems", problems, err)
+	}
+
+	writeFiles(t, dir, map[string]string{"fhir/encounter.py": "class Encounter: pass\n"})
+	if err := os.Remove(filepath.Join(dir, "fhir", "__init__.py")); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := m.Check(dir)
+	if err != nil {
+		t.Fatalf("Check() failed: %v", err)
+	}
+	want := []Problem{{Kind: Missing, Path: "fhir/__init__.py"}, {Kind: Edited, Path: "fhir/encounter.py"}}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Check() = %v, want %v", problems, want)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	recorded := &Manifest{
+		Schemas: map[string]string{"fhir/Patient": "1", "fhir/Visit": "2"},
+		Files:   map[string]string{"a.py": "1", "b.py": "2", "c.py": "3"},
+	}
+	fresh := &Manifest{
+		Schemas: map[string]string{"fhir/Patient": "1", "fhir/Encounter": "3"},
+		Files:   map[string]string{"a.py": "1", "b.py": "changed", "d.py": "4"},
+	}
+
+	want := []Problem{{Kind: Stale, Path: "b.py"}, {Kind: Removed, Path: "c.py"}, {Kind: Added, Path: "d.py"}}
+	if got := recorded.Drift(fresh); !reflect.DeepEqual(got, want) {
+		t.Errorf("Drift() = %v, want %v", got, want)
+	}
+	if got, want := recorded.ChangedSchemas(fresh), []string{"fhir/Encounter", "fhir/Visit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ChangedSchemas() = %v, want %v", got, want)
+	}
+}
+
+func TestHashFileMatchesNormalize(t *testing.T) {
+	dir := t.TempDir()
+	content := "# Generated at 2024-01-02T03:04:05.123+02:00\nclass Patient: ...\n# 2025-06-07T08:09:10Z"
+	writeFiles(t, dir, map[string]string{"patient.py": content})
+	got, err := hashFile(filepath.Join(dir, "patient.py"))
+	if err != nil {
+		t.Fatalf("hashFile() failed: %v", err)
+	}
+	sum := sha256.Sum256(Normalize([]byte(content)))
+	if want := hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("hashFile() = %s, want %s", got, want)
+	}
+}
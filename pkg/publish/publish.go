@@ -0,0 +1,196 @@
+// Package publish builds generated packages and uploads them to artifact
+// repositories with the language's own tooling.
+package publish
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+)
+
+// Environment variables that supply credentials not set in the configuration.
+const (
+	UsernameEnv = "EHRGLOT_REPO_USERNAME"
+	PasswordEnv = "EHRGLOT_REPO_PASSWORD"
+)
+
+// Languages lists the canonical names of the languages that can be published.
+var Languages = []string{"python", "typescript", "java", "kotlin"}
+
+// Command is one step of building or uploading a package. Arguments
+// containing * are expanded as file globs relative to the package directory
+// when the command runs.
+type Command struct {
+	Name string
+	Args []string
+	// Env holds extra NAME=value environment entries, used for credentials so
+	// that they never appear in arguments or files.
+	Env []string
+}
+
+// String formats c for display, naming but not showing its environment.
+func (c Command) String() string {
+	var parts []string
+	for _, e := range c.Env {
+		name, _, _ := strings.Cut(e, "=")
+		parts = append(parts, name+"=***")
+	}
+	parts = append(parts, c.Name)
+	parts = append(parts, c.Args...)
+	return strings.Join(parts, " ")
+}
+
+// Plan is the work needed to publish one package directory.
+type Plan struct {
+	// Files are written into the package directory before the commands run,
+	// keyed by relative path.
+	Files    map[string]string
+	Commands []Command
+}
+
+// New plans publishing the package generated for a canonical language name
+// to repo, with build, the config.BuildTool of the language, for Java and
+// Kotlin. Empty credentials fall back to UsernameEnv and PasswordEnv.
+func New(lang, build string, repo config.Repository) (*Plan, error) {
+	if repo.URL == "" {
+		return nil, fmt.Errorf("no repository configured for %s (set --repo or languages.%s.publish.url)", lang, lang)
+	}
+	username := expand(repo.Username, UsernameEnv)
+	password := expand(repo.Password, PasswordEnv)
+
+	switch lang {
+	case "python":
+		if username == "" {
+			// PyPI API tokens authenticate as the __token__ user
+			username = "__token__"
+		}
+		return &Plan{Commands: []Command{
+			{Name: "python3", Args: []string{"-m", "build", "--outdir", "dist", "."}},
+			{
+				Name: "python3",
+				Args: []string{"-m", "twine", "upload", "--non-interactive", "--repository-url", repo.URL, "dist/*"},
+				Env:  []string{"TWINE_USERNAME=" + username, "TWINE_PASSWORD=" + password},
+			},
+		}}, nil
+	case "typescript":
+		registry, err := url.Parse(repo.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL %q: %w", repo.URL, err)
+		}
+		// npm matches auth entries on the registry URL without its scheme
+		authKey := "//" + registry.Host + strings.TrimSuffix(registry.Path, "/") + "/:_authToken"
+		return &Plan{
+			Files: map[string]string{".npmrc": authKey + "=${" + PasswordEnv + "}\n"},
+			Commands: []Command{
+				{Name: "npm", Args: []string{"install", "--no-audit", "--no-fund"}},
+				{Name: "npm", Args: []string{"publish", "--registry", repo.URL}, Env: []string{PasswordEnv + "=" + password}},
+			},
+		}, nil
+	case "java", "kotlin":
+		if build == config.BuildGradle {
+			// The generated build reads the credentials from the environment
+			return &Plan{Commands: []Command{{
+				Name: "gradle",
+				Args: []string{"--no-daemon", "publish", "-PehrglotRepository=" + repo.URL},
+				Env:  []string{UsernameEnv + "=" + username, PasswordEnv + "=" + password},
+			}}}, nil
+		}
+		return &Plan{
+			Files: map[string]string{".ehrglot-settings.xml": mavenSettings},
+			Commands: []Command{{
+				Name: "mvn",
+				Args: []string{"-B", "-s", ".ehrglot-settings.xml", "deploy", "-DaltDeploymentRepository=ehrglot::" + repo.URL},
+				Env:  []string{UsernameEnv + "=" + username, PasswordEnv + "=" + password},
+			}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("publishing is not supported for %s (want %s)", lang, strings.Join(Languages, ", "))
+	}
+}
+
+// mavenSettings declares the deployment server, reading credentials from the
+// environment Maven runs in.
+const mavenSettings = `<settings>
+  <servers>
+    <server>
+      <id>ehrglot</id>
+      <username>${env.` + UsernameEnv + `}</username>
+      <password>${env.` + PasswordEnv + `}</password>
+    </server>
+  </servers>
+</settings>
+`
+
+func expand(value, fallbackEnv string) string {
+	if value == "" {
+		return os.Getenv(fallbackEnv)
+	}
+	return os.ExpandEnv(value)
+}
+
+// Run writes the plan's files into dir and runs its commands there, copying
+// their output to out.
+func (p *Plan) Run(dir string, out io.Writer) error {
+	paths := make([]string, 0, len(p.Files))
+	for path := range p.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(p.Files[path]), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	for _, c := range p.Commands {
+		if _, err := exec.LookPath(c.Name); err != nil {
+			return fmt.Errorf("%s not found on PATH", c.Name)
+		}
+		args, err := glob(dir, c.Args)
+		if err != nil {
+			return err
+		}
+
+		cmd := exec.Command(c.Name, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), c.Env...)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s %s failed: %w", c.Name, strings.Join(c.Args, " "), err)
+		}
+	}
+	return nil
+}
+
+func glob(dir string, args []string) ([]string, error) {
+	var expanded []string
+	for _, arg := range args {
+		if !strings.Contains(arg, "*") {
+			expanded = append(expanded, arg)
+			continue
+		}
+		// Match within dir, so that characters of dir such as [ are not read
+		// as pattern syntax; patterns are slash-separated on every OS
+		matches, err := fs.Glob(os.DirFS(dir), path.Clean(filepath.ToSlash(arg)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", arg)
+		}
+		for _, m := range matches {
+			expanded = append(expanded, filepath.FromSlash(m))
+		}
+	}
+	return expanded, nil
+}
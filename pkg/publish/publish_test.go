@@ -0,0 +1,80 @@
+package publish
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Setenv("PYPI_TOKEN", "pypi-secret")
+	t.Setenv(UsernameEnv, "deployer")
+	t.Setenv(PasswordEnv, "fallback-secret")
+
+	tests := []struct {
+		lang     string
+		build    string
+		repo     config.Repository
+		wantEnv  []string
+		wantFile string
+	}{
+		{
+			lang:    "python",
+			repo:    config.Repository{URL: "https://pypi.example.com/legacy/", Password: "${PYPI_TOKEN}"},
+			wantEnv: []string{"TWINE_USERNAME=deployer", "TWINE_PASSWORD=pypi-secret"},
+		},
+		{
+			lang:     "typescript",
+			repo:     config.Repository{URL: "https://npm.example.com/repo/"},
+			wantEnv:  []string{PasswordEnv + "=fallback-secret"},
+			wantFile: "//npm.example.com/repo/:_authToken=${" + PasswordEnv + "}\n",
+		},
+		{
+			lang:     "java",
+			repo:     config.Repository{URL: "https://maven.example.com/releases", Username: "ci", Password: "maven-secret"},
+			wantEnv:  []string{UsernameEnv + "=ci", PasswordEnv + "=maven-secret"},
+			wantFile: mavenSettings,
+		},
+		{
+			lang:    "kotlin",
+			build:   config.BuildGradle,
+			repo:    config.Repository{URL: "https://maven.example.com/releases", Password: "maven-secret"},
+			wantEnv: []string{UsernameEnv + "=deployer", PasswordEnv + "=maven-secret"},
+		},
+	}
+	for _, tt := range tests {
+		plan, err := New(tt.lang, tt.build, tt.repo)
+		if err != nil {
+			t.Fatalf("New(%s) failed: %v", tt.lang, err)
+		}
+
+		last := plan.Commands[len(plan.Commands)-1]
+		if !reflect.DeepEqual(last.Env, tt.wantEnv) {
+			t.Errorf("New(%s) upload env = %v, want %v", tt.lang, last.Env, tt.wantEnv)
+		}
+		if !strings.Contains(strings.Join(last.Args, " "), tt.repo.URL) {
+			t.Errorf("New(%s) upload args %v do not name %s", tt.lang, last.Args, tt.repo.URL)
+		}
+		for _, c := range plan.Commands {
+			if s := c.String() + strings.Join(c.Args, " "); strings.Contains(s, "secret") {
+				t.Errorf("New(%s) command %q exposes a credential", tt.lang, s)
+			}
+		}
+		for _, content := range plan.Files {
+			if content != tt.wantFile {
+				t.Errorf("New(%s) file = %q, want %q", tt.lang, content, tt.wantFile)
+			}
+		}
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New("python", "", config.Repository{}); err == nil || !strings.Contains(err.Error(), "--repo") {
+		t.Errorf("New() without URL error = %v, want missing repository", err)
+	}
+	if _, err := New("rust", "", config.Repository{URL: "https://example.com"}); err == nil {
+		t.Error("New(rust) succeeded, want unsupported language")
+	}
+}
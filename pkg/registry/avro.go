@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// defaultPrecision and defaultScale size decimals that declare no precision,
+// as the SQL generator's DECIMAL(18, 6) does.
+const (
+	defaultPrecision = 18
+	defaultScale     = 6
+)
+
+// null is the default of optional fields.
+var null = json.RawMessage("null")
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Doc       string      `json:"doc,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Doc     string          `json:"doc,omitempty"`
+	Type    any             `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// avroBuilder writes one self-contained Avro schema. Schemas it refers to are
+// defined where they are first used and named after that, which also ends
+// cycles between them.
+type avroBuilder struct {
+	resolver *resolve.Resolver
+	opts     Options
+	defined  map[string]bool
+}
+
+// avro returns the Avro record schema of s, with the schemas and datatypes
+// its fields refer to defined inline. Optional fields are unions with null
+// that default to null. Enums become strings, since FHIR codes such as
+// entered-in-error are not Avro symbols, and types that resolve to no schema
+// hold their JSON text.
+func avro(r *resolve.Resolver, s schema.Schema, opts Options) ([]byte, error) {
+	b := &avroBuilder{resolver: r, opts: opts, defined: make(map[string]bool)}
+	return marshal(b.record(r.Scope(s.Namespace), s.Namespace, s.GetName(), s.Description, s.StoredFields()))
+}
+
+func (b *avroBuilder) record(scope resolve.Scope, namespace, name, doc string, fields []schema.Field) avroRecord {
+	b.defined[namespace+"."+name] = true
+	rec := avroRecord{Type: "record", Name: name, Namespace: namespace, Doc: doc, Fields: []avroField{}}
+	for _, f := range fields {
+		field := avroField{Name: f.Name, Doc: f.Description, Type: b.fieldType(scope, namespace, name, f)}
+		if !f.Required {
+			field.Type = []any{"null", field.Type}
+			field.Default = null
+		}
+		rec.Fields = append(rec.Fields, field)
+	}
+	return rec
+}
+
+func (b *avroBuilder) fieldType(scope resolve.Scope, namespace, parent string, f schema.Field) any {
+	elem, list := strings.CutPrefix(f.Type, "[]")
+	var t any
+	switch target, ok := scope.Lookup(elem); {
+	case len(f.Children) > 0:
+		t = b.record(scope, namespace, parent+naming.Pascal.Apply(f.Name), f.Description, f.Children)
+	case ok:
+		fullName := target.Namespace + "." + target.GetName()
+		if b.defined[fullName] {
+			t = fullName
+		} else {
+			t = b.record(b.resolver.Scope(target.Namespace), target.Namespace, target.GetName(), target.Description, target.StoredFields())
+		}
+	default:
+		t = b.primitive(f, elem)
+	}
+	if list {
+		return map[string]any{"type": "array", "items": t}
+	}
+	return t
+}
+
+func (b *avroBuilder) primitive(f schema.Field, fieldType string) any {
+	switch fieldType {
+	case "integer", "positiveInt", "unsignedInt":
+		return "int"
+	case "decimal":
+		precision, scale := f.Precision, f.Scale
+		if precision == 0 {
+			precision, scale = defaultPrecision, defaultScale
+		}
+		return map[string]any{"type": "bytes", "logicalType": "decimal", "precision": precision, "scale": scale}
+	case "boolean":
+		return "boolean"
+	case "date":
+		if b.opts.PartialDates {
+			// YYYY, YYYY-MM, or YYYY-MM-DD
+			return "string"
+		}
+		return map[string]any{"type": "int", "logicalType": "date"}
+	case "datetime", "dateTime", "instant":
+		if b.opts.OffsetInstants {
+			// ISO 8601 text keeps the offset a timestamp would lose
+			return "string"
+		}
+		return map[string]any{"type": "long", "logicalType": "timestamp-millis"}
+	case "base64Binary":
+		return "bytes"
+	}
+	return "string"
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/validate"
+)
+
+// jsonSchemaDialect is the JSON Schema version documents are written in.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaBuilder writes one JSON Schema document, collecting the schemas
+// its fields refer to under $defs.
+type jsonSchemaBuilder struct {
+	resolver *resolve.Resolver
+	defs     map[string]any
+}
+
+// jsonSchema returns a JSON Schema document for records of s, checking the
+// same required fields, enums, and value patterns as ehrglot validate-data.
+// The schemas and datatypes its fields refer to are defined under $defs as
+// namespace.Name.
+func jsonSchema(r *resolve.Resolver, s schema.Schema) ([]byte, error) {
+	b := &jsonSchemaBuilder{resolver: r, defs: make(map[string]any)}
+	doc := b.object(r.Scope(s.Namespace), s.Description, s.StoredFields())
+	doc["$schema"] = jsonSchemaDialect
+	doc["title"] = s.GetName()
+	if len(b.defs) > 0 {
+		doc["$defs"] = b.defs
+	}
+	return marshal(doc)
+}
+
+func (b *jsonSchemaBuilder) object(scope resolve.Scope, description string, fields []schema.Field) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	for _, f := range fields {
+		properties[f.Name] = b.field(scope, f)
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	obj := map[string]any{"type": "object", "properties": properties}
+	if description != "" {
+		obj["description"] = description
+	}
+	if len(required) > 0 {
+		obj["required"] = required
+	}
+	return obj
+}
+
+func (b *jsonSchemaBuilder) field(scope resolve.Scope, f schema.Field) map[string]any {
+	elem, list := strings.CutPrefix(f.Type, "[]")
+	var t map[string]any
+	switch target, ok := scope.Lookup(elem); {
+	case len(f.Children) > 0:
+		t = b.object(scope, f.Description, f.Children)
+	case ok:
+		name := target.Namespace + "." + target.GetName()
+		if _, defined := b.defs[name]; !defined {
+			// Placeholder first, so that cycles end at the reference
+			b.defs[name] = nil
+			b.defs[name] = b.object(b.resolver.Scope(target.Namespace), target.Description, target.StoredFields())
+		}
+		t = map[string]any{"$ref": "#/$defs/" + name}
+	default:
+		t = b.primitive(f, elem)
+	}
+	if list {
+		t = map[string]any{"type": "array", "items": t}
+	}
+	if f.Description != "" && t["description"] == nil {
+		t["description"] = f.Description
+	}
+	return t
+}
+
+func (b *jsonSchemaBuilder) primitive(f schema.Field, fieldType string) map[string]any {
+	var t map[string]any
+	switch fieldType {
+	case "integer":
+		t = map[string]any{"type": "integer"}
+	case "positiveInt":
+		t = map[string]any{"type": "integer", "minimum": 1}
+	case "unsignedInt":
+		t = map[string]any{"type": "integer", "minimum": 0}
+	case "decimal":
+		t = map[string]any{"type": "number"}
+	case "boolean":
+		t = map[string]any{"type": "boolean"}
+	case "base64Binary":
+		t = map[string]any{"type": "string", "contentEncoding": "base64"}
+	case "uri", "url", "canonical":
+		t = map[string]any{"type": "string", "format": "uri-reference"}
+	default:
+		if !datatypes.IsPrimitive(fieldType) {
+			// Types that resolve to no schema accept any value
+			return map[string]any{}
+		}
+		t = map[string]any{"type": "string"}
+		if pattern := validate.Pattern(fieldType); pattern != nil {
+			t["pattern"] = pattern.String()
+		}
+	}
+	if len(f.Enum) > 0 {
+		t["enum"] = f.Enum
+	}
+	return t
+}
@@ -0,0 +1,225 @@
+// Package registry converts schemas to Avro and JSON Schema and registers
+// them as subjects in a Confluent-compatible schema registry, so that event
+// schemas are governed from the YAML schemas.
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Formats of registered schemas, named as the registry's schemaType.
+const (
+	Avro       = "AVRO"
+	JSONSchema = "JSON"
+)
+
+// contentType is the media type of registry requests.
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+// Options select how schema types without an exact Avro counterpart are
+// encoded.
+type Options struct {
+	// PartialDates encodes dates as text, as the partial dates policy keeps
+	// them, rather than as days since the epoch.
+	PartialDates bool
+	// OffsetInstants encodes datetimes and instants as ISO 8601 text, which
+	// keeps their UTC offset, rather than as timestamps.
+	OffsetInstants bool
+}
+
+// OptionsFor returns the options that match the project temporal policy.
+func OptionsFor(cfg config.Config) Options {
+	policy := cfg.TemporalPolicy("")
+	return Options{PartialDates: policy.Dates == config.DatesPartial, OffsetInstants: policy.Instants == config.InstantsOffset}
+}
+
+// Subject is a schema to register.
+type Subject struct {
+	// Name is the schema's namespace and name, as the record name subject
+	// strategy names the subjects of an Avro record.
+	Name   string
+	Format string
+	Schema []byte
+	// Properties are registered as the metadata of the schema version, for
+	// catalogs that read it: the owner, steward, and comma-separated tags of
+	// the schema.
+	Properties map[string]string
+}
+
+// Subjects converts schemas to subjects of format, in dependency order within
+// each namespace. Built-in datatypes are inlined into the subjects that use
+// them rather than registered themselves.
+func Subjects(schemas []schema.Schema, format string, opts Options) ([]Subject, error) {
+	r, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+	var subjects []Subject
+	for _, namespace := range r.Namespaces() {
+		for _, s := range r.Namespace(namespace) {
+			if datatypes.IsBuiltin(s) {
+				continue
+			}
+			var data []byte
+			switch format {
+			case Avro:
+				data, err = avro(r, s, opts)
+			case JSONSchema:
+				data, err = jsonSchema(r, s)
+			default:
+				return nil, fmt.Errorf("unknown schema format %q (want %s or %s)", format, Avro, JSONSchema)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert %s: %w", s.GetName(), err)
+			}
+			subjects = append(subjects, Subject{Name: s.Namespace + "." + s.GetName(), Format: format, Schema: data, Properties: properties(s)})
+		}
+	}
+	return subjects, nil
+}
+
+// properties returns the ownership metadata of s, or nil when it has none.
+func properties(s schema.Schema) map[string]string {
+	var props map[string]string
+	set := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if props == nil {
+			props = make(map[string]string)
+		}
+		props[key] = value
+	}
+	set("owner", s.Owner)
+	set("steward", s.Steward)
+	set("tags", strings.Join(s.Tags, ","))
+	return props
+}
+
+// marshal encodes v as compact JSON, leaving characters such as < in
+// descriptions unescaped.
+func marshal(v any) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+// Client talks to a schema registry.
+type Client struct {
+	URL      string
+	Username string
+	Password string
+	HTTP     *http.Client
+}
+
+// NewClient creates a client for the registry cfg describes. Credentials may
+// reference environment variables as ${NAME}.
+func NewClient(cfg config.Registry) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("no schema registry configured (set --registry-url or registry.url)")
+	}
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid registry URL %q: %w", cfg.URL, err)
+	}
+	return &Client{
+		URL:      strings.TrimSuffix(cfg.URL, "/"),
+		Username: os.ExpandEnv(cfg.Username),
+		Password: os.ExpandEnv(cfg.Password),
+		HTTP:     http.DefaultClient,
+	}, nil
+}
+
+// SetCompatibility sets the compatibility mode of a subject.
+func (c *Client) SetCompatibility(ctx context.Context, subject, mode string) error {
+	body := struct {
+		Compatibility string `json:"compatibility"`
+	}{mode}
+	return c.do(ctx, http.MethodPut, "/config/"+url.PathEscape(subject), body, nil)
+}
+
+// Register registers sub as a new version of its subject, or finds the
+// version it already is, and returns the registry's id for the schema.
+func (c *Client) Register(ctx context.Context, sub Subject) (int, error) {
+	type metadata struct {
+		Properties map[string]string `json:"properties"`
+	}
+	body := struct {
+		SchemaType string    `json:"schemaType,omitempty"`
+		Schema     string    `json:"schema"`
+		Metadata   *metadata `json:"metadata,omitempty"`
+	}{Schema: string(sub.Schema)}
+	if len(sub.Properties) > 0 {
+		body.Metadata = &metadata{sub.Properties}
+	}
+	if sub.Format != Avro {
+		// Avro is the registry's default schema type
+		body.SchemaType = sub.Format
+	}
+	var result struct {
+		ID int `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/subjects/"+url.PathEscape(sub.Name)+"/versions", body, &result); err != nil {
+		return 0, err
+	}
+	return result.ID, nil
+}
+
+// do sends body as JSON and decodes the response into result, if set.
+// Registry errors are reported with the message the registry returned.
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach schema registry: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read registry response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var regErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &regErr) == nil && regErr.Message != "" {
+			return fmt.Errorf("%s %s: %s (%s)", method, path, regErr.Message, resp.Status)
+		}
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to decode registry response: %w", err)
+	}
+	return nil
+}
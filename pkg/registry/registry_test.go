@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+var testSchemas = []schema.Schema{
+	{
+		Name:        "Specimen",
+		Description: "A sample <to be> analyzed",
+		Namespace:   "lab",
+		Owner:       "lab-data",
+		Tags:        []string{"clinical", "phi"},
+		Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "status", Type: "code", Enum: []string{"available", "entered-in-error"}},
+			{Name: "volume", Type: "decimal", Precision: 8, Scale: 2},
+			{Name: "parent", Type: "[]Specimen"},
+			{Name: "collection", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "collected", Type: "datetime", Required: true, Description: "When collected"},
+			}},
+		},
+	},
+}
+
+func TestSubjectsAvro(t *testing.T) {
+	subjects, err := Subjects(testSchemas, Avro, Options{})
+	if err != nil {
+		t.Fatalf("Subjects() failed: %v", err)
+	}
+	if len(subjects) != 1 || subjects[0].Name != "lab.Specimen" {
+		t.Fatalf("Subjects() = %+v, want lab.Specimen only", subjects)
+	}
+	if props := subjects[0].Properties; len(props) != 2 || props["owner"] != "lab-data" || props["tags"] != "clinical,phi" {
+		t.Errorf("Properties = %v, want the owner and tags", props)
+	}
+	want := `{"type":"record","name":"Specimen","namespace":"lab","doc":"A sample <to be> analyzed","fields":[` +
+		`{"name":"id","type":"string"},` +
+		`{"name":"status","type":["null","string"],"default":null},` +
+		`{"name":"volume","type":["null",{"logicalType":"decimal","precision":8,"scale":2,"type":"bytes"}],"default":null},` +
+		`{"name":"parent","type":["null",{"items":"lab.Specimen","type":"array"}],"default":null},` +
+		`{"name":"collection","type":["null",{"type":"record","name":"SpecimenCollection","namespace":"lab","fields":[` +
+		`{"name":"collected","doc":"When collected","type":{"logicalType":"timestamp-millis","type":"long"}}]}],"default":null}]}`
+	if got := string(subjects[0].Schema); got != want {
+		t.Errorf("Avro schema =\n%s\nwant\n%s", got, want)
+	}
+
+	subjects, err = Subjects(testSchemas, Avro, Options{OffsetInstants: true})
+	if err != nil {
+		t.Fatalf("Subjects() failed: %v", err)
+	}
+	if !strings.Contains(string(subjects[0].Schema), `"name":"collected","doc":"When collected","type":"string"`) {
+		t.Errorf("offset instants are not strings: %s", subjects[0].Schema)
+	}
+}
+
+func TestSubjectsJSONSchema(t *testing.T) {
+	subjects, err := Subjects(testSchemas, JSONSchema, Options{})
+	if err != nil {
+		t.Fatalf("Subjects() failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(subjects[0].Schema, &doc); err != nil {
+		t.Fatalf("invalid JSON Schema: %v", err)
+	}
+	if doc["$schema"] != jsonSchemaDialect || doc["title"] != "Specimen" {
+		t.Errorf("document header = %v, %v", doc["$schema"], doc["title"])
+	}
+	props := doc["properties"].(map[string]any)
+	status := props["status"].(map[string]any)
+	if enum, ok := status["enum"].([]any); !ok || len(enum) != 2 {
+		t.Errorf("status = %v, want an enum of 2 values", status)
+	}
+	if id := props["id"].(map[string]any); id["pattern"] == nil {
+		t.Errorf("id = %v, want a pattern", id)
+	}
+	parent := props["parent"].(map[string]any)
+	if ref := parent["items"].(map[string]any)["$ref"]; ref != "#/$defs/lab.Specimen" {
+		t.Errorf("parent items $ref = %v", ref)
+	}
+	if required := doc["required"].([]any); len(required) != 1 || required[0] != "id" {
+		t.Errorf("required = %v, want [id]", required)
+	}
+}
+
+func TestClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		user, pass, _ := r.BasicAuth()
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+" "+user+":"+pass+" "+string(body))
+		switch {
+		case r.URL.Path == "/subjects/lab.Broken/versions":
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, `{"error_code":409,"message":"Schema being registered is incompatible with an earlier schema"}`)
+		case strings.HasPrefix(r.URL.Path, "/subjects/"):
+			io.WriteString(w, `{"id":7}`)
+		default:
+			io.WriteString(w, string(body))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("REGISTRY_SECRET", "s3cret")
+	c, err := NewClient(config.Registry{URL: server.URL + "/", Username: "ci", Password: "${REGISTRY_SECRET}"})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	ctx := context.Background()
+	if err := c.SetCompatibility(ctx, "lab.Specimen", "BACKWARD"); err != nil {
+		t.Fatalf("SetCompatibility() failed: %v", err)
+	}
+	id, err := c.Register(ctx, Subject{Name: "lab.Specimen", Format: JSONSchema, Schema: []byte(`{"type":"object"}`), Properties: map[string]string{"owner": "lab-data"}})
+	if err != nil || id != 7 {
+		t.Fatalf("Register() = %d, %v; want 7", id, err)
+	}
+	_, err = c.Register(ctx, Subject{Name: "lab.Broken", Format: Avro, Schema: []byte(`"string"`)})
+	if err == nil || !strings.Contains(err.Error(), "incompatible with an earlier schema (409 Conflict)") {
+		t.Errorf("Register() error = %v, want the registry's message", err)
+	}
+
+	want := []string{
+		`PUT /config/lab.Specimen ci:s3cret {"compatibility":"BACKWARD"}`,
+		`POST /subjects/lab.Specimen/versions ci:s3cret {"schemaType":"JSON","schema":"{\"type\":\"object\"}","metadata":{"properties":{"owner":"lab-data"}}}`,
+		`POST /subjects/lab.Broken/versions ci:s3cret {"schema":"\"string\""}`,
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNewClientNoURL(t *testing.T) {
+	if _, err := NewClient(config.Registry{}); err == nil {
+		t.Error("NewClient() succeeded without a URL")
+	}
+}
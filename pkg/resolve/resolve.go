@@ -0,0 +1,352 @@
+// Package resolve links schema field types to the schemas they refer to,
+// across namespaces, and orders schemas so that dependencies come first.
+package resolve
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Resolver resolves references between a set of schemas.
+type Resolver struct {
+	schemas    []schema.Schema
+	namespaces []string
+	index      map[string]int             // "namespace.Name" -> position in schemas
+	byName     map[string][]int           // Name -> positions, in input order
+	deps       map[int][]int              // position -> referenced positions, sorted
+	nsDeps     map[string]map[string]bool // namespace -> namespaces it references
+}
+
+// New resolves every field type of schemas, together with the built-in
+// datatypes they use. Schemas that inherit a base resource are resolved, and
+// returned, with its elements flattened into their fields. A type resolves, in order, to a schema of the same name
+// in the field's own namespace, to a schema named by a qualified
+// "namespace.Name" type, to a built-in datatype, or to the only schema of
+// that name in another namespace. Types defined in several other namespaces
+// are ambiguous and reported as errors; types matching no schema stay
+// unresolved and keep each generator's fallback type.
+func New(schemas []schema.Schema) (*Resolver, error) {
+	return build(schemas, false)
+}
+
+// Lenient resolves like New but leaves ambiguous types unresolved instead of
+// failing, for tools such as editors that report them field by field with
+// Scope.Resolve.
+func Lenient(schemas []schema.Schema) *Resolver {
+	r, _ := build(schemas, true)
+	return r
+}
+
+func build(schemas []schema.Schema, lenient bool) (*Resolver, error) {
+	for _, s := range schemas {
+		if s.Inherits != "" && !datatypes.IsBaseName(s.Inherits) && !lenient {
+			return nil, fmt.Errorf("%s inherits unknown base resource %s", describe(s), s.Inherits)
+		}
+	}
+	r := &Resolver{
+		schemas: datatypes.Expand(schemas),
+		index:   make(map[string]int),
+		byName:  make(map[string][]int),
+		deps:    make(map[int][]int),
+		nsDeps:  make(map[string]map[string]bool),
+	}
+
+	for i, s := range r.schemas {
+		if _, seen := r.nsDeps[s.Namespace]; !seen {
+			r.namespaces = append(r.namespaces, s.Namespace)
+			r.nsDeps[s.Namespace] = make(map[string]bool)
+		}
+		r.index[key(s.Namespace, s.GetName())] = i
+		r.byName[s.GetName()] = append(r.byName[s.GetName()], i)
+	}
+
+	for i, s := range r.schemas {
+		seen := make(map[int]bool)
+		link := func(j int) {
+			if j == i || seen[j] {
+				return
+			}
+			seen[j] = true
+			r.deps[i] = append(r.deps[i], j)
+			if target := r.schemas[j].Namespace; target != s.Namespace {
+				r.nsDeps[s.Namespace][target] = true
+			}
+		}
+		for _, f := range s.Fields {
+			fieldType, _ := schema.ElementType(f.Type)
+			j, ok, err := r.lookup(s.Namespace, fieldType)
+			if err != nil && !lenient {
+				return nil, fmt.Errorf("%s field %s: %w", describe(s), f.Name, err)
+			}
+			if ok {
+				link(j)
+			}
+		}
+		// A base resource is a dependency only when it is emitted itself,
+		// as generators with base classes do with datatypes.WithBases
+		if s.Inherits != "" {
+			if j, ok, _ := r.lookup(s.Namespace, s.Inherits); ok {
+				link(j)
+			}
+		}
+		sort.Slice(r.deps[i], func(a, b int) bool {
+			x, y := r.schemas[r.deps[i][a]], r.schemas[r.deps[i][b]]
+			return key(x.Namespace, x.GetName()) < key(y.Namespace, y.GetName())
+		})
+	}
+
+	return r, nil
+}
+
+func key(namespace, name string) string {
+	return namespace + "." + name
+}
+
+func describe(s schema.Schema) string {
+	if s.SourceFile != "" && !datatypes.IsBuiltin(s) {
+		return fmt.Sprintf("%s (%s)", s.GetName(), s.SourceFile)
+	}
+	return s.GetName()
+}
+
+// lookup finds the schema a field type refers to from namespace.
+func (r *Resolver) lookup(namespace, fieldType string) (int, bool, error) {
+	if i, ok := r.index[key(namespace, fieldType)]; ok {
+		return i, true, nil
+	}
+	if ns, name, ok := strings.Cut(fieldType, "."); ok {
+		if i, ok := r.index[key(ns, name)]; ok {
+			return i, true, nil
+		}
+	}
+	if name, ok := datatypes.Lookup(fieldType); ok {
+		if i, ok := r.index[key(datatypes.Namespace, name)]; ok {
+			return i, true, nil
+		}
+	}
+
+	candidates := r.byName[fieldType]
+	switch len(candidates) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return candidates[0], true, nil
+	}
+	var namespaces []string
+	for _, i := range candidates {
+		namespaces = append(namespaces, r.schemas[i].Namespace)
+	}
+	return 0, false, fmt.Errorf("type %s is defined in namespaces %s; qualify it as namespace.%s", fieldType, strings.Join(namespaces, ", "), fieldType)
+}
+
+// Scope resolves field types as seen from one namespace.
+type Scope struct {
+	*Resolver
+	Namespace string
+}
+
+// Scope returns the view of r from namespace.
+func (r *Resolver) Scope(namespace string) Scope {
+	return Scope{Resolver: r, Namespace: namespace}
+}
+
+// Lookup returns the schema a field type refers to. Array types such as
+// []Coding do not resolve; callers resolve their element type.
+func (s Scope) Lookup(fieldType string) (schema.Schema, bool) {
+	i, ok, err := s.lookup(s.Namespace, fieldType)
+	if err != nil || !ok {
+		return schema.Schema{}, false
+	}
+	return s.schemas[i], true
+}
+
+// Resolve is Lookup, but reports types that are defined in several other
+// namespaces as errors rather than leaving them unresolved.
+func (s Scope) Resolve(fieldType string) (schema.Schema, bool, error) {
+	i, ok, err := s.lookup(s.Namespace, fieldType)
+	if err != nil || !ok {
+		return schema.Schema{}, false, err
+	}
+	return s.schemas[i], true, nil
+}
+
+// Namespaces returns the namespaces in dependency order: each namespace comes
+// after the namespaces it references, except where they reference each other
+// in a cycle.
+func (r *Resolver) Namespaces() []string {
+	var order []string
+	visited := make(map[string]bool)
+	var visit func(ns string)
+	visit = func(ns string) {
+		if visited[ns] {
+			return
+		}
+		visited[ns] = true
+		for _, dep := range r.namespaceDeps(ns) {
+			visit(dep)
+		}
+		order = append(order, ns)
+	}
+	for _, ns := range r.namespaces {
+		visit(ns)
+	}
+	return order
+}
+
+func (r *Resolver) namespaceDeps(namespace string) []string {
+	deps := make([]string, 0, len(r.nsDeps[namespace]))
+	for dep := range r.nsDeps[namespace] {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// Namespace returns the schemas of a namespace in dependency order. Schemas
+// that do not depend on each other keep their input order.
+func (r *Resolver) Namespace(namespace string) []schema.Schema {
+	var order []schema.Schema
+	visited := make(map[int]bool)
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, j := range r.deps[i] {
+			if r.schemas[j].Namespace == namespace {
+				visit(j)
+			}
+		}
+		order = append(order, r.schemas[i])
+	}
+	for i, s := range r.schemas {
+		if s.Namespace == namespace {
+			visit(i)
+		}
+	}
+	return order
+}
+
+// Dependencies returns the schemas that fields of s refer to, sorted by
+// namespace and name. s itself is not included.
+func (r *Resolver) Dependencies(s schema.Schema) []schema.Schema {
+	i, ok := r.index[key(s.Namespace, s.GetName())]
+	if !ok {
+		return nil
+	}
+	deps := make([]schema.Schema, 0, len(r.deps[i]))
+	for _, j := range r.deps[i] {
+		deps = append(deps, r.schemas[j])
+	}
+	return deps
+}
+
+// ClassDependencies returns the schemas a class generated for s refers to
+// when its base resource is emitted as a base class: the base, and the
+// schemas the fields s declares beyond the base's refer to. They are sorted
+// like Dependencies.
+func (s Scope) ClassDependencies(sc schema.Schema) []schema.Schema {
+	used := make(map[string]bool)
+	mark := func(fieldType string) {
+		if target, ok := s.Lookup(fieldType); ok {
+			used[key(target.Namespace, target.GetName())] = true
+		}
+	}
+	if sc.Inherits != "" {
+		mark(sc.Inherits)
+	}
+	for _, f := range datatypes.Own(sc) {
+		elem, _ := schema.ElementType(f.Type)
+		mark(elem)
+	}
+
+	var deps []schema.Schema
+	for _, dep := range s.Dependencies(sc) {
+		if used[key(dep.Namespace, dep.GetName())] {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// Cyclic reports whether a reference from one schema to another closes a
+// cycle, that is whether to refers back to from, directly or indirectly. A
+// schema referring to itself is cyclic.
+func (r *Resolver) Cyclic(from, to schema.Schema) bool {
+	start, ok := r.index[key(to.Namespace, to.GetName())]
+	if !ok {
+		return false
+	}
+	target, ok := r.index[key(from.Namespace, from.GetName())]
+	if !ok {
+		return false
+	}
+	if start == target {
+		return true
+	}
+
+	visited := map[int]bool{start: true}
+	stack := []int{start}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, j := range r.deps[i] {
+			if j == target {
+				return true
+			}
+			if !visited[j] {
+				visited[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+	return false
+}
+
+// NamespaceCycle returns namespaces that reference each other in a cycle,
+// starting and ending with the same namespace (e.g. [a b a]), or nil when the
+// namespaces are acyclic.
+func (r *Resolver) NamespaceCycle() []string {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(ns string) []string
+	visit = func(ns string) []string {
+		state[ns] = active
+		path = append(path, ns)
+		for _, dep := range r.namespaceDeps(ns) {
+			switch state[dep] {
+			case active:
+				for i, p := range path {
+					if p == dep {
+						return append(append([]string(nil), path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[ns] = done
+		return nil
+	}
+	for _, ns := range r.namespaces {
+		if state[ns] == unvisited {
+			if cycle := visit(ns); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package resolve
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func object(namespace, name string, fieldTypes ...string) schema.Schema {
+	s := schema.Schema{Name: name, Namespace: namespace}
+	for i, t := range fieldTypes {
+		s.Fields = append(s.Fields, schema.Field{Name: string(rune('a' + i)), Type: t})
+	}
+	return s
+}
+
+func TestLookup(t *testing.T) {
+	r, err := New([]schema.Schema{
+		object("clinical", "Encounter", "Patient", "admin.Location", "Reference", "Age", "array<Identifier>"),
+		object("clinical", "Reference"),
+		object("admin", "Patient", "Reference"),
+		object("admin", "Location"),
+		object("billing", "Location"),
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	tests := []struct {
+		namespace, fieldType string
+		want                 string // namespace.Name, or "" when unresolved
+	}{
+		{"clinical", "Patient", "admin.Patient"},            // only definition
+		{"clinical", "admin.Location", "admin.Location"},    // qualified
+		{"clinical", "Reference", "clinical.Reference"},     // own namespace shadows built-in
+		{"admin", "Reference", "datatypes.Reference"},       // built-in
+		{"clinical", "Age", "datatypes.Quantity"},           // profile
+		{"admin", "Location", "admin.Location"},             // own namespace
+		{"clinical", "Location", ""},                        // ambiguous
+		{"clinical", "[]Patient", ""},                       // arrays resolve by element
+		{"datatypes", "Identifier", "datatypes.Identifier"}, // built-in used in array<T>
+		{"clinical", "Patient.Contact", ""},                 // backbone element path
+		{"clinical", "string", ""},                          // primitive
+	}
+	for _, tt := range tests {
+		target, ok := r.Scope(tt.namespace).Lookup(tt.fieldType)
+		got := ""
+		if ok {
+			got = target.Namespace + "." + target.GetName()
+		}
+		if got != tt.want {
+			t.Errorf("Scope(%q).Lookup(%q) = %q, want %q", tt.namespace, tt.fieldType, got, tt.want)
+		}
+	}
+}
+
+func TestNewAmbiguous(t *testing.T) {
+	_, err := New([]schema.Schema{
+		object("clinical", "Encounter", "Location"),
+		object("admin", "Location"),
+		object("billing", "Location"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "admin, billing") {
+		t.Fatalf("New() error = %v, want ambiguous Location", err)
+	}
+}
+
+func TestLenient(t *testing.T) {
+	r := Lenient([]schema.Schema{
+		object("clinical", "Encounter", "Location", "Patient"),
+		object("clinical", "Patient"),
+		object("admin", "Location"),
+		object("billing", "Location"),
+	})
+	scope := r.Scope("clinical")
+	if _, ok, err := scope.Resolve("Location"); ok || err == nil || !strings.Contains(err.Error(), "admin, billing") {
+		t.Errorf("Resolve(Location) = %v, %v, want ambiguous", ok, err)
+	}
+	if target, ok, err := scope.Resolve("Patient"); !ok || err != nil || target.Namespace != "clinical" {
+		t.Errorf("Resolve(Patient) = %v, %v, %v", target.Namespace, ok, err)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	r, err := New([]schema.Schema{
+		object("clinical", "Encounter", "Patient", "Observation"),
+		object("clinical", "Observation", "Period"),
+		object("clinical", "Condition"),
+		object("admin", "Patient", "[]HumanName"),
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	if got, want := r.Namespaces(), []string{datatypes.Namespace, "admin", "clinical"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Namespaces() = %v, want %v", got, want)
+	}
+
+	var names []string
+	for _, s := range r.Namespace("clinical") {
+		names = append(names, s.GetName())
+	}
+	if want := []string{"Observation", "Encounter", "Condition"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Namespace(clinical) = %v, want %v", names, want)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	encounter := object("clinical", "Encounter", "[]Observation", "Patient")
+	observation := object("clinical", "Observation", "Encounter")
+	patient := object("admin", "Patient", "Patient")
+	r, err := New([]schema.Schema{encounter, observation, patient})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	tests := []struct {
+		from, to schema.Schema
+		want     bool
+	}{
+		{encounter, observation, true},
+		{observation, encounter, true},
+		{patient, patient, true},
+		{encounter, patient, false},
+	}
+	for _, tt := range tests {
+		if got := r.Cyclic(tt.from, tt.to); got != tt.want {
+			t.Errorf("Cyclic(%s, %s) = %v, want %v", tt.from.Name, tt.to.Name, got, tt.want)
+		}
+	}
+	if cycle := r.NamespaceCycle(); cycle != nil {
+		t.Errorf("NamespaceCycle() = %v, want nil", cycle)
+	}
+
+	r, err = New([]schema.Schema{
+		object("clinical", "Encounter", "Patient"),
+		object("admin", "Patient", "Encounter"),
+	})
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if got, want := r.NamespaceCycle(), []string{"clinical", "admin", "clinical"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NamespaceCycle() = %v, want %v", got, want)
+	}
+}
+
+func TestInherits(t *testing.T) {
+	patient := schema.Schema{Name: "Patient", Namespace: "clinical", Inherits: datatypes.DomainResourceName, Fields: []schema.Field{
+		{Name: "active", Type: "boolean"},
+		{Name: "id", Type: "id", Required: true},
+		{Name: "name", Type: "[]HumanName"},
+	}}
+	r, err := New(datatypes.WithBases([]schema.Schema{patient}))
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	scope := r.Scope("clinical")
+	flat, ok := scope.Lookup("Patient")
+	if !ok {
+		t.Fatal("Lookup(Patient) failed")
+	}
+	var names []string
+	for _, f := range flat.Fields {
+		names = append(names, f.Name)
+	}
+	want := []string{"id", "meta", "implicitRules", "language", "text", "extension", "modifierExtension", "active", "name"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("flattened fields = %v, want %v", names, want)
+	}
+	if !flat.Fields[0].Required {
+		t.Error("redeclared id is not required")
+	}
+	for _, name := range []string{"Meta", "Narrative", "Extension"} {
+		if _, ok := scope.Lookup(name); !ok {
+			t.Errorf("inherited datatype %s was not expanded", name)
+		}
+	}
+
+	var deps []string
+	for _, dep := range scope.ClassDependencies(flat) {
+		deps = append(deps, dep.GetName())
+	}
+	if want := []string{"DomainResource", "HumanName"}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("ClassDependencies() = %v, want %v", deps, want)
+	}
+
+	if _, err := New([]schema.Schema{{Name: "Patient", Namespace: "clinical", Inherits: "Element"}}); err == nil || !strings.Contains(err.Error(), "unknown base resource Element") {
+		t.Errorf("New() error = %v, want unknown base resource", err)
+	}
+}
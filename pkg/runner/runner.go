@@ -0,0 +1,398 @@
+// Package runner generates code from schemas programmatically, for services
+// that run generation for many projects at once without invoking the CLI.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/format"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/paths"
+	"github.com/konzy/ehrglot/pkg/provenance"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Request describes one generation run.
+type Request struct {
+	// Langs are the target languages, by canonical name or alias.
+	Langs []string
+	// Schemas are the schemas to generate, as returned by schema.Loader.
+	Schemas []schema.Schema
+	// Mappings are the mappings generators that support them also render,
+	// such as into the SQL generator's dbt lineage.
+	Mappings []schema.SchemaMapping
+	// Output is the directory a single language is generated into. With
+	// several languages, each is generated into a subdirectory of Output
+	// named after its canonical name.
+	Output string
+	// Config is the project configuration. The zero value uses each
+	// generator's defaults.
+	Config config.Config
+	// Package also writes package manifests, as generate --package does.
+	Package bool
+}
+
+// Stages of a language's generation reported to Runner.Progress.
+const (
+	// Rendering languages are being generated into a scratch directory.
+	Rendering = "rendering"
+	// Writing languages are being copied into the output directory.
+	Writing = "writing"
+	// Done languages were written with their manifest. Event.Err is a
+	// *PartialError when some schemas were left out.
+	Done = "done"
+	// Failed languages stopped with Event.Err.
+	Failed = "failed"
+)
+
+// Event reports the progress of one language.
+type Event struct {
+	Lang  string
+	Stage string
+	Err   error
+}
+
+// Result describes the output of one language.
+type Result struct {
+	Lang     string
+	Dir      string
+	Manifest *provenance.Manifest
+	// Notes name the configured formatters that were skipped because they
+	// are not installed.
+	Notes []string
+}
+
+// SchemaError is a schema that failed to generate, and why.
+type SchemaError struct {
+	// Schema is the schema's namespace and name, such as clinical.Patient.
+	Schema string
+	Err    error
+}
+
+// PartialError reports the schemas a language was generated without. The
+// rest of its schemas were generated and written.
+type PartialError struct {
+	Failures []SchemaError
+	// Total is the number of schemas of the request.
+	Total int
+}
+
+func (e *PartialError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d schemas failed to generate:", len(e.Failures), e.Total)
+	for _, f := range e.Failures {
+		fmt.Fprintf(&b, "\n  %s: %v", f.Schema, f.Err)
+	}
+	return b.String()
+}
+
+// Runner runs generation requests. A Runner holds no per-request state, so
+// one Runner may serve concurrent calls to Generate; the zero value is ready
+// to use.
+type Runner struct {
+	// Version is the ehrglot version recorded in generated manifests.
+	Version string
+	// Progress, if set, is called as each language moves through its stages.
+	// Calls for one request are serialized.
+	Progress func(Event)
+	// Parallelism limits how many languages of a request are generated at
+	// once. Zero or less generates all of them at once.
+	Parallelism int
+}
+
+// Generate generates every language of req, concurrently, and returns the
+// results in the order of req.Langs. Each language is rendered into a
+// scratch directory and only then copied into place, so a failed or
+// canceled language leaves its output directory as it was. When ctx is
+// canceled, languages that have not started writing stop, mid-render if need
+// be, and Generate returns ctx.Err(); other failures are joined, each
+// prefixed with its language.
+//
+// A schema that fails to render does not stop the others: the language is
+// generated without the schemas that fail on their own, and is returned
+// among the results with a *PartialError naming them among the failures.
+// Languages that failed outright have a zero Result.
+func (r *Runner) Generate(ctx context.Context, req Request) ([]Result, error) {
+	if len(req.Langs) == 0 {
+		return nil, fmt.Errorf("no languages requested")
+	}
+	cfg, err := generator.Configure(req.Config)
+	if err != nil {
+		return nil, err
+	}
+	langs := make([]string, len(req.Langs))
+	for i, lang := range req.Langs {
+		if langs[i], err = generator.Canonical(lang); err != nil {
+			return nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	report := func(e Event) {
+		if r.Progress != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			r.Progress(e)
+		}
+	}
+
+	parallelism := r.Parallelism
+	if parallelism <= 0 || parallelism > len(langs) {
+		parallelism = len(langs)
+	}
+	slots := make(chan struct{}, parallelism)
+	results := make([]Result, len(langs))
+	errs := make([]error, len(langs))
+	var wg sync.WaitGroup
+	for i, lang := range langs {
+		dir := req.Output
+		if len(langs) > 1 {
+			dir = filepath.Join(req.Output, lang)
+		}
+		wg.Add(1)
+		go func(i int, lang, dir string) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				report(Event{Lang: lang, Stage: Failed, Err: errs[i]})
+				return
+			}
+			manifest, notes, err := r.generate(ctx, lang, cfg, req, dir, report)
+			errs[i] = err
+			if manifest == nil {
+				report(Event{Lang: lang, Stage: Failed, Err: err})
+				return
+			}
+			results[i] = Result{Lang: lang, Dir: dir, Manifest: manifest, Notes: notes}
+			report(Event{Lang: lang, Stage: Done, Err: err})
+		}(i, lang, dir)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var failures []error
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", langs[i], err))
+		}
+	}
+	return results, errors.Join(failures...)
+}
+
+func (r *Runner) generate(ctx context.Context, lang string, cfg config.Config, req Request, dir string, report func(Event)) (*provenance.Manifest, []string, error) {
+	report(Event{Lang: lang, Stage: Rendering})
+	workDir, err := os.MkdirTemp("", "ehrglot-generate-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	schemas := req.Schemas
+	var partial error
+	notes, err := Render(ctx, lang, cfg, schemas, req.Mappings, workDir, req.Package)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, err
+		}
+		failures, isolateErr := isolate(ctx, lang, cfg, schemas, err)
+		if isolateErr != nil {
+			return nil, nil, isolateErr
+		}
+		// Failures no one schema causes, such as of the configuration, are
+		// failures of the whole language
+		if len(failures) == 0 || len(failures) == len(schemas) {
+			return nil, nil, err
+		}
+		schemas = without(schemas, failures)
+		if err := emptyDir(workDir); err != nil {
+			return nil, nil, fmt.Errorf("failed to clear work directory: %w", err)
+		}
+		if notes, err = Render(ctx, lang, cfg, schemas, req.Mappings, workDir, req.Package); err != nil {
+			return nil, nil, err
+		}
+		partial = &PartialError{Failures: failures, Total: len(req.Schemas)}
+	}
+	manifest, err := provenance.Build(workDir, r.Version, lang, req.Package, schemas)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	report(Event{Lang: lang, Stage: Writing})
+	if err := CopyTree(workDir, dir); err != nil {
+		return nil, nil, fmt.Errorf("failed to write generated code: %w", err)
+	}
+	if err := manifest.Write(dir); err != nil {
+		return nil, nil, err
+	}
+	return manifest, notes, partial
+}
+
+// isolate returns the schemas that fail to generate, given err, the failure
+// of generating them all. It generates halves of schemas, halving those that
+// fail until each failure is pinned to one schema, so that a few failures
+// among hundreds of schemas take a few dozen renders. Failures that only
+// schemas together cause are not returned.
+func isolate(ctx context.Context, lang string, cfg config.Config, schemas []schema.Schema, err error) ([]SchemaError, error) {
+	if len(schemas) == 1 {
+		return []SchemaError{{Schema: schemaKey(schemas[0]), Err: err}}, nil
+	}
+	var failures []SchemaError
+	mid := len(schemas) / 2
+	for _, half := range [][]schema.Schema{schemas[:mid], schemas[mid:]} {
+		renderErr := renderScratch(ctx, lang, cfg, half)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if renderErr == nil {
+			continue
+		}
+		found, err := isolate(ctx, lang, cfg, half, renderErr)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, found...)
+	}
+	return failures, nil
+}
+
+// renderScratch generates schemas, without mappings or package manifests,
+// into a scratch directory it then removes.
+func renderScratch(ctx context.Context, lang string, cfg config.Config, schemas []schema.Schema) error {
+	dir, err := os.MkdirTemp("", "ehrglot-isolate-")
+	if err != nil {
+		return fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	gen, err := generator.New(lang, cfg)
+	if err != nil {
+		return err
+	}
+	return gen.Generate(ctx, schemas, paths.Long(dir))
+}
+
+// schemaKey returns the namespace and name of s, such as clinical.Patient.
+func schemaKey(s schema.Schema) string {
+	return s.Namespace + "." + s.GetName()
+}
+
+// without returns schemas but for those that failed.
+func without(schemas []schema.Schema, failures []SchemaError) []schema.Schema {
+	failed := make(map[string]bool, len(failures))
+	for _, f := range failures {
+		failed[f.Schema] = true
+	}
+	var rest []schema.Schema
+	for _, s := range schemas {
+		if !failed[schemaKey(s)] {
+			rest = append(rest, s)
+		}
+	}
+	return rest
+}
+
+// emptyDir removes everything under dir, leaving dir itself in place.
+func emptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Render generates code for a canonical language from schemas and mappings
+// into dir, followed by its package manifests when pkg is set, and runs the
+// formatters configured for the language over it. It returns a note for
+// each formatter that is not installed. cfg must have been passed through
+// generator.Configure.
+func Render(ctx context.Context, lang string, cfg config.Config, schemas []schema.Schema, mappings []schema.SchemaMapping, dir string, pkg bool) ([]string, error) {
+	dir = paths.Long(dir)
+	gen, err := generator.New(lang, cfg)
+	if err != nil {
+		return nil, err
+	}
+	packager, ok := gen.(schema.Packager)
+	if pkg && !ok {
+		return nil, fmt.Errorf("--package is not supported for %s", lang)
+	}
+
+	if err := gen.Generate(ctx, schemas, dir); err != nil {
+		return nil, fmt.Errorf("failed to generate code: %w", err)
+	}
+	if err := gen.GenerateMappings(ctx, mappings, dir); err != nil {
+		return nil, fmt.Errorf("failed to generate mappings: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if pkg {
+		if err := packager.Package(schemas, dir); err != nil {
+			return nil, fmt.Errorf("failed to write package: %w", err)
+		}
+	}
+	notes, err := format.Run(ctx, dir, cfg.Language(lang).Format)
+	if err != nil {
+		return notes, fmt.Errorf("failed to format code: %w", err)
+	}
+	return notes, nil
+}
+
+// CopyTree copies the files under src into dst, replacing files of the same
+// name and leaving others in place.
+func CopyTree(src, dst string) error {
+	src, dst = paths.Long(src), paths.Long(dst)
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		return copyFile(path, target)
+	})
+}
+
+// copyFile streams src into dst rather than reading it whole, since a
+// namespace of a large guide may be generated into a single file.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,196 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/provenance"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestGenerateLanguages(t *testing.T) {
+	dir := t.TempDir()
+	var events []Event
+	r := Runner{Version: "1.2.3", Progress: func(e Event) { events = append(events, e) }}
+	results, err := r.Generate(context.Background(), Request{Langs: []string{"py", "go"}, Schemas: fixtures.Schemas(), Output: dir})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Lang != "python" || results[1].Lang != "go" {
+		t.Fatalf("Generate() results = %+v, want python then go", results)
+	}
+	for _, res := range results {
+		if res.Dir != filepath.Join(dir, res.Lang) {
+			t.Errorf("%s written to %s, want a subdirectory named after the language", res.Lang, res.Dir)
+		}
+		if res.Manifest.Generator != "1.2.3" || len(res.Manifest.Files) == 0 {
+			t.Errorf("%s manifest = %+v", res.Lang, res.Manifest)
+		}
+		if _, err := os.Stat(filepath.Join(res.Dir, provenance.ManifestFile)); err != nil {
+			t.Errorf("%s manifest not written: %v", res.Lang, err)
+		}
+	}
+
+	stages := map[string][]string{}
+	for _, e := range events {
+		stages[e.Lang] = append(stages[e.Lang], e.Stage)
+	}
+	for _, lang := range []string{"python", "go"} {
+		if fmt.Sprint(stages[lang]) != fmt.Sprint([]string{Rendering, Writing, Done}) {
+			t.Errorf("%s stages = %v", lang, stages[lang])
+		}
+	}
+}
+
+func TestGenerateSingleLanguage(t *testing.T) {
+	dir := t.TempDir()
+	results, err := new(Runner).Generate(context.Background(), Request{Langs: []string{"sql"}, Schemas: fixtures.Schemas(), Output: dir})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if results[0].Dir != dir {
+		t.Errorf("sql written to %s, want %s", results[0].Dir, dir)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	r := Runner{}
+	if _, err := r.Generate(context.Background(), Request{Schemas: fixtures.Schemas(), Output: t.TempDir()}); err == nil {
+		t.Error("Generate() without languages succeeded")
+	}
+	if _, err := r.Generate(context.Background(), Request{Langs: []string{"cobol"}, Schemas: fixtures.Schemas(), Output: t.TempDir()}); err == nil {
+		t.Error("Generate() with an unknown language succeeded")
+	}
+}
+
+func TestGeneratePartial(t *testing.T) {
+	dir := t.TempDir()
+	broken := func(name string) schema.Schema {
+		return schema.Schema{Name: name, Namespace: "broken", Fields: []schema.Field{
+			{Name: "at", Type: "date"},
+			{Name: "age", Type: "integer", Derived: "years(at"},
+		}}
+	}
+	schemas := append([]schema.Schema{broken("Visit")}, fixtures.Schemas()...)
+	schemas = append(schemas, broken("Stay"))
+	var done Event
+	r := Runner{Progress: func(e Event) {
+		if e.Stage == Done {
+			done = e
+		}
+	}}
+	results, err := r.Generate(context.Background(), Request{Langs: []string{"sql"}, Schemas: schemas, Output: dir})
+
+	var partial *PartialError
+	if !errors.As(err, &partial) {
+		t.Fatalf("Generate() error = %v, want a PartialError", err)
+	}
+	if partial.Total != len(schemas) || len(partial.Failures) != 2 || partial.Failures[0].Schema != "broken.Visit" || partial.Failures[1].Schema != "broken.Stay" {
+		t.Errorf("Generate() failures = %+v, want broken.Visit and broken.Stay of %d", partial.Failures, len(schemas))
+	}
+	if !errors.As(done.Err, &partial) {
+		t.Errorf("Done event error = %v, want the PartialError", done.Err)
+	}
+	if len(results) != 1 || results[0].Manifest == nil {
+		t.Fatalf("Generate() results = %+v, want the sql output", results)
+	}
+	for name := range results[0].Manifest.Schemas {
+		if strings.HasPrefix(name, "broken/") {
+			t.Errorf("manifest records %s, which failed", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, provenance.ManifestFile)); err != nil {
+		t.Errorf("manifest not written: %v", err)
+	}
+}
+
+func TestGenerateCanceled(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var failed int
+	r := Runner{Progress: func(e Event) {
+		if e.Stage == Failed {
+			failed++
+		}
+	}}
+	_, err := r.Generate(ctx, Request{Langs: []string{"python", "go"}, Schemas: fixtures.Schemas(), Output: dir})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Generate() error = %v, want context.Canceled", err)
+	}
+	if failed != 2 {
+		t.Errorf("reported %d failed languages, want 2", failed)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("canceled generation wrote %s", dir)
+	}
+}
+
+func TestGenerateCanceledWhileRendering(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := Runner{Progress: func(e Event) {
+		if e.Stage == Rendering {
+			cancel()
+		}
+	}}
+	_, err := r.Generate(ctx, Request{Langs: []string{"java"}, Schemas: fixtures.Schemas(), Output: dir})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Generate() error = %v, want context.Canceled", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("canceled generation wrote %s", dir)
+	}
+}
+
+func TestGenerateConcurrent(t *testing.T) {
+	r := Runner{Parallelism: 1}
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dir := filepath.Join(t.TempDir(), fmt.Sprint("tenant", i))
+			_, errs[i] = r.Generate(context.Background(), Request{Langs: []string{"typescript", "rust"}, Schemas: fixtures.Schemas(), Output: dir})
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("tenant %d: %v", i, err)
+		}
+	}
+}
+
+// BenchmarkRender renders the repository schemas, FHIR R4 included, in each
+// language.
+func BenchmarkRender(b *testing.B) {
+	schemas, err := schema.NewLoader("../../schemas").LoadAll(context.Background())
+	if err != nil {
+		b.Fatalf("LoadAll() failed: %v", err)
+	}
+	cfg, err := generator.Configure(config.Config{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, lang := range generator.Languages {
+		b.Run(lang, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Render(context.Background(), lang, cfg, schemas, nil, b.TempDir(), false); err != nil {
+					b.Fatalf("Render() failed: %v", err)
+				}
+			}
+		})
+	}
+}
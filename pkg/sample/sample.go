@@ -0,0 +1,159 @@
+// Package sample builds example records of schemas from the examples of
+// their fields, so that documentation, tests, and fake data come from the
+// same schema source.
+package sample
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// placeholders are the values of required fields of primitive types that
+// give no examples. Each is valid for its type, as validate checks it.
+var placeholders = map[string]any{
+	"integer":      json.Number("1"),
+	"positiveInt":  json.Number("1"),
+	"unsignedInt":  json.Number("0"),
+	"decimal":      json.Number("1.0"),
+	"boolean":      false,
+	"date":         "2024-01-01",
+	"datetime":     "2024-01-01T00:00:00Z",
+	"dateTime":     "2024-01-01T00:00:00Z",
+	"instant":      "2024-01-01T00:00:00Z",
+	"time":         "00:00:00",
+	"code":         "example",
+	"uri":          "https://example.org",
+	"url":          "https://example.org",
+	"canonical":    "https://example.org",
+	"oid":          "urn:oid:2.16.840.1.113883",
+	"uuid":         "urn:uuid:00000000-0000-4000-8000-000000000000",
+	"base64Binary": "ZXhhbXBsZQ==",
+}
+
+// Builder builds example records.
+type Builder struct {
+	resolver *resolve.Resolver
+}
+
+// New creates a Builder for records of schemas, resolving the types of their
+// fields among them.
+func New(schemas []schema.Schema) (*Builder, error) {
+	r, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{resolver: r}, nil
+}
+
+// Record returns the n-th example record of s, counting from 0, with values
+// as JSON decodes them with UseNumber. Fields that give examples take the
+// n-th of them, starting over when they run out; required fields without
+// examples take their n-th enum value, or a placeholder of their type; and
+// optional fields without examples are left out, unless their required_if
+// condition holds, as are fields whose enabled_if condition does not hold
+// and fields that would nest a record of a schema in itself. FHIR resources
+// get their resourceType.
+func (b *Builder) Record(s schema.Schema, n int) map[string]any {
+	scope := b.resolver.Scope(s.Namespace)
+	visiting := map[string]bool{s.Namespace + "." + s.GetName(): true}
+	record := b.object(scope, s.StoredFields(), n, visiting)
+	conditional, _ := s.ConditionalFields()
+	for _, c := range conditional {
+		if _, ok := record[c.Field.Name]; ok {
+			continue
+		}
+		value, set := record[c.Condition.Field]
+		if !c.Condition.Holds(fmt.Sprint(value), set) {
+			continue
+		}
+		f := c.Field
+		f.Required = true
+		if value, ok := b.field(scope, f, n, visiting); ok {
+			record[f.Name] = value
+		}
+	}
+	enabled, _ := s.EnabledFields()
+	for _, c := range enabled {
+		value, set := record[c.Condition.Field]
+		if !c.Condition.Holds(fmt.Sprint(value), set) {
+			delete(record, c.Field.Name)
+		}
+	}
+	if s.Resource != "" {
+		record["resourceType"] = s.Resource
+	}
+	return record
+}
+
+// object builds the fields of a record or nested element. visiting holds the
+// schemas being built, whose recursive fields are left out.
+func (b *Builder) object(scope resolve.Scope, fields []schema.Field, n int, visiting map[string]bool) map[string]any {
+	obj := make(map[string]any)
+	for _, f := range fields {
+		if value, ok := b.field(scope, f, n, visiting); ok {
+			obj[f.Name] = value
+		}
+	}
+	return obj
+}
+
+func (b *Builder) field(scope resolve.Scope, f schema.Field, n int, visiting map[string]bool) (any, bool) {
+	elem, list := schema.ElementType(f.Type)
+	value, ok := b.value(scope, f, elem, n, visiting)
+	if !ok || !list {
+		return value, ok
+	}
+	return []any{value}, true
+}
+
+func (b *Builder) value(scope resolve.Scope, f schema.Field, fieldType string, n int, visiting map[string]bool) (any, bool) {
+	// Nested elements are built when required, or when their own fields
+	// give examples.
+	var obj map[string]any
+	if len(f.Children) > 0 {
+		obj = b.object(scope, f.Children, n, visiting)
+	} else if target, ok := scope.Lookup(fieldType); ok {
+		key := target.Namespace + "." + target.GetName()
+		if visiting[key] {
+			return nil, false
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+		obj = b.object(b.resolver.Scope(target.Namespace), target.StoredFields(), n, visiting)
+	}
+	if obj != nil {
+		return obj, f.Required || len(obj) > 0
+	}
+
+	if examples := f.AllExamples(); len(examples) > 0 {
+		return typed(fieldType, examples[n%len(examples)]), true
+	}
+	if !f.Required {
+		return nil, false
+	}
+	if len(f.Enum) > 0 {
+		return f.Enum[n%len(f.Enum)], true
+	}
+	if value, ok := placeholders[fieldType]; ok {
+		return value, true
+	}
+	if fieldType == "id" {
+		return fmt.Sprintf("example-%d", n+1), true
+	}
+	return "example", true
+}
+
+// typed returns an example, which schemas hold as text, as a value of
+// fieldType.
+func typed(fieldType, example string) any {
+	switch fieldType {
+	case "integer", "positiveInt", "unsignedInt", "decimal":
+		return json.Number(example)
+	case "boolean":
+		return example == "true"
+	}
+	return example
+}
@@ -0,0 +1,99 @@
+package sample
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/validate"
+)
+
+var testSchemas = []schema.Schema{
+	{
+		Resource:  "Patient",
+		Namespace: "clinic",
+		Fields: []schema.Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "mrn", Type: "string", Required: true, Example: "MRN-0042", Examples: []string{"MRN-0077"}},
+			{Name: "age", Type: "integer", Example: "42"},
+			{Name: "active", Type: "boolean", Example: "true"},
+			{Name: "gender", Type: "code", Required: true, Enum: []string{"female", "male"}},
+			{Name: "nickname", Type: "string"},
+			{Name: "deceased", Type: "boolean", Example: "true"},
+			{Name: "deceasedDate", Type: "date", RequiredIf: "deceased == true"},
+			{Name: "pronouns", Type: "string", RequiredIf: "gender != female"},
+			{Name: "birthDate", Type: "date", Required: true},
+			{Name: "aliases", Type: "[]string", Example: "Jo"},
+			{Name: "contact", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "phone", Type: "string", Example: "555-0100"},
+			}},
+			{Name: "visit", Type: "Visit", Required: true},
+		},
+	},
+	{
+		Name:      "Visit",
+		Namespace: "clinic",
+		Fields: []schema.Field{
+			{Name: "reason", Type: "string", Required: true, Example: "checkup"},
+			{Name: "patient", Type: "Patient"},
+			{Name: "notes", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "text", Type: "string"},
+			}},
+		},
+	},
+}
+
+func TestRecord(t *testing.T) {
+	b, err := New(testSchemas)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	got := b.Record(testSchemas[0], 1)
+	want := map[string]any{
+		"resourceType": "Patient",
+		"id":           "example-2",
+		"mrn":          "MRN-0077",
+		"age":          json.Number("42"),
+		"active":       true,
+		"gender":       "male",
+		"deceased":     true,
+		"deceasedDate": "2024-01-01",
+		"pronouns":     "example",
+		"birthDate":    "2024-01-01",
+		"aliases":      []any{"Jo"},
+		"contact":      map[string]any{"phone": "555-0100"},
+		"visit":        map[string]any{"reason": "checkup"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Record() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestRecordsValidate(t *testing.T) {
+	b, err := New(testSchemas)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	v, err := validate.New(testSchemas, testSchemas[0], true)
+	if err != nil {
+		t.Fatalf("validate.New() failed: %v", err)
+	}
+	for n := 0; n < 3; n++ {
+		// Encode and decode, as ehrglot sample and validate-data do.
+		data, err := json.Marshal(b.Record(testSchemas[0], n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var record map[string]any
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		if err := d.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		if issues := v.Record(record); len(issues) > 0 {
+			t.Errorf("record %d %s: %v", n, data, issues)
+		}
+	}
+}
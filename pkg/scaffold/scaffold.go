@@ -0,0 +1,255 @@
+// Package scaffold writes starting points for code that runs generated
+// artifacts, meant to be edited rather than regenerated.
+package scaffold
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Orchestrators a pipeline can be scaffolded for.
+const (
+	Airflow = "airflow"
+	Dagster = "dagster"
+)
+
+// Orchestrators lists the supported orchestrators in sorted order.
+var Orchestrators = []string{Airflow, Dagster}
+
+// FileName returns the name of the file the pipeline for orchestrator is
+// written to.
+func FileName(orchestrator string) (string, error) {
+	switch orchestrator {
+	case Airflow:
+		return "ehrglot_dags.py", nil
+	case Dagster:
+		return "definitions.py", nil
+	}
+	return "", fmt.Errorf("unknown orchestrator %q (want %s)", orchestrator, strings.Join(Orchestrators, " or "))
+}
+
+// extract is a source table that one or more mappings read.
+type extract struct {
+	Name   string
+	System string
+	Table  string
+}
+
+// step converts the extract of a mapping's source table to its target
+// resources and loads them into the resource's table.
+type step struct {
+	Name     string
+	Extract  *extract
+	Mapping  string
+	Resource string
+	Table    string
+}
+
+// system groups the extracts and steps of one source system.
+type system struct {
+	Name     string
+	Extracts []*extract
+	Steps    []step
+}
+
+// Pipeline writes a pipeline for orchestrator that, for each mapping,
+// extracts its source table, converts the extract with ehrglot convert, and
+// loads the resources into the target's table. Extracting and loading are
+// stubs that read CSV files from a data directory and count the converted
+// resources, so the pipeline runs before they are replaced with real
+// connections. Mapping paths are written as loaded, so the pipeline runs from
+// the directory ehrglot was run in.
+func Pipeline(w io.Writer, orchestrator string, mappings []schema.SchemaMapping, version string) error {
+	tmpl, ok := pipelineTemplates[orchestrator]
+	if !ok {
+		_, err := FileName(orchestrator)
+		return err
+	}
+	data := struct {
+		Version string
+		Systems []*system
+	}{Version: version, Systems: systems(mappings)}
+	return tmpl.Execute(w, data)
+}
+
+// systems groups mappings by source system, reading each source table once
+// however many mappings it feeds. Systems, extracts, and steps are sorted by
+// name.
+func systems(mappings []schema.SchemaMapping) []*system {
+	byName := make(map[string]*system)
+	extracts := make(map[string]*extract)
+	for _, m := range mappings {
+		name := naming.Snake.Apply(m.SourceSystem)
+		sys, ok := byName[name]
+		if !ok {
+			sys = &system{Name: name}
+			byName[name] = sys
+		}
+		table := naming.Snake.Apply(m.SourceTable)
+		e, ok := extracts[name+"."+m.SourceTable]
+		if !ok {
+			e = &extract{Name: naming.Ident("python", name+"_"+table+"_extract"), System: name, Table: m.SourceTable}
+			extracts[name+"."+m.SourceTable] = e
+			sys.Extracts = append(sys.Extracts, e)
+		}
+		resource := naming.Snake.Apply(m.TargetResource)
+		sys.Steps = append(sys.Steps, step{
+			Name:     naming.Ident("python", name+"_"+table+"_to_"+resource),
+			Extract:  e,
+			Mapping:  filepath.ToSlash(m.SourceFile),
+			Resource: m.TargetResource,
+			Table:    resource,
+		})
+	}
+
+	var out []*system
+	for _, sys := range byName {
+		sort.Slice(sys.Extracts, func(i, j int) bool { return sys.Extracts[i].Name < sys.Extracts[j].Name })
+		sort.SliceStable(sys.Steps, func(i, j int) bool { return sys.Steps[i].Name < sys.Steps[j].Name })
+		out = append(out, sys)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// quote quotes s as a Python string literal.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// helpers are the extract, transform, and load functions both orchestrators
+// call.
+const helpers = `EHRGLOT = os.environ.get("EHRGLOT", "ehrglot")
+SCHEMAS = os.environ.get("EHRGLOT_SCHEMAS", "schemas")
+DATA_DIR = Path(os.environ.get("EHRGLOT_DATA", "data"))
+
+
+def extract(source_system: str, source_table: str) -> Path:
+    """Return a CSV extract of a source table.
+
+    Replace this with a query against the source system. Until then it reads
+    DATA_DIR/<source_system>/<source_table>.csv.
+    """
+    path = DATA_DIR / source_system / f"{source_table}.csv"
+    if not path.exists():
+        raise FileNotFoundError(f"no extract of {source_system}.{source_table} at {path}")
+    return path
+
+
+def transform(mapping: str, source: Path, name: str) -> Path:
+    """Convert a CSV extract to NDJSON resources with a mapping."""
+    out = DATA_DIR / "fhir" / f"{name}.ndjson"
+    out.parent.mkdir(parents=True, exist_ok=True)
+    subprocess.run(
+        [EHRGLOT, "convert", "--schemas", SCHEMAS, "--mapping", mapping, "--input", str(source), "--dest", str(out)],
+        check=True,
+    )
+    return out
+
+
+def load(table: str, resources: Path) -> int:
+    """Load NDJSON resources into a target table and return how many there were.
+
+    Replace this with a load into the warehouse. Until then it only counts them.
+    """
+    with resources.open() as f:
+        return sum(1 for line in f if line.strip())
+`
+
+var pipelineTemplates = map[string]*template.Template{
+	Airflow: template.Must(template.New(Airflow).Funcs(template.FuncMap{"quote": quote}).Parse(`"""Airflow DAGs that run ehrglot mappings, one per source system.
+
+Scaffolded by ehrglot v{{.Version}}. Edit freely: ehrglot does not regenerate it.
+"""
+
+from __future__ import annotations
+
+import os
+import subprocess
+from datetime import datetime
+from pathlib import Path
+
+from airflow.decorators import dag, task
+
+` + helpers + `{{range .Systems}}
+
+@dag(dag_id="ehrglot_{{.Name}}", schedule=None, start_date=datetime(2024, 1, 1), catchup=False, tags=["ehrglot"])
+def ehrglot_{{.Name}}() -> None:
+{{- range .Extracts}}
+    @task(task_id="{{.Name}}")
+    def {{.Name}}() -> str:
+        return str(extract({{quote .System}}, {{quote .Table}}))
+{{end}}
+{{- range .Steps}}
+    @task(task_id="{{.Name}}")
+    def {{.Name}}(source: str) -> str:
+        return str(transform({{quote .Mapping}}, Path(source), "{{.Name}}"))
+
+    @task(task_id="{{.Name}}_load")
+    def {{.Name}}_load(resources: str) -> None:
+        count = load({{quote .Table}}, Path(resources))
+        print(f"Loaded {count} {{.Resource}} resources into {{.Table}}")
+{{end}}
+{{- range .Extracts}}
+    {{.Name}}_out = {{.Name}}()
+{{- end}}
+{{- range .Steps}}
+    {{.Name}}_load({{.Name}}({{.Extract.Name}}_out))
+{{- end}}
+
+
+ehrglot_{{.Name}}()
+{{end}}`)),
+	Dagster: template.Must(template.New(Dagster).Funcs(template.FuncMap{"quote": quote}).Parse(`"""Dagster assets that run ehrglot mappings, grouped by source system.
+
+Scaffolded by ehrglot v{{.Version}}. Edit freely: ehrglot does not regenerate it.
+"""
+
+from __future__ import annotations
+
+import os
+import subprocess
+from pathlib import Path
+
+from dagster import AssetExecutionContext, Definitions, asset
+
+` + helpers + `{{range .Systems}}{{$system := .Name}}{{range .Extracts}}
+
+@asset(group_name="{{$system}}")
+def {{.Name}}() -> Path:
+    return extract({{quote .System}}, {{quote .Table}})
+{{end}}{{range .Steps}}
+
+@asset(group_name="{{$system}}")
+def {{.Name}}({{.Extract.Name}}: Path) -> Path:
+    return transform({{quote .Mapping}}, {{.Extract.Name}}, "{{.Name}}")
+
+
+@asset(group_name="{{$system}}")
+def {{.Name}}_load(context: AssetExecutionContext, {{.Name}}: Path) -> None:
+    count = load({{quote .Table}}, {{.Name}})
+    context.log.info(f"Loaded {count} {{.Resource}} resources into {{.Table}}")
+{{end}}{{end}}
+
+defs = Definitions(
+    assets=[
+{{- range .Systems}}
+{{- range .Extracts}}
+        {{.Name}},
+{{- end}}
+{{- range .Steps}}
+        {{.Name}},
+        {{.Name}}_load,
+{{- end}}
+{{- end}}
+    ],
+)
+`)),
+}
@@ -0,0 +1,46 @@
+package scaffold
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+)
+
+func TestPipelineGolden(t *testing.T) {
+	mappings := fixtures.Mappings()
+	for i := range mappings {
+		mappings[i].SourceFile = filepath.Join("schemas", mappings[i].SourceSystem, mappings[i].SourceTable+"_mapping.yaml")
+	}
+	for _, orchestrator := range Orchestrators {
+		t.Run(orchestrator, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := Pipeline(&got, orchestrator, mappings, "0.1.0"); err != nil {
+				t.Fatalf("Pipeline() failed: %v", err)
+			}
+			path := filepath.Join("testdata", orchestrator+".py")
+			if os.Getenv("UPDATE_GOLDEN") != "" {
+				if err := os.WriteFile(path, got.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to write golden file: %v", err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("Pipeline() differs from %s:\n%s", path, got.String())
+			}
+		})
+	}
+}
+
+func TestPipelineUnknownOrchestrator(t *testing.T) {
+	err := Pipeline(&bytes.Buffer{}, "luigi", fixtures.Mappings(), "0.1.0")
+	if err == nil || err.Error() != `unknown orchestrator "luigi" (want airflow or dagster)` {
+		t.Errorf("Pipeline() error = %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+"""Airflow DAGs that run ehrglot mappings, one per source system.
+
+Scaffolded by ehrglot v0.1.0. Edit freely: ehrglot does not regenerate it.
+"""
+
+from __future__ import annotations
+
+import os
+import subprocess
+from datetime import datetime
+from pathlib import Path
+
+from airflow.decorators import dag, task
+
+EHRGLOT = os.environ.get("EHRGLOT", "ehrglot")
+SCHEMAS = os.environ.get("EHRGLOT_SCHEMAS", "schemas")
+DATA_DIR = Path(os.environ.get("EHRGLOT_DATA", "data"))
+
+
+def extract(source_system: str, source_table: str) -> Path:
+    """Return a CSV extract of a source table.
+
+    Replace this with a query against the source system. Until then it reads
+    DATA_DIR/<source_system>/<source_table>.csv.
+    """
+    path = DATA_DIR / source_system / f"{source_table}.csv"
+    if not path.exists():
+        raise FileNotFoundError(f"no extract of {source_system}.{source_table} at {path}")
+    return path
+
+
+def transform(mapping: str, source: Path, name: str) -> Path:
+    """Convert a CSV extract to NDJSON resources with a mapping."""
+    out = DATA_DIR / "fhir" / f"{name}.ndjson"
+    out.parent.mkdir(parents=True, exist_ok=True)
+    subprocess.run(
+        [EHRGLOT, "convert", "--schemas", SCHEMAS, "--mapping", mapping, "--input", str(source), "--dest", str(out)],
+        check=True,
+    )
+    return out
+
+
+def load(table: str, resources: Path) -> int:
+    """Load NDJSON resources into a target table and return how many there were.
+
+    Replace this with a load into the warehouse. Until then it only counts them.
+    """
+    with resources.open() as f:
+        return sum(1 for line in f if line.strip())
+
+
+@dag(dag_id="ehrglot_ehr", schedule=None, start_date=datetime(2024, 1, 1), catchup=False, tags=["ehrglot"])
+def ehrglot_ehr() -> None:
+    @task(task_id="ehr_visit_extract")
+    def ehr_visit_extract() -> str:
+        return str(extract("ehr", "VISIT"))
+
+    @task(task_id="ehr_visit_to_practitioner")
+    def ehr_visit_to_practitioner(source: str) -> str:
+        return str(transform("schemas/ehr/VISIT_mapping.yaml", Path(source), "ehr_visit_to_practitioner"))
+
+    @task(task_id="ehr_visit_to_practitioner_load")
+    def ehr_visit_to_practitioner_load(resources: str) -> None:
+        count = load("practitioner", Path(resources))
+        print(f"Loaded {count} Practitioner resources into practitioner")
+
+    @task(task_id="ehr_visit_to_visit")
+    def ehr_visit_to_visit(source: str) -> str:
+        return str(transform("schemas/ehr/VISIT_mapping.yaml", Path(source), "ehr_visit_to_visit"))
+
+    @task(task_id="ehr_visit_to_visit_load")
+    def ehr_visit_to_visit_load(resources: str) -> None:
+        count = load("visit", Path(resources))
+        print(f"Loaded {count} Visit resources into visit")
+
+    ehr_visit_extract_out = ehr_visit_extract()
+    ehr_visit_to_practitioner_load(ehr_visit_to_practitioner(ehr_visit_extract_out))
+    ehr_visit_to_visit_load(ehr_visit_to_visit(ehr_visit_extract_out))
+
+
+ehrglot_ehr()
+
+
+@dag(dag_id="ehrglot_lis", schedule=None, start_date=datetime(2024, 1, 1), catchup=False, tags=["ehrglot"])
+def ehrglot_lis() -> None:
+    @task(task_id="lis_specimen_archive_extract")
+    def lis_specimen_archive_extract() -> str:
+        return str(extract("lis", "SPECIMEN_ARCHIVE"))
+
+    @task(task_id="lis_specimen_extract")
+    def lis_specimen_extract() -> str:
+        return str(extract("lis", "SPECIMEN"))
+
+    @task(task_id="lis_specimen_archive_to_specimen")
+    def lis_specimen_archive_to_specimen(source: str) -> str:
+        return str(transform("schemas/lis/SPECIMEN_ARCHIVE_mapping.yaml", Path(source), "lis_specimen_archive_to_specimen"))
+
+    @task(task_id="lis_specimen_archive_to_specimen_load")
+    def lis_specimen_archive_to_specimen_load(resources: str) -> None:
+        count = load("specimen", Path(resources))
+        print(f"Loaded {count} Specimen resources into specimen")
+
+    @task(task_id="lis_specimen_to_specimen")
+    def lis_specimen_to_specimen(source: str) -> str:
+        return str(transform("schemas/lis/SPECIMEN_mapping.yaml", Path(source), "lis_specimen_to_specimen"))
+
+    @task(task_id="lis_specimen_to_specimen_load")
+    def lis_specimen_to_specimen_load(resources: str) -> None:
+        count = load("specimen", Path(resources))
+        print(f"Loaded {count} Specimen resources into specimen")
+
+    lis_specimen_archive_extract_out = lis_specimen_archive_extract()
+    lis_specimen_extract_out = lis_specimen_extract()
+    lis_specimen_archive_to_specimen_load(lis_specimen_archive_to_specimen(lis_specimen_archive_extract_out))
+    lis_specimen_to_specimen_load(lis_specimen_to_specimen(lis_specimen_extract_out))
+
+
+ehrglot_lis()
@@ -0,0 +1,124 @@
+"""Dagster assets that run ehrglot mappings, grouped by source system.
+
+Scaffolded by ehrglot v0.1.0. Edit freely: ehrglot does not regenerate it.
+"""
+
+from __future__ import annotations
+
+import os
+import subprocess
+from pathlib import Path
+
+from dagster import AssetExecutionContext, Definitions, asset
+
+EHRGLOT = os.environ.get("EHRGLOT", "ehrglot")
+SCHEMAS = os.environ.get("EHRGLOT_SCHEMAS", "schemas")
+DATA_DIR = Path(os.environ.get("EHRGLOT_DATA", "data"))
+
+
+def extract(source_system: str, source_table: str) -> Path:
+    """Return a CSV extract of a source table.
+
+    Replace this with a query against the source system. Until then it reads
+    DATA_DIR/<source_system>/<source_table>.csv.
+    """
+    path = DATA_DIR / source_system / f"{source_table}.csv"
+    if not path.exists():
+        raise FileNotFoundError(f"no extract of {source_system}.{source_table} at {path}")
+    return path
+
+
+def transform(mapping: str, source: Path, name: str) -> Path:
+    """Convert a CSV extract to NDJSON resources with a mapping."""
+    out = DATA_DIR / "fhir" / f"{name}.ndjson"
+    out.parent.mkdir(parents=True, exist_ok=True)
+    subprocess.run(
+        [EHRGLOT, "convert", "--schemas", SCHEMAS, "--mapping", mapping, "--input", str(source), "--dest", str(out)],
+        check=True,
+    )
+    return out
+
+
+def load(table: str, resources: Path) -> int:
+    """Load NDJSON resources into a target table and return how many there were.
+
+    Replace this with a load into the warehouse. Until then it only counts them.
+    """
+    with resources.open() as f:
+        return sum(1 for line in f if line.strip())
+
+
+@asset(group_name="ehr")
+def ehr_visit_extract() -> Path:
+    return extract("ehr", "VISIT")
+
+
+@asset(group_name="ehr")
+def ehr_visit_to_practitioner(ehr_visit_extract: Path) -> Path:
+    return transform("schemas/ehr/VISIT_mapping.yaml", ehr_visit_extract, "ehr_visit_to_practitioner")
+
+
+@asset(group_name="ehr")
+def ehr_visit_to_practitioner_load(context: AssetExecutionContext, ehr_visit_to_practitioner: Path) -> None:
+    count = load("practitioner", ehr_visit_to_practitioner)
+    context.log.info(f"Loaded {count} Practitioner resources into practitioner")
+
+
+@asset(group_name="ehr")
+def ehr_visit_to_visit(ehr_visit_extract: Path) -> Path:
+    return transform("schemas/ehr/VISIT_mapping.yaml", ehr_visit_extract, "ehr_visit_to_visit")
+
+
+@asset(group_name="ehr")
+def ehr_visit_to_visit_load(context: AssetExecutionContext, ehr_visit_to_visit: Path) -> None:
+    count = load("visit", ehr_visit_to_visit)
+    context.log.info(f"Loaded {count} Visit resources into visit")
+
+
+@asset(group_name="lis")
+def lis_specimen_archive_extract() -> Path:
+    return extract("lis", "SPECIMEN_ARCHIVE")
+
+
+@asset(group_name="lis")
+def lis_specimen_extract() -> Path:
+    return extract("lis", "SPECIMEN")
+
+
+@asset(group_name="lis")
+def lis_specimen_archive_to_specimen(lis_specimen_archive_extract: Path) -> Path:
+    return transform("schemas/lis/SPECIMEN_ARCHIVE_mapping.yaml", lis_specimen_archive_extract, "lis_specimen_archive_to_specimen")
+
+
+@asset(group_name="lis")
+def lis_specimen_archive_to_specimen_load(context: AssetExecutionContext, lis_specimen_archive_to_specimen: Path) -> None:
+    count = load("specimen", lis_specimen_archive_to_specimen)
+    context.log.info(f"Loaded {count} Specimen resources into specimen")
+
+
+@asset(group_name="lis")
+def lis_specimen_to_specimen(lis_specimen_extract: Path) -> Path:
+    return transform("schemas/lis/SPECIMEN_mapping.yaml", lis_specimen_extract, "lis_specimen_to_specimen")
+
+
+@asset(group_name="lis")
+def lis_specimen_to_specimen_load(context: AssetExecutionContext, lis_specimen_to_specimen: Path) -> None:
+    count = load("specimen", lis_specimen_to_specimen)
+    context.log.info(f"Loaded {count} Specimen resources into specimen")
+
+
+defs = Definitions(
+    assets=[
+        ehr_visit_extract,
+        ehr_visit_to_practitioner,
+        ehr_visit_to_practitioner_load,
+        ehr_visit_to_visit,
+        ehr_visit_to_visit_load,
+        lis_specimen_archive_extract,
+        lis_specimen_extract,
+        lis_specimen_archive_to_specimen,
+        lis_specimen_archive_to_specimen_load,
+        lis_specimen_to_specimen,
+        lis_specimen_to_specimen_load,
+    ],
+)
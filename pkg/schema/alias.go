@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WireName returns the name of f in JSON: its json_name, or else name, the
+// name a generator derives from the field name by its own convention.
+func (f Field) WireName(name string) string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+	return name
+}
+
+// ColumnName returns the name of the SQL column of f: its db_column, or else
+// name, the name a generator derives from the field name.
+func (f Field) ColumnName(name string) string {
+	if f.DBColumn != "" {
+		return f.DBColumn
+	}
+	return name
+}
+
+var (
+	wireName   = regexp.MustCompile(`^[^\s"'\\,` + "`" + `]+$`)
+	columnName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// CheckAliases reports the first json_name that cannot be written in
+// generated code, such as one with quotes or commas, the first db_column that
+// is not a plain SQL identifier, and the first json_name or db_column that
+// another field of the same object already has as its own, or as its name.
+// Columns are compared case-insensitively, as unquoted SQL identifiers are.
+func (s Schema) CheckAliases() error {
+	return checkAliases(s.Fields, "")
+}
+
+func checkAliases(fields []Field, parent string) error {
+	wire := make(map[string]string)
+	columns := make(map[string]string)
+	for _, f := range fields {
+		wire[f.Name] = f.Name
+		columns[strings.ToLower(f.Name)] = f.Name
+	}
+	for _, f := range fields {
+		path := parent + f.Name
+		if f.JSONName != "" {
+			if !wireName.MatchString(f.JSONName) {
+				return fmt.Errorf("field %s has json_name %q, which has spaces, quotes, backslashes, or commas", path, f.JSONName)
+			}
+			if other, ok := wire[f.JSONName]; ok && other != f.Name {
+				return fmt.Errorf("field %s has json_name %s, which field %s has already", path, f.JSONName, parent+other)
+			}
+			wire[f.JSONName] = f.Name
+		}
+		if f.DBColumn != "" {
+			if !columnName.MatchString(f.DBColumn) {
+				return fmt.Errorf("field %s has db_column %q, which is not a SQL identifier", path, f.DBColumn)
+			}
+			if other, ok := columns[strings.ToLower(f.DBColumn)]; ok && other != f.Name {
+				return fmt.Errorf("field %s has db_column %s, which field %s has already", path, f.DBColumn, parent+other)
+			}
+			columns[strings.ToLower(f.DBColumn)] = f.Name
+		}
+		if err := checkAliases(f.Children, path+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWireAndColumnNames(t *testing.T) {
+	f := Field{Name: "mrn", JSONName: "medicalRecordNumber", DBColumn: "PAT_MRN_ID"}
+	if got := f.WireName("mrn"); got != "medicalRecordNumber" {
+		t.Errorf("WireName() = %s, want medicalRecordNumber", got)
+	}
+	if got := f.ColumnName("mrn"); got != "PAT_MRN_ID" {
+		t.Errorf("ColumnName() = %s, want PAT_MRN_ID", got)
+	}
+	if got := (Field{Name: "mrn"}).WireName("Mrn"); got != "Mrn" {
+		t.Errorf("WireName() without json_name = %s, want Mrn", got)
+	}
+}
+
+func TestCheckAliases(t *testing.T) {
+	for _, tc := range []struct {
+		fields []Field
+		want   string
+	}{
+		{[]Field{{Name: "mrn", JSONName: "medicalRecordNumber", DBColumn: "PAT_MRN_ID"}, {Name: "id", JSONName: "id"}}, ""},
+		{[]Field{{Name: "mrn", JSONName: "mrn id"}}, `field mrn has json_name "mrn id", which has spaces`},
+		{[]Field{{Name: "mrn", DBColumn: "PAT-MRN"}}, `field mrn has db_column "PAT-MRN", which is not a SQL identifier`},
+		{[]Field{{Name: "mrn", JSONName: "id"}, {Name: "id"}}, "field mrn has json_name id, which field id has already"},
+		{[]Field{{Name: "mrn", DBColumn: "PAT_ID"}, {Name: "id", DBColumn: "pat_id"}}, "field id has db_column pat_id, which field mrn has already"},
+		{[]Field{{Name: "contact", Children: []Field{{Name: "name"}, {Name: "phone", JSONName: "name"}}}}, "field contact.phone has json_name name, which field contact.name has already"},
+	} {
+		err := Schema{Name: "Patient", Fields: tc.fields}.CheckAliases()
+		if tc.want == "" && err != nil || tc.want != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.want)) {
+			t.Errorf("CheckAliases() = %v, want %q", err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Condition is a parsed required_if condition on another top-level field of
+// the same schema: Field compared with Value by Op, or, when Op is empty,
+// Field being set. A != condition also holds when Field is not set.
+type Condition struct {
+	Field string
+	Op    string // "==", "!=", or ""
+	Value string // Unquoted; ConditionalFields writes booleans and integers as JSON does
+}
+
+var conditionPattern = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*(?:(==|!=)\s*(.+?))?\s*$`)
+
+// ParseCondition parses a required_if condition: "field", "field == value",
+// or "field != value". String values may be quoted with " or '.
+func ParseCondition(text string) (Condition, error) {
+	m := conditionPattern.FindStringSubmatch(text)
+	if m == nil {
+		return Condition{}, fmt.Errorf("condition %q is not field, field == value, or field != value", text)
+	}
+	c := Condition{Field: m[1], Op: m[2], Value: m[3]}
+	if len(c.Value) >= 2 && (c.Value[0] == '"' || c.Value[0] == '\'') && c.Value[len(c.Value)-1] == c.Value[0] {
+		c.Value = c.Value[1 : len(c.Value)-1]
+	}
+	return c, nil
+}
+
+// String returns the condition as written in schemas, without quotes.
+func (c Condition) String() string {
+	if c.Op == "" {
+		return c.Field
+	}
+	return c.Field + " " + c.Op + " " + c.Value
+}
+
+// Holds reports whether the condition holds for the text of its field, as
+// JSON writes it, where set is false when the field is not set.
+func (c Condition) Holds(text string, set bool) bool {
+	switch c.Op {
+	case "==":
+		return set && text == c.Value
+	case "!=":
+		return !set || text != c.Value
+	}
+	return set
+}
+
+// comparableTypes are the field types a condition can compare with a value.
+// Others, such as dates, can only be tested for being set.
+var comparableTypes = map[string]bool{
+	"string": true, "code": true, "id": true, "uri": true, "url": true, "canonical": true,
+	"oid": true, "uuid": true, "markdown": true,
+	"integer": true, "positiveInt": true, "unsignedInt": true, "boolean": true,
+}
+
+// ConditionalField is a field of a schema that a condition makes required,
+// or without which it is left out, with the field the condition tests.
+type ConditionalField struct {
+	Field     Field
+	Condition Condition
+	On        Field
+}
+
+// ConditionalFields returns the fields of s that required_if makes
+// required, in field order, or the first invalid required_if. Conditions
+// test other top-level fields; values compared with them must be of their
+// type and, for fields with an enum, one of its values.
+func (s Schema) ConditionalFields() ([]ConditionalField, error) {
+	return s.conditions("required_if", func(f Field) string { return f.RequiredIf })
+}
+
+// EnabledFields returns the fields of s that enabled_if leaves out unless
+// its condition holds, in field order, or the first invalid enabled_if.
+// Conditions are checked as ConditionalFields checks them. Required fields
+// cannot take enabled_if; conditionally enabled fields that must be set
+// when enabled take the same condition as required_if.
+func (s Schema) EnabledFields() ([]ConditionalField, error) {
+	return s.conditions("enabled_if", func(f Field) string { return f.EnabledIf })
+}
+
+// conditions returns the fields of s with a condition under key, which
+// condition returns.
+func (s Schema) conditions(key string, condition func(Field) string) ([]ConditionalField, error) {
+	byName := make(map[string]Field, len(s.Fields))
+	for _, f := range s.Fields {
+		byName[f.Name] = f
+	}
+	var conditional []ConditionalField
+	var check func(prefix string, fields []Field) error
+	check = func(prefix string, fields []Field) error {
+		for _, f := range fields {
+			if err := check(prefix+f.Name+".", f.Children); err != nil {
+				return err
+			}
+			if condition(f) == "" {
+				continue
+			}
+			name := prefix + f.Name
+			if prefix != "" {
+				return fmt.Errorf("field %s is nested and cannot take %s", name, key)
+			}
+			if f.Required {
+				return fmt.Errorf("field %s is required and cannot take %s", name, key)
+			}
+			c, err := ParseCondition(condition(f))
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			on, ok := byName[c.Field]
+			if !ok || c.Field == f.Name {
+				return fmt.Errorf("field %s: %s names %s, which is not another top-level field", name, key, c.Field)
+			}
+			if on.Derived != "" {
+				return fmt.Errorf("field %s: %s names %s, which is derived and not stored", name, key, c.Field)
+			}
+			if c.Op != "" {
+				if c.Value, err = conditionValue(on, c.Value); err != nil {
+					return fmt.Errorf("field %s: %s %w", name, key, err)
+				}
+			}
+			conditional = append(conditional, ConditionalField{Field: f, Condition: c, On: on})
+		}
+		return nil
+	}
+	if err := check("", s.Fields); err != nil {
+		return nil, err
+	}
+	return conditional, nil
+}
+
+// conditionValue returns value, which a condition compares with field on,
+// in the form JSON writes it, or an error when on cannot hold it.
+func conditionValue(on Field, value string) (string, error) {
+	if !comparableTypes[on.Type] {
+		return "", fmt.Errorf("compares %s, whose type %s can only be tested for being set", on.Name, on.Type)
+	}
+	switch on.DefaultKind() {
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("compares %s with %q, which is not a valid %s", on.Name, value, on.Type)
+		}
+		value = strconv.FormatInt(i, 10)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("compares %s with %q, which is not a valid %s", on.Name, value, on.Type)
+		}
+		value = strconv.FormatBool(b)
+	}
+	if len(on.Enum) > 0 && !contains(on.Enum, value) {
+		return "", fmt.Errorf("compares %s with %q, which is not one of %s", on.Name, value, strings.Join(on.Enum, ", "))
+	}
+	return value, nil
+}
@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want Condition
+	}{
+		{"deceased == true", Condition{Field: "deceased", Op: "==", Value: "true"}},
+		{"status!='entered in error'", Condition{Field: "status", Op: "!=", Value: "entered in error"}},
+		{` kind == "a" `, Condition{Field: "kind", Op: "==", Value: "a"}},
+		{"deceased_at", Condition{Field: "deceased_at"}},
+		{"2ndOpinion == false", Condition{Field: "2ndOpinion", Op: "==", Value: "false"}},
+	} {
+		got, err := ParseCondition(tc.text)
+		if err != nil {
+			t.Errorf("ParseCondition(%q) failed: %v", tc.text, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseCondition(%q) = %+v, want %+v", tc.text, got, tc.want)
+		}
+	}
+	for _, text := range []string{"", "a > 1", "== true", "a ==", "a.b == 1"} {
+		if _, err := ParseCondition(text); err == nil {
+			t.Errorf("ParseCondition(%q) succeeded, want an error", text)
+		}
+	}
+}
+
+func TestConditionHolds(t *testing.T) {
+	for _, tc := range []struct {
+		condition string
+		text      string
+		set       bool
+		want      bool
+	}{
+		{"a", "x", true, true},
+		{"a", "", false, false},
+		{"a == x", "x", true, true},
+		{"a == x", "y", true, false},
+		{"a == x", "", false, false},
+		{"a != x", "y", true, true},
+		{"a != x", "x", true, false},
+		{"a != x", "", false, true},
+	} {
+		c, err := ParseCondition(tc.condition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Holds(tc.text, tc.set); got != tc.want {
+			t.Errorf("%q.Holds(%q, %v) = %v, want %v", tc.condition, tc.text, tc.set, got, tc.want)
+		}
+	}
+}
+
+func TestConditionalFields(t *testing.T) {
+	fields := []Field{
+		{Name: "deceased", Type: "boolean"},
+		{Name: "rank", Type: "integer"},
+		{Name: "status", Type: "code", Enum: []string{"active", "inactive"}},
+		{Name: "born", Type: "date"},
+	}
+	s := Schema{Name: "A", Fields: append(fields,
+		Field{Name: "deceased_at", Type: "dateTime", RequiredIf: "deceased == True"},
+		Field{Name: "reason", Type: "string", RequiredIf: "status != 'active'"},
+		Field{Name: "place", Type: "string", RequiredIf: "born"},
+		Field{Name: "note", Type: "string", RequiredIf: "rank == +2"},
+	)}
+	got, err := s.ConditionalFields()
+	if err != nil {
+		t.Fatalf("ConditionalFields() failed: %v", err)
+	}
+	want := []ConditionalField{
+		{Field: s.Fields[4], Condition: Condition{Field: "deceased", Op: "==", Value: "true"}, On: fields[0]},
+		{Field: s.Fields[5], Condition: Condition{Field: "status", Op: "!=", Value: "active"}, On: fields[2]},
+		{Field: s.Fields[6], Condition: Condition{Field: "born"}, On: fields[3]},
+		{Field: s.Fields[7], Condition: Condition{Field: "rank", Op: "==", Value: "2"}, On: fields[1]},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConditionalFields() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "x", Type: "string", Required: true, RequiredIf: "deceased"}, "field x is required and cannot take required_if"},
+		{Field{Name: "x", Type: "string", RequiredIf: "deceased = true"}, `field x: condition "deceased = true" is not field, field == value, or field != value`},
+		{Field{Name: "x", Type: "string", RequiredIf: "missing"}, "field x: required_if names missing, which is not another top-level field"},
+		{Field{Name: "x", Type: "string", RequiredIf: "x"}, "field x: required_if names x, which is not another top-level field"},
+		{Field{Name: "x", Type: "string", RequiredIf: "born == 2020-01-01"}, "field x: required_if compares born, whose type date can only be tested for being set"},
+		{Field{Name: "x", Type: "string", RequiredIf: "deceased == yes"}, `field x: required_if compares deceased with "yes", which is not a valid boolean`},
+		{Field{Name: "x", Type: "string", RequiredIf: "status == retired"}, `field x: required_if compares status with "retired", which is not one of active, inactive`},
+		{Field{Name: "x", Type: "BackboneElement", Children: []Field{{Name: "y", Type: "string", RequiredIf: "deceased"}}}, "field x.y is nested and cannot take required_if"},
+	} {
+		_, err := Schema{Name: "A", Fields: append(fields[:len(fields):len(fields)], tc.field)}.ConditionalFields()
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("ConditionalFields(%s) error = %q, want %q", tc.field.RequiredIf, got, tc.want)
+		}
+	}
+}
+
+func TestEnabledFields(t *testing.T) {
+	fields := []Field{
+		{Name: "smoker", Type: "boolean"},
+		{Name: "packs", Type: "integer", EnabledIf: "smoker == true", RequiredIf: "smoker == true"},
+		{Name: "note", Type: "string", RequiredIf: "smoker"},
+	}
+	got, err := Schema{Name: "A", Fields: fields}.EnabledFields()
+	if err != nil {
+		t.Fatalf("EnabledFields() failed: %v", err)
+	}
+	want := []ConditionalField{{Field: fields[1], Condition: Condition{Field: "smoker", Op: "==", Value: "true"}, On: fields[0]}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EnabledFields() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "x", Type: "string", Required: true, EnabledIf: "smoker"}, "field x is required and cannot take enabled_if"},
+		{Field{Name: "x", Type: "string", EnabledIf: "missing"}, "field x: enabled_if names missing, which is not another top-level field"},
+		{Field{Name: "x", Type: "string", EnabledIf: "smoker == often"}, `field x: enabled_if compares smoker with "often", which is not a valid boolean`},
+	} {
+		_, err := Schema{Name: "A", Fields: append(fields[:1:1], tc.field)}.EnabledFields()
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("EnabledFields(%s) error = %q, want %q", tc.field.EnabledIf, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultTypes are the field types that can take a default: those whose
+// values every generator can write as a literal of the field's type.
+var defaultTypes = map[string]bool{
+	"string": true, "code": true, "id": true, "uri": true, "url": true, "canonical": true,
+	"oid": true, "uuid": true, "markdown": true,
+	"integer": true, "positiveInt": true, "unsignedInt": true, "decimal": true, "boolean": true,
+}
+
+// DefaultKind returns how the default of f is written in generated code:
+// "integer", "decimal", "boolean", or "text" for the types whose values are
+// quoted.
+func (f Field) DefaultKind() string {
+	switch f.Type {
+	case "integer", "positiveInt", "unsignedInt":
+		return "integer"
+	case "decimal":
+		return "decimal"
+	case "boolean":
+		return "boolean"
+	}
+	return "text"
+}
+
+// CheckDefaults reports the first field of s whose default does not parse
+// as a value of its type or is not one of its enum values, and defaults of
+// fields with children or of types without literals, such as dates and
+// lists.
+func (s Schema) CheckDefaults() error {
+	var check func(prefix string, fields []Field) error
+	check = func(prefix string, fields []Field) error {
+		for _, f := range fields {
+			if f.Default != "" {
+				if len(f.Children) > 0 {
+					return fmt.Errorf("field %s has children and cannot take a default", prefix+f.Name)
+				}
+				if !defaultTypes[f.Type] {
+					return fmt.Errorf("field %s of type %s cannot take a default", prefix+f.Name, f.Type)
+				}
+				var err error
+				switch f.DefaultKind() {
+				case "integer":
+					_, err = strconv.ParseInt(f.Default, 10, 64)
+				case "decimal":
+					_, err = strconv.ParseFloat(f.Default, 64)
+				case "boolean":
+					_, err = strconv.ParseBool(f.Default)
+				}
+				if err != nil {
+					return fmt.Errorf("field %s has default %q, which is not a valid %s", prefix+f.Name, f.Default, f.Type)
+				}
+				if len(f.Enum) > 0 && !contains(f.Enum, f.Default) {
+					return fmt.Errorf("field %s has default %q, which is not one of %s", prefix+f.Name, f.Default, strings.Join(f.Enum, ", "))
+				}
+			}
+			if err := check(prefix+f.Name+".", f.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check("", s.Fields)
+}
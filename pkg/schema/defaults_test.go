@@ -0,0 +1,31 @@
+package schema
+
+import "testing"
+
+func TestCheckDefaults(t *testing.T) {
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "status", Type: "code", Default: "active"}, ""},
+		{Field{Name: "count", Type: "integer", Default: "0"}, ""},
+		{Field{Name: "amount", Type: "decimal", Default: "1.5"}, ""},
+		{Field{Name: "active", Type: "boolean", Default: "true"}, ""},
+		{Field{Name: "count", Type: "unsignedInt", Default: "many"}, `field count has default "many", which is not a valid unsignedInt`},
+		{Field{Name: "active", Type: "boolean", Default: "yes"}, `field active has default "yes", which is not a valid boolean`},
+		{Field{Name: "status", Type: "code", Enum: []string{"active", "retired"}, Default: "gone"}, `field status has default "gone", which is not one of active, retired`},
+		{Field{Name: "born", Type: "date", Default: "2000-01-01"}, "field born of type date cannot take a default"},
+		{Field{Name: "tags", Type: "[]string", Default: "a"}, "field tags of type []string cannot take a default"},
+		{Field{Name: "contact", Type: "BackboneElement", Default: "x", Children: []Field{{Name: "name", Type: "string"}}}, "field contact has children and cannot take a default"},
+		{Field{Name: "contact", Type: "BackboneElement", Children: []Field{{Name: "rank", Type: "integer", Default: "first"}}}, `field contact.rank has default "first", which is not a valid integer`},
+	} {
+		err := Schema{Name: "A", Fields: []Field{tc.field}}.CheckDefaults()
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("CheckDefaults(%s) = %q, want %q", tc.field.Name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Expr is a parsed derived field expression. Op says what it is: "number"
+// and "text" literals and "field" references hold their Value, "+", "-",
+// "*", and "/" combine their two Args, "neg" negates its one, and the
+// functions years_since, days_between, and concat apply to theirs. Kind is
+// the kind of value it yields: "integer", "decimal", "text", or "date".
+type Expr struct {
+	Op    string
+	Value string
+	Args  []Expr
+	Kind  string
+}
+
+// function is the signature of a function of derived field expressions.
+type function struct {
+	args     []string // Kinds of the arguments; the last repeats when variadic
+	variadic bool
+	kind     string
+}
+
+var functions = map[string]function{
+	// Whole years from a date to today, as ages are counted.
+	"years_since": {args: []string{"date"}, kind: "integer"},
+	// Days from the first date to the second.
+	"days_between": {args: []string{"date", "date"}, kind: "integer"},
+	// The texts one after another.
+	"concat": {args: []string{"text"}, variadic: true, kind: "text"},
+}
+
+// ValueKind returns the kind of value field f holds in derived field
+// expressions: "integer", "decimal", "text", "date", or "" for types they
+// cannot use.
+func (f Field) ValueKind() string {
+	switch {
+	case len(f.Children) > 0:
+		return ""
+	case f.Type == "date" || f.Type == "datetime" || f.Type == "dateTime" || f.Type == "instant":
+		return "date"
+	case !defaultTypes[f.Type] || f.Type == "boolean":
+		return ""
+	}
+	return f.DefaultKind()
+}
+
+// Fields returns the names of the fields e refers to, each once, in the
+// order it first refers to them.
+func (e Expr) Fields() []string {
+	var names []string
+	var walk func(e Expr)
+	walk = func(e Expr) {
+		if e.Op == "field" && !contains(names, e.Value) {
+			names = append(names, e.Value)
+		}
+		for _, a := range e.Args {
+			walk(a)
+		}
+	}
+	walk(e)
+	return names
+}
+
+// Functions returns the names of the functions e calls, each once.
+func (e Expr) Functions() []string {
+	var names []string
+	var walk func(e Expr)
+	walk = func(e Expr) {
+		if _, ok := functions[e.Op]; ok && !contains(names, e.Op) {
+			names = append(names, e.Op)
+		}
+		for _, a := range e.Args {
+			walk(a)
+		}
+	}
+	walk(e)
+	return names
+}
+
+// DerivedField is a field of a schema that generated code computes from
+// other fields instead of storing.
+type DerivedField struct {
+	Field Field
+	Expr  Expr
+}
+
+// StoredFields returns the top-level fields of s that records hold: all
+// but the derived ones.
+func (s Schema) StoredFields() []Field {
+	var stored []Field
+	for _, f := range s.Fields {
+		if f.Derived == "" {
+			stored = append(stored, f)
+		}
+	}
+	return stored
+}
+
+// DerivedFields returns the derived fields of s, in field order, or the
+// first invalid one. Derived fields are top-level fields of text, integer,
+// or decimal types, computed from stored top-level fields; they take no
+// constraints, since nothing sets them.
+func (s Schema) DerivedFields() ([]DerivedField, error) {
+	stored := make(map[string]Field)
+	for _, f := range s.StoredFields() {
+		stored[f.Name] = f
+	}
+	var derived []DerivedField
+	var check func(prefix string, fields []Field) error
+	check = func(prefix string, fields []Field) error {
+		for _, f := range fields {
+			if err := check(prefix+f.Name+".", f.Children); err != nil {
+				return err
+			}
+			if f.Derived == "" {
+				continue
+			}
+			name := prefix + f.Name
+			switch {
+			case prefix != "":
+				return fmt.Errorf("field %s is nested and cannot be derived", name)
+			case f.ValueKind() == "" || f.ValueKind() == "date":
+				return fmt.Errorf("field %s of type %s cannot be derived", name, f.Type)
+			case f.Required || f.RequiredIf != "" || f.EnabledIf != "" || f.Default != "" || f.HasReferenceRange() || f.Encryption != "":
+				return fmt.Errorf("field %s is derived and cannot take required, required_if, enabled_if, default, reference ranges, or encryption", name)
+			}
+			e, err := ParseExpr(f.Derived, stored)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", name, err)
+			}
+			if kind := f.ValueKind(); e.Kind != kind && !(kind == "decimal" && e.Kind == "integer") {
+				return fmt.Errorf("field %s of type %s is derived from a%s %s", name, f.Type, article(e.Kind), e.Kind)
+			}
+			derived = append(derived, DerivedField{Field: f, Expr: e})
+		}
+		return nil
+	}
+	if err := check("", s.Fields); err != nil {
+		return nil, err
+	}
+	return derived, nil
+}
+
+// article returns "n" for kinds that take "an" rather than "a".
+func article(kind string) string {
+	if kind == "integer" {
+		return "n"
+	}
+	return ""
+}
+
+// ParseExpr parses a derived field expression over fields, by name: numbers,
+// 'text', field names, + - * / with parentheses, and calls of years_since,
+// days_between, and concat. Arithmetic needs numbers; dividing yields a
+// decimal, and so does combining a decimal with an integer.
+func ParseExpr(text string, fields map[string]Field) (Expr, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return Expr{}, err
+	}
+	p := &exprParser{tokens: tokens, fields: fields}
+	e, err := p.sum()
+	if err != nil {
+		return Expr{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Expr{}, fmt.Errorf("unexpected %s in %q", p.tokens[p.pos], text)
+	}
+	return e, nil
+}
+
+// tokenize splits a derived field expression into names, numbers, quoted
+// texts, and operators.
+func tokenize(text string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(text); {
+		c := rune(text[i])
+		start := i
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '\'':
+			end := strings.IndexByte(text[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated text in %q", text)
+			}
+			i += end + 2
+		case c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			for i < len(text) && (text[i] == '_' || text[i] == '.' || unicode.IsLetter(rune(text[i])) || unicode.IsDigit(rune(text[i]))) {
+				i++
+			}
+		case strings.ContainsRune("+-*/(),", c):
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", c, text)
+		}
+		tokens = append(tokens, text[start:i])
+	}
+	return tokens, nil
+}
+
+var numberPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+type exprParser struct {
+	tokens []string
+	pos    int
+	fields map[string]Field
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *exprParser) expect(token string) error {
+	if p.peek() != token {
+		if p.peek() == "" {
+			return fmt.Errorf("expected %s at the end", token)
+		}
+		return fmt.Errorf("expected %s, not %s", token, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+// sum parses terms joined by + and -.
+func (p *exprParser) sum() (Expr, error) {
+	return p.binary(p.product, "+", "-")
+}
+
+// product parses factors joined by * and /.
+func (p *exprParser) product() (Expr, error) {
+	return p.binary(p.factor, "*", "/")
+}
+
+func (p *exprParser) binary(operand func() (Expr, error), ops ...string) (Expr, error) {
+	left, err := operand()
+	if err != nil {
+		return Expr{}, err
+	}
+	for contains(ops, p.peek()) {
+		op := p.tokens[p.pos]
+		p.pos++
+		right, err := operand()
+		if err != nil {
+			return Expr{}, err
+		}
+		if !numeric(left) || !numeric(right) {
+			return Expr{}, fmt.Errorf("%s needs numbers, not a%s %s and a%s %s", op, article(left.Kind), left.Kind, article(right.Kind), right.Kind)
+		}
+		kind := "integer"
+		if op == "/" || left.Kind == "decimal" || right.Kind == "decimal" {
+			kind = "decimal"
+		}
+		left = Expr{Op: op, Args: []Expr{left, right}, Kind: kind}
+	}
+	return left, nil
+}
+
+func numeric(e Expr) bool {
+	return e.Kind == "integer" || e.Kind == "decimal"
+}
+
+// factor parses a literal, field, call, parenthesized expression, or
+// negation.
+func (p *exprParser) factor() (Expr, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return Expr{}, fmt.Errorf("expression ends early")
+	case token == "-":
+		p.pos++
+		x, err := p.factor()
+		if err != nil {
+			return Expr{}, err
+		}
+		if !numeric(x) {
+			return Expr{}, fmt.Errorf("- needs a number, not a%s %s", article(x.Kind), x.Kind)
+		}
+		return Expr{Op: "neg", Args: []Expr{x}, Kind: x.Kind}, nil
+	case token == "(":
+		p.pos++
+		e, err := p.sum()
+		if err != nil {
+			return Expr{}, err
+		}
+		return e, p.expect(")")
+	case token[0] == '\'':
+		p.pos++
+		return Expr{Op: "text", Value: token[1 : len(token)-1], Kind: "text"}, nil
+	case numberPattern.MatchString(token):
+		p.pos++
+		kind := "integer"
+		if strings.Contains(token, ".") {
+			kind = "decimal"
+		}
+		return Expr{Op: "number", Value: token, Kind: kind}, nil
+	case strings.Contains(token, "."):
+		return Expr{}, fmt.Errorf("%s is not a number", token)
+	}
+	p.pos++
+	if p.peek() == "(" {
+		return p.call(token)
+	}
+	f, ok := p.fields[token]
+	if !ok {
+		return Expr{}, fmt.Errorf("%s is not a stored top-level field", token)
+	}
+	kind := f.ValueKind()
+	if kind == "" {
+		return Expr{}, fmt.Errorf("field %s of type %s cannot be used in expressions", token, f.Type)
+	}
+	return Expr{Op: "field", Value: token, Kind: kind}, nil
+}
+
+// call parses the arguments of a call of name, whose ( is next.
+func (p *exprParser) call(name string) (Expr, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return Expr{}, fmt.Errorf("%s is not a function; use years_since, days_between, or concat", name)
+	}
+	p.pos++
+	e := Expr{Op: name, Kind: fn.kind}
+	for p.peek() != ")" {
+		if len(e.Args) > 0 {
+			if err := p.expect(","); err != nil {
+				return Expr{}, err
+			}
+		}
+		arg, err := p.sum()
+		if err != nil {
+			return Expr{}, err
+		}
+		e.Args = append(e.Args, arg)
+	}
+	p.pos++
+	if len(e.Args) < len(fn.args) || len(e.Args) > len(fn.args) && !fn.variadic {
+		return Expr{}, fmt.Errorf("%s takes %d arguments, not %d", name, len(fn.args), len(e.Args))
+	}
+	for i, arg := range e.Args {
+		want := fn.args[min(i, len(fn.args)-1)]
+		if arg.Kind != want {
+			return Expr{}, fmt.Errorf("%s takes a%s %s, not a%s %s", name, article(want), want, article(arg.Kind), arg.Kind)
+		}
+	}
+	return e, nil
+}
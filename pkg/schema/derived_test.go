@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+var derivedFields = []Field{
+	{Name: "birth_date", Type: "date"},
+	{Name: "admitted_at", Type: "instant"},
+	{Name: "discharged_at", Type: "instant"},
+	{Name: "given", Type: "string"},
+	{Name: "family", Type: "string"},
+	{Name: "weight", Type: "decimal"},
+	{Name: "height", Type: "decimal"},
+	{Name: "visits", Type: "integer"},
+	{Name: "active", Type: "boolean"},
+}
+
+func TestParseExpr(t *testing.T) {
+	fields := make(map[string]Field)
+	for _, f := range derivedFields {
+		fields[f.Name] = f
+	}
+	for _, tc := range []struct {
+		text string
+		want Expr
+	}{
+		{"years_since(birth_date)", Expr{Op: "years_since", Kind: "integer", Args: []Expr{{Op: "field", Value: "birth_date", Kind: "date"}}}},
+		{"visits * 2 + 1", Expr{Op: "+", Kind: "integer", Args: []Expr{
+			{Op: "*", Kind: "integer", Args: []Expr{{Op: "field", Value: "visits", Kind: "integer"}, {Op: "number", Value: "2", Kind: "integer"}}},
+			{Op: "number", Value: "1", Kind: "integer"},
+		}}},
+		{"-(visits / 2)", Expr{Op: "neg", Kind: "decimal", Args: []Expr{
+			{Op: "/", Kind: "decimal", Args: []Expr{{Op: "field", Value: "visits", Kind: "integer"}, {Op: "number", Value: "2", Kind: "integer"}}},
+		}}},
+		{"concat(given, ' ', family)", Expr{Op: "concat", Kind: "text", Args: []Expr{
+			{Op: "field", Value: "given", Kind: "text"}, {Op: "text", Value: " ", Kind: "text"}, {Op: "field", Value: "family", Kind: "text"},
+		}}},
+	} {
+		got, err := ParseExpr(tc.text, fields)
+		if err != nil {
+			t.Errorf("ParseExpr(%q) failed: %v", tc.text, err)
+		} else if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseExpr(%q) = %+v, want %+v", tc.text, got, tc.want)
+		}
+	}
+
+	for text, want := range map[string]string{
+		"years_since(given)":       "years_since takes a date, not a text",
+		"days_between(birth_date)": "days_between takes 2 arguments, not 1",
+		"given * 2":                "* needs numbers, not a text and an integer",
+		"age(birth_date)":          "age is not a function; use years_since, days_between, or concat",
+		"height +":                 "expression ends early",
+		"(height":                  "expected ) at the end",
+		"height height":            `unexpected height in "height height"`,
+		"missing + 1":              "missing is not a stored top-level field",
+		"active":                   "field active of type boolean cannot be used in expressions",
+		"concat('a)":               `unterminated text in "concat('a)"`,
+		"1.2.3":                    "1.2.3 is not a number",
+	} {
+		if _, err := ParseExpr(text, fields); err == nil || err.Error() != want {
+			t.Errorf("ParseExpr(%q) = %v, want %q", text, err, want)
+		}
+	}
+}
+
+func TestDerivedFields(t *testing.T) {
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "age", Type: "integer", Derived: "years_since(birth_date)"}, ""},
+		{Field{Name: "bmi", Type: "decimal", Derived: "weight / (height * height)"}, ""},
+		{Field{Name: "stay", Type: "decimal", Derived: "days_between(admitted_at, discharged_at)"}, ""},
+		{Field{Name: "name", Type: "string", Derived: "concat(given, ' ', family)"}, ""},
+		{Field{Name: "bmi", Type: "integer", Derived: "weight / height"}, "field bmi of type integer is derived from a decimal"},
+		{Field{Name: "age", Type: "integer", Required: true, Derived: "years_since(birth_date)"}, "field age is derived and cannot take required, required_if, enabled_if, default, reference ranges, or encryption"},
+		{Field{Name: "born", Type: "date", Derived: "birth_date"}, "field born of type date cannot be derived"},
+		{Field{Name: "twice", Type: "integer", Derived: "visits * 2 + 1 +"}, "field twice: expression ends early"},
+		{Field{Name: "panel", Type: "BackboneElement", Children: []Field{{Name: "age", Type: "integer", Derived: "1"}}}, "field panel.age is nested and cannot be derived"},
+	} {
+		fields := append(append([]Field{}, derivedFields...), tc.field)
+		derived, err := Schema{Name: "A", Fields: fields}.DerivedFields()
+		got := ""
+		if err != nil {
+			got = err.Error()
+		} else if len(derived) != 1 || derived[0].Field.Name != tc.field.Name {
+			t.Errorf("DerivedFields(%s) = %v", tc.field.Name, derived)
+		}
+		if got != tc.want {
+			t.Errorf("DerivedFields(%s) = %q, want %q", tc.field.Name, got, tc.want)
+		}
+	}
+
+	s := Schema{Name: "A", Fields: []Field{{Name: "a", Type: "integer"}, {Name: "b", Type: "integer", Derived: "a + 1"}, {Name: "c", Type: "integer", Derived: "b + 1"}}}
+	if _, err := s.DerivedFields(); err == nil || err.Error() != "field c: b is not a stored top-level field" {
+		t.Errorf("DerivedFields() = %v, want an error for deriving from a derived field", err)
+	}
+	if got := len(s.StoredFields()); got != 1 {
+		t.Errorf("StoredFields() has %d fields, want 1", got)
+	}
+}
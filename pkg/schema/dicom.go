@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StyleDICOM marks a schema whose top-level fields are DICOM attributes, each
+// naming its tag in dicom_tag.
+const StyleDICOM = "dicom"
+
+// Tag is a DICOM attribute tag.
+type Tag struct {
+	Group, Element uint16
+}
+
+// ParseTag parses a tag written as (gggg,eeee), gggg,eeee, or ggggeeee, in
+// hex.
+func ParseTag(s string) (Tag, error) {
+	t := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "("), ")")
+	group, element, ok := strings.Cut(t, ",")
+	if !ok && len(t) == 8 {
+		group, element = t[:4], t[4:]
+	}
+	g, gerr := strconv.ParseUint(strings.TrimSpace(group), 16, 16)
+	e, eerr := strconv.ParseUint(strings.TrimSpace(element), 16, 16)
+	if gerr != nil || eerr != nil || len(strings.TrimSpace(group)) != 4 || len(strings.TrimSpace(element)) != 4 {
+		return Tag{}, fmt.Errorf("invalid DICOM tag %q (want (gggg,eeee) in hex)", s)
+	}
+	return Tag{Group: uint16(g), Element: uint16(e)}, nil
+}
+
+// String returns the tag as (gggg,eeee).
+func (t Tag) String() string {
+	return fmt.Sprintf("(%04X,%04X)", t.Group, t.Element)
+}
+
+// Keyword returns the tag as the eight hex digits that key attributes in the
+// DICOM JSON model, such as 0020000D.
+func (t Tag) Keyword() string {
+	return fmt.Sprintf("%04X%04X", t.Group, t.Element)
+}
+
+// TaggedField is a field of a dicom schema with its parsed tag.
+type TaggedField struct {
+	Field
+	Tag Tag
+}
+
+// DICOMTags returns the top-level fields of a dicom schema with their tags,
+// in field order. Untagged fields whose names start with _, such as audit
+// fields, hold no attribute and are left out. It reports the first other
+// field without a valid tag, and tags that two fields share.
+func (s Schema) DICOMTags() ([]TaggedField, error) {
+	tagged := make([]TaggedField, 0, len(s.Fields))
+	seen := make(map[Tag]string, len(s.Fields))
+	for _, f := range s.Fields {
+		if f.DICOMTag == "" && strings.HasPrefix(f.Name, "_") {
+			continue
+		}
+		if f.DICOMTag == "" {
+			return nil, fmt.Errorf("field %s has no dicom_tag", f.Name)
+		}
+		tag, err := ParseTag(f.DICOMTag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		if other, ok := seen[tag]; ok {
+			return nil, fmt.Errorf("fields %s and %s share DICOM tag %s", other, f.Name, tag)
+		}
+		seen[tag] = f.Name
+		tagged = append(tagged, TaggedField{Field: f, Tag: tag})
+	}
+	return tagged, nil
+}
@@ -0,0 +1,49 @@
+package schema
+
+import "testing"
+
+func TestParseTag(t *testing.T) {
+	for _, s := range []string{"(0020,000D)", "0020,000d", "0020000D", " (0020, 000D) "} {
+		tag, err := ParseTag(s)
+		if err != nil {
+			t.Errorf("ParseTag(%q) failed: %v", s, err)
+			continue
+		}
+		if tag != (Tag{Group: 0x0020, Element: 0x000D}) || tag.String() != "(0020,000D)" || tag.Keyword() != "0020000D" {
+			t.Errorf("ParseTag(%q) = %v", s, tag)
+		}
+	}
+	for _, s := range []string{"", "(20,D)", "(0020,000G)", "0020000D0"} {
+		if _, err := ParseTag(s); err == nil {
+			t.Errorf("ParseTag(%q) succeeded", s)
+		}
+	}
+}
+
+func TestDICOMTags(t *testing.T) {
+	s := Schema{Name: "Study", Style: StyleDICOM, Fields: []Field{
+		{Name: "study_uid", Type: "id", DICOMTag: "(0020,000D)"},
+		{Name: "accession", Type: "string", DICOMTag: "(0008,0050)"},
+	}}
+	tagged, err := s.DICOMTags()
+	if err != nil {
+		t.Fatalf("DICOMTags() failed: %v", err)
+	}
+	if len(tagged) != 2 || tagged[1].Name != "accession" || tagged[1].Tag != (Tag{Group: 0x0008, Element: 0x0050}) {
+		t.Errorf("DICOMTags() = %v", tagged)
+	}
+
+	s.Fields = append(s.Fields, Field{Name: "_ingested_at", Type: "instant"})
+	if tagged, err := s.DICOMTags(); err != nil || len(tagged) != 2 {
+		t.Errorf("DICOMTags() with an audit field = %v, %v; want it left out", tagged, err)
+	}
+
+	s.Fields = append(s.Fields, Field{Name: "uid_again", Type: "id", DICOMTag: "0020000D"})
+	if _, err := s.DICOMTags(); err == nil {
+		t.Error("DICOMTags() accepted a shared tag")
+	}
+	s.Fields = []Field{{Name: "untagged", Type: "string"}}
+	if _, err := s.DICOMTags(); err == nil {
+		t.Error("DICOMTags() accepted a field without a tag")
+	}
+}
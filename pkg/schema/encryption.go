@@ -0,0 +1,69 @@
+package schema
+
+import "fmt"
+
+// Encryption modes of fields whose values applications encrypt before
+// storing them.
+const (
+	// EncryptionDeterministic encrypts equal values to equal ciphertexts, so
+	// the field can still be matched and joined on, as MRNs are.
+	EncryptionDeterministic = "deterministic"
+	// EncryptionRandomized encrypts each value under a fresh nonce, for fields
+	// that are never searched on.
+	EncryptionRandomized = "randomized"
+)
+
+// textTypes are the types of fields whose values are text, which ciphertexts
+// and pseudonyms, encoded as text, can replace in the same column and model
+// property.
+var textTypes = map[string]bool{"string": true, "code": true, "id": true, "uri": true, "url": true}
+
+// EncryptedFields returns the top-level fields of s that declare an
+// encryption mode, in field order. It reports the first field with an
+// unknown mode, a nested encrypted field, and encrypted fields of types other
+// than string, code, id, uri, and url.
+func (s Schema) EncryptedFields() ([]Field, error) {
+	var encrypted []Field
+	for _, f := range s.Fields {
+		if f.Encryption == "" {
+			if err := checkNestedEncryption(f.Children, f.Name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch f.Encryption {
+		case EncryptionDeterministic, EncryptionRandomized:
+		default:
+			return nil, fmt.Errorf("field %s has unknown encryption %q (want %s or %s)", f.Name, f.Encryption, EncryptionDeterministic, EncryptionRandomized)
+		}
+		if !textTypes[f.Type] {
+			return nil, fmt.Errorf("field %s of type %s cannot be encrypted (want string, code, id, uri, or url)", f.Name, f.Type)
+		}
+		encrypted = append(encrypted, f)
+	}
+	return encrypted, nil
+}
+
+// HasEncrypted reports whether any top-level field of s declares an
+// encryption mode.
+func (s Schema) HasEncrypted() bool {
+	for _, f := range s.Fields {
+		if f.Encryption != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkNestedEncryption(fields []Field, parent string) error {
+	for _, f := range fields {
+		path := parent + "." + f.Name
+		if f.Encryption != "" {
+			return fmt.Errorf("field %s cannot be encrypted: only top-level fields can", path)
+		}
+		if err := checkNestedEncryption(f.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
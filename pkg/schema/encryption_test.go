@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncryptedFields(t *testing.T) {
+	s := Schema{Name: "Patient", Fields: []Field{
+		{Name: "mrn", Type: "string", Encryption: EncryptionDeterministic},
+		{Name: "active", Type: "boolean"},
+		{Name: "ssn", Type: "id", Encryption: EncryptionRandomized},
+	}}
+	encrypted, err := s.EncryptedFields()
+	if err != nil {
+		t.Fatalf("EncryptedFields() failed: %v", err)
+	}
+	if len(encrypted) != 2 || encrypted[0].Name != "mrn" || encrypted[1].Name != "ssn" {
+		t.Errorf("EncryptedFields() = %v", encrypted)
+	}
+	if !s.HasEncrypted() {
+		t.Error("HasEncrypted() = false")
+	}
+
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "mrn", Type: "string", Encryption: "aes"}, `unknown encryption "aes"`},
+		{Field{Name: "birth_date", Type: "date", Encryption: EncryptionRandomized}, "of type date cannot be encrypted"},
+		{Field{Name: "contact", Type: "BackboneElement", Children: []Field{{Name: "phone", Type: "string", Encryption: EncryptionRandomized}}}, "contact.phone cannot be encrypted"},
+	} {
+		_, err := Schema{Name: "Patient", Fields: []Field{tc.field}}.EncryptedFields()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("EncryptedFields() with %s = %v, want %q", tc.field.Name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package schema
+
+import "strings"
+
+// AllExamples returns the example values of f: Example, then Examples.
+func (f Field) AllExamples() []string {
+	var examples []string
+	if f.Example != "" {
+		examples = append(examples, f.Example)
+	}
+	return append(examples, f.Examples...)
+}
+
+// Doc returns the description of f followed by its examples, for the
+// comments of generated fields.
+func (f Field) Doc() string {
+	examples := f.AllExamples()
+	if len(examples) == 0 {
+		return f.Description
+	}
+	doc := "Example: " + strings.Join(examples, ", ")
+	if len(examples) > 1 {
+		doc = "Examples: " + strings.Join(examples, ", ")
+	}
+	if f.Description == "" {
+		return doc
+	}
+	return strings.TrimSuffix(f.Description, ".") + ". " + doc
+}
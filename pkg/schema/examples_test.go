@@ -0,0 +1,20 @@
+package schema
+
+import "testing"
+
+func TestFieldDoc(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{Field{Description: "Medical record number"}, "Medical record number"},
+		{Field{Description: "Medical record number.", Example: "MRN-0042"}, "Medical record number. Example: MRN-0042"},
+		{Field{Example: "final", Examples: []string{"amended"}}, "Examples: final, amended"},
+		{Field{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.field.Doc(); got != tt.want {
+			t.Errorf("Doc() of %+v = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
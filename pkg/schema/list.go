@@ -0,0 +1,33 @@
+package schema
+
+import "strings"
+
+// ElementType returns the element type of a list type, written as []T or
+// array<T>, and whether fieldType is a list. Any other type is returned as
+// it is.
+func ElementType(fieldType string) (string, bool) {
+	if elem, ok := strings.CutPrefix(fieldType, "[]"); ok {
+		return elem, true
+	}
+	if inner, ok := strings.CutPrefix(fieldType, "array<"); ok && strings.HasSuffix(inner, ">") {
+		return strings.TrimSuffix(inner, ">"), true
+	}
+	return fieldType, false
+}
+
+// ListTypes returns fields with their array<T> list types, and those of
+// their nested fields, written as []T, the spelling generators read.
+func ListTypes(fields []Field) []Field {
+	if fields == nil {
+		return nil
+	}
+	out := make([]Field, len(fields))
+	for i, f := range fields {
+		if elem, ok := ElementType(f.Type); ok {
+			f.Type = "[]" + elem
+		}
+		f.Children = ListTypes(f.Children)
+		out[i] = f
+	}
+	return out
+}
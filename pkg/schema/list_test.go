@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestElementType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		list bool
+	}{
+		{"[]HumanName", "HumanName", true},
+		{"array<HumanName>", "HumanName", true},
+		{"array<string>", "string", true},
+		{"HumanName", "HumanName", false},
+		{"array<string", "array<string", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, list := ElementType(tt.in)
+		if got != tt.want || list != tt.list {
+			t.Errorf("ElementType(%q) = %q, %v, want %q, %v", tt.in, got, list, tt.want, tt.list)
+		}
+	}
+}
+
+func TestListTypes(t *testing.T) {
+	fields := []Field{
+		{Name: "name", Type: "array<HumanName>"},
+		{Name: "given", Type: "[]string"},
+		{Name: "contact", Type: "BackboneElement", Children: []Field{{Name: "telecom", Type: "array<ContactPoint>"}}},
+	}
+	got := ListTypes(fields)
+	want := []Field{
+		{Name: "name", Type: "[]HumanName"},
+		{Name: "given", Type: "[]string"},
+		{Name: "contact", Type: "BackboneElement", Children: []Field{{Name: "telecom", Type: "[]ContactPoint"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTypes() = %+v, want %+v", got, want)
+	}
+	if fields[0].Type != "array<HumanName>" {
+		t.Errorf("ListTypes() changed its argument: %+v", fields[0])
+	}
+}
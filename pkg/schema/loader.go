@@ -2,34 +2,103 @@
 package schema
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/konzy/ehrglot/pkg/paths"
 	"gopkg.in/yaml.v3"
 )
 
 // Field represents a schema field definition.
 type Field struct {
-	Name        string  `yaml:"name"`
-	Type        string  `yaml:"type"`
-	Required    bool    `yaml:"required"`
-	Description string  `yaml:"description"`
-	PIILevel    string  `yaml:"pii_level,omitempty"`
-	Children    []Field `yaml:"children,omitempty"`
+	Name          string   `yaml:"name"`
+	UID           string   `yaml:"uid,omitempty"` // Stable identity across renames, for schema diffs
+	Type          string   `yaml:"type"`
+	Required      bool     `yaml:"required,omitempty"`
+	RequiredIf    string   `yaml:"required_if,omitempty"` // Condition on another top-level field, such as deceased == true
+	EnabledIf     string   `yaml:"enabled_if,omitempty"`  // Condition on another top-level field without which the field is left out
+	Derived       string   `yaml:"derived,omitempty"`     // Expression computing the field from stored ones, such as years_since(birth_date)
+	Description   string   `yaml:"description,omitempty"`
+	Default       string   `yaml:"default,omitempty"` // Value records migrated from versions without the field get
+	Example       string   `yaml:"example,omitempty"`
+	Examples      []string `yaml:"examples,omitempty"` // More examples, after Example
+	PIILevel      string   `yaml:"pii_level,omitempty"`
+	PIICategory   string   `yaml:"pii_category,omitempty"`   // Kind of personal information, such as quasi_identifier
+	Encryption    string   `yaml:"encryption,omitempty"`     // EncryptionDeterministic or EncryptionRandomized
+	Match         string   `yaml:"match,omitempty"`          // Role in patient matching keys, such as MatchFamilyName
+	Purposes      []string `yaml:"purposes,omitempty"`       // Narrows the schema's purposes of use for this field
+	CodeName      string   `yaml:"code_name,omitempty"`      // Overrides the generated identifier
+	JSONName      string   `yaml:"json_name,omitempty"`      // Overrides the name of the field in JSON
+	DBColumn      string   `yaml:"db_column,omitempty"`      // Overrides the name of the field's SQL column
+	DICOMTag      string   `yaml:"dicom_tag,omitempty"`      // (group,element) of the DICOM attribute, for dicom schemas
+	LinkID        string   `yaml:"link_id,omitempty"`        // linkId of the Questionnaire item the field answers
+	Precision     int      `yaml:"precision,omitempty"`      // Total digits for decimal fields
+	Scale         int      `yaml:"scale,omitempty"`          // Fractional digits for decimal fields
+	Unit          string   `yaml:"unit,omitempty"`           // UCUM unit of numeric fields, such as mmol/L
+	ReferenceLow  string   `yaml:"reference_low,omitempty"`  // Lowest expected value of numeric fields
+	ReferenceHigh string   `yaml:"reference_high,omitempty"` // Highest expected value of numeric fields
+	Enum          []string `yaml:"enum,omitempty"`
+	Children      []Field  `yaml:"children,omitempty"`
+}
+
+// Index is an index over top-level fields of a schema, or a unique key when
+// listed under unique.
+type Index struct {
+	Name   string   `yaml:"name,omitempty"` // Derived from the table and fields when empty
+	Fields []string `yaml:"fields"`
 }
 
 // Schema represents a YAML schema definition.
 type Schema struct {
-	Name        string  `yaml:"name"`
-	Resource    string  `yaml:"resource"` // FHIR uses 'resource' instead of 'name'
-	Description string  `yaml:"description,omitempty"`
-	Fields      []Field `yaml:"fields"`
-	SourceFile  string  `yaml:"-"`
-	Namespace   string  `yaml:"-"`
+	Name        string    `yaml:"name,omitempty"`
+	Resource    string    `yaml:"resource,omitempty"` // FHIR uses 'resource' instead of 'name'
+	Description string    `yaml:"description,omitempty"`
+	Owner       string    `yaml:"owner,omitempty"`        // Team accountable for the schema and its tables
+	Steward     string    `yaml:"steward,omitempty"`      // Person who answers for the meaning and quality of its data
+	Tags        []string  `yaml:"tags,omitempty"`         // Governance labels, such as domains, for catalogs
+	Inherits    string    `yaml:"inherits,omitempty"`     // FHIR base resource whose elements the schema includes
+	Style       string    `yaml:"style,omitempty"`        // StyleDICOM for schemas of DICOM attributes
+	History     string    `yaml:"history,omitempty"`      // HistorySCD2 or HistorySoftDelete, for SQL tables
+	Flatten     string    `yaml:"flatten,omitempty"`      // FlattenJSON, FlattenDelimited, or FlattenTables, for SQL tables
+	PartitionBy string    `yaml:"partition_by,omitempty"` // Field SQL tables are partitioned by
+	ClusterBy   []string  `yaml:"cluster_by,omitempty"`   // Fields SQL tables are clustered by
+	Purposes    []string  `yaml:"purposes,omitempty"`     // Purposes of use records may be disclosed for; all when empty
+	Retention   Retention `yaml:"retention,omitempty"`    // How long records are kept, for SQL purge scripts
+	Indexes     []Index   `yaml:"indexes,omitempty"`
+	Unique      []Index   `yaml:"unique,omitempty"` // Keys no two records may share
+	Fields      []Field   `yaml:"fields"`
+	SourceFile  string    `yaml:"-"`
+	Namespace   string    `yaml:"-"`
 }
 
+// History modes select how the SQL generator tracks changes to the rows of a
+// schema's table.
+const (
+	// HistorySCD2 keeps every version of a record as a slowly changing
+	// dimension of type 2, with validity columns and a surrogate key.
+	HistorySCD2 = "scd2"
+	// HistorySoftDelete flags deleted records instead of removing them.
+	HistorySoftDelete = "soft_delete"
+)
+
+// Flatten strategies select how the SQL generator stores the nested fields
+// and lists of a schema.
+const (
+	// FlattenJSON stores each nested object or list in a JSON column.
+	FlattenJSON = "json"
+	// FlattenDelimited widens the table with a column per field of nested
+	// objects and stores lists of primitives as delimited text.
+	FlattenDelimited = "delimited"
+	// FlattenTables moves nested objects and lists into child tables with
+	// foreign keys to their parent.
+	FlattenTables = "tables"
+)
+
 // GetName returns the schema name (handles both 'name' and 'resource' fields).
 func (s Schema) GetName() string {
 	if s.Name != "" {
@@ -38,11 +107,103 @@ func (s Schema) GetName() string {
 	return s.Resource
 }
 
+// CheckKeys reports the first index or unique key of s that names no fields,
+// or a field s does not declare. Inherited fields must be flattened into s
+// first.
+func (s Schema) CheckKeys() error {
+	declared := make(map[string]Field, len(s.Fields))
+	for _, f := range s.Fields {
+		declared[f.Name] = f
+	}
+	check := func(section string, keys []Index) error {
+		for i, key := range keys {
+			if len(key.Fields) == 0 {
+				return fmt.Errorf("%s[%d] names no fields", section, i)
+			}
+			for _, name := range key.Fields {
+				f, ok := declared[name]
+				if !ok {
+					return fmt.Errorf("%s[%d] names %s, which is not a field", section, i, name)
+				}
+				if f.Derived != "" {
+					return fmt.Errorf("%s[%d] names %s, which is derived and not stored", section, i, name)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check("unique", s.Unique); err != nil {
+		return err
+	}
+	return check("indexes", s.Indexes)
+}
+
+// KeyDocs describes the unique keys and indexes of s, one line each, for
+// generated documentation.
+func (s Schema) KeyDocs() []string {
+	var docs []string
+	for _, key := range s.Unique {
+		docs = append(docs, "Unique: "+strings.Join(key.Fields, ", "))
+	}
+	for _, key := range s.Indexes {
+		docs = append(docs, "Indexed: "+strings.Join(key.Fields, ", "))
+	}
+	return docs
+}
+
+// Docs describes the ownership, unique keys, and indexes of s, one line
+// each, for generated documentation.
+func (s Schema) Docs() []string {
+	var docs []string
+	if s.Owner != "" {
+		docs = append(docs, "Owner: "+s.Owner)
+	}
+	if s.Steward != "" {
+		docs = append(docs, "Steward: "+s.Steward)
+	}
+	if len(s.Tags) > 0 {
+		docs = append(docs, "Tags: "+strings.Join(s.Tags, ", "))
+	}
+	return append(docs, s.KeyDocs()...)
+}
+
 // Mapping represents a field mapping from source to target.
 type FieldMapping struct {
-	Source    string `yaml:"source"`
-	Target    string `yaml:"target"`
-	Transform string `yaml:"transform,omitempty"`
+	Source        string         `yaml:"source"`
+	Target        string         `yaml:"target"`
+	Transform     string         `yaml:"transform,omitempty"`
+	Description   string         `yaml:"description,omitempty"`
+	Default       any            `yaml:"default,omitempty"`
+	SkipIfNull    bool           `yaml:"skip_if_null,omitempty"`
+	TargetContext map[string]any `yaml:"target_context,omitempty"`
+	ValueMapping  ValueMapping   `yaml:"value_mapping,omitempty"`
+}
+
+// ValueMapping translates source codes to target codes. A null source code,
+// written as null or ~, is stored under the empty key.
+type ValueMapping map[string]string
+
+// UnmarshalYAML decodes a value mapping, keeping null keys.
+func (m *ValueMapping) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: value mapping must be a mapping", node.Line)
+	}
+	*m = make(ValueMapping, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: value mapping entries must be scalars", key.Line)
+		}
+		k, v := key.Value, value.Value
+		if key.Tag == "!!null" {
+			k = ""
+		}
+		if value.Tag == "!!null" {
+			v = ""
+		}
+		(*m)[k] = v
+	}
+	return nil
 }
 
 // SchemaMapping represents a complete source-to-target mapping.
@@ -50,13 +211,20 @@ type SchemaMapping struct {
 	SourceSystem   string         `yaml:"source_system"`
 	SourceTable    string         `yaml:"source_table"`
 	TargetResource string         `yaml:"target_resource"`
+	Description    string         `yaml:"description,omitempty"`
 	FieldMappings  []FieldMapping `yaml:"field_mappings"`
-	SourceFile     string         `yaml:"-"`
+	// ValueMappings are code translations shared by field mappings, keyed by
+	// the transform that uses them.
+	ValueMappings map[string]ValueMapping `yaml:"value_mappings,omitempty"`
+	SourceFile    string                  `yaml:"-"`
 }
 
 // Loader loads schemas from YAML files.
 type Loader struct {
 	baseDir string
+	// overlays maps logical namespaces to the vendor directories that back
+	// them.
+	overlays map[string]string
 }
 
 // NewLoader creates a new schema loader.
@@ -64,56 +232,85 @@ func NewLoader(baseDir string) *Loader {
 	return &Loader{baseDir: baseDir}
 }
 
-// LoadAll loads all schemas from the base directory.
-func (l *Loader) LoadAll() ([]Schema, error) {
+// LoadAll loads all schemas from the base directory, with array<T> list types
+// written as []T. It stops with ctx.Err() once ctx is canceled.
+func (l *Loader) LoadAll(ctx context.Context) ([]Schema, error) {
 	var schemas []Schema
+	err := l.Walk(ctx, func(s Schema) error {
+		schemas = append(schemas, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
 
-	// Load FHIR R4 schemas
+// Walk calls fn for each schema in the base directory, in the order LoadAll
+// returns them, reading one file at a time so that callers that do not need
+// every schema at once need not hold them, such as list and import.
+// Generation resolves references across the whole set and uses LoadAll. Walk
+// stops at the first error fn returns, and with ctx.Err() once ctx is
+// canceled.
+func (l *Loader) Walk(ctx context.Context, fn func(Schema) error) error {
+	// Walk FHIR R4 schemas first
 	fhirDir := filepath.Join(l.baseDir, "fhir_r4")
 	if _, err := os.Stat(fhirDir); err == nil {
-		fhirSchemas, err := l.loadSchemaDir(fhirDir, "fhir_r4")
-		if err != nil {
-			return nil, fmt.Errorf("failed to load fhir_r4: %w", err)
+		if err := l.walkSchemaDir(ctx, fhirDir, "fhir_r4", fn); err != nil {
+			return fmt.Errorf("failed to load fhir_r4: %w", err)
 		}
-		schemas = append(schemas, fhirSchemas...)
 	}
 
-	// Load other schema directories
+	// Walk other schema directories
 	entries, err := os.ReadDir(l.baseDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read schema dir: %w", err)
+		return fmt.Errorf("failed to read schema dir: %w", err)
 	}
 
+	var names []string
 	for _, entry := range entries {
 		if !entry.IsDir() {
 			continue
 		}
 		name := entry.Name()
-		if name == "fhir_r4" || name == "schema_overrides" {
+		if name == "fhir_r4" || name == "schema_overrides" || l.overlaid(name) != "" {
 			continue
 		}
-
-		dir := filepath.Join(l.baseDir, name)
-		dirSchemas, err := l.loadSchemaDir(dir, name)
+		names = append(names, name)
+	}
+	// Logical namespaces need no directory of their own
+	for namespace := range l.overlays {
+		if !contains(names, namespace) {
+			names = append(names, namespace)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var err error
+		if vendor, ok := l.overlays[name]; ok {
+			err = l.walkOverlay(ctx, name, vendor, fn)
+		} else {
+			err = l.walkSchemaDir(ctx, filepath.Join(l.baseDir, name), name, fn)
+		}
 		if err != nil {
-			// Skip directories that don't have schemas
-			continue
+			return fmt.Errorf("failed to load %s: %w", name, err)
 		}
-		schemas = append(schemas, dirSchemas...)
 	}
 
-	return schemas, nil
+	return nil
 }
 
-func (l *Loader) loadSchemaDir(dir, namespace string) ([]Schema, error) {
-	var schemas []Schema
-
-	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+func (l *Loader) walkSchemaDir(ctx context.Context, dir, namespace string, fn func(Schema) error) error {
+	files, err := paths.Files(dir, ".yaml")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// Skip mapping files
 		if strings.HasSuffix(file, "_mapping.yaml") {
 			continue
@@ -132,20 +329,56 @@ func (l *Loader) loadSchemaDir(dir, namespace string) ([]Schema, error) {
 		if schema.GetName() == "" {
 			continue
 		}
+		if err := Validate(file, data, false); err != nil {
+			return err
+		}
 
 		schema.SourceFile = file
 		schema.Namespace = namespace
-		schemas = append(schemas, schema)
+		schema.Fields = ListTypes(schema.Fields)
+		if err := fn(schema); err != nil {
+			return err
+		}
 	}
 
-	return schemas, nil
+	return nil
 }
 
-// LoadMappings loads all schema mappings.
-func (l *Loader) LoadMappings() ([]SchemaMapping, error) {
+// LoadMappings loads all schema mappings and checks their paths against the
+// schemas, as CheckMapping does, reporting the problems of every mapping. It
+// stops with ctx.Err() once ctx is canceled.
+func (l *Loader) LoadMappings(ctx context.Context) ([]SchemaMapping, error) {
 	var mappings []SchemaMapping
+	err := l.WalkMappings(ctx, func(m SchemaMapping) error {
+		mappings = append(mappings, m)
+		return nil
+	})
+	if err != nil || len(mappings) == 0 {
+		return mappings, err
+	}
+	schemas, err := l.LoadAll(ctx)
+	if err != nil {
+		return mappings, err
+	}
+	var problems []error
+	for _, m := range mappings {
+		for _, err := range CheckMapping(m, schemas) {
+			problems = append(problems, fmt.Errorf("%s: %w", m.SourceFile, err))
+		}
+	}
+	return mappings, errors.Join(problems...)
+}
 
+// WalkMappings calls fn for each schema mapping, one file at a time, like
+// Walk. With overlays, the mappings of a logical namespace and of its vendor
+// directory come last, overlaid as their schemas are.
+func (l *Loader) WalkMappings(ctx context.Context, fn func(SchemaMapping) error) error {
+	own := make(map[string][]SchemaMapping)
+	vendor := make(map[string][]SchemaMapping)
 	err := filepath.WalkDir(l.baseDir, func(path string, d os.DirEntry, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if err != nil {
 			return nil
 		}
@@ -162,31 +395,91 @@ func (l *Loader) LoadMappings() ([]SchemaMapping, error) {
 		if err := yaml.Unmarshal(data, &mapping); err != nil {
 			return nil
 		}
+		if err := Validate(path, data, true); err != nil {
+			return err
+		}
 
 		mapping.SourceFile = path
-		mappings = append(mappings, mapping)
-		return nil
+		if namespace := l.overlaid(mapping.SourceSystem); namespace != "" {
+			mapping.SourceSystem = namespace
+		}
+		if len(l.overlays) > 0 {
+			dir := topDir(l.baseDir, path)
+			if _, ok := l.overlays[dir]; ok {
+				own[dir] = append(own[dir], mapping)
+				return nil
+			}
+			if namespace := l.overlaid(dir); namespace != "" {
+				vendor[namespace] = append(vendor[namespace], mapping)
+				return nil
+			}
+		}
+		return fn(mapping)
 	})
+	if err != nil {
+		return err
+	}
 
-	return mappings, err
+	namespaces := make([]string, 0, len(l.overlays))
+	for namespace := range l.overlays {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		for _, m := range overlay(own[namespace], vendor[namespace], mappingKey) {
+			if err := fn(m); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-// ListSchemas returns a list of available schema names.
-func (l *Loader) ListSchemas() ([]string, error) {
-	schemas, err := l.LoadAll()
+// LoadMappingFile loads a single mapping file. Unlike LoadMappings, it
+// reports files that cannot be read or parsed.
+func LoadMappingFile(path string) (SchemaMapping, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return SchemaMapping{}, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	var mapping SchemaMapping
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return SchemaMapping{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := Validate(path, data, true); err != nil {
+		return SchemaMapping{}, err
 	}
+	mapping.SourceFile = path
+	return mapping, nil
+}
 
+// ListSchemas returns a list of available schema names.
+func (l *Loader) ListSchemas(ctx context.Context) ([]string, error) {
 	var names []string
-	for _, s := range schemas {
+	err := l.Walk(ctx, func(s Schema) error {
 		names = append(names, fmt.Sprintf("%s/%s", s.Namespace, s.GetName()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return names, nil
 }
 
 // Generator is the interface for language-specific code generators.
 type Generator interface {
-	Generate(schemas []Schema, outputDir string) error
-	GenerateMappings(mappings []SchemaMapping, outputDir string) error
+	Generate(ctx context.Context, schemas []Schema, outputDir string) error
+	GenerateMappings(ctx context.Context, mappings []SchemaMapping, outputDir string) error
+}
+
+// Packager is implemented by generators that can wrap their output in a
+// publishable package, such as a pyproject.toml or package.json.
+type Packager interface {
+	Package(schemas []Schema, outputDir string) error
+}
+
+// Layout is implemented by generators that write each namespace into its own
+// directory of the output.
+type Layout interface {
+	NamespaceDir(namespace string) string
 }
@@ -0,0 +1,223 @@
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// builtins looks up the built-in datatypes and base resources, such as
+// HumanName and DomainResource, that no schema file defines. Package
+// datatypes registers it.
+var builtins func(name string) (Schema, bool)
+
+// RegisterBuiltins sets the lookup of the built-in types that CheckMapping
+// resolves paths through when no loaded schema defines them.
+func RegisterBuiltins(lookup func(name string) (Schema, bool)) {
+	builtins = lookup
+}
+
+// mappingStep is a segment of a mapping path, such as name[0].
+var mappingStep = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\[(\d+)\])?$`)
+
+// primitiveKinds groups primitive field types by the values they hold.
+// Values of one kind can be assigned to fields of another kind only through
+// a transform, but for integers to decimals and dates to datetimes.
+var primitiveKinds = map[string]string{
+	"string": "text", "code": "text", "id": "text", "uri": "text", "url": "text",
+	"canonical": "text", "oid": "text", "uuid": "text", "markdown": "text", "base64Binary": "text",
+	"integer": "integer", "positiveInt": "integer", "unsignedInt": "integer",
+	"decimal": "decimal", "boolean": "boolean", "date": "date", "time": "time",
+	"datetime": "datetime", "dateTime": "datetime", "instant": "datetime",
+}
+
+// mappingScope resolves mapping paths among a set of schemas.
+type mappingScope struct {
+	byKey  map[string]Schema   // "namespace.Name"
+	byName map[string][]Schema // Name, in load order
+}
+
+func newMappingScope(schemas []Schema) mappingScope {
+	sc := mappingScope{byKey: make(map[string]Schema), byName: make(map[string][]Schema)}
+	for _, s := range schemas {
+		sc.byKey[s.Namespace+"."+s.GetName()] = s
+		sc.byName[s.GetName()] = append(sc.byName[s.GetName()], s)
+	}
+	return sc
+}
+
+// lookup returns the schema a type names as seen from namespace: one of
+// the namespace, one named by a qualified namespace.Name type, the only
+// one of the name in another namespace, or a built-in type.
+func (sc mappingScope) lookup(namespace, typeName string) (Schema, bool) {
+	if s, ok := sc.byKey[namespace+"."+typeName]; ok {
+		return s, true
+	}
+	if s, ok := sc.byKey[typeName]; ok {
+		return s, true
+	}
+	if found := sc.byName[typeName]; len(found) == 1 {
+		return found[0], true
+	}
+	if builtins != nil {
+		return builtins(typeName)
+	}
+	return Schema{}, false
+}
+
+// fields returns the fields of s, with those it inherits, and whether all
+// of them are known.
+func (sc mappingScope) fields(s Schema) ([]Field, bool) {
+	if s.Inherits == "" {
+		return s.Fields, true
+	}
+	base, ok := sc.lookup(s.Namespace, s.Inherits)
+	if !ok {
+		return s.Fields, false
+	}
+	inherited, known := sc.fields(base)
+	return append(append([]Field(nil), inherited...), s.Fields...), known
+}
+
+// resolve returns the field a path such as name[0].family ends at within s.
+// It reports false, without an error, when the path passes through a type
+// whose fields are not known, and an error when a segment names no field or
+// indexes a field that is not a list.
+func (sc mappingScope) resolve(s Schema, path string) (Field, bool, error) {
+	namespace := s.Namespace
+	fields, known := sc.fields(s)
+	owner := s.GetName()
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		m := mappingStep.FindStringSubmatch(part)
+		if m == nil {
+			return Field{}, false, fmt.Errorf("path %q is not made of names and [index]es", path)
+		}
+		var f *Field
+		for j := range fields {
+			if fields[j].Name == m[1] {
+				f = &fields[j]
+				break
+			}
+		}
+		if f == nil {
+			if !known {
+				return Field{}, false, nil
+			}
+			return Field{}, false, fmt.Errorf("%s has no field %s", owner, m[1])
+		}
+		elem, list := ElementType(f.Type)
+		if m[2] != "" && !list {
+			return Field{}, false, fmt.Errorf("%s.%s is not a list and cannot be indexed", owner, m[1])
+		}
+		if i == len(parts)-1 {
+			return *f, true, nil
+		}
+		owner += "." + m[1]
+		if len(f.Children) > 0 {
+			fields, known = f.Children, true
+			continue
+		}
+		target, ok := sc.lookup(namespace, elem)
+		if !ok {
+			return Field{}, false, nil
+		}
+		namespace = target.Namespace
+		fields, known = sc.fields(target)
+	}
+	return Field{}, false, nil
+}
+
+// topField returns the top-level field a path such as name[0].family
+// starts at.
+func topField(path string) string {
+	top, _, _ := strings.Cut(path, ".")
+	top, _, _ = strings.Cut(top, "[")
+	return top
+}
+
+// assignable reports whether values of field type from can be assigned to
+// fields of type to without a transform. Types that are not primitive must
+// be the same.
+func assignable(from, to string) bool {
+	from, _ = ElementType(from)
+	to, _ = ElementType(to)
+	fromKind, fromPrimitive := primitiveKinds[from]
+	toKind, toPrimitive := primitiveKinds[to]
+	if !fromPrimitive || !toPrimitive {
+		return from == to
+	}
+	switch {
+	case fromKind == toKind:
+		return true
+	case fromKind == "integer" && toKind == "decimal":
+		return true
+	case fromKind == "date" && toKind == "datetime":
+		return true
+	}
+	return false
+}
+
+// CheckMapping checks m against schemas, together with the built-in types,
+// and returns every problem found: target and target_context paths that
+// name no field of the target resource, sources that name no field of the
+// source table, where a schema defines it, sources of a type that cannot be
+// assigned to their target without a transform or value mapping, and
+// required top-level target fields without a default that nothing maps.
+// Paths through types no schema defines are not checked past them.
+func CheckMapping(m SchemaMapping, schemas []Schema) []error {
+	sc := newMappingScope(schemas)
+	target, ok := sc.lookup(filepath.Base(filepath.Dir(m.SourceFile)), m.TargetResource)
+	if !ok {
+		// Converting the mapping reports it
+		return nil
+	}
+	source, hasSource := sc.byKey[m.SourceSystem+"."+m.SourceTable]
+
+	var problems []error
+	mapped := make(map[string]bool)
+	for i, fm := range m.FieldMappings {
+		where := fmt.Sprintf("field mapping %d (%s)", i+1, fm.Target)
+		to, toOK, err := sc.resolve(target, fm.Target)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: target %w", where, err))
+		}
+		mapped[topField(fm.Target)] = true
+		context := make([]string, 0, len(fm.TargetContext))
+		for path := range fm.TargetContext {
+			context = append(context, path)
+		}
+		sort.Strings(context)
+		for _, path := range context {
+			if _, _, err := sc.resolve(target, path); err != nil {
+				problems = append(problems, fmt.Errorf("%s: target_context %w", where, err))
+			}
+			mapped[topField(path)] = true
+		}
+		if !hasSource || fm.Source == "" {
+			continue
+		}
+		from, fromOK, err := sc.resolve(source, fm.Source)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: source %w", where, err))
+			continue
+		}
+		if fromOK && toOK && fm.Transform == "" && fm.ValueMapping == nil && !assignable(from.Type, to.Type) {
+			problems = append(problems, fmt.Errorf("%s: source %s of type %s cannot be assigned to %s of type %s without a transform", where, fm.Source, from.Type, fm.Target, to.Type))
+		}
+	}
+
+	fields, _ := sc.fields(target)
+	for _, f := range fields {
+		// Servers assign the ids of the resources they create
+		if f.Name == "id" && target.Resource != "" {
+			continue
+		}
+		if f.Required && f.Default == "" && !mapped[f.Name] {
+			problems = append(problems, fmt.Errorf("required target field %s.%s is not mapped", target.GetName(), f.Name))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckMapping(t *testing.T) {
+	schemas := []Schema{
+		{Resource: "Patient", Namespace: "fhir", Fields: []Field{
+			{Name: "id", Type: "id", Required: true},
+			{Name: "resourceType", Type: "string", Required: true, Default: "Patient"},
+			{Name: "active", Type: "boolean", Required: true},
+			{Name: "gender", Type: "code"},
+			{Name: "birthDate", Type: "datetime"},
+			{Name: "weight", Type: "decimal"},
+			{Name: "name", Type: "array<Name>"},
+			{Name: "contact", Type: "[]BackboneElement", Children: []Field{{Name: "relationship", Type: "string"}}},
+			{Name: "photo", Type: "[]Attachment"},
+		}},
+		{Name: "Name", Namespace: "fhir", Fields: []Field{{Name: "family", Type: "string"}}},
+		{Name: "PERSON", Namespace: "ehr", Fields: []Field{
+			{Name: "SEX", Type: "boolean"},
+			{Name: "DOB", Type: "date"},
+			{Name: "WEIGHT", Type: "integer"},
+			{Name: "LAST", Type: "string"},
+		}},
+	}
+	m := SchemaMapping{
+		SourceSystem:   "ehr",
+		SourceTable:    "PERSON",
+		TargetResource: "Patient",
+		SourceFile:     "schemas/ehr/person_mapping.yaml",
+		FieldMappings: []FieldMapping{
+			{Source: "LAST", Target: "name[0].family"},
+			{Source: "LAST", Target: "name[0].given[0]"},
+			{Source: "DOB", Target: "birthDate"},
+			{Source: "WEIGHT", Target: "weight"},
+			{Source: "SEX", Target: "gender"},
+			{Source: "SEX", Target: "gender", Transform: "sex_to_gender"},
+			{Source: "SEX", Target: "gender[0]"},
+			{Source: "NAME", Target: "contact[0].relationship", TargetContext: map[string]any{"contact[0].kind": "x"}},
+			{Source: "LAST", Target: "photo[0].title"},
+			{Source: "LAST", Target: "maritalStatus"},
+		},
+	}
+	var got []string
+	for _, err := range CheckMapping(m, schemas) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		"field mapping 2 (name[0].given[0]): target Patient.name has no field given",
+		"field mapping 5 (gender): source SEX of type boolean cannot be assigned to gender of type code without a transform",
+		"field mapping 7 (gender[0]): target Patient.gender is not a list and cannot be indexed",
+		"field mapping 8 (contact[0].relationship): target_context Patient.contact has no field kind",
+		"field mapping 8 (contact[0].relationship): source PERSON has no field NAME",
+		"field mapping 10 (maritalStatus): target Patient has no field maritalStatus",
+		"required target field Patient.active is not mapped",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckMapping() =\n%q\nwant\n%q", got, want)
+	}
+
+	// Mappings to resources no schema defines are left to conversion
+	m.TargetResource = "Observation"
+	if problems := CheckMapping(m, schemas); len(problems) > 0 {
+		t.Errorf("CheckMapping() of an unknown target = %v, want no problems", problems)
+	}
+}
+
+func TestAssignable(t *testing.T) {
+	for _, tc := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"string", "code", true},
+		{"[]string", "array<string>", true},
+		{"integer", "decimal", true},
+		{"decimal", "integer", false},
+		{"date", "dateTime", true},
+		{"instant", "date", false},
+		{"boolean", "string", false},
+		{"CodeableConcept", "CodeableConcept", true},
+		{"string", "CodeableConcept", false},
+	} {
+		if got := assignable(tc.from, tc.to); got != tc.want {
+			t.Errorf("assignable(%q, %q) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestLoadMappingsChecksPaths(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"fhir/patient.yaml":       "resource: Patient\nfields:\n  - name: id\n    type: id\n  - name: gender\n    type: code\n",
+		"ehr/person.yaml":         testSchema("PERSON", "SEX"),
+		"ehr/person_mapping.yaml": "source_system: ehr\nsource_table: PERSON\ntarget_resource: Patient\nfield_mappings:\n  - source: SEX\n    target: gendr\n",
+	})
+	_, err := NewLoader(dir).LoadMappings(context.Background())
+	want := filepath.Join(dir, "ehr", "person_mapping.yaml") + ": field mapping 1 (gendr): target Patient has no field gendr"
+	if err == nil || err.Error() != want {
+		t.Errorf("LoadMappings() error = %v, want %s", err, want)
+	}
+}
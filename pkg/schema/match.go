@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Match roles of fields that patient matching keys are made from.
+const (
+	MatchFamilyName = "family_name"
+	MatchGivenName  = "given_name"
+	MatchBirthDate  = "birth_date"
+	MatchSex        = "sex"
+	// MatchIdentifier marks identifiers, such as MRNs, that each make a key
+	// of their own.
+	MatchIdentifier = "identifier"
+)
+
+// matchRoles are the match roles in the order their values make up the
+// demographic key.
+var matchRoles = []string{MatchFamilyName, MatchGivenName, MatchBirthDate, MatchSex, MatchIdentifier}
+
+// MatchFields are the top-level fields of a schema that its matching keys are
+// made from. Demographic holds the fields tagged with a name, birth date, or
+// sex role, in the order of their roles, whose normalized values together
+// make one blocking key; Identifiers holds those tagged identifier, in field
+// order, which make a key each.
+type MatchFields struct {
+	Demographic []Field
+	Identifiers []Field
+}
+
+// Empty reports whether the schema has no fields that make matching keys.
+func (m MatchFields) Empty() bool {
+	return len(m.Demographic) == 0 && len(m.Identifiers) == 0
+}
+
+// MatchFields returns the fields of s that its matching keys are made from.
+// It reports the first field with an unknown match role, a role other than
+// identifier tagged twice, nested, derived, and encrypted fields with a role,
+// and fields whose type does not suit their role: birth dates must be dates,
+// and names, sexes, and identifiers text.
+func (s Schema) MatchFields() (MatchFields, error) {
+	var m MatchFields
+	byRole := make(map[string]Field)
+	for _, f := range s.Fields {
+		if f.Match == "" {
+			if err := checkNestedMatch(f.Children, f.Name); err != nil {
+				return MatchFields{}, err
+			}
+			continue
+		}
+		if f.Derived != "" || f.Encryption != "" {
+			return MatchFields{}, fmt.Errorf("field %s is derived or encrypted and cannot have a match role", f.Name)
+		}
+		switch f.Match {
+		case MatchBirthDate:
+			if f.Type != "date" {
+				return MatchFields{}, fmt.Errorf("field %s of type %s cannot be matched as %s (want date)", f.Name, f.Type, f.Match)
+			}
+		case MatchFamilyName, MatchGivenName, MatchSex, MatchIdentifier:
+			if !textTypes[f.Type] {
+				return MatchFields{}, fmt.Errorf("field %s of type %s cannot be matched as %s (want string, code, id, uri, or url)", f.Name, f.Type, f.Match)
+			}
+		default:
+			return MatchFields{}, fmt.Errorf("field %s has unknown match role %q (want %s)", f.Name, f.Match, strings.Join(matchRoles, ", "))
+		}
+		if f.Match == MatchIdentifier {
+			m.Identifiers = append(m.Identifiers, f)
+			continue
+		}
+		if other, ok := byRole[f.Match]; ok {
+			return MatchFields{}, fmt.Errorf("fields %s and %s are both matched as %s", other.Name, f.Name, f.Match)
+		}
+		byRole[f.Match] = f
+	}
+	for _, role := range matchRoles {
+		if f, ok := byRole[role]; ok {
+			m.Demographic = append(m.Demographic, f)
+		}
+	}
+	return m, nil
+}
+
+func checkNestedMatch(fields []Field, parent string) error {
+	for _, f := range fields {
+		path := parent + "." + f.Name
+		if f.Match != "" {
+			return fmt.Errorf("field %s is nested and cannot have a match role", path)
+		}
+		if err := checkNestedMatch(f.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
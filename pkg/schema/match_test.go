@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchFields(t *testing.T) {
+	s := Schema{Name: "Person", Fields: []Field{
+		{Name: "mrn", Type: "string", Match: MatchIdentifier},
+		{Name: "sex", Type: "code", Match: MatchSex},
+		{Name: "birth_date", Type: "date", Match: MatchBirthDate},
+		{Name: "family", Type: "string", Match: MatchFamilyName},
+		{Name: "ssn", Type: "string", Match: MatchIdentifier},
+		{Name: "note", Type: "string"},
+	}}
+	m, err := s.MatchFields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var demographic, identifiers []string
+	for _, f := range m.Demographic {
+		demographic = append(demographic, f.Name)
+	}
+	for _, f := range m.Identifiers {
+		identifiers = append(identifiers, f.Name)
+	}
+	if got := strings.Join(demographic, ","); got != "family,birth_date,sex" {
+		t.Errorf("Demographic = %s, want the fields in role order", got)
+	}
+	if got := strings.Join(identifiers, ","); got != "mrn,ssn" {
+		t.Errorf("Identifiers = %s, want the fields in field order", got)
+	}
+}
+
+func TestMatchFieldsErrors(t *testing.T) {
+	tests := []struct {
+		fields []Field
+		want   string
+	}{
+		{[]Field{{Name: "name", Type: "string", Match: "name"}}, `unknown match role "name"`},
+		{[]Field{{Name: "born", Type: "datetime", Match: MatchBirthDate}}, "cannot be matched as birth_date (want date)"},
+		{[]Field{{Name: "sex", Type: "boolean", Match: MatchSex}}, "cannot be matched as sex"},
+		{[]Field{{Name: "family", Type: "string", Match: MatchFamilyName}, {Name: "maiden", Type: "string", Match: MatchFamilyName}}, "fields family and maiden are both matched as family_name"},
+		{[]Field{{Name: "mrn", Type: "string", Encryption: EncryptionDeterministic, Match: MatchIdentifier}}, "derived or encrypted"},
+		{[]Field{{Name: "contact", Type: "object", Children: []Field{{Name: "family", Type: "string", Match: MatchFamilyName}}}}, "field contact.family is nested"},
+	}
+	for _, tt := range tests {
+		_, err := Schema{Name: "Person", Fields: tt.fields}.MatchFields()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("MatchFields(%v) error = %v, want %q", tt.fields, err, tt.want)
+		}
+	}
+}
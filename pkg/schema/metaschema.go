@@ -0,0 +1,214 @@
+package schema
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MetaSchema is the JSON Schema of ehrglot schema and mapping files, for
+// editors that offer completion and validation while authoring them.
+//
+//go:embed ehrglot.schema.json
+var MetaSchema []byte
+
+// node is the subset of JSON Schema that MetaSchema uses.
+type node struct {
+	Ref                  string           `json:"$ref"`
+	Type                 typeList         `json:"type"`
+	Properties           map[string]*node `json:"properties"`
+	Required             []string         `json:"required"`
+	Items                *node            `json:"items"`
+	Enum                 []string         `json:"enum"`
+	AnyOf                []*node          `json:"anyOf"`
+	AdditionalProperties *bool            `json:"additionalProperties"`
+	Definitions          map[string]*node `json:"definitions"`
+}
+
+// typeList is a JSON Schema type, which is a single name or a list of names.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = typeList{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+var metaSchema = func() *node {
+	var root node
+	if err := json.Unmarshal(MetaSchema, &root); err != nil {
+		panic(fmt.Sprintf("invalid embedded meta-schema: %v", err))
+	}
+	return &root
+}()
+
+// Validate checks the YAML of a schema file, or of a mapping file when
+// mapping is true, against MetaSchema. Each problem is reported as a
+// *ValidationError, joined with errors.Join.
+func Validate(file string, data []byte, mapping bool) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("%s: empty document", file)
+	}
+
+	def := "schema"
+	if mapping {
+		def = "mapping"
+	}
+	v := validator{file: file}
+	v.validate(metaSchema.Definitions[def], doc.Content[0], "")
+	return errors.Join(v.errs...)
+}
+
+// ValidationError is a problem Validate found in a file.
+type ValidationError struct {
+	File         string
+	Line, Column int
+	// Path locates the offending value, such as fields[2].pii_level, or is
+	// "document" for the file as a whole.
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", e.File, e.Line, e.Column, e.Path, e.Message)
+}
+
+type validator struct {
+	file string
+	errs []error
+}
+
+func (v *validator) report(n *yaml.Node, path, format string, args ...any) {
+	if path == "" {
+		path = "document"
+	}
+	v.errs = append(v.errs, &ValidationError{
+		File:    v.file,
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) validate(s *node, n *yaml.Node, path string) {
+	for s.Ref != "" {
+		s = metaSchema.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+	}
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, n) {
+		v.report(n, path, "expected %s, got %s", strings.Join(s.Type, " or "), typeName(n))
+		return
+	}
+	if len(s.Enum) > 0 && n.Kind == yaml.ScalarNode && !contains(s.Enum, n.Value) {
+		v.report(n, path, "%q is not one of %s", n.Value, strings.Join(s.Enum, ", "))
+	}
+
+	if n.Kind == yaml.MappingNode {
+		present := make(map[string]bool)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			present[key] = true
+			switch prop, ok := s.Properties[key]; {
+			case ok:
+				v.validate(prop, value, join(path, key))
+			case s.AdditionalProperties != nil && !*s.AdditionalProperties:
+				v.report(n.Content[i], path, "unknown property %q", key)
+			}
+		}
+		for _, key := range s.Required {
+			if !present[key] {
+				v.report(n, path, "missing required property %q", key)
+			}
+		}
+	}
+	if n.Kind == yaml.SequenceNode && s.Items != nil {
+		for i, item := range n.Content {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		var alternatives []string
+		for _, alt := range s.AnyOf {
+			sub := validator{file: v.file}
+			sub.validate(alt, n, path)
+			if len(sub.errs) == 0 {
+				return
+			}
+			alternatives = append(alternatives, describe(alt))
+		}
+		v.report(n, path, "must have %s", strings.Join(alternatives, " or "))
+	}
+}
+
+// describe summarizes the alternative an anyOf branch requires.
+func describe(s *node) string {
+	if len(s.Required) > 0 {
+		return strings.Join(s.Required, " and ")
+	}
+	if s.Ref != "" {
+		return "the form of " + strings.TrimPrefix(s.Ref, "#/definitions/")
+	}
+	return "a valid form"
+}
+
+func matchesType(types []string, n *yaml.Node) bool {
+	got := typeName(n)
+	for _, t := range types {
+		if t == got || (t == "number" && got == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
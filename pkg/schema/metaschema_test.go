@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMetaSchemaCoversTypes keeps the meta-schema in step with the Go types
+// that files are decoded into.
+func TestMetaSchemaCoversTypes(t *testing.T) {
+	for def, typ := range map[string]reflect.Type{
+		"schema":       reflect.TypeOf(Schema{}),
+		"field":        reflect.TypeOf(Field{}),
+		"mapping":      reflect.TypeOf(SchemaMapping{}),
+		"fieldMapping": reflect.TypeOf(FieldMapping{}),
+	} {
+		props := metaSchema.Definitions[def].Properties
+		for i := 0; i < typ.NumField(); i++ {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("yaml"), ",")
+			if name == "-" || name == "" {
+				continue
+			}
+			if props[name] == nil {
+				t.Errorf("meta-schema definition %s lacks property %s of %s", def, name, typ.Name())
+			}
+		}
+	}
+}
+
+func TestRepositorySchemasValidate(t *testing.T) {
+	loader := NewLoader("../../schemas")
+	if _, err := loader.LoadAll(context.Background()); err != nil {
+		t.Errorf("LoadAll() failed: %v", err)
+	}
+	if _, err := loader.LoadMappings(context.Background()); err != nil {
+		t.Errorf("LoadMappings() failed: %v", err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	loader := NewLoader("../../schemas")
+	all, err := loader.LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() failed: %v", err)
+	}
+	var walked []string
+	err = loader.Walk(context.Background(), func(s Schema) error {
+		walked = append(walked, s.Namespace+"/"+s.GetName())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() failed: %v", err)
+	}
+	if len(walked) != len(all) {
+		t.Fatalf("Walk() visited %d schemas, want %d", len(walked), len(all))
+	}
+	for i, s := range all {
+		if walked[i] != s.Namespace+"/"+s.GetName() {
+			t.Fatalf("Walk() visited %s at %d, want %s/%s", walked[i], i, s.Namespace, s.GetName())
+		}
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = loader.Walk(context.Background(), func(Schema) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Errorf("Walk() = %v after %d schemas, want stop after 1", err, visited)
+	}
+}
+
+func TestLoaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	loader := NewLoader("../../schemas")
+	if _, err := loader.LoadAll(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadAll() error = %v, want context.Canceled", err)
+	}
+	if _, err := loader.LoadMappings(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("LoadMappings() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		mapping bool
+		want    []string
+	}{
+		{
+			name: "valid schema",
+			yaml: "name: Patient\nfields:\n  - name: id\n    type: id\n    pii_level: low\n",
+		},
+		{
+			name: "schema errors",
+			yaml: "fields:\n  - name: id\n    required: yes please\n    pii_level: secret\n  - type: string\n",
+			want: []string{
+				`f.yaml:3:15: fields[0].required: expected boolean, got string`,
+				`f.yaml:4:16: fields[0].pii_level: "secret" is not one of`,
+				`f.yaml:2:5: fields[0]: missing required property "type"`,
+				`f.yaml:5:5: fields[1]: missing required property "name"`,
+				`f.yaml:1:1: document: must have name or resource`,
+			},
+		},
+		{
+			name:    "mapping errors",
+			yaml:    "source_system: epic\nsource_table: PATIENT\ntarget_resource: Patient\nfield_mappings:\n  - source: PAT_ID\n    skip_if_null: 1\n",
+			mapping: true,
+			want: []string{
+				`f.yaml:6:19: field_mappings[0].skip_if_null: expected boolean, got integer`,
+				`f.yaml:5:5: field_mappings[0]: missing required property "target"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate("f.yaml", []byte(tt.yaml), tt.mapping)
+			var got []string
+			if err != nil {
+				got = strings.Split(err.Error(), "\n")
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() = %q, want %d errors", got, len(tt.want))
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("error %d = %q, want prefix %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
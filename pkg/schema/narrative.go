@@ -0,0 +1,35 @@
+package schema
+
+import "strings"
+
+// narrativeTypes are the field types narratives show: text, numbers,
+// booleans, dates, and times.
+var narrativeTypes = map[string]bool{
+	"string": true, "code": true, "id": true, "uri": true, "url": true,
+	"integer": true, "positiveInt": true, "unsignedInt": true, "decimal": true, "boolean": true,
+	"date": true, "datetime": true, "instant": true,
+}
+
+// NarrativeFields returns the top-level fields of s that narratives of its
+// records show, in field order: the stored fields of types with a readable
+// value, but not encrypted ones, whose values are ciphertext, nor those
+// whose names start with an underscore, which mark bookkeeping such as audit
+// fields.
+func (s Schema) NarrativeFields() []Field {
+	var shown []Field
+	for _, f := range s.StoredFields() {
+		if narrativeTypes[f.Type] && f.Encryption == "" && !strings.HasPrefix(f.Name, "_") {
+			shown = append(shown, f)
+		}
+	}
+	return shown
+}
+
+// NarrativeLabel returns the label narratives show for f: its description,
+// or its name when it has none.
+func (f Field) NarrativeLabel() string {
+	if f.Description != "" {
+		return f.Description
+	}
+	return f.Name
+}
@@ -0,0 +1,26 @@
+package schema
+
+import "testing"
+
+func TestNarrativeFields(t *testing.T) {
+	s := Schema{Name: "Patient", Fields: []Field{
+		{Name: "mrn", Type: "string", Encryption: EncryptionDeterministic},
+		{Name: "birth_date", Type: "date", Description: "Date of birth"},
+		{Name: "age", Type: "integer", Derived: "years_since(birth_date)"},
+		{Name: "photo", Type: "base64Binary"},
+		{Name: "name", Type: "HumanName"},
+		{Name: "aliases", Type: "[]string"},
+		{Name: "active", Type: "boolean"},
+		{Name: "_ingested_at", Type: "instant"},
+	}}
+	shown := s.NarrativeFields()
+	if len(shown) != 2 || shown[0].Name != "birth_date" || shown[1].Name != "active" {
+		t.Fatalf("NarrativeFields() = %v", shown)
+	}
+	if got := shown[0].NarrativeLabel(); got != "Date of birth" {
+		t.Errorf("NarrativeLabel() = %q, want the description", got)
+	}
+	if got := shown[1].NarrativeLabel(); got != "active" {
+		t.Errorf("NarrativeLabel() = %q, want the name", got)
+	}
+}
@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WithOverlays returns l backing each logical namespace in overlays, such
+// as ehr_source, with the vendor directory it maps to, such as
+// cerner_millennium. The schemas of the vendor directory load into the
+// logical namespace, replacing those of the namespace's own directory, if
+// it has one, that have the same name. The vendor directory is no longer a
+// namespace of its own: mappings whose source_system names it name the
+// logical namespace instead, and its mappings replace those of the logical
+// namespace with the same source table and target resource.
+func (l *Loader) WithOverlays(overlays map[string]string) *Loader {
+	return &Loader{baseDir: l.baseDir, overlays: overlays}
+}
+
+// overlaid returns the logical namespace the vendor directory dir backs, or
+// "" if it backs none.
+func (l *Loader) overlaid(dir string) string {
+	for namespace, vendor := range l.overlays {
+		if vendor == dir {
+			return namespace
+		}
+	}
+	return ""
+}
+
+// walkOverlay calls fn for the schemas of a logical namespace overlaid by
+// those of its vendor directory.
+func (l *Loader) walkOverlay(ctx context.Context, namespace, vendor string, fn func(Schema) error) error {
+	vendorDir := filepath.Join(l.baseDir, vendor)
+	if !isDir(vendorDir) {
+		return fmt.Errorf("overlay %s is not a directory of %s", vendor, l.baseDir)
+	}
+	collect := func(schemas *[]Schema) func(Schema) error {
+		return func(s Schema) error {
+			*schemas = append(*schemas, s)
+			return nil
+		}
+	}
+	var own, vendorSchemas []Schema
+	if dir := filepath.Join(l.baseDir, namespace); isDir(dir) {
+		if err := l.walkSchemaDir(ctx, dir, namespace, collect(&own)); err != nil {
+			return err
+		}
+	}
+	if err := l.walkSchemaDir(ctx, vendorDir, namespace, collect(&vendorSchemas)); err != nil {
+		return err
+	}
+	for _, s := range overlay(own, vendorSchemas, Schema.GetName) {
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlay returns base with the items of top that have the key of one of
+// its items in place of that item, followed by the other items of top.
+func overlay[T any](base, top []T, key func(T) string) []T {
+	index := make(map[string]int, len(base))
+	merged := append([]T(nil), base...)
+	for i, item := range merged {
+		index[key(item)] = i
+	}
+	for _, item := range top {
+		if i, ok := index[key(item)]; ok {
+			merged[i] = item
+		} else {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+func mappingKey(m SchemaMapping) string {
+	return m.SourceTable + " -> " + m.TargetResource
+}
+
+// topDir returns the directory of base that path is in, or "" for files of
+// base itself.
+func topDir(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return ""
+	}
+	dir, _, ok := strings.Cut(filepath.ToSlash(rel), "/")
+	if !ok {
+		return ""
+	}
+	return dir
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeTree writes files, keyed by slash-separated path, under a temporary
+// directory and returns it.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func testSchema(name, field string) string {
+	return "name: " + name + "\nfields:\n  - name: " + field + "\n    type: string\n"
+}
+
+func testMapping(system, table string) string {
+	return "source_system: " + system + "\nsource_table: " + table + "\ntarget_resource: Patient\nfield_mappings:\n  - source: ID\n    target: id\n"
+}
+
+func TestOverlays(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"ehr_source/patient.yaml":           testSchema("PATIENT", "PAT_ID"),
+		"ehr_source/shared.yaml":            testSchema("SHARED", "ID"),
+		"ehr_source/patient_mapping.yaml":   testMapping("ehr_source", "PATIENT"),
+		"ehr_source/shared_mapping.yaml":    testMapping("ehr_source", "SHARED"),
+		"cerner/patient.yaml":               testSchema("PATIENT", "PERSON_ID"),
+		"cerner/encounter.yaml":             testSchema("ENCOUNTER", "ENCNTR_ID"),
+		"cerner/patient_mapping.yaml":       testMapping("cerner", "PATIENT"),
+		"epic/patient.yaml":                 testSchema("PATIENT", "PAT_ID"),
+		"epic/patient_mapping.yaml":         testMapping("epic", "PATIENT"),
+		"custom/cerner_person_mapping.yaml": testMapping("cerner", "PERSON"),
+	})
+	loader := NewLoader(dir).WithOverlays(map[string]string{"ehr_source": "cerner"})
+
+	schemas, err := loader.LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() failed: %v", err)
+	}
+	var got []string
+	for _, s := range schemas {
+		got = append(got, s.Namespace+"/"+s.GetName()+"."+s.Fields[0].Name+" "+filepath.Base(filepath.Dir(s.SourceFile)))
+	}
+	// Cerner's PATIENT replaces the namespace's own; its ENCOUNTER follows.
+	want := []string{"ehr_source/PATIENT.PERSON_ID cerner", "ehr_source/SHARED.ID ehr_source", "ehr_source/ENCOUNTER.ENCNTR_ID cerner", "epic/PATIENT.PAT_ID epic"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAll() = %q, want %q", got, want)
+	}
+
+	mappings, err := loader.LoadMappings(context.Background())
+	if err != nil {
+		t.Fatalf("LoadMappings() failed: %v", err)
+	}
+	got = nil
+	for _, m := range mappings {
+		got = append(got, m.SourceSystem+"/"+m.SourceTable+" "+filepath.Base(filepath.Dir(m.SourceFile)))
+	}
+	want = []string{"ehr_source/PERSON custom", "epic/PATIENT epic", "ehr_source/PATIENT cerner", "ehr_source/SHARED ehr_source"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadMappings() = %q, want %q", got, want)
+	}
+}
+
+func TestOverlayWithoutOwnDirectory(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"meditech/patient.yaml": testSchema("PATIENT", "MT_ID"),
+	})
+	schemas, err := NewLoader(dir).WithOverlays(map[string]string{"ehr_source": "meditech"}).LoadAll(context.Background())
+	if err != nil {
+		t.Fatalf("LoadAll() failed: %v", err)
+	}
+	if len(schemas) != 1 || schemas[0].Namespace != "ehr_source" {
+		t.Errorf("LoadAll() = %+v, want meditech's PATIENT in ehr_source", schemas)
+	}
+
+	_, err = NewLoader(dir).WithOverlays(map[string]string{"ehr_source": "epic"}).LoadAll(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to load ehr_source: overlay epic is not a directory of") {
+		t.Errorf("LoadAll() error = %v, want missing overlay", err)
+	}
+}
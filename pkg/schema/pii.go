@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PIILevels are the pii_level values, from least to most sensitive.
+var PIILevels = []string{"none", "low", "medium", "high", "critical"}
+
+// Redacted replaces the values of sensitive fields when generated models are
+// printed or logged.
+const Redacted = "[REDACTED]"
+
+// PIIRank returns the position of level in PIILevels, compared
+// case-insensitively, or -1 when level is empty or unknown.
+func PIIRank(level string) int {
+	for i, l := range PIILevels {
+		if strings.EqualFold(l, level) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Sensitive reports whether the field's pii_level is at least level. No
+// field is sensitive when level is empty or none.
+func (f Field) Sensitive(level string) bool {
+	threshold := PIIRank(level)
+	return threshold > 0 && PIIRank(f.PIILevel) >= threshold
+}
+
+// HasSensitive reports whether any top-level field of s is sensitive at
+// level.
+func (s Schema) HasSensitive(level string) bool {
+	for _, f := range s.Fields {
+		if f.Sensitive(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pseudonymizable reports whether f holds text that a pseudonym can replace:
+// a string, code, id, uri, or url. Sensitive fields of other types are
+// cleared instead.
+func (f Field) Pseudonymizable() bool {
+	return textTypes[f.Type]
+}
+
+// piiCategories rank the pii_category values by how readily they identify a
+// person: not at all, in combination with other fields, or on their own.
+var piiCategories = map[string]int{
+	"none": 0, "clinical": 0, "financial": 0, "sensitive_data": 0,
+	"quasi_identifier": 1, "temporal": 1, "geographic": 1,
+	"direct_identifier": 2, "contact": 2, "biometric": 2,
+}
+
+// PIIThreshold returns the matcher of the fields a threshold selects. A
+// pii_level, such as high, selects fields at that level and above; a
+// pii_category, such as quasi_identifier, selects fields whose category
+// identifies a person as readily or more so: temporal and geographic rank
+// with quasi_identifier, and contact and biometric with direct_identifier.
+// Prefixed with "above:", a threshold selects only what ranks above it.
+// Fields without a pii_category never match a category threshold.
+func PIIThreshold(threshold string) (func(Field) bool, error) {
+	name, above := strings.CutPrefix(strings.ToLower(strings.TrimSpace(threshold)), "above:")
+	if rank := PIIRank(name); rank >= 0 {
+		if above {
+			rank++
+		}
+		return func(f Field) bool {
+			return rank > 0 && PIIRank(f.PIILevel) >= rank
+		}, nil
+	}
+	if rank, ok := piiCategories[name]; ok {
+		if above {
+			rank++
+		}
+		return func(f Field) bool {
+			r, ok := piiCategories[strings.ToLower(f.PIICategory)]
+			return ok && rank > 0 && r >= rank
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown PII threshold %q (want a pii_level or pii_category, optionally prefixed with above:)", threshold)
+}
+
+// Redact returns f as text that holds Redacted in place of its value,
+// keeping its names, description, and whether it is required, and dropping
+// the type, constraints, and roles that no longer apply to it.
+func Redact(f Field) Field {
+	return Field{
+		Name:        f.Name,
+		UID:         f.UID,
+		Type:        "string",
+		Required:    f.Required,
+		Description: f.Description,
+		Example:     Redacted,
+		Purposes:    f.Purposes,
+		CodeName:    f.CodeName,
+		JSONName:    f.JSONName,
+		DBColumn:    f.DBColumn,
+	}
+}
@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Select returns the schemas named in names, each by name or as
+// namespace/name, in load order, with the schemas of their namespaces that
+// their fields take as types, so that the subset still resolves. A name
+// without a namespace selects the schemas of that name in every namespace.
+// It reports the first name that matches no schema.
+func Select(schemas []Schema, names []string) ([]Schema, error) {
+	selected := make(map[string]bool)
+	var visit func(s Schema)
+	visit = func(s Schema) {
+		key := s.Namespace + "/" + s.GetName()
+		if selected[key] {
+			return
+		}
+		selected[key] = true
+		walkFields(s.Fields, func(f Field) {
+			typ := strings.TrimPrefix(f.Type, "[]")
+			for _, t := range schemas {
+				if t.Namespace == s.Namespace && t.GetName() == typ {
+					visit(t)
+				}
+			}
+		})
+	}
+	for _, name := range names {
+		ns, n, qualified := strings.Cut(name, "/")
+		if !qualified {
+			ns, n = "", name
+		}
+		found := false
+		for _, s := range schemas {
+			if s.GetName() == n && (!qualified || s.Namespace == ns) {
+				visit(s)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no schema %s", name)
+		}
+	}
+
+	var subset []Schema
+	for _, s := range schemas {
+		if selected[s.Namespace+"/"+s.GetName()] {
+			subset = append(subset, s)
+		}
+	}
+	return subset, nil
+}
+
+func walkFields(fields []Field, fn func(Field)) {
+	for _, f := range fields {
+		fn(f)
+		walkFields(f.Children, fn)
+	}
+}
+
+// StripPII returns s without its fields, at any depth, whose pii_level is at
+// least level, as Strip does. No field is stripped when level is empty or
+// none.
+func (s Schema) StripPII(level string) (Schema, []string) {
+	return s.Strip(func(f Field) bool { return f.Sensitive(level) }, false)
+}
+
+// Strip returns s without its fields, at any depth, that match, and without
+// what refers to them: derived fields computed from them, required_if
+// conditions on them, and the indexes, unique keys, partitioning, and
+// clustering by them. With redact set, matching fields are kept as text that
+// holds Redacted instead, so the shape of records stays the same. It also
+// returns the dotted paths of the fields it stripped.
+func (s Schema) Strip(match func(Field) bool, redact bool) (Schema, []string) {
+	var stripped []string
+	var strip func(prefix string, fields []Field) []Field
+	strip = func(prefix string, fields []Field) []Field {
+		var kept []Field
+		for _, f := range fields {
+			if match(f) {
+				stripped = append(stripped, prefix+f.Name)
+				if redact {
+					kept = append(kept, Redact(f))
+				}
+				continue
+			}
+			f.Children = strip(prefix+f.Name+".", f.Children)
+			kept = append(kept, f)
+		}
+		return kept
+	}
+	fields := strip("", s.Fields)
+	if len(stripped) == 0 {
+		return s, nil
+	}
+
+	// Derived fields may be computed from derived fields stripped in turn.
+	gone := make(map[string]bool)
+	for _, path := range stripped {
+		gone[path] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		var kept []Field
+		for _, f := range fields {
+			if f.Derived != "" && refersTo(f.Derived, gone) {
+				gone[f.Name] = true
+				stripped = append(stripped, f.Name)
+				changed = true
+				if redact {
+					kept = append(kept, Redact(f))
+				}
+				continue
+			}
+			kept = append(kept, f)
+		}
+		fields = kept
+	}
+	for i, f := range fields {
+		if f.RequiredIf != "" {
+			if c, err := ParseCondition(f.RequiredIf); err == nil && gone[c.Field] {
+				fields[i].RequiredIf = ""
+			}
+		}
+		if f.EnabledIf != "" {
+			if c, err := ParseCondition(f.EnabledIf); err == nil && gone[c.Field] {
+				fields[i].EnabledIf = ""
+			}
+		}
+	}
+
+	s.Fields = fields
+	s.Indexes = keptIndexes(s.Indexes, gone)
+	s.Unique = keptIndexes(s.Unique, gone)
+	if gone[s.PartitionBy] {
+		s.PartitionBy = ""
+	}
+	var cluster []string
+	for _, name := range s.ClusterBy {
+		if !gone[name] {
+			cluster = append(cluster, name)
+		}
+	}
+	s.ClusterBy = cluster
+	return s, stripped
+}
+
+var identifier = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// refersTo reports whether the derived field expression text names any of
+// the fields in names.
+func refersTo(text string, names map[string]bool) bool {
+	for _, word := range identifier.FindAllString(text, -1) {
+		if names[word] {
+			return true
+		}
+	}
+	return false
+}
+
+func keptIndexes(indexes []Index, gone map[string]bool) []Index {
+	var kept []Index
+	for _, idx := range indexes {
+		if !containsAny(idx.Fields, gone) {
+			kept = append(kept, idx)
+		}
+	}
+	return kept
+}
+
+func containsAny(names []string, set map[string]bool) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
+
+var pathIndex = regexp.MustCompile(`\[\d*\]`)
+
+// Trim returns schemas with the fields that match stripped, as Strip does,
+// and the mappings into the resources among them, without the field mappings
+// to or from stripped fields.
+func Trim(schemas []Schema, mappings []SchemaMapping, match func(Field) bool, redact bool) ([]Schema, []SchemaMapping) {
+	trimmed := make([]Schema, len(schemas))
+	// Mappings name source tables by namespace and table, and target
+	// resources by name alone.
+	tables := make(map[string][]string)
+	resources := make(map[string]bool)
+	targets := make(map[string][]string)
+	for i, s := range schemas {
+		var paths []string
+		trimmed[i], paths = s.Strip(match, redact)
+		tables[s.Namespace+"/"+s.GetName()] = paths
+		resources[s.GetName()] = true
+		targets[s.GetName()] = append(targets[s.GetName()], paths...)
+	}
+
+	var kept []SchemaMapping
+	for _, m := range mappings {
+		if !resources[m.TargetResource] {
+			continue
+		}
+		var fields []FieldMapping
+		for _, fm := range m.FieldMappings {
+			if !under(fm.Source, tables[m.SourceSystem+"/"+m.SourceTable]) && !under(fm.Target, targets[m.TargetResource]) {
+				fields = append(fields, fm)
+			}
+		}
+		m.FieldMappings = fields
+		kept = append(kept, m)
+	}
+	return trimmed, kept
+}
+
+// under reports whether path, with its list indexes left out, is one of
+// paths or a field nested in one of them.
+func under(path string, paths []string) bool {
+	path = pathIndex.ReplaceAllString(path, "")
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
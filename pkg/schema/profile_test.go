@@ -0,0 +1,187 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	schemas := []Schema{
+		{Name: "Patient", Namespace: "fhir_r4", Fields: []Field{{Name: "contact", Type: "[]PatientContact"}}},
+		{Name: "PatientContact", Namespace: "fhir_r4", Fields: []Field{{Name: "address", Type: "Address"}}},
+		{Name: "Observation", Namespace: "fhir_r4"},
+		{Name: "Claim", Namespace: "fhir_r4"},
+		{Name: "Patient", Namespace: "custom", Fields: []Field{{Name: "extra", Type: "Extra"}}},
+		{Name: "Extra", Namespace: "custom"},
+		{Name: "PatientContact", Namespace: "custom"},
+	}
+	names := func(schemas []Schema) []string {
+		var names []string
+		for _, s := range schemas {
+			names = append(names, s.Namespace+"/"+s.GetName())
+		}
+		return names
+	}
+
+	got, err := Select(schemas, []string{"Observation", "fhir_r4/Patient"})
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	want := []string{"fhir_r4/Patient", "fhir_r4/PatientContact", "fhir_r4/Observation"}
+	if !reflect.DeepEqual(names(got), want) {
+		t.Errorf("Select() = %q, want %q", names(got), want)
+	}
+
+	got, err = Select(schemas, []string{"Patient"})
+	if err != nil {
+		t.Fatalf("Select() failed: %v", err)
+	}
+	want = []string{"fhir_r4/Patient", "fhir_r4/PatientContact", "custom/Patient", "custom/Extra"}
+	if !reflect.DeepEqual(names(got), want) {
+		t.Errorf("Select() = %q, want %q", names(got), want)
+	}
+
+	for _, name := range []string{"patient", "custom/Observation"} {
+		if _, err := Select(schemas, []string{name}); err == nil || err.Error() != "no schema "+name {
+			t.Errorf("Select(%s) error = %v, want no schema", name, err)
+		}
+	}
+}
+
+func TestStripPII(t *testing.T) {
+	s := Schema{
+		Name:        "PATIENT",
+		PartitionBy: "BIRTH_DATE",
+		ClusterBy:   []string{"MRN", "STATE"},
+		Indexes:     []Index{{Fields: []string{"MRN"}}, {Fields: []string{"STATE"}}},
+		Unique:      []Index{{Fields: []string{"MRN", "STATE"}}},
+		Fields: []Field{
+			{Name: "ID", Type: "string"},
+			{Name: "MRN", Type: "string", PIILevel: "high"},
+			{Name: "BIRTH_DATE", Type: "date", PIILevel: "critical"},
+			{Name: "STATE", Type: "string", PIILevel: "medium"},
+			{Name: "ADDRESS", Type: "object", Children: []Field{
+				{Name: "LINE", Type: "string", PIILevel: "high"},
+				{Name: "ZIP3", Type: "string", PIILevel: "low"},
+			}},
+			{Name: "AGE", Type: "integer", Derived: "years_since(BIRTH_DATE)"},
+			{Name: "AGE_BAND", Type: "integer", Derived: "AGE / 10"},
+			{Name: "GUARDIAN", Type: "string", RequiredIf: "STATE == NY"},
+			{Name: "MRN_ISSUER", Type: "string", RequiredIf: "MRN"},
+		},
+	}
+
+	got, stripped := s.StripPII("high")
+	var fields []string
+	walkFields(got.Fields, func(f Field) { fields = append(fields, f.Name) })
+	if want := []string{"ID", "STATE", "ADDRESS", "ZIP3", "GUARDIAN", "MRN_ISSUER"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("StripPII() fields = %q, want %q", fields, want)
+	}
+	if want := []string{"MRN", "BIRTH_DATE", "ADDRESS.LINE", "AGE", "AGE_BAND"}; !reflect.DeepEqual(stripped, want) {
+		t.Errorf("StripPII() stripped = %q, want %q", stripped, want)
+	}
+	if got.Fields[3].RequiredIf != "STATE == NY" || got.Fields[4].RequiredIf != "" {
+		t.Errorf("StripPII() required_if = %q, %q", got.Fields[3].RequiredIf, got.Fields[4].RequiredIf)
+	}
+	if got.PartitionBy != "" || !reflect.DeepEqual(got.ClusterBy, []string{"STATE"}) || len(got.Indexes) != 1 || len(got.Unique) != 0 {
+		t.Errorf("StripPII() = partition %q, cluster %q, indexes %v, unique %v", got.PartitionBy, got.ClusterBy, got.Indexes, got.Unique)
+	}
+	if len(s.Fields[4].Children) != 2 {
+		t.Error("StripPII() changed the fields of its schema")
+	}
+
+	if got, stripped := s.StripPII(""); !reflect.DeepEqual(got, s) || stripped != nil {
+		t.Errorf("StripPII(\"\") stripped %q", stripped)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	schemas := []Schema{
+		{Name: "Patient", Namespace: "fhir_r4", Fields: []Field{
+			{Name: "id", Type: "id"},
+			{Name: "name", Type: "[]HumanName", PIILevel: "high"},
+			{Name: "gender", Type: "code", PIILevel: "low"},
+		}},
+		{Name: "PATIENT", Namespace: "epic", Fields: []Field{
+			{Name: "PAT_ID", Type: "string"},
+			{Name: "SSN", Type: "string", PIILevel: "critical"},
+		}},
+	}
+	mappings := []SchemaMapping{
+		{SourceSystem: "epic", SourceTable: "PATIENT", TargetResource: "Patient", FieldMappings: []FieldMapping{
+			{Source: "PAT_ID", Target: "id"},
+			{Source: "PAT_NAME", Target: "name[0].family"},
+			{Source: "SEX_C", Target: "gender"},
+			{Source: "SSN", Target: "identifier[1].value"},
+		}},
+		{SourceSystem: "epic", SourceTable: "ORDER_MED", TargetResource: "MedicationRequest"},
+	}
+
+	high := func(f Field) bool { return f.Sensitive("high") }
+	got, gotMappings := Trim(schemas, mappings, high, false)
+	if len(got) != 2 || len(got[0].Fields) != 2 || len(got[1].Fields) != 1 {
+		t.Errorf("Trim() schemas = %+v", got)
+	}
+	var targets []string
+	for _, m := range gotMappings {
+		for _, fm := range m.FieldMappings {
+			targets = append(targets, m.TargetResource+"."+fm.Target)
+		}
+	}
+	if want := []string{"Patient.id", "Patient.gender"}; len(gotMappings) != 1 || !reflect.DeepEqual(targets, want) {
+		t.Errorf("Trim() mappings = %q, want %q", targets, want)
+	}
+	if len(mappings[0].FieldMappings) != 4 {
+		t.Error("Trim() changed its mappings")
+	}
+
+	got, gotMappings = Trim(schemas, mappings, high, true)
+	if len(got[0].Fields) != 3 || !reflect.DeepEqual(got[0].Fields[1], Field{Name: "name", Type: "string", Example: Redacted}) || len(gotMappings[0].FieldMappings) != 2 {
+		t.Errorf("Trim() redacting = %+v, %+v", got, gotMappings)
+	}
+
+	_, gotMappings = Trim(schemas, mappings, func(Field) bool { return false }, false)
+	if len(gotMappings) != 1 || len(gotMappings[0].FieldMappings) != 4 {
+		t.Errorf("Trim() matching nothing = %+v", gotMappings)
+	}
+}
+
+func TestPIIThreshold(t *testing.T) {
+	fields := []Field{
+		{Name: "mrn", PIILevel: "CRITICAL", PIICategory: "direct_identifier"},
+		{Name: "phone", PIILevel: "high", PIICategory: "contact"},
+		{Name: "birth_date", PIILevel: "high", PIICategory: "temporal"},
+		{Name: "zip", PIILevel: "medium", PIICategory: "quasi_identifier"},
+		{Name: "code", PIILevel: "low", PIICategory: "clinical"},
+		{Name: "status"},
+	}
+	for _, tc := range []struct {
+		threshold string
+		want      []string
+	}{
+		{"high", []string{"mrn", "phone", "birth_date"}},
+		{"above:high", []string{"mrn"}},
+		{"quasi_identifier", []string{"mrn", "phone", "birth_date", "zip"}},
+		{"above:quasi_identifier", []string{"mrn", "phone"}},
+		{"above:none", []string{"mrn", "phone", "birth_date", "zip", "code"}},
+		{"none", nil},
+	} {
+		match, err := PIIThreshold(tc.threshold)
+		if err != nil {
+			t.Fatalf("PIIThreshold(%s) failed: %v", tc.threshold, err)
+		}
+		var got []string
+		for _, f := range fields {
+			if match(f) {
+				got = append(got, f.Name)
+			}
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("PIIThreshold(%s) matches %q, want %q", tc.threshold, got, tc.want)
+		}
+	}
+
+	if _, err := PIIThreshold("above:names"); err == nil {
+		t.Error("PIIThreshold(above:names) succeeded, want unknown threshold")
+	}
+}
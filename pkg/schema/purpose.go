@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Purposes of use that schemas and fields may be disclosed for.
+const (
+	PurposeTreatment  = "treatment"
+	PurposeResearch   = "research"
+	PurposeOperations = "operations"
+)
+
+// Purposes are the purposes of use, in the order generated code lists them.
+var Purposes = []string{PurposeTreatment, PurposeResearch, PurposeOperations}
+
+// HasPurposes reports whether s or any of its top-level fields restricts the
+// purposes of use it is disclosed for.
+func (s Schema) HasPurposes() bool {
+	if len(s.Purposes) > 0 {
+		return true
+	}
+	for _, f := range s.Fields {
+		if len(f.Purposes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Permits reports whether records of s may be disclosed for purpose. Schemas
+// that declare no purposes permit all of them.
+func (s Schema) Permits(purpose string) bool {
+	return len(s.Purposes) == 0 || contains(s.Purposes, purpose)
+}
+
+// PermitsField reports whether field f of s may be disclosed for purpose: s
+// must permit purpose, and so must f when it declares purposes of its own.
+func (s Schema) PermitsField(f Field, purpose string) bool {
+	return s.Permits(purpose) && (len(f.Purposes) == 0 || contains(f.Purposes, purpose))
+}
+
+// CheckPurposes reports the first unknown purpose s or one of its fields
+// declares, a field purpose that s itself does not permit, and purposes
+// declared by nested fields, which cannot be filtered on their own.
+func (s Schema) CheckPurposes() error {
+	check := func(where string, purposes []string) error {
+		for _, p := range purposes {
+			if !contains(Purposes, p) {
+				return fmt.Errorf("%s has unknown purpose %q (want %s)", where, p, strings.Join(Purposes, ", "))
+			}
+		}
+		return nil
+	}
+	if err := check("schema", s.Purposes); err != nil {
+		return err
+	}
+	for _, f := range s.Fields {
+		if err := check("field "+f.Name, f.Purposes); err != nil {
+			return err
+		}
+		for _, p := range f.Purposes {
+			if !s.Permits(p) {
+				return fmt.Errorf("field %s declares purpose %s, which the schema does not permit", f.Name, p)
+			}
+		}
+		if err := checkNestedPurposes(f.Children, f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkNestedPurposes(fields []Field, parent string) error {
+	for _, f := range fields {
+		path := parent + "." + f.Name
+		if len(f.Purposes) > 0 {
+			return fmt.Errorf("field %s cannot declare purposes: only top-level fields can", path)
+		}
+		if err := checkNestedPurposes(f.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPermits(t *testing.T) {
+	mrn := Field{Name: "mrn", Type: "string", Purposes: []string{PurposeTreatment}}
+	value := Field{Name: "value", Type: "decimal"}
+	s := Schema{Name: "LabResult", Purposes: []string{PurposeTreatment, PurposeResearch}, Fields: []Field{mrn, value}}
+	if err := s.CheckPurposes(); err != nil {
+		t.Fatalf("CheckPurposes() failed: %v", err)
+	}
+	for _, tc := range []struct {
+		field   Field
+		purpose string
+		want    bool
+	}{
+		{mrn, PurposeTreatment, true},
+		{mrn, PurposeResearch, false},
+		{value, PurposeResearch, true},
+		{value, PurposeOperations, false},
+	} {
+		if got := s.PermitsField(tc.field, tc.purpose); got != tc.want {
+			t.Errorf("PermitsField(%s, %s) = %v, want %v", tc.field.Name, tc.purpose, got, tc.want)
+		}
+	}
+	if !(Schema{Name: "Visit"}).Permits(PurposeOperations) {
+		t.Error("schema without purposes does not permit operations")
+	}
+}
+
+func TestCheckPurposes(t *testing.T) {
+	for _, tc := range []struct {
+		schema Schema
+		want   string
+	}{
+		{Schema{Name: "A", Purposes: []string{"marketing"}}, `schema has unknown purpose "marketing"`},
+		{Schema{Name: "A", Fields: []Field{{Name: "mrn", Purposes: []string{"Research"}}}}, `field mrn has unknown purpose "Research"`},
+		{Schema{Name: "A", Purposes: []string{PurposeTreatment}, Fields: []Field{{Name: "mrn", Purposes: []string{PurposeResearch}}}}, "which the schema does not permit"},
+		{Schema{Name: "A", Fields: []Field{{Name: "contact", Children: []Field{{Name: "phone", Purposes: []string{PurposeTreatment}}}}}}, "contact.phone cannot declare purposes"},
+	} {
+		if err := tc.schema.CheckPurposes(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("CheckPurposes() = %v, want %q", err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// numericTypes are the field types that can take a unit and a reference
+// range.
+var numericTypes = map[string]bool{
+	"integer": true, "positiveInt": true, "unsignedInt": true, "decimal": true,
+}
+
+// HasReferenceRange reports whether f declares a low or high reference
+// bound.
+func (f Field) HasReferenceRange() bool {
+	return f.ReferenceLow != "" || f.ReferenceHigh != ""
+}
+
+// ReferenceRange describes the reference range of f and its unit, such as
+// "3.5 to 11 umol/L", "at least 1", or "at most 4 mmol/L".
+func (f Field) ReferenceRange() string {
+	var text string
+	switch {
+	case f.ReferenceLow != "" && f.ReferenceHigh != "":
+		text = f.ReferenceLow + " to " + f.ReferenceHigh
+	case f.ReferenceLow != "":
+		text = "at least " + f.ReferenceLow
+	case f.ReferenceHigh != "":
+		text = "at most " + f.ReferenceHigh
+	}
+	if text != "" && f.Unit != "" {
+		text += " " + f.Unit
+	}
+	return text
+}
+
+// RangedFields returns the top-level fields of s with a reference range: the
+// values expected of them, such as the normal range of a lab test, outside
+// which generated code and quality checks flag values for review rather than
+// reject them. It reports units and reference ranges of fields that are not
+// numbers, reference ranges of nested fields, bounds that are not numbers,
+// and low bounds above high ones.
+func (s Schema) RangedFields() ([]Field, error) {
+	var ranged []Field
+	var check func(prefix string, fields []Field) error
+	check = func(prefix string, fields []Field) error {
+		for _, f := range fields {
+			if (f.Unit != "" || f.HasReferenceRange()) && !numericTypes[f.Type] {
+				return fmt.Errorf("field %s of type %s cannot take a unit or reference range", prefix+f.Name, f.Type)
+			}
+			if f.HasReferenceRange() {
+				if prefix != "" {
+					return fmt.Errorf("field %s is nested and cannot take a reference range", prefix+f.Name)
+				}
+				low, err := bound(f, "reference_low", f.ReferenceLow)
+				if err != nil {
+					return err
+				}
+				high, err := bound(f, "reference_high", f.ReferenceHigh)
+				if err != nil {
+					return err
+				}
+				if f.ReferenceLow != "" && f.ReferenceHigh != "" && low > high {
+					return fmt.Errorf("field %s has reference_low %s above its reference_high %s", f.Name, f.ReferenceLow, f.ReferenceHigh)
+				}
+				ranged = append(ranged, f)
+			}
+			if err := check(prefix+f.Name+".", f.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := check("", s.Fields); err != nil {
+		return nil, err
+	}
+	return ranged, nil
+}
+
+// bound parses a reference bound of f, which is a value of its type, or
+// empty for no bound.
+func bound(f Field, key, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if f.Type != "decimal" {
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, fmt.Errorf("field %s has %s %q, which is not a valid %s", f.Name, key, value, f.Type)
+		}
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("field %s has %s %q, which is not a valid %s", f.Name, key, value, f.Type)
+	}
+	return v, nil
+}
@@ -0,0 +1,47 @@
+package schema
+
+import "testing"
+
+func TestRangedFields(t *testing.T) {
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{Name: "potassium", Type: "decimal", Unit: "mmol/L", ReferenceLow: "3.5", ReferenceHigh: "5.1"}, ""},
+		{Field{Name: "count", Type: "integer", ReferenceHigh: "4"}, ""},
+		{Field{Name: "weight", Type: "decimal", Unit: "kg"}, ""},
+		{Field{Name: "label", Type: "string", Unit: "kg"}, "field label of type string cannot take a unit or reference range"},
+		{Field{Name: "count", Type: "integer", ReferenceLow: "1.5"}, `field count has reference_low "1.5", which is not a valid integer`},
+		{Field{Name: "potassium", Type: "decimal", ReferenceHigh: "high"}, `field potassium has reference_high "high", which is not a valid decimal`},
+		{Field{Name: "potassium", Type: "decimal", ReferenceLow: "5.1", ReferenceHigh: "3.5"}, "field potassium has reference_low 5.1 above its reference_high 3.5"},
+		{Field{Name: "panel", Type: "BackboneElement", Children: []Field{{Name: "sodium", Type: "decimal", ReferenceLow: "135"}}}, "field panel.sodium is nested and cannot take a reference range"},
+	} {
+		ranged, err := Schema{Name: "A", Fields: []Field{tc.field}}.RangedFields()
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.want {
+			t.Errorf("RangedFields(%s) = %q, want %q", tc.field.Name, got, tc.want)
+		}
+		if err == nil && len(ranged) != map[bool]int{true: 1}[tc.field.HasReferenceRange()] {
+			t.Errorf("RangedFields(%s) = %v", tc.field.Name, ranged)
+		}
+	}
+}
+
+func TestReferenceRange(t *testing.T) {
+	for _, tc := range []struct {
+		field Field
+		want  string
+	}{
+		{Field{ReferenceLow: "3.5", ReferenceHigh: "11", Unit: "umol/L"}, "3.5 to 11 umol/L"},
+		{Field{ReferenceLow: "1"}, "at least 1"},
+		{Field{ReferenceHigh: "4", Unit: "mmol/L"}, "at most 4 mmol/L"},
+		{Field{Unit: "kg"}, ""},
+	} {
+		if got := tc.field.ReferenceRange(); got != tc.want {
+			t.Errorf("ReferenceRange() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Retention is how long records of a schema are kept, counted from a date
+// or timestamp field.
+type Retention struct {
+	// Period is a count of days, months, or years, such as "7 years" or
+	// "90d".
+	Period string `yaml:"period"`
+	// Field is the top-level date or timestamp field records age from.
+	Field string `yaml:"field"`
+}
+
+// Units of retention periods.
+const (
+	RetentionDay   = "day"
+	RetentionMonth = "month"
+	RetentionYear  = "year"
+)
+
+// Period is a parsed retention period.
+type Period struct {
+	Count int
+	// Unit is RetentionDay, RetentionMonth, or RetentionYear.
+	Unit string
+}
+
+// String returns the period as a count and a unit, such as "7 years".
+func (p Period) String() string {
+	if p.Count == 1 {
+		return "1 " + p.Unit
+	}
+	return strconv.Itoa(p.Count) + " " + p.Unit + "s"
+}
+
+var periodPattern = regexp.MustCompile(`^\s*(\d+)\s*(d|days?|m|months?|y|years?)\s*$`)
+
+// ParsePeriod parses a retention period written as a count followed by a
+// unit: d, day, or days; m, month, or months; y, year, or years.
+func ParsePeriod(s string) (Period, error) {
+	m := periodPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Period{}, fmt.Errorf("invalid retention period %q (want a count of days, months, or years, such as 7 years)", s)
+	}
+	count, err := strconv.Atoi(m[1])
+	if err != nil || count == 0 {
+		return Period{}, fmt.Errorf("invalid retention period %q (want a positive count)", s)
+	}
+	units := map[byte]string{'d': RetentionDay, 'm': RetentionMonth, 'y': RetentionYear}
+	return Period{Count: count, Unit: units[m[2][0]]}, nil
+}
+
+// RetentionPeriod returns the retention period of s and the field its
+// records age from, or a nil field when s declares no retention. It reports
+// an invalid period and a field that is not a top-level date or timestamp
+// field of s.
+func (s Schema) RetentionPeriod() (Period, *Field, error) {
+	if s.Retention == (Retention{}) {
+		return Period{}, nil, nil
+	}
+	period, err := ParsePeriod(s.Retention.Period)
+	if err != nil {
+		return Period{}, nil, fmt.Errorf("retention: %w", err)
+	}
+	for i, f := range s.Fields {
+		if f.Name != s.Retention.Field {
+			continue
+		}
+		switch f.Type {
+		case "date", "datetime", "instant":
+			return period, &s.Fields[i], nil
+		}
+		return Period{}, nil, fmt.Errorf("retention: field %s of type %s is not a date or timestamp", f.Name, f.Type)
+	}
+	return Period{}, nil, fmt.Errorf("retention: field %q is not a field", s.Retention.Field)
+}
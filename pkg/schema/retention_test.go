@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for s, want := range map[string]Period{
+		"7 years":  {7, RetentionYear},
+		"1 year":   {1, RetentionYear},
+		"18m":      {18, RetentionMonth},
+		" 90 days": {90, RetentionDay},
+		"30d":      {30, RetentionDay},
+	} {
+		got, err := ParsePeriod(s)
+		if err != nil || got != want {
+			t.Errorf("ParsePeriod(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "7", "seven years", "0 days", "2 weeks"} {
+		if _, err := ParsePeriod(s); err == nil {
+			t.Errorf("ParsePeriod(%q) succeeded", s)
+		}
+	}
+	if got := (Period{7, RetentionYear}).String(); got != "7 years" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestRetentionPeriod(t *testing.T) {
+	fields := []Field{{Name: "id", Type: "id"}, {Name: "resulted_at", Type: "instant"}}
+	period, field, err := Schema{Name: "LabResult", Retention: Retention{Period: "7y", Field: "resulted_at"}, Fields: fields}.RetentionPeriod()
+	if err != nil || period != (Period{7, RetentionYear}) || field == nil || field.Name != "resulted_at" {
+		t.Errorf("RetentionPeriod() = %v, %v, %v", period, field, err)
+	}
+	if _, field, err := (Schema{Name: "LabResult", Fields: fields}).RetentionPeriod(); field != nil || err != nil {
+		t.Errorf("RetentionPeriod() without retention = %v, %v", field, err)
+	}
+	for _, tc := range []struct {
+		retention Retention
+		want      string
+	}{
+		{Retention{Period: "forever", Field: "resulted_at"}, "invalid retention period"},
+		{Retention{Period: "7 years", Field: "id"}, "is not a date or timestamp"},
+		{Retention{Period: "7 years", Field: "missing"}, `"missing" is not a field`},
+	} {
+		_, _, err := Schema{Name: "LabResult", Retention: tc.retention, Fields: fields}.RetentionPeriod()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("RetentionPeriod() with %+v = %v, want %q", tc.retention, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package schema
+
+import "fmt"
+
+// CheckUIDs reports a uid that two fields of s share. Uids identify fields
+// across renames, so each must be unique within its schema, children
+// included.
+func (s Schema) CheckUIDs() error {
+	owners := make(map[string]string)
+	var check func(prefix string, fields []Field) error
+	check = func(prefix string, fields []Field) error {
+		for _, f := range fields {
+			if f.UID != "" {
+				if owner, ok := owners[f.UID]; ok {
+					return fmt.Errorf("fields %s and %s share uid %q", owner, prefix+f.Name, f.UID)
+				}
+				owners[f.UID] = prefix + f.Name
+			}
+			if err := check(prefix+f.Name+".", f.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check("", s.Fields)
+}
@@ -0,0 +1,19 @@
+package schema
+
+import "testing"
+
+func TestCheckUIDs(t *testing.T) {
+	s := Schema{Name: "A", Fields: []Field{
+		{Name: "mrn", Type: "string", UID: "f1"},
+		{Name: "contact", Type: "BackboneElement", UID: "f2", Children: []Field{{Name: "phone", Type: "string", UID: "f3"}}},
+		{Name: "note", Type: "string"},
+	}}
+	if err := s.CheckUIDs(); err != nil {
+		t.Fatalf("CheckUIDs() failed: %v", err)
+	}
+	s.Fields[1].Children[0].UID = "f1"
+	want := `fields mrn and contact.phone share uid "f1"`
+	if err := s.CheckUIDs(); err == nil || err.Error() != want {
+		t.Errorf("CheckUIDs() error = %v, want %s", err, want)
+	}
+}
@@ -0,0 +1,193 @@
+package sdtm
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Code systems and extensions the domains read.
+const (
+	loinc               = "http://loinc.org"
+	usCoreRace          = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-race"
+	usCoreEthnicity     = "http://hl7.org/fhir/us/core/StructureDefinition/us-core-ethnicity"
+	observationCategory = "http://terminology.hl7.org/CodeSystem/observation-category"
+)
+
+// sex maps FHIR administrative gender to the SEX codelist.
+var sex = map[string]string{"male": "M", "female": "F", "unknown": "U", "other": "UNDIFFERENTIATED"}
+
+// races maps the OMB race categories of US Core to the RACE codelist.
+var races = map[string]string{
+	"1002-5": "AMERICAN INDIAN OR ALASKA NATIVE",
+	"2028-9": "ASIAN",
+	"2054-5": "BLACK OR AFRICAN AMERICAN",
+	"2076-8": "NATIVE HAWAIIAN OR OTHER PACIFIC ISLANDER",
+	"2106-3": "WHITE",
+}
+
+// ethnicities maps the OMB ethnicity categories of US Core to the ETHNIC
+// codelist.
+var ethnicities = map[string]string{
+	"2135-2": "HISPANIC OR LATINO",
+	"2186-5": "NOT HISPANIC OR LATINO",
+}
+
+// severities maps AdverseEvent severity to the AESEV codelist.
+var severities = map[string]string{"mild": "MILD", "moderate": "MODERATE", "severe": "SEVERE"}
+
+// outcomes maps AdverseEvent outcome to the AEOUT codelist.
+var outcomes = map[string]string{
+	"resolved":             "RECOVERED/RESOLVED",
+	"recovering":           "RECOVERING/RESOLVING",
+	"ongoing":              "NOT RECOVERED/NOT RESOLVED",
+	"resolvedWithSequelae": "RECOVERED/RESOLVED WITH SEQUELAE",
+	"fatal":                "FATAL",
+	"unknown":              "UNKNOWN",
+}
+
+// referenceRanges maps Observation interpretation codes to the LBNRIND
+// codelist.
+var referenceRanges = map[string]string{
+	"N": "NORMAL", "L": "LOW", "LL": "LOW", "H": "HIGH", "HH": "HIGH", "A": "ABNORMAL", "AA": "ABNORMAL",
+}
+
+// labTests maps the LOINC codes of common laboratory tests to their LBTESTCD
+// and LBTEST terms.
+var labTests = map[string][2]string{
+	"2345-7":  {"GLUC", "Glucose"},
+	"2160-0":  {"CREAT", "Creatinine"},
+	"2951-2":  {"SODIUM", "Sodium"},
+	"2823-3":  {"K", "Potassium"},
+	"2075-0":  {"CL", "Chloride"},
+	"3094-0":  {"BUN", "Blood Urea Nitrogen"},
+	"1742-6":  {"ALT", "Alanine Aminotransferase"},
+	"1920-8":  {"AST", "Aspartate Aminotransferase"},
+	"1975-2":  {"BILI", "Bilirubin"},
+	"718-7":   {"HGB", "Hemoglobin"},
+	"4544-3":  {"HCT", "Hematocrit"},
+	"6690-2":  {"WBC", "Leukocytes"},
+	"777-3":   {"PLAT", "Platelets"},
+	"4548-4":  {"HBA1C", "Hemoglobin A1C"},
+	"2093-3":  {"CHOL", "Cholesterol"},
+	"13457-7": {"LDL", "LDL Cholesterol"},
+}
+
+var nonAlnum = regexp.MustCompile(`[^A-Z0-9]`)
+
+func demographics(e *Exporter, p map[string]any) map[string]string {
+	id := str(p["id"])
+	row := map[string]string{
+		"USUBJID": e.study + "-" + id,
+		"SUBJID":  id,
+		"BRTHDTC": str(p["birthDate"]),
+		"SEX":     sex[str(p["gender"])],
+		"DTHDTC":  str(p["deceasedDateTime"]),
+	}
+	if row["DTHDTC"] != "" || p["deceasedBoolean"] == true {
+		row["DTHFL"] = "Y"
+	}
+
+	var race []string
+	for _, ext := range list(p["extension"]) {
+		ext := object(ext)
+		for _, sub := range list(ext["extension"]) {
+			sub := object(sub)
+			if str(sub["url"]) != "ombCategory" {
+				continue
+			}
+			code := str(object(sub["valueCoding"])["code"])
+			switch str(ext["url"]) {
+			case usCoreRace:
+				if r, ok := races[code]; ok {
+					race = append(race, r)
+				}
+			case usCoreEthnicity:
+				row["ETHNIC"] = ethnicities[code]
+			}
+		}
+	}
+	switch len(race) {
+	case 0:
+	case 1:
+		row["RACE"] = race[0]
+	default:
+		row["RACE"] = "MULTIPLE"
+	}
+	return row
+}
+
+func adverseEvent(e *Exporter, ae map[string]any) map[string]string {
+	row := map[string]string{
+		"USUBJID": e.subject(ae["subject"]),
+		"AETERM":  text(ae["event"]),
+		"AEDECOD": strings.ToUpper(text(ae["event"])),
+		"AESTDTC": str(ae["date"]),
+		"AESEV":   severities[code(ae["severity"], "")],
+		"AEOUT":   outcomes[code(ae["outcome"], "")],
+	}
+	for _, c := range codings(ae["event"]) {
+		// MedDRA preferred terms are the dictionary-derived term
+		if strings.Contains(str(c["system"]), "meddra") && str(c["display"]) != "" {
+			row["AEDECOD"] = strings.ToUpper(str(c["display"]))
+		}
+	}
+	switch code(ae["seriousness"], "") {
+	case "serious":
+		row["AESER"] = "Y"
+	case "non-serious":
+		row["AESER"] = "N"
+	}
+	return row
+}
+
+// labResult returns the LB row of an Observation, or nil for Observations
+// outside the laboratory category.
+func labResult(e *Exporter, obs map[string]any) map[string]string {
+	lab := false
+	for _, category := range list(obs["category"]) {
+		if code(category, observationCategory) == "laboratory" {
+			lab = true
+		}
+	}
+	if !lab {
+		return nil
+	}
+
+	row := map[string]string{
+		"USUBJID": e.subject(obs["subject"]),
+		"LBLOINC": code(obs["code"], loinc),
+		"LBTEST":  text(obs["code"]),
+		"LBDTC":   str(obs["effectiveDateTime"]),
+	}
+	if test, ok := labTests[row["LBLOINC"]]; ok {
+		row["LBTESTCD"], row["LBTEST"] = test[0], test[1]
+	} else {
+		// Test codes are at most eight characters, starting with a letter
+		testcd := nonAlnum.ReplaceAllString(strings.ToUpper(row["LBTEST"]), "")
+		testcd = strings.TrimLeft(testcd, "0123456789")
+		if len(testcd) > 8 {
+			testcd = testcd[:8]
+		}
+		row["LBTESTCD"] = testcd
+	}
+
+	if q := object(obs["valueQuantity"]); q != nil {
+		row["LBORRES"] = str(q["value"])
+		row["LBORRESU"] = str(q["unit"])
+	} else if s := str(obs["valueString"]); s != "" {
+		row["LBORRES"] = s
+	} else if c := obs["valueCodeableConcept"]; c != nil {
+		row["LBORRES"] = text(c)
+	}
+	for _, r := range list(obs["referenceRange"]) {
+		r := object(r)
+		row["LBORNRLO"] = str(object(r["low"])["value"])
+		row["LBORNRHI"] = str(object(r["high"])["value"])
+		break
+	}
+	for _, interpretation := range list(obs["interpretation"]) {
+		row["LBNRIND"] = referenceRanges[code(interpretation, "")]
+		break
+	}
+	return row
+}
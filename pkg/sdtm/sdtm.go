@@ -0,0 +1,307 @@
+// Package sdtm exports FHIR resources as CDISC SDTM domain datasets, for
+// converting EHR extracts into clinical trial submissions.
+package sdtm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Column is a variable of an SDTM domain.
+type Column struct {
+	Name  string
+	Label string
+	// Codelist is the NCI code of the CDISC controlled terminology the
+	// values are drawn from, such as C66731 for SEX, or empty.
+	Codelist string
+}
+
+// Domain is an SDTM domain: the resources it is built from and its
+// variables, in dataset order.
+type Domain struct {
+	Name     string
+	Label    string
+	Resource string
+	Columns  []Column
+	convert  func(e *Exporter, resource map[string]any) map[string]string
+}
+
+// Domains are the supported domains, in the order they are written.
+var Domains = []Domain{
+	{
+		Name:     "DM",
+		Label:    "Demographics",
+		Resource: "Patient",
+		Columns: []Column{
+			{Name: "STUDYID", Label: "Study Identifier"},
+			{Name: "DOMAIN", Label: "Domain Abbreviation"},
+			{Name: "USUBJID", Label: "Unique Subject Identifier"},
+			{Name: "SUBJID", Label: "Subject Identifier for the Study"},
+			{Name: "BRTHDTC", Label: "Date/Time of Birth"},
+			{Name: "SEX", Label: "Sex", Codelist: "C66731"},
+			{Name: "RACE", Label: "Race", Codelist: "C74457"},
+			{Name: "ETHNIC", Label: "Ethnicity", Codelist: "C66790"},
+			{Name: "DTHDTC", Label: "Date/Time of Death"},
+			{Name: "DTHFL", Label: "Subject Death Flag", Codelist: "C66742"},
+		},
+		convert: demographics,
+	},
+	{
+		Name:     "AE",
+		Label:    "Adverse Events",
+		Resource: "AdverseEvent",
+		Columns: []Column{
+			{Name: "STUDYID", Label: "Study Identifier"},
+			{Name: "DOMAIN", Label: "Domain Abbreviation"},
+			{Name: "USUBJID", Label: "Unique Subject Identifier"},
+			{Name: "AESEQ", Label: "Sequence Number"},
+			{Name: "AETERM", Label: "Reported Term for the Adverse Event"},
+			{Name: "AEDECOD", Label: "Dictionary-Derived Term"},
+			{Name: "AESTDTC", Label: "Start Date/Time of Adverse Event"},
+			{Name: "AESEV", Label: "Severity/Intensity", Codelist: "C66769"},
+			{Name: "AESER", Label: "Serious Event", Codelist: "C66742"},
+			{Name: "AEOUT", Label: "Outcome of Adverse Event", Codelist: "C66768"},
+		},
+		convert: adverseEvent,
+	},
+	{
+		Name:     "LB",
+		Label:    "Laboratory Test Results",
+		Resource: "Observation",
+		Columns: []Column{
+			{Name: "STUDYID", Label: "Study Identifier"},
+			{Name: "DOMAIN", Label: "Domain Abbreviation"},
+			{Name: "USUBJID", Label: "Unique Subject Identifier"},
+			{Name: "LBSEQ", Label: "Sequence Number"},
+			{Name: "LBTESTCD", Label: "Lab Test or Examination Short Name", Codelist: "C65047"},
+			{Name: "LBTEST", Label: "Lab Test or Examination Name", Codelist: "C67154"},
+			{Name: "LBLOINC", Label: "LOINC Code"},
+			{Name: "LBORRES", Label: "Result or Finding in Original Units"},
+			{Name: "LBORRESU", Label: "Original Units", Codelist: "C71620"},
+			{Name: "LBORNRLO", Label: "Reference Range Lower Limit in Orig Unit"},
+			{Name: "LBORNRHI", Label: "Reference Range Upper Limit in Orig Unit"},
+			{Name: "LBNRIND", Label: "Reference Range Indicator", Codelist: "C78736"},
+			{Name: "LBDTC", Label: "Date/Time of Specimen Collection"},
+		},
+		convert: labResult,
+	},
+}
+
+// Exporter collects the rows of each domain from FHIR resources.
+type Exporter struct {
+	study string
+	rows  map[string][]map[string]string
+	// skipped counts resources of types no domain is built from, and
+	// Observations that are not laboratory results.
+	skipped int
+}
+
+// New creates an Exporter for the study with the given STUDYID.
+func New(study string) *Exporter {
+	return &Exporter{study: study, rows: make(map[string][]map[string]string)}
+}
+
+// Read adds every resource of r, which holds NDJSON, a JSON array of
+// resources, or FHIR Bundles.
+func (e *Exporter) Read(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var v any
+		if err := dec.Decode(&v); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		e.add(v)
+	}
+}
+
+func (e *Exporter) add(v any) {
+	switch v := v.(type) {
+	case []any:
+		for _, item := range v {
+			e.add(item)
+		}
+	case map[string]any:
+		if v["resourceType"] == "Bundle" {
+			for _, entry := range list(v["entry"]) {
+				e.add(object(entry)["resource"])
+			}
+			return
+		}
+		e.Add(v)
+	}
+}
+
+// Add adds a resource to the domain built from its type. Observations are
+// added to LB only in the laboratory category.
+func (e *Exporter) Add(resource map[string]any) {
+	resourceType, _ := resource["resourceType"].(string)
+	for _, d := range Domains {
+		if d.Resource != resourceType {
+			continue
+		}
+		if row := d.convert(e, resource); row != nil {
+			row["STUDYID"] = e.study
+			row["DOMAIN"] = d.Name
+			e.rows[d.Name] = append(e.rows[d.Name], row)
+			return
+		}
+	}
+	e.skipped++
+}
+
+// Skipped returns the number of resources no domain was built from.
+func (e *Exporter) Skipped() int {
+	return e.skipped
+}
+
+// Rows returns the rows of a domain, sorted by subject and date and
+// numbered per subject in the domain's --SEQ variable.
+func (e *Exporter) Rows(domain string) []map[string]string {
+	rows := e.rows[domain]
+	date := domain + "DTC"
+	if domain == "AE" {
+		date = "AESTDTC"
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i]["USUBJID"] != rows[j]["USUBJID"] {
+			return rows[i]["USUBJID"] < rows[j]["USUBJID"]
+		}
+		return rows[i][date] < rows[j][date]
+	})
+	if domain != "DM" {
+		seq := 0
+		for i, row := range rows {
+			if i == 0 || row["USUBJID"] != rows[i-1]["USUBJID"] {
+				seq = 0
+			}
+			seq++
+			row[domain+"SEQ"] = fmt.Sprint(seq)
+		}
+	}
+	return rows
+}
+
+// Write writes each domain with rows to dir as <domain>.csv, and define.csv,
+// which lists the variables of those domains with their labels and
+// codelists. It returns the paths written.
+func (e *Exporter) Write(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	var paths []string
+	define := [][]string{{"DOMAIN", "DOMAIN_LABEL", "VARIABLE", "LABEL", "CODELIST"}}
+	for _, d := range Domains {
+		rows := e.Rows(d.Name)
+		if len(rows) == 0 {
+			continue
+		}
+		records := make([][]string, 0, len(rows)+1)
+		header := make([]string, len(d.Columns))
+		for i, c := range d.Columns {
+			header[i] = c.Name
+			define = append(define, []string{d.Name, d.Label, c.Name, c.Label, c.Codelist})
+		}
+		records = append(records, header)
+		for _, row := range rows {
+			record := make([]string, len(d.Columns))
+			for i, c := range d.Columns {
+				record[i] = row[c.Name]
+			}
+			records = append(records, record)
+		}
+		path := filepath.Join(dir, d.Name+".csv")
+		if err := writeCSV(path, records); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	path := filepath.Join(dir, "define.csv")
+	if err := writeCSV(path, define); err != nil {
+		return nil, err
+	}
+	return append(paths, path), nil
+}
+
+func writeCSV(path string, records [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// subject returns the USUBJID of the patient a reference points to.
+func (e *Exporter) subject(ref any) string {
+	id := str(object(ref)["reference"])
+	if id == "" {
+		return ""
+	}
+	return e.study + "-" + id[strings.LastIndex(id, "/")+1:]
+}
+
+func object(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func list(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func str(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64, bool:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// codings returns the codings of a CodeableConcept.
+func codings(concept any) []map[string]any {
+	var result []map[string]any
+	for _, c := range list(object(concept)["coding"]) {
+		result = append(result, object(c))
+	}
+	return result
+}
+
+// code returns the first code of a CodeableConcept in system, or in any
+// system when system is empty.
+func code(concept any, system string) string {
+	for _, c := range codings(concept) {
+		if system == "" || str(c["system"]) == system {
+			return str(c["code"])
+		}
+	}
+	return ""
+}
+
+// text returns the text of a CodeableConcept, or the display of its first
+// coding.
+func text(concept any) string {
+	if t := str(object(concept)["text"]); t != "" {
+		return t
+	}
+	for _, c := range codings(concept) {
+		if d := str(c["display"]); d != "" {
+			return d
+		}
+	}
+	return ""
+}
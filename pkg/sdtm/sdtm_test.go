@@ -0,0 +1,123 @@
+package sdtm
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bundle = `{"resourceType":"Bundle","type":"collection","entry":[
+{"resource":{"resourceType":"Patient","id":"p2","gender":"female","birthDate":"1980-02-01","deceasedDateTime":"2023-05-01",
+ "extension":[
+  {"url":"http://hl7.org/fhir/us/core/StructureDefinition/us-core-race","extension":[
+   {"url":"ombCategory","valueCoding":{"code":"2106-3"}},
+   {"url":"ombCategory","valueCoding":{"code":"2028-9"}}]},
+  {"url":"http://hl7.org/fhir/us/core/StructureDefinition/us-core-ethnicity","extension":[
+   {"url":"ombCategory","valueCoding":{"code":"2186-5"}}]}]}},
+{"resource":{"resourceType":"Patient","id":"p1","gender":"male","birthDate":"1975-07-12",
+ "extension":[{"url":"http://hl7.org/fhir/us/core/StructureDefinition/us-core-race","extension":[
+  {"url":"ombCategory","valueCoding":{"code":"2054-5"}}]}]}}
+]}`
+
+const ndjson = `{"resourceType":"AdverseEvent","subject":{"reference":"Patient/p1"},"date":"2023-03-02","event":{"text":"headache","coding":[{"system":"https://www.meddra.org","code":"10019211","display":"Headache"}]},"severity":{"coding":[{"code":"mild"}]},"seriousness":{"coding":[{"code":"non-serious"}]},"outcome":{"coding":[{"code":"resolved"}]}}
+{"resourceType":"AdverseEvent","subject":{"reference":"Patient/p1"},"date":"2023-01-15","event":{"text":"Rash"},"severity":{"coding":[{"code":"severe"}]},"seriousness":{"coding":[{"code":"serious"}]},"outcome":{"coding":[{"code":"fatal"}]}}
+{"resourceType":"Observation","subject":{"reference":"Patient/p1"},"effectiveDateTime":"2023-01-10","category":[{"coding":[{"system":"http://terminology.hl7.org/CodeSystem/observation-category","code":"laboratory"}]}],"code":{"coding":[{"system":"http://loinc.org","code":"2345-7"}]},"valueQuantity":{"value":182,"unit":"mg/dL"},"referenceRange":[{"low":{"value":70},"high":{"value":99}}],"interpretation":[{"coding":[{"code":"H"}]}]}
+{"resourceType":"Observation","subject":{"reference":"Patient/p1"},"effectiveDateTime":"2023-01-10","category":[{"coding":[{"system":"http://terminology.hl7.org/CodeSystem/observation-category","code":"laboratory"}]}],"code":{"text":"25-Hydroxyvitamin D3","coding":[{"system":"http://loinc.org","code":"1989-3"}]},"valueQuantity":{"value":31,"unit":"ng/mL"}}
+{"resourceType":"Observation","subject":{"reference":"Patient/p1"},"category":[{"coding":[{"system":"http://terminology.hl7.org/CodeSystem/observation-category","code":"vital-signs"}]}],"code":{"text":"Heart rate"}}
+{"resourceType":"Encounter","id":"e1"}
+`
+
+func exporter(t *testing.T) *Exporter {
+	t.Helper()
+	e := New("ABC")
+	for _, input := range []string{bundle, ndjson} {
+		if err := e.Read(strings.NewReader(input)); err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+	}
+	return e
+}
+
+func TestDemographics(t *testing.T) {
+	rows := exporter(t).Rows("DM")
+	want := []map[string]string{
+		{"STUDYID": "ABC", "DOMAIN": "DM", "USUBJID": "ABC-p1", "SUBJID": "p1", "BRTHDTC": "1975-07-12", "SEX": "M", "RACE": "BLACK OR AFRICAN AMERICAN", "DTHDTC": ""},
+		{"STUDYID": "ABC", "DOMAIN": "DM", "USUBJID": "ABC-p2", "SUBJID": "p2", "BRTHDTC": "1980-02-01", "SEX": "F", "RACE": "MULTIPLE", "ETHNIC": "NOT HISPANIC OR LATINO", "DTHDTC": "2023-05-01", "DTHFL": "Y"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("DM rows:\n got %v\nwant %v", rows, want)
+	}
+}
+
+func TestAdverseEvents(t *testing.T) {
+	rows := exporter(t).Rows("AE")
+	if len(rows) != 2 {
+		t.Fatalf("got %d AE rows, want 2", len(rows))
+	}
+	// Sorted by start date and numbered in that order
+	if rows[0]["AETERM"] != "Rash" || rows[0]["AESEQ"] != "1" || rows[0]["AESER"] != "Y" || rows[0]["AESEV"] != "SEVERE" || rows[0]["AEOUT"] != "FATAL" {
+		t.Errorf("AE row 1 = %v", rows[0])
+	}
+	if rows[1]["AEDECOD"] != "HEADACHE" || rows[1]["AESEQ"] != "2" || rows[1]["AESER"] != "N" || rows[1]["AEOUT"] != "RECOVERED/RESOLVED" {
+		t.Errorf("AE row 2 = %v", rows[1])
+	}
+}
+
+func TestLabResults(t *testing.T) {
+	e := exporter(t)
+	rows := e.Rows("LB")
+	if len(rows) != 2 {
+		t.Fatalf("got %d LB rows, want 2", len(rows))
+	}
+	want := map[string]string{
+		"STUDYID": "ABC", "DOMAIN": "LB", "USUBJID": "ABC-p1", "LBSEQ": "1", "LBTESTCD": "GLUC", "LBTEST": "Glucose", "LBLOINC": "2345-7",
+		"LBORRES": "182", "LBORRESU": "mg/dL", "LBORNRLO": "70", "LBORNRHI": "99", "LBNRIND": "HIGH", "LBDTC": "2023-01-10",
+	}
+	if !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("LB row 1:\n got %v\nwant %v", rows[0], want)
+	}
+	if got := rows[1]["LBTESTCD"]; got != "HYDROXYV" {
+		t.Errorf("LBTESTCD of an unknown test = %q, want HYDROXYV", got)
+	}
+	// The vital sign and the Encounter
+	if got := e.Skipped(); got != 2 {
+		t.Errorf("Skipped() = %d, want 2", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	e := New("ABC")
+	if err := e.Read(strings.NewReader(bundle)); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	paths, err := e.Write(dir)
+	if err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	want := []string{filepath.Join(dir, "DM.csv"), filepath.Join(dir, "define.csv")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("Write() = %v, want %v", paths, want)
+	}
+
+	dm, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDM := `STUDYID,DOMAIN,USUBJID,SUBJID,BRTHDTC,SEX,RACE,ETHNIC,DTHDTC,DTHFL
+ABC,DM,ABC-p1,p1,1975-07-12,M,BLACK OR AFRICAN AMERICAN,,,
+ABC,DM,ABC-p2,p2,1980-02-01,F,MULTIPLE,NOT HISPANIC OR LATINO,2023-05-01,Y
+`
+	if string(dm) != wantDM {
+		t.Errorf("DM.csv:\n%s\nwant:\n%s", dm, wantDM)
+	}
+	define, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(define), "DM,Demographics,SEX,Sex,C66731\n") {
+		t.Errorf("define.csv lacks the SEX codelist:\n%s", define)
+	}
+}
@@ -0,0 +1,244 @@
+// Package selftest compiles generated code with the target language toolchains.
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/config"
+	"github.com/konzy/ehrglot/pkg/generator"
+	"github.com/konzy/ehrglot/pkg/generator/golang"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Status is the outcome of checking one language.
+type Status string
+
+const (
+	Passed  Status = "passed"
+	Failed  Status = "failed"
+	Skipped Status = "skipped"
+)
+
+// Result reports the outcome of checking one language.
+type Result struct {
+	Lang   string
+	Status Status
+	Tool   string
+	Output string
+}
+
+// checker type-checks output generated into dir with cfg, returning the tool
+// used.
+type checker func(ctx context.Context, dir string, cfg config.Config) (tool string, output string, err error)
+
+// errToolMissing marks a check skipped because no toolchain is installed.
+var errToolMissing = errors.New("toolchain not found")
+
+var checkers = map[string]checker{
+	"go":         checkGo,
+	"python":     checkPython,
+	"typescript": checkTypeScript,
+	"java":       checkJava,
+	"rust":       checkRust,
+	"csharp":     checkCSharp,
+}
+
+// Run generates code for each language into workDir and compiles it. It
+// stops with ctx.Err() once ctx is canceled, killing any running toolchain.
+func Run(ctx context.Context, schemas []schema.Schema, langs []string, cfg config.Config, workDir string) ([]Result, error) {
+	var results []Result
+
+	for _, lang := range langs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		name, err := generator.Canonical(lang)
+		if err != nil {
+			return nil, err
+		}
+
+		check, ok := checkers[name]
+		if !ok {
+			results = append(results, Result{Lang: name, Status: Skipped, Output: "no compile check available"})
+			continue
+		}
+
+		gen, err := generator.New(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		dir := filepath.Join(workDir, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := gen.Generate(ctx, schemas, dir); err != nil {
+			results = append(results, Result{Lang: name, Status: Failed, Output: err.Error()})
+			continue
+		}
+
+		tool, output, err := check(ctx, dir, cfg)
+		result := Result{Lang: name, Tool: tool, Output: strings.TrimSpace(output), Status: Passed}
+		switch {
+		case err == errToolMissing:
+			result.Status = Skipped
+			result.Output = err.Error()
+		case err != nil:
+			result.Status = Failed
+			if result.Output == "" {
+				result.Output = err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func checkGo(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return "", "", errToolMissing
+	}
+	// Generated packages import each other under the configured module path
+	module := cfg.Language("go").Module
+	if module == "" {
+		module = golang.DefaultModule
+	}
+	gomod := "module " + module + "\n\ngo " + golang.GoVersion + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		return "", "", err
+	}
+	return run(ctx, dir, "go", "build", "./...")
+}
+
+func checkPython(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("mypy"); err == nil {
+		return run(ctx, dir, "mypy", "--ignore-missing-imports", ".")
+	}
+	for _, python := range []string{"python3", "python"} {
+		if _, err := exec.LookPath(python); err == nil {
+			return run(ctx, dir, python, "-m", "compileall", "-q", ".")
+		}
+	}
+	return "", "", errToolMissing
+}
+
+func checkTypeScript(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("tsc"); err != nil {
+		return "", "", errToolMissing
+	}
+	files, err := findFiles(dir, ".ts")
+	if err != nil {
+		return "", "", err
+	}
+	return run(ctx, dir, "tsc", append([]string{"--noEmit", "--strict"}, files...)...)
+}
+
+func checkJava(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("javac"); err != nil {
+		return "", "", errToolMissing
+	}
+	files, err := findFiles(dir, ".java")
+	if err != nil {
+		return "", "", err
+	}
+	classes := filepath.Join(dir, ".classes")
+	return run(ctx, dir, "javac", append([]string{"-d", classes}, files...)...)
+}
+
+func checkRust(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("cargo"); err != nil {
+		return "", "", errToolMissing
+	}
+	// Each namespace is a module directory, which a lib.rs declares into
+	// one crate
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+	var lib strings.Builder
+	for _, e := range entries {
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), "mod.rs")); e.IsDir() && err == nil {
+			fmt.Fprintf(&lib, "pub mod %s;\n", e.Name())
+		}
+	}
+	cargo := `[package]
+name = "ehrglot-selftest"
+version = "0.0.0"
+edition = "2021"
+
+[lib]
+path = "lib.rs"
+
+[dependencies]
+chrono = { version = "0.4", features = ["serde"] }
+serde = { version = "1", features = ["derive"] }
+serde_json = "1"
+`
+	if cfg.DecimalMode("rust") == config.DecimalExact {
+		cargo += `rust_decimal = { version = "1", features = ["serde"] }
+`
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(cargo), 0644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib.rs"), []byte(lib.String()), 0644); err != nil {
+		return "", "", err
+	}
+	return run(ctx, dir, "cargo", "check", "--quiet")
+}
+
+func checkCSharp(ctx context.Context, dir string, cfg config.Config) (string, string, error) {
+	if _, err := exec.LookPath("dotnet"); err != nil {
+		return "", "", errToolMissing
+	}
+	// System.Text.Json ships with the framework, so the project needs no
+	// package references
+	csproj := `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Nullable>enable</Nullable>
+  </PropertyGroup>
+</Project>
+`
+	if err := os.WriteFile(filepath.Join(dir, "Selftest.csproj"), []byte(csproj), 0644); err != nil {
+		return "", "", err
+	}
+	return run(ctx, dir, "dotnet", "build", "-nologo", "-clp:ErrorsOnly")
+}
+
+func run(ctx context.Context, dir, name string, args ...string) (string, string, error) {
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return name, out.String(), err
+}
+
+func findFiles(dir, ext string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ext) {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
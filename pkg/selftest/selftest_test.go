@@ -0,0 +1,75 @@
+package selftest
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/config"
+)
+
+// TestGoldenTrees runs the Rust and C# checkers over the golden trees of
+// their generators.
+func TestGoldenTrees(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiling golden output is slow")
+	}
+	// Resolve crates from the local cache rather than waiting on the
+	// network
+	t.Setenv("CARGO_NET_OFFLINE", "true")
+
+	for _, lang := range []string{"rust", "csharp"} {
+		roots, err := filepath.Glob(filepath.Join("..", "generator", lang, "testdata", "*"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, root := range roots {
+			t.Run(lang+"/"+filepath.Base(root), func(t *testing.T) {
+				var cfg config.Config
+				if filepath.Base(root) == "options" {
+					cfg.Decimal = config.DecimalExact
+				}
+				dir := t.TempDir()
+				copyTree(t, root, dir)
+
+				tool, output, err := checkers[lang](context.Background(), dir, cfg)
+				switch {
+				case err == errToolMissing:
+					t.Skip(err)
+				case err != nil && strings.Contains(output, "no matching package named"):
+					t.Skipf("%s dependencies not cached: %s", tool, strings.TrimSpace(output))
+				case err != nil:
+					t.Errorf("%s failed on %s: %v\n%s", tool, root, err, output)
+				}
+			})
+		}
+	}
+}
+
+func copyTree(t *testing.T, src, dst string) {
+	t.Helper()
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+	if err != nil {
+		t.Fatalf("failed to copy %s: %v", src, err)
+	}
+}
@@ -0,0 +1,413 @@
+// Package sheet moves the field mappings of mapping files to spreadsheets,
+// for informaticists to review and edit, and applies their edits back to the
+// files.
+package sheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats a spreadsheet can be written in.
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Formats lists the supported formats in sorted order.
+var Formats = []string{CSV, XLSX}
+
+// Columns are the columns of a spreadsheet, in the order they are written.
+// mapping is the path of the mapping file and # the position of the field
+// mapping in it, so edits find their way back; rows added in the
+// spreadsheet leave # empty.
+var Columns = []string{"mapping", "#", "source", "target", "transform", "description", "default", "skip_if_null"}
+
+// editable are the keys of a field mapping a spreadsheet edits. Other keys,
+// such as target_context and value_mapping, are kept as they are.
+var editable = []string{"source", "target", "transform", "description", "default", "skip_if_null"}
+
+// Row is a field mapping as a spreadsheet row.
+type Row struct {
+	Mapping string
+	// Index is the 1-based position of the field mapping in its file, or 0
+	// for a field mapping added in the spreadsheet.
+	Index  int
+	Values map[string]string
+	// Line is the row number in the spreadsheet, for errors.
+	Line int
+}
+
+// Rows returns the field mappings of the mapping file at path, whose
+// contents are data.
+func Rows(path string, data []byte) ([]Row, error) {
+	items, err := fieldMappings(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	var rows []Row
+	for i, item := range items.Content {
+		row := Row{Mapping: path, Index: i + 1, Values: make(map[string]string)}
+		for _, key := range editable {
+			row.Values[key] = cellValue(key, lookup(item, key))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Write writes rows as a spreadsheet in format, with a header row.
+func Write(w io.Writer, format string, rows []Row) error {
+	records := [][]string{Columns}
+	for _, row := range rows {
+		record := []string{row.Mapping, ""}
+		if row.Index > 0 {
+			record[1] = strconv.Itoa(row.Index)
+		}
+		for _, key := range editable {
+			record = append(record, row.Values[key])
+		}
+		records = append(records, record)
+	}
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		cw.WriteAll(records)
+		return cw.Error()
+	case XLSX:
+		return writeXLSX(w, records)
+	}
+	return unknownFormat(format)
+}
+
+// Read reads the rows of a spreadsheet in format. Columns may come in any
+// order, and all but mapping and target may be left out.
+func Read(r io.ReaderAt, size int64, format string) ([]Row, error) {
+	var records [][]string
+	var lines []int
+	var err error
+	switch format {
+	case CSV:
+		records, lines, err = readCSV(io.NewSectionReader(r, 0, size))
+	case XLSX:
+		records, lines, err = readXLSX(r, size)
+	default:
+		return nil, unknownFormat(format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+
+	index := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !contains(Columns, name) {
+			return nil, fmt.Errorf("unknown column %q (want %s)", name, strings.Join(Columns, ", "))
+		}
+		index[name] = i
+	}
+	for _, name := range []string{"mapping", "target"} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var rows []Row
+	for n, record := range records[1:] {
+		value := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+		row := Row{Mapping: value("mapping"), Values: make(map[string]string), Line: lines[n+1]}
+		if row.Mapping == "" {
+			return nil, fmt.Errorf("line %d: mapping is required", row.Line)
+		}
+		if i := value("#"); i != "" {
+			if row.Index, err = strconv.Atoi(i); err != nil || row.Index < 1 {
+				return nil, fmt.Errorf("line %d: # %q is not a positive integer", row.Line, i)
+			}
+		}
+		for _, key := range editable {
+			if _, ok := index[key]; ok {
+				row.Values[key] = value(key)
+			}
+		}
+		if skip := row.Values["skip_if_null"]; skip != "" {
+			b, err := strconv.ParseBool(skip)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: skip_if_null %q is not true or false", row.Line, skip)
+			}
+			row.Values["skip_if_null"] = ""
+			if b {
+				row.Values["skip_if_null"] = "true"
+			}
+		}
+		if row.Values["target"] == "" {
+			return nil, fmt.Errorf("line %d: target is required", row.Line)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// readCSV reads the records of a CSV file, with the line each starts on,
+// which differs from its position when descriptions span lines.
+func readCSV(r io.Reader) ([][]string, []int, error) {
+	cr := csv.NewReader(r)
+	var records [][]string
+	var lines []int
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return records, lines, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+}
+
+// Apply returns the mapping file at path, whose contents are data, with its
+// field mappings replaced by rows, in row order, and whether that changes
+// it. Rows keep the keys and comments of the field mapping they number and
+// change only the values that differ from it; field mappings no row numbers
+// are removed. Files are rewritten only when they change, keeping their
+// comments and blank lines.
+func Apply(path string, data []byte, rows []Row) ([]byte, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	items, err := fieldMappingsNode(&doc)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	original := items.Content
+	seen := make(map[int]bool)
+	changed := len(rows) != len(original)
+	var content []*yaml.Node
+	for i, row := range rows {
+		item := &yaml.Node{Kind: yaml.MappingNode}
+		if row.Index > 0 {
+			if row.Index > len(original) {
+				return nil, false, fmt.Errorf("line %d: %s has no field mapping #%d", row.Line, path, row.Index)
+			}
+			if seen[row.Index] {
+				return nil, false, fmt.Errorf("line %d: field mapping #%d of %s appears twice", row.Line, row.Index, path)
+			}
+			seen[row.Index] = true
+			item = original[row.Index-1]
+		}
+		changed = changed || row.Index != i+1
+		for _, key := range editable {
+			value, ok := row.Values[key]
+			if !ok || value == cellValue(key, lookup(item, key)) {
+				continue
+			}
+			if err := set(item, key, value); err != nil {
+				return nil, false, fmt.Errorf("line %d: %s: %w", row.Line, key, err)
+			}
+			changed = true
+		}
+		content = append(content, item)
+	}
+	if !changed {
+		return data, false, nil
+	}
+	items.Content = content
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	out := keepBlankLines(data, b.Bytes())
+	if err := schema.Validate(path, out, true); err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// fieldMappings returns the field_mappings sequence of a mapping file.
+func fieldMappings(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return fieldMappingsNode(&doc)
+}
+
+func fieldMappingsNode(doc *yaml.Node) (*yaml.Node, error) {
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("not a mapping file")
+	}
+	items := lookup(doc.Content[0], "field_mappings")
+	if items == nil || items.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("field_mappings must be a list")
+	}
+	for _, item := range items.Content {
+		if item.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: field mappings must be mappings", item.Line)
+		}
+	}
+	return items, nil
+}
+
+// lookup returns the value of key in the mapping node m, or nil.
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// cellValue returns the value of key as a spreadsheet cell: scalars as
+// written, trimmed as cells are read, collections as flow YAML, and null and
+// false as empty.
+func cellValue(key string, value *yaml.Node) string {
+	switch {
+	case value == nil || value.Tag == "!!null":
+		return ""
+	case key == "skip_if_null":
+		if b, err := strconv.ParseBool(value.Value); err == nil && !b {
+			return ""
+		}
+	case value.Kind != yaml.ScalarNode:
+		flow := *value
+		flow.Style = yaml.FlowStyle
+		data, err := yaml.Marshal(&flow)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+	return strings.TrimSpace(value.Value)
+}
+
+// set sets key of the mapping node m to the cell value, or removes it when
+// value is empty. Defaults are read as YAML, so that numbers and booleans
+// keep their type; skip_if_null is "true", and other values are text.
+func set(m *yaml.Node, key, value string) error {
+	var node *yaml.Node
+	switch {
+	case value == "":
+	case key == "default":
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return err
+		}
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+		if len(doc.Content) > 0 {
+			node = doc.Content[0]
+		}
+	case key == "skip_if_null":
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+	default:
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+		if strings.Contains(value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+	}
+
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != key {
+			continue
+		}
+		if node == nil {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+		} else {
+			m.Content[i+1] = node
+		}
+		return nil
+	}
+	if node != nil {
+		m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, node)
+	}
+	return nil
+}
+
+// keepBlankLines returns encoded with the blank lines of original put back,
+// which the YAML encoder drops: before the lines that followed them there,
+// matching only lines that occur once, and between field mappings when
+// original separates them. None go right after a key opening a block.
+func keepBlankLines(original, encoded []byte) []byte {
+	lines := strings.Split(string(original), "\n")
+	count := make(map[string]int)
+	for _, line := range lines {
+		count[line]++
+	}
+	blank := make(map[string]bool)
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i-1]) == "" && strings.TrimSpace(lines[i]) != "" && count[lines[i]] == 1 {
+			blank[lines[i]] = true
+		}
+	}
+	separated := separatedItems.Match(original)
+
+	out := strings.Split(string(encoded), "\n")
+	before := make([]bool, len(out))
+	for i, line := range out {
+		before[i] = blank[line]
+		if separated && strings.HasPrefix(line, "  - ") {
+			start := i
+			for start > 0 && strings.HasPrefix(out[start-1], "  #") {
+				start--
+			}
+			before[start] = true
+		}
+	}
+	var b strings.Builder
+	for i, line := range out {
+		if i > 0 && before[i] && strings.TrimSpace(out[i-1]) != "" && !strings.HasSuffix(out[i-1], ":") {
+			b.WriteString("\n")
+		}
+		b.WriteString(line)
+		if i < len(out)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+// separatedItems matches a list item, or the comments above it, that follows
+// a blank line.
+var separatedItems = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*#.*\n)*[ \t]*- `)
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, " or "))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
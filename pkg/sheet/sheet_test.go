@@ -0,0 +1,219 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const mappingFile = `# Lab to FHIR Specimen
+source_system: lis
+source_table: SPECIMEN
+target_resource: Specimen
+
+field_mappings:
+  # Specimen number
+  - source: SPEC_ID
+    target: id
+    transform: null
+
+  - source: STATUS_C
+    target: status
+    transform: status_map
+    default: available
+
+  # When the lab got it
+  - source: RECV_DTTM
+    target: receivedTime
+    description: |
+      Received,
+      as "logged"
+    skip_if_null: true
+    target_context:
+      receivedTime.timezone: UTC
+
+value_mappings:
+  status_map:
+    "1": available
+`
+
+const mappingPath = "schemas/lis/specimen_mapping.yaml"
+
+func TestRoundTrip(t *testing.T) {
+	rows, err := Rows(mappingPath, []byte(mappingFile))
+	if err != nil {
+		t.Fatalf("Rows() failed: %v", err)
+	}
+	if len(rows) != 3 || rows[2].Values["description"] != "Received,\nas \"logged\"" || rows[2].Values["skip_if_null"] != "true" || rows[0].Values["transform"] != "" {
+		t.Fatalf("Rows() = %+v", rows)
+	}
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := Write(&b, format, rows); err != nil {
+				t.Fatalf("Write() failed: %v", err)
+			}
+			got, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()), format)
+			if err != nil {
+				t.Fatalf("Read() failed: %v", err)
+			}
+			for i := range got {
+				got[i].Line = 0
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("Read() = %+v, want %+v", got, rows)
+			}
+
+			data, changed, err := Apply(mappingPath, []byte(mappingFile), got)
+			if err != nil {
+				t.Fatalf("Apply() failed: %v", err)
+			}
+			if changed || string(data) != mappingFile {
+				t.Errorf("Apply() changed an unedited file:\n%s", data)
+			}
+		})
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	rows, err := Rows(mappingPath, []byte(mappingFile))
+	if err != nil {
+		t.Fatalf("Rows() failed: %v", err)
+	}
+	// Move receivedTime first and no longer skip nulls, drop status, and
+	// add a collection date.
+	rows[2].Values["skip_if_null"] = ""
+	rows[2].Values["target"] = "receivedTime"
+	rows[0].Values["description"] = "Specimen | accession number"
+	added := Row{Mapping: mappingPath, Values: map[string]string{"source": "COLL_DTTM", "target": "collection.collectedDateTime", "transform": "to_datetime", "default": "42"}}
+	edited := []Row{rows[2], rows[0], added}
+
+	got, changed, err := Apply(mappingPath, []byte(mappingFile), edited)
+	if err != nil {
+		t.Fatalf("Apply() failed: %v", err)
+	}
+	want := `# Lab to FHIR Specimen
+source_system: lis
+source_table: SPECIMEN
+target_resource: Specimen
+
+field_mappings:
+  # When the lab got it
+  - source: RECV_DTTM
+    target: receivedTime
+    description: |
+      Received,
+      as "logged"
+    target_context:
+      receivedTime.timezone: UTC
+
+  # Specimen number
+  - source: SPEC_ID
+    target: id
+    transform: null
+    description: Specimen | accession number
+
+  - source: COLL_DTTM
+    target: collection.collectedDateTime
+    transform: to_datetime
+    default: 42
+
+value_mappings:
+  status_map:
+    "1": available
+`
+	if !changed || string(got) != want {
+		t.Errorf("Apply() = %v\n%s\nwant:\n%s", changed, got, want)
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		rows []Row
+		want string
+	}{
+		{"unknown index", []Row{{Mapping: mappingPath, Index: 4, Line: 2}}, "line 2: schemas/lis/specimen_mapping.yaml has no field mapping #4"},
+		{"repeated index", []Row{{Mapping: mappingPath, Index: 1, Line: 2}, {Mapping: mappingPath, Index: 1, Line: 3}}, "line 3: field mapping #1 of schemas/lis/specimen_mapping.yaml appears twice"},
+		{"bad default", []Row{{Mapping: mappingPath, Index: 1, Values: map[string]string{"default": "[1"}, Line: 2}}, "line 2: default: yaml: line 1: did not find expected ',' or ']'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Apply(mappingPath, []byte(mappingFile), tc.rows)
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("Apply() error = %v, want %s", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		csv  string
+		want string
+	}{
+		{"unknown column", "mapping,source,target,notes\n", `unknown column "notes" (want mapping, #, source, target, transform, description, default, skip_if_null)`},
+		{"missing column", "mapping,source\n", `missing column "target"`},
+		{"missing target", "mapping,source,target\na.yaml,X,\n", "line 2: target is required"},
+		{"line after multi-line cell", "mapping,source,target,description\na.yaml,X,y,\"one\ntwo\"\na.yaml,Z,,\n", "line 4: target is required"},
+		{"bad index", "mapping,#,source,target\na.yaml,0,X,y\n", `line 2: # "0" is not a positive integer`},
+		{"bad skip", "mapping,source,target,skip_if_null\na.yaml,X,y,maybe\n", `line 2: skip_if_null "maybe" is not true or false`},
+		{"unknown format", "", `unknown format "ods" (want csv or xlsx)`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			format := CSV
+			if tc.name == "unknown format" {
+				format = "ods"
+			}
+			_, err := Read(strings.NewReader(tc.csv), int64(len(tc.csv)), format)
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("Read() error = %v, want %s", err, tc.want)
+			}
+		})
+	}
+}
+
+// TestReadSavedXLSX reads a workbook as spreadsheet programs save it:
+// shared and formatted strings, sparse cells, numbers, and booleans.
+func TestReadSavedXLSX(t *testing.T) {
+	var b bytes.Buffer
+	z := zip.NewWriter(&b)
+	for name, data := range map[string]string{
+		"xl/workbook.xml":            `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Review" sheetId="1" r:id="rId3"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId3" Target="/xl/worksheets/review.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>mapping</t></si><si><t>source</t></si><si><t>target</t></si><si><t>skip_if_null</t></si><si><t>#</t></si><si><t>a.yaml</t></si><si><r><t>PAT</t></r><r><rPr><b/></rPr><t>_ID</t></r></si><si><t>id</t></si></sst>`,
+		"xl/worksheets/review.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>4</v></c><c r="C1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c><c r="F1" t="s"><v>3</v></c></row>` +
+			`<row r="4"><c r="A4" t="s"><v>5</v></c><c r="B4"><v>2</v></c><c r="C4" t="s"><v>6</v></c><c r="D4" t="s"><v>7</v></c><c r="F4" t="b"><v>1</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		f, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(data))
+	}
+	z.Close()
+
+	rows, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()), XLSX)
+	if err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	want := []Row{{Mapping: "a.yaml", Index: 2, Values: map[string]string{"source": "PAT_ID", "target": "id", "skip_if_null": "true"}, Line: 4}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Read() = %+v, want %+v", rows, want)
+	}
+}
+
+func TestColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := column(i); got != want {
+			t.Errorf("column(%d) = %s, want %s", i, got, want)
+		}
+		if got := columnIndex(want + "12"); got != i {
+			t.Errorf("columnIndex(%s12) = %d, want %d", want, got, i)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Package stats measures the size and complexity of schema namespaces.
+package stats
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// backboneElement is the type of inline nested elements.
+const backboneElement = "BackboneElement"
+
+// Namespace summarizes the schemas of one namespace.
+type Namespace struct {
+	Name      string `json:"namespace" yaml:"namespace"`
+	Resources int    `json:"resources" yaml:"resources"`
+	// Fields counts every field, nested children included.
+	Fields   int `json:"fields" yaml:"fields"`
+	Optional int `json:"optional" yaml:"optional"`
+	// PII counts fields with a pii_level other than none.
+	PII int `json:"pii" yaml:"pii"`
+	// Depth is the deepest nesting of any resource: 1 for flat resources,
+	// plus one for each level of children or referenced schemas.
+	Depth int `json:"depth" yaml:"depth"`
+	// Unresolved are the field types that are neither primitives nor
+	// schemas, in sorted order.
+	Unresolved []string `json:"unresolved,omitempty" yaml:"unresolved,omitempty"`
+}
+
+// OptionalRatio returns the share of fields that are not required.
+func (n Namespace) OptionalRatio() float64 {
+	if n.Fields == 0 {
+		return 0
+	}
+	return float64(n.Optional) / float64(n.Fields)
+}
+
+// Compute summarizes schemas per namespace, in namespace order. Built-in
+// datatypes the schemas reference, as []T and array<T> lists too, are
+// expanded as the resolver does for generators: they resolve, and count
+// toward nesting depth.
+func Compute(schemas []schema.Schema) []Namespace {
+	r := resolve.Lenient(schemas)
+	byName := make(map[string]*Namespace)
+	unresolved := make(map[string]map[string]bool)
+	var names []string
+	for _, s := range schemas {
+		n, ok := byName[s.Namespace]
+		if !ok {
+			n = &Namespace{Name: s.Namespace}
+			byName[s.Namespace] = n
+			unresolved[s.Namespace] = make(map[string]bool)
+			names = append(names, s.Namespace)
+		}
+		scope := r.Scope(s.Namespace)
+		n.Resources++
+		count(scope, s.Fields, n, unresolved[s.Namespace])
+		if d := depth(scope, s.Fields, map[string]bool{s.Namespace + "." + s.GetName(): true}); d > n.Depth {
+			n.Depth = d
+		}
+	}
+
+	sort.Strings(names)
+	out := make([]Namespace, 0, len(names))
+	for _, name := range names {
+		n := byName[name]
+		for u := range unresolved[name] {
+			n.Unresolved = append(n.Unresolved, u)
+		}
+		sort.Strings(n.Unresolved)
+		out = append(out, *n)
+	}
+	return out
+}
+
+// Total sums namespaces, taking the deepest nesting and every unresolved
+// type qualified by its namespace.
+func Total(namespaces []Namespace) Namespace {
+	total := Namespace{Name: "total"}
+	for _, n := range namespaces {
+		total.Resources += n.Resources
+		total.Fields += n.Fields
+		total.Optional += n.Optional
+		total.PII += n.PII
+		if n.Depth > total.Depth {
+			total.Depth = n.Depth
+		}
+		for _, u := range n.Unresolved {
+			total.Unresolved = append(total.Unresolved, n.Name+": "+u)
+		}
+	}
+	return total
+}
+
+func count(scope resolve.Scope, fields []schema.Field, n *Namespace, unresolved map[string]bool) {
+	for _, f := range fields {
+		n.Fields++
+		if !f.Required {
+			n.Optional++
+		}
+		if level := strings.ToLower(f.PIILevel); level != "" && level != "none" {
+			n.PII++
+		}
+		if len(f.Children) > 0 {
+			count(scope, f.Children, n, unresolved)
+			continue
+		}
+		elem, _ := schema.ElementType(f.Type)
+		if datatypes.IsPrimitive(elem) || elem == backboneElement {
+			continue
+		}
+		if _, ok, err := scope.Resolve(elem); err != nil || !ok {
+			unresolved[elem] = true
+		}
+	}
+}
+
+// depth returns the nesting depth of fields, following children and
+// referenced schemas. Schemas already on the path are not entered again, so
+// cycles count once.
+func depth(scope resolve.Scope, fields []schema.Field, path map[string]bool) int {
+	deepest := 0
+	for _, f := range fields {
+		d := 0
+		elem, _ := schema.ElementType(f.Type)
+		if len(f.Children) > 0 {
+			d = depth(scope, f.Children, path)
+		} else if target, ok := scope.Lookup(elem); ok {
+			key := target.Namespace + "." + target.GetName()
+			if !path[key] {
+				path[key] = true
+				d = depth(scope.Scope(target.Namespace), target.Fields, path)
+				delete(path, key)
+			}
+		}
+		if d > deepest {
+			deepest = d
+		}
+	}
+	if len(fields) == 0 {
+		return 0
+	}
+	return deepest + 1
+}
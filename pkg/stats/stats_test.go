@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func TestCompute(t *testing.T) {
+	got := Compute(fixtures.Schemas())
+	if len(got) != 2 {
+		t.Fatalf("Compute() returned %d namespaces, want 2", len(got))
+	}
+	fx := got[0]
+	fx.Depth = 0
+	want := Namespace{Name: fixtures.Namespace, Resources: 3, Fields: 43, Optional: 38, PII: 2}
+	if !reflect.DeepEqual(fx, want) {
+		t.Errorf("Compute()[0] = %+v, want %+v", fx, want)
+	}
+	if got[1].Name != fixtures.RegistryNamespace || got[1].Resources != 1 {
+		t.Errorf("Compute()[1] = %+v, want one registry resource", got[1])
+	}
+}
+
+func TestDepthAndUnresolved(t *testing.T) {
+	schemas := []schema.Schema{
+		{Name: "Order", Namespace: "vendor", Fields: []schema.Field{
+			{Name: "id", Type: "string", Required: true},
+			{Name: "detail", Type: "BackboneElement", Children: []schema.Field{
+				{Name: "item", Type: "[]Item"},
+				{Name: "status", Type: "StatusCode"},
+			}},
+		}},
+		{Name: "Item", Namespace: "vendor", Fields: []schema.Field{
+			{Name: "order", Type: "Order"},
+			{Name: "price", Type: "Money", PIILevel: "NONE"},
+			{Name: "buyer", Type: "string", PIILevel: "HIGH"},
+		}},
+	}
+	got := Compute(schemas)
+	want := []Namespace{{
+		Name:       "vendor",
+		Resources:  2,
+		Fields:     7,
+		Optional:   6,
+		PII:        1,
+		Depth:      3,
+		Unresolved: []string{"Money", "StatusCode"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compute() = %+v, want %+v", got, want)
+	}
+	if r := got[0].OptionalRatio(); r < 0.857 || r > 0.858 {
+		t.Errorf("OptionalRatio() = %v, want 6/7", r)
+	}
+
+	total := Total(append(got, Namespace{Name: "other", Resources: 1, Depth: 1, Unresolved: []string{"X"}}))
+	if total.Resources != 3 || total.Depth != 3 || !reflect.DeepEqual(total.Unresolved, []string{"vendor: Money", "vendor: StatusCode", "other: X"}) {
+		t.Errorf("Total() = %+v", total)
+	}
+}
+
+func TestBuiltinDatatypes(t *testing.T) {
+	schemas := []schema.Schema{
+		{Resource: "Patient", Namespace: "fhir_r4", Fields: []schema.Field{
+			{Name: "name", Type: "array<HumanName>"},
+			{Name: "identifier", Type: "[]Identifier"},
+			{Name: "managingOrganization", Type: "Reference"},
+			{Name: "age", Type: "Age"},
+		}},
+	}
+	got := Compute(schemas)
+	if len(got) != 1 || len(got[0].Unresolved) != 0 {
+		t.Fatalf("Compute() = %+v, want built-in datatypes resolved", got)
+	}
+	if got[0].Depth != 5 {
+		t.Errorf("Compute() depth = %d, want 5 through Reference, Identifier, CodeableConcept, and Coding", got[0].Depth)
+	}
+}
@@ -0,0 +1,104 @@
+package suggest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Mapping is a field mapping of a draft, with the comment written above it.
+type Mapping struct {
+	schema.FieldMapping
+	Comment string
+}
+
+// Draft writes the proposals as a mapping file from source to target for
+// review, with a comment above each field mapping that gives its confidence.
+func Draft(source, target schema.Schema, proposals []Proposal) ([]byte, error) {
+	var mappings []Mapping
+	for _, p := range proposals {
+		mappings = append(mappings, Mapping{
+			FieldMapping: schema.FieldMapping{Source: p.Column, Target: p.Field.Path},
+			Comment:      fmt.Sprintf("confidence %.2f (name %.2f, type %.2f)", p.Score, p.Name, p.Type),
+		})
+	}
+	header := fmt.Sprintf(`Drafted by ehrglot mapping suggest from %s/%s to %s.
+Review each field mapping; the comment above it rates its confidence from
+0 to 1 by how alike the names are and how well the types fit.`, source.Namespace, source.GetName(), target.GetName())
+	return Write(header, source, target, mappings)
+}
+
+// Write writes mappings as a mapping file from source to target for review.
+// The file starts with header as a comment, followed by the required fields
+// of target and the columns of source that no field mapping uses.
+func Write(header string, source, target schema.Schema, mappings []Mapping) ([]byte, error) {
+	m := schema.SchemaMapping{
+		SourceSystem:   source.Namespace,
+		SourceTable:    source.GetName(),
+		TargetResource: target.GetName(),
+		Description:    fmt.Sprintf("Draft mapping from %s %s to %s.", source.Namespace, source.GetName(), target.GetName()),
+	}
+	used := make(map[string]bool)
+	filled := make(map[string]bool)
+	for _, fm := range mappings {
+		m.FieldMappings = append(m.FieldMappings, fm.FieldMapping)
+		used[fm.Source] = true
+		filled[strings.SplitN(index.ReplaceAllString(fm.Target, ""), ".", 2)[0]] = true
+	}
+
+	var node yaml.Node
+	if err := node.Encode(m); err != nil {
+		return nil, err
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "field_mappings" {
+			continue
+		}
+		for j, item := range node.Content[i+1].Content {
+			item.HeadComment = mappings[j].Comment
+		}
+	}
+
+	var required, unused []string
+	for _, f := range datatypes.Flatten(target).Fields {
+		if f.Required && f.Derived == "" && f.Name != "resourceType" && !filled[f.Name] {
+			required = append(required, f.Name)
+		}
+	}
+	for _, c := range Columns(source) {
+		if !used[c.Name] {
+			unused = append(unused, c.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, line := range strings.Split(header, "\n") {
+		fmt.Fprintf(&buf, "# %s\n", line)
+	}
+	if len(required) > 0 {
+		fmt.Fprintf(&buf, "#\n# Required fields left unmapped: %s\n", strings.Join(required, ", "))
+	}
+	if len(unused) > 0 {
+		fmt.Fprintf(&buf, "#\n# Columns left unmapped: %s\n", strings.Join(unused, ", "))
+	}
+	buf.WriteString("\n")
+
+	var body bytes.Buffer
+	enc := yaml.NewEncoder(&body)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	// Separate the field mappings with blank lines, as mapping files do.
+	draft := strings.ReplaceAll(body.String(), "\n  # ", "\n\n  # ")
+	draft = strings.Replace(draft, "\nfield_mappings:\n\n", "\n\nfield_mappings:\n", 1)
+	buf.WriteString(draft)
+	return buf.Bytes(), nil
+}
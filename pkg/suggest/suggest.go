@@ -0,0 +1,299 @@
+// Package suggest proposes the source columns of target fields by how alike
+// their names are, after mapping healthcare synonyms such as sex to gender,
+// and how well their types fit.
+package suggest
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/datatypes"
+	"github.com/konzy/ehrglot/pkg/naming"
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// maxDepth caps how many datatypes deep target fields are listed, such as
+// identifier[0].type.coding[0].code.
+const maxDepth = 4
+
+// MinScore is the score below which a column is not suggested.
+const MinScore = 0.4
+
+// skipped are the datatypes whose elements mappings do not fill.
+var skipped = map[string]bool{"Extension": true, "Meta": true, "Narrative": true}
+
+// Field is a target field a mapping can fill.
+type Field struct {
+	// Path is the target path, with [0] after lists, such as name[0].family.
+	Path  string
+	Field schema.Field
+}
+
+// Fields returns the fields of target that hold values, in schema order:
+// primitives, and the primitive elements of its inline children and of the
+// datatypes and schemas it refers to.
+func Fields(schemas []schema.Schema, target schema.Schema) []Field {
+	r := resolve.Lenient(datatypes.Expand(schemas))
+	var fields []Field
+	var walk func(scope resolve.Scope, prefix string, fs []schema.Field, depth int, seen map[string]bool)
+	walk = func(scope resolve.Scope, prefix string, fs []schema.Field, depth int, seen map[string]bool) {
+		for _, f := range fs {
+			// The converter writes resourceType itself.
+			if f.Derived != "" || depth == 0 && prefix == "" && f.Name == "resourceType" {
+				continue
+			}
+			elem, list := schema.ElementType(f.Type)
+			path := prefix + f.Name
+			if list {
+				path += "[0]"
+			}
+			if len(f.Children) > 0 {
+				walk(scope, path+".", f.Children, depth, seen)
+				continue
+			}
+			s, ok := scope.Lookup(elem)
+			switch {
+			case !ok:
+				fields = append(fields, Field{Path: path, Field: f})
+			case skipped[s.GetName()] || seen[s.Namespace+"."+s.GetName()] || depth == maxDepth:
+			default:
+				inner := make(map[string]bool, len(seen)+1)
+				for k := range seen {
+					inner[k] = true
+				}
+				inner[s.Namespace+"."+s.GetName()] = true
+				walk(r.Scope(s.Namespace), path+".", s.Fields, depth+1, inner)
+			}
+		}
+	}
+	walk(r.Scope(target.Namespace), "", datatypes.Flatten(target).Fields, 0, map[string]bool{target.Namespace + "." + target.GetName(): true})
+	return fields
+}
+
+// Column is a column of a source schema.
+type Column struct {
+	// Name is the field name, with the names of its parents before it, such
+	// as ADDR.ZIP.
+	Name  string
+	Field schema.Field
+}
+
+// Columns returns the columns of a source schema: its fields, and their
+// children as dotted paths.
+func Columns(source schema.Schema) []Column {
+	var columns []Column
+	var walk func(prefix string, fs []schema.Field)
+	walk = func(prefix string, fs []schema.Field) {
+		for _, f := range fs {
+			if len(f.Children) > 0 {
+				walk(prefix+f.Name+".", f.Children)
+				continue
+			}
+			columns = append(columns, Column{Name: prefix + f.Name, Field: f})
+		}
+	}
+	walk("", source.Fields)
+	return columns
+}
+
+// Candidate is a source column suggested for a target field.
+type Candidate struct {
+	Column string
+	// Name rates how alike the names are and Type how well the types fit;
+	// Score is their product.
+	Name, Type, Score float64
+}
+
+// Rate returns how well column fits the target field f.
+func Rate(f Field, column Column) Candidate {
+	name := Similarity(f.Path, column.Name)
+	typ := Compatibility(f.Field, column.Field)
+	return Candidate{Column: column.Name, Name: name, Type: typ, Score: name * typ}
+}
+
+// Suggest returns up to n columns that fit the target field f, best first.
+func Suggest(f Field, columns []Column, n int) []Candidate {
+	var candidates []Candidate
+	for _, column := range columns {
+		if c := Rate(f, column); c.Score >= MinScore {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// Proposal is a field mapping proposed for review.
+type Proposal struct {
+	Field Field
+	Candidate
+}
+
+// Propose matches the fields of target to the columns of source, each used
+// at most once: best pairs first, down to pairs that score min. The
+// proposals are in target field order.
+func Propose(schemas []schema.Schema, source, target schema.Schema, min float64) []Proposal {
+	fields := Fields(schemas, target)
+	columns := Columns(source)
+	type pair struct {
+		field int
+		Candidate
+	}
+	var pairs []pair
+	for i, f := range fields {
+		for _, column := range columns {
+			if c := Rate(f, column); c.Score >= min && c.Score > 0 {
+				pairs = append(pairs, pair{i, c})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+
+	chosen := make([]*Candidate, len(fields))
+	used := make(map[string]bool)
+	for i := range pairs {
+		p := &pairs[i]
+		if chosen[p.field] == nil && !used[p.Column] {
+			chosen[p.field] = &p.Candidate
+			used[p.Column] = true
+		}
+	}
+	var proposals []Proposal
+	for i, c := range chosen {
+		if c != nil {
+			proposals = append(proposals, Proposal{Field: fields[i], Candidate: *c})
+		}
+	}
+	return proposals
+}
+
+// kind returns the kind of value a field holds: "integer", "decimal",
+// "text", "date", "boolean", or "" for others, such as times and binaries.
+// Lists are of the kind of their elements.
+func kind(f schema.Field) string {
+	f.Type, _ = schema.ElementType(f.Type)
+	if f.Type == "boolean" {
+		return "boolean"
+	}
+	return f.ValueKind()
+}
+
+// Compatibility rates from 0 to 1 how well the values of a source field fit
+// a target field: 1 for the same kind of value, such as a datetime for a
+// date, 0.9 for integers and decimals, 0.8 when either is text or of a kind
+// transforms deal with, such as a time, and 0.3 otherwise, such as a date
+// for a boolean.
+func Compatibility(target, source schema.Field) float64 {
+	t, s := kind(target), kind(source)
+	switch {
+	case t == s:
+		return 1
+	case t == "integer" && s == "decimal", t == "decimal" && s == "integer":
+		return 0.9
+	case t == "" || s == "" || t == "text" || s == "text":
+		return 0.8
+	}
+	return 0.3
+}
+
+// synonyms maps words of source column names to the words FHIR names use
+// for the same thing.
+var synonyms = map[string]string{
+	"sex": "gender", "dob": "birth", "born": "birth",
+	"last": "family", "surname": "family", "lname": "family",
+	"first": "given", "middle": "given", "fname": "given",
+	"zip": "postal", "postcode": "postal", "addr": "address", "add": "address",
+	"tel": "phone", "telephone": "phone", "cell": "phone", "mobile": "phone",
+	"dttm": "date", "dt": "date", "datetime": "date", "time": "date", "tm": "date",
+	"mrn": "identifier", "num": "number", "no": "number", "nbr": "number",
+	"desc": "description", "dx": "diagnosis", "stat": "status", "cd": "code",
+	"lang": "language", "st": "state", "cntry": "country", "ctry": "country",
+}
+
+// noise are words of source column names that carry no meaning for
+// matching, such as the _C of Epic category columns.
+var noise = map[string]bool{"c": true, "yn": true, "val": true}
+
+var index = regexp.MustCompile(`\[\d+\]`)
+
+// Similarity rates from 0 to 1 how alike a target path and a source column
+// are named, by the share of words they have in common after mapping
+// synonyms such as sex to gender. Columns sharing no word with the last
+// field of the path, such as address_line for address[0].use, rate half.
+// Names whose letters are nearly the same, such as abbreviations, rate by
+// those.
+func Similarity(path, column string) float64 {
+	plain := index.ReplaceAllString(path, "")
+	a, last, b := words(plain), words(plain[strings.LastIndex(plain, ".")+1:]), words(column)
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	score := overlap(a, b)
+	if overlap(last, b) == 0 {
+		score /= 2
+	}
+	if d := dice(strings.Join(last, ""), strings.Join(b, "")); d >= 0.7 {
+		score = max(score, d)
+	}
+	return score
+}
+
+// overlap returns the share of words a and b have in common.
+func overlap(a, b []string) float64 {
+	common := 0
+	used := make([]bool, len(b))
+	for _, w := range a {
+		for j, v := range b {
+			if !used[j] && w == v {
+				used[j] = true
+				common++
+				break
+			}
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+func words(name string) []string {
+	var ws []string
+	for _, w := range naming.Words(name) {
+		// Numbered columns such as address_line1 name the same thing.
+		w = strings.TrimRight(strings.ToLower(w), "0123456789")
+		if w == "" || noise[w] {
+			continue
+		}
+		if s, ok := synonyms[w]; ok {
+			w = s
+		}
+		ws = append(ws, w)
+	}
+	return ws
+}
+
+// dice returns the Sørensen–Dice coefficient of the letter pairs of a and
+// b.
+func dice(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+	pairs := make(map[string]int)
+	for i := 0; i+1 < len(a); i++ {
+		pairs[a[i:i+2]]++
+	}
+	common := 0
+	for i := 0; i+1 < len(b); i++ {
+		if pairs[b[i:i+2]] > 0 {
+			pairs[b[i:i+2]]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b)-2)
+}
@@ -0,0 +1,165 @@
+package suggest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// provider is a source table of practitioners.
+var provider = schema.Schema{
+	Name:      "PROVIDER",
+	Namespace: "ehr",
+	Fields: []schema.Field{
+		{Name: "PROV_ID", Type: "string"},
+		{Name: "PROV_LAST_NAME", Type: "string"},
+		{Name: "PROV_FIRST_NAME", Type: "string"},
+		{Name: "SEX_C", Type: "string"},
+		{Name: "NAME_START_DT", Type: "date"},
+		{Name: "NAME_END_YN", Type: "boolean"},
+		{Name: "ADDR", Type: "string", Children: []schema.Field{
+			{Name: "ZIP", Type: "string"},
+		}},
+	},
+}
+
+func practitioner(t *testing.T) schema.Schema {
+	t.Helper()
+	for _, s := range fixtures.Schemas() {
+		if s.GetName() == "Practitioner" {
+			return s
+		}
+	}
+	t.Fatal("fixtures have no Practitioner")
+	return schema.Schema{}
+}
+
+func TestSimilarity(t *testing.T) {
+	for _, tc := range []struct {
+		path, column string
+		want         float64
+	}{
+		{"birthDate", "BIRTH_DATE", 1},
+		{"gender", "SEX_C", 1},
+		{"name[0].family", "PAT_LAST_NAME", 0.8},
+		{"address[0].line[0]", "address_line1", 1},
+		{"address[0].postalCode", "zip_code", 1},
+		// Sharing only address with address[0].use halves the score.
+		{"address[0].use", "address_line1", 0.25},
+		{"identifier[0].type.coding[0].code", "gender_code", 1.0 / 3},
+		{"id", "", 0},
+	} {
+		if got := Similarity(tc.path, tc.column); got != tc.want {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", tc.path, tc.column, got, tc.want)
+		}
+	}
+}
+
+func TestCompatibility(t *testing.T) {
+	for _, tc := range []struct {
+		target, source string
+		want           float64
+	}{
+		{"date", "datetime", 1},
+		{"[]code", "string", 1},
+		{"positiveInt", "decimal", 0.9},
+		{"date", "string", 0.8},
+		{"time", "integer", 0.8},
+		{"boolean", "date", 0.3},
+	} {
+		got := Compatibility(schema.Field{Type: tc.target}, schema.Field{Type: tc.source})
+		if got != tc.want {
+			t.Errorf("Compatibility(%s, %s) = %v, want %v", tc.target, tc.source, got, tc.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	f := Field{Path: "name[0].given[0]", Field: schema.Field{Name: "given", Type: "[]string"}}
+	got := Suggest(f, Columns(provider), 3)
+	want := []Candidate{{Column: "PROV_FIRST_NAME", Name: 0.8, Type: 1, Score: 0.8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Suggest() = %v, want %v", got, want)
+	}
+}
+
+func TestPropose(t *testing.T) {
+	var got []string
+	for _, p := range Propose(fixtures.Schemas(), provider, practitioner(t), 0.5) {
+		got = append(got, fmt.Sprintf("%s=%s %.2f", p.Field.Path, p.Column, p.Score))
+	}
+	// NAME_END_YN names name[0].period.end alike, but a boolean does not
+	// fit a datetime.
+	want := []string{"id=PROV_ID 0.67", "name[0].family=PROV_LAST_NAME 0.80", "name[0].given[0]=PROV_FIRST_NAME 0.80", "name[0].period.start=NAME_START_DT 0.67"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Propose() = %q, want %q", got, want)
+	}
+}
+
+func TestFields(t *testing.T) {
+	var paths []string
+	for _, f := range Fields(fixtures.Schemas(), practitioner(t)) {
+		paths = append(paths, f.Path)
+	}
+	got := strings.Join(paths, " ")
+	for _, want := range []string{"id", "name[0].family", "name[0].given[0]", "name[0].period.start"} {
+		if !strings.Contains(" "+got+" ", " "+want+" ") {
+			t.Errorf("Fields() lacks %s: %s", want, got)
+		}
+	}
+	// The supervisor is a Practitioner too; its fields would repeat forever.
+	if strings.Contains(got, "supervisor") {
+		t.Errorf("Fields() walked into the Practitioner cycle: %s", got)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	var got []string
+	for _, c := range Columns(provider) {
+		got = append(got, c.Name)
+	}
+	want := []string{"PROV_ID", "PROV_LAST_NAME", "PROV_FIRST_NAME", "SEX_C", "NAME_START_DT", "NAME_END_YN", "ADDR.ZIP"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Columns() = %v, want %v", got, want)
+	}
+}
+
+func TestDraft(t *testing.T) {
+	target := practitioner(t)
+	data, err := Draft(provider, target, Propose(fixtures.Schemas(), provider, target, 0.7))
+	if err != nil {
+		t.Fatalf("Draft() failed: %v", err)
+	}
+	want := `# Drafted by ehrglot mapping suggest from ehr/PROVIDER to Practitioner.
+# Review each field mapping; the comment above it rates its confidence from
+# 0 to 1 by how alike the names are and how well the types fit.
+#
+# Required fields left unmapped: id
+#
+# Columns left unmapped: PROV_ID, SEX_C, NAME_START_DT, NAME_END_YN, ADDR.ZIP
+
+source_system: ehr
+source_table: PROVIDER
+target_resource: Practitioner
+description: Draft mapping from ehr PROVIDER to Practitioner.
+
+field_mappings:
+  # confidence 0.80 (name 0.80, type 1.00)
+  - source: PROV_LAST_NAME
+    target: name[0].family
+
+  # confidence 0.80 (name 0.80, type 1.00)
+  - source: PROV_FIRST_NAME
+    target: name[0].given[0]
+`
+	if string(data) != want {
+		t.Errorf("Draft() =\n%s\nwant:\n%s", data, want)
+	}
+	if err := schema.Validate("practitioner_mapping.yaml", data, true); err != nil {
+		t.Errorf("Draft() is not a valid mapping: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package templates parses generator templates once and reuses them for every
+// file rendered from them.
+package templates
+
+import (
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// Cache holds parsed templates keyed by their text. The zero value is ready
+// to use, and a Cache is safe for concurrent use.
+type Cache struct {
+	mu     sync.Mutex
+	parsed map[string]*template.Template
+}
+
+// Parse returns text parsed as a template with funcs. Texts parsed before are
+// cloned instead of parsed again, with funcs bound to the clone, so functions
+// that close over the file being rendered, such as its namespace scope or
+// header, see that file's values.
+func (c *Cache) Parse(text string, funcs template.FuncMap) (*template.Template, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tmpl, ok := c.parsed[text]
+	if !ok {
+		var err error
+		tmpl, err = template.New("").Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+		if c.parsed == nil {
+			c.parsed = make(map[string]*template.Template)
+		}
+		c.parsed[text] = tmpl
+	}
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return clone.Funcs(funcs), nil
+}
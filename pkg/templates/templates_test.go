@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+)
+
+func render(t *testing.T, c *Cache, text, name string) string {
+	t.Helper()
+	tmpl, err := c.Parse(text, template.FuncMap{"name": func() string { return name }})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	return b.String()
+}
+
+func TestParseRebindsFuncs(t *testing.T) {
+	var c Cache
+	const text = "hello {{name}}"
+	if got := render(t, &c, text, "Patient"); got != "hello Patient" {
+		t.Errorf("first render = %q", got)
+	}
+	if got := render(t, &c, text, "Encounter"); got != "hello Encounter" {
+		t.Errorf("cached render = %q, want the second call's function", got)
+	}
+	if len(c.parsed) != 1 {
+		t.Errorf("cache holds %d templates, want 1", len(c.parsed))
+	}
+}
+
+func TestParseError(t *testing.T) {
+	var c Cache
+	if _, err := c.Parse("{{if}}", nil); err == nil || !strings.Contains(err.Error(), "failed to parse template") {
+		t.Errorf("Parse() error = %v", err)
+	}
+}
+
+func TestParseConcurrent(t *testing.T) {
+	var c Cache
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if got := render(t, &c, "{{name}}", name); got != name {
+					t.Errorf("render = %q, want %q", got, name)
+				}
+			}
+		}(name)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,32 @@
+package validate
+
+// requiredIf reports the fields of the target that are not set although
+// their required_if condition holds. value returns the text of a field of
+// the record, as recordValue and rowValue do.
+func (v *Validator) requiredIf(value func(field string) (string, bool)) []Issue {
+	var issues []Issue
+	for _, c := range v.conditional {
+		if _, set := value(c.Field.Name); set {
+			continue
+		}
+		if c.Condition.Holds(value(c.Condition.Field)) {
+			issues = append(issues, Issue{Path: c.Field.Name, Message: "is required when " + c.Condition.String()})
+		}
+	}
+	return issues
+}
+
+// enabledIf reports the fields of the target that are set although their
+// enabled_if condition does not hold, as requiredIf reports missing ones.
+func (v *Validator) enabledIf(value func(field string) (string, bool)) []Issue {
+	var issues []Issue
+	for _, c := range v.enabled {
+		if _, set := value(c.Field.Name); !set {
+			continue
+		}
+		if !c.Condition.Holds(value(c.Condition.Field)) {
+			issues = append(issues, Issue{Path: c.Field.Name, Message: "must be left out unless " + c.Condition.String()})
+		}
+	}
+	return issues
+}
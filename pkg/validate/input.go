@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Input formats.
+const (
+	NDJSON = "ndjson"
+	JSON   = "json"
+	CSV    = "csv"
+)
+
+// maxLine is the longest NDJSON line accepted; bulk exports put whole
+// resources, attachments included, on one line.
+const maxLine = 64 << 20
+
+// Format returns the input format of a file from its extension.
+func Format(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return NDJSON, nil
+	case ".json":
+		return JSON, nil
+	case ".csv":
+		return CSV, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s; pass --format ndjson, json, or csv", path)
+}
+
+// Result is the outcome of validating one record.
+type Result struct {
+	// Location names the record: "line 3" in NDJSON, "record 3" in JSON, and
+	// "row 3" in CSV, counting the header as row 1.
+	Location string
+	Issues   []Issue
+}
+
+// Stream validates each record of r in the given format, calling fn with the
+// result of every record as it is read. Records that are not valid JSON are
+// reported as issues rather than stopping the stream, as are records that
+// repeat a unique key of an earlier record.
+func (v *Validator) Stream(r io.Reader, format string, fn func(Result) error) error {
+	keys := v.uniqueKeys()
+	switch format {
+	case NDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+		for line := 1; scanner.Scan(); line++ {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			if err := fn(v.decoded(fmt.Sprintf("line %d", line), text, keys)); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	case JSON:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		records, err := records(data)
+		if err != nil {
+			return err
+		}
+		for i, record := range records {
+			if err := fn(v.decoded(fmt.Sprintf("record %d", i+1), record, keys)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case CSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		header, err := reader.Read()
+		if err != nil {
+			return fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		for rowNum := 2; ; rowNum++ {
+			cells, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			location := fmt.Sprintf("row %d", rowNum)
+			if err != nil {
+				if err := fn(Result{Location: location, Issues: []Issue{{Message: err.Error()}}}); err != nil {
+					return err
+				}
+				continue
+			}
+			row := make(map[string]string, len(header))
+			for i, column := range header {
+				if i < len(cells) {
+					row[strings.TrimSpace(column)] = strings.TrimSpace(cells[i])
+				}
+			}
+			issues := append(v.Row(row), keys.check(location, rowValue(row))...)
+			if err := fn(Result{Location: location, Issues: issues}); err != nil {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("unknown format %q (want ndjson, json, or csv)", format)
+}
+
+func (v *Validator) decoded(location string, data []byte, keys *uniqueKeys) Result {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var record map[string]any
+	if err := dec.Decode(&record); err != nil {
+		return Result{Location: location, Issues: []Issue{{Message: "invalid JSON: " + err.Error()}}}
+	}
+	return Result{Location: location, Issues: append(v.Record(record), keys.check(location, recordValue(record))...)}
+}
+
+// records splits a JSON document into records: the elements of an array,
+// the entry resources of a FHIR Bundle, or the document itself.
+func records(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var list []json.RawMessage
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return list, nil
+	}
+
+	var bundle struct {
+		ResourceType string `json:"resourceType"`
+		Entry        []struct {
+			Resource json.RawMessage `json:"resource"`
+		} `json:"entry"`
+	}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if bundle.ResourceType != "Bundle" {
+		return []json.RawMessage{data}, nil
+	}
+	list := make([]json.RawMessage, 0, len(bundle.Entry))
+	for _, e := range bundle.Entry {
+		list = append(list, e.Resource)
+	}
+	return list, nil
+}
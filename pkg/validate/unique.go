@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// uniqueKeys remembers the unique key values of the records of one stream,
+// so that records repeating them can be reported.
+type uniqueKeys struct {
+	keys []schema.Index
+	// seen maps the values of each key to the first record that had them.
+	seen []map[string]string
+}
+
+func (v *Validator) uniqueKeys() *uniqueKeys {
+	u := &uniqueKeys{keys: v.target.Unique, seen: make([]map[string]string, len(v.target.Unique))}
+	for i := range u.seen {
+		u.seen[i] = make(map[string]string)
+	}
+	return u
+}
+
+// check reports the unique keys whose values an earlier record already had.
+// value returns the text of a field of the record; a key with a missing field
+// is not checked, as in SQL.
+func (u *uniqueKeys) check(location string, value func(field string) (string, bool)) []Issue {
+	var issues []Issue
+	for i, key := range u.keys {
+		values := make([]string, len(key.Fields))
+		complete := true
+		for j, field := range key.Fields {
+			if values[j], complete = value(field); !complete {
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+		k := strings.Join(values, "\x00")
+		if first, ok := u.seen[i][k]; ok {
+			issues = append(issues, Issue{Path: strings.Join(key.Fields, ", "), Message: "duplicates " + first})
+			continue
+		}
+		u.seen[i][k] = location
+	}
+	return issues
+}
+
+// recordValue returns the text of a field of a decoded JSON record: strings
+// as they are, other values as JSON.
+func recordValue(record map[string]any) func(string) (string, bool) {
+	return func(field string) (string, bool) {
+		switch value := record[field].(type) {
+		case nil:
+			return "", false
+		case string:
+			return value, true
+		default:
+			data, err := json.Marshal(value)
+			return string(data), err == nil
+		}
+	}
+}
+
+// rowValue returns the text of a non-empty column of a CSV row.
+func rowValue(row map[string]string) func(string) (string, bool) {
+	return func(field string) (string, bool) {
+		text := row[field]
+		return text, text != ""
+	}
+}
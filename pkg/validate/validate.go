@@ -0,0 +1,415 @@
+// Package validate checks data records, such as FHIR bulk export resources,
+// against schemas.
+package validate
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konzy/ehrglot/pkg/resolve"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// Value patterns of FHIR primitive types.
+var (
+	codePattern     = regexp.MustCompile(`^[^\s]+( [^\s]+)*$`)
+	idPattern       = regexp.MustCompile(`^[A-Za-z0-9\-.]{1,64}$`)
+	datePattern     = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01]))?)?$`)
+	dateTimePattern = regexp.MustCompile(`^\d{4}(-(0[1-9]|1[0-2])(-(0[1-9]|[12]\d|3[01])(T([01]\d|2[0-3]):[0-5]\d:([0-5]\d|60)(\.\d+)?(Z|[+-]((0\d|1[0-3]):[0-5]\d|14:00)))?)?)?$`)
+	timePattern     = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d:([0-5]\d|60)(\.\d+)?$`)
+)
+
+// Issue is a problem with one value of a record.
+type Issue struct {
+	// Path locates the value, such as name[0].family.
+	Path    string
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Validator checks records against one schema.
+type Validator struct {
+	target      schema.Schema
+	resolver    *resolve.Resolver
+	strict      bool
+	conditional []schema.ConditionalField
+	enabled     []schema.ConditionalField
+}
+
+// New creates a Validator for records of target, resolving the types of its
+// fields among schemas. Strict validators also report properties the schema
+// does not define.
+func New(schemas []schema.Schema, target schema.Schema, strict bool) (*Validator, error) {
+	r, err := resolve.New(schemas)
+	if err != nil {
+		return nil, err
+	}
+	conditional, err := target.ConditionalFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", target.GetName(), err)
+	}
+	enabled, err := target.EnabledFields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", target.GetName(), err)
+	}
+	return &Validator{target: target, resolver: r, strict: strict, conditional: conditional, enabled: enabled}, nil
+}
+
+// Find returns the schema named name, or namespace/Name, among schemas.
+func Find(schemas []schema.Schema, name string) (schema.Schema, error) {
+	var found []schema.Schema
+	for _, s := range schemas {
+		if s.GetName() == name || s.Namespace+"/"+s.GetName() == name {
+			found = append(found, s)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return schema.Schema{}, fmt.Errorf("no schema named %s", name)
+	case 1:
+		return found[0], nil
+	}
+	var names []string
+	for _, s := range found {
+		names = append(names, s.Namespace+"/"+s.GetName())
+	}
+	return schema.Schema{}, fmt.Errorf("%s is ambiguous; use one of %s", name, strings.Join(names, ", "))
+}
+
+// Record validates a decoded JSON record. Numbers must be decoded as
+// json.Number so that integers can be told apart from decimals. A required
+// field with a default may be left out, since loading fills it in.
+func (v *Validator) Record(record map[string]any) []Issue {
+	var issues []Issue
+	if rt, ok := record["resourceType"].(string); ok && rt != v.target.GetName() {
+		issues = append(issues, Issue{Path: "resourceType", Message: fmt.Sprintf("is %q, want %q", rt, v.target.GetName())})
+	}
+	c := checker{Validator: v, issues: &issues}
+	c.object(v.resolver.Scope(v.target.Namespace), v.target.Fields, record, "", map[string]bool{"resourceType": true})
+	issues = append(issues, v.requiredIf(recordValue(record))...)
+	return append(issues, v.enabledIf(recordValue(record))...)
+}
+
+// Row validates a CSV row, whose values are all text, keyed by column name.
+// Only columns named after top-level fields of primitive types are checked
+// for their values; required fields without a default, and fields whose
+// required_if condition holds, must have a non-empty column, and fields whose
+// enabled_if condition does not hold an empty one.
+func (v *Validator) Row(row map[string]string) []Issue {
+	var issues []Issue
+	for _, f := range v.target.Fields {
+		text, ok := row[f.Name]
+		if !ok || text == "" {
+			if f.Required && f.Default == "" && !hasPrefixedColumn(row, f.Name) {
+				issues = append(issues, Issue{Path: f.Name, Message: "is required"})
+			}
+			continue
+		}
+		if msg := checkText(f, text); msg != "" {
+			issues = append(issues, Issue{Path: f.Name, Message: msg})
+		}
+	}
+	value := func(field string) (string, bool) {
+		if text := row[field]; text != "" {
+			return text, true
+		}
+		return "", hasPrefixedColumn(row, field)
+	}
+	issues = append(issues, v.requiredIf(value)...)
+	issues = append(issues, v.enabledIf(value)...)
+	if v.strict {
+		known := make(map[string]bool)
+		for _, f := range v.target.Fields {
+			known[f.Name] = true
+		}
+		for column := range row {
+			field, _, _ := strings.Cut(column, ".")
+			if !known[field] && column != "resourceType" {
+				issues = append(issues, Issue{Path: column, Message: "is not defined by the schema"})
+			}
+		}
+		sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	}
+	return issues
+}
+
+// hasPrefixedColumn reports whether a row has a non-empty column for a part
+// of field, such as name.family for name.
+func hasPrefixedColumn(row map[string]string, field string) bool {
+	for column, value := range row {
+		if strings.HasPrefix(column, field+".") && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+type checker struct {
+	*Validator
+	issues *[]Issue
+}
+
+func (c checker) report(path, format string, args ...any) {
+	*c.issues = append(*c.issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c checker) object(scope resolve.Scope, fields []schema.Field, obj map[string]any, path string, ignore map[string]bool) {
+	known := make(map[string]bool)
+	for _, f := range fields {
+		known[f.Name] = true
+		value, ok := obj[f.Name]
+		if !ok || value == nil {
+			if f.Required && f.Default == "" {
+				c.report(join(path, f.Name), "is required")
+			}
+			continue
+		}
+		c.field(scope, f, value, join(path, f.Name))
+	}
+	if c.strict {
+		var extra []string
+		for key := range obj {
+			if !known[key] && !ignore[key] {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(extra)
+		for _, key := range extra {
+			c.report(join(path, key), "is not defined by the schema")
+		}
+	}
+}
+
+func (c checker) field(scope resolve.Scope, f schema.Field, value any, path string) {
+	elem, list := schema.ElementType(f.Type)
+	if !list {
+		c.value(scope, f, elem, value, path)
+		return
+	}
+	items, ok := value.([]any)
+	if !ok {
+		c.report(path, "expected a list, got %s", kind(value))
+		return
+	}
+	for i, item := range items {
+		c.value(scope, f, elem, item, fmt.Sprintf("%s[%d]", path, i))
+	}
+}
+
+func (c checker) value(scope resolve.Scope, f schema.Field, fieldType string, value any, path string) {
+	if len(f.Children) > 0 {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "expected an object, got %s", kind(value))
+			return
+		}
+		c.object(scope, f.Children, obj, path, nil)
+		return
+	}
+	if target, ok := scope.Lookup(fieldType); ok {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "expected %s object, got %s", target.GetName(), kind(value))
+			return
+		}
+		c.object(c.resolver.Scope(target.Namespace), target.Fields, obj, path, nil)
+		return
+	}
+	if msg := checkPrimitive(f, fieldType, value); msg != "" {
+		c.report(path, "%s", msg)
+	}
+}
+
+// checkPrimitive checks a JSON value against a primitive type. Types that are
+// neither primitives nor schemas accept any value.
+func checkPrimitive(f schema.Field, fieldType string, value any) string {
+	switch fieldType {
+	case "integer", "positiveInt", "unsignedInt":
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Sprintf("expected an integer, got %s", kind(value))
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil {
+			return fmt.Sprintf("%s is not an integer", n)
+		}
+		return checkRange(fieldType, i)
+	case "decimal":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Sprintf("expected a number, got %s", kind(value))
+		}
+		return ""
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected a boolean, got %s", kind(value))
+		}
+		return ""
+	}
+	if !isTextual(fieldType) {
+		return ""
+	}
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Sprintf("expected a string, got %s", kind(value))
+	}
+	return checkString(f, fieldType, s)
+}
+
+// Text checks a value written as text, such as a schema example, against
+// the type of f, or its element type when f is a list. It returns why the
+// value does not fit, or "".
+func Text(f schema.Field, text string) string {
+	if elem, list := schema.ElementType(f.Type); list {
+		f.Type = elem
+	}
+	return checkText(f, text)
+}
+
+// checkText checks a CSV cell against a field's type.
+func checkText(f schema.Field, text string) string {
+	fieldType, list := schema.ElementType(f.Type)
+	if list || len(f.Children) > 0 {
+		return ""
+	}
+	switch fieldType {
+	case "integer", "positiveInt", "unsignedInt":
+		i, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not an integer", text)
+		}
+		return checkRange(fieldType, i)
+	case "decimal":
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return fmt.Sprintf("%q is not a number", text)
+		}
+		return ""
+	case "boolean":
+		if text != "true" && text != "false" {
+			return fmt.Sprintf("%q is not true or false", text)
+		}
+		return ""
+	}
+	if !isTextual(fieldType) {
+		return ""
+	}
+	return checkString(f, fieldType, text)
+}
+
+func checkRange(fieldType string, i int64) string {
+	switch {
+	case fieldType == "positiveInt" && i < 1:
+		return fmt.Sprintf("%d is not positive", i)
+	case fieldType == "unsignedInt" && i < 0:
+		return fmt.Sprintf("%d is negative", i)
+	case i > 2147483647 || i < -2147483648:
+		return fmt.Sprintf("%d does not fit a 32-bit integer", i)
+	}
+	return ""
+}
+
+// Pattern returns the pattern that text values of a primitive type must
+// match, or nil for types without one. Data-quality suites check the same
+// patterns in the warehouse.
+func Pattern(fieldType string) *regexp.Regexp {
+	switch fieldType {
+	case "code":
+		return codePattern
+	case "id":
+		return idPattern
+	case "date":
+		return datePattern
+	case "datetime", "dateTime":
+		return dateTimePattern
+	case "time":
+		return timePattern
+	}
+	return nil
+}
+
+func isTextual(fieldType string) bool {
+	switch fieldType {
+	case "string", "code", "id", "uri", "url", "canonical", "oid", "uuid", "markdown",
+		"date", "datetime", "dateTime", "instant", "time", "base64Binary":
+		return true
+	}
+	return false
+}
+
+func checkString(f schema.Field, fieldType, s string) string {
+	valid := true
+	switch fieldType {
+	case "code":
+		valid = codePattern.MatchString(s)
+	case "id":
+		valid = idPattern.MatchString(s)
+	case "date":
+		valid = datePattern.MatchString(s) && validCalendarDate(s)
+	case "datetime", "dateTime":
+		valid = dateTimePattern.MatchString(s) && validCalendarDate(s)
+	case "instant":
+		_, err := time.Parse(time.RFC3339Nano, s)
+		valid = err == nil
+	case "time":
+		valid = timePattern.MatchString(s)
+	case "base64Binary":
+		_, err := base64.StdEncoding.DecodeString(s)
+		valid = err == nil
+	}
+	if !valid {
+		return fmt.Sprintf("%q is not a valid %s", s, fieldType)
+	}
+	if len(f.Enum) > 0 {
+		for _, allowed := range f.Enum {
+			if s == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %s", s, strings.Join(f.Enum, ", "))
+	}
+	return ""
+}
+
+// validCalendarDate rejects days past the end of their month, such as
+// 2023-02-30, in values that pass the date patterns.
+func validCalendarDate(s string) bool {
+	if len(s) < len("2006-01-02") {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", s[:len("2006-01-02")])
+	return err == nil
+}
+
+func kind(value any) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "an object"
+	}
+	return "null"
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
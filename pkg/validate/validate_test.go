@@ -0,0 +1,203 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+func validator(t *testing.T, name string, strict bool) *Validator {
+	t.Helper()
+	schemas := fixtures.Schemas()
+	target, err := Find(schemas, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := New(schemas, target, strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func stream(t *testing.T, v *Validator, format, input string) map[string][]string {
+	t.Helper()
+	got := make(map[string][]string)
+	err := v.Stream(strings.NewReader(input), format, func(r Result) error {
+		got[r.Location] = nil
+		for _, issue := range r.Issues {
+			got[r.Location] = append(got[r.Location], issue.String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() failed: %v", err)
+	}
+	return got
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	v := validator(t, "Specimen", false)
+	input := `{"resourceType":"Specimen","id":"s1","status":"available","receivedTime":"2023-04-01T10:00:00Z","collectedDate":"2023-04-01","containerCount":2,"note":["ok"]}
+
+{"resourceType":"Patient","status":"lost","collectedDate":"2023-02-30","containerCount":1.5,"fasting":"yes"}
+{"id":"s3","status":"available","note":"one","subject":{"reference":7},"collection":{"method":"needle  stick"}}
+{not json
+`
+	want := map[string][]string{
+		"line 1": nil,
+		"line 3": {
+			`resourceType: is "Patient", want "Specimen"`,
+			"id: is required",
+			`status: "lost" is not one of available, unavailable, entered-in-error`,
+			`collectedDate: "2023-02-30" is not a valid date`,
+			"containerCount: 1.5 is not an integer",
+			"fasting: expected a boolean, got a string",
+			"note: is required when status != available",
+		},
+		"line 4": {
+			"note: expected a list, got a string",
+			"subject.reference: expected a string, got a number",
+			`collection.method: "needle  stick" is not a valid code`,
+			"receivedTime: is required when status == available",
+		},
+		"line 5": {"invalid JSON: invalid character 'n' looking for beginning of object key string"},
+	}
+	if got := stream(t, v, NDJSON, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+}
+
+func TestStreamEnabledIf(t *testing.T) {
+	target := schema.Schema{Name: "intake", Namespace: "forms", Fields: []schema.Field{
+		{Name: "smoker", Type: "boolean"},
+		{Name: "packs", Type: "integer", EnabledIf: "smoker == true"},
+	}}
+	v, err := New([]schema.Schema{target}, target, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `{"smoker":true,"packs":2}
+{"smoker":false,"packs":2}
+{"packs":2}
+{"smoker":false}
+`
+	want := map[string][]string{
+		"line 1": nil,
+		"line 2": {"packs: must be left out unless smoker == true"},
+		"line 3": {"packs: must be left out unless smoker == true"},
+		"line 4": nil,
+	}
+	if got := stream(t, v, NDJSON, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+}
+
+func TestStreamStrict(t *testing.T) {
+	v := validator(t, "fixtures/Specimen", true)
+	input := `{"resourceType":"Specimen","id":"s1","status":"available","receivedTime":"2023-04-01T10:00:00Z","color":"red","collection":{"site":"arm"}}`
+	want := map[string][]string{
+		"line 1": {"collection.site: is not defined by the schema", "color: is not defined by the schema"},
+	}
+	if got := stream(t, v, NDJSON, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+}
+
+func TestStreamJSONBundle(t *testing.T) {
+	v := validator(t, "Specimen", false)
+	input := `{"resourceType":"Bundle","type":"collection","entry":[
+		{"resource":{"resourceType":"Specimen","id":"s1","status":"available","receivedTime":"2023-04-01T10:00:00Z"}},
+		{"resource":{"resourceType":"Specimen","id":"bad id!","status":"unavailable","visit":{"type":[{"text":"x"}]}}}
+	]}`
+	want := map[string][]string{
+		"record 1": nil,
+		"record 2": {`id: "bad id!" is not a valid id`, "visit.class: is required", "note: is required when status != available"},
+	}
+	if got := stream(t, v, JSON, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+}
+
+func TestStreamCSV(t *testing.T) {
+	v := validator(t, "lab_result", true)
+	input := `result_id,mrn,value_numeric,resulted_at,performer_name.family,extra
+r1,123,4.5,2023-04-01T10:00:00Z,Smith,
+r2,,high,yesterday,,x
+`
+	want := map[string][]string{
+		"row 2": {"extra: is not defined by the schema"},
+		"row 3": {
+			"extra: is not defined by the schema",
+			"mrn: is required",
+			`resulted_at: "yesterday" is not a valid instant`,
+			`value_numeric: "high" is not a number`,
+		},
+	}
+	if got := stream(t, v, CSV, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+}
+
+func TestStreamDefaultedRequired(t *testing.T) {
+	target := schema.Schema{Name: "Patient", Namespace: "fhir_r4", Fields: []schema.Field{
+		{Name: "resourceType", Type: "string", Required: true, Default: "Patient"},
+		{Name: "id", Type: "id", Required: true},
+		{Name: "active", Type: "boolean", Required: true, Default: "true"},
+	}}
+	v, err := New([]schema.Schema{target}, target, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `id,active
+p1,
+,false
+`
+	want := map[string][]string{
+		"row 2": nil,
+		"row 3": {"id: is required"},
+	}
+	if got := stream(t, v, CSV, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+	if got := stream(t, v, NDJSON, `{"id":"p1"}`); got["line 1"] != nil {
+		t.Errorf("Stream() = %#v, want defaulted fields to be optional", got)
+	}
+}
+
+func TestStreamUnique(t *testing.T) {
+	v := validator(t, "lab_result", false)
+	input := `{"result_id":"r1","mrn":"123"}
+{"result_id":"r2","mrn":"123"}
+{"mrn":"456"}
+{"result_id":"r1","mrn":"456"}
+`
+	want := map[string][]string{
+		"line 1": nil,
+		"line 2": nil,
+		"line 3": {"result_id: is required"},
+		"line 4": {"result_id: duplicates line 1"},
+	}
+	if got := stream(t, v, NDJSON, input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stream() = %#v, want %#v", got, want)
+	}
+
+	csv := "result_id,mrn\nr1,123\nr1,456\n"
+	if got := stream(t, v, CSV, csv); !reflect.DeepEqual(got["row 3"], []string{"result_id: duplicates row 2"}) {
+		t.Errorf("Stream(csv) = %#v, want row 3 to duplicate row 2", got)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for path, want := range map[string]string{"export/Patient.ndjson": NDJSON, "bundle.JSON": JSON, "patients.csv": CSV} {
+		if got, err := Format(path); err != nil || got != want {
+			t.Errorf("Format(%q) = %q, %v, want %q", path, got, err, want)
+		}
+	}
+	if _, err := Format("patients.txt"); err == nil {
+		t.Error("Format() accepted an unknown extension")
+	}
+}
@@ -0,0 +1,151 @@
+// Package wizard drafts mappings interactively: it walks through the target
+// fields a mapping does not fill yet and suggests the source columns that
+// fit them best.
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/konzy/ehrglot/pkg/schema"
+	"github.com/konzy/ehrglot/pkg/suggest"
+)
+
+// maxCandidates is the number of source columns suggested for a field.
+const maxCandidates = 3
+
+var index = regexp.MustCompile(`\[\d+\]`)
+
+// Wizard drafts the field mappings of a source schema to a target resource.
+type Wizard struct {
+	source  schema.Schema
+	target  schema.Schema
+	columns []suggest.Column
+	fields  []suggest.Field
+	all     bool
+}
+
+// New returns a wizard over the fields of target that none of existing fill.
+// Unless all is set, it walks only through the fields it has candidate
+// columns for.
+func New(schemas []schema.Schema, source, target schema.Schema, existing []schema.FieldMapping, all bool) *Wizard {
+	w := &Wizard{source: source, target: target, columns: suggest.Columns(source), all: all}
+	for _, f := range suggest.Fields(schemas, target) {
+		if !mapped(f.Path, existing) {
+			w.fields = append(w.fields, f)
+		}
+	}
+	return w
+}
+
+// mapped reports whether a field mapping fills path or a field inside it.
+func mapped(path string, existing []schema.FieldMapping) bool {
+	plain := index.ReplaceAllString(path, "")
+	for _, fm := range existing {
+		target := index.ReplaceAllString(fm.Target, "")
+		if target == plain || strings.HasPrefix(plain, target+".") || strings.HasPrefix(target, plain+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Run asks in turn for the source column of each field, reading answers from
+// in and writing prompts to out, and returns the field mappings of the
+// answers. An answer is the number of a suggested column, a column name or
+// source expression, empty to skip the field, or q to stop; the end of in
+// stops too. Each chosen column is followed by a question for its transform.
+func (w *Wizard) Run(in io.Reader, out io.Writer) ([]schema.FieldMapping, error) {
+	scanner := bufio.NewScanner(in)
+	ask := func(prompt string) (string, bool) {
+		fmt.Fprint(out, prompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return "", false
+		}
+		return strings.TrimSpace(scanner.Text()), true
+	}
+
+	type step struct {
+		field      suggest.Field
+		candidates []suggest.Candidate
+	}
+	var steps []step
+	unmatched := 0
+	for _, f := range w.fields {
+		candidates := suggest.Suggest(f, w.columns, maxCandidates)
+		if len(candidates) == 0 && !w.all {
+			unmatched++
+			continue
+		}
+		steps = append(steps, step{f, candidates})
+	}
+	fmt.Fprintf(out, "%d unmapped fields of %s have candidate columns in %s.\n", len(steps), w.target.GetName(), w.source.GetName())
+
+	var mappings []schema.FieldMapping
+walk:
+	for i, s := range steps {
+		label := s.field.Field.Type
+		if s.field.Field.Required {
+			label += ", required"
+		}
+		fmt.Fprintf(out, "\n[%d/%d] %s (%s)\n", i+1, len(steps), s.field.Path, label)
+		if description := strings.TrimSpace(s.field.Field.Description); description != "" {
+			line, _, _ := strings.Cut(description, "\n")
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+		for j, c := range s.candidates {
+			fmt.Fprintf(out, "  %d) %s (%.0f%%)\n", j+1, c.Column, 100*c.Score)
+		}
+
+		for {
+			answer, ok := ask("Source [number, column, Enter to skip, q to finish]: ")
+			if !ok || answer == "q" {
+				break walk
+			}
+			if answer == "" {
+				continue walk
+			}
+			column := answer
+			if n, err := strconv.Atoi(answer); err == nil {
+				if n < 1 || n > len(s.candidates) {
+					fmt.Fprintf(out, "  No suggestion %d.\n", n)
+					continue
+				}
+				column = s.candidates[n-1].Column
+			} else if c, ok := w.column(answer); ok {
+				column = c
+			} else {
+				fmt.Fprintf(out, "  %s is not a column of %s; keeping it as a source expression.\n", answer, w.source.GetName())
+			}
+
+			fm := schema.FieldMapping{Source: column, Target: s.field.Path}
+			transform, ok := ask("Transform [Enter for none]: ")
+			fm.Transform = transform
+			mappings = append(mappings, fm)
+			if !ok {
+				break walk
+			}
+			continue walk
+		}
+	}
+
+	if unmatched > 0 {
+		fmt.Fprintf(out, "\n%d more unmapped fields have no candidate columns; run with --all to map them too.\n", unmatched)
+	}
+	return mappings, scanner.Err()
+}
+
+// column returns the column of the source named name, ignoring case.
+func (w *Wizard) column(name string) (string, bool) {
+	for _, c := range w.columns {
+		if strings.EqualFold(c.Name, name) {
+			return c.Name, true
+		}
+	}
+	return "", false
+}
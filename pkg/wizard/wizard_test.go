@@ -0,0 +1,88 @@
+package wizard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konzy/ehrglot/pkg/fixtures"
+	"github.com/konzy/ehrglot/pkg/schema"
+)
+
+// provider is a source table of practitioners.
+var provider = schema.Schema{
+	Name:      "PROVIDER",
+	Namespace: "ehr",
+	Fields: []schema.Field{
+		{Name: "PROV_ID", Type: "string"},
+		{Name: "PROV_LAST_NAME", Type: "string"},
+		{Name: "PROV_FIRST_NAME", Type: "string"},
+		{Name: "SEX_C", Type: "string"},
+		{Name: "ADDR", Type: "string", Children: []schema.Field{
+			{Name: "ZIP", Type: "string"},
+		}},
+	},
+}
+
+func practitioner(t *testing.T) schema.Schema {
+	t.Helper()
+	for _, s := range fixtures.Schemas() {
+		if s.GetName() == "Practitioner" {
+			return s
+		}
+	}
+	t.Fatal("fixtures have no Practitioner")
+	return schema.Schema{}
+}
+
+func TestRun(t *testing.T) {
+	existing := []schema.FieldMapping{{Source: "PROV_ID", Target: "id"}}
+	w := New(fixtures.Schemas(), provider, practitioner(t), existing, false)
+
+	// Pick the suggestion for the family name, type a column in another
+	// case for the given name, then stop.
+	in := strings.NewReader("1\nto_upper\n9\nprov_first_name\n\nq\n")
+	var out strings.Builder
+	got, err := w.Run(in, &out)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	want := []schema.FieldMapping{
+		{Source: "PROV_LAST_NAME", Target: "name[0].family", Transform: "to_upper"},
+		{Source: "PROV_FIRST_NAME", Target: "name[0].given[0]"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Run() = %+v, want %+v\n%s", got, want, out.String())
+	}
+	for _, want := range []string{
+		"2 unmapped fields of Practitioner have candidate columns in PROVIDER.",
+		"[1/2] name[0].family (string)\n",
+		"1) PROV_LAST_NAME (80%)",
+		"No suggestion 9.",
+		"more unmapped fields have no candidate columns; run with --all to map them too.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "] id (") {
+		t.Errorf("Run() asked about the mapped id:\n%s", out.String())
+	}
+}
+
+func TestRunExpression(t *testing.T) {
+	w := New(fixtures.Schemas(), provider, practitioner(t), nil, false)
+	var out strings.Builder
+	got, err := w.Run(strings.NewReader("PROV_ID || 'x'\n"), &out)
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	// The input ends at the transform question, which stops the walk.
+	want := []schema.FieldMapping{{Source: "PROV_ID || 'x'", Target: "id"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Run() = %+v, want %+v", got, want)
+	}
+	if !strings.Contains(out.String(), "PROV_ID || 'x' is not a column of PROVIDER; keeping it as a source expression.") {
+		t.Errorf("output lacks the expression note:\n%s", out.String())
+	}
+}